@@ -20,9 +20,44 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//-----------------------------------------------------------------------------
+type CwfGatewayHealthConfigHealthProbe_ProbeType int32
+
+const (
+	// RADIUS Status-Server (RFC 5997) to an AAA server
+	CwfGatewayHealthConfigHealthProbe_RADIUS_STATUS_SERVER CwfGatewayHealthConfigHealthProbe_ProbeType = 0
+	// Diameter CER & DWR (RFC 6733) to a Diameter peer, e.g. the HSS or PCRF
+	CwfGatewayHealthConfigHealthProbe_DIAMETER_WATCHDOG CwfGatewayHealthConfigHealthProbe_ProbeType = 1
+	// HTTP GET of an endpoint, e.g. a captive portal
+	CwfGatewayHealthConfigHealthProbe_HTTP CwfGatewayHealthConfigHealthProbe_ProbeType = 2
+	// health reported by a FeG service, e.g. swx_proxy or session_proxy
+	CwfGatewayHealthConfigHealthProbe_FEG_SERVICE CwfGatewayHealthConfigHealthProbe_ProbeType = 3
+)
+
+var CwfGatewayHealthConfigHealthProbe_ProbeType_name = map[int32]string{
+	0: "RADIUS_STATUS_SERVER",
+	1: "DIAMETER_WATCHDOG",
+	2: "HTTP",
+	3: "FEG_SERVICE",
+}
+
+var CwfGatewayHealthConfigHealthProbe_ProbeType_value = map[string]int32{
+	"RADIUS_STATUS_SERVER": 0,
+	"DIAMETER_WATCHDOG":    1,
+	"HTTP":                 2,
+	"FEG_SERVICE":          3,
+}
+
+func (x CwfGatewayHealthConfigHealthProbe_ProbeType) String() string {
+	return proto.EnumName(CwfGatewayHealthConfigHealthProbe_ProbeType_name, int32(x))
+}
+
+func (CwfGatewayHealthConfigHealthProbe_ProbeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ab79e679bf56b47d, []int{0, 1, 0}
+}
+
+// -----------------------------------------------------------------------------
 // Health configs
-//-----------------------------------------------------------------------------
+// -----------------------------------------------------------------------------
 type CwfGatewayHealthConfig struct {
 	// cpu utilization threshold
 	CpuUtilThresholdPct float32 `protobuf:"fixed32,1,opt,name=cpu_util_threshold_pct,json=cpuUtilThresholdPct,proto3" json:"cpu_util_threshold_pct,omitempty"`
@@ -35,10 +70,12 @@ type CwfGatewayHealthConfig struct {
 	// gre peers to probe
 	GrePeers []*CwfGatewayHealthConfigGrePeer `protobuf:"bytes,5,rep,name=gre_peers,json=grePeers,proto3" json:"gre_peers,omitempty"`
 	// virtual IP used by AP/WLC to connect to HA cluster
-	ClusterVirtualIp     string   `protobuf:"bytes,6,opt,name=cluster_virtual_ip,json=clusterVirtualIp,proto3" json:"cluster_virtual_ip,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ClusterVirtualIp string `protobuf:"bytes,6,opt,name=cluster_virtual_ip,json=clusterVirtualIp,proto3" json:"cluster_virtual_ip,omitempty"`
+	// health probes of external dependencies of the gateway
+	HealthProbes         []*CwfGatewayHealthConfigHealthProbe `protobuf:"bytes,7,rep,name=health_probes,json=healthProbes,proto3" json:"health_probes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
 }
 
 func (m *CwfGatewayHealthConfig) Reset()         { *m = CwfGatewayHealthConfig{} }
@@ -108,6 +145,13 @@ func (m *CwfGatewayHealthConfig) GetClusterVirtualIp() string {
 	return ""
 }
 
+func (m *CwfGatewayHealthConfig) GetHealthProbes() []*CwfGatewayHealthConfigHealthProbe {
+	if m != nil {
+		return m.HealthProbes
+	}
+	return nil
+}
+
 type CwfGatewayHealthConfigGrePeer struct {
 	Ip                   string   `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -147,33 +191,192 @@ func (m *CwfGatewayHealthConfigGrePeer) GetIp() string {
 	return ""
 }
 
+type CwfGatewayHealthConfigHealthProbe struct {
+	// name of the probe in health messages & metrics
+	Name string                                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type CwfGatewayHealthConfigHealthProbe_ProbeType `protobuf:"varint,2,opt,name=type,proto3,enum=magma.mconfig.CwfGatewayHealthConfigHealthProbe_ProbeType" json:"type,omitempty"`
+	// RADIUS server or Diameter peer host:port, URL or FeG service name, depending on the type
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"`
+	// RADIUS shared secret
+	Secret string `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	// expected HTTP status code, any status below 400 if not set
+	ExpectedStatus uint32 `protobuf:"varint,5,opt,name=expected_status,json=expectedStatus,proto3" json:"expected_status,omitempty"`
+	// interval between probes
+	IntervalSecs uint32 `protobuf:"varint,6,opt,name=interval_secs,json=intervalSecs,proto3" json:"interval_secs,omitempty"`
+	// timeout of each probe
+	TimeoutMs uint32 `protobuf:"varint,7,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// successful probes slower than this are counted as failures if set
+	MaxLatencyMs uint32 `protobuf:"varint,8,opt,name=max_latency_ms,json=maxLatencyMs,proto3" json:"max_latency_ms,omitempty"`
+	// consecutive failed probes marking a healthy target unhealthy
+	FailureThreshold uint32 `protobuf:"varint,9,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	// consecutive successful probes marking an unhealthy target healthy again
+	SuccessThreshold uint32 `protobuf:"varint,10,opt,name=success_threshold,json=successThreshold,proto3" json:"success_threshold,omitempty"`
+	// Diameter identity of the gateway in the CER & DWR
+	DiameterOriginHost   string   `protobuf:"bytes,11,opt,name=diameter_origin_host,json=diameterOriginHost,proto3" json:"diameter_origin_host,omitempty"`
+	DiameterOriginRealm  string   `protobuf:"bytes,12,opt,name=diameter_origin_realm,json=diameterOriginRealm,proto3" json:"diameter_origin_realm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) Reset()         { *m = CwfGatewayHealthConfigHealthProbe{} }
+func (m *CwfGatewayHealthConfigHealthProbe) String() string { return proto.CompactTextString(m) }
+func (*CwfGatewayHealthConfigHealthProbe) ProtoMessage()    {}
+func (*CwfGatewayHealthConfigHealthProbe) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ab79e679bf56b47d, []int{0, 1}
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CwfGatewayHealthConfigHealthProbe.Unmarshal(m, b)
+}
+func (m *CwfGatewayHealthConfigHealthProbe) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CwfGatewayHealthConfigHealthProbe.Marshal(b, m, deterministic)
+}
+func (m *CwfGatewayHealthConfigHealthProbe) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CwfGatewayHealthConfigHealthProbe.Merge(m, src)
+}
+func (m *CwfGatewayHealthConfigHealthProbe) XXX_Size() int {
+	return xxx_messageInfo_CwfGatewayHealthConfigHealthProbe.Size(m)
+}
+func (m *CwfGatewayHealthConfigHealthProbe) XXX_DiscardUnknown() {
+	xxx_messageInfo_CwfGatewayHealthConfigHealthProbe.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CwfGatewayHealthConfigHealthProbe proto.InternalMessageInfo
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetType() CwfGatewayHealthConfigHealthProbe_ProbeType {
+	if m != nil {
+		return m.Type
+	}
+	return CwfGatewayHealthConfigHealthProbe_RADIUS_STATUS_SERVER
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetTarget() string {
+	if m != nil {
+		return m.Target
+	}
+	return ""
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetExpectedStatus() uint32 {
+	if m != nil {
+		return m.ExpectedStatus
+	}
+	return 0
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetIntervalSecs() uint32 {
+	if m != nil {
+		return m.IntervalSecs
+	}
+	return 0
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetTimeoutMs() uint32 {
+	if m != nil {
+		return m.TimeoutMs
+	}
+	return 0
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetMaxLatencyMs() uint32 {
+	if m != nil {
+		return m.MaxLatencyMs
+	}
+	return 0
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetFailureThreshold() uint32 {
+	if m != nil {
+		return m.FailureThreshold
+	}
+	return 0
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetSuccessThreshold() uint32 {
+	if m != nil {
+		return m.SuccessThreshold
+	}
+	return 0
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetDiameterOriginHost() string {
+	if m != nil {
+		return m.DiameterOriginHost
+	}
+	return ""
+}
+
+func (m *CwfGatewayHealthConfigHealthProbe) GetDiameterOriginRealm() string {
+	if m != nil {
+		return m.DiameterOriginRealm
+	}
+	return ""
+}
+
 func init() {
+	proto.RegisterEnum("magma.mconfig.CwfGatewayHealthConfigHealthProbe_ProbeType", CwfGatewayHealthConfigHealthProbe_ProbeType_name, CwfGatewayHealthConfigHealthProbe_ProbeType_value)
 	proto.RegisterType((*CwfGatewayHealthConfig)(nil), "magma.mconfig.CwfGatewayHealthConfig")
 	proto.RegisterType((*CwfGatewayHealthConfigGrePeer)(nil), "magma.mconfig.CwfGatewayHealthConfig.grePeer")
+	proto.RegisterType((*CwfGatewayHealthConfigHealthProbe)(nil), "magma.mconfig.CwfGatewayHealthConfig.healthProbe")
 }
 
 func init() { proto.RegisterFile("cwf/protos/mconfig/mconfigs.proto", fileDescriptor_ab79e679bf56b47d) }
 
 var fileDescriptor_ab79e679bf56b47d = []byte{
-	// 312 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x31, 0x4f, 0xeb, 0x30,
-	0x10, 0xc7, 0x95, 0xf4, 0xbd, 0xbe, 0x57, 0xa3, 0xa2, 0x12, 0x50, 0x15, 0x98, 0x52, 0x58, 0x32,
-	0x40, 0x22, 0xd1, 0x6f, 0x40, 0x07, 0x28, 0x53, 0x15, 0x01, 0x03, 0x8b, 0xe5, 0xba, 0xd7, 0xd4,
-	0xaa, 0x5d, 0x5b, 0xce, 0xb9, 0x15, 0x5f, 0x9c, 0x19, 0xd9, 0x4d, 0x07, 0xa0, 0x53, 0xe2, 0xfb,
-	0xdd, 0xcf, 0x3e, 0xfd, 0x8f, 0x8c, 0xf8, 0x6e, 0x59, 0x1a, 0xab, 0x51, 0x37, 0xa5, 0xe2, 0x7a,
-	0xb3, 0x14, 0xf5, 0xe1, 0xdb, 0x14, 0xa1, 0x9e, 0xf4, 0x15, 0xab, 0x15, 0x2b, 0xda, 0xea, 0xf5,
-	0x67, 0x4c, 0x86, 0x93, 0xdd, 0xf2, 0x91, 0x21, 0xec, 0xd8, 0xc7, 0x13, 0x30, 0x89, 0xab, 0x49,
-	0x40, 0xc9, 0x98, 0x0c, 0xb9, 0x71, 0xd4, 0xa1, 0x90, 0x14, 0x57, 0x16, 0x9a, 0x95, 0x96, 0x0b,
-	0x6a, 0x38, 0xa6, 0x51, 0x16, 0xe5, 0x71, 0x75, 0xce, 0x8d, 0x7b, 0x45, 0x21, 0x5f, 0x0e, 0x6c,
-	0xc6, 0xd1, 0x4b, 0x0a, 0xd4, 0x31, 0x29, 0xde, 0x4b, 0x0a, 0xd4, 0x2f, 0xe9, 0x96, 0x24, 0xb5,
-	0x05, 0x6a, 0xac, 0x9e, 0x03, 0x15, 0x1b, 0x04, 0xbb, 0x65, 0x32, 0xed, 0x64, 0x51, 0xde, 0xaf,
-	0x06, 0xb5, 0x85, 0x99, 0x07, 0xd3, 0xb6, 0x9e, 0x94, 0xe4, 0x42, 0x70, 0x65, 0xda, 0x76, 0xb3,
-	0x46, 0xca, 0xb5, 0xdb, 0x60, 0xfa, 0x27, 0xf4, 0x9f, 0x79, 0x16, 0x84, 0xd9, 0x1a, 0x27, 0x1e,
-	0x24, 0xcf, 0xa4, 0x17, 0xae, 0x07, 0xb0, 0x4d, 0xfa, 0x37, 0xeb, 0xe4, 0x27, 0xf7, 0x77, 0xc5,
-	0xb7, 0x18, 0x8a, 0xe3, 0x11, 0x14, 0xfe, 0x6d, 0x00, 0x5b, 0xfd, 0x6f, 0x7f, 0x1a, 0x3f, 0x2a,
-	0x97, 0xae, 0x41, 0xb0, 0x74, 0x2b, 0x2c, 0x3a, 0x26, 0xa9, 0x30, 0x69, 0x37, 0x8b, 0xf2, 0x5e,
-	0x35, 0x68, 0xc9, 0xdb, 0x1e, 0x4c, 0xcd, 0xd5, 0x25, 0xf9, 0xd7, 0x9a, 0xc9, 0x29, 0x89, 0x85,
-	0x09, 0xc9, 0xf5, 0xaa, 0x58, 0x98, 0x87, 0x9b, 0xf7, 0x51, 0x18, 0xa1, 0xf4, 0x2b, 0xe3, 0x52,
-	0xbb, 0x45, 0x59, 0xeb, 0x1f, 0xbb, 0x9b, 0x77, 0xc3, 0x79, 0xfc, 0x15, 0x00, 0x00, 0xff, 0xff,
-	0x7e, 0xc1, 0xde, 0x59, 0xd8, 0x01, 0x00, 0x00,
+	// 630 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdd, 0x6e, 0x1a, 0x3b,
+	0x10, 0x3e, 0x10, 0x4e, 0xc2, 0x9a, 0x40, 0x88, 0xf3, 0xa3, 0x3d, 0x91, 0x8e, 0x44, 0x92, 0x23,
+	0x1d, 0xa4, 0xb6, 0xd0, 0x26, 0x97, 0xbd, 0xa2, 0x84, 0x06, 0xaa, 0x46, 0x41, 0x66, 0x93, 0x48,
+	0xbd, 0xb1, 0x1c, 0x33, 0x80, 0x95, 0x35, 0x6b, 0xd9, 0xde, 0x24, 0x3c, 0x4d, 0xdf, 0xa8, 0xcf,
+	0x54, 0xd9, 0xbb, 0xe4, 0xaf, 0xb9, 0x68, 0x6f, 0x58, 0xcf, 0xf7, 0x33, 0x1e, 0x66, 0x46, 0x46,
+	0xfb, 0xfc, 0x6e, 0xd2, 0x56, 0x3a, 0xb1, 0x89, 0x69, 0x4b, 0x9e, 0xcc, 0x27, 0x62, 0xba, 0xfc,
+	0x9a, 0x96, 0xc7, 0x71, 0x55, 0xb2, 0xa9, 0x64, 0xad, 0x1c, 0x3d, 0xf8, 0x5e, 0x46, 0xbb, 0xdd,
+	0xbb, 0xc9, 0x29, 0xb3, 0x70, 0xc7, 0x16, 0x7d, 0x60, 0xb1, 0x9d, 0x75, 0x3d, 0x85, 0x8f, 0xd1,
+	0x2e, 0x57, 0x29, 0x4d, 0xad, 0x88, 0xa9, 0x9d, 0x69, 0x30, 0xb3, 0x24, 0x1e, 0x53, 0xc5, 0x6d,
+	0x58, 0x68, 0x14, 0x9a, 0x45, 0xb2, 0xc5, 0x55, 0x7a, 0x61, 0x45, 0x1c, 0x2d, 0xb9, 0x21, 0xb7,
+	0xce, 0x24, 0x41, 0xbe, 0x66, 0x2a, 0x66, 0x26, 0x09, 0xf2, 0x17, 0xd3, 0x5b, 0x84, 0xa7, 0x1a,
+	0xa8, 0xd2, 0xc9, 0x35, 0x50, 0x31, 0xb7, 0xa0, 0x6f, 0x59, 0x1c, 0xae, 0x34, 0x0a, 0xcd, 0x2a,
+	0xa9, 0x4f, 0x35, 0x0c, 0x1d, 0x31, 0xc8, 0x71, 0xdc, 0x46, 0xdb, 0x82, 0x4b, 0x95, 0xcb, 0xd5,
+	0x8d, 0xa5, 0x3c, 0x49, 0xe7, 0x36, 0x2c, 0x79, 0xfd, 0xa6, 0xe3, 0xbc, 0x61, 0x78, 0x63, 0xbb,
+	0x8e, 0xc0, 0x5f, 0x50, 0xe0, 0xd3, 0x03, 0x68, 0x13, 0xfe, 0xdd, 0x58, 0x69, 0x56, 0x8e, 0xde,
+	0xb5, 0x9e, 0xb5, 0xa1, 0xf5, 0x7a, 0x0b, 0x5a, 0xee, 0x6e, 0x00, 0x4d, 0xca, 0xf9, 0xc1, 0xb8,
+	0x52, 0x79, 0x9c, 0x1a, 0x0b, 0x9a, 0xde, 0x0a, 0x6d, 0x53, 0x16, 0x53, 0xa1, 0xc2, 0xd5, 0x46,
+	0xa1, 0x19, 0x90, 0x7a, 0xce, 0x5c, 0x66, 0xc4, 0x40, 0xe1, 0x4b, 0x54, 0x9d, 0xf9, 0x7c, 0x59,
+	0xb1, 0x26, 0x5c, 0xf3, 0xb7, 0x7f, 0xf8, 0xbd, 0xdb, 0x33, 0xab, 0xff, 0x2f, 0x64, 0xfd, 0x49,
+	0x60, 0xf6, 0xfe, 0x41, 0x6b, 0x79, 0x45, 0xb8, 0x86, 0x8a, 0x42, 0xf9, 0x89, 0x04, 0xa4, 0x28,
+	0xd4, 0xde, 0x8f, 0x12, 0xaa, 0x3c, 0xd1, 0x62, 0x8c, 0x4a, 0x73, 0x26, 0x21, 0x57, 0xf8, 0x33,
+	0x3e, 0x47, 0x25, 0xbb, 0x50, 0xe0, 0x47, 0x52, 0x3b, 0xfa, 0xf8, 0xc7, 0xd5, 0xb4, 0xfc, 0x6f,
+	0xb4, 0x50, 0x40, 0x7c, 0x22, 0xbc, 0x8b, 0x56, 0x2d, 0xd3, 0x53, 0xb0, 0x7e, 0x68, 0x01, 0xc9,
+	0x23, 0x87, 0x1b, 0xe0, 0x1a, 0xb2, 0xe1, 0x04, 0x24, 0x8f, 0xf0, 0xff, 0x68, 0x03, 0xee, 0x15,
+	0x70, 0x0b, 0x63, 0x6a, 0x2c, 0xb3, 0xa9, 0x9b, 0x8b, 0x9b, 0x5e, 0x6d, 0x09, 0x8f, 0x3c, 0x8a,
+	0x0f, 0x51, 0x75, 0xb9, 0x0f, 0xd4, 0x00, 0x37, 0xbe, 0xd3, 0x55, 0xb2, 0xbe, 0x04, 0x47, 0xc0,
+	0x0d, 0xfe, 0x17, 0x21, 0x2b, 0x24, 0x24, 0xa9, 0xa5, 0xd2, 0xb5, 0xd8, 0x29, 0x82, 0x1c, 0x39,
+	0x33, 0xf8, 0x3f, 0x54, 0x93, 0xec, 0x9e, 0xc6, 0xcc, 0xc2, 0x9c, 0x2f, 0x9c, 0xa4, 0x9c, 0x25,
+	0x91, 0xec, 0xfe, 0x6b, 0x06, 0x9e, 0x19, 0xfc, 0x06, 0x6d, 0x4e, 0x98, 0x88, 0x53, 0x0d, 0x8f,
+	0x7b, 0x1b, 0x06, 0xd9, 0x0a, 0xe6, 0xc4, 0xc3, 0xce, 0x3a, 0xb1, 0x49, 0x39, 0x07, 0x63, 0x9e,
+	0x88, 0x51, 0x26, 0xce, 0x89, 0x47, 0xf1, 0x7b, 0xb4, 0x3d, 0x16, 0x4c, 0x82, 0xdb, 0x99, 0x44,
+	0x8b, 0xa9, 0x98, 0xd3, 0x59, 0x62, 0x6c, 0x58, 0xf1, 0x2d, 0xc1, 0x4b, 0xee, 0xdc, 0x53, 0xfd,
+	0xc4, 0x58, 0x7c, 0x84, 0x76, 0x5e, 0x3a, 0x34, 0xb0, 0x58, 0x86, 0xeb, 0xde, 0xb2, 0xf5, 0xdc,
+	0x42, 0x1c, 0x75, 0x70, 0x85, 0x82, 0x87, 0xa9, 0xe0, 0x10, 0x6d, 0x93, 0xce, 0xc9, 0xe0, 0x62,
+	0x44, 0x47, 0x51, 0x27, 0x72, 0x9f, 0x1e, 0xb9, 0xec, 0x91, 0xfa, 0x5f, 0x78, 0x07, 0x6d, 0x9e,
+	0x0c, 0x3a, 0x67, 0xbd, 0xa8, 0x47, 0xe8, 0x55, 0x27, 0xea, 0xf6, 0x4f, 0xce, 0x4f, 0xeb, 0x05,
+	0x5c, 0x46, 0xa5, 0x7e, 0x14, 0x0d, 0xeb, 0x45, 0xbc, 0x81, 0x2a, 0x9f, 0x7b, 0xa7, 0xde, 0x30,
+	0xe8, 0xf6, 0xea, 0x2b, 0x9f, 0x0e, 0xbf, 0xed, 0xfb, 0xfd, 0x68, 0xbb, 0xb7, 0x85, 0xc7, 0x49,
+	0x3a, 0x6e, 0x4f, 0x93, 0x17, 0x8f, 0xcc, 0xf5, 0xaa, 0x8f, 0x8f, 0x7f, 0x0e, 0x00, 0xc4, 0x85,
+	0xc2, 0xad, 0x81, 0x04, 0x00, 0x00,
 }
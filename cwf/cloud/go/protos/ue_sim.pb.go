@@ -26,6 +26,138 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type EapMethod int32
+
+const (
+	EapMethod_EAP_AKA       EapMethod = 0
+	EapMethod_EAP_SIM       EapMethod = 1
+	EapMethod_EAP_AKA_PRIME EapMethod = 2
+)
+
+var EapMethod_name = map[int32]string{
+	0: "EAP_AKA",
+	1: "EAP_SIM",
+	2: "EAP_AKA_PRIME",
+}
+
+var EapMethod_value = map[string]int32{
+	"EAP_AKA":       0,
+	"EAP_SIM":       1,
+	"EAP_AKA_PRIME": 2,
+}
+
+func (x EapMethod) String() string {
+	return proto.EnumName(EapMethod_name, int32(x))
+}
+
+func (EapMethod) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_01bc05ea16f96cbc, []int{0}
+}
+
+// Deliberate misbehaviors of a UE to test the negative authentication cases
+type AuthFault int32
+
+const (
+	AuthFault_NO_FAULT AuthFault = 0
+	// The UE sends an invalid AT_MAC in its challenge responses
+	AuthFault_BAD_MAC AuthFault = 1
+	// The UE answers the first challenge of each authentication with a
+	// Synchronization Failure carrying its own SEQ (EAP-AKA and EAP-AKA' only)
+	AuthFault_SYNC_FAILURE AuthFault = 2
+)
+
+var AuthFault_name = map[int32]string{
+	0: "NO_FAULT",
+	1: "BAD_MAC",
+	2: "SYNC_FAILURE",
+}
+
+var AuthFault_value = map[string]int32{
+	"NO_FAULT":     0,
+	"BAD_MAC":      1,
+	"SYNC_FAILURE": 2,
+}
+
+func (x AuthFault) String() string {
+	return proto.EnumName(AuthFault_name, int32(x))
+}
+
+func (AuthFault) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_01bc05ea16f96cbc, []int{1}
+}
+
+type DisconnectPattern int32
+
+const (
+	// All the UEs send an Accounting Stop
+	DisconnectPattern_GRACEFUL DisconnectPattern = 0
+	// All the UEs drop without an Accounting Stop
+	DisconnectPattern_ABRUPT DisconnectPattern = 1
+	// A random abrupt_ratio of the UEs drop without an Accounting Stop
+	DisconnectPattern_MIXED DisconnectPattern = 2
+)
+
+var DisconnectPattern_name = map[int32]string{
+	0: "GRACEFUL",
+	1: "ABRUPT",
+	2: "MIXED",
+}
+
+var DisconnectPattern_value = map[string]int32{
+	"GRACEFUL": 0,
+	"ABRUPT":   1,
+	"MIXED":    2,
+}
+
+func (x DisconnectPattern) String() string {
+	return proto.EnumName(DisconnectPattern_name, int32(x))
+}
+
+func (DisconnectPattern) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_01bc05ea16f96cbc, []int{2}
+}
+
+type LoadStep int32
+
+const (
+	// EAP Identity Response, answered with the first request of the method
+	LoadStep_EAP_IDENTITY LoadStep = 0
+	// Method responses preceding the challenge response (EAP-AKA Identity,
+	// EAP-SIM Start, Synchronization Failure), answered with the next request
+	LoadStep_EAP_CHALLENGE LoadStep = 1
+	// Challenge Response, answered with the Access-Accept
+	LoadStep_EAP_ACCEPT   LoadStep = 2
+	LoadStep_ACCT_START   LoadStep = 3
+	LoadStep_ACCT_INTERIM LoadStep = 4
+	LoadStep_ACCT_STOP    LoadStep = 5
+)
+
+var LoadStep_name = map[int32]string{
+	0: "EAP_IDENTITY",
+	1: "EAP_CHALLENGE",
+	2: "EAP_ACCEPT",
+	3: "ACCT_START",
+	4: "ACCT_INTERIM",
+	5: "ACCT_STOP",
+}
+
+var LoadStep_value = map[string]int32{
+	"EAP_IDENTITY":  0,
+	"EAP_CHALLENGE": 1,
+	"EAP_ACCEPT":    2,
+	"ACCT_START":    3,
+	"ACCT_INTERIM":  4,
+	"ACCT_STOP":     5,
+}
+
+func (x LoadStep) String() string {
+	return proto.EnumName(LoadStep_name, int32(x))
+}
+
+func (LoadStep) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_01bc05ea16f96cbc, []int{3}
+}
+
 type AuthenticateRequestHssLess struct {
 	// MSISDN
 	Msisdn string `protobuf:"bytes,1,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
@@ -94,10 +226,13 @@ type UEConfig struct {
 	// Sequence Number (SEQ).
 	Seq uint64 `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
 	// HSSLess Configuration
-	HsslessCfg           *AuthenticateRequestHssLess `protobuf:"bytes,5,opt,name=hssless_cfg,json=hsslessCfg,proto3" json:"hssless_cfg,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	HsslessCfg *AuthenticateRequestHssLess `protobuf:"bytes,5,opt,name=hssless_cfg,json=hsslessCfg,proto3" json:"hssless_cfg,omitempty"`
+	// EAP method the UE authenticates with
+	EapMethod            EapMethod `protobuf:"varint,6,opt,name=eap_method,json=eapMethod,proto3,enum=magma.cwf.EapMethod" json:"eap_method,omitempty"`
+	AuthFault            AuthFault `protobuf:"varint,7,opt,name=auth_fault,json=authFault,proto3,enum=magma.cwf.AuthFault" json:"auth_fault,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *UEConfig) Reset()         { *m = UEConfig{} }
@@ -160,6 +295,20 @@ func (m *UEConfig) GetHsslessCfg() *AuthenticateRequestHssLess {
 	return nil
 }
 
+func (m *UEConfig) GetEapMethod() EapMethod {
+	if m != nil {
+		return m.EapMethod
+	}
+	return EapMethod_EAP_AKA
+}
+
+func (m *UEConfig) GetAuthFault() AuthFault {
+	if m != nil {
+		return m.AuthFault
+	}
+	return AuthFault_NO_FAULT
+}
+
 type AuthenticateRequest struct {
 	Imsi                 string   `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
 	CalledStationID      string   `protobuf:"bytes,2,opt,name=calledStationID,proto3" json:"calledStationID,omitempty"`
@@ -610,7 +759,326 @@ func (m *TrafficSummary) GetRetransmits() int32 {
 	return 0
 }
 
+type LoadRequest struct {
+	// IMSI of the first UE, the other UEs get the following IMSIs
+	ImsiStart string `protobuf:"bytes,1,opt,name=imsi_start,json=imsiStart,proto3" json:"imsi_start,omitempty"`
+	// Number of UEs to simulate
+	NumUes uint32 `protobuf:"varint,2,opt,name=num_ues,json=numUes,proto3" json:"num_ues,omitempty"`
+	// Authentication key (k) shared by all the UEs, the OPc is derived from the configured Op
+	AuthKey []byte `protobuf:"bytes,3,opt,name=auth_key,json=authKey,proto3" json:"auth_key,omitempty"`
+	// Sequence Number (SEQ) the UEs start with
+	Seq uint64 `protobuf:"varint,4,opt,name=seq,proto3" json:"seq,omitempty"`
+	// UE attaches per second
+	AttachRate float64 `protobuf:"fixed64,5,opt,name=attach_rate,json=attachRate,proto3" json:"attach_rate,omitempty"`
+	// Time a UE keeps its session before disconnecting
+	HoldTimeMs uint32 `protobuf:"varint,6,opt,name=hold_time_ms,json=holdTimeMs,proto3" json:"hold_time_ms,omitempty"`
+	// Interval of the Accounting Interim Updates, no updates are sent if 0
+	InterimIntervalMs uint32            `protobuf:"varint,7,opt,name=interim_interval_ms,json=interimIntervalMs,proto3" json:"interim_interval_ms,omitempty"`
+	DisconnectPattern DisconnectPattern `protobuf:"varint,8,opt,name=disconnect_pattern,json=disconnectPattern,proto3,enum=magma.cwf.DisconnectPattern" json:"disconnect_pattern,omitempty"`
+	// Ratio of the UEs dropping without an Accounting Stop with the MIXED pattern
+	AbruptRatio     float64 `protobuf:"fixed64,9,opt,name=abrupt_ratio,json=abruptRatio,proto3" json:"abrupt_ratio,omitempty"`
+	CalledStationID string  `protobuf:"bytes,10,opt,name=calledStationID,proto3" json:"calledStationID,omitempty"`
+	// Timeout of each RADIUS exchange, 5s if 0
+	RequestTimeoutMs     uint32   `protobuf:"varint,11,opt,name=request_timeout_ms,json=requestTimeoutMs,proto3" json:"request_timeout_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoadRequest) Reset()         { *m = LoadRequest{} }
+func (m *LoadRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadRequest) ProtoMessage()    {}
+func (*LoadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01bc05ea16f96cbc, []int{10}
+}
+
+func (m *LoadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadRequest.Unmarshal(m, b)
+}
+func (m *LoadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadRequest.Marshal(b, m, deterministic)
+}
+func (m *LoadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadRequest.Merge(m, src)
+}
+func (m *LoadRequest) XXX_Size() int {
+	return xxx_messageInfo_LoadRequest.Size(m)
+}
+func (m *LoadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadRequest proto.InternalMessageInfo
+
+func (m *LoadRequest) GetImsiStart() string {
+	if m != nil {
+		return m.ImsiStart
+	}
+	return ""
+}
+
+func (m *LoadRequest) GetNumUes() uint32 {
+	if m != nil {
+		return m.NumUes
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetAuthKey() []byte {
+	if m != nil {
+		return m.AuthKey
+	}
+	return nil
+}
+
+func (m *LoadRequest) GetSeq() uint64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetAttachRate() float64 {
+	if m != nil {
+		return m.AttachRate
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetHoldTimeMs() uint32 {
+	if m != nil {
+		return m.HoldTimeMs
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetInterimIntervalMs() uint32 {
+	if m != nil {
+		return m.InterimIntervalMs
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetDisconnectPattern() DisconnectPattern {
+	if m != nil {
+		return m.DisconnectPattern
+	}
+	return DisconnectPattern_GRACEFUL
+}
+
+func (m *LoadRequest) GetAbruptRatio() float64 {
+	if m != nil {
+		return m.AbruptRatio
+	}
+	return 0
+}
+
+func (m *LoadRequest) GetCalledStationID() string {
+	if m != nil {
+		return m.CalledStationID
+	}
+	return ""
+}
+
+func (m *LoadRequest) GetRequestTimeoutMs() uint32 {
+	if m != nil {
+		return m.RequestTimeoutMs
+	}
+	return 0
+}
+
+type LoadStepReport struct {
+	Step      LoadStep `protobuf:"varint,1,opt,name=step,proto3,enum=magma.cwf.LoadStep" json:"step,omitempty"`
+	Attempts  uint32   `protobuf:"varint,2,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Successes uint32   `protobuf:"varint,3,opt,name=successes,proto3" json:"successes,omitempty"`
+	Failures  uint32   `protobuf:"varint,4,opt,name=failures,proto3" json:"failures,omitempty"`
+	// Latency percentiles of the successful exchanges
+	LatencyP50Ms float64 `protobuf:"fixed64,5,opt,name=latency_p50_ms,json=latencyP50Ms,proto3" json:"latency_p50_ms,omitempty"`
+	LatencyP90Ms float64 `protobuf:"fixed64,6,opt,name=latency_p90_ms,json=latencyP90Ms,proto3" json:"latency_p90_ms,omitempty"`
+	LatencyP99Ms float64 `protobuf:"fixed64,7,opt,name=latency_p99_ms,json=latencyP99Ms,proto3" json:"latency_p99_ms,omitempty"`
+	LatencyMaxMs float64 `protobuf:"fixed64,8,opt,name=latency_max_ms,json=latencyMaxMs,proto3" json:"latency_max_ms,omitempty"`
+	// Number of failures per reason
+	FailureReasons       map[string]uint32 `protobuf:"bytes,9,rep,name=failure_reasons,json=failureReasons,proto3" json:"failure_reasons,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LoadStepReport) Reset()         { *m = LoadStepReport{} }
+func (m *LoadStepReport) String() string { return proto.CompactTextString(m) }
+func (*LoadStepReport) ProtoMessage()    {}
+func (*LoadStepReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01bc05ea16f96cbc, []int{11}
+}
+
+func (m *LoadStepReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadStepReport.Unmarshal(m, b)
+}
+func (m *LoadStepReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadStepReport.Marshal(b, m, deterministic)
+}
+func (m *LoadStepReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadStepReport.Merge(m, src)
+}
+func (m *LoadStepReport) XXX_Size() int {
+	return xxx_messageInfo_LoadStepReport.Size(m)
+}
+func (m *LoadStepReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadStepReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadStepReport proto.InternalMessageInfo
+
+func (m *LoadStepReport) GetStep() LoadStep {
+	if m != nil {
+		return m.Step
+	}
+	return LoadStep_EAP_IDENTITY
+}
+
+func (m *LoadStepReport) GetAttempts() uint32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *LoadStepReport) GetSuccesses() uint32 {
+	if m != nil {
+		return m.Successes
+	}
+	return 0
+}
+
+func (m *LoadStepReport) GetFailures() uint32 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *LoadStepReport) GetLatencyP50Ms() float64 {
+	if m != nil {
+		return m.LatencyP50Ms
+	}
+	return 0
+}
+
+func (m *LoadStepReport) GetLatencyP90Ms() float64 {
+	if m != nil {
+		return m.LatencyP90Ms
+	}
+	return 0
+}
+
+func (m *LoadStepReport) GetLatencyP99Ms() float64 {
+	if m != nil {
+		return m.LatencyP99Ms
+	}
+	return 0
+}
+
+func (m *LoadStepReport) GetLatencyMaxMs() float64 {
+	if m != nil {
+		return m.LatencyMaxMs
+	}
+	return 0
+}
+
+func (m *LoadStepReport) GetFailureReasons() map[string]uint32 {
+	if m != nil {
+		return m.FailureReasons
+	}
+	return nil
+}
+
+type LoadReport struct {
+	Running     bool    `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	ElapsedSecs float64 `protobuf:"fixed64,2,opt,name=elapsed_secs,json=elapsedSecs,proto3" json:"elapsed_secs,omitempty"`
+	// UEs which started attaching
+	UesStarted uint32 `protobuf:"varint,3,opt,name=ues_started,json=uesStarted,proto3" json:"ues_started,omitempty"`
+	// UEs which went through all their steps
+	UesCompleted uint32 `protobuf:"varint,4,opt,name=ues_completed,json=uesCompleted,proto3" json:"ues_completed,omitempty"`
+	// UEs which stopped after a failed step
+	UesFailed            uint32            `protobuf:"varint,5,opt,name=ues_failed,json=uesFailed,proto3" json:"ues_failed,omitempty"`
+	Steps                []*LoadStepReport `protobuf:"bytes,6,rep,name=steps,proto3" json:"steps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LoadReport) Reset()         { *m = LoadReport{} }
+func (m *LoadReport) String() string { return proto.CompactTextString(m) }
+func (*LoadReport) ProtoMessage()    {}
+func (*LoadReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_01bc05ea16f96cbc, []int{12}
+}
+
+func (m *LoadReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadReport.Unmarshal(m, b)
+}
+func (m *LoadReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadReport.Marshal(b, m, deterministic)
+}
+func (m *LoadReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadReport.Merge(m, src)
+}
+func (m *LoadReport) XXX_Size() int {
+	return xxx_messageInfo_LoadReport.Size(m)
+}
+func (m *LoadReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadReport proto.InternalMessageInfo
+
+func (m *LoadReport) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+func (m *LoadReport) GetElapsedSecs() float64 {
+	if m != nil {
+		return m.ElapsedSecs
+	}
+	return 0
+}
+
+func (m *LoadReport) GetUesStarted() uint32 {
+	if m != nil {
+		return m.UesStarted
+	}
+	return 0
+}
+
+func (m *LoadReport) GetUesCompleted() uint32 {
+	if m != nil {
+		return m.UesCompleted
+	}
+	return 0
+}
+
+func (m *LoadReport) GetUesFailed() uint32 {
+	if m != nil {
+		return m.UesFailed
+	}
+	return 0
+}
+
+func (m *LoadReport) GetSteps() []*LoadStepReport {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("magma.cwf.EapMethod", EapMethod_name, EapMethod_value)
+	proto.RegisterEnum("magma.cwf.AuthFault", AuthFault_name, AuthFault_value)
+	proto.RegisterEnum("magma.cwf.DisconnectPattern", DisconnectPattern_name, DisconnectPattern_value)
+	proto.RegisterEnum("magma.cwf.LoadStep", LoadStep_name, LoadStep_value)
 	proto.RegisterType((*AuthenticateRequestHssLess)(nil), "magma.cwf.AuthenticateRequestHssLess")
 	proto.RegisterType((*UEConfig)(nil), "magma.cwf.UEConfig")
 	proto.RegisterType((*AuthenticateRequest)(nil), "magma.cwf.AuthenticateRequest")
@@ -621,63 +1089,114 @@ func init() {
 	proto.RegisterType((*GenTrafficResponse)(nil), "magma.cwf.GenTrafficResponse")
 	proto.RegisterType((*TrafficOutput)(nil), "magma.cwf.TrafficOutput")
 	proto.RegisterType((*TrafficSummary)(nil), "magma.cwf.TrafficSummary")
+	proto.RegisterType((*LoadRequest)(nil), "magma.cwf.LoadRequest")
+	proto.RegisterType((*LoadStepReport)(nil), "magma.cwf.LoadStepReport")
+	proto.RegisterMapType((map[string]uint32)(nil), "magma.cwf.LoadStepReport.FailureReasonsEntry")
+	proto.RegisterType((*LoadReport)(nil), "magma.cwf.LoadReport")
 }
 
 func init() { proto.RegisterFile("cwf/protos/ue_sim.proto", fileDescriptor_01bc05ea16f96cbc) }
 
 var fileDescriptor_01bc05ea16f96cbc = []byte{
-	// 813 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4d, 0x6f, 0x1b, 0x37,
-	0x10, 0xf5, 0x3a, 0xd6, 0x87, 0x47, 0x72, 0x53, 0x33, 0x41, 0xb2, 0x56, 0x5d, 0x57, 0x58, 0xa0,
-	0x85, 0x4e, 0x12, 0xea, 0x06, 0xad, 0x0f, 0xbd, 0xa4, 0x8e, 0xd3, 0x1a, 0x69, 0x91, 0x84, 0xaa,
-	0x83, 0xa0, 0x17, 0x81, 0xe2, 0x8e, 0x24, 0xc2, 0xbb, 0xe4, 0x86, 0xc3, 0x95, 0xa1, 0x73, 0x7f,
-	0x4c, 0x6f, 0x3d, 0xf4, 0xaf, 0xf5, 0x0f, 0x14, 0xcb, 0x5d, 0xc5, 0xab, 0x3a, 0x76, 0x7a, 0xe8,
-	0x49, 0x33, 0xf3, 0x1e, 0x1f, 0x47, 0xe4, 0xe3, 0x2c, 0x3c, 0x96, 0x57, 0xb3, 0x51, 0x66, 0x8d,
-	0x33, 0x34, 0xca, 0x71, 0x42, 0x2a, 0x1d, 0xfa, 0x8c, 0xed, 0xa6, 0x62, 0x9e, 0x8a, 0xa1, 0xbc,
-	0x9a, 0xf5, 0x0e, 0x8c, 0x95, 0x27, 0x76, 0xcd, 0x92, 0x26, 0x4d, 0x8d, 0x2e, 0x59, 0xbd, 0xa3,
-	0xb9, 0x31, 0xf3, 0x04, 0x4b, 0x6c, 0x9a, 0xcf, 0x46, 0x57, 0x56, 0x64, 0x19, 0x5a, 0x2a, 0xf1,
-	0xe8, 0x2d, 0xf4, 0x9e, 0xe6, 0x6e, 0x81, 0xda, 0x29, 0x29, 0x1c, 0x72, 0x7c, 0x97, 0x23, 0xb9,
-	0x9f, 0x88, 0x7e, 0x46, 0x22, 0xf6, 0x08, 0x9a, 0x29, 0x29, 0x8a, 0x75, 0x18, 0xf4, 0x83, 0xc1,
-	0x2e, 0xaf, 0x32, 0xf6, 0x29, 0xdc, 0x13, 0x99, 0x0e, 0xb7, 0x7d, 0xb1, 0x08, 0x8b, 0x8a, 0x15,
-	0x2e, 0xbc, 0xd7, 0x0f, 0x06, 0x7b, 0xbc, 0x08, 0xa3, 0x3f, 0x03, 0x68, 0x5f, 0x9c, 0x9d, 0x1a,
-	0x3d, 0x53, 0x73, 0xc6, 0x60, 0x47, 0xa5, 0xa4, 0x2a, 0x19, 0x1f, 0xb3, 0x03, 0x68, 0x8b, 0xdc,
-	0x2d, 0x26, 0x97, 0xb8, 0xf2, 0x4a, 0x5d, 0xde, 0x2a, 0xf2, 0x17, 0xb8, 0x7a, 0x0f, 0x99, 0x4c,
-	0x7a, 0xc9, 0x0a, 0x7a, 0x99, 0xc9, 0x62, 0x23, 0xc2, 0x77, 0xe1, 0x4e, 0x3f, 0x18, 0xec, 0xf0,
-	0x22, 0x64, 0xcf, 0xa1, 0xb3, 0x20, 0x4a, 0x90, 0x68, 0x22, 0x67, 0xf3, 0xb0, 0xd1, 0x0f, 0x06,
-	0x9d, 0xe3, 0x2f, 0x87, 0xef, 0x8f, 0x67, 0x78, 0xfb, 0x1f, 0xe4, 0x50, 0xad, 0x3c, 0x9d, 0xcd,
-	0xa3, 0x31, 0x3c, 0xf8, 0x00, 0xf3, 0x83, 0xad, 0x0f, 0xe0, 0xbe, 0x14, 0x49, 0x82, 0xf1, 0xd8,
-	0x09, 0xa7, 0x8c, 0x3e, 0x7f, 0x56, 0x9d, 0xc5, 0xbf, 0xcb, 0xd1, 0x5b, 0x78, 0xb8, 0x29, 0x4a,
-	0x99, 0xd1, 0x84, 0x2c, 0x82, 0xae, 0x15, 0xb1, 0xca, 0xe9, 0x95, 0x90, 0x97, 0xe8, 0xbc, 0x7a,
-	0x97, 0x6f, 0xd4, 0xd8, 0x21, 0xec, 0x12, 0x12, 0x15, 0x42, 0x71, 0xa5, 0x7f, 0x5d, 0x88, 0x5e,
-	0xc3, 0xfe, 0x33, 0x45, 0xd2, 0x68, 0x8d, 0xd2, 0xfd, 0x3f, 0xcd, 0x9e, 0x00, 0xab, 0x4b, 0xfe,
-	0xf7, 0x56, 0xa3, 0xbf, 0xb7, 0x61, 0xff, 0x47, 0xd4, 0xbf, 0x5a, 0x31, 0x9b, 0x29, 0x79, 0x57,
-	0x37, 0x4f, 0xa0, 0xb9, 0x34, 0x49, 0x9e, 0xa2, 0x6f, 0xa2, 0x73, 0x7c, 0x38, 0x2c, 0x1d, 0x3a,
-	0x5c, 0x3b, 0x74, 0x38, 0x76, 0x56, 0xe9, 0xf9, 0x1b, 0x91, 0xe4, 0xc8, 0x2b, 0x2e, 0xfb, 0x16,
-	0x5a, 0x53, 0xe5, 0xac, 0x70, 0xe8, 0xfd, 0xf0, 0xb1, 0x65, 0x6b, 0x32, 0x3b, 0x02, 0x70, 0x2a,
-	0xc5, 0x73, 0x3d, 0x46, 0x49, 0x95, 0x69, 0x6a, 0x15, 0x76, 0x02, 0x8f, 0x2d, 0x66, 0xc6, 0x3a,
-	0xa5, 0xe7, 0xe7, 0xda, 0xa1, 0x5d, 0x8a, 0xa4, 0x22, 0x37, 0x3c, 0xf9, 0x36, 0x98, 0xf5, 0xa1,
-	0x63, 0x71, 0x89, 0x96, 0xf0, 0x17, 0x13, 0x63, 0xd8, 0xec, 0x07, 0x83, 0x36, 0xaf, 0x97, 0x58,
-	0x08, 0xad, 0x62, 0x27, 0x93, 0xbb, 0xb0, 0xe5, 0x9f, 0xc5, 0x3a, 0x65, 0xcf, 0xe1, 0x28, 0x56,
-	0x24, 0xa6, 0x09, 0x8e, 0xd1, 0x2e, 0xd1, 0x72, 0x14, 0x72, 0x21, 0xa6, 0x2a, 0x51, 0x6e, 0x75,
-	0xba, 0x40, 0x79, 0x19, 0xb6, 0xbd, 0xdc, 0x47, 0x58, 0x11, 0x02, 0xab, 0x1f, 0x7a, 0x75, 0x5f,
-	0x8f, 0xa0, 0x69, 0x72, 0x97, 0xe5, 0xeb, 0x9b, 0xaa, 0x32, 0xf6, 0x1d, 0x00, 0xea, 0x78, 0x52,
-	0x61, 0xe5, 0xe9, 0x87, 0xb5, 0x67, 0x52, 0xe9, 0xbc, 0xf4, 0x38, 0xdf, 0x45, 0x1d, 0x97, 0x61,
-	0xf4, 0x7b, 0x00, 0x7b, 0x1b, 0x20, 0x7b, 0x02, 0x6d, 0xca, 0xd3, 0x09, 0xa1, 0x2e, 0x37, 0xe9,
-	0x1c, 0x1f, 0xdc, 0x14, 0x1a, 0xe7, 0x69, 0x2a, 0xec, 0x8a, 0xb7, 0x28, 0x4f, 0xc7, 0xa8, 0x1d,
-	0xfb, 0x1e, 0xba, 0xc5, 0x2a, 0x8b, 0x12, 0xd5, 0x12, 0xe3, 0xaa, 0x85, 0x3b, 0x56, 0x76, 0x28,
-	0x4f, 0x79, 0xc5, 0x8e, 0xfe, 0x0a, 0xe0, 0x93, 0x4d, 0x9c, 0x3d, 0x84, 0x06, 0x39, 0x61, 0xcb,
-	0x1e, 0x02, 0x5e, 0x26, 0xc5, 0x84, 0x40, 0x5d, 0xaa, 0x07, 0xbc, 0x08, 0x8b, 0x9b, 0x20, 0x94,
-	0x46, 0xc7, 0xe4, 0xdd, 0x13, 0xf0, 0x75, 0x5a, 0x28, 0x4c, 0x57, 0x0e, 0x4b, 0x6b, 0x34, 0x78,
-	0x99, 0xb0, 0xaf, 0xe0, 0xfe, 0x54, 0x39, 0x9a, 0x64, 0x68, 0x27, 0x25, 0xd3, 0xbb, 0x21, 0xe0,
-	0x7b, 0x45, 0xf9, 0x15, 0xda, 0xb1, 0x2f, 0x96, 0x1e, 0x70, 0x56, 0x68, 0x4a, 0x95, 0x23, 0xef,
-	0x81, 0x06, 0xaf, 0x97, 0x8e, 0xff, 0xd8, 0x86, 0xc6, 0xc5, 0xd9, 0x58, 0xa5, 0xec, 0x6b, 0x68,
-	0x3c, 0x8d, 0xe3, 0x8b, 0x33, 0xf6, 0xa0, 0xf6, 0x7f, 0xd7, 0xf3, 0xb1, 0xb7, 0x5f, 0x15, 0xfd,
-	0x20, 0x1f, 0xbe, 0x31, 0x2a, 0x8e, 0xb6, 0xd8, 0x6b, 0xe8, 0xd6, 0x67, 0x07, 0x3b, 0xba, 0x7b,
-	0xa6, 0xf5, 0xbe, 0xb8, 0x15, 0x2f, 0x9d, 0x11, 0x6d, 0xb1, 0x17, 0x00, 0xd7, 0x2f, 0x9c, 0x1d,
-	0xd6, 0x16, 0xdc, 0x98, 0x25, 0xbd, 0xcf, 0x6f, 0x41, 0xeb, 0x62, 0xd7, 0xf6, 0xdb, 0x10, 0xbb,
-	0x31, 0x0a, 0x36, 0xc4, 0x6e, 0x7a, 0x36, 0xda, 0xfa, 0xe1, 0xb3, 0xdf, 0x0e, 0x3c, 0x63, 0x54,
-	0x7c, 0xef, 0x64, 0x62, 0xf2, 0x78, 0x34, 0x37, 0xd5, 0x27, 0x6d, 0xda, 0xf4, 0xbf, 0xdf, 0xfc,
-	0x13, 0x00, 0x00, 0xff, 0xff, 0xcf, 0x29, 0x08, 0x60, 0x0d, 0x07, 0x00, 0x00,
+	// 1556 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xdb, 0x72, 0xdb, 0xc8,
+	0x11, 0x15, 0x44, 0x51, 0x22, 0x9b, 0xa4, 0x0c, 0x8d, 0x1d, 0x1b, 0x66, 0x7c, 0x51, 0x90, 0x9b,
+	0x6a, 0x2b, 0xa1, 0x1c, 0xed, 0x25, 0x72, 0x92, 0x17, 0x9a, 0xa2, 0xbc, 0x2c, 0x8b, 0x32, 0x77,
+	0x40, 0xb9, 0xbc, 0x79, 0x41, 0x8d, 0x80, 0x26, 0x89, 0x32, 0x6e, 0x8b, 0x19, 0xc8, 0xab, 0xe7,
+	0xfc, 0x44, 0x7e, 0x22, 0x2f, 0xf9, 0x84, 0x7c, 0x44, 0x5e, 0xf3, 0x11, 0xf9, 0x81, 0xd4, 0xcc,
+	0x00, 0x14, 0x29, 0x52, 0xbb, 0x4e, 0xd5, 0x3e, 0x69, 0xfa, 0xf4, 0xe9, 0x46, 0x4f, 0xdf, 0x38,
+	0x82, 0x47, 0xde, 0xc7, 0xc9, 0x61, 0x9a, 0x25, 0x22, 0xe1, 0x87, 0x39, 0xba, 0x3c, 0x88, 0x3a,
+	0x4a, 0x22, 0xf5, 0x88, 0x4d, 0x23, 0xd6, 0xf1, 0x3e, 0x4e, 0xda, 0x8f, 0x93, 0xcc, 0x3b, 0xce,
+	0x4a, 0x96, 0x97, 0x44, 0x51, 0x12, 0x6b, 0x56, 0xfb, 0xd9, 0x34, 0x49, 0xa6, 0x21, 0x6a, 0xdd,
+	0x65, 0x3e, 0x39, 0xfc, 0x98, 0xb1, 0x34, 0xc5, 0x8c, 0x6b, 0xbd, 0xfd, 0x1e, 0xda, 0xdd, 0x5c,
+	0xcc, 0x30, 0x16, 0x81, 0xc7, 0x04, 0x52, 0xfc, 0x2e, 0x47, 0x2e, 0xbe, 0xe6, 0xfc, 0x0c, 0x39,
+	0x27, 0x0f, 0x61, 0x3b, 0xe2, 0x01, 0xf7, 0x63, 0xcb, 0xd8, 0x37, 0x0e, 0xea, 0xb4, 0x90, 0x88,
+	0x09, 0x15, 0x96, 0xc6, 0xd6, 0xa6, 0x02, 0xe5, 0x51, 0x22, 0x19, 0x13, 0x56, 0x65, 0xdf, 0x38,
+	0x68, 0x51, 0x79, 0xb4, 0xff, 0xbe, 0x09, 0xb5, 0x8b, 0x7e, 0x2f, 0x89, 0x27, 0xc1, 0x94, 0x10,
+	0xd8, 0x0a, 0x22, 0x1e, 0x14, 0x6e, 0xd4, 0x99, 0x3c, 0x86, 0x1a, 0xcb, 0xc5, 0xcc, 0xfd, 0x80,
+	0xd7, 0xca, 0x53, 0x93, 0xee, 0x48, 0xf9, 0x0d, 0x5e, 0xcf, 0x55, 0x49, 0xea, 0x59, 0x95, 0x1b,
+	0xd5, 0xdb, 0xd4, 0x93, 0x1f, 0xe2, 0xf8, 0x9d, 0xb5, 0xb5, 0x6f, 0x1c, 0x6c, 0x51, 0x79, 0x24,
+	0xa7, 0xd0, 0x98, 0x71, 0x1e, 0x22, 0xe7, 0xae, 0x37, 0x99, 0x5a, 0xd5, 0x7d, 0xe3, 0xa0, 0x71,
+	0xf4, 0xeb, 0xce, 0x3c, 0x3d, 0x9d, 0xbb, 0x2f, 0x48, 0xa1, 0xb0, 0xec, 0x4d, 0xa6, 0xe4, 0x73,
+	0x00, 0x64, 0xa9, 0x1b, 0xa1, 0x98, 0x25, 0xbe, 0xb5, 0xbd, 0x6f, 0x1c, 0xec, 0x1e, 0x3d, 0x58,
+	0x70, 0xd3, 0x67, 0xe9, 0x50, 0xe9, 0x68, 0x1d, 0xcb, 0xa3, 0x34, 0x52, 0x91, 0x4e, 0x58, 0x1e,
+	0x0a, 0x6b, 0x67, 0xc5, 0x48, 0x7e, 0xfb, 0x54, 0xea, 0x68, 0x9d, 0x95, 0x47, 0xdb, 0x81, 0xfb,
+	0x6b, 0x62, 0x5a, 0x9b, 0xa4, 0x03, 0xb8, 0xe7, 0xb1, 0x30, 0x44, 0xdf, 0x11, 0x4c, 0x04, 0x49,
+	0x3c, 0x38, 0x29, 0xb2, 0x7e, 0x1b, 0xb6, 0xdf, 0xc3, 0x83, 0x65, 0xa7, 0x3c, 0x4d, 0x62, 0x8e,
+	0xc4, 0x86, 0x66, 0xc6, 0xfc, 0x20, 0xe7, 0x23, 0xe6, 0x7d, 0x40, 0xa1, 0xbc, 0x37, 0xe9, 0x12,
+	0x46, 0x9e, 0x40, 0x9d, 0x23, 0xe7, 0xd2, 0x91, 0x5f, 0xf8, 0xbf, 0x01, 0xec, 0x6f, 0x60, 0xef,
+	0x24, 0xe0, 0x5e, 0x12, 0xc7, 0xe8, 0x89, 0x9f, 0x26, 0xd8, 0x63, 0x20, 0x8b, 0x2e, 0x3f, 0x3d,
+	0x54, 0xfb, 0xbf, 0x9b, 0xb0, 0xf7, 0x1a, 0xe3, 0x71, 0xc6, 0x26, 0x93, 0xc0, 0xfb, 0xa1, 0x68,
+	0xbe, 0x80, 0xed, 0xab, 0x24, 0xcc, 0x23, 0x54, 0x41, 0x34, 0x8e, 0x9e, 0x74, 0xf4, 0x2c, 0x74,
+	0xca, 0x59, 0xe8, 0x38, 0x22, 0x0b, 0xe2, 0xe9, 0x3b, 0x16, 0xe6, 0x48, 0x0b, 0x2e, 0xf9, 0x0a,
+	0x76, 0x2e, 0x03, 0x91, 0x31, 0x81, 0x56, 0xe5, 0x13, 0xcc, 0x4a, 0x32, 0x79, 0x06, 0x20, 0x82,
+	0x08, 0x07, 0xb1, 0x83, 0x1e, 0x2f, 0xda, 0x73, 0x01, 0x21, 0xc7, 0xf0, 0x28, 0xc3, 0x34, 0xc9,
+	0x44, 0x10, 0x4f, 0x07, 0xb1, 0xc0, 0xec, 0x8a, 0x85, 0x05, 0xb9, 0xaa, 0xc8, 0x77, 0xa9, 0xc9,
+	0x3e, 0x34, 0x32, 0xbc, 0xc2, 0x8c, 0xe3, 0x30, 0xf1, 0x51, 0x35, 0x66, 0x8d, 0x2e, 0x42, 0xc4,
+	0x82, 0x1d, 0xf9, 0xa5, 0x24, 0xd7, 0x1d, 0xd8, 0xa2, 0xa5, 0x48, 0x4e, 0xe1, 0x99, 0x1f, 0x70,
+	0x76, 0x19, 0xa2, 0x83, 0xd9, 0x15, 0x66, 0x14, 0x99, 0x37, 0x63, 0x97, 0x41, 0x18, 0x88, 0xeb,
+	0xde, 0x0c, 0xbd, 0x0f, 0x56, 0x4d, 0xb9, 0xfb, 0x11, 0x96, 0x8d, 0x40, 0x16, 0x93, 0x5e, 0xd4,
+	0xeb, 0x21, 0x6c, 0x27, 0xb9, 0x48, 0xf3, 0xb2, 0x52, 0x85, 0x44, 0xfe, 0x08, 0x80, 0xb1, 0xef,
+	0x16, 0x3a, 0x9d, 0x7d, 0x6b, 0x61, 0x28, 0x0a, 0x3f, 0x6f, 0x95, 0x9e, 0xd6, 0x31, 0xf6, 0xf5,
+	0xd1, 0xfe, 0x9b, 0x01, 0xad, 0x25, 0x25, 0xf9, 0x02, 0x6a, 0x3c, 0x8f, 0x5c, 0x8e, 0xb1, 0xfe,
+	0x48, 0xe3, 0xe8, 0xf1, 0xaa, 0x23, 0x27, 0x8f, 0x22, 0x96, 0x5d, 0xd3, 0x1d, 0x9e, 0x47, 0x0e,
+	0xc6, 0x82, 0xfc, 0x05, 0x9a, 0xd2, 0x2a, 0x43, 0x0f, 0x83, 0x2b, 0xf4, 0xad, 0xcd, 0x1f, 0xb3,
+	0x6c, 0xf0, 0x3c, 0xa2, 0x05, 0xdb, 0xfe, 0xa7, 0x01, 0xbb, 0xcb, 0x7a, 0xf2, 0x00, 0xaa, 0x5c,
+	0xb0, 0x4c, 0xc7, 0x60, 0x50, 0x2d, 0xc8, 0x5d, 0x84, 0xb1, 0xf6, 0x6e, 0x50, 0x79, 0x94, 0x95,
+	0xe0, 0xe8, 0x25, 0xb1, 0xcf, 0x55, 0xf7, 0x18, 0xb4, 0x14, 0xa5, 0x87, 0xcb, 0x6b, 0x81, 0xba,
+	0x35, 0xaa, 0x54, 0x0b, 0xe4, 0x37, 0x70, 0xef, 0x32, 0x10, 0xdc, 0x4d, 0x31, 0x73, 0x35, 0x53,
+	0x75, 0x83, 0x41, 0x5b, 0x12, 0x1e, 0x61, 0xe6, 0x28, 0x50, 0xf7, 0x80, 0xc8, 0x58, 0xcc, 0xa3,
+	0x40, 0x70, 0xd5, 0x03, 0x55, 0xba, 0x08, 0xd9, 0xff, 0xa8, 0x40, 0xe3, 0x2c, 0x61, 0x7e, 0x39,
+	0x11, 0x4f, 0x01, 0xe4, 0x14, 0xb8, 0x37, 0x61, 0xd7, 0x69, 0x5d, 0x22, 0x8e, 0x0a, 0xfd, 0x11,
+	0xec, 0xc4, 0x79, 0xe4, 0xe6, 0xc8, 0x55, 0xf8, 0x2d, 0xba, 0x1d, 0xe7, 0xd1, 0x05, 0xf2, 0xa5,
+	0xad, 0x5c, 0x59, 0xde, 0xca, 0xab, 0xab, 0xf7, 0x39, 0x34, 0x98, 0x10, 0xcc, 0x9b, 0xb9, 0x6a,
+	0x60, 0x74, 0xe8, 0xa0, 0x21, 0x2a, 0xa7, 0x62, 0x1f, 0x9a, 0xb3, 0x24, 0xf4, 0x5d, 0xd9, 0x8f,
+	0x6e, 0xa4, 0x03, 0x6f, 0x51, 0x90, 0xd8, 0x38, 0x88, 0x70, 0xc8, 0x49, 0x07, 0xee, 0x07, 0xb2,
+	0xdf, 0x83, 0xc8, 0x0d, 0x8a, 0xbe, 0x97, 0x44, 0xdd, 0xc7, 0x7b, 0x85, 0xaa, 0x9c, 0x88, 0x21,
+	0x27, 0x6f, 0x80, 0xf8, 0xf3, 0xcd, 0xe1, 0xa6, 0x4c, 0x08, 0xcc, 0x62, 0xd5, 0xc5, 0xbb, 0x47,
+	0x4f, 0x16, 0x0a, 0x7c, 0xb3, 0x5e, 0x46, 0x9a, 0x43, 0xf7, 0xfc, 0xdb, 0x10, 0xf9, 0x05, 0x34,
+	0xd9, 0x65, 0x96, 0xa7, 0x42, 0xc6, 0x1f, 0x24, 0x56, 0x5d, 0x5d, 0xa0, 0xa1, 0x31, 0x2a, 0xa1,
+	0x75, 0x3b, 0x0d, 0xd6, 0xee, 0x34, 0xf2, 0x3b, 0x20, 0x99, 0x4e, 0xbe, 0x5b, 0x8c, 0x9f, 0xbc,
+	0x48, 0x43, 0x5d, 0xc4, 0x2c, 0x34, 0x63, 0xad, 0x18, 0x72, 0xfb, 0x5f, 0x15, 0xd8, 0x95, 0xf5,
+	0x72, 0x04, 0xa6, 0x54, 0x4d, 0x3e, 0xf9, 0x2d, 0x6c, 0x71, 0x81, 0xa9, 0x2a, 0xd6, 0xee, 0xd1,
+	0xfd, 0x85, 0xcb, 0xcc, 0x89, 0x8a, 0x40, 0xda, 0x50, 0x93, 0x17, 0x88, 0x52, 0x51, 0x56, 0x6f,
+	0x2e, 0xab, 0x55, 0x9e, 0x7b, 0x1e, 0x72, 0x8e, 0xbc, 0xf8, 0x39, 0xbe, 0x01, 0xa4, 0xe5, 0x84,
+	0x05, 0x61, 0x9e, 0x15, 0x8d, 0xd8, 0xa2, 0x73, 0x99, 0xfc, 0x0a, 0x76, 0x43, 0x26, 0x30, 0xf6,
+	0xae, 0xdd, 0xf4, 0xcb, 0x17, 0x32, 0x76, 0x5d, 0xcf, 0x66, 0x81, 0x8e, 0xbe, 0x7c, 0x31, 0x5c,
+	0x66, 0xbd, 0x7c, 0x51, 0xd6, 0x74, 0x81, 0xf5, 0x72, 0x85, 0xf5, 0xb2, 0x2c, 0xe8, 0x22, 0xeb,
+	0xe5, 0x32, 0x2b, 0x62, 0xdf, 0x4b, 0x56, 0x6d, 0x89, 0x35, 0x64, 0xdf, 0x0f, 0x39, 0x79, 0x07,
+	0xf7, 0x8a, 0x18, 0xdd, 0x0c, 0x19, 0x4f, 0x62, 0x6e, 0xd5, 0xf7, 0x2b, 0x07, 0x8d, 0xa3, 0xdf,
+	0xaf, 0xcb, 0x90, 0x4a, 0x65, 0xe7, 0x54, 0x1b, 0x50, 0xcd, 0xef, 0xc7, 0x22, 0xbb, 0xa6, 0xbb,
+	0x93, 0x25, 0xb0, 0xdd, 0x85, 0xfb, 0x6b, 0x68, 0xb2, 0xcb, 0x65, 0xef, 0xeb, 0x89, 0x91, 0x47,
+	0x39, 0xba, 0x57, 0x72, 0xd9, 0x17, 0xb9, 0xd6, 0xc2, 0x9f, 0x36, 0x8f, 0x0d, 0xfb, 0x3f, 0x06,
+	0x80, 0x1e, 0x3a, 0x55, 0x40, 0x0b, 0x76, 0xb2, 0x3c, 0x8e, 0x83, 0x78, 0xaa, 0xcc, 0x6b, 0xb4,
+	0x14, 0x65, 0xa3, 0x61, 0xc8, 0x52, 0x8e, 0xbe, 0x1c, 0x73, 0x5e, 0xac, 0x8c, 0x46, 0x81, 0xa9,
+	0x35, 0xff, 0x1c, 0x1a, 0x39, 0x72, 0x3d, 0xaf, 0xe8, 0x17, 0xa5, 0x83, 0x1c, 0xb9, 0xa3, 0x11,
+	0xf2, 0x4b, 0x68, 0x49, 0x82, 0x97, 0x44, 0x69, 0x88, 0x92, 0xa2, 0x0b, 0xd8, 0xcc, 0x91, 0xf7,
+	0x4a, 0x4c, 0x8e, 0xbd, 0x24, 0xc9, 0xab, 0xa2, 0xde, 0x25, 0x2d, 0x5a, 0xcf, 0x91, 0x9f, 0x2a,
+	0x80, 0x1c, 0xca, 0x3d, 0x86, 0xa9, 0x2c, 0x5a, 0xe5, 0xd6, 0x46, 0x5c, 0xce, 0x20, 0xd5, 0xbc,
+	0xcf, 0x8e, 0xa1, 0x3e, 0x7f, 0xf7, 0x90, 0x06, 0xec, 0xf4, 0xbb, 0x23, 0xb7, 0xfb, 0xa6, 0x6b,
+	0x6e, 0x94, 0x82, 0x33, 0x18, 0x9a, 0x06, 0xd9, 0x83, 0x56, 0xa1, 0x71, 0x47, 0x74, 0x30, 0xec,
+	0x9b, 0x9b, 0xd2, 0x72, 0xfe, 0xf8, 0x21, 0x4d, 0xa8, 0x9d, 0xbf, 0x75, 0x4f, 0xbb, 0x17, 0x67,
+	0x63, 0x6d, 0xfa, 0xaa, 0x7b, 0xe2, 0x0e, 0xbb, 0x3d, 0xd3, 0x20, 0x26, 0x34, 0x9d, 0x6f, 0xcf,
+	0x7b, 0xee, 0x69, 0x77, 0x70, 0x76, 0x41, 0xb5, 0xe5, 0xde, 0xca, 0xf4, 0x4a, 0x0f, 0xaf, 0x69,
+	0xb7, 0xd7, 0x3f, 0xbd, 0x38, 0x33, 0x37, 0x08, 0xc0, 0x76, 0xf7, 0x15, 0xbd, 0x18, 0x8d, 0x4d,
+	0x83, 0xd4, 0xa1, 0x3a, 0x1c, 0xbc, 0xef, 0x9f, 0x98, 0x9b, 0x9f, 0xa5, 0x50, 0x2b, 0xaf, 0x21,
+	0xfd, 0xca, 0x90, 0x06, 0x27, 0xfd, 0xf3, 0xf1, 0x60, 0xfc, 0xad, 0xb9, 0x51, 0x06, 0xd9, 0xfb,
+	0xba, 0x7b, 0x76, 0xd6, 0x3f, 0x7f, 0xdd, 0x37, 0x0d, 0xb2, 0x0b, 0xa0, 0xe2, 0xee, 0xf5, 0xfa,
+	0xa3, 0xb1, 0xb9, 0x29, 0xe5, 0x6e, 0xaf, 0x37, 0x76, 0x9d, 0x71, 0x97, 0x8e, 0xcd, 0x8a, 0x74,
+	0xa2, 0xe4, 0xc1, 0xf9, 0xb8, 0x4f, 0x07, 0x43, 0x73, 0x8b, 0xb4, 0xa0, 0x5e, 0x30, 0xde, 0x8e,
+	0xcc, 0xea, 0xd1, 0xbf, 0x2b, 0x50, 0xbd, 0xe8, 0x3b, 0x41, 0x44, 0xfe, 0x00, 0xd5, 0xae, 0xef,
+	0x5f, 0xf4, 0xc9, 0xe2, 0xe0, 0x96, 0x0f, 0xe0, 0xf6, 0x5e, 0x01, 0xaa, 0x97, 0x7a, 0xe7, 0x5d,
+	0x12, 0xf8, 0xf6, 0x06, 0xf9, 0x06, 0x9a, 0x8b, 0x4f, 0x36, 0xf2, 0xec, 0x87, 0x1f, 0xad, 0xed,
+	0xe7, 0x77, 0xea, 0xf5, 0x0f, 0xb2, 0xbd, 0x41, 0xde, 0x00, 0xdc, 0xe4, 0x8e, 0xac, 0x5f, 0x88,
+	0xa5, 0xbb, 0xa7, 0x77, 0x68, 0x17, 0x9d, 0xdd, 0xfc, 0xea, 0x2f, 0x39, 0x5b, 0x79, 0x81, 0xb5,
+	0x9f, 0xde, 0xa1, 0x9d, 0x3b, 0x3b, 0x86, 0xba, 0xea, 0x64, 0x59, 0x20, 0xf2, 0xf0, 0x56, 0xe3,
+	0x95, 0x5e, 0xd6, 0xa6, 0xe9, 0xcf, 0xd0, 0x7a, 0x8d, 0x62, 0x61, 0xce, 0x56, 0x59, 0xed, 0x9f,
+	0xad, 0x38, 0x94, 0x4c, 0x7b, 0x83, 0x7c, 0x05, 0x35, 0x47, 0x24, 0xa9, 0xfa, 0xea, 0xff, 0x61,
+	0xf7, 0xea, 0xe7, 0x7f, 0x7d, 0xac, 0x34, 0x87, 0xf2, 0xff, 0x2f, 0x2f, 0x4c, 0x72, 0xff, 0x70,
+	0x9a, 0x14, 0xff, 0x62, 0x5d, 0x6e, 0xab, 0xbf, 0x9f, 0xff, 0x6f, 0x00, 0xcb, 0x6e, 0x44, 0xe9,
+	0x9d, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -701,6 +1220,12 @@ type UESimClient interface {
 	Disconnect(ctx context.Context, in *DisconnectRequest, opts ...grpc.CallOption) (*DisconnectResponse, error)
 	// Triggers iperf traffic towards the CWAG
 	GenTraffic(ctx context.Context, in *GenTrafficRequest, opts ...grpc.CallOption) (*GenTrafficResponse, error)
+	// Starts attaching & detaching a range of UEs in the background
+	StartLoad(ctx context.Context, in *LoadRequest, opts ...grpc.CallOption) (*protos.Void, error)
+	// Returns the statistics of the current or last load run
+	GetLoadReport(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*LoadReport, error)
+	// Stops the current load run and returns its statistics
+	StopLoad(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*LoadReport, error)
 }
 
 type uESimClient struct {
@@ -747,6 +1272,33 @@ func (c *uESimClient) GenTraffic(ctx context.Context, in *GenTrafficRequest, opt
 	return out, nil
 }
 
+func (c *uESimClient) StartLoad(ctx context.Context, in *LoadRequest, opts ...grpc.CallOption) (*protos.Void, error) {
+	out := new(protos.Void)
+	err := c.cc.Invoke(ctx, "/magma.cwf.UESim/StartLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uESimClient) GetLoadReport(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*LoadReport, error) {
+	out := new(LoadReport)
+	err := c.cc.Invoke(ctx, "/magma.cwf.UESim/GetLoadReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *uESimClient) StopLoad(ctx context.Context, in *protos.Void, opts ...grpc.CallOption) (*LoadReport, error) {
+	out := new(LoadReport)
+	err := c.cc.Invoke(ctx, "/magma.cwf.UESim/StopLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UESimServer is the server API for UESim service.
 type UESimServer interface {
 	// Adds a new UE to the store.
@@ -758,6 +1310,12 @@ type UESimServer interface {
 	Disconnect(context.Context, *DisconnectRequest) (*DisconnectResponse, error)
 	// Triggers iperf traffic towards the CWAG
 	GenTraffic(context.Context, *GenTrafficRequest) (*GenTrafficResponse, error)
+	// Starts attaching & detaching a range of UEs in the background
+	StartLoad(context.Context, *LoadRequest) (*protos.Void, error)
+	// Returns the statistics of the current or last load run
+	GetLoadReport(context.Context, *protos.Void) (*LoadReport, error)
+	// Stops the current load run and returns its statistics
+	StopLoad(context.Context, *protos.Void) (*LoadReport, error)
 }
 
 // UnimplementedUESimServer can be embedded to have forward compatible implementations.
@@ -776,6 +1334,15 @@ func (*UnimplementedUESimServer) Disconnect(ctx context.Context, req *Disconnect
 func (*UnimplementedUESimServer) GenTraffic(ctx context.Context, req *GenTrafficRequest) (*GenTrafficResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenTraffic not implemented")
 }
+func (*UnimplementedUESimServer) StartLoad(ctx context.Context, req *LoadRequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartLoad not implemented")
+}
+func (*UnimplementedUESimServer) GetLoadReport(ctx context.Context, req *protos.Void) (*LoadReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoadReport not implemented")
+}
+func (*UnimplementedUESimServer) StopLoad(ctx context.Context, req *protos.Void) (*LoadReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopLoad not implemented")
+}
 
 func RegisterUESimServer(s *grpc.Server, srv UESimServer) {
 	s.RegisterService(&_UESim_serviceDesc, srv)
@@ -853,6 +1420,60 @@ func _UESim_GenTraffic_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UESim_StartLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UESimServer).StartLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.cwf.UESim/StartLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UESimServer).StartLoad(ctx, req.(*LoadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UESim_GetLoadReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UESimServer).GetLoadReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.cwf.UESim/GetLoadReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UESimServer).GetLoadReport(ctx, req.(*protos.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UESim_StopLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UESimServer).StopLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.cwf.UESim/StopLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UESimServer).StopLoad(ctx, req.(*protos.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _UESim_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.cwf.UESim",
 	HandlerType: (*UESimServer)(nil),
@@ -873,6 +1494,18 @@ var _UESim_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GenTraffic",
 			Handler:    _UESim_GenTraffic_Handler,
 		},
+		{
+			MethodName: "StartLoad",
+			Handler:    _UESim_StartLoad_Handler,
+		},
+		{
+			MethodName: "GetLoadReport",
+			Handler:    _UESim_GetLoadReport_Handler,
+		},
+		{
+			MethodName: "StopLoad",
+			Handler:    _UESim_StopLoad_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cwf/protos/ue_sim.proto",
@@ -6,8 +6,11 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
 )
 
@@ -21,6 +24,9 @@ type GatewayHealthConfigs struct {
 	// gre probe interval secs
 	GreProbeIntervalSecs uint32 `json:"gre_probe_interval_secs,omitempty"`
 
+	// health probes
+	HealthProbes []*GatewayHealthProbe `json:"health_probes"`
+
 	// icmp probe pkt count
 	IcmpProbePktCount uint32 `json:"icmp_probe_pkt_count,omitempty"`
 
@@ -30,6 +36,40 @@ type GatewayHealthConfigs struct {
 
 // Validate validates this gateway health configs
 func (m *GatewayHealthConfigs) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateHealthProbes(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GatewayHealthConfigs) validateHealthProbes(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.HealthProbes) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.HealthProbes); i++ {
+		if swag.IsZero(m.HealthProbes[i]) { // not required
+			continue
+		}
+
+		if m.HealthProbes[i] != nil {
+			if err := m.HealthProbes[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("health_probes" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
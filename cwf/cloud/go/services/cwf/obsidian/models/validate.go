@@ -49,6 +49,21 @@ func (m *GatewayCwfConfigs) ValidateModel() error {
 		}
 		set[peer.IP] = append(set[peer.IP], swag.Uint32Value(peer.Key))
 	}
+	if m.GatewayHealthConfigs != nil {
+		probeNames := map[string]bool{}
+		for _, probe := range m.GatewayHealthConfigs.HealthProbes {
+			if probe == nil {
+				continue
+			}
+			if probeNames[probe.Name] {
+				return errors.New(fmt.Sprintf("Found duplicate health probe %s", probe.Name))
+			}
+			probeNames[probe.Name] = true
+			if probe.Type == GatewayHealthProbeTypeRADIUSSTATUSSERVER && len(probe.Secret) == 0 {
+				return errors.New(fmt.Sprintf("Secret of RADIUS health probe %s must be set", probe.Name))
+			}
+		}
+	}
 	return nil
 }
 
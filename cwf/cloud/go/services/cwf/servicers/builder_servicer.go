@@ -172,12 +172,37 @@ func buildFromConfigs(nwConfig *models.NetworkCarrierWifiConfigs, gwConfig *mode
 	}
 	if healthCfg != nil {
 		protos.FillIn(healthCfg, mc)
+		mc.HealthProbes = getHealthServiceProbes(healthCfg.HealthProbes)
 	}
 	ret["health"] = mc
 
 	return ret, nil
 }
 
+func getHealthServiceProbes(probes []*models.GatewayHealthProbe) []*cwf_mconfig.CwfGatewayHealthConfigHealthProbe {
+	var ret []*cwf_mconfig.CwfGatewayHealthConfigHealthProbe
+	for _, probe := range probes {
+		if probe == nil {
+			continue
+		}
+		ret = append(ret, &cwf_mconfig.CwfGatewayHealthConfigHealthProbe{
+			Name:                probe.Name,
+			Type:                cwf_mconfig.CwfGatewayHealthConfigHealthProbe_ProbeType(cwf_mconfig.CwfGatewayHealthConfigHealthProbe_ProbeType_value[probe.Type]),
+			Target:              probe.Target,
+			Secret:              probe.Secret,
+			ExpectedStatus:      probe.ExpectedStatus,
+			IntervalSecs:        probe.IntervalSecs,
+			TimeoutMs:           probe.TimeoutMs,
+			MaxLatencyMs:        probe.MaxLatencyMs,
+			FailureThreshold:    probe.FailureThreshold,
+			SuccessThreshold:    probe.SuccessThreshold,
+			DiameterOriginHost:  probe.DiameterOriginHost,
+			DiameterOriginRealm: probe.DiameterOriginRealm,
+		})
+	}
+	return ret
+}
+
 func getPipelineDAllowedGrePeers(allowedGrePeers models.AllowedGrePeers) ([]*lte_mconfig.PipelineD_AllowedGrePeer, error) {
 	ues := make([]*lte_mconfig.PipelineD_AllowedGrePeer, 0, len(allowedGrePeers))
 	for _, entry := range allowedGrePeers {
@@ -164,6 +164,70 @@ func TestBuilder_Build(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, expected, actual)
 	})
+
+	t.Run("gateway health probes", func(t *testing.T) {
+		nw := configurator.Network{
+			ID: "n1",
+			Configs: map[string]interface{}{
+				cwf.CwfNetworkType: defaultnwConfig,
+			},
+		}
+		gw := configurator.NetworkEntity{
+			Type: orc8r.MagmadGatewayType, Key: "gw1",
+			Associations: []storage.TypeAndKey{
+				{Type: cwf.CwfGatewayType, Key: "gw1"},
+			},
+		}
+		gwConfig := &models.GatewayCwfConfigs{
+			AllowedGrePeers: defaultgwConfig.AllowedGrePeers,
+			IpdrExportDst:   defaultgwConfig.IpdrExportDst,
+			GatewayHealthConfigs: &models.GatewayHealthConfigs{
+				CPUUtilThresholdPct: 0.8,
+				HealthProbes: []*models.GatewayHealthProbe{
+					{Name: "aaa", Type: models.GatewayHealthProbeTypeRADIUSSTATUSSERVER, Target: "10.0.0.1:1812", Secret: "123456"},
+					{Name: "portal", Type: models.GatewayHealthProbeTypeHTTP, Target: "http://portal", ExpectedStatus: 302, FailureThreshold: 5},
+				},
+			},
+		}
+		cwfGW := configurator.NetworkEntity{
+			Type: cwf.CwfGatewayType, Key: "gw1",
+			Config:             gwConfig,
+			ParentAssociations: []storage.TypeAndKey{gw.GetTypeAndKey()},
+		}
+		graph := configurator.EntityGraph{
+			Entities: []configurator.NetworkEntity{cwfGW, gw},
+			Edges: []configurator.GraphEdge{
+				{From: gw.GetTypeAndKey(), To: cwfGW.GetTypeAndKey()},
+			},
+		}
+
+		expected := &cwf_mconfig.CwfGatewayHealthConfig{
+			CpuUtilThresholdPct: 0.8,
+			GrePeers: []*cwf_mconfig.CwfGatewayHealthConfigGrePeer{
+				{Ip: "1.2.3.4/24"},
+				{Ip: "1.1.1.1/24"},
+			},
+			HealthProbes: []*cwf_mconfig.CwfGatewayHealthConfigHealthProbe{
+				{
+					Name:   "aaa",
+					Type:   cwf_mconfig.CwfGatewayHealthConfigHealthProbe_RADIUS_STATUS_SERVER,
+					Target: "10.0.0.1:1812",
+					Secret: "123456",
+				},
+				{
+					Name:             "portal",
+					Type:             cwf_mconfig.CwfGatewayHealthConfigHealthProbe_HTTP,
+					Target:           "http://portal",
+					ExpectedStatus:   302,
+					FailureThreshold: 5,
+				},
+			},
+		}
+
+		actual, err := build(&nw, &graph, "gw1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual["health"])
+	})
 }
 
 func build(network *configurator.Network, graph *configurator.EntityGraph, gatewayID string) (map[string]proto.Message, error) {
@@ -21,6 +21,7 @@ import (
 	mconfigprotos "magma/cwf/cloud/go/protos/mconfig"
 	"magma/cwf/gateway/registry"
 	"magma/cwf/gateway/services/gateway_health/health/gre_probe"
+	"magma/cwf/gateway/services/gateway_health/health/probe"
 	"magma/cwf/gateway/services/gateway_health/health/service_health"
 	"magma/cwf/gateway/services/gateway_health/health/system_health"
 	"magma/cwf/gateway/services/gateway_health/servicers"
@@ -50,7 +51,11 @@ func main() {
 		glog.Fatalf("Error creating %s service: %s", registry.GatewayHealth, err)
 	}
 	cfg := getHealthMconfig()
-	probe := gre_probe.NewICMPProbe(cfg.GrePeers, cfg.GreProbeInterval, int(cfg.IcmpProbePktCount))
+	greProbe := gre_probe.NewICMPProbe(cfg.GrePeers, cfg.GreProbeInterval, int(cfg.IcmpProbePktCount))
+	prober, err := probe.NewProbeSetFromConfig(cfg.HealthProbes)
+	if err != nil {
+		glog.Fatalf("Error creating health probes: %s", err)
+	}
 
 	transportVIP := cfg.GetClusterVirtualIp()
 	if len(transportVIP) == 0 {
@@ -66,14 +71,19 @@ func main() {
 	if err != nil {
 		glog.Fatalf("Error creating DockerServiceHealthProvider: %s", err)
 	}
-	servicer := servicers.NewGatewayHealthServicer(cfg, probe, dockerHealth, systemHealth)
+	servicer := servicers.NewGatewayHealthServicer(cfg, greProbe, prober, dockerHealth, systemHealth)
 	fegprotos.RegisterServiceHealthServer(srv.GrpcServer, servicer)
 
 	// Start GRE probe
-	err = probe.Start()
+	err = greProbe.Start()
 	if err != nil {
 		glog.Fatalf("Error running GRE health probe: %s", err)
 	}
+	// Start RADIUS, Diameter & HTTP health probes
+	err = prober.Start()
+	if err != nil {
+		glog.Fatalf("Error running health probes: %s", err)
+	}
 	// Run the service
 	err = srv.Run()
 	if err != nil {
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"fmt"
+	"time"
+
+	"magma/cwf/cloud/go/protos/mconfig"
+)
+
+// NewProbeSetFromConfig creates the probes of the gateway health mconfig.
+func NewProbeSetFromConfig(cfgs []*mconfig.CwfGatewayHealthConfigHealthProbe) (*ProbeSet, error) {
+	set := NewProbeSet()
+	names := map[string]bool{}
+	for _, cfg := range cfgs {
+		if len(cfg.GetName()) == 0 {
+			return nil, fmt.Errorf("health probe name must be set: %v", cfg)
+		}
+		if names[cfg.GetName()] {
+			return nil, fmt.Errorf("duplicate health probe name: %s", cfg.GetName())
+		}
+		names[cfg.GetName()] = true
+		if len(cfg.GetTarget()) == 0 {
+			return nil, fmt.Errorf("target of health probe %s must be set", cfg.GetName())
+		}
+		probe, err := newProbe(cfg)
+		if err != nil {
+			return nil, err
+		}
+		set.Add(Config{
+			Name:             cfg.GetName(),
+			Interval:         time.Duration(cfg.GetIntervalSecs()) * time.Second,
+			Timeout:          time.Duration(cfg.GetTimeoutMs()) * time.Millisecond,
+			MaxLatency:       time.Duration(cfg.GetMaxLatencyMs()) * time.Millisecond,
+			FailureThreshold: int(cfg.GetFailureThreshold()),
+			SuccessThreshold: int(cfg.GetSuccessThreshold()),
+		}, probe)
+	}
+	return set, nil
+}
+
+func newProbe(cfg *mconfig.CwfGatewayHealthConfigHealthProbe) (Probe, error) {
+	switch cfg.GetType() {
+	case mconfig.CwfGatewayHealthConfigHealthProbe_RADIUS_STATUS_SERVER:
+		if len(cfg.GetSecret()) == 0 {
+			return nil, fmt.Errorf("secret of RADIUS health probe %s must be set", cfg.GetName())
+		}
+		return NewRadiusProbe(cfg.GetTarget(), []byte(cfg.GetSecret())), nil
+	case mconfig.CwfGatewayHealthConfigHealthProbe_DIAMETER_WATCHDOG:
+		return NewDiameterWatchdogProbe(cfg.GetTarget(), cfg.GetDiameterOriginHost(), cfg.GetDiameterOriginRealm()), nil
+	case mconfig.CwfGatewayHealthConfigHealthProbe_HTTP:
+		return NewHTTPProbe(cfg.GetTarget(), int(cfg.GetExpectedStatus())), nil
+	case mconfig.CwfGatewayHealthConfigHealthProbe_FEG_SERVICE:
+		return NewFegServiceProbe(cfg.GetTarget()), nil
+	default:
+		return nil, fmt.Errorf("unsupported type of health probe %s: %s", cfg.GetName(), cfg.GetType())
+	}
+}
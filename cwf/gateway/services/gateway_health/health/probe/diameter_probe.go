@@ -0,0 +1,136 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+const (
+	DefaultDiameterOriginRealm = "magma.com"
+	diameterProductName        = "cwag_health_probe"
+	vendor3GPP                 = 10415
+	swxAppID                   = 16777265
+)
+
+// DiameterWatchdogProbe checks a Diameter peer, e.g. the HSS or PCRF, by
+// opening a connection, exchanging capabilities & sending a Device-Watchdog
+// request (RFC 6733).
+type DiameterWatchdogProbe struct {
+	Addr        string
+	OriginHost  string
+	OriginRealm string
+}
+
+// NewDiameterWatchdogProbe creates a watchdog probe of the Diameter peer
+// at addr. The origin host defaults to the hostname.
+func NewDiameterWatchdogProbe(addr, originHost, originRealm string) *DiameterWatchdogProbe {
+	if len(originHost) == 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "cwag"
+		}
+		originHost = hostname + "." + DefaultDiameterOriginRealm
+	}
+	if len(originRealm) == 0 {
+		originRealm = DefaultDiameterOriginRealm
+	}
+	return &DiameterWatchdogProbe{Addr: addr, OriginHost: originHost, OriginRealm: originRealm}
+}
+
+// Check exchanges a CER/CEA & DWR/DWA with the peer, both answers must
+// be successful.
+func (p *DiameterWatchdogProbe) Check(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", p.Addr)
+	if err != nil {
+		return fmt.Errorf("connection to Diameter peer %s failed: %s", p.Addr, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	cer := diam.NewRequest(diam.CapabilitiesExchange, 0, dict.Default)
+	p.addOriginAVPs(cer)
+	cer.NewAVP(avp.HostIPAddress, avp.Mbit, 0, datatype.Address(conn.LocalAddr().(*net.TCPAddr).IP))
+	cer.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(0))
+	cer.NewAVP(avp.ProductName, 0, 0, datatype.UTF8String(diameterProductName))
+	// Advertise the applications of the HSS, PCRF & OCS, so that any of them
+	// has an application in common with the probe
+	cer.NewAVP(avp.SupportedVendorID, avp.Mbit, 0, datatype.Unsigned32(vendor3GPP))
+	cer.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.CHARGING_CONTROL_APP_ID))
+	for _, appID := range []uint32{swxAppID, diam.GX_CHARGING_CONTROL_APP_ID} {
+		cer.NewAVP(avp.VendorSpecificApplicationID, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(vendor3GPP)),
+				diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(appID)),
+			},
+		})
+	}
+	if err = p.exchange(conn, cer); err != nil {
+		return err
+	}
+
+	dwr := diam.NewRequest(diam.DeviceWatchdog, 0, dict.Default)
+	p.addOriginAVPs(dwr)
+	return p.exchange(conn, dwr)
+}
+
+func (p *DiameterWatchdogProbe) addOriginAVPs(m *diam.Message) {
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(p.OriginHost))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(p.OriginRealm))
+}
+
+// exchange sends the request & checks the Result-Code of its answer
+func (p *DiameterWatchdogProbe) exchange(conn net.Conn, request *diam.Message) error {
+	command := getCommandName(request)
+	if _, err := request.WriteTo(conn); err != nil {
+		return fmt.Errorf("error sending %s to Diameter peer %s: %s", command, p.Addr, err)
+	}
+	for {
+		answer, err := diam.ReadMessage(conn, dict.Default)
+		if err != nil {
+			return fmt.Errorf("no %s answer from Diameter peer %s: %s", command, p.Addr, err)
+		}
+		// Skip the requests of the peer, e.g. its own watchdog
+		if answer.Header.CommandFlags&diam.RequestFlag != 0 ||
+			answer.Header.HopByHopID != request.Header.HopByHopID {
+			continue
+		}
+		resultCode, err := answer.FindAVP(avp.ResultCode, 0)
+		if err != nil {
+			return fmt.Errorf("no Result-Code in %s answer from Diameter peer %s", command, p.Addr)
+		}
+		if code, _ := resultCode.Data.(datatype.Unsigned32); code != diam.Success {
+			return fmt.Errorf("%s to Diameter peer %s failed with Result-Code %d", command, p.Addr, code)
+		}
+		return nil
+	}
+}
+
+func getCommandName(m *diam.Message) string {
+	if m.Header.CommandCode == diam.CapabilitiesExchange {
+		return "CER"
+	}
+	return "DWR"
+}
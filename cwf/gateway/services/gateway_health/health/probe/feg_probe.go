@@ -0,0 +1,61 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"fmt"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/registry"
+	orcprotos "magma/orc8r/lib/go/protos"
+)
+
+// FegServiceProbe checks the health reported by a FeG service, e.g. swx_proxy
+// for the HSS or session_proxy for the PCRF & OCS. The FeG services are
+// reached through the gateway's service registry.
+type FegServiceProbe struct {
+	Service   string
+	getClient func(service string) (protos.ServiceHealthClient, error)
+}
+
+// NewFegServiceProbe creates a probe of the FeG service.
+func NewFegServiceProbe(service string) *FegServiceProbe {
+	return &FegServiceProbe{Service: service, getClient: getServiceHealthClient}
+}
+
+// Check fetches the health status of the FeG service, the service must
+// report itself as healthy.
+func (p *FegServiceProbe) Check(ctx context.Context) error {
+	client, err := p.getClient(p.Service)
+	if err != nil {
+		return fmt.Errorf("error connecting to FeG service %s: %s", p.Service, err)
+	}
+	status, err := client.GetHealthStatus(ctx, &orcprotos.Void{})
+	if err != nil {
+		return fmt.Errorf("error getting health of FeG service %s: %s", p.Service, err)
+	}
+	if status.GetHealth() != protos.HealthStatus_HEALTHY {
+		return fmt.Errorf("FeG service %s is unhealthy: %s", p.Service, status.GetHealthMessage())
+	}
+	return nil
+}
+
+func getServiceHealthClient(service string) (protos.ServiceHealthClient, error) {
+	conn, err := registry.GetConnection(service)
+	if err != nil {
+		return nil, err
+	}
+	return protos.NewServiceHealthClient(conn), nil
+}
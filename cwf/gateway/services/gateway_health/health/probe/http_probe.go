@@ -0,0 +1,68 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// HTTPProbe checks an HTTP endpoint, e.g. a captive portal, with GET requests.
+type HTTPProbe struct {
+	URL string
+	// ExpectedStatus is the expected status code, any status below 400 is
+	// accepted if it is not set.
+	ExpectedStatus int
+	client         *http.Client
+}
+
+// NewHTTPProbe creates a probe of the URL. Redirects are not followed, so that
+// the redirect of a captive portal can be checked as well.
+func NewHTTPProbe(url string, expectedStatus int) *HTTPProbe {
+	return &HTTPProbe{
+		URL:            url,
+		ExpectedStatus: expectedStatus,
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Check sends a GET request to the URL & checks the status of the response.
+func (p *HTTPProbe) Check(ctx context.Context) error {
+	req, err := http.NewRequest(http.MethodGet, p.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("HTTP GET %s failed: %s", p.URL, err)
+	}
+	// Drain the body to reuse the connection
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if p.ExpectedStatus != 0 && resp.StatusCode != p.ExpectedStatus {
+		return fmt.Errorf("HTTP GET %s returned status %d, expected %d", p.URL, resp.StatusCode, p.ExpectedStatus)
+	}
+	if p.ExpectedStatus == 0 && resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("HTTP GET %s returned status %d", p.URL, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"magma/cwf/gateway/services/gateway_health/metrics"
+
+	"github.com/golang/glog"
+)
+
+const (
+	DefaultInterval         = 10 * time.Second
+	DefaultTimeout          = 3 * time.Second
+	DefaultFailureThreshold = 3
+	DefaultSuccessThreshold = 2
+)
+
+// Probe defines an interface to check the health of a dependency of the
+// gateway, e.g. an AAA server, a Diameter peer or a captive portal.
+type Probe interface {
+	// Check probes the target once and returns an error if the target
+	// is unhealthy.
+	Check(ctx context.Context) error
+}
+
+// Prober defines an interface to begin periodic probes of the gateway's
+// dependencies and fetch their status at a later point.
+type Prober interface {
+	// Start begins the periodic probes.
+	Start() error
+
+	// Stop stops the periodic probes.
+	Stop()
+
+	// GetStatus fetches the current status of every probe.
+	GetStatus() []*Status
+}
+
+// Config holds the scheduling & thresholds of a probe.
+type Config struct {
+	Name     string
+	Interval time.Duration
+	Timeout  time.Duration
+	// MaxLatency marks successful checks slower than it as failed, if set.
+	MaxLatency time.Duration
+	// FailureThreshold is the number of consecutive failed checks after which
+	// a healthy target is marked unhealthy, and SuccessThreshold the number of
+	// consecutive successful checks after which an unhealthy target is marked
+	// healthy again.
+	FailureThreshold int
+	SuccessThreshold int
+}
+
+// Status is the current status of a probe. A target is considered healthy
+// until FailureThreshold consecutive checks failed.
+type Status struct {
+	Name                 string
+	Healthy              bool
+	Message              string
+	ConsecutiveFailures  int
+	ConsecutiveSuccesses int
+	LastCheck            time.Time
+}
+
+// runner periodically checks a probe & tracks its status with hysteresis.
+type runner struct {
+	cfg    Config
+	probe  Probe
+	status Status
+}
+
+func newRunner(cfg Config, probe Probe) *runner {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultFailureThreshold
+	}
+	if cfg.SuccessThreshold <= 0 {
+		cfg.SuccessThreshold = DefaultSuccessThreshold
+	}
+	return &runner{
+		cfg:    cfg,
+		probe:  probe,
+		status: Status{Name: cfg.Name, Healthy: true, Message: "not probed yet"},
+	}
+}
+
+// check runs a single check of the probe & returns its result, it does not
+// update the status.
+func (r *runner) check() error {
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.Timeout)
+	defer cancel()
+	start := time.Now()
+	err := r.probe.Check(ctx)
+	latency := time.Since(start)
+	if err == nil && r.cfg.MaxLatency > 0 && latency > r.cfg.MaxLatency {
+		err = fmt.Errorf("latency exceeds threshold: %s > %s", latency, r.cfg.MaxLatency)
+	}
+	return err
+}
+
+// update updates the status with the result of a check.
+func (r *runner) update(err error, now time.Time) {
+	s := &r.status
+	s.LastCheck = now
+	if err != nil {
+		s.ConsecutiveFailures++
+		s.ConsecutiveSuccesses = 0
+		s.Message = err.Error()
+		if s.Healthy && s.ConsecutiveFailures >= r.cfg.FailureThreshold {
+			glog.Warningf("Health probe %s is now unhealthy: %s", s.Name, err)
+			s.Healthy = false
+		}
+	} else {
+		s.ConsecutiveSuccesses++
+		s.ConsecutiveFailures = 0
+		s.Message = "ok"
+		if !s.Healthy && s.ConsecutiveSuccesses >= r.cfg.SuccessThreshold {
+			glog.Infof("Health probe %s is healthy again", s.Name)
+			s.Healthy = true
+		}
+	}
+	if s.Healthy {
+		metrics.HealthProbeHealthy.WithLabelValues(s.Name).Set(1)
+	} else {
+		metrics.HealthProbeHealthy.WithLabelValues(s.Name).Set(0)
+	}
+}
+
+// ProbeSet implements the Prober interface by running each of its probes
+// at its own interval.
+type ProbeSet struct {
+	runners      []*runner
+	sync.RWMutex // R/W lock synchronizing runner status access
+	stop         chan struct{}
+	wg           sync.WaitGroup
+}
+
+// NewProbeSet creates an empty ProbeSet.
+func NewProbeSet() *ProbeSet {
+	return &ProbeSet{}
+}
+
+// Add adds a probe to the set, it must be called before Start.
+func (p *ProbeSet) Add(cfg Config, probe Probe) {
+	p.Lock()
+	defer p.Unlock()
+	p.runners = append(p.runners, newRunner(cfg, probe))
+}
+
+// Start begins the periodic probes of the set.
+func (p *ProbeSet) Start() error {
+	p.Lock()
+	defer p.Unlock()
+	if p.stop != nil {
+		return fmt.Errorf("probes are already running")
+	}
+	p.stop = make(chan struct{})
+	for _, r := range p.runners {
+		p.wg.Add(1)
+		go p.run(r, p.stop)
+	}
+	return nil
+}
+
+// Stop stops the periodic probes of the set & waits for the running checks.
+func (p *ProbeSet) Stop() {
+	p.Lock()
+	stop := p.stop
+	p.stop = nil
+	p.Unlock()
+	if stop != nil {
+		close(stop)
+		p.wg.Wait()
+	}
+}
+
+// GetStatus returns a copy of the current status of each probe.
+func (p *ProbeSet) GetStatus() []*Status {
+	p.RLock()
+	defer p.RUnlock()
+	ret := make([]*Status, 0, len(p.runners))
+	for _, r := range p.runners {
+		status := r.status
+		ret = append(ret, &status)
+	}
+	return ret
+}
+
+func (p *ProbeSet) run(r *runner, stop chan struct{}) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		err := r.check()
+		p.Lock()
+		r.update(err, time.Now())
+		p.Unlock()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"magma/cwf/cloud/go/protos/mconfig"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type funcProbe func(ctx context.Context) error
+
+func (f funcProbe) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestRunner_Hysteresis(t *testing.T) {
+	r := newRunner(Config{Name: "test", FailureThreshold: 2, SuccessThreshold: 3}, nil)
+	assert.True(t, r.status.Healthy)
+
+	failure := errors.New("failure")
+	now := time.Now()
+	r.update(failure, now)
+	assert.True(t, r.status.Healthy)
+	assert.Equal(t, 1, r.status.ConsecutiveFailures)
+	assert.Equal(t, "failure", r.status.Message)
+	assert.Equal(t, now, r.status.LastCheck)
+
+	// A success resets the failure count
+	r.update(nil, now)
+	r.update(failure, now)
+	assert.True(t, r.status.Healthy)
+	r.update(failure, now)
+	assert.False(t, r.status.Healthy)
+	assert.Equal(t, 2, r.status.ConsecutiveFailures)
+
+	r.update(nil, now)
+	r.update(nil, now)
+	assert.False(t, r.status.Healthy)
+	assert.Equal(t, "ok", r.status.Message)
+	r.update(failure, now)
+	r.update(nil, now)
+	r.update(nil, now)
+	assert.False(t, r.status.Healthy)
+	r.update(nil, now)
+	assert.True(t, r.status.Healthy)
+	assert.Equal(t, 3, r.status.ConsecutiveSuccesses)
+	assert.Equal(t, 0, r.status.ConsecutiveFailures)
+}
+
+func TestRunner_Check(t *testing.T) {
+	slow := funcProbe(func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	r := newRunner(Config{Name: "slow", MaxLatency: 10 * time.Millisecond}, slow)
+	assert.Error(t, r.check())
+	r = newRunner(Config{Name: "slow", MaxLatency: time.Second}, slow)
+	assert.NoError(t, r.check())
+
+	blocked := funcProbe(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	r = newRunner(Config{Name: "blocked", Timeout: 10 * time.Millisecond}, blocked)
+	assert.Equal(t, context.DeadlineExceeded, r.check())
+}
+
+func TestProbeSet(t *testing.T) {
+	var healthy int32 = 1
+	set := NewProbeSet()
+	set.Add(Config{Name: "ok", Interval: 5 * time.Millisecond}, funcProbe(func(ctx context.Context) error {
+		return nil
+	}))
+	set.Add(Config{Name: "flaky", Interval: 5 * time.Millisecond, FailureThreshold: 1, SuccessThreshold: 1},
+		funcProbe(func(ctx context.Context) error {
+			if atomic.LoadInt32(&healthy) == 1 {
+				return nil
+			}
+			return errors.New("down")
+		}))
+	assert.NoError(t, set.Start())
+	assert.Error(t, set.Start())
+	defer set.Stop()
+
+	atomic.StoreInt32(&healthy, 0)
+	assert.Eventually(t, func() bool {
+		status := set.GetStatus()
+		return status[0].Healthy && !status[1].Healthy
+	}, time.Second, 5*time.Millisecond)
+	status := set.GetStatus()
+	assert.Equal(t, "ok", status[0].Name)
+	assert.Equal(t, "flaky", status[1].Name)
+	assert.Equal(t, "down", status[1].Message)
+
+	atomic.StoreInt32(&healthy, 1)
+	assert.Eventually(t, func() bool {
+		return set.GetStatus()[1].Healthy
+	}, time.Second, 5*time.Millisecond)
+
+	set.Stop()
+	// Stopped probes may be started again
+	assert.NoError(t, set.Start())
+}
+
+func TestNewProbeSetFromConfig(t *testing.T) {
+	set, err := NewProbeSetFromConfig([]*mconfig.CwfGatewayHealthConfigHealthProbe{
+		{
+			Name:   "aaa",
+			Type:   mconfig.CwfGatewayHealthConfigHealthProbe_RADIUS_STATUS_SERVER,
+			Target: "127.0.0.1:1812",
+			Secret: "123456",
+		},
+		{
+			Name:             "hss",
+			Type:             mconfig.CwfGatewayHealthConfigHealthProbe_DIAMETER_WATCHDOG,
+			Target:           "127.0.0.1:3868",
+			IntervalSecs:     5,
+			TimeoutMs:        500,
+			MaxLatencyMs:     200,
+			FailureThreshold: 4,
+			SuccessThreshold: 1,
+		},
+		{
+			Name:           "portal",
+			Type:           mconfig.CwfGatewayHealthConfigHealthProbe_HTTP,
+			Target:         "http://127.0.0.1/portal",
+			ExpectedStatus: 302,
+		},
+		{
+			Name:   "pcrf",
+			Type:   mconfig.CwfGatewayHealthConfigHealthProbe_FEG_SERVICE,
+			Target: "session_proxy",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, set.runners, 4)
+	assert.IsType(t, &RadiusProbe{}, set.runners[0].probe)
+	assert.Equal(t, Config{
+		Name:             "aaa",
+		Interval:         DefaultInterval,
+		Timeout:          DefaultTimeout,
+		FailureThreshold: DefaultFailureThreshold,
+		SuccessThreshold: DefaultSuccessThreshold,
+	}, set.runners[0].cfg)
+	assert.IsType(t, &DiameterWatchdogProbe{}, set.runners[1].probe)
+	assert.Equal(t, Config{
+		Name:             "hss",
+		Interval:         5 * time.Second,
+		Timeout:          500 * time.Millisecond,
+		MaxLatency:       200 * time.Millisecond,
+		FailureThreshold: 4,
+		SuccessThreshold: 1,
+	}, set.runners[1].cfg)
+	httpProbe := set.runners[2].probe.(*HTTPProbe)
+	assert.Equal(t, "http://127.0.0.1/portal", httpProbe.URL)
+	assert.Equal(t, 302, httpProbe.ExpectedStatus)
+	assert.Equal(t, "session_proxy", set.runners[3].probe.(*FegServiceProbe).Service)
+
+	_, err = NewProbeSetFromConfig([]*mconfig.CwfGatewayHealthConfigHealthProbe{
+		{Name: "aaa", Type: mconfig.CwfGatewayHealthConfigHealthProbe_HTTP, Target: "http://127.0.0.1"},
+		{Name: "aaa", Type: mconfig.CwfGatewayHealthConfigHealthProbe_HTTP, Target: "http://127.0.0.1"},
+	})
+	assert.EqualError(t, err, "duplicate health probe name: aaa")
+	_, err = NewProbeSetFromConfig([]*mconfig.CwfGatewayHealthConfigHealthProbe{
+		{Name: "aaa", Type: mconfig.CwfGatewayHealthConfigHealthProbe_RADIUS_STATUS_SERVER, Target: "127.0.0.1:1812"},
+	})
+	assert.EqualError(t, err, "secret of RADIUS health probe aaa must be set")
+	_, err = NewProbeSetFromConfig([]*mconfig.CwfGatewayHealthConfigHealthProbe{
+		{Name: "aaa", Type: mconfig.CwfGatewayHealthConfigHealthProbe_HTTP},
+	})
+	assert.EqualError(t, err, "target of health probe aaa must be set")
+}
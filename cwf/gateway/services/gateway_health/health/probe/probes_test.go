@@ -0,0 +1,159 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	orcprotos "magma/orc8r/lib/go/protos"
+
+	"fbc/lib/go/radius"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRadiusProbe(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	responseCode := radius.CodeAccessAccept
+	server := &radius.PacketServer{
+		SecretSource: radius.StaticSecretSource([]byte("123456")),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			if r.Code == radius.CodeStatusServer {
+				w.Write(r.Response(responseCode))
+			}
+		}),
+	}
+	go server.Serve(conn)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(t, server.Shutdown(ctx))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	probe := NewRadiusProbe(conn.LocalAddr().String(), []byte("123456"))
+	assert.NoError(t, probe.Check(ctx))
+
+	responseCode = radius.CodeAccessReject
+	assert.Error(t, probe.Check(ctx))
+
+	// Responses authenticated with another secret are dropped
+	responseCode = radius.CodeAccessAccept
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Error(t, NewRadiusProbe(conn.LocalAddr().String(), []byte("654321")).Check(ctx))
+}
+
+func TestDiameterWatchdogProbe(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer lis.Close()
+	mux := sm.New(&sm.Settings{
+		OriginHost:       datatype.DiameterIdentity("pcrf.magma.com"),
+		OriginRealm:      datatype.DiameterIdentity("magma.com"),
+		VendorID:         datatype.Unsigned32(vendor3GPP),
+		ProductName:      datatype.UTF8String("pcrf"),
+		FirmwareRevision: 1,
+	})
+	go (&diam.Server{Handler: mux}).Serve(lis)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	probe := NewDiameterWatchdogProbe(lis.Addr().String(), "", "")
+	assert.Equal(t, DefaultDiameterOriginRealm, probe.OriginRealm)
+	assert.NoError(t, probe.Check(ctx))
+
+	// Peer without a Diameter server
+	lis2, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() {
+		conn, err := lis2.Accept()
+		if err == nil {
+			conn.Close()
+		}
+	}()
+	defer lis2.Close()
+	assert.Error(t, NewDiameterWatchdogProbe(lis2.Addr().String(), "cwag", "magma.com").Check(ctx))
+}
+
+func TestHTTPProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/portal":
+			http.Redirect(w, r, "/login", http.StatusFound)
+		case "/login":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	assert.NoError(t, NewHTTPProbe(server.URL+"/login", 0).Check(ctx))
+	assert.NoError(t, NewHTTPProbe(server.URL+"/portal", 0).Check(ctx))
+	assert.NoError(t, NewHTTPProbe(server.URL+"/portal", http.StatusFound).Check(ctx))
+	// Redirects are not followed
+	assert.EqualError(t, NewHTTPProbe(server.URL+"/portal", http.StatusOK).Check(ctx),
+		"HTTP GET "+server.URL+"/portal returned status 302, expected 200")
+	assert.EqualError(t, NewHTTPProbe(server.URL+"/down", 0).Check(ctx),
+		"HTTP GET "+server.URL+"/down returned status 503")
+}
+
+type fakeServiceHealthClient struct {
+	status *protos.HealthStatus
+	err    error
+}
+
+func (c *fakeServiceHealthClient) Disable(ctx context.Context, in *protos.DisableMessage, opts ...grpc.CallOption) (*orcprotos.Void, error) {
+	return &orcprotos.Void{}, nil
+}
+
+func (c *fakeServiceHealthClient) Enable(ctx context.Context, in *orcprotos.Void, opts ...grpc.CallOption) (*orcprotos.Void, error) {
+	return &orcprotos.Void{}, nil
+}
+
+func (c *fakeServiceHealthClient) GetHealthStatus(ctx context.Context, in *orcprotos.Void, opts ...grpc.CallOption) (*protos.HealthStatus, error) {
+	return c.status, c.err
+}
+
+func TestFegServiceProbe(t *testing.T) {
+	client := &fakeServiceHealthClient{status: &protos.HealthStatus{Health: protos.HealthStatus_HEALTHY}}
+	probe := NewFegServiceProbe("swx_proxy")
+	probe.getClient = func(service string) (protos.ServiceHealthClient, error) {
+		assert.Equal(t, "swx_proxy", service)
+		return client, nil
+	}
+	ctx := context.Background()
+	assert.NoError(t, probe.Check(ctx))
+
+	client.status = &protos.HealthStatus{Health: protos.HealthStatus_UNHEALTHY, HealthMessage: "HSS timeouts"}
+	assert.EqualError(t, probe.Check(ctx), "FeG service swx_proxy is unhealthy: HSS timeouts")
+
+	client.err = errors.New("unavailable")
+	assert.EqualError(t, probe.Check(ctx), "error getting health of FeG service swx_proxy: unavailable")
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"context"
+	"fmt"
+
+	"fbc/lib/go/radius"
+)
+
+const messageAuthenticatorType radius.Type = 80
+
+// RadiusProbe checks a RADIUS server with Status-Server requests (RFC 5997).
+type RadiusProbe struct {
+	Addr   string
+	Secret []byte
+	client *radius.Client
+}
+
+// NewRadiusProbe creates a Status-Server probe of the RADIUS server at addr.
+func NewRadiusProbe(addr string, secret []byte) *RadiusProbe {
+	return &RadiusProbe{Addr: addr, Secret: secret, client: &radius.Client{}}
+}
+
+// Check sends a Status-Server request & expects an Access-Accept, or an
+// Accounting-Response from accounting servers.
+func (p *RadiusProbe) Check(ctx context.Context) error {
+	packet := radius.New(radius.CodeStatusServer, p.Secret)
+	// Status-Server requests must contain a Message-Authenticator, computed on exchange
+	packet.Set(messageAuthenticatorType, make(radius.Attribute, 16))
+	response, err := p.client.Exchange(ctx, packet, p.Addr)
+	if err != nil {
+		return fmt.Errorf("RADIUS Status-Server to %s failed: %s", p.Addr, err)
+	}
+	if response.Code != radius.CodeAccessAccept && response.Code != radius.CodeAccountingResponse {
+		return fmt.Errorf("unexpected RADIUS Status-Server response from %s: %s", p.Addr, response.Code)
+	}
+	return nil
+}
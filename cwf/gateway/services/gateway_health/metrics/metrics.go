@@ -23,8 +23,15 @@ var (
 		},
 		[]string{"ip_addr"},
 	)
+	HealthProbeHealthy = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "health_probe_healthy",
+			Help: "Dependency of the gateway healthy according to its health probe",
+		},
+		[]string{"probe"},
+	)
 )
 
 func init() {
-	prometheus.MustRegister(GreEndpointReachable)
+	prometheus.MustRegister(GreEndpointReachable, HealthProbeHealthy)
 }
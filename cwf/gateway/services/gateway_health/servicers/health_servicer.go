@@ -16,10 +16,12 @@ package servicers
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"magma/cwf/cloud/go/protos/mconfig"
 	"magma/cwf/gateway/services/gateway_health/events"
 	"magma/cwf/gateway/services/gateway_health/health/gre_probe"
+	"magma/cwf/gateway/services/gateway_health/health/probe"
 	"magma/cwf/gateway/services/gateway_health/health/service_health"
 	"magma/cwf/gateway/services/gateway_health/health/system_health"
 	"magma/feg/cloud/go/protos"
@@ -42,6 +44,7 @@ type gatewayState string
 type GatewayHealthServicer struct {
 	config        *mconfig.CwfGatewayHealthConfig
 	greProbe      gre_probe.GREProbe
+	prober        probe.Prober
 	serviceHealth service_health.ServiceHealth
 	systemHealth  system_health.SystemHealth
 	currentState  gatewayState
@@ -51,12 +54,14 @@ type GatewayHealthServicer struct {
 func NewGatewayHealthServicer(
 	cfg *mconfig.CwfGatewayHealthConfig,
 	greProbe gre_probe.GREProbe,
+	prober probe.Prober,
 	serviceHealth service_health.ServiceHealth,
 	systemHealth system_health.SystemHealth,
 ) *GatewayHealthServicer {
 	return &GatewayHealthServicer{
 		config:        cfg,
 		greProbe:      greProbe,
+		prober:        prober,
 		systemHealth:  systemHealth,
 		serviceHealth: serviceHealth,
 		currentState:  "",
@@ -126,8 +131,9 @@ func (s *GatewayHealthServicer) Enable(ctx context.Context, req *orcprotos.Void)
 func (s *GatewayHealthServicer) GetHealthStatus(ctx context.Context, req *orcprotos.Void) (*protos.HealthStatus, error) {
 	greHealth := s.getGREHealth()
 	systemHealth := s.getSystemHealth()
+	probeHealth := s.getProbeHealth()
 	serviceHealth := s.getServiceHealth()
-	return s.composeAggregateHealth(greHealth, systemHealth, serviceHealth), nil
+	return s.composeAggregateHealth(greHealth, systemHealth, probeHealth, serviceHealth), nil
 
 }
 
@@ -176,6 +182,26 @@ func (s *GatewayHealthServicer) getSystemHealth() *protos.HealthStatus {
 	}
 }
 
+func (s *GatewayHealthServicer) getProbeHealth() *protos.HealthStatus {
+	var unhealthyProbes []string
+	for _, status := range s.prober.GetStatus() {
+		glog.V(1).Infof("health probe %s: healthy: %t, last result: %s", status.Name, status.Healthy, status.Message)
+		if !status.Healthy {
+			unhealthyProbes = append(unhealthyProbes, fmt.Sprintf("%s (%s)", status.Name, status.Message))
+		}
+	}
+	if len(unhealthyProbes) > 0 {
+		return &protos.HealthStatus{
+			Health:        protos.HealthStatus_UNHEALTHY,
+			HealthMessage: fmt.Sprintf("The following probes were unhealthy: %s", strings.Join(unhealthyProbes, ", ")),
+		}
+	}
+	return &protos.HealthStatus{
+		Health:        protos.HealthStatus_HEALTHY,
+		HealthMessage: "All probes appear healthy",
+	}
+}
+
 func (s *GatewayHealthServicer) getServiceHealth() *protos.HealthStatus {
 	unhealthyServices, err := s.serviceHealth.GetUnhealthyServices()
 	if err != nil {
@@ -201,10 +227,11 @@ func (s *GatewayHealthServicer) getServiceHealth() *protos.HealthStatus {
 func (s *GatewayHealthServicer) composeAggregateHealth(
 	greHealth *protos.HealthStatus,
 	systemHealth *protos.HealthStatus,
+	probeHealth *protos.HealthStatus,
 	serviceHealth *protos.HealthStatus,
 ) *protos.HealthStatus {
 	isGatewayHealthy := greHealth.Health == protos.HealthStatus_HEALTHY && serviceHealth.Health == protos.HealthStatus_HEALTHY &&
-		systemHealth.Health == protos.HealthStatus_HEALTHY
+		systemHealth.Health == protos.HealthStatus_HEALTHY && probeHealth.Health == protos.HealthStatus_HEALTHY
 	if isGatewayHealthy {
 		return &protos.HealthStatus{
 			Health:        protos.HealthStatus_HEALTHY,
@@ -218,6 +245,9 @@ func (s *GatewayHealthServicer) composeAggregateHealth(
 	if systemHealth.Health == protos.HealthStatus_UNHEALTHY {
 		healthMsg = fmt.Sprintf("%sSystem status: %s; ", healthMsg, systemHealth.HealthMessage)
 	}
+	if probeHealth.Health == protos.HealthStatus_UNHEALTHY {
+		healthMsg = fmt.Sprintf("%sProbe status: %s; ", healthMsg, probeHealth.HealthMessage)
+	}
 	if serviceHealth.Health == protos.HealthStatus_UNHEALTHY {
 		healthMsg = fmt.Sprintf("%sService status: %s", healthMsg, serviceHealth.HealthMessage)
 	}
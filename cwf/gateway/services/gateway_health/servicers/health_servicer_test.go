@@ -19,6 +19,7 @@ import (
 
 	"magma/cwf/cloud/go/protos/mconfig"
 	"magma/cwf/gateway/services/gateway_health/health/gre_probe"
+	"magma/cwf/gateway/services/gateway_health/health/probe"
 	"magma/cwf/gateway/services/gateway_health/health/system_health"
 	"magma/feg/cloud/go/protos"
 	orc8rprotos "magma/orc8r/lib/go/protos"
@@ -31,6 +32,7 @@ func TestGetHealthStatus(t *testing.T) {
 	mockService := &mockServiceHealth{}
 	mockSystem := &mockSystemHealth{}
 	mockGREProbe := &mockGREProbe{}
+	mockProber := &mockProber{}
 	req := &orc8rprotos.Void{}
 	hc := &mconfig.CwfGatewayHealthConfig{
 		GrePeers: []*mconfig.CwfGatewayHealthConfigGrePeer{
@@ -47,19 +49,28 @@ func TestGetHealthStatus(t *testing.T) {
 		Reachable:   []string{},
 		Unreachable: []string{"127.0.0.1"},
 	}
-	servicer := NewGatewayHealthServicer(hc, mockGREProbe, mockService, mockSystem)
+	healthyProbes := []*probe.Status{
+		{Name: "aaa", Healthy: true, Message: "ok"},
+		{Name: "portal", Healthy: true, Message: "ok"},
+	}
+	unhealthyProbes := []*probe.Status{
+		{Name: "aaa", Healthy: false, Message: "RADIUS Status-Server to 10.0.0.1:1812 failed: timeout"},
+		{Name: "portal", Healthy: true, Message: "ok"},
+	}
+	servicer := NewGatewayHealthServicer(hc, mockGREProbe, mockProber, mockService, mockSystem)
 	expectedStatus := &protos.HealthStatus{
 		Health:        protos.HealthStatus_HEALTHY,
 		HealthMessage: "gateway status appears healthy",
 	}
 	// Simulate healthy status
 	mockGREProbe.On("GetStatus").Return(healthyGRE).Once()
+	mockProber.On("GetStatus").Return(healthyProbes).Once()
 	mockSystem.On("GetSystemStats").Return(&system_health.SystemStats{CpuUtilPct: 0.1, MemUtilPct: 0.1}, nil).Once()
 	mockService.On("GetUnhealthyServices").Return([]string{}, nil).Once()
 	health, err := servicer.GetHealthStatus(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedStatus, health)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 
 	// Simulate successful enable
 	mockSystem.On("Enable").Return(nil)
@@ -67,24 +78,25 @@ func TestGetHealthStatus(t *testing.T) {
 	mockService.On("Restart", "sessiond").Return(nil)
 	_, err = servicer.Enable(context.Background(), req)
 	assert.NoError(t, err)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 
 	// Subsequent Enable should be a no-op
 	mockSystem.On("Enable").Return(nil)
 	_, err = servicer.Enable(context.Background(), req)
 	assert.NoError(t, err)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 
 	// Simulate GRE unhealthy
 	expectedStatus.Health = protos.HealthStatus_UNHEALTHY
 	expectedStatus.HealthMessage = "GRE status: All GRE peers are detected as unreachable; unreachable: [127.0.0.1]; "
 	mockGREProbe.On("GetStatus").Return(unhealthyGRE).Once()
+	mockProber.On("GetStatus").Return(healthyProbes).Once()
 	mockSystem.On("GetSystemStats").Return(&system_health.SystemStats{CpuUtilPct: 0.1, MemUtilPct: 0.1}, nil).Once()
 	mockService.On("GetUnhealthyServices").Return([]string{}, nil).Once()
 	health, err = servicer.GetHealthStatus(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedStatus, health)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 
 	// Simulate successful disable
 	disableReq := &protos.DisableMessage{}
@@ -92,16 +104,17 @@ func TestGetHealthStatus(t *testing.T) {
 	mockService.On("Restart", "aaa_server").Return(nil)
 	_, err = servicer.Disable(context.Background(), disableReq)
 	assert.NoError(t, err)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 
 	// Subsequent disable should be a no-op
 	mockSystem.On("Disable").Return(nil)
 	_, err = servicer.Disable(context.Background(), disableReq)
 	assert.NoError(t, err)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 
 	// Simulate unhealthy system status
 	mockGREProbe.On("GetStatus").Return(healthyGRE).Once()
+	mockProber.On("GetStatus").Return(healthyProbes).Once()
 	mockSystem.On("GetSystemStats").Return(&system_health.SystemStats{CpuUtilPct: 0.99, MemUtilPct: 0.5}, nil).Once()
 	mockService.On("GetUnhealthyServices").Return([]string{}, nil).Once()
 	expectedStatus.Health = protos.HealthStatus_UNHEALTHY
@@ -109,10 +122,11 @@ func TestGetHealthStatus(t *testing.T) {
 	health, err = servicer.GetHealthStatus(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedStatus, health)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 
 	// Simulate unhealthy services and GRE
 	mockGREProbe.On("GetStatus").Return(unhealthyGRE).Once()
+	mockProber.On("GetStatus").Return(healthyProbes).Once()
 	mockSystem.On("GetSystemStats").Return(&system_health.SystemStats{CpuUtilPct: 0.1, MemUtilPct: 0.1}, nil).Once()
 	mockService.On("GetUnhealthyServices").Return([]string{"sessiond"}, nil).Once()
 	expectedStatus.Health = protos.HealthStatus_UNHEALTHY
@@ -120,11 +134,24 @@ func TestGetHealthStatus(t *testing.T) {
 	health, err = servicer.GetHealthStatus(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedStatus, health)
-	assertMocks(t, mockGREProbe, mockSystem, mockService)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
+
+	// Simulate unhealthy probe and services
+	mockGREProbe.On("GetStatus").Return(healthyGRE).Once()
+	mockProber.On("GetStatus").Return(unhealthyProbes).Once()
+	mockSystem.On("GetSystemStats").Return(&system_health.SystemStats{CpuUtilPct: 0.1, MemUtilPct: 0.1}, nil).Once()
+	mockService.On("GetUnhealthyServices").Return([]string{"sessiond"}, nil).Once()
+	expectedStatus.Health = protos.HealthStatus_UNHEALTHY
+	expectedStatus.HealthMessage = "Probe status: The following probes were unhealthy: aaa (RADIUS Status-Server to 10.0.0.1:1812 failed: timeout); Service status: The following services were unhealthy: [sessiond]"
+	health, err = servicer.GetHealthStatus(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedStatus, health)
+	assertMocks(t, mockGREProbe, mockProber, mockSystem, mockService)
 }
 
-func assertMocks(t *testing.T, probe *mockGREProbe, systemHealth *mockSystemHealth, serviceHealth *mockServiceHealth) {
-	probe.AssertExpectations(t)
+func assertMocks(t *testing.T, greProbe *mockGREProbe, prober *mockProber, systemHealth *mockSystemHealth, serviceHealth *mockServiceHealth) {
+	greProbe.AssertExpectations(t)
+	prober.AssertExpectations(t)
 	systemHealth.AssertExpectations(t)
 	serviceHealth.AssertExpectations(t)
 }
@@ -184,3 +211,21 @@ func (m *mockGREProbe) GetStatus() *gre_probe.GREProbeStatus {
 	args := m.Called()
 	return args.Get(0).(*gre_probe.GREProbeStatus)
 }
+
+type mockProber struct {
+	mock.Mock
+}
+
+func (m *mockProber) Start() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *mockProber) Stop() {
+	_ = m.Called()
+}
+
+func (m *mockProber) GetStatus() []*probe.Status {
+	args := m.Called()
+	return args.Get(0).([]*probe.Status)
+}
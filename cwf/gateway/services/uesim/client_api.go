@@ -23,6 +23,7 @@ import (
 
 	cwfprotos "magma/cwf/cloud/go/protos"
 	"magma/cwf/gateway/registry"
+	orcprotos "magma/orc8r/lib/go/protos"
 
 	"github.com/golang/glog"
 	"google.golang.org/grpc"
@@ -92,3 +93,32 @@ func GenTraffic(req *cwfprotos.GenTrafficRequest) (*cwfprotos.GenTrafficResponse
 	resp, err := cli.GenTraffic(context.Background(), req)
 	return resp, err
 }
+
+// StartLoad starts attaching & detaching a range of UEs in the background.
+// Input: The UE range, attach rate, session hold time and disconnect pattern.
+func StartLoad(req *cwfprotos.LoadRequest) error {
+	cli, err := getUESimClient()
+	if err != nil {
+		return err
+	}
+	_, err = cli.StartLoad(context.Background(), req)
+	return err
+}
+
+// GetLoadReport returns the per step statistics of the current or last load run.
+func GetLoadReport() (*cwfprotos.LoadReport, error) {
+	cli, err := getUESimClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.GetLoadReport(context.Background(), &orcprotos.Void{})
+}
+
+// StopLoad stops the current load run and returns its statistics.
+func StopLoad() (*cwfprotos.LoadReport, error) {
+	cli, err := getUESimClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.StopLoad(context.Background(), &orcprotos.Void{})
+}
@@ -14,9 +14,13 @@
 package servicers
 
 import (
+	"sync"
+
 	cwfprotos "magma/cwf/cloud/go/protos"
 	fegprotos "magma/feg/gateway/services/aaa/protos"
 	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers/aka"
+	"magma/feg/gateway/services/eap/providers/sim"
 
 	"github.com/pkg/errors"
 )
@@ -26,6 +30,86 @@ const (
 	IdentityPostfix = "@wlan.mnc001.mcc001.3gppnetwork.org"
 )
 
+// maxEapRounds is the maximum number of EAP requests answered in a single
+// authentication.
+const maxEapRounds = 8
+
+// Permanent identity prefixes of the EAP methods (RFC 4186, 4187 & 5448)
+const (
+	akaIdentityPrefix      = "0"
+	simIdentityPrefix      = "1"
+	akaPrimeIdentityPrefix = "6"
+)
+
+// eapSession is the client state of the ongoing EAP authentication of a UE.
+type eapSession struct {
+	// EAP-SIM NONCE_MT & negotiated versions
+	nonce           []byte
+	versionList     []byte
+	selectedVersion []byte
+	// K_aut of the last verified challenge, used to protect notifications
+	kAut []byte
+	// Whether the UE already reported a synchronization failure
+	resynced bool
+}
+
+// eapSessions holds the EAP sessions of the UEs keyed by IMSI.
+type eapSessions struct {
+	sync.Mutex
+	sessions map[string]*eapSession
+}
+
+// reset starts a new EAP session for the UE.
+func (s *eapSessions) reset(imsi string) *eapSession {
+	s.Lock()
+	defer s.Unlock()
+	if s.sessions == nil {
+		s.sessions = map[string]*eapSession{}
+	}
+	session := &eapSession{}
+	s.sessions[imsi] = session
+	return session
+}
+
+// get returns the EAP session of the UE, starting one if needed.
+func (s *eapSessions) get(imsi string) *eapSession {
+	s.Lock()
+	defer s.Unlock()
+	if s.sessions == nil {
+		s.sessions = map[string]*eapSession{}
+	}
+	session, ok := s.sessions[imsi]
+	if !ok {
+		session = &eapSession{}
+		s.sessions[imsi] = session
+	}
+	return session
+}
+
+// getEapIdentity returns the permanent identity of the UE for the EAP type.
+func getEapIdentity(ue *cwfprotos.UEConfig, eapType fegprotos.EapType) string {
+	prefix := akaIdentityPrefix
+	switch eapType {
+	case fegprotos.EapType_SIM:
+		prefix = simIdentityPrefix
+	case eapTypeAkaPrime:
+		prefix = akaPrimeIdentityPrefix
+	}
+	return prefix + ue.GetImsi() + IdentityPostfix
+}
+
+// getMethodEapType returns the EAP type of the method configured for the UE.
+func getMethodEapType(ue *cwfprotos.UEConfig) fegprotos.EapType {
+	switch ue.GetEapMethod() {
+	case cwfprotos.EapMethod_EAP_SIM:
+		return fegprotos.EapType_SIM
+	case cwfprotos.EapMethod_EAP_AKA_PRIME:
+		return eapTypeAkaPrime
+	default:
+		return fegprotos.EapType_AKA
+	}
+}
+
 // HandleEAP routes the EAP request to the UE with the specified imsi.
 func (srv *UESimServer) HandleEap(ue *cwfprotos.UEConfig, req eap.Packet) (eap.Packet, error) {
 	err := req.Validate()
@@ -36,22 +120,90 @@ func (srv *UESimServer) HandleEap(ue *cwfprotos.UEConfig, req eap.Packet) (eap.P
 	switch fegprotos.EapType(req.Type()) {
 	case fegprotos.EapType_Identity:
 		return srv.eapIdentityRequest(ue, req)
-	case fegprotos.EapType_AKA:
+	case fegprotos.EapType_AKA, eapTypeAkaPrime:
 		return srv.handleEapAka(ue, req)
+	case fegprotos.EapType_SIM:
+		return srv.handleEapSim(ue, req)
 	}
 	return nil, errors.Errorf("Unsupported Eap Type: %d", req[eap.EapMsgMethodType])
 }
 
 func (srv *UESimServer) eapIdentityRequest(ue *cwfprotos.UEConfig, req eap.Packet) (res eap.Packet, err error) {
+	// An identity request starts a new authentication
+	srv.eapSessions.reset(ue.GetImsi())
+
 	// Create the response EAP packet with the identity attribute.
 	p := eap.NewPacket(
 		eap.ResponseCode,
 		req.Identifier(),
 		append(
 			[]byte{uint8(fegprotos.EapType_Identity)},
-			[]byte("\x30"+ue.GetImsi()+IdentityPostfix)...,
+			[]byte(getEapIdentity(ue, getMethodEapType(ue)))...,
 		),
 	)
 
 	return p, nil
 }
+
+// eapNotificationRequest acknowledges an EAP-SIM, EAP-AKA or EAP-AKA'
+// notification, protecting the response with the K_aut of the session if the
+// notification was sent after a successful challenge.
+func (srv *UESimServer) eapNotificationRequest(ue *cwfprotos.UEConfig, req eap.Packet) (eap.Packet, error) {
+	var notification []byte
+	scanner, err := eap.NewAttributeScanner(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating new attribute scanner")
+	}
+	var a eap.Attribute
+	for a, err = scanner.Next(); err == nil; a, err = scanner.Next() {
+		if a.Type() == aka.AT_NOTIFICATION {
+			notification = a.Value()
+		}
+	}
+	if len(notification) < 2 {
+		return nil, errors.New("Missing AT_NOTIFICATION in notification request")
+	}
+
+	eapType := fegprotos.EapType(req.Type())
+	p := eap.NewPacket(eap.ResponseCode, req.Identifier(), []byte{byte(eapType), byte(aka.SubtypeNotification), 0, 0})
+	// The P bit is not set for notifications sent after the challenge
+	kAut := srv.eapSessions.get(ue.GetImsi()).kAut
+	if notification[0]&0x40 != 0 || kAut == nil {
+		return p, nil
+	}
+	atMacOffset := len(p) + aka.ATT_HDR_LEN
+	p, err = p.Append(eap.NewAttribute(aka.AT_MAC, make([]byte, 2+aka.MAC_LEN)))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error appending attribute to packet")
+	}
+	var mac []byte
+	if eapType == fegprotos.EapType_SIM {
+		mac = sim.GenMac(p, nil, kAut)
+	} else {
+		mac = genAkaMac(eapType, p, kAut)
+	}
+	copy(p[atMacOffset:], mac)
+	return p, nil
+}
+
+// isEapChallengeResponse returns whether the packet is the UE's response to
+// the EAP-SIM, EAP-AKA or EAP-AKA' challenge, which completes the authentication.
+func isEapChallengeResponse(p eap.Packet) bool {
+	if len(p) <= eap.EapSubtype || p.Code() != eap.ResponseCode {
+		return false
+	}
+	switch fegprotos.EapType(p.Type()) {
+	case fegprotos.EapType_AKA, eapTypeAkaPrime:
+		return aka.Subtype(p[eap.EapSubtype]) == aka.SubtypeChallenge
+	case fegprotos.EapType_SIM:
+		return sim.Subtype(p[eap.EapSubtype]) == sim.SubtypeChallenge
+	}
+	return false
+}
+
+// corruptMac flips the bits of the MAC to make the server reject it.
+func corruptMac(mac []byte) {
+	for i := range mac {
+		mac[i] ^= 0xff
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"reflect"
 
 	"magma/cwf/cloud/go/protos"
+	fegprotos "magma/feg/gateway/services/aaa/protos"
 	"magma/feg/gateway/services/eap"
 	"magma/feg/gateway/services/eap/providers/aka"
 	"magma/lte/cloud/go/crypto"
@@ -46,19 +47,21 @@ const (
 	maxSeqDelta = 1 << 28
 )
 
-// handleEapAka routes the EAP-AKA request to the UE with the specified imsi.
+// handleEapAka routes the EAP-AKA or EAP-AKA' request to the UE with the specified imsi.
 func (srv *UESimServer) handleEapAka(ue *protos.UEConfig, req eap.Packet) (eap.Packet, error) {
 	switch aka.Subtype(req[eap.EapSubtype]) {
 	case aka.SubtypeIdentity:
 		return srv.eapAkaIdentityRequest(ue, req)
 	case aka.SubtypeChallenge:
 		return srv.eapAkaChallengeRequest(ue, req)
+	case aka.SubtypeNotification:
+		return srv.eapNotificationRequest(ue, req)
 	default:
 		return nil, errors.Errorf("Unsupported Subtype: %d", req[eap.EapSubtype])
 	}
 }
 
-// Given a UE and the EAP-AKA identity request, generates the EAP response.
+// Given a UE and the EAP-AKA or EAP-AKA' identity request, generates the EAP response.
 func (srv *UESimServer) eapAkaIdentityRequest(ue *protos.UEConfig, req eap.Packet) (eap.Packet, error) {
 	scanner, err := eap.NewAttributeScanner(req)
 	if err != nil {
@@ -75,11 +78,11 @@ func (srv *UESimServer) eapAkaIdentityRequest(ue *protos.UEConfig, req eap.Packe
 			p := eap.NewPacket(
 				eap.ResponseCode,
 				req.Identifier(),
-				[]byte{aka.TYPE, byte(aka.SubtypeIdentity), 0, 0},
+				[]byte{req.Type(), byte(aka.SubtypeIdentity), 0, 0},
 			)
 
 			// Append Identity Attribute data to packet.
-			id := []byte("\x30" + ue.GetImsi() + IdentityPostfix)
+			id := []byte(getEapIdentity(ue, fegprotos.EapType(req.Type())))
 			p, err = p.Append(
 				eap.NewAttribute(
 					aka.AT_IDENTITY,
@@ -104,10 +107,14 @@ type challengeAttributes struct {
 	rand eap.Attribute
 	autn eap.Attribute
 	mac  eap.Attribute
+	// EAP-AKA' only
+	kdfInput eap.Attribute
+	kdf      eap.Attribute
 }
 
-// Given a UE, the Op, the Amf, and the EAP challenge, generates the EAP response.
+// Given a UE, the Op, the Amf, and the EAP-AKA or EAP-AKA' challenge, generates the EAP response.
 func (srv *UESimServer) eapAkaChallengeRequest(ue *protos.UEConfig, req eap.Packet) (eap.Packet, error) {
+	eapType := fegprotos.EapType(req.Type())
 	attrs, err := parseChallengeAttributes(req)
 	if err != io.EOF {
 		return nil, errors.Wrap(err, "Error while parsing attributes of request packet")
@@ -115,13 +122,16 @@ func (srv *UESimServer) eapAkaChallengeRequest(ue *protos.UEConfig, req eap.Pack
 	if attrs.rand == nil || attrs.autn == nil || attrs.mac == nil {
 		return nil, errors.Errorf("Missing one or more expected attributes\nRAND: %s\nAUTN: %s\nMAC: %s\n", attrs.rand, attrs.autn, attrs.mac)
 	}
+	if eapType == eapTypeAkaPrime && (attrs.kdfInput == nil || attrs.kdf == nil) {
+		return nil, errors.Errorf("Missing one or more expected attributes\nKDF_INPUT: %s\nKDF: %s\n", attrs.kdfInput, attrs.kdf)
+	}
 
 	// Parse out RAND, expected AUTN, and expected MAC values.
 	rand := attrs.rand.Marshaled()[aka.ATT_HDR_LEN:]
 	expectedAutn := attrs.autn.Marshaled()[aka.ATT_HDR_LEN:]
 	expectedMac := attrs.mac.Marshaled()[aka.ATT_HDR_LEN:]
 
-	id := []byte("\x30" + ue.GetImsi() + IdentityPostfix)
+	id := []byte(getEapIdentity(ue, eapType))
 	key := []byte(ue.AuthKey)
 
 	// Calculate SQN using SEQ and arbitrary IND
@@ -158,8 +168,17 @@ func (srv *UESimServer) eapAkaChallengeRequest(ue *protos.UEConfig, req eap.Pack
 	}
 
 	// Calculate and verify MAC.
-	_, kAut, _, _ := aka.MakeAKAKeys(id, intermediateVec.IntegrityKey[:], intermediateVec.ConfidentialityKey[:])
-	mac := aka.GenMac(copyReq, kAut)
+	var kAut []byte
+	if eapType == eapTypeAkaPrime {
+		kAut, err = getAkaPrimeKaut(
+			id, intermediateVec.ConfidentialityKey[:], intermediateVec.IntegrityKey[:], expectedAutn, attrs)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, kAut, _, _ = aka.MakeAKAKeys(id, intermediateVec.IntegrityKey[:], intermediateVec.ConfidentialityKey[:])
+	}
+	mac := genAkaMac(eapType, copyReq, kAut)
 	if !reflect.DeepEqual(expectedMac, mac) {
 		return nil, fmt.Errorf("Invalid MAC: Expected MAC: %x; Actual MAC: %x", expectedMac, mac)
 	}
@@ -178,11 +197,13 @@ func (srv *UESimServer) eapAkaChallengeRequest(ue *protos.UEConfig, req eap.Pack
 	}
 	seq, _ := servicers.SplitSqn(receivedSqn)
 	isSeqValid := seq > ue.Seq && (seq-ue.GetSeq()) < maxSeqDelta
-	if !isSeqValid {
-		// TODO: Implement re-sync procedure
-		// For now just return the error
-		return nil, fmt.Errorf("Invalid SEQ received. HSS SEQ: %d, UE SEQ: %d", seq, ue.GetSeq())
+	session := srv.eapSessions.get(ue.GetImsi())
+	if !isSeqValid || (ue.GetAuthFault() == protos.AuthFault_SYNC_FAILURE && !session.resynced) {
+		glog.Infof("Sending Synchronization Failure for IMSI %s. HSS SEQ: %d, UE SEQ: %d", ue.GetImsi(), seq, ue.GetSeq())
+		session.resynced = true
+		return eapAkaSyncFailure(ue, req, milenage, key, opc[:], rand)
 	}
+	session.kAut = kAut
 
 	// Update UE SEQ number
 	ue.Seq = seq
@@ -192,7 +213,7 @@ func (srv *UESimServer) eapAkaChallengeRequest(ue *protos.UEConfig, req eap.Pack
 	}
 
 	// Create the response EAP packet.
-	p := eap.NewPacket(eap.ResponseCode, req.Identifier(), []byte{aka.TYPE, byte(aka.SubtypeChallenge), 0, 0})
+	p := eap.NewPacket(eap.ResponseCode, req.Identifier(), []byte{byte(eapType), byte(aka.SubtypeChallenge), 0, 0})
 
 	// Add the RES attribute.
 	p, err = p.Append(
@@ -209,12 +230,17 @@ func (srv *UESimServer) eapAkaChallengeRequest(ue *protos.UEConfig, req eap.Pack
 	}
 
 	// Add the CHECKCODE attribute.
-	p, err = p.Append(
-		eap.NewAttribute(
-			aka.AT_CHECKCODE,
-			[]byte(CheckcodeValue),
-		),
-	)
+	if eapType == fegprotos.EapType_AKA {
+		p, err = p.Append(
+			eap.NewAttribute(
+				aka.AT_CHECKCODE,
+				[]byte(CheckcodeValue),
+			),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error appending attribute to packet")
+		}
+	}
 
 	atMacOffset := len(p) + aka.ATT_HDR_LEN
 
@@ -230,12 +256,54 @@ func (srv *UESimServer) eapAkaChallengeRequest(ue *protos.UEConfig, req eap.Pack
 	}
 
 	// Calculate and Copy MAC into packet.
-	mac = aka.GenMac(p, kAut)
+	mac = genAkaMac(eapType, p, kAut)
+	if ue.GetAuthFault() == protos.AuthFault_BAD_MAC {
+		corruptMac(mac)
+	}
 	copy(p[atMacOffset:], mac)
 
 	return p, nil
 }
 
+// eapAkaSyncFailure generates the Synchronization Failure response carrying
+// the AUTS of the UE's current SEQ, so that the HSS can re-synchronize.
+func eapAkaSyncFailure(
+	ue *protos.UEConfig,
+	req eap.Packet,
+	milenage *crypto.MilenageCipher,
+	key, opc, rand []byte,
+) (eap.Packet, error) {
+	auts, err := milenage.GenerateAuts(key, opc, rand, servicers.SeqToSqn(ue.GetSeq(), defaultInd))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error calculating AUTS")
+	}
+	p := eap.NewPacket(
+		eap.ResponseCode,
+		req.Identifier(),
+		[]byte{req.Type(), byte(aka.SubtypeSynchronizationFailure), 0, 0},
+	)
+	p, err = p.Append(eap.NewAttribute(aka.AT_AUTS, auts))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error appending attribute to packet")
+	}
+	// EAP-AKA' peers repeat the selected KDF (RFC 5448 section 3.2)
+	if fegprotos.EapType(req.Type()) == eapTypeAkaPrime {
+		p, err = p.Append(eap.NewAttribute(atKdf, []byte{0, kdfAkaPrime}))
+		if err != nil {
+			return nil, errors.Wrap(err, "Error appending attribute to packet")
+		}
+	}
+	return p, nil
+}
+
+// genAkaMac calculates the AT_MAC value of an EAP-AKA or EAP-AKA' packet.
+func genAkaMac(eapType fegprotos.EapType, data, kAut []byte) []byte {
+	if eapType == eapTypeAkaPrime {
+		return genAkaPrimeMac(data, kAut)
+	}
+	return aka.GenMac(data, kAut)
+}
+
 // Given an EAP packet, parses out the RAND, AUTN, and MAC.
 func parseChallengeAttributes(req eap.Packet) (challengeAttributes, error) {
 	attrs := challengeAttributes{}
@@ -256,6 +324,13 @@ func parseChallengeAttributes(req eap.Packet) (challengeAttributes, error) {
 				return attrs, fmt.Errorf("Malformed AT_MAC")
 			}
 			attrs.mac = a
+		case atKdfInput:
+			attrs.kdfInput = a
+		case atKdf:
+			// The first AT_KDF holds the KDF preferred by the server
+			if attrs.kdf == nil {
+				attrs.kdf = a
+			}
 		default:
 			glog.Info(fmt.Sprintf("Unexpected EAP-AKA Challenge Request Attribute type %d", a.Type()))
 		}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+
+	fegprotos "magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+
+	"github.com/pkg/errors"
+)
+
+// EAP-AKA' definitions (RFC 5448). The FeG EAP providers do not implement
+// EAP-AKA', so UESim only implements the peer side of the method.
+const (
+	eapTypeAkaPrime = fegprotos.EapType(50)
+
+	atKdfInput eap.AttrType = 23
+	atKdf      eap.AttrType = 24
+
+	// kdfAkaPrime is the only key derivation function defined by RFC 5448
+	kdfAkaPrime = 1
+	// fcCkIkPrime is the FC value of the CK' & IK' derivation (3GPP TS 33.402 Annex A.2)
+	fcCkIkPrime = 0x20
+
+	akaPrimeMkLen = 208
+)
+
+// getAkaPrimeKaut validates the EAP-AKA' challenge KDF attributes and returns
+// the K_aut derived from them.
+func getAkaPrimeKaut(identity, ck, ik, autn []byte, attrs challengeAttributes) ([]byte, error) {
+	kdf := attrs.kdf.Value()
+	if len(kdf) < 2 || binary.BigEndian.Uint16(kdf) != kdfAkaPrime {
+		return nil, errors.Errorf("Unsupported AT_KDF: %v", kdf)
+	}
+	kdfInput := attrs.kdfInput.Value()
+	if len(kdfInput) < 2 {
+		return nil, errors.New("Malformed AT_KDF_INPUT")
+	}
+	nameLen := int(binary.BigEndian.Uint16(kdfInput))
+	if nameLen == 0 || nameLen > len(kdfInput)-2 {
+		return nil, errors.Errorf("Invalid AT_KDF_INPUT network name length: %d", nameLen)
+	}
+	ckPrime, ikPrime := makeCkIkPrime(ck, ik, kdfInput[2:2+nameLen], autn)
+	_, kAut, _, _, _ := makeAkaPrimeKeys(identity, ckPrime, ikPrime)
+	return kAut, nil
+}
+
+// makeCkIkPrime derives CK' & IK' from CK & IK, the access network name and
+// SQN ^ AK as described in RFC 5448 section 3.3.
+func makeCkIkPrime(ck, ik, networkName, sqnXorAk []byte) (ckPrime, ikPrime []byte) {
+	s := make([]byte, 0, 1+len(networkName)+2+SqnLen+2)
+	s = append(s, fcCkIkPrime)
+	s = append(s, networkName...)
+	s = append(s, byte(len(networkName)>>8), byte(len(networkName)))
+	s = append(s, sqnXorAk[:SqnLen]...)
+	s = append(s, 0, SqnLen)
+
+	h := hmac.New(sha256.New, append(append([]byte{}, ck...), ik...))
+	h.Write(s)
+	key := h.Sum(nil)
+	return key[:16], key[16:]
+}
+
+// prfPrime implements the PRF' pseudo random function of RFC 5448 section 3.4,
+// where T1 = HMAC-SHA-256(K, S | 0x01) and Tn = HMAC-SHA-256(K, Tn-1 | S | n).
+func prfPrime(key, s []byte, length int) []byte {
+	res := make([]byte, 0, length+sha256.Size)
+	var t []byte
+	for n := byte(1); len(res) < length; n++ {
+		h := hmac.New(sha256.New, key)
+		h.Write(t)
+		h.Write(s)
+		h.Write([]byte{n})
+		t = h.Sum(nil)
+		res = append(res, t...)
+	}
+	return res[:length]
+}
+
+// makeAkaPrimeKeys returns K_encr, K_aut, K_re, MSK & EMSK for the identity
+// and CK' & IK' (RFC 5448 section 3.3).
+func makeAkaPrimeKeys(identity, ckPrime, ikPrime []byte) (kEncr, kAut, kRe, msk, emsk []byte) {
+	mk := prfPrime(
+		append(append([]byte{}, ikPrime...), ckPrime...),
+		append([]byte("EAP-AKA'"), identity...),
+		akaPrimeMkLen,
+	)
+	return mk[:16], mk[16:48], mk[48:80], mk[80:144], mk[144:208]
+}
+
+// genAkaPrimeMac calculates the EAP-AKA' AT_MAC value (HMAC-SHA-256-128).
+func genAkaPrimeMac(data, kAut []byte) []byte {
+	h := hmac.New(sha256.New, kAut)
+	h.Write(data)
+	return h.Sum(nil)[:16]
+}
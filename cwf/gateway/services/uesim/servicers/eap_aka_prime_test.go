@@ -0,0 +1,119 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicers
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	cwfprotos "magma/cwf/cloud/go/protos"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers/aka"
+	hss "magma/feg/gateway/services/testcore/hss/servicers"
+	"magma/lte/cloud/go/crypto"
+	"magma/orc8r/cloud/go/test_utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 5448 Appendix C, Test Case 1
+func TestMakeAkaPrimeKeys(t *testing.T) {
+	ck := decodeHex(t, "5349fbe0 98649f94 8f5d2e97 3a81c00f")
+	ik := decodeHex(t, "9744871a d32bf9bb d1dd5ce5 4e3e2e5a")
+	autn := decodeHex(t, "bb52e91c 747ac3ab 2a5c23d1 5ee351d5")
+
+	ckPrime, ikPrime := makeCkIkPrime(ck, ik, []byte("WLAN"), autn)
+	assert.Equal(t, decodeHex(t, "0093962d 0dd84aa5 684b045c 9edffa04"), ckPrime)
+	assert.Equal(t, decodeHex(t, "ccfc230c a74fcc96 c0a5d611 64f5a76c"), ikPrime)
+
+	kEncr, kAut, kRe, msk, emsk := makeAkaPrimeKeys([]byte("0555444333222111"), ckPrime, ikPrime)
+	assert.Equal(t, decodeHex(t, "766fa0a6 c317174b 812d52fb cd11a179"), kEncr)
+	assert.Equal(t, decodeHex(t, "0842ea72 2ff6835b fa203249 9fc3ec23 c2f0e388 b4f07543 ffc677f1 696d71ea"), kAut)
+	assert.Equal(t, decodeHex(t, "cf83aa8b c7e0aced 892acc98 e76a9b20 95b558c7 795c7094 715cb339 3aa7d17a"), kRe)
+	assert.Equal(t, decodeHex(t, "67c42d9a a56c1b79 e295e345 9fc3d187 d42be0bf 818d3070 e362c5e9 67a4d544"+
+		"e8ecfe19 358ab303 9aff03b7 c930588c 055babee 58a02650 b067ec4e 9347c75a"), msk)
+	assert.Len(t, emsk, 64)
+}
+
+func TestEapAkaPrimeChallengeRequest(t *testing.T) {
+	srv := &UESimServer{store: test_utils.NewSQLBlobstore(t, "uesim_aka_prime_test_blobstore"), cfg: getDefaultUESimConfig()}
+	key := decodeHex(t, "8baf473f 2f8fd094 87cccbd7 097c6862")
+	opc, err := crypto.GenerateOpc(key, defaultOp)
+	assert.NoError(t, err)
+	ue := &cwfprotos.UEConfig{
+		Imsi:      "001010000000091",
+		AuthKey:   key,
+		AuthOpc:   opc[:],
+		Seq:       31,
+		EapMethod: cwfprotos.EapMethod_EAP_AKA_PRIME,
+	}
+	_, err = srv.AddUE(context.Background(), ue)
+	assert.NoError(t, err)
+
+	// Generate the challenge of the next SEQ the way a server would
+	milenage, err := crypto.NewMilenageCipher(defaultAmf)
+	assert.NoError(t, err)
+	rand := decodeHex(t, "81e92b6c 0ee0e12e bceba8d9 2a99dfa5")
+	vec, err := milenage.GenerateSIPAuthVectorWithRand(rand, key, opc[:], hss.SeqToSqn(32, defaultInd))
+	assert.NoError(t, err)
+	ckPrime, ikPrime := makeCkIkPrime(vec.ConfidentialityKey[:], vec.IntegrityKey[:], []byte("WLAN"), vec.Autn[:])
+	_, kAut, _, _, _ := makeAkaPrimeKeys([]byte("6001010000000091"+IdentityPostfix), ckPrime, ikPrime)
+	newChallengeReq := func(kdf byte) eap.Packet {
+		p := eap.NewPacket(eap.RequestCode, 1, []byte{byte(eapTypeAkaPrime), byte(aka.SubtypeChallenge), 0, 0})
+		for _, a := range []eap.Attribute{
+			eap.NewAttribute(aka.AT_RAND, append([]byte{0, 0}, rand...)),
+			eap.NewAttribute(aka.AT_AUTN, append([]byte{0, 0}, vec.Autn[:]...)),
+			eap.NewAttribute(atKdfInput, append([]byte{0, 4}, "WLAN"...)),
+			eap.NewAttribute(atKdf, []byte{0, kdf}),
+			eap.NewAttribute(aka.AT_MAC, make([]byte, 2+aka.MAC_LEN)),
+		} {
+			p, err = p.Append(a)
+			assert.NoError(t, err)
+		}
+		copy(p[len(p)-aka.MAC_LEN:], genAkaPrimeMac(p, kAut))
+		return p
+	}
+
+	_, err = srv.HandleEap(ue, newChallengeReq(2))
+	assert.EqualError(t, err, "Unsupported AT_KDF: [0 2]")
+
+	res, err := srv.HandleEap(ue, newChallengeReq(kdfAkaPrime))
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(eapTypeAkaPrime), res.Type())
+	assert.Equal(t, uint8(aka.SubtypeChallenge), res[eap.EapSubtype])
+	assert.Equal(t, uint64(32), ue.GetSeq())
+
+	scanner, err := eap.NewAttributeScanner(res)
+	assert.NoError(t, err)
+	resAttr, err := scanner.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, aka.AT_RES, resAttr.Type())
+	assert.Equal(t, vec.Xres[:], resAttr.Value()[2:])
+
+	// EAP-AKA' responses carry no AT_CHECKCODE and are signed with HMAC-SHA-256
+	macAttr, err := scanner.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, aka.AT_MAC, macAttr.Type())
+	mac := append([]byte{}, macAttr.Value()[2:]...)
+	copy(res[len(res)-aka.MAC_LEN:], make([]byte, aka.MAC_LEN))
+	assert.Equal(t, genAkaPrimeMac(res, kAut), mac)
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	assert.NoError(t, err)
+	return b
+}
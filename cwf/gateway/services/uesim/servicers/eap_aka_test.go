@@ -17,7 +17,11 @@ import (
 	"reflect"
 	"testing"
 
+	cwfprotos "magma/cwf/cloud/go/protos"
 	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers/aka"
+	"magma/feg/gateway/services/testcore/hss/servicers"
+	"magma/lte/cloud/go/crypto"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -34,6 +38,7 @@ const (
 		"\x94\x73\x37\x74\x82\xbd\x67\x41\x51\x11\x05\x57\x68\x17\xaa\x23" +
 		"\x0b\x05\x00\x00\xda\x14\xa9\xce\x0e\x66\xaf\x38\x7b\x9f\xc1\xe6" +
 		"\xf0\x31\x5e\x00"
+	EapAkaChallengeRand           = "\xee\xb3\x53\x6c\x2f\xc3\x68\xfe\x3a\xfb\xd5\x5c\xfe\xf9\x6b\x29"
 	EapAkaChallengeResponsePacket = "\x02\xea\x00\x40\x17\x01\x00\x00\x03\x03\x00\x40\xdc\x89\x15\x16" +
 		"\x8d\xd2\xeb\x56\x86\x06\x00\x00\x86\xe8\x20\x4d\xc6\xe1\xe3\xd8" +
 		"\x94\x44\x3c\x26\xa7\xc6\x5d\xee\x3c\x42\xab\xf8\x0b\x05\x00\x00" +
@@ -69,3 +74,63 @@ func TestEapAkaChallengeRequest(t *testing.T) {
 		res,
 	)
 }
+
+func TestEapAkaChallengeRequest_BadMac(t *testing.T) {
+	server, ue, err := setupTest(t)
+	assert.NoError(t, err)
+	ue.AuthFault = cwfprotos.AuthFault_BAD_MAC
+
+	res, err := server.HandleEap(ue, eap.Packet(EapAkaChallengeRequestPacket))
+	assert.NoError(t, err)
+	macOffset := len(EapAkaChallengeResponsePacket) - aka.MAC_LEN
+	assert.Equal(t, []byte(EapAkaChallengeResponsePacket[:macOffset]), []byte(res[:macOffset]))
+	for i := macOffset; i < len(res); i++ {
+		assert.Equal(t, EapAkaChallengeResponsePacket[i]^0xff, res[i])
+	}
+}
+
+func TestEapAkaChallengeRequest_SyncFailure(t *testing.T) {
+	server, ue, err := setupTest(t)
+	assert.NoError(t, err)
+	ue.AuthFault = cwfprotos.AuthFault_SYNC_FAILURE
+
+	// The first challenge of the authentication is answered with the UE's SQN
+	res, err := server.HandleEap(ue, eap.Packet(EapAkaChallengeRequestPacket))
+	assert.NoError(t, err)
+	assertSyncFailure(t, res, Seq)
+
+	// The next challenge is answered
+	res, err = server.HandleEap(ue, eap.Packet(EapAkaChallengeRequestPacket))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(EapAkaChallengeResponsePacket), []byte(res))
+}
+
+func TestEapAkaChallengeRequest_InvalidSeq(t *testing.T) {
+	server, ue, err := setupTest(t)
+	assert.NoError(t, err)
+	ue.Seq = 1 << 40
+
+	res, err := server.HandleEap(ue, eap.Packet(EapAkaChallengeRequestPacket))
+	assert.NoError(t, err)
+	assertSyncFailure(t, res, 1<<40)
+}
+
+func assertSyncFailure(t *testing.T, res eap.Packet, seq uint64) {
+	assert.Equal(t, uint8(eap.ResponseCode), res.Code())
+	assert.Equal(t, uint8(aka.TYPE), res.Type())
+	assert.Equal(t, uint8(aka.SubtypeSynchronizationFailure), res[eap.EapSubtype])
+
+	scanner, err := eap.NewAttributeScanner(res)
+	assert.NoError(t, err)
+	a, err := scanner.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, aka.AT_AUTS, a.Type())
+
+	// Verify that the HSS recovers the UE's SQN from AUTS
+	milenage, err := crypto.NewMilenageCipher([]byte("\x67\x41"))
+	assert.NoError(t, err)
+	sqn, macS, err := milenage.GenerateResync(a.Value(), []byte(Key), []byte(Opc), []byte(EapAkaChallengeRand))
+	assert.NoError(t, err)
+	assert.Equal(t, servicers.SeqToSqn(seq, 0), sqn)
+	assert.Equal(t, a.Value()[6:], macS[:])
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicers
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"reflect"
+
+	"magma/cwf/cloud/go/protos"
+	fegprotos "magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers/sim"
+	"magma/lte/cloud/go/crypto"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// handleEapSim routes the EAP-SIM request to the UE with the specified imsi.
+func (srv *UESimServer) handleEapSim(ue *protos.UEConfig, req eap.Packet) (eap.Packet, error) {
+	switch sim.Subtype(req[eap.EapSubtype]) {
+	case sim.SubtypeStart:
+		return srv.eapSimStartRequest(ue, req)
+	case sim.SubtypeChallenge:
+		return srv.eapSimChallengeRequest(ue, req)
+	case sim.SubtypeNotification:
+		return srv.eapNotificationRequest(ue, req)
+	default:
+		return nil, errors.Errorf("Unsupported Subtype: %d", req[eap.EapSubtype])
+	}
+}
+
+// Given a UE and the EAP-SIM start request, negotiates the version and
+// generates the EAP response with the UE's NONCE_MT and identity.
+func (srv *UESimServer) eapSimStartRequest(ue *protos.UEConfig, req eap.Packet) (eap.Packet, error) {
+	scanner, err := eap.NewAttributeScanner(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating new attribute scanner")
+	}
+
+	var (
+		a           eap.Attribute
+		versionList []byte
+		idRequested bool
+	)
+	for a, err = scanner.Next(); err == nil; a, err = scanner.Next() {
+		switch a.Type() {
+		case sim.AT_VERSION_LIST:
+			value := a.Value()
+			if len(value) < 2 {
+				return nil, errors.New("Malformed AT_VERSION_LIST")
+			}
+			actualLen := int(value[0])<<8 + int(value[1])
+			if actualLen > len(value)-2 {
+				return nil, errors.Errorf("Invalid AT_VERSION_LIST length: %d", actualLen)
+			}
+			versionList = value[2 : 2+actualLen]
+		case sim.AT_PERMANENT_ID_REQ, sim.AT_FULLAUTH_ID_REQ, sim.AT_ANY_ID_REQ:
+			idRequested = true
+		default:
+			glog.Info(fmt.Sprintf("Unexpected EAP-SIM Start Request Attribute type %d", a.Type()))
+		}
+	}
+	if err != io.EOF {
+		return nil, errors.Wrap(err, "Error while processing EAP-SIM Start Request")
+	}
+	selectedVersion := []byte{0, sim.Version}
+	if !containsSimVersion(versionList, selectedVersion) {
+		return nil, errors.Errorf("Unsupported EAP-SIM version list: %v", versionList)
+	}
+
+	nonce := make([]byte, sim.RAND_LEN)
+	if _, err = crand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "Error generating NONCE_MT")
+	}
+	session := srv.eapSessions.get(ue.GetImsi())
+	session.nonce, session.versionList, session.selectedVersion = nonce, versionList, selectedVersion
+
+	// Create the response EAP packet.
+	p := eap.NewPacket(eap.ResponseCode, req.Identifier(), []byte{sim.TYPE, byte(sim.SubtypeStart), 0, 0})
+	p, err = p.Append(eap.NewAttribute(sim.AT_NONCE_MT, append([]byte{0, 0}, nonce...)))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error appending attribute to packet")
+	}
+	p, err = p.Append(eap.NewAttribute(sim.AT_SELECTED_VERSION, selectedVersion))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error appending attribute to packet")
+	}
+	if idRequested {
+		id := []byte(getEapIdentity(ue, fegprotos.EapType_SIM))
+		p, err = p.Append(
+			eap.NewAttribute(
+				sim.AT_IDENTITY,
+				append(
+					[]byte{uint8(len(id) >> 8), uint8(len(id))}, // actual len of Identity
+					id...,
+				),
+			),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error appending attribute to packet")
+		}
+	}
+	return p, nil
+}
+
+// Given a UE and the EAP-SIM challenge, derives the GSM triplets of the RANDs
+// from the UMTS keys, verifies the server's MAC and generates the EAP response.
+func (srv *UESimServer) eapSimChallengeRequest(ue *protos.UEConfig, req eap.Packet) (eap.Packet, error) {
+	session := srv.eapSessions.get(ue.GetImsi())
+	if session.nonce == nil {
+		return nil, errors.New("Received EAP-SIM Challenge before Start")
+	}
+
+	scanner, err := eap.NewAttributeScanner(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating new attribute scanner")
+	}
+	var a, atRand, atMac eap.Attribute
+	for a, err = scanner.Next(); err == nil; a, err = scanner.Next() {
+		switch a.Type() {
+		case sim.AT_RAND:
+			atRand = a
+		case sim.AT_MAC:
+			if len(a.Marshaled()) < sim.ATT_HDR_LEN+sim.MAC_LEN {
+				return nil, errors.New("Malformed AT_MAC")
+			}
+			atMac = a
+		default:
+			glog.Info(fmt.Sprintf("Unexpected EAP-SIM Challenge Request Attribute type %d", a.Type()))
+		}
+	}
+	if err != io.EOF {
+		return nil, errors.Wrap(err, "Error while parsing attributes of request packet")
+	}
+	if atRand == nil || atMac == nil {
+		return nil, errors.Errorf("Missing one or more expected attributes\nRAND: %s\nMAC: %s\n", atRand, atMac)
+	}
+	rands := atRand.Value()[2:]
+	if len(rands) == 0 || len(rands)%sim.RAND_LEN != 0 {
+		return nil, errors.Errorf("Invalid AT_RAND length: %d", len(rands))
+	}
+
+	// Calculate Opc using key and Op, and verify that it matches the UE's Opc
+	key := ue.GetAuthKey()
+	opc, err := crypto.GenerateOpc(key, srv.cfg.op)
+	if err != nil {
+		return nil, fmt.Errorf("Error while calculating Opc")
+	}
+	if !reflect.DeepEqual(opc[:], ue.GetAuthOpc()) {
+		return nil, fmt.Errorf("Invalid Opc: Expected Opc: %x; Actual Opc: %x", opc[:], ue.GetAuthOpc())
+	}
+
+	// Derive Kc & SRES of every RAND from its UMTS quintuplet (3GPP TS 55.205)
+	milenage, err := crypto.NewMilenageCipher(srv.cfg.amf)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating milenage cipher")
+	}
+	var kc, sres [][]byte
+	for offset := 0; offset < len(rands); offset += sim.RAND_LEN {
+		vec, err := milenage.GenerateSIPAuthVectorWithRand(rands[offset:offset+sim.RAND_LEN], key, opc[:], 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error calculating authentication vector")
+		}
+		kci, sresi := sim.GsmFromUmts1(vec.ConfidentialityKey[:], vec.IntegrityKey[:], vec.Xres[:])
+		kc, sres = append(kc, kci), append(sres, sresi)
+	}
+	id := []byte(getEapIdentity(ue, fegprotos.EapType_SIM))
+	_, kAut, _, _ := sim.MakeKeys(id, session.nonce, session.versionList, session.selectedVersion, kc)
+
+	// Make copy of packet, zero out MAC value and verify MAC.
+	expectedMac := atMac.Value()[2:]
+	copyReq := make([]byte, len(req))
+	copy(copyReq, req)
+	copyAttrs, err := parseChallengeAttributes(eap.Packet(copyReq))
+	if err != io.EOF {
+		return nil, errors.Wrap(err, "Error while parsing attributes of copied request packet")
+	}
+	copyMacBytes := copyAttrs.mac.Marshaled()
+	for i := sim.ATT_HDR_LEN; i < len(copyMacBytes); i++ {
+		copyMacBytes[i] = 0
+	}
+	mac := sim.GenMac(copyReq, session.nonce, kAut)
+	if !reflect.DeepEqual(expectedMac[:sim.MAC_LEN], mac) {
+		return nil, fmt.Errorf("Invalid MAC: Expected MAC: %x; Actual MAC: %x", expectedMac, mac)
+	}
+	session.kAut = kAut
+
+	// Create the response EAP packet with the empty MAC attribute.
+	p := eap.NewPacket(eap.ResponseCode, req.Identifier(), []byte{sim.TYPE, byte(sim.SubtypeChallenge), 0, 0})
+	atMacOffset := len(p) + sim.ATT_HDR_LEN
+	p, err = p.Append(eap.NewAttribute(sim.AT_MAC, make([]byte, 2+sim.MAC_LEN)))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error appending attribute to packet")
+	}
+
+	// Calculate and Copy MAC into packet.
+	mac = sim.GenChallengeMac(p, sres, kAut)
+	if ue.GetAuthFault() == protos.AuthFault_BAD_MAC {
+		corruptMac(mac)
+	}
+	copy(p[atMacOffset:], mac)
+	return p, nil
+}
+
+// containsSimVersion returns whether the AT_VERSION_LIST versions include the version.
+func containsSimVersion(versionList, version []byte) bool {
+	for i := 0; i+1 < len(versionList); i += 2 {
+		if versionList[i] == version[0] && versionList[i+1] == version[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicers_test
+
+import (
+	"testing"
+
+	cwfprotos "magma/cwf/cloud/go/protos"
+	"magma/cwf/gateway/services/uesim/servicers"
+	"magma/feg/gateway/services/eap"
+	"magma/feg/gateway/services/eap/providers/sim"
+	"magma/lte/cloud/go/crypto"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	EapSimIdentityResponsePacket = "\x02\xe7\x00\x38\x01\x31\x30\x30\x31\x30\x31\x30\x30\x30\x30\x30" +
+		"\x30\x30\x30\x39\x31\x40\x77\x6c\x61\x6e\x2e\x6d\x6e\x63\x30\x30" +
+		"\x31\x2e\x6d\x63\x63\x30\x30\x31\x2e\x33\x67\x70\x70\x6e\x65\x74" +
+		"\x77\x6f\x72\x6b\x2e\x6f\x72\x67"
+
+	EapSimIdentity = "1" + Imsi + servicers.IdentityPostfix
+)
+
+var eapSimRands = [sim.GsmTripletsNumber]string{
+	"\xee\xb3\x53\x6c\x2f\xc3\x68\xfe\x3a\xfb\xd5\x5c\xfe\xf9\x6b\x29",
+	"\x94\x73\x37\x74\x82\xbd\x67\x41\x51\x11\x05\x57\x68\x17\xaa\x23",
+	"\xda\x14\xa9\xce\x0e\x66\xaf\x38\x7b\x9f\xc1\xe6\xf0\x31\x5e\x00",
+}
+
+// simServer holds the server side state of an EAP-SIM authentication.
+type simServer struct {
+	nonce, selectedVersion []byte
+	kAut                   []byte
+	sres                   [][]byte
+}
+
+func TestEapSimIdentityRequest(t *testing.T) {
+	server, ue, err := setupTest(t)
+	assert.NoError(t, err)
+	ue.EapMethod = cwfprotos.EapMethod_EAP_SIM
+
+	res, err := server.HandleEap(ue, eap.Packet(EapIdentityRequestPacket))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(EapSimIdentityResponsePacket), []byte(res))
+}
+
+func TestEapSim(t *testing.T) {
+	server, ue, err := setupTest(t)
+	assert.NoError(t, err)
+	ue.EapMethod = cwfprotos.EapMethod_EAP_SIM
+
+	simSrv := &simServer{}
+	res, err := server.HandleEap(ue, sim.NewStartReq(1, sim.AT_PERMANENT_ID_REQ))
+	assert.NoError(t, err)
+	simSrv.handleStartResponse(t, res)
+
+	res, err = server.HandleEap(ue, simSrv.newChallengeReq(t, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(eap.ResponseCode), res.Code())
+	assert.Equal(t, uint8(2), res.Identifier())
+	assert.Equal(t, simSrv.genChallengeMac(t, res), getSimMac(t, res))
+
+	// The challenge is not answered if the server MAC is invalid
+	req := simSrv.newChallengeReq(t, 3)
+	req[len(req)-1] ^= 0xff
+	_, err = server.HandleEap(ue, req)
+	assert.Error(t, err)
+
+	// Notifications after the challenge are protected by the session K_aut
+	req = eap.NewPacket(eap.RequestCode, 4, []byte{sim.TYPE, byte(sim.SubtypeNotification), 0, 0, byte(sim.AT_NOTIFICATION), 1, 0, 0})
+	res, err = server.HandleEap(ue, req)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(sim.SubtypeNotification), res[eap.EapSubtype])
+	assert.Equal(t, sim.GenMac(zeroSimMac(t, res), nil, simSrv.kAut), getSimMac(t, res))
+}
+
+func TestEapSim_BadMac(t *testing.T) {
+	server, ue, err := setupTest(t)
+	assert.NoError(t, err)
+	ue.EapMethod = cwfprotos.EapMethod_EAP_SIM
+	ue.AuthFault = cwfprotos.AuthFault_BAD_MAC
+
+	simSrv := &simServer{}
+	res, err := server.HandleEap(ue, sim.NewStartReq(1, sim.AT_PERMANENT_ID_REQ))
+	assert.NoError(t, err)
+	simSrv.handleStartResponse(t, res)
+
+	res, err = server.HandleEap(ue, simSrv.newChallengeReq(t, 2))
+	assert.NoError(t, err)
+	assert.NotEqual(t, simSrv.genChallengeMac(t, res), getSimMac(t, res))
+}
+
+func TestEapSimChallengeRequest_NoStart(t *testing.T) {
+	server, ue, err := setupTest(t)
+	assert.NoError(t, err)
+
+	simSrv := &simServer{nonce: make([]byte, 16), selectedVersion: []byte{0, sim.Version}}
+	_, err = server.HandleEap(ue, simSrv.newChallengeReq(t, 2))
+	assert.EqualError(t, err, "Received EAP-SIM Challenge before Start")
+}
+
+// handleStartResponse validates the UE's start response the way the FeG
+// EAP-SIM provider does & stores the negotiated parameters.
+func (s *simServer) handleStartResponse(t *testing.T, res eap.Packet) {
+	assert.Equal(t, uint8(eap.ResponseCode), res.Code())
+	assert.Equal(t, uint8(sim.TYPE), res.Type())
+	assert.Equal(t, uint8(sim.SubtypeStart), res[eap.EapSubtype])
+
+	scanner, err := eap.NewAttributeScanner(res)
+	assert.NoError(t, err)
+	var identity string
+	for a, err := scanner.Next(); err == nil; a, err = scanner.Next() {
+		switch a.Type() {
+		case sim.AT_NONCE_MT:
+			assert.Equal(t, uint8(5), a.AttrLen())
+			s.nonce = a.Value()[2:]
+		case sim.AT_SELECTED_VERSION:
+			assert.Equal(t, uint8(1), a.AttrLen())
+			s.selectedVersion = a.Value()
+		case sim.AT_IDENTITY:
+			v := a.Value()
+			identity = string(v[2 : 2+int(v[0])<<8+int(v[1])])
+		}
+	}
+	assert.Len(t, s.nonce, 16)
+	assert.Equal(t, []byte{0, sim.Version}, s.selectedVersion)
+	assert.Equal(t, EapSimIdentity, identity)
+}
+
+// newChallengeReq builds the EAP-SIM challenge from the GSM triplets of the
+// subscriber, the way the FeG EAP-SIM provider does.
+func (s *simServer) newChallengeReq(t *testing.T, identifier uint8) eap.Packet {
+	milenage, err := crypto.NewMilenageCipher([]byte("\x67\x41"))
+	assert.NoError(t, err)
+	var rands, kc [][]byte
+	s.sres = nil
+	for _, rand := range eapSimRands {
+		vec, err := milenage.GenerateSIPAuthVectorWithRand([]byte(rand), []byte(Key), []byte(Opc), 0)
+		assert.NoError(t, err)
+		kci, sresi := sim.GsmFromUmts1(vec.ConfidentialityKey[:], vec.IntegrityKey[:], vec.Xres[:])
+		rands, kc, s.sres = append(rands, []byte(rand)), append(kc, kci), append(s.sres, sresi)
+	}
+	_, s.kAut, _, _ = sim.MakeKeys([]byte(EapSimIdentity), s.nonce, []byte{0, sim.Version}, s.selectedVersion, kc)
+
+	p := eap.NewPacket(eap.RequestCode, identifier, []byte{sim.TYPE, byte(sim.SubtypeChallenge), 0, 0})
+	atRand := []byte{0, 0}
+	for _, rand := range rands {
+		atRand = append(atRand, rand...)
+	}
+	p, err = p.Append(eap.NewAttribute(sim.AT_RAND, atRand))
+	assert.NoError(t, err)
+	p, err = p.Append(eap.NewAttribute(sim.AT_MAC, make([]byte, 2+sim.MAC_LEN)))
+	assert.NoError(t, err)
+	copy(p[len(p)-sim.MAC_LEN:], sim.GenMac(p, s.nonce, s.kAut))
+	return p
+}
+
+// genChallengeMac returns the MAC the server expects in the challenge response.
+func (s *simServer) genChallengeMac(t *testing.T, res eap.Packet) []byte {
+	return sim.GenChallengeMac(zeroSimMac(t, res), s.sres, s.kAut)
+}
+
+// getSimMac returns the value of the AT_MAC attribute of the packet.
+func getSimMac(t *testing.T, p eap.Packet) []byte {
+	scanner, err := eap.NewAttributeScanner(p)
+	assert.NoError(t, err)
+	for a, err := scanner.Next(); err == nil; a, err = scanner.Next() {
+		if a.Type() == sim.AT_MAC {
+			return a.Value()[2:]
+		}
+	}
+	assert.Fail(t, "Missing AT_MAC")
+	return nil
+}
+
+// zeroSimMac returns a copy of the packet with the AT_MAC value set to zeros.
+func zeroSimMac(t *testing.T, p eap.Packet) eap.Packet {
+	res := make([]byte, len(p))
+	copy(res, p)
+	mac := getSimMac(t, res)
+	for i := range mac {
+		mac[i] = 0
+	}
+	return res
+}
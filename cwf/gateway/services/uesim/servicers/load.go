@@ -0,0 +1,469 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicers
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2866"
+	"fbc/lib/go/radius/rfc2869"
+	cwfprotos "magma/cwf/cloud/go/protos"
+	"magma/feg/gateway/services/eap"
+	"magma/lte/cloud/go/crypto"
+	"magma/orc8r/lib/go/protos"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultLoadRequestTimeout = 5 * time.Second
+
+	// Failure reasons of the load steps
+	reasonTimeout        = "timeout"
+	reasonExchangeError  = "exchange_error"
+	reasonAccessReject   = "access_reject"
+	reasonEapFailure     = "eap_failure"
+	reasonUnexpectedCode = "unexpected_code"
+	reasonUEError        = "ue_error"
+)
+
+// loadRun tracks the UEs & statistics of a load generation run.
+type loadRun struct {
+	req    *cwfprotos.LoadRequest
+	opc    []byte
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu        sync.Mutex
+	start     time.Time
+	end       time.Time
+	started   uint32
+	completed uint32
+	failed    uint32
+	steps     map[cwfprotos.LoadStep]*loadStepStats
+}
+
+type loadStepStats struct {
+	attempts  uint32
+	latencies []time.Duration
+	failures  map[string]uint32
+}
+
+// StartLoad starts attaching the range of UEs of the request at the requested
+// rate. Each UE authenticates, starts accounting, sends interim updates during
+// its hold time and then disconnects as per the disconnect pattern. Only one
+// load run can be active at a time.
+func (srv *UESimServer) StartLoad(ctx context.Context, req *cwfprotos.LoadRequest) (*protos.Void, error) {
+	err := validateLoadRequest(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	opc, err := crypto.GenerateOpc(req.GetAuthKey(), srv.cfg.op)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Error generating OPc: %v", err)
+	}
+
+	srv.loadMu.Lock()
+	defer srv.loadMu.Unlock()
+	if srv.load != nil && srv.load.isRunning() {
+		return nil, status.Errorf(codes.FailedPrecondition, "A load run is already in progress")
+	}
+	loadCtx, cancel := context.WithCancel(context.Background())
+	srv.load = &loadRun{
+		req:    req,
+		opc:    opc[:],
+		cancel: cancel,
+		done:   make(chan struct{}),
+		start:  time.Now(),
+		steps:  map[cwfprotos.LoadStep]*loadStepStats{},
+	}
+	glog.Infof("Starting load of %d UEs from IMSI %s at %.2f attaches/s",
+		req.GetNumUes(), req.GetImsiStart(), req.GetAttachRate())
+	go srv.runLoad(loadCtx, srv.load)
+	return &protos.Void{}, nil
+}
+
+// GetLoadReport returns the statistics of the current or last load run.
+func (srv *UESimServer) GetLoadReport(ctx context.Context, void *protos.Void) (*cwfprotos.LoadReport, error) {
+	srv.loadMu.Lock()
+	run := srv.load
+	srv.loadMu.Unlock()
+	if run == nil {
+		return nil, status.Errorf(codes.NotFound, "No load run was started")
+	}
+	return run.report(), nil
+}
+
+// StopLoad stops the current load run, waits for its UEs to stop and returns
+// its statistics. The steps interrupted by the stop are not accounted.
+func (srv *UESimServer) StopLoad(ctx context.Context, void *protos.Void) (*cwfprotos.LoadReport, error) {
+	srv.loadMu.Lock()
+	run := srv.load
+	srv.loadMu.Unlock()
+	if run == nil {
+		return nil, status.Errorf(codes.NotFound, "No load run was started")
+	}
+	run.cancel()
+	<-run.done
+	return run.report(), nil
+}
+
+// getLoadIMSI returns the IMSI at the offset of the range starting at start.
+func getLoadIMSI(start string, offset uint32) (string, error) {
+	if len(start) < 5 || len(start) > 15 {
+		return "", fmt.Errorf("Invalid Argument: imsi_start must be between 5 and 15 digits long")
+	}
+	first, err := strconv.ParseUint(start, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("Invalid Argument: imsi_start must only be digits")
+	}
+	imsi := fmt.Sprintf("%0*d", len(start), first+uint64(offset))
+	if len(imsi) != len(start) {
+		return "", fmt.Errorf("Invalid Argument: IMSI range of %d UEs from %s overflows", offset+1, start)
+	}
+	return imsi, nil
+}
+
+// getLoadCallingStationID returns a unique MAC address for the UE at the offset
+// of the load range, since the RADIUS server keys the EAP sessions on it.
+func getLoadCallingStationID(offset uint32) string {
+	return fmt.Sprintf("02-00-%02X-%02X-%02X-%02X",
+		byte(offset>>24), byte(offset>>16), byte(offset>>8), byte(offset))
+}
+
+func (srv *UESimServer) runLoad(ctx context.Context, run *loadRun) {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		run.finish()
+		glog.Infof("Load run finished: %+v", run.report())
+	}()
+
+	interval := time.Duration(float64(time.Second) / run.req.GetAttachRate())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := uint32(0); i < run.req.GetNumUes(); i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+		imsi, _ := getLoadIMSI(run.req.GetImsiStart(), i)
+		wg.Add(1)
+		go func(imsi string, offset uint32) {
+			defer wg.Done()
+			srv.runLoadUE(ctx, run, imsi, getLoadCallingStationID(offset))
+		}(imsi, i)
+	}
+}
+
+// runLoadUE runs the attach, session & disconnect of a single UE.
+func (srv *UESimServer) runLoadUE(ctx context.Context, run *loadRun, imsi, callingStationID string) {
+	ue := &cwfprotos.UEConfig{
+		Imsi:    imsi,
+		AuthKey: run.req.GetAuthKey(),
+		AuthOpc: run.opc,
+		Seq:     run.req.GetSeq(),
+	}
+	addUeToStore(srv.store, ue)
+	run.ueStarted()
+
+	calledStationID := run.req.GetCalledStationID()
+	if !srv.loadAuthenticate(ctx, run, imsi, callingStationID, calledStationID) {
+		run.ueDone(ctx, false)
+		return
+	}
+
+	sessionID := fmt.Sprintf("%s-%d", imsi, time.Now().UnixNano())
+	acct := func(step cwfprotos.LoadStep, statusType rfc2866.AcctStatusType) bool {
+		req, err := srv.makeAccountingRequest(statusType, imsi, callingStationID, calledStationID, sessionID)
+		if err != nil {
+			run.recordFailure(step, reasonUEError)
+			return false
+		}
+		return srv.loadExchange(ctx, run, step, req, srv.cfg.radiusAcctAddress, radius.CodeAccountingResponse) != nil
+	}
+	if !acct(cwfprotos.LoadStep_ACCT_START, rfc2866.AcctStatusType_Value_Start) {
+		run.ueDone(ctx, false)
+		return
+	}
+
+	hold := time.NewTimer(time.Duration(run.req.GetHoldTimeMs()) * time.Millisecond)
+	defer hold.Stop()
+	var interim <-chan time.Time
+	if run.req.GetInterimIntervalMs() > 0 {
+		ticker := time.NewTicker(time.Duration(run.req.GetInterimIntervalMs()) * time.Millisecond)
+		defer ticker.Stop()
+		interim = ticker.C
+	}
+	for holding := true; holding; {
+		select {
+		case <-ctx.Done():
+			return
+		case <-interim:
+			if !acct(cwfprotos.LoadStep_ACCT_INTERIM, rfc2866.AcctStatusType_Value_InterimUpdate) {
+				run.ueDone(ctx, false)
+				return
+			}
+		case <-hold.C:
+			holding = false
+		}
+	}
+
+	if isAbruptDisconnect(run.req) {
+		run.ueDone(ctx, true)
+		return
+	}
+	run.ueDone(ctx, acct(cwfprotos.LoadStep_ACCT_STOP, rfc2866.AcctStatusType_Value_Stop))
+}
+
+// loadAuthenticate runs the EAP-AKA authentication of a UE, each RADIUS
+// exchange being a load step.
+func (srv *UESimServer) loadAuthenticate(ctx context.Context, run *loadRun, imsi, callingStationID, calledStationID string) bool {
+	req, err := srv.createEAPIdentityRequest(imsi, callingStationID, calledStationID)
+	if err != nil {
+		glog.Errorf("Error creating EAP Identity Response for %s: %v", imsi, err)
+		run.recordFailure(cwfprotos.LoadStep_EAP_IDENTITY, reasonUEError)
+		return false
+	}
+	step := cwfprotos.LoadStep_EAP_IDENTITY
+	for round := 0; ; round++ {
+		expectedCode := radius.CodeAccessChallenge
+		if step == cwfprotos.LoadStep_EAP_ACCEPT {
+			expectedCode = radius.CodeAccessAccept
+		}
+		res := srv.loadExchange(ctx, run, step, req, srv.cfg.radiusAuthAddress, expectedCode)
+		if res == nil {
+			return false
+		}
+		if step == cwfprotos.LoadStep_EAP_ACCEPT {
+			return true
+		}
+		if round >= maxEapRounds {
+			glog.Errorf("EAP authentication of %s did not complete after %d rounds", imsi, round)
+			run.recordFailure(cwfprotos.LoadStep_EAP_CHALLENGE, reasonUEError)
+			return false
+		}
+		req, err = srv.handleRadius(imsi, callingStationID, calledStationID, res)
+		if err != nil {
+			glog.Errorf("Error handling %s answer for %s: %v", step, imsi, err)
+			run.recordFailure(cwfprotos.LoadStep_EAP_CHALLENGE, reasonUEError)
+			return false
+		}
+		step = cwfprotos.LoadStep_EAP_CHALLENGE
+		if isEapChallengeResponse(eap.Packet(rfc2869.EAPMessage_Get(req))) {
+			step = cwfprotos.LoadStep_EAP_ACCEPT
+		}
+	}
+}
+
+// loadExchange sends the request of a load step and records its latency or
+// failure reason. It returns nil if the exchange failed.
+func (srv *UESimServer) loadExchange(
+	ctx context.Context,
+	run *loadRun,
+	step cwfprotos.LoadStep,
+	req *radius.Packet,
+	addr string,
+	expectedCode radius.Code,
+) *radius.Packet {
+	timeout := defaultLoadRequestTimeout
+	if run.req.GetRequestTimeoutMs() > 0 {
+		timeout = time.Duration(run.req.GetRequestTimeoutMs()) * time.Millisecond
+	}
+	exchangeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	res, err := radius.Exchange(exchangeCtx, req, addr)
+	latency := time.Since(start)
+	if ctx.Err() != nil {
+		// The load run was stopped
+		return nil
+	}
+	if err != nil {
+		reason := reasonExchangeError
+		if exchangeCtx.Err() == context.DeadlineExceeded {
+			reason = reasonTimeout
+		}
+		run.recordFailure(step, reason)
+		return nil
+	}
+	if reason := checkLoadResponse(res, expectedCode); len(reason) > 0 {
+		run.recordFailure(step, reason)
+		return nil
+	}
+	run.recordSuccess(step, latency)
+	return res
+}
+
+// checkLoadResponse returns the failure reason of an unexpected response, or
+// an empty string if the response is the expected one.
+func checkLoadResponse(res *radius.Packet, expectedCode radius.Code) string {
+	if res.Code != expectedCode {
+		if res.Code == radius.CodeAccessReject {
+			return reasonAccessReject
+		}
+		return fmt.Sprintf("%s: %s", reasonUnexpectedCode, res.Code)
+	}
+	if expectedCode == radius.CodeAccessAccept {
+		if !eap.Packet(rfc2869.EAPMessage_Get(res)).IsSuccess() {
+			return reasonEapFailure
+		}
+	}
+	return ""
+}
+
+func isAbruptDisconnect(req *cwfprotos.LoadRequest) bool {
+	switch req.GetDisconnectPattern() {
+	case cwfprotos.DisconnectPattern_ABRUPT:
+		return true
+	case cwfprotos.DisconnectPattern_MIXED:
+		return rand.Float64() < req.GetAbruptRatio()
+	default:
+		return false
+	}
+}
+
+func (run *loadRun) isRunning() bool {
+	select {
+	case <-run.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (run *loadRun) finish() {
+	run.mu.Lock()
+	run.end = time.Now()
+	run.mu.Unlock()
+	close(run.done)
+}
+
+func (run *loadRun) ueStarted() {
+	run.mu.Lock()
+	run.started++
+	run.mu.Unlock()
+}
+
+// ueDone records the outcome of a UE, unless the load run was stopped.
+func (run *loadRun) ueDone(ctx context.Context, success bool) {
+	if ctx.Err() != nil {
+		return
+	}
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	if success {
+		run.completed++
+	} else {
+		run.failed++
+	}
+}
+
+func (run *loadRun) getStep(step cwfprotos.LoadStep) *loadStepStats {
+	stats, ok := run.steps[step]
+	if !ok {
+		stats = &loadStepStats{failures: map[string]uint32{}}
+		run.steps[step] = stats
+	}
+	return stats
+}
+
+func (run *loadRun) recordSuccess(step cwfprotos.LoadStep, latency time.Duration) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	stats := run.getStep(step)
+	stats.attempts++
+	stats.latencies = append(stats.latencies, latency)
+}
+
+func (run *loadRun) recordFailure(step cwfprotos.LoadStep, reason string) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	stats := run.getStep(step)
+	stats.attempts++
+	stats.failures[reason]++
+}
+
+func (run *loadRun) report() *cwfprotos.LoadReport {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	end := run.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+	report := &cwfprotos.LoadReport{
+		Running:      run.end.IsZero(),
+		ElapsedSecs:  end.Sub(run.start).Seconds(),
+		UesStarted:   run.started,
+		UesCompleted: run.completed,
+		UesFailed:    run.failed,
+	}
+	for step := range cwfprotos.LoadStep_name {
+		if stats, ok := run.steps[cwfprotos.LoadStep(step)]; ok {
+			report.Steps = append(report.Steps, stats.toProto(cwfprotos.LoadStep(step)))
+		}
+	}
+	sort.Slice(report.Steps, func(i, j int) bool { return report.Steps[i].Step < report.Steps[j].Step })
+	return report
+}
+
+func (stats *loadStepStats) toProto(step cwfprotos.LoadStep) *cwfprotos.LoadStepReport {
+	latencies := make([]time.Duration, len(stats.latencies))
+	copy(latencies, stats.latencies)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	failures := make(map[string]uint32, len(stats.failures))
+	var failed uint32
+	for reason, count := range stats.failures {
+		failures[reason] = count
+		failed += count
+	}
+	return &cwfprotos.LoadStepReport{
+		Step:           step,
+		Attempts:       stats.attempts,
+		Successes:      uint32(len(latencies)),
+		Failures:       failed,
+		LatencyP50Ms:   getPercentileMs(latencies, 50),
+		LatencyP90Ms:   getPercentileMs(latencies, 90),
+		LatencyP99Ms:   getPercentileMs(latencies, 99),
+		LatencyMaxMs:   getPercentileMs(latencies, 100),
+		FailureReasons: failures,
+	}
+}
+
+// getPercentileMs returns the nearest-rank percentile of sorted latencies in
+// milliseconds.
+func getPercentileMs(sorted []time.Duration, percentile float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return float64(sorted[rank-1]) / float64(time.Millisecond)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicers
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"fbc/lib/go/radius"
+	cwfprotos "magma/cwf/cloud/go/protos"
+	"magma/orc8r/cloud/go/test_utils"
+	"magma/orc8r/lib/go/protos"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestGetLoadIMSI(t *testing.T) {
+	imsi, err := getLoadIMSI("001010000000009", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "001010000000010", imsi)
+
+	_, err = getLoadIMSI("99999", 1)
+	assert.Error(t, err)
+	_, err = getLoadIMSI("0010A", 0)
+	assert.Error(t, err)
+
+	assert.Equal(t, "02-00-00-00-01-00", getLoadCallingStationID(256))
+}
+
+func TestValidateLoadRequest(t *testing.T) {
+	req := &cwfprotos.LoadRequest{ImsiStart: "001010000000001", NumUes: 10, AttachRate: 1, AuthKey: make([]byte, 16)}
+	assert.NoError(t, validateLoadRequest(req))
+
+	req.NumUes = 0
+	assert.EqualError(t, validateLoadRequest(req), "Invalid Argument: num_ues must be greater than 0")
+	req.NumUes = 10
+	req.AttachRate = 0
+	assert.EqualError(t, validateLoadRequest(req), "Invalid Argument: attach_rate must be greater than 0")
+	req.AttachRate = 1
+	req.AuthKey = nil
+	assert.EqualError(t, validateLoadRequest(req), "Invalid Argument: key cannot be nil")
+}
+
+func TestGetPercentileMs(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 0.0, getPercentileMs(nil, 50))
+	assert.Equal(t, 50.0, getPercentileMs(latencies, 50))
+	assert.Equal(t, 99.0, getPercentileMs(latencies, 99))
+	assert.Equal(t, 100.0, getPercentileMs(latencies, 100))
+	assert.Equal(t, 1.0, getPercentileMs(latencies[:1], 90))
+}
+
+func TestLoad_AccessReject(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &radius.PacketServer{
+		SecretSource: radius.StaticSecretSource([]byte(defaultRadiusSecret)),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			w.Write(r.Response(radius.CodeAccessReject))
+		}),
+	}
+	go server.Serve(conn)
+	defer server.Shutdown(context.Background())
+
+	cfg := getDefaultUESimConfig()
+	cfg.radiusAuthAddress = conn.LocalAddr().String()
+	srv := &UESimServer{store: test_utils.NewSQLBlobstore(t, "uesim_load_test_blobstore"), cfg: cfg}
+
+	_, err = srv.GetLoadReport(context.Background(), &protos.Void{})
+	assert.Error(t, err)
+
+	req := &cwfprotos.LoadRequest{
+		ImsiStart:        "001010000000001",
+		NumUes:           3,
+		AttachRate:       100,
+		AuthKey:          make([]byte, 16),
+		RequestTimeoutMs: 1000,
+	}
+	_, err = srv.StartLoad(context.Background(), req)
+	assert.NoError(t, err)
+	_, err = srv.StartLoad(context.Background(), req)
+	assert.Error(t, err)
+
+	assert.Eventually(t, func() bool {
+		report, err := srv.GetLoadReport(context.Background(), &protos.Void{})
+		return err == nil && !report.GetRunning()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	report, err := srv.StopLoad(context.Background(), &protos.Void{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3), report.GetUesStarted())
+	assert.Equal(t, uint32(3), report.GetUesFailed())
+	assert.Equal(t, uint32(0), report.GetUesCompleted())
+	assert.Len(t, report.GetSteps(), 1)
+	step := report.GetSteps()[0]
+	assert.Equal(t, cwfprotos.LoadStep_EAP_IDENTITY, step.GetStep())
+	assert.Equal(t, uint32(3), step.GetAttempts())
+	assert.Equal(t, uint32(3), step.GetFailures())
+	assert.Equal(t, map[string]uint32{reasonAccessReject: 3}, step.GetFailureReasons())
+
+	// The UEs of the range are added to the store
+	ue, err := getUE(srv.store, "001010000000003")
+	assert.NoError(t, err)
+	assert.Len(t, ue.GetAuthOpc(), 16)
+}
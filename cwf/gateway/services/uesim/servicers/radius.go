@@ -36,6 +36,10 @@ const (
 
 // HandleRadius routes the Radius packet to the UE with the specified imsi.
 func (srv *UESimServer) HandleRadius(imsi string, calledStationID string, p *radius.Packet) (*radius.Packet, error) {
+	return srv.handleRadius(imsi, srv.cfg.brMac, calledStationID, p)
+}
+
+func (srv *UESimServer) handleRadius(imsi, callingStationID, calledStationID string, p *radius.Packet) (*radius.Packet, error) {
 	// todo Validate the packet. (Requires keeping state)
 
 	// Extract EAP packet.
@@ -63,7 +67,7 @@ func (srv *UESimServer) HandleRadius(imsi string, calledStationID string, p *rad
 	}
 
 	// Wrap EAP response in Radius packet.
-	res, err := srv.EapToRadius(eapRes, imsi, calledStationID, p.Identifier+1)
+	res, err := srv.eapToRadius(eapRes, imsi, callingStationID, calledStationID, p.Identifier+1)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +77,14 @@ func (srv *UESimServer) HandleRadius(imsi string, calledStationID string, p *rad
 
 // EapToRadius puts an Eap packet payload in a Radius packet.
 func (srv *UESimServer) EapToRadius(eapP eap.Packet, imsi string, calledStationID string, identifier uint8) (*radius.Packet, error) {
+	return srv.eapToRadius(eapP, imsi, srv.cfg.brMac, calledStationID, identifier)
+}
+
+func (srv *UESimServer) eapToRadius(
+	eapP eap.Packet,
+	imsi, callingStationID, calledStationID string,
+	identifier uint8,
+) (*radius.Packet, error) {
 	radiusP := radius.New(radius.CodeAccessRequest, []byte(srv.cfg.radiusSecret))
 	radiusP.Identifier = identifier
 
@@ -82,7 +94,7 @@ func (srv *UESimServer) EapToRadius(eapP eap.Packet, imsi string, calledStationI
 		[]byte(imsi + IdentityPostfix),
 	}
 	// TODO: Fetch UE MAC addr and use as CallingStationID
-	err := rfc2865.CallingStationID_SetString(radiusP, srv.cfg.brMac)
+	err := rfc2865.CallingStationID_SetString(radiusP, callingStationID)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +139,33 @@ func (srv *UESimServer) MakeAccountingStopRequest(calledStationID string) (*radi
 	return radiusP, err
 }
 
+// makeAccountingRequest creates an Accounting Request radius packet for the
+// session of a UE
+func (srv *UESimServer) makeAccountingRequest(
+	statusType rfc2866.AcctStatusType,
+	imsi, callingStationID, calledStationID, sessionID string,
+) (*radius.Packet, error) {
+	radiusP := radius.New(radius.CodeAccountingRequest, []byte(srv.cfg.radiusSecret))
+	err := rfc2866.AcctStatusType_Set(radiusP, statusType)
+	if err != nil {
+		return nil, err
+	}
+	err = rfc2865.UserName_SetString(radiusP, imsi+IdentityPostfix)
+	if err != nil {
+		return nil, err
+	}
+	err = rfc2866.AcctSessionID_SetString(radiusP, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	err = rfc2865.CallingStationID_SetString(radiusP, callingStationID)
+	if err != nil {
+		return nil, err
+	}
+	err = rfc2865.CalledStationID_SetString(radiusP, calledStationID)
+	return radiusP, err
+}
+
 // addMessageAuthenticator calculates and adds the Message-Authenticator
 // Attribute to a RADIUS packet.
 func (srv *UESimServer) addMessageAuthenticator(encoded []byte) []byte {
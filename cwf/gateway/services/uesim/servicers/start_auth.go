@@ -26,6 +26,10 @@ const (
 // CreateEAPIdentityRequest simulates starting the EAP-AKA authentication by sending a UE an
 // EAP Identity Request packet.
 func (srv *UESimServer) CreateEAPIdentityRequest(imsi, calledStationID string) (*radius.Packet, error) {
+	return srv.createEAPIdentityRequest(imsi, srv.cfg.brMac, calledStationID)
+}
+
+func (srv *UESimServer) createEAPIdentityRequest(imsi, callingStationID, calledStationID string) (*radius.Packet, error) {
 	ue, err := getUE(srv.store, imsi)
 	if err != nil {
 		return nil, err
@@ -37,5 +41,5 @@ func (srv *UESimServer) CreateEAPIdentityRequest(imsi, calledStationID string) (
 	}
 
 	// Set packet Identifier to 0.
-	return srv.EapToRadius(eapReponse, imsi, calledStationID, 0)
+	return srv.eapToRadius(eapReponse, imsi, callingStationID, calledStationID, 0)
 }
@@ -20,6 +20,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"fbc/lib/go/radius"
@@ -47,8 +48,12 @@ const (
 
 // UESimServer tracks all the UEs being simulated.
 type UESimServer struct {
-	store blobstore.BlobStorageFactory
-	cfg   *UESimConfig
+	store       blobstore.BlobStorageFactory
+	cfg         *UESimConfig
+	eapSessions eapSessions
+
+	loadMu sync.Mutex
+	load   *loadRun
 }
 
 type UESimConfig struct {
@@ -155,43 +160,32 @@ func (srv *UESimServer) AddUE(ctx context.Context, ue *cwfprotos.UEConfig) (ret
 // Input: The IMSI of the UE to try to authenticate.
 // Output: The resulting Radius packet returned by the Radius server.
 func (srv *UESimServer) Authenticate(ctx context.Context, id *cwfprotos.AuthenticateRequest) (*cwfprotos.AuthenticateResponse, error) {
-	eapIDResp, err := srv.CreateEAPIdentityRequest(id.GetImsi(), id.GetCalledStationID())
-	if err != nil {
-		return &cwfprotos.AuthenticateResponse{}, err
-	}
-
-	akaIDReq, err := radius.Exchange(context.Background(), eapIDResp, srv.cfg.radiusAuthAddress)
-	if err != nil {
-		return &cwfprotos.AuthenticateResponse{}, err
-	}
-
-	akaIDResp, err := srv.HandleRadius(id.GetImsi(), id.GetCalledStationID(), akaIDReq)
-	if err != nil {
-		return &cwfprotos.AuthenticateResponse{}, err
-	}
-
-	akaChalReq, err := radius.Exchange(context.Background(), akaIDResp, srv.cfg.radiusAuthAddress)
-	if err != nil {
-		return &cwfprotos.AuthenticateResponse{}, err
-	}
-
-	akaChalResp, err := srv.HandleRadius(id.GetImsi(), id.GetCalledStationID(), akaChalReq)
-	if err != nil {
-		return &cwfprotos.AuthenticateResponse{}, err
-	}
-
-	result, err := radius.Exchange(context.Background(), akaChalResp, srv.cfg.radiusAuthAddress)
+	req, err := srv.CreateEAPIdentityRequest(id.GetImsi(), id.GetCalledStationID())
 	if err != nil {
 		return &cwfprotos.AuthenticateResponse{}, err
 	}
 
-	resultBytes, err := result.Encode()
-	if err != nil {
-		return &cwfprotos.AuthenticateResponse{}, errors.Wrap(err, "Error encoding Radius packet")
+	// Answer the EAP requests of the method until the authentication completes
+	for round := 0; ; round++ {
+		result, err := radius.Exchange(context.Background(), req, srv.cfg.radiusAuthAddress)
+		if err != nil {
+			return &cwfprotos.AuthenticateResponse{}, err
+		}
+		if result.Code != radius.CodeAccessChallenge {
+			resultBytes, err := result.Encode()
+			if err != nil {
+				return &cwfprotos.AuthenticateResponse{}, errors.Wrap(err, "Error encoding Radius packet")
+			}
+			return &cwfprotos.AuthenticateResponse{RadiusPacket: resultBytes}, nil
+		}
+		if round >= maxEapRounds {
+			return &cwfprotos.AuthenticateResponse{}, fmt.Errorf("EAP authentication did not complete after %d rounds", round)
+		}
+		req, err = srv.HandleRadius(id.GetImsi(), id.GetCalledStationID(), result)
+		if err != nil {
+			return &cwfprotos.AuthenticateResponse{}, err
+		}
 	}
-	radiusPacket := &cwfprotos.AuthenticateResponse{RadiusPacket: resultBytes}
-
-	return radiusPacket, nil
 }
 
 func (srv *UESimServer) Disconnect(ctx context.Context, id *cwfprotos.DisconnectRequest) (*cwfprotos.DisconnectResponse, error) {
@@ -23,6 +23,8 @@ import (
 
 	"github.com/golang/glog"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -136,6 +138,18 @@ func (srv *UESimServerHssLess) GenTraffic(ctx context.Context, req *cwfprotos.Ge
 	return nil, nil
 }
 
+func (srv *UESimServerHssLess) StartLoad(ctx context.Context, req *cwfprotos.LoadRequest) (*protos.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "Load generation requires HSS authentication")
+}
+
+func (srv *UESimServerHssLess) GetLoadReport(ctx context.Context, void *protos.Void) (*cwfprotos.LoadReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "Load generation requires HSS authentication")
+}
+
+func (srv *UESimServerHssLess) StopLoad(ctx context.Context, void *protos.Void) (*cwfprotos.LoadReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "Load generation requires HSS authentication")
+}
+
 func makeSubscriberId(imsi string) *lte_protos.SubscriberID {
 	if !strings.HasPrefix(imsi, IMSI_PREFIX) {
 		imsi = IMSI_PREFIX + imsi
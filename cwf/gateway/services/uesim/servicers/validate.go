@@ -15,6 +15,7 @@ package servicers
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 
 	"magma/cwf/cloud/go/protos"
@@ -98,3 +99,26 @@ func validateUEDataForHssLess(ue *protos.UEConfig) error {
 
 	return nil
 }
+
+// validateLoadRequest ensures that a load request describes a valid range of
+// UEs and a valid attach & disconnect pattern.
+func validateLoadRequest(req *protos.LoadRequest) error {
+	if req == nil {
+		return errors.New("Invalid Argument: load request cannot be nil")
+	}
+	if req.GetNumUes() == 0 {
+		return errors.New("Invalid Argument: num_ues must be greater than 0")
+	}
+	if req.GetAttachRate() <= 0 {
+		return errors.New("Invalid Argument: attach_rate must be greater than 0")
+	}
+	if req.GetAbruptRatio() < 0 || req.GetAbruptRatio() > 1 {
+		return fmt.Errorf("Invalid Argument: abrupt_ratio must be between 0 and 1, got %v", req.GetAbruptRatio())
+	}
+	errkey := validateUEKey(req.GetAuthKey())
+	if errkey != nil {
+		return errkey
+	}
+	_, err := getLoadIMSI(req.GetImsiStart(), req.GetNumUes()-1)
+	return err
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -47,12 +48,27 @@ const (
 	DefaultApn                  = "test"
 	DefaultMsisdn               = "5100001234"
 	DefaultRatType              = 6
+
+	DefaultLoadNumUEs          = 1000
+	DefaultLoadAttachRate      = 10
+	DefaultLoadHoldTime        = 30 * time.Second
+	DefaultLoadReportInterval  = 5 * time.Second
+	DefaultLoadCalledStationID = "76-02-DE-AD-BE-FF"
 )
 
 var (
 	cmdRegistry          = new(commands.Map)
 	trafficLength uint64 = DefaultTrafficGenLengthSecs
 	maxBreak      uint64 = DefaultMaxBreakSecs
+
+	loadReq            = &protos.LoadRequest{}
+	loadNumUEs         uint
+	loadAuthKey        string
+	loadHoldTime       time.Duration
+	loadInterim        time.Duration
+	loadTimeout        time.Duration
+	loadDisconnect     string
+	loadReportInterval time.Duration
 )
 
 func init() {
@@ -92,6 +108,26 @@ func init() {
 			"\tUsage: %s [OPTIONS] %s [%s OPTIONS]\n", os.Args[0], trafficGenCmd.Name(), trafficGenCmd.Name())
 		authFlags.PrintDefaults()
 	}
+
+	loadCmd := cmdRegistry.Add("load", "Attach & detach a range of UEs and report per step statistics", handleLoadCmd)
+	loadFlags := loadCmd.Flags()
+	loadFlags.StringVar(&loadReq.ImsiStart, "imsi_start", "", "IMSI of the first UE of the range")
+	loadFlags.UintVar(&loadNumUEs, "num_ues", DefaultLoadNumUEs, "Number of UEs to simulate")
+	loadFlags.Float64Var(&loadReq.AttachRate, "rate", DefaultLoadAttachRate, "UE attaches per second")
+	loadFlags.StringVar(&loadAuthKey, "auth_key", "", "Hex encoded authentication key (k) shared by all the UEs")
+	loadFlags.Uint64Var(&loadReq.Seq, "seq", 0, "Sequence number (SEQ) the UEs start with")
+	loadFlags.DurationVar(&loadHoldTime, "hold", DefaultLoadHoldTime, "Time each UE keeps its session")
+	loadFlags.DurationVar(&loadInterim, "interim", 0, "Accounting interim update interval, no updates if 0")
+	loadFlags.StringVar(&loadDisconnect, "disconnect", "graceful", "Disconnect pattern: graceful, abrupt or mixed")
+	loadFlags.Float64Var(&loadReq.AbruptRatio, "abrupt_ratio", 0.5, "Ratio of the UEs dropping without Accounting Stop with the mixed pattern")
+	loadFlags.StringVar(&loadReq.CalledStationID, "called_station_id", DefaultLoadCalledStationID, "Called-Station-Id of the RADIUS requests")
+	loadFlags.DurationVar(&loadTimeout, "timeout", 0, "Timeout of each RADIUS exchange, 5s if 0")
+	loadFlags.DurationVar(&loadReportInterval, "report_interval", DefaultLoadReportInterval, "Interval of the progress reports")
+	loadFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS]\n", os.Args[0], loadCmd.Name(), loadCmd.Name())
+		loadFlags.PrintDefaults()
+	}
 }
 
 func handleAuthCmd(cmd *commands.Command, args []string) int {
@@ -328,13 +364,33 @@ func createUeConfig(imsi string, seq_num uint64, configMap *config.ConfigMap) (*
 		glog.Infof("RAT-Type not set for Imsi[%s], setting default Rat-Type %d", imsi, DefaultRatType)
 	}
 
-	glog.Infof("Creating UE with IMSI:[%s] MSISDN[%s] APN[%s] RAT[%d] ", imsi, msisdn, apn, rat)
+	eapMethod := protos.EapMethod_EAP_AKA
+	if method, err := configMap.GetString("eap_method"); err == nil {
+		value, ok := protos.EapMethod_value[strings.ToUpper(method)]
+		if !ok {
+			return nil, fmt.Errorf("Could not add subscriber due to unknown eap_method: %s", method)
+		}
+		eapMethod = protos.EapMethod(value)
+	}
+	authFault := protos.AuthFault_NO_FAULT
+	if fault, err := configMap.GetString("auth_fault"); err == nil {
+		value, ok := protos.AuthFault_value[strings.ToUpper(fault)]
+		if !ok {
+			return nil, fmt.Errorf("Could not add subscriber due to unknown auth_fault: %s", fault)
+		}
+		authFault = protos.AuthFault(value)
+	}
+
+	glog.Infof("Creating UE with IMSI:[%s] MSISDN[%s] APN[%s] RAT[%d] EAP[%s] Fault[%s]",
+		imsi, msisdn, apn, rat, eapMethod, authFault)
 
 	return &protos.UEConfig{
-		Imsi:    imsi,
-		AuthKey: authKeyBytes,
-		AuthOpc: opc[:],
-		Seq:     seq_num,
+		Imsi:      imsi,
+		AuthKey:   authKeyBytes,
+		AuthOpc:   opc[:],
+		Seq:       seq_num,
+		EapMethod: eapMethod,
+		AuthFault: authFault,
 		HsslessCfg: &protos.AuthenticateRequestHssLess{
 			Msisdn: msisdn,
 			Apn:    apn,
@@ -395,6 +451,69 @@ func addUes(ues []*protos.UEConfig) error {
 	return nil
 }
 
+func handleLoadCmd(cmd *commands.Command, args []string) int {
+	authKey, err := hex.DecodeString(loadAuthKey)
+	if err != nil {
+		fmt.Printf("Invalid auth_key: %s\n", err)
+		return 1
+	}
+	disconnectPattern, ok := protos.DisconnectPattern_value[strings.ToUpper(loadDisconnect)]
+	if !ok {
+		fmt.Printf("Invalid disconnect pattern: %s\n", loadDisconnect)
+		return 1
+	}
+	loadReq.NumUes = uint32(loadNumUEs)
+	loadReq.AuthKey = authKey
+	loadReq.HoldTimeMs = uint32(loadHoldTime / time.Millisecond)
+	loadReq.InterimIntervalMs = uint32(loadInterim / time.Millisecond)
+	loadReq.RequestTimeoutMs = uint32(loadTimeout / time.Millisecond)
+	loadReq.DisconnectPattern = protos.DisconnectPattern(disconnectPattern)
+
+	err = uesim.StartLoad(loadReq)
+	if err != nil {
+		fmt.Printf("Start Load Error: %s\n", err)
+		return 2
+	}
+	fmt.Printf("***** Running load of %d UEs from IMSI %s *****\n", loadReq.NumUes, loadReq.ImsiStart)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	ticker := time.NewTicker(loadReportInterval)
+	defer ticker.Stop()
+	var report *protos.LoadReport
+	for running := true; running; {
+		select {
+		case <-interrupt:
+			report, err = uesim.StopLoad()
+		case <-ticker.C:
+			report, err = uesim.GetLoadReport()
+		}
+		if err != nil {
+			fmt.Printf("Load Report Error: %s\n", err)
+			return 2
+		}
+		printLoadReport(report)
+		running = report.GetRunning()
+	}
+	if report.GetUesFailed() != 0 {
+		return 1
+	}
+	return 0
+}
+
+func printLoadReport(report *protos.LoadReport) {
+	fmt.Printf("[%.1fs] UEs started: %d, completed: %d, failed: %d\n",
+		report.GetElapsedSecs(), report.GetUesStarted(), report.GetUesCompleted(), report.GetUesFailed())
+	for _, step := range report.GetSteps() {
+		fmt.Printf("\t%-13s attempts: %d, successes: %d, failures: %d, latency ms p50: %.1f, p90: %.1f, p99: %.1f, max: %.1f\n",
+			step.GetStep(), step.GetAttempts(), step.GetSuccesses(), step.GetFailures(),
+			step.GetLatencyP50Ms(), step.GetLatencyP90Ms(), step.GetLatencyP99Ms(), step.GetLatencyMaxMs())
+		for reason, count := range step.GetFailureReasons() {
+			fmt.Printf("\t\t%s: %d\n", reason, count)
+		}
+	}
+}
+
 func handleDisconnectCmd(cmd *commands.Command, args []string) int {
 	f := cmd.Flags()
 	if f.NArg() < 1 {
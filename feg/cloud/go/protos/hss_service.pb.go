@@ -26,26 +26,277 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Subscriber records hold the IMSI, K, OPc, AMF, SQN & APNs of a subscriber.
+// CSV files start with a header naming the columns (imsi, k, opc, amf, sqn,
+// apns), APNs are separated by ';'. JSON files hold an array of records.
+type SubscriberFileFormat int32
+
+const (
+	SubscriberFileFormat_SUBSCRIBER_FILE_CSV  SubscriberFileFormat = 0
+	SubscriberFileFormat_SUBSCRIBER_FILE_JSON SubscriberFileFormat = 1
+)
+
+var SubscriberFileFormat_name = map[int32]string{
+	0: "SUBSCRIBER_FILE_CSV",
+	1: "SUBSCRIBER_FILE_JSON",
+}
+
+var SubscriberFileFormat_value = map[string]int32{
+	"SUBSCRIBER_FILE_CSV":  0,
+	"SUBSCRIBER_FILE_JSON": 1,
+}
+
+func (x SubscriberFileFormat) String() string {
+	return proto.EnumName(SubscriberFileFormat_name, int32(x))
+}
+
+func (SubscriberFileFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_6adda26d69f7818f, []int{0}
+}
+
+type SubscriberImportRequest struct {
+	Format               SubscriberFileFormat `protobuf:"varint,1,opt,name=format,proto3,enum=magma.feg.SubscriberFileFormat" json:"format,omitempty"`
+	Content              []byte               `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Overwrite            bool                 `protobuf:"varint,3,opt,name=overwrite,proto3" json:"overwrite,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SubscriberImportRequest) Reset()         { *m = SubscriberImportRequest{} }
+func (m *SubscriberImportRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscriberImportRequest) ProtoMessage()    {}
+func (*SubscriberImportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6adda26d69f7818f, []int{0}
+}
+
+func (m *SubscriberImportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriberImportRequest.Unmarshal(m, b)
+}
+func (m *SubscriberImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriberImportRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscriberImportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriberImportRequest.Merge(m, src)
+}
+func (m *SubscriberImportRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscriberImportRequest.Size(m)
+}
+func (m *SubscriberImportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriberImportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriberImportRequest proto.InternalMessageInfo
+
+func (m *SubscriberImportRequest) GetFormat() SubscriberFileFormat {
+	if m != nil {
+		return m.Format
+	}
+	return SubscriberFileFormat_SUBSCRIBER_FILE_CSV
+}
+
+func (m *SubscriberImportRequest) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *SubscriberImportRequest) GetOverwrite() bool {
+	if m != nil {
+		return m.Overwrite
+	}
+	return false
+}
+
+type SubscriberImportResult struct {
+	Added                uint32   `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Updated              uint32   `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Errors               []string `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscriberImportResult) Reset()         { *m = SubscriberImportResult{} }
+func (m *SubscriberImportResult) String() string { return proto.CompactTextString(m) }
+func (*SubscriberImportResult) ProtoMessage()    {}
+func (*SubscriberImportResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6adda26d69f7818f, []int{1}
+}
+
+func (m *SubscriberImportResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriberImportResult.Unmarshal(m, b)
+}
+func (m *SubscriberImportResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriberImportResult.Marshal(b, m, deterministic)
+}
+func (m *SubscriberImportResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriberImportResult.Merge(m, src)
+}
+func (m *SubscriberImportResult) XXX_Size() int {
+	return xxx_messageInfo_SubscriberImportResult.Size(m)
+}
+func (m *SubscriberImportResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriberImportResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriberImportResult proto.InternalMessageInfo
+
+func (m *SubscriberImportResult) GetAdded() uint32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *SubscriberImportResult) GetUpdated() uint32 {
+	if m != nil {
+		return m.Updated
+	}
+	return 0
+}
+
+func (m *SubscriberImportResult) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type SubscriberExportRequest struct {
+	Format               SubscriberFileFormat `protobuf:"varint,1,opt,name=format,proto3,enum=magma.feg.SubscriberFileFormat" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SubscriberExportRequest) Reset()         { *m = SubscriberExportRequest{} }
+func (m *SubscriberExportRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscriberExportRequest) ProtoMessage()    {}
+func (*SubscriberExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6adda26d69f7818f, []int{2}
+}
+
+func (m *SubscriberExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriberExportRequest.Unmarshal(m, b)
+}
+func (m *SubscriberExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriberExportRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscriberExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriberExportRequest.Merge(m, src)
+}
+func (m *SubscriberExportRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscriberExportRequest.Size(m)
+}
+func (m *SubscriberExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriberExportRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriberExportRequest proto.InternalMessageInfo
+
+func (m *SubscriberExportRequest) GetFormat() SubscriberFileFormat {
+	if m != nil {
+		return m.Format
+	}
+	return SubscriberFileFormat_SUBSCRIBER_FILE_CSV
+}
+
+type SubscriberExportResult struct {
+	Content              []byte   `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Count                uint32   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscriberExportResult) Reset()         { *m = SubscriberExportResult{} }
+func (m *SubscriberExportResult) String() string { return proto.CompactTextString(m) }
+func (*SubscriberExportResult) ProtoMessage()    {}
+func (*SubscriberExportResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6adda26d69f7818f, []int{3}
+}
+
+func (m *SubscriberExportResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriberExportResult.Unmarshal(m, b)
+}
+func (m *SubscriberExportResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriberExportResult.Marshal(b, m, deterministic)
+}
+func (m *SubscriberExportResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriberExportResult.Merge(m, src)
+}
+func (m *SubscriberExportResult) XXX_Size() int {
+	return xxx_messageInfo_SubscriberExportResult.Size(m)
+}
+func (m *SubscriberExportResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriberExportResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscriberExportResult proto.InternalMessageInfo
+
+func (m *SubscriberExportResult) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *SubscriberExportResult) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("magma.feg.SubscriberFileFormat", SubscriberFileFormat_name, SubscriberFileFormat_value)
+	proto.RegisterType((*SubscriberImportRequest)(nil), "magma.feg.SubscriberImportRequest")
+	proto.RegisterType((*SubscriberImportResult)(nil), "magma.feg.SubscriberImportResult")
+	proto.RegisterType((*SubscriberExportRequest)(nil), "magma.feg.SubscriberExportRequest")
+	proto.RegisterType((*SubscriberExportResult)(nil), "magma.feg.SubscriberExportResult")
+}
+
 func init() { proto.RegisterFile("feg/protos/hss_service.proto", fileDescriptor_6adda26d69f7818f) }
 
 var fileDescriptor_6adda26d69f7818f = []byte{
-	// 243 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x91, 0x41, 0x4b, 0x03, 0x31,
-	0x10, 0x85, 0x8b, 0x07, 0xc1, 0x80, 0xd8, 0x06, 0x41, 0x76, 0xd5, 0x4b, 0x7f, 0x40, 0x16, 0xf4,
-	0xe2, 0x4d, 0xad, 0x01, 0xed, 0x79, 0xd1, 0x83, 0x17, 0xc9, 0x26, 0xb3, 0x31, 0xb0, 0xe9, 0x94,
-	0xc9, 0xac, 0xff, 0xd9, 0x7f, 0x21, 0x66, 0x5b, 0xb5, 0xd0, 0x1e, 0xec, 0x29, 0xf0, 0xde, 0xe3,
-	0xcb, 0x1b, 0x9e, 0xb8, 0x68, 0xc1, 0x57, 0x4b, 0x42, 0xc6, 0x54, 0xbd, 0xa7, 0xf4, 0x96, 0x80,
-	0x3e, 0x82, 0x05, 0x95, 0x25, 0x79, 0x14, 0x8d, 0x8f, 0x46, 0xb5, 0xe0, 0xcb, 0x02, 0xc9, 0xde,
-	0xd0, 0x3a, 0x6a, 0x31, 0x46, 0x5c, 0x0c, 0xa9, 0xf2, 0xb2, 0x63, 0x58, 0x1b, 0xa9, 0x6f, 0x92,
-	0xa5, 0xd0, 0x00, 0xb9, 0x66, 0xb0, 0xaf, 0x3e, 0x0f, 0xc4, 0xc9, 0x53, 0x5d, 0x3f, 0xe0, 0xa2,
-	0x0d, 0xbe, 0x27, 0xc3, 0x48, 0xf2, 0x56, 0x1c, 0xdf, 0x3b, 0x57, 0xff, 0x84, 0x65, 0xa1, 0x86,
-	0xaf, 0x3a, 0x06, 0xf5, 0x2b, 0x6b, 0xc3, 0xa6, 0x9c, 0xac, 0xac, 0x5c, 0x40, 0xbd, 0x60, 0x70,
-	0xd3, 0x91, 0xbc, 0x13, 0x63, 0x0d, 0x1d, 0x30, 0xfc, 0x61, 0x9c, 0x6d, 0x65, 0xcc, 0xf5, 0x76,
-	0xc2, 0x4c, 0x8c, 0x9f, 0x97, 0xce, 0x6c, 0x10, 0xfe, 0xdb, 0x62, 0x2e, 0x26, 0x8f, 0xc0, 0x9b,
-	0xc9, 0xdd, 0x35, 0x76, 0xd3, 0xa7, 0x23, 0xa9, 0xc5, 0xa9, 0x06, 0x02, 0x1f, 0x12, 0x03, 0xed,
-	0x7b, 0xd4, 0xec, 0xfc, 0xb5, 0xc8, 0x6a, 0xf5, 0x3d, 0xab, 0xed, 0xb0, 0x77, 0x95, 0xc7, 0xd5,
-	0x36, 0xcd, 0x61, 0x7e, 0xaf, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xaf, 0xf4, 0x1b, 0x20, 0xf4,
-	0x01, 0x00, 0x00,
+	// 548 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x63, 0xaa, 0x06, 0x3a, 0x22, 0x90, 0x2c, 0x56, 0xe2, 0x84, 0x22, 0x82, 0x0f, 0x28,
+	0xe2, 0x90, 0x48, 0x45, 0x2a, 0xdc, 0x68, 0x12, 0x27, 0xd4, 0x08, 0x81, 0xb4, 0x56, 0x7b, 0x40,
+	0x48, 0xc1, 0x7f, 0x26, 0xae, 0x25, 0xdb, 0x1b, 0x76, 0xd7, 0x25, 0xbc, 0x02, 0xef, 0xc5, 0x7b,
+	0xa1, 0xd8, 0x4e, 0xe3, 0xb4, 0x2e, 0x85, 0xc2, 0x29, 0x1a, 0xcf, 0xb7, 0xbf, 0xf9, 0x66, 0xb2,
+	0xb3, 0xb0, 0x3f, 0x47, 0x7f, 0xb0, 0xe0, 0x4c, 0x32, 0x31, 0x38, 0x13, 0x62, 0x26, 0x90, 0x9f,
+	0x07, 0x2e, 0xf6, 0xd3, 0x4f, 0x64, 0x2f, 0xb2, 0xfd, 0xc8, 0xee, 0xcf, 0xd1, 0xef, 0xb4, 0x19,
+	0x77, 0x5f, 0xf3, 0xb5, 0xd4, 0x65, 0x51, 0xc4, 0xe2, 0x4c, 0xd5, 0x79, 0x12, 0x4a, 0x5c, 0x27,
+	0x44, 0xe2, 0x08, 0x97, 0x07, 0x0e, 0x72, 0xcf, 0xc9, 0xd3, 0x9d, 0x42, 0x09, 0x71, 0x68, 0xcf,
+	0x16, 0x9c, 0x2d, 0xbf, 0x67, 0x39, 0xfd, 0x87, 0x02, 0x2d, 0xeb, 0xe2, 0x88, 0x19, 0x2d, 0x18,
+	0x97, 0x14, 0xbf, 0x26, 0x28, 0x24, 0x79, 0x05, 0xd5, 0x39, 0xe3, 0x91, 0x2d, 0x35, 0xa5, 0xab,
+	0xf4, 0x1e, 0x1c, 0x3c, 0xed, 0x5f, 0xb8, 0xe9, 0x6f, 0xce, 0x4c, 0x83, 0x10, 0xa7, 0xa9, 0x8c,
+	0xe6, 0x72, 0xa2, 0xc1, 0x5d, 0x97, 0xc5, 0x12, 0x63, 0xa9, 0xdd, 0xe9, 0x2a, 0xbd, 0xfb, 0x74,
+	0x1d, 0x92, 0x7d, 0xd8, 0x63, 0xe7, 0xc8, 0xbf, 0xf1, 0x40, 0xa2, 0xb6, 0xd3, 0x55, 0x7a, 0xf7,
+	0xe8, 0xe6, 0x83, 0xfe, 0x05, 0x9a, 0x57, 0xbd, 0x88, 0x24, 0x94, 0x44, 0x85, 0x5d, 0xdb, 0xf3,
+	0xd0, 0x4b, 0x9d, 0xd4, 0x68, 0x16, 0xac, 0xea, 0x24, 0x0b, 0xcf, 0x96, 0xe8, 0xa5, 0x75, 0x6a,
+	0x74, 0x1d, 0x92, 0x26, 0x54, 0x91, 0x73, 0xc6, 0x85, 0xb6, 0xd3, 0xdd, 0xe9, 0xed, 0xd1, 0x3c,
+	0xd2, 0x69, 0xb1, 0xdb, 0xc9, 0xf2, 0x7f, 0x74, 0xab, 0x1f, 0x43, 0xf3, 0x2a, 0x33, 0x75, 0x5d,
+	0x98, 0x83, 0xb2, 0x3d, 0x07, 0x15, 0x76, 0x5d, 0x96, 0xe4, 0xf3, 0xa9, 0xd1, 0x2c, 0x78, 0x61,
+	0x82, 0x5a, 0x56, 0x89, 0xb4, 0xe0, 0x91, 0x75, 0x32, 0xb2, 0xc6, 0xd4, 0x1c, 0x4d, 0xe8, 0x6c,
+	0x6a, 0xbe, 0x9f, 0xcc, 0xc6, 0xd6, 0x69, 0xbd, 0x42, 0x34, 0x50, 0x2f, 0x27, 0xde, 0x59, 0x1f,
+	0x3f, 0xd4, 0x95, 0x83, 0x9f, 0x55, 0x78, 0x78, 0x6c, 0x59, 0x63, 0x16, 0xcf, 0x03, 0x3f, 0xe1,
+	0xb6, 0x64, 0x9c, 0xbc, 0x81, 0xda, 0xd0, 0xf3, 0x36, 0x15, 0x48, 0x3b, 0x6f, 0x31, 0x94, 0x58,
+	0x68, 0xd1, 0xb0, 0xa5, 0xdd, 0x69, 0xe4, 0xa9, 0xf4, 0xd2, 0xf5, 0x4f, 0x59, 0xe0, 0xe9, 0x15,
+	0x72, 0x04, 0x75, 0x03, 0x43, 0x94, 0x58, 0x60, 0xb4, 0x4a, 0x19, 0xa6, 0x51, 0x4e, 0x18, 0x41,
+	0xfd, 0x24, 0xfd, 0x8b, 0xfe, 0xc1, 0x85, 0x09, 0x8d, 0xb7, 0x28, 0xb7, 0x95, 0xd7, 0xdb, 0xb8,
+	0x9e, 0xae, 0x57, 0x88, 0x01, 0xaa, 0x81, 0x1c, 0xfd, 0x40, 0x48, 0xe4, 0xb7, 0x6e, 0xca, 0x00,
+	0xd5, 0x8c, 0x05, 0xf2, 0x3f, 0xf6, 0x54, 0x4a, 0xb1, 0x40, 0xbd, 0x3c, 0xdc, 0x94, 0xf2, 0xbc,
+	0x70, 0x0f, 0xcb, 0x04, 0xf9, 0xfd, 0x2d, 0x87, 0x0e, 0xa1, 0x41, 0x71, 0x98, 0xc8, 0x33, 0xeb,
+	0xd0, 0xb1, 0x50, 0x88, 0x80, 0xc5, 0xe2, 0x2f, 0x7d, 0x1d, 0x41, 0x7d, 0xe8, 0x30, 0x2e, 0x6f,
+	0x4f, 0xf8, 0x0c, 0x8d, 0x6c, 0x99, 0x37, 0x52, 0x41, 0xf4, 0xd2, 0xf5, 0xda, 0x7a, 0x80, 0x3a,
+	0xcf, 0x7e, 0xab, 0x59, 0xad, 0x58, 0x46, 0xcf, 0x96, 0xee, 0x66, 0xfa, 0x64, 0x79, 0x33, 0xbd,
+	0xb8, 0xc0, 0x7a, 0x65, 0xf4, 0xf8, 0x53, 0x3b, 0x55, 0x0d, 0x56, 0x6f, 0xa8, 0x1b, 0xb2, 0xc4,
+	0x1b, 0xf8, 0x2c, 0x7f, 0x4c, 0x9d, 0x6a, 0xfa, 0xfb, 0xf2, 0xd7, 0x00, 0x60, 0x86, 0x1a, 0x9c,
+	0xc4, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -78,6 +329,31 @@ type HSSConfiguratorClient interface {
 	GetSubscriberData(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos.SubscriberData, error)
 	// De-register an authenticated subscriber
 	DeregisterSubscriber(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos1.Void, error)
+	// Sends an Insert Subscriber Data Request with the subscriber's profile
+	// to the MME serving the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	InsertSubscriberData(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos1.Void, error)
+	// Sends a Delete Subscriber Data Request to the MME serving the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	DeleteSubscriberData(ctx context.Context, in *DeleteSubscriberDataRequest, opts ...grpc.CallOption) (*protos1.Void, error)
+	// Sends an S6b Re-Auth-Request for each PGW session of the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	ReAuthS6BSessions(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos1.Void, error)
+	// Sends an S6b Abort-Session-Request for each PGW session of the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	AbortS6BSessions(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos1.Void, error)
+	// Adds the subscribers of a CSV or JSON file to the store.
+	// Existing subscribers are only replaced if overwrite is set, the result
+	// lists the records which could not be imported.
+	//
+	ImportSubscribers(ctx context.Context, in *SubscriberImportRequest, opts ...grpc.CallOption) (*SubscriberImportResult, error)
+	// Exports all the subscribers of the store to a CSV or JSON file.
+	//
+	ExportSubscribers(ctx context.Context, in *SubscriberExportRequest, opts ...grpc.CallOption) (*SubscriberExportResult, error)
 }
 
 type hSSConfiguratorClient struct {
@@ -133,6 +409,60 @@ func (c *hSSConfiguratorClient) DeregisterSubscriber(ctx context.Context, in *pr
 	return out, nil
 }
 
+func (c *hSSConfiguratorClient) InsertSubscriberData(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos1.Void, error) {
+	out := new(protos1.Void)
+	err := c.cc.Invoke(ctx, "/magma.feg.HSSConfigurator/InsertSubscriberData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hSSConfiguratorClient) DeleteSubscriberData(ctx context.Context, in *DeleteSubscriberDataRequest, opts ...grpc.CallOption) (*protos1.Void, error) {
+	out := new(protos1.Void)
+	err := c.cc.Invoke(ctx, "/magma.feg.HSSConfigurator/DeleteSubscriberData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hSSConfiguratorClient) ReAuthS6BSessions(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos1.Void, error) {
+	out := new(protos1.Void)
+	err := c.cc.Invoke(ctx, "/magma.feg.HSSConfigurator/ReAuthS6bSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hSSConfiguratorClient) AbortS6BSessions(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*protos1.Void, error) {
+	out := new(protos1.Void)
+	err := c.cc.Invoke(ctx, "/magma.feg.HSSConfigurator/AbortS6bSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hSSConfiguratorClient) ImportSubscribers(ctx context.Context, in *SubscriberImportRequest, opts ...grpc.CallOption) (*SubscriberImportResult, error) {
+	out := new(SubscriberImportResult)
+	err := c.cc.Invoke(ctx, "/magma.feg.HSSConfigurator/ImportSubscribers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hSSConfiguratorClient) ExportSubscribers(ctx context.Context, in *SubscriberExportRequest, opts ...grpc.CallOption) (*SubscriberExportResult, error) {
+	out := new(SubscriberExportResult)
+	err := c.cc.Invoke(ctx, "/magma.feg.HSSConfigurator/ExportSubscribers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HSSConfiguratorServer is the server API for HSSConfigurator service.
 type HSSConfiguratorServer interface {
 	// Adds a new subscriber to the store.
@@ -153,6 +483,31 @@ type HSSConfiguratorServer interface {
 	GetSubscriberData(context.Context, *protos.SubscriberID) (*protos.SubscriberData, error)
 	// De-register an authenticated subscriber
 	DeregisterSubscriber(context.Context, *protos.SubscriberID) (*protos1.Void, error)
+	// Sends an Insert Subscriber Data Request with the subscriber's profile
+	// to the MME serving the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	InsertSubscriberData(context.Context, *protos.SubscriberID) (*protos1.Void, error)
+	// Sends a Delete Subscriber Data Request to the MME serving the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	DeleteSubscriberData(context.Context, *DeleteSubscriberDataRequest) (*protos1.Void, error)
+	// Sends an S6b Re-Auth-Request for each PGW session of the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	ReAuthS6BSessions(context.Context, *protos.SubscriberID) (*protos1.Void, error)
+	// Sends an S6b Abort-Session-Request for each PGW session of the subscriber.
+	// Throws NOT_FOUND if the subscriber is missing.
+	//
+	AbortS6BSessions(context.Context, *protos.SubscriberID) (*protos1.Void, error)
+	// Adds the subscribers of a CSV or JSON file to the store.
+	// Existing subscribers are only replaced if overwrite is set, the result
+	// lists the records which could not be imported.
+	//
+	ImportSubscribers(context.Context, *SubscriberImportRequest) (*SubscriberImportResult, error)
+	// Exports all the subscribers of the store to a CSV or JSON file.
+	//
+	ExportSubscribers(context.Context, *SubscriberExportRequest) (*SubscriberExportResult, error)
 }
 
 // UnimplementedHSSConfiguratorServer can be embedded to have forward compatible implementations.
@@ -174,6 +529,24 @@ func (*UnimplementedHSSConfiguratorServer) GetSubscriberData(ctx context.Context
 func (*UnimplementedHSSConfiguratorServer) DeregisterSubscriber(ctx context.Context, req *protos.SubscriberID) (*protos1.Void, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeregisterSubscriber not implemented")
 }
+func (*UnimplementedHSSConfiguratorServer) InsertSubscriberData(ctx context.Context, req *protos.SubscriberID) (*protos1.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertSubscriberData not implemented")
+}
+func (*UnimplementedHSSConfiguratorServer) DeleteSubscriberData(ctx context.Context, req *DeleteSubscriberDataRequest) (*protos1.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubscriberData not implemented")
+}
+func (*UnimplementedHSSConfiguratorServer) ReAuthS6BSessions(ctx context.Context, req *protos.SubscriberID) (*protos1.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReAuthS6BSessions not implemented")
+}
+func (*UnimplementedHSSConfiguratorServer) AbortS6BSessions(ctx context.Context, req *protos.SubscriberID) (*protos1.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortS6BSessions not implemented")
+}
+func (*UnimplementedHSSConfiguratorServer) ImportSubscribers(ctx context.Context, req *SubscriberImportRequest) (*SubscriberImportResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSubscribers not implemented")
+}
+func (*UnimplementedHSSConfiguratorServer) ExportSubscribers(ctx context.Context, req *SubscriberExportRequest) (*SubscriberExportResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSubscribers not implemented")
+}
 
 func RegisterHSSConfiguratorServer(s *grpc.Server, srv HSSConfiguratorServer) {
 	s.RegisterService(&_HSSConfigurator_serviceDesc, srv)
@@ -269,6 +642,114 @@ func _HSSConfigurator_DeregisterSubscriber_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HSSConfigurator_InsertSubscriberData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.SubscriberID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HSSConfiguratorServer).InsertSubscriberData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.HSSConfigurator/InsertSubscriberData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HSSConfiguratorServer).InsertSubscriberData(ctx, req.(*protos.SubscriberID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HSSConfigurator_DeleteSubscriberData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubscriberDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HSSConfiguratorServer).DeleteSubscriberData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.HSSConfigurator/DeleteSubscriberData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HSSConfiguratorServer).DeleteSubscriberData(ctx, req.(*DeleteSubscriberDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HSSConfigurator_ReAuthS6BSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.SubscriberID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HSSConfiguratorServer).ReAuthS6BSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.HSSConfigurator/ReAuthS6BSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HSSConfiguratorServer).ReAuthS6BSessions(ctx, req.(*protos.SubscriberID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HSSConfigurator_AbortS6BSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.SubscriberID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HSSConfiguratorServer).AbortS6BSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.HSSConfigurator/AbortS6BSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HSSConfiguratorServer).AbortS6BSessions(ctx, req.(*protos.SubscriberID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HSSConfigurator_ImportSubscribers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscriberImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HSSConfiguratorServer).ImportSubscribers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.HSSConfigurator/ImportSubscribers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HSSConfiguratorServer).ImportSubscribers(ctx, req.(*SubscriberImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HSSConfigurator_ExportSubscribers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscriberExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HSSConfiguratorServer).ExportSubscribers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.HSSConfigurator/ExportSubscribers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HSSConfiguratorServer).ExportSubscribers(ctx, req.(*SubscriberExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _HSSConfigurator_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.feg.HSSConfigurator",
 	HandlerType: (*HSSConfiguratorServer)(nil),
@@ -293,6 +774,30 @@ var _HSSConfigurator_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeregisterSubscriber",
 			Handler:    _HSSConfigurator_DeregisterSubscriber_Handler,
 		},
+		{
+			MethodName: "InsertSubscriberData",
+			Handler:    _HSSConfigurator_InsertSubscriberData_Handler,
+		},
+		{
+			MethodName: "DeleteSubscriberData",
+			Handler:    _HSSConfigurator_DeleteSubscriberData_Handler,
+		},
+		{
+			MethodName: "ReAuthS6bSessions",
+			Handler:    _HSSConfigurator_ReAuthS6BSessions_Handler,
+		},
+		{
+			MethodName: "AbortS6bSessions",
+			Handler:    _HSSConfigurator_AbortS6BSessions_Handler,
+		},
+		{
+			MethodName: "ImportSubscribers",
+			Handler:    _HSSConfigurator_ImportSubscribers_Handler,
+		},
+		{
+			MethodName: "ExportSubscribers",
+			Handler:    _HSSConfigurator_ExportSubscribers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "feg/protos/hss_service.proto",
@@ -1011,11 +1011,13 @@ type AAAConfig struct {
 	// Postpone Auth success until successful accounting CreateSession completion
 	CreateSessionOnAuth bool `protobuf:"varint,4,opt,name=CreateSessionOnAuth,proto3" json:"CreateSessionOnAuth,omitempty"`
 	// enable event logging for aaa events
-	EventLoggingEnabled  bool          `protobuf:"varint,5,opt,name=EventLoggingEnabled,proto3" json:"EventLoggingEnabled,omitempty"`
-	RadiusConfig         *RadiusConfig `protobuf:"bytes,6,opt,name=RadiusConfig,proto3" json:"RadiusConfig,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	EventLoggingEnabled bool          `protobuf:"varint,5,opt,name=EventLoggingEnabled,proto3" json:"EventLoggingEnabled,omitempty"`
+	RadiusConfig        *RadiusConfig `protobuf:"bytes,6,opt,name=RadiusConfig,proto3" json:"RadiusConfig,omitempty"`
+	// Session table storage: "memory" (default) or "redis" for a persistent, HA capable table
+	SessionStore         string   `protobuf:"bytes,7,opt,name=SessionStore,proto3" json:"SessionStore,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *AAAConfig) Reset()         { *m = AAAConfig{} }
@@ -1085,6 +1087,13 @@ func (m *AAAConfig) GetRadiusConfig() *RadiusConfig {
 	return nil
 }
 
+func (m *AAAConfig) GetSessionStore() string {
+	if m != nil {
+		return m.SessionStore
+	}
+	return ""
+}
+
 type RadiusConfig struct {
 	// Radius server secret
 	Secret []byte `protobuf:"bytes,1,opt,name=Secret,proto3" json:"Secret,omitempty"`
@@ -1479,19 +1488,95 @@ func (m *SCTPClientConfig) GetLocalAddress() string {
 	return ""
 }
 
+type VLRConfig struct {
+	Name   string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Client *SCTPClientConfig `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	// NRI values of the VLR, used to route UEs by the NRI of their TMSI
+	NriValues []uint32 `protobuf:"varint,3,rep,packed,name=nri_values,json=nriValues,proto3" json:"nri_values,omitempty"`
+	// hex encoded LAIs (PLMN + LAC) served by the VLR, empty if it serves all LAs
+	LocationAreas        []string `protobuf:"bytes,4,rep,name=location_areas,json=locationAreas,proto3" json:"location_areas,omitempty"`
+	Weight               uint32   `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VLRConfig) Reset()         { *m = VLRConfig{} }
+func (m *VLRConfig) String() string { return proto.CompactTextString(m) }
+func (*VLRConfig) ProtoMessage()    {}
+func (*VLRConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ac1e34e12c6f455d, []int{17}
+}
+
+func (m *VLRConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VLRConfig.Unmarshal(m, b)
+}
+func (m *VLRConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VLRConfig.Marshal(b, m, deterministic)
+}
+func (m *VLRConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VLRConfig.Merge(m, src)
+}
+func (m *VLRConfig) XXX_Size() int {
+	return xxx_messageInfo_VLRConfig.Size(m)
+}
+func (m *VLRConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_VLRConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VLRConfig proto.InternalMessageInfo
+
+func (m *VLRConfig) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VLRConfig) GetClient() *SCTPClientConfig {
+	if m != nil {
+		return m.Client
+	}
+	return nil
+}
+
+func (m *VLRConfig) GetNriValues() []uint32 {
+	if m != nil {
+		return m.NriValues
+	}
+	return nil
+}
+
+func (m *VLRConfig) GetLocationAreas() []string {
+	if m != nil {
+		return m.LocationAreas
+	}
+	return nil
+}
+
+func (m *VLRConfig) GetWeight() uint32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
 type CsfbConfig struct {
-	LogLevel             protos.LogLevel   `protobuf:"varint,1,opt,name=log_level,json=logLevel,proto3,enum=magma.orc8r.LogLevel" json:"log_level,omitempty"`
-	Client               *SCTPClientConfig `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	LogLevel protos.LogLevel   `protobuf:"varint,1,opt,name=log_level,json=logLevel,proto3,enum=magma.orc8r.LogLevel" json:"log_level,omitempty"`
+	Client   *SCTPClientConfig `protobuf:"bytes,2,opt,name=client,proto3" json:"client,omitempty"`
+	// VLR pool (MSC pool), client is used as a single VLR if it's empty
+	VlrPool              []*VLRConfig `protobuf:"bytes,3,rep,name=vlr_pool,json=vlrPool,proto3" json:"vlr_pool,omitempty"`
+	NriBitLength         uint32       `protobuf:"varint,4,opt,name=nri_bit_length,json=nriBitLength,proto3" json:"nri_bit_length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *CsfbConfig) Reset()         { *m = CsfbConfig{} }
 func (m *CsfbConfig) String() string { return proto.CompactTextString(m) }
 func (*CsfbConfig) ProtoMessage()    {}
 func (*CsfbConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ac1e34e12c6f455d, []int{17}
+	return fileDescriptor_ac1e34e12c6f455d, []int{18}
 }
 
 func (m *CsfbConfig) XXX_Unmarshal(b []byte) error {
@@ -1526,6 +1611,20 @@ func (m *CsfbConfig) GetClient() *SCTPClientConfig {
 	return nil
 }
 
+func (m *CsfbConfig) GetVlrPool() []*VLRConfig {
+	if m != nil {
+		return m.VlrPool
+	}
+	return nil
+}
+
+func (m *CsfbConfig) GetNriBitLength() uint32 {
+	if m != nil {
+		return m.NriBitLength
+	}
+	return 0
+}
+
 type EnvoyControllerConfig struct {
 	LogLevel             protos.LogLevel `protobuf:"varint,1,opt,name=log_level,json=logLevel,proto3,enum=magma.orc8r.LogLevel" json:"log_level,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
@@ -1537,7 +1636,7 @@ func (m *EnvoyControllerConfig) Reset()         { *m = EnvoyControllerConfig{} }
 func (m *EnvoyControllerConfig) String() string { return proto.CompactTextString(m) }
 func (*EnvoyControllerConfig) ProtoMessage()    {}
 func (*EnvoyControllerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ac1e34e12c6f455d, []int{18}
+	return fileDescriptor_ac1e34e12c6f455d, []int{19}
 }
 
 func (m *EnvoyControllerConfig) XXX_Unmarshal(b []byte) error {
@@ -1578,7 +1677,7 @@ func (m *S8Config) Reset()         { *m = S8Config{} }
 func (m *S8Config) String() string { return proto.CompactTextString(m) }
 func (*S8Config) ProtoMessage()    {}
 func (*S8Config) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ac1e34e12c6f455d, []int{19}
+	return fileDescriptor_ac1e34e12c6f455d, []int{20}
 }
 
 func (m *S8Config) XXX_Unmarshal(b []byte) error {
@@ -1642,6 +1741,7 @@ func init() {
 	proto.RegisterType((*HSSConfig_SubscriptionProfile)(nil), "magma.mconfig.HSSConfig.SubscriptionProfile")
 	proto.RegisterType((*RadiusdConfig)(nil), "magma.mconfig.RadiusdConfig")
 	proto.RegisterType((*SCTPClientConfig)(nil), "magma.mconfig.SCTPClientConfig")
+	proto.RegisterType((*VLRConfig)(nil), "magma.mconfig.VLRConfig")
 	proto.RegisterType((*CsfbConfig)(nil), "magma.mconfig.CsfbConfig")
 	proto.RegisterType((*EnvoyControllerConfig)(nil), "magma.mconfig.EnvoyControllerConfig")
 	proto.RegisterType((*S8Config)(nil), "magma.mconfig.S8Config")
@@ -1650,119 +1750,127 @@ func init() {
 func init() { proto.RegisterFile("feg/protos/mconfig/mconfigs.proto", fileDescriptor_ac1e34e12c6f455d) }
 
 var fileDescriptor_ac1e34e12c6f455d = []byte{
-	// 1818 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0xcd, 0x6f, 0x1b, 0xb9,
-	0x15, 0xaf, 0x24, 0x7f, 0x48, 0x4f, 0xb2, 0x2d, 0xd3, 0xf9, 0x50, 0xbc, 0x49, 0xe3, 0xcc, 0x76,
-	0x51, 0x77, 0x77, 0x2b, 0x6f, 0x5d, 0x20, 0x4d, 0x83, 0x45, 0x03, 0x45, 0x56, 0x12, 0x63, 0xed,
-	0xc4, 0x98, 0x71, 0x16, 0x68, 0x51, 0x74, 0x40, 0xcf, 0x50, 0x23, 0x22, 0x33, 0xc3, 0x29, 0x87,
-	0x23, 0x5b, 0xbd, 0xb6, 0xa7, 0xb6, 0xa7, 0x3d, 0xf7, 0x1f, 0xe8, 0xad, 0x87, 0xbd, 0x17, 0xe8,
-	0xa1, 0x40, 0x7b, 0xeb, 0x7f, 0x54, 0xf0, 0x63, 0x46, 0x1f, 0x96, 0x8d, 0x38, 0x6a, 0x0f, 0x7b,
-	0x92, 0xf8, 0x7e, 0x3f, 0x92, 0x8f, 0x3f, 0x3e, 0x3e, 0xf2, 0x0d, 0x3c, 0xea, 0x93, 0x60, 0x2f,
-	0xe1, 0x4c, 0xb0, 0x74, 0x2f, 0xf2, 0x58, 0xdc, 0xa7, 0x41, 0xfe, 0x9b, 0xb6, 0x95, 0x1d, 0xad,
-	0x45, 0x38, 0x88, 0x70, 0xdb, 0x58, 0xb7, 0xef, 0x31, 0xee, 0x3d, 0xe1, 0x79, 0x1f, 0x8f, 0x45,
-	0x11, 0x8b, 0x35, 0xd3, 0xfa, 0x47, 0x05, 0x9a, 0x07, 0x14, 0x47, 0xdd, 0x90, 0x92, 0x58, 0x74,
-	0x15, 0x1f, 0x6d, 0x43, 0x55, 0xa1, 0x1e, 0x0b, 0x5b, 0xa5, 0x9d, 0xd2, 0x6e, 0xcd, 0x2e, 0xda,
-	0xa8, 0x05, 0xab, 0xd8, 0xf7, 0x39, 0x49, 0xd3, 0x56, 0x59, 0x41, 0x79, 0x13, 0xed, 0x40, 0x9d,
-	0x13, 0xc1, 0x71, 0x9c, 0x46, 0x54, 0xa4, 0xad, 0xca, 0x4e, 0x69, 0x77, 0xcd, 0x9e, 0x34, 0xa1,
-	0xcf, 0x60, 0xf3, 0x1c, 0x0b, 0x6f, 0xe0, 0xb3, 0xc0, 0xa5, 0xb1, 0x20, 0x7c, 0x88, 0xc3, 0xd6,
-	0x92, 0xe2, 0x35, 0x73, 0xe0, 0xd0, 0xd8, 0xd1, 0x43, 0x3d, 0xdc, 0xc8, 0xf5, 0x58, 0x16, 0x8b,
-	0xd6, 0xb2, 0xa2, 0x81, 0x32, 0x75, 0xa5, 0x05, 0x7d, 0x0c, 0x6b, 0x21, 0xf3, 0x70, 0xe8, 0xe6,
-	0xfe, 0xac, 0x28, 0x7f, 0x1a, 0xca, 0xd8, 0x31, 0x4e, 0x3d, 0x82, 0x46, 0xc2, 0x99, 0x9f, 0x79,
-	0xc2, 0x8d, 0x71, 0x44, 0x5a, 0xab, 0x8a, 0x53, 0x37, 0xb6, 0xd7, 0x38, 0x22, 0xe8, 0x16, 0x2c,
-	0x73, 0x82, 0xc3, 0xa8, 0x55, 0x55, 0x98, 0x6e, 0x20, 0x04, 0x4b, 0x03, 0x96, 0x8a, 0x56, 0x4d,
-	0x19, 0xd5, 0x7f, 0xf4, 0x00, 0xc0, 0x27, 0xa9, 0x70, 0x35, 0x1d, 0x14, 0x52, 0x93, 0x16, 0x5b,
-	0x75, 0xf9, 0x08, 0x54, 0xc3, 0x55, 0xfd, 0xea, 0x5a, 0x37, 0x69, 0x78, 0x25, 0xfb, 0x7e, 0x0a,
-	0x9b, 0x3e, 0x4d, 0xf1, 0x59, 0x48, 0xdc, 0x31, 0xa9, 0xb1, 0x53, 0xda, 0xad, 0xda, 0x1b, 0x06,
-	0x38, 0xc8, 0xb9, 0x6d, 0xd8, 0x62, 0x43, 0xc2, 0xcf, 0x39, 0x15, 0x93, 0xec, 0x35, 0xc5, 0xde,
-	0x2c, 0xa0, 0x9c, 0x6f, 0xfd, 0xb5, 0xa4, 0x37, 0xd1, 0x21, 0x7c, 0x48, 0xf8, 0x42, 0x9b, 0x78,
-	0x49, 0xd4, 0xca, 0x1c, 0x51, 0xa7, 0x16, 0xba, 0x34, 0xb3, 0xd0, 0x69, 0x91, 0x96, 0x67, 0x44,
-	0xb2, 0xfe, 0x58, 0x86, 0x9a, 0xf3, 0x18, 0x1b, 0x27, 0xf7, 0xa1, 0x16, 0xb2, 0xc0, 0x0d, 0xc9,
-	0x90, 0x68, 0x2f, 0xd7, 0xf7, 0x6f, 0xb7, 0x75, 0xf0, 0xaa, 0x98, 0x6d, 0x1f, 0xb1, 0xe0, 0x48,
-	0x82, 0x76, 0x35, 0x34, 0xff, 0xd0, 0xcf, 0x60, 0x25, 0x55, 0x0b, 0x55, 0x83, 0xd7, 0xf7, 0x1f,
-	0xb6, 0xa7, 0xa2, 0xbd, 0x3d, 0x1b, 0xce, 0xb6, 0xa1, 0xa3, 0xa7, 0x70, 0x8f, 0x93, 0xdf, 0x66,
-	0xd2, 0xb9, 0x3e, 0xa6, 0x61, 0xc6, 0x89, 0x2b, 0x06, 0x9c, 0xa4, 0x03, 0x16, 0xfa, 0x2a, 0x78,
-	0xca, 0xf6, 0x5d, 0x43, 0x78, 0xa1, 0xf1, 0xd3, 0x1c, 0x96, 0x7d, 0x23, 0x1a, 0xd3, 0x28, 0x8b,
-	0xdc, 0x7c, 0x8c, 0x71, 0xdf, 0x55, 0x15, 0x9b, 0x77, 0x0d, 0xc1, 0xd6, 0xf8, 0xb8, 0x6f, 0x0b,
-	0x56, 0x4f, 0xc2, 0x28, 0x3e, 0xf4, 0xd3, 0x56, 0x75, 0xa7, 0x22, 0xd5, 0x36, 0x4d, 0xeb, 0x2f,
-	0x25, 0x58, 0xff, 0x9a, 0x72, 0x91, 0xe1, 0xb0, 0x93, 0xc4, 0x76, 0x16, 0x12, 0x29, 0x1f, 0x4e,
-	0x62, 0xb7, 0x4f, 0x43, 0x41, 0xb8, 0xd9, 0xb8, 0x1a, 0x4e, 0xe2, 0x17, 0xca, 0x20, 0xf7, 0x47,
-	0xc2, 0x45, 0x0c, 0x98, 0xfd, 0x6b, 0xe0, 0x24, 0x7e, 0x93, 0xdb, 0xd0, 0x0b, 0x78, 0xe8, 0x0d,
-	0x30, 0x0f, 0x68, 0x1c, 0xb8, 0xf2, 0x0f, 0xf6, 0x04, 0xe1, 0x34, 0x15, 0xd4, 0x4b, 0xf3, 0x81,
-	0xf5, 0xb6, 0x3e, 0xc8, 0x69, 0xdd, 0x69, 0x96, 0x9e, 0xcc, 0xfa, 0x53, 0x19, 0xaa, 0x2f, 0x2f,
-	0xcc, 0x56, 0x8d, 0x65, 0x2f, 0xdd, 0x4c, 0x76, 0x0b, 0x1a, 0x85, 0x6b, 0x9d, 0x24, 0xce, 0x3d,
-	0x9e, 0xb4, 0xa1, 0x9f, 0xc3, 0xaa, 0x66, 0xcb, 0x80, 0xab, 0xbc, 0xcf, 0xe8, 0x39, 0x1f, 0xdd,
-	0x87, 0xda, 0x81, 0x3e, 0x3f, 0x2f, 0x2f, 0x54, 0x30, 0x56, 0xed, 0xb1, 0x01, 0x1d, 0xc2, 0xe6,
-	0x50, 0x0b, 0xec, 0x4a, 0xdd, 0x78, 0x16, 0x92, 0xb4, 0xb5, 0xac, 0xa6, 0x78, 0x30, 0x33, 0xc5,
-	0xf4, 0x46, 0xd8, 0x1b, 0xc3, 0xa9, 0x76, 0x6a, 0xfd, 0x4b, 0xaa, 0x31, 0x5a, 0x54, 0x8d, 0x2f,
-	0xa1, 0x4e, 0x63, 0x2a, 0xdc, 0x88, 0x88, 0x01, 0xf3, 0x95, 0x18, 0xeb, 0xfb, 0x1f, 0xcd, 0xf4,
-	0x7e, 0x39, 0x3a, 0x8c, 0xa9, 0x38, 0x56, 0x14, 0x1b, 0x68, 0xf1, 0xff, 0x92, 0x96, 0x95, 0xeb,
-	0xb5, 0x5c, 0xfa, 0x70, 0x2d, 0x47, 0xea, 0x74, 0x4d, 0x68, 0x39, 0x9a, 0xaf, 0xe5, 0xca, 0x07,
-	0x69, 0xf9, 0x4d, 0x19, 0x90, 0x43, 0xd2, 0x94, 0xb2, 0xf8, 0x84, 0xb3, 0x8b, 0xd1, 0x02, 0xe9,
-	0xe0, 0x87, 0x50, 0x0e, 0x2e, 0x4c, 0x2a, 0xb8, 0x3b, 0xab, 0xa3, 0x09, 0x5e, 0xbb, 0x1c, 0x5c,
-	0x28, 0xe2, 0x48, 0x9d, 0xf3, 0x39, 0xc4, 0x51, 0x41, 0x1c, 0x5d, 0x9f, 0x27, 0x56, 0x17, 0xc8,
-	0x13, 0xd5, 0x6b, 0xf3, 0x84, 0xf5, 0xe7, 0x25, 0xa8, 0x39, 0xe7, 0x17, 0xff, 0x93, 0xd4, 0x58,
-	0xbe, 0x59, 0x54, 0xfe, 0x04, 0x6e, 0x0d, 0x09, 0xa7, 0xfd, 0x91, 0x8b, 0x33, 0x31, 0x60, 0x9c,
-	0xfe, 0x0e, 0x0b, 0xca, 0x74, 0x7c, 0x55, 0xed, 0x2d, 0x8d, 0x75, 0x26, 0x21, 0xb4, 0x0b, 0x1b,
-	0x5d, 0xec, 0x0d, 0xc8, 0xe9, 0xe9, 0x91, 0x43, 0x3c, 0x16, 0xfb, 0xa9, 0xb9, 0xca, 0x67, 0xcd,
-	0xd7, 0xeb, 0xb9, 0xbc, 0x80, 0x9e, 0x2b, 0xd7, 0xe7, 0xdd, 0x5d, 0x68, 0x72, 0x12, 0xd0, 0x54,
-	0x10, 0xee, 0xb2, 0x58, 0xad, 0x4c, 0x6d, 0x5f, 0xd5, 0x5e, 0xcf, 0xed, 0x6f, 0x62, 0xb9, 0x28,
-	0xf4, 0x18, 0xee, 0xfa, 0x84, 0xd3, 0x21, 0x71, 0xb3, 0xb8, 0xe8, 0x32, 0x7e, 0x14, 0x54, 0xed,
-	0xdb, 0x1a, 0x7e, 0x5b, 0xa0, 0xfa, 0xc6, 0xdf, 0x81, 0xc6, 0x20, 0xe4, 0x6e, 0x12, 0x46, 0xb1,
-	0x4b, 0xfd, 0xb4, 0x55, 0x53, 0xe9, 0x1d, 0x06, 0x21, 0x37, 0x19, 0x7e, 0xf2, 0x30, 0xc2, 0xcd,
-	0x0e, 0xa3, 0xf5, 0xfb, 0x0a, 0x34, 0x7a, 0x38, 0xe9, 0xbc, 0x5b, 0xe4, 0xb2, 0xfc, 0x05, 0xac,
-	0x0a, 0x1a, 0x11, 0x96, 0x09, 0x13, 0x12, 0x3f, 0x98, 0x99, 0x7f, 0x72, 0x86, 0xf6, 0xa9, 0xa6,
-	0xa6, 0x76, 0xde, 0x69, 0xf2, 0xee, 0xaa, 0x4c, 0xdd, 0x5d, 0xe8, 0x0e, 0xac, 0xbc, 0x4d, 0x89,
-	0xf3, 0x18, 0x9b, 0xa4, 0x6b, 0x5a, 0xd2, 0x7e, 0x1c, 0x7b, 0x47, 0x24, 0x56, 0x5b, 0xbb, 0x6c,
-	0x9b, 0xd6, 0xf6, 0xb7, 0x25, 0xa8, 0xe6, 0xe3, 0xcb, 0xb7, 0x62, 0x77, 0x80, 0xc3, 0x90, 0xc4,
-	0x01, 0x39, 0x4e, 0xd5, 0x62, 0xd6, 0xec, 0x49, 0x13, 0xfa, 0x02, 0xb6, 0x7a, 0x9c, 0x33, 0xfe,
-	0x9a, 0x09, 0xda, 0xa7, 0x9e, 0x8a, 0xb9, 0x63, 0xfd, 0x5c, 0x59, 0xb3, 0xe7, 0x41, 0x32, 0x79,
-	0x99, 0x94, 0x72, 0x9c, 0xbf, 0x3e, 0xc7, 0x06, 0xf4, 0x18, 0xee, 0x98, 0x86, 0xdc, 0x71, 0x12,
-	0x0b, 0xd9, 0x91, 0xf8, 0xc7, 0x79, 0xd4, 0x5e, 0x81, 0x5a, 0x7f, 0x2f, 0xc1, 0x56, 0x0f, 0x27,
-	0x27, 0x9c, 0x0d, 0xa9, 0x4f, 0xf8, 0x77, 0x70, 0x05, 0xff, 0x2e, 0xa9, 0x38, 0x72, 0x68, 0xb4,
-	0x40, 0x1c, 0x7d, 0x39, 0x1b, 0x47, 0xd6, 0xe5, 0x38, 0x9a, 0xd5, 0xe8, 0xff, 0x10, 0x45, 0xd6,
-	0x3f, 0xcb, 0x50, 0xeb, 0x74, 0x3a, 0x0b, 0xac, 0x64, 0x1f, 0x6e, 0x1d, 0xfa, 0x21, 0x31, 0x62,
-	0x19, 0x5f, 0x8b, 0x7d, 0x99, 0x8b, 0xa1, 0xcf, 0x61, 0xb3, 0xe3, 0xa9, 0x3a, 0x84, 0xc6, 0x41,
-	0x2f, 0x96, 0xf7, 0xa1, 0x6f, 0x72, 0xe3, 0x65, 0x40, 0x6e, 0x7c, 0x97, 0x13, 0x2c, 0xf2, 0x71,
-	0x74, 0x92, 0x31, 0x0b, 0x9c, 0x07, 0xa9, 0x50, 0x19, 0x92, 0x58, 0x1c, 0xb1, 0x20, 0x98, 0x98,
-	0x41, 0xdf, 0xc0, 0xf3, 0x20, 0xf4, 0x0c, 0x1a, 0x36, 0xf6, 0x69, 0x96, 0x6a, 0x25, 0xcc, 0xb5,
-	0x36, 0xfb, 0x8e, 0x98, 0xa4, 0xd8, 0x53, 0x1d, 0xac, 0x6f, 0x4a, 0xd3, 0x23, 0x48, 0xc5, 0x1d,
-	0xe2, 0x71, 0x22, 0x94, 0x90, 0x0d, 0xdb, 0xb4, 0xe4, 0xde, 0xbd, 0x26, 0xe2, 0x9c, 0xf1, 0x77,
-	0x79, 0xad, 0x60, 0x9a, 0xb2, 0xc2, 0x90, 0xde, 0xcb, 0xaa, 0xc0, 0x3c, 0x44, 0x8a, 0xb6, 0xc2,
-	0x3c, 0x4f, 0x28, 0xcc, 0x54, 0x08, 0x79, 0x5b, 0x8e, 0x78, 0xd0, 0xe9, 0x29, 0x48, 0x97, 0x07,
-	0x79, 0xd3, 0xfa, 0x5b, 0x19, 0xb6, 0x5e, 0x62, 0x41, 0xce, 0xf1, 0xe8, 0x15, 0xc1, 0xa1, 0x18,
-	0x18, 0xdf, 0x3e, 0x83, 0x4d, 0x99, 0xfd, 0x29, 0x27, 0xbe, 0x2b, 0xd3, 0x23, 0xf5, 0x88, 0x3c,
-	0x72, 0x32, 0x92, 0x9a, 0x39, 0xe0, 0x18, 0x3b, 0xfa, 0x02, 0x6e, 0x65, 0x89, 0x8f, 0x05, 0x29,
-	0x6a, 0x4c, 0x37, 0x25, 0x5e, 0xbe, 0xc1, 0x48, 0x63, 0x79, 0x99, 0xe9, 0x10, 0x2f, 0x45, 0x4f,
-	0xa0, 0x65, 0x7a, 0x5c, 0xbe, 0x9f, 0xf4, 0x31, 0xbc, 0xa3, 0xf1, 0x4b, 0xd7, 0xd3, 0x33, 0xb8,
-	0xef, 0x85, 0x2c, 0xf3, 0x5d, 0x9f, 0xa6, 0x1e, 0x8b, 0x63, 0xe2, 0x09, 0x37, 0x21, 0x9c, 0x32,
-	0x5f, 0xcf, 0xa9, 0x4f, 0xe6, 0x3d, 0xc5, 0x39, 0x28, 0x28, 0x27, 0x8a, 0xa1, 0xa6, 0x7e, 0x06,
-	0xf7, 0x75, 0xbd, 0x75, 0xc5, 0x00, 0xba, 0xec, 0xbd, 0xa7, 0x38, 0xf3, 0x06, 0xb0, 0xbe, 0x5d,
-	0x82, 0xda, 0x2b, 0xc7, 0xb9, 0xc1, 0xb3, 0x74, 0xb2, 0x4a, 0x2c, 0x1e, 0x00, 0xdf, 0x87, 0x7a,
-	0x28, 0x88, 0xba, 0x23, 0x5d, 0x96, 0x28, 0xad, 0x1a, 0x76, 0x2d, 0x14, 0x44, 0xee, 0xe8, 0x9b,
-	0x44, 0xde, 0x74, 0x05, 0x8e, 0xa3, 0xbe, 0x92, 0xa5, 0x61, 0x83, 0x21, 0x74, 0xa2, 0x3e, 0x3a,
-	0x82, 0x46, 0x9a, 0x9d, 0xb9, 0x09, 0x67, 0x7d, 0x2a, 0x1f, 0x86, 0xfa, 0xed, 0xf9, 0xa3, 0x19,
-	0x07, 0x0a, 0x57, 0xdb, 0x4e, 0x76, 0x76, 0x62, 0xb8, 0xbd, 0x58, 0xf0, 0x91, 0x5d, 0x4f, 0xc7,
-	0x16, 0xf4, 0x6b, 0xd8, 0xf2, 0x49, 0x1f, 0x67, 0xa1, 0x70, 0x27, 0x46, 0x35, 0xcf, 0xbc, 0xcf,
-	0xaf, 0x1b, 0x34, 0xf5, 0x38, 0x4d, 0x84, 0x7e, 0x58, 0xca, 0x3e, 0xf6, 0xa6, 0x19, 0x68, 0x3c,
-	0x21, 0xfa, 0x31, 0xa0, 0x54, 0x70, 0x82, 0x23, 0x39, 0xb8, 0xec, 0x70, 0x26, 0x2f, 0xe8, 0x15,
-	0x7d, 0xa0, 0x35, 0xe2, 0x8c, 0x81, 0x6d, 0x0f, 0xb6, 0xe6, 0x0c, 0x8c, 0x3e, 0x81, 0x8d, 0x08,
-	0x5f, 0xb8, 0x59, 0xe8, 0x9e, 0x51, 0xe1, 0x72, 0x2c, 0x88, 0x52, 0x7d, 0xc9, 0x6e, 0x44, 0xf8,
-	0xe2, 0x6d, 0xf8, 0x9c, 0x0a, 0x1b, 0x8b, 0x82, 0xe6, 0x4f, 0xd0, 0xca, 0x05, 0xed, 0x20, 0xa7,
-	0x6d, 0x87, 0xd0, 0x9c, 0x95, 0x04, 0x35, 0xa1, 0xf2, 0x8e, 0x8c, 0x4c, 0x15, 0x28, 0xff, 0xa2,
-	0xe7, 0xb0, 0x3c, 0xc4, 0x61, 0x46, 0x4c, 0x16, 0xbe, 0x99, 0x12, 0xba, 0xeb, 0xd3, 0xf2, 0x93,
-	0x92, 0xf5, 0x9f, 0x12, 0xac, 0xe9, 0xe3, 0xef, 0x9b, 0xd0, 0x69, 0xc3, 0x16, 0x57, 0x06, 0x59,
-	0x9a, 0x70, 0x59, 0x2a, 0x26, 0x8c, 0x0b, 0x73, 0xb1, 0x6d, 0x6a, 0xe8, 0x58, 0x23, 0x27, 0x8c,
-	0x8b, 0x79, 0x7c, 0x2c, 0x06, 0x26, 0x47, 0xcc, 0xf0, 0xb1, 0xca, 0x71, 0xf3, 0x8f, 0x65, 0xe5,
-	0xca, 0x63, 0x79, 0x79, 0x86, 0x89, 0x0f, 0x0e, 0xd3, 0x33, 0xa8, 0xcf, 0x20, 0xbf, 0x81, 0xa6,
-	0xd3, 0x3d, 0x3d, 0x99, 0xfa, 0x94, 0xf5, 0x09, 0xac, 0xeb, 0x08, 0x2f, 0x3e, 0x68, 0x68, 0x31,
-	0xd7, 0xb4, 0xb5, 0x73, 0xd5, 0x67, 0x8f, 0xf2, 0xe5, 0xcf, 0x1e, 0xd6, 0x08, 0xa0, 0x9b, 0xf6,
-	0xcf, 0x16, 0x7b, 0x9f, 0x7b, 0xca, 0xbb, 0x2b, 0xde, 0xe7, 0xb3, 0xee, 0xdb, 0x86, 0x6e, 0x7d,
-	0x05, 0xb7, 0x7b, 0xf1, 0x90, 0xc9, 0x2a, 0x45, 0x70, 0x16, 0x86, 0xc5, 0x57, 0x9e, 0x0f, 0xf0,
-	0xc2, 0xfa, 0x43, 0x09, 0xaa, 0xce, 0x93, 0x05, 0x96, 0xf1, 0x3e, 0x6a, 0xa1, 0x87, 0x50, 0x4f,
-	0x82, 0xf3, 0x99, 0xef, 0x48, 0x90, 0x04, 0xe7, 0x86, 0xf0, 0xe9, 0x53, 0x68, 0x4c, 0xd6, 0xb9,
-	0xa8, 0x01, 0x55, 0xbb, 0xe7, 0xf4, 0xec, 0xaf, 0x7b, 0x07, 0xcd, 0xef, 0xa1, 0x0d, 0xa8, 0x9f,
-	0xf4, 0x6c, 0xd7, 0xe9, 0x39, 0xce, 0xe1, 0x9b, 0xd7, 0xcd, 0x12, 0xaa, 0xc3, 0xaa, 0x34, 0x7c,
-	0xd5, 0xfb, 0x65, 0xb3, 0xfc, 0xfc, 0xe3, 0x5f, 0x3d, 0x52, 0x3e, 0xee, 0xf5, 0x49, 0xb0, 0xa7,
-	0xb2, 0xeb, 0x5e, 0xc0, 0x66, 0x3e, 0x8a, 0x9e, 0xad, 0xa8, 0xf6, 0x4f, 0xff, 0x1b, 0x00, 0x00,
-	0xff, 0xff, 0xcf, 0xb9, 0x3c, 0xc2, 0x31, 0x15, 0x00, 0x00,
+	// 1937 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x58, 0x4b, 0x6f, 0x1b, 0xc9,
+	0x11, 0x0e, 0x49, 0x3d, 0xc8, 0x12, 0x29, 0x53, 0x2d, 0x3f, 0x68, 0xaf, 0x1d, 0xcb, 0xb3, 0xbb,
+	0x88, 0xb2, 0xbb, 0xa1, 0x37, 0x5a, 0xc0, 0x71, 0x8c, 0x45, 0x0c, 0x5a, 0xa2, 0x6d, 0x61, 0x25,
+	0x5b, 0xe8, 0x91, 0x0d, 0x24, 0x08, 0x32, 0x68, 0xcd, 0x34, 0xc9, 0x86, 0x7b, 0xa6, 0x99, 0x9e,
+	0x1e, 0x4a, 0xcc, 0x35, 0x39, 0x25, 0x39, 0xed, 0x39, 0x7f, 0x20, 0xb7, 0x1c, 0x16, 0xb9, 0x06,
+	0xc8, 0x2d, 0xb9, 0x05, 0xc8, 0xaf, 0xc8, 0xaf, 0x08, 0xfa, 0x31, 0x7c, 0x89, 0x12, 0xd6, 0x62,
+	0x72, 0xd8, 0xd3, 0x4c, 0x57, 0x7d, 0xfd, 0xfa, 0xaa, 0xba, 0xaa, 0xab, 0xe1, 0x41, 0x87, 0x76,
+	0x1f, 0xf6, 0xa5, 0x50, 0x22, 0x7d, 0x18, 0x87, 0x22, 0xe9, 0xb0, 0x6e, 0xfe, 0x4d, 0x9b, 0x46,
+	0x8e, 0x6a, 0x31, 0xe9, 0xc6, 0xa4, 0xe9, 0xa4, 0x77, 0x6e, 0x0b, 0x19, 0x3e, 0x96, 0x79, 0x9f,
+	0x50, 0xc4, 0xb1, 0x48, 0x2c, 0xd2, 0xfb, 0x7b, 0x09, 0xea, 0x7b, 0x8c, 0xc4, 0xbb, 0x9c, 0xd1,
+	0x44, 0xed, 0x1a, 0x3c, 0xba, 0x03, 0x65, 0xa3, 0x0d, 0x05, 0x6f, 0x14, 0xb6, 0x0a, 0xdb, 0x15,
+	0x3c, 0x6a, 0xa3, 0x06, 0xac, 0x92, 0x28, 0x92, 0x34, 0x4d, 0x1b, 0x45, 0xa3, 0xca, 0x9b, 0x68,
+	0x0b, 0xd6, 0x24, 0x55, 0x92, 0x24, 0x69, 0xcc, 0x54, 0xda, 0x28, 0x6d, 0x15, 0xb6, 0x6b, 0x78,
+	0x52, 0x84, 0x3e, 0x85, 0x8d, 0x53, 0xa2, 0xc2, 0x5e, 0x24, 0xba, 0x01, 0x4b, 0x14, 0x95, 0x03,
+	0xc2, 0x1b, 0x4b, 0x06, 0x57, 0xcf, 0x15, 0xfb, 0x4e, 0x8e, 0xee, 0xdb, 0xe1, 0x86, 0x41, 0x28,
+	0xb2, 0x44, 0x35, 0x96, 0x0d, 0x0c, 0x8c, 0x68, 0x57, 0x4b, 0xd0, 0x87, 0x50, 0xe3, 0x22, 0x24,
+	0x3c, 0xc8, 0xd7, 0xb3, 0x62, 0xd6, 0x53, 0x35, 0xc2, 0x96, 0x5b, 0xd4, 0x03, 0xa8, 0xf6, 0xa5,
+	0x88, 0xb2, 0x50, 0x05, 0x09, 0x89, 0x69, 0x63, 0xd5, 0x60, 0xd6, 0x9c, 0xec, 0x15, 0x89, 0x29,
+	0xba, 0x0e, 0xcb, 0x92, 0x12, 0x1e, 0x37, 0xca, 0x46, 0x67, 0x1b, 0x08, 0xc1, 0x52, 0x4f, 0xa4,
+	0xaa, 0x51, 0x31, 0x42, 0xf3, 0x8f, 0xee, 0x01, 0x44, 0x34, 0x55, 0x81, 0x85, 0x83, 0xd1, 0x54,
+	0xb4, 0x04, 0x9b, 0x2e, 0x1f, 0x80, 0x69, 0x04, 0xa6, 0xdf, 0x9a, 0xe5, 0x4d, 0x0b, 0x5e, 0xea,
+	0xbe, 0x9f, 0xc0, 0x46, 0xc4, 0x52, 0x72, 0xc2, 0x69, 0x30, 0x06, 0x55, 0xb7, 0x0a, 0xdb, 0x65,
+	0x7c, 0xcd, 0x29, 0xf6, 0x72, 0x6c, 0x13, 0x36, 0xc5, 0x80, 0xca, 0x53, 0xc9, 0xd4, 0x24, 0xba,
+	0x66, 0xd0, 0x1b, 0x23, 0x55, 0x8e, 0xf7, 0xfe, 0x5c, 0xb0, 0x46, 0xf4, 0xa9, 0x1c, 0x50, 0xb9,
+	0x90, 0x11, 0xcf, 0x91, 0x5a, 0x9a, 0x43, 0xea, 0xd4, 0x46, 0x97, 0x66, 0x36, 0x3a, 0x4d, 0xd2,
+	0xf2, 0x0c, 0x49, 0xde, 0xef, 0x8b, 0x50, 0xf1, 0x1f, 0x11, 0xb7, 0xc8, 0x1d, 0xa8, 0x70, 0xd1,
+	0x0d, 0x38, 0x1d, 0x50, 0xbb, 0xca, 0xf5, 0x9d, 0x1b, 0x4d, 0xeb, 0xbc, 0xc6, 0x67, 0x9b, 0x07,
+	0xa2, 0x7b, 0xa0, 0x95, 0xb8, 0xcc, 0xdd, 0x1f, 0xfa, 0x09, 0xac, 0xa4, 0x66, 0xa3, 0x66, 0xf0,
+	0xb5, 0x9d, 0xfb, 0xcd, 0x29, 0x6f, 0x6f, 0xce, 0xba, 0x33, 0x76, 0x70, 0xf4, 0x04, 0x6e, 0x4b,
+	0xfa, 0xeb, 0x4c, 0x2f, 0xae, 0x43, 0x18, 0xcf, 0x24, 0x0d, 0x54, 0x4f, 0xd2, 0xb4, 0x27, 0x78,
+	0x64, 0x9c, 0xa7, 0x88, 0x6f, 0x39, 0xc0, 0x73, 0xab, 0x3f, 0xce, 0xd5, 0xba, 0x6f, 0xcc, 0x12,
+	0x16, 0x67, 0x71, 0x90, 0x8f, 0x31, 0xee, 0xbb, 0x6a, 0x7c, 0xf3, 0x96, 0x03, 0x60, 0xab, 0x1f,
+	0xf7, 0x6d, 0xc0, 0xea, 0x11, 0x8f, 0x93, 0xfd, 0x28, 0x6d, 0x94, 0xb7, 0x4a, 0x9a, 0x6d, 0xd7,
+	0xf4, 0xfe, 0x54, 0x80, 0xf5, 0xb7, 0x4c, 0xaa, 0x8c, 0xf0, 0x56, 0x3f, 0xc1, 0x19, 0xa7, 0x9a,
+	0x3e, 0xd2, 0x4f, 0x82, 0x0e, 0xe3, 0x8a, 0x4a, 0x67, 0xb8, 0x0a, 0xe9, 0x27, 0xcf, 0x8d, 0x40,
+	0xdb, 0x47, 0xab, 0x47, 0x3e, 0xe0, 0xec, 0x57, 0x25, 0xfd, 0xe4, 0x75, 0x2e, 0x43, 0xcf, 0xe1,
+	0x7e, 0xd8, 0x23, 0xb2, 0xcb, 0x92, 0x6e, 0xa0, 0x7f, 0x48, 0xa8, 0xa8, 0x64, 0xa9, 0x62, 0x61,
+	0x9a, 0x0f, 0x6c, 0xcd, 0x7a, 0x2f, 0x87, 0xed, 0x4e, 0xa3, 0xec, 0x64, 0xde, 0x1f, 0x8a, 0x50,
+	0x7e, 0x71, 0xe6, 0x4c, 0x35, 0xa6, 0xbd, 0xf0, 0x7e, 0xb4, 0x7b, 0x50, 0x1d, 0x2d, 0xad, 0xd5,
+	0x4f, 0xf2, 0x15, 0x4f, 0xca, 0xd0, 0x4f, 0x61, 0xd5, 0xa2, 0xb5, 0xc3, 0x95, 0xbe, 0xcd, 0xe8,
+	0x39, 0x1e, 0xdd, 0x85, 0xca, 0x9e, 0x3d, 0x3f, 0x2f, 0xce, 0x8c, 0x33, 0x96, 0xf1, 0x58, 0x80,
+	0xf6, 0x61, 0x63, 0x60, 0x09, 0x0e, 0x34, 0x6f, 0x32, 0xe3, 0x34, 0x6d, 0x2c, 0x9b, 0x29, 0xee,
+	0xcd, 0x4c, 0x31, 0x6d, 0x08, 0x7c, 0x6d, 0x30, 0xd5, 0x4e, 0xbd, 0x7f, 0x68, 0x36, 0x86, 0x8b,
+	0xb2, 0xf1, 0x25, 0xac, 0xb1, 0x84, 0xa9, 0x20, 0xa6, 0xaa, 0x27, 0x22, 0x43, 0xc6, 0xfa, 0xce,
+	0x07, 0x33, 0xbd, 0x5f, 0x0c, 0xf7, 0x13, 0xa6, 0x0e, 0x0d, 0x04, 0x03, 0x1b, 0xfd, 0x9f, 0xe3,
+	0xb2, 0x74, 0x39, 0x97, 0x4b, 0x57, 0xe7, 0x72, 0xd8, 0x58, 0x9e, 0xe6, 0x72, 0x38, 0x9f, 0xcb,
+	0x95, 0x2b, 0x71, 0xf9, 0x75, 0x11, 0x90, 0x4f, 0xd3, 0x94, 0x89, 0xe4, 0x48, 0x8a, 0xb3, 0xe1,
+	0x02, 0xe1, 0xe0, 0x07, 0x50, 0xec, 0x9e, 0xb9, 0x50, 0x70, 0x6b, 0x96, 0x47, 0xe7, 0xbc, 0xb8,
+	0xd8, 0x3d, 0x33, 0xc0, 0x61, 0x63, 0x65, 0x3e, 0x70, 0x38, 0x02, 0x0e, 0x2f, 0x8f, 0x13, 0xab,
+	0x0b, 0xc4, 0x89, 0xf2, 0xa5, 0x71, 0xc2, 0xfb, 0xe3, 0x12, 0x54, 0xfc, 0xd3, 0xb3, 0xff, 0x49,
+	0x68, 0x2c, 0xbe, 0x9f, 0x57, 0xfe, 0x18, 0xae, 0x0f, 0xa8, 0x64, 0x9d, 0x61, 0x40, 0x32, 0xd5,
+	0x13, 0x92, 0xfd, 0x86, 0x28, 0x26, 0xac, 0x7f, 0x95, 0xf1, 0xa6, 0xd5, 0xb5, 0x26, 0x55, 0x68,
+	0x1b, 0xae, 0xed, 0x92, 0xb0, 0x47, 0x8f, 0x8f, 0x0f, 0x7c, 0x1a, 0x8a, 0x24, 0x4a, 0x5d, 0x2a,
+	0x9f, 0x15, 0x5f, 0xce, 0xe7, 0xf2, 0x02, 0x7c, 0xae, 0x5c, 0x1e, 0x77, 0xb7, 0xa1, 0x2e, 0x69,
+	0x97, 0xa5, 0x8a, 0xca, 0x40, 0x24, 0x66, 0x67, 0xc6, 0x7c, 0x65, 0xbc, 0x9e, 0xcb, 0x5f, 0x27,
+	0x7a, 0x53, 0xe8, 0x11, 0xdc, 0x8a, 0xa8, 0x64, 0x03, 0x1a, 0x64, 0xc9, 0xa8, 0xcb, 0xf8, 0x52,
+	0x50, 0xc6, 0x37, 0xac, 0xfa, 0xcd, 0x48, 0x6b, 0x33, 0xfe, 0x16, 0x54, 0x7b, 0x5c, 0x06, 0x7d,
+	0x1e, 0x27, 0x01, 0x8b, 0xd2, 0x46, 0xc5, 0x84, 0x77, 0xe8, 0x71, 0xe9, 0x22, 0xfc, 0xe4, 0x61,
+	0x84, 0xf7, 0x3b, 0x8c, 0xde, 0x6f, 0x4b, 0x50, 0x6d, 0x93, 0x7e, 0xeb, 0xdd, 0x22, 0xc9, 0xf2,
+	0x67, 0xb0, 0xaa, 0x58, 0x4c, 0x45, 0xa6, 0x9c, 0x4b, 0x7c, 0x34, 0x33, 0xff, 0xe4, 0x0c, 0xcd,
+	0x63, 0x0b, 0x4d, 0x71, 0xde, 0x69, 0x32, 0x77, 0x95, 0xa6, 0x72, 0x17, 0xba, 0x09, 0x2b, 0x6f,
+	0x52, 0xea, 0x3f, 0x22, 0x2e, 0xe8, 0xba, 0x96, 0x96, 0x1f, 0x26, 0xe1, 0x01, 0x4d, 0x8c, 0x69,
+	0x97, 0xb1, 0x6b, 0xdd, 0xf9, 0xa6, 0x00, 0xe5, 0x7c, 0x7c, 0x7d, 0x57, 0xdc, 0xed, 0x11, 0xce,
+	0x69, 0xd2, 0xa5, 0x87, 0xa9, 0xd9, 0x4c, 0x0d, 0x4f, 0x8a, 0xd0, 0xe7, 0xb0, 0xd9, 0x96, 0x52,
+	0xc8, 0x57, 0x42, 0xb1, 0x0e, 0x0b, 0x8d, 0xcf, 0x1d, 0xda, 0xeb, 0x4a, 0x0d, 0xcf, 0x53, 0xe9,
+	0xe0, 0xe5, 0x42, 0xca, 0x61, 0x7e, 0xfb, 0x1c, 0x0b, 0xd0, 0x23, 0xb8, 0xe9, 0x1a, 0xda, 0xe2,
+	0x34, 0x51, 0xba, 0x23, 0x8d, 0x0e, 0x73, 0xaf, 0xbd, 0x40, 0xeb, 0xfd, 0xad, 0x00, 0x9b, 0x6d,
+	0xd2, 0x3f, 0x92, 0x62, 0xc0, 0x22, 0x2a, 0xbf, 0x83, 0x3b, 0xf8, 0x67, 0xc1, 0xf8, 0x91, 0xcf,
+	0xe2, 0x05, 0xfc, 0xe8, 0xcb, 0x59, 0x3f, 0xf2, 0xce, 0xfb, 0xd1, 0x2c, 0x47, 0xff, 0x07, 0x2f,
+	0xf2, 0xfe, 0x53, 0x84, 0x4a, 0xab, 0xd5, 0x5a, 0x60, 0x27, 0x3b, 0x70, 0x7d, 0x3f, 0xe2, 0xd4,
+	0x91, 0xe5, 0xd6, 0x3a, 0xb2, 0xcb, 0x5c, 0x1d, 0xfa, 0x0c, 0x36, 0x5a, 0xa1, 0xa9, 0x43, 0x58,
+	0xd2, 0x6d, 0x27, 0x3a, 0x1f, 0x46, 0x2e, 0x36, 0x9e, 0x57, 0x68, 0xc3, 0xef, 0x4a, 0x4a, 0x54,
+	0x3e, 0x8e, 0x0d, 0x32, 0x6e, 0x83, 0xf3, 0x54, 0xc6, 0x55, 0x06, 0x34, 0x51, 0x07, 0xa2, 0xdb,
+	0x9d, 0x98, 0xc1, 0x66, 0xe0, 0x79, 0x2a, 0xf4, 0x14, 0xaa, 0x98, 0x44, 0x2c, 0x4b, 0x2d, 0x13,
+	0x2e, 0xad, 0xcd, 0xde, 0x23, 0x26, 0x21, 0x78, 0xaa, 0x83, 0xbe, 0x49, 0xb8, 0x35, 0xf8, 0x4a,
+	0xc8, 0xbc, 0x30, 0x9a, 0x92, 0x79, 0x5f, 0x17, 0xa6, 0x67, 0xd1, 0x56, 0xf1, 0x69, 0x28, 0xa9,
+	0x32, 0x64, 0x57, 0xb1, 0x6b, 0x69, 0xfb, 0xbe, 0xa2, 0xea, 0x54, 0xc8, 0x77, 0x79, 0x3d, 0xe1,
+	0x9a, 0xba, 0x0a, 0xd1, 0x3b, 0xd4, 0x95, 0x83, 0xbb, 0xac, 0x8c, 0xda, 0x46, 0x17, 0x86, 0xca,
+	0xe8, 0x5c, 0x15, 0x91, 0xb7, 0xf5, 0x88, 0x7b, 0xad, 0xb6, 0x51, 0xd9, 0x12, 0x22, 0x6f, 0x7a,
+	0x7f, 0x29, 0xc2, 0xe6, 0x0b, 0xa2, 0xe8, 0x29, 0x19, 0xbe, 0xa4, 0x84, 0xab, 0x9e, 0x5b, 0xdb,
+	0xa7, 0xb0, 0xa1, 0x33, 0x04, 0x93, 0x34, 0x0a, 0x74, 0x08, 0x65, 0x21, 0xd5, 0xc7, 0x52, 0x7b,
+	0x5b, 0x3d, 0x57, 0xf8, 0x4e, 0x8e, 0x3e, 0x87, 0xeb, 0x59, 0x3f, 0x22, 0x8a, 0x8e, 0xea, 0xd0,
+	0x20, 0xa5, 0x61, 0xee, 0x04, 0xc8, 0xea, 0xf2, 0x52, 0xd4, 0xa7, 0x61, 0x8a, 0x1e, 0x43, 0xc3,
+	0xf5, 0x38, 0x9f, 0xc3, 0xec, 0x51, 0xbd, 0x69, 0xf5, 0xe7, 0x52, 0xd8, 0x53, 0xb8, 0x1b, 0x72,
+	0x91, 0x45, 0x41, 0xc4, 0xd2, 0x50, 0x24, 0x09, 0x0d, 0x55, 0xd0, 0xa7, 0x92, 0x89, 0xc8, 0xce,
+	0x69, 0x4f, 0xef, 0x6d, 0x83, 0xd9, 0x1b, 0x41, 0x8e, 0x0c, 0xc2, 0x4c, 0xfd, 0x14, 0xee, 0xda,
+	0x9a, 0xec, 0x82, 0x01, 0x6c, 0x69, 0x7c, 0xdb, 0x60, 0xe6, 0x0d, 0xe0, 0x7d, 0xb3, 0x04, 0x95,
+	0x97, 0xbe, 0xff, 0x1e, 0x57, 0xd7, 0xc9, 0x4a, 0x72, 0x74, 0x49, 0xf8, 0x3e, 0xac, 0x71, 0x45,
+	0x4d, 0x1e, 0x0d, 0x44, 0xdf, 0x70, 0x55, 0xc5, 0x15, 0xae, 0xa8, 0xb6, 0xe8, 0xeb, 0xbe, 0xce,
+	0x86, 0x23, 0x3d, 0x89, 0x3b, 0x86, 0x96, 0x2a, 0x06, 0x07, 0x68, 0xc5, 0x1d, 0x74, 0x00, 0xd5,
+	0x34, 0x3b, 0x09, 0xfa, 0x52, 0x74, 0x18, 0xa7, 0xf9, 0xfd, 0xf4, 0x87, 0x33, 0x0b, 0x18, 0x2d,
+	0xb5, 0xe9, 0x67, 0x27, 0x47, 0x0e, 0xdb, 0x4e, 0x94, 0x1c, 0xe2, 0xb5, 0x74, 0x2c, 0x41, 0xbf,
+	0x84, 0xcd, 0x88, 0x76, 0x48, 0xc6, 0x55, 0x30, 0x31, 0xaa, 0xbb, 0x0a, 0x7e, 0x76, 0xd9, 0xa0,
+	0x69, 0x28, 0x59, 0x5f, 0xd9, 0xcb, 0xa7, 0xee, 0x83, 0x37, 0xdc, 0x40, 0xe3, 0x09, 0xd1, 0x8f,
+	0x00, 0xa5, 0x4a, 0x52, 0x12, 0x07, 0xa9, 0xed, 0x70, 0xa2, 0x93, 0xf8, 0x8a, 0x3d, 0xf4, 0x56,
+	0xe3, 0x8f, 0x15, 0x77, 0x42, 0xd8, 0x9c, 0x33, 0x30, 0xfa, 0x18, 0xae, 0xc5, 0xe4, 0x2c, 0xc8,
+	0x78, 0x70, 0xc2, 0x54, 0x20, 0x89, 0xa2, 0x86, 0xf5, 0x25, 0x5c, 0x8d, 0xc9, 0xd9, 0x1b, 0xfe,
+	0x8c, 0x29, 0x4c, 0xd4, 0x08, 0x16, 0x4d, 0xc0, 0x8a, 0x23, 0xd8, 0x5e, 0x0e, 0xbb, 0xc3, 0xa1,
+	0x3e, 0x4b, 0x09, 0xaa, 0x43, 0xe9, 0x1d, 0x1d, 0xba, 0x4a, 0x51, 0xff, 0xa2, 0x67, 0xb0, 0x3c,
+	0x20, 0x3c, 0xa3, 0x8d, 0xe2, 0x15, 0x98, 0xb0, 0x5d, 0x9f, 0x14, 0x1f, 0x17, 0xbc, 0x7f, 0x15,
+	0xa0, 0x66, 0x8f, 0x7f, 0xe4, 0x5c, 0xa7, 0x09, 0x9b, 0xd2, 0x08, 0x74, 0xf9, 0x22, 0x75, 0x39,
+	0xd9, 0x17, 0x52, 0xb9, 0xe4, 0xb7, 0x61, 0x55, 0x87, 0x56, 0x73, 0x24, 0xa4, 0x9a, 0x87, 0x27,
+	0xaa, 0xe7, 0x62, 0xc4, 0x0c, 0x9e, 0xa8, 0xde, 0x85, 0xc7, 0xb2, 0x74, 0xe1, 0xb1, 0x3c, 0x3f,
+	0xc3, 0xc4, 0xa3, 0xc4, 0xf4, 0x0c, 0xe6, 0xa9, 0xe4, 0x57, 0x50, 0xf7, 0x77, 0x8f, 0x8f, 0xa6,
+	0x9e, 0xbb, 0x3e, 0x86, 0x75, 0xeb, 0xe1, 0xa3, 0x47, 0x0f, 0x4b, 0x66, 0xcd, 0x4a, 0x5b, 0x17,
+	0x3d, 0x8d, 0x14, 0xcf, 0x3f, 0x8d, 0x78, 0x7f, 0x2d, 0x40, 0xe5, 0xed, 0x01, 0x76, 0x23, 0x23,
+	0x58, 0x32, 0xaf, 0x4e, 0x76, 0x3c, 0xf3, 0xaf, 0x8f, 0x5f, 0x68, 0x66, 0xbf, 0xe0, 0x8e, 0x3e,
+	0xbb, 0x3c, 0xec, 0xe0, 0xfa, 0x65, 0x20, 0x91, 0x2c, 0x30, 0xf6, 0xb1, 0x79, 0xb4, 0x86, 0x2b,
+	0x89, 0x64, 0x6f, 0x8d, 0x40, 0xef, 0x42, 0xaf, 0x44, 0xdb, 0x32, 0x20, 0x92, 0x12, 0x7b, 0xba,
+	0x2a, 0xb8, 0x96, 0x4b, 0x5b, 0x5a, 0xa8, 0x43, 0xf8, 0x29, 0x65, 0xdd, 0x5e, 0xfe, 0xa2, 0xe6,
+	0x5a, 0xde, 0xbf, 0x0b, 0x00, 0xbb, 0x69, 0xe7, 0x64, 0xb1, 0xea, 0xe3, 0x6a, 0x3b, 0xfb, 0x02,
+	0xca, 0x03, 0x7d, 0x8d, 0x16, 0x82, 0xbb, 0xf2, 0xbf, 0x31, 0x5b, 0x4f, 0xe6, 0x94, 0xe2, 0xd5,
+	0x01, 0x97, 0x47, 0x42, 0x70, 0xf4, 0x11, 0xac, 0x6b, 0x3a, 0xf4, 0x79, 0xd1, 0x37, 0x2e, 0x97,
+	0x60, 0x6b, 0xb8, 0x9a, 0x48, 0xf6, 0x8c, 0xa9, 0x03, 0x23, 0xf3, 0xbe, 0x82, 0x1b, 0xed, 0x64,
+	0x20, 0x74, 0x79, 0xa7, 0xa4, 0xe0, 0x9c, 0xca, 0xab, 0x6f, 0xd0, 0xfb, 0x5d, 0x01, 0xca, 0xfe,
+	0xe3, 0x05, 0x18, 0xfa, 0x36, 0x2e, 0xa4, 0x1f, 0x3e, 0xfb, 0xdd, 0xd3, 0x99, 0x07, 0x38, 0xe8,
+	0x77, 0x4f, 0x1d, 0xe0, 0x93, 0x27, 0x50, 0x9d, 0x7c, 0x20, 0x40, 0x55, 0x28, 0xe3, 0xb6, 0xdf,
+	0xc6, 0x6f, 0xdb, 0x7b, 0xf5, 0xef, 0xa1, 0x6b, 0xb0, 0x76, 0xd4, 0xc6, 0x81, 0xdf, 0xf6, 0xfd,
+	0xfd, 0xd7, 0xaf, 0xea, 0x05, 0xb4, 0x06, 0xab, 0x5a, 0xf0, 0x55, 0xfb, 0xe7, 0xf5, 0xe2, 0xb3,
+	0x0f, 0x7f, 0xf1, 0xc0, 0xac, 0xf1, 0xa1, 0x7e, 0x44, 0x36, 0x29, 0xe7, 0x61, 0x57, 0xcc, 0xbc,
+	0x26, 0x9f, 0xac, 0x98, 0xf6, 0x17, 0xff, 0x1d, 0x00, 0xe2, 0x9c, 0xe5, 0xdd, 0x6a, 0x16, 0x00,
+	0x00,
 }
@@ -222,6 +222,59 @@ func (CreditInfo_UnitType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_ef3afe2df05d1dc6, []int{7, 0}
 }
 
+type Tariff_Unit int32
+
+const (
+	Tariff_Volume Tariff_Unit = 0
+	Tariff_Time   Tariff_Unit = 1
+	Tariff_Event  Tariff_Unit = 2
+)
+
+var Tariff_Unit_name = map[int32]string{
+	0: "Volume",
+	1: "Time",
+	2: "Event",
+}
+
+var Tariff_Unit_value = map[string]int32{
+	"Volume": 0,
+	"Time":   1,
+	"Event":  2,
+}
+
+func (x Tariff_Unit) String() string {
+	return proto.EnumName(Tariff_Unit_name, int32(x))
+}
+
+func (Tariff_Unit) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{10, 0}
+}
+
+type AFSessionEvent_EventType int32
+
+const (
+	AFSessionEvent_REAUTH AFSessionEvent_EventType = 0
+	AFSessionEvent_ABORT  AFSessionEvent_EventType = 1
+)
+
+var AFSessionEvent_EventType_name = map[int32]string{
+	0: "REAUTH",
+	1: "ABORT",
+}
+
+var AFSessionEvent_EventType_value = map[string]int32{
+	"REAUTH": 0,
+	"ABORT":  1,
+}
+
+func (x AFSessionEvent_EventType) String() string {
+	return proto.EnumName(AFSessionEvent_EventType_name, int32(x))
+}
+
+func (AFSessionEvent_EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{51, 0}
+}
+
 type Reply struct {
 	ServerBehavior Reply_ServerBehavior `protobuf:"varint,1,opt,name=server_behavior,json=serverBehavior,proto3,enum=magma.feg.Reply_ServerBehavior" json:"server_behavior,omitempty"`
 	// reply delaying time in sec
@@ -1213,286 +1266,721 @@ func (m *CreditInfos) GetCreditInformation() map[uint32]*CreditInfo {
 	return nil
 }
 
-type ChargingReAuthTarget struct {
-	Imsi                 string   `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
-	RatingGroup          uint32   `protobuf:"varint,2,opt,name=rating_group,json=ratingGroup,proto3" json:"rating_group,omitempty"`
+type TariffPeriod struct {
+	// Start of the period in seconds since midnight UTC, the period lasts
+	// until the start of the next one
+	StartTime uint32 `protobuf:"varint,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Price of a unit block during the period
+	Price                uint64   `protobuf:"varint,2,opt,name=price,proto3" json:"price,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ChargingReAuthTarget) Reset()         { *m = ChargingReAuthTarget{} }
-func (m *ChargingReAuthTarget) String() string { return proto.CompactTextString(m) }
-func (*ChargingReAuthTarget) ProtoMessage()    {}
-func (*ChargingReAuthTarget) Descriptor() ([]byte, []int) {
+func (m *TariffPeriod) Reset()         { *m = TariffPeriod{} }
+func (m *TariffPeriod) String() string { return proto.CompactTextString(m) }
+func (*TariffPeriod) ProtoMessage()    {}
+func (*TariffPeriod) Descriptor() ([]byte, []int) {
 	return fileDescriptor_ef3afe2df05d1dc6, []int{9}
 }
 
-func (m *ChargingReAuthTarget) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChargingReAuthTarget.Unmarshal(m, b)
+func (m *TariffPeriod) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TariffPeriod.Unmarshal(m, b)
 }
-func (m *ChargingReAuthTarget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChargingReAuthTarget.Marshal(b, m, deterministic)
+func (m *TariffPeriod) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TariffPeriod.Marshal(b, m, deterministic)
 }
-func (m *ChargingReAuthTarget) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChargingReAuthTarget.Merge(m, src)
+func (m *TariffPeriod) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TariffPeriod.Merge(m, src)
 }
-func (m *ChargingReAuthTarget) XXX_Size() int {
-	return xxx_messageInfo_ChargingReAuthTarget.Size(m)
+func (m *TariffPeriod) XXX_Size() int {
+	return xxx_messageInfo_TariffPeriod.Size(m)
 }
-func (m *ChargingReAuthTarget) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChargingReAuthTarget.DiscardUnknown(m)
+func (m *TariffPeriod) XXX_DiscardUnknown() {
+	xxx_messageInfo_TariffPeriod.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChargingReAuthTarget proto.InternalMessageInfo
+var xxx_messageInfo_TariffPeriod proto.InternalMessageInfo
 
-func (m *ChargingReAuthTarget) GetImsi() string {
+func (m *TariffPeriod) GetStartTime() uint32 {
 	if m != nil {
-		return m.Imsi
+		return m.StartTime
 	}
-	return ""
+	return 0
 }
 
-func (m *ChargingReAuthTarget) GetRatingGroup() uint32 {
+func (m *TariffPeriod) GetPrice() uint64 {
 	if m != nil {
-		return m.RatingGroup
+		return m.Price
 	}
 	return 0
 }
 
-type ChargingReAuthAnswer struct {
-	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	ResultCode           uint32   `protobuf:"varint,2,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type Tariff struct {
+	RatingGroup uint32      `protobuf:"varint,1,opt,name=rating_group,json=ratingGroup,proto3" json:"rating_group,omitempty"`
+	Unit        Tariff_Unit `protobuf:"varint,2,opt,name=unit,proto3,enum=magma.feg.Tariff_Unit" json:"unit,omitempty"`
+	// Number of octets, seconds or events charged as one block (1 if not set)
+	UnitSize uint64 `protobuf:"varint,3,opt,name=unit_size,json=unitSize,proto3" json:"unit_size,omitempty"`
+	// Price of a unit block when no period is configured
+	Price uint64 `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Daily tariff periods, the tariff switches at the start of each period
+	Periods []*TariffPeriod `protobuf:"bytes,5,rep,name=periods,proto3" json:"periods,omitempty"`
+	// Maximum number of units granted per request, unlimited if not set
+	MaxGrant uint64 `protobuf:"varint,6,opt,name=max_grant,json=maxGrant,proto3" json:"max_grant,omitempty"`
+	// Credit pool the rating group draws from, 0 is the main balance
+	PoolId uint32 `protobuf:"varint,7,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	// Action once the balance is depleted, the OCS settings apply if not set
+	FinalUnitIndication  *FinalUnitIndication `protobuf:"bytes,8,opt,name=final_unit_indication,json=finalUnitIndication,proto3" json:"final_unit_indication,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func (m *ChargingReAuthAnswer) Reset()         { *m = ChargingReAuthAnswer{} }
-func (m *ChargingReAuthAnswer) String() string { return proto.CompactTextString(m) }
-func (*ChargingReAuthAnswer) ProtoMessage()    {}
-func (*ChargingReAuthAnswer) Descriptor() ([]byte, []int) {
+func (m *Tariff) Reset()         { *m = Tariff{} }
+func (m *Tariff) String() string { return proto.CompactTextString(m) }
+func (*Tariff) ProtoMessage()    {}
+func (*Tariff) Descriptor() ([]byte, []int) {
 	return fileDescriptor_ef3afe2df05d1dc6, []int{10}
 }
 
-func (m *ChargingReAuthAnswer) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ChargingReAuthAnswer.Unmarshal(m, b)
+func (m *Tariff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tariff.Unmarshal(m, b)
 }
-func (m *ChargingReAuthAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ChargingReAuthAnswer.Marshal(b, m, deterministic)
+func (m *Tariff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tariff.Marshal(b, m, deterministic)
 }
-func (m *ChargingReAuthAnswer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ChargingReAuthAnswer.Merge(m, src)
+func (m *Tariff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tariff.Merge(m, src)
 }
-func (m *ChargingReAuthAnswer) XXX_Size() int {
-	return xxx_messageInfo_ChargingReAuthAnswer.Size(m)
+func (m *Tariff) XXX_Size() int {
+	return xxx_messageInfo_Tariff.Size(m)
 }
-func (m *ChargingReAuthAnswer) XXX_DiscardUnknown() {
-	xxx_messageInfo_ChargingReAuthAnswer.DiscardUnknown(m)
+func (m *Tariff) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tariff.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ChargingReAuthAnswer proto.InternalMessageInfo
+var xxx_messageInfo_Tariff proto.InternalMessageInfo
 
-func (m *ChargingReAuthAnswer) GetSessionId() string {
+func (m *Tariff) GetRatingGroup() uint32 {
 	if m != nil {
-		return m.SessionId
+		return m.RatingGroup
 	}
-	return ""
+	return 0
 }
 
-func (m *ChargingReAuthAnswer) GetResultCode() uint32 {
+func (m *Tariff) GetUnit() Tariff_Unit {
 	if m != nil {
-		return m.ResultCode
+		return m.Unit
+	}
+	return Tariff_Volume
+}
+
+func (m *Tariff) GetUnitSize() uint64 {
+	if m != nil {
+		return m.UnitSize
 	}
 	return 0
 }
 
-type PCRFConfigs struct {
-	UseMockDriver        bool     `protobuf:"varint,1,opt,name=use_mock_driver,json=useMockDriver,proto3" json:"use_mock_driver,omitempty"`
+func (m *Tariff) GetPrice() uint64 {
+	if m != nil {
+		return m.Price
+	}
+	return 0
+}
+
+func (m *Tariff) GetPeriods() []*TariffPeriod {
+	if m != nil {
+		return m.Periods
+	}
+	return nil
+}
+
+func (m *Tariff) GetMaxGrant() uint64 {
+	if m != nil {
+		return m.MaxGrant
+	}
+	return 0
+}
+
+func (m *Tariff) GetPoolId() uint32 {
+	if m != nil {
+		return m.PoolId
+	}
+	return 0
+}
+
+func (m *Tariff) GetFinalUnitIndication() *FinalUnitIndication {
+	if m != nil {
+		return m.FinalUnitIndication
+	}
+	return nil
+}
+
+type CreditPool struct {
+	PoolId               uint32   `protobuf:"varint,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Balance              uint64   `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PCRFConfigs) Reset()         { *m = PCRFConfigs{} }
-func (m *PCRFConfigs) String() string { return proto.CompactTextString(m) }
-func (*PCRFConfigs) ProtoMessage()    {}
-func (*PCRFConfigs) Descriptor() ([]byte, []int) {
+func (m *CreditPool) Reset()         { *m = CreditPool{} }
+func (m *CreditPool) String() string { return proto.CompactTextString(m) }
+func (*CreditPool) ProtoMessage()    {}
+func (*CreditPool) Descriptor() ([]byte, []int) {
 	return fileDescriptor_ef3afe2df05d1dc6, []int{11}
 }
 
-func (m *PCRFConfigs) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PCRFConfigs.Unmarshal(m, b)
+func (m *CreditPool) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreditPool.Unmarshal(m, b)
 }
-func (m *PCRFConfigs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PCRFConfigs.Marshal(b, m, deterministic)
+func (m *CreditPool) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreditPool.Marshal(b, m, deterministic)
 }
-func (m *PCRFConfigs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PCRFConfigs.Merge(m, src)
+func (m *CreditPool) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreditPool.Merge(m, src)
 }
-func (m *PCRFConfigs) XXX_Size() int {
-	return xxx_messageInfo_PCRFConfigs.Size(m)
+func (m *CreditPool) XXX_Size() int {
+	return xxx_messageInfo_CreditPool.Size(m)
 }
-func (m *PCRFConfigs) XXX_DiscardUnknown() {
-	xxx_messageInfo_PCRFConfigs.DiscardUnknown(m)
+func (m *CreditPool) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreditPool.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PCRFConfigs proto.InternalMessageInfo
+var xxx_messageInfo_CreditPool proto.InternalMessageInfo
 
-func (m *PCRFConfigs) GetUseMockDriver() bool {
+func (m *CreditPool) GetPoolId() uint32 {
 	if m != nil {
-		return m.UseMockDriver
+		return m.PoolId
 	}
-	return false
+	return 0
 }
 
-type AccountRules struct {
-	Imsi                   string            `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
-	StaticRuleNames        []string          `protobuf:"bytes,2,rep,name=static_rule_names,json=staticRuleNames,proto3" json:"static_rule_names,omitempty"`
-	StaticRuleBaseNames    []string          `protobuf:"bytes,3,rep,name=static_rule_base_names,json=staticRuleBaseNames,proto3" json:"static_rule_base_names,omitempty"`
-	DynamicRuleDefinitions []*RuleDefinition `protobuf:"bytes,4,rep,name=dynamic_rule_definitions,json=dynamicRuleDefinitions,proto3" json:"dynamic_rule_definitions,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{}          `json:"-"`
-	XXX_unrecognized       []byte            `json:"-"`
-	XXX_sizecache          int32             `json:"-"`
+func (m *CreditPool) GetBalance() uint64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
 }
 
-func (m *AccountRules) Reset()         { *m = AccountRules{} }
-func (m *AccountRules) String() string { return proto.CompactTextString(m) }
-func (*AccountRules) ProtoMessage()    {}
-func (*AccountRules) Descriptor() ([]byte, []int) {
+type SubscriberBalance struct {
+	Imsi                 string        `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	Pools                []*CreditPool `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *SubscriberBalance) Reset()         { *m = SubscriberBalance{} }
+func (m *SubscriberBalance) String() string { return proto.CompactTextString(m) }
+func (*SubscriberBalance) ProtoMessage()    {}
+func (*SubscriberBalance) Descriptor() ([]byte, []int) {
 	return fileDescriptor_ef3afe2df05d1dc6, []int{12}
 }
 
-func (m *AccountRules) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AccountRules.Unmarshal(m, b)
+func (m *SubscriberBalance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscriberBalance.Unmarshal(m, b)
 }
-func (m *AccountRules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AccountRules.Marshal(b, m, deterministic)
+func (m *SubscriberBalance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscriberBalance.Marshal(b, m, deterministic)
 }
-func (m *AccountRules) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AccountRules.Merge(m, src)
+func (m *SubscriberBalance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscriberBalance.Merge(m, src)
 }
-func (m *AccountRules) XXX_Size() int {
-	return xxx_messageInfo_AccountRules.Size(m)
+func (m *SubscriberBalance) XXX_Size() int {
+	return xxx_messageInfo_SubscriberBalance.Size(m)
 }
-func (m *AccountRules) XXX_DiscardUnknown() {
-	xxx_messageInfo_AccountRules.DiscardUnknown(m)
+func (m *SubscriberBalance) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscriberBalance.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AccountRules proto.InternalMessageInfo
+var xxx_messageInfo_SubscriberBalance proto.InternalMessageInfo
 
-func (m *AccountRules) GetImsi() string {
+func (m *SubscriberBalance) GetImsi() string {
 	if m != nil {
 		return m.Imsi
 	}
 	return ""
 }
 
-func (m *AccountRules) GetStaticRuleNames() []string {
+func (m *SubscriberBalance) GetPools() []*CreditPool {
 	if m != nil {
-		return m.StaticRuleNames
+		return m.Pools
 	}
 	return nil
 }
 
-func (m *AccountRules) GetStaticRuleBaseNames() []string {
+type TariffScenario struct {
+	Name     string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tariffs  []*Tariff            `protobuf:"bytes,2,rep,name=tariffs,proto3" json:"tariffs,omitempty"`
+	Balances []*SubscriberBalance `protobuf:"bytes,3,rep,name=balances,proto3" json:"balances,omitempty"`
+	// Validity time of the grants, the OCS settings apply if not set
+	ValidityTime         uint32   `protobuf:"varint,4,opt,name=validity_time,json=validityTime,proto3" json:"validity_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TariffScenario) Reset()         { *m = TariffScenario{} }
+func (m *TariffScenario) String() string { return proto.CompactTextString(m) }
+func (*TariffScenario) ProtoMessage()    {}
+func (*TariffScenario) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{13}
+}
+
+func (m *TariffScenario) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TariffScenario.Unmarshal(m, b)
+}
+func (m *TariffScenario) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TariffScenario.Marshal(b, m, deterministic)
+}
+func (m *TariffScenario) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TariffScenario.Merge(m, src)
+}
+func (m *TariffScenario) XXX_Size() int {
+	return xxx_messageInfo_TariffScenario.Size(m)
+}
+func (m *TariffScenario) XXX_DiscardUnknown() {
+	xxx_messageInfo_TariffScenario.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TariffScenario proto.InternalMessageInfo
+
+func (m *TariffScenario) GetName() string {
 	if m != nil {
-		return m.StaticRuleBaseNames
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TariffScenario) GetTariffs() []*Tariff {
+	if m != nil {
+		return m.Tariffs
 	}
 	return nil
 }
 
-func (m *AccountRules) GetDynamicRuleDefinitions() []*RuleDefinition {
+func (m *TariffScenario) GetBalances() []*SubscriberBalance {
 	if m != nil {
-		return m.DynamicRuleDefinitions
+		return m.Balances
 	}
 	return nil
 }
 
-type RuleDefinition struct {
-	RuleName             string                      `protobuf:"bytes,1,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
-	RatingGroup          uint32                      `protobuf:"varint,2,opt,name=rating_group,json=ratingGroup,proto3" json:"rating_group,omitempty"`
-	Precedence           uint32                      `protobuf:"varint,3,opt,name=precedence,proto3" json:"precedence,omitempty"`
-	MonitoringKey        string                      `protobuf:"bytes,4,opt,name=monitoring_key,json=monitoringKey,proto3" json:"monitoring_key,omitempty"`
-	FlowDescriptions     []string                    `protobuf:"bytes,5,rep,name=flow_descriptions,json=flowDescriptions,proto3" json:"flow_descriptions,omitempty"`
-	RedirectInformation  *protos.RedirectInformation `protobuf:"bytes,7,opt,name=redirect_information,json=redirectInformation,proto3" json:"redirect_information,omitempty"`
-	QosInformation       *protos.FlowQos             `protobuf:"bytes,8,opt,name=qos_information,json=qosInformation,proto3" json:"qos_information,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+func (m *TariffScenario) GetValidityTime() uint32 {
+	if m != nil {
+		return m.ValidityTime
+	}
+	return 0
 }
 
-func (m *RuleDefinition) Reset()         { *m = RuleDefinition{} }
-func (m *RuleDefinition) String() string { return proto.CompactTextString(m) }
-func (*RuleDefinition) ProtoMessage()    {}
-func (*RuleDefinition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{13}
+type RatedUsage struct {
+	RatingGroup          uint32   `protobuf:"varint,1,opt,name=rating_group,json=ratingGroup,proto3" json:"rating_group,omitempty"`
+	GrantedUnits         uint64   `protobuf:"varint,2,opt,name=granted_units,json=grantedUnits,proto3" json:"granted_units,omitempty"`
+	UsedUnits            uint64   `protobuf:"varint,3,opt,name=used_units,json=usedUnits,proto3" json:"used_units,omitempty"`
+	Charged              uint64   `protobuf:"varint,4,opt,name=charged,proto3" json:"charged,omitempty"`
+	FinalUnits           bool     `protobuf:"varint,5,opt,name=final_units,json=finalUnits,proto3" json:"final_units,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RuleDefinition) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RuleDefinition.Unmarshal(m, b)
+func (m *RatedUsage) Reset()         { *m = RatedUsage{} }
+func (m *RatedUsage) String() string { return proto.CompactTextString(m) }
+func (*RatedUsage) ProtoMessage()    {}
+func (*RatedUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{14}
 }
-func (m *RuleDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RuleDefinition.Marshal(b, m, deterministic)
+
+func (m *RatedUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RatedUsage.Unmarshal(m, b)
 }
-func (m *RuleDefinition) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RuleDefinition.Merge(m, src)
+func (m *RatedUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RatedUsage.Marshal(b, m, deterministic)
 }
-func (m *RuleDefinition) XXX_Size() int {
-	return xxx_messageInfo_RuleDefinition.Size(m)
+func (m *RatedUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RatedUsage.Merge(m, src)
 }
-func (m *RuleDefinition) XXX_DiscardUnknown() {
-	xxx_messageInfo_RuleDefinition.DiscardUnknown(m)
+func (m *RatedUsage) XXX_Size() int {
+	return xxx_messageInfo_RatedUsage.Size(m)
+}
+func (m *RatedUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_RatedUsage.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RuleDefinition proto.InternalMessageInfo
+var xxx_messageInfo_RatedUsage proto.InternalMessageInfo
 
-func (m *RuleDefinition) GetRuleName() string {
+func (m *RatedUsage) GetRatingGroup() uint32 {
 	if m != nil {
-		return m.RuleName
+		return m.RatingGroup
 	}
-	return ""
+	return 0
 }
 
-func (m *RuleDefinition) GetRatingGroup() uint32 {
+func (m *RatedUsage) GetGrantedUnits() uint64 {
 	if m != nil {
-		return m.RatingGroup
+		return m.GrantedUnits
 	}
 	return 0
 }
 
-func (m *RuleDefinition) GetPrecedence() uint32 {
+func (m *RatedUsage) GetUsedUnits() uint64 {
 	if m != nil {
-		return m.Precedence
+		return m.UsedUnits
 	}
 	return 0
 }
 
-func (m *RuleDefinition) GetMonitoringKey() string {
+func (m *RatedUsage) GetCharged() uint64 {
 	if m != nil {
-		return m.MonitoringKey
+		return m.Charged
 	}
-	return ""
+	return 0
 }
 
-func (m *RuleDefinition) GetFlowDescriptions() []string {
+func (m *RatedUsage) GetFinalUnits() bool {
 	if m != nil {
-		return m.FlowDescriptions
+		return m.FinalUnits
 	}
-	return nil
+	return false
 }
 
-func (m *RuleDefinition) GetRedirectInformation() *protos.RedirectInformation {
-	if m != nil {
-		return m.RedirectInformation
-	}
-	return nil
+type RatingState struct {
+	Imsi                 string        `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	Pools                []*CreditPool `protobuf:"bytes,2,rep,name=pools,proto3" json:"pools,omitempty"`
+	Usages               []*RatedUsage `protobuf:"bytes,3,rep,name=usages,proto3" json:"usages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
-func (m *RuleDefinition) GetQosInformation() *protos.FlowQos {
-	if m != nil {
-		return m.QosInformation
-	}
+func (m *RatingState) Reset()         { *m = RatingState{} }
+func (m *RatingState) String() string { return proto.CompactTextString(m) }
+func (*RatingState) ProtoMessage()    {}
+func (*RatingState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{15}
+}
+
+func (m *RatingState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RatingState.Unmarshal(m, b)
+}
+func (m *RatingState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RatingState.Marshal(b, m, deterministic)
+}
+func (m *RatingState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RatingState.Merge(m, src)
+}
+func (m *RatingState) XXX_Size() int {
+	return xxx_messageInfo_RatingState.Size(m)
+}
+func (m *RatingState) XXX_DiscardUnknown() {
+	xxx_messageInfo_RatingState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RatingState proto.InternalMessageInfo
+
+func (m *RatingState) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *RatingState) GetPools() []*CreditPool {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+func (m *RatingState) GetUsages() []*RatedUsage {
+	if m != nil {
+		return m.Usages
+	}
+	return nil
+}
+
+type ChargingReAuthTarget struct {
+	Imsi                 string   `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	RatingGroup          uint32   `protobuf:"varint,2,opt,name=rating_group,json=ratingGroup,proto3" json:"rating_group,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChargingReAuthTarget) Reset()         { *m = ChargingReAuthTarget{} }
+func (m *ChargingReAuthTarget) String() string { return proto.CompactTextString(m) }
+func (*ChargingReAuthTarget) ProtoMessage()    {}
+func (*ChargingReAuthTarget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{16}
+}
+
+func (m *ChargingReAuthTarget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChargingReAuthTarget.Unmarshal(m, b)
+}
+func (m *ChargingReAuthTarget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChargingReAuthTarget.Marshal(b, m, deterministic)
+}
+func (m *ChargingReAuthTarget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChargingReAuthTarget.Merge(m, src)
+}
+func (m *ChargingReAuthTarget) XXX_Size() int {
+	return xxx_messageInfo_ChargingReAuthTarget.Size(m)
+}
+func (m *ChargingReAuthTarget) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChargingReAuthTarget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChargingReAuthTarget proto.InternalMessageInfo
+
+func (m *ChargingReAuthTarget) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *ChargingReAuthTarget) GetRatingGroup() uint32 {
+	if m != nil {
+		return m.RatingGroup
+	}
+	return 0
+}
+
+type ChargingReAuthAnswer struct {
+	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ResultCode           uint32   `protobuf:"varint,2,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChargingReAuthAnswer) Reset()         { *m = ChargingReAuthAnswer{} }
+func (m *ChargingReAuthAnswer) String() string { return proto.CompactTextString(m) }
+func (*ChargingReAuthAnswer) ProtoMessage()    {}
+func (*ChargingReAuthAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{17}
+}
+
+func (m *ChargingReAuthAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChargingReAuthAnswer.Unmarshal(m, b)
+}
+func (m *ChargingReAuthAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChargingReAuthAnswer.Marshal(b, m, deterministic)
+}
+func (m *ChargingReAuthAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChargingReAuthAnswer.Merge(m, src)
+}
+func (m *ChargingReAuthAnswer) XXX_Size() int {
+	return xxx_messageInfo_ChargingReAuthAnswer.Size(m)
+}
+func (m *ChargingReAuthAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChargingReAuthAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChargingReAuthAnswer proto.InternalMessageInfo
+
+func (m *ChargingReAuthAnswer) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *ChargingReAuthAnswer) GetResultCode() uint32 {
+	if m != nil {
+		return m.ResultCode
+	}
+	return 0
+}
+
+type PCRFConfigs struct {
+	UseMockDriver        bool     `protobuf:"varint,1,opt,name=use_mock_driver,json=useMockDriver,proto3" json:"use_mock_driver,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PCRFConfigs) Reset()         { *m = PCRFConfigs{} }
+func (m *PCRFConfigs) String() string { return proto.CompactTextString(m) }
+func (*PCRFConfigs) ProtoMessage()    {}
+func (*PCRFConfigs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{18}
+}
+
+func (m *PCRFConfigs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PCRFConfigs.Unmarshal(m, b)
+}
+func (m *PCRFConfigs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PCRFConfigs.Marshal(b, m, deterministic)
+}
+func (m *PCRFConfigs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PCRFConfigs.Merge(m, src)
+}
+func (m *PCRFConfigs) XXX_Size() int {
+	return xxx_messageInfo_PCRFConfigs.Size(m)
+}
+func (m *PCRFConfigs) XXX_DiscardUnknown() {
+	xxx_messageInfo_PCRFConfigs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PCRFConfigs proto.InternalMessageInfo
+
+func (m *PCRFConfigs) GetUseMockDriver() bool {
+	if m != nil {
+		return m.UseMockDriver
+	}
+	return false
+}
+
+type AccountRules struct {
+	Imsi                   string            `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	StaticRuleNames        []string          `protobuf:"bytes,2,rep,name=static_rule_names,json=staticRuleNames,proto3" json:"static_rule_names,omitempty"`
+	StaticRuleBaseNames    []string          `protobuf:"bytes,3,rep,name=static_rule_base_names,json=staticRuleBaseNames,proto3" json:"static_rule_base_names,omitempty"`
+	DynamicRuleDefinitions []*RuleDefinition `protobuf:"bytes,4,rep,name=dynamic_rule_definitions,json=dynamicRuleDefinitions,proto3" json:"dynamic_rule_definitions,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}          `json:"-"`
+	XXX_unrecognized       []byte            `json:"-"`
+	XXX_sizecache          int32             `json:"-"`
+}
+
+func (m *AccountRules) Reset()         { *m = AccountRules{} }
+func (m *AccountRules) String() string { return proto.CompactTextString(m) }
+func (*AccountRules) ProtoMessage()    {}
+func (*AccountRules) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{19}
+}
+
+func (m *AccountRules) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountRules.Unmarshal(m, b)
+}
+func (m *AccountRules) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountRules.Marshal(b, m, deterministic)
+}
+func (m *AccountRules) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountRules.Merge(m, src)
+}
+func (m *AccountRules) XXX_Size() int {
+	return xxx_messageInfo_AccountRules.Size(m)
+}
+func (m *AccountRules) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountRules.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountRules proto.InternalMessageInfo
+
+func (m *AccountRules) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *AccountRules) GetStaticRuleNames() []string {
+	if m != nil {
+		return m.StaticRuleNames
+	}
+	return nil
+}
+
+func (m *AccountRules) GetStaticRuleBaseNames() []string {
+	if m != nil {
+		return m.StaticRuleBaseNames
+	}
+	return nil
+}
+
+func (m *AccountRules) GetDynamicRuleDefinitions() []*RuleDefinition {
+	if m != nil {
+		return m.DynamicRuleDefinitions
+	}
+	return nil
+}
+
+type RuleDefinition struct {
+	RuleName             string                      `protobuf:"bytes,1,opt,name=rule_name,json=ruleName,proto3" json:"rule_name,omitempty"`
+	RatingGroup          uint32                      `protobuf:"varint,2,opt,name=rating_group,json=ratingGroup,proto3" json:"rating_group,omitempty"`
+	Precedence           uint32                      `protobuf:"varint,3,opt,name=precedence,proto3" json:"precedence,omitempty"`
+	MonitoringKey        string                      `protobuf:"bytes,4,opt,name=monitoring_key,json=monitoringKey,proto3" json:"monitoring_key,omitempty"`
+	FlowDescriptions     []string                    `protobuf:"bytes,5,rep,name=flow_descriptions,json=flowDescriptions,proto3" json:"flow_descriptions,omitempty"`
+	RedirectInformation  *protos.RedirectInformation `protobuf:"bytes,7,opt,name=redirect_information,json=redirectInformation,proto3" json:"redirect_information,omitempty"`
+	QosInformation       *protos.FlowQos             `protobuf:"bytes,8,opt,name=qos_information,json=qosInformation,proto3" json:"qos_information,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *RuleDefinition) Reset()         { *m = RuleDefinition{} }
+func (m *RuleDefinition) String() string { return proto.CompactTextString(m) }
+func (*RuleDefinition) ProtoMessage()    {}
+func (*RuleDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{20}
+}
+
+func (m *RuleDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RuleDefinition.Unmarshal(m, b)
+}
+func (m *RuleDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RuleDefinition.Marshal(b, m, deterministic)
+}
+func (m *RuleDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RuleDefinition.Merge(m, src)
+}
+func (m *RuleDefinition) XXX_Size() int {
+	return xxx_messageInfo_RuleDefinition.Size(m)
+}
+func (m *RuleDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_RuleDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RuleDefinition proto.InternalMessageInfo
+
+func (m *RuleDefinition) GetRuleName() string {
+	if m != nil {
+		return m.RuleName
+	}
+	return ""
+}
+
+func (m *RuleDefinition) GetRatingGroup() uint32 {
+	if m != nil {
+		return m.RatingGroup
+	}
+	return 0
+}
+
+func (m *RuleDefinition) GetPrecedence() uint32 {
+	if m != nil {
+		return m.Precedence
+	}
+	return 0
+}
+
+func (m *RuleDefinition) GetMonitoringKey() string {
+	if m != nil {
+		return m.MonitoringKey
+	}
+	return ""
+}
+
+func (m *RuleDefinition) GetFlowDescriptions() []string {
+	if m != nil {
+		return m.FlowDescriptions
+	}
+	return nil
+}
+
+func (m *RuleDefinition) GetRedirectInformation() *protos.RedirectInformation {
+	if m != nil {
+		return m.RedirectInformation
+	}
+	return nil
+}
+
+func (m *RuleDefinition) GetQosInformation() *protos.FlowQos {
+	if m != nil {
+		return m.QosInformation
+	}
 	return nil
 }
 
@@ -1508,7 +1996,7 @@ func (m *UsageMonitorConfiguration) Reset()         { *m = UsageMonitorConfigura
 func (m *UsageMonitorConfiguration) String() string { return proto.CompactTextString(m) }
 func (*UsageMonitorConfiguration) ProtoMessage()    {}
 func (*UsageMonitorConfiguration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{14}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{21}
 }
 
 func (m *UsageMonitorConfiguration) XXX_Unmarshal(b []byte) error {
@@ -1557,7 +2045,7 @@ func (m *UsageMonitor) Reset()         { *m = UsageMonitor{} }
 func (m *UsageMonitor) String() string { return proto.CompactTextString(m) }
 func (*UsageMonitor) ProtoMessage()    {}
 func (*UsageMonitor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{15}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{22}
 }
 
 func (m *UsageMonitor) XXX_Unmarshal(b []byte) error {
@@ -1606,7 +2094,7 @@ func (m *GxCreditControlExpectations) Reset()         { *m = GxCreditControlExpe
 func (m *GxCreditControlExpectations) String() string { return proto.CompactTextString(m) }
 func (*GxCreditControlExpectations) ProtoMessage()    {}
 func (*GxCreditControlExpectations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{16}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{23}
 }
 
 func (m *GxCreditControlExpectations) XXX_Unmarshal(b []byte) error {
@@ -1667,7 +2155,7 @@ func (m *GxCreditControlResult) Reset()         { *m = GxCreditControlResult{} }
 func (m *GxCreditControlResult) String() string { return proto.CompactTextString(m) }
 func (*GxCreditControlResult) ProtoMessage()    {}
 func (*GxCreditControlResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{17}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{24}
 }
 
 func (m *GxCreditControlResult) XXX_Unmarshal(b []byte) error {
@@ -1714,7 +2202,7 @@ func (m *ErrorByIndex) Reset()         { *m = ErrorByIndex{} }
 func (m *ErrorByIndex) String() string { return proto.CompactTextString(m) }
 func (*ErrorByIndex) ProtoMessage()    {}
 func (*ErrorByIndex) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{18}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{25}
 }
 
 func (m *ErrorByIndex) XXX_Unmarshal(b []byte) error {
@@ -1761,7 +2249,7 @@ func (m *ExpectationResult) Reset()         { *m = ExpectationResult{} }
 func (m *ExpectationResult) String() string { return proto.CompactTextString(m) }
 func (*ExpectationResult) ProtoMessage()    {}
 func (*ExpectationResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{19}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{26}
 }
 
 func (m *ExpectationResult) XXX_Unmarshal(b []byte) error {
@@ -1808,7 +2296,7 @@ func (m *GxCreditControlExpectation) Reset()         { *m = GxCreditControlExpec
 func (m *GxCreditControlExpectation) String() string { return proto.CompactTextString(m) }
 func (*GxCreditControlExpectation) ProtoMessage()    {}
 func (*GxCreditControlExpectation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{20}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{27}
 }
 
 func (m *GxCreditControlExpectation) XXX_Unmarshal(b []byte) error {
@@ -1863,7 +2351,7 @@ func (m *GxCreditControlRequest) Reset()         { *m = GxCreditControlRequest{}
 func (m *GxCreditControlRequest) String() string { return proto.CompactTextString(m) }
 func (*GxCreditControlRequest) ProtoMessage()    {}
 func (*GxCreditControlRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{21}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{28}
 }
 
 func (m *GxCreditControlRequest) XXX_Unmarshal(b []byte) error {
@@ -1943,7 +2431,7 @@ func (m *GxCreditControlAnswer) Reset()         { *m = GxCreditControlAnswer{} }
 func (m *GxCreditControlAnswer) String() string { return proto.CompactTextString(m) }
 func (*GxCreditControlAnswer) ProtoMessage()    {}
 func (*GxCreditControlAnswer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{22}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{29}
 }
 
 func (m *GxCreditControlAnswer) XXX_Unmarshal(b []byte) error {
@@ -2019,7 +2507,7 @@ func (m *GyCreditControlExpectations) Reset()         { *m = GyCreditControlExpe
 func (m *GyCreditControlExpectations) String() string { return proto.CompactTextString(m) }
 func (*GyCreditControlExpectations) ProtoMessage()    {}
 func (*GyCreditControlExpectations) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{23}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{30}
 }
 
 func (m *GyCreditControlExpectations) XXX_Unmarshal(b []byte) error {
@@ -2073,7 +2561,7 @@ func (m *GyCreditControlResult) Reset()         { *m = GyCreditControlResult{} }
 func (m *GyCreditControlResult) String() string { return proto.CompactTextString(m) }
 func (*GyCreditControlResult) ProtoMessage()    {}
 func (*GyCreditControlResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{24}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{31}
 }
 
 func (m *GyCreditControlResult) XXX_Unmarshal(b []byte) error {
@@ -2120,7 +2608,7 @@ func (m *GyCreditControlExpectation) Reset()         { *m = GyCreditControlExpec
 func (m *GyCreditControlExpectation) String() string { return proto.CompactTextString(m) }
 func (*GyCreditControlExpectation) ProtoMessage()    {}
 func (*GyCreditControlExpectation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{25}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{32}
 }
 
 func (m *GyCreditControlExpectation) XXX_Unmarshal(b []byte) error {
@@ -2175,7 +2663,7 @@ func (m *GyCreditControlRequest) Reset()         { *m = GyCreditControlRequest{}
 func (m *GyCreditControlRequest) String() string { return proto.CompactTextString(m) }
 func (*GyCreditControlRequest) ProtoMessage()    {}
 func (*GyCreditControlRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{26}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{33}
 }
 
 func (m *GyCreditControlRequest) XXX_Unmarshal(b []byte) error {
@@ -2253,7 +2741,7 @@ func (m *GyCreditControlAnswer) Reset()         { *m = GyCreditControlAnswer{} }
 func (m *GyCreditControlAnswer) String() string { return proto.CompactTextString(m) }
 func (*GyCreditControlAnswer) ProtoMessage()    {}
 func (*GyCreditControlAnswer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{27}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{34}
 }
 
 func (m *GyCreditControlAnswer) XXX_Unmarshal(b []byte) error {
@@ -2325,7 +2813,7 @@ func (m *QuotaGrant) Reset()         { *m = QuotaGrant{} }
 func (m *QuotaGrant) String() string { return proto.CompactTextString(m) }
 func (*QuotaGrant) ProtoMessage()    {}
 func (*QuotaGrant) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{28}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{35}
 }
 
 func (m *QuotaGrant) XXX_Unmarshal(b []byte) error {
@@ -2402,7 +2890,7 @@ func (m *UsageMonitoringInformation) Reset()         { *m = UsageMonitoringInfor
 func (m *UsageMonitoringInformation) String() string { return proto.CompactTextString(m) }
 func (*UsageMonitoringInformation) ProtoMessage()    {}
 func (*UsageMonitoringInformation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{29}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{36}
 }
 
 func (m *UsageMonitoringInformation) XXX_Unmarshal(b []byte) error {
@@ -2457,7 +2945,7 @@ func (m *MultipleServicesCreditControl) Reset()         { *m = MultipleServicesC
 func (m *MultipleServicesCreditControl) String() string { return proto.CompactTextString(m) }
 func (*MultipleServicesCreditControl) ProtoMessage()    {}
 func (*MultipleServicesCreditControl) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{30}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{37}
 }
 
 func (m *MultipleServicesCreditControl) XXX_Unmarshal(b []byte) error {
@@ -2513,7 +3001,7 @@ func (m *QosInfo) Reset()         { *m = QosInfo{} }
 func (m *QosInfo) String() string { return proto.CompactTextString(m) }
 func (*QosInfo) ProtoMessage()    {}
 func (*QosInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{31}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{38}
 }
 
 func (m *QosInfo) XXX_Unmarshal(b []byte) error {
@@ -2563,7 +3051,7 @@ func (m *RuleInstalls) Reset()         { *m = RuleInstalls{} }
 func (m *RuleInstalls) String() string { return proto.CompactTextString(m) }
 func (*RuleInstalls) ProtoMessage()    {}
 func (*RuleInstalls) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{32}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{39}
 }
 
 func (m *RuleInstalls) XXX_Unmarshal(b []byte) error {
@@ -2631,7 +3119,7 @@ func (m *RuleRemovals) Reset()         { *m = RuleRemovals{} }
 func (m *RuleRemovals) String() string { return proto.CompactTextString(m) }
 func (*RuleRemovals) ProtoMessage()    {}
 func (*RuleRemovals) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{33}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{40}
 }
 
 func (m *RuleRemovals) XXX_Unmarshal(b []byte) error {
@@ -2679,7 +3167,7 @@ func (m *Octets) Reset()         { *m = Octets{} }
 func (m *Octets) String() string { return proto.CompactTextString(m) }
 func (*Octets) ProtoMessage()    {}
 func (*Octets) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{34}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{41}
 }
 
 func (m *Octets) XXX_Unmarshal(b []byte) error {
@@ -2735,7 +3223,7 @@ func (m *PolicyReAuthTarget) Reset()         { *m = PolicyReAuthTarget{} }
 func (m *PolicyReAuthTarget) String() string { return proto.CompactTextString(m) }
 func (*PolicyReAuthTarget) ProtoMessage()    {}
 func (*PolicyReAuthTarget) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{35}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{42}
 }
 
 func (m *PolicyReAuthTarget) XXX_Unmarshal(b []byte) error {
@@ -2797,7 +3285,7 @@ func (m *PolicyReAuthAnswer) Reset()         { *m = PolicyReAuthAnswer{} }
 func (m *PolicyReAuthAnswer) String() string { return proto.CompactTextString(m) }
 func (*PolicyReAuthAnswer) ProtoMessage()    {}
 func (*PolicyReAuthAnswer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{36}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{43}
 }
 
 func (m *PolicyReAuthAnswer) XXX_Unmarshal(b []byte) error {
@@ -2850,7 +3338,7 @@ func (m *AbortSessionRequest) Reset()         { *m = AbortSessionRequest{} }
 func (m *AbortSessionRequest) String() string { return proto.CompactTextString(m) }
 func (*AbortSessionRequest) ProtoMessage()    {}
 func (*AbortSessionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{37}
+	return fileDescriptor_ef3afe2df05d1dc6, []int{44}
 }
 
 func (m *AbortSessionRequest) XXX_Unmarshal(b []byte) error {
@@ -2869,68 +3357,479 @@ func (m *AbortSessionRequest) XXX_DiscardUnknown() {
 	xxx_messageInfo_AbortSessionRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AbortSessionRequest proto.InternalMessageInfo
+var xxx_messageInfo_AbortSessionRequest proto.InternalMessageInfo
+
+func (m *AbortSessionRequest) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+type AbortSessionAnswer struct {
+	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ResultCode           uint32   `protobuf:"varint,2,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	ErrorMessage         string   `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AbortSessionAnswer) Reset()         { *m = AbortSessionAnswer{} }
+func (m *AbortSessionAnswer) String() string { return proto.CompactTextString(m) }
+func (*AbortSessionAnswer) ProtoMessage()    {}
+func (*AbortSessionAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{45}
+}
+
+func (m *AbortSessionAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AbortSessionAnswer.Unmarshal(m, b)
+}
+func (m *AbortSessionAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AbortSessionAnswer.Marshal(b, m, deterministic)
+}
+func (m *AbortSessionAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AbortSessionAnswer.Merge(m, src)
+}
+func (m *AbortSessionAnswer) XXX_Size() int {
+	return xxx_messageInfo_AbortSessionAnswer.Size(m)
+}
+func (m *AbortSessionAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_AbortSessionAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AbortSessionAnswer proto.InternalMessageInfo
+
+func (m *AbortSessionAnswer) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *AbortSessionAnswer) GetResultCode() uint32 {
+	if m != nil {
+		return m.ResultCode
+	}
+	return 0
+}
+
+func (m *AbortSessionAnswer) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+type AFMediaSubComponent struct {
+	FlowNumber uint32 `protobuf:"varint,1,opt,name=flow_number,json=flowNumber,proto3" json:"flow_number,omitempty"`
+	// IPFilterRule flow descriptions, i.e. "permit out 17 from 10.0.0.1 5000 to 192.168.128.12 6000"
+	FlowDescriptions     []string `protobuf:"bytes,2,rep,name=flow_descriptions,json=flowDescriptions,proto3" json:"flow_descriptions,omitempty"`
+	FlowUsage            uint32   `protobuf:"varint,3,opt,name=flow_usage,json=flowUsage,proto3" json:"flow_usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AFMediaSubComponent) Reset()         { *m = AFMediaSubComponent{} }
+func (m *AFMediaSubComponent) String() string { return proto.CompactTextString(m) }
+func (*AFMediaSubComponent) ProtoMessage()    {}
+func (*AFMediaSubComponent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{46}
+}
+
+func (m *AFMediaSubComponent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AFMediaSubComponent.Unmarshal(m, b)
+}
+func (m *AFMediaSubComponent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AFMediaSubComponent.Marshal(b, m, deterministic)
+}
+func (m *AFMediaSubComponent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AFMediaSubComponent.Merge(m, src)
+}
+func (m *AFMediaSubComponent) XXX_Size() int {
+	return xxx_messageInfo_AFMediaSubComponent.Size(m)
+}
+func (m *AFMediaSubComponent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AFMediaSubComponent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AFMediaSubComponent proto.InternalMessageInfo
+
+func (m *AFMediaSubComponent) GetFlowNumber() uint32 {
+	if m != nil {
+		return m.FlowNumber
+	}
+	return 0
+}
+
+func (m *AFMediaSubComponent) GetFlowDescriptions() []string {
+	if m != nil {
+		return m.FlowDescriptions
+	}
+	return nil
+}
+
+func (m *AFMediaSubComponent) GetFlowUsage() uint32 {
+	if m != nil {
+		return m.FlowUsage
+	}
+	return 0
+}
+
+type AFMediaComponent struct {
+	MediaComponentNumber uint32                 `protobuf:"varint,1,opt,name=media_component_number,json=mediaComponentNumber,proto3" json:"media_component_number,omitempty"`
+	SubComponents        []*AFMediaSubComponent `protobuf:"bytes,2,rep,name=sub_components,json=subComponents,proto3" json:"sub_components,omitempty"`
+	MediaType            uint32                 `protobuf:"varint,3,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	MaxRequestedBwUl     uint32                 `protobuf:"varint,4,opt,name=max_requested_bw_ul,json=maxRequestedBwUl,proto3" json:"max_requested_bw_ul,omitempty"`
+	MaxRequestedBwDl     uint32                 `protobuf:"varint,5,opt,name=max_requested_bw_dl,json=maxRequestedBwDl,proto3" json:"max_requested_bw_dl,omitempty"`
+	MinRequestedBwUl     uint32                 `protobuf:"varint,6,opt,name=min_requested_bw_ul,json=minRequestedBwUl,proto3" json:"min_requested_bw_ul,omitempty"`
+	MinRequestedBwDl     uint32                 `protobuf:"varint,7,opt,name=min_requested_bw_dl,json=minRequestedBwDl,proto3" json:"min_requested_bw_dl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *AFMediaComponent) Reset()         { *m = AFMediaComponent{} }
+func (m *AFMediaComponent) String() string { return proto.CompactTextString(m) }
+func (*AFMediaComponent) ProtoMessage()    {}
+func (*AFMediaComponent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{47}
+}
+
+func (m *AFMediaComponent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AFMediaComponent.Unmarshal(m, b)
+}
+func (m *AFMediaComponent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AFMediaComponent.Marshal(b, m, deterministic)
+}
+func (m *AFMediaComponent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AFMediaComponent.Merge(m, src)
+}
+func (m *AFMediaComponent) XXX_Size() int {
+	return xxx_messageInfo_AFMediaComponent.Size(m)
+}
+func (m *AFMediaComponent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AFMediaComponent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AFMediaComponent proto.InternalMessageInfo
+
+func (m *AFMediaComponent) GetMediaComponentNumber() uint32 {
+	if m != nil {
+		return m.MediaComponentNumber
+	}
+	return 0
+}
+
+func (m *AFMediaComponent) GetSubComponents() []*AFMediaSubComponent {
+	if m != nil {
+		return m.SubComponents
+	}
+	return nil
+}
+
+func (m *AFMediaComponent) GetMediaType() uint32 {
+	if m != nil {
+		return m.MediaType
+	}
+	return 0
+}
+
+func (m *AFMediaComponent) GetMaxRequestedBwUl() uint32 {
+	if m != nil {
+		return m.MaxRequestedBwUl
+	}
+	return 0
+}
+
+func (m *AFMediaComponent) GetMaxRequestedBwDl() uint32 {
+	if m != nil {
+		return m.MaxRequestedBwDl
+	}
+	return 0
+}
+
+func (m *AFMediaComponent) GetMinRequestedBwUl() uint32 {
+	if m != nil {
+		return m.MinRequestedBwUl
+	}
+	return 0
+}
+
+func (m *AFMediaComponent) GetMinRequestedBwDl() uint32 {
+	if m != nil {
+		return m.MinRequestedBwDl
+	}
+	return 0
+}
+
+type AFSessionRequest struct {
+	// session_id is generated by the mock AF if empty
+	SessionId            string              `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Imsi                 string              `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	MediaComponents      []*AFMediaComponent `protobuf:"bytes,3,rep,name=media_components,json=mediaComponents,proto3" json:"media_components,omitempty"`
+	SpecificActions      []uint32            `protobuf:"varint,4,rep,packed,name=specific_actions,json=specificActions,proto3" json:"specific_actions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *AFSessionRequest) Reset()         { *m = AFSessionRequest{} }
+func (m *AFSessionRequest) String() string { return proto.CompactTextString(m) }
+func (*AFSessionRequest) ProtoMessage()    {}
+func (*AFSessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{48}
+}
+
+func (m *AFSessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AFSessionRequest.Unmarshal(m, b)
+}
+func (m *AFSessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AFSessionRequest.Marshal(b, m, deterministic)
+}
+func (m *AFSessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AFSessionRequest.Merge(m, src)
+}
+func (m *AFSessionRequest) XXX_Size() int {
+	return xxx_messageInfo_AFSessionRequest.Size(m)
+}
+func (m *AFSessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AFSessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AFSessionRequest proto.InternalMessageInfo
+
+func (m *AFSessionRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *AFSessionRequest) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *AFSessionRequest) GetMediaComponents() []*AFMediaComponent {
+	if m != nil {
+		return m.MediaComponents
+	}
+	return nil
+}
+
+func (m *AFSessionRequest) GetSpecificActions() []uint32 {
+	if m != nil {
+		return m.SpecificActions
+	}
+	return nil
+}
+
+type AFSessionTarget struct {
+	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AFSessionTarget) Reset()         { *m = AFSessionTarget{} }
+func (m *AFSessionTarget) String() string { return proto.CompactTextString(m) }
+func (*AFSessionTarget) ProtoMessage()    {}
+func (*AFSessionTarget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{49}
+}
+
+func (m *AFSessionTarget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AFSessionTarget.Unmarshal(m, b)
+}
+func (m *AFSessionTarget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AFSessionTarget.Marshal(b, m, deterministic)
+}
+func (m *AFSessionTarget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AFSessionTarget.Merge(m, src)
+}
+func (m *AFSessionTarget) XXX_Size() int {
+	return xxx_messageInfo_AFSessionTarget.Size(m)
+}
+func (m *AFSessionTarget) XXX_DiscardUnknown() {
+	xxx_messageInfo_AFSessionTarget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AFSessionTarget proto.InternalMessageInfo
+
+func (m *AFSessionTarget) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+type AFSessionAnswer struct {
+	SessionId              string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ResultCode             uint32   `protobuf:"varint,2,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
+	ExperimentalResultCode uint32   `protobuf:"varint,3,opt,name=experimental_result_code,json=experimentalResultCode,proto3" json:"experimental_result_code,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
+}
+
+func (m *AFSessionAnswer) Reset()         { *m = AFSessionAnswer{} }
+func (m *AFSessionAnswer) String() string { return proto.CompactTextString(m) }
+func (*AFSessionAnswer) ProtoMessage()    {}
+func (*AFSessionAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{50}
+}
+
+func (m *AFSessionAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AFSessionAnswer.Unmarshal(m, b)
+}
+func (m *AFSessionAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AFSessionAnswer.Marshal(b, m, deterministic)
+}
+func (m *AFSessionAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AFSessionAnswer.Merge(m, src)
+}
+func (m *AFSessionAnswer) XXX_Size() int {
+	return xxx_messageInfo_AFSessionAnswer.Size(m)
+}
+func (m *AFSessionAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_AFSessionAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AFSessionAnswer proto.InternalMessageInfo
 
-func (m *AbortSessionRequest) GetImsi() string {
+func (m *AFSessionAnswer) GetSessionId() string {
 	if m != nil {
-		return m.Imsi
+		return m.SessionId
 	}
 	return ""
 }
 
-type AbortSessionAnswer struct {
-	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	ResultCode           uint32   `protobuf:"varint,2,opt,name=result_code,json=resultCode,proto3" json:"result_code,omitempty"`
-	ErrorMessage         string   `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *AFSessionAnswer) GetResultCode() uint32 {
+	if m != nil {
+		return m.ResultCode
+	}
+	return 0
 }
 
-func (m *AbortSessionAnswer) Reset()         { *m = AbortSessionAnswer{} }
-func (m *AbortSessionAnswer) String() string { return proto.CompactTextString(m) }
-func (*AbortSessionAnswer) ProtoMessage()    {}
-func (*AbortSessionAnswer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ef3afe2df05d1dc6, []int{38}
+func (m *AFSessionAnswer) GetExperimentalResultCode() uint32 {
+	if m != nil {
+		return m.ExperimentalResultCode
+	}
+	return 0
 }
 
-func (m *AbortSessionAnswer) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AbortSessionAnswer.Unmarshal(m, b)
+type AFSessionEvent struct {
+	Type                  AFSessionEvent_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=magma.feg.AFSessionEvent_EventType" json:"type,omitempty"`
+	SessionId             string                   `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SpecificAction        uint32                   `protobuf:"varint,3,opt,name=specific_action,json=specificAction,proto3" json:"specific_action,omitempty"`
+	AbortCause            uint32                   `protobuf:"varint,4,opt,name=abort_cause,json=abortCause,proto3" json:"abort_cause,omitempty"`
+	MediaComponentNumbers []uint32                 `protobuf:"varint,5,rep,packed,name=media_component_numbers,json=mediaComponentNumbers,proto3" json:"media_component_numbers,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{}                 `json:"-"`
+	XXX_unrecognized      []byte                   `json:"-"`
+	XXX_sizecache         int32                    `json:"-"`
 }
-func (m *AbortSessionAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AbortSessionAnswer.Marshal(b, m, deterministic)
+
+func (m *AFSessionEvent) Reset()         { *m = AFSessionEvent{} }
+func (m *AFSessionEvent) String() string { return proto.CompactTextString(m) }
+func (*AFSessionEvent) ProtoMessage()    {}
+func (*AFSessionEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{51}
 }
-func (m *AbortSessionAnswer) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AbortSessionAnswer.Merge(m, src)
+
+func (m *AFSessionEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AFSessionEvent.Unmarshal(m, b)
 }
-func (m *AbortSessionAnswer) XXX_Size() int {
-	return xxx_messageInfo_AbortSessionAnswer.Size(m)
+func (m *AFSessionEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AFSessionEvent.Marshal(b, m, deterministic)
 }
-func (m *AbortSessionAnswer) XXX_DiscardUnknown() {
-	xxx_messageInfo_AbortSessionAnswer.DiscardUnknown(m)
+func (m *AFSessionEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AFSessionEvent.Merge(m, src)
+}
+func (m *AFSessionEvent) XXX_Size() int {
+	return xxx_messageInfo_AFSessionEvent.Size(m)
+}
+func (m *AFSessionEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AFSessionEvent.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AbortSessionAnswer proto.InternalMessageInfo
+var xxx_messageInfo_AFSessionEvent proto.InternalMessageInfo
 
-func (m *AbortSessionAnswer) GetSessionId() string {
+func (m *AFSessionEvent) GetType() AFSessionEvent_EventType {
+	if m != nil {
+		return m.Type
+	}
+	return AFSessionEvent_REAUTH
+}
+
+func (m *AFSessionEvent) GetSessionId() string {
 	if m != nil {
 		return m.SessionId
 	}
 	return ""
 }
 
-func (m *AbortSessionAnswer) GetResultCode() uint32 {
+func (m *AFSessionEvent) GetSpecificAction() uint32 {
 	if m != nil {
-		return m.ResultCode
+		return m.SpecificAction
 	}
 	return 0
 }
 
-func (m *AbortSessionAnswer) GetErrorMessage() string {
+func (m *AFSessionEvent) GetAbortCause() uint32 {
 	if m != nil {
-		return m.ErrorMessage
+		return m.AbortCause
 	}
-	return ""
+	return 0
+}
+
+func (m *AFSessionEvent) GetMediaComponentNumbers() []uint32 {
+	if m != nil {
+		return m.MediaComponentNumbers
+	}
+	return nil
+}
+
+type AFSessionEvents struct {
+	Events               []*AFSessionEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *AFSessionEvents) Reset()         { *m = AFSessionEvents{} }
+func (m *AFSessionEvents) String() string { return proto.CompactTextString(m) }
+func (*AFSessionEvents) ProtoMessage()    {}
+func (*AFSessionEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ef3afe2df05d1dc6, []int{52}
+}
+
+func (m *AFSessionEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AFSessionEvents.Unmarshal(m, b)
+}
+func (m *AFSessionEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AFSessionEvents.Marshal(b, m, deterministic)
+}
+func (m *AFSessionEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AFSessionEvents.Merge(m, src)
+}
+func (m *AFSessionEvents) XXX_Size() int {
+	return xxx_messageInfo_AFSessionEvents.Size(m)
+}
+func (m *AFSessionEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_AFSessionEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AFSessionEvents proto.InternalMessageInfo
+
+func (m *AFSessionEvents) GetEvents() []*AFSessionEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
 }
 
 func init() {
@@ -2941,6 +3840,8 @@ func init() {
 	proto.RegisterEnum("magma.feg.AbortCauseType", AbortCauseType_name, AbortCauseType_value)
 	proto.RegisterEnum("magma.feg.Reply_ServerBehavior", Reply_ServerBehavior_name, Reply_ServerBehavior_value)
 	proto.RegisterEnum("magma.feg.CreditInfo_UnitType", CreditInfo_UnitType_name, CreditInfo_UnitType_value)
+	proto.RegisterEnum("magma.feg.Tariff_Unit", Tariff_Unit_name, Tariff_Unit_value)
+	proto.RegisterEnum("magma.feg.AFSessionEvent_EventType", AFSessionEvent_EventType_name, AFSessionEvent_EventType_value)
 	proto.RegisterType((*Reply)(nil), "magma.feg.Reply")
 	proto.RegisterType((*ExpectedRequest)(nil), "magma.feg.ExpectedRequest")
 	proto.RegisterType((*RequestReply)(nil), "magma.feg.RequestReply")
@@ -2951,6 +3852,13 @@ func init() {
 	proto.RegisterType((*CreditInfo)(nil), "magma.feg.CreditInfo")
 	proto.RegisterType((*CreditInfos)(nil), "magma.feg.CreditInfos")
 	proto.RegisterMapType((map[uint32]*CreditInfo)(nil), "magma.feg.CreditInfos.CreditInformationEntry")
+	proto.RegisterType((*TariffPeriod)(nil), "magma.feg.TariffPeriod")
+	proto.RegisterType((*Tariff)(nil), "magma.feg.Tariff")
+	proto.RegisterType((*CreditPool)(nil), "magma.feg.CreditPool")
+	proto.RegisterType((*SubscriberBalance)(nil), "magma.feg.SubscriberBalance")
+	proto.RegisterType((*TariffScenario)(nil), "magma.feg.TariffScenario")
+	proto.RegisterType((*RatedUsage)(nil), "magma.feg.RatedUsage")
+	proto.RegisterType((*RatingState)(nil), "magma.feg.RatingState")
 	proto.RegisterType((*ChargingReAuthTarget)(nil), "magma.feg.ChargingReAuthTarget")
 	proto.RegisterType((*ChargingReAuthAnswer)(nil), "magma.feg.ChargingReAuthAnswer")
 	proto.RegisterType((*PCRFConfigs)(nil), "magma.feg.PCRFConfigs")
@@ -2982,239 +3890,298 @@ func init() {
 	proto.RegisterMapType((map[string]uint32)(nil), "magma.feg.PolicyReAuthAnswer.FailedRulesEntry")
 	proto.RegisterType((*AbortSessionRequest)(nil), "magma.feg.AbortSessionRequest")
 	proto.RegisterType((*AbortSessionAnswer)(nil), "magma.feg.AbortSessionAnswer")
+	proto.RegisterType((*AFMediaSubComponent)(nil), "magma.feg.AFMediaSubComponent")
+	proto.RegisterType((*AFMediaComponent)(nil), "magma.feg.AFMediaComponent")
+	proto.RegisterType((*AFSessionRequest)(nil), "magma.feg.AFSessionRequest")
+	proto.RegisterType((*AFSessionTarget)(nil), "magma.feg.AFSessionTarget")
+	proto.RegisterType((*AFSessionAnswer)(nil), "magma.feg.AFSessionAnswer")
+	proto.RegisterType((*AFSessionEvent)(nil), "magma.feg.AFSessionEvent")
+	proto.RegisterType((*AFSessionEvents)(nil), "magma.feg.AFSessionEvents")
 }
 
 func init() { proto.RegisterFile("feg/protos/mock_core.proto", fileDescriptor_ef3afe2df05d1dc6) }
 
 var fileDescriptor_ef3afe2df05d1dc6 = []byte{
-	// 3632 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0x4b, 0x93, 0x1b, 0xc9,
-	0x56, 0x96, 0xd4, 0x0f, 0xb7, 0x8e, 0x5e, 0xd5, 0xd9, 0x0f, 0xcb, 0xed, 0xeb, 0x71, 0x4f, 0x79,
-	0xc6, 0x78, 0x7c, 0xb9, 0xed, 0x88, 0x9e, 0x0b, 0x38, 0xc6, 0x33, 0x77, 0x50, 0xab, 0xbb, 0xdd,
-	0x62, 0xfa, 0x61, 0xa7, 0xd4, 0x76, 0xdc, 0x99, 0x08, 0x8a, 0xea, 0x52, 0x4a, 0x2e, 0x5c, 0xaa,
-	0x92, 0x33, 0xab, 0xda, 0xad, 0x08, 0x16, 0xb0, 0x60, 0x41, 0x04, 0x11, 0xec, 0x08, 0xd6, 0x04,
-	0x1b, 0x7e, 0x00, 0xf0, 0x13, 0xb8, 0x0b, 0x96, 0x2c, 0x88, 0xe0, 0x07, 0xf0, 0x13, 0x08, 0x36,
-	0x04, 0x91, 0x8f, 0x92, 0xb2, 0x1e, 0x72, 0x7b, 0xae, 0x07, 0x86, 0xbb, 0x92, 0xea, 0xe4, 0x77,
-	0x4e, 0x65, 0x9e, 0x3c, 0xf9, 0xe5, 0xc9, 0x93, 0x05, 0x5b, 0x03, 0x32, 0x7c, 0x34, 0xa6, 0x41,
-	0x18, 0xb0, 0x47, 0xa3, 0xc0, 0x79, 0x6d, 0x39, 0x01, 0x25, 0x3b, 0x42, 0x80, 0xca, 0x23, 0x7b,
-	0x38, 0xb2, 0x77, 0x06, 0x64, 0xb8, 0x75, 0x2b, 0xa0, 0xce, 0x63, 0x1a, 0x03, 0x9d, 0x60, 0x34,
-	0x0a, 0x7c, 0x89, 0xda, 0xda, 0xd0, 0x2c, 0x38, 0x6c, 0x70, 0xa1, 0xc4, 0xb7, 0xbc, 0x90, 0xc4,
-	0xe2, 0x71, 0xe0, 0xb9, 0xce, 0xa4, 0x1f, 0x37, 0x6d, 0x6b, 0x4d, 0x8c, 0x30, 0xe6, 0x06, 0xbe,
-	0x35, 0xb2, 0x7d, 0x7b, 0x48, 0xa8, 0x42, 0xdc, 0xd1, 0x11, 0xd1, 0x05, 0x73, 0xa8, 0x7b, 0x41,
-	0xe8, 0xd4, 0xc0, 0xdd, 0x61, 0x10, 0x0c, 0x3d, 0x85, 0xb8, 0x88, 0x06, 0x8f, 0x42, 0x77, 0x44,
-	0x58, 0x68, 0x8f, 0xc6, 0x0a, 0xf0, 0x51, 0x1a, 0xf0, 0x96, 0xda, 0xe3, 0x31, 0xa1, 0x4c, 0xb6,
-	0x9b, 0x7f, 0x53, 0x81, 0x25, 0x4c, 0xc6, 0xde, 0x04, 0x1d, 0x41, 0x83, 0x11, 0x7a, 0x49, 0xa8,
-	0x75, 0x41, 0x5e, 0xd9, 0x97, 0x6e, 0x40, 0x9b, 0xc5, 0xed, 0xe2, 0x83, 0xfa, 0xee, 0xdd, 0x9d,
-	0xe9, 0xe8, 0x77, 0x04, 0x74, 0xa7, 0x2b, 0x70, 0x7b, 0x0a, 0x86, 0xeb, 0x2c, 0xf1, 0x8c, 0xee,
-	0x42, 0x85, 0x72, 0x9c, 0xd5, 0x27, 0x9e, 0x3d, 0x69, 0x96, 0xb6, 0x8b, 0x0f, 0x96, 0x30, 0x08,
-	0xd1, 0x3e, 0x97, 0xa0, 0x5f, 0x40, 0xcd, 0xf6, 0x08, 0x0d, 0x2d, 0x4a, 0xde, 0x44, 0x84, 0x85,
-	0xcd, 0x85, 0xed, 0xe2, 0x83, 0xca, 0xee, 0x4d, 0xed, 0x45, 0x2d, 0xde, 0x8e, 0x65, 0xf3, 0x51,
-	0x01, 0x57, 0x6d, 0xed, 0x19, 0xfd, 0x01, 0xac, 0xf6, 0x83, 0xb7, 0xbe, 0xe7, 0xfa, 0xaf, 0xad,
-	0xc8, 0x77, 0xc3, 0xbe, 0x1d, 0xda, 0xcd, 0x45, 0x61, 0xe3, 0xb6, 0x66, 0x63, 0x5f, 0x61, 0xce,
-	0x15, 0xe4, 0xa8, 0x80, 0x8d, 0x7e, 0x4a, 0x86, 0xbe, 0x86, 0x3a, 0x19, 0x33, 0xab, 0x4f, 0x42,
-	0xdb, 0x79, 0x65, 0xd9, 0xce, 0xeb, 0xe6, 0x52, 0xa6, 0x33, 0x07, 0xcf, 0xba, 0xfb, 0xa2, 0xbd,
-	0xe5, 0xbc, 0xe6, 0x9d, 0x21, 0x63, 0x36, 0x7d, 0x46, 0x7b, 0xd0, 0x70, 0x47, 0xcc, 0xd5, 0x2d,
-	0x2c, 0x0b, 0x0b, 0x4d, 0xcd, 0x42, 0xe7, 0xa4, 0xdb, 0xd1, 0x4d, 0xd4, 0xb8, 0xca, 0xcc, 0xc6,
-	0x4b, 0xd8, 0xf4, 0x02, 0xc7, 0x0e, 0xf9, 0xfc, 0x47, 0xe3, 0xbe, 0x1d, 0x12, 0xcb, 0x76, 0x1c,
-	0x32, 0x0e, 0x9b, 0x37, 0x84, 0x29, 0x7d, 0x0a, 0x8e, 0x15, 0xf0, 0x5c, 0xe0, 0x5a, 0x02, 0x76,
-	0x54, 0xc0, 0xeb, 0x5e, 0x8e, 0x3c, 0xcf, 0x30, 0x25, 0x7f, 0x4c, 0x9c, 0xb0, 0xb9, 0x72, 0x8d,
-	0x61, 0x2c, 0x60, 0x59, 0xc3, 0x52, 0xce, 0x0d, 0x8f, 0x46, 0x96, 0xeb, 0x0f, 0x02, 0x3a, 0x92,
-	0xe6, 0xe3, 0xb9, 0x2c, 0x67, 0x0c, 0x9f, 0x9c, 0x74, 0x66, 0xb8, 0xd9, 0x9c, 0xae, 0x8f, 0x46,
-	0x59, 0x39, 0x6a, 0x41, 0x7d, 0x6c, 0x0f, 0x5d, 0x7f, 0x38, 0x35, 0x08, 0x19, 0x6f, 0x3e, 0x13,
-	0x80, 0x99, 0xa5, 0xda, 0x58, 0x17, 0xa0, 0x7d, 0x68, 0x50, 0xe2, 0x11, 0x9b, 0x91, 0xa9, 0x8d,
-	0x8a, 0xb0, 0x71, 0x2b, 0x11, 0xc9, 0x02, 0x31, 0x33, 0x52, 0xa7, 0x09, 0x09, 0xea, 0xc1, 0x06,
-	0x8f, 0x6b, 0xd7, 0x21, 0x96, 0x7d, 0x11, 0x68, 0xc1, 0x5a, 0x15, 0xb6, 0x3e, 0xd2, 0x6c, 0x75,
-	0x25, 0xae, 0xc5, 0x61, 0x33, 0x83, 0x6b, 0x2c, 0x2b, 0x46, 0xbb, 0x50, 0xa6, 0x84, 0x91, 0x50,
-	0xc4, 0x49, 0x4d, 0x58, 0x5a, 0x4b, 0xf4, 0x8a, 0x91, 0x50, 0x86, 0xc8, 0x0a, 0x55, 0xff, 0xd1,
-	0x53, 0x30, 0xa4, 0x8e, 0xeb, 0xf7, 0x5d, 0x39, 0x17, 0xcd, 0xba, 0x50, 0xdd, 0x4a, 0xab, 0x76,
-	0xa6, 0x88, 0xa3, 0x02, 0x6e, 0xd0, 0xa4, 0x08, 0xfd, 0x14, 0x96, 0x59, 0x68, 0x87, 0x11, 0x6b,
-	0x36, 0x84, 0xfa, 0xaa, 0x3e, 0x06, 0xd1, 0x70, 0x54, 0xc0, 0x0a, 0x82, 0xbe, 0x85, 0x9b, 0x0e,
-	0x25, 0x3c, 0x62, 0x62, 0x66, 0xa2, 0x84, 0x8d, 0x03, 0x9f, 0x91, 0xa6, 0x21, 0xb4, 0xb7, 0x95,
-	0xb6, 0x17, 0x92, 0x9d, 0xb6, 0x40, 0x76, 0x25, 0x10, 0x2b, 0xdc, 0x51, 0x11, 0x6f, 0x38, 0x79,
-	0x0d, 0xdc, 0xb6, 0x8a, 0xc6, 0x8c, 0xed, 0xd5, 0x8c, 0x6d, 0x19, 0x77, 0x39, 0xb6, 0xa3, 0xbc,
-	0x06, 0xe4, 0xc0, 0x56, 0x6c, 0x34, 0x24, 0x74, 0xe4, 0xfa, 0x32, 0xe8, 0x95, 0x79, 0x24, 0xcc,
-	0xdf, 0xd3, 0xcc, 0x2b, 0xfd, 0x5e, 0x8c, 0xd5, 0xde, 0xd0, 0x64, 0x73, 0xda, 0xcc, 0x36, 0xd4,
-	0x93, 0x24, 0x88, 0xd6, 0xa0, 0x81, 0x0f, 0x9e, 0x1d, 0xff, 0xd2, 0xea, 0x9c, 0x76, 0x7b, 0xad,
-	0xd3, 0xde, 0xf1, 0x2f, 0x8d, 0x02, 0xaa, 0x03, 0x48, 0xe1, 0x71, 0xab, 0x77, 0x60, 0x14, 0x51,
-	0x15, 0x56, 0x4e, 0xcf, 0x2c, 0x21, 0x32, 0x4a, 0x7b, 0x35, 0xa8, 0xb0, 0x21, 0xb3, 0x46, 0x84,
-	0x31, 0x7b, 0x48, 0xf6, 0xea, 0x50, 0x1d, 0x5e, 0x0d, 0x27, 0xf1, 0xb3, 0xf9, 0x4f, 0x00, 0x8d,
-	0x83, 0xab, 0x31, 0x71, 0x42, 0xd2, 0xd7, 0xc2, 0x47, 0x32, 0x27, 0x0f, 0x9f, 0x62, 0x26, 0x7c,
-	0x04, 0x6b, 0xaa, 0xf0, 0xb1, 0xd5, 0x7f, 0xf4, 0x04, 0xaa, 0x31, 0xdb, 0x8a, 0x95, 0x5f, 0x12,
-	0x6a, 0x9b, 0x59, 0xb2, 0x55, 0x0b, 0xbe, 0x62, 0xcf, 0x1e, 0xf9, 0x2a, 0xd0, 0xe8, 0x51, 0x0b,
-	0xc0, 0x85, 0xcc, 0x2a, 0x98, 0xb2, 0x64, 0x22, 0x08, 0xd7, 0xa6, 0x64, 0xa9, 0x05, 0xe2, 0x4b,
-	0xd8, 0xd4, 0x39, 0x53, 0x33, 0xbb, 0x98, 0x61, 0x8f, 0x19, 0x75, 0x26, 0xec, 0xae, 0xcf, 0x18,
-	0x54, 0x33, 0xfc, 0x2d, 0xdc, 0xcc, 0xf2, 0x9d, 0x5c, 0xb6, 0x4b, 0x89, 0xc0, 0xca, 0x23, 0xbc,
-	0x78, 0xe1, 0x6e, 0x78, 0x79, 0x0d, 0x7c, 0xd7, 0x9a, 0x32, 0x93, 0x70, 0xe4, 0x72, 0x66, 0xa3,
-	0x88, 0x89, 0x49, 0x79, 0xb2, 0x3a, 0xd6, 0x9e, 0x39, 0x2d, 0xc5, 0x84, 0x12, 0xf7, 0xe9, 0x46,
-	0x86, 0x96, 0x14, 0x95, 0x68, 0xb4, 0xc4, 0x12, 0x12, 0x1e, 0xde, 0x21, 0x77, 0x1d, 0x25, 0xb6,
-	0x37, 0x1d, 0xaa, 0x13, 0x8c, 0xc6, 0x1e, 0x09, 0x89, 0x62, 0xf5, 0x7b, 0x9a, 0xc1, 0xde, 0x49,
-	0xb7, 0x83, 0x35, 0x6c, 0x5b, 0x41, 0x8f, 0x0a, 0xb8, 0xc9, 0x0d, 0xe5, 0xb5, 0xf1, 0xf9, 0x89,
-	0xf8, 0x16, 0x14, 0xba, 0x97, 0x6e, 0x38, 0xd1, 0xe7, 0x27, 0xcb, 0xee, 0xe7, 0x07, 0x2d, 0x85,
-	0x4b, 0xce, 0x4f, 0x44, 0xb2, 0x72, 0xce, 0xee, 0x11, 0xb1, 0x22, 0x9f, 0x12, 0xdb, 0x79, 0x65,
-	0x5f, 0x78, 0x24, 0x87, 0xdd, 0xcf, 0x0f, 0xce, 0x67, 0xed, 0x9c, 0xdd, 0x23, 0xa2, 0x09, 0xb8,
-	0x1b, 0xa3, 0x71, 0x72, 0xeb, 0xcf, 0xb2, 0xfb, 0xf9, 0x38, 0xb5, 0xf1, 0xd7, 0xa3, 0x84, 0x24,
-	0xc9, 0xc3, 0xd5, 0x5f, 0x9f, 0x87, 0x6b, 0x1f, 0xc6, 0xc3, 0xf5, 0xeb, 0x79, 0xf8, 0x25, 0x6c,
-	0x66, 0x78, 0x58, 0x46, 0x4f, 0x23, 0x31, 0x17, 0x39, 0x34, 0x2c, 0x63, 0xa8, 0x88, 0xd7, 0x9d,
-	0x1c, 0xb9, 0x98, 0xe4, 0x34, 0x09, 0x4b, 0xc3, 0x46, 0xc6, 0x70, 0x8a, 0x83, 0xa7, 0x86, 0xa3,
-	0x1c, 0x39, 0xfa, 0x23, 0xb8, 0x95, 0xc7, 0xc0, 0xd2, 0xb6, 0xe4, 0x77, 0xf3, 0x9d, 0x04, 0x1c,
-	0x9b, 0xbf, 0xc9, 0xf2, 0x9b, 0xae, 0x63, 0x4e, 0x0f, 0xaa, 0x0a, 0x29, 0x53, 0xdb, 0x9f, 0xc3,
-	0x8d, 0xf8, 0xf5, 0xc5, 0xcc, 0x7c, 0xa5, 0x28, 0x16, 0xc7, 0x50, 0x74, 0x1f, 0x96, 0x44, 0xce,
-	0xaa, 0x08, 0xd3, 0x48, 0xa7, 0xc1, 0x58, 0x36, 0x9b, 0x5d, 0x58, 0x93, 0x7b, 0x41, 0x3b, 0xf0,
-	0x07, 0xee, 0x30, 0xa2, 0x72, 0x92, 0xbf, 0x84, 0x9a, 0xb2, 0x64, 0x49, 0x33, 0xc5, 0xed, 0x85,
-	0x14, 0x5d, 0xe8, 0x9d, 0xc4, 0x55, 0xaa, 0x3d, 0x99, 0x87, 0x50, 0xc7, 0xa4, 0xef, 0x52, 0xe2,
-	0x84, 0xd2, 0x38, 0xfa, 0x39, 0x6c, 0x24, 0x25, 0xad, 0x7e, 0x9f, 0x12, 0xc6, 0xc4, 0x90, 0xca,
-	0x38, 0xbf, 0xd1, 0xfc, 0x55, 0x11, 0xd6, 0x0e, 0x5d, 0xdf, 0xf6, 0x78, 0xe4, 0x6b, 0x21, 0xb8,
-	0xc7, 0x73, 0x24, 0xa9, 0x60, 0xc9, 0xf4, 0x5d, 0xb9, 0x26, 0x99, 0x23, 0xe9, 0x26, 0x79, 0x86,
-	0x94, 0xe8, 0xd1, 0x21, 0xac, 0x0e, 0xb8, 0x69, 0xb1, 0x10, 0x05, 0x5b, 0x04, 0xbe, 0x70, 0x56,
-	0x3d, 0xe1, 0xe0, 0xe9, 0xeb, 0x5b, 0x02, 0x81, 0x1b, 0x83, 0xa4, 0x00, 0x7d, 0x0a, 0x75, 0x4a,
-	0x58, 0x48, 0x5d, 0x27, 0xb4, 0x68, 0xe4, 0x11, 0xd6, 0x5c, 0xd8, 0x5e, 0x78, 0x50, 0xc6, 0xb5,
-	0x58, 0x8a, 0xb9, 0xd0, 0xfc, 0xab, 0x12, 0x94, 0xcf, 0xda, 0x5d, 0xe9, 0x65, 0xf4, 0x04, 0x8c,
-	0x91, 0x7d, 0x65, 0x45, 0x7c, 0xc2, 0xad, 0xc0, 0x09, 0x49, 0xc8, 0xd4, 0x08, 0xf4, 0xd5, 0x74,
-	0x26, 0x1a, 0x70, 0x7d, 0x64, 0x5f, 0x9d, 0x73, 0xa4, 0x7c, 0x46, 0x9f, 0x40, 0x7d, 0xa6, 0xcc,
-	0x8f, 0x4c, 0xa2, 0xdb, 0x35, 0x5c, 0x8d, 0x71, 0x3d, 0x77, 0x44, 0xd0, 0x3d, 0xa8, 0x5d, 0xda,
-	0x9e, 0xdb, 0xe7, 0x14, 0x28, 0x40, 0x0b, 0x12, 0x14, 0x0b, 0x05, 0xe8, 0x3e, 0x34, 0x22, 0x46,
-	0x2c, 0x71, 0x62, 0xec, 0x53, 0x97, 0x3b, 0x92, 0xef, 0x61, 0x2b, 0xb8, 0x16, 0x31, 0x72, 0x12,
-	0x38, 0xaf, 0xf7, 0x85, 0x10, 0x61, 0xd8, 0xd0, 0x9c, 0xa5, 0x31, 0xc8, 0x52, 0x66, 0x23, 0xcd,
-	0x99, 0x2f, 0xbc, 0x36, 0xc8, 0x0a, 0xcd, 0x7f, 0x29, 0x02, 0xb4, 0xf9, 0xa4, 0x84, 0x3c, 0x91,
-	0x46, 0x08, 0x16, 0xf9, 0xa6, 0xa8, 0x02, 0x42, 0xfc, 0x47, 0x1f, 0x43, 0xd5, 0x79, 0x65, 0x53,
-	0xb1, 0x6d, 0xbd, 0x26, 0x13, 0x35, 0xce, 0x4a, 0x2c, 0xfb, 0x86, 0x4c, 0xd0, 0x67, 0xb0, 0x7c,
-	0x19, 0x78, 0x91, 0x1a, 0x5f, 0xae, 0xff, 0x14, 0x00, 0x3d, 0x81, 0xb2, 0xe8, 0x7e, 0x38, 0x19,
-	0x13, 0x31, 0xcc, 0x7a, 0xa2, 0xe3, 0xb3, 0xbe, 0xec, 0xf0, 0x9e, 0xf6, 0x26, 0x63, 0x82, 0x57,
-	0x22, 0xf5, 0xcf, 0xbc, 0x0b, 0x2b, 0xb1, 0x14, 0x95, 0x61, 0x69, 0x6f, 0x12, 0x12, 0x66, 0x14,
-	0xd0, 0x0a, 0x2c, 0x72, 0x47, 0x1a, 0x45, 0xf3, 0x9f, 0x8b, 0x50, 0x99, 0x99, 0x60, 0xe8, 0x3b,
-	0x58, 0x75, 0xa6, 0x8f, 0xea, 0x98, 0xa0, 0x56, 0xd1, 0xcf, 0x72, 0xdf, 0xca, 0xb4, 0xff, 0x0a,
-	0x7f, 0xe0, 0x87, 0x74, 0x82, 0xb3, 0x76, 0xb6, 0xbe, 0x83, 0xcd, 0x7c, 0x30, 0x32, 0x60, 0x81,
-	0x7b, 0xaa, 0x28, 0x3c, 0xc5, 0xff, 0xa2, 0x9f, 0xc2, 0xd2, 0xa5, 0xed, 0x45, 0x44, 0x31, 0xc1,
-	0x46, 0xee, 0xcb, 0xb1, 0xc4, 0x7c, 0x51, 0x7a, 0x5c, 0x34, 0x4f, 0x60, 0xbd, 0xad, 0x3c, 0x8c,
-	0x49, 0x2b, 0x0a, 0x5f, 0xf5, 0x6c, 0x3a, 0x24, 0xe1, 0xbc, 0x19, 0xe2, 0x8c, 0xe1, 0x0f, 0xad,
-	0x21, 0x0d, 0xa2, 0x71, 0x3c, 0x43, 0x52, 0xf6, 0x94, 0x8b, 0xcc, 0x17, 0x69, 0x73, 0x2d, 0x9f,
-	0xbd, 0x25, 0x14, 0xdd, 0x01, 0x88, 0x89, 0xd6, 0xed, 0x2b, 0xa3, 0x65, 0x25, 0xe9, 0xf4, 0xe5,
-	0x39, 0x9c, 0x45, 0x5e, 0x68, 0x39, 0x41, 0x3f, 0x0e, 0x71, 0x90, 0xa2, 0x76, 0xd0, 0x27, 0xe6,
-	0xef, 0x40, 0xe5, 0x59, 0x1b, 0x1f, 0xca, 0x15, 0xc5, 0xf2, 0x42, 0xb9, 0x98, 0x13, 0xca, 0xe6,
-	0xbf, 0x15, 0xa1, 0xda, 0x72, 0x9c, 0x20, 0xf2, 0xe5, 0xca, 0xcc, 0x1d, 0xd6, 0x43, 0x58, 0xe5,
-	0x1b, 0x98, 0xeb, 0x88, 0x25, 0x6d, 0xf9, 0xf6, 0x88, 0xb0, 0x66, 0x49, 0xac, 0xeb, 0x86, 0x6c,
-	0xe0, 0xba, 0xa7, 0x5c, 0x8c, 0x3e, 0x87, 0x4d, 0x1d, 0x7b, 0xc1, 0x4f, 0x6e, 0x52, 0x41, 0x12,
-	0xc1, 0xda, 0x4c, 0x61, 0xcf, 0x66, 0x4a, 0xa9, 0x0b, 0xcd, 0xfe, 0xc4, 0xb7, 0x47, 0xb1, 0x56,
-	0x9f, 0x0c, 0x5c, 0xdf, 0xe5, 0xd3, 0xc8, 0x9a, 0x8b, 0x22, 0x48, 0x12, 0x54, 0x16, 0x79, 0x64,
-	0x7f, 0x8a, 0xc0, 0x9b, 0x4a, 0x35, 0x29, 0x66, 0xe6, 0xbf, 0x96, 0xa0, 0x9e, 0x94, 0xa1, 0xdb,
-	0x50, 0x9e, 0x8e, 0x40, 0x8d, 0x70, 0x85, 0xaa, 0xae, 0xbf, 0xc7, 0xe4, 0xa1, 0x8f, 0x00, 0xc6,
-	0x94, 0x38, 0xa4, 0x4f, 0x7c, 0x27, 0xa6, 0x10, 0x4d, 0xc2, 0xd9, 0x6f, 0x14, 0xf8, 0x6e, 0x18,
-	0xd0, 0x78, 0x8d, 0x2e, 0x8a, 0x97, 0xd4, 0x66, 0xd2, 0x6f, 0x44, 0x0c, 0xae, 0x0e, 0xbc, 0xe0,
-	0xad, 0xd5, 0x27, 0xcc, 0xa1, 0xee, 0x58, 0x8e, 0x73, 0x49, 0xb8, 0xc7, 0xe0, 0x0d, 0xfb, 0x9a,
-	0x1c, 0x3d, 0x87, 0xf5, 0x29, 0xbb, 0x6b, 0x67, 0x74, 0x95, 0x6f, 0x7e, 0xa4, 0x6d, 0xbe, 0x31,
-	0xc5, 0xeb, 0x27, 0xf1, 0x35, 0x9a, 0x15, 0xa2, 0x27, 0xd0, 0x78, 0x13, 0xb0, 0x84, 0x35, 0x99,
-	0x6c, 0x22, 0xcd, 0xda, 0xa1, 0x17, 0xbc, 0x7d, 0x1e, 0x30, 0x5c, 0x7f, 0x13, 0x30, 0x4d, 0xd9,
-	0xfc, 0x13, 0xb8, 0x25, 0x68, 0xf5, 0x44, 0x0e, 0x29, 0xb9, 0x51, 0xe6, 0x45, 0xcf, 0x37, 0xb0,
-	0x21, 0xc9, 0x59, 0x39, 0xc1, 0x92, 0x0b, 0x58, 0x46, 0x50, 0x72, 0x13, 0xd5, 0x0d, 0xe3, 0xb5,
-	0x48, 0x7f, 0x8d, 0xd4, 0x31, 0xff, 0xb6, 0x08, 0x55, 0x1d, 0x85, 0xfe, 0x10, 0x9a, 0xb1, 0x5d,
-	0x3e, 0x1e, 0x6b, 0x4c, 0xa8, 0x95, 0x4c, 0x10, 0x3e, 0x9d, 0xf3, 0x02, 0xd7, 0x1f, 0xea, 0x9e,
-	0xda, 0x50, 0x66, 0xb8, 0xec, 0x19, 0xa1, 0xb3, 0x53, 0x5a, 0x25, 0x0c, 0x42, 0xdb, 0xb3, 0xde,
-	0x44, 0x41, 0x68, 0x2b, 0xd6, 0xc8, 0xa1, 0x55, 0x10, 0xa8, 0xe7, 0x1c, 0x64, 0xfe, 0x7b, 0x09,
-	0x6e, 0x3f, 0xbd, 0x92, 0x5d, 0x6e, 0x07, 0x7e, 0x48, 0x03, 0x4f, 0x66, 0x26, 0xb6, 0x9c, 0xd2,
-	0x0e, 0x54, 0x89, 0xf6, 0xac, 0x78, 0x50, 0xef, 0xe7, 0x7c, 0x6d, 0x9c, 0x50, 0x45, 0x7d, 0xb8,
-	0x1d, 0xf9, 0x44, 0xa5, 0x3d, 0xf1, 0xc0, 0x67, 0x55, 0x3f, 0xb9, 0x83, 0x7f, 0xa2, 0x7b, 0x60,
-	0x8a, 0x8e, 0x13, 0xb0, 0xb8, 0xf4, 0x77, 0x2b, 0x9a, 0xd7, 0x84, 0x0e, 0xa1, 0x3e, 0xbc, 0xe2,
-	0xab, 0xd2, 0x16, 0x0c, 0xe4, 0xd8, 0x6a, 0x7b, 0xd9, 0x9e, 0xdf, 0x65, 0x49, 0x6b, 0xb8, 0x3a,
-	0xbc, 0xda, 0x97, 0x6a, 0x6d, 0xc7, 0x46, 0x6d, 0x58, 0x97, 0xce, 0x9c, 0xf6, 0x58, 0xcc, 0xb1,
-	0x3a, 0x29, 0xe6, 0x78, 0x15, 0x09, 0x78, 0x9c, 0xd6, 0x89, 0xd9, 0x33, 0xff, 0xb4, 0x08, 0x1b,
-	0xa9, 0x97, 0x61, 0xc1, 0x83, 0xe8, 0x77, 0x79, 0x6e, 0xc8, 0xff, 0xc5, 0x2e, 0xfd, 0x49, 0x26,
-	0x37, 0x54, 0xf5, 0x29, 0x0e, 0xc2, 0x31, 0x18, 0x3d, 0x82, 0x65, 0x42, 0x69, 0x40, 0xf3, 0x42,
-	0xf2, 0x80, 0x37, 0xec, 0xf1, 0x73, 0x0f, 0xb9, 0xc2, 0x0a, 0x66, 0x7e, 0x01, 0x55, 0x5d, 0x8e,
-	0xd6, 0x61, 0xc9, 0xe5, 0x7f, 0x44, 0xc4, 0x2d, 0x61, 0xf9, 0xc0, 0xa5, 0x02, 0x2f, 0x66, 0xa1,
-	0x8c, 0xe5, 0x83, 0xe9, 0xc2, 0x6a, 0xa6, 0x2b, 0x9c, 0x11, 0xb4, 0x69, 0xb5, 0x74, 0x63, 0x86,
-	0xd6, 0x20, 0xdf, 0xf6, 0x5b, 0xd0, 0xd0, 0xc1, 0x23, 0x22, 0xeb, 0x00, 0x2b, 0xb8, 0xae, 0x89,
-	0x4f, 0x48, 0x68, 0xfe, 0x5d, 0x11, 0xb6, 0xe6, 0x47, 0x12, 0x3a, 0x06, 0x23, 0x1d, 0x39, 0x6a,
-	0xc9, 0x7c, 0x3c, 0x7f, 0x5e, 0xe3, 0x3c, 0xb7, 0x91, 0x8a, 0x15, 0xf4, 0x18, 0x96, 0x6d, 0x31,
-	0xe7, 0x6a, 0x8d, 0x5c, 0x1f, 0x1b, 0x0a, 0x6f, 0xfe, 0x77, 0x09, 0x36, 0xf3, 0xdf, 0x92, 0xcb,
-	0x27, 0x4f, 0x20, 0x4e, 0xaf, 0x65, 0xee, 0x22, 0x63, 0x5c, 0x3f, 0x75, 0xb6, 0xdb, 0x4a, 0x5f,
-	0x64, 0x2d, 0x15, 0x3a, 0x7b, 0x40, 0x7b, 0x3c, 0x3f, 0x95, 0xca, 0x7e, 0x34, 0xba, 0x20, 0x54,
-	0x45, 0xf2, 0xed, 0x1d, 0x59, 0x5c, 0xdf, 0x89, 0x8b, 0xeb, 0x3b, 0x1d, 0x3f, 0xfc, 0x7c, 0xf7,
-	0x05, 0x4f, 0x03, 0x70, 0x9c, 0xfc, 0x9f, 0x0a, 0x0d, 0x64, 0x41, 0x33, 0x41, 0x68, 0xb2, 0x8c,
-	0x30, 0x0e, 0x68, 0xc8, 0x9a, 0x95, 0xcc, 0x52, 0x7e, 0x07, 0xe5, 0x6c, 0x46, 0xc9, 0x36, 0x2c,
-	0x8d, 0xa0, 0xdf, 0x06, 0x24, 0x5f, 0x20, 0xad, 0x5a, 0x7d, 0xe2, 0x85, 0xb6, 0x38, 0xd9, 0x2e,
-	0x62, 0x43, 0xb4, 0x48, 0xe4, 0x3e, 0x97, 0xa3, 0xdf, 0x87, 0x1a, 0xb9, 0x24, 0x7e, 0x68, 0x85,
-	0xd4, 0x1d, 0x0e, 0x09, 0x55, 0xe7, 0xd8, 0x77, 0x8e, 0xa8, 0x2a, 0x34, 0x7a, 0x52, 0xc1, 0xfc,
-	0xb3, 0x85, 0xcc, 0x8a, 0x52, 0x59, 0x49, 0x2a, 0xed, 0x28, 0xa6, 0xd3, 0x0e, 0xf4, 0x1d, 0x6c,
-	0x66, 0x7c, 0xc1, 0x79, 0xf8, 0x7b, 0x7a, 0x62, 0x3d, 0xca, 0xb6, 0x31, 0x71, 0xec, 0xe2, 0xdb,
-	0xb5, 0xeb, 0xb3, 0xd0, 0xf6, 0x3c, 0xa6, 0x0e, 0xf7, 0x37, 0x53, 0xb9, 0x40, 0x47, 0x35, 0xe3,
-	0x2a, 0xd5, 0x9e, 0xa6, 0xda, 0x94, 0x8c, 0x82, 0x4b, 0xdb, 0x63, 0xca, 0x2f, 0x69, 0x6d, 0xac,
-	0x9a, 0xa5, 0x76, 0xfc, 0xc4, 0xb7, 0xf2, 0x84, 0x57, 0xf9, 0xf9, 0x7e, 0xe1, 0x41, 0x0d, 0xd7,
-	0x74, 0xcf, 0x31, 0xf4, 0x14, 0x56, 0x29, 0x11, 0x87, 0x08, 0xb9, 0x18, 0xc5, 0xd9, 0xa2, 0xa1,
-	0x0e, 0xa6, 0xe9, 0x09, 0xe8, 0xc5, 0x17, 0x3a, 0xd8, 0xd0, 0x95, 0xb8, 0xd8, 0xfc, 0x6b, 0xbe,
-	0x67, 0x4c, 0x3e, 0x68, 0xcf, 0x98, 0xfc, 0xbf, 0xdb, 0x33, 0x26, 0xd7, 0xed, 0x19, 0x93, 0xfc,
-	0x3d, 0x63, 0x32, 0xdb, 0x33, 0x24, 0xdd, 0x4f, 0x7e, 0x54, 0xba, 0x17, 0x3c, 0x3a, 0xf9, 0x50,
-	0x1e, 0x9d, 0xfc, 0x00, 0x3c, 0x3a, 0x79, 0x17, 0x8f, 0xfe, 0x8a, 0xf3, 0xe8, 0xe4, 0x37, 0x87,
-	0x47, 0x7f, 0x06, 0x2b, 0x71, 0x1a, 0xda, 0xdc, 0x48, 0xe4, 0x9f, 0xfc, 0xe5, 0xcf, 0x65, 0xda,
-	0x89, 0x6f, 0xa8, 0xfc, 0x13, 0x7d, 0x09, 0x8b, 0x23, 0xe6, 0x38, 0xcd, 0x4d, 0x31, 0x69, 0x0f,
-	0xf4, 0x4b, 0xa9, 0xc8, 0x0b, 0xdd, 0xb1, 0x47, 0x54, 0xc1, 0x95, 0x25, 0x5d, 0x20, 0xb4, 0xbe,
-	0x1f, 0xa7, 0x9a, 0xff, 0x95, 0x0d, 0x3a, 0xc5, 0x88, 0x69, 0xaf, 0x15, 0xbf, 0x8f, 0xd7, 0x3e,
-	0xcd, 0x78, 0x4d, 0x1e, 0x32, 0x52, 0x8e, 0x49, 0xb1, 0xee, 0x42, 0x86, 0x75, 0x1f, 0x43, 0x55,
-	0xa4, 0xa3, 0xd6, 0x90, 0xda, 0x7e, 0x18, 0x9f, 0x91, 0xf4, 0xb3, 0xac, 0x48, 0x44, 0x9f, 0xf2,
-	0x56, 0x5c, 0x79, 0x33, 0xfd, 0xcf, 0xf8, 0x21, 0x47, 0xd4, 0x5b, 0x07, 0xb6, 0xeb, 0x45, 0x94,
-	0x88, 0x8a, 0xc5, 0x0a, 0xae, 0x70, 0xd9, 0xa1, 0x14, 0x99, 0xff, 0x58, 0x02, 0x98, 0xa9, 0x67,
-	0x8e, 0x45, 0xc5, 0xec, 0xb1, 0xa8, 0x0d, 0xeb, 0xa2, 0x23, 0xa4, 0x6f, 0xc5, 0x55, 0xf1, 0xc8,
-	0x77, 0xc3, 0xf9, 0xc9, 0x32, 0x52, 0x70, 0x35, 0x61, 0xe7, 0xbe, 0x1b, 0xbe, 0x5f, 0x85, 0x26,
-	0xe5, 0x99, 0xc5, 0x8c, 0x67, 0xee, 0x43, 0xc3, 0x65, 0x96, 0xac, 0xce, 0xc8, 0x73, 0x86, 0xa8,
-	0x28, 0xaf, 0xe0, 0x9a, 0xcb, 0x44, 0x2d, 0x46, 0xce, 0xe9, 0xfc, 0x12, 0x4e, 0xf5, 0xd7, 0x2f,
-	0xe1, 0xfc, 0x43, 0x11, 0xb6, 0xe6, 0xef, 0x71, 0x39, 0x87, 0x43, 0xee, 0xca, 0x6a, 0xfa, 0x70,
-	0x78, 0x00, 0x86, 0x06, 0xf3, 0xc8, 0x25, 0xf1, 0x72, 0x0a, 0x71, 0xb3, 0x57, 0x1c, 0x73, 0x04,
-	0x6e, 0x8c, 0x92, 0x02, 0xf4, 0x19, 0x2c, 0xab, 0x4a, 0xda, 0xfc, 0x4a, 0x90, 0x04, 0xf0, 0x33,
-	0xd5, 0x9d, 0x77, 0x2e, 0xa1, 0xf7, 0x89, 0x81, 0xaf, 0x60, 0x35, 0x62, 0xef, 0x1d, 0x00, 0x0d,
-	0x8e, 0xd5, 0x67, 0xff, 0x2e, 0x54, 0x54, 0x01, 0x5b, 0xac, 0xaa, 0x05, 0xf9, 0x9d, 0x81, 0x14,
-	0x89, 0x8a, 0x13, 0x83, 0x1b, 0x8a, 0x11, 0xd0, 0xe7, 0x70, 0xd3, 0x1e, 0xfb, 0x96, 0x3d, 0x1c,
-	0x5a, 0x23, 0xfb, 0xca, 0xba, 0x70, 0x43, 0x8b, 0x72, 0xcd, 0xc8, 0x53, 0x1d, 0x43, 0xf6, 0xd8,
-	0x6f, 0x0d, 0x87, 0x27, 0xf6, 0xd5, 0x9e, 0x1b, 0x62, 0x3b, 0x24, 0xe7, 0xde, 0x5c, 0xa5, 0xbe,
-	0xa7, 0xd6, 0x60, 0x46, 0x69, 0xdf, 0x33, 0xff, 0xbe, 0x04, 0x55, 0x3d, 0xc3, 0x40, 0x77, 0x00,
-	0xb4, 0x12, 0x48, 0x51, 0x1c, 0xd9, 0xcb, 0x74, 0x5a, 0xfc, 0xb8, 0x0f, 0x8d, 0x74, 0xd5, 0xa3,
-	0xa4, 0xca, 0x9f, 0x89, 0x7a, 0xc7, 0x3e, 0x18, 0x99, 0x3a, 0xc7, 0xc2, 0x75, 0x75, 0x0e, 0x61,
-	0x5a, 0x2b, 0x70, 0xa0, 0x36, 0x34, 0xc4, 0xb5, 0x8e, 0x96, 0x79, 0x2c, 0x5e, 0x9b, 0x79, 0xd4,
-	0x67, 0x2a, 0x62, 0x45, 0x3d, 0x85, 0xd5, 0x3e, 0x49, 0x9b, 0x59, 0xba, 0x3e, 0x81, 0xd1, 0x95,
-	0x44, 0x02, 0x73, 0x2e, 0x5d, 0x35, 0x4d, 0xa0, 0x7e, 0x18, 0x57, 0x99, 0x0c, 0x96, 0x55, 0xa1,
-	0xf7, 0x63, 0xa8, 0xca, 0xc3, 0xa3, 0x56, 0x21, 0x5e, 0xc4, 0xf2, 0x74, 0x3e, 0x83, 0xb8, 0xfe,
-	0x38, 0x0a, 0x63, 0x48, 0x49, 0x42, 0x84, 0x4c, 0x41, 0xee, 0x41, 0x2d, 0x88, 0x42, 0x0d, 0xb3,
-	0x20, 0x30, 0x55, 0x29, 0x94, 0x20, 0xf3, 0x2f, 0x4a, 0x80, 0x9e, 0x89, 0xcf, 0x7b, 0xae, 0x2d,
-	0xf9, 0x7d, 0x2d, 0xc7, 0xc1, 0xac, 0x30, 0x90, 0x99, 0x66, 0x5c, 0x59, 0x9c, 0x9b, 0x67, 0x8a,
-	0x01, 0xb2, 0x5e, 0x20, 0x04, 0x04, 0xb5, 0x64, 0x2c, 0x08, 0x03, 0x2a, 0xd1, 0xcd, 0xf9, 0x86,
-	0x26, 0x91, 0xe7, 0xd6, 0x95, 0x05, 0x25, 0x78, 0x47, 0x12, 0xbe, 0xf8, 0xc1, 0x49, 0xb8, 0xf9,
-	0x1f, 0xc5, 0xa4, 0x2f, 0x7e, 0x98, 0x7a, 0x25, 0x7a, 0x0e, 0x55, 0xbe, 0x07, 0xf1, 0x94, 0x69,
-	0x7a, 0x4d, 0x50, 0xd9, 0xdd, 0xd1, 0x2f, 0x60, 0x33, 0x2f, 0xdd, 0x39, 0x14, 0x1a, 0xa2, 0x50,
-	0x29, 0x8b, 0xc1, 0x95, 0xc1, 0x4c, 0xb2, 0xf5, 0x0b, 0x30, 0xd2, 0x00, 0xbd, 0x00, 0x5c, 0x96,
-	0x05, 0xe0, 0x75, 0xbd, 0x00, 0x5c, 0xd3, 0x2b, 0xbd, 0x9f, 0xc1, 0x9a, 0xf8, 0xbe, 0x23, 0x75,
-	0x05, 0x96, 0x33, 0xeb, 0xe6, 0x04, 0x90, 0x0e, 0xfd, 0x81, 0x7c, 0x72, 0x0f, 0x6a, 0x22, 0xe3,
-	0x8c, 0x2f, 0xbf, 0x44, 0x1c, 0x94, 0x71, 0x55, 0x08, 0x4f, 0xa4, 0xec, 0xe1, 0x97, 0xd0, 0x48,
-	0xdd, 0xc2, 0xa0, 0x1a, 0x94, 0xa7, 0xd7, 0x6a, 0x46, 0x01, 0x55, 0x61, 0x25, 0x2e, 0x05, 0xca,
-	0xef, 0x14, 0xb0, 0xba, 0x7b, 0x31, 0x4a, 0x0f, 0x77, 0xa1, 0x91, 0xda, 0x3a, 0x90, 0x01, 0x55,
-	0x35, 0x0c, 0xf1, 0x6c, 0x14, 0xb8, 0x3d, 0xee, 0x42, 0xf9, 0x58, 0x7c, 0xf8, 0x14, 0x6a, 0x89,
-	0x9c, 0x86, 0x9b, 0xb4, 0x3a, 0xa7, 0x2f, 0x5a, 0xc7, 0x9d, 0x7d, 0xa3, 0x80, 0x2a, 0x70, 0xa3,
-	0x73, 0xda, 0xe9, 0x75, 0x5a, 0xc7, 0x46, 0x11, 0x01, 0x2c, 0x9f, 0x3f, 0xdb, 0x6f, 0xf5, 0x0e,
-	0x8c, 0x12, 0x6a, 0x40, 0xa5, 0x77, 0x80, 0x4f, 0x3a, 0xa7, 0xad, 0x5e, 0xe7, 0xec, 0xd4, 0x58,
-	0x78, 0xf8, 0x02, 0x6e, 0xcd, 0x3d, 0x4a, 0xa0, 0x9b, 0xb0, 0xd6, 0x3e, 0x3b, 0xed, 0x75, 0x4e,
-	0xcf, 0x0f, 0xac, 0x97, 0x9d, 0xde, 0x91, 0x75, 0x80, 0xf1, 0x19, 0x36, 0x0a, 0x68, 0x1b, 0x7e,
-	0x92, 0x6c, 0xd8, 0x3f, 0x38, 0x6c, 0x9d, 0x1f, 0xf7, 0xac, 0xd6, 0x69, 0xf7, 0xe5, 0x01, 0x36,
-	0x8a, 0x0f, 0x07, 0x50, 0x17, 0xb3, 0xd1, 0xb6, 0x23, 0x26, 0x76, 0x0b, 0x84, 0xa0, 0xbe, 0x47,
-	0x6c, 0x4a, 0xa8, 0xfa, 0x34, 0xa8, 0x6f, 0x14, 0xd0, 0x5d, 0xb8, 0xdd, 0xf1, 0x59, 0x34, 0x18,
-	0xb8, 0x8e, 0x4b, 0xfc, 0xf8, 0x22, 0x8c, 0xb0, 0x20, 0xa2, 0x0e, 0x61, 0x46, 0x31, 0x0d, 0x88,
-	0x0d, 0xc4, 0x80, 0xd2, 0xee, 0x5f, 0x16, 0x61, 0xfd, 0x24, 0x70, 0x5e, 0xb7, 0x03, 0x4a, 0x66,
-	0x75, 0xcf, 0x80, 0xa2, 0x36, 0x54, 0xe5, 0xb3, 0xba, 0x4d, 0x4b, 0x7f, 0x50, 0x94, 0xba, 0x4f,
-	0xdc, 0x8a, 0x77, 0x44, 0xf1, 0xfd, 0xe1, 0xce, 0x8b, 0xc0, 0xed, 0x9b, 0x05, 0xf4, 0x08, 0x96,
-	0xc4, 0x7d, 0x33, 0xca, 0xb6, 0xe6, 0x2a, 0xec, 0xfe, 0xe7, 0x22, 0xdc, 0xe0, 0xdd, 0x39, 0x6b,
-	0x77, 0xd1, 0x13, 0xa8, 0x77, 0x49, 0x78, 0xd6, 0xee, 0x76, 0x49, 0xc8, 0x37, 0x65, 0x86, 0xd6,
-	0xf5, 0x5d, 0x37, 0xbe, 0x6a, 0xcb, 0x7f, 0xf3, 0xef, 0x41, 0xb9, 0x4b, 0x42, 0x95, 0x19, 0xe5,
-	0xdf, 0x88, 0xe4, 0x2b, 0x7e, 0x05, 0x35, 0x79, 0x4d, 0xad, 0xae, 0x10, 0xd0, 0x4d, 0xfd, 0x2e,
-	0x78, 0xfa, 0x89, 0x63, 0x67, 0x3f, 0x5f, 0xfd, 0x0b, 0x30, 0xda, 0x1e, 0xb1, 0xe9, 0x0c, 0xc9,
-	0xde, 0x77, 0xf0, 0xe8, 0x18, 0x96, 0x25, 0x35, 0x20, 0xfd, 0x03, 0x86, 0xbc, 0x9b, 0x9a, 0xad,
-	0xf9, 0x00, 0xb9, 0x6e, 0xcd, 0x02, 0xfa, 0x1a, 0xe0, 0x69, 0xec, 0x01, 0x36, 0x7f, 0x14, 0x9b,
-	0xf9, 0x57, 0x55, 0x66, 0x01, 0x9d, 0x41, 0x55, 0x27, 0x84, 0x44, 0x04, 0xe4, 0x90, 0xca, 0xd6,
-	0x9d, 0x39, 0xed, 0xd3, 0x1e, 0x9d, 0x42, 0xa3, 0x4b, 0xc2, 0x44, 0x09, 0xe0, 0xfe, 0x7b, 0x1d,
-	0xf6, 0x59, 0xbe, 0xbf, 0xbe, 0x01, 0xd4, 0x62, 0x8c, 0xd0, 0xa4, 0xc9, 0x1c, 0x6f, 0x6f, 0xbf,
-	0xeb, 0xcc, 0xca, 0xa9, 0xca, 0x2c, 0xec, 0xfe, 0xf9, 0x12, 0xac, 0xf0, 0xc8, 0x7b, 0xd6, 0xc6,
-	0x87, 0xe8, 0x2b, 0x11, 0x7a, 0xfa, 0xe5, 0x93, 0xee, 0x26, 0x4d, 0xfe, 0xbf, 0x12, 0x43, 0x8f,
-	0x61, 0xa5, 0x4b, 0xd4, 0xdd, 0x55, 0xe2, 0xa3, 0x53, 0xed, 0x52, 0x2b, 0x5f, 0xf3, 0x04, 0x8c,
-	0x2e, 0x09, 0xf5, 0xfd, 0x90, 0xa1, 0x4f, 0xe6, 0xec, 0x94, 0xef, 0xb1, 0x7c, 0x3f, 0x24, 0x98,
-	0x8f, 0xa6, 0xc1, 0x7c, 0x67, 0xce, 0x06, 0xa8, 0x42, 0xf9, 0xce, 0x3b, 0xf7, 0xc7, 0x1f, 0x23,
-	0x0e, 0xaf, 0xfe, 0x4f, 0xe2, 0xf0, 0x2a, 0x37, 0x0e, 0xf7, 0x6e, 0x7f, 0x7b, 0x4b, 0x80, 0x1e,
-	0x0d, 0xc8, 0xf0, 0x91, 0xe3, 0x05, 0x51, 0xff, 0xd1, 0x30, 0x50, 0x9f, 0x58, 0x5f, 0x2c, 0x8b,
-	0xdf, 0xcf, 0xff, 0x27, 0x00, 0x00, 0xff, 0xff, 0x2b, 0x64, 0xc0, 0x57, 0x0d, 0x2e, 0x00, 0x00,
+	// 4463 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x7b, 0x4b, 0x93, 0x1c, 0xc7,
+	0x56, 0xff, 0x54, 0x4f, 0xcf, 0xa3, 0x4f, 0xbf, 0x6a, 0x72, 0x1e, 0x6a, 0x8d, 0x2c, 0x6b, 0x5c,
+	0xf2, 0x43, 0x96, 0xff, 0x1e, 0xfd, 0xef, 0xd8, 0xdc, 0xab, 0xb0, 0xec, 0x6b, 0x7a, 0x7a, 0x5e,
+	0x8d, 0x67, 0x46, 0x72, 0x76, 0x8f, 0x1c, 0xd7, 0x8e, 0xa0, 0xa8, 0xa9, 0xce, 0x69, 0x17, 0xaa,
+	0xae, 0x6a, 0x57, 0x56, 0x49, 0xd3, 0x0e, 0x88, 0x00, 0x22, 0x58, 0x10, 0x10, 0xb0, 0x23, 0x58,
+	0x13, 0x2c, 0x60, 0x43, 0xb0, 0xe1, 0xf1, 0x09, 0xe0, 0x2e, 0x58, 0xb2, 0x20, 0x82, 0x0f, 0x40,
+	0x04, 0x2b, 0xb6, 0x6c, 0x08, 0x22, 0x1f, 0x55, 0x9d, 0xf5, 0x68, 0xcd, 0xd8, 0x32, 0x5c, 0xd8,
+	0x48, 0x9d, 0x27, 0xcf, 0x39, 0x95, 0x79, 0xce, 0xc9, 0x5f, 0x9e, 0x3c, 0x99, 0x03, 0x9b, 0x17,
+	0x64, 0xf8, 0x60, 0x1c, 0xf8, 0xa1, 0x4f, 0x1f, 0x8c, 0x7c, 0xfb, 0x99, 0x69, 0xfb, 0x01, 0xd9,
+	0xe6, 0x04, 0x54, 0x19, 0x59, 0xc3, 0x91, 0xb5, 0x7d, 0x41, 0x86, 0x9b, 0x37, 0xfd, 0xc0, 0x7e,
+	0x18, 0xc4, 0x8c, 0xb6, 0x3f, 0x1a, 0xf9, 0x9e, 0xe0, 0xda, 0x5c, 0x57, 0x34, 0xd8, 0xf4, 0xe2,
+	0x5c, 0x92, 0x6f, 0xba, 0x21, 0x89, 0xc9, 0x63, 0xdf, 0x75, 0xec, 0xc9, 0x20, 0xee, 0xda, 0x52,
+	0xba, 0x28, 0xa1, 0xd4, 0xf1, 0x3d, 0x73, 0x64, 0x79, 0xd6, 0x90, 0x04, 0x92, 0xe3, 0xb6, 0xca,
+	0x11, 0x9d, 0x53, 0x3b, 0x70, 0xce, 0x49, 0x90, 0x28, 0xb8, 0x33, 0xf4, 0xfd, 0xa1, 0x2b, 0x39,
+	0xce, 0xa3, 0x8b, 0x07, 0xa1, 0x33, 0x22, 0x34, 0xb4, 0x46, 0x63, 0xc9, 0xf0, 0x7a, 0x96, 0xe1,
+	0x45, 0x60, 0x8d, 0xc7, 0x24, 0xa0, 0xa2, 0xdf, 0xf8, 0x93, 0x2a, 0x2c, 0x60, 0x32, 0x76, 0x27,
+	0xe8, 0x08, 0x9a, 0x94, 0x04, 0xcf, 0x49, 0x60, 0x9e, 0x93, 0xaf, 0xad, 0xe7, 0x8e, 0x1f, 0xb4,
+	0xb4, 0x2d, 0xed, 0x5e, 0x63, 0xe7, 0xce, 0x76, 0x32, 0xfb, 0x6d, 0xce, 0xba, 0xdd, 0xe3, 0x7c,
+	0xbb, 0x92, 0x0d, 0x37, 0x68, 0xaa, 0x8d, 0xee, 0x40, 0x35, 0x60, 0x7c, 0xe6, 0x80, 0xb8, 0xd6,
+	0xa4, 0x55, 0xda, 0xd2, 0xee, 0x2d, 0x60, 0xe0, 0xa4, 0x3d, 0x46, 0x41, 0x3f, 0x85, 0xba, 0xe5,
+	0x92, 0x20, 0x34, 0x03, 0xf2, 0x4d, 0x44, 0x68, 0xd8, 0x9a, 0xdf, 0xd2, 0xee, 0x55, 0x77, 0x6e,
+	0x28, 0x1f, 0x6a, 0xb3, 0x7e, 0x2c, 0xba, 0x8f, 0xe6, 0x70, 0xcd, 0x52, 0xda, 0xe8, 0x57, 0x60,
+	0x65, 0xe0, 0xbf, 0xf0, 0x5c, 0xc7, 0x7b, 0x66, 0x46, 0x9e, 0x13, 0x0e, 0xac, 0xd0, 0x6a, 0x95,
+	0xb9, 0x8e, 0x5b, 0x8a, 0x8e, 0x3d, 0xc9, 0x73, 0x26, 0x59, 0x8e, 0xe6, 0xb0, 0x3e, 0xc8, 0xd0,
+	0xd0, 0xa7, 0xd0, 0x20, 0x63, 0x6a, 0x0e, 0x48, 0x68, 0xd9, 0x5f, 0x9b, 0x96, 0xfd, 0xac, 0xb5,
+	0x90, 0x1b, 0xcc, 0xfe, 0x93, 0xde, 0x1e, 0xef, 0x6f, 0xdb, 0xcf, 0xd8, 0x60, 0xc8, 0x98, 0x26,
+	0x6d, 0xb4, 0x0b, 0x4d, 0x67, 0x44, 0x1d, 0x55, 0xc3, 0x22, 0xd7, 0xd0, 0x52, 0x34, 0x74, 0x4f,
+	0x7a, 0x5d, 0x55, 0x45, 0x9d, 0x89, 0x4c, 0x75, 0x7c, 0x01, 0x1b, 0xae, 0x6f, 0x5b, 0x21, 0xf3,
+	0x7f, 0x34, 0x1e, 0x58, 0x21, 0x31, 0x2d, 0xdb, 0x26, 0xe3, 0xb0, 0xb5, 0xc4, 0x55, 0xa9, 0x2e,
+	0x38, 0x96, 0x8c, 0x67, 0x9c, 0xaf, 0xcd, 0xd9, 0x8e, 0xe6, 0xf0, 0x9a, 0x5b, 0x40, 0x2f, 0x52,
+	0x1c, 0x90, 0x5f, 0x27, 0x76, 0xd8, 0x5a, 0xbe, 0x42, 0x31, 0xe6, 0x6c, 0x79, 0xc5, 0x82, 0xce,
+	0x14, 0x8f, 0x46, 0xa6, 0xe3, 0x5d, 0xf8, 0xc1, 0x48, 0xa8, 0x8f, 0x7d, 0x59, 0xc9, 0x29, 0x3e,
+	0x39, 0xe9, 0x4e, 0xf9, 0xa6, 0x3e, 0x5d, 0x1b, 0x8d, 0xf2, 0x74, 0xd4, 0x86, 0xc6, 0xd8, 0x1a,
+	0x3a, 0xde, 0x30, 0x51, 0x08, 0x39, 0x6b, 0x3e, 0xe1, 0x0c, 0x53, 0x4d, 0xf5, 0xb1, 0x4a, 0x40,
+	0x7b, 0xd0, 0x0c, 0x88, 0x4b, 0x2c, 0x4a, 0x12, 0x1d, 0x55, 0xae, 0xe3, 0x66, 0x2a, 0x92, 0x39,
+	0xc7, 0x54, 0x49, 0x23, 0x48, 0x51, 0x50, 0x1f, 0xd6, 0x59, 0x5c, 0x3b, 0x36, 0x31, 0xad, 0x73,
+	0x5f, 0x09, 0xd6, 0x1a, 0xd7, 0xf5, 0xba, 0xa2, 0xab, 0x27, 0xf8, 0xda, 0x8c, 0x6d, 0xaa, 0x70,
+	0x95, 0xe6, 0xc9, 0x68, 0x07, 0x2a, 0x01, 0xa1, 0x24, 0xe4, 0x71, 0x52, 0xe7, 0x9a, 0x56, 0x53,
+	0xa3, 0xa2, 0x24, 0x14, 0x21, 0xb2, 0x1c, 0xc8, 0xdf, 0xe8, 0x10, 0x74, 0x21, 0xe3, 0x78, 0x03,
+	0x47, 0xf8, 0xa2, 0xd5, 0xe0, 0xa2, 0x9b, 0x59, 0xd1, 0x6e, 0xc2, 0x71, 0x34, 0x87, 0x9b, 0x41,
+	0x9a, 0x84, 0xde, 0x83, 0x45, 0x1a, 0x5a, 0x61, 0x44, 0x5b, 0x4d, 0x2e, 0xbe, 0xa2, 0xce, 0x81,
+	0x77, 0x1c, 0xcd, 0x61, 0xc9, 0x82, 0xbe, 0x84, 0x1b, 0x76, 0x40, 0x58, 0xc4, 0xc4, 0xc8, 0x14,
+	0x10, 0x3a, 0xf6, 0x3d, 0x4a, 0x5a, 0x3a, 0x97, 0xde, 0x92, 0xd2, 0x6e, 0x48, 0xb6, 0x3b, 0x9c,
+	0xb3, 0x27, 0x18, 0xb1, 0xe4, 0x3b, 0xd2, 0xf0, 0xba, 0x5d, 0xd4, 0xc1, 0x74, 0xcb, 0x68, 0xcc,
+	0xe9, 0x5e, 0xc9, 0xe9, 0x16, 0x71, 0x57, 0xa0, 0x3b, 0x2a, 0xea, 0x40, 0x36, 0x6c, 0xc6, 0x4a,
+	0x43, 0x12, 0x8c, 0x1c, 0x4f, 0x04, 0xbd, 0x54, 0x8f, 0xb8, 0xfa, 0xbb, 0x8a, 0x7a, 0x29, 0xdf,
+	0x8f, 0x79, 0x95, 0x2f, 0xb4, 0xe8, 0x8c, 0x3e, 0xa3, 0x03, 0x8d, 0x34, 0x08, 0xa2, 0x55, 0x68,
+	0xe2, 0xfd, 0x27, 0xc7, 0x3f, 0x33, 0xbb, 0xa7, 0xbd, 0x7e, 0xfb, 0xb4, 0x7f, 0xfc, 0x33, 0x7d,
+	0x0e, 0x35, 0x00, 0x04, 0xf1, 0xb8, 0xdd, 0xdf, 0xd7, 0x35, 0x54, 0x83, 0xe5, 0xd3, 0xc7, 0x26,
+	0x27, 0xe9, 0xa5, 0xdd, 0x3a, 0x54, 0xe9, 0x90, 0x9a, 0x23, 0x42, 0xa9, 0x35, 0x24, 0xbb, 0x0d,
+	0xa8, 0x0d, 0x2f, 0x87, 0x93, 0xb8, 0x6d, 0xfc, 0x2d, 0x40, 0x73, 0xff, 0x72, 0x4c, 0xec, 0x90,
+	0x0c, 0x94, 0xf0, 0x11, 0xc8, 0xc9, 0xc2, 0x47, 0xcb, 0x85, 0x0f, 0x47, 0x4d, 0x19, 0x3e, 0x96,
+	0xfc, 0x8d, 0x1e, 0x41, 0x2d, 0x46, 0x5b, 0xbe, 0xf2, 0x4b, 0x5c, 0x6c, 0x23, 0x0f, 0xb6, 0x72,
+	0xc1, 0x57, 0xad, 0x69, 0x93, 0xad, 0x02, 0x05, 0x1e, 0x95, 0x00, 0x9c, 0xcf, 0xad, 0x82, 0x04,
+	0x25, 0x53, 0x41, 0xb8, 0x9a, 0x80, 0xe5, 0x94, 0xcc, 0xd0, 0x43, 0xc5, 0x4c, 0x45, 0x6d, 0x39,
+	0x87, 0x1e, 0x53, 0xe8, 0x4c, 0xe9, 0x5d, 0x9b, 0x22, 0xa8, 0xa2, 0xf8, 0x4b, 0xb8, 0x91, 0xc7,
+	0x3b, 0xb1, 0x6c, 0x17, 0x52, 0x81, 0x55, 0x04, 0x78, 0xf1, 0xc2, 0x5d, 0x77, 0x8b, 0x3a, 0xd8,
+	0xae, 0x95, 0x20, 0x13, 0x37, 0xe4, 0x62, 0x6e, 0xa3, 0x88, 0x81, 0x49, 0x5a, 0xb2, 0x36, 0x56,
+	0xda, 0x0c, 0x96, 0x62, 0x40, 0x89, 0xc7, 0xb4, 0x94, 0x83, 0x25, 0x09, 0x25, 0x0a, 0x2c, 0xd1,
+	0x14, 0x85, 0x85, 0x77, 0xc8, 0x4c, 0x17, 0x10, 0xcb, 0x4d, 0xa6, 0x6a, 0xfb, 0xa3, 0xb1, 0x4b,
+	0x42, 0xd2, 0x5a, 0x4e, 0x85, 0x37, 0x53, 0xd8, 0x3f, 0xe9, 0x75, 0xb1, 0xc2, 0xdb, 0x91, 0xac,
+	0x47, 0x73, 0xb8, 0xc5, 0x14, 0x15, 0xf5, 0x31, 0xff, 0x44, 0x6c, 0x0b, 0x0a, 0x9d, 0xe7, 0x4e,
+	0x38, 0x51, 0xfd, 0x93, 0x47, 0xf7, 0xb3, 0xfd, 0xb6, 0xe4, 0x4b, 0xfb, 0x27, 0x22, 0x79, 0x3a,
+	0x43, 0xf7, 0x88, 0x98, 0x91, 0x17, 0x10, 0xcb, 0xfe, 0xda, 0x3a, 0x77, 0x49, 0x01, 0xba, 0x9f,
+	0xed, 0x9f, 0x4d, 0xfb, 0x19, 0xba, 0x47, 0x44, 0x21, 0x30, 0x33, 0x46, 0xe3, 0xf4, 0xd6, 0x9f,
+	0x47, 0xf7, 0xb3, 0x71, 0x66, 0xe3, 0x6f, 0x44, 0x29, 0x4a, 0x1a, 0x87, 0x6b, 0xdf, 0x1f, 0x87,
+	0xeb, 0xaf, 0x86, 0xc3, 0x8d, 0xab, 0x71, 0xf8, 0x0b, 0xd8, 0xc8, 0xe1, 0xb0, 0x88, 0x9e, 0x66,
+	0xca, 0x17, 0x05, 0x30, 0x2c, 0x62, 0x48, 0xc3, 0x6b, 0x76, 0x01, 0x9d, 0x3b, 0x39, 0x0b, 0xc2,
+	0x42, 0xb1, 0x9e, 0x53, 0x9c, 0xc1, 0xe0, 0x44, 0x71, 0x54, 0x40, 0x47, 0xbf, 0x06, 0x37, 0x8b,
+	0x10, 0x58, 0xe8, 0x16, 0xf8, 0x6e, 0xbc, 0x14, 0x80, 0x63, 0xf5, 0x37, 0x68, 0x71, 0xd7, 0x55,
+	0xc8, 0xe9, 0x42, 0x4d, 0x72, 0x8a, 0xd4, 0xf6, 0x43, 0x58, 0x8a, 0x3f, 0xaf, 0xe5, 0xfc, 0x95,
+	0x81, 0x58, 0x1c, 0xb3, 0xa2, 0xb7, 0x61, 0x81, 0xe7, 0xac, 0x12, 0x30, 0xf5, 0x6c, 0x1a, 0x8c,
+	0x45, 0xb7, 0xd1, 0x83, 0x55, 0xb1, 0x17, 0x74, 0x7c, 0xef, 0xc2, 0x19, 0x46, 0x81, 0x70, 0xf2,
+	0xc7, 0x50, 0x97, 0x9a, 0x4c, 0xa1, 0x46, 0xdb, 0x9a, 0xcf, 0xc0, 0x85, 0x3a, 0x48, 0x5c, 0x0b,
+	0x94, 0x96, 0x71, 0x00, 0x0d, 0x4c, 0x06, 0x4e, 0x40, 0xec, 0x50, 0x28, 0x47, 0x1f, 0xc2, 0x7a,
+	0x9a, 0xd2, 0x1e, 0x0c, 0x02, 0x42, 0x29, 0x9f, 0x52, 0x05, 0x17, 0x77, 0x1a, 0x3f, 0xd7, 0x60,
+	0xf5, 0xc0, 0xf1, 0x2c, 0x97, 0x45, 0xbe, 0x12, 0x82, 0xbb, 0x2c, 0x47, 0x12, 0x02, 0xa6, 0x48,
+	0xdf, 0x5b, 0x5a, 0x6e, 0x15, 0xa5, 0x55, 0xb2, 0x0c, 0x29, 0x35, 0xa2, 0x03, 0x58, 0xb9, 0x60,
+	0xaa, 0xf9, 0x42, 0xe4, 0x68, 0xe1, 0x7b, 0xdc, 0x58, 0x8d, 0x94, 0x81, 0x93, 0xcf, 0xb7, 0x39,
+	0x07, 0x6e, 0x5e, 0xa4, 0x09, 0xe8, 0x2d, 0x68, 0x04, 0x84, 0x86, 0x81, 0x63, 0x87, 0x66, 0x10,
+	0xb9, 0x84, 0xb6, 0xe6, 0xb7, 0xe6, 0xef, 0x55, 0x70, 0x3d, 0xa6, 0x62, 0x46, 0x34, 0xfe, 0xa8,
+	0x04, 0x95, 0xc7, 0x9d, 0x9e, 0xb0, 0x32, 0x7a, 0x04, 0xfa, 0xc8, 0xba, 0x34, 0x23, 0xe6, 0x70,
+	0xd3, 0xb7, 0x43, 0x12, 0xd2, 0x96, 0x96, 0x5b, 0x4d, 0x8f, 0x79, 0x07, 0x6e, 0x8c, 0xac, 0xcb,
+	0x33, 0xc6, 0x29, 0xda, 0xe8, 0x4d, 0x68, 0x4c, 0x85, 0x43, 0x67, 0x44, 0xf8, 0xb0, 0xeb, 0xb8,
+	0x16, 0xf3, 0xf5, 0x9d, 0x11, 0x41, 0x77, 0xa1, 0xfe, 0xdc, 0x72, 0x9d, 0x01, 0x83, 0x40, 0xce,
+	0x34, 0x2f, 0x98, 0x62, 0x22, 0x67, 0x7a, 0x1b, 0x9a, 0x11, 0x25, 0x26, 0x3f, 0x31, 0x0e, 0x02,
+	0x87, 0x19, 0x92, 0xed, 0x61, 0xcb, 0xb8, 0x1e, 0x51, 0x72, 0xe2, 0xdb, 0xcf, 0xf6, 0x38, 0x11,
+	0x61, 0x58, 0x57, 0x8c, 0xa5, 0x20, 0xc8, 0x42, 0x6e, 0x23, 0x2d, 0xf0, 0x17, 0x5e, 0xbd, 0xc8,
+	0x13, 0x8d, 0x7f, 0xd4, 0x00, 0x3a, 0xcc, 0x29, 0x21, 0x4b, 0xa4, 0x11, 0x82, 0x32, 0xdb, 0x14,
+	0x65, 0x40, 0xf0, 0xdf, 0xe8, 0x0d, 0xa8, 0xd9, 0x5f, 0x5b, 0x01, 0xdf, 0xb6, 0x9e, 0x91, 0x89,
+	0x9c, 0x67, 0x35, 0xa6, 0x7d, 0x46, 0x26, 0xe8, 0x5d, 0x58, 0x7c, 0xee, 0xbb, 0x91, 0x9c, 0x5f,
+	0xa1, 0xfd, 0x24, 0x03, 0x7a, 0x04, 0x15, 0x3e, 0xfc, 0x70, 0x32, 0x26, 0x7c, 0x9a, 0x8d, 0xd4,
+	0xc0, 0xa7, 0x63, 0xd9, 0x66, 0x23, 0xed, 0x4f, 0xc6, 0x04, 0x2f, 0x47, 0xf2, 0x97, 0x71, 0x07,
+	0x96, 0x63, 0x2a, 0xaa, 0xc0, 0xc2, 0xee, 0x24, 0x24, 0x54, 0x9f, 0x43, 0xcb, 0x50, 0x66, 0x86,
+	0xd4, 0x35, 0xe3, 0x1f, 0x34, 0xa8, 0x4e, 0x55, 0x50, 0xf4, 0x15, 0xac, 0xd8, 0x49, 0x53, 0x1e,
+	0x13, 0xe4, 0x2a, 0x7a, 0xbf, 0xf0, 0xab, 0x74, 0xbb, 0x93, 0xe5, 0xdf, 0xf7, 0xc2, 0x60, 0x82,
+	0xf3, 0x7a, 0x36, 0xbf, 0x82, 0x8d, 0x62, 0x66, 0xa4, 0xc3, 0x3c, 0xb3, 0x94, 0xc6, 0x2d, 0xc5,
+	0x7e, 0xa2, 0xf7, 0x60, 0xe1, 0xb9, 0xe5, 0x46, 0x44, 0x22, 0xc1, 0x7a, 0xe1, 0xc7, 0xb1, 0xe0,
+	0xf9, 0xa8, 0xf4, 0x50, 0x33, 0x3a, 0x50, 0xeb, 0x5b, 0x81, 0x73, 0x71, 0xf1, 0x84, 0x04, 0x8e,
+	0x3f, 0x40, 0xb7, 0x01, 0x68, 0x68, 0x05, 0xa1, 0x08, 0x23, 0xa1, 0xb9, 0xc2, 0x29, 0x3c, 0x86,
+	0xd6, 0x60, 0x61, 0x1c, 0x38, 0xb6, 0xd0, 0x5f, 0xc6, 0xa2, 0x61, 0xfc, 0x7b, 0x09, 0x16, 0x85,
+	0x16, 0xe6, 0x45, 0x86, 0x2a, 0xde, 0xd0, 0x1c, 0x06, 0x7e, 0x34, 0x96, 0x1a, 0xaa, 0x82, 0x76,
+	0xc8, 0x48, 0xe8, 0x3e, 0x94, 0x99, 0xa5, 0xe5, 0xfa, 0x53, 0xb3, 0x3b, 0xa1, 0x83, 0x7b, 0x04,
+	0x73, 0x1e, 0x74, 0x4b, 0xba, 0x91, 0x3a, 0xdf, 0x0a, 0xa7, 0x97, 0x85, 0x9b, 0x7a, 0xce, 0xb7,
+	0xca, 0x60, 0xca, 0xca, 0x60, 0xd0, 0x8f, 0x60, 0x69, 0xcc, 0xe7, 0x42, 0x5b, 0x0b, 0x39, 0x1c,
+	0x53, 0xe7, 0x8a, 0x63, 0x3e, 0xf6, 0x15, 0xb6, 0xc8, 0x86, 0x81, 0xe5, 0x89, 0x5c, 0xa9, 0x8c,
+	0x97, 0x47, 0xd6, 0xe5, 0x21, 0x6b, 0xa3, 0x1b, 0xb0, 0x34, 0xf6, 0x7d, 0xd7, 0x74, 0x06, 0x3c,
+	0x09, 0xaa, 0xe3, 0x45, 0xd6, 0xec, 0x0e, 0x66, 0xaf, 0x93, 0xe5, 0xef, 0xbf, 0x4e, 0xde, 0x81,
+	0x32, 0xa3, 0x20, 0x80, 0xc5, 0xa7, 0x3c, 0x90, 0xd5, 0xb0, 0x63, 0xb1, 0xb8, 0xff, 0x9c, 0x78,
+	0xa1, 0x5e, 0x32, 0x3e, 0x8d, 0xd7, 0xd3, 0x13, 0xdf, 0x77, 0xd5, 0x31, 0x6a, 0xa9, 0x31, 0xb6,
+	0x60, 0xe9, 0xdc, 0x72, 0x2d, 0x2f, 0xf1, 0x58, 0xdc, 0x34, 0xfa, 0xb0, 0xd2, 0x4b, 0xaa, 0x34,
+	0xbb, 0x82, 0x58, 0xb8, 0x2e, 0xdf, 0x83, 0x05, 0xa6, 0x8c, 0xb6, 0x4a, 0x5b, 0xf3, 0x85, 0x21,
+	0xc5, 0x46, 0x80, 0x05, 0x8f, 0xf1, 0x57, 0x1a, 0x34, 0x84, 0x8d, 0x7b, 0x36, 0xf1, 0xac, 0xc0,
+	0xe1, 0x6b, 0xdd, 0xb3, 0x64, 0x2c, 0x55, 0x30, 0xff, 0x8d, 0xde, 0x83, 0xa5, 0x90, 0x73, 0xc5,
+	0x5a, 0x57, 0x72, 0x3e, 0xc2, 0x31, 0x07, 0x7a, 0x08, 0xcb, 0x72, 0xd0, 0x02, 0x6e, 0xab, 0x3b,
+	0xaf, 0xa9, 0x59, 0x48, 0x76, 0x12, 0x38, 0xe1, 0xce, 0xc3, 0x62, 0x39, 0x0f, 0x8b, 0xc6, 0x5f,
+	0x6a, 0x00, 0xd8, 0x0a, 0xc9, 0x80, 0xc3, 0xe9, 0x75, 0x02, 0xf8, 0x2e, 0xd4, 0x79, 0xa8, 0x90,
+	0x01, 0x77, 0x3d, 0x95, 0xa6, 0xad, 0x49, 0x22, 0x73, 0x20, 0x65, 0x0b, 0x29, 0xa2, 0x09, 0x87,
+	0x08, 0xdd, 0x4a, 0x44, 0xe3, 0xee, 0x16, 0x2c, 0x71, 0x64, 0x23, 0x03, 0x19, 0xbd, 0x71, 0x93,
+	0xd5, 0xa4, 0xa6, 0x61, 0x45, 0x39, 0xe8, 0x2e, 0x63, 0x48, 0x82, 0x85, 0x1a, 0xbf, 0x09, 0x55,
+	0xcc, 0x47, 0xc3, 0x12, 0xb0, 0x57, 0xf7, 0x19, 0x7a, 0x1f, 0x16, 0xf9, 0xf6, 0x12, 0x5b, 0x57,
+	0xe5, 0x9e, 0x1a, 0x06, 0x4b, 0x26, 0xe3, 0x04, 0xd6, 0x3a, 0x12, 0x93, 0x31, 0x69, 0x47, 0xe1,
+	0xd7, 0x7d, 0x36, 0xee, 0x70, 0x16, 0xa6, 0xa7, 0x8c, 0x59, 0xca, 0x19, 0xd3, 0x78, 0x9a, 0x55,
+	0xd7, 0xf6, 0xe8, 0x0b, 0x12, 0x70, 0x20, 0x92, 0xa9, 0x99, 0x8c, 0xea, 0x0a, 0xae, 0x48, 0x4a,
+	0x77, 0x20, 0x2a, 0x77, 0x34, 0x72, 0x43, 0xd3, 0xf6, 0x07, 0xf1, 0xa6, 0x08, 0x82, 0xd4, 0xf1,
+	0x07, 0xc4, 0xf8, 0x25, 0xa8, 0x3e, 0xe9, 0xe0, 0x03, 0xb1, 0x07, 0xd3, 0xa2, 0xcd, 0x4f, 0x2b,
+	0xd8, 0xfc, 0x8c, 0x7f, 0xd6, 0xa0, 0xd6, 0xb6, 0x6d, 0x3f, 0xf2, 0xc4, 0x5e, 0x5e, 0x38, 0xad,
+	0xfb, 0xb0, 0x42, 0x43, 0x2b, 0x74, 0x6c, 0x9e, 0x04, 0x98, 0x2c, 0xa4, 0x85, 0xa9, 0x2b, 0xb8,
+	0x29, 0x3a, 0x98, 0xec, 0x29, 0x23, 0xa3, 0x0f, 0x60, 0x43, 0xe5, 0x3d, 0xb7, 0x68, 0x2c, 0x20,
+	0x52, 0x87, 0xd5, 0xa9, 0xc0, 0xae, 0x45, 0xa5, 0x50, 0x0f, 0x5a, 0x83, 0x89, 0x67, 0x8d, 0x62,
+	0xa9, 0x01, 0xb9, 0x70, 0x3c, 0x87, 0x21, 0x04, 0x6d, 0x95, 0xb7, 0xe6, 0xb3, 0xc9, 0x4f, 0xe4,
+	0x92, 0xbd, 0x84, 0x03, 0x6f, 0x48, 0xd1, 0x34, 0x99, 0x1a, 0xff, 0x54, 0x82, 0x46, 0x9a, 0xc6,
+	0x80, 0x2f, 0x99, 0x81, 0x9c, 0xe1, 0x72, 0x20, 0x87, 0x7e, 0x0d, 0xe7, 0xa1, 0xd7, 0x01, 0xc6,
+	0x01, 0xb1, 0xc9, 0x80, 0x30, 0x84, 0x11, 0x49, 0x87, 0x42, 0x61, 0xf9, 0xd2, 0xc8, 0xf7, 0x9c,
+	0xd0, 0x0f, 0xe2, 0x5d, 0xbd, 0xcc, 0x3f, 0x52, 0x9f, 0x52, 0x3f, 0xe3, 0xbb, 0xd6, 0xca, 0x85,
+	0xeb, 0xbf, 0x30, 0x07, 0x84, 0x2d, 0xe5, 0xb1, 0x98, 0xe7, 0x02, 0x37, 0x8f, 0xce, 0x3a, 0xf6,
+	0x14, 0x3a, 0xfa, 0x1c, 0xd6, 0x92, 0x7c, 0x50, 0xa9, 0xea, 0xb5, 0x96, 0x52, 0xa8, 0xcb, 0xd2,
+	0xf5, 0x38, 0x29, 0x54, 0x6b, 0x77, 0xab, 0x41, 0x9e, 0x88, 0x1e, 0x41, 0xf3, 0x1b, 0x9f, 0xa6,
+	0xb4, 0x09, 0x0c, 0x47, 0x8a, 0xb6, 0x03, 0xd7, 0x7f, 0xf1, 0xb9, 0x4f, 0x71, 0xe3, 0x1b, 0x9f,
+	0x2a, 0xc2, 0xc6, 0x6f, 0xc0, 0x4d, 0xbe, 0x40, 0x4e, 0xc4, 0x94, 0xd2, 0xa9, 0x75, 0x51, 0xf4,
+	0x7c, 0x06, 0xeb, 0x22, 0x9d, 0x93, 0x46, 0x30, 0xc5, 0x96, 0x1f, 0x2f, 0x56, 0x75, 0xbb, 0x52,
+	0x15, 0xe3, 0xd5, 0x48, 0xfd, 0x8c, 0x90, 0x31, 0xfe, 0x54, 0x83, 0x9a, 0xca, 0x85, 0x7e, 0x15,
+	0x5a, 0xb1, 0x5e, 0x36, 0x1f, 0x73, 0x4c, 0x02, 0x33, 0x7d, 0xa4, 0x78, 0x6b, 0xc6, 0x07, 0x1c,
+	0x6f, 0xa8, 0x5a, 0x6a, 0x5d, 0xaa, 0x61, 0xb4, 0x27, 0x24, 0x98, 0xd6, 0x75, 0xaa, 0xa1, 0x1f,
+	0x5a, 0xae, 0xf9, 0x4d, 0xe4, 0x87, 0x96, 0xcc, 0x33, 0x0a, 0x12, 0x31, 0xe0, 0x5c, 0x9f, 0x33,
+	0x26, 0xe3, 0x5f, 0x4a, 0x70, 0xeb, 0xf0, 0x52, 0x0c, 0xb9, 0xe3, 0x7b, 0x61, 0xe0, 0xbb, 0xe2,
+	0x2c, 0x63, 0x09, 0x97, 0x76, 0xa1, 0x46, 0x94, 0xb6, 0xcc, 0x9c, 0xd4, 0x71, 0xce, 0x96, 0xc6,
+	0x29, 0x51, 0x34, 0x80, 0x5b, 0x91, 0x47, 0xe4, 0x41, 0x29, 0x9e, 0xf8, 0xf4, 0x9e, 0x40, 0xe4,
+	0x1c, 0x6f, 0xaa, 0x16, 0x48, 0xb8, 0xe3, 0x23, 0x9b, 0xe4, 0xc5, 0x37, 0xa3, 0x59, 0x5d, 0xe8,
+	0x00, 0x1a, 0xc3, 0x4b, 0xb6, 0x2a, 0x2d, 0x8e, 0x40, 0xb6, 0xd5, 0x9a, 0xcf, 0xd5, 0x6c, 0x32,
+	0x43, 0x16, 0xb0, 0x86, 0x6b, 0xc3, 0xcb, 0x3d, 0x21, 0xd6, 0xb1, 0x2d, 0xd4, 0x81, 0x35, 0x61,
+	0xcc, 0x64, 0xc4, 0xdc, 0xc7, 0xad, 0xf2, 0x2c, 0xab, 0x22, 0xce, 0x1e, 0x1f, 0x04, 0xb9, 0xf7,
+	0x8c, 0xdf, 0xd2, 0x60, 0x3d, 0xf3, 0x31, 0xcc, 0x71, 0x10, 0xfd, 0x98, 0x9d, 0x26, 0xd9, 0xaf,
+	0xd8, 0xa4, 0xaf, 0xe5, 0x4e, 0x93, 0xc2, 0x86, 0x9c, 0x09, 0xc7, 0xcc, 0xe8, 0x01, 0x2c, 0x92,
+	0x20, 0xf0, 0x83, 0xa2, 0x90, 0xdc, 0x67, 0x1d, 0xbb, 0xac, 0x52, 0x42, 0x2e, 0xb1, 0x64, 0x33,
+	0x3e, 0x82, 0x9a, 0x4a, 0x67, 0x99, 0x99, 0xc3, 0x7e, 0xf0, 0x88, 0x5b, 0xc0, 0x0b, 0x4e, 0x4c,
+	0xe5, 0xfc, 0xdc, 0x0b, 0x15, 0x2c, 0x1a, 0x86, 0x03, 0x2b, 0xb9, 0xa1, 0x30, 0x44, 0x50, 0xdc,
+	0x6a, 0xaa, 0xca, 0x74, 0xa5, 0x43, 0x7c, 0xed, 0x1d, 0x68, 0xaa, 0xcc, 0x23, 0x22, 0x72, 0xcb,
+	0x65, 0xdc, 0x50, 0xc8, 0x27, 0x24, 0x34, 0xfe, 0x4c, 0x83, 0xcd, 0xd9, 0x91, 0x84, 0x8e, 0x41,
+	0xcf, 0x46, 0x8e, 0x5c, 0x32, 0x6f, 0xcc, 0xf6, 0x6b, 0x7c, 0x32, 0x6e, 0x66, 0x62, 0x05, 0x3d,
+	0x84, 0x45, 0x8b, 0xfb, 0xbc, 0x55, 0xba, 0x66, 0x6c, 0x48, 0x7e, 0xe3, 0x3f, 0x4b, 0xb0, 0x51,
+	0xfc, 0x95, 0x42, 0x3c, 0x79, 0x04, 0xf1, 0x81, 0x5c, 0x9c, 0x76, 0x44, 0x8c, 0xab, 0x75, 0xaa,
+	0x4e, 0x47, 0xca, 0xf3, 0x73, 0x4e, 0x35, 0x98, 0x36, 0xd0, 0x2e, 0x34, 0x64, 0xd3, 0xf4, 0xa2,
+	0xd1, 0x39, 0x09, 0x64, 0x24, 0xdf, 0xda, 0x16, 0xd7, 0x71, 0xdb, 0xf1, 0x75, 0xdc, 0x76, 0xd7,
+	0x0b, 0x3f, 0xd8, 0x79, 0xca, 0x0e, 0x0e, 0x38, 0x2e, 0x17, 0x9c, 0x72, 0x09, 0x64, 0x42, 0x2b,
+	0x05, 0x68, 0xa2, 0xf0, 0x38, 0xf6, 0x83, 0x90, 0xb6, 0xaa, 0xb9, 0xa5, 0xfc, 0x12, 0xc8, 0xd9,
+	0x88, 0xd2, 0x7d, 0x58, 0x28, 0x41, 0xff, 0x0f, 0x90, 0xf8, 0x80, 0xd0, 0xca, 0x6e, 0xeb, 0x42,
+	0x8b, 0xd7, 0xc2, 0xca, 0x58, 0xe7, 0x3d, 0x82, 0x73, 0x8f, 0xd1, 0xd1, 0x2f, 0x43, 0x9d, 0xb0,
+	0x2c, 0xd9, 0x0c, 0x03, 0x67, 0x38, 0x24, 0x41, 0xab, 0x7e, 0xf5, 0x8c, 0x6a, 0x5c, 0xa2, 0x2f,
+	0x04, 0x8c, 0xdf, 0x9e, 0x87, 0xf5, 0x42, 0x17, 0x65, 0xd3, 0x0e, 0x2d, 0x9b, 0x76, 0xa0, 0xaf,
+	0x60, 0x23, 0x67, 0x0b, 0x86, 0xc3, 0xdf, 0xd1, 0x12, 0x6b, 0x51, 0xbe, 0x8f, 0xf2, 0x42, 0x0d,
+	0xdb, 0xae, 0x1d, 0x8f, 0x86, 0x96, 0xeb, 0x52, 0x59, 0x0e, 0xbc, 0x91, 0xc9, 0x05, 0xba, 0xb2,
+	0x1b, 0xd7, 0x02, 0xa5, 0x95, 0x48, 0x07, 0x64, 0xe4, 0x3f, 0xb7, 0x5c, 0xda, 0xaa, 0x17, 0x4a,
+	0x63, 0xd9, 0x2d, 0xa4, 0xe3, 0x16, 0xdb, 0xca, 0x53, 0x56, 0x65, 0x15, 0xc1, 0xf9, 0x7b, 0x75,
+	0x5c, 0x57, 0x2d, 0x47, 0xd1, 0x21, 0xac, 0x04, 0x84, 0xe7, 0xd7, 0x62, 0x31, 0xf2, 0xb4, 0xbb,
+	0x29, 0x4b, 0x59, 0x59, 0x07, 0xf4, 0xe3, 0x2b, 0x60, 0xac, 0xab, 0x42, 0x8c, 0x6c, 0xfc, 0x31,
+	0xdb, 0x33, 0x26, 0xaf, 0xb4, 0x67, 0x4c, 0xfe, 0xd7, 0xed, 0x19, 0x93, 0xab, 0xf6, 0x8c, 0x49,
+	0xf1, 0x9e, 0x31, 0x99, 0xee, 0x19, 0x02, 0xee, 0x27, 0xbf, 0x50, 0xb8, 0xe7, 0x38, 0x3a, 0x79,
+	0x55, 0x1c, 0x9d, 0xfc, 0x00, 0x38, 0x3a, 0x79, 0x19, 0x8e, 0xfe, 0x9c, 0xe1, 0xe8, 0xe4, 0xff,
+	0x0e, 0x8e, 0xbe, 0x0f, 0xcb, 0x71, 0x1a, 0xda, 0x5a, 0x4f, 0xe5, 0x9f, 0xec, 0xe3, 0x9f, 0x8b,
+	0xb4, 0x13, 0x2f, 0xc9, 0xfc, 0x13, 0x7d, 0x0c, 0xe5, 0x11, 0xb5, 0xed, 0xd6, 0x06, 0x77, 0xda,
+	0x3d, 0xf5, 0x1a, 0x3b, 0x72, 0x43, 0x67, 0xec, 0x12, 0x79, 0x45, 0x43, 0xd3, 0x26, 0xe0, 0x52,
+	0xdf, 0x0d, 0x53, 0x8d, 0xff, 0xc8, 0x07, 0x9d, 0x44, 0xc4, 0xac, 0xd5, 0xb4, 0xef, 0x62, 0xb5,
+	0xb7, 0x72, 0x56, 0x13, 0x87, 0x8c, 0x8c, 0x61, 0x32, 0xa8, 0x3b, 0x9f, 0x43, 0xdd, 0x87, 0x50,
+	0xe3, 0xe9, 0xa8, 0x28, 0xe1, 0xc4, 0x67, 0x24, 0xf5, 0x20, 0xcb, 0x13, 0x51, 0x5e, 0xd0, 0xc1,
+	0xd5, 0x6f, 0x92, 0xdf, 0x94, 0x1d, 0x72, 0xf8, 0x0d, 0xcd, 0x85, 0xe5, 0xb8, 0x51, 0x40, 0xe4,
+	0x71, 0xbb, 0xca, 0x68, 0x07, 0x82, 0x64, 0xfc, 0x4d, 0x09, 0x60, 0x2a, 0x7e, 0x9d, 0x02, 0x41,
+	0x07, 0xd6, 0xe2, 0x02, 0x41, 0x7c, 0x8f, 0x96, 0x54, 0xbc, 0x8a, 0xd3, 0x3a, 0xc9, 0x2e, 0x1d,
+	0xc6, 0x4b, 0x40, 0xd7, 0xaa, 0xe9, 0x66, 0x2c, 0x53, 0xce, 0x59, 0xe6, 0x6d, 0x68, 0x3a, 0xd4,
+	0x14, 0x05, 0x05, 0x71, 0xce, 0xe0, 0x77, 0x50, 0xcb, 0xb8, 0xee, 0x50, 0x5e, 0x95, 0x12, 0x3e,
+	0x9d, 0x5d, 0xcc, 0xaa, 0x7d, 0xff, 0x62, 0xd6, 0x5f, 0x6b, 0xb0, 0x39, 0x7b, 0x8f, 0x2b, 0x38,
+	0x1c, 0x32, 0x53, 0xd6, 0xb2, 0x87, 0xc3, 0x7d, 0xd0, 0x15, 0x36, 0x97, 0x3c, 0x27, 0x6e, 0x41,
+	0xe9, 0x7e, 0xfa, 0x89, 0x63, 0xc6, 0x81, 0x9b, 0xa3, 0x34, 0x81, 0xd5, 0x8e, 0x65, 0xed, 0x7d,
+	0x76, 0xed, 0x58, 0x30, 0xb0, 0x33, 0xd5, 0xed, 0x97, 0x2e, 0xa1, 0xeb, 0xc4, 0xc0, 0x27, 0xb0,
+	0x12, 0xd1, 0x6b, 0x07, 0x00, 0x2b, 0x4e, 0xa4, 0xbc, 0x7f, 0x07, 0xaa, 0xf2, 0xca, 0x8b, 0xaf,
+	0xaa, 0x79, 0xf1, 0x32, 0x49, 0x90, 0x78, 0x8d, 0x9a, 0xc2, 0x92, 0x44, 0x04, 0xf4, 0x01, 0xdc,
+	0xb0, 0xc6, 0x9e, 0x69, 0x0d, 0x87, 0x26, 0x2b, 0x63, 0x9e, 0x3b, 0xa1, 0x19, 0x30, 0xc9, 0xc8,
+	0x95, 0x03, 0x43, 0xd6, 0xd8, 0x6b, 0x0f, 0x87, 0x27, 0xd6, 0xe5, 0xae, 0x13, 0x62, 0x2b, 0x24,
+	0x67, 0xee, 0x4c, 0xa1, 0x81, 0xdb, 0x2a, 0x15, 0x0b, 0xed, 0xb9, 0xc6, 0x5f, 0x94, 0xa0, 0xa6,
+	0x66, 0x18, 0xac, 0x4a, 0xa3, 0x94, 0x40, 0x34, 0x7e, 0x64, 0xaf, 0x04, 0x49, 0xf1, 0xe3, 0x6d,
+	0x68, 0x66, 0xab, 0x1e, 0x25, 0x79, 0x61, 0x92, 0xaa, 0x77, 0xec, 0x81, 0x9e, 0xab, 0x73, 0xcc,
+	0x5f, 0x55, 0xe7, 0x68, 0x06, 0xa9, 0x36, 0x45, 0x1d, 0x68, 0xf2, 0x8b, 0x60, 0x25, 0xf3, 0x28,
+	0x5f, 0x99, 0x79, 0x34, 0xa6, 0x22, 0x7c, 0x45, 0x1d, 0xc2, 0xca, 0x80, 0x64, 0xd5, 0x2c, 0x5c,
+	0x9d, 0xc0, 0xa8, 0x42, 0x8c, 0x6c, 0x9c, 0x09, 0x53, 0x25, 0x09, 0xd4, 0x0f, 0x63, 0x2a, 0x83,
+	0xc2, 0xa2, 0xbc, 0x1a, 0x7a, 0x03, 0x6a, 0xe2, 0xf0, 0xa8, 0xdc, 0x29, 0x95, 0xb1, 0x38, 0x9d,
+	0x4f, 0x59, 0x1c, 0x6f, 0x1c, 0x85, 0x31, 0x8b, 0x28, 0x54, 0x56, 0x39, 0x4d, 0xb2, 0xdc, 0x85,
+	0xba, 0x1f, 0x85, 0x0a, 0x8f, 0x28, 0x55, 0xd6, 0x04, 0x51, 0x30, 0x19, 0xbf, 0x57, 0x02, 0xf4,
+	0x84, 0x3f, 0x08, 0xbc, 0xb2, 0xe4, 0xf7, 0xa9, 0x98, 0x07, 0x35, 0x43, 0x5f, 0x64, 0x9a, 0xf1,
+	0x5d, 0xc4, 0xcc, 0x3c, 0x93, 0x4f, 0x90, 0xf6, 0x7d, 0x4e, 0x20, 0xa8, 0x0d, 0x7a, 0xa2, 0x40,
+	0x26, 0xba, 0xad, 0xf9, 0x42, 0x0d, 0x49, 0x9e, 0xdb, 0x90, 0x1a, 0x24, 0xe1, 0x25, 0x49, 0x78,
+	0xf9, 0x95, 0x93, 0x70, 0xe3, 0x5f, 0xb5, 0xb4, 0x2d, 0x7e, 0x98, 0x7a, 0x25, 0xfa, 0x1c, 0x6a,
+	0x6c, 0x0f, 0x62, 0x29, 0x53, 0x72, 0xb1, 0x58, 0xdd, 0xd9, 0x56, 0x9f, 0x6c, 0xe4, 0x3e, 0xba,
+	0x7d, 0xc0, 0x25, 0x78, 0xa1, 0x52, 0x5c, 0x1f, 0x55, 0x2f, 0xa6, 0x94, 0xcd, 0x9f, 0x82, 0x9e,
+	0x65, 0x50, 0xaf, 0x8c, 0x2a, 0xe2, 0xca, 0x68, 0x4d, 0xbd, 0x32, 0xaa, 0xab, 0x77, 0x43, 0xef,
+	0xc2, 0x2a, 0x7f, 0x11, 0x96, 0xb9, 0x34, 0x2f, 0xf0, 0xba, 0x31, 0x01, 0xa4, 0xb2, 0xfe, 0x40,
+	0x36, 0xb9, 0x0b, 0x75, 0x9e, 0x71, 0xc6, 0xd7, 0xe5, 0x3c, 0x0e, 0x2a, 0xb8, 0xc6, 0x89, 0x27,
+	0x82, 0x66, 0xfc, 0x8e, 0x06, 0xab, 0xed, 0x83, 0x13, 0x32, 0x70, 0xac, 0x5e, 0x74, 0xce, 0x1e,
+	0x86, 0xf8, 0x1e, 0xf1, 0x38, 0x82, 0xf2, 0xa2, 0xa2, 0x4c, 0x2c, 0xe4, 0x51, 0x8d, 0x91, 0x64,
+	0x56, 0x51, 0x58, 0x75, 0x2c, 0xcd, 0xa8, 0x3a, 0xde, 0x06, 0x2e, 0x6a, 0x46, 0xc9, 0x38, 0xea,
+	0xb8, 0xc2, 0x28, 0xa2, 0x06, 0xf3, 0x6f, 0x25, 0xd0, 0xe5, 0x20, 0xa6, 0x23, 0xf8, 0x10, 0x36,
+	0x46, 0x8c, 0x62, 0xda, 0x31, 0x29, 0x3d, 0x98, 0xb5, 0x51, 0x8a, 0x5f, 0x0e, 0x6b, 0x1f, 0x1a,
+	0x34, 0x3a, 0x9f, 0xca, 0xc4, 0x59, 0xb9, 0xba, 0x05, 0x17, 0xcc, 0x17, 0xd7, 0xa9, 0xd2, 0xe2,
+	0x03, 0x16, 0x1f, 0x4f, 0xf6, 0x8f, 0x3a, 0xae, 0x70, 0x0a, 0xcf, 0xbc, 0xde, 0x87, 0x55, 0x06,
+	0xfb, 0x32, 0xcf, 0x22, 0x03, 0xf3, 0xfc, 0x05, 0xdb, 0x2f, 0x44, 0x02, 0xc1, 0xee, 0xab, 0x71,
+	0xdc, 0xb3, 0xfb, 0xe2, 0xcc, 0x2d, 0x64, 0x1f, 0xb8, 0xad, 0x85, 0x22, 0xf6, 0x3d, 0xc1, 0xee,
+	0x78, 0x39, 0xed, 0x8b, 0x92, 0xdd, 0xf1, 0xf2, 0xda, 0xb3, 0xec, 0x03, 0xb7, 0xb5, 0x54, 0xc4,
+	0xbe, 0xe7, 0x1a, 0x7f, 0xa7, 0x31, 0x63, 0x67, 0xa2, 0xf2, 0x8a, 0x58, 0x8b, 0x83, 0xb6, 0xa4,
+	0x40, 0xd5, 0x01, 0xe8, 0x19, 0xff, 0xc4, 0xcb, 0xee, 0x56, 0xde, 0xd6, 0x53, 0x43, 0x37, 0xd3,
+	0x6e, 0xa3, 0xe8, 0x5d, 0xd0, 0xe9, 0x98, 0xd8, 0xce, 0x85, 0x63, 0xcb, 0xb7, 0x05, 0x02, 0x68,
+	0xea, 0xb8, 0x19, 0xd3, 0xc5, 0xfb, 0x01, 0x6a, 0xfc, 0x7f, 0x68, 0x26, 0x23, 0x97, 0x20, 0xfa,
+	0xf2, 0x81, 0x1b, 0xbf, 0xaf, 0x29, 0x22, 0x3f, 0xd0, 0xba, 0x7a, 0x08, 0x2d, 0x76, 0xca, 0x0a,
+	0x9c, 0x11, 0xf1, 0xd8, 0x06, 0x92, 0x4f, 0xae, 0x37, 0xd4, 0x7e, 0x9c, 0x48, 0x1a, 0x7f, 0x58,
+	0x82, 0x46, 0x32, 0x1a, 0x7e, 0x17, 0x89, 0x7e, 0x02, 0x65, 0x25, 0xf1, 0xbf, 0x9b, 0xb2, 0x9c,
+	0xca, 0xb8, 0xcd, 0xff, 0xe5, 0x67, 0x00, 0x2e, 0x90, 0x99, 0x45, 0x29, 0x3b, 0x8b, 0x77, 0xa0,
+	0x99, 0xb1, 0xaa, 0x1c, 0x5b, 0x23, 0x6d, 0x54, 0x36, 0x5d, 0xf1, 0xec, 0xd5, 0xb6, 0x22, 0x9a,
+	0xe4, 0xc0, 0x9c, 0xd4, 0x61, 0x14, 0xf4, 0x63, 0xb8, 0x51, 0xbc, 0x0e, 0xc5, 0x25, 0x43, 0x1d,
+	0xaf, 0x17, 0x2d, 0x44, 0x6a, 0x18, 0x50, 0x49, 0xc6, 0xcc, 0x6e, 0x64, 0xf1, 0x7e, 0xfb, 0xac,
+	0x7f, 0xa4, 0xcf, 0xb1, 0x7b, 0xd8, 0xf6, 0xee, 0x63, 0xdc, 0xd7, 0x35, 0x63, 0x4f, 0xf1, 0x0e,
+	0x67, 0xa6, 0xe8, 0x47, 0xb0, 0xc8, 0x6b, 0x22, 0xf1, 0x29, 0xfc, 0xe6, 0x4c, 0x93, 0x60, 0xc9,
+	0x78, 0xff, 0x63, 0x68, 0x66, 0xde, 0x9e, 0xa0, 0x3a, 0x54, 0x92, 0xc7, 0x44, 0xfa, 0x1c, 0x7b,
+	0x8f, 0x19, 0x5f, 0x67, 0x88, 0xd7, 0x99, 0x58, 0xbe, 0x38, 0xd1, 0x4b, 0xf7, 0x77, 0xa0, 0x99,
+	0x49, 0x7f, 0x91, 0x0e, 0x35, 0xf9, 0x21, 0xde, 0xd6, 0xe7, 0x98, 0x3e, 0xb6, 0x0d, 0x88, 0xa6,
+	0x76, 0xff, 0x10, 0xea, 0xa9, 0x73, 0x19, 0x53, 0x69, 0x76, 0x4f, 0x9f, 0xb6, 0x8f, 0xbb, 0x7b,
+	0xfa, 0x1c, 0xaa, 0xc2, 0x52, 0xf7, 0xb4, 0xdb, 0xef, 0xb6, 0x8f, 0x75, 0x8d, 0x4d, 0xfd, 0xec,
+	0xc9, 0x1e, 0x7b, 0x17, 0x5a, 0x42, 0x4d, 0xa8, 0xf6, 0xf7, 0xf1, 0x49, 0xf7, 0xb4, 0xdd, 0xef,
+	0x3e, 0x3e, 0xd5, 0xe7, 0xef, 0x3f, 0x85, 0x9b, 0x33, 0xcb, 0x21, 0xe8, 0x06, 0xac, 0x76, 0x1e,
+	0x9f, 0xf6, 0xbb, 0xa7, 0x67, 0xfb, 0xe6, 0x17, 0xdd, 0xfe, 0x91, 0xb9, 0x8f, 0xf1, 0x63, 0xac,
+	0xcf, 0xa1, 0x2d, 0x78, 0x2d, 0xdd, 0xb1, 0xb7, 0x7f, 0xd0, 0x3e, 0x3b, 0xee, 0x9b, 0xed, 0xd3,
+	0xde, 0x17, 0xfb, 0x58, 0xd7, 0xee, 0x5f, 0x40, 0xa3, 0x9d, 0xb8, 0x90, 0x8f, 0x10, 0x41, 0x63,
+	0x97, 0x58, 0x01, 0x09, 0xe4, 0x83, 0xe8, 0x81, 0x3e, 0x87, 0xee, 0xc0, 0xad, 0xae, 0x47, 0xa3,
+	0x8b, 0x0b, 0xc7, 0x76, 0x88, 0x17, 0x3f, 0xff, 0x21, 0xd4, 0x8f, 0x02, 0x9b, 0x50, 0x5d, 0xcb,
+	0x32, 0xc4, 0x0a, 0x62, 0x86, 0xd2, 0xce, 0x1f, 0x68, 0xb0, 0xc6, 0xee, 0xff, 0x3a, 0x7e, 0x40,
+	0xa6, 0x77, 0x37, 0x7e, 0x80, 0x3a, 0x50, 0x13, 0x6d, 0xa1, 0x14, 0x65, 0x9f, 0x51, 0x67, 0x5e,
+	0x51, 0x6d, 0xc6, 0x59, 0x3d, 0xff, 0xab, 0x8b, 0xed, 0xa7, 0xbe, 0x33, 0x30, 0xe6, 0xd0, 0x03,
+	0xf6, 0x37, 0x0b, 0x94, 0x84, 0x28, 0xdf, 0x5b, 0x28, 0xb0, 0xf3, 0xf7, 0x8b, 0xb0, 0xc4, 0x86,
+	0xf3, 0xb8, 0xd3, 0x43, 0x8f, 0xd8, 0xd3, 0xdd, 0xf0, 0x71, 0xa7, 0xd7, 0x23, 0x21, 0x3b, 0x58,
+	0x50, 0xb4, 0xa6, 0x9e, 0x1c, 0xe2, 0x07, 0x46, 0xc5, 0x5f, 0xfe, 0x09, 0x54, 0x7a, 0x24, 0x94,
+	0xa7, 0xbb, 0xe2, 0x77, 0x20, 0xc5, 0x82, 0x9f, 0x40, 0x5d, 0x3c, 0xce, 0x93, 0xd7, 0xa0, 0xe8,
+	0x86, 0xfa, 0x02, 0x2e, 0xb9, 0x6d, 0xef, 0xee, 0x15, 0x8b, 0x7f, 0x04, 0x7a, 0xc7, 0x25, 0x56,
+	0x30, 0xe5, 0xa4, 0xd7, 0x9d, 0x3c, 0x3a, 0x86, 0x45, 0x91, 0xde, 0x20, 0xf5, 0xd9, 0x66, 0xd1,
+	0x6d, 0xf3, 0xe6, 0x6c, 0x06, 0x81, 0x91, 0xc6, 0x1c, 0xfa, 0x14, 0xe0, 0x30, 0xb6, 0x00, 0x9d,
+	0x3d, 0x8b, 0x8d, 0x42, 0xdb, 0x50, 0x63, 0x0e, 0x3d, 0x86, 0x9a, 0x9a, 0xd4, 0xa4, 0x22, 0xa0,
+	0x20, 0x31, 0xda, 0xbc, 0x3d, 0xa3, 0x3f, 0x19, 0xd1, 0x29, 0x34, 0x7b, 0x24, 0x4c, 0x95, 0x31,
+	0xdf, 0xbe, 0x56, 0xc1, 0x92, 0x16, 0xdb, 0xeb, 0x33, 0x40, 0x6d, 0x4a, 0x49, 0x90, 0x56, 0x59,
+	0x60, 0xed, 0xad, 0x97, 0xd5, 0xdd, 0x18, 0xb8, 0x1b, 0x73, 0xa8, 0x03, 0x2b, 0x3d, 0x12, 0x66,
+	0x1e, 0x6f, 0xdc, 0xcc, 0xbd, 0xcb, 0x88, 0xbb, 0x8a, 0x47, 0xd4, 0x81, 0x95, 0xc3, 0x9c, 0x92,
+	0x82, 0x01, 0xcd, 0xd6, 0xcb, 0x95, 0x34, 0x0e, 0x49, 0xa8, 0xbe, 0x71, 0xb8, 0x96, 0xf3, 0x14,
+	0x01, 0x63, 0x6e, 0xe7, 0x77, 0x17, 0x60, 0x99, 0x2d, 0x24, 0xf6, 0x08, 0x00, 0x7d, 0xc2, 0x57,
+	0x92, 0xfa, 0x1e, 0x40, 0x15, 0x54, 0xe8, 0xff, 0x2d, 0x4b, 0xe2, 0x21, 0x2c, 0xf7, 0x88, 0x7c,
+	0x4e, 0x90, 0xfa, 0xcb, 0x21, 0xe5, 0x9d, 0x41, 0xb1, 0xe4, 0x09, 0xe8, 0x3d, 0x12, 0xaa, 0x47,
+	0x14, 0x8a, 0xde, 0x9c, 0x71, 0x78, 0xb9, 0x06, 0x1a, 0xbd, 0xca, 0xda, 0x3c, 0x4a, 0xd6, 0xe6,
+	0xed, 0x19, 0x67, 0x12, 0xb9, 0x32, 0x6f, 0xbf, 0xf4, 0xc8, 0xf2, 0x8b, 0x58, 0x56, 0x97, 0xff,
+	0x23, 0xcb, 0xea, 0xb2, 0x70, 0x59, 0xed, 0xfc, 0x79, 0x09, 0x16, 0x59, 0x1c, 0xb6, 0x0f, 0x98,
+	0x37, 0x99, 0x21, 0xfc, 0xc0, 0xf9, 0x36, 0x7e, 0x88, 0x8c, 0x6e, 0x15, 0x25, 0x07, 0xf1, 0xcc,
+	0x37, 0x8b, 0x3a, 0x93, 0x69, 0x1f, 0x83, 0x9e, 0x64, 0x08, 0xb1, 0xba, 0x42, 0x09, 0xe9, 0x98,
+	0x97, 0x6b, 0xeb, 0x80, 0x7e, 0x48, 0xc2, 0x74, 0x26, 0x53, 0x30, 0xe5, 0xcd, 0x99, 0xc9, 0x0c,
+	0x43, 0xcc, 0x8f, 0x01, 0x89, 0x00, 0xbb, 0x4a, 0x4d, 0x91, 0xdd, 0x77, 0x6f, 0x7d, 0x79, 0x93,
+	0x53, 0x1f, 0xb0, 0xbf, 0x4e, 0xb4, 0x5d, 0x3f, 0x1a, 0x3c, 0x18, 0xfa, 0xf2, 0x4f, 0x0a, 0xcf,
+	0x17, 0xf9, 0xff, 0x1f, 0xfc, 0xd7, 0x00, 0x73, 0x7f, 0xa4, 0xdd, 0xfd, 0x38, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3347,6 +4314,11 @@ type MockOCSClient interface {
 	// Todo
 	SetExpectations(ctx context.Context, in *GyCreditControlExpectations, opts ...grpc.CallOption) (*protos1.Void, error)
 	AssertExpectations(ctx context.Context, in *protos1.Void, opts ...grpc.CallOption) (*GyCreditControlResult, error)
+	// Rating engine mode: credit is granted & charged according to the tariffs
+	// of the scenario. An empty scenario disables the rating engine.
+	SetTariffScenario(ctx context.Context, in *TariffScenario, opts ...grpc.CallOption) (*protos1.Void, error)
+	GetTariffScenario(ctx context.Context, in *protos1.Void, opts ...grpc.CallOption) (*TariffScenario, error)
+	GetRatingState(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*RatingState, error)
 }
 
 type mockOCSClient struct {
@@ -3438,6 +4410,33 @@ func (c *mockOCSClient) AssertExpectations(ctx context.Context, in *protos1.Void
 	return out, nil
 }
 
+func (c *mockOCSClient) SetTariffScenario(ctx context.Context, in *TariffScenario, opts ...grpc.CallOption) (*protos1.Void, error) {
+	out := new(protos1.Void)
+	err := c.cc.Invoke(ctx, "/magma.feg.MockOCS/SetTariffScenario", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockOCSClient) GetTariffScenario(ctx context.Context, in *protos1.Void, opts ...grpc.CallOption) (*TariffScenario, error) {
+	out := new(TariffScenario)
+	err := c.cc.Invoke(ctx, "/magma.feg.MockOCS/GetTariffScenario", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockOCSClient) GetRatingState(ctx context.Context, in *protos.SubscriberID, opts ...grpc.CallOption) (*RatingState, error) {
+	out := new(RatingState)
+	err := c.cc.Invoke(ctx, "/magma.feg.MockOCS/GetRatingState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MockOCSServer is the server API for MockOCS service.
 type MockOCSServer interface {
 	SetOCSSettings(context.Context, *OCSConfig) (*protos1.Void, error)
@@ -3450,6 +4449,11 @@ type MockOCSServer interface {
 	// Todo
 	SetExpectations(context.Context, *GyCreditControlExpectations) (*protos1.Void, error)
 	AssertExpectations(context.Context, *protos1.Void) (*GyCreditControlResult, error)
+	// Rating engine mode: credit is granted & charged according to the tariffs
+	// of the scenario. An empty scenario disables the rating engine.
+	SetTariffScenario(context.Context, *TariffScenario) (*protos1.Void, error)
+	GetTariffScenario(context.Context, *protos1.Void) (*TariffScenario, error)
+	GetRatingState(context.Context, *protos.SubscriberID) (*RatingState, error)
 }
 
 // UnimplementedMockOCSServer can be embedded to have forward compatible implementations.
@@ -3483,6 +4487,15 @@ func (*UnimplementedMockOCSServer) SetExpectations(ctx context.Context, req *GyC
 func (*UnimplementedMockOCSServer) AssertExpectations(ctx context.Context, req *protos1.Void) (*GyCreditControlResult, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AssertExpectations not implemented")
 }
+func (*UnimplementedMockOCSServer) SetTariffScenario(ctx context.Context, req *TariffScenario) (*protos1.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTariffScenario not implemented")
+}
+func (*UnimplementedMockOCSServer) GetTariffScenario(ctx context.Context, req *protos1.Void) (*TariffScenario, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTariffScenario not implemented")
+}
+func (*UnimplementedMockOCSServer) GetRatingState(ctx context.Context, req *protos.SubscriberID) (*RatingState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRatingState not implemented")
+}
 
 func RegisterMockOCSServer(s *grpc.Server, srv MockOCSServer) {
 	s.RegisterService(&_MockOCS_serviceDesc, srv)
@@ -3650,6 +4663,60 @@ func _MockOCS_AssertExpectations_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MockOCS_SetTariffScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TariffScenario)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockOCSServer).SetTariffScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.MockOCS/SetTariffScenario",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockOCSServer).SetTariffScenario(ctx, req.(*TariffScenario))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockOCS_GetTariffScenario_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos1.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockOCSServer).GetTariffScenario(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.MockOCS/GetTariffScenario",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockOCSServer).GetTariffScenario(ctx, req.(*protos1.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockOCS_GetRatingState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos.SubscriberID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockOCSServer).GetRatingState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.MockOCS/GetRatingState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockOCSServer).GetRatingState(ctx, req.(*protos.SubscriberID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _MockOCS_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.feg.MockOCS",
 	HandlerType: (*MockOCSServer)(nil),
@@ -3690,6 +4757,18 @@ var _MockOCS_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AssertExpectations",
 			Handler:    _MockOCS_AssertExpectations_Handler,
 		},
+		{
+			MethodName: "SetTariffScenario",
+			Handler:    _MockOCS_SetTariffScenario_Handler,
+		},
+		{
+			MethodName: "GetTariffScenario",
+			Handler:    _MockOCS_GetTariffScenario_Handler,
+		},
+		{
+			MethodName: "GetRatingState",
+			Handler:    _MockOCS_GetRatingState_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "feg/protos/mock_core.proto",
@@ -4054,3 +5133,189 @@ var _MockPCRF_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "feg/protos/mock_core.proto",
 }
+
+// MockAFClient is the client API for MockAF service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MockAFClient interface {
+	// AuthorizeSession sends an AAR to session_proxy for the AF session
+	AuthorizeSession(ctx context.Context, in *AFSessionRequest, opts ...grpc.CallOption) (*AFSessionAnswer, error)
+	// TerminateSession sends a STR to session_proxy for the AF session
+	TerminateSession(ctx context.Context, in *AFSessionTarget, opts ...grpc.CallOption) (*AFSessionAnswer, error)
+	// GetSessionEvents returns the RAR & ASR received from session_proxy
+	GetSessionEvents(ctx context.Context, in *protos1.Void, opts ...grpc.CallOption) (*AFSessionEvents, error)
+	ClearSessionEvents(ctx context.Context, in *protos1.Void, opts ...grpc.CallOption) (*protos1.Void, error)
+}
+
+type mockAFClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMockAFClient(cc grpc.ClientConnInterface) MockAFClient {
+	return &mockAFClient{cc}
+}
+
+func (c *mockAFClient) AuthorizeSession(ctx context.Context, in *AFSessionRequest, opts ...grpc.CallOption) (*AFSessionAnswer, error) {
+	out := new(AFSessionAnswer)
+	err := c.cc.Invoke(ctx, "/magma.feg.MockAF/AuthorizeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockAFClient) TerminateSession(ctx context.Context, in *AFSessionTarget, opts ...grpc.CallOption) (*AFSessionAnswer, error) {
+	out := new(AFSessionAnswer)
+	err := c.cc.Invoke(ctx, "/magma.feg.MockAF/TerminateSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockAFClient) GetSessionEvents(ctx context.Context, in *protos1.Void, opts ...grpc.CallOption) (*AFSessionEvents, error) {
+	out := new(AFSessionEvents)
+	err := c.cc.Invoke(ctx, "/magma.feg.MockAF/GetSessionEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mockAFClient) ClearSessionEvents(ctx context.Context, in *protos1.Void, opts ...grpc.CallOption) (*protos1.Void, error) {
+	out := new(protos1.Void)
+	err := c.cc.Invoke(ctx, "/magma.feg.MockAF/ClearSessionEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MockAFServer is the server API for MockAF service.
+type MockAFServer interface {
+	// AuthorizeSession sends an AAR to session_proxy for the AF session
+	AuthorizeSession(context.Context, *AFSessionRequest) (*AFSessionAnswer, error)
+	// TerminateSession sends a STR to session_proxy for the AF session
+	TerminateSession(context.Context, *AFSessionTarget) (*AFSessionAnswer, error)
+	// GetSessionEvents returns the RAR & ASR received from session_proxy
+	GetSessionEvents(context.Context, *protos1.Void) (*AFSessionEvents, error)
+	ClearSessionEvents(context.Context, *protos1.Void) (*protos1.Void, error)
+}
+
+// UnimplementedMockAFServer can be embedded to have forward compatible implementations.
+type UnimplementedMockAFServer struct {
+}
+
+func (*UnimplementedMockAFServer) AuthorizeSession(ctx context.Context, req *AFSessionRequest) (*AFSessionAnswer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeSession not implemented")
+}
+func (*UnimplementedMockAFServer) TerminateSession(ctx context.Context, req *AFSessionTarget) (*AFSessionAnswer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateSession not implemented")
+}
+func (*UnimplementedMockAFServer) GetSessionEvents(ctx context.Context, req *protos1.Void) (*AFSessionEvents, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionEvents not implemented")
+}
+func (*UnimplementedMockAFServer) ClearSessionEvents(ctx context.Context, req *protos1.Void) (*protos1.Void, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearSessionEvents not implemented")
+}
+
+func RegisterMockAFServer(s *grpc.Server, srv MockAFServer) {
+	s.RegisterService(&_MockAF_serviceDesc, srv)
+}
+
+func _MockAF_AuthorizeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AFSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockAFServer).AuthorizeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.MockAF/AuthorizeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockAFServer).AuthorizeSession(ctx, req.(*AFSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockAF_TerminateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AFSessionTarget)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockAFServer).TerminateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.MockAF/TerminateSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockAFServer).TerminateSession(ctx, req.(*AFSessionTarget))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockAF_GetSessionEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos1.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockAFServer).GetSessionEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.MockAF/GetSessionEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockAFServer).GetSessionEvents(ctx, req.(*protos1.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MockAF_ClearSessionEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(protos1.Void)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MockAFServer).ClearSessionEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.MockAF/ClearSessionEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MockAFServer).ClearSessionEvents(ctx, req.(*protos1.Void))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MockAF_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "magma.feg.MockAF",
+	HandlerType: (*MockAFServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AuthorizeSession",
+			Handler:    _MockAF_AuthorizeSession_Handler,
+		},
+		{
+			MethodName: "TerminateSession",
+			Handler:    _MockAF_TerminateSession_Handler,
+		},
+		{
+			MethodName: "GetSessionEvents",
+			Handler:    _MockAF_GetSessionEvents_Handler,
+		},
+		{
+			MethodName: "ClearSessionEvents",
+			Handler:    _MockAF_ClearSessionEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "feg/protos/mock_core.proto",
+}
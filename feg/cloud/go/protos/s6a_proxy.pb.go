@@ -220,6 +220,31 @@ func (CancelLocationRequest_CancellationType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_f32b2af5087a8858, []int{4, 0}
 }
 
+type NotifyRequest_AlertReason int32
+
+const (
+	NotifyRequest_UE_PRESENT          NotifyRequest_AlertReason = 0
+	NotifyRequest_UE_MEMORY_AVAILABLE NotifyRequest_AlertReason = 1
+)
+
+var NotifyRequest_AlertReason_name = map[int32]string{
+	0: "UE_PRESENT",
+	1: "UE_MEMORY_AVAILABLE",
+}
+
+var NotifyRequest_AlertReason_value = map[string]int32{
+	"UE_PRESENT":          0,
+	"UE_MEMORY_AVAILABLE": 1,
+}
+
+func (x NotifyRequest_AlertReason) String() string {
+	return proto.EnumName(NotifyRequest_AlertReason_name, int32(x))
+}
+
+func (NotifyRequest_AlertReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_f32b2af5087a8858, []int{14, 0}
+}
+
 // Authentication Information Request (Section 7.2.5)
 type AuthenticationInformationRequest struct {
 	// Subscriber identifier
@@ -1224,11 +1249,404 @@ func (m *ResetAnswer) GetErrorCode() ErrorCode {
 	return ErrorCode_UNDEFINED
 }
 
+// Insert Subscriber Data Request (3GPP TS 29.272 Section 7.2.9)
+type InsertSubscriberDataRequest struct {
+	// Subscriber identifier
+	UserName string `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// IDR-Flags 7.3.103
+	IdrFlags uint32 `protobuf:"varint,2,opt,name=idr_flags,json=idrFlags,proto3" json:"idr_flags,omitempty"`
+	// Subscription data to add or modify, fields are the same as in the ULA
+	Msisdn                         []byte                                         `protobuf:"bytes,3,opt,name=msisdn,proto3" json:"msisdn,omitempty"`
+	DefaultContextId               uint32                                         `protobuf:"varint,4,opt,name=default_context_id,json=defaultContextId,proto3" json:"default_context_id,omitempty"`
+	TotalAmbr                      *UpdateLocationAnswer_AggregatedMaximumBitrate `protobuf:"bytes,5,opt,name=total_ambr,json=totalAmbr,proto3" json:"total_ambr,omitempty"`
+	AllApnsIncluded                bool                                           `protobuf:"varint,6,opt,name=all_apns_included,json=allApnsIncluded,proto3" json:"all_apns_included,omitempty"`
+	Apn                            []*UpdateLocationAnswer_APNConfiguration       `protobuf:"bytes,7,rep,name=apn,proto3" json:"apn,omitempty"`
+	DefaultChargingCharacteristics string                                         `protobuf:"bytes,8,opt,name=default_charging_characteristics,json=defaultChargingCharacteristics,proto3" json:"default_charging_characteristics,omitempty"`
+	NetworkAccessMode              UpdateLocationAnswer_NetworkAccessMode         `protobuf:"varint,9,opt,name=network_access_mode,json=networkAccessMode,proto3,enum=magma.feg.UpdateLocationAnswer_NetworkAccessMode" json:"network_access_mode,omitempty"`
+	RegionalSubscriptionZoneCode   [][]byte                                       `protobuf:"bytes,10,rep,name=regional_subscription_zone_code,json=regionalSubscriptionZoneCode,proto3" json:"regional_subscription_zone_code,omitempty"`
+	XXX_NoUnkeyedLiteral           struct{}                                       `json:"-"`
+	XXX_unrecognized               []byte                                         `json:"-"`
+	XXX_sizecache                  int32                                          `json:"-"`
+}
+
+func (m *InsertSubscriberDataRequest) Reset()         { *m = InsertSubscriberDataRequest{} }
+func (m *InsertSubscriberDataRequest) String() string { return proto.CompactTextString(m) }
+func (*InsertSubscriberDataRequest) ProtoMessage()    {}
+func (*InsertSubscriberDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32b2af5087a8858, []int{10}
+}
+
+func (m *InsertSubscriberDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InsertSubscriberDataRequest.Unmarshal(m, b)
+}
+func (m *InsertSubscriberDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InsertSubscriberDataRequest.Marshal(b, m, deterministic)
+}
+func (m *InsertSubscriberDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InsertSubscriberDataRequest.Merge(m, src)
+}
+func (m *InsertSubscriberDataRequest) XXX_Size() int {
+	return xxx_messageInfo_InsertSubscriberDataRequest.Size(m)
+}
+func (m *InsertSubscriberDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InsertSubscriberDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InsertSubscriberDataRequest proto.InternalMessageInfo
+
+func (m *InsertSubscriberDataRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *InsertSubscriberDataRequest) GetIdrFlags() uint32 {
+	if m != nil {
+		return m.IdrFlags
+	}
+	return 0
+}
+
+func (m *InsertSubscriberDataRequest) GetMsisdn() []byte {
+	if m != nil {
+		return m.Msisdn
+	}
+	return nil
+}
+
+func (m *InsertSubscriberDataRequest) GetDefaultContextId() uint32 {
+	if m != nil {
+		return m.DefaultContextId
+	}
+	return 0
+}
+
+func (m *InsertSubscriberDataRequest) GetTotalAmbr() *UpdateLocationAnswer_AggregatedMaximumBitrate {
+	if m != nil {
+		return m.TotalAmbr
+	}
+	return nil
+}
+
+func (m *InsertSubscriberDataRequest) GetAllApnsIncluded() bool {
+	if m != nil {
+		return m.AllApnsIncluded
+	}
+	return false
+}
+
+func (m *InsertSubscriberDataRequest) GetApn() []*UpdateLocationAnswer_APNConfiguration {
+	if m != nil {
+		return m.Apn
+	}
+	return nil
+}
+
+func (m *InsertSubscriberDataRequest) GetDefaultChargingCharacteristics() string {
+	if m != nil {
+		return m.DefaultChargingCharacteristics
+	}
+	return ""
+}
+
+func (m *InsertSubscriberDataRequest) GetNetworkAccessMode() UpdateLocationAnswer_NetworkAccessMode {
+	if m != nil {
+		return m.NetworkAccessMode
+	}
+	return UpdateLocationAnswer_PACKET_AND_CIRCUIT
+}
+
+func (m *InsertSubscriberDataRequest) GetRegionalSubscriptionZoneCode() [][]byte {
+	if m != nil {
+		return m.RegionalSubscriptionZoneCode
+	}
+	return nil
+}
+
+// Insert Subscriber Data Answer (3GPP TS 29.272 Section 7.2.10)
+type InsertSubscriberDataAnswer struct {
+	// EPC error code on failure
+	ErrorCode ErrorCode `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3,enum=magma.feg.ErrorCode" json:"error_code,omitempty"`
+	// IDA-Flags 7.3.47
+	IdaFlags             uint32   `protobuf:"varint,2,opt,name=ida_flags,json=idaFlags,proto3" json:"ida_flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InsertSubscriberDataAnswer) Reset()         { *m = InsertSubscriberDataAnswer{} }
+func (m *InsertSubscriberDataAnswer) String() string { return proto.CompactTextString(m) }
+func (*InsertSubscriberDataAnswer) ProtoMessage()    {}
+func (*InsertSubscriberDataAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32b2af5087a8858, []int{11}
+}
+
+func (m *InsertSubscriberDataAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InsertSubscriberDataAnswer.Unmarshal(m, b)
+}
+func (m *InsertSubscriberDataAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InsertSubscriberDataAnswer.Marshal(b, m, deterministic)
+}
+func (m *InsertSubscriberDataAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InsertSubscriberDataAnswer.Merge(m, src)
+}
+func (m *InsertSubscriberDataAnswer) XXX_Size() int {
+	return xxx_messageInfo_InsertSubscriberDataAnswer.Size(m)
+}
+func (m *InsertSubscriberDataAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_InsertSubscriberDataAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InsertSubscriberDataAnswer proto.InternalMessageInfo
+
+func (m *InsertSubscriberDataAnswer) GetErrorCode() ErrorCode {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ErrorCode_UNDEFINED
+}
+
+func (m *InsertSubscriberDataAnswer) GetIdaFlags() uint32 {
+	if m != nil {
+		return m.IdaFlags
+	}
+	return 0
+}
+
+// Delete Subscriber Data Request (3GPP TS 29.272 Section 7.2.11)
+type DeleteSubscriberDataRequest struct {
+	// Subscriber identifier
+	UserName string `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// DSR-Flags 7.3.25, bit 0 withdraws the Regional Subscription Zone Codes,
+	// bit 3 the PDN subscription contexts listed in context_identifiers
+	DsrFlags uint32 `protobuf:"varint,2,opt,name=dsr_flags,json=dsrFlags,proto3" json:"dsr_flags,omitempty"`
+	// Context identifiers of the APN configurations to delete
+	ContextIdentifiers   []uint32 `protobuf:"varint,3,rep,packed,name=context_identifiers,json=contextIdentifiers,proto3" json:"context_identifiers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSubscriberDataRequest) Reset()         { *m = DeleteSubscriberDataRequest{} }
+func (m *DeleteSubscriberDataRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteSubscriberDataRequest) ProtoMessage()    {}
+func (*DeleteSubscriberDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32b2af5087a8858, []int{12}
+}
+
+func (m *DeleteSubscriberDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSubscriberDataRequest.Unmarshal(m, b)
+}
+func (m *DeleteSubscriberDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSubscriberDataRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteSubscriberDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSubscriberDataRequest.Merge(m, src)
+}
+func (m *DeleteSubscriberDataRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteSubscriberDataRequest.Size(m)
+}
+func (m *DeleteSubscriberDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSubscriberDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSubscriberDataRequest proto.InternalMessageInfo
+
+func (m *DeleteSubscriberDataRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *DeleteSubscriberDataRequest) GetDsrFlags() uint32 {
+	if m != nil {
+		return m.DsrFlags
+	}
+	return 0
+}
+
+func (m *DeleteSubscriberDataRequest) GetContextIdentifiers() []uint32 {
+	if m != nil {
+		return m.ContextIdentifiers
+	}
+	return nil
+}
+
+// Delete Subscriber Data Answer (3GPP TS 29.272 Section 7.2.12)
+type DeleteSubscriberDataAnswer struct {
+	// EPC error code on failure
+	ErrorCode ErrorCode `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3,enum=magma.feg.ErrorCode" json:"error_code,omitempty"`
+	// DSA-Flags 7.3.26
+	DsaFlags             uint32   `protobuf:"varint,2,opt,name=dsa_flags,json=dsaFlags,proto3" json:"dsa_flags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteSubscriberDataAnswer) Reset()         { *m = DeleteSubscriberDataAnswer{} }
+func (m *DeleteSubscriberDataAnswer) String() string { return proto.CompactTextString(m) }
+func (*DeleteSubscriberDataAnswer) ProtoMessage()    {}
+func (*DeleteSubscriberDataAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32b2af5087a8858, []int{13}
+}
+
+func (m *DeleteSubscriberDataAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteSubscriberDataAnswer.Unmarshal(m, b)
+}
+func (m *DeleteSubscriberDataAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteSubscriberDataAnswer.Marshal(b, m, deterministic)
+}
+func (m *DeleteSubscriberDataAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteSubscriberDataAnswer.Merge(m, src)
+}
+func (m *DeleteSubscriberDataAnswer) XXX_Size() int {
+	return xxx_messageInfo_DeleteSubscriberDataAnswer.Size(m)
+}
+func (m *DeleteSubscriberDataAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteSubscriberDataAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteSubscriberDataAnswer proto.InternalMessageInfo
+
+func (m *DeleteSubscriberDataAnswer) GetErrorCode() ErrorCode {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ErrorCode_UNDEFINED
+}
+
+func (m *DeleteSubscriberDataAnswer) GetDsaFlags() uint32 {
+	if m != nil {
+		return m.DsaFlags
+	}
+	return 0
+}
+
+// Notify Request (3GPP TS 29.272 Section 7.2.17)
+type NotifyRequest struct {
+	// Subscriber identifier
+	UserName string `protobuf:"bytes,1,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// NOR-Flags 7.3.49
+	NorFlags uint32 `protobuf:"varint,2,opt,name=nor_flags,json=norFlags,proto3" json:"nor_flags,omitempty"`
+	// Context identifier and APN of a dynamically allocated PDN GW
+	ContextIdentifier uint32 `protobuf:"varint,3,opt,name=context_identifier,json=contextIdentifier,proto3" json:"context_identifier,omitempty"`
+	ServiceSelection  string `protobuf:"bytes,4,opt,name=service_selection,json=serviceSelection,proto3" json:"service_selection,omitempty"`
+	// Alert-Reason 7.3.83
+	AlertReason          NotifyRequest_AlertReason `protobuf:"varint,5,opt,name=alert_reason,json=alertReason,proto3,enum=magma.feg.NotifyRequest_AlertReason" json:"alert_reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *NotifyRequest) Reset()         { *m = NotifyRequest{} }
+func (m *NotifyRequest) String() string { return proto.CompactTextString(m) }
+func (*NotifyRequest) ProtoMessage()    {}
+func (*NotifyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32b2af5087a8858, []int{14}
+}
+
+func (m *NotifyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyRequest.Unmarshal(m, b)
+}
+func (m *NotifyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyRequest.Marshal(b, m, deterministic)
+}
+func (m *NotifyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyRequest.Merge(m, src)
+}
+func (m *NotifyRequest) XXX_Size() int {
+	return xxx_messageInfo_NotifyRequest.Size(m)
+}
+func (m *NotifyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyRequest proto.InternalMessageInfo
+
+func (m *NotifyRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *NotifyRequest) GetNorFlags() uint32 {
+	if m != nil {
+		return m.NorFlags
+	}
+	return 0
+}
+
+func (m *NotifyRequest) GetContextIdentifier() uint32 {
+	if m != nil {
+		return m.ContextIdentifier
+	}
+	return 0
+}
+
+func (m *NotifyRequest) GetServiceSelection() string {
+	if m != nil {
+		return m.ServiceSelection
+	}
+	return ""
+}
+
+func (m *NotifyRequest) GetAlertReason() NotifyRequest_AlertReason {
+	if m != nil {
+		return m.AlertReason
+	}
+	return NotifyRequest_UE_PRESENT
+}
+
+// Notify Answer (3GPP TS 29.272 Section 7.2.18)
+type NotifyAnswer struct {
+	// EPC error code on failure
+	ErrorCode            ErrorCode `protobuf:"varint,1,opt,name=error_code,json=errorCode,proto3,enum=magma.feg.ErrorCode" json:"error_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *NotifyAnswer) Reset()         { *m = NotifyAnswer{} }
+func (m *NotifyAnswer) String() string { return proto.CompactTextString(m) }
+func (*NotifyAnswer) ProtoMessage()    {}
+func (*NotifyAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f32b2af5087a8858, []int{15}
+}
+
+func (m *NotifyAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NotifyAnswer.Unmarshal(m, b)
+}
+func (m *NotifyAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NotifyAnswer.Marshal(b, m, deterministic)
+}
+func (m *NotifyAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NotifyAnswer.Merge(m, src)
+}
+func (m *NotifyAnswer) XXX_Size() int {
+	return xxx_messageInfo_NotifyAnswer.Size(m)
+}
+func (m *NotifyAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_NotifyAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NotifyAnswer proto.InternalMessageInfo
+
+func (m *NotifyAnswer) GetErrorCode() ErrorCode {
+	if m != nil {
+		return m.ErrorCode
+	}
+	return ErrorCode_UNDEFINED
+}
+
 func init() {
 	proto.RegisterEnum("magma.feg.ErrorCode", ErrorCode_name, ErrorCode_value)
 	proto.RegisterEnum("magma.feg.UpdateLocationAnswer_NetworkAccessMode", UpdateLocationAnswer_NetworkAccessMode_name, UpdateLocationAnswer_NetworkAccessMode_value)
 	proto.RegisterEnum("magma.feg.UpdateLocationAnswer_APNConfiguration_PDNType", UpdateLocationAnswer_APNConfiguration_PDNType_name, UpdateLocationAnswer_APNConfiguration_PDNType_value)
 	proto.RegisterEnum("magma.feg.CancelLocationRequest_CancellationType", CancelLocationRequest_CancellationType_name, CancelLocationRequest_CancellationType_value)
+	proto.RegisterEnum("magma.feg.NotifyRequest_AlertReason", NotifyRequest_AlertReason_name, NotifyRequest_AlertReason_value)
 	proto.RegisterType((*AuthenticationInformationRequest)(nil), "magma.feg.AuthenticationInformationRequest")
 	proto.RegisterType((*AuthenticationInformationAnswer)(nil), "magma.feg.AuthenticationInformationAnswer")
 	proto.RegisterType((*AuthenticationInformationAnswer_EUTRANVector)(nil), "magma.feg.AuthenticationInformationAnswer.EUTRANVector")
@@ -1245,132 +1663,156 @@ func init() {
 	proto.RegisterType((*PurgeUEAnswer)(nil), "magma.feg.PurgeUEAnswer")
 	proto.RegisterType((*ResetRequest)(nil), "magma.feg.ResetRequest")
 	proto.RegisterType((*ResetAnswer)(nil), "magma.feg.ResetAnswer")
+	proto.RegisterType((*InsertSubscriberDataRequest)(nil), "magma.feg.InsertSubscriberDataRequest")
+	proto.RegisterType((*InsertSubscriberDataAnswer)(nil), "magma.feg.InsertSubscriberDataAnswer")
+	proto.RegisterType((*DeleteSubscriberDataRequest)(nil), "magma.feg.DeleteSubscriberDataRequest")
+	proto.RegisterType((*DeleteSubscriberDataAnswer)(nil), "magma.feg.DeleteSubscriberDataAnswer")
+	proto.RegisterType((*NotifyRequest)(nil), "magma.feg.NotifyRequest")
+	proto.RegisterType((*NotifyAnswer)(nil), "magma.feg.NotifyAnswer")
 }
 
 func init() { proto.RegisterFile("feg/protos/s6a_proxy.proto", fileDescriptor_f32b2af5087a8858) }
 
 var fileDescriptor_f32b2af5087a8858 = []byte{
-	// 1905 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xeb, 0x6e, 0xdb, 0xc8,
-	0x15, 0xb6, 0x7c, 0x89, 0xad, 0x63, 0xc9, 0xa1, 0x67, 0x9d, 0x48, 0x56, 0x92, 0xda, 0xab, 0xb6,
-	0x88, 0xe1, 0x6d, 0xed, 0x6d, 0xd2, 0xba, 0x97, 0xfd, 0xd1, 0xa5, 0xc5, 0x49, 0xcc, 0x58, 0xa2,
-	0xb4, 0x43, 0xd2, 0xc6, 0xa6, 0xc5, 0x4e, 0xc7, 0xe4, 0x58, 0x21, 0x42, 0x91, 0x0a, 0x49, 0x39,
-	0x76, 0x5f, 0xa0, 0xe8, 0x05, 0xe8, 0x03, 0x74, 0x81, 0xa2, 0x2d, 0xd0, 0x5f, 0xbd, 0x01, 0xfd,
-	0xd5, 0x6e, 0xbb, 0xbd, 0xbc, 0x41, 0x0b, 0xf4, 0x21, 0xfa, 0xa7, 0xcf, 0x50, 0xcc, 0x90, 0x54,
-	0x28, 0x25, 0x41, 0x12, 0x78, 0x7f, 0x79, 0x78, 0xae, 0xdf, 0x9c, 0xf3, 0x9d, 0x43, 0x53, 0xd0,
-	0x38, 0xe5, 0xfd, 0xdd, 0x61, 0x14, 0x26, 0x61, 0xbc, 0x1b, 0xef, 0x31, 0x3a, 0x8c, 0xc2, 0xf3,
-	0x8b, 0x1d, 0x29, 0x40, 0xe5, 0x01, 0xeb, 0x0f, 0xd8, 0xce, 0x29, 0xef, 0x37, 0x7f, 0x30, 0x07,
-	0x9b, 0xea, 0x28, 0x79, 0xc4, 0x83, 0xc4, 0x73, 0x58, 0xe2, 0x85, 0x81, 0x1e, 0x9c, 0x86, 0xd1,
-	0x40, 0x1e, 0x09, 0x7f, 0x32, 0xe2, 0x71, 0x82, 0x6e, 0x40, 0x79, 0x14, 0xf3, 0x88, 0x06, 0x6c,
-	0xc0, 0xeb, 0xa5, 0xcd, 0xd2, 0x56, 0x99, 0x2c, 0x09, 0x81, 0xc1, 0x06, 0x1c, 0xbd, 0x0d, 0x95,
-	0x33, 0x2f, 0xf6, 0x12, 0xee, 0xd2, 0xa1, 0x3f, 0x08, 0xea, 0xb3, 0x9b, 0xa5, 0xad, 0x0a, 0x59,
-	0xce, 0x64, 0x3d, 0x7f, 0x10, 0xa0, 0x6f, 0xc3, 0xcd, 0x60, 0x34, 0xa0, 0x51, 0x1a, 0x8e, 0xbb,
-	0x94, 0x8f, 0x92, 0x88, 0x05, 0xf4, 0x8c, 0x3b, 0x49, 0x18, 0xc5, 0xf5, 0xb9, 0xcd, 0xd2, 0x56,
-	0x95, 0xac, 0x07, 0xa3, 0x01, 0xc9, 0x4d, 0xb0, 0xb4, 0x38, 0x4a, 0x0d, 0xd0, 0xfb, 0x70, 0xd3,
-	0x1b, 0x0c, 0xb8, 0xeb, 0xb1, 0x84, 0xd3, 0x88, 0xc7, 0xc3, 0x30, 0x88, 0x39, 0x1d, 0x46, 0xfc,
-	0x94, 0x47, 0x11, 0x77, 0xeb, 0xf3, 0x9b, 0xa5, 0xad, 0x25, 0xd2, 0x18, 0xdb, 0x90, 0xcc, 0xa4,
-	0x97, 0x5b, 0xa0, 0x0d, 0x58, 0x8e, 0x78, 0x7c, 0x11, 0x38, 0xd4, 0x0b, 0x4e, 0xc3, 0xfa, 0x82,
-	0x04, 0x09, 0xa9, 0x48, 0xdc, 0x18, 0x1d, 0xc0, 0xdb, 0x93, 0x18, 0x53, 0x88, 0x7d, 0x5e, 0x04,
-	0x7a, 0x45, 0x02, 0xbd, 0x55, 0x04, 0x6a, 0x0b, 0xb3, 0xfb, 0xbc, 0x00, 0xf6, 0x6b, 0x50, 0x2b,
-	0xfa, 0x16, 0xd3, 0x2e, 0xca, 0xb4, 0x6b, 0xa3, 0xb1, 0x0f, 0x19, 0x03, 0x68, 0x7e, 0xb2, 0x00,
-	0x1b, 0x2f, 0xed, 0x84, 0x1a, 0xc4, 0x4f, 0x79, 0x84, 0xee, 0x02, 0xf0, 0x28, 0x0a, 0x23, 0xea,
-	0x84, 0x6e, 0xda, 0x89, 0x95, 0x3b, 0x6b, 0x3b, 0xe3, 0x6e, 0xee, 0x60, 0xa1, 0x6c, 0x85, 0x2e,
-	0x27, 0x65, 0x9e, 0x1f, 0xd1, 0x47, 0xb0, 0x32, 0x55, 0xef, 0xd9, 0xcd, 0xb9, 0xad, 0xe5, 0x3b,
-	0x5f, 0x2f, 0x38, 0xbe, 0x22, 0xf1, 0x0e, 0xb6, 0x2d, 0xa2, 0x1a, 0xe9, 0x0d, 0x49, 0x95, 0x4f,
-	0x34, 0xe7, 0x3b, 0x50, 0x9d, 0x6e, 0xa7, 0x08, 0xbf, 0xf7, 0x06, 0xe1, 0x8b, 0xd1, 0x2b, 0xd3,
-	0xc1, 0x27, 0x5b, 0x30, 0xff, 0xc6, 0xc1, 0xef, 0xe3, 0x42, 0xf0, 0x7e, 0xa1, 0x53, 0x8d, 0xef,
-	0x41, 0xa5, 0x78, 0x31, 0x84, 0x60, 0x3e, 0x62, 0x81, 0x2b, 0x0b, 0x5b, 0x21, 0xf2, 0x2c, 0x64,
-	0xe7, 0x11, 0x8f, 0x33, 0x5a, 0xcb, 0xb3, 0x90, 0xb1, 0x51, 0x12, 0x48, 0xde, 0x56, 0x88, 0x3c,
-	0xa3, 0x35, 0x58, 0x78, 0xcc, 0xe2, 0x01, 0x97, 0x5c, 0xac, 0x90, 0xf4, 0xa1, 0xf1, 0xf3, 0x12,
-	0x2c, 0x7f, 0x56, 0x19, 0x76, 0xe1, 0x2d, 0x27, 0x0c, 0x4e, 0x3d, 0x57, 0x5c, 0x96, 0xf9, 0x5e,
-	0x72, 0x41, 0x1f, 0xf3, 0x8b, 0x2c, 0x1f, 0x9a, 0x52, 0x1d, 0xf2, 0x0b, 0xf4, 0x79, 0xa8, 0x7a,
-	0x41, 0xc2, 0xfb, 0x51, 0x6e, 0x9a, 0xb2, 0xbe, 0x32, 0x16, 0x1e, 0xf2, 0x8b, 0x06, 0x86, 0xe5,
-	0x42, 0x81, 0x5e, 0x06, 0x30, 0x2e, 0x00, 0x14, 0x67, 0xb4, 0x02, 0xb3, 0x87, 0x4e, 0x06, 0x6f,
-	0xf6, 0xd0, 0x69, 0xfe, 0xa1, 0x04, 0xd7, 0xec, 0xa1, 0xcb, 0x12, 0xde, 0x0e, 0x9d, 0xcf, 0x74,
-	0x79, 0xbc, 0x0b, 0x6b, 0xf1, 0x63, 0x6f, 0x48, 0xe3, 0xd1, 0x49, 0xec, 0x44, 0xde, 0x09, 0x8f,
-	0xa8, 0xcb, 0x12, 0x26, 0x73, 0x2f, 0x11, 0x24, 0x74, 0xe6, 0x58, 0xa5, 0xb1, 0x84, 0xa1, 0x2f,
-	0xc2, 0x8a, 0x17, 0x78, 0xa2, 0x10, 0x94, 0x25, 0x09, 0x73, 0x1e, 0x65, 0xfb, 0xa1, 0x9a, 0x49,
-	0x55, 0x29, 0x6c, 0x7e, 0xbc, 0x0c, 0x6b, 0x93, 0x90, 0x2f, 0x33, 0x65, 0x5f, 0x02, 0xe4, 0xf2,
-	0x53, 0x36, 0xf2, 0x13, 0xea, 0x84, 0x41, 0xc2, 0xcf, 0x13, 0xea, 0xb9, 0xf2, 0x3e, 0x55, 0xa2,
-	0x64, 0x9a, 0x56, 0xaa, 0xd0, 0x5d, 0x74, 0x0c, 0x90, 0x84, 0x89, 0x00, 0x38, 0x38, 0x89, 0xe4,
-	0x55, 0x96, 0xef, 0x7c, 0xa3, 0x90, 0xe2, 0x45, 0xb8, 0x76, 0xd4, 0x7e, 0x3f, 0xe2, 0x7d, 0x96,
-	0x70, 0xb7, 0xc3, 0xce, 0xbd, 0xc1, 0x68, 0xb0, 0xef, 0x25, 0x91, 0xd8, 0x76, 0x65, 0x19, 0x4b,
-	0x1d, 0x9c, 0x44, 0x68, 0x1b, 0x56, 0x99, 0xef, 0x53, 0x36, 0x0c, 0x62, 0xea, 0x05, 0x8e, 0x3f,
-	0x72, 0xc7, 0xeb, 0xf1, 0x2a, 0xf3, 0x7d, 0x75, 0x18, 0xc4, 0x7a, 0x26, 0x46, 0xfb, 0x30, 0xc7,
-	0x86, 0x41, 0x7d, 0x41, 0x4e, 0xd4, 0xbb, 0xaf, 0xcc, 0xde, 0x33, 0x5a, 0x82, 0x63, 0xfd, 0x51,
-	0x94, 0xf6, 0x57, 0x38, 0xa3, 0x03, 0xd8, 0x1c, 0x5f, 0xfb, 0x11, 0x8b, 0xfa, 0x5e, 0xd0, 0x97,
-	0x07, 0xe6, 0x24, 0x3c, 0xf2, 0xe2, 0xc4, 0x73, 0xd2, 0xad, 0x59, 0x26, 0x9f, 0xcb, 0x8b, 0x90,
-	0x99, 0xb5, 0x26, 0xad, 0xd0, 0x75, 0xb8, 0x32, 0x88, 0xbd, 0xd8, 0x0d, 0xb2, 0x2d, 0x99, 0x3d,
-	0x21, 0x06, 0x6f, 0x05, 0x3c, 0x79, 0x1a, 0x46, 0x8f, 0x29, 0x73, 0x1c, 0x1e, 0xc7, 0x74, 0x20,
-	0xda, 0xb2, 0x24, 0xdb, 0xf2, 0x95, 0x57, 0xa1, 0x36, 0x52, 0x57, 0x55, 0x7a, 0x76, 0x44, 0xcf,
-	0x56, 0x83, 0x69, 0x11, 0xc2, 0xb0, 0x11, 0xf1, 0xbe, 0x17, 0x06, 0xcc, 0xcf, 0x69, 0x36, 0x14,
-	0x31, 0xe8, 0xf7, 0xc3, 0x80, 0xa7, 0x2c, 0x28, 0x6f, 0xce, 0x6d, 0x55, 0xc8, 0xcd, 0xdc, 0xcc,
-	0x2c, 0x58, 0x3d, 0x0c, 0x03, 0x2e, 0x28, 0xd0, 0xf8, 0xe9, 0x02, 0x28, 0xd3, 0x55, 0x42, 0xb7,
-	0x00, 0x0a, 0x7c, 0x28, 0x49, 0x3e, 0x94, 0x9d, 0x31, 0x11, 0xde, 0x81, 0xd5, 0x98, 0x47, 0x67,
-	0x9e, 0xc3, 0x69, 0xcc, 0x7d, 0xee, 0x08, 0x1f, 0xc9, 0x9a, 0x32, 0x51, 0x32, 0x85, 0x99, 0xcb,
-	0xd1, 0x77, 0x61, 0xf9, 0x49, 0x18, 0x8b, 0x57, 0xf9, 0xa9, 0xe7, 0xf3, 0x8c, 0x36, 0xef, 0xbd,
-	0x69, 0xe3, 0x76, 0x3e, 0x08, 0xcd, 0x5e, 0x1a, 0x82, 0xc0, 0x93, 0x30, 0xce, 0xce, 0xa8, 0x0d,
-	0xf3, 0x92, 0x8d, 0xf3, 0x97, 0x64, 0xa3, 0x8c, 0x82, 0x1e, 0xc0, 0xdc, 0xd0, 0x0d, 0xe4, 0xca,
-	0x59, 0x79, 0x8d, 0x60, 0xd3, 0x18, 0x7b, 0x9a, 0x61, 0x5d, 0x0c, 0x39, 0x11, 0x41, 0xd0, 0x37,
-	0xa1, 0xfe, 0x0a, 0x72, 0xd5, 0x9c, 0x17, 0xb3, 0xaa, 0xf1, 0x69, 0x09, 0xe0, 0xd9, 0x7d, 0xd1,
-	0x3a, 0x2c, 0x39, 0x3e, 0x8b, 0xe3, 0xbc, 0x17, 0x0b, 0x64, 0x51, 0x3e, 0xeb, 0xae, 0xd8, 0x1a,
-	0xc3, 0xc8, 0x0b, 0xe5, 0xb2, 0xf4, 0xf9, 0x19, 0xf7, 0xb3, 0xe1, 0xad, 0xe6, 0xd2, 0xb6, 0x10,
-	0xa2, 0xbb, 0x70, 0x6d, 0x18, 0x71, 0x3e, 0x48, 0x09, 0xe2, 0xb0, 0x21, 0x3b, 0xf1, 0xc4, 0xc2,
-	0xcd, 0xf6, 0xd1, 0xda, 0x33, 0x65, 0x6b, 0xac, 0x13, 0x17, 0x28, 0x38, 0x9d, 0x8d, 0xfc, 0x80,
-	0x47, 0xb9, 0x5f, 0x3a, 0x9c, 0xb5, 0x67, 0xfa, 0xa3, 0xa2, 0xba, 0xf9, 0x1e, 0x2c, 0x66, 0xb5,
-	0x40, 0x4b, 0x30, 0xaf, 0xf7, 0x8e, 0xbe, 0xaa, 0xcc, 0x64, 0xa7, 0x3d, 0xa5, 0x84, 0x00, 0xae,
-	0x08, 0xd9, 0xd1, 0x9e, 0x32, 0x8b, 0x14, 0xa8, 0x88, 0x33, 0xed, 0x12, 0x2a, 0xb5, 0x73, 0x8d,
-	0x00, 0xea, 0x2f, 0x6b, 0x13, 0xda, 0x02, 0x65, 0xc0, 0xce, 0xe9, 0x09, 0x0b, 0xdc, 0xa7, 0x9e,
-	0x9b, 0x3c, 0xa2, 0x23, 0x3f, 0xa3, 0xe7, 0xca, 0x80, 0x9d, 0xef, 0xe7, 0x62, 0xdb, 0x7f, 0xde,
-	0xd2, 0xcd, 0x6b, 0x33, 0x61, 0xa9, 0xf9, 0xcd, 0x07, 0xb0, 0xfa, 0xdc, 0xc0, 0xa1, 0xeb, 0x80,
-	0x7a, 0x6a, 0xeb, 0x10, 0x5b, 0x54, 0x35, 0x34, 0xda, 0xd2, 0x49, 0xcb, 0xd6, 0x2d, 0x65, 0x06,
-	0x55, 0x60, 0x89, 0x60, 0x13, 0x93, 0x23, 0xac, 0x29, 0x25, 0x74, 0x15, 0x96, 0xbb, 0x46, 0xfb,
-	0x43, 0x9a, 0x9a, 0x2a, 0xb3, 0xcd, 0x3f, 0xce, 0xc2, 0xb5, 0x16, 0x0b, 0x1c, 0xee, 0xbf, 0xd1,
-	0x1b, 0xe5, 0x23, 0x58, 0x75, 0xa4, 0x97, 0x2f, 0x7d, 0x68, 0x72, 0x31, 0xe4, 0x12, 0xed, 0xe4,
-	0xb2, 0x78, 0x61, 0xe4, 0x4c, 0x9a, 0x7a, 0x4a, 0xfa, 0x29, 0xce, 0x94, 0xa4, 0xf9, 0x71, 0x09,
-	0x94, 0x69, 0x33, 0x54, 0x87, 0xb5, 0x4e, 0x07, 0x53, 0xbb, 0xa7, 0xa9, 0x16, 0xa6, 0x3d, 0xd2,
-	0x6d, 0x61, 0xcd, 0x26, 0x58, 0x99, 0x41, 0xeb, 0x70, 0xcd, 0xbc, 0x6f, 0x1a, 0xcf, 0xab, 0x4a,
-	0xe8, 0x06, 0xd4, 0x4c, 0x7b, 0xdf, 0x6c, 0x11, 0xbd, 0x67, 0xe9, 0x5d, 0x83, 0x1e, 0xeb, 0xd6,
-	0x81, 0x46, 0xd4, 0x63, 0xb5, 0xad, 0xcc, 0x8a, 0x88, 0xd3, 0x2e, 0x54, 0x3f, 0xbe, 0xa7, 0xcc,
-	0xa1, 0x9b, 0x50, 0xd7, 0x0d, 0xdd, 0xd2, 0xd5, 0x36, 0x55, 0x2d, 0x4b, 0x6d, 0x1d, 0x14, 0x82,
-	0xce, 0x37, 0x0f, 0x61, 0x6d, 0xf2, 0x6a, 0x97, 0x78, 0xa7, 0x35, 0xbf, 0x0c, 0x2b, 0xbd, 0x51,
-	0xd4, 0xe7, 0x36, 0x7e, 0x9d, 0xd2, 0x37, 0x35, 0xa8, 0x66, 0xe6, 0x97, 0x49, 0x7a, 0x1b, 0x2a,
-	0x84, 0xc7, 0x3c, 0xc9, 0x53, 0xd6, 0x60, 0x51, 0xa6, 0x94, 0x13, 0x3b, 0xb7, 0x55, 0x26, 0x57,
-	0xc4, 0xa3, 0xee, 0x36, 0xf7, 0x61, 0x59, 0x1a, 0x5e, 0x22, 0xd9, 0xf6, 0xff, 0xe6, 0xa1, 0x3c,
-	0x56, 0xa0, 0x2a, 0x94, 0x6d, 0x43, 0xc3, 0xf7, 0x74, 0x03, 0x6b, 0xca, 0x0c, 0xba, 0x06, 0x4a,
-	0xc7, 0x6e, 0x5b, 0x3a, 0x25, 0x5d, 0xdb, 0xd0, 0xa8, 0x6a, 0x5b, 0x07, 0xca, 0x7f, 0x17, 0x51,
-	0x05, 0x16, 0x4d, 0xbb, 0xd5, 0xc2, 0xa6, 0xa9, 0xfc, 0xeb, 0x2a, 0x5a, 0x83, 0xab, 0x6d, 0xbd,
-	0xa3, 0x5b, 0x58, 0xa3, 0xb9, 0xf4, 0xdf, 0x57, 0x51, 0x0d, 0x50, 0xab, 0xdb, 0xe9, 0x08, 0xc2,
-	0xdb, 0x86, 0x69, 0xf7, 0xba, 0xc4, 0xc2, 0x9a, 0xf2, 0xa7, 0x1a, 0xba, 0x0e, 0xab, 0xb6, 0xa1,
-	0xee, 0xb7, 0x31, 0xb5, 0xba, 0x54, 0xc3, 0x6d, 0xfd, 0x08, 0x13, 0xe5, 0xcf, 0x35, 0x91, 0x8b,
-	0x60, 0xb5, 0xdd, 0xa1, 0x46, 0xd7, 0xa2, 0xd9, 0x50, 0x7c, 0x52, 0x43, 0x55, 0x58, 0xb2, 0xba,
-	0x5d, 0xba, 0x6f, 0x9b, 0x1f, 0x2a, 0x7f, 0xa9, 0x21, 0x04, 0xd5, 0x76, 0xb7, 0xdb, 0xa3, 0x1a,
-	0xb6, 0x70, 0x4b, 0x44, 0xfc, 0x6b, 0x0d, 0xd5, 0xe1, 0x2d, 0x82, 0x35, 0x9d, 0xe0, 0x96, 0x45,
-	0x75, 0x43, 0xd3, 0x5b, 0xaa, 0x60, 0x93, 0xf2, 0x69, 0x0d, 0xdd, 0x84, 0x9a, 0xda, 0xeb, 0xb5,
-	0x33, 0x49, 0x0a, 0x24, 0x43, 0xf2, 0x37, 0x99, 0x51, 0x37, 0x8e, 0xd4, 0xb6, 0xae, 0x1d, 0x50,
-	0x8d, 0xd0, 0x7d, 0xdd, 0x32, 0x95, 0xbf, 0x17, 0xc5, 0x54, 0x3d, 0xea, 0xa5, 0xe2, 0x7f, 0xd4,
-	0xd0, 0x2a, 0x54, 0x6c, 0xe3, 0xd0, 0xe8, 0x1e, 0x1b, 0xb4, 0x87, 0x31, 0x51, 0xfe, 0x99, 0x86,
-	0xb7, 0xad, 0x03, 0x6c, 0x58, 0x79, 0x06, 0x82, 0x1f, 0xa4, 0xb0, 0x7e, 0xb1, 0x21, 0x1c, 0xba,
-	0xb6, 0x45, 0xbb, 0xf7, 0xa8, 0xd9, 0x53, 0x5b, 0x58, 0xf9, 0xe5, 0x86, 0x40, 0x8f, 0xdb, 0xb8,
-	0x25, 0x4d, 0xdb, 0x5d, 0xd3, 0x52, 0x7e, 0xb5, 0x81, 0x6e, 0xc0, 0x75, 0x11, 0xa4, 0x4b, 0xf4,
-	0x87, 0x53, 0x31, 0x7e, 0x7c, 0x5b, 0x26, 0x35, 0x31, 0xa1, 0x59, 0x66, 0xe5, 0x87, 0xb7, 0x45,
-	0x61, 0x73, 0x1c, 0x26, 0x36, 0x4d, 0xe1, 0xa1, 0x6b, 0xca, 0x8f, 0x6e, 0xa3, 0x5b, 0x50, 0xcf,
-	0x15, 0xb8, 0x67, 0xd2, 0xe2, 0x64, 0x29, 0xbf, 0xd9, 0x16, 0x6d, 0x22, 0xaa, 0x25, 0xab, 0xab,
-	0xb6, 0xdb, 0xdd, 0x63, 0xac, 0x29, 0xbf, 0xdd, 0x96, 0xb5, 0xeb, 0xaa, 0x1d, 0xdd, 0xb8, 0x3f,
-	0xa1, 0xf9, 0xc9, 0x6d, 0xd1, 0x27, 0xfc, 0x81, 0xad, 0xf7, 0x3a, 0xd8, 0xb0, 0xc6, 0xf9, 0x7f,
-	0x27, 0x3d, 0x6c, 0xe3, 0x30, 0x4d, 0x4f, 0x8e, 0x52, 0x47, 0x0d, 0x2b, 0xbf, 0xdf, 0x46, 0x5f,
-	0x80, 0x8d, 0xa9, 0x72, 0x68, 0xaa, 0xa5, 0x52, 0xdb, 0x50, 0x8f, 0x54, 0xbd, 0x2d, 0x5a, 0xae,
-	0xfc, 0x67, 0xf3, 0xce, 0xcf, 0x66, 0x61, 0xc9, 0xdc, 0x63, 0x3d, 0xf1, 0x35, 0x8e, 0xce, 0x60,
-	0xfd, 0xa5, 0x1f, 0x2f, 0xe8, 0x9d, 0xd7, 0xf9, 0xc4, 0xc9, 0x86, 0xa4, 0xb1, 0xfd, 0xfa, 0xdf,
-	0x43, 0xcd, 0x19, 0x64, 0xc3, 0xca, 0xe4, 0x5b, 0x18, 0x6d, 0xbe, 0xf4, 0x05, 0x9d, 0x67, 0xd8,
-	0x78, 0xc5, 0x2b, 0xbc, 0x39, 0x83, 0xde, 0x87, 0xc5, 0x6c, 0xfe, 0xd1, 0x7a, 0xc1, 0x7a, 0x72,
-	0x85, 0x34, 0xea, 0xcf, 0xab, 0xf2, 0x08, 0x77, 0x7e, 0x5d, 0x82, 0x55, 0x73, 0x8f, 0xdd, 0x67,
-	0x09, 0x7f, 0xca, 0x2e, 0xcc, 0xf4, 0xff, 0x1f, 0x01, 0x77, 0x72, 0xa7, 0x4d, 0xc0, 0x7d, 0xe1,
-	0x26, 0x9f, 0x80, 0xfb, 0xa2, 0x85, 0xd8, 0x9c, 0x41, 0xdf, 0x82, 0x05, 0xb9, 0x3f, 0x50, 0xad,
-	0x60, 0x5b, 0x5c, 0x3d, 0x8d, 0xeb, 0xd3, 0x8a, 0xdc, 0x77, 0xff, 0xc6, 0xc3, 0x75, 0xa9, 0xda,
-	0x3d, 0xe5, 0xfd, 0x5d, 0xc7, 0x0f, 0x47, 0xee, 0x6e, 0x3f, 0xcc, 0x7e, 0x6e, 0x39, 0xb9, 0x22,
-	0xff, 0xde, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xb4, 0xb4, 0x95, 0x86, 0x83, 0x11, 0x00,
-	0x00,
+	// 2202 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0x5b, 0x6f, 0xe3, 0xc6,
+	0xf5, 0x5f, 0xc9, 0x57, 0x1d, 0x49, 0x5e, 0x7a, 0xd6, 0xbb, 0xd2, 0xca, 0x9b, 0xbf, 0x1d, 0xfd,
+	0x93, 0xae, 0xb1, 0x69, 0xbc, 0xa9, 0xd3, 0xba, 0x69, 0x83, 0xa2, 0xa1, 0x45, 0xae, 0xcd, 0x58,
+	0xa2, 0x94, 0x21, 0x69, 0x23, 0x69, 0x91, 0xe9, 0x98, 0x1c, 0x6b, 0x89, 0xa5, 0x48, 0x85, 0xa4,
+	0xbc, 0x76, 0xbf, 0x40, 0xaf, 0x40, 0xbf, 0x40, 0x80, 0xa2, 0x2d, 0xd0, 0xa7, 0xde, 0x80, 0x3e,
+	0xb5, 0x69, 0xd3, 0xcb, 0x37, 0x68, 0x81, 0xbe, 0xe4, 0x1b, 0xf4, 0xa5, 0xcf, 0x7d, 0x2c, 0x66,
+	0x48, 0xc9, 0x94, 0x6c, 0xaf, 0xbd, 0x75, 0xd2, 0x27, 0x0d, 0xcf, 0xfd, 0xcc, 0xf9, 0xcd, 0x9c,
+	0xa3, 0x81, 0xda, 0x21, 0xeb, 0x3e, 0xec, 0x87, 0x41, 0x1c, 0x44, 0x0f, 0xa3, 0x4d, 0x4a, 0xfa,
+	0x61, 0x70, 0x7c, 0xb2, 0x2e, 0x08, 0xa8, 0xd0, 0xa3, 0xdd, 0x1e, 0x5d, 0x3f, 0x64, 0xdd, 0xfa,
+	0x77, 0xa6, 0x60, 0x55, 0x1e, 0xc4, 0x8f, 0x99, 0x1f, 0xbb, 0x36, 0x8d, 0xdd, 0xc0, 0xd7, 0xfc,
+	0xc3, 0x20, 0xec, 0x89, 0x25, 0x66, 0x1f, 0x0c, 0x58, 0x14, 0xa3, 0x65, 0x28, 0x0c, 0x22, 0x16,
+	0x12, 0x9f, 0xf6, 0x58, 0x35, 0xb7, 0x9a, 0x5b, 0x2b, 0xe0, 0x79, 0x4e, 0xd0, 0x69, 0x8f, 0xa1,
+	0x17, 0xa1, 0x74, 0xe4, 0x46, 0x6e, 0xcc, 0x1c, 0xd2, 0xf7, 0x7a, 0x7e, 0x35, 0xbf, 0x9a, 0x5b,
+	0x2b, 0xe1, 0x62, 0x4a, 0xeb, 0x78, 0x3d, 0x1f, 0x7d, 0x1d, 0xee, 0xf9, 0x83, 0x1e, 0x09, 0x13,
+	0x73, 0xcc, 0x21, 0x6c, 0x10, 0x87, 0xd4, 0x27, 0x47, 0xcc, 0x8e, 0x83, 0x30, 0xaa, 0x4e, 0xad,
+	0xe6, 0xd6, 0xca, 0xf8, 0xae, 0x3f, 0xe8, 0xe1, 0xa1, 0x88, 0x2a, 0x24, 0xf6, 0x12, 0x01, 0xf4,
+	0x16, 0xdc, 0x73, 0x7b, 0x3d, 0xe6, 0xb8, 0x34, 0x66, 0x24, 0x64, 0x51, 0x3f, 0xf0, 0x23, 0x46,
+	0xfa, 0x21, 0x3b, 0x64, 0x61, 0xc8, 0x9c, 0xea, 0xf4, 0x6a, 0x6e, 0x6d, 0x1e, 0xd7, 0x46, 0x32,
+	0x38, 0x15, 0xe9, 0x0c, 0x25, 0xd0, 0x0a, 0x14, 0x43, 0x16, 0x9d, 0xf8, 0x36, 0x71, 0xfd, 0xc3,
+	0xa0, 0x3a, 0x23, 0x82, 0x84, 0x84, 0xc4, 0x33, 0x46, 0x3b, 0xf0, 0xe2, 0x78, 0x8c, 0x49, 0x88,
+	0x5d, 0x96, 0x0d, 0x74, 0x56, 0x04, 0xfa, 0x42, 0x36, 0x50, 0x8b, 0x8b, 0x6d, 0xb3, 0x4c, 0xb0,
+	0x5f, 0x82, 0x4a, 0x56, 0x37, 0xeb, 0x76, 0x4e, 0xb8, 0x5d, 0x1a, 0x8c, 0x74, 0xf0, 0x28, 0x80,
+	0xfa, 0x47, 0x33, 0xb0, 0x72, 0x61, 0x25, 0x64, 0x3f, 0x7a, 0xca, 0x42, 0xf4, 0x3a, 0x00, 0x0b,
+	0xc3, 0x20, 0x24, 0x76, 0xe0, 0x24, 0x95, 0x58, 0xd8, 0x58, 0x5a, 0x1f, 0x55, 0x73, 0x5d, 0xe5,
+	0xcc, 0x46, 0xe0, 0x30, 0x5c, 0x60, 0xc3, 0x25, 0x7a, 0x1f, 0x16, 0x26, 0xf6, 0x3b, 0xbf, 0x3a,
+	0xb5, 0x56, 0xdc, 0xf8, 0x72, 0x46, 0xf1, 0x12, 0xc7, 0xeb, 0xaa, 0x65, 0x62, 0x59, 0x4f, 0x32,
+	0xc4, 0x65, 0x36, 0x56, 0x9c, 0x6f, 0x40, 0x79, 0xb2, 0x9c, 0xdc, 0xfc, 0xe6, 0x73, 0x98, 0xcf,
+	0x5a, 0x2f, 0x4d, 0x1a, 0x1f, 0x2f, 0xc1, 0xf4, 0x73, 0x1b, 0xdf, 0x56, 0x33, 0xc6, 0xbb, 0x99,
+	0x4a, 0xd5, 0xbe, 0x05, 0xa5, 0x6c, 0x62, 0x08, 0xc1, 0x74, 0x48, 0x7d, 0x47, 0x6c, 0x6c, 0x09,
+	0x8b, 0x35, 0xa7, 0x1d, 0x87, 0x2c, 0x4a, 0x61, 0x2d, 0xd6, 0x9c, 0x46, 0x07, 0xb1, 0x2f, 0x70,
+	0x5b, 0xc2, 0x62, 0x8d, 0x96, 0x60, 0xe6, 0x09, 0x8d, 0x7a, 0x4c, 0x60, 0xb1, 0x84, 0x93, 0x8f,
+	0xda, 0x8f, 0x73, 0x50, 0xfc, 0xb4, 0x3c, 0x3c, 0x84, 0x5b, 0x76, 0xe0, 0x1f, 0xba, 0x0e, 0x4f,
+	0x96, 0x7a, 0x6e, 0x7c, 0x42, 0x9e, 0xb0, 0x93, 0xd4, 0x1f, 0x9a, 0x60, 0xed, 0xb2, 0x13, 0xf4,
+	0xff, 0x50, 0x76, 0xfd, 0x98, 0x75, 0xc3, 0xa1, 0x68, 0x82, 0xfa, 0xd2, 0x88, 0xb8, 0xcb, 0x4e,
+	0x6a, 0x2a, 0x14, 0x33, 0x1b, 0x74, 0x51, 0x80, 0x51, 0x26, 0x40, 0xbe, 0x46, 0x0b, 0x90, 0xdf,
+	0xb5, 0xd3, 0xf0, 0xf2, 0xbb, 0x76, 0xfd, 0x37, 0x39, 0xb8, 0x6d, 0xf5, 0x1d, 0x1a, 0xb3, 0x66,
+	0x60, 0x7f, 0xaa, 0x97, 0xc7, 0x6b, 0xb0, 0x14, 0x3d, 0x71, 0xfb, 0x24, 0x1a, 0x1c, 0x44, 0x76,
+	0xe8, 0x1e, 0xb0, 0x90, 0x38, 0x34, 0xa6, 0xc2, 0xf7, 0x3c, 0x46, 0x9c, 0x67, 0x8c, 0x58, 0x0a,
+	0x8d, 0x29, 0x7a, 0x19, 0x16, 0x5c, 0xdf, 0xe5, 0x1b, 0x41, 0x68, 0x1c, 0x53, 0xfb, 0x71, 0x7a,
+	0x3f, 0x94, 0x53, 0xaa, 0x2c, 0x88, 0xf5, 0x0f, 0x8b, 0xb0, 0x34, 0x1e, 0xf2, 0x75, 0x4e, 0xd9,
+	0xe7, 0x01, 0x39, 0xec, 0x90, 0x0e, 0xbc, 0x98, 0xd8, 0x81, 0x1f, 0xb3, 0xe3, 0x98, 0xb8, 0x8e,
+	0xc8, 0xa7, 0x8c, 0xa5, 0x94, 0xd3, 0x48, 0x18, 0x9a, 0x83, 0xf6, 0x01, 0xe2, 0x20, 0xe6, 0x01,
+	0xf6, 0x0e, 0x42, 0x91, 0x4a, 0x71, 0xe3, 0x8d, 0x8c, 0x8b, 0xf3, 0xe2, 0x5a, 0x97, 0xbb, 0xdd,
+	0x90, 0x75, 0x69, 0xcc, 0x9c, 0x16, 0x3d, 0x76, 0x7b, 0x83, 0xde, 0x96, 0x1b, 0x87, 0xfc, 0xb6,
+	0x2b, 0x08, 0x5b, 0x72, 0xef, 0x20, 0x44, 0x0f, 0x60, 0x91, 0x7a, 0x1e, 0xa1, 0x7d, 0x3f, 0x22,
+	0xae, 0x6f, 0x7b, 0x03, 0x67, 0x74, 0x3d, 0xde, 0xa4, 0x9e, 0x27, 0xf7, 0xfd, 0x48, 0x4b, 0xc9,
+	0x68, 0x0b, 0xa6, 0x68, 0xdf, 0xaf, 0xce, 0x88, 0x13, 0xf5, 0xda, 0xa5, 0xde, 0x3b, 0x7a, 0x83,
+	0x63, 0xac, 0x3b, 0x08, 0x93, 0xfa, 0x72, 0x65, 0xb4, 0x03, 0xab, 0xa3, 0xb4, 0x1f, 0xd3, 0xb0,
+	0xeb, 0xfa, 0x5d, 0xb1, 0xa0, 0x76, 0xcc, 0x42, 0x37, 0x8a, 0x5d, 0x3b, 0xb9, 0x35, 0x0b, 0xf8,
+	0xff, 0x86, 0x9b, 0x90, 0x8a, 0x35, 0xc6, 0xa5, 0xd0, 0x1d, 0x98, 0xed, 0x45, 0x6e, 0xe4, 0xf8,
+	0xe9, 0x2d, 0x99, 0x7e, 0x21, 0x0a, 0xb7, 0x7c, 0x16, 0x3f, 0x0d, 0xc2, 0x27, 0x84, 0xda, 0x36,
+	0x8b, 0x22, 0xd2, 0xe3, 0x65, 0x99, 0x17, 0x65, 0xf9, 0xc2, 0x65, 0x51, 0xeb, 0x89, 0xaa, 0x2c,
+	0x34, 0x5b, 0xbc, 0x66, 0x8b, 0xfe, 0x24, 0x09, 0xa9, 0xb0, 0x12, 0xb2, 0xae, 0x1b, 0xf8, 0xd4,
+	0x1b, 0xc2, 0xac, 0xcf, 0x6d, 0x90, 0x6f, 0x07, 0x3e, 0x4b, 0x50, 0x50, 0x58, 0x9d, 0x5a, 0x2b,
+	0xe1, 0x7b, 0x43, 0x31, 0x23, 0x23, 0xf5, 0x5e, 0xe0, 0x33, 0x0e, 0x81, 0xda, 0x8f, 0x66, 0x40,
+	0x9a, 0xdc, 0x25, 0xf4, 0x02, 0x40, 0x06, 0x0f, 0x39, 0x81, 0x87, 0x82, 0x3d, 0x02, 0xc2, 0x2b,
+	0xb0, 0x18, 0xb1, 0xf0, 0xc8, 0xb5, 0x19, 0x89, 0x98, 0xc7, 0x6c, 0xae, 0x23, 0x50, 0x53, 0xc0,
+	0x52, 0xca, 0x30, 0x86, 0x74, 0xf4, 0x4d, 0x28, 0x7e, 0x10, 0x44, 0xbc, 0x95, 0x1f, 0xba, 0x1e,
+	0x4b, 0x61, 0xf3, 0xe6, 0xf3, 0x16, 0x6e, 0xfd, 0x9d, 0xc0, 0xe8, 0x24, 0x26, 0x30, 0x7c, 0x10,
+	0x44, 0xe9, 0x1a, 0x35, 0x61, 0x5a, 0xa0, 0x71, 0xfa, 0x9a, 0x68, 0x14, 0x56, 0xd0, 0xdb, 0x30,
+	0xd5, 0x77, 0x7c, 0x71, 0xe5, 0x2c, 0x6c, 0xbc, 0xf1, 0xdc, 0x31, 0x76, 0x14, 0xdd, 0x3c, 0xe9,
+	0x33, 0xcc, 0x8d, 0xa0, 0xaf, 0x40, 0xf5, 0x12, 0x70, 0x55, 0xec, 0xf3, 0x51, 0x55, 0xfb, 0x38,
+	0x07, 0x70, 0x9a, 0x2f, 0xba, 0x0b, 0xf3, 0xb6, 0x47, 0xa3, 0x68, 0x58, 0x8b, 0x19, 0x3c, 0x27,
+	0xbe, 0x35, 0x87, 0xdf, 0x1a, 0xfd, 0xd0, 0x0d, 0xc4, 0x65, 0xe9, 0xb1, 0x23, 0xe6, 0xa5, 0x87,
+	0xb7, 0x3c, 0xa4, 0x36, 0x39, 0x11, 0xbd, 0x0e, 0xb7, 0xfb, 0x21, 0x63, 0xbd, 0x04, 0x20, 0x36,
+	0xed, 0xd3, 0x03, 0x97, 0x5f, 0xb8, 0xe9, 0x7d, 0xb4, 0x74, 0xca, 0x6c, 0x8c, 0x78, 0x3c, 0x81,
+	0x8c, 0xd2, 0xd1, 0xc0, 0xf3, 0x59, 0x38, 0xd4, 0x4b, 0x0e, 0x67, 0xe5, 0x94, 0xbf, 0x97, 0x65,
+	0xd7, 0xdf, 0x84, 0xb9, 0x74, 0x2f, 0xd0, 0x3c, 0x4c, 0x6b, 0x9d, 0xbd, 0x2f, 0x4a, 0x37, 0xd2,
+	0xd5, 0xa6, 0x94, 0x43, 0x00, 0xb3, 0x9c, 0xb6, 0xb7, 0x29, 0xe5, 0x91, 0x04, 0x25, 0xbe, 0x26,
+	0x6d, 0x4c, 0x04, 0x77, 0xaa, 0xe6, 0x43, 0xf5, 0xa2, 0x32, 0xa1, 0x35, 0x90, 0x7a, 0xf4, 0x98,
+	0x1c, 0x50, 0xdf, 0x79, 0xea, 0x3a, 0xf1, 0x63, 0x32, 0xf0, 0x52, 0x78, 0x2e, 0xf4, 0xe8, 0xf1,
+	0xd6, 0x90, 0x6c, 0x79, 0x67, 0x25, 0x9d, 0xe1, 0xde, 0x8c, 0x49, 0x2a, 0x5e, 0xfd, 0x6d, 0x58,
+	0x3c, 0x73, 0xe0, 0xd0, 0x1d, 0x40, 0x1d, 0xb9, 0xb1, 0xab, 0x9a, 0x44, 0xd6, 0x15, 0xd2, 0xd0,
+	0x70, 0xc3, 0xd2, 0x4c, 0xe9, 0x06, 0x2a, 0xc1, 0x3c, 0x56, 0x0d, 0x15, 0xef, 0xa9, 0x8a, 0x94,
+	0x43, 0x37, 0xa1, 0xd8, 0xd6, 0x9b, 0xef, 0x92, 0x44, 0x54, 0xca, 0xd7, 0x7f, 0x9b, 0x87, 0xdb,
+	0x0d, 0xea, 0xdb, 0xcc, 0x7b, 0xae, 0x8e, 0xf2, 0x3e, 0x2c, 0xda, 0x42, 0xcb, 0x13, 0x3a, 0x24,
+	0x3e, 0xe9, 0xb3, 0x6a, 0xfe, 0xcc, 0x65, 0x71, 0xae, 0xe5, 0xf5, 0x46, 0x46, 0x53, 0xc0, 0x4f,
+	0xb2, 0x27, 0x28, 0xf5, 0x0f, 0x73, 0x20, 0x4d, 0x8a, 0xa1, 0x2a, 0x2c, 0xb5, 0x5a, 0x2a, 0xb1,
+	0x3a, 0x8a, 0x6c, 0xaa, 0xa4, 0x83, 0xdb, 0x0d, 0x55, 0xb1, 0xb0, 0x2a, 0xdd, 0x40, 0x77, 0xe1,
+	0xb6, 0xb1, 0x6d, 0xe8, 0x67, 0x59, 0x39, 0xb4, 0x0c, 0x15, 0xc3, 0xda, 0x32, 0x1a, 0x58, 0xeb,
+	0x98, 0x5a, 0x5b, 0x27, 0xfb, 0x9a, 0xb9, 0xa3, 0x60, 0x79, 0x5f, 0x6e, 0x4a, 0x79, 0x6e, 0x71,
+	0x52, 0x85, 0x68, 0xfb, 0x8f, 0xa4, 0x29, 0x74, 0x0f, 0xaa, 0x9a, 0xae, 0x99, 0x9a, 0xdc, 0x24,
+	0xb2, 0x69, 0xca, 0x8d, 0x9d, 0x8c, 0xd1, 0xe9, 0xfa, 0x2e, 0x2c, 0x8d, 0xa7, 0x76, 0x8d, 0x9e,
+	0x56, 0x7f, 0x15, 0x16, 0x3a, 0x83, 0xb0, 0xcb, 0x2c, 0xf5, 0x2a, 0x5b, 0x5f, 0x57, 0xa0, 0x9c,
+	0x8a, 0x5f, 0xc7, 0xe9, 0x7d, 0x28, 0x61, 0x16, 0xb1, 0x78, 0xe8, 0xb2, 0x02, 0x73, 0xc2, 0xa5,
+	0x38, 0xb1, 0x53, 0x6b, 0x05, 0x3c, 0xcb, 0x3f, 0x35, 0xa7, 0xbe, 0x05, 0x45, 0x21, 0x78, 0x1d,
+	0x67, 0x9f, 0x4c, 0xc3, 0xb2, 0xe6, 0x47, 0x2c, 0x8c, 0xc7, 0x67, 0x88, 0x2b, 0x41, 0x6d, 0x19,
+	0x0a, 0xae, 0x13, 0x92, 0x43, 0x8f, 0x76, 0xa3, 0xf4, 0x40, 0xcc, 0xbb, 0x4e, 0xf8, 0x88, 0x7f,
+	0x67, 0xda, 0xd9, 0xd4, 0x58, 0x3b, 0x3b, 0x7f, 0x4e, 0x98, 0xbe, 0xd2, 0x9c, 0x30, 0xf3, 0x19,
+	0xcf, 0x09, 0xb3, 0xcf, 0x9c, 0x13, 0xe6, 0x3e, 0xeb, 0x39, 0x61, 0xfe, 0x4a, 0x73, 0xc2, 0x05,
+	0xf3, 0x40, 0xe1, 0x7f, 0x3b, 0x0f, 0xc0, 0xe5, 0xf3, 0x40, 0xdd, 0x87, 0xda, 0x79, 0xd8, 0xba,
+	0xce, 0x94, 0x29, 0x20, 0x47, 0x27, 0x21, 0x47, 0x05, 0xe4, 0xea, 0xdf, 0xcd, 0xc1, 0xb2, 0xc2,
+	0x3c, 0x16, 0xb3, 0xff, 0x0e, 0xcc, 0x4e, 0x34, 0x01, 0x66, 0x27, 0x4a, 0xc1, 0x9c, 0xfc, 0xf5,
+	0x48, 0xc1, 0xca, 0xff, 0x63, 0x1c, 0xba, 0x2c, 0xfd, 0xa3, 0x57, 0xc6, 0x28, 0x65, 0x69, 0xa7,
+	0x1c, 0x9e, 0xfa, 0x79, 0x91, 0x5c, 0x33, 0x75, 0x27, 0xa2, 0x93, 0x01, 0xa6, 0xa9, 0xff, 0x3c,
+	0x0f, 0x65, 0x3d, 0x88, 0xdd, 0xc3, 0x93, 0xab, 0x26, 0xeb, 0x07, 0x13, 0xc9, 0xfa, 0x41, 0x9a,
+	0xec, 0xab, 0x80, 0xce, 0x26, 0x9b, 0xbe, 0x51, 0x2c, 0x9e, 0xc9, 0xf5, 0xfc, 0x09, 0x6e, 0xfa,
+	0x82, 0x09, 0x6e, 0x1b, 0x4a, 0xd4, 0x63, 0x61, 0x4c, 0x42, 0x46, 0xa3, 0x60, 0x38, 0x1e, 0xbd,
+	0x94, 0xc9, 0x7d, 0x2c, 0x8b, 0x75, 0x99, 0x0b, 0x63, 0x21, 0x8b, 0x8b, 0xf4, 0xf4, 0xa3, 0xbe,
+	0x09, 0xc5, 0x0c, 0x0f, 0x2d, 0x00, 0x58, 0xbc, 0x55, 0xa8, 0x86, 0xaa, 0xf3, 0xde, 0x5a, 0x81,
+	0x5b, 0x96, 0x4a, 0x5a, 0x6a, 0xab, 0x8d, 0xdf, 0x25, 0xf2, 0x9e, 0xac, 0x35, 0xe5, 0xad, 0xa6,
+	0x2a, 0xe5, 0xea, 0x0d, 0x28, 0x25, 0x1e, 0xae, 0x51, 0x8a, 0x07, 0xff, 0x9a, 0x86, 0xc2, 0x88,
+	0x81, 0xca, 0x50, 0xb0, 0x74, 0x45, 0x7d, 0xa4, 0xe9, 0xaa, 0x22, 0xdd, 0x40, 0xb7, 0x41, 0x6a,
+	0x59, 0x4d, 0x53, 0x23, 0xb8, 0x6d, 0xe9, 0x0a, 0x91, 0x2d, 0x73, 0x47, 0xfa, 0xe7, 0x1c, 0x2a,
+	0xc1, 0x9c, 0x61, 0x35, 0x1a, 0xaa, 0x61, 0x48, 0x7f, 0xbb, 0x89, 0x96, 0xe0, 0x66, 0x53, 0x6b,
+	0x69, 0xa6, 0xaa, 0x90, 0x21, 0xf5, 0xef, 0x37, 0x51, 0x05, 0x50, 0xa3, 0xdd, 0x6a, 0xf1, 0x31,
+	0xc1, 0xd2, 0x0d, 0xab, 0xd3, 0xc6, 0xa6, 0xaa, 0x48, 0xbf, 0xab, 0xa0, 0x3b, 0xb0, 0x68, 0xe9,
+	0x3c, 0x03, 0x62, 0xb6, 0x89, 0xa2, 0x36, 0xb5, 0x3d, 0x15, 0x4b, 0xbf, 0xaf, 0x70, 0x5f, 0x58,
+	0x95, 0x9b, 0x2d, 0xa2, 0xb7, 0x4d, 0x92, 0x8e, 0x12, 0x1f, 0x55, 0x50, 0x19, 0xe6, 0xcd, 0x76,
+	0x9b, 0x6c, 0x59, 0xc6, 0xbb, 0xd2, 0x1f, 0x2a, 0x08, 0x41, 0xb9, 0xd9, 0x6e, 0x77, 0x88, 0xa2,
+	0x9a, 0x6a, 0x83, 0x5b, 0xfc, 0x63, 0x05, 0x55, 0xe1, 0x16, 0x56, 0x15, 0x0d, 0xab, 0x0d, 0x93,
+	0x68, 0xba, 0xa2, 0x35, 0x64, 0xde, 0x83, 0xa5, 0x8f, 0x2b, 0xe8, 0x1e, 0x54, 0xe4, 0x4e, 0xa7,
+	0x99, 0x52, 0x92, 0x40, 0xd2, 0x48, 0xfe, 0x24, 0x3c, 0x6a, 0xfa, 0x9e, 0xdc, 0xd4, 0x94, 0x1d,
+	0xa2, 0x60, 0xb2, 0xa5, 0x99, 0x86, 0xf4, 0xe7, 0x2c, 0x99, 0xc8, 0x7b, 0x9d, 0x84, 0xfc, 0x97,
+	0x0a, 0x5a, 0x84, 0x92, 0xa5, 0xef, 0xea, 0xed, 0x7d, 0x9d, 0x74, 0x54, 0x15, 0x4b, 0x7f, 0x4d,
+	0xcc, 0x5b, 0xe6, 0x8e, 0xaa, 0x9b, 0x43, 0x0f, 0x58, 0x7d, 0x3b, 0x09, 0xeb, 0x27, 0x2b, 0x5c,
+	0xa1, 0x6d, 0x99, 0xa4, 0xfd, 0x88, 0x18, 0x1d, 0xb9, 0xa1, 0x4a, 0x3f, 0x5d, 0xe1, 0xd1, 0xab,
+	0x4d, 0xb5, 0x21, 0x44, 0x9b, 0x6d, 0xc3, 0x94, 0x7e, 0xb6, 0x82, 0x96, 0xe1, 0x0e, 0x37, 0xd2,
+	0xc6, 0xda, 0x7b, 0x13, 0x36, 0x7e, 0x70, 0x5f, 0x38, 0x35, 0x54, 0x4c, 0x52, 0xcf, 0xd2, 0xf7,
+	0xee, 0xf3, 0x8d, 0x1d, 0xc6, 0x61, 0xa8, 0x86, 0xc1, 0x35, 0x34, 0x45, 0xfa, 0xfe, 0x7d, 0xf4,
+	0x02, 0x54, 0x87, 0x0c, 0xb5, 0x63, 0x90, 0xec, 0x3c, 0x22, 0xfd, 0xe2, 0x01, 0x2f, 0x13, 0x96,
+	0x4d, 0xb1, 0xbb, 0x72, 0xb3, 0xd9, 0xde, 0x57, 0x15, 0xe9, 0x97, 0x0f, 0xc4, 0xde, 0xb5, 0xe5,
+	0x96, 0xa6, 0x6f, 0x8f, 0x71, 0x7e, 0x78, 0x9f, 0xd7, 0x49, 0x7d, 0xc7, 0xd2, 0x3a, 0x2d, 0x55,
+	0x37, 0x47, 0xfe, 0x7f, 0x25, 0x34, 0x2c, 0x7d, 0x37, 0x71, 0x8f, 0xf7, 0x12, 0x45, 0x45, 0x95,
+	0x7e, 0xfd, 0x00, 0xbd, 0x04, 0x2b, 0x13, 0xdb, 0xa1, 0xc8, 0xa6, 0x4c, 0x2c, 0xfd, 0x14, 0xb4,
+	0xff, 0x58, 0xdd, 0xf8, 0x24, 0x0f, 0xf3, 0xc6, 0x26, 0xed, 0xf0, 0x37, 0x4c, 0x74, 0x04, 0x77,
+	0x2f, 0x7c, 0xf2, 0x41, 0xaf, 0x5c, 0xe5, 0x61, 0x28, 0x3d, 0x5d, 0xb5, 0x07, 0x57, 0x7f, 0x45,
+	0xaa, 0xdf, 0x40, 0x16, 0x2c, 0x8c, 0xb7, 0x14, 0xb4, 0x7a, 0x61, 0xb7, 0x19, 0x7a, 0x58, 0xb9,
+	0xa4, 0x1f, 0xd5, 0x6f, 0xa0, 0xb7, 0x60, 0x2e, 0x9d, 0x9a, 0xd0, 0xdd, 0x8c, 0xf4, 0xf8, 0xe0,
+	0x55, 0xab, 0x9e, 0x65, 0x8d, 0x2c, 0x7c, 0x0d, 0x66, 0x93, 0x33, 0x8d, 0xaa, 0x17, 0x5d, 0x24,
+	0xb5, 0xca, 0x19, 0xce, 0x50, 0x7d, 0xe3, 0xdf, 0x79, 0x58, 0x34, 0x36, 0xe9, 0x36, 0x8d, 0xd9,
+	0x53, 0x7a, 0x62, 0x24, 0x57, 0x16, 0xcf, 0x76, 0x7c, 0x90, 0x1c, 0xcb, 0xf6, 0xdc, 0xf1, 0xb9,
+	0xb6, 0x72, 0xa1, 0xc4, 0x28, 0xd6, 0xaf, 0xc2, 0x8c, 0x18, 0xda, 0x50, 0x36, 0xa0, 0xec, 0xbc,
+	0x57, 0xbb, 0x33, 0xc9, 0x18, 0xe9, 0x76, 0x61, 0xe9, 0xbc, 0x7e, 0x8a, 0x3e, 0x97, 0xd1, 0x78,
+	0xc6, 0x30, 0x57, 0x7b, 0xf9, 0x12, 0xb9, 0xac, 0xa3, 0xf3, 0xba, 0xd7, 0x98, 0xa3, 0x67, 0x34,
+	0xda, 0xda, 0xcb, 0x97, 0xc8, 0x0d, 0x1d, 0x6d, 0x2d, 0xbf, 0x77, 0x57, 0x48, 0x3e, 0xe4, 0x8f,
+	0xf5, 0xb6, 0x17, 0x0c, 0x9c, 0x87, 0xdd, 0x20, 0x7d, 0xb5, 0x3f, 0x98, 0x15, 0xbf, 0xaf, 0xff,
+	0x67, 0x00, 0xdb, 0x61, 0xe3, 0xb0, 0xca, 0x17, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1391,6 +1833,8 @@ type S6AProxyClient interface {
 	UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*UpdateLocationAnswer, error)
 	// Purge-UE (Code 321)
 	PurgeUE(ctx context.Context, in *PurgeUERequest, opts ...grpc.CallOption) (*PurgeUEAnswer, error)
+	// Notify (Code 323)
+	Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyAnswer, error)
 }
 
 type s6AProxyClient struct {
@@ -1428,6 +1872,15 @@ func (c *s6AProxyClient) PurgeUE(ctx context.Context, in *PurgeUERequest, opts .
 	return out, nil
 }
 
+func (c *s6AProxyClient) Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyAnswer, error) {
+	out := new(NotifyAnswer)
+	err := c.cc.Invoke(ctx, "/magma.feg.S6aProxy/Notify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // S6AProxyServer is the server API for S6AProxy service.
 type S6AProxyServer interface {
 	// Authentication-Information (Code 318)
@@ -1436,6 +1889,8 @@ type S6AProxyServer interface {
 	UpdateLocation(context.Context, *UpdateLocationRequest) (*UpdateLocationAnswer, error)
 	// Purge-UE (Code 321)
 	PurgeUE(context.Context, *PurgeUERequest) (*PurgeUEAnswer, error)
+	// Notify (Code 323)
+	Notify(context.Context, *NotifyRequest) (*NotifyAnswer, error)
 }
 
 // UnimplementedS6AProxyServer can be embedded to have forward compatible implementations.
@@ -1451,6 +1906,9 @@ func (*UnimplementedS6AProxyServer) UpdateLocation(ctx context.Context, req *Upd
 func (*UnimplementedS6AProxyServer) PurgeUE(ctx context.Context, req *PurgeUERequest) (*PurgeUEAnswer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PurgeUE not implemented")
 }
+func (*UnimplementedS6AProxyServer) Notify(ctx context.Context, req *NotifyRequest) (*NotifyAnswer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Notify not implemented")
+}
 
 func RegisterS6AProxyServer(s *grpc.Server, srv S6AProxyServer) {
 	s.RegisterService(&_S6AProxy_serviceDesc, srv)
@@ -1510,6 +1968,24 @@ func _S6AProxy_PurgeUE_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _S6AProxy_Notify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(S6AProxyServer).Notify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.S6aProxy/Notify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(S6AProxyServer).Notify(ctx, req.(*NotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _S6AProxy_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.feg.S6aProxy",
 	HandlerType: (*S6AProxyServer)(nil),
@@ -1526,6 +2002,10 @@ var _S6AProxy_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PurgeUE",
 			Handler:    _S6AProxy_PurgeUE_Handler,
 		},
+		{
+			MethodName: "Notify",
+			Handler:    _S6AProxy_Notify_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "feg/protos/s6a_proxy.proto",
@@ -1539,6 +2019,10 @@ type S6AGatewayServiceClient interface {
 	CancelLocation(ctx context.Context, in *CancelLocationRequest, opts ...grpc.CallOption) (*CancelLocationAnswer, error)
 	// Reset (Code 322)
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetAnswer, error)
+	// Insert-Subscriber-Data (Code 319)
+	InsertSubscriberData(ctx context.Context, in *InsertSubscriberDataRequest, opts ...grpc.CallOption) (*InsertSubscriberDataAnswer, error)
+	// Delete-Subscriber-Data (Code 320)
+	DeleteSubscriberData(ctx context.Context, in *DeleteSubscriberDataRequest, opts ...grpc.CallOption) (*DeleteSubscriberDataAnswer, error)
 }
 
 type s6AGatewayServiceClient struct {
@@ -1567,12 +2051,34 @@ func (c *s6AGatewayServiceClient) Reset(ctx context.Context, in *ResetRequest, o
 	return out, nil
 }
 
+func (c *s6AGatewayServiceClient) InsertSubscriberData(ctx context.Context, in *InsertSubscriberDataRequest, opts ...grpc.CallOption) (*InsertSubscriberDataAnswer, error) {
+	out := new(InsertSubscriberDataAnswer)
+	err := c.cc.Invoke(ctx, "/magma.feg.S6aGatewayService/InsertSubscriberData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *s6AGatewayServiceClient) DeleteSubscriberData(ctx context.Context, in *DeleteSubscriberDataRequest, opts ...grpc.CallOption) (*DeleteSubscriberDataAnswer, error) {
+	out := new(DeleteSubscriberDataAnswer)
+	err := c.cc.Invoke(ctx, "/magma.feg.S6aGatewayService/DeleteSubscriberData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // S6AGatewayServiceServer is the server API for S6AGatewayService service.
 type S6AGatewayServiceServer interface {
 	// Cancel-Location (Code 317)
 	CancelLocation(context.Context, *CancelLocationRequest) (*CancelLocationAnswer, error)
 	// Reset (Code 322)
 	Reset(context.Context, *ResetRequest) (*ResetAnswer, error)
+	// Insert-Subscriber-Data (Code 319)
+	InsertSubscriberData(context.Context, *InsertSubscriberDataRequest) (*InsertSubscriberDataAnswer, error)
+	// Delete-Subscriber-Data (Code 320)
+	DeleteSubscriberData(context.Context, *DeleteSubscriberDataRequest) (*DeleteSubscriberDataAnswer, error)
 }
 
 // UnimplementedS6AGatewayServiceServer can be embedded to have forward compatible implementations.
@@ -1585,6 +2091,12 @@ func (*UnimplementedS6AGatewayServiceServer) CancelLocation(ctx context.Context,
 func (*UnimplementedS6AGatewayServiceServer) Reset(ctx context.Context, req *ResetRequest) (*ResetAnswer, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
 }
+func (*UnimplementedS6AGatewayServiceServer) InsertSubscriberData(ctx context.Context, req *InsertSubscriberDataRequest) (*InsertSubscriberDataAnswer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertSubscriberData not implemented")
+}
+func (*UnimplementedS6AGatewayServiceServer) DeleteSubscriberData(ctx context.Context, req *DeleteSubscriberDataRequest) (*DeleteSubscriberDataAnswer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSubscriberData not implemented")
+}
 
 func RegisterS6AGatewayServiceServer(s *grpc.Server, srv S6AGatewayServiceServer) {
 	s.RegisterService(&_S6AGatewayService_serviceDesc, srv)
@@ -1626,6 +2138,42 @@ func _S6AGatewayService_Reset_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _S6AGatewayService_InsertSubscriberData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InsertSubscriberDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(S6AGatewayServiceServer).InsertSubscriberData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.S6aGatewayService/InsertSubscriberData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(S6AGatewayServiceServer).InsertSubscriberData(ctx, req.(*InsertSubscriberDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _S6AGatewayService_DeleteSubscriberData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSubscriberDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(S6AGatewayServiceServer).DeleteSubscriberData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.S6aGatewayService/DeleteSubscriberData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(S6AGatewayServiceServer).DeleteSubscriberData(ctx, req.(*DeleteSubscriberDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _S6AGatewayService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "magma.feg.S6aGatewayService",
 	HandlerType: (*S6AGatewayServiceServer)(nil),
@@ -1638,6 +2186,14 @@ var _S6AGatewayService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Reset",
 			Handler:    _S6AGatewayService_Reset_Handler,
 		},
+		{
+			MethodName: "InsertSubscriberData",
+			Handler:    _S6AGatewayService_InsertSubscriberData_Handler,
+		},
+		{
+			MethodName: "DeleteSubscriberData",
+			Handler:    _S6AGatewayService_DeleteSubscriberData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "feg/protos/s6a_proxy.proto",
@@ -0,0 +1,507 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: feg/protos/s6b_proxy.proto
+
+package protos
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protos "magma/lte/cloud/go/protos"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type S6BPGWIdentity struct {
+	// Diameter identity of the PGW (MIP-Home-Agent-Host)
+	Host  string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Realm string `protobuf:"bytes,2,opt,name=realm,proto3" json:"realm,omitempty"`
+	// PGW IP address (MIP-Home-Agent-Address)
+	IpAddress            string   `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *S6BPGWIdentity) Reset()         { *m = S6BPGWIdentity{} }
+func (m *S6BPGWIdentity) String() string { return proto.CompactTextString(m) }
+func (*S6BPGWIdentity) ProtoMessage()    {}
+func (*S6BPGWIdentity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_991aa782918903dc, []int{0}
+}
+
+func (m *S6BPGWIdentity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_S6BPGWIdentity.Unmarshal(m, b)
+}
+func (m *S6BPGWIdentity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_S6BPGWIdentity.Marshal(b, m, deterministic)
+}
+func (m *S6BPGWIdentity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S6BPGWIdentity.Merge(m, src)
+}
+func (m *S6BPGWIdentity) XXX_Size() int {
+	return xxx_messageInfo_S6BPGWIdentity.Size(m)
+}
+func (m *S6BPGWIdentity) XXX_DiscardUnknown() {
+	xxx_messageInfo_S6BPGWIdentity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S6BPGWIdentity proto.InternalMessageInfo
+
+func (m *S6BPGWIdentity) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *S6BPGWIdentity) GetRealm() string {
+	if m != nil {
+		return m.Realm
+	}
+	return ""
+}
+
+func (m *S6BPGWIdentity) GetIpAddress() string {
+	if m != nil {
+		return m.IpAddress
+	}
+	return ""
+}
+
+type S6BAuthorizationRequest struct {
+	// Session ID of the S6b session, a new one is generated if empty
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Subscriber's IMSI
+	UserName string `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// APN of the PDN connection (Service-Selection)
+	Apn string          `protobuf:"bytes,3,opt,name=apn,proto3" json:"apn,omitempty"`
+	Pgw *S6BPGWIdentity `protobuf:"bytes,4,opt,name=pgw,proto3" json:"pgw,omitempty"`
+	// Context-Identifier of the APN configuration, updates the PGW identity
+	// of a specific APN configuration if non zero
+	ContextId        uint32 `protobuf:"varint,5,opt,name=context_id,json=contextId,proto3" json:"context_id,omitempty"`
+	VisitedNetworkId string `protobuf:"bytes,6,opt,name=visited_network_id,json=visitedNetworkId,proto3" json:"visited_network_id,omitempty"`
+	// RAT-Type (3GPP TS 29.212 5.3.31), WLAN if not set
+	RatType              uint32   `protobuf:"varint,7,opt,name=rat_type,json=ratType,proto3" json:"rat_type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *S6BAuthorizationRequest) Reset()         { *m = S6BAuthorizationRequest{} }
+func (m *S6BAuthorizationRequest) String() string { return proto.CompactTextString(m) }
+func (*S6BAuthorizationRequest) ProtoMessage()    {}
+func (*S6BAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_991aa782918903dc, []int{1}
+}
+
+func (m *S6BAuthorizationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_S6BAuthorizationRequest.Unmarshal(m, b)
+}
+func (m *S6BAuthorizationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_S6BAuthorizationRequest.Marshal(b, m, deterministic)
+}
+func (m *S6BAuthorizationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S6BAuthorizationRequest.Merge(m, src)
+}
+func (m *S6BAuthorizationRequest) XXX_Size() int {
+	return xxx_messageInfo_S6BAuthorizationRequest.Size(m)
+}
+func (m *S6BAuthorizationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_S6BAuthorizationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S6BAuthorizationRequest proto.InternalMessageInfo
+
+func (m *S6BAuthorizationRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *S6BAuthorizationRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *S6BAuthorizationRequest) GetApn() string {
+	if m != nil {
+		return m.Apn
+	}
+	return ""
+}
+
+func (m *S6BAuthorizationRequest) GetPgw() *S6BPGWIdentity {
+	if m != nil {
+		return m.Pgw
+	}
+	return nil
+}
+
+func (m *S6BAuthorizationRequest) GetContextId() uint32 {
+	if m != nil {
+		return m.ContextId
+	}
+	return 0
+}
+
+func (m *S6BAuthorizationRequest) GetVisitedNetworkId() string {
+	if m != nil {
+		return m.VisitedNetworkId
+	}
+	return ""
+}
+
+func (m *S6BAuthorizationRequest) GetRatType() uint32 {
+	if m != nil {
+		return m.RatType
+	}
+	return 0
+}
+
+type S6BAuthorizationAnswer struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserName  string `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// Session-Timeout in seconds, 0 if the session has no timeout
+	SessionTimeout uint32 `protobuf:"varint,3,opt,name=session_timeout,json=sessionTimeout,proto3" json:"session_timeout,omitempty"`
+	// APN configuration authorized for the PDN connection
+	ApnConfig            *protos.APNConfiguration `protobuf:"bytes,4,opt,name=apn_config,json=apnConfig,proto3" json:"apn_config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *S6BAuthorizationAnswer) Reset()         { *m = S6BAuthorizationAnswer{} }
+func (m *S6BAuthorizationAnswer) String() string { return proto.CompactTextString(m) }
+func (*S6BAuthorizationAnswer) ProtoMessage()    {}
+func (*S6BAuthorizationAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_991aa782918903dc, []int{2}
+}
+
+func (m *S6BAuthorizationAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_S6BAuthorizationAnswer.Unmarshal(m, b)
+}
+func (m *S6BAuthorizationAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_S6BAuthorizationAnswer.Marshal(b, m, deterministic)
+}
+func (m *S6BAuthorizationAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S6BAuthorizationAnswer.Merge(m, src)
+}
+func (m *S6BAuthorizationAnswer) XXX_Size() int {
+	return xxx_messageInfo_S6BAuthorizationAnswer.Size(m)
+}
+func (m *S6BAuthorizationAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_S6BAuthorizationAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S6BAuthorizationAnswer proto.InternalMessageInfo
+
+func (m *S6BAuthorizationAnswer) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *S6BAuthorizationAnswer) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *S6BAuthorizationAnswer) GetSessionTimeout() uint32 {
+	if m != nil {
+		return m.SessionTimeout
+	}
+	return 0
+}
+
+func (m *S6BAuthorizationAnswer) GetApnConfig() *protos.APNConfiguration {
+	if m != nil {
+		return m.ApnConfig
+	}
+	return nil
+}
+
+type S6BSessionTerminationRequest struct {
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Subscriber's IMSI
+	UserName string `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	// Termination-Cause (RFC 6733 8.15), DIAMETER_LOGOUT if not set
+	TerminationCause     uint32   `protobuf:"varint,3,opt,name=termination_cause,json=terminationCause,proto3" json:"termination_cause,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *S6BSessionTerminationRequest) Reset()         { *m = S6BSessionTerminationRequest{} }
+func (m *S6BSessionTerminationRequest) String() string { return proto.CompactTextString(m) }
+func (*S6BSessionTerminationRequest) ProtoMessage()    {}
+func (*S6BSessionTerminationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_991aa782918903dc, []int{3}
+}
+
+func (m *S6BSessionTerminationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_S6BSessionTerminationRequest.Unmarshal(m, b)
+}
+func (m *S6BSessionTerminationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_S6BSessionTerminationRequest.Marshal(b, m, deterministic)
+}
+func (m *S6BSessionTerminationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S6BSessionTerminationRequest.Merge(m, src)
+}
+func (m *S6BSessionTerminationRequest) XXX_Size() int {
+	return xxx_messageInfo_S6BSessionTerminationRequest.Size(m)
+}
+func (m *S6BSessionTerminationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_S6BSessionTerminationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S6BSessionTerminationRequest proto.InternalMessageInfo
+
+func (m *S6BSessionTerminationRequest) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *S6BSessionTerminationRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *S6BSessionTerminationRequest) GetTerminationCause() uint32 {
+	if m != nil {
+		return m.TerminationCause
+	}
+	return 0
+}
+
+type S6BSessionTerminationAnswer struct {
+	SessionId            string   `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *S6BSessionTerminationAnswer) Reset()         { *m = S6BSessionTerminationAnswer{} }
+func (m *S6BSessionTerminationAnswer) String() string { return proto.CompactTextString(m) }
+func (*S6BSessionTerminationAnswer) ProtoMessage()    {}
+func (*S6BSessionTerminationAnswer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_991aa782918903dc, []int{4}
+}
+
+func (m *S6BSessionTerminationAnswer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_S6BSessionTerminationAnswer.Unmarshal(m, b)
+}
+func (m *S6BSessionTerminationAnswer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_S6BSessionTerminationAnswer.Marshal(b, m, deterministic)
+}
+func (m *S6BSessionTerminationAnswer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_S6BSessionTerminationAnswer.Merge(m, src)
+}
+func (m *S6BSessionTerminationAnswer) XXX_Size() int {
+	return xxx_messageInfo_S6BSessionTerminationAnswer.Size(m)
+}
+func (m *S6BSessionTerminationAnswer) XXX_DiscardUnknown() {
+	xxx_messageInfo_S6BSessionTerminationAnswer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_S6BSessionTerminationAnswer proto.InternalMessageInfo
+
+func (m *S6BSessionTerminationAnswer) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*S6BPGWIdentity)(nil), "magma.feg.S6bPGWIdentity")
+	proto.RegisterType((*S6BAuthorizationRequest)(nil), "magma.feg.S6bAuthorizationRequest")
+	proto.RegisterType((*S6BAuthorizationAnswer)(nil), "magma.feg.S6bAuthorizationAnswer")
+	proto.RegisterType((*S6BSessionTerminationRequest)(nil), "magma.feg.S6bSessionTerminationRequest")
+	proto.RegisterType((*S6BSessionTerminationAnswer)(nil), "magma.feg.S6bSessionTerminationAnswer")
+}
+
+func init() { proto.RegisterFile("feg/protos/s6b_proxy.proto", fileDescriptor_991aa782918903dc) }
+
+var fileDescriptor_991aa782918903dc = []byte{
+	// 497 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcb, 0x6e, 0x13, 0x31,
+	0x14, 0x65, 0xe8, 0x2b, 0x73, 0x51, 0x4b, 0xb0, 0x10, 0xe4, 0x41, 0xa5, 0x30, 0x0b, 0x1a, 0xa9,
+	0x28, 0x91, 0x8a, 0x94, 0x05, 0x62, 0x13, 0xba, 0x40, 0xd9, 0x44, 0x55, 0x12, 0x09, 0xc1, 0x66,
+	0xe4, 0x89, 0x6f, 0xa6, 0x16, 0x19, 0xdb, 0xd8, 0x1e, 0xd2, 0xf0, 0x03, 0x7c, 0x13, 0x5f, 0xc0,
+	0x4f, 0xb1, 0x40, 0xf6, 0x38, 0x85, 0xa0, 0xf2, 0x90, 0x60, 0x35, 0x9e, 0x73, 0xcf, 0xbd, 0xe7,
+	0x3e, 0xa1, 0xb5, 0xc0, 0xbc, 0xaf, 0xb4, 0xb4, 0xd2, 0xf4, 0xcd, 0x20, 0x4b, 0x95, 0x96, 0x57,
+	0xeb, 0x9e, 0x07, 0x48, 0x5c, 0xd0, 0xbc, 0xa0, 0xbd, 0x05, 0xe6, 0xad, 0xe3, 0xa5, 0xc5, 0x6b,
+	0x5a, 0x99, 0x99, 0xb9, 0xe6, 0x19, 0x6a, 0x96, 0x55, 0xcc, 0xe4, 0x0d, 0x1c, 0x4d, 0x07, 0xd9,
+	0xc5, 0xab, 0xd7, 0x23, 0x86, 0xc2, 0x72, 0xbb, 0x26, 0x04, 0x76, 0x2f, 0xa5, 0xb1, 0x8d, 0xa8,
+	0x13, 0x75, 0xe3, 0x89, 0x7f, 0x93, 0xfb, 0xb0, 0xa7, 0x91, 0x2e, 0x8b, 0xc6, 0x6d, 0x0f, 0x56,
+	0x3f, 0xe4, 0x18, 0x80, 0xab, 0x94, 0x32, 0xa6, 0xd1, 0x98, 0xc6, 0x8e, 0x37, 0xc5, 0x5c, 0x0d,
+	0x2b, 0x20, 0xf9, 0x1a, 0xc1, 0xc3, 0xe9, 0x20, 0x1b, 0x96, 0xf6, 0x52, 0x6a, 0xfe, 0x91, 0x5a,
+	0x2e, 0xc5, 0x04, 0xdf, 0x97, 0x68, 0xac, 0x73, 0x35, 0x68, 0x0c, 0x97, 0x22, 0xe5, 0x2c, 0x48,
+	0xc5, 0x01, 0x19, 0x31, 0xd2, 0x86, 0xb8, 0x34, 0xa8, 0x53, 0x41, 0x0b, 0x0c, 0x9a, 0x35, 0x07,
+	0x8c, 0x69, 0x81, 0xa4, 0x0e, 0x3b, 0x54, 0x89, 0xa0, 0xe7, 0x9e, 0xe4, 0x14, 0x76, 0x54, 0xbe,
+	0x6a, 0xec, 0x76, 0xa2, 0xee, 0x9d, 0xb3, 0x66, 0xef, 0xba, 0xf8, 0xde, 0x76, 0x69, 0x13, 0xc7,
+	0x72, 0xd2, 0x73, 0x29, 0x2c, 0x5e, 0x59, 0x27, 0xbd, 0xd7, 0x89, 0xba, 0x87, 0x93, 0x38, 0x20,
+	0x23, 0x46, 0x9e, 0x02, 0xf9, 0xc0, 0x0d, 0xb7, 0xc8, 0x52, 0x81, 0x76, 0x25, 0xf5, 0x3b, 0x47,
+	0xdb, 0xf7, 0x62, 0xf5, 0x60, 0x19, 0x57, 0x86, 0x11, 0x23, 0x4d, 0xa8, 0x69, 0x6a, 0x53, 0xbb,
+	0x56, 0xd8, 0x38, 0xf0, 0xa1, 0x0e, 0x34, 0xb5, 0xb3, 0xb5, 0xc2, 0xe4, 0x73, 0x04, 0x0f, 0x7e,
+	0x2e, 0x7f, 0x28, 0xcc, 0x0a, 0xf5, 0x3f, 0x55, 0x7f, 0x02, 0x77, 0x37, 0xbe, 0x96, 0x17, 0x28,
+	0x4b, 0xeb, 0x3b, 0x71, 0x38, 0x39, 0x0a, 0xf0, 0xac, 0x42, 0xc9, 0x73, 0x00, 0xaa, 0x44, 0x3a,
+	0x97, 0x62, 0xc1, 0xf3, 0xd0, 0x9b, 0x76, 0xe8, 0xcd, 0xd2, 0x62, 0x6f, 0x78, 0x31, 0x3e, 0xf7,
+	0xb6, 0x52, 0x57, 0xa3, 0x89, 0xa9, 0x12, 0x15, 0x92, 0x7c, 0x8a, 0xe0, 0xd1, 0x74, 0x90, 0x4d,
+	0x43, 0x44, 0xd4, 0x05, 0x17, 0xff, 0x6d, 0x7e, 0xa7, 0x70, 0xcf, 0x7e, 0x8f, 0x98, 0xce, 0x69,
+	0x69, 0x30, 0xd4, 0x50, 0xff, 0xc1, 0x70, 0xee, 0xf0, 0xe4, 0x05, 0xb4, 0x6f, 0x4c, 0xe4, 0xaf,
+	0x3a, 0x79, 0xf6, 0x25, 0x82, 0x9a, 0xdb, 0x01, 0x77, 0x1a, 0x64, 0x06, 0xf1, 0x66, 0x18, 0x48,
+	0x92, 0xed, 0x2d, 0xb9, 0x69, 0x49, 0x5b, 0x8f, 0x7f, 0xc3, 0xa9, 0xf4, 0x93, 0x5b, 0x04, 0xa1,
+	0xbe, 0x49, 0x0b, 0x43, 0x9a, 0xe4, 0x64, 0xdb, 0xf1, 0x97, 0x6d, 0x6c, 0x3d, 0xf9, 0x13, 0x71,
+	0x23, 0xf3, 0xb2, 0xfd, 0xb6, 0xe9, 0xa9, 0x7d, 0x77, 0xf5, 0xf3, 0xa5, 0x2c, 0x59, 0x3f, 0x97,
+	0xe1, 0xae, 0xb3, 0x7d, 0xff, 0x7d, 0xf6, 0x6d, 0x00, 0x15, 0x67, 0xf5, 0x83, 0x13, 0x04, 0x00,
+	0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// S6BProxyClient is the client API for S6BProxy service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type S6BProxyClient interface {
+	// Authorize the PGW session of a user & update the PGW identity in the
+	// 3GPP AAA server using AAR/AAA, see 3GPP TS 29.273 Section 9.2.2.2
+	Authorize(ctx context.Context, in *S6BAuthorizationRequest, opts ...grpc.CallOption) (*S6BAuthorizationAnswer, error)
+	// TerminateSession notifies the 3GPP AAA server of the end of the PGW
+	// session using STR/STA, see 3GPP TS 29.273 Section 9.2.2.3
+	TerminateSession(ctx context.Context, in *S6BSessionTerminationRequest, opts ...grpc.CallOption) (*S6BSessionTerminationAnswer, error)
+}
+
+type s6BProxyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewS6BProxyClient(cc grpc.ClientConnInterface) S6BProxyClient {
+	return &s6BProxyClient{cc}
+}
+
+func (c *s6BProxyClient) Authorize(ctx context.Context, in *S6BAuthorizationRequest, opts ...grpc.CallOption) (*S6BAuthorizationAnswer, error) {
+	out := new(S6BAuthorizationAnswer)
+	err := c.cc.Invoke(ctx, "/magma.feg.S6bProxy/Authorize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *s6BProxyClient) TerminateSession(ctx context.Context, in *S6BSessionTerminationRequest, opts ...grpc.CallOption) (*S6BSessionTerminationAnswer, error) {
+	out := new(S6BSessionTerminationAnswer)
+	err := c.cc.Invoke(ctx, "/magma.feg.S6bProxy/TerminateSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// S6BProxyServer is the server API for S6BProxy service.
+type S6BProxyServer interface {
+	// Authorize the PGW session of a user & update the PGW identity in the
+	// 3GPP AAA server using AAR/AAA, see 3GPP TS 29.273 Section 9.2.2.2
+	Authorize(context.Context, *S6BAuthorizationRequest) (*S6BAuthorizationAnswer, error)
+	// TerminateSession notifies the 3GPP AAA server of the end of the PGW
+	// session using STR/STA, see 3GPP TS 29.273 Section 9.2.2.3
+	TerminateSession(context.Context, *S6BSessionTerminationRequest) (*S6BSessionTerminationAnswer, error)
+}
+
+// UnimplementedS6BProxyServer can be embedded to have forward compatible implementations.
+type UnimplementedS6BProxyServer struct {
+}
+
+func (*UnimplementedS6BProxyServer) Authorize(ctx context.Context, req *S6BAuthorizationRequest) (*S6BAuthorizationAnswer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
+}
+func (*UnimplementedS6BProxyServer) TerminateSession(ctx context.Context, req *S6BSessionTerminationRequest) (*S6BSessionTerminationAnswer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TerminateSession not implemented")
+}
+
+func RegisterS6BProxyServer(s *grpc.Server, srv S6BProxyServer) {
+	s.RegisterService(&_S6BProxy_serviceDesc, srv)
+}
+
+func _S6BProxy_Authorize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(S6BAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(S6BProxyServer).Authorize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.S6bProxy/Authorize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(S6BProxyServer).Authorize(ctx, req.(*S6BAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _S6BProxy_TerminateSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(S6BSessionTerminationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(S6BProxyServer).TerminateSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.feg.S6bProxy/TerminateSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(S6BProxyServer).TerminateSession(ctx, req.(*S6BSessionTerminationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _S6BProxy_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "magma.feg.S6bProxy",
+	HandlerType: (*S6BProxyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Authorize",
+			Handler:    _S6BProxy_Authorize_Handler,
+		},
+		{
+			MethodName: "TerminateSession",
+			Handler:    _S6BProxy_TerminateSession_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "feg/protos/s6b_proxy.proto",
+}
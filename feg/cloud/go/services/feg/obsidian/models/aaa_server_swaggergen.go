@@ -6,10 +6,13 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"encoding/json"
+
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // AaaServer aaa server configuration
@@ -30,6 +33,10 @@ type AaaServer struct {
 
 	// radius config
 	RadiusConfig *RadiusConfig `json:"radius_config,omitempty"`
+
+	// session store
+	// Enum: [memory redis]
+	SessionStore string `json:"session_store,omitempty"`
 }
 
 // Validate validates this aaa server
@@ -40,6 +47,10 @@ func (m *AaaServer) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSessionStore(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -64,6 +75,49 @@ func (m *AaaServer) validateRadiusConfig(formats strfmt.Registry) error {
 	return nil
 }
 
+var aaaServerTypeSessionStorePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["memory","redis"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		aaaServerTypeSessionStorePropEnum = append(aaaServerTypeSessionStorePropEnum, v)
+	}
+}
+
+const (
+
+	// AaaServerSessionStoreMemory captures enum value "memory"
+	AaaServerSessionStoreMemory string = "memory"
+
+	// AaaServerSessionStoreRedis captures enum value "redis"
+	AaaServerSessionStoreRedis string = "redis"
+)
+
+// prop value enum
+func (m *AaaServer) validateSessionStoreEnum(path, location string, value string) error {
+	if err := validate.Enum(path, location, value, aaaServerTypeSessionStorePropEnum); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *AaaServer) validateSessionStore(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SessionStore) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateSessionStoreEnum("session_store", "body", m.SessionStore); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *AaaServer) MarshalBinary() ([]byte, error) {
 	if m == nil {
@@ -315,6 +315,17 @@ func ToVirtualApnRuleMconfig(rules []*VirtualApnRule) []*mconfig.VirtualApnRule
 	return virtualApnRuleConfigs
 }
 
+// ToVLRPoolMconfig converts the VLR pool of the CSFB config, nil if it's empty
+func ToVLRPoolMconfig(vlrs []*VlrConfig) []*mconfig.VLRConfig {
+	var vlrConfigs []*mconfig.VLRConfig
+	for _, vlr := range vlrs {
+		vlrConf := &mconfig.VLRConfig{}
+		protos.FillIn(vlr, vlrConf)
+		vlrConfigs = append(vlrConfigs, vlrConf)
+	}
+	return vlrConfigs
+}
+
 func ToFederatedModesMap(modesMap *FederatedModeMap) *lte_mconfig.FederatedModeMap {
 	if modesMap == nil {
 		return &lte_mconfig.FederatedModeMap{}
@@ -6,10 +6,13 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // Csfb csfb configuration
@@ -18,6 +21,14 @@ type Csfb struct {
 
 	// client
 	Client *SctpClientConfigs `json:"client,omitempty"`
+
+	// Length in bits of the NRI of the TMSIs allocated by the VLRs, 0 disables NRI based routing
+	// Maximum: 10
+	// Minimum: 0
+	NriBitLength uint32 `json:"nri_bit_length,omitempty"`
+
+	// VLRs of the MSC pool, client is used as a single VLR if it's empty
+	VlrPool []*VlrConfig `json:"vlr_pool"`
 }
 
 // Validate validates this csfb
@@ -28,6 +39,14 @@ func (m *Csfb) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateNriBitLength(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVlrPool(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -52,6 +71,48 @@ func (m *Csfb) validateClient(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Csfb) validateNriBitLength(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.NriBitLength) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("nri_bit_length", "body", int64(m.NriBitLength), 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("nri_bit_length", "body", int64(m.NriBitLength), 10, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *Csfb) validateVlrPool(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.VlrPool) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.VlrPool); i++ {
+		if swag.IsZero(m.VlrPool[i]) { // not required
+			continue
+		}
+
+		if m.VlrPool[i] != nil {
+			if err := m.VlrPool[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("vlr_pool" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *Csfb) MarshalBinary() ([]byte, error) {
 	if m == nil {
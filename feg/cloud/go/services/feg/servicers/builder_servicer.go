@@ -171,6 +171,7 @@ func (s *builderServicer) Build(ctx context.Context, request *builder_protos.Bui
 	if csfb != nil {
 		mc := &feg_mconfig.CsfbConfig{LogLevel: protos.LogLevel_INFO}
 		protos.FillIn(csfb, mc)
+		mc.VlrPool = models.ToVLRPoolMconfig(csfb.VlrPool)
 		vals["csfb"] = mc
 	}
 
@@ -276,6 +276,70 @@ func TestBuilder_Build(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestBuilder_BuildCsfbVLRPool(t *testing.T) {
+	feg_test_init.StartTestService(t)
+
+	gwConfig := &models.GatewayFederationConfigs{
+		Csfb: &models.Csfb{
+			Client:       &models.SctpClientConfigs{ServerAddress: "10.0.0.1:29118"},
+			NriBitLength: 2,
+			VlrPool: []*models.VlrConfig{
+				{
+					Name:          "vlr1.example.com",
+					Client:        &models.SctpClientConfigs{ServerAddress: "10.0.0.2:29118", LocalAddress: ":56789"},
+					NriValues:     []uint32{1, 2},
+					LocationAreas: []string{"00f1100001"},
+					Weight:        2,
+				},
+				{
+					Name:   "vlr2.example.com",
+					Client: &models.SctpClientConfigs{ServerAddress: "10.0.0.3:29118"},
+				},
+			},
+		},
+	}
+	nw := configurator.Network{ID: "n1"}
+	gw := configurator.NetworkEntity{
+		Type:         orc8r.MagmadGatewayType,
+		Key:          "gw1",
+		Associations: []storage.TypeAndKey{{Type: feg.FegGatewayType, Key: "gw1"}},
+	}
+	fegw := configurator.NetworkEntity{
+		Type:               feg.FegGatewayType,
+		Key:                "gw1",
+		Config:             gwConfig,
+		ParentAssociations: []storage.TypeAndKey{{Type: orc8r.MagmadGatewayType, Key: "gw1"}},
+	}
+	graph := configurator.EntityGraph{
+		Entities: []configurator.NetworkEntity{gw, fegw},
+		Edges: []configurator.GraphEdge{
+			{From: storage.TypeAndKey{Type: orc8r.MagmadGatewayType, Key: "gw1"}, To: storage.TypeAndKey{Type: feg.FegGatewayType, Key: "gw1"}},
+		},
+	}
+
+	expected := &feg_mconfig.CsfbConfig{
+		LogLevel:     1,
+		Client:       &feg_mconfig.SCTPClientConfig{ServerAddress: "10.0.0.1:29118"},
+		NriBitLength: 2,
+		VlrPool: []*feg_mconfig.VLRConfig{
+			{
+				Name:          "vlr1.example.com",
+				Client:        &feg_mconfig.SCTPClientConfig{ServerAddress: "10.0.0.2:29118", LocalAddress: ":56789"},
+				NriValues:     []uint32{1, 2},
+				LocationAreas: []string{"00f1100001"},
+				Weight:        2,
+			},
+			{
+				Name:   "vlr2.example.com",
+				Client: &feg_mconfig.SCTPClientConfig{ServerAddress: "10.0.0.3:29118"},
+			},
+		},
+	}
+	actual, err := build(&nw, &graph, "gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual["csfb"])
+}
+
 func build(network *configurator.Network, graph *configurator.EntityGraph, gatewayID string) (map[string]proto.Message, error) {
 	networkProto, err := network.ToProto(serdes.Network)
 	if err != nil {
@@ -19,6 +19,8 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fiorix/go-diameter/v4/diam"
@@ -41,6 +43,24 @@ type KeyAndAnswer struct{ Answer, Key interface{} }
 // Output: struct containing the related request key and parsed answer
 type AnswerHandler func(message *diam.Message) KeyAndAnswer
 
+// MessageRecorder records the application messages sent & received by a Client,
+// for example to capture them for debugging
+type MessageRecorder interface {
+	RecordMessage(message *diam.Message, outgoing bool)
+}
+
+// recorderHolder wraps MessageRecorder to store it in atomic.Value
+type recorderHolder struct{ MessageRecorder }
+
+// RecordQueueSize is the number of messages waiting to be recorded above which
+// the client drops the messages to record instead of blocking
+const RecordQueueSize = 1024
+
+type recordedMessage struct {
+	message  *diam.Message
+	outgoing bool
+}
+
 // Client is a wrapper around a sm.Client that handles connection management,
 // request tracking, and configuration. Using this, the application should not
 // know anything about the underlying diameter connection
@@ -51,6 +71,10 @@ type Client struct {
 	requestTracker *RequestTracker
 	cfg            *DiameterClientConfig
 	originStateID  uint32
+	recorder       atomic.Value
+	recordQueue    chan recordedMessage
+	startRecording sync.Once
+	droppedRecords uint64
 }
 
 // OriginRealm returns client's config Realm
@@ -213,6 +237,8 @@ func (client *Client) SendRequest(
 		err = conn.SendRequestToServer(m, client.cfg.RetryCount, server)
 		if err != nil {
 			client.requestTracker.DeregisterRequest(key)
+		} else {
+			client.RecordMessage(m, true)
 		}
 	}
 	return err
@@ -235,6 +261,48 @@ func (client *Client) AddOriginAVPsToMessage(message *diam.Message) *diam.Messag
 	return message
 }
 
+// SetMessageRecorder sets the recorder of the application messages sent & received by the client.
+// Messages are recorded asynchronously so that a slow recorder never delays the Diameter exchanges
+func (client *Client) SetMessageRecorder(recorder MessageRecorder) {
+	client.startRecording.Do(func() {
+		client.recordQueue = make(chan recordedMessage, RecordQueueSize)
+		go client.recordMessages(client.recordQueue)
+	})
+	client.recorder.Store(recorderHolder{recorder})
+}
+
+// RecordMessage queues the message for the client's recorder if one is set. Messages
+// sent & received by the client are recorded automatically, except for the answers
+// written by request handlers, which must record them. RecordMessage never blocks:
+// the message is dropped if RecordQueueSize messages are already waiting to be recorded
+func (client *Client) RecordMessage(message *diam.Message, outgoing bool) {
+	if holder, ok := client.recorder.Load().(recorderHolder); !ok || holder.MessageRecorder == nil {
+		return
+	}
+	select {
+	case client.recordQueue <- recordedMessage{message: message, outgoing: outgoing}:
+	default:
+		dropped := atomic.AddUint64(&client.droppedRecords, 1)
+		if dropped == 1 || dropped%RecordQueueSize == 0 {
+			glog.Warningf("Message recorder is too slow, %d messages were not recorded", dropped)
+		}
+	}
+}
+
+// DroppedRecords returns the number of messages which were not recorded because the recorder was too slow
+func (client *Client) DroppedRecords() uint64 {
+	return atomic.LoadUint64(&client.droppedRecords)
+}
+
+// recordMessages passes the queued messages to the recorder set when they are dequeued
+func (client *Client) recordMessages(queue <-chan recordedMessage) {
+	for m := range queue {
+		if holder, ok := client.recorder.Load().(recorderHolder); ok && holder.MessageRecorder != nil {
+			holder.RecordMessage(m.message, m.outgoing)
+		}
+	}
+}
+
 // IgnoreAnswer untracks a request if the application, say, times out
 // Input: key identifying request
 func (client *Client) IgnoreAnswer(key interface{}) {
@@ -250,6 +318,7 @@ func (client *Client) IgnoreAnswer(key interface{}) {
 func (client *Client) RegisterAnswerHandlerForAppID(command uint32, appID uint32, handler AnswerHandler) {
 	index := diam.CommandIndex{AppID: appID, Code: command, Request: false}
 	muxHandler := diam.HandlerFunc(func(c diam.Conn, m *diam.Message) {
+		client.RecordMessage(m, false)
 		answerKey := handler(m)
 		if answerKey.Key == nil {
 			return
@@ -278,12 +347,20 @@ func (client *Client) RegisterAnswerHandler(command uint32, handler AnswerHandle
 // Input: command - the diameter code for the command (like diam.CreditControl)
 //				handler - the function to call when a message is received
 func (client *Client) RegisterRequestHandlerForAppID(command uint32, appID uint32, handler diam.HandlerFunc) {
-	client.mux.HandleIdx(diam.CommandIndex{AppID: appID, Code: command, Request: true}, handler)
+	client.mux.HandleIdx(diam.CommandIndex{AppID: appID, Code: command, Request: true}, client.recordingHandler(handler))
 }
 
 // RegisterHandler registers diameter handler to be used for given command and app
 func (client *Client) RegisterHandler(command uint32, appID uint32, request bool, handler diam.Handler) {
-	client.mux.HandleIdx(diam.CommandIndex{AppID: appID, Code: command, Request: request}, handler)
+	client.mux.HandleIdx(diam.CommandIndex{AppID: appID, Code: command, Request: request}, client.recordingHandler(handler))
+}
+
+// recordingHandler records the received messages before passing them to the handler
+func (client *Client) recordingHandler(handler diam.Handler) diam.Handler {
+	return diam.HandlerFunc(func(c diam.Conn, m *diam.Message) {
+		client.RecordMessage(m, false)
+		handler.ServeDIAM(c, m)
+	})
 }
 
 // GenSessionIDOpt generates rfc6733 compliant session ID:
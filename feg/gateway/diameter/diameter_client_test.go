@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diameter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/stretchr/testify/assert"
+)
+
+type blockingRecorder struct {
+	release  chan struct{}
+	recorded chan *diam.Message
+}
+
+func (r *blockingRecorder) RecordMessage(message *diam.Message, outgoing bool) {
+	<-r.release
+	r.recorded <- message
+}
+
+func TestClient_RecordMessageDropsOnOverflow(t *testing.T) {
+	client := NewClient(&DiameterClientConfig{Host: "test.test.com", Realm: "test.com", ProductName: "test"})
+	message := diam.NewRequest(diam.CreditControl, diam.GX_CHARGING_CONTROL_APP_ID, dict.Default)
+
+	// Nothing is queued without a recorder
+	client.RecordMessage(message, true)
+	assert.Equal(t, uint64(0), client.DroppedRecords())
+
+	recorder := &blockingRecorder{release: make(chan struct{}), recorded: make(chan *diam.Message, 2*RecordQueueSize)}
+	client.SetMessageRecorder(recorder)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < RecordQueueSize+10; i++ {
+			client.RecordMessage(message, true)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RecordMessage blocked on a slow recorder")
+	}
+	// The recorder may have dequeued one message before blocking
+	dropped := client.DroppedRecords()
+	assert.True(t, dropped == 9 || dropped == 10, "dropped %d messages", dropped)
+
+	close(recorder.release)
+	for i := uint64(0); i < RecordQueueSize+10-dropped; i++ {
+		select {
+		case m := <-recorder.recorded:
+			assert.Equal(t, message, m)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Only %d messages were recorded", i)
+		}
+	}
+}
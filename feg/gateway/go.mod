@@ -22,6 +22,7 @@ replace (
 )
 
 require (
+	github.com/Masterminds/squirrel v1.1.1-0.20190513200039-d13326f0be73
 	github.com/emakeev/milenage v1.0.0
 	github.com/emakeev/snowflake v0.0.0-20200206205012-767080b052fe
 	github.com/envoyproxy/go-control-plane v0.9.4
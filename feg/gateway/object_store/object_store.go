@@ -77,6 +77,12 @@ func (rm *RedisMap) Delete(key string) error {
 	return rm.client.HDel(rm.hash, key)
 }
 
+// DeleteExisting deletes an object from the map and returns true if it was
+// in the map
+func (rm *RedisMap) DeleteExisting(key string) (bool, error) {
+	return rm.client.HDelExisting(rm.hash, key)
+}
+
 // GetAll returns all objects in the map
 func (rm *RedisMap) GetAll() (map[string]interface{}, error) {
 	valMap, err := rm.client.HGetAll(rm.hash)
@@ -15,163 +15,133 @@ package policydb
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
-	"strings"
 
 	"magma/lte/cloud/go/protos"
 )
 
-const (
-	// action direction proto from src to dst
-	descriptorRegExp = "(.+)\\s+(.+)\\s+(.+)\\s+from\\s+(.+)\\s+to\\s+(.+)"
-)
-
-type address struct {
-	ip      string
-	version protos.IPAddress_IPVersion
-	port    uint32
-}
+// MaxFlowsPerRule is the maximum number of flows an IPFilterRule with port
+// lists or ranges may be expanded to
+const MaxFlowsPerRule = 64
 
 // GetFlowDescriptionFromFlowString returns a proto.FlowDescription from a IPFilterRule string
 // passed in the Flow-Description AVP. This AVP can have many variations, but follows
 // the format:
-//    action direction proto from src to dst
+//
+//	action direction proto from src to dst [options]
+//
 // e.g.:
-//    permit out ip from 1.2.3.0/24 to any
+//
+//	permit out ip from 1.2.3.0/24 to any
+//
+// It returns an error if the rule matches several ports, see GetFlowDescriptionsFromFlowString
 func GetFlowDescriptionFromFlowString(descriptorStr string) (*protos.FlowDescription, error) {
-	split, err := tokenizeString(descriptorStr)
+	flows, err := GetFlowDescriptionsFromFlowString(descriptorStr)
 	if err != nil {
 		return nil, err
 	}
-	return getFlowDescriptionFromSplit(split)
+	if len(flows) != 1 {
+		return nil, fmt.Errorf("IPFilterRule '%s' matches %d port combinations, expected a single one", descriptorStr, len(flows))
+	}
+	return flows[0], nil
 }
 
-func tokenizeString(descriptorStr string) ([]string, error) {
-	re, err := regexp.Compile(descriptorRegExp)
+// GetFlowDescriptionsFromFlowString returns the proto.FlowDescriptions enforcing an IPFilterRule
+// string passed in the Flow-Description AVP. Rules with port lists or ranges are expanded to
+// a flow per port combination, up to MaxFlowsPerRule flows
+func GetFlowDescriptionsFromFlowString(descriptorStr string) ([]*protos.FlowDescription, error) {
+	rule, err := ParseIPFilterRule(descriptorStr)
 	if err != nil {
 		return nil, err
 	}
-	matches := re.FindStringSubmatch(descriptorStr)
-	if len(matches) == 0 {
-		return nil, fmt.Errorf("Invalid format for descriptor %s", descriptorStr)
-	}
-	return matches[1:], nil
+	return rule.ToFlowDescriptions()
 }
 
-func getFlowDescriptionFromSplit(matches []string) (*protos.FlowDescription, error) {
-	action, err := parseAction(matches[0])
-	if err != nil {
-		return nil, err
-	}
-	direction, err := parseDirection(matches[1])
-	if err != nil {
-		return nil, err
-	}
-	proto, err := parseProto(matches[2])
-	if err != nil {
-		return nil, err
-	}
-	src, err := parseAddress(matches[3])
-	if err != nil {
-		return nil, err
-	}
-	dst, err := parseAddress(matches[4])
-	if err != nil {
-		return nil, err
-	}
-	rule := &protos.FlowDescription{
-		Action: action,
-		Match: &protos.FlowMatch{
-			Direction: direction,
-			IpProto:   proto,
-		},
-	}
-	if src.ip != "" {
-		rule.Match.IpSrc = &protos.IPAddress{
-			Version: src.version,
-			Address: []byte(src.ip),
-		}
-	}
-	if dst.ip != "" {
-		rule.Match.IpDst = &protos.IPAddress{
-			Version: dst.version,
-			Address: []byte(dst.ip),
+// ToFlowDescriptions converts the rule to the flows enforced by pipelined. The rules using
+// features the flows cannot express, address negation & options, are rejected.
+// The "assigned" address is enforced as "any", since the flows of a rule only match
+// the traffic of its subscriber.
+func (r *IPFilterRule) ToFlowDescriptions() ([]*protos.FlowDescription, error) {
+	if r.Src.Negated || r.Dst.Negated {
+		return nil, fmt.Errorf("IPFilterRule '%s': negated addresses are not supported", r)
+	}
+	if r.Options.hasAny() {
+		return nil, fmt.Errorf("IPFilterRule '%s': options are not supported", r)
+	}
+	if _, ok := protos.FlowMatch_IPProto_name[int32(r.Protocol)]; !ok {
+		return nil, fmt.Errorf("IPFilterRule '%s': protocol %d is not supported", r, r.Protocol)
+	}
+	hasPorts := len(r.Src.Ports) > 0 || len(r.Dst.Ports) > 0
+	if hasPorts && r.Protocol != protoTCP && r.Protocol != protoUDP {
+		return nil, fmt.Errorf("IPFilterRule '%s': ports are only supported for tcp (6) & udp (17)", r)
+	}
+	if combinations := countPorts(r.Src.Ports) * countPorts(r.Dst.Ports); combinations > MaxFlowsPerRule {
+		return nil, fmt.Errorf("IPFilterRule '%s': matches %d port combinations, more than the maximum of %d",
+			r, combinations, MaxFlowsPerRule)
+	}
+	srcPorts, dstPorts := expandPorts(r.Src.Ports), expandPorts(r.Dst.Ports)
+
+	var flows []*protos.FlowDescription
+	for _, srcPort := range srcPorts {
+		for _, dstPort := range dstPorts {
+			flow := &protos.FlowDescription{
+				Action: r.Action,
+				Match: &protos.FlowMatch{
+					Direction: r.Direction,
+					IpProto:   protos.FlowMatch_IPProto(r.Protocol),
+					IpSrc:     r.Src.toIPAddress(),
+					IpDst:     r.Dst.toIPAddress(),
+				},
+			}
+			if r.Protocol == protoTCP {
+				flow.Match.TcpSrc, flow.Match.TcpDst = srcPort, dstPort
+			} else if r.Protocol == protoUDP {
+				flow.Match.UdpSrc, flow.Match.UdpDst = srcPort, dstPort
+			}
+			flows = append(flows, flow)
 		}
 	}
-	if proto == protos.FlowMatch_IPPROTO_TCP {
-		rule.Match.TcpSrc = src.port
-		rule.Match.TcpDst = dst.port
-	} else if proto == protos.FlowMatch_IPPROTO_UDP {
-		rule.Match.UdpSrc = src.port
-		rule.Match.UdpDst = dst.port
-	}
-
-	return rule, nil
+	return flows, nil
 }
 
-// action can be "permit" or "deny"
-func parseAction(action string) (protos.FlowDescription_Action, error) {
-	if action == "permit" {
-		return protos.FlowDescription_PERMIT, nil
+// expandPorts returns the ports of the ranges, or the 0 wildcard port if there are none
+func expandPorts(ranges []Range) []uint32 {
+	if len(ranges) == 0 {
+		return []uint32{0}
 	}
-	if action == "deny" {
-		return protos.FlowDescription_DENY, nil
-	}
-	return protos.FlowDescription_PERMIT, fmt.Errorf("Unable to parse action %s", action)
-}
-
-// direction can be "in" (Uplink) or "out" (Downlink)
-func parseDirection(direction string) (protos.FlowMatch_Direction, error) {
-	if direction == "in" {
-		return protos.FlowMatch_UPLINK, nil
-	} else if direction == "out" {
-		return protos.FlowMatch_DOWNLINK, nil
+	var ports []uint32
+	for _, r := range ranges {
+		for port := uint32(r.Low); port <= uint32(r.High); port++ {
+			ports = append(ports, port)
+		}
 	}
-	return protos.FlowMatch_UPLINK, fmt.Errorf("Unable to parse direction %s", direction)
+	return ports
 }
 
-// proto can be "ip" or a proto number like "6" (TCP)
-func parseProto(proto string) (protos.FlowMatch_IPProto, error) {
-	if proto == "ip" {
-		return protos.FlowMatch_IPPROTO_IP, nil
+// countPorts returns the number of ports of the ranges, counting the wildcard port as one
+func countPorts(ranges []Range) int {
+	if len(ranges) == 0 {
+		return 1
 	}
-	protoInt, err := strconv.Atoi(proto)
-	if err != nil {
-		return protos.FlowMatch_IPPROTO_IP, err
+	count := 0
+	for _, r := range ranges {
+		count += int(r.High) - int(r.Low) + 1
 	}
-	_, ok := protos.FlowMatch_IPProto_name[int32(protoInt)]
-	if !ok {
-		return protos.FlowMatch_IPPROTO_IP, err
-	}
-	return protos.FlowMatch_IPProto(protoInt), nil
+	return count
 }
 
-// address looks like "any" or "1.1.1.1/32 4444"
-func parseAddress(addr string) (*address, error) {
-	matches := strings.Split(addr, " ")
-	if len(matches) < 1 {
-		return nil, fmt.Errorf("Invalid format for address %s", addr)
+func (e IPFilterEndpoint) toIPAddress() *protos.IPAddress {
+	addr := e.addressString()
+	if len(addr) == 0 {
+		return nil
 	}
-	ipAddr := matches[0]
 	version := protos.IPAddress_IPV4
-	if ipAddr == "any" {
-		return &address{ip: "", version: version, port: 0}, nil
-	}
-
-	// Easy check if its an ipv6 addr
-	if strings.Count(ipAddr, ":") >= 2 {
+	if len(e.IP) == 16 {
 		version = protos.IPAddress_IPV6
 	}
+	return &protos.IPAddress{Version: version, Address: []byte(addr)}
+}
 
-	if len(matches) < 2 {
-		return &address{ip: ipAddr, version: version, port: 0}, nil
-	}
-
-	// Don't support port ranges for now
-	portInt, err := strconv.Atoi(matches[1])
-	if err != nil {
-		return nil, err
-	}
-	return &address{ip: ipAddr, version: version, port: uint32(portInt)}, nil
+func (o *IPFilterOptions) hasAny() bool {
+	return o.Fragment || o.IPOptions != nil || o.TCPOptions != nil || o.TCPFlags != nil ||
+		o.Established || o.Setup || len(o.ICMPTypes) > 0
 }
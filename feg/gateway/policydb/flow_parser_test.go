@@ -118,3 +118,40 @@ func TestAll(t *testing.T) {
 	assert.Equal(t, flow1.TcpDst, uint32(0))
 	assert.Equal(t, flow1.UdpDst, uint32(8000))
 }
+
+func TestFlowPortExpansion(t *testing.T) {
+	_, err := policydb.GetFlowDescriptionFromFlowString("permit out 6 from any 80,443 to assigned")
+	assert.EqualError(t, err, "IPFilterRule 'permit out 6 from any 80,443 to assigned' matches 2 port combinations, expected a single one")
+
+	flows, err := policydb.GetFlowDescriptionsFromFlowString("permit out 6 from any 80,443 to assigned 5000-5001")
+	assert.NoError(t, err)
+	assert.Len(t, flows, 4)
+	var ports [][2]uint32
+	for _, flow := range flows {
+		assert.Equal(t, protos.FlowMatch_IPPROTO_TCP, flow.Match.IpProto)
+		assert.Nil(t, flow.Match.IpSrc)
+		assert.Nil(t, flow.Match.IpDst)
+		ports = append(ports, [2]uint32{flow.Match.TcpSrc, flow.Match.TcpDst})
+	}
+	assert.Equal(t, [][2]uint32{{80, 5000}, {80, 5001}, {443, 5000}, {443, 5001}}, ports)
+
+	_, err = policydb.GetFlowDescriptionsFromFlowString("permit out 17 from any to any 1000-2000")
+	assert.EqualError(t, err, "IPFilterRule 'permit out 17 from any to any 1000-2000': matches 1001 port combinations, more than the maximum of 64")
+}
+
+func TestFlowUnsupported(t *testing.T) {
+	for rule, expected := range map[string]string{
+		"permit in ip from !10.0.0.0/8 to any":    "negated addresses are not supported",
+		"permit in 6 from any to any established": "options are not supported",
+		"permit in 50 from any to any":            "protocol 50 is not supported",
+		"permit in 132 from any to any 80":        "ports are only supported for tcp (6) & udp (17)",
+		"permit in ip from any to any frag":       "options are not supported",
+		"permit in 1 from any to any icmptypes 8": "options are not supported",
+	} {
+		_, err := policydb.GetFlowDescriptionsFromFlowString(rule)
+		assert.EqualError(t, err, "IPFilterRule '"+rule+"': "+expected)
+	}
+
+	_, err := policydb.GetFlowDescriptionsFromFlowString("permit in ip from any to any keep-state")
+	assert.IsType(t, &policydb.IPFilterRuleError{}, err)
+}
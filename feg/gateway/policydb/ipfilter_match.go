@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policydb
+
+import (
+	"net"
+
+	"magma/lte/cloud/go/protos"
+)
+
+// Packet holds the header fields of an IP packet matched against IPFilterRules
+type Packet struct {
+	Direction protos.FlowMatch_Direction
+	Protocol  uint8
+	Src       net.IP
+	Dst       net.IP
+	SrcPort   uint16
+	DstPort   uint16
+	// AssignedIP is the address assigned to the terminal, matched by the
+	// "assigned" address
+	AssignedIP net.IP
+	// Fragment is set for the fragments following the first fragment of a datagram
+	Fragment bool
+	// IPOptions, TCPOptions & TCPFlags are bitmasks of the IPOption*,
+	// TCPOption* & TCPFlag* constants
+	IPOptions  uint8
+	TCPOptions uint8
+	TCPFlags   uint8
+	ICMPType   uint8
+}
+
+// Match returns true if the packet matches the rule, regardless of the rule's action
+func (r *IPFilterRule) Match(p *Packet) bool {
+	if p.Direction != r.Direction {
+		return false
+	}
+	if r.Protocol != 0 && r.Protocol != p.Protocol {
+		return false
+	}
+	// Ports are only known for the first fragment of a datagram
+	if p.Fragment && (len(r.Src.Ports) > 0 || len(r.Dst.Ports) > 0) {
+		return false
+	}
+	return r.Src.match(p.Src, p.SrcPort, p.AssignedIP) &&
+		r.Dst.match(p.Dst, p.DstPort, p.AssignedIP) &&
+		r.Options.match(p)
+}
+
+func (e *IPFilterEndpoint) match(ip net.IP, port uint16, assignedIP net.IP) bool {
+	if len(e.Ports) > 0 && !inRanges(e.Ports, port) {
+		return false
+	}
+	return e.matchAddress(ip, assignedIP) != e.Negated
+}
+
+func (e *IPFilterEndpoint) matchAddress(ip net.IP, assignedIP net.IP) bool {
+	switch {
+	case e.Any:
+		return true
+	case e.Assigned:
+		return assignedIP != nil && assignedIP.Equal(ip)
+	}
+	if ip4 := ip.To4(); ip4 != nil && len(e.IP) == net.IPv4len {
+		ip = ip4
+	}
+	if len(ip) != len(e.IP) {
+		return false
+	}
+	ipNet := net.IPNet{IP: e.IP.Mask(net.CIDRMask(e.PrefixLen, len(e.IP)*8)), Mask: net.CIDRMask(e.PrefixLen, len(e.IP)*8)}
+	return ipNet.Contains(ip)
+}
+
+func (o *IPFilterOptions) match(p *Packet) bool {
+	if o.Fragment && !p.Fragment {
+		return false
+	}
+	if o.IPOptions != nil && !o.IPOptions.Match(p.IPOptions) {
+		return false
+	}
+	if o.TCPOptions != nil && !o.TCPOptions.Match(p.TCPOptions) {
+		return false
+	}
+	if o.TCPFlags != nil && !o.TCPFlags.Match(p.TCPFlags) {
+		return false
+	}
+	if o.Established && p.TCPFlags&(TCPFlagRST|TCPFlagACK) == 0 {
+		return false
+	}
+	if o.Setup && (p.TCPFlags&TCPFlagSYN == 0 || p.TCPFlags&TCPFlagACK != 0) {
+		return false
+	}
+	if len(o.ICMPTypes) > 0 && !inRanges(o.ICMPTypes, uint16(p.ICMPType)) {
+		return false
+	}
+	return true
+}
+
+// Match returns true if all the Set flags & none of the Unset flags are set
+func (s *FlagSpec) Match(flags uint8) bool {
+	return flags&s.Set == s.Set && flags&s.Unset == 0
+}
+
+func inRanges(ranges []Range, value uint16) bool {
+	for _, r := range ranges {
+		if value >= r.Low && value <= r.High {
+			return true
+		}
+	}
+	return false
+}
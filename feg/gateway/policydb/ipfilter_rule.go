@@ -0,0 +1,529 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policydb
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"magma/lte/cloud/go/protos"
+)
+
+// IP options of the ipoptions IPFilterRule option
+const (
+	IPOptionSSRR uint8 = 1 << iota
+	IPOptionLSRR
+	IPOptionRR
+	IPOptionTS
+)
+
+// TCP options of the tcpoptions IPFilterRule option
+const (
+	TCPOptionMSS uint8 = 1 << iota
+	TCPOptionWindow
+	TCPOptionSACK
+	TCPOptionTS
+	TCPOptionCC
+)
+
+// TCP header flags of the tcpflags IPFilterRule option
+const (
+	TCPFlagFIN uint8 = 0x01
+	TCPFlagSYN uint8 = 0x02
+	TCPFlagRST uint8 = 0x04
+	TCPFlagPSH uint8 = 0x08
+	TCPFlagACK uint8 = 0x10
+	TCPFlagURG uint8 = 0x20
+)
+
+const (
+	protoICMP   = 1
+	protoTCP    = 6
+	protoUDP    = 17
+	protoICMPv6 = 58
+	protoSCTP   = 132
+)
+
+var (
+	ipOptionNames  = map[string]uint8{"ssrr": IPOptionSSRR, "lsrr": IPOptionLSRR, "rr": IPOptionRR, "ts": IPOptionTS}
+	tcpOptionNames = map[string]uint8{
+		"mss": TCPOptionMSS, "window": TCPOptionWindow, "sack": TCPOptionSACK, "ts": TCPOptionTS, "cc": TCPOptionCC,
+	}
+	tcpFlagNames = map[string]uint8{
+		"fin": TCPFlagFIN, "syn": TCPFlagSYN, "rst": TCPFlagRST, "psh": TCPFlagPSH, "ack": TCPFlagACK, "urg": TCPFlagURG,
+	}
+	icmpTypeNames = map[string]uint16{
+		"echoreply": 0, "unreach": 3, "squench": 4, "redirect": 5, "echo": 8, "routeradv": 9, "routersol": 10,
+		"timex": 11, "paramprob": 12, "tstamp": 13, "tstamprep": 14, "inforeq": 15, "inforeqrep": 16,
+		"maskreq": 17, "maskrep": 18,
+	}
+	protocolNames = map[string]uint8{
+		"ip": 0, "icmp": protoICMP, "tcp": protoTCP, "udp": protoUDP, "ipv6-icmp": protoICMPv6, "sctp": protoSCTP,
+	}
+)
+
+// IPFilterRule is a parsed IPFilterRule (RFC 6733 section 4.3.1), the format of
+// the Flow-Description AVP:
+//
+//	action dir proto from src to dst [options]
+type IPFilterRule struct {
+	Action    protos.FlowDescription_Action
+	Direction protos.FlowMatch_Direction
+	// Protocol is the IP protocol number, 0 ("ip") matches any protocol
+	Protocol uint8
+	Src      IPFilterEndpoint
+	Dst      IPFilterEndpoint
+	Options  IPFilterOptions
+}
+
+// IPFilterEndpoint is the source or destination of an IPFilterRule
+type IPFilterEndpoint struct {
+	// Negated inverts the match of the address, not of the ports
+	Negated bool
+	// Any matches any address, Assigned the address assigned to the terminal,
+	// otherwise the address matches IP/PrefixLen
+	Any       bool
+	Assigned  bool
+	IP        net.IP
+	PrefixLen int
+	HasPrefix bool
+	// Ports match any port if empty
+	Ports []Range
+}
+
+// Range is an inclusive range of ports or ICMP types
+type Range struct {
+	Low  uint16
+	High uint16
+}
+
+// FlagSpec matches a set of flags or options which must all be Set and of
+// which none may be Unset, e.g. "syn,!ack"
+type FlagSpec struct {
+	Set   uint8
+	Unset uint8
+}
+
+// IPFilterOptions are the options of an IPFilterRule
+type IPFilterOptions struct {
+	// Fragment matches the fragments following the first fragment of a datagram
+	Fragment   bool
+	IPOptions  *FlagSpec
+	TCPOptions *FlagSpec
+	TCPFlags   *FlagSpec
+	// Established matches TCP packets with the RST or ACK flag & Setup the
+	// TCP packets with the SYN flag but no ACK flag
+	Established bool
+	Setup       bool
+	ICMPTypes   []Range
+}
+
+// IPFilterRuleError is the error of an invalid IPFilterRule
+type IPFilterRuleError struct {
+	Rule string
+	// Token is the invalid token, it is empty if the rule is incomplete
+	Token string
+	Msg   string
+}
+
+func (e *IPFilterRuleError) Error() string {
+	if len(e.Token) == 0 {
+		return fmt.Sprintf("Invalid IPFilterRule '%s': %s", e.Rule, e.Msg)
+	}
+	return fmt.Sprintf("Invalid IPFilterRule '%s': %s at '%s'", e.Rule, e.Msg, e.Token)
+}
+
+// ParseIPFilterRule parses an IPFilterRule, it supports the whole RFC 6733 syntax:
+// port lists & ranges, protocol numbers, "any" & "assigned" addresses, IPv6
+// prefixes, address negation & the frag, ipoptions, tcpoptions, established,
+// setup, tcpflags & icmptypes options
+func ParseIPFilterRule(rule string) (*IPFilterRule, error) {
+	p := &ruleParser{rule: rule, tokens: strings.Fields(rule)}
+	return p.parse()
+}
+
+type ruleParser struct {
+	rule   string
+	tokens []string
+	pos    int
+}
+
+func (p *ruleParser) errorf(token string, format string, args ...interface{}) error {
+	return &IPFilterRuleError{Rule: p.rule, Token: token, Msg: fmt.Sprintf(format, args...)}
+}
+
+// next returns the next token, an error if there is none
+func (p *ruleParser) next(expected string) (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", p.errorf("", "missing %s", expected)
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+func (p *ruleParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *ruleParser) parse() (*IPFilterRule, error) {
+	rule := &IPFilterRule{}
+	token, err := p.next("action")
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(token) {
+	case "permit":
+		rule.Action = protos.FlowDescription_PERMIT
+	case "deny":
+		rule.Action = protos.FlowDescription_DENY
+	default:
+		return nil, p.errorf(token, "action must be permit or deny")
+	}
+
+	if token, err = p.next("direction"); err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(token) {
+	case "in":
+		rule.Direction = protos.FlowMatch_UPLINK
+	case "out":
+		rule.Direction = protos.FlowMatch_DOWNLINK
+	default:
+		return nil, p.errorf(token, "direction must be in or out")
+	}
+
+	if token, err = p.next("protocol"); err != nil {
+		return nil, err
+	}
+	if rule.Protocol, err = p.parseProtocol(token); err != nil {
+		return nil, err
+	}
+
+	if err = p.expectKeyword("from"); err != nil {
+		return nil, err
+	}
+	if rule.Src, err = p.parseEndpoint(rule.Protocol, "source"); err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("to"); err != nil {
+		return nil, err
+	}
+	if rule.Dst, err = p.parseEndpoint(rule.Protocol, "destination"); err != nil {
+		return nil, err
+	}
+	if rule.Options, err = p.parseOptions(rule.Protocol); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (p *ruleParser) expectKeyword(keyword string) error {
+	token, err := p.next(keyword)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(token, keyword) {
+		return p.errorf(token, "expected %s", keyword)
+	}
+	return nil
+}
+
+// protocol is "ip", a protocol number or a common protocol name
+func (p *ruleParser) parseProtocol(token string) (uint8, error) {
+	if proto, ok := protocolNames[strings.ToLower(token)]; ok {
+		return proto, nil
+	}
+	proto, err := strconv.ParseUint(token, 10, 8)
+	if err != nil {
+		return 0, p.errorf(token, "protocol must be ip or a protocol number between 0 and 255")
+	}
+	return uint8(proto), nil
+}
+
+// endpoint looks like "[!] any|assigned|ipno[/bits] [ports]"
+func (p *ruleParser) parseEndpoint(proto uint8, name string) (IPFilterEndpoint, error) {
+	endpoint := IPFilterEndpoint{}
+	token, err := p.next(name + " address")
+	if err != nil {
+		return endpoint, err
+	}
+	if strings.HasPrefix(token, "!") {
+		endpoint.Negated = true
+		token = token[1:]
+		if len(token) == 0 {
+			if token, err = p.next(name + " address"); err != nil {
+				return endpoint, err
+			}
+		}
+	}
+	switch strings.ToLower(token) {
+	case "any":
+		endpoint.Any = true
+	case "assigned":
+		endpoint.Assigned = true
+	default:
+		if endpoint.IP, endpoint.PrefixLen, endpoint.HasPrefix, err = p.parseAddress(token); err != nil {
+			return endpoint, err
+		}
+	}
+
+	// Ports are the only optional token of an endpoint starting with a digit
+	if token = p.peek(); len(token) == 0 || token[0] < '0' || token[0] > '9' {
+		return endpoint, nil
+	}
+	p.pos++
+	if proto != protoTCP && proto != protoUDP && proto != protoSCTP {
+		return endpoint, p.errorf(token, "ports are only valid for tcp (6), udp (17) & sctp (132)")
+	}
+	endpoint.Ports, err = p.parseRanges(token, "port", 65535, nil)
+	return endpoint, err
+}
+
+func (p *ruleParser) parseAddress(token string) (net.IP, int, bool, error) {
+	addr, bits, hasPrefix := token, "", false
+	if i := strings.Index(token, "/"); i >= 0 {
+		addr, bits, hasPrefix = token[:i], token[i+1:], true
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return nil, 0, false, p.errorf(token, "address must be any, assigned or an IP address with an optional prefix length")
+	}
+	if ip4 := ip.To4(); ip4 != nil && !strings.Contains(addr, ":") {
+		ip = ip4
+	}
+	if !hasPrefix {
+		return ip, len(ip) * 8, false, nil
+	}
+	prefixLen, err := strconv.Atoi(bits)
+	if err != nil || prefixLen < 0 || prefixLen > len(ip)*8 {
+		return nil, 0, false, p.errorf(token, "prefix length must be between 0 and %d", len(ip)*8)
+	}
+	return ip, prefixLen, true, nil
+}
+
+// parseRanges parses a comma separated list of values & ranges of values,
+// e.g. "80,443,8000-8080", named values are looked up in names
+func (p *ruleParser) parseRanges(token, name string, max uint64, names map[string]uint16) ([]Range, error) {
+	var ranges []Range
+	parseValue := func(value string) (uint16, error) {
+		if v, ok := names[strings.ToLower(value)]; ok {
+			return v, nil
+		}
+		v, err := strconv.ParseUint(value, 10, 16)
+		if err != nil || v > max {
+			return 0, p.errorf(token, "%s must be a number between 0 and %d", name, max)
+		}
+		return uint16(v), nil
+	}
+	for _, item := range strings.Split(token, ",") {
+		low, high := item, item
+		if i := strings.Index(item, "-"); i >= 0 {
+			low, high = item[:i], item[i+1:]
+		}
+		lowValue, err := parseValue(low)
+		if err != nil {
+			return nil, err
+		}
+		highValue, err := parseValue(high)
+		if err != nil {
+			return nil, err
+		}
+		if lowValue > highValue {
+			return nil, p.errorf(token, "invalid %s range %s", name, item)
+		}
+		ranges = append(ranges, Range{Low: lowValue, High: highValue})
+	}
+	return ranges, nil
+}
+
+func (p *ruleParser) parseOptions(proto uint8) (IPFilterOptions, error) {
+	options := IPFilterOptions{}
+	seen := map[string]bool{}
+	for p.pos < len(p.tokens) {
+		token, _ := p.next("option")
+		option := strings.ToLower(token)
+		if seen[option] {
+			return options, p.errorf(token, "duplicate option")
+		}
+		seen[option] = true
+		switch option {
+		case "frag":
+			options.Fragment = true
+		case "ipoptions":
+			spec, err := p.parseFlagSpec(option, ipOptionNames)
+			if err != nil {
+				return options, err
+			}
+			options.IPOptions = spec
+		case "tcpoptions", "tcpflags", "established", "setup":
+			if proto != protoTCP {
+				return options, p.errorf(token, "option is only valid for tcp (6)")
+			}
+			var err error
+			switch option {
+			case "tcpoptions":
+				options.TCPOptions, err = p.parseFlagSpec(option, tcpOptionNames)
+			case "tcpflags":
+				options.TCPFlags, err = p.parseFlagSpec(option, tcpFlagNames)
+			case "established":
+				options.Established = true
+			case "setup":
+				options.Setup = true
+			}
+			if err != nil {
+				return options, err
+			}
+		case "icmptypes":
+			if proto != protoICMP && proto != protoICMPv6 {
+				return options, p.errorf(token, "option is only valid for icmp (1) & ipv6-icmp (58)")
+			}
+			types, err := p.next("ICMP types")
+			if err != nil {
+				return options, err
+			}
+			if options.ICMPTypes, err = p.parseRanges(types, "ICMP type", 255, icmpTypeNames); err != nil {
+				return options, err
+			}
+		default:
+			return options, p.errorf(token, "unknown option")
+		}
+	}
+	return options, nil
+}
+
+// parseFlagSpec parses a comma separated list of flags, each optionally
+// preceded by "!", e.g. "syn,!ack"
+func (p *ruleParser) parseFlagSpec(option string, names map[string]uint8) (*FlagSpec, error) {
+	token, err := p.next(option + " spec")
+	if err != nil {
+		return nil, err
+	}
+	spec := &FlagSpec{}
+	for _, item := range strings.Split(strings.ToLower(token), ",") {
+		negated := strings.HasPrefix(item, "!")
+		flag, ok := names[strings.TrimPrefix(item, "!")]
+		if !ok {
+			return nil, p.errorf(token, "unknown %s value %s", option, item)
+		}
+		if negated {
+			spec.Unset |= flag
+		} else {
+			spec.Set |= flag
+		}
+	}
+	if spec.Set&spec.Unset != 0 {
+		return nil, p.errorf(token, "%s value both set & unset", option)
+	}
+	return spec, nil
+}
+
+// String returns the rule in the IPFilterRule format
+func (r *IPFilterRule) String() string {
+	parts := []string{"permit", "in", "ip", "from", r.Src.String(), "to", r.Dst.String()}
+	if r.Action == protos.FlowDescription_DENY {
+		parts[0] = "deny"
+	}
+	if r.Direction == protos.FlowMatch_DOWNLINK {
+		parts[1] = "out"
+	}
+	if r.Protocol != 0 {
+		parts[2] = strconv.Itoa(int(r.Protocol))
+	}
+	o := r.Options
+	if o.Fragment {
+		parts = append(parts, "frag")
+	}
+	if o.IPOptions != nil {
+		parts = append(parts, "ipoptions", o.IPOptions.format(ipOptionNames))
+	}
+	if o.TCPOptions != nil {
+		parts = append(parts, "tcpoptions", o.TCPOptions.format(tcpOptionNames))
+	}
+	if o.Established {
+		parts = append(parts, "established")
+	}
+	if o.Setup {
+		parts = append(parts, "setup")
+	}
+	if o.TCPFlags != nil {
+		parts = append(parts, "tcpflags", o.TCPFlags.format(tcpFlagNames))
+	}
+	if len(o.ICMPTypes) > 0 {
+		parts = append(parts, "icmptypes", formatRanges(o.ICMPTypes))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (e IPFilterEndpoint) String() string {
+	addr := e.addressString()
+	if len(addr) == 0 {
+		addr = "any"
+		if e.Assigned {
+			addr = "assigned"
+		}
+	}
+	if e.Negated {
+		addr = "!" + addr
+	}
+	if len(e.Ports) > 0 {
+		addr += " " + formatRanges(e.Ports)
+	}
+	return addr
+}
+
+// addressString returns the address & prefix of the endpoint as received,
+// it is empty for the any & assigned addresses
+func (e IPFilterEndpoint) addressString() string {
+	if e.Any || e.Assigned || e.IP == nil {
+		return ""
+	}
+	if e.HasPrefix {
+		return fmt.Sprintf("%s/%d", e.IP, e.PrefixLen)
+	}
+	return e.IP.String()
+}
+
+func formatRanges(ranges []Range) string {
+	items := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Low == r.High {
+			items = append(items, strconv.Itoa(int(r.Low)))
+		} else {
+			items = append(items, fmt.Sprintf("%d-%d", r.Low, r.High))
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+func (s *FlagSpec) format(names map[string]uint8) string {
+	var items []string
+	for bit := uint8(1); bit != 0; bit <<= 1 {
+		for name, flag := range names {
+			if flag != bit {
+				continue
+			}
+			if s.Set&bit != 0 {
+				items = append(items, name)
+			} else if s.Unset&bit != 0 {
+				items = append(items, "!"+name)
+			}
+		}
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policydb_test
+
+import (
+	"net"
+	"testing"
+
+	"magma/feg/gateway/policydb"
+	"magma/lte/cloud/go/protos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIPFilterRule(t *testing.T) {
+	rule, err := policydb.ParseIPFilterRule("deny out 6 from !10.0.0.0/8 80,443,8000-8080 to assigned 1024-65535 established tcpflags syn,!ack")
+	assert.NoError(t, err)
+	assert.Equal(t, &policydb.IPFilterRule{
+		Action:    protos.FlowDescription_DENY,
+		Direction: protos.FlowMatch_DOWNLINK,
+		Protocol:  6,
+		Src: policydb.IPFilterEndpoint{
+			Negated:   true,
+			IP:        net.IP{10, 0, 0, 0},
+			PrefixLen: 8,
+			HasPrefix: true,
+			Ports:     []policydb.Range{{Low: 80, High: 80}, {Low: 443, High: 443}, {Low: 8000, High: 8080}},
+		},
+		Dst: policydb.IPFilterEndpoint{
+			Assigned: true,
+			Ports:    []policydb.Range{{Low: 1024, High: 65535}},
+		},
+		Options: policydb.IPFilterOptions{
+			Established: true,
+			TCPFlags:    &policydb.FlagSpec{Set: policydb.TCPFlagSYN, Unset: policydb.TCPFlagACK},
+		},
+	}, rule)
+
+	rule, err = policydb.ParseIPFilterRule("permit in ipv6-icmp from 2001:db8::/32 to ! 2001:db8:1::1 icmptypes 128-129,echo frag ipoptions rr,!ts")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(58), rule.Protocol)
+	assert.Equal(t, net.ParseIP("2001:db8::"), rule.Src.IP)
+	assert.Equal(t, 32, rule.Src.PrefixLen)
+	assert.True(t, rule.Dst.Negated)
+	assert.False(t, rule.Dst.HasPrefix)
+	assert.Equal(t, 128, rule.Dst.PrefixLen)
+	assert.Equal(t, []policydb.Range{{Low: 128, High: 129}, {Low: 8, High: 8}}, rule.Options.ICMPTypes)
+	assert.True(t, rule.Options.Fragment)
+	assert.Equal(t, &policydb.FlagSpec{Set: policydb.IPOptionRR, Unset: policydb.IPOptionTS}, rule.Options.IPOptions)
+
+	// Keywords are case insensitive
+	rule, err = policydb.ParseIPFilterRule("PERMIT IN UDP FROM ANY TO 1.2.3.4 53 tcpoptions mss")
+	assert.EqualError(t, err, "Invalid IPFilterRule 'PERMIT IN UDP FROM ANY TO 1.2.3.4 53 tcpoptions mss': option is only valid for tcp (6) at 'tcpoptions'")
+	rule, err = policydb.ParseIPFilterRule("PERMIT IN UDP FROM ANY TO 1.2.3.4 53")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(17), rule.Protocol)
+	assert.True(t, rule.Src.Any)
+}
+
+func TestParseIPFilterRule_Errors(t *testing.T) {
+	for rule, expected := range map[string]string{
+		"":                                              "missing action",
+		"allow in ip from any to any":                   "action must be permit or deny at 'allow'",
+		"permit both ip from any to any":                "direction must be in or out at 'both'",
+		"permit in 256 from any to any":                 "protocol must be ip or a protocol number between 0 and 255 at '256'",
+		"permit in ip any to any":                       "expected from at 'any'",
+		"permit in ip from any":                         "missing to",
+		"permit in ip from any to":                      "missing destination address",
+		"permit in ip from 1.2.3 to any":                "address must be any, assigned or an IP address with an optional prefix length at '1.2.3'",
+		"permit in ip from 1.2.3.4/33 to any":           "prefix length must be between 0 and 32 at '1.2.3.4/33'",
+		"permit in ip from any to 2001:db8::/129":       "prefix length must be between 0 and 128 at '2001:db8::/129'",
+		"permit in ip from any 80 to any":               "ports are only valid for tcp (6), udp (17) & sctp (132) at '80'",
+		"permit in 6 from any 80-70 to any":             "invalid port range 80-70 at '80-70'",
+		"permit in 6 from any 65536 to any":             "port must be a number between 0 and 65535 at '65536'",
+		"permit in 6 from any to any setup setup":       "duplicate option at 'setup'",
+		"permit in 6 from any to any tcpflags":          "missing tcpflags spec",
+		"permit in 6 from any to any tcpflags xyz":      "unknown tcpflags value xyz at 'xyz'",
+		"permit in 6 from any to any tcpflags syn,!syn": "tcpflags value both set & unset at 'syn,!syn'",
+		"permit in 6 from any to any icmptypes 8":       "option is only valid for icmp (1) & ipv6-icmp (58) at 'icmptypes'",
+		"permit in 1 from any to any icmptypes 256":     "ICMP type must be a number between 0 and 255 at '256'",
+		"permit in ip from any to any keep-state":       "unknown option at 'keep-state'",
+	} {
+		_, err := policydb.ParseIPFilterRule(rule)
+		assert.EqualError(t, err, "Invalid IPFilterRule '"+rule+"': "+expected)
+		assert.IsType(t, &policydb.IPFilterRuleError{}, err)
+	}
+}
+
+func TestIPFilterRule_String(t *testing.T) {
+	for _, rule := range []string{
+		"permit out ip from any to assigned",
+		"deny in 6 from !10.0.0.0/8 80,443,8000-8080 to 1.2.3.4 setup tcpflags syn,!ack",
+		"permit in 6 from 2001:db8::/32 to any tcpoptions mss,!sack established",
+		"permit out 1 from any to any frag ipoptions lsrr icmptypes 0,8-11",
+	} {
+		parsed, err := policydb.ParseIPFilterRule(rule)
+		assert.NoError(t, err)
+		assert.Equal(t, rule, parsed.String())
+	}
+}
+
+func TestIPFilterRule_Match(t *testing.T) {
+	ue := net.ParseIP("192.168.128.10")
+	uplink := func(proto uint8, dst string, dstPort uint16) *policydb.Packet {
+		return &policydb.Packet{
+			Direction:  protos.FlowMatch_UPLINK,
+			Protocol:   proto,
+			Src:        ue,
+			Dst:        net.ParseIP(dst),
+			SrcPort:    40000,
+			DstPort:    dstPort,
+			AssignedIP: ue,
+		}
+	}
+	match := func(rule string, packet *policydb.Packet) bool {
+		parsed, err := policydb.ParseIPFilterRule(rule)
+		assert.NoError(t, err)
+		return parsed.Match(packet)
+	}
+
+	assert.True(t, match("permit in ip from any to any", uplink(17, "8.8.8.8", 53)))
+	assert.False(t, match("permit out ip from any to any", uplink(17, "8.8.8.8", 53)))
+	assert.True(t, match("permit in 17 from assigned to 8.8.0.0/16 53", uplink(17, "8.8.8.8", 53)))
+	assert.False(t, match("permit in 17 from assigned to 8.8.0.0/16 53", uplink(6, "8.8.8.8", 53)))
+	assert.False(t, match("permit in 17 from assigned to 8.8.0.0/16 53", uplink(17, "8.9.8.8", 53)))
+	assert.False(t, match("permit in 17 from assigned to 8.8.0.0/16 53", uplink(17, "8.8.8.8", 54)))
+	assert.False(t, match("permit in 17 from 10.0.0.1 to any", uplink(17, "8.8.8.8", 53)))
+
+	// Port lists & ranges
+	assert.True(t, match("permit in 6 from any to any 80,443,8000-8080", uplink(6, "1.1.1.1", 443)))
+	assert.True(t, match("permit in 6 from any to any 80,443,8000-8080", uplink(6, "1.1.1.1", 8080)))
+	assert.False(t, match("permit in 6 from any to any 80,443,8000-8080", uplink(6, "1.1.1.1", 8081)))
+	assert.True(t, match("permit in 6 from any 30000-50000 to any", uplink(6, "1.1.1.1", 80)))
+
+	// Negation applies to the address only
+	assert.True(t, match("permit in 6 from any to !10.0.0.0/8 80", uplink(6, "1.1.1.1", 80)))
+	assert.False(t, match("permit in 6 from any to !10.0.0.0/8 80", uplink(6, "10.1.1.1", 80)))
+	assert.False(t, match("permit in 6 from any to !10.0.0.0/8 80", uplink(6, "1.1.1.1", 81)))
+
+	// IPv6
+	v6 := uplink(6, "2001:db8::1", 443)
+	v6.Src = net.ParseIP("2001:db8:ffff::10")
+	assert.True(t, match("permit in 6 from 2001:db8::/32 to 2001:db8::1 443", v6))
+	assert.False(t, match("permit in 6 from 2001:db8::/48 to any", v6))
+	assert.False(t, match("permit in 6 from 10.0.0.0/8 to any", v6))
+	assert.False(t, match("permit in 6 from assigned to any", v6))
+
+	// Options
+	syn := uplink(6, "1.1.1.1", 80)
+	syn.TCPFlags = policydb.TCPFlagSYN
+	ack := uplink(6, "1.1.1.1", 80)
+	ack.TCPFlags = policydb.TCPFlagACK | policydb.TCPFlagPSH
+	ack.TCPOptions = policydb.TCPOptionTS | policydb.TCPOptionSACK
+	assert.True(t, match("permit in 6 from any to any setup", syn))
+	assert.False(t, match("permit in 6 from any to any setup", ack))
+	assert.False(t, match("permit in 6 from any to any established", syn))
+	assert.True(t, match("permit in 6 from any to any established", ack))
+	assert.True(t, match("permit in 6 from any to any tcpflags ack,!syn", ack))
+	assert.False(t, match("permit in 6 from any to any tcpflags ack,!psh", ack))
+	assert.True(t, match("permit in 6 from any to any tcpoptions sack,!mss", ack))
+	assert.False(t, match("permit in 6 from any to any tcpoptions mss", ack))
+
+	frag := uplink(17, "1.1.1.1", 0)
+	frag.Fragment = true
+	frag.IPOptions = policydb.IPOptionRR
+	assert.True(t, match("permit in 17 from any to any frag ipoptions rr,!ssrr", frag))
+	assert.False(t, match("permit in 17 from any to any frag", uplink(17, "1.1.1.1", 53)))
+	// Ports of fragments are unknown
+	assert.False(t, match("permit in 17 from any to any 53", frag))
+
+	ping := uplink(1, "1.1.1.1", 0)
+	ping.ICMPType = 8
+	assert.True(t, match("permit in 1 from any to any icmptypes echo,13-14", ping))
+	assert.False(t, match("permit in 1 from any to any icmptypes 0,13-14", ping))
+}
@@ -30,6 +30,7 @@ const (
 	S8_PROXY         = "S8_PROXY"
 	SESSION_PROXY    = "SESSION_PROXY"
 	SWX_PROXY        = "SWX_PROXY"
+	S6B_PROXY        = "S6B_PROXY"
 	HLR_PROXY        = "HLR_PROXY"
 	HEALTH           = "HEALTH"
 	CSFB             = "CSFB"
@@ -49,6 +50,7 @@ const (
 	MOCK_PCRF        = "MOCK_PCRF"
 	MOCK_PCRF2       = "MOCK_PCRF2"
 	MOCK_HSS         = "HSS"
+	MOCK_AF          = "MOCK_AF"
 
 	SESSION_MANAGER = "SESSIOND"
 )
@@ -94,6 +96,7 @@ func init() {
 	addLocalService(EAP_SIM, 9118)
 	addLocalService(EAP_AKA, 9123)
 	addLocalService(SWX_PROXY, 9110)
+	addLocalService(S6B_PROXY, 9111)
 	addLocalService(RADIUSD, 9115)
 	addLocalService(HLR_PROXY, 9116)
 	addLocalService(PIPELINED, 9117)
@@ -105,6 +108,7 @@ func init() {
 	addLocalService(MOCK_PCRF2, 9206)
 	addLocalService(MOCK_VLR, 9203)
 	addLocalService(MOCK_HSS, 9204)
+	addLocalService(MOCK_AF, 9207)
 
 	// Overwrite/Add from /etc/magma/service_registry.yml if it exists
 	// moduleName is "" since all feg configs lie in /etc/magma without a module name
@@ -22,7 +22,9 @@ import (
 
 	fegprotos "magma/feg/cloud/go/protos"
 	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/object_store"
 	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/aaa"
 	"magma/feg/gateway/services/aaa/protos"
 	"magma/feg/gateway/services/aaa/servicers"
 	"magma/feg/gateway/services/aaa/store"
@@ -34,14 +36,14 @@ import (
 const (
 	AAAServiceName = "aaa_server"
 	Version        = "0.1"
+
+	SessionStoreMemory = "memory"
+	SessionStoreRedis  = "redis"
 )
 
 func main() {
 	flag.Parse() // for glog
 
-	// Create a shared Session Table
-	sessions := store.NewMemorySessionTable()
-
 	// Create the EAP AKA Provider service
 	srv, err := service.NewServiceWithOptions(registry.ModuleName, registry.AAA_SERVER)
 	if err != nil {
@@ -53,6 +55,9 @@ func main() {
 		glog.Warningf("Error getting AAA Server service configs: %s", err)
 		aaaConfigs = nil
 	}
+
+	// Create a shared Session Table
+	sessions := newSessionTable(aaaConfigs)
 	acct, _ := servicers.NewAccountingService(sessions, proto.Clone(aaaConfigs).(*mconfig.AAAConfig))
 	protos.RegisterAccountingServer(srv.GrpcServer, acct)
 	lteprotos.RegisterAbortSessionResponderServer(srv.GrpcServer, acct)
@@ -71,3 +76,21 @@ func main() {
 		glog.Fatalf("Error running AAA service: %s", err)
 	}
 }
+
+// newSessionTable creates the session table selected by the AAA configuration, it falls back to the in memory table
+// if Redis is not available
+func newSessionTable(cfg *mconfig.AAAConfig) aaa.SessionTable {
+	switch storeType := cfg.GetSessionStore(); storeType {
+	case "", SessionStoreMemory:
+	case SessionStoreRedis:
+		redisClient, err := object_store.NewRedisClient()
+		if err == nil {
+			glog.Info("Using Redis AAA session table")
+			return store.NewRedisSessionTable(redisClient)
+		}
+		glog.Errorf("Error creating Redis client for AAA session table: %v; using in memory session table", err)
+	default:
+		glog.Errorf("Unknown AAA session store type '%s'; using in memory session table", storeType)
+	}
+	return store.NewMemorySessionTable()
+}
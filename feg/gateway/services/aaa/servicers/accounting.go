@@ -51,12 +51,19 @@ const (
 
 // NewEapAuthenticator returns a new instance of EAP Auth service
 func NewAccountingService(sessions aaa.SessionTable, cfg *mconfig.AAAConfig) (*accountingService, error) {
-	return &accountingService{
+	srv := &accountingService{
 		sessions:    sessions,
 		config:      cfg,
 		sessionTout: GetIdleSessionTimeout(cfg),
 		dae:         dae.NewDAEServicer(cfg.GetRadiusConfig()),
-	}, nil
+	}
+	// Sessions restored from a persistent table need to be timed out by this service
+	if pst, ok := sessions.(aaa.PersistentSessionTable); ok {
+		if _, err := pst.RestoreTimeouts(srv.timeoutSessionNotifier); err != nil {
+			glog.Errorf("failed to restore persisted AAA sessions: %v", err)
+		}
+	}
+	return srv, nil
 }
 
 // Start implements Radius Acct-Status-Type: Start endpoint
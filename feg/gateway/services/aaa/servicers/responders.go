@@ -226,6 +226,42 @@ func (srv *accountingService) CancelLocation(
 	return res, nil
 }
 
+// InsertSubscriberData fulfils S6a's IDR, AAA does not keep S6a subscription data, the updated
+// data will be used by the next authentication of the UE
+func (srv *accountingService) InsertSubscriberData(
+	_ context.Context, req *fegprotos.InsertSubscriberDataRequest) (*fegprotos.InsertSubscriberDataAnswer, error) {
+
+	res := &fegprotos.InsertSubscriberDataAnswer{}
+	if req == nil {
+		return res, Errorf(codes.InvalidArgument, "Nil IDR Request")
+	}
+	imsi := req.GetUserName()
+	if len(imsi) < MinIMSILen {
+		return res, Errorf(codes.InvalidArgument, "Invalid IDR IMSI: %s", imsi)
+	}
+	glog.V(2).Infof("S6a IDR for IMSI: %s", imsi)
+	res.ErrorCode = fegprotos.ErrorCode_SUCCESS
+	return res, nil
+}
+
+// DeleteSubscriberData fulfils S6a's DSR, AAA does not keep S6a subscription data, the withdrawn
+// data will not be used by the next authentication of the UE
+func (srv *accountingService) DeleteSubscriberData(
+	_ context.Context, req *fegprotos.DeleteSubscriberDataRequest) (*fegprotos.DeleteSubscriberDataAnswer, error) {
+
+	res := &fegprotos.DeleteSubscriberDataAnswer{}
+	if req == nil {
+		return res, Errorf(codes.InvalidArgument, "Nil DSR Request")
+	}
+	imsi := req.GetUserName()
+	if len(imsi) < MinIMSILen {
+		return res, Errorf(codes.InvalidArgument, "Invalid DSR IMSI: %s", imsi)
+	}
+	glog.V(2).Infof("S6a DSR for IMSI: %s", imsi)
+	res.ErrorCode = fegprotos.ErrorCode_SUCCESS
+	return res, nil
+}
+
 // Reset fulfils S6a's RSR and disconnect UE from AAA if successful
 func (srv *accountingService) Reset(_ context.Context, req *fegprotos.ResetRequest) (*fegprotos.ResetAnswer, error) {
 	res := &fegprotos.ResetAnswer{}
@@ -71,3 +71,11 @@ type SessionTable interface {
 	// SetTimeout - [Re]sets the session's cleanup timeout to fire after tout duration
 	SetTimeout(sid string, tout time.Duration, callback TimeoutNotifier) bool
 }
+
+// PersistentSessionTable - SessionTable which keeps sessions in a persistent store & survives AAA server restarts
+type PersistentSessionTable interface {
+	SessionTable
+	// RestoreTimeouts loads all persisted sessions & re-arms their cleanup timeouts with the given callback,
+	// returns the number of restored sessions
+	RestoreTimeouts(callback TimeoutNotifier) (int, error)
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+
+	"magma/feg/gateway/object_store"
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+)
+
+const (
+	// RedisSessionsHash is the Redis hash of serialized AAA session contexts keyed by session ID
+	RedisSessionsHash = "aaa:sessions"
+	// RedisImsiSidsHash is the Redis hash of session IDs keyed by IMSI
+	RedisImsiSidsHash = "aaa:imsi_sids"
+	// RedisTimeoutsHash is the Redis hash of session expiration times (Unix ns) keyed by session ID
+	RedisTimeoutsHash = "aaa:session_timeouts"
+)
+
+// redisSession - AAA session backed by Redis, the session context is written back to Redis on Unlock
+type redisSession struct {
+	*protos.Context
+	imsi     string
+	owner    *redisSessionTable
+	timer    *sessionTimer // guarded by owner's lock
+	notifier aaa.TimeoutNotifier
+	mu       sync.Mutex
+	locked   int32 // set while the session's mutex is held, accessed atomically
+}
+
+// Lock - locks the Session's mutex & reloads the session context from Redis, so updates made by other
+// AAA instances are not lost
+func (s *redisSession) Lock() {
+	if s != nil {
+		s.mu.Lock()
+		atomic.StoreInt32(&s.locked, 1)
+		if s.owner != nil {
+			s.owner.reload(s)
+		}
+	}
+}
+
+// Unlock - persists the session context if the session is still in the table & unlocks the Session's mutex
+func (s *redisSession) Unlock() {
+	if s != nil {
+		if s.owner != nil {
+			s.owner.persist(s)
+		}
+		atomic.StoreInt32(&s.locked, 0)
+		s.mu.Unlock()
+	}
+}
+
+// GetCtx returns AAA Session Context
+func (s *redisSession) GetCtx() *protos.Context {
+	if s != nil {
+		return s.Context
+	}
+	return nil
+}
+
+// SetCtx sets AAA Session Context - must be called on a Locked session
+func (s *redisSession) SetCtx(pc *protos.Context) {
+	if s != nil {
+		s.Context = pc
+	}
+}
+
+// StopTimeout - stops the session's local timeout if possible, returns if the timeout was successfully stopped
+func (s *redisSession) StopTimeout() bool {
+	if s != nil && s.owner != nil {
+		s.owner.rwl.Lock()
+		defer s.owner.rwl.Unlock()
+		return s.stopTimerUnsafe()
+	}
+	return false
+}
+
+func (s *redisSession) stopTimerUnsafe() bool {
+	if s.timer != nil {
+		res := s.timer.Stop()
+		s.timer = nil
+		return res
+	}
+	return false
+}
+
+// redisSessionTable - SessionTable persisted in Redis, session timeouts are stored as absolute expiration
+// times, so a restarted or a failed over AAA server can restore the sessions and their pending timeouts
+type redisSessionTable struct {
+	sessions object_store.ObjectMap // *protos.Context by SID
+	sids     object_store.ObjectMap // SID by IMSI
+	timeouts *object_store.RedisMap // expiration time by SID
+	local    map[string]*redisSession
+	rwl      sync.RWMutex // R/W lock synchronizing local map & timers access
+}
+
+// NewRedisSessionTable - returns a new Redis backed session table using the given Redis client
+func NewRedisSessionTable(client object_store.RedisClient) aaa.PersistentSessionTable {
+	return &redisSessionTable{
+		sessions: object_store.NewRedisMap(client, RedisSessionsHash, contextSerializer, contextDeserializer),
+		sids:     object_store.NewRedisMap(client, RedisImsiSidsHash, stringSerializer, stringDeserializer),
+		timeouts: object_store.NewRedisMap(client, RedisTimeoutsHash, timeSerializer, timeDeserializer),
+		local:    map[string]*redisSession{},
+	}
+}
+
+// AddSession - adds a new session to the table & returns the newly created session pointer.
+// If a session with the same ID already is in the table - returns "Session with SID: XYZ already exist" as well as the
+// existing session.
+func (st *redisSessionTable) AddSession(
+	pc *protos.Context, tout time.Duration, notifier aaa.TimeoutNotifier, overwrite ...bool) (aaa.Session, error) {
+
+	if st == nil {
+		return nil, fmt.Errorf("Nil SessionTable")
+	}
+	if pc == nil {
+		return nil, fmt.Errorf("Nil Session Context")
+	}
+	sid := strings.TrimSpace(pc.SessionId)
+	if len(sid) == 0 {
+		return nil, fmt.Errorf("Empty Session Id")
+	}
+	if tout < aaa.MinimalSessionTimeout {
+		tout = aaa.MinimalSessionTimeout
+	}
+	imsi := pc.GetImsi()
+	msisdn := pc.GetMsisdn()
+	s := &redisSession{Context: pc, imsi: imsi, owner: st}
+
+	st.rwl.Lock()
+
+	isExistingSession := false
+	if oldSession := st.getSessionUnsafe(sid); oldSession != nil {
+		if len(overwrite) == 0 || !overwrite[0] {
+			st.rwl.Unlock()
+			return oldSession, fmt.Errorf("Session with SID: %s already exist", sid)
+		}
+		isExistingSession = true
+		oldImsi := oldSession.imsi
+		glog.Warningf("Session with SID: %s already exist, will overwrite. Old IMSI: %s, New IMSI: %s",
+			sid, oldImsi, imsi)
+		oldSession.stopTimerUnsafe()
+		if oldImsi != imsi {
+			isExistingSession = false
+			if oldSid, err := st.sids.Get(oldImsi); err == nil && oldSid == sid {
+				st.deleteUnsafe(st.sids, oldImsi)
+			}
+			updateSessionMetricsForRemovedSession(oldSession.GetApn(), oldImsi, sid, msisdn)
+		}
+	}
+	// Handle the case of old session with the same IMSI and different radius session ID (roaming?)
+	if oldSid, err := st.sids.Get(imsi); err == nil && oldSid != sid {
+		oldSessionId := oldSid.(string)
+		if oldImsiSession := st.getSessionUnsafe(oldSessionId); oldImsiSession != nil {
+			st.removeUnsafe(oldSessionId, oldImsiSession)
+			updateSessionMetricsForRemovedSession(oldImsiSession.GetApn(), imsi, oldSessionId, msisdn)
+			glog.Infof("old session with SID: %s found for IMSI: %s, will remove", oldSessionId, imsi)
+		}
+	}
+	if err := st.sessions.Set(sid, pc); err != nil {
+		st.rwl.Unlock()
+		return nil, fmt.Errorf("failed to store session with SID: %s; %v", sid, err)
+	}
+	if err := st.sids.Set(imsi, sid); err != nil {
+		glog.Errorf("failed to store SID: %s for IMSI: %s; %v", sid, imsi, err)
+	}
+	st.local[sid] = s
+	glog.V(1).Infof("setting timeout of %f seconds for session: %s", tout.Seconds(), sid)
+	st.setTimeoutUnsafe(sid, s, tout, notifier)
+	apn := s.GetApn()
+	st.rwl.Unlock()
+
+	if !isExistingSession {
+		updateSessionMetricsForNewSession(apn, imsi, sid, msisdn)
+	}
+	return s, nil
+}
+
+// GetSession returns session corresponding to the given sid or nil if not found
+func (st *redisSessionTable) GetSession(sid string) aaa.Session {
+	if st == nil {
+		return nil
+	}
+	st.rwl.Lock()
+	s := st.getSessionUnsafe(sid)
+	st.rwl.Unlock()
+	if s == nil {
+		return nil
+	}
+	return s
+}
+
+// FindSession returns session ID corresponding to the given IMSI or empty string if not found
+func (st *redisSessionTable) FindSession(imsi string) string {
+	if st != nil {
+		if sid, err := st.sids.Get(imsi); err == nil {
+			return sid.(string)
+		}
+	}
+	return ""
+}
+
+// GetSessionByImsi returns session corresponding to the given IMSI or nil if not found
+func (st *redisSessionTable) GetSessionByImsi(imsi string) aaa.Session {
+	if sid := st.FindSession(imsi); len(sid) > 0 {
+		return st.GetSession(sid)
+	}
+	return nil
+}
+
+// RemoveSession - removes the session with the given SID and returns it
+func (st *redisSessionTable) RemoveSession(sid string) aaa.Session {
+	if st == nil {
+		return nil
+	}
+	st.rwl.Lock()
+	s := st.getSessionUnsafe(sid)
+	if s != nil {
+		st.removeUnsafe(sid, s)
+	}
+	st.rwl.Unlock()
+	if s == nil {
+		return nil
+	}
+	updateSessionMetricsForRemovedSession(s.GetApn(), s.GetImsi(), sid, s.GetMsisdn())
+	return s
+}
+
+// SetTimeout - [Re]sets the session's cleanup timeout to fire after tout duration
+func (st *redisSessionTable) SetTimeout(sid string, tout time.Duration, notifier aaa.TimeoutNotifier) bool {
+	var res bool
+	if tout > 0 && st != nil && len(sid) > 0 {
+		st.rwl.Lock()
+		if s := st.getSessionUnsafe(sid); s != nil {
+			st.setTimeoutUnsafe(sid, s, tout, notifier)
+			res = true
+		}
+		st.rwl.Unlock()
+	}
+	return res
+}
+
+// RestoreTimeouts loads all sessions persisted in Redis & re-arms their timeouts using given notifier.
+// Sessions with already expired timeouts will be timed out right away.
+func (st *redisSessionTable) RestoreTimeouts(notifier aaa.TimeoutNotifier) (int, error) {
+	if st == nil {
+		return 0, fmt.Errorf("Nil SessionTable")
+	}
+	all, err := st.sessions.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	deadlines, err := st.timeouts.GetAll()
+	if err != nil {
+		return 0, err
+	}
+	var restored int
+	now := time.Now()
+	st.rwl.Lock()
+	for sid, ctx := range all {
+		pc := ctx.(*protos.Context)
+		s, found := st.local[sid]
+		if !found {
+			s = &redisSession{Context: pc, imsi: pc.GetImsi(), owner: st}
+			st.local[sid] = s
+			updateSessionMetricsForNewSession(pc.GetApn(), pc.GetImsi(), sid, pc.GetMsisdn())
+		}
+		tout := aaa.DefaultSessionTimeout
+		if deadline, ok := deadlines[sid]; ok {
+			tout = deadline.(time.Time).Sub(now)
+		}
+		if tout < aaa.MinimalSessionTimeout {
+			tout = aaa.MinimalSessionTimeout
+		}
+		st.setTimeoutUnsafe(sid, s, tout, notifier)
+		restored++
+	}
+	st.rwl.Unlock()
+	glog.Infof("restored %d AAA sessions from Redis", restored)
+	return restored, nil
+}
+
+// getSessionUnsafe returns the session for the given SID, the session context is always loaded from Redis,
+// the local session is created if it was not known to this table (session added by another AAA instance).
+// The context of a locked session is left to its holder, which reloaded it when locking the session.
+// The local session is only removed if Redis no longer has it, if Redis can't be read the local session is
+// returned with its cached context.
+func (st *redisSessionTable) getSessionUnsafe(sid string) *redisSession {
+	ctx, err := st.sessions.Get(sid)
+	if err == redis.Nil {
+		if s, found := st.local[sid]; found {
+			glog.V(1).Infof("session with SID: %s is no longer persisted, removing local session", sid)
+			s.stopTimerUnsafe()
+			delete(st.local, sid)
+		}
+		return nil
+	}
+	if err != nil {
+		s, found := st.local[sid]
+		glog.Errorf("failed to load session with SID: %s from Redis, using cached session: %t; %v", sid, found, err)
+		if !found {
+			return nil
+		}
+		return s
+	}
+	pc := ctx.(*protos.Context)
+	s, found := st.local[sid]
+	if !found {
+		s = &redisSession{Context: pc, imsi: pc.GetImsi(), owner: st}
+		st.local[sid] = s
+	} else if atomic.LoadInt32(&s.locked) == 0 {
+		s.Context = pc
+	}
+	return s
+}
+
+// reload replaces the context of the locked session with the one persisted in Redis
+func (st *redisSessionTable) reload(s *redisSession) {
+	sid := s.GetSessionId()
+	ctx, err := st.sessions.Get(sid)
+	if err != nil {
+		glog.V(1).Infof("failed to reload session with SID: %s from Redis; %v", sid, err)
+		return
+	}
+	s.Context = ctx.(*protos.Context)
+}
+
+// removeUnsafe removes the session from Redis & the local map
+func (st *redisSessionTable) removeUnsafe(sid string, s *redisSession) {
+	s.stopTimerUnsafe()
+	delete(st.local, sid)
+	st.deleteUnsafe(st.sessions, sid)
+	st.deleteUnsafe(st.timeouts, sid)
+	if oldSid, err := st.sids.Get(s.imsi); err == nil && oldSid == sid {
+		st.deleteUnsafe(st.sids, s.imsi)
+	}
+}
+
+func (st *redisSessionTable) deleteUnsafe(m object_store.ObjectMap, key string) {
+	if err := m.Delete(key); err != nil {
+		glog.Errorf("failed to delete AAA session key '%s' from Redis: %v", key, err)
+	}
+}
+
+// persist writes the session's context to Redis if the session is still owned by the table
+func (st *redisSessionTable) persist(s *redisSession) {
+	sid := s.GetSessionId()
+	st.rwl.RLock()
+	defer st.rwl.RUnlock()
+	if ls, found := st.local[sid]; !found || ls != s {
+		return
+	}
+	if err := st.sessions.Set(sid, s.Context); err != nil {
+		glog.Errorf("failed to persist session with SID: %s; %v", sid, err)
+	}
+}
+
+func (st *redisSessionTable) setTimeoutUnsafe(sid string, s *redisSession, tout time.Duration, notifier aaa.TimeoutNotifier) {
+	if err := st.timeouts.Set(sid, time.Now().Add(tout)); err != nil {
+		glog.Errorf("failed to persist timeout for session with SID: %s; %v", sid, err)
+	}
+	st.armTimerUnsafe(sid, s, tout, notifier)
+}
+
+// sessionTimer identifies the currently armed session timer, stale timers firing after being re-armed are ignored
+type sessionTimer struct {
+	*time.Timer
+}
+
+func (st *redisSessionTable) armTimerUnsafe(sid string, s *redisSession, tout time.Duration, notifier aaa.TimeoutNotifier) {
+	s.stopTimerUnsafe()
+	s.notifier = notifier
+	timer := &sessionTimer{}
+	timer.Timer = time.AfterFunc(tout, func() { st.cleanupTimer(sid, s, timer) })
+	s.timer = timer
+}
+
+// cleanupTimer removes the timed out session, the persisted expiration time is checked first and
+// the timer is re-armed if the timeout was extended by another AAA instance. Every AAA instance with the
+// session arms a timer, so the timeout is claimed by deleting its expiration time from Redis and only the
+// instance which deleted it notifies the timeout.
+func (st *redisSessionTable) cleanupTimer(sid string, s *redisSession, timer *sessionTimer) {
+	st.rwl.Lock()
+	if ls, found := st.local[sid]; !found || ls != s || s.timer != timer {
+		st.rwl.Unlock()
+		return
+	}
+	if deadline, err := st.timeouts.Get(sid); err == nil {
+		if remaining := time.Until(deadline.(time.Time)); remaining >= aaa.MinimalSessionTimeout {
+			st.armTimerUnsafe(sid, s, remaining, s.notifier)
+			st.rwl.Unlock()
+			return
+		}
+	}
+	s.timer = nil
+	claimed, err := st.timeouts.DeleteExisting(sid)
+	if err != nil {
+		glog.Errorf("failed to claim timeout of session with SID: %s; %v", sid, err)
+	}
+	if !claimed {
+		// Timed out by another AAA instance, only drop the local session
+		delete(st.local, sid)
+		st.rwl.Unlock()
+		return
+	}
+	notifier := s.notifier
+	st.removeUnsafe(sid, s)
+	st.rwl.Unlock()
+
+	var notifyResult error
+	if notifier != nil {
+		notifyResult = notifier(s)
+	}
+	glog.Infof(
+		"Timed out session '%s' for SessionId: %s; IMSI: %s; Identity: %s; MAC: %s; IP: %s; notify result: %v",
+		sid, s.GetSessionId(), s.GetImsi(), s.GetIdentity(), s.GetMacAddr(), s.GetIpAddr(), notifyResult)
+
+	updateSessionMetricsForTimedOutSession(s.GetApn(), s.GetImsi(), s.GetSessionId(), s.GetMsisdn())
+}
+
+func contextSerializer(object interface{}) (string, error) {
+	pc, ok := object.(*protos.Context)
+	if !ok {
+		return "", fmt.Errorf("Could not cast object to AAA Context")
+	}
+	bytes, err := proto.Marshal(pc)
+	if err != nil {
+		return "", fmt.Errorf("Could not marshal AAA Context: %v", err)
+	}
+	return string(bytes), nil
+}
+
+func contextDeserializer(serialized string) (interface{}, error) {
+	pc := &protos.Context{}
+	if err := proto.Unmarshal([]byte(serialized), pc); err != nil {
+		return nil, err
+	}
+	return pc, nil
+}
+
+func stringSerializer(object interface{}) (string, error) {
+	str, ok := object.(string)
+	if !ok {
+		return "", fmt.Errorf("Could not cast object to string")
+	}
+	return str, nil
+}
+
+func stringDeserializer(serialized string) (interface{}, error) {
+	return serialized, nil
+}
+
+func timeSerializer(object interface{}) (string, error) {
+	t, ok := object.(time.Time)
+	if !ok {
+		return "", fmt.Errorf("Could not cast object to time")
+	}
+	return strconv.FormatInt(t.UnixNano(), 10), nil
+}
+
+func timeDeserializer(serialized string) (interface{}, error) {
+	ns, err := strconv.ParseInt(serialized, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return time.Unix(0, ns), nil
+}
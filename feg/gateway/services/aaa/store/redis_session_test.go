@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/gateway/services/aaa"
+	"magma/feg/gateway/services/aaa/protos"
+	"magma/feg/gateway/services/aaa/store"
+)
+
+type mockRedisClient struct {
+	sync.Mutex
+	hashes map[string]map[string]string
+	// getErr is returned by HGet if set, e.g. to simulate Redis being unreachable
+	getErr error
+}
+
+func newMockRedisClient() *mockRedisClient {
+	return &mockRedisClient{hashes: map[string]map[string]string{}}
+}
+
+func (client *mockRedisClient) HSet(hash string, field string, value string) error {
+	client.Lock()
+	defer client.Unlock()
+	if _, ok := client.hashes[hash]; !ok {
+		client.hashes[hash] = map[string]string{}
+	}
+	client.hashes[hash][field] = value
+	return nil
+}
+
+func (client *mockRedisClient) HGet(hash string, field string) (string, error) {
+	client.Lock()
+	defer client.Unlock()
+	if client.getErr != nil {
+		return "", client.getErr
+	}
+	str, ok := client.hashes[hash][field]
+	if !ok {
+		return "", redis.Nil
+	}
+	return str, nil
+}
+
+func (client *mockRedisClient) setGetErr(err error) {
+	client.Lock()
+	defer client.Unlock()
+	client.getErr = err
+}
+
+func (client *mockRedisClient) HGetAll(hash string) (map[string]string, error) {
+	client.Lock()
+	defer client.Unlock()
+	res := map[string]string{}
+	for k, v := range client.hashes[hash] {
+		res[k] = v
+	}
+	return res, nil
+}
+
+func (client *mockRedisClient) HDel(hash string, field string) error {
+	client.Lock()
+	defer client.Unlock()
+	delete(client.hashes[hash], field)
+	return nil
+}
+
+func (client *mockRedisClient) HDelExisting(hash string, field string) (bool, error) {
+	client.Lock()
+	defer client.Unlock()
+	_, ok := client.hashes[hash][field]
+	delete(client.hashes[hash], field)
+	return ok, nil
+}
+
+func TestRedisSessionTable(t *testing.T) {
+	client := newMockRedisClient()
+	st := store.NewRedisSessionTable(client)
+
+	sid := aaa.CreateSessionId()
+	imsi := "123456789012345"
+	s, err := st.AddSession(&protos.Context{SessionId: sid, Imsi: imsi, Apn: "magma.ipv4"}, time.Minute, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+
+	_, err = st.AddSession(&protos.Context{SessionId: sid, Imsi: imsi}, time.Minute, nil)
+	assert.Error(t, err)
+
+	assert.Equal(t, sid, st.FindSession(imsi))
+	s = st.GetSessionByImsi(imsi)
+	assert.NotNil(t, s)
+
+	// Context changes are persisted on Unlock & visible to other tables sharing the same Redis
+	s.Lock()
+	s.GetCtx().AcctSessionId = "acct-session-1"
+	s.Unlock()
+
+	other := store.NewRedisSessionTable(client)
+	otherSession := other.GetSession(sid)
+	assert.NotNil(t, otherSession)
+	assert.Equal(t, "acct-session-1", otherSession.GetCtx().GetAcctSessionId())
+	assert.Equal(t, sid, other.FindSession(imsi))
+
+	// Updates by the other table are seen by the table which cached the session
+	otherSession.Lock()
+	otherSession.GetCtx().AcctSessionId = "acct-session-2"
+	otherSession.Unlock()
+	assert.Equal(t, "acct-session-2", st.GetSession(sid).GetCtx().GetAcctSessionId())
+	s.Lock()
+	assert.Equal(t, "acct-session-2", s.GetCtx().GetAcctSessionId())
+	s.Unlock()
+
+	// New session for the same IMSI replaces the old one
+	sid2 := aaa.CreateSessionId()
+	_, err = st.AddSession(&protos.Context{SessionId: sid2, Imsi: imsi}, time.Minute, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sid2, st.FindSession(imsi))
+	assert.Nil(t, st.GetSession(sid))
+	assert.Nil(t, other.GetSession(sid))
+
+	rs := st.RemoveSession(sid2)
+	assert.NotNil(t, rs)
+	assert.Nil(t, st.GetSession(sid2))
+	assert.Equal(t, "", st.FindSession(imsi))
+	assert.Nil(t, st.RemoveSession(sid2))
+}
+
+func TestRedisSessionTableRedisError(t *testing.T) {
+	client := newMockRedisClient()
+	st := store.NewRedisSessionTable(client)
+
+	sid := aaa.CreateSessionId()
+	imsi := "123456789012349"
+	_, err := st.AddSession(&protos.Context{SessionId: sid, Imsi: imsi, Apn: "magma.ipv4"}, time.Minute, nil)
+	assert.NoError(t, err)
+
+	// A failure to read Redis keeps the local session
+	client.setGetErr(fmt.Errorf("connection refused"))
+	s := st.GetSession(sid)
+	assert.NotNil(t, s)
+	assert.Equal(t, "magma.ipv4", s.GetCtx().GetApn())
+	assert.Nil(t, store.NewRedisSessionTable(client).GetSession(sid))
+
+	client.setGetErr(nil)
+	assert.NotNil(t, st.GetSession(sid))
+
+	// A session which is no longer persisted is removed
+	assert.NoError(t, client.HDel(store.RedisSessionsHash, sid))
+	assert.Nil(t, st.GetSession(sid))
+	client.setGetErr(fmt.Errorf("connection refused"))
+	assert.Nil(t, st.GetSession(sid))
+}
+
+func TestRedisSessionTableTimeout(t *testing.T) {
+	client := newMockRedisClient()
+	st := store.NewRedisSessionTable(client)
+
+	var done callbackDone
+	sid := aaa.CreateSessionId()
+	imsi := "123456789012346"
+	_, err := st.AddSession(&protos.Context{SessionId: sid, Imsi: imsi}, time.Millisecond*20, done.timeoutCallback)
+	assert.NoError(t, err)
+
+	// Extend the timeout
+	assert.True(t, st.SetTimeout(sid, time.Millisecond*200, done.timeoutCallback))
+	time.Sleep(time.Millisecond * 50)
+	assert.Equal(t, int32(0), atomic.LoadInt32((*int32)(&done)))
+	assert.NotNil(t, st.GetSession(sid))
+
+	time.Sleep(time.Millisecond * 250)
+	assert.Equal(t, int32(1), atomic.LoadInt32((*int32)(&done)))
+	assert.Nil(t, st.GetSession(sid))
+	assert.Equal(t, "", st.FindSession(imsi))
+}
+
+func TestRedisSessionTableRestore(t *testing.T) {
+	client := newMockRedisClient()
+	st := store.NewRedisSessionTable(client)
+
+	sid := aaa.CreateSessionId()
+	imsi := "123456789012347"
+	_, err := st.AddSession(&protos.Context{SessionId: sid, Imsi: imsi}, time.Millisecond*100, nil)
+	assert.NoError(t, err)
+	// Simulate AAA restart, the original table's timers are no longer relevant
+	st.GetSession(sid).StopTimeout()
+
+	var done callbackDone
+	restarted := store.NewRedisSessionTable(client)
+	n, err := restarted.RestoreTimeouts(done.timeoutCallback)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, sid, restarted.FindSession(imsi))
+
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, int32(1), atomic.LoadInt32((*int32)(&done)))
+	assert.Nil(t, restarted.GetSession(sid))
+}
+
+func TestRedisSessionTableTimeoutNotifiedOnce(t *testing.T) {
+	client := newMockRedisClient()
+	st := store.NewRedisSessionTable(client)
+	other := store.NewRedisSessionTable(client)
+
+	var done callbackDone
+	sid := aaa.CreateSessionId()
+	imsi := "123456789012348"
+	_, err := st.AddSession(&protos.Context{SessionId: sid, Imsi: imsi}, time.Millisecond*50, done.timeoutCallback)
+	assert.NoError(t, err)
+	// Both tables arm a timer for the session
+	assert.True(t, other.SetTimeout(sid, time.Millisecond*50, done.timeoutCallback))
+
+	time.Sleep(time.Millisecond * 200)
+	assert.Equal(t, int32(1), atomic.LoadInt32((*int32)(&done)))
+	assert.Nil(t, st.GetSession(sid))
+	assert.Nil(t, other.GetSession(sid))
+}
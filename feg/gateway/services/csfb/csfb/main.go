@@ -16,6 +16,7 @@ package main
 import (
 	"flag"
 	"io"
+	"sync/atomic"
 	"time"
 
 	"magma/feg/cloud/go/protos"
@@ -42,73 +43,91 @@ func main() {
 		glog.Fatalf("Error creating CSFB service: %s", err)
 	}
 
-	vlrConn, err := servicers.CreateVlrSCTPconnection(servicers.GetCsfbConfig())
+	pool, err := servicers.CreateVLRPool(servicers.GetCsfbConfig())
 	if err != nil {
-		glog.Fatalf("Failed to create VLR connection: %s", err)
+		glog.Fatalf("Failed to create VLR pool: %s", err)
 	}
 
-	servicer, err := servicers.NewCsfbServer(vlrConn)
+	servicer, err := servicers.NewCsfbPoolServer(pool)
 	if err != nil {
 		glog.Fatalf("Failed to create CSFB service: %v", err)
 	}
 	protos.RegisterCSFBFedGWServiceServer(srv.GrpcServer, servicer)
 
-	defer vlrConn.CloseConn() // attempt to close from main thread if GRPC srv errors out
+	var failedAssociations int32
+	for _, vlr := range pool.VLRs() {
+		// attempt to close from main thread if GRPC srv errors out
+		defer vlr.Conn.CloseConn()
 
-	go func() {
-		for retries := uint(0); retries <= MaxVLRConnectAttempts; retries++ {
-			err := vlrConn.EstablishConn()
-			vlrIPs, vlrPort := vlrConn.GetVlrIPandPort()
-			vlrIP := vlrIPs[0]
-			if err != nil {
-				glog.Errorf("Error connecting to VLR Server @ %s:%d; %s; attempt #%d", vlrIP, vlrPort, err, retries)
-				time.Sleep(time.Second * time.Duration(retries))
-				continue
+		go func(vlr *servicers.VLR) {
+			runAssociation(pool, servicer, vlr)
+			pool.SetAssociationState(vlr, false)
+			if int(atomic.AddInt32(&failedAssociations, 1)) == len(pool.VLRs()) {
+				glog.Fatalf("Exceeded Maximum VLR Connect Retry Attempts - %d", MaxVLRConnectAttempts)
 			}
-			var receivedMsg []byte
-			for {
-				// blocked until a message is received
-				receivedMsg, err = vlrConn.Receive()
-				if err != nil {
-					if err == io.EOF {
-						glog.Errorf("Connection to %s:%d is closed by the VLR server", vlrIP, vlrPort)
-					} else {
-						glog.Errorf("Failed to receive message from %s:%d: %s", vlrIP, vlrPort, err)
-					}
-					clerr := vlrConn.CloseConn()
-					if clerr != nil {
-						glog.Errorf("Error closing VLR connection: %s", err)
-					}
-					break // break out & try to reconnect
-				}
-				msgType, decodedMsg, err := message.SGsMessageDecoder(receivedMsg)
-				if err != nil {
-					glog.Errorf("Failed to decode VLR message: %s", err)
-					continue
+			glog.Errorf("Exceeded Maximum VLR %s Connect Retry Attempts - %d", vlr.Name, MaxVLRConnectAttempts)
+		}(vlr)
+	}
+
+	// Run the service
+	err = srv.Run()
+	if err != nil {
+		glog.Errorf("Error running service: %s", err)
+	}
+}
+
+// runAssociation maintains the SGs association with the VLR, forwarding the
+// messages it sends to the gateway, until the connect attempts are exhausted
+func runAssociation(pool *servicers.VLRPool, servicer *servicers.CsfbServer, vlr *servicers.VLR) {
+	for retries := uint(0); retries <= MaxVLRConnectAttempts; retries++ {
+		err := vlr.Conn.EstablishConn()
+		if err != nil {
+			pool.SetAssociationState(vlr, false)
+			glog.Errorf("Error connecting to VLR %s; %s; attempt #%d", vlr.Name, err, retries)
+			time.Sleep(time.Second * time.Duration(retries))
+			continue
+		}
+		pool.SetAssociationState(vlr, true)
+		var receivedMsg []byte
+		for {
+			// blocked until a message is received
+			receivedMsg, err = vlr.Conn.Receive()
+			if err != nil {
+				if err == io.EOF {
+					glog.Errorf("Connection to VLR %s is closed by the VLR server", vlr.Name)
+				} else {
+					glog.Errorf("Failed to receive message from VLR %s: %s", vlr.Name, err)
 				}
-				if msgType == decode.SGsAPResetIndication {
-					glog.V(2).Info("Sending Reset Ack to VLR")
-					err = servicer.SendResetAck()
-					if err != nil {
-						glog.Errorf(
-							"Failed to send Reset Ack to VLR: %s",
-							err,
-						)
-					}
+				pool.SetAssociationState(vlr, false)
+				clerr := vlr.Conn.CloseConn()
+				if clerr != nil {
+					glog.Errorf("Error closing VLR %s connection: %s", vlr.Name, clerr)
 				}
-				_, err = csfb.SendSGsMessageToGateway(msgType, decodedMsg)
+				break // break out & try to reconnect
+			}
+			msgType, decodedMsg, err := message.SGsMessageDecoder(receivedMsg)
+			if err != nil {
+				glog.Errorf("Failed to decode VLR %s message: %s", vlr.Name, err)
+				continue
+			}
+			if msgType == decode.SGsAPResetIndication {
+				glog.V(2).Infof("Sending Reset Ack to VLR %s", vlr.Name)
+				err = servicer.SendResetAck(vlr)
 				if err != nil {
-					glog.Errorf("Failed to send message to gateway: %s", err)
-					continue
+					glog.Errorf(
+						"Failed to send Reset Ack to VLR %s: %s",
+						vlr.Name,
+						err,
+					)
 				}
+			} else {
+				pool.Observe(vlr, decodedMsg)
+			}
+			_, err = csfb.SendSGsMessageToGateway(msgType, decodedMsg)
+			if err != nil {
+				glog.Errorf("Failed to send message to gateway: %s", err)
+				continue
 			}
 		}
-		glog.Fatalf("Exceeded Maximum VLR Connect Retry Attempts - %d", MaxVLRConnectAttempts)
-	}()
-
-	// Run the service
-	err = srv.Run()
-	if err != nil {
-		glog.Errorf("Error running service: %s", err)
 	}
 }
@@ -39,7 +39,7 @@ const (
 func GetCsfbConfig() *mconfProtos.CsfbConfig {
 	config := &mconfProtos.CsfbConfig{}
 	err := fegMconfig.GetServiceConfigs(CsfbServiceName, config)
-	if err != nil || config.Client == nil {
+	if err != nil || (config.Client == nil && len(config.VlrPool) == 0) {
 		glog.V(2).Infof("%s Managed Configs Load Error: %v", CsfbServiceName, err)
 		return envOrDefaultConfig()
 	}
@@ -26,7 +26,7 @@ import (
 type PortNumber = int
 
 type CsfbServer struct {
-	Conn            ClientConnectionInterface
+	Pool            *VLRPool
 	ReceivingBuffer SafeBuffer
 }
 
@@ -64,8 +64,19 @@ func CreateVlrSCTPconnection(config *mconfig.CsfbConfig) (*SCTPClientConnection,
 	return NewSCTPClientConnection(vlrSCTP, localSCTP)
 }
 
+// NewCsfbServer creates a CSFB server connected to a single VLR
 func NewCsfbServer(ConnectionInterface ClientConnectionInterface) (*CsfbServer, error) {
-	return &CsfbServer{Conn: ConnectionInterface}, nil
+	pool, err := NewVLRPool(0, &VLR{Name: DefaultVLRName, Conn: ConnectionInterface})
+	if err != nil {
+		return nil, err
+	}
+	return NewCsfbPoolServer(pool)
+}
+
+// NewCsfbPoolServer creates a CSFB server routing the messages of each UE to
+// its VLR of the pool
+func NewCsfbPoolServer(pool *VLRPool) (*CsfbServer, error) {
+	return &CsfbServer{Pool: pool}, nil
 }
 
 // AlertAc sends SGsAP-ALERT-ACK to VLR
@@ -79,7 +90,7 @@ func (srv *CsfbServer) AlertAc(
 		glog.Errorf("Failed to encode SGsAP-ALERT-ACK: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // AlertRej sends SGsAP-ALERT-REJECT to VLR to indicate that the MME
@@ -93,7 +104,7 @@ func (srv *CsfbServer) AlertRej(
 		glog.Errorf("Failed to encode SGsAP-ALERT-REJECT: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // EPSDetachInd sends SGsAP-EPS-DETACH-INDICATION to VLR
@@ -107,7 +118,7 @@ func (srv *CsfbServer) EPSDetachInd(
 		glog.Errorf("Failed to encode SGsAP-EPS-DETACH-INDICATION: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // IMSIDetachInd sends SGsAP-IMSI-DETACH-INDICATION to VLR
@@ -121,7 +132,7 @@ func (srv *CsfbServer) IMSIDetachInd(
 		glog.Errorf("Failed to encode SGsAP-IMSI-DETACH-INDICATION: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // LocationUpdateReq sends SGsAP-LOCATION-UPDATE-REQUEST to VLR either
@@ -135,7 +146,7 @@ func (srv *CsfbServer) LocationUpdateReq(
 		glog.Errorf("Failed to encode SGsAP-LOCATION-UPDATE-REQUEST: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, req.NewLocationAreaIdentifier, encodedMsg)
 }
 
 // PagingRej sends SGsAP-PAGING-REJECT to VLR to indicate that
@@ -149,7 +160,7 @@ func (srv *CsfbServer) PagingRej(
 		glog.Errorf("Failed to encode SGsAP-PAGING-REJECT: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // ServiceReq sends SGsAP-SERVICE-REQUEST to VLR as a response
@@ -166,7 +177,7 @@ func (srv *CsfbServer) ServiceReq(
 		glog.Errorf("Failed to encode SGsAP-SERVICE-REQUEST: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // TMSIReallocationComp sends SGsAP-TMSI-REALLOCATION-COMPLETE to VLR
@@ -180,7 +191,7 @@ func (srv *CsfbServer) TMSIReallocationComp(
 		glog.Errorf("Failed to encode SGsAP-TMSI-REALLOCATION-COMPLETE: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // UEActivityInd sends SGsAP-UE-ACTIVITY-INDICATION to VLR
@@ -194,7 +205,7 @@ func (srv *CsfbServer) UEActivityInd(
 		glog.Errorf("Failed to encode SGsAP-UE-ACTIVITY-INDICATION: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // UEUnreach sends SGsAP-UE-UNREACHABLE to VLR to indicate that,
@@ -209,7 +220,7 @@ func (srv *CsfbServer) UEUnreach(
 		glog.Errorf("Failed to encode SGsAP-UE-UNREACHABLE: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // Uplink sends SGsAP-UPLINK-UNITDATA to VLR
@@ -223,7 +234,7 @@ func (srv *CsfbServer) Uplink(
 		glog.Errorf("Failed to encode SGsAP-UPLINK-UNITDATA: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
 // MMEResetAck sends SGsAP-RESET-ACK to VLR to acknowledge
@@ -238,7 +249,7 @@ func (srv *CsfbServer) MMEResetAck(
 		glog.Errorf("Failed to encode SGsAP-RESET-ACK: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.SendToVLR(req.VlrName, encodedMsg)
 }
 
 // MMEResetIndication sends SGsAP-RESET-INDICATION to VLR
@@ -253,7 +264,7 @@ func (srv *CsfbServer) MMEResetIndication(
 		glog.Errorf("Failed to encode SGsAP-RESET-INDICATION: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	return &orcprotos.Void{}, srv.Pool.SendToVLR(req.VlrName, encodedMsg)
 }
 
 // MMEStatus sends SGsAP-STATUS to VLR to indicate an error
@@ -266,14 +277,18 @@ func (srv *CsfbServer) MMEStatus(
 		glog.Errorf("Failed to encode SGsAP-STATUS: %s", err)
 		return &orcprotos.Void{}, err
 	}
-	return &orcprotos.Void{}, srv.Conn.Send(encodedMsg)
+	if req.Imsi == "" {
+		return &orcprotos.Void{}, srv.Pool.SendToVLR("", encodedMsg)
+	}
+	return &orcprotos.Void{}, srv.Pool.Send(req.Imsi, nil, encodedMsg)
 }
 
-// SendResetAck sends SGsAP-RESET-ACK to VLR
+// SendResetAck sends SGsAP-RESET-ACK to the VLR which was reset
 // Different from the MMEResetAck invoked by the gateway through GRPC,
 // SendResetAck is invoked in the FeG as soon as the SGsAP-RESET-INDICATION
-// is received and decoded.
-func (srv *CsfbServer) SendResetAck() error {
+// is received and decoded. The UEs served by the VLR are released.
+func (srv *CsfbServer) SendResetAck(vlr *VLR) error {
+	srv.Pool.Reset(vlr)
 	req, err := constructResetAck()
 	if err != nil {
 		glog.Errorf("Failed to construct SGsAP-RESET-ACK: %s", err)
@@ -284,7 +299,7 @@ func (srv *CsfbServer) SendResetAck() error {
 		glog.Errorf("Failed to encode SGsAP-RESET-ACK: %s", err)
 		return err
 	}
-	return srv.Conn.Send(encodedMsg)
+	return vlr.Conn.Send(encodedMsg)
 }
 
 func constructResetAck() (*protos.ResetAck, error) {
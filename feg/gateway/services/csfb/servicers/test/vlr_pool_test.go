@@ -0,0 +1,352 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/services/csfb/servicers"
+	"magma/feg/gateway/services/csfb/servicers/encode/message"
+	"magma/feg/gateway/services/csfb/servicers/mocks"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/context"
+)
+
+func newTestVLR(name string) *servicers.VLR {
+	conn := &mocks.ClientConnectionInterface{}
+	conn.On("Send", mock.Anything).Return(nil)
+	return &servicers.VLR{Name: name, Conn: conn}
+}
+
+func sentMessages(vlr *servicers.VLR) int {
+	conn := vlr.Conn.(*mocks.ClientConnectionInterface)
+	count := 0
+	for _, call := range conn.Calls {
+		if call.Method == "Send" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestVLRPool_HashDistribution(t *testing.T) {
+	vlr1, vlr2, vlr3 := newTestVLR("vlr1"), newTestVLR("vlr2"), newTestVLR("vlr3")
+	pool, err := servicers.NewVLRPool(0, vlr1, vlr2, vlr3)
+	assert.NoError(t, err)
+
+	selected := map[string]*servicers.VLR{}
+	counts := map[*servicers.VLR]int{}
+	for i := 0; i < 300; i++ {
+		imsi := fmt.Sprintf("00101%010d", i)
+		vlr, err := pool.SelectVLR(imsi, nil)
+		assert.NoError(t, err)
+		selected[imsi] = vlr
+		counts[vlr]++
+
+		// the selection is sticky
+		again, err := pool.SelectVLR(imsi, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, vlr, again)
+	}
+	for _, vlr := range []*servicers.VLR{vlr1, vlr2, vlr3} {
+		assert.True(t, counts[vlr] > 50, "VLR %s got %d UEs", vlr.Name, counts[vlr])
+	}
+
+	// UEs of a VLR whose association drops are redistributed, the other UEs stay
+	pool.SetAssociationState(vlr2, false)
+	for imsi, previous := range selected {
+		vlr, err := pool.SelectVLR(imsi, nil)
+		assert.NoError(t, err)
+		assert.NotEqual(t, vlr2, vlr)
+		if previous != vlr2 {
+			assert.Equal(t, previous, vlr)
+		}
+	}
+
+	// UEs moved away stay with their new VLR once the association is back up
+	pool.SetAssociationState(vlr2, true)
+	for imsi, previous := range selected {
+		vlr, err := pool.SelectVLR(imsi, nil)
+		assert.NoError(t, err)
+		if previous == vlr2 {
+			assert.NotEqual(t, vlr2, vlr)
+		}
+	}
+
+	pool.SetAssociationState(vlr1, false)
+	pool.SetAssociationState(vlr2, false)
+	pool.SetAssociationState(vlr3, false)
+	_, err = pool.SelectVLR("001010000000001", nil)
+	assert.Error(t, err)
+}
+
+func TestVLRPool_Weights(t *testing.T) {
+	heavy, light := newTestVLR("heavy"), newTestVLR("light")
+	heavy.Weight = 9
+	pool, err := servicers.NewVLRPool(0, heavy, light)
+	assert.NoError(t, err)
+
+	heavyCount := 0
+	for i := 0; i < 1000; i++ {
+		vlr, err := pool.SelectVLR(fmt.Sprintf("00101%010d", i), nil)
+		assert.NoError(t, err)
+		if vlr == heavy {
+			heavyCount++
+		}
+	}
+	assert.InDelta(t, 900, heavyCount, 50)
+}
+
+func TestVLRPool_LocationAreaRouting(t *testing.T) {
+	lai1 := []byte{0x00, 0xf1, 0x10, 0x00, 0x01}
+	lai2 := []byte{0x00, 0xf1, 0x10, 0x00, 0x02}
+	vlr1, vlr2, vlrAll := newTestVLR("vlr1"), newTestVLR("vlr2"), newTestVLR("all")
+	vlr1.LocationAreas = [][]byte{lai1}
+	vlr2.LocationAreas = [][]byte{lai2}
+	pool, err := servicers.NewVLRPool(0, vlr1, vlr2, vlrAll)
+	assert.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		vlr, err := pool.SelectVLR(fmt.Sprintf("00101%010d", i), lai1)
+		assert.NoError(t, err)
+		assert.Equal(t, vlr1, vlr)
+		vlr, err = pool.SelectVLR(fmt.Sprintf("00102%010d", i), lai2)
+		assert.NoError(t, err)
+		assert.Equal(t, vlr2, vlr)
+		vlr, err = pool.SelectVLR(fmt.Sprintf("00103%010d", i), []byte{0x00, 0xf1, 0x10, 0x00, 0x03})
+		assert.NoError(t, err)
+		assert.Equal(t, vlrAll, vlr)
+	}
+
+	// UEs of an LA whose VLR is down fall back to VLRs serving all LAs
+	pool.SetAssociationState(vlr1, false)
+	vlr, err := pool.SelectVLR("001040000000001", lai1)
+	assert.NoError(t, err)
+	assert.Equal(t, vlrAll, vlr)
+}
+
+func TestVLRPool_NRIRouting(t *testing.T) {
+	vlr1, vlr2 := newTestVLR("vlr1"), newTestVLR("vlr2")
+	vlr1.NRIs = []uint32{1}
+	vlr2.NRIs = []uint32{2}
+	// UEs without a TMSI are all but always routed to vlr1
+	vlr1.Weight = 1000000
+	pool, err := servicers.NewVLRPool(4, vlr1, vlr2)
+	assert.NoError(t, err)
+
+	// TMSI with NRI 2 in its bits 23 to 20
+	tmsi := []byte{0x00, 0x20, 0x00, 0x01}
+	assert.Equal(t, uint32(2), servicers.GetNRI(tmsi, 4))
+
+	imsi := "001010000000001"
+	accept, err := ptypes.MarshalAny(&protos.LocationUpdateAccept{
+		Imsi:        imsi,
+		NewIMSITMSI: &protos.LocationUpdateAccept_NewTmsi{NewTmsi: tmsi},
+	})
+	assert.NoError(t, err)
+	pool.Observe(vlr2, accept)
+	vlr, err := pool.SelectVLR(imsi, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, vlr2, vlr)
+
+	// A VLR reset releases its UEs, which are routed back by their NRI
+	pool.Reset(vlr2)
+	vlr, err = pool.SelectVLR(imsi, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, vlr2, vlr)
+
+	// UEs are redistributed while the VLR of their NRI is down
+	pool.SetAssociationState(vlr2, false)
+	vlr, err = pool.SelectVLR(imsi, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, vlr1, vlr)
+
+	_, err = servicers.NewVLRPool(1, &servicers.VLR{Name: "vlr", Conn: vlr1.Conn, NRIs: []uint32{2}})
+	assert.Error(t, err)
+	_, err = servicers.NewVLRPool(2, vlr1, &servicers.VLR{Name: "vlr", Conn: vlr1.Conn, NRIs: []uint32{1}})
+	assert.Error(t, err)
+	_, err = servicers.NewVLRPool(0, vlr1, vlr1)
+	assert.Error(t, err)
+}
+
+func TestVLRPool_LocationAreaChange(t *testing.T) {
+	lai1 := []byte{0x00, 0xf1, 0x10, 0x00, 0x01}
+	lai2 := []byte{0x00, 0xf1, 0x10, 0x00, 0x02}
+	vlr1, vlr2 := newTestVLR("vlr1"), newTestVLR("vlr2")
+	vlr1.LocationAreas, vlr1.NRIs = [][]byte{lai1}, []uint32{1}
+	vlr2.LocationAreas, vlr2.NRIs = [][]byte{lai2}, []uint32{2}
+	pool, err := servicers.NewVLRPool(4, vlr1, vlr2)
+	assert.NoError(t, err)
+
+	imsi := "001010000000001"
+	accept, err := ptypes.MarshalAny(&protos.LocationUpdateAccept{
+		Imsi:        imsi,
+		NewIMSITMSI: &protos.LocationUpdateAccept_NewTmsi{NewTmsi: []byte{0x00, 0x10, 0x00, 0x01}},
+	})
+	assert.NoError(t, err)
+	pool.Observe(vlr1, accept)
+	vlr, err := pool.SelectVLR(imsi, lai1)
+	assert.NoError(t, err)
+	assert.Equal(t, vlr1, vlr)
+
+	// A UE moving to an LA its VLR doesn't serve is moved to a VLR of the LA,
+	// even though the NRI of its TMSI points to its former VLR
+	vlr, err = pool.SelectVLR(imsi, lai2)
+	assert.NoError(t, err)
+	assert.Equal(t, vlr2, vlr)
+	vlr, err = pool.SelectVLR(imsi, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, vlr2, vlr)
+
+	vlr, err = pool.SelectVLR(imsi, lai1)
+	assert.NoError(t, err)
+	assert.Equal(t, vlr1, vlr)
+}
+
+func TestVLRPool_Detach(t *testing.T) {
+	vlr1, vlr2 := newTestVLR("vlr1"), newTestVLR("vlr2")
+	vlr1.NRIs = []uint32{1}
+	vlr2.NRIs = []uint32{2}
+	// UEs without a TMSI are all but always routed to vlr1
+	vlr1.Weight = 1000000
+	pool, err := servicers.NewVLRPool(4, vlr1, vlr2)
+	assert.NoError(t, err)
+
+	imsi := "001010000000001"
+	accept, err := ptypes.MarshalAny(&protos.LocationUpdateAccept{
+		Imsi:        imsi,
+		NewIMSITMSI: &protos.LocationUpdateAccept_NewTmsi{NewTmsi: []byte{0x00, 0x20, 0x00, 0x01}},
+	})
+	assert.NoError(t, err)
+	epsDetachAck, err := ptypes.MarshalAny(&protos.EPSDetachAck{Imsi: imsi})
+	assert.NoError(t, err)
+	imsiDetachAck, err := ptypes.MarshalAny(&protos.IMSIDetachAck{Imsi: imsi})
+	assert.NoError(t, err)
+
+	// The binding & TMSI of a detached UE are released, it's selected anew
+	for _, detachAck := range []*any.Any{epsDetachAck, imsiDetachAck} {
+		pool.Observe(vlr2, accept)
+		vlr, err := pool.SelectVLR(imsi, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, vlr2, vlr)
+
+		pool.Observe(vlr2, detachAck)
+		vlr, err = pool.SelectVLR(imsi, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, vlr1, vlr)
+	}
+}
+
+func TestVLRPool_CsfbServer(t *testing.T) {
+	vlr1, vlr2 := newTestVLR("vlr1"), newTestVLR("vlr2")
+	pool, err := servicers.NewVLRPool(0, vlr1, vlr2)
+	assert.NoError(t, err)
+	srv, err := servicers.NewCsfbPoolServer(pool)
+	assert.NoError(t, err)
+
+	req := &protos.UEActivityIndication{Imsi: "001010000000001"}
+	_, err = srv.UEActivityInd(context.Background(), req)
+	assert.NoError(t, err)
+	served, err := pool.SelectVLR(req.Imsi, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sentMessages(served))
+
+	// MME reset indications are sent to the named VLR or to all VLRs
+	_, err = srv.MMEResetIndication(context.Background(), &protos.ResetIndication{MmeName: servicers.DefaultMMEName, VlrName: "vlr2"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sentMessages(vlr2)-boolToInt(served == vlr2))
+	_, err = srv.MMEResetIndication(context.Background(), &protos.ResetIndication{MmeName: servicers.DefaultMMEName})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sentMessages(vlr2)-boolToInt(served == vlr2))
+	assert.Equal(t, 1, sentMessages(vlr1)-boolToInt(served == vlr1))
+
+	// a VLR name which isn't part of the pool isn't broadcast
+	_, err = srv.MMEResetIndication(context.Background(), &protos.ResetIndication{MmeName: servicers.DefaultMMEName, VlrName: "vlr3.example.com"})
+	assert.Error(t, err)
+	assert.Equal(t, 2, sentMessages(vlr2)-boolToInt(served == vlr2))
+	assert.Equal(t, 1, sentMessages(vlr1)-boolToInt(served == vlr1))
+
+	// the reset ack goes to the VLR which was reset
+	resetAck, err := message.EncodeSGsAPResetAck(&protos.ResetAck{MmeName: servicers.DefaultMMEName})
+	assert.NoError(t, err)
+	assert.NoError(t, srv.SendResetAck(vlr1))
+	vlr1.Conn.(*mocks.ClientConnectionInterface).AssertCalled(t, "Send", resetAck)
+	vlr2.Conn.(*mocks.ClientConnectionInterface).AssertNotCalled(t, "Send", resetAck)
+}
+
+func TestVLRPool_CreateFromConfig(t *testing.T) {
+	pool, err := servicers.CreateVLRPool(&mconfig.CsfbConfig{
+		NriBitLength: 2,
+		VlrPool: []*mconfig.VLRConfig{
+			{
+				Name:          "msc1",
+				Client:        &mconfig.SCTPClientConfig{ServerAddress: "127.0.0.1:1357"},
+				NriValues:     []uint32{1},
+				LocationAreas: []string{"00f1100001"},
+				Weight:        2,
+			},
+			{
+				Client: &mconfig.SCTPClientConfig{ServerAddress: "127.0.0.1:1358"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	vlrs := pool.VLRs()
+	assert.Len(t, vlrs, 2)
+	assert.Equal(t, "msc1", vlrs[0].Name)
+	assert.Equal(t, [][]byte{{0x00, 0xf1, 0x10, 0x00, 0x01}}, vlrs[0].LocationAreas)
+	assert.Equal(t, uint32(2), vlrs[0].Weight)
+	assert.Equal(t, "vlr1", vlrs[1].Name)
+	assert.Equal(t, uint32(1), vlrs[1].Weight)
+
+	_, err = servicers.CreateVLRPool(&mconfig.CsfbConfig{
+		VlrPool: []*mconfig.VLRConfig{{
+			Client:        &mconfig.SCTPClientConfig{ServerAddress: "127.0.0.1:1357"},
+			LocationAreas: []string{"00f110"},
+		}},
+	})
+	assert.Error(t, err)
+
+	// the client config is used as a single VLR
+	pool, err = servicers.CreateVLRPool(&mconfig.CsfbConfig{
+		Client: &mconfig.SCTPClientConfig{ServerAddress: "127.0.0.1:1357"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pool.VLRs(), 1)
+	assert.Equal(t, servicers.DefaultVLRName, pool.VLRs()[0].Name)
+}
+
+func TestVLRPool_SendToSingleVLR(t *testing.T) {
+	vlr := newTestVLR(servicers.DefaultVLRName)
+	pool, err := servicers.NewVLRPool(0, vlr)
+	assert.NoError(t, err)
+
+	// the VLR Name advertised by the single VLR may differ from its configured name
+	assert.NoError(t, pool.SendToVLR("vlr.example.com", []byte{0x01}))
+	assert.Equal(t, 1, sentMessages(vlr))
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,416 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/services/csfb/servicers/decode"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/any"
+)
+
+const (
+	DefaultVLRName = "vlr"
+	// MaxNRIBitLength is the maximum length of the NRI field of a TMSI (TS 23.236)
+	MaxNRIBitLength = 10
+	tmsiLength      = 4
+)
+
+// VLR is an SGs association of the VLR pool
+type VLR struct {
+	Name string
+	Conn ClientConnectionInterface
+	// NRIs of the VLR, UEs with a TMSI allocated by the VLR are routed to it
+	NRIs []uint32
+	// LocationAreas served by the VLR, it serves all LAs if empty
+	LocationAreas [][]byte
+	// Weight of the VLR when distributing UEs, 1 if not set
+	Weight uint32
+
+	up bool
+}
+
+// VLRPool routes SGs messages to the VLRs of an MSC pool. The VLR of a UE is
+// selected when it's first seen, by the NRI of its TMSI, its location area
+// & a weighted hash of its IMSI, and kept for the UE while its SGs
+// association is up. The UEs of a VLR whose association drops are
+// redistributed among the remaining VLRs.
+type VLRPool struct {
+	vlrs         []*VLR
+	nriBitLength uint32
+
+	mu       sync.RWMutex
+	bindings map[string]*VLR   // IMSI -> serving VLR
+	tmsis    map[string][]byte // IMSI -> TMSI allocated by the serving VLR
+}
+
+// NewVLRPool creates a pool of the given VLRs, all VLRs are considered up
+// until SetAssociationState reports otherwise
+func NewVLRPool(nriBitLength uint32, vlrs ...*VLR) (*VLRPool, error) {
+	if len(vlrs) == 0 {
+		return nil, errors.New("VLR pool is empty")
+	}
+	if nriBitLength > MaxNRIBitLength {
+		return nil, fmt.Errorf("invalid NRI bit length %d, must be at most %d", nriBitLength, MaxNRIBitLength)
+	}
+	names := map[string]bool{}
+	nris := map[uint32]string{}
+	for _, vlr := range vlrs {
+		if vlr.Conn == nil {
+			return nil, fmt.Errorf("VLR %s has no connection", vlr.Name)
+		}
+		if names[vlr.Name] {
+			return nil, fmt.Errorf("duplicate VLR name %s", vlr.Name)
+		}
+		names[vlr.Name] = true
+		for _, nri := range vlr.NRIs {
+			if nri >= 1<<nriBitLength {
+				return nil, fmt.Errorf("NRI %d of VLR %s exceeds NRI bit length %d", nri, vlr.Name, nriBitLength)
+			}
+			if other, ok := nris[nri]; ok {
+				return nil, fmt.Errorf("NRI %d is used by both VLR %s and %s", nri, other, vlr.Name)
+			}
+			nris[nri] = vlr.Name
+		}
+		for _, lai := range vlr.LocationAreas {
+			if len(lai) != decode.IELengthLocationAreaIdentifier-decode.LengthIEI-decode.LengthLengthIndicator {
+				return nil, fmt.Errorf("invalid location area %x of VLR %s", lai, vlr.Name)
+			}
+		}
+		if vlr.Weight == 0 {
+			vlr.Weight = 1
+		}
+		vlr.up = true
+	}
+	return &VLRPool{
+		vlrs:         vlrs,
+		nriBitLength: nriBitLength,
+		bindings:     map[string]*VLR{},
+		tmsis:        map[string][]byte{},
+	}, nil
+}
+
+// CreateVLRPool creates the VLR pool of the CSFB config, the client config
+// is used as a single VLR if no VLR pool is configured
+func CreateVLRPool(config *mconfig.CsfbConfig) (*VLRPool, error) {
+	if len(config.GetVlrPool()) == 0 {
+		conn, err := CreateVlrSCTPconnection(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewVLRPool(0, &VLR{Name: DefaultVLRName, Conn: conn})
+	}
+	var vlrs []*VLR
+	for idx, vlrConfig := range config.GetVlrPool() {
+		if vlrConfig.GetClient() == nil {
+			return nil, fmt.Errorf("VLR #%d has no client config", idx)
+		}
+		name := vlrConfig.GetName()
+		if name == "" {
+			name = fmt.Sprintf("%s%d", DefaultVLRName, idx)
+		}
+		conn, err := CreateVlrSCTPconnection(&mconfig.CsfbConfig{Client: vlrConfig.GetClient()})
+		if err != nil {
+			return nil, err
+		}
+		vlr := &VLR{
+			Name:   name,
+			Conn:   conn,
+			NRIs:   vlrConfig.GetNriValues(),
+			Weight: vlrConfig.GetWeight(),
+		}
+		for _, lai := range vlrConfig.GetLocationAreas() {
+			decoded, err := hex.DecodeString(lai)
+			if err != nil {
+				return nil, fmt.Errorf("invalid location area %s of VLR %s: %s", lai, name, err)
+			}
+			vlr.LocationAreas = append(vlr.LocationAreas, decoded)
+		}
+		vlrs = append(vlrs, vlr)
+	}
+	return NewVLRPool(config.GetNriBitLength(), vlrs...)
+}
+
+// VLRs returns the VLRs of the pool
+func (pool *VLRPool) VLRs() []*VLR {
+	return pool.vlrs
+}
+
+// GetVLR returns the VLR of the given name, nil if there is none. Names are
+// FQDNs, so they are compared case insensitively.
+func (pool *VLRPool) GetVLR(name string) *VLR {
+	for _, vlr := range pool.vlrs {
+		if strings.EqualFold(vlr.Name, name) {
+			return vlr
+		}
+	}
+	return nil
+}
+
+// IsUp returns whether the SGs association of the VLR is up
+func (pool *VLRPool) IsUp(vlr *VLR) bool {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+	return vlr.up
+}
+
+// SetAssociationState records the state of the SGs association of the VLR.
+// The UEs served by a VLR whose association is down are released, to be
+// redistributed among the remaining VLRs.
+func (pool *VLRPool) SetAssociationState(vlr *VLR, up bool) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	if vlr.up == up {
+		return
+	}
+	vlr.up = up
+	if up {
+		glog.Infof("SGs association with VLR %s is up", vlr.Name)
+		return
+	}
+	released := pool.releaseLocked(vlr)
+	glog.Warningf("SGs association with VLR %s is down, redistributing its %d UEs", vlr.Name, released)
+}
+
+// Reset releases the UEs served by the VLR, following an
+// SGsAP-RESET-INDICATION received from it
+func (pool *VLRPool) Reset(vlr *VLR) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	released := pool.releaseLocked(vlr)
+	glog.V(2).Infof("VLR %s was reset, released its %d UEs", vlr.Name, released)
+}
+
+func (pool *VLRPool) releaseLocked(vlr *VLR) int {
+	released := 0
+	for imsi, serving := range pool.bindings {
+		if serving == vlr {
+			// the TMSI is kept, its NRI routes the UE back to the VLR once it's available
+			delete(pool.bindings, imsi)
+			released++
+		}
+	}
+	return released
+}
+
+// SelectVLR returns the VLR serving the UE, selecting one if the UE has none.
+// lai is the location area of the UE, nil if unknown.
+func (pool *VLRPool) SelectVLR(imsi string, lai []byte) (*VLR, error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	bound, ok := pool.bindings[imsi]
+	if ok && bound.up && bound.serves(lai) {
+		return bound, nil
+	}
+	if ok && !bound.serves(lai) {
+		// the UE moved out of the location areas of its VLR, the TMSI
+		// allocated by the VLR no longer routes the UE
+		delete(pool.tmsis, imsi)
+	}
+	vlr := pool.vlrByNRILocked(pool.tmsis[imsi])
+	if vlr == nil || !vlr.up || !vlr.serves(lai) {
+		vlr = pool.vlrByHashLocked(imsi, pool.candidatesLocked(lai))
+	}
+	if vlr == nil {
+		return nil, errors.New("no VLR is available")
+	}
+	pool.bindings[imsi] = vlr
+	return vlr, nil
+}
+
+// serves returns whether the VLR serves the location area, an unknown
+// location area is served by all VLRs
+func (vlr *VLR) serves(lai []byte) bool {
+	if lai == nil || len(vlr.LocationAreas) == 0 {
+		return true
+	}
+	for _, vlrLAI := range vlr.LocationAreas {
+		if bytes.Equal(vlrLAI, lai) {
+			return true
+		}
+	}
+	return false
+}
+
+// vlrByNRILocked returns the VLR of the NRI of the TMSI, nil if there is none
+func (pool *VLRPool) vlrByNRILocked(tmsi []byte) *VLR {
+	if pool.nriBitLength == 0 || len(tmsi) != tmsiLength {
+		return nil
+	}
+	nri := GetNRI(tmsi, pool.nriBitLength)
+	for _, vlr := range pool.vlrs {
+		for _, vlrNRI := range vlr.NRIs {
+			if vlrNRI == nri {
+				return vlr
+			}
+		}
+	}
+	return nil
+}
+
+// candidatesLocked returns the available VLRs serving the location area,
+// falling back to the VLRs serving all location areas
+func (pool *VLRPool) candidatesLocked(lai []byte) []*VLR {
+	var serving, unrestricted []*VLR
+	for _, vlr := range pool.vlrs {
+		if !vlr.up {
+			continue
+		}
+		if len(vlr.LocationAreas) == 0 {
+			unrestricted = append(unrestricted, vlr)
+			continue
+		}
+		if vlr.serves(lai) {
+			serving = append(serving, vlr)
+		}
+	}
+	if len(serving) > 0 && lai != nil {
+		return serving
+	}
+	return append(unrestricted, serving...)
+}
+
+// vlrByHashLocked selects a VLR by weighted rendezvous hashing of the IMSI,
+// so that only the UEs of a VLR which becomes unavailable are moved
+func (pool *VLRPool) vlrByHashLocked(imsi string, candidates []*VLR) *VLR {
+	var selected *VLR
+	maxScore := math.Inf(-1)
+	for _, vlr := range candidates {
+		hash := fnv.New64a()
+		hash.Write([]byte(vlr.Name))
+		hash.Write([]byte{0})
+		hash.Write([]byte(imsi))
+		// uniformly distributed in (0, 1)
+		u := (float64(mix(hash.Sum64())>>11) + 0.5) / (1 << 53)
+		score := -float64(vlr.Weight) / math.Log(u)
+		if score > maxScore {
+			selected, maxScore = vlr, score
+		}
+	}
+	return selected
+}
+
+// mix is the splitmix64 finalizer, spreading the FNV hash to its high bits
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	return h ^ (h >> 31)
+}
+
+// Send sends the message to the VLR serving the UE
+func (pool *VLRPool) Send(imsi string, lai []byte, message []byte) error {
+	vlr, err := pool.SelectVLR(imsi, lai)
+	if err != nil {
+		return err
+	}
+	glog.V(2).Infof("Sending message of IMSI %s to VLR %s", imsi, vlr.Name)
+	return vlr.Conn.Send(message)
+}
+
+// SendToVLR sends the message to the named VLR, or to all available VLRs if
+// the name is empty. A name which isn't part of the pool is an error, unless
+// the pool holds a single VLR, whose configured name may differ from the VLR
+// Name the VLR advertises.
+func (pool *VLRPool) SendToVLR(vlrName string, message []byte) error {
+	if vlrName != "" {
+		vlr := pool.GetVLR(vlrName)
+		if vlr == nil && len(pool.vlrs) == 1 {
+			vlr = pool.vlrs[0]
+		}
+		if vlr == nil {
+			return fmt.Errorf("VLR %s is not part of the VLR pool", vlrName)
+		}
+		return vlr.Conn.Send(message)
+	}
+	var errs []error
+	sent := false
+	for _, vlr := range pool.vlrs {
+		if !pool.IsUp(vlr) {
+			continue
+		}
+		if err := vlr.Conn.Send(message); err != nil {
+			errs = append(errs, fmt.Errorf("VLR %s: %s", vlr.Name, err))
+			continue
+		}
+		sent = true
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to send message to VLRs: %v", errs)
+	}
+	if !sent {
+		return errors.New("no VLR is available")
+	}
+	return nil
+}
+
+// Observe records the VLR serving the UE of a message received from the VLR,
+// along with the TMSI allocated to the UE. The UE is released once the VLR
+// acknowledges its EPS or IMSI detach.
+func (pool *VLRPool) Observe(vlr *VLR, msg *any.Any) {
+	var dynamicMsg ptypes.DynamicAny
+	if err := ptypes.UnmarshalAny(msg, &dynamicMsg); err != nil {
+		return
+	}
+	ueMsg, ok := dynamicMsg.Message.(interface{ GetImsi() string })
+	if !ok || ueMsg.GetImsi() == "" {
+		return
+	}
+	imsi := ueMsg.GetImsi()
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	switch dynamicMsg.Message.(type) {
+	case *protos.EPSDetachAck, *protos.IMSIDetachAck:
+		delete(pool.bindings, imsi)
+		delete(pool.tmsis, imsi)
+		return
+	}
+	if serving, ok := pool.bindings[imsi]; ok && serving != vlr {
+		delete(pool.tmsis, imsi)
+	}
+	pool.bindings[imsi] = vlr
+	switch m := dynamicMsg.Message.(type) {
+	case *protos.LocationUpdateAccept:
+		if tmsi := m.GetNewTmsi(); len(tmsi) == tmsiLength {
+			pool.tmsis[imsi] = tmsi
+		}
+	case *protos.PagingRequest:
+		if tmsi := m.GetTmsi(); len(tmsi) == tmsiLength {
+			pool.tmsis[imsi] = tmsi
+		}
+	}
+}
+
+// GetNRI returns the NRI of the TMSI, held by its bits 23 and down (TS 23.236)
+func GetNRI(tmsi []byte, nriBitLength uint32) uint32 {
+	if len(tmsi) != tmsiLength || nriBitLength == 0 {
+		return 0
+	}
+	return (binary.BigEndian.Uint32(tmsi) >> (24 - nriBitLength)) & (1<<nriBitLength - 1)
+}
@@ -23,6 +23,7 @@ import (
 	"magma/feg/gateway/registry"
 	"magma/feg/gateway/service_health"
 	"magma/feg/gateway/services/s6a_proxy"
+	"magma/feg/gateway/services/s6a_proxy/servicers"
 	"magma/feg/gateway/services/s6a_proxy/servicers/test"
 	"magma/feg/gateway/services/s6a_proxy/test_init"
 )
@@ -138,6 +139,21 @@ func TestS6aProxyClient(t *testing.T) {
 		t.Errorf("Unexpected PUA Error Code: %d", r.ErrorCode)
 	}
 
+	// NOR
+	noResp, err := s6a_proxy.Notify(&protos.NotifyRequest{
+		UserName:         test.TEST_IMSI,
+		NorFlags:         servicers.NORFlagReadyForSMFromMME,
+		AlertReason:      protos.NotifyRequest_UE_MEMORY_AVAILABLE,
+		ServiceSelection: "magma.ipv4",
+	})
+	if err != nil {
+		t.Fatalf("GRPC NOR Error: %v", err)
+	}
+	t.Logf("GRPC NOA: %#+v", *noResp)
+	if noResp.ErrorCode != protos.ErrorCode_SUCCESS {
+		t.Errorf("Unexpected NOA Error Code: %d", noResp.ErrorCode)
+	}
+
 	// Disable connections and ensure subsequent requests fail
 	disableReq := &protos.DisableMessage{
 		DisablePeriodSecs: 10,
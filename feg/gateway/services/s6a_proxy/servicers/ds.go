@@ -0,0 +1,82 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicers implements S6a GRPC proxy service
+// It handles DSR, sends sync rpc request to gateway, then returns a DSA over diameter connection.
+package servicers
+
+import (
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/s6a_proxy"
+)
+
+// S6a DSR
+func handleDSR(s *s6aProxy) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		glog.V(2).Infof("Received S6a DSR message:\n%s\n", m)
+		var dsr DSR
+		err := m.Unmarshal(&dsr)
+		if err != nil {
+			glog.Errorf("DSR Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		var res *protos.DeleteSubscriberDataAnswer
+		var retries = MaxSyncRPCRetries
+		for ; retries >= 0; retries-- {
+			res, err = s.Relay.RelayDSR(&dsr)
+			if err != nil {
+				glog.Errorf("Failed to forward DSR to gateway. err: %v. Retries left: %v\n", err, retries)
+			} else {
+				break
+			}
+		}
+		err = s.sendDSA(c, m, res, &dsr, MaxDiamClRetries)
+		if err != nil {
+			glog.Errorf("Failed to send DSA: %s", err.Error())
+		} else {
+			glog.V(2).Infof("Successfully sent DSA\n")
+		}
+	}
+}
+
+// RelayDSR forwards DSR to the gateway serving the subscriber
+func (fegRelayClient) RelayDSR(dsr *DSR) (*protos.DeleteSubscriberDataAnswer, error) {
+	in := &protos.DeleteSubscriberDataRequest{
+		UserName:           dsr.UserName,
+		DsrFlags:           dsr.DSRFlags,
+		ContextIdentifiers: dsr.ContextIdentifiers,
+	}
+	return s6a_proxy.GWS6AProxyDeleteSubscriberData(in)
+}
+
+func (s *s6aProxy) sendDSA(
+	c diam.Conn, m *diam.Message, res *protos.DeleteSubscriberDataAnswer, dsr *DSR, retries uint) error {
+
+	code := protos.ErrorCode_UNABLE_TO_DELIVER
+	if res != nil {
+		code = res.GetErrorCode()
+	}
+	ans := s.newAnswer(m, dsr.SessionID, dsr.AuthSessionState, code)
+	if res.GetDsaFlags() != 0 {
+		ans.NewAVP(DSAFlags, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(res.GetDsaFlags()))
+	}
+	glog.V(2).Infof("Sending S6a DSA message\n%s\n", ans)
+	_, err := ans.WriteToWithRetry(c, retries)
+	return err
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/stretchr/testify/assert"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+)
+
+// testConn is a diam.Conn which records the messages written to it
+type testConn struct {
+	bytes.Buffer
+	ctx context.Context
+}
+
+func (c *testConn) WriteStream(b []byte, _ uint) (int, error) { return c.Write(b) }
+func (c *testConn) Close()                                    {}
+func (c *testConn) LocalAddr() net.Addr                       { return &net.TCPAddr{} }
+func (c *testConn) RemoteAddr() net.Addr                      { return &net.TCPAddr{} }
+func (c *testConn) TLS() *tls.ConnectionState                 { return nil }
+func (c *testConn) Dictionary() *dict.Parser                  { return dict.Default }
+func (c *testConn) Context() context.Context                  { return c.ctx }
+func (c *testConn) SetContext(ctx context.Context)            { c.ctx = ctx }
+func (c *testConn) Connection() net.Conn                      { return nil }
+
+// testRelay records the relayed requests & answers them with the configured answers or error
+type testRelay struct {
+	idrs []*IDR
+	dsrs []*DSR
+	ida  *protos.InsertSubscriberDataAnswer
+	dsa  *protos.DeleteSubscriberDataAnswer
+	err  error
+}
+
+func (r *testRelay) RelayIDR(idr *IDR) (*protos.InsertSubscriberDataAnswer, error) {
+	r.idrs = append(r.idrs, idr)
+	return r.ida, r.err
+}
+
+func (r *testRelay) RelayDSR(dsr *DSR) (*protos.DeleteSubscriberDataAnswer, error) {
+	r.dsrs = append(r.dsrs, dsr)
+	return r.dsa, r.err
+}
+
+func newTestProxy(relay Relay) *s6aProxy {
+	return &s6aProxy{
+		config: &S6aProxyConfig{
+			ClientCfg: &diameter.DiameterClientConfig{
+				Host:  "magma-fedgw.magma.com",
+				Realm: "magma.com",
+			},
+		},
+		requestTracker: diameter.NewRequestTracker(),
+		Relay:          relay,
+	}
+}
+
+func newTestRequest(code uint32, avps ...*diam.AVP) *diam.Message {
+	m := diam.NewRequest(code, diam.TGPP_S6A_APP_ID, dict.Default)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("hss;1234;1"))
+	m.NewAVP(avp.AuthSessionState, avp.Mbit, 0, datatype.Enumerated(1))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("hss.magma.com"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("magma.com"))
+	m.NewAVP(avp.DestinationHost, avp.Mbit, 0, datatype.DiameterIdentity("magma-fedgw.magma.com"))
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity("magma.com"))
+	m.NewAVP(avp.UserName, avp.Mbit, 0, datatype.UTF8String("001010000000001"))
+	for _, a := range avps {
+		m.AddAVP(a)
+	}
+	return m
+}
+
+// readAnswer parses the single answer written to the connection into ans
+func readAnswer(t *testing.T, c *testConn, ans interface{}) *diam.Message {
+	m, err := diam.ReadMessage(&c.Buffer, dict.Default)
+	assert.NoError(t, err)
+	assert.False(t, m.Header.CommandFlags&diam.RequestFlag != 0)
+	assert.NoError(t, m.Unmarshal(ans))
+	assert.Zero(t, c.Len())
+	return m
+}
+
+func apnConfigurationProfile(allIncluded int32) *diam.AVP {
+	return diam.NewAVP(avp.APNConfigurationProfile, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(1)),
+			diam.NewAVP(avp.AllAPNConfigurationsIncludedIndicator, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(allIncluded)),
+			diam.NewAVP(avp.APNConfiguration, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(1)),
+					diam.NewAVP(avp.PDNType, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(0)),
+					diam.NewAVP(avp.ServiceSelection, avp.Mbit, 0, datatype.UTF8String("magma.ipv4")),
+				},
+			}),
+		},
+	})
+}
+
+func subscriptionData(avps ...*diam.AVP) *diam.AVP {
+	avps = append(
+		[]*diam.AVP{diam.NewAVP(avp.MSISDN, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.OctetString("12345"))},
+		avps...)
+	return diam.NewAVP(avp.SubscriptionData, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{AVP: avps})
+}
+
+func TestHandleIDR(t *testing.T) {
+	relay := &testRelay{ida: &protos.InsertSubscriberDataAnswer{ErrorCode: protos.ErrorCode_SUCCESS, IdaFlags: 1}}
+	conn := &testConn{}
+	req := newTestRequest(InsertSubscriberData,
+		subscriptionData(apnConfigurationProfile(0)),
+		diam.NewAVP(IDRFlags, avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(1)))
+	handleIDR(newTestProxy(relay))(conn, req)
+
+	assert.Len(t, relay.idrs, 1)
+	assert.Equal(t, "001010000000001", relay.idrs[0].UserName)
+	assert.Equal(t, uint32(1), relay.idrs[0].IDRFlags)
+	assert.NotNil(t, relay.idrs[0].SubscriptionData.APNConfigurationProfile)
+
+	var ida IDA
+	ans := readAnswer(t, conn, &ida)
+	assert.Equal(t, uint32(InsertSubscriberData), ans.Header.CommandCode)
+	assert.Equal(t, req.Header.HopByHopID, ans.Header.HopByHopID)
+	assert.Equal(t, "hss;1234;1", ida.SessionID)
+	assert.Equal(t, uint32(diam.Success), ida.ResultCode)
+	assert.Equal(t, uint32(1), ida.IDAFlags)
+	assert.Equal(t, datatype.DiameterIdentity("magma-fedgw.magma.com"), ida.OriginHost)
+
+	// The relay fails, the HSS is told the IDR couldn't be delivered
+	relay = &testRelay{err: fmt.Errorf("gateway unreachable")}
+	conn = &testConn{}
+	handleIDR(newTestProxy(relay))(conn, req)
+
+	assert.Len(t, relay.idrs, MaxSyncRPCRetries+1)
+	ida = IDA{}
+	readAnswer(t, conn, &ida)
+	assert.Equal(t, uint32(mapProtoToDiamResult(protos.ErrorCode_UNABLE_TO_DELIVER)), ida.ResultCode)
+	assert.Zero(t, ida.IDAFlags)
+
+	// Subscriber unknown to the gateway is sent in an Experimental-Result
+	relay = &testRelay{ida: &protos.InsertSubscriberDataAnswer{ErrorCode: protos.ErrorCode_USER_UNKNOWN}}
+	conn = &testConn{}
+	handleIDR(newTestProxy(relay))(conn, req)
+
+	ida = IDA{}
+	readAnswer(t, conn, &ida)
+	assert.Zero(t, ida.ResultCode)
+	assert.Equal(t, uint32(diameter.Vendor3GPP), ida.ExperimentalResult.VendorId)
+	assert.Equal(t, uint32(protos.ErrorCode_USER_UNKNOWN), ida.ExperimentalResult.ExperimentalResultCode)
+}
+
+func TestConvertIDR(t *testing.T) {
+	var idr IDR
+	err := newTestRequest(InsertSubscriberData, subscriptionData(apnConfigurationProfile(1))).Unmarshal(&idr)
+	assert.NoError(t, err)
+	in := convertIDR(&idr)
+	assert.Equal(t, "001010000000001", in.UserName)
+	assert.Equal(t, []byte("12345"), in.Msisdn)
+	assert.Equal(t, uint32(1), in.DefaultContextId)
+	assert.False(t, in.AllApnsIncluded)
+	assert.Len(t, in.Apn, 1)
+	assert.Equal(t, "magma.ipv4", in.Apn[0].ServiceSelection)
+
+	idr = IDR{}
+	err = newTestRequest(InsertSubscriberData, subscriptionData(apnConfigurationProfile(0))).Unmarshal(&idr)
+	assert.NoError(t, err)
+	in = convertIDR(&idr)
+	assert.True(t, in.AllApnsIncluded)
+	assert.Len(t, in.Apn, 1)
+
+	// An IDR without APN-Configuration-Profile leaves the APNs of the subscriber untouched
+	idr = IDR{}
+	err = newTestRequest(InsertSubscriberData, subscriptionData()).Unmarshal(&idr)
+	assert.NoError(t, err)
+	assert.Nil(t, idr.SubscriptionData.APNConfigurationProfile)
+	in = convertIDR(&idr)
+	assert.Equal(t, []byte("12345"), in.Msisdn)
+	assert.Zero(t, in.DefaultContextId)
+	assert.False(t, in.AllApnsIncluded)
+	assert.Nil(t, in.Apn)
+}
+
+func TestHandleDSR(t *testing.T) {
+	relay := &testRelay{dsa: &protos.DeleteSubscriberDataAnswer{ErrorCode: protos.ErrorCode_SUCCESS, DsaFlags: 1}}
+	conn := &testConn{}
+	req := newTestRequest(DeleteSubscriberData,
+		diam.NewAVP(DSRFlags, avp.Mbit|avp.Vbit, diameter.Vendor3GPP,
+			datatype.Unsigned32(DSRFlagPDNSubscriptionContextWithdraw)),
+		diam.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(2)),
+		diam.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(3)))
+	handleDSR(newTestProxy(relay))(conn, req)
+
+	assert.Len(t, relay.dsrs, 1)
+	assert.Equal(t, "001010000000001", relay.dsrs[0].UserName)
+	assert.Equal(t, uint32(DSRFlagPDNSubscriptionContextWithdraw), relay.dsrs[0].DSRFlags)
+	assert.Equal(t, []uint32{2, 3}, relay.dsrs[0].ContextIdentifiers)
+
+	var dsa DSA
+	ans := readAnswer(t, conn, &dsa)
+	assert.Equal(t, uint32(DeleteSubscriberData), ans.Header.CommandCode)
+	assert.Equal(t, "hss;1234;1", dsa.SessionID)
+	assert.Equal(t, uint32(diam.Success), dsa.ResultCode)
+	assert.Equal(t, uint32(1), dsa.DSAFlags)
+
+	relay = &testRelay{err: fmt.Errorf("gateway unreachable")}
+	conn = &testConn{}
+	handleDSR(newTestProxy(relay))(conn, req)
+
+	assert.Len(t, relay.dsrs, MaxSyncRPCRetries+1)
+	dsa = DSA{}
+	readAnswer(t, conn, &dsa)
+	assert.Equal(t, uint32(mapProtoToDiamResult(protos.ErrorCode_UNABLE_TO_DELIVER)), dsa.ResultCode)
+	assert.Zero(t, dsa.DSAFlags)
+}
+
+func TestHandleNOA(t *testing.T) {
+	proxy := newTestProxy(&testRelay{})
+	ch := make(chan interface{}, 1)
+	proxy.requestTracker.RegisterRequest("nor;1", ch)
+
+	noa := diam.NewMessage(diam.Notify, 0, diam.TGPP_S6A_APP_ID, 1, 1, dict.Default)
+	noa.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("nor;1"))
+	noa.NewAVP(avp.ResultCode, avp.Mbit, 0, datatype.Unsigned32(diam.Success))
+	noa.NewAVP(avp.AuthSessionState, avp.Mbit, 0, datatype.Enumerated(1))
+	noa.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("hss.magma.com"))
+	noa.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("magma.com"))
+	handleNOA(proxy)(&testConn{}, noa)
+
+	select {
+	case res := <-ch:
+		assert.IsType(t, &NOA{}, res)
+		assert.Equal(t, "nor;1", res.(*NOA).SessionID)
+		assert.Equal(t, uint32(diam.Success), res.(*NOA).ResultCode)
+	case <-time.After(time.Second):
+		assert.Fail(t, "NOA wasn't delivered to the pending NOR")
+	}
+	// The NOR is deregistered once answered, a duplicate NOA is dropped
+	assert.Nil(t, proxy.requestTracker.DeregisterRequest("nor;1"))
+	handleNOA(proxy)(&testConn{}, noa)
+	assert.Empty(t, ch)
+}
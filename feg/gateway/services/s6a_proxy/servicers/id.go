@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicers implements S6a GRPC proxy service
+// It handles IDR, sends sync rpc request to gateway, then returns an IDA over diameter connection.
+package servicers
+
+import (
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/s6a_proxy"
+)
+
+// S6a IDR
+func handleIDR(s *s6aProxy) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		glog.V(2).Infof("Received S6a IDR message:\n%s\n", m)
+		var idr IDR
+		err := m.Unmarshal(&idr)
+		if err != nil {
+			glog.Errorf("IDR Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		var res *protos.InsertSubscriberDataAnswer
+		var retries = MaxSyncRPCRetries
+		for ; retries >= 0; retries-- {
+			res, err = s.Relay.RelayIDR(&idr)
+			if err != nil {
+				glog.Errorf("Failed to forward IDR to gateway. err: %v. Retries left: %v\n", err, retries)
+			} else {
+				break
+			}
+		}
+		err = s.sendIDA(c, m, res, &idr, MaxDiamClRetries)
+		if err != nil {
+			glog.Errorf("Failed to send IDA: %s", err.Error())
+		} else {
+			glog.V(2).Infof("Successfully sent IDA\n")
+		}
+	}
+}
+
+// fegRelayClient forwards HSS initiated requests to the serving gateway via feg_relay
+type fegRelayClient struct{}
+
+// RelayIDR forwards IDR to the gateway serving the subscriber
+func (fegRelayClient) RelayIDR(idr *IDR) (*protos.InsertSubscriberDataAnswer, error) {
+	return s6a_proxy.GWS6AProxyInsertSubscriberData(convertIDR(idr))
+}
+
+// convertIDR returns the RPC representation of the IDR. The APN fields are only
+// set if the IDR carries an APN-Configuration-Profile, an IDR without it leaves
+// the APN configurations of the subscriber as they are.
+func convertIDR(idr *IDR) *protos.InsertSubscriberDataRequest {
+	data := &idr.SubscriptionData
+	in := &protos.InsertSubscriberDataRequest{
+		UserName:                       idr.UserName,
+		IdrFlags:                       idr.IDRFlags,
+		Msisdn:                         data.MSISDN.Serialize(),
+		TotalAmbr:                      convertAMBR(data.AMBR),
+		DefaultChargingCharacteristics: data.TgppChargingCharacteristics,
+		NetworkAccessMode:              protos.UpdateLocationAnswer_NetworkAccessMode(data.NetworkAccessMode),
+		RegionalSubscriptionZoneCode:   convertZoneCodes(data.RegionalSubscriptionZoneCode),
+	}
+	if profile := data.APNConfigurationProfile; profile != nil {
+		in.DefaultContextId = profile.ContextIdentifier
+		in.AllApnsIncluded = profile.AllAPNConfigurationsIncludedIndicator == 0
+		in.Apn = convertAPNConfigs(profile.APNConfigs)
+	}
+	return in
+}
+
+func (s *s6aProxy) sendIDA(
+	c diam.Conn, m *diam.Message, res *protos.InsertSubscriberDataAnswer, idr *IDR, retries uint) error {
+
+	code := protos.ErrorCode_UNABLE_TO_DELIVER
+	if res != nil {
+		code = res.GetErrorCode()
+	}
+	ans := s.newAnswer(m, idr.SessionID, idr.AuthSessionState, code)
+	if res.GetIdaFlags() != 0 {
+		ans.NewAVP(IDAFlags, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(res.GetIdaFlags()))
+	}
+	glog.V(2).Infof("Sending S6a IDA message\n%s\n", ans)
+	_, err := ans.WriteToWithRetry(c, retries)
+	return err
+}
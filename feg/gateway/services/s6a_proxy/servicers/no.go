@@ -0,0 +1,124 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicers implements S6a GRPC proxy service which sends NOR messages over diameter connection,
+// waits (blocks) for diameter's NOAs & returns their RPC representation
+package servicers
+
+import (
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+)
+
+// NOR-Flags bits (3GPP TS 29.272 Table 7.3.49/1) which require the Alert-Reason AVP
+const (
+	NORFlagReadyForSMFromSGSN = 1 << 2
+	NORFlagReadyForSMFromMME  = 1 << 5
+)
+
+// sendNOR - sends NOR with given Session ID (sid)
+func (s *s6aProxy) sendNOR(sid string, req *protos.NotifyRequest, retryCount uint) error {
+	c, err := s.connMan.GetConnection(s.smClient, s.config.ServerCfg)
+	if err != nil {
+		return err
+	}
+	m := diameter.NewProxiableRequest(diam.Notify, diam.TGPP_S6A_APP_ID, dict.Default)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sid))
+	m.NewAVP(avp.AuthSessionState, avp.Mbit, 0, datatype.Enumerated(1))
+	s.addDiamOriginAVPs(m)
+	m.NewAVP(avp.UserName, avp.Mbit, 0, datatype.UTF8String(req.UserName))
+	if len(req.ServiceSelection) > 0 {
+		m.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP,
+			datatype.Unsigned32(req.ContextIdentifier))
+		m.NewAVP(avp.ServiceSelection, avp.Mbit, 0, datatype.UTF8String(req.ServiceSelection))
+	}
+	if req.NorFlags&(NORFlagReadyForSMFromSGSN|NORFlagReadyForSMFromMME) != 0 {
+		m.NewAVP(AlertReason, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(req.AlertReason))
+	}
+	if req.NorFlags != 0 {
+		m.NewAVP(avp.NORFlags, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(req.NorFlags))
+	}
+
+	glog.V(2).Infof("Sending S6a NOR message\n%s\n", m)
+	err = c.SendRequest(m, retryCount)
+	if err != nil {
+		err = Error(codes.DataLoss, err)
+	}
+	return err
+}
+
+// S6a NOA
+func handleNOA(s *s6aProxy) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		var noa NOA
+		err := m.Unmarshal(&noa)
+		if err != nil {
+			glog.Errorf("NOA Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		ch := s.requestTracker.DeregisterRequest(noa.SessionID)
+		if ch != nil {
+			ch <- &noa
+		} else {
+			glog.Errorf("NOA SessionID %s not found. Message: %s, Remote: %s", noa.SessionID, m, c.RemoteAddr())
+		}
+	}
+}
+
+// NotifyImpl sends NOR over diameter connection,
+// waits (blocks) for NOA & returns its RPC representation
+func (s *s6aProxy) NotifyImpl(req *protos.NotifyRequest) (*protos.NotifyAnswer, error) {
+	res := &protos.NotifyAnswer{}
+	if req == nil {
+		return res, Errorf(codes.InvalidArgument, "Nil NO Request")
+	}
+
+	sid := s.genSID()
+	ch := make(chan interface{})
+	s.requestTracker.RegisterRequest(sid, ch)
+	// if request hasn't been removed by end of transaction, remove it
+	defer s.requestTracker.DeregisterRequest(sid)
+
+	err := s.sendNOR(sid, req, MAX_DIAM_RETRIES)
+	if err != nil {
+		glog.Errorf("Error sending NOR with SID %s: %v", sid, err)
+		return res, err
+	}
+	select {
+	case resp, open := <-ch:
+		if !open {
+			return res, Errorf(codes.Aborted, "NOR for Session ID: %s is canceled", sid)
+		}
+		noa, ok := resp.(*NOA)
+		if !ok {
+			return res, Errorf(codes.Internal, "Invalid Response Type: %T, NOA expected.", resp)
+		}
+		err = diameter.TranslateDiamResultCode(noa.ResultCode)
+		res.ErrorCode = protos.ErrorCode(noa.ExperimentalResult.ExperimentalResultCode)
+		if res.ErrorCode == protos.ErrorCode_UNDEFINED {
+			res.ErrorCode = protos.ErrorCode(noa.ResultCode)
+		}
+		return res, err
+	case <-time.After(time.Second * TIMEOUT_SECONDS):
+		return res, Errorf(codes.DeadlineExceeded, "NOR Timed Out for Session ID: %s", sid)
+	}
+}
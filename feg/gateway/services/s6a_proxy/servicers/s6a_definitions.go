@@ -180,15 +180,17 @@ type APNConfigurationProfile struct {
 }
 
 type SubscriptionData struct {
-	MSISDN                        datatype.OctetString    `avp:"MSISDN"`
-	AccessRestrictionData         uint32                  `avp:"Access-Restriction-Data"`
-	SubscriberStatus              int32                   `avp:"Subscriber-Status"`
-	NetworkAccessMode             int32                   `avp:"Network-Access-Mode"`
-	AMBR                          AMBR                    `avp:"AMBR"`
-	APNConfigurationProfile       APNConfigurationProfile `avp:"APN-Configuration-Profile"`
-	SubscribedPeriodicRauTauTimer uint32                  `avp:"Subscribed-Periodic-RAU-TAU-Timer"`
-	TgppChargingCharacteristics   string                  `avp:"TGPP-Charging-Characteristics"`
-	RegionalSubscriptionZoneCode  []datatype.OctetString  `avp:"Regional-Subscription-Zone-Code"`
+	MSISDN                datatype.OctetString `avp:"MSISDN"`
+	AccessRestrictionData uint32               `avp:"Access-Restriction-Data"`
+	SubscriberStatus      int32                `avp:"Subscriber-Status"`
+	NetworkAccessMode     int32                `avp:"Network-Access-Mode"`
+	AMBR                  AMBR                 `avp:"AMBR"`
+	// APNConfigurationProfile is nil if the AVP is absent, e.g. in an IDR
+	// which doesn't update the APN configurations
+	APNConfigurationProfile       *APNConfigurationProfile `avp:"APN-Configuration-Profile"`
+	SubscribedPeriodicRauTauTimer uint32                   `avp:"Subscribed-Periodic-RAU-TAU-Timer"`
+	TgppChargingCharacteristics   string                   `avp:"TGPP-Charging-Characteristics"`
+	RegionalSubscriptionZoneCode  []datatype.OctetString   `avp:"Regional-Subscription-Zone-Code"`
 }
 
 type ULA struct {
@@ -380,3 +382,127 @@ type ULR struct {
 	RATType          datatype.Unsigned32       `avp:"RAT-Type"`
 	ULRFlags         datatype.Unsigned32       `avp:"ULR-Flags"`
 }
+
+// IDR is Go representation of Insert-Subscriber-Data-Request message
+//
+// < Insert-Subscriber-Data-Request> ::= < Diameter Header: 319, REQ, PXY, 16777251 >
+//
+// < Session-Id >
+// [ Vendor-Specific-Application-Id ]
+// { Auth-Session-State }
+// { Origin-Host }
+// { Origin-Realm }
+// { Destination-Host }
+// { Destination-Realm }
+// { User-Name }
+// *[ Supported-Features ]
+// { Subscription-Data }
+// [ IDR-Flags ]
+// *[ AVP ]
+// *[ Proxy-Info ]
+// *[ Route-Record ]
+type IDR struct {
+	SessionID        string                    `avp:"Session-Id"`
+	AuthSessionState int32                     `avp:"Auth-Session-State"`
+	OriginHost       datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm      datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationHost  datatype.DiameterIdentity `avp:"Destination-Host"`
+	DestinationRealm datatype.DiameterIdentity `avp:"Destination-Realm"`
+	UserName         string                    `avp:"User-Name"`
+	SubscriptionData SubscriptionData          `avp:"Subscription-Data"`
+	IDRFlags         uint32                    `avp:"IDR-Flags"`
+}
+
+// IDA is Go representation of Insert-Subscriber-Data-Answer message
+type IDA struct {
+	SessionID          string                    `avp:"Session-Id"`
+	ResultCode         uint32                    `avp:"Result-Code"`
+	ExperimentalResult ExperimentalResult        `avp:"Experimental-Result"`
+	AuthSessionState   int32                     `avp:"Auth-Session-State"`
+	OriginHost         datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm        datatype.DiameterIdentity `avp:"Origin-Realm"`
+	IDAFlags           uint32                    `avp:"IDA-Flags"`
+}
+
+// DSR is Go representation of Delete-Subscriber-Data-Request message
+//
+// < Delete-Subscriber-Data-Request > ::= < Diameter Header: 320, REQ, PXY, 16777251 >
+//
+// < Session-Id >
+// [ Vendor-Specific-Application-Id ]
+// { Auth-Session-State }
+// { Origin-Host }
+// { Origin-Realm }
+// { Destination-Host }
+// { Destination-Realm }
+// { User-Name }
+// *[ Supported-Features ]
+// { DSR-Flags }
+// *[ Context-Identifier ]
+// *[ AVP ]
+// *[ Proxy-Info ]
+// *[ Route-Record ]
+type DSR struct {
+	SessionID          string                    `avp:"Session-Id"`
+	AuthSessionState   int32                     `avp:"Auth-Session-State"`
+	OriginHost         datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm        datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationHost    datatype.DiameterIdentity `avp:"Destination-Host"`
+	DestinationRealm   datatype.DiameterIdentity `avp:"Destination-Realm"`
+	UserName           string                    `avp:"User-Name"`
+	DSRFlags           uint32                    `avp:"DSR-Flags"`
+	ContextIdentifiers []uint32                  `avp:"Context-Identifier"`
+}
+
+// DSA is Go representation of Delete-Subscriber-Data-Answer message
+type DSA struct {
+	SessionID          string                    `avp:"Session-Id"`
+	ResultCode         uint32                    `avp:"Result-Code"`
+	ExperimentalResult ExperimentalResult        `avp:"Experimental-Result"`
+	AuthSessionState   int32                     `avp:"Auth-Session-State"`
+	OriginHost         datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm        datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DSAFlags           uint32                    `avp:"DSA-Flags"`
+}
+
+// NOR is Go representation of Notify-Request message
+//
+// < Notify-Request> ::= < Diameter Header: 323, REQ, PXY, 16777251 >
+//
+// < Session-Id >
+// [ Vendor-Specific-Application-Id ]
+// { Auth-Session-State }
+// { Origin-Host }
+// { Origin-Realm }
+// [ Destination-Host ]
+// { Destination-Realm }
+// { User-Name }
+// *[ Supported-Features ]
+// [ Context-Identifier ]
+// [ Service-Selection ]
+// [ Alert-Reason ]
+// [ NOR-Flags ]
+// *[ AVP ]
+// *[ Proxy-Info ]
+// *[ Route-Record ]
+type NOR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	AuthSessionState  int32                     `avp:"Auth-Session-State"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	UserName          string                    `avp:"User-Name"`
+	ContextIdentifier uint32                    `avp:"Context-Identifier"`
+	ServiceSelection  string                    `avp:"Service-Selection"`
+	AlertReason       int32                     `avp:"Alert-Reason"`
+	NORFlags          uint32                    `avp:"NOR-Flags"`
+}
+
+// NOA is Go representation of Notify-Answer message
+type NOA struct {
+	SessionID          string                    `avp:"Session-Id"`
+	ResultCode         uint32                    `avp:"Result-Code"`
+	ExperimentalResult ExperimentalResult        `avp:"Experimental-Result"`
+	AuthSessionState   int32                     `avp:"Auth-Session-State"`
+	OriginHost         datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm        datatype.DiameterIdentity `avp:"Origin-Realm"`
+}
@@ -46,6 +46,12 @@ type S6aProxyConfig struct {
 	PlmnIds   plmn_filter.PlmnIdVals
 }
 
+// Relay forwards HSS initiated subscriber data management requests to the serving gateway
+type Relay interface {
+	RelayIDR(*IDR) (*protos.InsertSubscriberDataAnswer, error)
+	RelayDSR(*DSR) (*protos.DeleteSubscriberDataAnswer, error)
+}
+
 type s6aProxy struct {
 	config         *S6aProxyConfig
 	smClient       *sm.Client
@@ -53,6 +59,7 @@ type s6aProxy struct {
 	requestTracker *diameter.RequestTracker
 	healthTracker  *metrics.S6aHealthTracker
 	originStateID  uint32
+	Relay          Relay
 }
 
 func NewS6aProxy(
@@ -121,6 +128,7 @@ func NewS6aProxy(
 		requestTracker: diameter.NewRequestTracker(),
 		healthTracker:  metrics.NewS6aHealthTracker(),
 		originStateID:  originStateID,
+		Relay:          fegRelayClient{},
 	}
 	mux.HandleIdx(
 		diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.AuthenticationInformation, Request: false},
@@ -141,6 +149,18 @@ func NewS6aProxy(
 		diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.Reset, Request: true},
 		handleRSR(proxy))
 
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: InsertSubscriberData, Request: true},
+		handleIDR(proxy))
+
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: DeleteSubscriberData, Request: true},
+		handleDSR(proxy))
+
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.Notify, Request: false},
+		handleNOA(proxy))
+
 	return proxy, nil
 }
 
@@ -182,6 +202,14 @@ func (s *s6aProxy) PurgeUE(ctx context.Context, req *protos.PurgeUERequest) (*pr
 	return res, err
 }
 
+// Notify sends NOR (Code 323) over diameter connection,
+// waits (blocks) for NOA & returns its RPC representation
+func (s *s6aProxy) Notify(ctx context.Context, req *protos.NotifyRequest) (*protos.NotifyAnswer, error) {
+	res, err := s.NotifyImpl(req)
+	metrics.UpdateS6aRecentRequestMetrics(err)
+	return res, err
+}
+
 // Disable closes all existing diameter connections and disables
 // connection creation for the time specified in the request
 func (s *s6aProxy) Disable(ctx context.Context, req *protos.DisableMessage) (*orcprotos.Void, error) {
@@ -110,38 +110,14 @@ func (s *s6aProxy) UpdateLocationImpl(req *protos.UpdateLocationRequest) (*proto
 					err = diameter.TranslateDiamResultCode(ula.ResultCode)
 					res.ErrorCode = protos.ErrorCode(ula.ExperimentalResult.ExperimentalResultCode)
 					res.Msisdn = ula.SubscriptionData.MSISDN.Serialize()
-					res.DefaultContextId = ula.SubscriptionData.APNConfigurationProfile.ContextIdentifier
-					res.TotalAmbr = &protos.UpdateLocationAnswer_AggregatedMaximumBitrate{
-						MaxBandwidthUl: ula.SubscriptionData.AMBR.MaxRequestedBandwidthUL,
-						MaxBandwidthDl: ula.SubscriptionData.AMBR.MaxRequestedBandwidthDL,
-					}
+					res.TotalAmbr = convertAMBR(ula.SubscriptionData.AMBR)
 					res.DefaultChargingCharacteristics = ula.SubscriptionData.TgppChargingCharacteristics
-					res.AllApnsIncluded =
-						ula.SubscriptionData.APNConfigurationProfile.AllAPNConfigurationsIncludedIndicator == 0
 					res.NetworkAccessMode = protos.UpdateLocationAnswer_NetworkAccessMode(ula.SubscriptionData.NetworkAccessMode)
-					res.RegionalSubscriptionZoneCode = make([][]byte, len(ula.SubscriptionData.RegionalSubscriptionZoneCode))
-					for i, code := range ula.SubscriptionData.RegionalSubscriptionZoneCode {
-						res.RegionalSubscriptionZoneCode[i] = code.Serialize()
-					}
-					for _, apnCfg := range ula.SubscriptionData.APNConfigurationProfile.APNConfigs {
-						res.Apn = append(
-							res.Apn,
-							&protos.UpdateLocationAnswer_APNConfiguration{
-								ContextId:        apnCfg.ContextIdentifier,
-								Pdn:              protos.UpdateLocationAnswer_APNConfiguration_PDNType(apnCfg.PDNType),
-								ServiceSelection: apnCfg.ServiceSelection,
-								QosProfile: &protos.UpdateLocationAnswer_APNConfiguration_QoSProfile{
-									ClassId:                 apnCfg.EPSSubscribedQoSProfile.QoSClassIdentifier,
-									PriorityLevel:           apnCfg.EPSSubscribedQoSProfile.AllocationRetentionPriority.PriorityLevel,
-									PreemptionCapability:    apnCfg.EPSSubscribedQoSProfile.AllocationRetentionPriority.PreemptionCapability == 0,
-									PreemptionVulnerability: apnCfg.EPSSubscribedQoSProfile.AllocationRetentionPriority.PreemptionVulnerability == 0,
-								},
-								Ambr: &protos.UpdateLocationAnswer_AggregatedMaximumBitrate{
-									MaxBandwidthUl: apnCfg.AMBR.MaxRequestedBandwidthUL,
-									MaxBandwidthDl: apnCfg.AMBR.MaxRequestedBandwidthDL,
-								},
-								ChargingCharacteristics: apnCfg.TgppChargingCharacteristics,
-							})
+					res.RegionalSubscriptionZoneCode = convertZoneCodes(ula.SubscriptionData.RegionalSubscriptionZoneCode)
+					if profile := ula.SubscriptionData.APNConfigurationProfile; profile != nil {
+						res.DefaultContextId = profile.ContextIdentifier
+						res.AllApnsIncluded = profile.AllAPNConfigurationsIncludedIndicator == 0
+						res.Apn = convertAPNConfigs(profile.APNConfigs)
 					}
 					return res, err
 				} else {
@@ -158,3 +134,40 @@ func (s *s6aProxy) UpdateLocationImpl(req *protos.UpdateLocationRequest) (*proto
 	}
 	return res, err
 }
+
+func convertAMBR(ambr AMBR) *protos.UpdateLocationAnswer_AggregatedMaximumBitrate {
+	return &protos.UpdateLocationAnswer_AggregatedMaximumBitrate{
+		MaxBandwidthUl: ambr.MaxRequestedBandwidthUL,
+		MaxBandwidthDl: ambr.MaxRequestedBandwidthDL,
+	}
+}
+
+func convertZoneCodes(codes []datatype.OctetString) [][]byte {
+	res := make([][]byte, len(codes))
+	for i, code := range codes {
+		res[i] = code.Serialize()
+	}
+	return res
+}
+
+func convertAPNConfigs(apnCfgs []APNConfiguration) []*protos.UpdateLocationAnswer_APNConfiguration {
+	var res []*protos.UpdateLocationAnswer_APNConfiguration
+	for _, apnCfg := range apnCfgs {
+		res = append(
+			res,
+			&protos.UpdateLocationAnswer_APNConfiguration{
+				ContextId:        apnCfg.ContextIdentifier,
+				Pdn:              protos.UpdateLocationAnswer_APNConfiguration_PDNType(apnCfg.PDNType),
+				ServiceSelection: apnCfg.ServiceSelection,
+				QosProfile: &protos.UpdateLocationAnswer_APNConfiguration_QoSProfile{
+					ClassId:                 apnCfg.EPSSubscribedQoSProfile.QoSClassIdentifier,
+					PriorityLevel:           apnCfg.EPSSubscribedQoSProfile.AllocationRetentionPriority.PriorityLevel,
+					PreemptionCapability:    apnCfg.EPSSubscribedQoSProfile.AllocationRetentionPriority.PreemptionCapability == 0,
+					PreemptionVulnerability: apnCfg.EPSSubscribedQoSProfile.AllocationRetentionPriority.PreemptionVulnerability == 0,
+				},
+				Ambr:                    convertAMBR(apnCfg.AMBR),
+				ChargingCharacteristics: apnCfg.TgppChargingCharacteristics,
+			})
+	}
+	return res
+}
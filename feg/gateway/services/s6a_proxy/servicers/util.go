@@ -19,6 +19,9 @@ import (
 	"github.com/fiorix/go-diameter/v4/diam"
 	"github.com/fiorix/go-diameter/v4/diam/avp"
 	"github.com/fiorix/go-diameter/v4/diam/datatype"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
 )
 
 func (s *s6aProxy) addDiamOriginAVPs(m *diam.Message) {
@@ -28,3 +31,48 @@ func (s *s6aProxy) addDiamOriginAVPs(m *diam.Message) {
 		m.NewAVP(avp.OriginStateID, avp.Mbit, 0, datatype.Unsigned32(s.originStateID))
 	}
 }
+
+// isExperimentalResult returns true if the error code is a 3GPP S6a failure (3GPP TS 29.272 7.4.3 & 7.4.4)
+// which has to be sent in an Experimental-Result AVP
+func isExperimentalResult(code protos.ErrorCode) bool {
+	switch code {
+	case protos.ErrorCode_USER_UNKNOWN,
+		protos.ErrorCode_UNKNOWN_EPS_SUBSCRIPTION,
+		protos.ErrorCode_RAT_NOT_ALLOWED,
+		protos.ErrorCode_ROAMING_NOT_ALLOWED,
+		protos.ErrorCode_EQUIPMENT_UNKNOWN,
+		protos.ErrorCode_UNKOWN_SERVING_NODE,
+		protos.ErrorCode_AUTHENTICATION_DATA_UNAVAILABLE:
+		return true
+	default:
+		return false
+	}
+}
+
+// newAnswer creates an answer to the HSS initiated request m with the given result code
+// carried either in the Result-Code or in the Experimental-Result AVP
+func (s *s6aProxy) newAnswer(m *diam.Message, sessionID string, authSessionState int32, code protos.ErrorCode) *diam.Message {
+	var ans *diam.Message
+	if isExperimentalResult(code) {
+		ans = diam.NewMessage(
+			m.Header.CommandCode,
+			m.Header.CommandFlags&^diam.RequestFlag,
+			m.Header.ApplicationID,
+			m.Header.HopByHopID,
+			m.Header.EndToEndID,
+			m.Dictionary())
+		ans.NewAVP(avp.ExperimentalResult, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(diameter.Vendor3GPP)),
+				diam.NewAVP(avp.ExperimentalResultCode, avp.Mbit, 0, datatype.Unsigned32(code)),
+			},
+		})
+	} else {
+		ans = m.Answer(uint32(mapProtoToDiamResult(code)))
+	}
+	// SessionID is required to be the AVP in position 1
+	ans.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID)))
+	ans.NewAVP(avp.AuthSessionState, avp.Mbit, 0, datatype.Enumerated(authSessionState))
+	s.addDiamOriginAVPs(ans)
+	return ans
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s6b_proxy provides a thin client for using s6b proxy service.
+// This can be used by apps to discover and contact the service, without knowing about
+// the RPC implementation.
+package s6b_proxy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/registry"
+	"magma/orc8r/lib/go/util"
+)
+
+// Wrapper for GRPC Client
+// functionality
+type s6bProxyClient struct {
+	protos.S6BProxyClient
+	cc *grpc.ClientConn
+}
+
+// getS6bProxyClient is a utility function to get a RPC connection to the
+// S6b Proxy service
+func getS6bProxyClient() (*s6bProxyClient, error) {
+	var conn *grpc.ClientConn
+	var err error
+	if util.GetEnvBool("USE_REMOTE_S6B_PROXY", true) {
+		conn, err = registry.Get().GetSharedCloudConnection(strings.ToLower(registry.S6B_PROXY))
+	} else {
+		conn, err = registry.GetConnection(registry.S6B_PROXY)
+	}
+	if err != nil {
+		errMsg := fmt.Sprintf("S6b Proxy client initialization error: %s", err)
+		glog.Error(errMsg)
+		return nil, errors.New(errMsg)
+	}
+	return &s6bProxyClient{
+		protos.NewS6BProxyClient(conn),
+		conn,
+	}, err
+}
+
+// Authorize sends AAR (code 265) over diameter connection,
+// waits (blocks) for AAA & returns its RPC representation
+func Authorize(req *protos.S6BAuthorizationRequest) (*protos.S6BAuthorizationAnswer, error) {
+	err := verifyAuthorizationRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid S6bAuthorizationRequest provided: %s", err)
+	}
+	cli, err := getS6bProxyClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.Authorize(context.Background(), req)
+}
+
+// TerminateSession sends STR (code 275) over diameter connection,
+// waits (blocks) for STA & returns its RPC representation
+func TerminateSession(req *protos.S6BSessionTerminationRequest) (*protos.S6BSessionTerminationAnswer, error) {
+	if req == nil || len(req.GetSessionId()) == 0 {
+		return nil, fmt.Errorf("Invalid S6bSessionTerminationRequest provided: no session ID")
+	}
+	cli, err := getS6bProxyClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.TerminateSession(context.Background(), req)
+}
+
+func verifyAuthorizationRequest(req *protos.S6BAuthorizationRequest) error {
+	if req == nil {
+		return fmt.Errorf("request is nil")
+	}
+	if len(req.GetApn()) == 0 {
+		return fmt.Errorf("no APN provided")
+	}
+	username := req.GetUserName()
+	if len(username) == 0 {
+		return fmt.Errorf("no username provided")
+	} else if len(username) > 15 {
+		return fmt.Errorf("username is too long (must be 15 digits or less)")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Prometheus counters are monotonically increasing
+// Counters reset to zero on service restart
+var (
+	AARRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_aar_requests_total",
+		Help: "Total number of S6b AAR requests sent to the AAA server",
+	})
+	AARSendFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_aar_send_failures_total",
+		Help: "Total number of S6b AAR requests that failed to send to the AAA server",
+	})
+	STRRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_str_requests_total",
+		Help: "Total number of S6b STR requests sent to the AAA server",
+	})
+	STRSendFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_str_send_failures_total",
+		Help: "Total number of S6b STR requests that failed to send to the AAA server",
+	})
+	RARRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_rar_requests_total",
+		Help: "Total number of S6b RAR requests received from the AAA server",
+	})
+	ASRRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_asr_requests_total",
+		Help: "Total number of S6b ASR requests received from the AAA server",
+	})
+	S6bTimeouts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_timeouts_total",
+		Help: "Total number of s6b timeouts",
+	})
+	S6bUnparseableMsg = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_unparseable_msg_total",
+		Help: "Total number of s6b messages received that cannot be parsed",
+	})
+	S6bInvalidSessions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "s6b_invalid_sessions_total",
+		Help: "Total number of s6b responses received with invalid sids",
+	})
+	S6bResultCodes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "s6b_result_codes",
+			Help: "s6b accumulated result codes",
+		},
+		[]string{"code"},
+	)
+	S6bExperimentalResultCodes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "s6b_experimental_result_codes",
+			Help: "s6b accumulated experimental result codes",
+		},
+		[]string{"code"},
+	)
+
+	// Latency Metrics
+	AARLatency = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name:       "s6b_aar_latency",
+		Help:       "Latency of S6b AAR Diameter requests (seconds).",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+	})
+	STRLatency = prometheus.NewSummary(prometheus.SummaryOpts{
+		Name:       "s6b_str_latency",
+		Help:       "Latency of S6b STR Diameter requests (seconds).",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+	})
+)
+
+func init() {
+	prometheus.MustRegister(AARRequests, AARSendFailures, STRRequests,
+		STRSendFailures, RARRequests, ASRRequests, S6bTimeouts, S6bUnparseableMsg,
+		S6bInvalidSessions, S6bResultCodes, S6bExperimentalResultCodes,
+		AARLatency, STRLatency)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Magma's S6b Proxy Service converts gRPC requests into S6b protocol over diameter
+package main
+
+import (
+	"flag"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/s6b_proxy/servicers"
+	"magma/orc8r/lib/go/service"
+
+	"github.com/golang/glog"
+)
+
+func init() {
+	flag.Parse()
+}
+
+func main() {
+	// Create the service
+	srv, err := service.NewServiceWithOptions(registry.ModuleName, registry.S6B_PROXY)
+	if err != nil {
+		glog.Fatalf("Error creating S6b Proxy service: %s", err)
+	}
+
+	// Create servicers
+	servicer, err := servicers.NewS6bProxy(servicers.GetS6bProxyConfig())
+	if err != nil {
+		glog.Fatalf("Failed to create S6bProxy: %v", err)
+	}
+
+	// Register services
+	protos.RegisterS6BProxyServer(srv.GrpcServer, servicer)
+
+	// Run the service
+	err = srv.Run()
+	if err != nil {
+		glog.Fatalf("Error running service: %s", err)
+	}
+}
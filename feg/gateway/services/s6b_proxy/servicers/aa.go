@@ -0,0 +1,182 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/s6b_proxy/metrics"
+	lteprotos "magma/lte/cloud/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Authorize sends AAR (code 265) over diameter connection,
+// waits (blocks) for AAA & returns its RPC representation
+func (s *s6bProxy) Authorize(
+	ctx context.Context,
+	req *protos.S6BAuthorizationRequest,
+) (*protos.S6BAuthorizationAnswer, error) {
+	err := validateAuthorizationRequest(req)
+	if err != nil {
+		return &protos.S6BAuthorizationAnswer{}, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	sid := req.GetSessionId()
+	if len(sid) == 0 {
+		sid = s.genSID(req.GetUserName())
+	}
+	res := &protos.S6BAuthorizationAnswer{SessionId: sid, UserName: req.GetUserName()}
+	aaa, err := s.sendAAR(sid, req)
+	if err != nil {
+		return res, err
+	}
+	res.SessionTimeout = aaa.SessionTimeout
+	res.ApnConfig = aaa.APNConfiguration.toProto()
+
+	session := *req
+	session.SessionId = sid
+	s.setSession(sid, &session)
+	return res, nil
+}
+
+func (s *s6bProxy) sendAAR(sid string, req *protos.S6BAuthorizationRequest) (*AAA, error) {
+	aarStartTime := time.Now()
+	resp, err := s.sendRequest(s.createAAR(sid, req), sid, "AAR", metrics.AARRequests, metrics.AARSendFailures)
+	if err != nil {
+		return nil, err
+	}
+	metrics.AARLatency.Observe(time.Since(aarStartTime).Seconds())
+	aaa, ok := resp.(*AAA)
+	if !ok {
+		metrics.S6bUnparseableMsg.Inc()
+		err = status.Errorf(codes.Internal, "Invalid Response Type: %T, AAA expected.", resp)
+		glog.Error(err)
+		return nil, err
+	}
+	return aaa, translateResultCodes(aaa.ResultCode, aaa.ExperimentalResult)
+}
+
+// createAAR creates an AA-Request authorizing the PGW session of the user (3GPP TS 29.273 9.2.2.2.1)
+func (s *s6bProxy) createAAR(sid string, req *protos.S6BAuthorizationRequest) *diam.Message {
+	msg := s.newS6bRequest(diam.AA, sid, req.GetUserName())
+	msg.NewAVP(avp.AuthRequestType, avp.Mbit, 0, datatype.Enumerated(AuthRequestType_AUTHORIZE_ONLY))
+	msg.NewAVP(avp.ServiceSelection, avp.Mbit, 0, datatype.UTF8String(req.GetApn()))
+	if pgwAVP := getMIP6AgentInfoAVP(req.GetPgw()); pgwAVP != nil {
+		msg.AddAVP(pgwAVP)
+	}
+	if len(req.GetVisitedNetworkId()) > 0 {
+		msg.NewAVP(avp.VisitedNetworkIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP,
+			datatype.OctetString(req.GetVisitedNetworkId()))
+	}
+	if req.GetContextId() != 0 {
+		msg.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(req.GetContextId()))
+	}
+	msg.NewAVP(avp.RATType, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(req.GetRatType()))
+	return msg
+}
+
+// getMIP6AgentInfoAVP returns the MIP6-Agent-Info AVP holding the PGW identity or nil
+// if the identity is not provided
+func getMIP6AgentInfoAVP(pgw *protos.S6BPGWIdentity) *diam.AVP {
+	var avps []*diam.AVP
+	if ip := net.ParseIP(pgw.GetIpAddress()); ip != nil {
+		avps = append(avps, diam.NewAVP(avp.MIPHomeAgentAddress, avp.Mbit, 0, datatype.Address(ip)))
+	}
+	if len(pgw.GetHost()) > 0 {
+		avps = append(avps, diam.NewAVP(avp.MIPHomeAgentHost, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity(pgw.GetRealm())),
+				diam.NewAVP(avp.DestinationHost, avp.Mbit, 0, datatype.DiameterIdentity(pgw.GetHost())),
+			},
+		}))
+	}
+	if len(avps) == 0 {
+		return nil
+	}
+	return diam.NewAVP(avp.MIP6AgentInfo, avp.Mbit, 0, &diam.GroupedAVP{AVP: avps})
+}
+
+func handleAAA(s *s6bProxy) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		var aaa AAA
+		err := m.Unmarshal(&aaa)
+		if err != nil {
+			metrics.S6bUnparseableMsg.Inc()
+			glog.Errorf("AAA Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		s.forwardAnswer(c, m, aaa.SessionID, &aaa)
+	}
+}
+
+// toProto converts the APN-Configuration AVP authorized by the AAA server, nil if the AVP is not present
+func (apn *APNConfiguration) toProto() *lteprotos.APNConfiguration {
+	if len(apn.ServiceSelection) == 0 {
+		return nil
+	}
+	qos := apn.EPSSubscribedQoSProfile
+	config := &lteprotos.APNConfiguration{
+		ContextId:        apn.ContextIdentifier,
+		ServiceSelection: apn.ServiceSelection,
+		Pdn:              lteprotos.APNConfiguration_PDNType(apn.PDNType),
+		QosProfile: &lteprotos.APNConfiguration_QoSProfile{
+			ClassId:                 qos.QoSClassIdentifier,
+			PriorityLevel:           qos.AllocationRetentionPriority.PriorityLevel,
+			PreemptionCapability:    qos.AllocationRetentionPriority.PreemptionCapability == 0,
+			PreemptionVulnerability: qos.AllocationRetentionPriority.PreemptionVulnerability == 0,
+		},
+		Ambr: &lteprotos.AggregatedMaximumBitrate{
+			MaxBandwidthUl: apn.AMBR.MaxRequestedBandwidthUL,
+			MaxBandwidthDl: apn.AMBR.MaxRequestedBandwidthDL,
+		},
+	}
+	if len(apn.ServedPartyIPAddress) > 0 {
+		config.AssignedStaticIp = net.IP(apn.ServedPartyIPAddress[0]).String()
+	}
+	return config
+}
+
+func validateAuthorizationRequest(req *protos.S6BAuthorizationRequest) error {
+	if req == nil {
+		return fmt.Errorf("Nil authorization request provided")
+	}
+	if err := validateUserName(req.GetUserName()); err != nil {
+		return err
+	}
+	if len(req.GetApn()) == 0 {
+		return fmt.Errorf("Empty APN provided in authorization request")
+	}
+	return nil
+}
+
+func validateUserName(userName string) error {
+	if len(userName) == 0 {
+		return fmt.Errorf("Empty user-name provided")
+	}
+	// imsi cannot be greater than 15 digits according to 3GPP Spec 23.003
+	if len(userName) > 15 {
+		return fmt.Errorf("Provided username %s is greater than 15 digits", userName)
+	}
+	return nil
+}
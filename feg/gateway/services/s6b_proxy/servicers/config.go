@@ -0,0 +1,78 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"fmt"
+
+	"magma/feg/gateway/diameter"
+)
+
+const (
+	S6bProxyServiceName = "s6b_proxy"
+
+	AAAAddrEnv           = "S6B_AAA_ADDR"
+	S6bNetworkEnv        = "S6B_NETWORK"
+	S6bDiamHostEnv       = "S6B_DIAM_HOST"
+	S6bDiamRealmEnv      = "S6B_DIAM_REALM"
+	S6bDiamProductEnv    = "S6B_DIAM_PRODUCT"
+	S6bLocalAddrEnv      = "S6B_LOCAL_ADDR"
+	AAAHostEnv           = "S6B_AAA_HOST"
+	AAARealmEnv          = "S6B_AAA_REALM"
+	DisableDestHostEnv   = "S6B_DISABLE_DEST_HOST"
+	OverwriteDestHostEnv = "S6B_OVERWRITE_DEST_HOST"
+
+	DefaultS6bDiamRealm = "epc.mnc070.mcc722.3gppnetwork.org"
+	DefaultS6bDiamHost  = "feg-s6b.epc.mnc070.mcc722.3gppnetwork.org"
+)
+
+// GetS6bProxyConfig returns the service config based on the flags & environment variables
+// or the default values provided
+func GetS6bProxyConfig() *S6bProxyConfig {
+	return &S6bProxyConfig{
+		ClientCfg: &diameter.DiameterClientConfig{
+			Host:        diameter.GetValueOrEnv(diameter.HostFlag, S6bDiamHostEnv, DefaultS6bDiamHost),
+			Realm:       diameter.GetValueOrEnv(diameter.RealmFlag, S6bDiamRealmEnv, DefaultS6bDiamRealm),
+			ProductName: diameter.GetValueOrEnv(diameter.ProductFlag, S6bDiamProductEnv, diameter.DiamProductName),
+		},
+		ServerCfg: &diameter.DiameterServerConfig{DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+			Addr:      diameter.GetValueOrEnv(diameter.AddrFlag, AAAAddrEnv, ""),
+			Protocol:  diameter.GetValueOrEnv(diameter.NetworkFlag, S6bNetworkEnv, "sctp"),
+			LocalAddr: diameter.GetValueOrEnv(diameter.LocalAddrFlag, S6bLocalAddrEnv, "")},
+			DestHost:          diameter.GetValueOrEnv(diameter.DestHostFlag, AAAHostEnv, ""),
+			DestRealm:         diameter.GetValueOrEnv(diameter.DestRealmFlag, AAARealmEnv, ""),
+			DisableDestHost:   diameter.GetBoolValueOrEnv(diameter.DisableDestHostFlag, DisableDestHostEnv, false),
+			OverwriteDestHost: diameter.GetBoolValueOrEnv(diameter.OverwriteDestHostFlag, OverwriteDestHostEnv, false),
+		},
+	}
+}
+
+// ValidateS6bProxyConfig ensures that the s6b proxy config specified has valid
+// diameter client and server configs
+func ValidateS6bProxyConfig(config *S6bProxyConfig) error {
+	if config == nil {
+		return fmt.Errorf("Nil S6bProxyConfig provided")
+	}
+	if config.ClientCfg == nil {
+		return fmt.Errorf("Nil client config provided")
+	}
+	err := config.ClientCfg.Validate()
+	if err != nil {
+		return err
+	}
+	if config.ServerCfg == nil {
+		return fmt.Errorf("Nil server config provided")
+	}
+	return config.ServerCfg.Validate()
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"fmt"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/s6b_proxy/metrics"
+	"magma/feg/gateway/services/session_proxy/relay"
+	"magma/gateway/service_registry"
+	lteprotos "magma/lte/cloud/go/protos"
+)
+
+const (
+	// MaxDiamRTRetries - number of retries for responding to RAR & ASR
+	MaxDiamRTRetries = 1
+)
+
+type fegRelayClient struct {
+	registry service_registry.GatewayRegistry
+}
+
+// RelayASR terminates the session of the user on the gateway serving it
+func (r *fegRelayClient) RelayASR(asr *diameter.ASR) (protos.ErrorCode, error) {
+	if r == nil || r.registry == nil {
+		return protos.ErrorCode_UNABLE_TO_DELIVER, fmt.Errorf("No relay registry for ASR")
+	}
+	client, err := relay.GetAbortSessionResponderClient(r.registry)
+	if err != nil {
+		return protos.ErrorCode_UNABLE_TO_DELIVER, err
+	}
+	defer client.Close()
+
+	res, err := client.AbortSession(context.Background(), &lteprotos.AbortSessionRequest{
+		SessionId: asr.SessionID,
+		UserName:  string(asr.UserName),
+	})
+	if err != nil {
+		return protos.ErrorCode_UNABLE_TO_DELIVER, err
+	}
+	switch res.GetCode() {
+	case lteprotos.AbortSessionResult_SESSION_REMOVED:
+		return protos.ErrorCode_SUCCESS, nil
+	case lteprotos.AbortSessionResult_SESSION_NOT_FOUND, lteprotos.AbortSessionResult_USER_NOT_FOUND:
+		return protos.ErrorCode_UNKNOWN_SESSION_ID, fmt.Errorf(res.GetErrorMessage())
+	default:
+		return protos.ErrorCode_UNABLE_TO_DELIVER, fmt.Errorf(res.GetErrorMessage())
+	}
+}
+
+// handleRAR answers the Re-Auth-Request of the AAA server & re-authorizes the PGW session
+// with a new AAR (3GPP TS 29.273 9.2.2.4). The session is aborted if it is no longer authorized
+func handleRAR(s *s6bProxy) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		glog.V(2).Infof("handling RAR %v\n", m)
+		metrics.RARRequests.Inc()
+		var rar RAR
+		err := m.Unmarshal(&rar)
+		if err != nil {
+			metrics.S6bUnparseableMsg.Inc()
+			glog.Errorf("RAR Unmarshal failed for remote %s & message %s: %v", c.RemoteAddr(), m, err)
+			return
+		}
+		sid := string(rar.SessionID)
+		session, ok := s.getSession(sid)
+		if !ok {
+			err = s.sendAnswer(c, m, sid, diam.UnknownSessionID, MaxDiamRTRetries)
+			if err != nil {
+				glog.Errorf("Failed to send RAA: %v", err)
+			}
+			return
+		}
+		err = s.sendAnswer(c, m, sid, diam.Success, MaxDiamRTRetries)
+		if err != nil {
+			glog.Errorf("Failed to send RAA: %v", err)
+			return
+		}
+		go func() {
+			_, err := s.sendAAR(sid, session)
+			if err == nil {
+				return
+			}
+			glog.Errorf("Re-authorization of S6b session %s failed: %v", sid, err)
+			if status.Code(err) != codes.Code(diam.AuthorizationRejected) &&
+				status.Code(err) != codes.Code(diam.UnknownSessionID) {
+				return
+			}
+			asr := &diameter.ASR{SessionID: sid, UserName: datatype.UTF8String(session.GetUserName())}
+			if _, err = s.Relay.RelayASR(asr); err != nil {
+				glog.Errorf("Failed to abort unauthorized session %s: %v", sid, err)
+			}
+			s.terminateAbortedSession(sid, session.GetUserName())
+		}()
+	}
+}
+
+// handleASR aborts the PGW session on the gateway serving the user, answers the
+// Abort-Session-Request & terminates the session with a STR (3GPP TS 29.273 9.2.2.5)
+func handleASR(s *s6bProxy) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		glog.V(2).Infof("handling ASR %v\n", m)
+		metrics.ASRRequests.Inc()
+		var asr diameter.ASR
+		err := m.Unmarshal(&asr)
+		if err != nil {
+			metrics.S6bUnparseableMsg.Inc()
+			glog.Errorf("ASR Unmarshal failed for remote %s & message %s: %v", c.RemoteAddr(), m, err)
+			return
+		}
+		if len(asr.UserName) == 0 {
+			if session, ok := s.getSession(asr.SessionID); ok {
+				asr.UserName = datatype.UTF8String(session.GetUserName())
+			}
+		}
+		go func() {
+			code, err := s.Relay.RelayASR(&asr)
+			if err != nil {
+				glog.Error(err)
+			}
+			err = s.sendAnswer(c, m, asr.SessionID, uint32(code), MaxDiamRTRetries)
+			if err != nil {
+				glog.Errorf("Failed to send ASA: %v", err)
+			}
+			if code == protos.ErrorCode_SUCCESS {
+				s.terminateAbortedSession(asr.SessionID, string(asr.UserName))
+			}
+		}()
+	}
+}
+
+func (s *s6bProxy) sendAnswer(c diam.Conn, m *diam.Message, sid string, code uint32, retries uint) error {
+	ans := m.Answer(code)
+	// SessionID is required to be the AVP in position 1
+	ans.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sid)))
+	ans.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(s.config.ClientCfg.Host))
+	ans.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(s.config.ClientCfg.Realm))
+	if s.originStateID != 0 {
+		ans.NewAVP(avp.OriginStateID, avp.Mbit, 0, datatype.Unsigned32(s.originStateID))
+	}
+	_, err := ans.WriteToWithRetry(c, retries)
+	return err
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import "github.com/fiorix/go-diameter/v4/diam/datatype"
+
+const (
+	// RFC 6733 8.7: only authorization is requested over S6b, the user is already authenticated
+	AuthRequestType_AUTHORIZE_ONLY = 2
+
+	// RFC 6733 8.12: the PGW has to send a new AAR after a RAR
+	ReAuthRequestType_AUTHORIZE_ONLY = 0
+
+	// RFC 6733 8.15: the user initiated a disconnect
+	TerminationCause_DIAMETER_LOGOUT = 1
+	// RFC 6733 8.15: the session was aborted by the AAA server
+	TerminationCause_DIAMETER_ADMINISTRATIVE = 4
+
+	// 3GPP 29.273 5.2.3.6
+	RadioAccessTechnologyType_WLAN = 0
+
+	// Value of AVP auth-session-state indicating that no state is maintained
+	// between calls.
+	AuthSessionState_NO_STATE_MAINTAINED = 1
+)
+
+// 3GPP 29.273 9.2.2.2.1 - AA-Request for PGW authorization
+type AAR struct {
+	SessionID        datatype.UTF8String       `avp:"Session-Id"`
+	OriginHost       datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm      datatype.DiameterIdentity `avp:"Origin-Realm"`
+	AuthRequestType  datatype.Enumerated       `avp:"Auth-Request-Type"`
+	UserName         datatype.UTF8String       `avp:"User-Name"`
+	ServiceSelection datatype.UTF8String       `avp:"Service-Selection"`
+	MIP6AgentInfo    MIP6AgentInfo             `avp:"MIP6-Agent-Info"`
+	VisitedNetworkID datatype.OctetString      `avp:"Visited-Network-Identifier"`
+	ContextID        datatype.Unsigned32       `avp:"Context-Identifier"`
+	RATType          datatype.Enumerated       `avp:"RAT-Type"`
+}
+
+// 3GPP 29.273 9.2.2.2.1 - AA-Answer for PGW authorization
+type AAA struct {
+	SessionID          string                    `avp:"Session-Id"`
+	ResultCode         uint32                    `avp:"Result-Code"`
+	ExperimentalResult ExperimentalResult        `avp:"Experimental-Result"`
+	OriginHost         datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm        datatype.DiameterIdentity `avp:"Origin-Realm"`
+	UserName           string                    `avp:"User-Name"`
+	SessionTimeout     uint32                    `avp:"Session-Timeout"`
+	APNConfiguration   APNConfiguration          `avp:"APN-Configuration"`
+}
+
+// MIP6AgentInfo holds the PGW identity (RFC 5447 4.2.1)
+type MIP6AgentInfo struct {
+	HomeAgentAddress datatype.Address `avp:"MIP-Home-Agent-Address"`
+	HomeAgentHost    MIPHomeAgentHost `avp:"MIP-Home-Agent-Host"`
+}
+
+type MIPHomeAgentHost struct {
+	DestinationRealm datatype.DiameterIdentity `avp:"Destination-Realm"`
+	DestinationHost  datatype.DiameterIdentity `avp:"Destination-Host"`
+}
+
+type ExperimentalResult struct {
+	VendorId               uint32 `avp:"Vendor-Id"`
+	ExperimentalResultCode uint32 `avp:"Experimental-Result-Code"`
+}
+
+type APNConfiguration struct {
+	ContextIdentifier       uint32                  `avp:"Context-Identifier"`
+	ServedPartyIPAddress    []datatype.Address      `avp:"Served-Party-IP-Address"`
+	PDNType                 int32                   `avp:"PDN-Type"`
+	ServiceSelection        string                  `avp:"Service-Selection"`
+	EPSSubscribedQoSProfile EPSSubscribedQoSProfile `avp:"EPS-Subscribed-QoS-Profile"`
+	AMBR                    AMBR                    `avp:"AMBR"`
+}
+
+type EPSSubscribedQoSProfile struct {
+	QoSClassIdentifier          int32                       `avp:"QoS-Class-Identifier"`
+	AllocationRetentionPriority AllocationRetentionPriority `avp:"Allocation-Retention-Priority"`
+}
+
+type AllocationRetentionPriority struct {
+	PriorityLevel           uint32 `avp:"Priority-Level"`
+	PreemptionCapability    int32  `avp:"Pre-emption-Capability"`
+	PreemptionVulnerability int32  `avp:"Pre-emption-Vulnerability"`
+}
+
+type AMBR struct {
+	MaxRequestedBandwidthUL uint32 `avp:"Max-Requested-Bandwidth-UL"`
+	MaxRequestedBandwidthDL uint32 `avp:"Max-Requested-Bandwidth-DL"`
+}
+
+// 3GPP 29.273 9.2.2.3.1 - Session-Termination-Request
+type STR struct {
+	SessionID        datatype.UTF8String       `avp:"Session-Id"`
+	OriginHost       datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm      datatype.DiameterIdentity `avp:"Origin-Realm"`
+	TerminationCause datatype.Enumerated       `avp:"Termination-Cause"`
+	UserName         datatype.UTF8String       `avp:"User-Name"`
+}
+
+// 3GPP 29.273 9.2.2.3.2 - Session-Termination-Answer
+type STA struct {
+	SessionID          string                    `avp:"Session-Id"`
+	ResultCode         uint32                    `avp:"Result-Code"`
+	ExperimentalResult ExperimentalResult        `avp:"Experimental-Result"`
+	OriginHost         datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm        datatype.DiameterIdentity `avp:"Origin-Realm"`
+}
+
+// 3GPP 29.273 9.2.2.4.1 - Re-Auth-Request, the PGW is requested to re-authorize the session
+type RAR struct {
+	SessionID         datatype.UTF8String       `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	ReAuthRequestType datatype.Enumerated       `avp:"Re-Auth-Request-Type"`
+	UserName          datatype.UTF8String       `avp:"User-Name"`
+}
+
+// 3GPP 29.273 9.2.2.4.2 - Re-Auth-Answer
+type RAA struct {
+	SessionID  string `avp:"Session-Id"`
+	ResultCode uint32 `avp:"Result-Code"`
+}
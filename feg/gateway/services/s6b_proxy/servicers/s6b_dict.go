@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// S6b application ID, missing from the go-diameter default dictionary (3GPP TS 29.273 section 9.1.2)
+const S6bAppID = 16777999
+
+// s6bDictExtension adds the S6b application with its AA command & AVPs to the default dictionary.
+// AVPs of the other 3GPP applications (APN-Configuration, MIP6-Agent-Info, etc.) are not part of the
+// base dictionary & need to be redefined within the S6b application to be found by the parser.
+// STR/STA, RAR/RAA & ASR/ASA are base commands
+const s6bDictExtension = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+    <application id="16777999" type="auth" name="TGPP S6B">
+        <vendor id="10415" name="TGPP"/>
+        <command code="265" short="AA" name="AA">
+            <!-- 3GPP TS 29.273 Section 9.2.2.2 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Service-Selection" required="false" max="1"/>
+                <rule avp="MIP6-Agent-Info" required="false" max="1"/>
+                <rule avp="MIP6-Feature-Vector" required="false" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="Context-Identifier" required="false" max="1"/>
+                <rule avp="RAT-Type" required="false" max="1"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Session-Timeout" required="false" max="1"/>
+                <rule avp="APN-Configuration" required="false" max="1"/>
+                <rule avp="MIP6-Feature-Vector" required="false" max="1"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Redirect-Host" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </answer>
+        </command>
+        <avp name="Service-Selection" code="493" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <data type="UTF8String"/>
+        </avp>
+        <avp name="MIP6-Agent-Info" code="486" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <data type="Grouped">
+                <rule avp="MIP-Home-Agent-Address" required="false" max="2"/>
+                <rule avp="MIP-Home-Agent-Host" required="false" max="1"/>
+                <rule avp="MIP6-Home-Link-Prefix" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+        <avp name="MIP-Home-Agent-Address" code="334" must="M" must-not="V" vendor-id="0">
+            <data type="Address"/>
+        </avp>
+        <avp name="MIP-Home-Agent-Host" code="348" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <data type="Grouped">
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+        <avp name="MIP6-Home-Link-Prefix" code="125" must="M" must-not="V" vendor-id="0">
+            <data type="OctetString"/>
+        </avp>
+        <avp name="MIP6-Feature-Vector" code="124" must="M" may="P" may-encrypt="N" vendor-id="0">
+            <data type="Unsigned64"/>
+        </avp>
+        <avp name="Visited-Network-Identifier" code="600" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+        <avp name="Context-Identifier" code="1423" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="RAT-Type" code="1032" must="M,V" may="P" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="WLAN"/>
+                <item code="1" name="VIRTUAL"/>
+                <item code="1000" name="UTRAN"/>
+                <item code="1001" name="GERAN"/>
+                <item code="1002" name="GAN"/>
+                <item code="1003" name="HSPA_EVOLUTION"/>
+                <item code="1004" name="EUTRAN"/>
+            </data>
+        </avp>
+        <avp name="APN-Configuration" code="1430" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Context-Identifier" required="true" max="1"/>
+                <rule avp="Served-Party-IP-Address" required="false" max="2"/>
+                <rule avp="PDN-Type" required="true" max="1"/>
+                <rule avp="Service-Selection" required="true" max="1"/>
+                <rule avp="EPS-Subscribed-QoS-Profile" required="false" max="1"/>
+                <rule avp="MIP6-Agent-Info" required="false" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="AMBR" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+        <avp name="Served-Party-IP-Address" code="848" must="M,V" may="P" may-encrypt="N" vendor-id="10415">
+            <data type="Address"/>
+        </avp>
+        <avp name="PDN-Type" code="1456" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="IPv4"/>
+                <item code="1" name="IPv6"/>
+                <item code="2" name="IPv4v6"/>
+                <item code="3" name="IPv4_OR_IPv6"/>
+            </data>
+        </avp>
+        <avp name="EPS-Subscribed-QoS-Profile" code="1431" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="QoS-Class-Identifier" required="true" max="1"/>
+                <rule avp="Allocation-Retention-Priority" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+        <avp name="QoS-Class-Identifier" code="1028" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="1" name="QCI_1"/>
+                <item code="2" name="QCI_2"/>
+                <item code="3" name="QCI_3"/>
+                <item code="4" name="QCI_4"/>
+                <item code="5" name="QCI_5"/>
+                <item code="6" name="QCI_6"/>
+                <item code="7" name="QCI_7"/>
+                <item code="8" name="QCI_8"/>
+                <item code="9" name="QCI_9"/>
+            </data>
+        </avp>
+        <avp name="Allocation-Retention-Priority" code="1034" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Priority-Level" required="true" max="1"/>
+                <rule avp="Pre-emption-Capability" required="false" max="1"/>
+                <rule avp="Pre-emption-Vulnerability" required="false" max="1"/>
+            </data>
+        </avp>
+        <avp name="Priority-Level" code="1046" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Pre-emption-Capability" code="1047" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="PRE-EMPTION_CAPABILITY_ENABLED"/>
+                <item code="1" name="PRE-EMPTION_CAPABILITY_DISABLED"/>
+            </data>
+        </avp>
+        <avp name="Pre-emption-Vulnerability" code="1048" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="PRE-EMPTION_VULNERABILITY_ENABLED"/>
+                <item code="1" name="PRE-EMPTION_VULNERABILITY_DISABLED"/>
+            </data>
+        </avp>
+        <avp name="AMBR" code="1435" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Max-Requested-Bandwidth-UL" required="true" max="1"/>
+                <rule avp="Max-Requested-Bandwidth-DL" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+        <avp name="Max-Requested-Bandwidth-DL" code="515" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Max-Requested-Bandwidth-UL" code="516" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+    </application>
+</diameter>`
+
+func init() {
+	err := dict.Default.Load(bytes.NewReader([]byte(s6bDictExtension)))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load S6b dictionary extension: %v", err))
+	}
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicers implements S6b GRPC proxy service which sends AAR/STR messages over
+// diameter connection, waits (blocks) for diameter's AAA/STAs and returns their RPC representation.
+// RAR & ASR received from the 3GPP AAA server are handled on behalf of the PGW
+package servicers
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/s6b_proxy/metrics"
+)
+
+const (
+	TIMEOUT_SECONDS  = 10
+	MAX_DIAM_RETRIES = 1
+)
+
+// Relay forwards the requests of the 3GPP AAA server to the gateway serving the user
+type Relay interface {
+	RelayASR(*diameter.ASR) (protos.ErrorCode, error)
+}
+
+type s6bProxy struct {
+	config         *S6bProxyConfig
+	smClient       *sm.Client
+	connMan        *diameter.ConnectionManager
+	requestTracker *diameter.RequestTracker
+	originStateID  uint32
+	Relay          Relay
+
+	// sessions holds the last authorization request of the authorized PGW sessions by S6b session ID
+	sessions   map[string]*protos.S6BAuthorizationRequest
+	sessionsMu sync.Mutex
+}
+
+type S6bProxyConfig struct {
+	ClientCfg *diameter.DiameterClientConfig
+	ServerCfg *diameter.DiameterServerConfig
+}
+
+// NewS6bProxy creates a new instance of the proxy & begins the connection to the 3GPP AAA server
+func NewS6bProxy(config *S6bProxyConfig) (*s6bProxy, error) {
+	err := ValidateS6bProxyConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientCfg = config.ClientCfg.FillInDefaults()
+
+	originStateID := uint32(time.Now().Unix())
+
+	mux := sm.New(&sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(config.ClientCfg.Host),
+		OriginRealm:      datatype.DiameterIdentity(config.ClientCfg.Realm),
+		VendorID:         datatype.Unsigned32(diameter.Vendor3GPP),
+		ProductName:      datatype.UTF8String(config.ClientCfg.ProductName),
+		OriginStateID:    datatype.Unsigned32(originStateID),
+		FirmwareRevision: 1,
+	})
+
+	mux.HandleFunc("ALL", func(c diam.Conn, m *diam.Message) {
+		if m != nil {
+			glog.Infof("Unhandled S6b message: %s", m)
+		}
+	}) // Catch all.
+
+	if config.ClientCfg.WatchdogInterval == 0 {
+		config.ClientCfg.WatchdogInterval = diameter.DefaultWatchdogIntervalSeconds
+	}
+
+	smClient := &sm.Client{
+		Dict:               dict.Default,
+		Handler:            mux,
+		MaxRetransmits:     config.ClientCfg.Retransmits,
+		RetransmitInterval: time.Second,
+		EnableWatchdog:     config.ClientCfg.WatchdogInterval > 0,
+		WatchdogInterval:   time.Second * time.Duration(config.ClientCfg.WatchdogInterval),
+		SupportedVendorID: []*diam.AVP{
+			diam.NewAVP(avp.SupportedVendorID, avp.Mbit, 0, datatype.Unsigned32(diameter.Vendor3GPP)),
+		},
+		VendorSpecificApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.VendorSpecificApplicationID, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(S6bAppID)),
+					diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(diameter.Vendor3GPP)),
+				},
+			}),
+		},
+	}
+
+	connMan := diameter.NewConnectionManager()
+	// create connection in connection map
+	connMan.GetConnection(smClient, config.ServerCfg)
+
+	proxy := &s6bProxy{
+		config:         config,
+		smClient:       smClient,
+		connMan:        connMan,
+		requestTracker: diameter.NewRequestTracker(),
+		originStateID:  originStateID,
+		Relay:          &fegRelayClient{registry: registry.Get()},
+		sessions:       map[string]*protos.S6BAuthorizationRequest{},
+	}
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: S6bAppID, Code: diam.AA, Request: false},
+		handleAAA(proxy))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: S6bAppID, Code: diam.SessionTermination, Request: false},
+		handleSTA(proxy))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: S6bAppID, Code: diam.ReAuth, Request: true},
+		handleRAR(proxy))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: S6bAppID, Code: diam.AbortSession, Request: true},
+		handleASR(proxy))
+
+	return proxy, nil
+}
+
+func (s *s6bProxy) genSID(imsi string) string {
+	return s.config.ClientCfg.GenSessionIdImsi("s6b", imsi)
+}
+
+func (s *s6bProxy) sendDiameterMsg(msg *diam.Message, retryCount uint) error {
+	conn, err := s.connMan.GetConnection(s.smClient, s.config.ServerCfg)
+	if err != nil {
+		return err
+	}
+	err = conn.SendRequest(msg, retryCount)
+	if err != nil {
+		err = status.Errorf(codes.DataLoss, err.Error())
+	}
+	return err
+}
+
+// newS6bRequest creates a PGW initiated S6b request with the mandatory AVPs
+func (s *s6bProxy) newS6bRequest(cmd uint32, sid string, userName string) *diam.Message {
+	msg := diameter.NewProxiableRequest(cmd, S6bAppID, dict.Default)
+	msg.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sid))
+	msg.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(S6bAppID))
+	msg.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(s.config.ClientCfg.Host))
+	msg.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(s.config.ClientCfg.Realm))
+	if len(userName) > 0 {
+		msg.NewAVP(avp.UserName, avp.Mbit, 0, datatype.UTF8String(userName))
+	}
+	return msg
+}
+
+// sendRequest sends the request to the 3GPP AAA server & waits (blocks) for the answer
+// matching the session ID
+func (s *s6bProxy) sendRequest(
+	msg *diam.Message, sid, name string, requests, sendFailures prometheus.Counter) (interface{}, error) {
+	ch := make(chan interface{})
+	s.requestTracker.RegisterRequest(sid, ch)
+	// if request hasn't been removed by end of transaction, remove it
+	defer s.requestTracker.DeregisterRequest(sid)
+
+	err := s.sendDiameterMsg(msg, MAX_DIAM_RETRIES)
+	if err != nil {
+		sendFailures.Inc()
+		glog.Errorf("Error while sending %s with SID %s: %s", name, sid, err)
+		return nil, err
+	}
+	requests.Inc()
+	select {
+	case resp, open := <-ch:
+		if !open {
+			metrics.S6bInvalidSessions.Inc()
+			err = status.Errorf(codes.Aborted, "%s for Session ID: %s is cancelled", name, sid)
+			glog.Error(err)
+			return nil, err
+		}
+		return resp, nil
+	case <-time.After(time.Second * TIMEOUT_SECONDS):
+		metrics.S6bTimeouts.Inc()
+		err = status.Errorf(codes.DeadlineExceeded, "%s Timed Out for Session ID: %s", name, sid)
+		glog.Error(err)
+		return nil, err
+	}
+}
+
+// translateResultCodes returns the error reflected by the Result-Code or the
+// Experimental-Result of an answer, if any
+func translateResultCodes(resultCode uint32, experimentalResult ExperimentalResult) error {
+	metrics.S6bResultCodes.WithLabelValues(strconv.FormatUint(uint64(resultCode), 10)).Inc()
+	err := diameter.TranslateDiamResultCode(resultCode)
+	// If there is no base diameter error, check that there is no experimental error either
+	if err == nil {
+		code := experimentalResult.ExperimentalResultCode
+		metrics.S6bExperimentalResultCodes.WithLabelValues(strconv.FormatUint(uint64(code), 10)).Inc()
+		err = diameter.TranslateDiamResultCode(code)
+	}
+	return err
+}
+
+// forwardAnswer passes the answer to the request waiting for it
+func (s *s6bProxy) forwardAnswer(c diam.Conn, m *diam.Message, sid string, answer interface{}) {
+	ch := s.requestTracker.DeregisterRequest(sid)
+	if ch != nil {
+		ch <- answer
+	} else {
+		metrics.S6bInvalidSessions.Inc()
+		glog.Errorf("S6b answer SessionID %s not found. Message: %s, Remote: %s", sid, m, c.RemoteAddr())
+	}
+}
+
+func (s *s6bProxy) getSession(sid string) (*protos.S6BAuthorizationRequest, bool) {
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	req, ok := s.sessions[sid]
+	return req, ok
+}
+
+func (s *s6bProxy) setSession(sid string, req *protos.S6BAuthorizationRequest) {
+	s.sessionsMu.Lock()
+	s.sessions[sid] = req
+	s.sessionsMu.Unlock()
+}
+
+func (s *s6bProxy) removeSession(sid string) (*protos.S6BAuthorizationRequest, bool) {
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	req, ok := s.sessions[sid]
+	delete(s.sessions, sid)
+	return req, ok
+}
@@ -0,0 +1,291 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/s6b_proxy/servicers"
+	"magma/feg/gateway/services/s6b_proxy/servicers/test"
+	lteprotos "magma/lte/cloud/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testIMSI = "001010000000001"
+	testAPN  = "internet"
+)
+
+// mockRelay records the ASRs relayed to the gateway & answers them with code
+type mockRelay struct {
+	code protos.ErrorCode
+	asrs chan *diameter.ASR
+}
+
+func (r *mockRelay) RelayASR(asr *diameter.ASR) (protos.ErrorCode, error) {
+	r.asrs <- asr
+	return r.code, nil
+}
+
+func TestS6bProxyService_AuthorizeAndTerminate(t *testing.T) {
+	_, client, server := initS6bTestSetup(t, protos.ErrorCode_SUCCESS)
+
+	res, err := client.Authorize(context.Background(), &protos.S6BAuthorizationRequest{
+		UserName: testIMSI,
+		Apn:      testAPN,
+		Pgw:      &protos.S6BPGWIdentity{IpAddress: "192.168.1.1"},
+		RatType:  servicers.RadioAccessTechnologyType_WLAN,
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.GetSessionId())
+	assert.Equal(t, testIMSI, res.GetUserName())
+	assert.Equal(t, uint32(test.DefaultSessionTimeout), res.GetSessionTimeout())
+	assert.Equal(t, &lteprotos.APNConfiguration{
+		ContextId:        test.DefaultContextID,
+		ServiceSelection: testAPN,
+		Pdn:              lteprotos.APNConfiguration_IPV4,
+		QosProfile: &lteprotos.APNConfiguration_QoSProfile{
+			ClassId:                 test.DefaultQCI,
+			PriorityLevel:           test.DefaultPriorityLevel,
+			PreemptionCapability:    false,
+			PreemptionVulnerability: true,
+		},
+		Ambr: &lteprotos.AggregatedMaximumBitrate{
+			MaxBandwidthUl: test.DefaultMaxBandwidthUL,
+			MaxBandwidthDl: test.DefaultMaxBandwidthDL,
+		},
+		AssignedStaticIp: test.DefaultStaticIP,
+	}, res.GetApnConfig())
+
+	termRes, err := client.TerminateSession(context.Background(), &protos.S6BSessionTerminationRequest{
+		SessionId: res.GetSessionId(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, res.GetSessionId(), termRes.GetSessionId())
+	str := waitForSTR(t, server)
+	assert.Equal(t, res.GetSessionId(), string(str.SessionID))
+	// the user name of the authorized session is used when it is not provided
+	assert.Equal(t, testIMSI, string(str.UserName))
+	assert.Equal(t, datatype.Enumerated(servicers.TerminationCause_DIAMETER_LOGOUT), str.TerminationCause)
+}
+
+func TestS6bProxyService_AuthorizationRejected(t *testing.T) {
+	_, client, server := initS6bTestSetup(t, protos.ErrorCode_SUCCESS)
+	server.RejectUser(testIMSI)
+
+	_, err := client.Authorize(context.Background(), &protos.S6BAuthorizationRequest{UserName: testIMSI, Apn: testAPN})
+	assert.Error(t, err)
+	assert.Equal(t, codes.Code(diam.AuthorizationRejected), status.Code(err))
+}
+
+func TestS6bProxyService_ValidationErrors(t *testing.T) {
+	_, client, _ := initS6bTestSetup(t, protos.ErrorCode_SUCCESS)
+
+	_, err := client.Authorize(context.Background(), &protos.S6BAuthorizationRequest{Apn: testAPN})
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = Empty user-name provided")
+
+	_, err = client.Authorize(context.Background(), &protos.S6BAuthorizationRequest{
+		UserName: "0010100000000001",
+		Apn:      testAPN,
+	})
+	assert.EqualError(t, err,
+		"rpc error: code = InvalidArgument desc = Provided username 0010100000000001 is greater than 15 digits")
+
+	_, err = client.Authorize(context.Background(), &protos.S6BAuthorizationRequest{UserName: testIMSI})
+	assert.EqualError(t, err, "rpc error: code = InvalidArgument desc = Empty APN provided in authorization request")
+
+	_, err = client.TerminateSession(context.Background(), &protos.S6BSessionTerminationRequest{UserName: testIMSI})
+	assert.EqualError(t, err,
+		"rpc error: code = InvalidArgument desc = Empty session ID provided in session termination request")
+}
+
+func TestS6bProxyService_ASR(t *testing.T) {
+	relay, client, server := initS6bTestSetup(t, protos.ErrorCode_SUCCESS)
+
+	res, err := client.Authorize(context.Background(), &protos.S6BAuthorizationRequest{UserName: testIMSI, Apn: testAPN})
+	assert.NoError(t, err)
+	sid := res.GetSessionId()
+
+	// the user name of the authorized session is relayed when the ASR does not hold it
+	assert.NoError(t, server.SendASR(sid, ""))
+	asr := waitForASR(t, relay)
+	assert.Equal(t, sid, asr.SessionID)
+	assert.Equal(t, testIMSI, string(asr.UserName))
+
+	asa := waitForAnswer(t, server)
+	assert.Equal(t, uint32(diam.AbortSession), asa.Header.CommandCode)
+	assert.Equal(t, uint32(diam.Success), getResultCode(t, asa))
+
+	str := waitForSTR(t, server)
+	assert.Equal(t, sid, string(str.SessionID))
+	assert.Equal(t, datatype.Enumerated(servicers.TerminationCause_DIAMETER_ADMINISTRATIVE), str.TerminationCause)
+}
+
+func TestS6bProxyService_ASRUnknownSession(t *testing.T) {
+	relay, client, server := initS6bTestSetup(t, protos.ErrorCode_UNKNOWN_SESSION_ID)
+	// the proxy connects to the AAA server with its first request
+	_, err := client.TerminateSession(context.Background(), &protos.S6BSessionTerminationRequest{
+		SessionId: "connect;session",
+		UserName:  testIMSI,
+	})
+	assert.NoError(t, err)
+	waitForSTR(t, server)
+
+	assert.NoError(t, server.SendASR("unknown;session", testIMSI))
+	waitForASR(t, relay)
+	asa := waitForAnswer(t, server)
+	assert.Equal(t, uint32(diam.UnknownSessionID), getResultCode(t, asa))
+	select {
+	case str := <-server.Terminations:
+		t.Fatalf("Unexpected STR for a session that was not aborted: %v", str)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestS6bProxyService_RAR(t *testing.T) {
+	relay, client, server := initS6bTestSetup(t, protos.ErrorCode_SUCCESS)
+
+	res, err := client.Authorize(context.Background(), &protos.S6BAuthorizationRequest{UserName: testIMSI, Apn: testAPN})
+	assert.NoError(t, err)
+	sid := res.GetSessionId()
+
+	// unknown sessions are rejected
+	assert.NoError(t, server.SendRAR("unknown;session", testIMSI))
+	raa := waitForAnswer(t, server)
+	assert.Equal(t, uint32(diam.ReAuth), raa.Header.CommandCode)
+	assert.Equal(t, uint32(diam.UnknownSessionID), getResultCode(t, raa))
+
+	// the session stays up when it is authorized again
+	assert.NoError(t, server.SendRAR(sid, testIMSI))
+	raa = waitForAnswer(t, server)
+	assert.Equal(t, uint32(diam.Success), getResultCode(t, raa))
+	select {
+	case asr := <-relay.asrs:
+		t.Fatalf("Unexpected ASR for a re-authorized session: %v", asr)
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	// the session is aborted & terminated when the re-authorization is rejected
+	server.RejectUser(testIMSI)
+	assert.NoError(t, server.SendRAR(sid, testIMSI))
+	raa = waitForAnswer(t, server)
+	assert.Equal(t, uint32(diam.Success), getResultCode(t, raa))
+	asr := waitForASR(t, relay)
+	assert.Equal(t, sid, asr.SessionID)
+	assert.Equal(t, testIMSI, string(asr.UserName))
+	str := waitForSTR(t, server)
+	assert.Equal(t, sid, string(str.SessionID))
+	assert.Equal(t, datatype.Enumerated(servicers.TerminationCause_DIAMETER_ADMINISTRATIVE), str.TerminationCause)
+
+	// the aborted session is gone
+	assert.NoError(t, server.SendRAR(sid, testIMSI))
+	raa = waitForAnswer(t, server)
+	assert.Equal(t, uint32(diam.UnknownSessionID), getResultCode(t, raa))
+}
+
+func initS6bTestSetup(t *testing.T, relayCode protos.ErrorCode) (*mockRelay, protos.S6BProxyClient, *test.S6bServer) {
+	// ---- CORE 3gpp ----
+	server, err := test.StartTestS6bServer("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Started S6b Server at %s", server.Addr)
+
+	// ---- GRPC ----
+	grpcListener, err := net.Listen("tcp", "")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	service, err := servicers.NewS6bProxy(getS6bTestConfig(server.Addr))
+	if err != nil {
+		t.Fatalf("failed to create S6bProxy: %v", err)
+	}
+	relay := &mockRelay{code: relayCode, asrs: make(chan *diameter.ASR, 8)}
+	service.Relay = relay
+	grpcServer := grpc.NewServer()
+	protos.RegisterS6BProxyServer(grpcServer, service)
+	// start GRPC server
+	go grpcServer.Serve(grpcListener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial(grpcListener.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("GRPC connect error: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return relay, protos.NewS6BProxyClient(conn), server
+}
+
+func getS6bTestConfig(serverAddr string) *servicers.S6bProxyConfig {
+	return &servicers.S6bProxyConfig{
+		ClientCfg: &diameter.DiameterClientConfig{
+			Host:  "feg-s6b.epc.mnc070.mcc722.3gppnetwork.org",
+			Realm: "epc.mnc070.mcc722.3gppnetwork.org",
+		},
+		ServerCfg: &diameter.DiameterServerConfig{DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+			Addr:     serverAddr,
+			Protocol: "tcp"},
+		},
+	}
+}
+
+func waitForSTR(t *testing.T, server *test.S6bServer) *servicers.STR {
+	select {
+	case str := <-server.Terminations:
+		return str
+	case <-time.After(time.Second * 2):
+		t.Fatal("Timed out waiting for STR")
+		return nil
+	}
+}
+
+func waitForASR(t *testing.T, relay *mockRelay) *diameter.ASR {
+	select {
+	case asr := <-relay.asrs:
+		return asr
+	case <-time.After(time.Second * 2):
+		t.Fatal("Timed out waiting for relayed ASR")
+		return nil
+	}
+}
+
+func waitForAnswer(t *testing.T, server *test.S6bServer) *diam.Message {
+	select {
+	case m := <-server.Answers:
+		return m
+	case <-time.After(time.Second * 2):
+		t.Fatal("Timed out waiting for answer")
+		return nil
+	}
+}
+
+func getResultCode(t *testing.T, m *diam.Message) uint32 {
+	resultAVP, err := m.FindAVP(avp.ResultCode, 0)
+	if err != nil {
+		t.Fatalf("Missing Result-Code in %s", m)
+	}
+	return uint32(resultAVP.Data.(datatype.Unsigned32))
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/s6b_proxy/metrics"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TerminateSession sends STR (code 275) over diameter connection,
+// waits (blocks) for STA & returns its RPC representation
+func (s *s6bProxy) TerminateSession(
+	ctx context.Context,
+	req *protos.S6BSessionTerminationRequest,
+) (*protos.S6BSessionTerminationAnswer, error) {
+	if req == nil || len(req.GetSessionId()) == 0 {
+		return &protos.S6BSessionTerminationAnswer{}, status.Errorf(
+			codes.InvalidArgument, "Empty session ID provided in session termination request")
+	}
+	sid := req.GetSessionId()
+	res := &protos.S6BSessionTerminationAnswer{SessionId: sid}
+	userName := req.GetUserName()
+	// the PGW session is gone regardless of the STA
+	if session, ok := s.removeSession(sid); ok && len(userName) == 0 {
+		userName = session.GetUserName()
+	}
+	cause := req.GetTerminationCause()
+	if cause == 0 {
+		cause = TerminationCause_DIAMETER_LOGOUT
+	}
+	_, err := s.sendSTR(sid, userName, cause)
+	return res, err
+}
+
+func (s *s6bProxy) sendSTR(sid, userName string, cause uint32) (*STA, error) {
+	strStartTime := time.Now()
+	msg := s.newS6bRequest(diam.SessionTermination, sid, userName)
+	msg.NewAVP(avp.TerminationCause, avp.Mbit, 0, datatype.Enumerated(cause))
+	resp, err := s.sendRequest(msg, sid, "STR", metrics.STRRequests, metrics.STRSendFailures)
+	if err != nil {
+		return nil, err
+	}
+	metrics.STRLatency.Observe(time.Since(strStartTime).Seconds())
+	sta, ok := resp.(*STA)
+	if !ok {
+		metrics.S6bUnparseableMsg.Inc()
+		err = status.Errorf(codes.Internal, "Invalid Response Type: %T, STA expected.", resp)
+		glog.Error(err)
+		return nil, err
+	}
+	return sta, translateResultCodes(sta.ResultCode, sta.ExperimentalResult)
+}
+
+// terminateAbortedSession sends the STR required once the PGW session is aborted
+// (3GPP TS 29.273 9.2.2.5)
+func (s *s6bProxy) terminateAbortedSession(sid, userName string) {
+	s.removeSession(sid)
+	_, err := s.sendSTR(sid, userName, TerminationCause_DIAMETER_ADMINISTRATIVE)
+	if err != nil {
+		glog.Errorf("Failed to terminate aborted S6b session %s: %v", sid, err)
+	}
+}
+
+func handleSTA(s *s6bProxy) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		var sta STA
+		err := m.Unmarshal(&sta)
+		if err != nil {
+			metrics.S6bUnparseableMsg.Inc()
+			glog.Errorf("STA Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		s.forwardAnswer(c, m, sta.SessionID, &sta)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package test implements a test 3GPP AAA server answering the S6b requests of the s6b proxy
+package test
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/diameter"
+	s6b "magma/feg/gateway/services/s6b_proxy/servicers"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+const (
+	VENDOR_3GPP = diameter.Vendor3GPP
+
+	DefaultSessionTimeout = 3600
+	DefaultContextID      = 1
+	DefaultQCI            = 9
+	DefaultPriorityLevel  = 15
+	DefaultMaxBandwidthUL = 100000
+	DefaultMaxBandwidthDL = 200000
+	DefaultStaticIP       = "10.10.10.10"
+)
+
+// S6bServer is a test 3GPP AAA server. It authorizes the AARs of all users except
+// the rejected ones, answers STRs & can initiate RARs & ASRs towards the proxy
+type S6bServer struct {
+	Addr string
+	// Answers receives the RAAs & ASAs sent by the proxy
+	Answers chan *diam.Message
+	// Terminations receives the STRs sent by the proxy
+	Terminations chan *s6b.STR
+
+	settings *sm.Settings
+	mu       sync.Mutex
+	conn     diam.Conn
+	rejected map[string]bool
+}
+
+// StartTestS6bServer starts a new Test S6b Server on given network & address
+func StartTestS6bServer(network, addr string) (*S6bServer, error) {
+	settings := &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity("aaa.epc.mnc070.mcc722.3gppnetwork.org"),
+		OriginRealm:      datatype.DiameterIdentity("epc.mnc070.mcc722.3gppnetwork.org"),
+		VendorID:         datatype.Unsigned32(diameter.Vendor3GPP),
+		ProductName:      "go-diameter-s6b",
+		FirmwareRevision: 1,
+	}
+	srv := &S6bServer{
+		Answers:      make(chan *diam.Message, 16),
+		Terminations: make(chan *s6b.STR, 16),
+		settings:     settings,
+		rejected:     map[string]bool{},
+	}
+	// Create the state machine (mux) and set its message handlers.
+	errResults := make(chan error, 2)
+	addrResult := make(chan string, 1)
+
+	mux := sm.New(settings)
+
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.AA, Request: true},
+		srv.testHandleAAR())
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.SessionTermination, Request: true},
+		srv.testHandleSTR())
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.ReAuth, Request: false},
+		srv.testHandleAnswer())
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.AbortSession, Request: false},
+		srv.testHandleAnswer())
+
+	// Catch All
+	mux.HandleIdx(diam.ALL_CMD_INDEX, testHandleALL())
+
+	// Print error reports.
+	go testPrintErrors(mux.ErrorReports())
+
+	// Start S6b Diameter Server
+	go func() {
+		errResults <- nil
+		server := diam.Server{
+			Network: network,
+			Addr:    addr,
+			Handler: mux,
+		}
+		lis, err := diam.MultistreamListen(network, addr)
+		if err != nil {
+			fmt.Printf("StartTestS6bServer Error: %v for address: %s\n", err, addr)
+			errResults <- err
+			addrResult <- ""
+			return
+		}
+		addrResult <- lis.Addr().String()
+		server.Serve(lis)
+	}()
+	err := <-errResults
+	srv.Addr = <-addrResult
+	if err == nil {
+		// the listener reports its error after the first nil result
+		select {
+		case err = <-errResults:
+		default:
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(time.Millisecond * 20)
+	return srv, nil
+}
+
+// RejectUser makes the server reject the following AARs of the user with DIAMETER_AUTHORIZATION_REJECTED
+func (srv *S6bServer) RejectUser(userName string) {
+	srv.mu.Lock()
+	srv.rejected[userName] = true
+	srv.mu.Unlock()
+}
+
+// SendRAR sends a Re-Auth-Request for the session to the proxy
+func (srv *S6bServer) SendRAR(sid, userName string) error {
+	msg := srv.newRequest(diam.ReAuth, sid, userName)
+	msg.NewAVP(avp.ReAuthRequestType, avp.Mbit, 0, datatype.Enumerated(s6b.ReAuthRequestType_AUTHORIZE_ONLY))
+	return srv.send(msg)
+}
+
+// SendASR sends an Abort-Session-Request for the session to the proxy
+func (srv *S6bServer) SendASR(sid, userName string) error {
+	return srv.send(srv.newRequest(diam.AbortSession, sid, userName))
+}
+
+func (srv *S6bServer) newRequest(cmd uint32, sid, userName string) *diam.Message {
+	msg := diam.NewRequest(cmd, s6b.S6bAppID, dict.Default)
+	msg.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sid))
+	msg.NewAVP(avp.OriginHost, avp.Mbit, 0, srv.settings.OriginHost)
+	msg.NewAVP(avp.OriginRealm, avp.Mbit, 0, srv.settings.OriginRealm)
+	msg.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity("epc.mnc070.mcc722.3gppnetwork.org"))
+	msg.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(s6b.S6bAppID))
+	if len(userName) > 0 {
+		msg.NewAVP(avp.UserName, avp.Mbit, 0, datatype.UTF8String(userName))
+	}
+	return msg
+}
+
+func (srv *S6bServer) send(msg *diam.Message) error {
+	srv.mu.Lock()
+	conn := srv.conn
+	srv.mu.Unlock()
+	if conn == nil {
+		return fmt.Errorf("No proxy connection to send %s", msg)
+	}
+	_, err := msg.WriteTo(conn)
+	return err
+}
+
+func (srv *S6bServer) isRejected(userName string) bool {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.rejected[userName]
+}
+
+func (srv *S6bServer) setConn(c diam.Conn) {
+	srv.mu.Lock()
+	srv.conn = c
+	srv.mu.Unlock()
+}
+
+func testHandleALL() diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		fmt.Printf("Received unexpected message from %s:\n%s", c.RemoteAddr(), m)
+	}
+}
+
+// S6b AA-Request
+func (srv *S6bServer) testHandleAAR() diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		srv.setConn(c)
+		var req s6b.AAR
+		code := uint32(diam.Success)
+		err := m.Unmarshal(&req)
+		if err != nil {
+			fmt.Printf("AAR Unmarshal for message: %s failed: %s", m, err)
+			code = diam.UnableToComply
+		} else if srv.isRejected(string(req.UserName)) {
+			code = diam.AuthorizationRejected
+		}
+		a := srv.newAnswer(m, code, req.SessionID)
+		a.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(s6b.S6bAppID))
+		a.NewAVP(avp.AuthRequestType, avp.Mbit, 0, datatype.Enumerated(s6b.AuthRequestType_AUTHORIZE_ONLY))
+		a.NewAVP(avp.UserName, avp.Mbit, 0, req.UserName)
+		if code == diam.Success {
+			a.NewAVP(avp.SessionTimeout, avp.Mbit, 0, datatype.Unsigned32(DefaultSessionTimeout))
+			a.AddAVP(getAPNConfigurationAVP(string(req.ServiceSelection)))
+		}
+		_, err = a.WriteTo(c)
+		if err != nil {
+			fmt.Printf("Failed to send AAA: %s", err.Error())
+		}
+	}
+}
+
+// S6b Session-Termination-Request
+func (srv *S6bServer) testHandleSTR() diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		srv.setConn(c)
+		var req s6b.STR
+		code := uint32(diam.Success)
+		err := m.Unmarshal(&req)
+		if err != nil {
+			fmt.Printf("STR Unmarshal for message: %s failed: %s", m, err)
+			code = diam.UnableToComply
+		}
+		a := srv.newAnswer(m, code, req.SessionID)
+		_, err = a.WriteTo(c)
+		if err != nil {
+			fmt.Printf("Failed to send STA: %s", err.Error())
+		}
+		srv.Terminations <- &req
+	}
+}
+
+func (srv *S6bServer) testHandleAnswer() diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		srv.Answers <- m
+	}
+}
+
+func (srv *S6bServer) newAnswer(m *diam.Message, code uint32, sid datatype.UTF8String) *diam.Message {
+	a := m.Answer(code)
+	// SessionID is required to be the AVP in position 1
+	a.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, sid))
+	a.NewAVP(avp.OriginHost, avp.Mbit, 0, srv.settings.OriginHost)
+	a.NewAVP(avp.OriginRealm, avp.Mbit, 0, srv.settings.OriginRealm)
+	return a
+}
+
+func getAPNConfigurationAVP(apn string) *diam.AVP {
+	return diam.NewAVP(avp.APNConfiguration, avp.Mbit|avp.Vbit, VENDOR_3GPP, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, VENDOR_3GPP, datatype.Unsigned32(DefaultContextID)),
+			diam.NewAVP(avp.ServedPartyIPAddress, avp.Mbit|avp.Vbit, VENDOR_3GPP,
+				datatype.Address(net.ParseIP(DefaultStaticIP).To4())),
+			diam.NewAVP(avp.PDNType, avp.Mbit|avp.Vbit, VENDOR_3GPP, datatype.Enumerated(0)), // IPv4
+			diam.NewAVP(avp.ServiceSelection, avp.Mbit, 0, datatype.UTF8String(apn)),
+			diam.NewAVP(avp.EPSSubscribedQoSProfile, avp.Mbit|avp.Vbit, VENDOR_3GPP, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.QoSClassIdentifier, avp.Mbit|avp.Vbit, VENDOR_3GPP, datatype.Enumerated(DefaultQCI)),
+					diam.NewAVP(avp.AllocationRetentionPriority, avp.Vbit, VENDOR_3GPP, &diam.GroupedAVP{
+						AVP: []*diam.AVP{
+							diam.NewAVP(avp.PriorityLevel, avp.Vbit, VENDOR_3GPP, datatype.Unsigned32(DefaultPriorityLevel)),
+							diam.NewAVP(avp.PreemptionCapability, avp.Vbit, VENDOR_3GPP, datatype.Enumerated(1)),
+							diam.NewAVP(avp.PreemptionVulnerability, avp.Vbit, VENDOR_3GPP, datatype.Enumerated(0)),
+						},
+					}),
+				},
+			}),
+			diam.NewAVP(avp.AMBR, avp.Mbit|avp.Vbit, VENDOR_3GPP, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.MaxRequestedBandwidthUL, avp.Mbit|avp.Vbit, VENDOR_3GPP,
+						datatype.Unsigned32(DefaultMaxBandwidthUL)),
+					diam.NewAVP(avp.MaxRequestedBandwidthDL, avp.Mbit|avp.Vbit, VENDOR_3GPP,
+						datatype.Unsigned32(DefaultMaxBandwidthDL)),
+				},
+			}),
+		},
+	})
+}
+
+func testPrintErrors(ec <-chan *diam.ErrorReport) {
+	for err := range ec {
+		fmt.Printf("Error: %v for Message: %s", err.Error, err.Message)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capture
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"magma/feg/gateway/diameter"
+)
+
+// Capture Environment Variables
+const (
+	CaptureFileEnv      = "SESSION_PROXY_CAPTURE_FILE"
+	CaptureMaxSizeMBEnv = "SESSION_PROXY_CAPTURE_MAX_SIZE_MB"
+	CaptureMaxFilesEnv  = "SESSION_PROXY_CAPTURE_MAX_FILES"
+	CaptureIMSIsEnv     = "SESSION_PROXY_CAPTURE_IMSIS"
+
+	CaptureFileFlag      = "capture_file"
+	CaptureMaxSizeMBFlag = "capture_max_size_mb"
+	CaptureMaxFilesFlag  = "capture_max_files"
+	CaptureIMSIsFlag     = "capture_imsis"
+
+	DefaultCaptureMaxSizeMB = 100
+	DefaultCaptureMaxFiles  = 5
+)
+
+func init() {
+	_ = flag.String(CaptureFileFlag, "", "File to capture Gx & Gy messages to, capture is disabled if empty")
+	_ = flag.String(CaptureMaxSizeMBFlag, "", "Size in MB at which the capture file is rotated")
+	_ = flag.String(CaptureMaxFilesFlag, "", "Number of rotated capture files to keep")
+	_ = flag.String(CaptureIMSIsFlag, "", "Comma separated IMSIs to capture the messages of, all IMSIs if empty")
+}
+
+// Config is the configuration of the Gx & Gy message capture
+type Config struct {
+	File      string
+	MaxSizeMB int64
+	MaxFiles  int
+	IMSIs     []string
+}
+
+// GetConfig returns the capture configuration from the flags or environment variables
+func GetConfig() (*Config, error) {
+	cfg := &Config{
+		File: diameter.GetValueOrEnv(CaptureFileFlag, CaptureFileEnv, ""),
+	}
+	maxSize := diameter.GetValueOrEnv(CaptureMaxSizeMBFlag, CaptureMaxSizeMBEnv, strconv.Itoa(DefaultCaptureMaxSizeMB))
+	var err error
+	cfg.MaxSizeMB, err = strconv.ParseInt(maxSize, 10, 64)
+	if err != nil || cfg.MaxSizeMB <= 0 {
+		return nil, fmt.Errorf("Invalid capture max size: %s", maxSize)
+	}
+	maxFiles := diameter.GetValueOrEnv(CaptureMaxFilesFlag, CaptureMaxFilesEnv, strconv.Itoa(DefaultCaptureMaxFiles))
+	cfg.MaxFiles, err = strconv.Atoi(maxFiles)
+	if err != nil || cfg.MaxFiles < 0 {
+		return nil, fmt.Errorf("Invalid capture max files: %s", maxFiles)
+	}
+	for _, imsi := range strings.Split(diameter.GetValueOrEnv(CaptureIMSIsFlag, CaptureIMSIsEnv, ""), ",") {
+		imsi = strings.TrimPrefix(strings.TrimSpace(imsi), "IMSI")
+		if len(imsi) > 0 {
+			cfg.IMSIs = append(cfg.IMSIs, imsi)
+		}
+	}
+	return cfg, nil
+}
+
+// NewConfiguredRecorder creates the recorder of the capture configuration,
+// it returns nil if the capture is disabled
+func NewConfiguredRecorder() (*Recorder, error) {
+	cfg, err := GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.File) == 0 {
+		return nil, nil
+	}
+	writer, err := NewFileWriter(cfg.File, cfg.MaxSizeMB*1024*1024, cfg.MaxFiles)
+	if err != nil {
+		return nil, err
+	}
+	return NewRecorder(writer, cfg.IMSIs), nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capture
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// DefaultIgnoredAVPs are the AVPs which differ between two runs of the same
+// exchange and are therefore not compared
+var DefaultIgnoredAVPs = []string{
+	"Session-Id",
+	"Origin-Host",
+	"Origin-Realm",
+	"Origin-State-Id",
+	"Destination-Host",
+	"Destination-Realm",
+	"Event-Timestamp",
+	"Route-Record",
+	"Proxy-Info",
+}
+
+var paddingSuffix = regexp.MustCompile(`,Padding:\d+$`)
+
+// DiffMessages compares the AVPs of the expected & actual messages regardless of
+// their order, except for the given ignored AVPs. It returns the sorted differences,
+// as "- <AVP path>=<value>" for missing AVPs & "+ <AVP path>=<value>" for unexpected ones
+func DiffMessages(expected, actual *diam.Message, ignoredAVPs ...string) []string {
+	ignored := map[string]bool{}
+	for _, name := range ignoredAVPs {
+		ignored[name] = true
+	}
+	counts := map[string]int{}
+	flattenAVPs(expected.Header.ApplicationID, "", expected.AVP, ignored, counts, 1)
+	flattenAVPs(actual.Header.ApplicationID, "", actual.AVP, ignored, counts, -1)
+
+	var diff []string
+	if expected.Header.CommandCode != actual.Header.CommandCode {
+		diff = append(diff,
+			fmt.Sprintf("- Command-Code=%d", expected.Header.CommandCode),
+			fmt.Sprintf("+ Command-Code=%d", actual.Header.CommandCode))
+	}
+	for value, count := range counts {
+		for ; count > 0; count-- {
+			diff = append(diff, "- "+value)
+		}
+		for ; count < 0; count++ {
+			diff = append(diff, "+ "+value)
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		// Order by AVP path first, so that the changed values are next to each other
+		if diff[i][2:] != diff[j][2:] {
+			return diff[i][2:] < diff[j][2:]
+		}
+		return diff[i] < diff[j]
+	})
+	return diff
+}
+
+// flattenAVPs adds increment to the count of every "<AVP path>=<value>" of the AVPs
+func flattenAVPs(appID uint32, prefix string, avps []*diam.AVP, ignored map[string]bool, counts map[string]int, increment int) {
+	for _, a := range avps {
+		name := getAVPName(appID, a)
+		if ignored[name] {
+			continue
+		}
+		path := prefix + name
+		if group, ok := a.Data.(*diam.GroupedAVP); ok {
+			flattenAVPs(appID, path+"/", group.AVP, ignored, counts, increment)
+			continue
+		}
+		value := ""
+		if a.Data != nil {
+			value = paddingSuffix.ReplaceAllString(a.Data.String(), "")
+		}
+		counts[path+"="+value] += increment
+	}
+}
+
+func getAVPName(appID uint32, a *diam.AVP) string {
+	dictAVP, err := dict.Default.FindAVPWithVendor(appID, a.Code, a.VendorID)
+	if err != nil {
+		return fmt.Sprintf("AVP(%d,%d)", a.Code, a.VendorID)
+	}
+	return dictAVP.Name
+}
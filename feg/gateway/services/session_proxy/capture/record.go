@@ -0,0 +1,216 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package capture records the Gx & Gy Diameter messages exchanged by session_proxy
+// and replays captured sessions against a PCRF/OCS to compare their answers
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/golang/glog"
+)
+
+// Interfaces of the captured messages
+const (
+	InterfaceGx = "gx"
+	InterfaceGy = "gy"
+)
+
+// Record is a captured Diameter message, it is stored as one JSON line of a capture file
+type Record struct {
+	Time       time.Time `json:"time"`
+	Interface  string    `json:"interface"`
+	Outgoing   bool      `json:"outgoing"`
+	IMSI       string    `json:"imsi,omitempty"`
+	SessionID  string    `json:"session_id"`
+	Command    string    `json:"command"`
+	Request    bool      `json:"request"`
+	EndToEndID uint32    `json:"end_to_end_id"`
+	// Message is the serialized message, Text its human readable form
+	Message []byte `json:"message"`
+	Text    string `json:"text,omitempty"`
+}
+
+// NewRecord creates the record of a Gx or Gy message
+func NewRecord(message *diam.Message, outgoing bool) (*Record, error) {
+	iface := GetInterface(message.Header.ApplicationID)
+	if len(iface) == 0 {
+		return nil, fmt.Errorf("Unsupported application ID: %d", message.Header.ApplicationID)
+	}
+	serialized, err := message.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("Error serializing %s message: %s", iface, err)
+	}
+	// AVPs modified in place do not update the header's length, use the actual one
+	length := len(serialized)
+	serialized[1], serialized[2], serialized[3] = byte(length>>16), byte(length>>8), byte(length)
+	sessionID := getSessionID(message)
+	return &Record{
+		Time:       time.Now(),
+		Interface:  iface,
+		Outgoing:   outgoing,
+		IMSI:       getIMSI(message, sessionID),
+		SessionID:  sessionID,
+		Command:    getCommandName(message),
+		Request:    message.Header.CommandFlags&diam.RequestFlag == diam.RequestFlag,
+		EndToEndID: message.Header.EndToEndID,
+		Message:    serialized,
+		Text:       message.String(),
+	}, nil
+}
+
+// Decode returns the captured message
+func (r *Record) Decode() (*diam.Message, error) {
+	return diam.ReadMessage(bytes.NewReader(r.Message), dict.Default)
+}
+
+// GetInterface returns the interface of the Diameter application, or an empty string
+// if the application is neither Gx nor Gy
+func GetInterface(appID uint32) string {
+	switch appID {
+	case diam.GX_CHARGING_CONTROL_APP_ID:
+		return InterfaceGx
+	case diam.CHARGING_CONTROL_APP_ID:
+		return InterfaceGy
+	default:
+		return ""
+	}
+}
+
+// getAppID returns the Diameter application of the interface
+func getAppID(iface string) (uint32, error) {
+	switch iface {
+	case InterfaceGx:
+		return diam.GX_CHARGING_CONTROL_APP_ID, nil
+	case InterfaceGy:
+		return diam.CHARGING_CONTROL_APP_ID, nil
+	default:
+		return 0, fmt.Errorf("Unsupported interface: %s", iface)
+	}
+}
+
+// Recorder is a diameter.MessageRecorder writing the Gx & Gy messages of all or
+// of selected IMSIs to a capture file
+type Recorder struct {
+	writer *FileWriter
+	imsis  map[string]bool
+}
+
+// NewRecorder creates a recorder of the messages of the given IMSIs,
+// the messages of all IMSIs are recorded if none is given
+func NewRecorder(writer *FileWriter, imsis []string) *Recorder {
+	recorder := &Recorder{writer: writer}
+	if len(imsis) > 0 {
+		recorder.imsis = map[string]bool{}
+		for _, imsi := range imsis {
+			recorder.imsis[imsi] = true
+		}
+	}
+	return recorder
+}
+
+// RecordMessage implements diameter.MessageRecorder
+func (r *Recorder) RecordMessage(message *diam.Message, outgoing bool) {
+	if message == nil || len(GetInterface(message.Header.ApplicationID)) == 0 {
+		return
+	}
+	record, err := NewRecord(message, outgoing)
+	if err != nil {
+		glog.Errorf("Error capturing message: %s", err)
+		return
+	}
+	if r.imsis != nil && !r.imsis[record.IMSI] {
+		return
+	}
+	if err = r.writer.Write(record); err != nil {
+		glog.Errorf("Error writing %s %s capture: %s", record.Interface, record.Command, err)
+	}
+}
+
+// Close closes the capture file of the recorder
+func (r *Recorder) Close() error {
+	return r.writer.Close()
+}
+
+func getSessionID(message *diam.Message) string {
+	sidAVP, err := message.FindAVP(avp.SessionID, 0)
+	if err != nil || sidAVP == nil {
+		return ""
+	}
+	sid, ok := sidAVP.Data.(datatype.UTF8String)
+	if !ok {
+		return ""
+	}
+	return string(sid)
+}
+
+// getIMSI returns the IMSI encoded in the session ID, or the IMSI Subscription-Id of the message
+func getIMSI(message *diam.Message, sessionID string) string {
+	imsi, err := diameter.ExtractImsiFromSessionID(sessionID)
+	if err == nil {
+		return imsi
+	}
+	subscriptionIDs, err := message.FindAVPs(avp.SubscriptionID, 0)
+	if err != nil {
+		return ""
+	}
+	for _, subscriptionID := range subscriptionIDs {
+		group, ok := subscriptionID.Data.(*diam.GroupedAVP)
+		if !ok {
+			continue
+		}
+		var (
+			idType uint32 = ^uint32(0)
+			idData string
+		)
+		for _, a := range group.AVP {
+			switch a.Code {
+			case avp.SubscriptionIDType:
+				if t, ok := a.Data.(datatype.Enumerated); ok {
+					idType = uint32(t)
+				}
+			case avp.SubscriptionIDData:
+				if d, ok := a.Data.(datatype.UTF8String); ok {
+					idData = string(d)
+				}
+			}
+		}
+		if idType == uint32(credit_control.EndUserIMSI) {
+			return idData
+		}
+	}
+	return ""
+}
+
+// getCommandName returns the abbreviation of the message command, e.g. CCR or RAA
+func getCommandName(message *diam.Message) string {
+	suffix := "A"
+	if message.Header.CommandFlags&diam.RequestFlag == diam.RequestFlag {
+		suffix = "R"
+	}
+	command, err := dict.Default.FindCommand(message.Header.ApplicationID, message.Header.CommandCode)
+	if err != nil {
+		return fmt.Sprintf("%d%s", message.Header.CommandCode, suffix)
+	}
+	return command.Short + suffix
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capture
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"magma/feg/gateway/diameter"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// DefaultReplayTimeout is the default time to wait for the answer of a replayed request
+const DefaultReplayTimeout = 3 * time.Second
+
+// replacedAVPs are the AVPs of the captured requests replaced by the replaying client
+var replacedAVPs = map[uint32]bool{
+	avp.OriginHost:       true,
+	avp.OriginRealm:      true,
+	avp.OriginStateID:    true,
+	avp.DestinationHost:  true,
+	avp.DestinationRealm: true,
+	avp.RouteRecord:      true,
+	avp.ProxyInfo:        true,
+}
+
+// ReplayOptions selects the captured requests to replay & how to compare the answers
+type ReplayOptions struct {
+	// IMSI & SessionID select the sessions to replay, all are replayed if empty
+	IMSI      string
+	SessionID string
+	// NewSessionIDs replaces the captured session IDs by new ones, for servers
+	// which already know the captured sessions
+	NewSessionIDs bool
+	Timeout       time.Duration
+	// IgnoredAVPs are not compared in addition to DefaultIgnoredAVPs
+	IgnoredAVPs []string
+}
+
+// Result is the outcome of a replayed request
+type Result struct {
+	SessionID     string
+	Command       string
+	RequestNumber uint32
+	// Expected is the captured answer, if any, & Actual the answer to the replayed request
+	Expected *diam.Message
+	Actual   *diam.Message
+	Diff     []string
+	Err      error
+}
+
+// Matches returns true if the replayed request was answered like the captured one
+func (r *Result) Matches() bool {
+	return r.Err == nil && r.Expected != nil && r.Actual != nil && len(r.Diff) == 0
+}
+
+func (r *Result) String() string {
+	header := fmt.Sprintf("%s %s #%d", r.SessionID, r.Command, r.RequestNumber)
+	switch {
+	case r.Err != nil:
+		return fmt.Sprintf("%s: ERROR %s", header, r.Err)
+	case r.Expected == nil:
+		return fmt.Sprintf("%s: no captured answer to compare with, received:\n%s", header, r.Actual)
+	case len(r.Diff) == 0:
+		return fmt.Sprintf("%s: OK", header)
+	default:
+		return fmt.Sprintf("%s: answers differ (- captured, + replayed):\n\t%s", header, strings.Join(r.Diff, "\n\t"))
+	}
+}
+
+// Replayer replays the captured Gx or Gy credit control requests against a
+// PCRF or OCS and compares its answers with the captured ones. The captured
+// requests initiated by the server (RAR, ASR) cannot be replayed and are skipped
+type Replayer struct {
+	iface     string
+	appID     uint32
+	clientCfg *diameter.DiameterClientConfig
+	serverCfg *diameter.DiameterServerConfig
+	client    *diameter.Client
+}
+
+type replayKey struct {
+	sessionID     string
+	requestNumber uint32
+}
+
+type answerKey struct {
+	sessionID  string
+	endToEndID uint32
+}
+
+// NewReplayer creates a replayer of the interface's captured requests
+func NewReplayer(
+	iface string,
+	clientCfg *diameter.DiameterClientConfig,
+	serverCfg *diameter.DiameterServerConfig,
+) (*Replayer, error) {
+	appID, err := getAppID(iface)
+	if err != nil {
+		return nil, err
+	}
+	client := diameter.NewClient(clientCfg)
+	client.RegisterAnswerHandlerForAppID(diam.CreditControl, appID, func(message *diam.Message) diameter.KeyAndAnswer {
+		requestNumber, err := getUint32AVP(message, avp.CCRequestNumber)
+		if err != nil {
+			return diameter.KeyAndAnswer{}
+		}
+		return diameter.KeyAndAnswer{
+			Answer: message,
+			Key:    replayKey{sessionID: getSessionID(message), requestNumber: requestNumber},
+		}
+	})
+	return &Replayer{iface: iface, appID: appID, clientCfg: clientCfg, serverCfg: serverCfg, client: client}, nil
+}
+
+// Replay sends the selected captured requests in their capture order, waiting for
+// the answer of each request before sending the next one
+func (r *Replayer) Replay(records []*Record, opts *ReplayOptions) []*Result {
+	if opts == nil {
+		opts = &ReplayOptions{}
+	}
+	answers := map[answerKey]*Record{}
+	for _, record := range records {
+		if record.Interface == r.iface && !record.Outgoing && !record.Request {
+			answers[answerKey{record.SessionID, record.EndToEndID}] = record
+		}
+	}
+	ignored := append(append([]string{}, DefaultIgnoredAVPs...), opts.IgnoredAVPs...)
+	newSessionIDs := map[string]string{}
+	var results []*Result
+	for _, record := range records {
+		if record.Interface != r.iface || !record.Outgoing || !record.Request || record.Command != "CCR" ||
+			(len(opts.IMSI) > 0 && record.IMSI != opts.IMSI) ||
+			(len(opts.SessionID) > 0 && record.SessionID != opts.SessionID) {
+			continue
+		}
+		sessionID := record.SessionID
+		if opts.NewSessionIDs {
+			if _, ok := newSessionIDs[sessionID]; !ok {
+				newSessionIDs[sessionID] = r.newSessionID(record)
+			}
+			sessionID = newSessionIDs[sessionID]
+		}
+		result := r.replayRequest(record, sessionID, opts.Timeout)
+		if result.Err == nil {
+			if answer, ok := answers[answerKey{record.SessionID, record.EndToEndID}]; ok {
+				result.Expected, result.Err = answer.Decode()
+			}
+		}
+		if result.Expected != nil && result.Actual != nil {
+			result.Diff = DiffMessages(result.Expected, result.Actual, ignored...)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func (r *Replayer) replayRequest(record *Record, sessionID string, timeout time.Duration) *Result {
+	result := &Result{SessionID: record.SessionID, Command: record.Command}
+	request, err := record.Decode()
+	if err != nil {
+		result.Err = fmt.Errorf("Error decoding captured request: %s", err)
+		return result
+	}
+	result.RequestNumber, err = getUint32AVP(request, avp.CCRequestNumber)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	prepareRequest(request, sessionID)
+
+	if timeout <= 0 {
+		timeout = DefaultReplayTimeout
+	}
+	key := replayKey{sessionID: sessionID, requestNumber: result.RequestNumber}
+	done := make(chan interface{}, 1)
+	if err = r.client.SendRequest(r.serverCfg, done, request, key); err != nil {
+		result.Err = fmt.Errorf("Error sending request: %s", err)
+		return result
+	}
+	select {
+	case answer := <-done:
+		result.Actual = answer.(*diam.Message)
+	case <-time.After(timeout):
+		r.client.IgnoreAnswer(key)
+		result.Err = fmt.Errorf("No answer after %s", timeout)
+	}
+	return result
+}
+
+func (r *Replayer) newSessionID(record *Record) string {
+	if len(record.IMSI) > 0 {
+		return r.clientCfg.GenSessionIdImsi(r.iface, record.IMSI)
+	}
+	return r.clientCfg.GenSessionID(r.iface)
+}
+
+// prepareRequest removes the AVPs of the capturing client & peer from the request,
+// sets its session ID & gives it a new hop-by-hop ID
+func prepareRequest(request *diam.Message, sessionID string) {
+	avps := request.AVP[:0]
+	for _, a := range request.AVP {
+		if replacedAVPs[a.Code] && a.VendorID == 0 {
+			continue
+		}
+		if a.Code == avp.SessionID && a.VendorID == 0 {
+			a.Data = datatype.UTF8String(sessionID)
+		}
+		avps = append(avps, a)
+	}
+	request.AVP = avps
+	request.Header.HopByHopID = rand.Uint32()
+	request.Header.MessageLength = uint32(request.Len())
+}
+
+func getUint32AVP(message *diam.Message, code uint32) (uint32, error) {
+	a, err := message.FindAVP(code, 0)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := a.Data.(datatype.Unsigned32)
+	if !ok {
+		return 0, fmt.Errorf("Invalid AVP %d data type: %T", code, a.Data)
+	}
+	return uint32(value), nil
+}
+
+// ReplayFile replays the captured requests of the interface from the capture file
+func ReplayFile(
+	path string,
+	iface string,
+	clientCfg *diameter.DiameterClientConfig,
+	serverCfg *diameter.DiameterServerConfig,
+	opts *ReplayOptions,
+) ([]*Result, error) {
+	records, err := ReadRecords(path)
+	if err != nil {
+		return nil, err
+	}
+	replayer, err := NewReplayer(iface, clientCfg, serverCfg)
+	if err != nil {
+		return nil, err
+	}
+	return replayer.Replay(records, opts), nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capture_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/session_proxy/capture"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+	"magma/feg/gateway/services/session_proxy/credit_control/gx"
+	"magma/feg/gateway/services/testcore/pcrf/mock_pcrf"
+	"magma/lte/cloud/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+const (
+	testIMSI1 = "001010000000001"
+	testIMSI2 = "001010000000002"
+)
+
+func TestCaptureAndReplay(t *testing.T) {
+	clientConfig := &diameter.DiameterClientConfig{
+		Host:        "test.test.com",
+		Realm:       "test.com",
+		ProductName: "capture_test",
+		AppID:       diam.GX_CHARGING_CONTROL_APP_ID,
+	}
+	serverConfig := &diameter.DiameterServerConfig{
+		DiameterServerConnConfig: diameter.DiameterServerConnConfig{Addr: "127.0.0.1:0", Protocol: "tcp"},
+	}
+	pcrf := startPCRF(t, clientConfig, serverConfig)
+	for _, imsi := range []string{testIMSI1, testIMSI2} {
+		pcrf.CreateAccount(context.Background(), &protos.SubscriberID{Id: imsi})
+		pcrf.SetRules(context.Background(), &fegprotos.AccountRules{Imsi: imsi, StaticRuleNames: []string{"rule1"}})
+	}
+
+	dir, err := ioutil.TempDir("", "capture_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.json")
+	writer, err := capture.NewFileWriter(path, 1024*1024, 1)
+	assert.NoError(t, err)
+
+	// Capture the session of IMSI 1 only
+	gxClient := gx.NewGxClient(clientConfig, serverConfig, nil, nil, &gx.GxGlobalConfig{})
+	gxClient.SetMessageRecorder(capture.NewRecorder(writer, []string{testIMSI1}))
+	for _, imsi := range []string{testIMSI1, testIMSI2} {
+		sendCCR(t, gxClient, serverConfig, imsi, credit_control.CRTInit, 0)
+		sendCCR(t, gxClient, serverConfig, imsi, credit_control.CRTTerminate, 1)
+	}
+	// Messages are recorded asynchronously
+	var records []*capture.Record
+	assert.Eventually(t, func() bool {
+		records, err = capture.ReadRecords(path)
+		return err == nil && len(records) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, writer.Close())
+	assert.Len(t, records, 4)
+	for i, record := range records {
+		assert.Equal(t, capture.InterfaceGx, record.Interface)
+		assert.Equal(t, testIMSI1, record.IMSI)
+		assert.Equal(t, i%2 == 0, record.Outgoing)
+		assert.Equal(t, i%2 == 0, record.Request)
+		assert.Equal(t, map[bool]string{true: "CCR", false: "CCA"}[record.Request], record.Command)
+		assert.Equal(t, records[0].SessionID, record.SessionID)
+	}
+
+	// Replaying against the unchanged PCRF gives the same answers
+	replayer, err := capture.NewReplayer(capture.InterfaceGx, clientConfig, serverConfig)
+	assert.NoError(t, err)
+	results := replayer.Replay(records, &capture.ReplayOptions{NewSessionIDs: true})
+	assert.Len(t, results, 2)
+	for i, result := range results {
+		assert.NoError(t, result.Err)
+		assert.Equal(t, uint32(i), result.RequestNumber)
+		assert.True(t, result.Matches(), result.String())
+		assert.NotEqual(t, records[0].SessionID, getSessionID(t, result.Actual))
+	}
+
+	// Replaying after the subscriber's rules changed shows the difference
+	pcrf.SetRules(context.Background(), &fegprotos.AccountRules{Imsi: testIMSI1, StaticRuleNames: []string{"rule2"}})
+	results = replayer.Replay(records, &capture.ReplayOptions{IMSI: testIMSI1, NewSessionIDs: true})
+	assert.Len(t, results, 2)
+	assert.Equal(t, []string{
+		"- Charging-Rule-Install/Charging-Rule-Name=OctetString{0x72756c6531}",
+		"+ Charging-Rule-Install/Charging-Rule-Name=OctetString{0x72756c6532}",
+	}, results[0].Diff)
+	assert.True(t, results[1].Matches(), results[1].String())
+
+	assert.Empty(t, replayer.Replay(records, &capture.ReplayOptions{IMSI: testIMSI2}))
+	assert.Empty(t, replayer.Replay(records, &capture.ReplayOptions{SessionID: "unknown"}))
+}
+
+func TestDiffMessages(t *testing.T) {
+	newCCA := func(resultCode uint32, ruleNames ...string) *diam.Message {
+		m := diam.NewMessage(diam.CreditControl, 0, diam.GX_CHARGING_CONTROL_APP_ID, 1, 1, dict.Default)
+		m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(ruleNames[0]))
+		m.NewAVP(avp.ResultCode, avp.Mbit, 0, datatype.Unsigned32(resultCode))
+		m.NewAVP(avp.EventTimestamp, avp.Mbit, 0, datatype.Unsigned32(resultCode))
+		for _, name := range ruleNames {
+			m.NewAVP(avp.ChargingRuleInstall, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.ChargingRuleName, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.OctetString(name)),
+				},
+			})
+		}
+		return m
+	}
+	assert.Empty(t, capture.DiffMessages(newCCA(2001, "a", "b"), newCCA(2001, "b", "a"), capture.DefaultIgnoredAVPs...))
+	assert.Equal(t,
+		[]string{
+			"- Charging-Rule-Install/Charging-Rule-Name=OctetString{0x61}",
+			"- Event-Timestamp=Unsigned32{2001}",
+			"+ Event-Timestamp=Unsigned32{5030}",
+			"- Result-Code=Unsigned32{2001}",
+			"+ Result-Code=Unsigned32{5030}",
+			"- Session-Id=UTF8String{a}",
+			"+ Session-Id=UTF8String{b}",
+		},
+		capture.DiffMessages(newCCA(2001, "a", "b"), newCCA(5030, "b")))
+	assert.Equal(t,
+		[]string{
+			"+ Charging-Rule-Install/Charging-Rule-Name=OctetString{0x62}",
+			"- Result-Code=Unsigned32{2001}",
+			"+ Result-Code=Unsigned32{5030}",
+		},
+		capture.DiffMessages(newCCA(2001, "a"), newCCA(5030, "a", "b"), capture.DefaultIgnoredAVPs...))
+}
+
+func startPCRF(
+	t *testing.T,
+	client *diameter.DiameterClientConfig,
+	server *diameter.DiameterServerConfig,
+) *mock_pcrf.PCRFServer {
+	pcrf := mock_pcrf.NewPCRFServer(client, server)
+	lis, err := pcrf.StartListener()
+	assert.NoError(t, err)
+	// Overwrite config addr with the allocated port
+	server.Addr = lis.Addr().String()
+	go pcrf.Start(lis)
+	return pcrf
+}
+
+func sendCCR(
+	t *testing.T,
+	gxClient *gx.GxClient,
+	server *diameter.DiameterServerConfig,
+	imsi string,
+	requestType credit_control.CreditRequestType,
+	requestNumber uint32,
+) {
+	done := make(chan interface{}, 1)
+	request := &gx.CreditControlRequest{
+		SessionID:     "IMSI" + imsi + "-1234",
+		Type:          requestType,
+		IMSI:          imsi,
+		RequestNumber: requestNumber,
+		IPAddr:        "192.168.1.1",
+	}
+	assert.NoError(t, gxClient.SendCreditControlRequest(server, done, request))
+	answer := gx.GetAnswer(done)
+	assert.Equal(t, uint32(diam.Success), answer.ResultCode)
+}
+
+func getSessionID(t *testing.T, message *diam.Message) string {
+	sidAVP, err := message.FindAVP(avp.SessionID, 0)
+	assert.NoError(t, err)
+	return string(sidAVP.Data.(datatype.UTF8String))
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capture
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// maxRecordSize is the maximum size of a record line read from a capture file
+const maxRecordSize = 4 * 1024 * 1024
+
+// FileWriter writes records to a capture file, rotating it once it reaches its
+// maximum size. Rotated files are renamed <path>.1 to <path>.<maxFiles>, <path>.1
+// being the most recent one
+type FileWriter struct {
+	sync.Mutex
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewFileWriter opens or creates the capture file at path for appending
+func NewFileWriter(path string, maxSize int64, maxFiles int) (*FileWriter, error) {
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("Invalid capture file max size: %d", maxSize)
+	}
+	if maxFiles < 0 {
+		return nil, fmt.Errorf("Invalid capture max files: %d", maxFiles)
+	}
+	w := &FileWriter{path: path, maxSize: maxSize, maxFiles: maxFiles}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends the record to the capture file
+func (w *FileWriter) Write(record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	w.Lock()
+	defer w.Unlock()
+	if w.file == nil {
+		return fmt.Errorf("Capture file %s is closed", w.path)
+	}
+	if w.size > 0 && w.size+int64(len(line)) > w.maxSize {
+		if err = w.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := w.file.Write(line)
+	w.size += int64(n)
+	return err
+}
+
+// Close closes the capture file
+func (w *FileWriter) Close() error {
+	w.Lock()
+	defer w.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *FileWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("Error opening capture file: %s", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("Error opening capture file: %s", err)
+	}
+	w.file, w.size = file, info.Size()
+	return nil
+}
+
+// rotate shifts the rotated files, dropping the oldest one, and starts a new capture file
+func (w *FileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+	if w.maxFiles == 0 {
+		if err := os.Remove(w.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return w.open()
+	}
+	for i := w.maxFiles - 1; i > 0; i-- {
+		err := os.Rename(rotatedPath(w.path, i), rotatedPath(w.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(w.path, rotatedPath(w.path, 1)); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+func rotatedPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+// ReadRecords returns the records of a capture file
+func ReadRecords(path string) ([]*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &Record{}
+		if err = json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("Invalid record at %s:%d: %s", path, line, err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
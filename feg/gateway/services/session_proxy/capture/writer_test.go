@@ -0,0 +1,125 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capture
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileWriter_Rotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture_test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "capture.json")
+
+	record := &Record{Interface: InterfaceGx, SessionID: "sid", Command: "CCR", Message: make([]byte, 100)}
+	line, err := json.Marshal(record)
+	assert.NoError(t, err)
+	// Every file holds two records
+	maxSize := int64(5 * (len(line) + 1) / 2)
+	writer, err := NewFileWriter(path, maxSize, 2)
+	assert.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		record.EndToEndID = uint32(i)
+		assert.NoError(t, writer.Write(record))
+	}
+	assert.NoError(t, writer.Close())
+	assert.Error(t, writer.Write(record))
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	assertEndToEndIDs(t, path, 6)
+	assertEndToEndIDs(t, path+".1", 4, 5)
+	assertEndToEndIDs(t, path+".2", 2, 3)
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	// Reopening appends to the existing capture file
+	writer, err = NewFileWriter(path, maxSize, 0)
+	assert.NoError(t, err)
+	record.EndToEndID = 7
+	assert.NoError(t, writer.Write(record))
+	assertEndToEndIDs(t, path, 6, 7)
+	record.EndToEndID = 8
+	assert.NoError(t, writer.Write(record))
+	assert.NoError(t, writer.Close())
+	assertEndToEndIDs(t, path, 8)
+	assertEndToEndIDs(t, path+".1", 4, 5)
+
+	_, err = NewFileWriter(path, 0, 1)
+	assert.EqualError(t, err, "Invalid capture file max size: 0")
+}
+
+func TestNewRecord(t *testing.T) {
+	m := diam.NewRequest(diam.CreditControl, diam.GX_CHARGING_CONTROL_APP_ID, dict.Default)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("sid"))
+	m.NewAVP(avp.SubscriptionID, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.SubscriptionIDType, avp.Mbit, 0, datatype.Enumerated(0)),
+			diam.NewAVP(avp.SubscriptionIDData, avp.Mbit, 0, datatype.UTF8String("5491123456789")),
+		},
+	})
+	m.NewAVP(avp.SubscriptionID, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.SubscriptionIDType, avp.Mbit, 0, datatype.Enumerated(1)),
+			diam.NewAVP(avp.SubscriptionIDData, avp.Mbit, 0, datatype.UTF8String("001010000000001")),
+		},
+	})
+	// AVP modified in place, without updating the message length
+	m.AVP[0].Data = datatype.UTF8String("magma;123;456;IMSI001010000000002")
+
+	record, err := NewRecord(m, true)
+	assert.NoError(t, err)
+	assert.Equal(t, InterfaceGx, record.Interface)
+	assert.Equal(t, "CCR", record.Command)
+	assert.True(t, record.Request)
+	assert.True(t, record.Outgoing)
+	assert.Equal(t, "magma;123;456;IMSI001010000000002", record.SessionID)
+	// The IMSI of the session ID has priority
+	assert.Equal(t, "001010000000002", record.IMSI)
+
+	decoded, err := record.Decode()
+	assert.NoError(t, err)
+	assert.Empty(t, DiffMessages(m, decoded))
+
+	m.AVP = m.AVP[1:]
+	record, err = NewRecord(m, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "", record.SessionID)
+	assert.Equal(t, "001010000000001", record.IMSI)
+
+	_, err = NewRecord(diam.NewRequest(diam.CreditControl, 16777236, dict.Default), false)
+	assert.EqualError(t, err, "Unsupported application ID: 16777236")
+}
+
+func assertEndToEndIDs(t *testing.T, path string, ids ...uint32) {
+	records, err := ReadRecords(path)
+	assert.NoError(t, err)
+	var actual []uint32
+	for _, record := range records {
+		actual = append(actual, record.EndToEndID)
+	}
+	assert.Equal(t, ids, actual, path)
+}
@@ -99,6 +99,7 @@ func (h *asrHandler) sendASA(conn diam.Conn, m *diam.Message, sid string, code u
 	asaMsg := m.Answer(code)
 	asaMsg.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sid)))
 	asaMsg = h.diamClient.AddOriginAVPsToMessage(asaMsg)
+	h.diamClient.RecordMessage(asaMsg, true)
 	_, err := asaMsg.WriteToWithRetry(conn, h.diamClient.Retries())
 	if err != nil {
 		glog.Errorf(
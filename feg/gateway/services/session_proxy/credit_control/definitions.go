@@ -43,6 +43,7 @@ const (
 	None RequestKeyNamespace = iota
 	Gx
 	Gy
+	Rx
 )
 
 type SubscriptionIDType uint8
@@ -151,6 +151,11 @@ func (gxClient *GxClient) IgnoreAnswer(request *CreditControlRequest) {
 	)
 }
 
+// SetMessageRecorder sets the recorder of the Gx messages sent & received by the client
+func (gxClient *GxClient) SetMessageRecorder(recorder diameter.MessageRecorder) {
+	gxClient.diamClient.SetMessageRecorder(recorder)
+}
+
 func (gxClient *GxClient) EnableConnections() error {
 	if gxClient.globalConfig.DisableGx {
 		return nil
@@ -178,6 +183,7 @@ func registerReAuthHandler(reAuthHandler PolicyReAuthHandler, diamClient *diamet
 			raa := reAuthHandler(rar)
 			raaMsg := createReAuthAnswerMessage(message, raa, diamClient)
 			raaMsg = diamClient.AddOriginAVPsToMessage(raaMsg)
+			diamClient.RecordMessage(raaMsg, true)
 			_, err := raaMsg.WriteToWithRetry(conn, diamClient.Retries())
 			if err != nil {
 				glog.Errorf(
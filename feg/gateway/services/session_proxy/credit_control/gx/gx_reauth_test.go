@@ -51,6 +51,24 @@ func TestReAuthRelay(t *testing.T) {
 	// Handler should use session ID from request instead of response
 	assert.Equal(t, &gx.PolicyReAuthAnswer{SessionID: sessionID, ResultCode: diam.Success, RuleReports: []*gx.ChargingRuleReport{}}, actual)
 
+	// Rules with invalid flows are not relayed, but reported in the RAA
+	req = &gx.PolicyReAuthRequest{
+		SessionID: sessionID,
+		RulesToInstall: []*gx.RuleInstallAVP{{
+			RuleDefinitions: []*gx.RuleDefinition{
+				{RuleName: "invalid", FlowDescriptions: []string{"permit out ip from any to !assigned"}},
+			},
+		}},
+	}
+	sm.On("PolicyReAuth", mock.Anything, &protos.PolicyReAuthRequest{SessionId: sessionID, Imsi: imsi}).
+		Return(&protos.PolicyReAuthAnswer{SessionId: "mock_ret"}, nil).Once()
+	actual = handler(req)
+	assert.Equal(t, &gx.PolicyReAuthAnswer{
+		SessionID:   sessionID,
+		ResultCode:  diam.Success,
+		RuleReports: []*gx.ChargingRuleReport{{RuleNames: []string{"invalid"}, FailureCode: gx.IncorrectFlowInformation}},
+	}, actual)
+
 	// Bad session ID
 	req = &gx.PolicyReAuthRequest{SessionID: "bad"}
 	actual = handler(req)
@@ -82,7 +82,8 @@ func GetGxReAuthHandler(cloudRegistry service_registry.GatewayRegistry, policyDB
 		}
 		defer client.Close()
 
-		gwReq := request.ToProto(imsi, sid, policyDBClient)
+		gwReq, failures := request.ToProto(imsi, sid, policyDBClient)
+		ReportRuleInstallFailures(sid, "Gx RAR", failures)
 		ans, err := client.PolicyReAuth(context.Background(), gwReq)
 		if err != nil {
 			glog.Errorf("Error relaying Gx reauth request to gateway: %s", err)
@@ -91,6 +92,8 @@ func GetGxReAuthHandler(cloudRegistry service_registry.GatewayRegistry, policyDB
 				ResultCode: diam.UnableToDeliver,
 			}
 		}
-		return (&PolicyReAuthAnswer{}).FromProto(request.SessionID, ans)
+		raa := (&PolicyReAuthAnswer{}).FromProto(request.SessionID, ans)
+		raa.RuleReports = append(raa.RuleReports, ToChargingRuleReports(failures)...)
+		return raa
 	}
 }
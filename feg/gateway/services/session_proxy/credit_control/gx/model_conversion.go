@@ -14,10 +14,12 @@ limitations under the License.
 package gx
 
 import (
+	"fmt"
 	"time"
 
 	"magma/feg/gateway/policydb"
 	"magma/feg/gateway/services/session_proxy/credit_control"
+	"magma/feg/gateway/services/session_proxy/metrics"
 	"magma/lte/cloud/go/protos"
 
 	"github.com/fiorix/go-diameter/v4/diam"
@@ -55,17 +57,24 @@ func (qos *QosRequestInfo) FromProtos(pQos *protos.QosInformationRequest) *QosRe
 	return qos
 }
 
-func (rd *RuleDefinition) ToProto() *protos.PolicyRule {
+// ToProto converts the rule definition to a policy rule. It returns an error if
+// one of the rule's flow descriptions is invalid, since enforcing a rule with
+// a partial flow list would match the wrong traffic.
+func (rd *RuleDefinition) ToProto() (*protos.PolicyRule, error) {
+	flowList, err := rd.GetFlowList()
+	if err != nil {
+		return nil, err
+	}
 	return &protos.PolicyRule{
 		Id:            rd.RuleName,
 		RatingGroup:   swag.Uint32Value(rd.RatingGroup),
 		MonitoringKey: rd.MonitoringKey,
 		Priority:      rd.Precedence,
 		Redirect:      rd.RedirectInformation.ToProto(),
-		FlowList:      rd.GetFlowList(),
+		FlowList:      flowList,
 		Qos:           rd.Qos.ToProto(),
 		TrackingType:  rd.GetTrackingType(),
-	}
+	}, nil
 }
 
 func (q *QosInformation) ToProto() *protos.FlowQos {
@@ -106,24 +115,30 @@ func (r *RedirectInformation) ToProto() *protos.RedirectInformation {
 	}
 }
 
-func (rd *RuleDefinition) GetFlowList() []*protos.FlowDescription {
+// GetFlowList returns the flows of the Flow-Description & Flow-Information AVPs
+// of the rule, or the error of the first invalid flow description
+func (rd *RuleDefinition) GetFlowList() ([]*protos.FlowDescription, error) {
 	allFlowStrings := rd.FlowDescriptions[:]
 	for _, info := range rd.FlowInformations {
 		allFlowStrings = append(allFlowStrings, info.FlowDescription)
 	}
 	var flowList []*protos.FlowDescription
 	for _, flowString := range allFlowStrings {
-		flow, err := policydb.GetFlowDescriptionFromFlowString(flowString)
+		flows, err := policydb.GetFlowDescriptionsFromFlowString(flowString)
 		if err != nil {
-			glog.Errorf("Could not get flow for description %s : %s", flowString, err)
-		} else {
-			flowList = append(flowList, flow)
+			return nil, fmt.Errorf("Invalid Flow-Description of rule %s: %s", rd.RuleName, err)
 		}
+		flowList = append(flowList, flows...)
 	}
-	return flowList
+	return flowList, nil
 }
 
-func (rar *PolicyReAuthRequest) ToProto(imsi, sid string, policyDBClient policydb.PolicyDBClient) *protos.PolicyReAuthRequest {
+// ToProto converts the RAR to the request relayed to the gateway, along with the
+// rules to install which were rejected
+func (rar *PolicyReAuthRequest) ToProto(
+	imsi, sid string,
+	policyDBClient policydb.PolicyDBClient,
+) (*protos.PolicyReAuthRequest, []*RuleInstallFailure) {
 	var rulesToRemove, baseNamesToRemove []string
 
 	for _, ruleRemove := range rar.RulesToRemove {
@@ -134,7 +149,7 @@ func (rar *PolicyReAuthRequest) ToProto(imsi, sid string, policyDBClient policyd
 	baseNameRuleIDsToRemove := policyDBClient.GetRuleIDsForBaseNames(baseNamesToRemove)
 	rulesToRemove = append(rulesToRemove, baseNameRuleIDsToRemove...)
 
-	staticRulesToInstall, dynamicRulesToInstall := ParseRuleInstallAVPs(
+	staticRulesToInstall, dynamicRulesToInstall, failures := ParseRuleInstallAVPs(
 		policyDBClient,
 		rar.RulesToInstall,
 	)
@@ -153,7 +168,7 @@ func (rar *PolicyReAuthRequest) ToProto(imsi, sid string, policyDBClient policyd
 		RevalidationTime:       revalidationTime,
 		UsageMonitoringCredits: usageMonitoringCredits,
 		QosInfo:                qosInfo,
-	}
+	}, failures
 }
 
 func (raa *PolicyReAuthAnswer) FromProto(sessionID string, answer *protos.PolicyReAuthAnswer) *PolicyReAuthAnswer {
@@ -181,10 +196,21 @@ func ConvertToProtoTimestamp(unixTime *time.Time) *timestamp.Timestamp {
 	return protoTimestamp
 }
 
+// RuleInstallFailure is a rule of a Charging-Rule-Install AVP which cannot be installed
+type RuleInstallFailure struct {
+	RuleName string
+	Code     RuleFailureCode
+	Err      error
+}
+
+// ParseRuleInstallAVPs returns the static & dynamic rules to install. Dynamic rules
+// with invalid flow descriptions are not installed, they are returned as failures
+// so they can be reported to the PCRF.
 func ParseRuleInstallAVPs(
 	policyDBClient policydb.PolicyDBClient,
 	ruleInstalls []*RuleInstallAVP,
-) ([]*protos.StaticRuleInstall, []*protos.DynamicRuleInstall) {
+) ([]*protos.StaticRuleInstall, []*protos.DynamicRuleInstall, []*RuleInstallFailure) {
+	var failures []*RuleInstallFailure
 	staticRulesToInstall := make([]*protos.StaticRuleInstall, 0, len(ruleInstalls))
 	dynamicRulesToInstall := make([]*protos.DynamicRuleInstall, 0, len(ruleInstalls))
 	for _, ruleInstall := range ruleInstalls {
@@ -217,17 +243,47 @@ func ParseRuleInstallAVPs(
 		}
 
 		for _, def := range ruleInstall.RuleDefinitions {
+			policyRule, err := def.ToProto()
+			if err != nil {
+				failures = append(
+					failures,
+					&RuleInstallFailure{RuleName: def.RuleName, Code: IncorrectFlowInformation, Err: err},
+				)
+				continue
+			}
 			dynamicRulesToInstall = append(
 				dynamicRulesToInstall,
 				&protos.DynamicRuleInstall{
-					PolicyRule:       def.ToProto(),
+					PolicyRule:       policyRule,
 					ActivationTime:   activationTime,
 					DeactivationTime: deactivationTime,
 				},
 			)
 		}
 	}
-	return staticRulesToInstall, dynamicRulesToInstall
+	return staticRulesToInstall, dynamicRulesToInstall, failures
+}
+
+// ToChargingRuleReports converts the rule install failures to the
+// Charging-Rule-Reports sent back to the PCRF
+func ToChargingRuleReports(failures []*RuleInstallFailure) []*ChargingRuleReport {
+	reports := make([]*ChargingRuleReport, 0, len(failures))
+	for _, failure := range failures {
+		reports = append(
+			reports,
+			&ChargingRuleReport{RuleNames: []string{failure.RuleName}, FailureCode: failure.Code},
+		)
+	}
+	return reports
+}
+
+// ReportRuleInstallFailures logs the rules of a Gx message which were rejected
+// & counts them in the invalid rules metric
+func ReportRuleInstallFailures(sessionID, message string, failures []*RuleInstallFailure) {
+	for _, failure := range failures {
+		glog.Errorf("Rejected rule %s of %s for session %s: %s", failure.RuleName, message, sessionID, failure.Err)
+		metrics.GxInvalidRules.Inc()
+	}
 }
 
 func ParseRuleRemoveAVPs(policyDBClient policydb.PolicyDBClient, rulesToRemoveAVP []*RuleRemoveAVP) []string {
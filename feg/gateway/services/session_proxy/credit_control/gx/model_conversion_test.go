@@ -107,7 +107,8 @@ func TestReAuthRequest_ToProto(t *testing.T) {
 	policyClient.On("GetRuleIDsForBaseNames", []string{"baseInstall2", "baseInstall3"}).
 		Return([]string{"install42", "install43"})
 
-	actual := in.ToProto("IMSI001010000000001", "magma;1234;1234;IMSI001010000000001", policyClient)
+	actual, failures := in.ToProto("IMSI001010000000001", "magma;1234;1234;IMSI001010000000001", policyClient)
+	assert.Empty(t, failures)
 	expected := &protos.PolicyReAuthRequest{
 		SessionId:     "magma;1234;1234;IMSI001010000000001",
 		Imsi:          "IMSI001010000000001",
@@ -187,6 +188,55 @@ func TestReAuthRequest_ToProto(t *testing.T) {
 	policyClient.AssertExpectations(t)
 }
 
+func TestParseRuleInstallAVPs_InvalidFlows(t *testing.T) {
+	ruleInstalls := []*gx.RuleInstallAVP{
+		{
+			RuleNames: []string{"static1"},
+			RuleDefinitions: []*gx.RuleDefinition{
+				{
+					RuleName:         "valid",
+					Precedence:       100,
+					FlowDescriptions: []string{"permit out 6 from any 80,443 to assigned"},
+					FlowInformations: []*gx.FlowInformation{{FlowDescription: "permit in ip from assigned to 2001:db8::/32"}},
+				},
+				{
+					RuleName:         "negated",
+					FlowDescriptions: []string{"permit out ip from any to assigned", "permit in ip from assigned to !10.0.0.0/8"},
+				},
+				{
+					RuleName:         "malformed",
+					FlowInformations: []*gx.FlowInformation{{FlowDescription: "permit in ip from 1.2.3 to any"}},
+				},
+			},
+		},
+	}
+	staticRules, dynamicRules, failures := gx.ParseRuleInstallAVPs(&mocks.PolicyDBClient{}, ruleInstalls)
+	assert.Equal(t, []*protos.StaticRuleInstall{{RuleId: "static1"}}, staticRules)
+	assert.Len(t, dynamicRules, 1)
+	assert.Equal(t, "valid", dynamicRules[0].PolicyRule.Id)
+	flows := dynamicRules[0].PolicyRule.FlowList
+	assert.Len(t, flows, 3)
+	assert.Equal(t, uint32(80), flows[0].Match.TcpSrc)
+	assert.Equal(t, uint32(443), flows[1].Match.TcpSrc)
+	assert.Equal(t, []byte("2001:db8::/32"), flows[2].Match.IpDst.Address)
+	assert.Equal(t, protos.IPAddress_IPV6, flows[2].Match.IpDst.Version)
+
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "negated", failures[0].RuleName)
+	assert.Equal(t, gx.IncorrectFlowInformation, failures[0].Code)
+	assert.EqualError(t, failures[0].Err,
+		"Invalid Flow-Description of rule negated: IPFilterRule 'permit in ip from assigned to !10.0.0.0/8': negated addresses are not supported")
+	assert.Equal(t, "malformed", failures[1].RuleName)
+	assert.EqualError(t, failures[1].Err,
+		"Invalid Flow-Description of rule malformed: Invalid IPFilterRule 'permit in ip from 1.2.3 to any': "+
+			"address must be any, assigned or an IP address with an optional prefix length at '1.2.3'")
+
+	assert.Equal(t, []*gx.ChargingRuleReport{
+		{RuleNames: []string{"negated"}, FailureCode: gx.IncorrectFlowInformation},
+		{RuleNames: []string{"malformed"}, FailureCode: gx.IncorrectFlowInformation},
+	}, gx.ToChargingRuleReports(failures))
+}
+
 func TestReAuthAnswer_FromProto(t *testing.T) {
 	in := &protos.PolicyReAuthAnswer{
 		SessionId: "foo",
@@ -224,39 +274,44 @@ func TestRuleDefinition_ToProto(t *testing.T) {
 	monitoringKey := []byte("monitor")
 	var ratingGroup uint32 = 10
 	var ruleOut *protos.PolicyRule = nil
+	var err error
 
-	ruleOut = (&gx.RuleDefinition{
+	ruleOut, err = (&gx.RuleDefinition{
 		RuleName:      "rgonly",
 		MonitoringKey: nil,
 		RatingGroup:   &ratingGroup,
 	}).ToProto()
+	assert.NoError(t, err)
 	assert.Equal(t, []byte(nil), ruleOut.MonitoringKey)
 	assert.Equal(t, uint32(10), ruleOut.RatingGroup)
 	assert.Equal(t, protos.PolicyRule_ONLY_OCS, ruleOut.TrackingType)
 
-	ruleOut = (&gx.RuleDefinition{
+	ruleOut, err = (&gx.RuleDefinition{
 		RuleName:      "mkonly",
 		MonitoringKey: monitoringKey,
 		RatingGroup:   nil,
 	}).ToProto()
+	assert.NoError(t, err)
 	assert.Equal(t, []byte("monitor"), ruleOut.MonitoringKey)
 	assert.Equal(t, uint32(0), ruleOut.RatingGroup)
 	assert.Equal(t, protos.PolicyRule_ONLY_PCRF, ruleOut.TrackingType)
 
-	ruleOut = (&gx.RuleDefinition{
+	ruleOut, err = (&gx.RuleDefinition{
 		RuleName:      "both",
 		MonitoringKey: monitoringKey,
 		RatingGroup:   &ratingGroup,
 	}).ToProto()
+	assert.NoError(t, err)
 	assert.Equal(t, []byte("monitor"), ruleOut.MonitoringKey)
 	assert.Equal(t, uint32(10), ruleOut.RatingGroup)
 	assert.Equal(t, protos.PolicyRule_OCS_AND_PCRF, ruleOut.TrackingType)
 
-	ruleOut = (&gx.RuleDefinition{
+	ruleOut, err = (&gx.RuleDefinition{
 		RuleName:      "neither",
 		MonitoringKey: nil,
 		RatingGroup:   nil,
 	}).ToProto()
+	assert.NoError(t, err)
 	assert.Equal(t, []byte(nil), ruleOut.MonitoringKey)
 	assert.Equal(t, uint32(0), ruleOut.RatingGroup)
 	assert.Equal(t, protos.PolicyRule_NO_TRACKING, ruleOut.TrackingType)
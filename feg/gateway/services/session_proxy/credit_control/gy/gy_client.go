@@ -164,6 +164,11 @@ func (gyClient *GyClient) IgnoreAnswer(request *CreditControlRequest) {
 	)
 }
 
+// SetMessageRecorder sets the recorder of the Gy messages sent & received by the client
+func (gyClient *GyClient) SetMessageRecorder(recorder diameter.MessageRecorder) {
+	gyClient.diamClient.SetMessageRecorder(recorder)
+}
+
 func (gyClient *GyClient) EnableConnections() error {
 	if gyClient.globalConfig.DisableGy {
 		return nil
@@ -194,6 +199,7 @@ func registerReAuthHandler(reAuthHandler ChargingReAuthHandler, diamClient *diam
 			raaMsg := createReAuthAnswerMessage(message, raa)
 			raaMsg = diamClient.AddOriginAVPsToMessage(raaMsg)
 			glog.V(2).Infof("Sending (responding) Gy reauth message:\n%s\n", raaMsg)
+			diamClient.RecordMessage(raaMsg, true)
 			_, err := raaMsg.WriteToWithRetry(conn, diamClient.Retries())
 			if err != nil {
 				glog.Errorf(
@@ -0,0 +1,62 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx
+
+import (
+	"magma/feg/gateway/diameter"
+)
+
+// P-CSCF/AF Environment Variables
+const (
+	PCSCFAddrEnv         = "PCSCF_ADDR"
+	RxNetworkEnv         = "RX_NETWORK"
+	RxDiamHostEnv        = "RX_DIAM_HOST"
+	RxDiamRealmEnv       = "RX_DIAM_REALM"
+	RxDiamProductEnv     = "RX_DIAM_PRODUCT"
+	RxLocalAddrEnv       = "RX_LOCAL_ADDR"
+	PCSCFHostEnv         = "PCSCF_HOST"
+	PCSCFRealmEnv        = "PCSCF_REALM"
+	RxDisableDestHostEnv = "RX_DISABLE_DEST_HOST"
+)
+
+// GetPCSCFConfiguration returns the configuration of the P-CSCF/AF peer or nil if
+// Rx is not configured (PCSCF_ADDR is not set)
+func GetPCSCFConfiguration() *diameter.DiameterServerConfig {
+	addr := diameter.GetValueOrEnv("", PCSCFAddrEnv, "")
+	if len(addr) == 0 {
+		return nil
+	}
+	return &diameter.DiameterServerConfig{
+		DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+			Addr:      addr,
+			Protocol:  diameter.GetValueOrEnv("", RxNetworkEnv, "tcp"),
+			LocalAddr: diameter.GetValueOrEnv("", RxLocalAddrEnv, ""),
+		},
+		DestHost:        diameter.GetValueOrEnv("", PCSCFHostEnv, ""),
+		DestRealm:       diameter.GetValueOrEnv("", PCSCFRealmEnv, ""),
+		DisableDestHost: diameter.GetBoolValueOrEnv("", RxDisableDestHostEnv, false),
+	}
+}
+
+// GetRxClientConfiguration returns the diameter client configuration of the Rx connection
+func GetRxClientConfiguration() *diameter.DiameterClientConfig {
+	return &diameter.DiameterClientConfig{
+		Host:             diameter.GetValueOrEnv("", RxDiamHostEnv, diameter.DiamHost),
+		Realm:            diameter.GetValueOrEnv("", RxDiamRealmEnv, diameter.DiamRealm),
+		ProductName:      diameter.GetValueOrEnv("", RxDiamProductEnv, diameter.DiamProductName),
+		AppID:            RxAppID,
+		WatchdogInterval: diameter.DefaultWatchdogIntervalSeconds,
+		RetryCount:       1,
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx
+
+import "magma/feg/gateway/services/session_proxy/credit_control"
+
+type MediaType uint32
+
+const (
+	MediaTypeAudio       MediaType = 0
+	MediaTypeVideo       MediaType = 1
+	MediaTypeData        MediaType = 2
+	MediaTypeApplication MediaType = 3
+	MediaTypeControl     MediaType = 4
+	MediaTypeText        MediaType = 5
+	MediaTypeMessage     MediaType = 6
+	MediaTypeOther       MediaType = 0xFFFFFFFF
+)
+
+type FlowStatus uint32
+
+const (
+	FlowEnabledUplink   FlowStatus = 0
+	FlowEnabledDownlink FlowStatus = 1
+	FlowEnabled         FlowStatus = 2
+	FlowDisabled        FlowStatus = 3
+	FlowRemoved         FlowStatus = 4
+)
+
+type FlowUsage uint32
+
+const (
+	FlowUsageNoInformation FlowUsage = 0
+	FlowUsageRTCP          FlowUsage = 1
+	FlowUsageAFSignalling  FlowUsage = 2
+)
+
+type SpecificAction uint32
+
+const (
+	ChargingCorrelationExchange               SpecificAction = 1
+	IndicationOfLossOfBearer                  SpecificAction = 2
+	IndicationOfRecoveryOfBearer              SpecificAction = 3
+	IndicationOfReleaseOfBearer               SpecificAction = 4
+	IPCANChange                               SpecificAction = 6
+	IndicationOfOutOfCredit                   SpecificAction = 7
+	IndicationOfSuccessfulResourcesAllocation SpecificAction = 8
+	IndicationOfFailedResourcesAllocation     SpecificAction = 9
+)
+
+type AbortCause uint32
+
+const (
+	BearerReleased              AbortCause = 0
+	InsufficientServerResources AbortCause = 1
+	InsufficientBearerResources AbortCause = 2
+	PsToCsHandover              AbortCause = 3
+)
+
+type RequestType uint32
+
+const (
+	InitialRequest   RequestType = 0
+	UpdateRequest    RequestType = 1
+	PCSCFRestoration RequestType = 2
+)
+
+// Rx experimental result codes (3GPP TS 29.214 section 5.5.3)
+const (
+	InvalidServiceInformation       = 5061
+	FilterRestrictions              = 5062
+	RequestedServiceNotAuthorized   = 5063
+	DuplicatedAFSession             = 5064
+	IPCANSessionNotAvailable        = 5065
+	UnauthorizedNonEmergencySession = 5066
+	TemporaryNetworkFailure         = 5068
+)
+
+type SubscriptionID struct {
+	Type credit_control.SubscriptionIDType `avp:"Subscription-Id-Type"`
+	Data string                            `avp:"Subscription-Id-Data"`
+}
+
+// Media-Sub-Component ::= < AVP Header: 519 >
+//
+//	{ Flow-Number }
+//	0*2[ Flow-Description ]
+//	[ Flow-Status ]
+//	[ Flow-Usage ]
+//	[ Max-Requested-Bandwidth-UL ]
+//	[ Max-Requested-Bandwidth-DL ]
+//	[ AF-Signalling-Protocol ]
+//	[ ToS-Traffic-Class ]
+//	*[ AVP ]
+type MediaSubComponent struct {
+	FlowNumber       uint32      `avp:"Flow-Number"`
+	FlowDescriptions []string    `avp:"Flow-Description"`
+	FlowStatus       *FlowStatus `avp:"Flow-Status"`
+	FlowUsage        FlowUsage   `avp:"Flow-Usage"`
+	MaxReqBwUL       *uint32     `avp:"Max-Requested-Bandwidth-UL"`
+	MaxReqBwDL       *uint32     `avp:"Max-Requested-Bandwidth-DL"`
+}
+
+// Media-Component-Description ::= < AVP Header: 517 >
+//
+//	{ Media-Component-Number }
+//	*[ Media-Sub-Component ]
+//	[ AF-Application-Identifier ]
+//	[ Media-Type ]
+//	[ Max-Requested-Bandwidth-UL ]
+//	[ Max-Requested-Bandwidth-DL ]
+//	[ Min-Requested-Bandwidth-UL ]
+//	[ Min-Requested-Bandwidth-DL ]
+//	[ Flow-Status ]
+//	0*2[ Codec-Data ]
+//	*[ AVP ]
+//
+// Only the AVPs needed to derive dedicated bearer rules are supported
+type MediaComponentDescription struct {
+	MediaComponentNumber uint32               `avp:"Media-Component-Number"`
+	SubComponents        []*MediaSubComponent `avp:"Media-Sub-Component"`
+	AFApplicationID      []byte               `avp:"AF-Application-Identifier"`
+	MediaType            *MediaType           `avp:"Media-Type"`
+	MaxReqBwUL           *uint32              `avp:"Max-Requested-Bandwidth-UL"`
+	MaxReqBwDL           *uint32              `avp:"Max-Requested-Bandwidth-DL"`
+	MinReqBwUL           *uint32              `avp:"Min-Requested-Bandwidth-UL"`
+	MinReqBwDL           *uint32              `avp:"Min-Requested-Bandwidth-DL"`
+	FlowStatus           *FlowStatus          `avp:"Flow-Status"`
+	CodecData            [][]byte             `avp:"Codec-Data"`
+}
+
+// Flows ::= < AVP Header: 510 >
+//
+//	{ Media-Component-Number }
+//	*[ Flow-Number ]
+//	[ Final-Unit-Action ]
+type Flows struct {
+	MediaComponentNumber uint32   `avp:"Media-Component-Number"`
+	FlowNumbers          []uint32 `avp:"Flow-Number"`
+}
+
+// <AA-Request> ::= < Diameter Header: 265, REQ, PXY >
+//
+//	< Session-Id >
+//	[ DRMP ]
+//	{ Auth-Application-Id }
+//	{ Origin-Host }
+//	{ Origin-Realm }
+//	{ Destination-Realm }
+//	[ Destination-Host ]
+//	[ IP-Domain-Id ]
+//	[ Auth-Session-State ]
+//	[ AF-Application-Identifier ]
+//	*[ Media-Component-Description ]
+//	[ Service-Info-Status ]
+//	[ AF-Charging-Identifier ]
+//	[ SIP-Forking-Indication ]
+//	*[ Specific-Action ]
+//	*[ Subscription-Id ]
+//	[ OC-Supported-Features ]
+//	*[ Supported-Features ]
+//	[ Reservation-Priority ]
+//	[ Framed-IP-Address ]
+//	[ Framed-IPv6-Prefix ]
+//	[ Called-Station-Id ]
+//	[ Service-URN ]
+//	[ Sponsored-Connectivity-Data ]
+//	[ MPS-Identifier ]
+//	[ GCS-Identifier ]
+//	[ MCPTT-Identifier ]
+//	[ MCVideo-Identifier ]
+//	[ IMS-Content-Identifier ]
+//	[ IMS-Content-Type ]
+//	[ Rx-Request-Type ]
+//	*[ Required-Access-Info ]
+//	[ AF-Requested-Data ]
+//	[ Reference-Id ]
+//	[ Pre-emption-Control-Info ]
+//	[ Origin-State-Id ]
+//	*[ Proxy-Info ]
+//	*[ Route-Record ]
+//	*[ AVP ]
+type AAR struct {
+	SessionID       string                       `avp:"Session-Id"`
+	OriginHost      string                       `avp:"Origin-Host"`
+	OriginRealm     string                       `avp:"Origin-Realm"`
+	AFApplicationID []byte                       `avp:"AF-Application-Identifier"`
+	MediaComponents []*MediaComponentDescription `avp:"Media-Component-Description"`
+	AFChargingID    []byte                       `avp:"AF-Charging-Identifier"`
+	SpecificActions []SpecificAction             `avp:"Specific-Action"`
+	SubscriptionIDs []*SubscriptionID            `avp:"Subscription-Id"`
+	FramedIPAddress []byte                       `avp:"Framed-IP-Address"`
+	CalledStationID string                       `avp:"Called-Station-Id"`
+	RequestType     RequestType                  `avp:"Rx-Request-Type"`
+}
+
+// <ST-Request> ::= < Diameter Header: 275, REQ, PXY >
+//
+//	< Session-Id >
+//	[ DRMP ]
+//	{ Origin-Host }
+//	{ Origin-Realm }
+//	{ Destination-Realm }
+//	{ Auth-Application-Id }
+//	{ Termination-Cause }
+//	[ Destination-Host ]
+//	[ OC-Supported-Features ]
+//	*[ Required-Access-Info ]
+//	*[ Class ]
+//	[ Origin-State-Id ]
+//	*[ Proxy-Info ]
+//	*[ Route-Record ]
+//	*[ AVP ]
+type STR struct {
+	SessionID        string `avp:"Session-Id"`
+	OriginHost       string `avp:"Origin-Host"`
+	OriginRealm      string `avp:"Origin-Realm"`
+	TerminationCause uint32 `avp:"Termination-Cause"`
+}
+
+// ReAuthAnswer is the AF answer to a Rx RAR (3GPP TS 29.214 section 5.6.4)
+type ReAuthAnswer struct {
+	SessionID          string `avp:"Session-Id"`
+	ResultCode         uint32 `avp:"Result-Code"`
+	ExperimentalResult struct {
+		VendorId               uint32 `avp:"Vendor-Id"`
+		ExperimentalResultCode uint32 `avp:"Experimental-Result-Code"`
+	} `avp:"Experimental-Result"`
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx
+
+import (
+	"sort"
+
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/session_proxy/metrics"
+	"magma/feg/gateway/services/session_proxy/relay"
+	"magma/gateway/service_registry"
+	"magma/lte/cloud/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+// PolicyInstaller installs & removes the AF session rules on the subscriber's IP-CAN session.
+// This can be used to stub out the gateway
+type PolicyInstaller func(request *protos.PolicyReAuthRequest) (*protos.PolicyReAuthAnswer, error)
+
+// GetPolicyInstaller returns a PolicyInstaller relaying the rules to the gateway serving the subscriber
+func GetPolicyInstaller(cloudRegistry service_registry.GatewayRegistry) PolicyInstaller {
+	return func(request *protos.PolicyReAuthRequest) (*protos.PolicyReAuthAnswer, error) {
+		client, err := relay.GetSessionProxyResponderClient(cloudRegistry)
+		if err != nil {
+			return nil, err
+		}
+		defer client.Close()
+		return client.PolicyReAuth(context.Background(), request)
+	}
+}
+
+// handleAAR authorizes the AF session media components (3GPP TS 29.214 section 4.4.1 & 4.4.2)
+// and answers with an AAA
+func (c *RxClient) handleAAR(conn diam.Conn, m *diam.Message) {
+	var aar AAR
+	if err := m.Unmarshal(&aar); err != nil {
+		metrics.RxUnparseableMsg.Inc()
+		glog.Errorf("Received unparseable AAR over Rx %s\n%s", m, err)
+		return
+	}
+	glog.V(2).Infof("Received Rx AAR message:\n%s\n", m)
+	go func() {
+		resultCode, failedFlows := c.authorize(&aar)
+		c.sendAnswer(conn, m, aar.SessionID, resultCode)
+		if len(failedFlows) > 0 {
+			done := make(chan interface{}, 1)
+			err := c.SendReAuthRequest(aar.SessionID, IndicationOfFailedResourcesAllocation, failedFlows, done)
+			if err != nil {
+				glog.V(2).Infof("Failed resources allocation not reported to the AF: %v", err)
+				return
+			}
+			c.waitForAnswer(done, getRequestKey(aar.SessionID, diam.ReAuth))
+		}
+	}()
+}
+
+// authorize installs the rules of the AF session & returns the AAA result code with the flows which
+// failed to be installed. Requests of the same AF session are serialized, so that an update is
+// computed from the rules installed by the previous one.
+func (c *RxClient) authorize(aar *AAR) (uint32, []*Flows) {
+	unlock := c.lockSession(aar.SessionID)
+	defer unlock()
+
+	c.sessionsMu.Lock()
+	session, exists := c.sessions[aar.SessionID]
+	c.sessionsMu.Unlock()
+	if !exists {
+		imsi, err := aar.GetIMSI()
+		if err != nil {
+			glog.Error(err)
+			return IPCANSessionNotAvailable, nil
+		}
+		session = &afSession{imsi: imsi}
+	}
+	components := MergeMediaComponents(session.components, aar.MediaComponents)
+	rules := getPolicyRules(aar.SessionID, components)
+
+	dynamicRules := make([]*protos.DynamicRuleInstall, 0, len(rules))
+	newRuleIDs := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		dynamicRules = append(dynamicRules, &protos.DynamicRuleInstall{PolicyRule: rule})
+		newRuleIDs[rule.Id] = struct{}{}
+	}
+	var rulesToRemove []string
+	for _, ruleID := range session.ruleIDs {
+		if _, ok := newRuleIDs[ruleID]; !ok {
+			rulesToRemove = append(rulesToRemove, ruleID)
+		}
+	}
+	ans, err := c.installer(&protos.PolicyReAuthRequest{
+		Imsi:                  session.imsi,
+		RulesToRemove:         rulesToRemove,
+		DynamicRulesToInstall: dynamicRules,
+	})
+	if err != nil {
+		glog.Errorf("Error installing rules of AF session %s: %v", aar.SessionID, err)
+		return diam.UnableToDeliver, nil
+	}
+	if ans.GetResult() == protos.ReAuthResult_SESSION_NOT_FOUND {
+		glog.Errorf("No IP-CAN session found for AF session %s of %s", aar.SessionID, session.imsi)
+		return IPCANSessionNotAvailable, nil
+	}
+	installed := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if _, failed := ans.GetFailedRules()[rule.Id]; !failed {
+			installed = append(installed, rule.Id)
+		}
+	}
+	if len(rules) > 0 && len(installed) == 0 {
+		glog.Errorf("All rules of AF session %s failed to be installed: %v", aar.SessionID, ans.GetFailedRules())
+		return RequestedServiceNotAuthorized, nil
+	}
+
+	c.sessionsMu.Lock()
+	if len(aar.SpecificActions) > 0 {
+		session.specificActions = aar.SpecificActions
+	}
+	session.components = components
+	session.ruleIDs = installed
+	c.sessions[aar.SessionID] = session
+	c.sessionsMu.Unlock()
+	return diam.Success, getFailedFlows(aar.SessionID, components, ans.GetFailedRules())
+}
+
+// handleSTR removes the AF session & its rules (3GPP TS 29.214 section 4.4.4)
+func (c *RxClient) handleSTR(conn diam.Conn, m *diam.Message) {
+	var str STR
+	if err := m.Unmarshal(&str); err != nil {
+		metrics.RxUnparseableMsg.Inc()
+		glog.Errorf("Received unparseable STR over Rx %s\n%s", m, err)
+		return
+	}
+	glog.V(2).Infof("Received Rx STR message:\n%s\n", m)
+	go func() {
+		unlock := c.lockSession(str.SessionID)
+		defer unlock()
+
+		c.sessionsMu.Lock()
+		session, ok := c.sessions[str.SessionID]
+		delete(c.sessions, str.SessionID)
+		c.sessionsMu.Unlock()
+		if !ok {
+			c.sendAnswer(conn, m, str.SessionID, diam.UnknownSessionID)
+			return
+		}
+		if len(session.ruleIDs) > 0 {
+			_, err := c.installer(&protos.PolicyReAuthRequest{Imsi: session.imsi, RulesToRemove: session.ruleIDs})
+			if err != nil {
+				// the AF session is terminated regardless, rules are removed with the IP-CAN session
+				glog.Errorf("Error removing rules of AF session %s: %v", str.SessionID, err)
+			}
+		}
+		c.sendAnswer(conn, m, str.SessionID, diam.Success)
+	}()
+}
+
+// sendAnswer sends the answer to the AF request. 3GPP result codes are sent as Experimental-Result
+func (c *RxClient) sendAnswer(conn diam.Conn, m *diam.Message, sessionID string, code uint32) {
+	var ans *diam.Message
+	if code >= InvalidServiceInformation && code <= TemporaryNetworkFailure {
+		ans = m.Answer(0)
+		ans.NewAVP(avp.ExperimentalResult, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(diameter.Vendor3GPP)),
+				diam.NewAVP(avp.ExperimentalResultCode, avp.Mbit, 0, datatype.Unsigned32(code)),
+			},
+		})
+	} else {
+		ans = m.Answer(code)
+	}
+	// SessionID must be the first AVP
+	ans.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID)))
+	ans.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(RxAppID))
+	ans = c.diamClient.AddOriginAVPsToMessage(ans)
+	_, err := ans.WriteToWithRetry(conn, c.diamClient.Retries())
+	if err != nil {
+		glog.Errorf(
+			"Rx Answer Write Failed for %s->%s, SessionID: %s - %v",
+			conn.LocalAddr(), conn.RemoteAddr(), sessionID, err)
+		conn.Close() // close connection on error
+	}
+}
+
+// raaHandler parses a RAA received over Rx and returns the `KeyAndAnswer` packed inside
+func raaHandler(message *diam.Message) diameter.KeyAndAnswer {
+	var raa ReAuthAnswer
+	glog.V(2).Infof("Received Rx RAA message:\n%s\n", message)
+	if err := message.Unmarshal(&raa); err != nil {
+		metrics.RxUnparseableMsg.Inc()
+		glog.Errorf("Received unparseable RAA over Rx: %s", err)
+		return diameter.KeyAndAnswer{}
+	}
+	return diameter.KeyAndAnswer{Key: getRequestKey(raa.SessionID, diam.ReAuth), Answer: &raa}
+}
+
+// asaHandler parses an ASA received over Rx and returns the `KeyAndAnswer` packed inside
+func asaHandler(message *diam.Message) diameter.KeyAndAnswer {
+	var asa diameter.ASA
+	glog.V(2).Infof("Received Rx ASA message:\n%s\n", message)
+	if err := message.Unmarshal(&asa); err != nil {
+		metrics.RxUnparseableMsg.Inc()
+		glog.Errorf("Received unparseable ASA over Rx: %s", err)
+		return diameter.KeyAndAnswer{}
+	}
+	return diameter.KeyAndAnswer{Key: getRequestKey(asa.SessionID, diam.AbortSession), Answer: &asa}
+}
+
+func getPolicyRules(afSessionID string, components map[uint32]*MediaComponentDescription) []*protos.PolicyRule {
+	numbers := make([]int, 0, len(components))
+	for num := range components {
+		numbers = append(numbers, int(num))
+	}
+	sort.Ints(numbers)
+	var rules []*protos.PolicyRule
+	for _, num := range numbers {
+		rules = append(rules, components[uint32(num)].ToPolicyRules(afSessionID)...)
+	}
+	return rules
+}
+
+func getFailedFlows(
+	afSessionID string,
+	components map[uint32]*MediaComponentDescription,
+	failedRules map[string]protos.PolicyReAuthAnswer_FailureCode,
+) []*Flows {
+	if len(failedRules) == 0 {
+		return nil
+	}
+	var flows []*Flows
+	for num, component := range components {
+		var flowNumbers []uint32
+		for _, sub := range component.SubComponents {
+			if _, failed := failedRules[GetRuleID(afSessionID, num, sub.FlowNumber)]; failed {
+				flowNumbers = append(flowNumbers, sub.FlowNumber)
+			}
+		}
+		if len(flowNumbers) > 0 {
+			flows = append(flows, &Flows{MediaComponentNumber: num, FlowNumbers: flowNumbers})
+		}
+	}
+	return flows
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"magma/feg/gateway/services/session_proxy/credit_control"
+	"magma/lte/cloud/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize_SerializedPerSession(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		requests   []*protos.PolicyReAuthRequest
+		inProgress int32
+		overlapped int32
+	)
+	installer := func(req *protos.PolicyReAuthRequest) (*protos.PolicyReAuthAnswer, error) {
+		if atomic.AddInt32(&inProgress, 1) > 1 {
+			atomic.StoreInt32(&overlapped, 1)
+		}
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+		atomic.AddInt32(&inProgress, -1)
+		return &protos.PolicyReAuthAnswer{Result: protos.ReAuthResult_UPDATE_INITIATED}, nil
+	}
+	client := &RxClient{
+		installer:    installer,
+		sessions:     map[string]*afSession{},
+		sessionLocks: map[string]*sessionLock{},
+	}
+
+	// concurrent AARs of the same AF session, each adding a media component
+	var wg sync.WaitGroup
+	for num := uint32(1); num <= 3; num++ {
+		wg.Add(1)
+		go func(num uint32) {
+			defer wg.Done()
+			resultCode, _ := client.authorize(&AAR{
+				SessionID:       "af-session-1",
+				SubscriptionIDs: []*SubscriptionID{{Type: credit_control.EndUserIMSI, Data: "001010000000001"}},
+				MediaComponents: []*MediaComponentDescription{{
+					MediaComponentNumber: num,
+					SubComponents: []*MediaSubComponent{{
+						FlowNumber:       1,
+						FlowDescriptions: []string{"permit out 17 from 10.0.0.1 5000 to 192.168.128.12 6000"},
+					}},
+				}},
+			})
+			assert.Equal(t, uint32(diam.Success), resultCode)
+		}(num)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&overlapped))
+	assert.Len(t, requests, 3)
+	// the last update holds the rules of all components
+	assert.Len(t, requests[2].DynamicRulesToInstall, 3)
+	assert.Len(t, client.sessions["af-session-1"].ruleIDs, 3)
+	assert.Empty(t, client.sessionLocks)
+}
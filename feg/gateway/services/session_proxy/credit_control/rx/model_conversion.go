@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx
+
+import (
+	"fmt"
+
+	"magma/feg/gateway/policydb"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+	"magma/lte/cloud/go/protos"
+
+	"github.com/go-openapi/swag"
+	"github.com/golang/glog"
+)
+
+const (
+	// AFRulePriority is the priority of the dynamic rules installed for AF sessions.
+	// It needs to be higher than the priority of the default bearer rules so the media
+	// flows are matched by the dedicated bearer rules first
+	AFRulePriority = 100
+	afRulePrefix   = "rx"
+)
+
+// GetRuleID returns the ID of the dynamic rule installed for the given media sub component
+func GetRuleID(afSessionID string, mediaComponentNumber, flowNumber uint32) string {
+	return fmt.Sprintf("%s-%s-%d-%d", afRulePrefix, afSessionID, mediaComponentNumber, flowNumber)
+}
+
+// GetIMSI returns the subscriber's IMSI (with prefix) from the AAR Subscription-Ids
+func (aar *AAR) GetIMSI() (string, error) {
+	for _, subID := range aar.SubscriptionIDs {
+		if subID != nil && subID.Type == credit_control.EndUserIMSI && len(subID.Data) > 0 {
+			return credit_control.AddIMSIPrefix(subID.Data), nil
+		}
+	}
+	return "", fmt.Errorf("No IMSI Subscription-Id in AAR for AF session %s", aar.SessionID)
+}
+
+// HasSpecificAction returns true if the AF subscribed to the given specific action
+func HasSpecificAction(actions []SpecificAction, action SpecificAction) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeMediaComponents returns the media components of an AF session updated with the components
+// received in an AAR. Sub components replace the existing ones only if the update includes them.
+// The current components are not modified
+func MergeMediaComponents(
+	current map[uint32]*MediaComponentDescription,
+	updates []*MediaComponentDescription,
+) map[uint32]*MediaComponentDescription {
+	merged := make(map[uint32]*MediaComponentDescription, len(current)+len(updates))
+	for num, component := range current {
+		merged[num] = component
+	}
+	for _, update := range updates {
+		if update == nil {
+			continue
+		}
+		existing, ok := merged[update.MediaComponentNumber]
+		if !ok {
+			merged[update.MediaComponentNumber] = update
+			continue
+		}
+		component := *existing
+		if len(update.SubComponents) > 0 {
+			component.SubComponents = update.SubComponents
+		}
+		if update.MediaType != nil {
+			component.MediaType = update.MediaType
+		}
+		if update.MaxReqBwUL != nil {
+			component.MaxReqBwUL = update.MaxReqBwUL
+		}
+		if update.MaxReqBwDL != nil {
+			component.MaxReqBwDL = update.MaxReqBwDL
+		}
+		if update.MinReqBwUL != nil {
+			component.MinReqBwUL = update.MinReqBwUL
+		}
+		if update.MinReqBwDL != nil {
+			component.MinReqBwDL = update.MinReqBwDL
+		}
+		if update.FlowStatus != nil {
+			component.FlowStatus = update.FlowStatus
+		}
+		merged[update.MediaComponentNumber] = &component
+	}
+	return merged
+}
+
+// ToPolicyRules converts the media component into one dynamic rule per media sub component.
+// Sub components with REMOVED flow status are not converted
+func (mcd *MediaComponentDescription) ToPolicyRules(afSessionID string) []*protos.PolicyRule {
+	rules := make([]*protos.PolicyRule, 0, len(mcd.SubComponents))
+	for _, sub := range mcd.SubComponents {
+		if sub == nil {
+			continue
+		}
+		status := mcd.getFlowStatus(sub)
+		if status == FlowRemoved {
+			continue
+		}
+		rules = append(rules, &protos.PolicyRule{
+			Id:           GetRuleID(afSessionID, mcd.MediaComponentNumber, sub.FlowNumber),
+			Priority:     AFRulePriority,
+			FlowList:     sub.getFlowList(status),
+			Qos:          mcd.getQos(sub),
+			TrackingType: protos.PolicyRule_NO_TRACKING,
+		})
+	}
+	return rules
+}
+
+// getFlowStatus returns the sub component flow status or the component's one if not set
+func (mcd *MediaComponentDescription) getFlowStatus(sub *MediaSubComponent) FlowStatus {
+	if sub.FlowStatus != nil {
+		return *sub.FlowStatus
+	}
+	if mcd.FlowStatus != nil {
+		return *mcd.FlowStatus
+	}
+	return FlowEnabled
+}
+
+// getFlowList converts the flow descriptions of the sub component to the policydb model.
+// Flows of a disabled direction are kept, but their gate is closed (DENY)
+func (sub *MediaSubComponent) getFlowList(status FlowStatus) []*protos.FlowDescription {
+	var flowList []*protos.FlowDescription
+	for _, flowString := range sub.FlowDescriptions {
+		flows, err := policydb.GetFlowDescriptionsFromFlowString(flowString)
+		if err != nil {
+			glog.Errorf("Could not get flow for description %s : %s", flowString, err)
+			continue
+		}
+		for _, flow := range flows {
+			if !isDirectionEnabled(status, flow.GetMatch().GetDirection()) {
+				flow.Action = protos.FlowDescription_DENY
+			}
+			flowList = append(flowList, flow)
+		}
+	}
+	return flowList
+}
+
+func isDirectionEnabled(status FlowStatus, direction protos.FlowMatch_Direction) bool {
+	switch status {
+	case FlowEnabled:
+		return true
+	case FlowEnabledUplink:
+		return direction == protos.FlowMatch_UPLINK
+	case FlowEnabledDownlink:
+		return direction == protos.FlowMatch_DOWNLINK
+	default:
+		return false
+	}
+}
+
+// getQos derives the QoS of the sub component flows (3GPP TS 29.213 section 6.3).
+// Sub component bandwidths take precedence over the component ones
+func (mcd *MediaComponentDescription) getQos(sub *MediaSubComponent) *protos.FlowQos {
+	qci := mcd.getQCI(sub)
+	qos := &protos.FlowQos{
+		Qci:        qci,
+		MaxReqBwUl: swag.Uint32Value(firstNonNil(sub.MaxReqBwUL, mcd.MaxReqBwUL)),
+		MaxReqBwDl: swag.Uint32Value(firstNonNil(sub.MaxReqBwDL, mcd.MaxReqBwDL)),
+	}
+	if isGBR(qci) {
+		qos.GbrUl = swag.Uint32Value(firstNonNil(mcd.MinReqBwUL, sub.MaxReqBwUL, mcd.MaxReqBwUL))
+		qos.GbrDl = swag.Uint32Value(firstNonNil(mcd.MinReqBwDL, sub.MaxReqBwDL, mcd.MaxReqBwDL))
+	}
+	return qos
+}
+
+func (mcd *MediaComponentDescription) getQCI(sub *MediaSubComponent) protos.FlowQos_Qci {
+	if sub.FlowUsage == FlowUsageAFSignalling {
+		return protos.FlowQos_QCI_5
+	}
+	if mcd.MediaType == nil {
+		return protos.FlowQos_QCI_9
+	}
+	switch *mcd.MediaType {
+	case MediaTypeAudio:
+		return protos.FlowQos_QCI_1
+	case MediaTypeVideo:
+		return protos.FlowQos_QCI_2
+	case MediaTypeControl:
+		return protos.FlowQos_QCI_5
+	default:
+		return protos.FlowQos_QCI_9
+	}
+}
+
+func isGBR(qci protos.FlowQos_Qci) bool {
+	switch qci {
+	case protos.FlowQos_QCI_1, protos.FlowQos_QCI_2, protos.FlowQos_QCI_3, protos.FlowQos_QCI_4,
+		protos.FlowQos_QCI_65, protos.FlowQos_QCI_66, protos.FlowQos_QCI_67, protos.FlowQos_QCI_75:
+		return true
+	default:
+		return false
+	}
+}
+
+func firstNonNil(values ...*uint32) *uint32 {
+	for _, v := range values {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
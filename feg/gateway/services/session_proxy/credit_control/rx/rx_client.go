@@ -0,0 +1,222 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+)
+
+const afRequestTimeout = 5 * time.Second
+
+// AFSessionNotifier is an interface to notify the AFs about IP-CAN session events.
+// This can be used to stub out the Rx interface
+type AFSessionNotifier interface {
+	// AbortSessions sends an ASR to the AF for every AF session of the subscriber
+	AbortSessions(imsi string, cause AbortCause)
+}
+
+type afSession struct {
+	imsi            string
+	components      map[uint32]*MediaComponentDescription
+	ruleIDs         []string
+	specificActions []SpecificAction
+}
+
+// RxClient terminates the Rx interface toward the P-CSCF/AF on top of a diameter.Client
+// connection. AF session requests (AAR/STR) are converted into dynamic rules installed
+// on the subscriber's IP-CAN session & AF notifications (RAR/ASR) are sent by the client
+type RxClient struct {
+	diamClient *diameter.Client
+	serverCfg  *diameter.DiameterServerConfig
+	installer  PolicyInstaller
+	sessionsMu sync.Mutex
+	sessions   map[string]*afSession // AF sessions by diameter session ID
+	// sessionLocks serialize the AAR & STR handling of each AF session, guarded by sessionsMu
+	sessionLocks map[string]*sessionLock
+}
+
+// sessionLock a lock of an AF session, shared by the requests of the session being handled
+type sessionLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewConnectedRxClient contructs a new RxClient with the magma diameter settings
+func NewConnectedRxClient(
+	diamClient *diameter.Client,
+	serverCfg *diameter.DiameterServerConfig,
+	installer PolicyInstaller,
+) *RxClient {
+	client := &RxClient{
+		diamClient:   diamClient,
+		serverCfg:    serverCfg,
+		installer:    installer,
+		sessions:     map[string]*afSession{},
+		sessionLocks: map[string]*sessionLock{},
+	}
+	diamClient.RegisterRequestHandlerForAppID(AuthorizeAuthenticate, RxAppID, client.handleAAR)
+	diamClient.RegisterRequestHandlerForAppID(diam.SessionTermination, RxAppID, client.handleSTR)
+	diamClient.RegisterAnswerHandlerForAppID(diam.ReAuth, RxAppID, raaHandler)
+	diamClient.RegisterAnswerHandlerForAppID(diam.AbortSession, RxAppID, asaHandler)
+	return client
+}
+
+// NewRxClient contructs a new RxClient & begins the connection to the P-CSCF/AF
+func NewRxClient(
+	clientCfg *diameter.DiameterClientConfig,
+	serverCfg *diameter.DiameterServerConfig,
+	installer PolicyInstaller,
+) *RxClient {
+	diamClient := diameter.NewClient(clientCfg)
+	diamClient.BeginConnection(serverCfg)
+	return NewConnectedRxClient(diamClient, serverCfg, installer)
+}
+
+// AbortSessions sends an Abort Session Request for all AF sessions of the subscriber
+// (3GPP TS 29.214 section 4.4.6.2) and waits for the answers. AF sessions are removed
+// once the AF answers with a Session Termination Request
+func (c *RxClient) AbortSessions(imsi string, cause AbortCause) {
+	imsi = credit_control.AddIMSIPrefix(credit_control.RemoveIMSIPrefix(imsi))
+	for _, sid := range c.getSessionIDs(imsi) {
+		done := make(chan interface{}, 1)
+		err := c.SendAbortSessionRequest(sid, cause, done)
+		if err != nil {
+			glog.Errorf("Failed to send Rx ASR for AF session %s: %v", sid, err)
+			continue
+		}
+		c.waitForAnswer(done, getRequestKey(sid, diam.AbortSession))
+	}
+}
+
+// SendAbortSessionRequest sends an Abort Session Request to the AF
+func (c *RxClient) SendAbortSessionRequest(afSessionID string, cause AbortCause, done chan interface{}) error {
+	m := c.newAFRequest(diam.AbortSession, afSessionID)
+	m.NewAVP(AbortCauseAVP, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(cause))
+	glog.V(2).Infof("Sending Rx ASR message\n%s\n", m)
+	return c.diamClient.SendRequest(c.serverCfg, done, m, getRequestKey(afSessionID, diam.AbortSession))
+}
+
+// SendReAuthRequest notifies the AF about the given specific action for the flows of the
+// AF session if the AF subscribed to it (3GPP TS 29.214 section 4.4.6)
+func (c *RxClient) SendReAuthRequest(
+	afSessionID string,
+	action SpecificAction,
+	flows []*Flows,
+	done chan interface{},
+) error {
+	c.sessionsMu.Lock()
+	session, ok := c.sessions[afSessionID]
+	subscribed := ok && HasSpecificAction(session.specificActions, action)
+	c.sessionsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("Unknown AF session: %s", afSessionID)
+	}
+	if !subscribed {
+		return fmt.Errorf("AF session %s is not subscribed to specific action %d", afSessionID, action)
+	}
+	m := c.newAFRequest(diam.ReAuth, afSessionID)
+	m.NewAVP(SpecificActionAVP, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(action))
+	for _, flow := range flows {
+		m.AddAVP(flow.toAVP())
+	}
+	glog.V(2).Infof("Sending Rx RAR message\n%s\n", m)
+	return c.diamClient.SendRequest(c.serverCfg, done, m, getRequestKey(afSessionID, diam.ReAuth))
+}
+
+// IgnoreAnswer removes the tracked AF request for the session & command
+func (c *RxClient) IgnoreAnswer(afSessionID string, cmd uint32) {
+	c.diamClient.IgnoreAnswer(getRequestKey(afSessionID, cmd))
+}
+
+func (c *RxClient) newAFRequest(cmd uint32, afSessionID string) *diam.Message {
+	m := diameter.NewProxiableRequest(cmd, RxAppID, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(afSessionID))
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(RxAppID))
+	if cmd == diam.ReAuth {
+		// AUTHORIZE_ONLY(0)
+		m.NewAVP(avp.ReAuthRequestType, avp.Mbit, 0, datatype.Enumerated(0))
+	}
+	return m
+}
+
+func (c *RxClient) waitForAnswer(done chan interface{}, key credit_control.RequestKey) {
+	select {
+	case <-done:
+	case <-time.After(afRequestTimeout):
+		glog.Errorf("Timed out waiting for Rx answer for AF session %s", key.SessionID)
+		c.diamClient.IgnoreAnswer(key)
+	}
+}
+
+// lockSession serializes the read-modify-write of the AF session by the requests
+// of the session & returns the function releasing the lock
+func (c *RxClient) lockSession(afSessionID string) func() {
+	c.sessionsMu.Lock()
+	lock, ok := c.sessionLocks[afSessionID]
+	if !ok {
+		lock = &sessionLock{}
+		c.sessionLocks[afSessionID] = lock
+	}
+	lock.refs++
+	c.sessionsMu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		c.sessionsMu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(c.sessionLocks, afSessionID)
+		}
+		c.sessionsMu.Unlock()
+	}
+}
+
+func (c *RxClient) getSessionIDs(imsi string) []string {
+	c.sessionsMu.Lock()
+	defer c.sessionsMu.Unlock()
+	var res []string
+	for sid, session := range c.sessions {
+		if session.imsi == imsi {
+			res = append(res, sid)
+		}
+	}
+	return res
+}
+
+func getRequestKey(afSessionID string, cmd uint32) credit_control.RequestKey {
+	// Commands are used as request numbers since AF sessions have one outstanding request per command
+	return credit_control.GetRequestKey(credit_control.Rx, afSessionID, cmd)
+}
+
+func (f *Flows) toAVP() *diam.AVP {
+	avps := []*diam.AVP{
+		diam.NewAVP(MediaComponentNumberAVP, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(f.MediaComponentNumber)),
+	}
+	for _, flowNumber := range f.FlowNumbers {
+		avps = append(avps,
+			diam.NewAVP(FlowNumberAVP, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(flowNumber)))
+	}
+	return diam.NewAVP(FlowsAVP, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{AVP: avps})
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx_test
+
+import (
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/session_proxy/credit_control/rx"
+	"magma/feg/gateway/services/testcore/af/mock_af"
+	"magma/lte/cloud/go/protos"
+	orcprotos "magma/orc8r/lib/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+const (
+	testIMSI      = "IMSI001010000000001"
+	testAudioFlow = "permit out 17 from 10.0.0.1 5000 to 192.168.128.12 6000"
+	testRTCPFlow  = "permit out 17 from 10.0.0.1 5001 to 192.168.128.12 6001"
+)
+
+// stubInstaller records the policy requests & answers with the configured answer
+type stubInstaller struct {
+	sync.Mutex
+	requests    []*protos.PolicyReAuthRequest
+	failedRules map[string]protos.PolicyReAuthAnswer_FailureCode
+	result      protos.ReAuthResult
+}
+
+func (s *stubInstaller) install(req *protos.PolicyReAuthRequest) (*protos.PolicyReAuthAnswer, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.requests = append(s.requests, req)
+	return &protos.PolicyReAuthAnswer{Result: s.result, FailedRules: s.failedRules}, nil
+}
+
+func (s *stubInstaller) getRequests() []*protos.PolicyReAuthRequest {
+	s.Lock()
+	defer s.Unlock()
+	return s.requests
+}
+
+func TestRxClient_AuthorizeAndTerminate(t *testing.T) {
+	installer := &stubInstaller{}
+	af := startAFAndClient(t, installer)
+
+	ans := authorizeSession(t, af, &fegprotos.AFSessionRequest{
+		SessionId:       "af-session-1",
+		Imsi:            testIMSI,
+		MediaComponents: []*fegprotos.AFMediaComponent{getAudioComponent()},
+	})
+	assert.Equal(t, uint32(diam.Success), ans.ResultCode)
+
+	requests := installer.getRequests()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, testIMSI, requests[0].Imsi)
+	assert.Empty(t, requests[0].RulesToRemove)
+	assert.Len(t, requests[0].DynamicRulesToInstall, 2)
+	audioRule := requests[0].DynamicRulesToInstall[0].PolicyRule
+	assert.Equal(t, rx.GetRuleID("af-session-1", 1, 1), audioRule.Id)
+	assert.Equal(t, uint32(rx.AFRulePriority), audioRule.Priority)
+	assert.Equal(t, protos.FlowQos_QCI_1, audioRule.Qos.Qci)
+	assert.Equal(t, uint32(64000), audioRule.Qos.MaxReqBwUl)
+	assert.Equal(t, uint32(32000), audioRule.Qos.GbrDl)
+	assert.Len(t, audioRule.FlowList, 1)
+	assert.Equal(t, protos.FlowMatch_DOWNLINK, audioRule.FlowList[0].Match.Direction)
+	rtcpRule := requests[0].DynamicRulesToInstall[1].PolicyRule
+	assert.Equal(t, rx.GetRuleID("af-session-1", 1, 2), rtcpRule.Id)
+
+	// update the session removing the RTCP flow
+	ans = authorizeSession(t, af, &fegprotos.AFSessionRequest{
+		SessionId: "af-session-1",
+		MediaComponents: []*fegprotos.AFMediaComponent{{
+			MediaComponentNumber: 1,
+			SubComponents: []*fegprotos.AFMediaSubComponent{
+				{FlowNumber: 1, FlowDescriptions: []string{testAudioFlow}},
+			},
+		}},
+	})
+	assert.Equal(t, uint32(diam.Success), ans.ResultCode)
+	requests = installer.getRequests()
+	assert.Len(t, requests, 2)
+	assert.Equal(t, []string{rx.GetRuleID("af-session-1", 1, 2)}, requests[1].RulesToRemove)
+	assert.Len(t, requests[1].DynamicRulesToInstall, 1)
+
+	ans, err := af.TerminateSession(context.Background(), &fegprotos.AFSessionTarget{SessionId: "af-session-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(diam.Success), ans.ResultCode)
+	requests = installer.getRequests()
+	assert.Len(t, requests, 3)
+	assert.Equal(t, []string{rx.GetRuleID("af-session-1", 1, 1)}, requests[2].RulesToRemove)
+
+	// the session is gone
+	ans, err = af.TerminateSession(context.Background(), &fegprotos.AFSessionTarget{SessionId: "af-session-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(diam.UnknownSessionID), ans.ResultCode)
+}
+
+func TestRxClient_IPCANSessionNotAvailable(t *testing.T) {
+	installer := &stubInstaller{result: protos.ReAuthResult_SESSION_NOT_FOUND}
+	af := startAFAndClient(t, installer)
+
+	ans := authorizeSession(t, af, &fegprotos.AFSessionRequest{
+		SessionId:       "af-session-2",
+		Imsi:            testIMSI,
+		MediaComponents: []*fegprotos.AFMediaComponent{getAudioComponent()},
+	})
+	assert.Equal(t, uint32(0), ans.ResultCode)
+	assert.Equal(t, uint32(rx.IPCANSessionNotAvailable), ans.ExperimentalResultCode)
+}
+
+func TestRxClient_FailedResourcesAllocation(t *testing.T) {
+	installer := &stubInstaller{
+		failedRules: map[string]protos.PolicyReAuthAnswer_FailureCode{
+			rx.GetRuleID("af-session-3", 1, 2): protos.PolicyReAuthAnswer_RESOURCE_ALLOCATION_FAILURE,
+		},
+	}
+	af := startAFAndClient(t, installer)
+
+	ans := authorizeSession(t, af, &fegprotos.AFSessionRequest{
+		SessionId:       "af-session-3",
+		Imsi:            testIMSI,
+		MediaComponents: []*fegprotos.AFMediaComponent{getAudioComponent()},
+		SpecificActions: []uint32{uint32(rx.IndicationOfFailedResourcesAllocation)},
+	})
+	assert.Equal(t, uint32(diam.Success), ans.ResultCode)
+
+	events := waitForEvents(t, af, 1)
+	assert.Equal(t, fegprotos.AFSessionEvent_REAUTH, events[0].Type)
+	assert.Equal(t, "af-session-3", events[0].SessionId)
+	assert.Equal(t, uint32(rx.IndicationOfFailedResourcesAllocation), events[0].SpecificAction)
+	assert.Equal(t, []uint32{1}, events[0].MediaComponentNumbers)
+}
+
+func TestRxClient_AbortSessions(t *testing.T) {
+	installer := &stubInstaller{}
+	af, client := startAFAndRxClient(t, installer)
+
+	ans := authorizeSession(t, af, &fegprotos.AFSessionRequest{
+		SessionId:       "af-session-4",
+		Imsi:            testIMSI,
+		MediaComponents: []*fegprotos.AFMediaComponent{getAudioComponent()},
+	})
+	assert.Equal(t, uint32(diam.Success), ans.ResultCode)
+
+	client.AbortSessions("001010000000001", rx.BearerReleased)
+	events := waitForEvents(t, af, 1)
+	assert.Equal(t, fegprotos.AFSessionEvent_ABORT, events[0].Type)
+	assert.Equal(t, "af-session-4", events[0].SessionId)
+	assert.Equal(t, uint32(rx.BearerReleased), events[0].AbortCause)
+
+	// the AF terminates the aborted session
+	assert.Eventually(t, func() bool { return len(installer.getRequests()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t,
+		[]string{rx.GetRuleID("af-session-4", 1, 1), rx.GetRuleID("af-session-4", 1, 2)},
+		installer.getRequests()[1].RulesToRemove)
+}
+
+func startAFAndClient(t *testing.T, installer *stubInstaller) *mock_af.AFServer {
+	af, _ := startAFAndRxClient(t, installer)
+	return af
+}
+
+func startAFAndRxClient(t *testing.T, installer *stubInstaller) (*mock_af.AFServer, *rx.RxClient) {
+	serverConfig := &diameter.DiameterServerConfig{
+		DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+			Addr:     "127.0.0.1:0",
+			Protocol: "tcp"},
+	}
+	afConfig := &diameter.DiameterClientConfig{Host: "pcscf.magma.com", Realm: "magma.com", ProductName: "pcscf"}
+	af := mock_af.NewAFServer(afConfig, serverConfig)
+	lis, err := af.StartListener()
+	if err != nil {
+		t.Fatalf("Could not start listener for AF, %s", err.Error())
+	}
+	// Overwrite config addr with the allocated port
+	serverConfig.Addr = lis.Addr().String()
+	go func() {
+		log.Printf("Starting AF server at %s", serverConfig.Addr)
+		log.Print(af.Start(lis))
+	}()
+
+	clientConfig := &diameter.DiameterClientConfig{
+		Host:        "magma-oai.openair4G.eur",
+		Realm:       "openair4G.eur",
+		ProductName: "magma",
+		AppID:       rx.RxAppID,
+	}
+	client := rx.NewRxClient(clientConfig, serverConfig, installer.install)
+	return af, client
+}
+
+// authorizeSession sends the AAR once the Rx connection is established by the client
+func authorizeSession(t *testing.T, af *mock_af.AFServer, req *fegprotos.AFSessionRequest) *fegprotos.AFSessionAnswer {
+	var (
+		ans *fegprotos.AFSessionAnswer
+		err error
+	)
+	for i := 0; i < 20; i++ {
+		ans, err = af.AuthorizeSession(context.Background(), req)
+		if err == nil {
+			return ans
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("Failed to authorize AF session: %v", err)
+	return nil
+}
+
+func waitForEvents(t *testing.T, af *mock_af.AFServer, count int) []*fegprotos.AFSessionEvent {
+	var events []*fegprotos.AFSessionEvent
+	assert.Eventually(t, func() bool {
+		res, err := af.GetSessionEvents(context.Background(), &orcprotos.Void{})
+		assert.NoError(t, err)
+		events = res.Events
+		return len(events) >= count
+	}, 2*time.Second, 10*time.Millisecond)
+	if len(events) < count {
+		t.FailNow()
+	}
+	return events
+}
+
+func getAudioComponent() *fegprotos.AFMediaComponent {
+	return &fegprotos.AFMediaComponent{
+		MediaComponentNumber: 1,
+		MediaType:            uint32(rx.MediaTypeAudio),
+		MaxRequestedBwUl:     64000,
+		MaxRequestedBwDl:     64000,
+		MinRequestedBwUl:     32000,
+		MinRequestedBwDl:     32000,
+		SubComponents: []*fegprotos.AFMediaSubComponent{
+			{FlowNumber: 1, FlowDescriptions: []string{testAudioFlow}},
+			{FlowNumber: 2, FlowDescriptions: []string{testRTCPFlow}, FlowUsage: uint32(rx.FlowUsageRTCP)},
+		},
+	}
+}
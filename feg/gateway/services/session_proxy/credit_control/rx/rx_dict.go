@@ -0,0 +1,260 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rx
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// Rx application ID, command code & AVPs missing from the go-diameter default dictionary
+// (3GPP TS 29.214 section 5.3)
+const (
+	RxAppID = 16777236
+
+	// AAR/AAA command code, Rx uses the NASREQ AA command (3GPP TS 29.214 5.6.1)
+	AuthorizeAuthenticate = 265
+
+	AbortCauseAVP                = 500
+	AFApplicationIdentifierAVP   = 504
+	AFChargingIdentifierAVP      = 505
+	FlowDescriptionAVP           = 507
+	FlowNumberAVP                = 509
+	FlowsAVP                     = 510
+	FlowStatusAVP                = 511
+	FlowUsageAVP                 = 512
+	SpecificActionAVP            = 513
+	MaxRequestedBandwidthDLAVP   = 515
+	MaxRequestedBandwidthULAVP   = 516
+	MediaComponentDescriptionAVP = 517
+	MediaComponentNumberAVP      = 518
+	MediaSubComponentAVP         = 519
+	MediaTypeAVP                 = 520
+	CodecDataAVP                 = 524
+	RxRequestTypeAVP             = 533
+	MinRequestedBandwidthDLAVP   = 534
+	MinRequestedBandwidthULAVP   = 535
+)
+
+// rxDictExtension adds the Rx application with its AA command & AVPs to the default dictionary.
+// Base AVPs used by the application (Subscription-Id, Framed-IP-Address, etc.) are not part of the
+// base dictionary & need to be redefined within the Rx application to be found by the parser
+const rxDictExtension = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+    <application id="16777236" type="auth" name="TGPP RX">
+        <vendor id="10415" name="TGPP"/>
+        <command code="265" short="AA" name="AA">
+            <request>
+                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="DRMP" required="false" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="false" max="1"/>
+                <rule avp="AF-Application-Identifier" required="false" max="1"/>
+                <rule avp="Media-Component-Description" required="false"/>
+                <rule avp="AF-Charging-Identifier" required="false" max="1"/>
+                <rule avp="Specific-Action" required="false"/>
+                <rule avp="Subscription-Id" required="false"/>
+                <rule avp="Framed-IP-Address" required="false" max="1"/>
+                <rule avp="Framed-IPv6-Prefix" required="false" max="1"/>
+                <rule avp="Called-Station-Id" required="false" max="1"/>
+                <rule avp="Rx-Request-Type" required="false" max="1"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="DRMP" required="false" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="false" max="1"/>
+                <rule avp="Flows" required="false"/>
+                <rule avp="Error-Message" required="false" max="1"/>
+                <rule avp="Error-Reporting-Host" required="false" max="1"/>
+                <rule avp="Failed-AVP" required="false"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+            </answer>
+        </command>
+        <avp name="Abort-Cause" code="500" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="BEARER_RELEASED"/>
+                <item code="1" name="INSUFFICIENT_SERVER_RESOURCES"/>
+                <item code="2" name="INSUFFICIENT_BEARER_RESOURCES"/>
+                <item code="3" name="PS_TO_CS_HANDOVER"/>
+                <item code="4" name="SPONSORED_DATA_CONNECTIVITY_DISALLOWED"/>
+            </data>
+        </avp>
+        <avp name="AF-Application-Identifier" code="504" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+        <avp name="AF-Charging-Identifier" code="505" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+        <avp name="Flow-Description" code="507" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="IPFilterRule"/>
+        </avp>
+        <avp name="Flow-Number" code="509" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Flows" code="510" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Media-Component-Number" required="true" max="1"/>
+                <rule avp="Flow-Number" required="false"/>
+                <rule avp="Final-Unit-Action" required="false" max="1"/>
+            </data>
+        </avp>
+        <avp name="Flow-Status" code="511" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="ENABLED-UPLINK"/>
+                <item code="1" name="ENABLED-DOWNLINK"/>
+                <item code="2" name="ENABLED"/>
+                <item code="3" name="DISABLED"/>
+                <item code="4" name="REMOVED"/>
+            </data>
+        </avp>
+        <avp name="Flow-Usage" code="512" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="NO_INFORMATION"/>
+                <item code="1" name="RTCP"/>
+                <item code="2" name="AF_SIGNALLING"/>
+            </data>
+        </avp>
+        <avp name="Specific-Action" code="513" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="1" name="CHARGING_CORRELATION_EXCHANGE"/>
+                <item code="2" name="INDICATION_OF_LOSS_OF_BEARER"/>
+                <item code="3" name="INDICATION_OF_RECOVERY_OF_BEARER"/>
+                <item code="4" name="INDICATION_OF_RELEASE_OF_BEARER"/>
+                <item code="6" name="IP-CAN_CHANGE"/>
+                <item code="7" name="INDICATION_OF_OUT_OF_CREDIT"/>
+                <item code="8" name="INDICATION_OF_SUCCESSFUL_RESOURCES_ALLOCATION"/>
+                <item code="9" name="INDICATION_OF_FAILED_RESOURCES_ALLOCATION"/>
+                <item code="10" name="INDICATION_OF_LIMITED_PCC_DEPLOYMENT"/>
+                <item code="11" name="USAGE_REPORT"/>
+                <item code="12" name="ACCESS_NETWORK_INFO_REPORT"/>
+            </data>
+        </avp>
+        <avp name="Max-Requested-Bandwidth-DL" code="515" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Max-Requested-Bandwidth-UL" code="516" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Media-Component-Description" code="517" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Media-Component-Number" required="true" max="1"/>
+                <rule avp="Media-Sub-Component" required="false"/>
+                <rule avp="AF-Application-Identifier" required="false" max="1"/>
+                <rule avp="Media-Type" required="false" max="1"/>
+                <rule avp="Max-Requested-Bandwidth-UL" required="false" max="1"/>
+                <rule avp="Max-Requested-Bandwidth-DL" required="false" max="1"/>
+                <rule avp="Min-Requested-Bandwidth-UL" required="false" max="1"/>
+                <rule avp="Min-Requested-Bandwidth-DL" required="false" max="1"/>
+                <rule avp="Flow-Status" required="false" max="1"/>
+                <rule avp="Codec-Data" required="false" max="2"/>
+            </data>
+        </avp>
+        <avp name="Media-Component-Number" code="518" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Media-Sub-Component" code="519" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Flow-Number" required="true" max="1"/>
+                <rule avp="Flow-Description" required="false" max="2"/>
+                <rule avp="Flow-Status" required="false" max="1"/>
+                <rule avp="Flow-Usage" required="false" max="1"/>
+                <rule avp="Max-Requested-Bandwidth-UL" required="false" max="1"/>
+                <rule avp="Max-Requested-Bandwidth-DL" required="false" max="1"/>
+            </data>
+        </avp>
+        <avp name="Media-Type" code="520" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="AUDIO"/>
+                <item code="1" name="VIDEO"/>
+                <item code="2" name="DATA"/>
+                <item code="3" name="APPLICATION"/>
+                <item code="4" name="CONTROL"/>
+                <item code="5" name="TEXT"/>
+                <item code="6" name="MESSAGE"/>
+            </data>
+        </avp>
+        <avp name="Codec-Data" code="524" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+        <avp name="Rx-Request-Type" code="533" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="INITIAL_REQUEST"/>
+                <item code="1" name="UPDATE_REQUEST"/>
+                <item code="2" name="PCSCF_RESTORATION"/>
+            </data>
+        </avp>
+        <avp name="Min-Requested-Bandwidth-DL" code="534" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Min-Requested-Bandwidth-UL" code="535" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+        <avp name="Subscription-Id" code="443" must="M" may="P" must-not="V" may-encrypt="Y">
+            <data type="Grouped">
+                <rule avp="Subscription-Id-Type" required="true" max="1"/>
+                <rule avp="Subscription-Id-Data" required="true" max="1"/>
+            </data>
+        </avp>
+        <avp name="Subscription-Id-Data" code="444" must="M" may="P" must-not="V" may-encrypt="Y">
+            <data type="UTF8String"/>
+        </avp>
+        <avp name="Subscription-Id-Type" code="450" must="M" may="P" must-not="V" may-encrypt="Y">
+            <data type="Enumerated">
+                <item code="0" name="END_USER_E164"/>
+                <item code="1" name="END_USER_IMSI"/>
+                <item code="2" name="END_USER_SIP_URI"/>
+                <item code="3" name="END_USER_NAI"/>
+                <item code="4" name="END_USER_PRIVATE"/>
+            </data>
+        </avp>
+        <avp name="Framed-IP-Address" code="8" must="M" may="-" must-not="V" may-encrypt="Y">
+            <data type="OctetString"/>
+        </avp>
+        <avp name="Framed-IPv6-Prefix" code="97" must="M" may="-" must-not="V" may-encrypt="Y">
+            <data type="OctetString"/>
+        </avp>
+        <avp name="Called-Station-Id" code="30" must="M" may="-" must-not="V" may-encrypt="Y">
+            <data type="UTF8String"/>
+        </avp>
+        <avp name="Final-Unit-Action" code="449" must="M" may="P" must-not="V" may-encrypt="Y">
+            <data type="Enumerated">
+                <item code="0" name="TERMINATE"/>
+                <item code="1" name="REDIRECT"/>
+                <item code="2" name="RESTRICT_ACCESS"/>
+            </data>
+        </avp>
+    </application>
+</diameter>`
+
+func init() {
+	err := dict.Default.Load(bytes.NewReader([]byte(rxDictExtension)))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load Rx dictionary extension: %v", err))
+	}
+}
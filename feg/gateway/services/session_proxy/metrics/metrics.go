@@ -91,6 +91,15 @@ var (
 		Name: "gy_unparseable_msg_total",
 		Help: "Total number of gy messages received that cannot be parsed",
 	})
+	RxUnparseableMsg = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "rx_unparseable_msg_total",
+		Help: "Total number of rx messages received that cannot be parsed",
+	})
+
+	GxInvalidRules = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "gx_invalid_rules_total",
+		Help: "Total number of gx rules rejected because of invalid flow descriptions",
+	})
 
 	GxTimeouts = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "gx_timeouts_total",
@@ -164,7 +173,7 @@ func init() {
 	prometheus.MustRegister(PcrfCcrInitRequests, PcrfCcrInitSendFailures, PcrfCcrUpdateRequests, PcrfCcrUpdateSendFailures,
 		PcrfCcrTerminateRequests, PcrfCcrTerminateSendFailures, OcsCcrInitRequests, OcsCcrInitSendFailures,
 		OcsCcrUpdateRequests, OcsCcrUpdateSendFailures, OcsCcrTerminateRequests, OcsCcrTerminateSendFailures,
-		GxUnparseableMsg, GyUnparseableMsg, GxTimeouts, GyTimeouts, GxResultCodes, GyResultCodes,
+		GxUnparseableMsg, GyUnparseableMsg, RxUnparseableMsg, GxInvalidRules, GxTimeouts, GyTimeouts, GxResultCodes, GyResultCodes,
 		GxSuccessTimestamp, GxFailuresSinceLastSuccess, GySuccessTimestamp, GyFailuresSinceLastSuccess)
 }
 
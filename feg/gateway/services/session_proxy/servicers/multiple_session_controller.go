@@ -22,6 +22,7 @@ import (
 	"magma/feg/gateway/policydb"
 	"magma/feg/gateway/services/session_proxy/credit_control/gx"
 	"magma/feg/gateway/services/session_proxy/credit_control/gy"
+	"magma/feg/gateway/services/session_proxy/credit_control/rx"
 	"magma/lte/cloud/go/protos"
 	"magma/orc8r/lib/go/errors"
 	orcprotos "magma/orc8r/lib/go/protos"
@@ -61,6 +62,8 @@ type ControllerParam struct {
 	CreditClient gy.CreditClient
 	PolicyClient gx.PolicyClient
 	Config       *SessionControllerConfig
+	// AFNotifier is optional and only set if Rx is configured
+	AFNotifier rx.AFSessionNotifier
 }
 
 // NewCentralSessionControllers creates centralControllers which is a slice of centralController.
@@ -73,7 +76,7 @@ func NewCentralSessionControllers(
 	totalLen := len(controlParam)
 	controllers := make([]*CentralSessionController, 0, totalLen)
 	for _, cp := range controlParam {
-		singleController := NewCentralSessionController(cp.CreditClient, cp.PolicyClient, dbClient, cp.Config)
+		singleController := NewCentralSessionController(cp.CreditClient, cp.PolicyClient, cp.AFNotifier, dbClient, cp.Config)
 		controllers = append(controllers, singleController)
 	}
 	return &CentralSessionControllers{
@@ -92,7 +95,7 @@ func NewCentralSessionControllerDefaultMultiplexWithHealth(
 ) (CentralSessionControllerServerWithHealth, error) {
 	if len(controlParam) == 1 {
 		cp := controlParam[0]
-		return NewCentralSessionController(cp.CreditClient, cp.PolicyClient, dbClient, cp.Config), nil
+		return NewCentralSessionController(cp.CreditClient, cp.PolicyClient, cp.AFNotifier, dbClient, cp.Config), nil
 	}
 	mux, err := multiplex.NewStaticMultiplexByIMSI(len(controlParam))
 	if err != nil {
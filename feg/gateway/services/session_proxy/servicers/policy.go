@@ -301,10 +301,11 @@ func addMissingGxResponses(
 func (srv *CentralSessionController) getSingleUsageMonitorResponseFromCCA(
 	answer *gx.CreditControlAnswer, request *gx.CreditControlRequest) *protos.UsageMonitoringUpdateResponse {
 
-	staticRules, dynamicRules := gx.ParseRuleInstallAVPs(
+	staticRules, dynamicRules, ruleFailures := gx.ParseRuleInstallAVPs(
 		srv.dbClient,
 		answer.RuleInstallAVP,
 	)
+	gx.ReportRuleInstallFailures(request.SessionID, "Gx CCA-U", ruleFailures)
 	rulesToRemove := gx.ParseRuleRemoveAVPs(
 		srv.dbClient,
 		answer.RuleRemoveAVP,
@@ -24,6 +24,7 @@ import (
 	"magma/feg/gateway/services/session_proxy/credit_control"
 	"magma/feg/gateway/services/session_proxy/credit_control/gx"
 	"magma/feg/gateway/services/session_proxy/credit_control/gy"
+	"magma/feg/gateway/services/session_proxy/credit_control/rx"
 	"magma/feg/gateway/services/session_proxy/metrics"
 	"magma/lte/cloud/go/protos"
 	"magma/orc8r/lib/go/errors"
@@ -39,6 +40,7 @@ import (
 type CentralSessionController struct {
 	creditClient  gy.CreditClient
 	policyClient  gx.PolicyClient
+	afNotifier    rx.AFSessionNotifier
 	dbClient      policydb.PolicyDBClient
 	cfg           *SessionControllerConfig
 	healthTracker *metrics.SessionHealthTracker
@@ -66,12 +68,14 @@ type SessionControllerConfig struct {
 func NewCentralSessionController(
 	creditClient gy.CreditClient,
 	policyClient gx.PolicyClient,
+	afNotifier rx.AFSessionNotifier,
 	dbClient policydb.PolicyDBClient,
 	cfg *SessionControllerConfig,
 ) *CentralSessionController {
 	return &CentralSessionController{
 		creditClient:  creditClient,
 		policyClient:  policyClient,
+		afNotifier:    afNotifier,
 		dbClient:      dbClient,
 		cfg:           cfg,
 		healthTracker: metrics.NewSessionHealthTracker(),
@@ -103,7 +107,8 @@ func (srv *CentralSessionController) CreateSession(
 		glog.Errorf("Could not inject omnipresent Rules, skipping. Error: %+v", err)
 	}
 
-	staticRuleInstalls, dynamicRuleInstalls := gx.ParseRuleInstallAVPs(srv.dbClient, gxCCAInit.RuleInstallAVP)
+	staticRuleInstalls, dynamicRuleInstalls, ruleFailures := gx.ParseRuleInstallAVPs(srv.dbClient, gxCCAInit.RuleInstallAVP)
+	gx.ReportRuleInstallFailures(request.SessionId, "Gx CCA-I", ruleFailures)
 	chargingKeys := srv.getChargingKeysFromRuleInstalls(staticRuleInstalls, dynamicRuleInstalls)
 	eventTriggers, revalidationTime := gx.GetEventTriggersRelatedInfo(gxCCAInit.EventTriggers, gxCCAInit.RevalidationTime)
 	gxOriginHost, gyOriginHost := gxCCAInit.OriginHost, ""
@@ -301,6 +306,10 @@ func (srv *CentralSessionController) TerminateSession(
 		}
 	}()
 	wg.Wait()
+	if srv.afNotifier != nil {
+		// the IP-CAN session is gone, so are the AF sessions bearers
+		go srv.afNotifier.AbortSessions(request.GetCommonContext().GetSid().GetId(), rx.BearerReleased)
+	}
 	// in the event of any errors on Gx or Gy, the session should regardless be
 	// terminated, so there are no errors sent back
 	return &protos.SessionTerminateResponse{
@@ -1151,6 +1151,7 @@ func getMockControllerParams(mockConfig []*servicers.SessionControllerConfig) []
 			&mockGy.CreditClient{},
 			&mockGx.PolicyClient{},
 			mockConfig[i],
+			nil,
 		}
 		controlParams = append(controlParams, cp)
 	}
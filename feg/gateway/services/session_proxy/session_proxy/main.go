@@ -28,9 +28,11 @@ import (
 	"magma/feg/gateway/diameter"
 	"magma/feg/gateway/policydb"
 	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/session_proxy/capture"
 	"magma/feg/gateway/services/session_proxy/credit_control"
 	"magma/feg/gateway/services/session_proxy/credit_control/gx"
 	"magma/feg/gateway/services/session_proxy/credit_control/gy"
+	"magma/feg/gateway/services/session_proxy/credit_control/rx"
 	"magma/feg/gateway/services/session_proxy/servicers"
 	lteprotos "magma/lte/cloud/go/protos"
 	"magma/orc8r/lib/go/service"
@@ -111,14 +113,29 @@ func generateClientsConfsAndDiameterConnection() (
 			"Number of Gx and Gy servers configured must be equal Gx:%d Gx:%d",
 			len(OCSConfs), len(PCRFConfs))
 	}
+	PCSCFConf := rx.GetPCSCFConfiguration()
+	// Optional capture of all Gx and Gy messages, nil if disabled
+	recorder, err := capture.NewConfiguredRecorder()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error creating Gx/Gy message capture: %s", err)
+	}
+	if recorder != nil {
+		glog.Info("Capturing Gx and Gy messages")
+	}
 	glog.Info("------ Done reading configuration ------")
 
 	// ---- Create diammeter connections and build parameters for CentralSessionControllersn ----
 	glog.Info("------ Create diameter connexions ------")
 	totalLen := len(OCSConfs)
+	// A single Rx connection is shared by all the controllers
+	var afNotifier rx.AFSessionNotifier
+	if PCSCFConf != nil {
+		glog.Infof("Using Rx connection to P-CSCF/AF: %+v", PCSCFConf.DiameterServerConnConfig)
+		afNotifier = rx.NewRxClient(rx.GetRxClientConfiguration(), PCSCFConf, rx.GetPolicyInstaller(cloudReg))
+	}
 	controllerParms := make([]*servicers.ControllerParam, 0, totalLen)
 	for i := 0; i < totalLen; i++ {
-		controlParam := &servicers.ControllerParam{}
+		controlParam := &servicers.ControllerParam{AFNotifier: afNotifier}
 		// Fill in general parameters for controler i
 		controlParam.Config = &servicers.SessionControllerConfig{
 			OCSConfig:        OCSConfs[i],
@@ -134,6 +151,9 @@ func generateClientsConfsAndDiameterConnection() (
 			var clientCfg = *gxCliConfs[i]
 			clientCfg.AuthAppID = gyCLiConfs[i].AppID
 			diamClient := diameter.NewClient(&clientCfg)
+			if recorder != nil {
+				diamClient.SetMessageRecorder(recorder)
+			}
 			diamClient.BeginConnection(OCSConfsCopy[i])
 			if gyGlobalConf.DisableGy {
 				glog.Info("Gy Disabled by configuration, not connecting to OCS")
@@ -164,22 +184,30 @@ func generateClientsConfsAndDiameterConnection() (
 			if gyGlobalConf.DisableGy {
 				glog.Info("Gy Disabled by configuration, not connecting to OCS")
 			} else {
-				controlParam.CreditClient = gy.NewGyClient(
+				gyClient := gy.NewGyClient(
 					gy.GetGyClientConfiguration()[i],
 					OCSConfsCopy[i],
 					gy.GetGyReAuthHandler(cloudReg),
 					cloudReg,
 					gyGlobalConf)
+				if recorder != nil {
+					gyClient.SetMessageRecorder(recorder)
+				}
+				controlParam.CreditClient = gyClient
 			}
 			if gxGlobalConf.DisableGx {
 				glog.Info("Gx Disabled by configuration, not connecting to PCRF")
 			} else {
-				controlParam.PolicyClient = gx.NewGxClient(
+				gxClient := gx.NewGxClient(
 					gx.GetGxClientConfiguration()[i],
 					PCRFConfsCopy[i],
 					gx.GetGxReAuthHandler(cloudReg, policyDBClient),
 					cloudReg,
 					gxGlobalConf)
+				if recorder != nil {
+					gxClient.SetMessageRecorder(recorder)
+				}
+				controlParam.PolicyClient = gxClient
 			}
 		}
 		controllerParms = append(controllerParms, controlParam)
@@ -0,0 +1,72 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"log"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/session_proxy/credit_control/rx"
+	"magma/feg/gateway/services/testcore/af/mock_af"
+	"magma/orc8r/lib/go/service"
+
+	"github.com/golang/glog"
+)
+
+func main() {
+	flag.Parse()
+
+	log.Print("------ Reading Rx configuration ------")
+	afServConf := rx.GetPCSCFConfiguration()
+	if afServConf == nil {
+		log.Fatalf("Rx is not configured, %s must be set", rx.PCSCFAddrEnv)
+		return
+	}
+	// The mock AF uses the P-CSCF identity expected by session_proxy
+	afCliConf := rx.GetRxClientConfiguration()
+	if len(afServConf.DestHost) > 0 {
+		afCliConf.Host = afServConf.DestHost
+	}
+	if len(afServConf.DestRealm) > 0 {
+		afCliConf.Realm = afServConf.DestRealm
+	}
+	log.Print("------ Done reading Rx configuration  ------")
+	log.Printf("Mock AF using Rx server address %s", afServConf.Addr)
+
+	afServer := mock_af.NewAFServer(afCliConf, afServConf)
+
+	srv, err := service.NewServiceWithOptions(registry.ModuleName, registry.MOCK_AF)
+	if err != nil {
+		log.Fatalf("Error creating mock %s service: %s", registry.MOCK_AF, err)
+	}
+
+	lis, err := afServer.StartListener()
+	if err != nil {
+		log.Fatalf("Unable to start listener for mock %s: %s", registry.MOCK_AF, err)
+	}
+
+	protos.RegisterMockAFServer(srv.GrpcServer, afServer)
+
+	go func() {
+		glog.V(2).Infof("Starting mock %s server at %s", registry.MOCK_AF, lis.Addr().String())
+		glog.Errorf(afServer.Start(lis).Error()) // blocks
+	}()
+
+	err = srv.Run()
+	if err != nil {
+		log.Fatalf("Error running mock %s service: %s", registry.MOCK_AF, err)
+	}
+}
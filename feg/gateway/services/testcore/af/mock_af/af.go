@@ -0,0 +1,404 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mock_af implements a mock P-CSCF/AF peer of the Rx interface used to
+// create & terminate AF sessions on session_proxy for local testing
+package mock_af
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+	"magma/feg/gateway/services/session_proxy/credit_control/rx"
+	orcprotos "magma/orc8r/lib/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smpeer"
+	"github.com/golang/glog"
+)
+
+const (
+	answerTimeout = 10 * time.Second
+	// DIAMETER_LOGOUT Termination-Cause
+	terminationCauseLogout = 1
+)
+
+type afAnswer struct {
+	SessionID          string `avp:"Session-Id"`
+	ResultCode         uint32 `avp:"Result-Code"`
+	ExperimentalResult struct {
+		ExperimentalResultCode uint32 `avp:"Experimental-Result-Code"`
+	} `avp:"Experimental-Result"`
+}
+
+type afRequest struct {
+	SessionID       string              `avp:"Session-Id"`
+	SpecificActions []rx.SpecificAction `avp:"Specific-Action"`
+	Flows           []*rx.Flows         `avp:"Flows"`
+	AbortCause      *rx.AbortCause      `avp:"Abort-Cause"`
+}
+
+type answerKey struct {
+	sessionID string
+	command   uint32
+}
+
+// AFServer is a mock AF terminating the Rx connection of session_proxy. session_proxy
+// connects to the AF, AF session requests are sent over the established connection
+type AFServer struct {
+	diameterClientConfig *diameter.DiameterClientConfig
+	diameterServerConfig *diameter.DiameterServerConfig
+	mux                  *sm.StateMachine
+
+	mu       sync.Mutex
+	conn     diam.Conn
+	pending  map[answerKey]chan *afAnswer
+	events   []*protos.AFSessionEvent
+	sessions map[string]struct{}
+}
+
+// NewAFServer initializes a mock AF
+// Input: *diameter.DiameterClientConfig containing the AF diameter identity
+//				*diameter.DiameterServerConfig containing the server address
+//
+// Output: a new AFServer
+func NewAFServer(clientConfig *diameter.DiameterClientConfig, serverConfig *diameter.DiameterServerConfig) *AFServer {
+	return &AFServer{
+		diameterClientConfig: clientConfig,
+		diameterServerConfig: serverConfig,
+		pending:              map[answerKey]chan *afAnswer{},
+		sessions:             map[string]struct{}{},
+	}
+}
+
+// Start begins the server and blocks, listening to the network
+// Output: error if the server could not be started
+func (srv *AFServer) Start(lis net.Listener) error {
+	srv.mux = sm.New(&sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(srv.diameterClientConfig.Host),
+		OriginRealm:      datatype.DiameterIdentity(srv.diameterClientConfig.Realm),
+		VendorID:         datatype.Unsigned32(diameter.Vendor3GPP),
+		ProductName:      datatype.UTF8String(srv.diameterClientConfig.ProductName),
+		OriginStateID:    datatype.Unsigned32(time.Now().Unix()),
+		FirmwareRevision: 1,
+	})
+	srv.mux.HandleIdx(
+		diam.CommandIndex{AppID: rx.RxAppID, Code: rx.AuthorizeAuthenticate, Request: false},
+		diam.HandlerFunc(srv.handleAnswer))
+	srv.mux.HandleIdx(
+		diam.CommandIndex{AppID: rx.RxAppID, Code: diam.SessionTermination, Request: false},
+		diam.HandlerFunc(srv.handleAnswer))
+	srv.mux.HandleIdx(
+		diam.CommandIndex{AppID: rx.RxAppID, Code: diam.ReAuth, Request: true},
+		diam.HandlerFunc(srv.handleRAR))
+	srv.mux.HandleIdx(
+		diam.CommandIndex{AppID: rx.RxAppID, Code: diam.AbortSession, Request: true},
+		diam.HandlerFunc(srv.handleASR))
+	go logErrors(srv.mux.ErrorReports())
+
+	serverConfig := srv.diameterServerConfig
+	server := &diam.Server{
+		Network: serverConfig.Protocol,
+		Addr:    serverConfig.Addr,
+		Handler: diam.HandlerFunc(srv.trackConnection),
+		Dict:    nil,
+	}
+	return server.Serve(lis)
+}
+
+// StartListener starts a listener based on ServerConfig
+func (srv *AFServer) StartListener() (net.Listener, error) {
+	return diam.Listen(srv.diameterServerConfig.Protocol, srv.diameterServerConfig.Addr)
+}
+
+// AuthorizeSession sends an AAR with the media components of the AF session & waits for the AAA
+func (srv *AFServer) AuthorizeSession(
+	_ context.Context,
+	req *protos.AFSessionRequest,
+) (*protos.AFSessionAnswer, error) {
+	sessionID := req.GetSessionId()
+	if len(sessionID) == 0 {
+		sessionID = diameter.GenSessionID(srv.diameterClientConfig.Host, "rx")
+	}
+	m, err := srv.newRequest(rx.AuthorizeAuthenticate, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	// the subscriber is only required for the initial AAR of the AF session
+	if len(req.GetImsi()) > 0 {
+		m.NewAVP(avp.SubscriptionID, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.SubscriptionIDType, avp.Mbit, 0, datatype.Enumerated(credit_control.EndUserIMSI)),
+				diam.NewAVP(avp.SubscriptionIDData, avp.Mbit, 0,
+					datatype.UTF8String(credit_control.RemoveIMSIPrefix(req.GetImsi()))),
+			},
+		})
+	}
+	for _, action := range req.GetSpecificActions() {
+		m.NewAVP(rx.SpecificActionAVP, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(action))
+	}
+	for _, component := range req.GetMediaComponents() {
+		m.AddAVP(toMediaComponentAVP(component))
+	}
+	ans, err := srv.sendRequest(m, sessionID, rx.AuthorizeAuthenticate)
+	if err != nil {
+		return nil, err
+	}
+	if ans.ResultCode == diam.Success {
+		srv.mu.Lock()
+		srv.sessions[sessionID] = struct{}{}
+		srv.mu.Unlock()
+	}
+	return toAFSessionAnswer(sessionID, ans), nil
+}
+
+// TerminateSession sends a STR for the AF session & waits for the STA
+func (srv *AFServer) TerminateSession(
+	_ context.Context,
+	target *protos.AFSessionTarget,
+) (*protos.AFSessionAnswer, error) {
+	ans, err := srv.terminateSession(target.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return toAFSessionAnswer(target.GetSessionId(), ans), nil
+}
+
+// GetSessionEvents returns the RAR & ASR received by the AF
+func (srv *AFServer) GetSessionEvents(_ context.Context, _ *orcprotos.Void) (*protos.AFSessionEvents, error) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	events := make([]*protos.AFSessionEvent, len(srv.events))
+	copy(events, srv.events)
+	return &protos.AFSessionEvents{Events: events}, nil
+}
+
+// ClearSessionEvents removes all the events received by the AF
+func (srv *AFServer) ClearSessionEvents(_ context.Context, _ *orcprotos.Void) (*orcprotos.Void, error) {
+	srv.mu.Lock()
+	srv.events = nil
+	srv.mu.Unlock()
+	return &orcprotos.Void{}, nil
+}
+
+func (srv *AFServer) terminateSession(sessionID string) (*afAnswer, error) {
+	m, err := srv.newRequest(diam.SessionTermination, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	m.NewAVP(avp.TerminationCause, avp.Mbit, 0, datatype.Enumerated(terminationCauseLogout))
+	ans, err := srv.sendRequest(m, sessionID, diam.SessionTermination)
+	if err != nil {
+		return nil, err
+	}
+	srv.mu.Lock()
+	delete(srv.sessions, sessionID)
+	srv.mu.Unlock()
+	return ans, nil
+}
+
+// trackConnection keeps the last connection used by session_proxy before handing the message to the
+// state machine. HandshakeNotify is not used since notifications are dropped if nobody is waiting
+func (srv *AFServer) trackConnection(conn diam.Conn, m *diam.Message) {
+	srv.mu.Lock()
+	if srv.conn != conn {
+		glog.V(2).Infof("Rx connection established with %s", conn.RemoteAddr())
+		srv.conn = conn
+	}
+	srv.mu.Unlock()
+	srv.mux.ServeDIAM(conn, m)
+}
+
+func (srv *AFServer) newRequest(cmd uint32, sessionID string) (*diam.Message, error) {
+	srv.mu.Lock()
+	conn := srv.conn
+	srv.mu.Unlock()
+	if conn == nil {
+		return nil, fmt.Errorf("No Rx connection established")
+	}
+	meta, ok := smpeer.FromContext(conn.Context())
+	if !ok {
+		return nil, fmt.Errorf("peer metadata unavailable")
+	}
+	cfg := srv.mux.Settings()
+	m := diameter.NewProxiableRequest(cmd, rx.RxAppID, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(rx.RxAppID))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, cfg.OriginHost)
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, cfg.OriginRealm)
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, meta.OriginRealm)
+	m.NewAVP(avp.DestinationHost, avp.Mbit, 0, meta.OriginHost)
+	return m, nil
+}
+
+func (srv *AFServer) sendRequest(m *diam.Message, sessionID string, cmd uint32) (*afAnswer, error) {
+	key := answerKey{sessionID: sessionID, command: cmd}
+	done := make(chan *afAnswer, 1)
+	srv.mu.Lock()
+	srv.pending[key] = done
+	conn := srv.conn
+	srv.mu.Unlock()
+	defer func() {
+		srv.mu.Lock()
+		delete(srv.pending, key)
+		srv.mu.Unlock()
+	}()
+
+	glog.V(2).Infof("Sending Rx request to %s\n%s", conn.RemoteAddr(), m)
+	if _, err := m.WriteTo(conn); err != nil {
+		return nil, err
+	}
+	select {
+	case ans := <-done:
+		return ans, nil
+	case <-time.After(answerTimeout):
+		return nil, fmt.Errorf("No answer received for Rx request %d of AF session %s", cmd, sessionID)
+	}
+}
+
+func (srv *AFServer) handleAnswer(_ diam.Conn, m *diam.Message) {
+	var ans afAnswer
+	if err := m.Unmarshal(&ans); err != nil {
+		glog.Errorf("Received unparseable Rx answer %s\n%s", m, err)
+		return
+	}
+	glog.V(2).Infof("Received Rx answer\n%s", m)
+	srv.mu.Lock()
+	done, ok := srv.pending[answerKey{sessionID: ans.SessionID, command: m.Header.CommandCode}]
+	srv.mu.Unlock()
+	if !ok {
+		glog.Errorf("Unexpected Rx answer for AF session %s", ans.SessionID)
+		return
+	}
+	done <- &ans
+}
+
+func (srv *AFServer) handleRAR(conn diam.Conn, m *diam.Message) {
+	var rar afRequest
+	if err := m.Unmarshal(&rar); err != nil {
+		glog.Errorf("Received unparseable Rx RAR %s\n%s", m, err)
+		return
+	}
+	glog.V(2).Infof("Received Rx RAR\n%s", m)
+	var componentNumbers []uint32
+	for _, flow := range rar.Flows {
+		componentNumbers = append(componentNumbers, flow.MediaComponentNumber)
+	}
+	srv.mu.Lock()
+	for _, action := range rar.SpecificActions {
+		srv.events = append(srv.events, &protos.AFSessionEvent{
+			Type:                  protos.AFSessionEvent_REAUTH,
+			SessionId:             rar.SessionID,
+			SpecificAction:        uint32(action),
+			MediaComponentNumbers: componentNumbers,
+		})
+	}
+	srv.mu.Unlock()
+	srv.sendAnswer(conn, m, rar.SessionID)
+}
+
+// handleASR answers the ASR & terminates the AF session as required by 3GPP TS 29.214 section 4.4.6.2
+func (srv *AFServer) handleASR(conn diam.Conn, m *diam.Message) {
+	var asr afRequest
+	if err := m.Unmarshal(&asr); err != nil {
+		glog.Errorf("Received unparseable Rx ASR %s\n%s", m, err)
+		return
+	}
+	glog.V(2).Infof("Received Rx ASR\n%s", m)
+	event := &protos.AFSessionEvent{Type: protos.AFSessionEvent_ABORT, SessionId: asr.SessionID}
+	if asr.AbortCause != nil {
+		event.AbortCause = uint32(*asr.AbortCause)
+	}
+	srv.mu.Lock()
+	srv.events = append(srv.events, event)
+	srv.mu.Unlock()
+	srv.sendAnswer(conn, m, asr.SessionID)
+	go func() {
+		if _, err := srv.terminateSession(asr.SessionID); err != nil {
+			glog.Errorf("Failed to terminate aborted AF session %s: %v", asr.SessionID, err)
+		}
+	}()
+}
+
+func (srv *AFServer) sendAnswer(conn diam.Conn, m *diam.Message, sessionID string) {
+	cfg := srv.mux.Settings()
+	a := m.Answer(diam.Success)
+	a.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID)))
+	a.NewAVP(avp.OriginHost, avp.Mbit, 0, cfg.OriginHost)
+	a.NewAVP(avp.OriginRealm, avp.Mbit, 0, cfg.OriginRealm)
+	if _, err := a.WriteTo(conn); err != nil {
+		glog.Errorf("Failed to send Rx answer for AF session %s: %v", sessionID, err)
+	}
+}
+
+func toAFSessionAnswer(sessionID string, ans *afAnswer) *protos.AFSessionAnswer {
+	return &protos.AFSessionAnswer{
+		SessionId:              sessionID,
+		ResultCode:             ans.ResultCode,
+		ExperimentalResultCode: ans.ExperimentalResult.ExperimentalResultCode,
+	}
+}
+
+func toMediaComponentAVP(component *protos.AFMediaComponent) *diam.AVP {
+	avps := []*diam.AVP{
+		newRxAVP(rx.MediaComponentNumberAVP, datatype.Unsigned32(component.GetMediaComponentNumber())),
+		newRxAVP(rx.MediaTypeAVP, datatype.Enumerated(component.GetMediaType())),
+	}
+	for _, sub := range component.GetSubComponents() {
+		subAVPs := []*diam.AVP{
+			newRxAVP(rx.FlowNumberAVP, datatype.Unsigned32(sub.GetFlowNumber())),
+		}
+		for _, flow := range sub.GetFlowDescriptions() {
+			subAVPs = append(subAVPs, newRxAVP(rx.FlowDescriptionAVP, datatype.IPFilterRule(flow)))
+		}
+		if sub.GetFlowUsage() != 0 {
+			subAVPs = append(subAVPs, newRxAVP(rx.FlowUsageAVP, datatype.Enumerated(sub.GetFlowUsage())))
+		}
+		avps = append(avps, newRxAVP(rx.MediaSubComponentAVP, &diam.GroupedAVP{AVP: subAVPs}))
+	}
+	bandwidths := []struct {
+		code  uint32
+		value uint32
+	}{
+		{rx.MaxRequestedBandwidthULAVP, component.GetMaxRequestedBwUl()},
+		{rx.MaxRequestedBandwidthDLAVP, component.GetMaxRequestedBwDl()},
+		{rx.MinRequestedBandwidthULAVP, component.GetMinRequestedBwUl()},
+		{rx.MinRequestedBandwidthDLAVP, component.GetMinRequestedBwDl()},
+	}
+	for _, bw := range bandwidths {
+		if bw.value != 0 {
+			avps = append(avps, newRxAVP(bw.code, datatype.Unsigned32(bw.value)))
+		}
+	}
+	return newRxAVP(rx.MediaComponentDescriptionAVP, &diam.GroupedAVP{AVP: avps})
+}
+
+func newRxAVP(code uint32, data datatype.Type) *diam.AVP {
+	return diam.NewAVP(code, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, data)
+}
+
+// logErrors logs errors received during transmission
+func logErrors(ec <-chan *diam.ErrorReport) {
+	for err := range ec {
+		glog.Errorf("AF transmit error: %s", err)
+	}
+}
@@ -145,6 +145,98 @@ func DeregisterSubscriber(id string) error {
 	return err
 }
 
+// InsertSubscriberData sends the subscriber's profile to the MME serving the subscriber.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber whose profile will be sent.
+func InsertSubscriberData(id string) error {
+	err := verifyID(id)
+	if err != nil {
+		errMsg := fmt.Errorf("Invalid InsertSubscriberDataRequest provided: %s", err)
+		return errors.New(errMsg.Error())
+	}
+	cli, err := getHSSClient()
+	if err != nil {
+		return err
+	}
+	_, err = cli.InsertSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: id})
+	return err
+}
+
+// DeleteSubscriberData deletes subscription data from the MME serving the subscriber.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber & the subscription data to be deleted.
+func DeleteSubscriberData(req *fegprotos.DeleteSubscriberDataRequest) error {
+	err := verifyID(req.GetUserName())
+	if err != nil {
+		errMsg := fmt.Errorf("Invalid DeleteSubscriberDataRequest provided: %s", err)
+		return errors.New(errMsg.Error())
+	}
+	cli, err := getHSSClient()
+	if err != nil {
+		return err
+	}
+	_, err = cli.DeleteSubscriberData(context.Background(), req)
+	return err
+}
+
+// ReAuthS6bSessions sends an S6b Re-Auth-Request for each PGW session of the subscriber.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber whose sessions will be re-authorized.
+func ReAuthS6bSessions(id string) error {
+	err := verifyID(id)
+	if err != nil {
+		errMsg := fmt.Errorf("Invalid ReAuthS6bSessionsRequest provided: %s", err)
+		return errors.New(errMsg.Error())
+	}
+	cli, err := getHSSClient()
+	if err != nil {
+		return err
+	}
+	_, err = cli.ReAuthS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: id})
+	return err
+}
+
+// AbortS6bSessions sends an S6b Abort-Session-Request for each PGW session of the subscriber.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber whose sessions will be aborted.
+func AbortS6bSessions(id string) error {
+	err := verifyID(id)
+	if err != nil {
+		errMsg := fmt.Errorf("Invalid AbortS6bSessionsRequest provided: %s", err)
+		return errors.New(errMsg.Error())
+	}
+	cli, err := getHSSClient()
+	if err != nil {
+		return err
+	}
+	_, err = cli.AbortS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: id})
+	return err
+}
+
+// ImportSubscribers adds the subscribers of a CSV or JSON file to the store.
+// Records which could not be imported are listed in the result.
+// Input: The subscriber file content, its format & whether existing subscribers are updated.
+func ImportSubscribers(req *fegprotos.SubscriberImportRequest) (*fegprotos.SubscriberImportResult, error) {
+	if req == nil || len(req.GetContent()) == 0 {
+		return nil, errors.New("Invalid SubscriberImportRequest provided: no content")
+	}
+	cli, err := getHSSClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.ImportSubscribers(context.Background(), req)
+}
+
+// ExportSubscribers exports all the subscribers of the store to a CSV or JSON file.
+// Input: The format of the subscriber file.
+func ExportSubscribers(format fegprotos.SubscriberFileFormat) (*fegprotos.SubscriberExportResult, error) {
+	cli, err := getHSSClient()
+	if err != nil {
+		return nil, err
+	}
+	return cli.ExportSubscribers(context.Background(), &fegprotos.SubscriberExportRequest{Format: format})
+}
+
 func VerifySubscriberData(sub *lteprotos.SubscriberData) error {
 	if sub == nil {
 		return fmt.Errorf("subscriber is nil")
@@ -24,9 +24,15 @@ import (
 	"magma/feg/gateway/services/testcore/hss/servicers"
 	"magma/feg/gateway/services/testcore/hss/storage"
 	"magma/gateway/streamer"
+	"magma/orc8r/cloud/go/sqorc"
 	"magma/orc8r/lib/go/service"
 )
 
+var (
+	sqlDriver = flag.String("sql_driver", sqorc.SQLiteDriver, "SQL driver of the subscriber store")
+	sqlSource = flag.String("sql_source", "", "SQL data source of the subscriber store, subscribers are kept in memory if not set")
+)
+
 func main() {
 	flag.Parse()
 	srv, err := service.NewServiceWithOptions(registry.ModuleName, registry.MOCK_HSS)
@@ -37,7 +43,10 @@ func main() {
 	if err != nil {
 		log.Printf("Error getting hss config: %s", err)
 	}
-	store := storage.NewMemorySubscriberStore()
+	store, err := newSubscriberStore()
+	if err != nil {
+		log.Fatalf("Error creating subscriber store: %s", err)
+	}
 	servicer, err := servicers.NewHomeSubscriberServer(store, config)
 	if err != nil {
 		log.Fatalf("Error creating home subscriber server: %s", err)
@@ -82,3 +91,22 @@ func main() {
 		log.Fatalf("Error running hss service: %s", err)
 	}
 }
+
+// newSubscriberStore creates a SQL subscriber store which persists subscribers
+// & their SQNs across restarts if a data source is set, otherwise an in memory
+// store.
+func newSubscriberStore() (storage.SubscriberStore, error) {
+	if len(*sqlSource) == 0 {
+		return storage.NewMemorySubscriberStore(), nil
+	}
+	db, err := sqorc.Open(*sqlDriver, *sqlSource)
+	if err != nil {
+		return nil, err
+	}
+	store := storage.NewSQLSubscriberStore(db, sqorc.GetSqlBuilder())
+	if err = store.Initialize(); err != nil {
+		return nil, err
+	}
+	log.Printf("Using %s subscriber store", *sqlDriver)
+	return store, nil
+}
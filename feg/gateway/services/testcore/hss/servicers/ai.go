@@ -41,33 +41,14 @@ func NewAIA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error)
 		return msg.Answer(diam.UnableToComply), fmt.Errorf("AIR Unmarshal failed for message: %v failed: %v", msg, err)
 	}
 
-	subscriber, err := srv.store.GetSubscriberData(air.UserName)
+	amf, err := srv.store.GetAuthAmf(air.UserName)
 	if err != nil {
 		if _, ok := err.(storage.UnknownSubscriberError); ok {
 			return ConstructFailureAnswer(msg, air.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_USER_UNKNOWN)), err
 		}
 		return ConstructFailureAnswer(msg, air.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_AUTHENTICATION_DATA_UNAVAILABLE)), err
 	}
-
-	subscriber.Lock()
-	defer subscriber.Unlock()
-
-	lteAuthNextSeq, err := ResyncLteAuthSeq(
-		subscriber, air.RequestedEUTRANAuthInfo.ResyncInfo.Serialize(), srv.Config.LteAuthOp)
-	if err != nil {
-		return ConvertAuthErrorToFailureMessage(err, msg, air.SessionID, srv.Config.Server), err
-	}
-	if len(air.RequestedUtranGeranAuthInfo.ResyncInfo) > 0 {
-		lteAuthNextUtranSeq, err := ResyncLteAuthSeq(
-			subscriber, air.RequestedUtranGeranAuthInfo.ResyncInfo.Serialize(), srv.Config.LteAuthOp)
-		if err != nil {
-			return ConvertAuthErrorToFailureMessage(err, msg, air.SessionID, srv.Config.Server), err
-		}
-		if len(air.RequestedEUTRANAuthInfo.ResyncInfo) == 0 || lteAuthNextUtranSeq > lteAuthNextSeq {
-			lteAuthNextSeq = lteAuthNextUtranSeq
-		}
-	}
-	err = srv.setLteAuthNextSeq(subscriber, lteAuthNextSeq)
+	mcipher, err := GetMilenageCipher(srv.Milenage, amf)
 	if err != nil {
 		return ConvertAuthErrorToFailureMessage(err, msg, air.SessionID, srv.Config.Server), err
 	}
@@ -75,26 +56,64 @@ func NewAIA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error)
 	const plmnOffsetBytes = 1
 	plmn := air.VisitedPLMNID.Serialize()[plmnOffsetBytes:]
 
-	vectors, utranVectors, lteAuthNextSeq, err := GenerateLteAuthVectors(
-		uint32(air.RequestedEUTRANAuthInfo.NumVectors),
-		uint32(air.RequestedUtranGeranAuthInfo.NumVectors),
-		srv.Milenage, subscriber, plmn, srv.Config.LteAuthOp, srv.AuthSqnInd)
+	// The SEQ is read, used & incremented within a single store modification so
+	// that concurrent requests never generate vectors with the same SQN
+	var (
+		vectors      []*milenage.EutranVector
+		utranVectors []*milenage.UtranVector
+		vectorsErr   error
+	)
+	err = srv.store.ModifySubscriber(air.UserName, func(subscriber *lteprotos.SubscriberData) error {
+		lteAuthNextSeq, err := ResyncLteAuthSeq(
+			subscriber, air.RequestedEUTRANAuthInfo.ResyncInfo.Serialize(), srv.Config.LteAuthOp)
+		if err != nil {
+			return err
+		}
+		if len(air.RequestedUtranGeranAuthInfo.ResyncInfo) > 0 {
+			lteAuthNextUtranSeq, err := ResyncLteAuthSeq(
+				subscriber, air.RequestedUtranGeranAuthInfo.ResyncInfo.Serialize(), srv.Config.LteAuthOp)
+			if err != nil {
+				return err
+			}
+			if len(air.RequestedEUTRANAuthInfo.ResyncInfo) == 0 || lteAuthNextUtranSeq > lteAuthNextSeq {
+				lteAuthNextSeq = lteAuthNextUtranSeq
+			}
+		}
+		if err = setLteAuthNextSeq(subscriber, lteAuthNextSeq); err != nil {
+			return err
+		}
+
+		var nextSeq uint64
+		vectors, utranVectors, nextSeq, vectorsErr = GenerateLteAuthVectors(
+			uint32(air.RequestedEUTRANAuthInfo.NumVectors),
+			uint32(air.RequestedUtranGeranAuthInfo.NumVectors),
+			mcipher, subscriber, plmn, srv.Config.LteAuthOp, srv.AuthSqnInd)
+		if vectorsErr != nil {
+			// the re-synchronized SEQ is kept even if no vector could be generated
+			nextSeq = lteAuthNextSeq
+		}
+		return setLteAuthNextSeq(subscriber, nextSeq)
+	})
 	if err == nil {
-		err = srv.setLteAuthNextSeq(subscriber, lteAuthNextSeq)
+		err = vectorsErr
 	}
 	if err != nil {
+		if _, ok := err.(storage.UnknownSubscriberError); ok {
+			return ConstructFailureAnswer(msg, air.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_USER_UNKNOWN)), err
+		}
 		return ConvertAuthErrorToFailureMessage(err, msg, air.SessionID, srv.Config.Server), err
 	}
 
 	return srv.NewSuccessfulAIA(msg, air.SessionID, vectors, utranVectors), nil
 }
 
-func (srv *HomeSubscriberServer) setLteAuthNextSeq(subscriber *lteprotos.SubscriberData, lteAuthNextSeq uint64) error {
+// setLteAuthNextSeq sets the SEQ the next auth vector of the subscriber is generated with
+func setLteAuthNextSeq(subscriber *lteprotos.SubscriberData, lteAuthNextSeq uint64) error {
 	if subscriber.GetState() == nil {
 		return NewAuthDataUnavailableError("subscriber state was nil")
 	}
 	subscriber.State.LteAuthNextSeq = lteAuthNextSeq
-	return srv.store.UpdateSubscriber(subscriber)
+	return nil
 }
 
 // NewSuccessfulAIA outputs a successful authentication information answer (AIA) to reply to an
@@ -268,6 +268,19 @@ func GetOrGenerateOpc(lte *protos.LTESubscription, lteAuthOp []byte) ([]byte, er
 	return lte.AuthOpc, nil
 }
 
+// GetMilenageCipher returns the cipher to use for a subscriber, which is the
+// default cipher unless the subscriber has its own AMF
+func GetMilenageCipher(defaultCipher *milenage.Cipher, amf []byte) (*milenage.Cipher, error) {
+	if len(amf) == 0 {
+		return defaultCipher, nil
+	}
+	mcipher, err := milenage.NewCipher(amf)
+	if err != nil {
+		return nil, NewAuthDataUnavailableError(err.Error())
+	}
+	return mcipher, nil
+}
+
 // SeqToSqn computes the 48 bit SQN given a seq given the formula defined in
 // 3GPP TS 33.102 Annex C.3.2. The length of IND is 5 bits.
 // SQN = SEQ || IND
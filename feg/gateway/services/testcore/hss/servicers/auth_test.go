@@ -62,6 +62,22 @@ func TestGetOrGenerateOpc(t *testing.T) {
 	assert.Equal(t, expectedOpc[:], opc)
 }
 
+func TestGetMilenageCipher(t *testing.T) {
+	defaultCipher, err := milenage.NewCipher(defaultLteAuthAmf)
+	assert.NoError(t, err)
+
+	mcipher, err := servicers.GetMilenageCipher(defaultCipher, nil)
+	assert.NoError(t, err)
+	assert.True(t, defaultCipher == mcipher)
+
+	mcipher, err = servicers.GetMilenageCipher(defaultCipher, []byte("\x90\x01"))
+	assert.NoError(t, err)
+	assert.False(t, defaultCipher == mcipher)
+
+	_, err = servicers.GetMilenageCipher(defaultCipher, []byte("\x90"))
+	assert.Error(t, err)
+}
+
 func TestGenerateLteAuthVector_MissingLTE(t *testing.T) {
 	mcipher, err := milenage.NewCipher(defaultLteAuthAmf)
 	assert.NoError(t, err)
@@ -14,6 +14,8 @@ limitations under the License.
 package servicers
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/emakeev/milenage"
@@ -22,10 +24,14 @@ import (
 	"github.com/fiorix/go-diameter/v4/diam/datatype"
 	"github.com/fiorix/go-diameter/v4/diam/dict"
 	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/golang/glog"
 	"golang.org/x/net/context"
 
+	fegprotos "magma/feg/cloud/go/protos"
 	"magma/feg/cloud/go/protos/mconfig"
 	"magma/feg/gateway/diameter"
+	s6a "magma/feg/gateway/services/s6a_proxy/servicers"
+	s6b "magma/feg/gateway/services/s6b_proxy/servicers"
 	"magma/feg/gateway/services/testcore/hss/storage"
 	lteprotos "magma/lte/cloud/go/protos"
 	"magma/orc8r/lib/go/protos"
@@ -47,6 +53,14 @@ type HomeSubscriberServer struct {
 	requestTracker *diameter.RequestTracker
 	clientMapping  map[string]string
 
+	// servingMMEs maps the IMSIs of attached subscribers to the Diameter Identity of their serving MME
+	servingMMEs map[string]string
+	mmesMu      sync.RWMutex
+
+	// s6bSessions maps the S6b session IDs to the PGW sessions authorized over S6b
+	s6bSessions map[string]*s6bSession
+	s6bMu       sync.RWMutex
+
 	// authSqnInd is an index used in the array scheme described by 3GPP TS 33.102 Appendix C.1.2 and C.2.2.
 	// SQN consists of two parts (SQN = SEQ||IND).
 	AuthSqnInd uint64
@@ -66,6 +80,8 @@ func NewHomeSubscriberServer(store storage.SubscriberStore, config *mconfig.HSSC
 		requestTracker: diameter.NewRequestTracker(),
 		connMan:        diameter.NewConnectionManager(),
 		clientMapping:  map[string]string{},
+		servingMMEs:    map[string]string{},
+		s6bSessions:    map[string]*s6bSession{},
 	}, nil
 }
 
@@ -98,6 +114,85 @@ func (srv *HomeSubscriberServer) UpdateSubscriber(ctx context.Context, req *ltep
 	return &protos.Void{}, err
 }
 
+// ImportSubscribers adds the subscribers of a CSV or JSON file to the store.
+// Existing subscribers are only updated if requested, records which can't be
+// imported are reported in the result rather than failing the whole import.
+func (srv *HomeSubscriberServer) ImportSubscribers(
+	ctx context.Context,
+	req *fegprotos.SubscriberImportRequest,
+) (*fegprotos.SubscriberImportResult, error) {
+	records, err := ParseSubscriberRecords(req.GetFormat(), req.GetContent())
+	if err != nil {
+		return nil, storage.ConvertStorageErrorToGrpcStatus(storage.NewInvalidArgumentError(err.Error()))
+	}
+	result := &fegprotos.SubscriberImportResult{}
+	for _, record := range records {
+		existing, err := srv.store.GetSubscriberData(record.IMSI)
+		if err != nil {
+			existing = nil
+		} else if !req.GetOverwrite() {
+			result.Errors = append(result.Errors, fmt.Sprintf("Subscriber '%s' already exists", record.IMSI))
+			continue
+		}
+		subscriber, err := record.ToSubscriberData(existing)
+		if err == nil {
+			if existing != nil {
+				err = srv.store.UpdateSubscriber(subscriber)
+			} else {
+				err = srv.store.AddSubscriber(subscriber)
+			}
+		}
+		if err == nil {
+			err = srv.importAuthAmf(record)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("Subscriber '%s': %v", record.IMSI, err))
+		} else if existing != nil {
+			result.Updated++
+		} else {
+			result.Added++
+		}
+	}
+	glog.V(2).Infof("Imported subscribers: %d added, %d updated, %d errors",
+		result.Added, result.Updated, len(result.Errors))
+	return result, nil
+}
+
+// importAuthAmf stores the AMF of an imported subscriber, the current AMF is
+// kept if the record doesn't set it.
+func (srv *HomeSubscriberServer) importAuthAmf(record *SubscriberRecord) error {
+	amf, err := record.AuthAmf()
+	if err != nil || amf == nil {
+		return err
+	}
+	return srv.store.SetAuthAmf(record.IMSI, amf)
+}
+
+// ExportSubscribers exports all the subscribers of the store to a CSV or JSON
+// file.
+func (srv *HomeSubscriberServer) ExportSubscribers(
+	ctx context.Context,
+	req *fegprotos.SubscriberExportRequest,
+) (*fegprotos.SubscriberExportResult, error) {
+	subscribers, err := srv.store.ListSubscribers()
+	if err != nil {
+		return nil, storage.ConvertStorageErrorToGrpcStatus(err)
+	}
+	records := make([]*SubscriberRecord, 0, len(subscribers))
+	for _, subscriber := range subscribers {
+		amf, err := srv.store.GetAuthAmf(subscriber.GetSid().GetId())
+		if err != nil {
+			return nil, storage.ConvertStorageErrorToGrpcStatus(err)
+		}
+		records = append(records, NewSubscriberRecord(subscriber, amf))
+	}
+	content, err := FormatSubscriberRecords(req.GetFormat(), records)
+	if err != nil {
+		return nil, storage.ConvertStorageErrorToGrpcStatus(storage.NewInvalidArgumentError(err.Error()))
+	}
+	return &fegprotos.SubscriberExportResult{Content: content, Count: uint32(len(records))}, nil
+}
+
 // DeleteSubscriber deletes a subscriber by their Id.
 // If the subscriber is not found, then this call is ignored.
 // Input: The id of the subscriber to be deleted.
@@ -118,6 +213,51 @@ func (srv *HomeSubscriberServer) DeregisterSubscriber(ctx context.Context, req *
 	return &protos.Void{}, srv.TerminateRegistration(sub)
 }
 
+// InsertSubscriberData sends the subscriber's profile to the MME serving the subscriber
+// in an Insert Subscriber Data Request.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber whose profile will be sent.
+func (srv *HomeSubscriberServer) InsertSubscriberData(ctx context.Context, req *lteprotos.SubscriberID) (*protos.Void, error) {
+	sub, err := srv.store.GetSubscriberData(req.Id)
+	if err != nil {
+		return &protos.Void{}, storage.ConvertStorageErrorToGrpcStatus(err)
+	}
+	return &protos.Void{}, srv.SendInsertSubscriberData(sub)
+}
+
+// DeleteSubscriberData sends a Delete Subscriber Data Request to the MME serving the subscriber.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber & the subscription data to be deleted.
+func (srv *HomeSubscriberServer) DeleteSubscriberData(ctx context.Context, req *fegprotos.DeleteSubscriberDataRequest) (*protos.Void, error) {
+	_, err := srv.store.GetSubscriberData(req.GetUserName())
+	if err != nil {
+		return &protos.Void{}, storage.ConvertStorageErrorToGrpcStatus(err)
+	}
+	return &protos.Void{}, srv.SendDeleteSubscriberData(req)
+}
+
+// ReAuthS6BSessions sends an S6b Re-Auth-Request for each PGW session of the subscriber.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber whose sessions will be re-authorized.
+func (srv *HomeSubscriberServer) ReAuthS6BSessions(ctx context.Context, req *lteprotos.SubscriberID) (*protos.Void, error) {
+	_, err := srv.store.GetSubscriberData(req.Id)
+	if err != nil {
+		return &protos.Void{}, storage.ConvertStorageErrorToGrpcStatus(err)
+	}
+	return &protos.Void{}, srv.SendS6bReAuth(req.Id)
+}
+
+// AbortS6BSessions sends an S6b Abort-Session-Request for each PGW session of the subscriber.
+// If the subscriber is not found, an error is returned instead.
+// Input: The id of the subscriber whose sessions will be aborted.
+func (srv *HomeSubscriberServer) AbortS6BSessions(ctx context.Context, req *lteprotos.SubscriberID) (*protos.Void, error) {
+	_, err := srv.store.GetSubscriberData(req.Id)
+	if err != nil {
+		return &protos.Void{}, storage.ConvertStorageErrorToGrpcStatus(err)
+	}
+	return &protos.Void{}, srv.SendS6bAbortSession(req.Id)
+}
+
 // Start begins the server and blocks, listening to the network
 // Input: a channel to signal when the server is started & return the local server address string
 // Output: error if the server could not be started
@@ -138,9 +278,28 @@ func (srv *HomeSubscriberServer) Start(started chan string) error {
 	mux.Handle(diam.ULR, srv.handleMessage(NewULA))
 	mux.Handle(diam.MAR, srv.handleMessage(NewMAA))
 	mux.Handle(diam.SAR, srv.handleMessage(NewSAA))
+	mux.Handle(diam.NOR, srv.handleMessage(NewNOA))
 	mux.HandleIdx(
 		diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.RegistrationTermination, Request: false},
 		handleRTA(srv))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: s6a.InsertSubscriberData, Request: false},
+		handleIDA(srv))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: s6a.DeleteSubscriberData, Request: false},
+		handleDSA(srv))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.AA, Request: true},
+		srv.handleMessage(NewS6bAAA))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.SessionTermination, Request: true},
+		srv.handleMessage(NewS6bSTA))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.ReAuth, Request: false},
+		handleS6bRAA(srv))
+	mux.HandleIdx(
+		diam.CommandIndex{AppID: s6b.S6bAppID, Code: diam.AbortSession, Request: false},
+		handleS6bASA(srv))
 
 	clientCfg := diameter.DiameterClientConfig{}
 	clientCfg.FillInDefaults()
@@ -23,8 +23,10 @@ import (
 	"magma/feg/gateway/diameter"
 	"magma/feg/gateway/plmn_filter"
 	"magma/feg/gateway/services/s6a_proxy/servicers"
+	hss "magma/feg/gateway/services/testcore/hss/servicers"
 	"magma/feg/gateway/services/testcore/hss/servicers/test_utils"
 	"magma/lte/cloud/go/crypto"
+	lteprotos "magma/lte/cloud/go/protos"
 )
 
 func TestAIR_Successful(t *testing.T) {
@@ -167,10 +169,93 @@ func TestULR_UnknownIMSI(t *testing.T) {
 	assert.Equal(t, 0, len(ula.Apn))
 }
 
+func TestNOR_Successful(t *testing.T) {
+	s6aProxy := getTestS6aProxy(t, []string{})
+	nor := &protos.NotifyRequest{
+		UserName:          "sub1",
+		NorFlags:          servicers.NORFlagReadyForSMFromMME,
+		ContextIdentifier: 1,
+		ServiceSelection:  "oai.ipv4",
+		AlertReason:       protos.NotifyRequest_UE_MEMORY_AVAILABLE,
+	}
+
+	noa, err := s6aProxy.Notify(context.Background(), nor)
+	assert.NoError(t, err)
+	assert.Equal(t, protos.ErrorCode_SUCCESS, noa.ErrorCode)
+}
+
+func TestNOR_UnknownIMSI(t *testing.T) {
+	s6aProxy := getTestS6aProxy(t, []string{})
+	noa, err := s6aProxy.Notify(context.Background(), &protos.NotifyRequest{UserName: "sub_unknown"})
+	assert.NoError(t, err)
+	assert.Equal(t, protos.ErrorCode_USER_UNKNOWN, noa.ErrorCode)
+}
+
+func TestIDR_Successful(t *testing.T) {
+	hssSrv := getTestHSSDiameterServer(t)
+	relay := &mockS6aRelay{code: protos.ErrorCode_SUCCESS}
+	s6aProxy := getTestS6aProxyWithHSS(t, hssSrv, []string{}, relay)
+	_, err := s6aProxy.UpdateLocation(context.Background(), &protos.UpdateLocationRequest{
+		UserName:    "sub1",
+		VisitedPlmn: []byte{0, 0, 0},
+	})
+	assert.NoError(t, err)
+
+	_, err = hssSrv.InsertSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.NoError(t, err)
+	assert.NotNil(t, relay.idr)
+	assert.Equal(t, "sub1", relay.idr.UserName)
+	assert.Equal(t, uint32(test_utils.DefaultMaxUlBitRate), relay.idr.SubscriptionData.AMBR.MaxRequestedBandwidthUL)
+	assert.Equal(t, 1, len(relay.idr.SubscriptionData.APNConfigurationProfile.APNConfigs))
+	assert.Equal(t, "oai.ipv4", relay.idr.SubscriptionData.APNConfigurationProfile.APNConfigs[0].ServiceSelection)
+
+	// failures of the serving gateway are reported back to the HSS
+	relay.code = protos.ErrorCode_USER_UNKNOWN
+	_, err = hssSrv.InsertSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.Error(t, err)
+}
+
+func TestIDR_NoServingMME(t *testing.T) {
+	hssSrv := getTestHSSDiameterServer(t)
+	_, err := hssSrv.InsertSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.Error(t, err)
+	_, err = hssSrv.InsertSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: "sub_unknown"})
+	assert.Error(t, err)
+}
+
+func TestDSR_Successful(t *testing.T) {
+	hssSrv := getTestHSSDiameterServer(t)
+	relay := &mockS6aRelay{code: protos.ErrorCode_SUCCESS}
+	s6aProxy := getTestS6aProxyWithHSS(t, hssSrv, []string{}, relay)
+	_, err := s6aProxy.UpdateLocation(context.Background(), &protos.UpdateLocationRequest{
+		UserName:    "sub1",
+		VisitedPlmn: []byte{0, 0, 0},
+	})
+	assert.NoError(t, err)
+
+	_, err = hssSrv.DeleteSubscriberData(context.Background(), &protos.DeleteSubscriberDataRequest{
+		UserName:           "sub1",
+		DsrFlags:           servicers.DSRFlagPDNSubscriptionContextWithdraw,
+		ContextIdentifiers: []uint32{1, 2},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, relay.dsr)
+	assert.Equal(t, "sub1", relay.dsr.UserName)
+	assert.Equal(t, uint32(servicers.DSRFlagPDNSubscriptionContextWithdraw), relay.dsr.DSRFlags)
+	assert.Equal(t, []uint32{1, 2}, relay.dsr.ContextIdentifiers)
+}
+
 // getTestS6aProxy creates a s6a proxy server and test hss diameter
 // server which are configured to communicate with each other.
 func getTestS6aProxy(t *testing.T, plmns []string) protos.S6AProxyServer {
-	hss := getTestHSSDiameterServer(t)
+	return getTestS6aProxyWithHSS(t, getTestHSSDiameterServer(t), plmns, nil)
+}
+
+// getTestS6aProxyWithHSS creates a s6a proxy server connected to the given test hss diameter server
+// which forwards HSS initiated requests to the given relay
+func getTestS6aProxyWithHSS(
+	t *testing.T, hss *hss.HomeSubscriberServer, plmns []string, relay servicers.Relay) protos.S6AProxyServer {
+
 	serverCfg := hss.Config.Server
 
 	// Create an s6a proxy server and client configuration
@@ -199,6 +284,24 @@ func getTestS6aProxy(t *testing.T, plmns []string) protos.S6AProxyServer {
 
 	s6aProxy, err := servicers.NewS6aProxy(config)
 	assert.NoError(t, err)
-
+	if relay != nil {
+		s6aProxy.Relay = relay
+	}
 	return s6aProxy
 }
+
+type mockS6aRelay struct {
+	code protos.ErrorCode
+	idr  *servicers.IDR
+	dsr  *servicers.DSR
+}
+
+func (r *mockS6aRelay) RelayIDR(idr *servicers.IDR) (*protos.InsertSubscriberDataAnswer, error) {
+	r.idr = idr
+	return &protos.InsertSubscriberDataAnswer{ErrorCode: r.code}, nil
+}
+
+func (r *mockS6aRelay) RelayDSR(dsr *servicers.DSR) (*protos.DeleteSubscriberDataAnswer, error) {
+	r.dsr = dsr
+	return &protos.DeleteSubscriberDataAnswer{ErrorCode: r.code}, nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	s6b "magma/feg/gateway/services/s6b_proxy/servicers"
+	hss "magma/feg/gateway/services/testcore/hss/servicers"
+	lteprotos "magma/lte/cloud/go/protos"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestS6bAAR_Successful(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	s6bProxy := getTestS6bProxy(t, hss, &successfulMockRelay{})
+
+	aaa, err := s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "sub1", aaa.GetUserName())
+	assert.NotEmpty(t, aaa.GetSessionId())
+
+	apnConfig := aaa.GetApnConfig()
+	assert.NotNil(t, apnConfig)
+	assert.Equal(t, "magma.ipv4", apnConfig.GetServiceSelection())
+	assert.Equal(t, uint32(10), apnConfig.GetContextId())
+	assert.Equal(t, lteprotos.APNConfiguration_IPV6, apnConfig.GetPdn())
+	assert.Equal(t, int32(7), apnConfig.GetQosProfile().GetClassId())
+	assert.Equal(t, uint32(3), apnConfig.GetQosProfile().GetPriorityLevel())
+	assert.True(t, apnConfig.GetQosProfile().GetPreemptionCapability())
+	assert.True(t, apnConfig.GetQosProfile().GetPreemptionVulnerability())
+	assert.NotZero(t, apnConfig.GetAmbr().GetMaxBandwidthUl())
+}
+
+func TestS6bAAR_UnknownIMSI(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	s6bProxy := getTestS6bProxy(t, hss, &successfulMockRelay{})
+
+	aaa, err := s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub_unknown"))
+	assert.EqualError(t, err, "rpc error: code = Code(5001) desc = Diameter Error: 5001 (USER_UNKNOWN)")
+	assert.Nil(t, aaa.GetApnConfig())
+}
+
+func TestS6bAAR_AuthRejected(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	subscriber, err := hss.GetSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.NoError(t, err)
+	subscriber.Non_3Gpp.Non_3GppIpAccess = lteprotos.Non3GPPUserProfile_NON_3GPP_SUBSCRIPTION_BARRED
+	_, err = hss.UpdateSubscriber(context.Background(), subscriber)
+	assert.NoError(t, err)
+
+	s6bProxy := getTestS6bProxy(t, hss, &successfulMockRelay{})
+	_, err = s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.Equal(t, codes.Code(fegprotos.ErrorCode_AUTHORIZATION_REJECTED), status.Code(err))
+}
+
+func TestS6bAAR_UnknownAPN(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	subscriber, err := hss.GetSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.NoError(t, err)
+	subscriber.Non_3Gpp.ApnConfig[0].ServiceSelection = "magma.ipv6"
+	_, err = hss.UpdateSubscriber(context.Background(), subscriber)
+	assert.NoError(t, err)
+
+	s6bProxy := getTestS6bProxy(t, hss, &successfulMockRelay{})
+	_, err = s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.Equal(t, codes.Code(fegprotos.ErrorCode_AUTHORIZATION_REJECTED), status.Code(err))
+}
+
+func TestS6bSTR_Successful(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	s6bProxy := getTestS6bProxy(t, hss, &successfulMockRelay{})
+
+	aaa, err := s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.NoError(t, err)
+
+	str := &fegprotos.S6BSessionTerminationRequest{SessionId: aaa.GetSessionId()}
+	sta, err := s6bProxy.TerminateSession(context.Background(), str)
+	assert.NoError(t, err)
+	assert.Equal(t, aaa.GetSessionId(), sta.GetSessionId())
+
+	// The session is no longer known by the AAA server
+	_, err = s6bProxy.TerminateSession(context.Background(), str)
+	assert.Equal(t, codes.Code(fegprotos.ErrorCode_UNKNOWN_SESSION_ID), status.Code(err))
+}
+
+func TestS6bRAR_Successful(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	s6bProxy := getTestS6bProxy(t, hss, &successfulMockRelay{})
+
+	_, err := s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.NoError(t, err)
+
+	_, err = hss.ReAuthS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.NoError(t, err)
+
+	_, err = hss.ReAuthS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: "sub_unknown"})
+	assert.Error(t, err)
+}
+
+func TestS6bRAR_AuthorizationRevoked(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	relay := &recordingMockRelay{asrs: make(chan *diameter.ASR, 1)}
+	s6bProxy := getTestS6bProxy(t, hss, relay)
+
+	aaa, err := s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.NoError(t, err)
+
+	subscriber, err := hss.GetSubscriberData(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.NoError(t, err)
+	subscriber.Non_3Gpp.Non_3GppIpAccess = lteprotos.Non3GPPUserProfile_NON_3GPP_SUBSCRIPTION_BARRED
+	_, err = hss.UpdateSubscriber(context.Background(), subscriber)
+	assert.NoError(t, err)
+
+	// The RAR is answered & the rejected re-authorization aborts the session
+	_, err = hss.ReAuthS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.NoError(t, err)
+	select {
+	case asr := <-relay.asrs:
+		assert.Equal(t, aaa.GetSessionId(), asr.SessionID)
+		assert.Equal(t, "sub1", string(asr.UserName))
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Session was not aborted after the rejected re-authorization")
+	}
+}
+
+func TestS6bASR_Successful(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	relay := &recordingMockRelay{asrs: make(chan *diameter.ASR, 1)}
+	s6bProxy := getTestS6bProxy(t, hss, relay)
+
+	aaa, err := s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.NoError(t, err)
+
+	_, err = hss.AbortS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.NoError(t, err)
+	asr := <-relay.asrs
+	assert.Equal(t, aaa.GetSessionId(), asr.SessionID)
+	assert.Equal(t, "sub1", string(asr.UserName))
+}
+
+func TestS6bASR_Unsuccessful(t *testing.T) {
+	hss := getTestHSSDiameterServer(t)
+	s6bProxy := getTestS6bProxy(t, hss, &unsuccessfulMockRelay{})
+
+	_, err := s6bProxy.Authorize(context.Background(), getTestS6bAuthorizationRequest("sub1"))
+	assert.NoError(t, err)
+
+	_, err = hss.AbortS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: "sub1"})
+	assert.EqualError(t, err, "rpc error: code = Code(3002) desc = Diameter Error: 3002 (UNABLE_TO_DELIVER)")
+}
+
+func getTestS6bAuthorizationRequest(imsi string) *fegprotos.S6BAuthorizationRequest {
+	return &fegprotos.S6BAuthorizationRequest{
+		UserName: imsi,
+		Apn:      "magma.ipv4",
+		Pgw: &fegprotos.S6BPGWIdentity{
+			Host:      "pgw.magma.com",
+			Realm:     "magma.com",
+			IpAddress: "10.0.0.1",
+		},
+		RatType: s6b.RadioAccessTechnologyType_WLAN,
+	}
+}
+
+// getTestS6bProxy creates an S6b Proxy server which is configured to
+// communicate with the test HSS Diameter server.
+func getTestS6bProxy(t *testing.T, hss *hss.HomeSubscriberServer, relay s6b.Relay) fegprotos.S6BProxyServer {
+	serverCfg := hss.Config.Server
+	clientCfg := &diameter.DiameterClientConfig{
+		Host:             serverCfg.DestHost,
+		Realm:            serverCfg.DestRealm,
+		ProductName:      "magma",
+		Retransmits:      3,
+		WatchdogInterval: 10,
+		RetryCount:       3,
+	}
+	diameterServerCfg := &diameter.DiameterServerConfig{
+		DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+			Addr:      serverCfg.Address,
+			Protocol:  serverCfg.Protocol,
+			LocalAddr: serverCfg.LocalAddress},
+		DestHost:  serverCfg.DestHost,
+		DestRealm: serverCfg.DestRealm,
+	}
+	s6bProxy, err := s6b.NewS6bProxy(&s6b.S6bProxyConfig{ClientCfg: clientCfg, ServerCfg: diameterServerCfg})
+	assert.NoError(t, err)
+	s6bProxy.Relay = relay
+	return s6bProxy
+}
+
+type recordingMockRelay struct {
+	asrs chan *diameter.ASR
+}
+
+func (r *recordingMockRelay) RelayASR(asr *diameter.ASR) (fegprotos.ErrorCode, error) {
+	r.asrs <- asr
+	return fegprotos.ErrorCode_SUCCESS, nil
+}
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/testcore/hss/servicers"
 	"magma/feg/gateway/services/testcore/hss/servicers/test_utils"
 	"magma/lte/cloud/go/protos"
 	orcprotos "magma/orc8r/lib/go/protos"
@@ -113,6 +114,55 @@ func TestHomeSubscriberServer_DeleteSubscriber(t *testing.T) {
 	assert.EqualError(t, err, "rpc error: code = NotFound desc = Subscriber '1' not found")
 }
 
+func TestHomeSubscriberServer_ImportExportSubscribers(t *testing.T) {
+	server := test_utils.NewTestHomeSubscriberServer(t)
+	csv := []byte(`imsi,k,amf,sqn,apns
+001010000000011,465b5ce8b199b49faa5f0a2ee238a6bc,9001,64,internet
+001010000000012,465b5ce8b199b49faa5f0a2ee238a6bc,,,
+001010000000013,465b,,,
+`)
+
+	res, err := server.ImportSubscribers(context.Background(), &fegprotos.SubscriberImportRequest{Content: csv})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), res.GetAdded())
+	assert.Len(t, res.GetErrors(), 1)
+
+	sub, err := server.GetSubscriberData(context.Background(), &protos.SubscriberID{Id: "001010000000011"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), sub.GetState().GetLteAuthNextSeq())
+
+	// Existing subscribers are only updated on request
+	res, err = server.ImportSubscribers(context.Background(), &fegprotos.SubscriberImportRequest{Content: csv})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), res.GetAdded()+res.GetUpdated())
+	res, err = server.ImportSubscribers(context.Background(), &fegprotos.SubscriberImportRequest{Content: csv, Overwrite: true})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), res.GetUpdated())
+
+	_, err = server.ImportSubscribers(context.Background(), &fegprotos.SubscriberImportRequest{
+		Format:  fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_JSON,
+		Content: csv,
+	})
+	assert.Error(t, err)
+
+	export, err := server.ExportSubscribers(context.Background(), &fegprotos.SubscriberExportRequest{
+		Format: fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_JSON,
+	})
+	assert.NoError(t, err)
+	records, err := servicers.ParseSubscriberRecords(fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_JSON, export.GetContent())
+	assert.NoError(t, err)
+	assert.Len(t, records, int(export.GetCount()))
+	exported := map[string]*servicers.SubscriberRecord{}
+	for _, record := range records {
+		exported[record.IMSI] = record
+	}
+	assert.Equal(t, uint64(64), *exported["001010000000011"].SQN)
+	assert.Equal(t, "9001", exported["001010000000011"].AMF)
+	assert.Contains(t, exported, "001010000000012")
+	assert.Empty(t, exported["001010000000012"].AMF)
+	assert.NotContains(t, exported, "001010000000013")
+}
+
 func getConnToTestHomeSubscriberServer(t *testing.T) *grpc.ClientConn {
 	srv := test_utils.NewTestHomeSubscriberServer(t)
 
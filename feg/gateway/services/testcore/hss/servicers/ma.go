@@ -43,7 +43,7 @@ func NewMAA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error)
 		return msg.Answer(diam.UnableToComply), fmt.Errorf("MAR Unmarshal failed for message: %v failed: %v", msg, err)
 	}
 
-	subscriber, err := srv.store.GetSubscriberData(mar.UserName)
+	amf, err := srv.store.GetAuthAmf(mar.UserName)
 	if err != nil {
 		if _, ok := err.(storage.UnknownSubscriberError); ok {
 			return ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_USER_UNKNOWN)), err
@@ -51,49 +51,74 @@ func NewMAA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error)
 		return ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(diam.UnableToComply)), err
 	}
 
-	subscriber.Lock()
-	defer subscriber.Unlock()
-
 	if !isRATTypeAllowed(uint32(mar.RATType)) {
 		answer := ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_RAT_NOT_ALLOWED))
 		return answer, fmt.Errorf("RAT-Type not allowed: %v", uint32(mar.RATType))
 	}
 
-	aaaServer := datatype.DiameterIdentity(subscriber.GetState().GetTgppAaaServerName())
-	if len(aaaServer) == 0 {
-		err = srv.set3GPPAAAServerName(subscriber, mar.OriginHost)
-		if err != nil {
-			return ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(diam.UnableToComply)), err
-		}
-	} else if aaaServer != mar.OriginHost {
-		err = errors.New("diameter identity for AAA server already registered")
-		return getRedirectMessage(msg, mar.SessionID, srv.Config.Server, aaaServer), err
-	}
-
-	lteAuthNextSeq, err := ResyncLteAuthSeq(subscriber, mar.AuthData.Authorization.Serialize(), srv.Config.LteAuthOp)
-	if err == nil {
-		err = srv.setLteAuthNextSeq(subscriber, lteAuthNextSeq)
-	}
+	mcipher, err := GetMilenageCipher(srv.Milenage, amf)
 	if err != nil {
 		return ConvertAuthErrorToFailureMessage(err, msg, mar.SessionID, srv.Config.Server), err
 	}
 
-	if mar.AuthData.AuthScheme != swx.SipAuthScheme_EAP_AKA {
-		err = fmt.Errorf("Unsupported SIP authentication scheme: %s", mar.AuthData.AuthScheme)
-		return ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(diam.UnableToComply)), err
-	}
+	// The SEQ is read, used & incremented within a single store modification so
+	// that concurrent requests never generate vectors with the same SQN.
+	// Failures after the AAA server is registered are answered without
+	// discarding the modifications already made.
+	var (
+		vectors   []*milenage.SIPAuthVector
+		answer    *diam.Message
+		answerErr error
+	)
+	err = srv.store.ModifySubscriber(mar.UserName, func(subscriber *lteprotos.SubscriberData) error {
+		aaaServer := datatype.DiameterIdentity(subscriber.GetState().GetTgppAaaServerName())
+		if len(aaaServer) == 0 {
+			set3GPPAAAServerName(subscriber, mar.OriginHost)
+		} else if aaaServer != mar.OriginHost {
+			answerErr = errors.New("diameter identity for AAA server already registered")
+			answer = getRedirectMessage(msg, mar.SessionID, srv.Config.Server, aaaServer)
+			return answerErr
+		}
+
+		lteAuthNextSeq, err := ResyncLteAuthSeq(subscriber, mar.AuthData.Authorization.Serialize(), srv.Config.LteAuthOp)
+		if err == nil {
+			err = setLteAuthNextSeq(subscriber, lteAuthNextSeq)
+		}
+		if err != nil {
+			answerErr = err
+			answer = ConvertAuthErrorToFailureMessage(err, msg, mar.SessionID, srv.Config.Server)
+			return nil
+		}
+
+		if mar.AuthData.AuthScheme != swx.SipAuthScheme_EAP_AKA {
+			answerErr = fmt.Errorf("Unsupported SIP authentication scheme: %s", mar.AuthData.AuthScheme)
+			answer = ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(diam.UnableToComply))
+			return nil
+		}
 
-	vectors, lteAuthNextSeq, err := srv.GenerateSIPAuthVectors(subscriber, mar.NumberAuthItems)
-	if err == nil {
-		err = srv.setLteAuthNextSeq(subscriber, lteAuthNextSeq)
+		vectors, lteAuthNextSeq, err = srv.GenerateSIPAuthVectors(mcipher, subscriber, mar.NumberAuthItems)
+		if err == nil {
+			err = setLteAuthNextSeq(subscriber, lteAuthNextSeq)
+		}
+		if err != nil {
+			// If we generated any auth vectors successfully, then we can return them.
+			// Otherwise, we must signal an error.
+			// See 3GPP TS 29.273 section 8.1.2.1.2.
+			if len(vectors) == 0 {
+				answerErr = err
+				answer = ConvertAuthErrorToFailureMessage(err, msg, mar.SessionID, srv.Config.Server)
+			}
+		}
+		return nil
+	})
+	if answer != nil {
+		return answer, answerErr
 	}
 	if err != nil {
-		// If we generated any auth vectors successfully, then we can return them.
-		// Otherwise, we must signal an error.
-		// See 3GPP TS 29.273 section 8.1.2.1.2.
-		if len(vectors) == 0 {
-			return ConvertAuthErrorToFailureMessage(err, msg, mar.SessionID, srv.Config.Server), err
+		if _, ok := err.(storage.UnknownSubscriberError); ok {
+			return ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_USER_UNKNOWN)), err
 		}
+		return ConstructFailureAnswer(msg, mar.SessionID, srv.Config.Server, uint32(diam.UnableToComply)), err
 	}
 
 	return srv.NewSuccessfulMAA(msg, mar.SessionID, datatype.UTF8String(mar.UserName), vectors), nil
@@ -124,11 +149,11 @@ func (srv *HomeSubscriberServer) NewSuccessfulMAA(msg *diam.Message, sessionID d
 
 // GenerateSIPAuthVectors generates `numVectors` SIP auth vectors for the subscriber.
 // The vectors and the next value of lteAuthNextSeq are returned (or an error).
-func (srv *HomeSubscriberServer) GenerateSIPAuthVectors(subscriber *lteprotos.SubscriberData, numVectors uint32) ([]*milenage.SIPAuthVector, uint64, error) {
+func (srv *HomeSubscriberServer) GenerateSIPAuthVectors(mcipher *milenage.Cipher, subscriber *lteprotos.SubscriberData, numVectors uint32) ([]*milenage.SIPAuthVector, uint64, error) {
 	var vectors = make([]*milenage.SIPAuthVector, 0, numVectors)
 	lteAuthNextSeq := subscriber.GetState().GetLteAuthNextSeq()
 	for i := uint32(0); i < numVectors; i++ {
-		vector, nextSeq, err := srv.GenerateSIPAuthVector(subscriber)
+		vector, nextSeq, err := srv.GenerateSIPAuthVector(mcipher, subscriber)
 		if err != nil {
 			if i == 0 {
 				return nil, 0, err
@@ -144,7 +169,7 @@ func (srv *HomeSubscriberServer) GenerateSIPAuthVectors(subscriber *lteprotos.Su
 }
 
 // GenerateSIPAuthVector returns the SIP auth vector and the next value of lteAuthNextSeq for the subscriber (or an error).
-func (srv *HomeSubscriberServer) GenerateSIPAuthVector(subscriber *lteprotos.SubscriberData) (*milenage.SIPAuthVector, uint64, error) {
+func (srv *HomeSubscriberServer) GenerateSIPAuthVector(mcipher *milenage.Cipher, subscriber *lteprotos.SubscriberData) (*milenage.SIPAuthVector, uint64, error) {
 	lte := subscriber.Lte
 	if err := ValidateLteSubscription(lte); err != nil {
 		return nil, 0, NewAuthRejectedError(err.Error())
@@ -159,7 +184,7 @@ func (srv *HomeSubscriberServer) GenerateSIPAuthVector(subscriber *lteprotos.Sub
 	}
 
 	sqn := SeqToSqn(subscriber.State.LteAuthNextSeq, srv.AuthSqnInd)
-	vector, err := srv.Milenage.GenerateSIPAuthVector(lte.AuthKey, opc, sqn)
+	vector, err := mcipher.GenerateSIPAuthVector(lte.AuthKey, opc, sqn)
 	if err != nil {
 		return nil, 0, NewAuthRejectedError(err.Error())
 	}
@@ -196,11 +221,10 @@ func ValidateMAR(msg *diam.Message) error {
 }
 
 // set3GPPAAAServerName sets the 3GPP AAA Server stored inside of a SubscriberData proto.
-func (srv *HomeSubscriberServer) set3GPPAAAServerName(subscriber *lteprotos.SubscriberData, serverName datatype.DiameterIdentity) error {
+func set3GPPAAAServerName(subscriber *lteprotos.SubscriberData, serverName datatype.DiameterIdentity) {
 	if subscriber.State == nil {
 		subscriber.State = &lteprotos.SubscriberState{}
 	}
 	subscriber.State.TgppAaaServerName = string(serverName)
 	subscriber.State.TgppAaaServerRegistered = false
-	return srv.store.UpdateSubscriber(subscriber)
 }
@@ -0,0 +1,70 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"errors"
+	"fmt"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/s6a_proxy/servicers"
+	"magma/feg/gateway/services/testcore/hss/storage"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+)
+
+// NewNOA outputs a notify answer (NOA) to reply to a notify request (NOR) message.
+// See 3GPP TS 29.272 section 5.2.5.1.
+func NewNOA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error) {
+	err := ValidateNOR(msg)
+	if err != nil {
+		return msg.Answer(diam.MissingAVP), err
+	}
+
+	var nor servicers.NOR
+	if err := msg.Unmarshal(&nor); err != nil {
+		return msg.Answer(diam.UnableToComply), fmt.Errorf("NOR Unmarshal failed for message: %v failed: %v", msg, err)
+	}
+	sessionID := datatype.UTF8String(nor.SessionID)
+
+	_, err = srv.store.GetSubscriberData(nor.UserName)
+	if err != nil {
+		if _, ok := err.(storage.UnknownSubscriberError); ok {
+			return ConstructFailureAnswer(msg, sessionID, srv.Config.Server, uint32(protos.ErrorCode_USER_UNKNOWN)), err
+		}
+		return ConstructFailureAnswer(msg, sessionID, srv.Config.Server, uint32(diam.UnableToComply)), err
+	}
+	glog.V(2).Infof(
+		"Notify for subscriber %s: NOR-Flags: %d, APN: '%s' (context %d), Alert-Reason: %d",
+		nor.UserName, nor.NORFlags, nor.ServiceSelection, nor.ContextIdentifier, nor.AlertReason)
+
+	return ConstructSuccessAnswer(msg, sessionID, srv.Config.Server, diam.TGPP_S6A_APP_ID), nil
+}
+
+// ValidateNOR returns an error if the message is missing any mandatory AVPs.
+// Mandatory AVPs are specified in 3GPP TS 29.272 Table 5.2.5.1.1/1
+func ValidateNOR(msg *diam.Message) error {
+	_, err := msg.FindAVP(avp.UserName, 0)
+	if err != nil {
+		return errors.New("Missing IMSI in message")
+	}
+	_, err = msg.FindAVP(avp.SessionID, 0)
+	if err != nil {
+		return errors.New("Missing SessionID in message")
+	}
+	return nil
+}
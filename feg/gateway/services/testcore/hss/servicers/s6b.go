@@ -0,0 +1,313 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	s6b "magma/feg/gateway/services/s6b_proxy/servicers"
+	"magma/feg/gateway/services/testcore/hss/storage"
+	lteprotos "magma/lte/cloud/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// wildcardAPN is the Service-Selection of the APN configuration applying to any APN (3GPP TS 23.003 9.2)
+const wildcardAPN = "*"
+
+// s6bSession is a PGW session authorized over S6b
+type s6bSession struct {
+	imsi string
+	apn  string
+	// pgw is the Diameter Identity of the S6b peer which authorized the session
+	pgw string
+}
+
+// NewS6bAAA outputs an AA-Answer (AAA) to reply to an S6b AA-Request (AAR) authorizing
+// a PGW session. See 3GPP TS 29.273 section 9.2.2.2.
+func NewS6bAAA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error) {
+	var aar s6b.AAR
+	if err := msg.Unmarshal(&aar); err != nil {
+		return msg.Answer(diam.UnableToComply), fmt.Errorf("AAR Unmarshal failed for message: %v failed: %v", msg, err)
+	}
+	if len(aar.UserName) == 0 {
+		return msg.Answer(diam.MissingAVP), errors.New("Missing IMSI in message")
+	}
+	if len(aar.ServiceSelection) == 0 {
+		return msg.Answer(diam.MissingAVP), errors.New("Missing service selection in message")
+	}
+
+	subscriber, err := srv.store.GetSubscriberData(string(aar.UserName))
+	if err != nil {
+		if _, ok := err.(storage.UnknownSubscriberError); ok {
+			return ConstructFailureAnswer(msg, aar.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_USER_UNKNOWN)), err
+		}
+		return ConstructFailureAnswer(msg, aar.SessionID, srv.Config.Server, uint32(diam.UnableToComply)), err
+	}
+	profile := subscriber.GetNon_3Gpp()
+	if profile.GetNon_3GppIpAccess() != lteprotos.Non3GPPUserProfile_NON_3GPP_SUBSCRIPTION_ALLOWED ||
+		profile.GetNon_3GppIpAccessApn() != lteprotos.Non3GPPUserProfile_NON_3GPP_APNS_ENABLE {
+		err = fmt.Errorf("User %s is not allowed to use non-3GPP IP access", aar.UserName)
+		return ConstructFailureAnswer(msg, aar.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_AUTHORIZATION_REJECTED)), err
+	}
+	apnConfig := findAPNConfiguration(profile, string(aar.ServiceSelection))
+	if apnConfig == nil {
+		err = fmt.Errorf("APN %s is not authorized for user %s", aar.ServiceSelection, aar.UserName)
+		return ConstructFailureAnswer(msg, aar.SessionID, srv.Config.Server, uint32(fegprotos.ErrorCode_AUTHORIZATION_REJECTED)), err
+	}
+
+	srv.setS6bSession(string(aar.SessionID), &s6bSession{
+		imsi: string(aar.UserName),
+		apn:  apnConfig.GetServiceSelection(),
+		pgw:  string(aar.OriginHost),
+	})
+
+	answer := msg.Answer(diam.Success)
+	AddStandardAnswerAVPS(answer, aar.SessionID, srv.Config.Server, diam.Success)
+	answer.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(s6b.S6bAppID))
+	answer.NewAVP(avp.AuthRequestType, avp.Mbit, 0, aar.AuthRequestType)
+	answer.NewAVP(avp.UserName, avp.Mbit, 0, aar.UserName)
+	answer.AddAVP(getS6bAPNConfigurationAVP(apnConfig))
+	return answer, nil
+}
+
+// NewS6bSTA outputs a Session-Termination-Answer (STA) to reply to an S6b
+// Session-Termination-Request (STR). See 3GPP TS 29.273 section 9.2.2.3.
+func NewS6bSTA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error) {
+	var str s6b.STR
+	if err := msg.Unmarshal(&str); err != nil {
+		return msg.Answer(diam.UnableToComply), fmt.Errorf("STR Unmarshal failed for message: %v failed: %v", msg, err)
+	}
+	answer := msg.Answer(diam.Success)
+	if !srv.removeS6bSession(string(str.SessionID)) {
+		answer = msg.Answer(diam.UnknownSessionID)
+	}
+	answer.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, str.SessionID))
+	answer.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(srv.Config.Server.DestHost))
+	answer.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(srv.Config.Server.DestRealm))
+	answer.NewAVP(avp.OriginStateID, avp.Mbit, 0, datatype.Unsigned32(time.Now().Unix()))
+	return answer, nil
+}
+
+// SendS6bReAuth sends a Re-Auth-Request (RAR) for each PGW session of the subscriber
+// and waits for the answers. See 3GPP TS 29.273 section 9.2.2.4.
+func (srv *HomeSubscriberServer) SendS6bReAuth(imsi string) error {
+	for sid, session := range srv.getS6bSessions(imsi) {
+		msg := srv.newS6bRequest(diam.ReAuth, sid, session)
+		msg.NewAVP(avp.ReAuthRequestType, avp.Mbit, 0, datatype.Enumerated(s6b.ReAuthRequestType_AUTHORIZE_ONLY))
+		resp, err := srv.sendS6bRequest(msg, sid, session)
+		if err != nil {
+			return err
+		}
+		raa, ok := resp.(*s6b.RAA)
+		if !ok {
+			return status.Errorf(codes.Internal, "Invalid Response Type: %T, RAA expected.", resp)
+		}
+		if err = diameter.TranslateDiamResultCode(raa.ResultCode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SendS6bAbortSession sends an Abort-Session-Request (ASR) for each PGW session of the subscriber
+// and waits for the answers. See 3GPP TS 29.273 section 9.2.2.5.
+func (srv *HomeSubscriberServer) SendS6bAbortSession(imsi string) error {
+	for sid, session := range srv.getS6bSessions(imsi) {
+		msg := srv.newS6bRequest(diam.AbortSession, sid, session)
+		resp, err := srv.sendS6bRequest(msg, sid, session)
+		if err != nil {
+			return err
+		}
+		asa, ok := resp.(*diameter.ASA)
+		if !ok {
+			return status.Errorf(codes.Internal, "Invalid Response Type: %T, ASA expected.", resp)
+		}
+		if err = diameter.TranslateDiamResultCode(asa.ResultCode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newS6bRequest creates a HSS initiated S6b request with the mandatory AVPs
+func (srv *HomeSubscriberServer) newS6bRequest(cmd uint32, sessionID string, session *s6bSession) *diam.Message {
+	msg := diameter.NewProxiableRequest(cmd, s6b.S6bAppID, dict.Default)
+	msg.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	msg.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(s6b.S6bAppID))
+	// Set origin host and realm to server's host and realm since the request is sent from HSS
+	msg.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(srv.Config.Server.DestHost))
+	msg.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(srv.Config.Server.DestRealm))
+	msg.NewAVP(avp.UserName, avp.Mbit, 0, datatype.UTF8String(session.imsi))
+	return msg
+}
+
+// sendS6bRequest sends the request to the PGW of the session & waits (blocks) for the answer
+func (srv *HomeSubscriberServer) sendS6bRequest(msg *diam.Message, sid string, session *s6bSession) (interface{}, error) {
+	pgwCfg, err := srv.genPeerConfig(session.pgw)
+	if err != nil {
+		return nil, fmt.Errorf("S6b request error: %s", err)
+	}
+	ch := make(chan interface{})
+	srv.requestTracker.RegisterRequest(sid, ch)
+	// if request hasn't been removed by end of transaction, remove it
+	defer srv.requestTracker.DeregisterRequest(sid)
+
+	glog.V(2).Infof("Sending S6b request to %s: %s", session.pgw, msg)
+	err = srv.sendDiameterMsg(msg, pgwCfg, maxDiamRetries)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case resp, open := <-ch:
+		if !open {
+			err = status.Errorf(codes.Aborted, "S6b request for Session ID: %s is cancelled", sid)
+			glog.Error(err)
+			return nil, err
+		}
+		return resp, nil
+	case <-time.After(time.Second * timeoutSeconds):
+		err = status.Errorf(codes.DeadlineExceeded, "S6b request Timed Out for Session ID: %s", sid)
+		glog.Error(err)
+		return nil, err
+	}
+}
+
+func handleS6bRAA(srv *HomeSubscriberServer) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		var raa s6b.RAA
+		err := m.Unmarshal(&raa)
+		if err != nil {
+			glog.Errorf("RAA Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		ch := srv.requestTracker.DeregisterRequest(raa.SessionID)
+		if ch != nil {
+			ch <- &raa
+		} else {
+			glog.Errorf("RAA SessionID %s not found. Message: %s, Remote: %s", raa.SessionID, m, c.RemoteAddr())
+		}
+	}
+}
+
+func handleS6bASA(srv *HomeSubscriberServer) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		var asa diameter.ASA
+		err := m.Unmarshal(&asa)
+		if err != nil {
+			glog.Errorf("ASA Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		ch := srv.requestTracker.DeregisterRequest(asa.SessionID)
+		if ch != nil {
+			ch <- &asa
+		} else {
+			glog.Errorf("ASA SessionID %s not found. Message: %s, Remote: %s", asa.SessionID, m, c.RemoteAddr())
+		}
+	}
+}
+
+// findAPNConfiguration returns the subscriber's configuration of the APN or nil if
+// the APN is not configured. The wildcard APN configuration is used if there is no
+// configuration for the APN itself
+func findAPNConfiguration(profile *lteprotos.Non3GPPUserProfile, apn string) *lteprotos.APNConfiguration {
+	var wildcard *lteprotos.APNConfiguration
+	for _, apnConfig := range profile.GetApnConfig() {
+		switch apnConfig.GetServiceSelection() {
+		case apn:
+			return apnConfig
+		case wildcardAPN:
+			wildcard = apnConfig
+		}
+	}
+	if wildcard == nil {
+		return nil
+	}
+	apnConfig := proto.Clone(wildcard).(*lteprotos.APNConfiguration)
+	apnConfig.ServiceSelection = apn
+	return apnConfig
+}
+
+// getS6bAPNConfigurationAVP converts an APNConfiguration proto to the APN-Configuration AVP
+// authorized for a PGW session
+func getS6bAPNConfigurationAVP(apnConfig *lteprotos.APNConfiguration) *diam.AVP {
+	qosProfile := apnConfig.GetQosProfile()
+	avps := []*diam.AVP{
+		diam.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(apnConfig.GetContextId())),
+		diam.NewAVP(avp.PDNType, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(apnConfig.GetPdn())),
+		diam.NewAVP(avp.ServiceSelection, avp.Mbit, 0, datatype.UTF8String(apnConfig.GetServiceSelection())),
+		diam.NewAVP(avp.EPSSubscribedQoSProfile, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.QoSClassIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(qosProfile.GetClassId())),
+				diam.NewAVP(avp.AllocationRetentionPriority, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
+					AVP: []*diam.AVP{
+						diam.NewAVP(avp.PriorityLevel, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(qosProfile.GetPriorityLevel())),
+						// 3GPP TS 29.272 7.3.46/7.3.47: 0 enables pre-emption capability/vulnerability
+						diam.NewAVP(avp.PreemptionCapability, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(BoolToInt(!qosProfile.GetPreemptionCapability()))),
+						diam.NewAVP(avp.PreemptionVulnerability, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Enumerated(BoolToInt(!qosProfile.GetPreemptionVulnerability()))),
+					},
+				}),
+			},
+		}),
+		diam.NewAVP(avp.AMBR, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.MaxRequestedBandwidthUL, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(apnConfig.GetAmbr().GetMaxBandwidthUl())),
+				diam.NewAVP(avp.MaxRequestedBandwidthDL, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(apnConfig.GetAmbr().GetMaxBandwidthDl())),
+			},
+		}),
+	}
+	if ip := net.ParseIP(apnConfig.GetAssignedStaticIp()); ip != nil {
+		avps = append(avps, diam.NewAVP(avp.ServedPartyIPAddress, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Address(ip)))
+	}
+	return diam.NewAVP(avp.APNConfiguration, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{AVP: avps})
+}
+
+func (srv *HomeSubscriberServer) setS6bSession(sid string, session *s6bSession) {
+	srv.s6bMu.Lock()
+	srv.s6bSessions[sid] = session
+	srv.s6bMu.Unlock()
+}
+
+func (srv *HomeSubscriberServer) removeS6bSession(sid string) bool {
+	srv.s6bMu.Lock()
+	defer srv.s6bMu.Unlock()
+	_, ok := srv.s6bSessions[sid]
+	delete(srv.s6bSessions, sid)
+	return ok
+}
+
+// getS6bSessions returns the PGW sessions of the subscriber by S6b session ID
+func (srv *HomeSubscriberServer) getS6bSessions(imsi string) map[string]*s6bSession {
+	srv.s6bMu.RLock()
+	defer srv.s6bMu.RUnlock()
+	sessions := map[string]*s6bSession{}
+	for sid, session := range srv.s6bSessions {
+		if session.imsi == imsi {
+			sessions[sid] = session
+		}
+	}
+	return sessions
+}
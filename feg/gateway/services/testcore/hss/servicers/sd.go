@@ -0,0 +1,181 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"fmt"
+	"time"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/cloud/go/protos/mconfig"
+	"magma/feg/gateway/diameter"
+	s6a "magma/feg/gateway/services/s6a_proxy/servicers"
+	"magma/lte/cloud/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SendInsertSubscriberData sends an Insert Subscriber Data Request (IDR) with the subscriber's
+// profile to the MME serving the subscriber and waits for the answer.
+func (srv *HomeSubscriberServer) SendInsertSubscriberData(sub *protos.SubscriberData) error {
+	imsi := sub.GetSid().GetId()
+	profile := srv.getSubscriptionProfile(sub)
+	if profile == nil {
+		return status.Errorf(codes.FailedPrecondition, "No subscription profile found for subscriber: %s", imsi)
+	}
+	sid := (&diameter.DiameterClientConfig{}).GenSessionID("s6a")
+	msg := srv.newS6aRequest(s6a.InsertSubscriberData, sid, imsi)
+	msg.AddAVP(newSubscriptionDataAVP(profile))
+
+	resp, err := srv.sendS6aRequest(msg, sid, imsi)
+	if err != nil {
+		return err
+	}
+	ida, ok := resp.(*s6a.IDA)
+	if !ok {
+		return status.Errorf(codes.Internal, "Invalid Response Type: %T, IDA expected.", resp)
+	}
+	if err = diameter.TranslateDiamResultCode(ida.ResultCode); err != nil {
+		return err
+	}
+	return diameter.TranslateDiamResultCode(ida.ExperimentalResult.ExperimentalResultCode)
+}
+
+// SendDeleteSubscriberData sends a Delete Subscriber Data Request (DSR) to the MME
+// serving the subscriber and waits for the answer.
+func (srv *HomeSubscriberServer) SendDeleteSubscriberData(req *fegprotos.DeleteSubscriberDataRequest) error {
+	imsi := req.GetUserName()
+	sid := (&diameter.DiameterClientConfig{}).GenSessionID("s6a")
+	msg := srv.newS6aRequest(s6a.DeleteSubscriberData, sid, imsi)
+	msg.NewAVP(s6a.DSRFlags, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(req.GetDsrFlags()))
+	for _, contextID := range req.GetContextIdentifiers() {
+		msg.NewAVP(avp.ContextIdentifier, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(contextID))
+	}
+
+	resp, err := srv.sendS6aRequest(msg, sid, imsi)
+	if err != nil {
+		return err
+	}
+	dsa, ok := resp.(*s6a.DSA)
+	if !ok {
+		return status.Errorf(codes.Internal, "Invalid Response Type: %T, DSA expected.", resp)
+	}
+	if err = diameter.TranslateDiamResultCode(dsa.ResultCode); err != nil {
+		return err
+	}
+	return diameter.TranslateDiamResultCode(dsa.ExperimentalResult.ExperimentalResultCode)
+}
+
+// getSubscriptionProfile returns the subscriber's profile or the default profile
+// if the subscriber's one is not configured
+func (srv *HomeSubscriberServer) getSubscriptionProfile(sub *protos.SubscriberData) *mconfig.HSSConfig_SubscriptionProfile {
+	profile, ok := srv.Config.SubProfiles[sub.GetSubProfile()]
+	if !ok || profile == nil {
+		return srv.Config.DefaultSubProfile
+	}
+	return profile
+}
+
+// newS6aRequest creates a HSS initiated S6a request with the mandatory AVPs
+func (srv *HomeSubscriberServer) newS6aRequest(cmd uint32, sessionID, imsi string) *diam.Message {
+	msg := diameter.NewProxiableRequest(cmd, diam.TGPP_S6A_APP_ID, dict.Default)
+	msg.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	msg.NewAVP(avp.VendorSpecificApplicationID, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.TGPP_S6A_APP_ID)),
+			diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(diameter.Vendor3GPP)),
+		},
+	})
+	msg.NewAVP(avp.AuthSessionState, avp.Mbit, 0, datatype.Enumerated(1))
+	// Set origin host and realm to server's host and realm since the request is sent from HSS
+	msg.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(srv.Config.Server.DestHost))
+	msg.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(srv.Config.Server.DestRealm))
+	msg.NewAVP(avp.UserName, avp.Mbit, 0, datatype.UTF8String(imsi))
+	return msg
+}
+
+// sendS6aRequest sends the request to the MME serving the subscriber & waits (blocks) for the answer
+func (srv *HomeSubscriberServer) sendS6aRequest(msg *diam.Message, sid, imsi string) (interface{}, error) {
+	mme, ok := srv.getServingMME(imsi)
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "No MME found for subscriber: %s", imsi)
+	}
+	mmeCfg, err := srv.genPeerConfig(mme)
+	if err != nil {
+		return nil, fmt.Errorf("S6a request error: %s", err)
+	}
+	ch := make(chan interface{})
+	srv.requestTracker.RegisterRequest(sid, ch)
+	// if request hasn't been removed by end of transaction, remove it
+	defer srv.requestTracker.DeregisterRequest(sid)
+
+	glog.V(2).Infof("Sending S6a request to %s: %s", mme, msg)
+	err = srv.sendDiameterMsg(msg, mmeCfg, maxDiamRetries)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case resp, open := <-ch:
+		if !open {
+			err = status.Errorf(codes.Aborted, "S6a request for Session ID: %s is cancelled", sid)
+			glog.Error(err)
+			return nil, err
+		}
+		return resp, nil
+	case <-time.After(time.Second * timeoutSeconds):
+		err = status.Errorf(codes.DeadlineExceeded, "S6a request Timed Out for Session ID: %s", sid)
+		glog.Error(err)
+		return nil, err
+	}
+}
+
+func handleIDA(srv *HomeSubscriberServer) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		var ida s6a.IDA
+		err := m.Unmarshal(&ida)
+		if err != nil {
+			glog.Errorf("IDA Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		ch := srv.requestTracker.DeregisterRequest(ida.SessionID)
+		if ch != nil {
+			ch <- &ida
+		} else {
+			glog.Errorf("IDA SessionID %s not found. Message: %s, Remote: %s", ida.SessionID, m, c.RemoteAddr())
+		}
+	}
+}
+
+func handleDSA(srv *HomeSubscriberServer) diam.HandlerFunc {
+	return func(c diam.Conn, m *diam.Message) {
+		var dsa s6a.DSA
+		err := m.Unmarshal(&dsa)
+		if err != nil {
+			glog.Errorf("DSA Unmarshal failed for remote %s & message %s: %s", c.RemoteAddr(), m, err)
+			return
+		}
+		ch := srv.requestTracker.DeregisterRequest(dsa.SessionID)
+		if ch != nil {
+			ch <- &dsa
+		} else {
+			glog.Errorf("DSA SessionID %s not found. Message: %s, Remote: %s", dsa.SessionID, m, c.RemoteAddr())
+		}
+	}
+}
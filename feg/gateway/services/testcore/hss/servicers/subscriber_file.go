@@ -0,0 +1,266 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	lteprotos "magma/lte/cloud/go/protos"
+
+	"github.com/emakeev/milenage"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	imsiColumn = "imsi"
+	kColumn    = "k"
+	opcColumn  = "opc"
+	amfColumn  = "amf"
+	sqnColumn  = "sqn"
+	apnsColumn = "apns"
+
+	apnSeparator = ";"
+)
+
+var subscriberFileColumns = []string{imsiColumn, kColumn, opcColumn, amfColumn, sqnColumn, apnsColumn}
+
+// SubscriberRecord is a subscriber entry of an import/export file. Keys are
+// hex encoded & the SQN is the 48 bit sequence number (SEQ || IND) the next
+// auth vector is generated with. Optional fields left empty keep the current
+// value of an existing subscriber.
+type SubscriberRecord struct {
+	IMSI string   `json:"imsi"`
+	K    string   `json:"k"`
+	OPc  string   `json:"opc,omitempty"`
+	AMF  string   `json:"amf,omitempty"`
+	SQN  *uint64  `json:"sqn,omitempty"`
+	APNs []string `json:"apns,omitempty"`
+}
+
+// ParseSubscriberRecords decodes the records of a CSV or JSON subscriber file.
+func ParseSubscriberRecords(format fegprotos.SubscriberFileFormat, content []byte) ([]*SubscriberRecord, error) {
+	switch format {
+	case fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_JSON:
+		var records []*SubscriberRecord
+		if err := json.Unmarshal(content, &records); err != nil {
+			return nil, fmt.Errorf("Invalid JSON subscriber file: %v", err)
+		}
+		return records, nil
+	case fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_CSV:
+		return parseCSVSubscriberRecords(content)
+	default:
+		return nil, fmt.Errorf("Unsupported subscriber file format: %v", format)
+	}
+}
+
+// FormatSubscriberRecords encodes records to a CSV or JSON subscriber file.
+func FormatSubscriberRecords(format fegprotos.SubscriberFileFormat, records []*SubscriberRecord) ([]byte, error) {
+	switch format {
+	case fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_JSON:
+		return json.MarshalIndent(records, "", "  ")
+	case fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_CSV:
+		buf := &bytes.Buffer{}
+		writer := csv.NewWriter(buf)
+		if err := writer.Write(subscriberFileColumns); err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			sqn := ""
+			if record.SQN != nil {
+				sqn = strconv.FormatUint(*record.SQN, 10)
+			}
+			row := []string{record.IMSI, record.K, record.OPc, record.AMF, sqn, strings.Join(record.APNs, apnSeparator)}
+			if err := writer.Write(row); err != nil {
+				return nil, err
+			}
+		}
+		writer.Flush()
+		return buf.Bytes(), writer.Error()
+	default:
+		return nil, fmt.Errorf("Unsupported subscriber file format: %v", format)
+	}
+}
+
+func parseCSVSubscriberRecords(content []byte) ([]*SubscriberRecord, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CSV subscriber file header: %v", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{imsiColumn, kColumn} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV subscriber file is missing the '%s' column", required)
+		}
+	}
+	get := func(row []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var records []*SubscriberRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Invalid CSV subscriber file: %v", err)
+		}
+		record := &SubscriberRecord{
+			IMSI: get(row, imsiColumn),
+			K:    get(row, kColumn),
+			OPc:  get(row, opcColumn),
+			AMF:  get(row, amfColumn),
+		}
+		if sqn := get(row, sqnColumn); len(sqn) > 0 {
+			value, err := strconv.ParseUint(sqn, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid SQN '%s' for IMSI %s: %v", sqn, record.IMSI, err)
+			}
+			record.SQN = &value
+		}
+		if apns := get(row, apnsColumn); len(apns) > 0 {
+			for _, apn := range strings.Split(apns, apnSeparator) {
+				if apn = strings.TrimSpace(apn); len(apn) > 0 {
+					record.APNs = append(record.APNs, apn)
+				}
+			}
+		}
+		records = append(records, record)
+	}
+}
+
+// ToSubscriberData applies the record on top of the existing subscriber data,
+// or on a new subscriber if existing is nil.
+func (record *SubscriberRecord) ToSubscriberData(existing *lteprotos.SubscriberData) (*lteprotos.SubscriberData, error) {
+	if err := validateIMSI(record.IMSI); err != nil {
+		return nil, err
+	}
+	k, err := decodeHexField("K", record.K, milenage.ExpectedKeyBytes, true)
+	if err != nil {
+		return nil, err
+	}
+	opc, err := decodeHexField("OPc", record.OPc, milenage.ExpectedOpcBytes, false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = record.AuthAmf(); err != nil {
+		return nil, err
+	}
+
+	var subscriber *lteprotos.SubscriberData
+	if existing != nil {
+		subscriber = proto.Clone(existing).(*lteprotos.SubscriberData)
+	} else {
+		subscriber = createSubscriber(record.IMSI, k, true)
+	}
+	if subscriber.Lte == nil {
+		subscriber.Lte = &lteprotos.LTESubscription{State: lteprotos.LTESubscription_ACTIVE}
+	}
+	subscriber.Lte.AuthKey = k
+	if opc != nil {
+		subscriber.Lte.AuthOpc = opc
+	}
+	if subscriber.State == nil {
+		subscriber.State = &lteprotos.SubscriberState{}
+	}
+	if record.SQN != nil {
+		subscriber.State.LteAuthNextSeq, _ = SplitSqn(*record.SQN)
+	}
+	if len(record.APNs) > 0 {
+		if subscriber.Non_3Gpp == nil {
+			subscriber.Non_3Gpp = &lteprotos.Non3GPPUserProfile{}
+		}
+		subscriber.Non_3Gpp.ApnConfig = make([]*lteprotos.APNConfiguration, 0, len(record.APNs))
+		for i, apn := range record.APNs {
+			subscriber.Non_3Gpp.ApnConfig = append(subscriber.Non_3Gpp.ApnConfig, &lteprotos.APNConfiguration{
+				ContextId:        uint32(i + 1),
+				ServiceSelection: apn,
+			})
+		}
+	}
+	return subscriber, nil
+}
+
+// AuthAmf returns the AMF of the record, or nil if it is not set. The AMF is
+// kept by the subscriber store, apart from the subscriber data.
+func (record *SubscriberRecord) AuthAmf() ([]byte, error) {
+	return decodeHexField("AMF", record.AMF, milenage.ExpectedAmfBytes, false)
+}
+
+// NewSubscriberRecord creates the import/export record of a subscriber, amf is
+// nil if the subscriber uses the network default AMF.
+func NewSubscriberRecord(subscriber *lteprotos.SubscriberData, amf []byte) *SubscriberRecord {
+	lte := subscriber.GetLte()
+	sqn := SeqToSqn(subscriber.GetState().GetLteAuthNextSeq(), 0)
+	record := &SubscriberRecord{
+		IMSI: subscriber.GetSid().GetId(),
+		K:    hex.EncodeToString(lte.GetAuthKey()),
+		OPc:  hex.EncodeToString(lte.GetAuthOpc()),
+		AMF:  hex.EncodeToString(amf),
+		SQN:  &sqn,
+	}
+	for _, apn := range subscriber.GetNon_3Gpp().GetApnConfig() {
+		if len(apn.GetServiceSelection()) > 0 {
+			record.APNs = append(record.APNs, apn.GetServiceSelection())
+		}
+	}
+	return record
+}
+
+func validateIMSI(imsi string) error {
+	if len(imsi) < 5 || len(imsi) > 15 {
+		return fmt.Errorf("IMSI '%s' must be 5 - 15 digits long", imsi)
+	}
+	if _, err := strconv.ParseUint(imsi, 10, 64); err != nil {
+		return fmt.Errorf("Invalid IMSI '%s': %v", imsi, err)
+	}
+	return nil
+}
+
+func decodeHexField(name, value string, size int, required bool) ([]byte, error) {
+	if len(value) == 0 {
+		if required {
+			return nil, fmt.Errorf("%s is required", name)
+		}
+		return nil, nil
+	}
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s '%s': %v", name, value, err)
+	}
+	if len(decoded) != size {
+		return nil, fmt.Errorf("%s must be %d bytes long, got %d", name, size, len(decoded))
+	}
+	return decoded, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicers_test
+
+import (
+	"testing"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/testcore/hss/servicers"
+	"magma/lte/cloud/go/protos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSubscriberCSV = `imsi,k,opc,amf,sqn,apns
+001010000000001,465b5ce8b199b49faa5f0a2ee238a6bc,cd63cb71954a9f4e48a5994e37a02baf,8000,64,internet;ims
+001010000000002, 465b5ce8b199b49faa5f0a2ee238a6bc,,,,
+`
+
+func TestParseSubscriberRecords_CSV(t *testing.T) {
+	records, err := servicers.ParseSubscriberRecords(fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_CSV, []byte(testSubscriberCSV))
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, "001010000000001", records[0].IMSI)
+	assert.Equal(t, "cd63cb71954a9f4e48a5994e37a02baf", records[0].OPc)
+	assert.Equal(t, uint64(64), *records[0].SQN)
+	assert.Equal(t, []string{"internet", "ims"}, records[0].APNs)
+	assert.Equal(t, "465b5ce8b199b49faa5f0a2ee238a6bc", records[1].K)
+	assert.Nil(t, records[1].SQN)
+	assert.Empty(t, records[1].APNs)
+
+	_, err = servicers.ParseSubscriberRecords(fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_CSV, []byte("imsi,opc\n001010000000001,00\n"))
+	assert.EqualError(t, err, "CSV subscriber file is missing the 'k' column")
+}
+
+func TestSubscriberRecords_RoundTrip(t *testing.T) {
+	records, err := servicers.ParseSubscriberRecords(fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_CSV, []byte(testSubscriberCSV))
+	assert.NoError(t, err)
+
+	subscriber, err := records[0].ToSubscriberData(nil)
+	assert.NoError(t, err)
+	amf, err := records[0].AuthAmf()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("\x80\x00"), amf)
+	assert.Equal(t, uint64(2), subscriber.GetState().GetLteAuthNextSeq())
+	assert.Equal(t, "ims", subscriber.GetNon_3Gpp().GetApnConfig()[1].GetServiceSelection())
+	assert.Equal(t, uint32(2), subscriber.GetNon_3Gpp().GetApnConfig()[1].GetContextId())
+
+	for _, format := range []fegprotos.SubscriberFileFormat{
+		fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_CSV,
+		fegprotos.SubscriberFileFormat_SUBSCRIBER_FILE_JSON,
+	} {
+		content, err := servicers.FormatSubscriberRecords(format, []*servicers.SubscriberRecord{servicers.NewSubscriberRecord(subscriber, amf)})
+		assert.NoError(t, err)
+		parsed, err := servicers.ParseSubscriberRecords(format, content)
+		assert.NoError(t, err)
+		assert.Equal(t, records[0], parsed[0])
+	}
+}
+
+func TestSubscriberRecord_ToSubscriberData(t *testing.T) {
+	sqn := uint64(0x40)
+	record := &servicers.SubscriberRecord{IMSI: "001010000000001", K: "465b5ce8b199b49faa5f0a2ee238a6bc", SQN: &sqn}
+	existing := &protos.SubscriberData{
+		Sid:        &protos.SubscriberID{Id: "001010000000001"},
+		SubProfile: "gold",
+		Lte:        &protos.LTESubscription{AuthOpc: []byte("\xcdc\xcbq\x95J\x9fNH\xa5\x99N7\xa0+\xaf")},
+	}
+	subscriber, err := record.ToSubscriberData(existing)
+	assert.NoError(t, err)
+	assert.Equal(t, "gold", subscriber.GetSubProfile())
+	assert.Equal(t, existing.Lte.AuthOpc, subscriber.GetLte().GetAuthOpc())
+	assert.Equal(t, uint64(2), subscriber.GetState().GetLteAuthNextSeq())
+	assert.Empty(t, existing.GetState().GetLteAuthNextSeq())
+
+	record.IMSI = "0010A"
+	_, err = record.ToSubscriberData(nil)
+	assert.Error(t, err)
+
+	record.IMSI = "001010000000001"
+	record.K = "465b5ce8"
+	_, err = record.ToSubscriberData(nil)
+	assert.EqualError(t, err, "K must be 16 bytes long, got 4")
+}
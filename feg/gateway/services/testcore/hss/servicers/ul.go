@@ -81,6 +81,7 @@ func NewULA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error)
 		return answer, fmt.Errorf("RAT-Type not allowed: %v", uint32(ulr.RATType))
 	}
 
+	srv.setServingMME(string(ulr.UserName), string(ulr.OriginHost))
 	return srv.NewSuccessfulULA(msg, ulr.SessionID, profile), nil
 }
 
@@ -90,7 +91,13 @@ func NewULA(srv *HomeSubscriberServer, msg *diam.Message) (*diam.Message, error)
 func (srv *HomeSubscriberServer) NewSuccessfulULA(msg *diam.Message, sessionID datatype.UTF8String, profile *mconfig.HSSConfig_SubscriptionProfile) *diam.Message {
 	ula := ConstructSuccessAnswer(msg, sessionID, srv.Config.Server, diam.TGPP_S6A_APP_ID)
 	ula.NewAVP(avp.ULAFlags, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(ulaFlags))
-	ula.NewAVP(avp.SubscriptionData, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
+	ula.AddAVP(newSubscriptionDataAVP(profile))
+	return ula
+}
+
+// newSubscriptionDataAVP creates the Subscription-Data AVP of the subscriber profile
+func newSubscriptionDataAVP(profile *mconfig.HSSConfig_SubscriptionProfile) *diam.AVP {
+	return diam.NewAVP(avp.SubscriptionData, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, &diam.GroupedAVP{
 		AVP: []*diam.AVP{
 			diam.NewAVP(avp.MSISDN, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.OctetString(msisdn)),
 			diam.NewAVP(avp.AccessRestrictionData, avp.Mbit|avp.Vbit, diameter.Vendor3GPP, datatype.Unsigned32(accessRestrictionData)),
@@ -135,7 +142,21 @@ func (srv *HomeSubscriberServer) NewSuccessfulULA(msg *diam.Message, sessionID d
 			}),
 		},
 	})
-	return ula
+}
+
+// setServingMME records the MME serving the subscriber, it is the destination of HSS initiated requests
+func (srv *HomeSubscriberServer) setServingMME(imsi, mme string) {
+	srv.mmesMu.Lock()
+	srv.servingMMEs[imsi] = mme
+	srv.mmesMu.Unlock()
+}
+
+// getServingMME returns the MME serving the subscriber
+func (srv *HomeSubscriberServer) getServingMME(imsi string) (string, bool) {
+	srv.mmesMu.RLock()
+	defer srv.mmesMu.RUnlock()
+	mme, ok := srv.servingMMEs[imsi]
+	return mme, ok
 }
 
 // ValidateULR returns an error if the message is missing any mandatory AVPs.
@@ -14,6 +14,7 @@ limitations under the License.
 package storage
 
 import (
+	"sort"
 	"sync"
 
 	"magma/lte/cloud/go/protos"
@@ -25,7 +26,9 @@ import (
 // MemorySubscriberStore is an in memory implementation of SubscriberStore.
 type MemorySubscriberStore struct {
 	accounts map[string]*protos.SubscriberData
-	mutex    sync.RWMutex
+	// amfs holds the AMF of the subscribers which don't use the network default
+	amfs  map[string][]byte
+	mutex sync.RWMutex
 }
 
 // NewMemorySubscriberStore initializes a MemorySubscriberStore with an empty accounts map.
@@ -33,6 +36,7 @@ type MemorySubscriberStore struct {
 func NewMemorySubscriberStore() *MemorySubscriberStore {
 	return &MemorySubscriberStore{
 		accounts: make(map[string]*protos.SubscriberData),
+		amfs:     make(map[string][]byte),
 	}
 }
 
@@ -82,6 +86,78 @@ func (store *MemorySubscriberStore) UpdateSubscriber(data *protos.SubscriberData
 	return nil
 }
 
+// ModifySubscriber atomically applies modify to the data of an existing
+// subscriber & stores the result. Nothing is stored if modify returns an error,
+// the error is returned instead.
+// If the subscriber cannot be found, an error is returned instead.
+func (store *MemorySubscriberStore) ModifySubscriber(id string, modify func(data *protos.SubscriberData) error) error {
+	if err := validateSubscriberID(id); err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	data, exists := store.accounts[id]
+	if !exists {
+		glog.Errorf("Subscriber '%s' not found", id)
+		return NewUnknownSubscriberError(id)
+	}
+	modified := proto.Clone(data).(*protos.SubscriberData)
+	if err := modify(modified); err != nil {
+		return err
+	}
+	if err := validateSubscriberData(modified); err != nil {
+		return err
+	}
+	if modified.GetSid().GetId() != id {
+		return NewInvalidArgumentError("Subscriber id cannot be modified")
+	}
+	store.accounts[id] = modified
+	return nil
+}
+
+// GetAuthAmf returns the authentication management field (AMF) of a
+// subscriber, or nil if the subscriber uses the network default AMF.
+// If the subscriber cannot be found, an error is returned instead.
+func (store *MemorySubscriberStore) GetAuthAmf(id string) ([]byte, error) {
+	if err := validateSubscriberID(id); err != nil {
+		return nil, err
+	}
+
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if _, exists := store.accounts[id]; !exists {
+		glog.Errorf("Subscriber '%s' not found", id)
+		return nil, NewUnknownSubscriberError(id)
+	}
+	return store.amfs[id], nil
+}
+
+// SetAuthAmf sets the authentication management field (AMF) of an existing
+// subscriber, nil restores the network default AMF.
+// If the subscriber cannot be found, an error is returned instead.
+func (store *MemorySubscriberStore) SetAuthAmf(id string, amf []byte) error {
+	if err := validateSubscriberID(id); err != nil {
+		return err
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, exists := store.accounts[id]; !exists {
+		glog.Errorf("Subscriber '%s' not found", id)
+		return NewUnknownSubscriberError(id)
+	}
+	if len(amf) == 0 {
+		delete(store.amfs, id)
+	} else {
+		store.amfs[id] = append([]byte{}, amf...)
+	}
+	return nil
+}
+
 // GetSubscriberData looks up a subscriber by their id.
 // If the subscriber cannot be found, an error is returned instead.
 // Input: The id of the subscriber to be looked up.
@@ -102,6 +178,18 @@ func (store *MemorySubscriberStore) GetSubscriberData(id string) (*protos.Subscr
 	return nil, NewUnknownSubscriberError(id)
 }
 
+// ListSubscribers returns the data of all the subscribers, sorted by id.
+func (store *MemorySubscriberStore) ListSubscribers() ([]*protos.SubscriberData, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	subscribers := make([]*protos.SubscriberData, 0, len(store.accounts))
+	for _, data := range store.accounts {
+		subscribers = append(subscribers, proto.Clone(data).(*protos.SubscriberData))
+	}
+	sort.Slice(subscribers, func(i, j int) bool { return subscribers[i].GetSid().GetId() < subscribers[j].GetSid().GetId() })
+	return subscribers, nil
+}
+
 // DeleteSubscriber deletes a subscriber by their id.
 // If the subscriber is not found, then this call is ignored.
 // Input: The id of the subscriber to be deleted.
@@ -114,6 +202,7 @@ func (store *MemorySubscriberStore) DeleteSubscriber(id string) error {
 	defer store.mutex.Unlock()
 
 	delete(store.accounts, id)
+	delete(store.amfs, id)
 	return nil
 }
 
@@ -121,5 +210,6 @@ func (store *MemorySubscriberStore) DeleteAllSubscribers() error {
 	store.mutex.Lock()
 	defer store.mutex.Unlock()
 	store.accounts = make(map[string]*protos.SubscriberData)
+	store.amfs = make(map[string][]byte)
 	return nil
 }
@@ -0,0 +1,329 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"database/sql"
+
+	"magma/lte/cloud/go/protos"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	subscriberTableName = "testcore_hss_subscribers"
+
+	subscriberIDCol   = "id"
+	subscriberDataCol = "data"
+	// subscriberAmfCol holds the AMF of the subscribers which don't use the
+	// network default, it is not part of the subscriber data
+	subscriberAmfCol = "amf"
+)
+
+// SQLSubscriberStore is a SQL backed implementation of SubscriberStore.
+// Subscribers, including their auth sequence numbers, are kept across
+// restarts.
+type SQLSubscriberStore struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+// NewSQLSubscriberStore creates a SQLSubscriberStore, Initialize must be
+// called before it is used.
+func NewSQLSubscriberStore(db *sql.DB, builder sqorc.StatementBuilder) *SQLSubscriberStore {
+	return &SQLSubscriberStore{db: db, builder: builder}
+}
+
+// Initialize creates the subscriber table if it doesn't exist yet.
+func (store *SQLSubscriberStore) Initialize() error {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		_, err := store.builder.CreateTable(subscriberTableName).
+			IfNotExists().
+			Column(subscriberIDCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+			Column(subscriberDataCol).Type(sqorc.ColumnTypeBytes).NotNull().EndColumn().
+			Column(subscriberAmfCol).Type(sqorc.ColumnTypeBytes).EndColumn().
+			RunWith(tx).
+			Exec()
+		return nil, errors.Wrap(err, "initialize subscriber table")
+	}
+	_, err := sqorc.ExecInTx(store.db, nil, nil, txFn)
+	return err
+}
+
+// AddSubscriber tries to add this subscriber to the server.
+// This function returns an AlreadyExists error if the subscriber has already
+// been added.
+// Input: The subscriber data which will be added.
+func (store *SQLSubscriberStore) AddSubscriber(data *protos.SubscriberData) error {
+	if err := validateSubscriberData(data); err != nil {
+		return err
+	}
+	id := data.GetSid().GetId()
+	marshaled, err := proto.Marshal(data)
+	if err != nil {
+		return errors.Wrapf(err, "marshal subscriber %s", id)
+	}
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		exists, err := store.exists(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			glog.Errorf("Subscriber '%s' already added", id)
+			return nil, NewAlreadyExistsError(id)
+		}
+		_, err = store.builder.Insert(subscriberTableName).
+			Columns(subscriberIDCol, subscriberDataCol).
+			Values(id, marshaled).
+			RunWith(tx).
+			Exec()
+		return nil, errors.Wrapf(err, "insert subscriber %s", id)
+	}
+	_, err = sqorc.ExecInTx(store.db, nil, nil, txFn)
+	return err
+}
+
+// UpdateSubscriber changes the data stored for an existing subscriber.
+// If the subscriber cannot be found, an error is returned instead.
+// Input: The new subscriber data to store
+func (store *SQLSubscriberStore) UpdateSubscriber(data *protos.SubscriberData) error {
+	if err := validateSubscriberData(data); err != nil {
+		return err
+	}
+	id := data.GetSid().GetId()
+	marshaled, err := proto.Marshal(data)
+	if err != nil {
+		return errors.Wrapf(err, "marshal subscriber %s", id)
+	}
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		exists, err := store.exists(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			glog.Errorf("Subscriber '%s' not found", id)
+			return nil, NewUnknownSubscriberError(id)
+		}
+		_, err = store.builder.Update(subscriberTableName).
+			Set(subscriberDataCol, marshaled).
+			Where(squirrel.Eq{subscriberIDCol: id}).
+			RunWith(tx).
+			Exec()
+		return nil, errors.Wrapf(err, "update subscriber %s", id)
+	}
+	_, err = sqorc.ExecInTx(store.db, nil, nil, txFn)
+	return err
+}
+
+// ModifySubscriber atomically applies modify to the data of an existing
+// subscriber & stores the result. Nothing is stored if modify returns an error,
+// the error is returned instead.
+// If the subscriber cannot be found, an error is returned instead.
+func (store *SQLSubscriberStore) ModifySubscriber(id string, modify func(data *protos.SubscriberData) error) error {
+	if err := validateSubscriberID(id); err != nil {
+		return err
+	}
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		// The no-op update locks the row until the end of the transaction, so
+		// that concurrent modifications read the data stored by the previous
+		// one. This is the portable equivalent of SELECT ... FOR UPDATE, which
+		// sqlite doesn't support.
+		res, err := store.builder.Update(subscriberTableName).
+			Set(subscriberDataCol, squirrel.Expr(subscriberDataCol)).
+			Where(squirrel.Eq{subscriberIDCol: id}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrapf(err, "lock subscriber %s", id)
+		}
+		if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+			glog.Errorf("Subscriber '%s' not found", id)
+			return nil, NewUnknownSubscriberError(id)
+		}
+		data, err := store.getSubscriberData(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err = modify(data); err != nil {
+			return nil, err
+		}
+		if err = validateSubscriberData(data); err != nil {
+			return nil, err
+		}
+		if data.GetSid().GetId() != id {
+			return nil, NewInvalidArgumentError("Subscriber id cannot be modified")
+		}
+		marshaled, err := proto.Marshal(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "marshal subscriber %s", id)
+		}
+		_, err = store.builder.Update(subscriberTableName).
+			Set(subscriberDataCol, marshaled).
+			Where(squirrel.Eq{subscriberIDCol: id}).
+			RunWith(tx).
+			Exec()
+		return nil, errors.Wrapf(err, "update subscriber %s", id)
+	}
+	_, err := sqorc.ExecInTx(store.db, nil, nil, txFn)
+	return err
+}
+
+// GetAuthAmf returns the authentication management field (AMF) of a
+// subscriber, or nil if the subscriber uses the network default AMF.
+// If the subscriber cannot be found, an error is returned instead.
+func (store *SQLSubscriberStore) GetAuthAmf(id string) ([]byte, error) {
+	if err := validateSubscriberID(id); err != nil {
+		return nil, err
+	}
+	var amf []byte
+	err := store.builder.Select(subscriberAmfCol).
+		From(subscriberTableName).
+		Where(squirrel.Eq{subscriberIDCol: id}).
+		RunWith(store.db).
+		QueryRow().
+		Scan(&amf)
+	if err == sql.ErrNoRows {
+		glog.Errorf("Subscriber '%s' not found", id)
+		return nil, NewUnknownSubscriberError(id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "select AMF of subscriber %s", id)
+	}
+	if len(amf) == 0 {
+		return nil, nil
+	}
+	return amf, nil
+}
+
+// SetAuthAmf sets the authentication management field (AMF) of an existing
+// subscriber, nil restores the network default AMF.
+// If the subscriber cannot be found, an error is returned instead.
+func (store *SQLSubscriberStore) SetAuthAmf(id string, amf []byte) error {
+	if err := validateSubscriberID(id); err != nil {
+		return err
+	}
+	var value interface{}
+	if len(amf) > 0 {
+		value = amf
+	}
+	res, err := store.builder.Update(subscriberTableName).
+		Set(subscriberAmfCol, value).
+		Where(squirrel.Eq{subscriberIDCol: id}).
+		RunWith(store.db).
+		Exec()
+	if err != nil {
+		return errors.Wrapf(err, "update AMF of subscriber %s", id)
+	}
+	if rows, err := res.RowsAffected(); err == nil && rows == 0 {
+		glog.Errorf("Subscriber '%s' not found", id)
+		return NewUnknownSubscriberError(id)
+	}
+	return nil
+}
+
+// GetSubscriberData looks up a subscriber by their id.
+// If the subscriber cannot be found, an error is returned instead.
+// Input: The id of the subscriber to be looked up.
+// Output: The data of the corresponding subscriber or an error.
+func (store *SQLSubscriberStore) GetSubscriberData(id string) (*protos.SubscriberData, error) {
+	if err := validateSubscriberID(id); err != nil {
+		return nil, err
+	}
+	return store.getSubscriberData(store.db, id)
+}
+
+func (store *SQLSubscriberStore) getSubscriberData(runner squirrel.BaseRunner, id string) (*protos.SubscriberData, error) {
+	var marshaled []byte
+	err := store.builder.Select(subscriberDataCol).
+		From(subscriberTableName).
+		Where(squirrel.Eq{subscriberIDCol: id}).
+		RunWith(runner).
+		QueryRow().
+		Scan(&marshaled)
+	if err == sql.ErrNoRows {
+		glog.Errorf("Subscriber '%s' not found", id)
+		return nil, NewUnknownSubscriberError(id)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "select subscriber %s", id)
+	}
+	data := &protos.SubscriberData{}
+	err = proto.Unmarshal(marshaled, data)
+	return data, errors.Wrapf(err, "unmarshal subscriber %s", id)
+}
+
+// ListSubscribers returns the data of all the subscribers, sorted by id.
+func (store *SQLSubscriberStore) ListSubscribers() ([]*protos.SubscriberData, error) {
+	rows, err := store.builder.Select(subscriberDataCol).
+		From(subscriberTableName).
+		OrderBy(subscriberIDCol).
+		RunWith(store.db).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "select subscribers")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "ListSubscribers")
+
+	subscribers := []*protos.SubscriberData{}
+	for rows.Next() {
+		var marshaled []byte
+		if err = rows.Scan(&marshaled); err != nil {
+			return nil, errors.Wrap(err, "select subscribers, SQL row scan error")
+		}
+		data := &protos.SubscriberData{}
+		if err = proto.Unmarshal(marshaled, data); err != nil {
+			return nil, errors.Wrap(err, "unmarshal subscriber")
+		}
+		subscribers = append(subscribers, data)
+	}
+	return subscribers, errors.Wrap(rows.Err(), "select subscribers, SQL rows error")
+}
+
+// DeleteSubscriber deletes a subscriber by their id.
+// If the subscriber is not found, then this call is ignored.
+// Input: The id of the subscriber to be deleted.
+func (store *SQLSubscriberStore) DeleteSubscriber(id string) error {
+	if err := validateSubscriberID(id); err != nil {
+		return err
+	}
+	_, err := store.builder.Delete(subscriberTableName).
+		Where(squirrel.Eq{subscriberIDCol: id}).
+		RunWith(store.db).
+		Exec()
+	return errors.Wrapf(err, "delete subscriber %s", id)
+}
+
+// DeleteAllSubscribers deletes all the data from the store.
+func (store *SQLSubscriberStore) DeleteAllSubscribers() error {
+	_, err := store.builder.Delete(subscriberTableName).RunWith(store.db).Exec()
+	return errors.Wrap(err, "delete all subscribers")
+}
+
+func (store *SQLSubscriberStore) exists(tx *sql.Tx, id string) (bool, error) {
+	var count uint64
+	err := store.builder.Select("COUNT(*)").
+		From(subscriberTableName).
+		Where(squirrel.Eq{subscriberIDCol: id}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&count)
+	if err != nil {
+		return false, errors.Wrapf(err, "select subscriber %s", id)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestSQLSubscriberStore(t *testing.T) {
+	testSuite := new(SubscriberStoreTestSuite)
+	testSuite.createStore = func() SubscriberStore {
+		db, err := sqorc.Open("sqlite3", ":memory:")
+		assert.NoError(t, err)
+		store := NewSQLSubscriberStore(db, sqorc.GetSqlBuilder())
+		assert.NoError(t, store.Initialize())
+		return store
+	}
+	suite.Run(t, testSuite)
+}
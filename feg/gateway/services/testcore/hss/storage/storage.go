@@ -31,11 +31,31 @@ type SubscriberStore interface {
 	// Output: The data of the corresponding subscriber or an error.
 	GetSubscriberData(id string) (*protos.SubscriberData, error)
 
+	// ListSubscribers returns the data of all the subscribers, sorted by Id.
+	ListSubscribers() ([]*protos.SubscriberData, error)
+
 	// UpdateSubscriber changes the data stored for an existing subscriber.
 	// If the subscriber cannot be found, an error is returned instead.
 	// Input: The new subscriber data to store
 	UpdateSubscriber(data *protos.SubscriberData) error
 
+	// ModifySubscriber atomically applies modify to the data of an existing
+	// subscriber & stores the result, concurrent modifications of the same
+	// subscriber are serialized. Nothing is stored if modify returns an error,
+	// the error is returned instead.
+	// If the subscriber cannot be found, an error is returned instead.
+	ModifySubscriber(id string, modify func(data *protos.SubscriberData) error) error
+
+	// GetAuthAmf returns the authentication management field (AMF) of a
+	// subscriber, or nil if the subscriber uses the network default AMF.
+	// If the subscriber cannot be found, an error is returned instead.
+	GetAuthAmf(id string) ([]byte, error)
+
+	// SetAuthAmf sets the authentication management field (AMF) of an
+	// existing subscriber, nil restores the network default AMF.
+	// If the subscriber cannot be found, an error is returned instead.
+	SetAuthAmf(id string, amf []byte) error
+
 	// DeleteSubscriber deletes a subscriber by their Id.
 	// If the subscriber is not found, then this call is ignored.
 	// Input: The id of the subscriber to be deleted.
@@ -18,6 +18,7 @@ import (
 
 	"magma/feg/gateway/services/testcore/hss/storage"
 	"magma/lte/cloud/go/protos"
+	"magma/orc8r/cloud/go/sqorc"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
@@ -28,6 +29,26 @@ func TestTestcoreStorageMemory_Integration(t *testing.T) {
 	testTestcoreStorageImpl(t, store)
 }
 
+func TestTestcoreStorageSQL_Integration(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	store := storage.NewSQLSubscriberStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Initialize())
+	testTestcoreStorageImpl(t, store)
+
+	// Subscribers & their auth sequence numbers outlive the store
+	sub := &protos.SubscriberData{
+		Sid:   &protos.SubscriberID{Id: "subscriber_id_2"},
+		State: &protos.SubscriberState{LteAuthNextSeq: 42},
+	}
+	assert.NoError(t, store.AddSubscriber(sub))
+	store = storage.NewSQLSubscriberStore(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Initialize())
+	dataRecvd, err := store.GetSubscriberData("subscriber_id_2")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), dataRecvd.GetState().GetLteAuthNextSeq())
+}
+
 func testTestcoreStorageImpl(t *testing.T, store storage.SubscriberStore) {
 	sub0 := "subscriber_id_0"
 	sub1 := "subscriber_id_1"
@@ -141,6 +141,124 @@ func (suite *SubscriberStoreTestSuite) TestDeleteAllSubscribers() {
 	suite.Exactly(NewUnknownSubscriberError("2"), err)
 }
 
+func (suite *SubscriberStoreTestSuite) TestListSubscribers() {
+	store := suite.store
+
+	subscribers, err := store.ListSubscribers()
+	suite.NoError(err)
+	suite.Empty(subscribers)
+
+	sub1 := &protos.SubscriberData{Sid: &protos.SubscriberID{Id: "1"}}
+	sub2 := &protos.SubscriberData{
+		Sid:   &protos.SubscriberID{Id: "2"},
+		State: &protos.SubscriberState{LteAuthNextSeq: 7},
+	}
+	err = store.AddSubscriber(sub2)
+	suite.NoError(err)
+	err = store.AddSubscriber(sub1)
+	suite.NoError(err)
+
+	subscribers, err = store.ListSubscribers()
+	suite.NoError(err)
+	suite.Len(subscribers, 2)
+	suite.True(proto.Equal(sub1, subscribers[0]))
+	suite.True(proto.Equal(sub2, subscribers[1]))
+}
+
+func (suite *SubscriberStoreTestSuite) TestModifySubscriber() {
+	store := suite.store
+	incrementSeq := func(data *protos.SubscriberData) error {
+		data.State.LteAuthNextSeq++
+		return nil
+	}
+
+	err := store.ModifySubscriber("1", incrementSeq)
+	suite.Exactly(NewUnknownSubscriberError("1"), err)
+
+	err = store.AddSubscriber(&protos.SubscriberData{
+		Sid:   &protos.SubscriberID{Id: "1"},
+		State: &protos.SubscriberState{LteAuthNextSeq: 1},
+	})
+	suite.NoError(err)
+
+	// Nothing is stored when the modification fails
+	err = store.ModifySubscriber("1", func(data *protos.SubscriberData) error {
+		data.State.LteAuthNextSeq = 100
+		return NewInvalidArgumentError("failed")
+	})
+	suite.Exactly(NewInvalidArgumentError("failed"), err)
+	err = store.ModifySubscriber("1", func(data *protos.SubscriberData) error {
+		data.Sid.Id = "2"
+		return nil
+	})
+	suite.Exactly(NewInvalidArgumentError("Subscriber id cannot be modified"), err)
+	data, err := store.GetSubscriberData("1")
+	suite.NoError(err)
+	suite.Equal(uint64(1), data.GetState().GetLteAuthNextSeq())
+
+	// Concurrent modifications never read the same data
+	writers := 10
+	doneSignal := make(chan struct{})
+	for i := 0; i < writers; i++ {
+		go func() {
+			suite.NoError(store.ModifySubscriber("1", incrementSeq))
+			doneSignal <- struct{}{}
+		}()
+	}
+	for i := 0; i < writers; i++ {
+		<-doneSignal
+	}
+	data, err = store.GetSubscriberData("1")
+	suite.NoError(err)
+	suite.Equal(uint64(writers+1), data.GetState().GetLteAuthNextSeq())
+}
+
+func (suite *SubscriberStoreTestSuite) TestAuthAmf() {
+	store := suite.store
+
+	_, err := store.GetAuthAmf("1")
+	suite.Exactly(NewUnknownSubscriberError("1"), err)
+	err = store.SetAuthAmf("1", []byte("\x90\x01"))
+	suite.Exactly(NewUnknownSubscriberError("1"), err)
+
+	sub := &protos.SubscriberData{Sid: &protos.SubscriberID{Id: "1"}}
+	err = store.AddSubscriber(sub)
+	suite.NoError(err)
+	amf, err := store.GetAuthAmf("1")
+	suite.NoError(err)
+	suite.Nil(amf)
+
+	err = store.SetAuthAmf("1", []byte("\x90\x01"))
+	suite.NoError(err)
+	amf, err = store.GetAuthAmf("1")
+	suite.NoError(err)
+	suite.Equal([]byte("\x90\x01"), amf)
+
+	// The AMF is kept apart from the subscriber data
+	err = store.UpdateSubscriber(sub)
+	suite.NoError(err)
+	amf, err = store.GetAuthAmf("1")
+	suite.NoError(err)
+	suite.Equal([]byte("\x90\x01"), amf)
+
+	err = store.SetAuthAmf("1", nil)
+	suite.NoError(err)
+	amf, err = store.GetAuthAmf("1")
+	suite.NoError(err)
+	suite.Nil(amf)
+
+	// The AMF of a deleted subscriber is gone
+	err = store.SetAuthAmf("1", []byte("\x90\x01"))
+	suite.NoError(err)
+	err = store.DeleteSubscriber("1")
+	suite.NoError(err)
+	err = store.AddSubscriber(sub)
+	suite.NoError(err)
+	amf, err = store.GetAuthAmf("1")
+	suite.NoError(err)
+	suite.Nil(amf)
+}
+
 func (suite *SubscriberStoreTestSuite) TestRaceCondition() {
 	store := suite.store
 	sub := &protos.SubscriberData{
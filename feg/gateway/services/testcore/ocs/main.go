@@ -14,6 +14,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -34,11 +35,13 @@ const (
 )
 
 var (
-	serverNumber int
+	serverNumber   int
+	tariffScenario string
 )
 
 func init() {
 	flag.IntVar(&serverNumber, "servernumber", 1, "Number of the server. Will use Gy[servernumber-1] configuration")
+	flag.StringVar(&tariffScenario, "tariff_scenario", "", "JSON tariff scenario file enabling the rating engine")
 }
 
 func main() {
@@ -76,6 +79,17 @@ func main() {
 		},
 	)
 
+	if len(tariffScenario) > 0 {
+		scenario, err := mock_ocs.LoadTariffScenario(tariffScenario)
+		if err != nil {
+			log.Fatalf("Unable to load tariff scenario for mock %s: %s", serviceName, err)
+		}
+		_, err = diamServer.SetTariffScenario(context.Background(), scenario)
+		if err != nil {
+			log.Fatalf("Invalid tariff scenario for mock %s: %s", serviceName, err)
+		}
+	}
+
 	srv, err := service.NewServiceWithOptions(registry.ModuleName, serviceName)
 	if err != nil {
 		log.Fatalf("Error creating mock %s service: %s", serviceName, err)
@@ -115,6 +115,13 @@ func getCCRHandler(srv *OCSDiamServer) diam.HandlerFunc {
 			return
 		}
 
+		// the engine is read once so that the whole CCR is rated by the same scenario
+		if engine := srv.getRatingEngine(); engine != nil {
+			avps, resultCode := rateCCR(srv, engine, imsi, requestType, m)
+			sendAnswer(ccr, c, m, resultCode, avps...)
+			return
+		}
+
 		if requestType == credit_control.CRTTerminate {
 			sendAnswer(ccr, c, m, diam.Success)
 			return
@@ -174,6 +181,41 @@ func getCCRHandler(srv *OCSDiamServer) diam.HandlerFunc {
 	}
 }
 
+// rateCCR charges & grants credit with the rating engine. The answer is only
+// rejected with CREDIT_LIMIT_REACHED when no rating group is granted credit.
+func rateCCR(
+	srv *OCSDiamServer,
+	engine *RatingEngine,
+	imsi string,
+	requestType credit_control.CreditRequestType,
+	m *diam.Message,
+) ([]*diam.AVP, uint32) {
+	var ccr ratingCCRMessage
+	if err := m.Unmarshal(&ccr); err != nil {
+		glog.Errorf("Failed to unmarshal CCR for rating %s", err)
+		return nil, diam.UnableToComply
+	}
+	validityTime := engine.Scenario().GetValidityTime()
+	if validityTime == 0 {
+		validityTime = srv.ocsConfig.ValidityTime
+	}
+	credits := engine.Rate(imsi, requestType, ccr.MSCC)
+	creditAnswers := make([]*diam.AVP, 0, len(credits))
+	resultCode := uint32(DiameterCreditLimitReached)
+	for _, credit := range credits {
+		if credit.resultCode == diam.Success {
+			resultCode = diam.Success
+		}
+		creditAnswers = append(
+			creditAnswers,
+			toRatedCreditAVP(credit, validityTime, srv.ocsConfig.FinalUnitIndication))
+	}
+	if len(credits) == 0 {
+		resultCode = diam.Success
+	}
+	return creditAnswers, resultCode
+}
+
 func decrementUsedCredit(credit *CreditBucket, usage *usedServiceUnit) {
 	credit.Volume.TotalOctets = decrementOrZero(credit.Volume.GetTotalOctets(), usage.TotalOctets)
 	credit.Volume.OutputOctets = decrementOrZero(credit.Volume.GetOutputOctets(), usage.OutputOctets)
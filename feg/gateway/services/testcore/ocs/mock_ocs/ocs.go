@@ -16,6 +16,7 @@ package mock_ocs
 import (
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
 	"magma/feg/cloud/go/protos"
@@ -35,7 +36,10 @@ import (
 	"golang.org/x/net/context"
 )
 
-const DiameterCreditLimitReached = 4012
+const (
+	DiameterCreditLimitReached = 4012
+	DiameterRatingFailed       = 5031
+)
 
 type CreditBucket struct {
 	Unit   protos.CreditInfo_UnitType
@@ -76,12 +80,17 @@ type OCSDiamServer struct {
 	mux                     *sm.StateMachine
 	lastDiamMessageReceived *diam.Message
 	mockDriver              *mock_driver.MockDriver
+	// ratingEngine is replaced by SetTariffScenario while CCRs are rated,
+	// it must be accessed through getRatingEngine & setRatingEngine
+	ratingEngine   *RatingEngine
+	ratingEngineMu sync.RWMutex
 }
 
 // NewOCSDiamServer initializes an OCS with an empty account map
 // Input: *sm.Settings containing the diameter related parameters
-//				*TestOCSConfig containing the server address, and standard OCS settings
-//					like how many bytes to allocate to users
+//
+//	*TestOCSConfig containing the server address, and standard OCS settings
+//		like how many bytes to allocate to users
 //
 // Output: a new OCSDiamServer
 func NewOCSDiamServer(
@@ -169,8 +178,9 @@ func (srv *OCSDiamServer) CreateAccount(
 // SetOCSSettings changes the standard OCS return values. All parameters are
 // optional, and this only sets the non-nil ones.
 // Input: *uint32 optional maximum bytes to return in a CCA
-//			  *uint32 optional maximum time to return in a CCA
-//			  *uint32 optional credit validity time to return in a CCA
+//
+//	*uint32 optional maximum time to return in a CCA
+//	*uint32 optional credit validity time to return in a CCA
 func (srv *OCSDiamServer) SetOCSSettings(
 	_ context.Context,
 	ocsConfig *protos.OCSConfig,
@@ -190,9 +200,11 @@ func (srv *OCSDiamServer) SetOCSSettings(
 
 // SetCredit sets or overrides the prepaid credit allocated for an account
 // Input: string IMSI for the account
-//			  uint32 charging key to add credit to
-//			  uint64 volume (in any units) to set this bucket to
-//		    UnitType dictating which unit the volume represents
+//
+//		  uint32 charging key to add credit to
+//		  uint64 volume (in any units) to set this bucket to
+//	    UnitType dictating which unit the volume represents
+//
 // Output: error if account could not be found
 func (srv *OCSDiamServer) SetCredit(
 	_ context.Context,
@@ -212,7 +224,8 @@ func (srv *OCSDiamServer) SetCredit(
 // GetCredits returns all the credits allocated for an account
 // Input: string IMSI for the account
 // Output: map[uint32]*CreditBucket a map of charging key to credit bucket
-//			   error if account could not be found
+//
+//	error if account could not be found
 func (srv *OCSDiamServer) GetCredits(
 	_ context.Context,
 	subscriberID *lteprotos.SubscriberID,
@@ -268,6 +281,73 @@ func (srv *OCSDiamServer) AssertExpectations(_ context.Context, void *orcprotos.
 	return &protos.GyCreditControlResult{Results: results, Errors: errs}, nil
 }
 
+// SetTariffScenario enables the rating engine with the tariffs & balances of
+// the scenario, replacing any previous rating state. Accounts are created for
+// the subscribers of the scenario if needed. An empty scenario disables the
+// rating engine.
+func (srv *OCSDiamServer) SetTariffScenario(
+	_ context.Context,
+	scenario *protos.TariffScenario,
+) (*orcprotos.Void, error) {
+	if len(scenario.GetTariffs()) == 0 {
+		srv.setRatingEngine(nil)
+		glog.V(2).Info("Rating engine disabled")
+		return &orcprotos.Void{}, nil
+	}
+	engine, err := NewRatingEngine(scenario)
+	if err != nil {
+		return nil, err
+	}
+	for _, balance := range scenario.GetBalances() {
+		if _, ok := srv.accounts[balance.GetImsi()]; !ok {
+			srv.accounts[balance.GetImsi()] = &SubscriberAccount{
+				ChargingCredit: make(map[uint32]*CreditBucket),
+			}
+		}
+	}
+	srv.setRatingEngine(engine)
+	glog.V(2).Infof("Rating engine enabled with tariff scenario %s", scenario.GetName())
+	return &orcprotos.Void{}, nil
+}
+
+// GetTariffScenario returns the scenario used by the rating engine, or an
+// empty scenario if the rating engine is disabled
+func (srv *OCSDiamServer) GetTariffScenario(
+	_ context.Context,
+	_ *orcprotos.Void,
+) (*protos.TariffScenario, error) {
+	engine := srv.getRatingEngine()
+	if engine == nil {
+		return &protos.TariffScenario{}, nil
+	}
+	return engine.Scenario(), nil
+}
+
+// GetRatingState returns the remaining balances & rated usage of a subscriber
+// Output: error if the rating engine is disabled or the subscriber is unknown
+func (srv *OCSDiamServer) GetRatingState(
+	_ context.Context,
+	subscriberID *lteprotos.SubscriberID,
+) (*protos.RatingState, error) {
+	engine := srv.getRatingEngine()
+	if engine == nil {
+		return nil, fmt.Errorf("Rating engine is not enabled")
+	}
+	return engine.State(subscriberID.GetId())
+}
+
+func (srv *OCSDiamServer) getRatingEngine() *RatingEngine {
+	srv.ratingEngineMu.RLock()
+	defer srv.ratingEngineMu.RUnlock()
+	return srv.ratingEngine
+}
+
+func (srv *OCSDiamServer) setRatingEngine(engine *RatingEngine) {
+	srv.ratingEngineMu.Lock()
+	srv.ratingEngine = engine
+	srv.ratingEngineMu.Unlock()
+}
+
 // ReAuth initiates a reauth call for a subscriber and optional rating group.
 // It waits for any answer from the OCS
 func (srv *OCSDiamServer) ReAuth(
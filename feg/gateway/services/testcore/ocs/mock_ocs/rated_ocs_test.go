@@ -0,0 +1,198 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock_ocs_test
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	fegprotos "magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/eap/test"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+	"magma/feg/gateway/services/session_proxy/credit_control/gy"
+	"magma/feg/gateway/services/testcore/ocs/mock_ocs"
+	lteprotos "magma/lte/cloud/go/protos"
+	orcprotos "magma/orc8r/lib/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/go-openapi/swag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOCSRatingEngine(t *testing.T) {
+	serverConfig := diameter.DiameterServerConfig{DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+		Addr:     "127.0.0.1:0",
+		Protocol: "tcp"},
+	}
+	clientConfig := getClientConfig()
+	ocs := startRatingServer(clientConfig, &serverConfig)
+	gyClient := gy.NewGyClient(clientConfig, &serverConfig, getReAuthHandler(), nil, getGyGlobalConfig("", ""))
+
+	scenario := &fegprotos.TariffScenario{
+		Name: "flat",
+		Tariffs: []*fegprotos.Tariff{
+			{RatingGroup: 1, Unit: fegprotos.Tariff_Volume, UnitSize: 100, Price: 1, MaxGrant: 1000},
+		},
+		Balances: []*fegprotos.SubscriberBalance{
+			{Imsi: test.IMSI1, Pools: []*fegprotos.CreditPool{{Balance: 15}}},
+		},
+		ValidityTime: 30,
+	}
+	_, err := ocs.SetTariffScenario(context.Background(), scenario)
+	assert.NoError(t, err)
+	actualScenario, err := ocs.GetTariffScenario(context.Background(), &orcprotos.Void{})
+	assert.NoError(t, err)
+	assert.Equal(t, "flat", actualScenario.GetName())
+
+	ccr := &gy.CreditControlRequest{
+		SessionID:     "1",
+		Type:          credit_control.CRTUpdate,
+		IMSI:          test.IMSI1,
+		RequestNumber: 1,
+		Credits: []*gy.UsedCredits{{
+			RatingGroup:    1,
+			Type:           gy.QUOTA_EXHAUSTED,
+			RequestedUnits: &lteprotos.RequestedUnits{Total: 1000},
+		}},
+	}
+	done := make(chan interface{}, 1000)
+	assert.NoError(t, gyClient.SendCreditControlRequest(&serverConfig, done, ccr))
+	answer := gy.GetAnswer(done)
+	assert.Equal(t, uint32(diam.Success), answer.ResultCode)
+	assert.Len(t, answer.Credits, 1)
+	assert.Equal(t, uint64(1000), swag.Uint64Value(answer.Credits[0].GrantedUnits.TotalOctets))
+	assert.Equal(t, uint32(30), answer.Credits[0].ValidityTime)
+	assert.Nil(t, answer.Credits[0].FinalUnitIndication)
+
+	// 1000 octets cost 10, the remaining balance only affords 500 final octets
+	ccr.RequestNumber = 2
+	ccr.Credits[0].TotalOctets = 1000
+	done = make(chan interface{}, 1000)
+	assert.NoError(t, gyClient.SendCreditControlRequest(&serverConfig, done, ccr))
+	answer = gy.GetAnswer(done)
+	assert.Equal(t, uint32(diam.Success), answer.ResultCode)
+	assert.Equal(t, uint64(500), swag.Uint64Value(answer.Credits[0].GrantedUnits.TotalOctets))
+	assert.NotNil(t, answer.Credits[0].FinalUnitIndication)
+	assert.Equal(t, fegprotos.FinalUnitAction_Terminate, fegprotos.FinalUnitAction(answer.Credits[0].FinalUnitIndication.FinalAction))
+
+	// The balance is depleted
+	ccr.RequestNumber = 3
+	ccr.Credits[0].TotalOctets = 500
+	done = make(chan interface{}, 1000)
+	assert.NoError(t, gyClient.SendCreditControlRequest(&serverConfig, done, ccr))
+	answer = gy.GetAnswer(done)
+	assert.Equal(t, uint32(mock_ocs.DiameterCreditLimitReached), answer.ResultCode)
+
+	state, err := ocs.GetRatingState(context.Background(), &lteprotos.SubscriberID{Id: test.IMSI1})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), state.GetPools()[0].GetBalance())
+	assert.Equal(t, uint64(15), state.GetUsages()[0].GetCharged())
+	assert.Equal(t, uint64(1500), state.GetUsages()[0].GetUsedUnits())
+
+	// An empty scenario disables the rating engine
+	_, err = ocs.SetTariffScenario(context.Background(), &fegprotos.TariffScenario{})
+	assert.NoError(t, err)
+	_, err = ocs.GetRatingState(context.Background(), &lteprotos.SubscriberID{Id: test.IMSI1})
+	assert.Error(t, err)
+}
+
+// TestOCSRatingEngine_ConcurrentScenarioChange replaces the tariff scenario
+// while CCRs are being rated, run with -race to check the engine access
+func TestOCSRatingEngine_ConcurrentScenarioChange(t *testing.T) {
+	serverConfig := diameter.DiameterServerConfig{DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+		Addr:     "127.0.0.1:0",
+		Protocol: "tcp"},
+	}
+	clientConfig := getClientConfig()
+	ocs := startRatingServer(clientConfig, &serverConfig)
+	gyClient := gy.NewGyClient(clientConfig, &serverConfig, getReAuthHandler(), nil, getGyGlobalConfig("", ""))
+
+	scenario := &fegprotos.TariffScenario{
+		Name: "flat",
+		Tariffs: []*fegprotos.Tariff{
+			{RatingGroup: 1, Unit: fegprotos.Tariff_Volume, UnitSize: 100, Price: 1, MaxGrant: 1000},
+		},
+		Balances: []*fegprotos.SubscriberBalance{
+			{Imsi: test.IMSI1, Pools: []*fegprotos.CreditPool{{Balance: 1000000}}},
+		},
+	}
+	_, err := ocs.SetTariffScenario(context.Background(), scenario)
+	assert.NoError(t, err)
+
+	const requests = 20
+	stop := make(chan struct{})
+	changed := make(chan struct{})
+	go func() {
+		defer close(changed)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			_, err := ocs.SetTariffScenario(context.Background(), scenario)
+			assert.NoError(t, err)
+			_, err = ocs.GetTariffScenario(context.Background(), &orcprotos.Void{})
+			assert.NoError(t, err)
+		}
+	}()
+	for i := uint32(1); i <= requests; i++ {
+		ccr := &gy.CreditControlRequest{
+			SessionID:     "1",
+			Type:          credit_control.CRTUpdate,
+			IMSI:          test.IMSI1,
+			RequestNumber: i,
+			Credits: []*gy.UsedCredits{{
+				RatingGroup:    1,
+				Type:           gy.QUOTA_EXHAUSTED,
+				RequestedUnits: &lteprotos.RequestedUnits{Total: 1000},
+			}},
+		}
+		done := make(chan interface{}, 1000)
+		assert.NoError(t, gyClient.SendCreditControlRequest(&serverConfig, done, ccr))
+		answer := gy.GetAnswer(done)
+		assert.Equal(t, uint32(diam.Success), answer.ResultCode)
+	}
+	close(stop)
+	<-changed
+}
+
+func startRatingServer(
+	client *diameter.DiameterClientConfig,
+	server *diameter.DiameterServerConfig,
+) *mock_ocs.OCSDiamServer {
+	ocs := mock_ocs.NewOCSDiamServer(
+		client,
+		&mock_ocs.OCSConfig{
+			ServerConfig:        server,
+			GyInitMethod:        gy.PerSessionInit,
+			ValidityTime:        60,
+			FinalUnitIndication: mock_ocs.FinalUnitIndication{FinalUnitAction: fegprotos.FinalUnitAction_Terminate},
+		},
+	)
+	lis, err := ocs.StartListener()
+	if err != nil {
+		log.Fatalf("Could not start listener for OCS, %s", err.Error())
+	}
+	server.Addr = lis.Addr().String()
+	go func() {
+		err := ocs.Start(lis)
+		if err != nil {
+			log.Printf("Could not start test OCS server, %s", err.Error())
+		}
+	}()
+	return ocs
+}
@@ -0,0 +1,482 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock_ocs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+	orcprotos "magma/orc8r/lib/go/protos"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/golang/glog"
+)
+
+const (
+	// Tariff-Change-Usage values (RFC 4006 8.27)
+	unitBeforeTariffChange = 0
+	unitAfterTariffChange  = 1
+
+	// CC-Unit-Type values (RFC 4006 8.32)
+	ccUnitTypeTime                 = 0
+	ccUnitTypeTotalOctets          = 2
+	ccUnitTypeServiceSpecificUnits = 5
+
+	// Unit-Value of the G-S-U-Pool-Reference is sent in micro units
+	unitValueExponent = -6
+	unitValueScale    = 1000000
+
+	// unlimitedGrant is granted for free rating groups without max grant
+	unlimitedGrant = math.MaxUint32
+
+	secondsPerDay = 24 * 60 * 60
+)
+
+// ratingCCRMessage holds the parts of a CCR used by the rating engine, it
+// complements ccrMessage with the usage split around tariff changes
+type ratingCCRMessage struct {
+	MSCC []*ratingCredit `avp:"Multiple-Services-Credit-Control"`
+}
+
+type ratingCredit struct {
+	RatingGroup      uint32              `avp:"Rating-Group"`
+	UsedServiceUnits []*ratedServiceUnit `avp:"Used-Service-Unit"`
+}
+
+type ratedServiceUnit struct {
+	TariffChangeUsage    *uint32 `avp:"Tariff-Change-Usage"`
+	Time                 uint32  `avp:"CC-Time"`
+	InputOctets          uint64  `avp:"CC-Input-Octets"`
+	OutputOctets         uint64  `avp:"CC-Output-Octets"`
+	TotalOctets          uint64  `avp:"CC-Total-Octets"`
+	ServiceSpecificUnits uint64  `avp:"CC-Service-Specific-Units"`
+}
+
+// ratedCredit is the rating engine answer for a single rating group
+type ratedCredit struct {
+	ratingGroup         uint32
+	resultCode          uint32
+	unit                protos.Tariff_Unit
+	grantedUnits        uint64
+	final               bool
+	poolID              uint32
+	price               uint64
+	unitSize            uint64
+	tariffTimeChange    time.Time
+	finalUnitIndication *protos.FinalUnitIndication
+}
+
+type ratedUsage struct {
+	protos.RatedUsage
+	// grantedAt is the time of the last grant
+	grantedAt time.Time
+	// tariffTimeChange is the tariff switch time sent with the last grant
+	tariffTimeChange time.Time
+}
+
+type ratingAccount struct {
+	pools  map[uint32]uint64      // map of pool ID to balance
+	usages map[uint32]*ratedUsage // map of rating group to usage
+}
+
+// RatingEngine grants & charges credit according to the tariffs of a scenario.
+// Rating groups sharing a pool draw from the same balance.
+type RatingEngine struct {
+	sync.Mutex
+	scenario *protos.TariffScenario
+	tariffs  map[uint32]*protos.Tariff // map of rating group to tariff
+	accounts map[string]*ratingAccount // map of IMSI to rating account
+	clock    func() time.Time
+}
+
+// NewRatingEngine creates a rating engine with the tariffs & initial balances
+// of the scenario
+func NewRatingEngine(scenario *protos.TariffScenario) (*RatingEngine, error) {
+	engine := &RatingEngine{
+		scenario: scenario,
+		tariffs:  make(map[uint32]*protos.Tariff),
+		accounts: make(map[string]*ratingAccount),
+		clock:    time.Now,
+	}
+	for _, tariff := range scenario.GetTariffs() {
+		if _, ok := engine.tariffs[tariff.GetRatingGroup()]; ok {
+			return nil, fmt.Errorf("Duplicate tariff for rating group %d", tariff.GetRatingGroup())
+		}
+		engine.tariffs[tariff.GetRatingGroup()] = tariff
+	}
+	for _, balance := range scenario.GetBalances() {
+		if len(balance.GetImsi()) == 0 {
+			return nil, fmt.Errorf("Balance with no IMSI in scenario %s", scenario.GetName())
+		}
+		account := engine.getAccount(balance.GetImsi())
+		for _, pool := range balance.GetPools() {
+			account.pools[pool.GetPoolId()] += pool.GetBalance()
+		}
+	}
+	return engine, nil
+}
+
+// LoadTariffScenario reads a tariff scenario from a JSON file
+func LoadTariffScenario(path string) (*protos.TariffScenario, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read tariff scenario %s: %s", path, err)
+	}
+	scenario := &protos.TariffScenario{}
+	err = orcprotos.Unmarshal(content, scenario)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse tariff scenario %s: %s", path, err)
+	}
+	return scenario, nil
+}
+
+// Scenario returns the scenario the engine was created with
+func (e *RatingEngine) Scenario() *protos.TariffScenario {
+	return e.scenario
+}
+
+// State returns the remaining balances & rated usage of a subscriber
+func (e *RatingEngine) State(imsi string) (*protos.RatingState, error) {
+	e.Lock()
+	defer e.Unlock()
+	account, ok := e.accounts[imsi]
+	if !ok {
+		return nil, fmt.Errorf("Could not find rating state for imsi %s", imsi)
+	}
+	state := &protos.RatingState{Imsi: imsi}
+	for poolID, balance := range account.pools {
+		state.Pools = append(state.Pools, &protos.CreditPool{PoolId: poolID, Balance: balance})
+	}
+	sort.Slice(state.Pools, func(i, j int) bool { return state.Pools[i].PoolId < state.Pools[j].PoolId })
+	for ratingGroup, usage := range account.usages {
+		state.Usages = append(state.Usages, &protos.RatedUsage{
+			RatingGroup:  ratingGroup,
+			GrantedUnits: usage.GrantedUnits,
+			UsedUnits:    usage.UsedUnits,
+			Charged:      usage.Charged,
+			FinalUnits:   usage.FinalUnits,
+		})
+	}
+	sort.Slice(state.Usages, func(i, j int) bool { return state.Usages[i].RatingGroup < state.Usages[j].RatingGroup })
+	return state, nil
+}
+
+// Rate charges the reported usage of each rating group & grants new credit
+// for initial & update requests. Rating groups without a tariff are answered
+// with RATING_FAILED, depleted ones with CREDIT_LIMIT_REACHED.
+func (e *RatingEngine) Rate(
+	imsi string,
+	requestType credit_control.CreditRequestType,
+	credits []*ratingCredit,
+) []*ratedCredit {
+	e.Lock()
+	defer e.Unlock()
+	now := e.clock()
+	account := e.getAccount(imsi)
+	// credit granted in this answer, so pooled rating groups don't share the same balance twice
+	reserved := make(map[uint32]uint64)
+	answers := make([]*ratedCredit, 0, len(credits))
+	for _, credit := range credits {
+		tariff, ok := e.tariffs[credit.RatingGroup]
+		if !ok {
+			glog.Errorf("No tariff found for %s:%d", imsi, credit.RatingGroup)
+			answers = append(answers, &ratedCredit{ratingGroup: credit.RatingGroup, resultCode: DiameterRatingFailed})
+			continue
+		}
+		usage := account.getUsage(credit.RatingGroup)
+		for _, usu := range credit.UsedServiceUnits {
+			e.charge(account, tariff, usage, usu, now)
+		}
+		if requestType == credit_control.CRTTerminate {
+			continue
+		}
+		answer := e.grant(account, tariff, usage, reserved[tariff.GetPoolId()], now)
+		if answer.resultCode == diam.Success {
+			reserved[tariff.GetPoolId()] += blocks(answer.grantedUnits, answer.unitSize) * answer.price
+		}
+		answers = append(answers, answer)
+	}
+	return answers
+}
+
+// charge decrements the pool balance by the cost of the reported usage
+func (e *RatingEngine) charge(
+	account *ratingAccount,
+	tariff *protos.Tariff,
+	usage *ratedUsage,
+	usu *ratedServiceUnit,
+	now time.Time,
+) {
+	units := usu.units(tariff.GetUnit())
+	ratedAt := usage.grantedAt
+	if usu.TariffChangeUsage != nil && !usage.tariffTimeChange.IsZero() {
+		switch *usu.TariffChangeUsage {
+		case unitBeforeTariffChange:
+			ratedAt = usage.tariffTimeChange.Add(-time.Second)
+		case unitAfterTariffChange:
+			ratedAt = usage.tariffTimeChange
+		}
+	}
+	if ratedAt.IsZero() {
+		ratedAt = now
+	}
+	cost := blocks(units, getUnitSize(tariff)) * getPrice(tariff, ratedAt)
+	balance := account.pools[tariff.GetPoolId()]
+	if cost > balance {
+		cost = balance
+	}
+	account.pools[tariff.GetPoolId()] = balance - cost
+	usage.UsedUnits += units
+	usage.Charged += cost
+	glog.V(2).Infof("Charged %d for %d units of rating group %d, pool %d balance is %d",
+		cost, units, tariff.GetRatingGroup(), tariff.GetPoolId(), account.pools[tariff.GetPoolId()])
+}
+
+// grant computes the units the remaining balance of the pool can afford at
+// the current price, capped to the max grant of the tariff
+func (e *RatingEngine) grant(
+	account *ratingAccount,
+	tariff *protos.Tariff,
+	usage *ratedUsage,
+	reserved uint64,
+	now time.Time,
+) *ratedCredit {
+	answer := &ratedCredit{
+		ratingGroup:         tariff.GetRatingGroup(),
+		resultCode:          diam.Success,
+		unit:                tariff.GetUnit(),
+		poolID:              tariff.GetPoolId(),
+		price:               getPrice(tariff, now),
+		unitSize:            getUnitSize(tariff),
+		finalUnitIndication: tariff.GetFinalUnitIndication(),
+	}
+	answer.tariffTimeChange, _ = getNextTariffChange(tariff, now)
+
+	maxGrant := tariff.GetMaxGrant()
+	if answer.price == 0 {
+		answer.grantedUnits = maxGrant
+		if maxGrant == 0 {
+			answer.grantedUnits = unlimitedGrant
+		}
+	} else {
+		var balance uint64
+		if available := account.pools[tariff.GetPoolId()]; available > reserved {
+			balance = available - reserved
+		}
+		affordable := balance / answer.price * answer.unitSize
+		answer.grantedUnits = affordable
+		answer.final = true
+		if maxGrant != 0 && maxGrant < affordable {
+			answer.grantedUnits = maxGrant
+			answer.final = false
+		}
+	}
+	if answer.unit == protos.Tariff_Time && answer.grantedUnits > unlimitedGrant {
+		answer.grantedUnits = unlimitedGrant
+	}
+	if answer.grantedUnits == 0 {
+		answer.resultCode = DiameterCreditLimitReached
+		answer.final = true
+	}
+	usage.GrantedUnits += answer.grantedUnits
+	usage.FinalUnits = answer.final
+	usage.grantedAt = now
+	usage.tariffTimeChange = answer.tariffTimeChange
+	return answer
+}
+
+func (e *RatingEngine) getAccount(imsi string) *ratingAccount {
+	account, ok := e.accounts[imsi]
+	if !ok {
+		account = &ratingAccount{
+			pools:  make(map[uint32]uint64),
+			usages: make(map[uint32]*ratedUsage),
+		}
+		e.accounts[imsi] = account
+	}
+	return account
+}
+
+func (account *ratingAccount) getUsage(ratingGroup uint32) *ratedUsage {
+	usage, ok := account.usages[ratingGroup]
+	if !ok {
+		usage = &ratedUsage{}
+		account.usages[ratingGroup] = usage
+	}
+	return usage
+}
+
+// units returns the used units in the unit of the tariff
+func (usu *ratedServiceUnit) units(unit protos.Tariff_Unit) uint64 {
+	switch unit {
+	case protos.Tariff_Time:
+		return uint64(usu.Time)
+	case protos.Tariff_Event:
+		return usu.ServiceSpecificUnits
+	default:
+		if usu.TotalOctets != 0 {
+			return usu.TotalOctets
+		}
+		return usu.InputOctets + usu.OutputOctets
+	}
+}
+
+func getUnitSize(tariff *protos.Tariff) uint64 {
+	if tariff.GetUnitSize() == 0 {
+		return 1
+	}
+	return tariff.GetUnitSize()
+}
+
+// getPrice returns the price of a unit block at the given time, which is the
+// price of the latest period started that day (or the day before)
+func getPrice(tariff *protos.Tariff, at time.Time) uint64 {
+	periods := tariff.GetPeriods()
+	if len(periods) == 0 {
+		return tariff.GetPrice()
+	}
+	secs := secondsSinceMidnight(at)
+	var current, last *protos.TariffPeriod
+	for _, period := range periods {
+		if last == nil || period.GetStartTime() > last.GetStartTime() {
+			last = period
+		}
+		if period.GetStartTime() <= secs && (current == nil || period.GetStartTime() > current.GetStartTime()) {
+			current = period
+		}
+	}
+	if current == nil {
+		return last.GetPrice()
+	}
+	return current.GetPrice()
+}
+
+// getNextTariffChange returns the start of the next tariff period, if the
+// tariff has more than one
+func getNextTariffChange(tariff *protos.Tariff, at time.Time) (time.Time, bool) {
+	periods := tariff.GetPeriods()
+	if len(periods) < 2 {
+		return time.Time{}, false
+	}
+	secs := secondsSinceMidnight(at)
+	var next, first *protos.TariffPeriod
+	for _, period := range periods {
+		if first == nil || period.GetStartTime() < first.GetStartTime() {
+			first = period
+		}
+		if period.GetStartTime() > secs && (next == nil || period.GetStartTime() < next.GetStartTime()) {
+			next = period
+		}
+	}
+	midnight := at.UTC().Truncate(24 * time.Hour)
+	if next == nil {
+		return midnight.Add(time.Duration(secondsPerDay+first.GetStartTime()) * time.Second), true
+	}
+	return midnight.Add(time.Duration(next.GetStartTime()) * time.Second), true
+}
+
+func secondsSinceMidnight(at time.Time) uint32 {
+	at = at.UTC()
+	return uint32(at.Hour()*3600 + at.Minute()*60 + at.Second())
+}
+
+// blocks returns the number of unit blocks needed to cover the units
+func blocks(units, unitSize uint64) uint64 {
+	return (units + unitSize - 1) / unitSize
+}
+
+// toRatedCreditAVP converts a rating engine answer to a MSCC AVP, the OCS
+// final unit indication is used if the tariff has none
+func toRatedCreditAVP(credit *ratedCredit, validityTime uint32, defaultFUI FinalUnitIndication) *diam.AVP {
+	creditGroup := &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(credit.ratingGroup)),
+			diam.NewAVP(avp.ResultCode, avp.Mbit, 0, datatype.Unsigned32(credit.resultCode)),
+		},
+	}
+	if credit.resultCode != diam.Success {
+		return diam.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, creditGroup)
+	}
+	gsu := []*diam.AVP{}
+	switch credit.unit {
+	case protos.Tariff_Time:
+		gsu = append(gsu, diam.NewAVP(avp.CCTime, avp.Mbit, 0, datatype.Unsigned32(credit.grantedUnits)))
+	case protos.Tariff_Event:
+		gsu = append(gsu, diam.NewAVP(avp.CCServiceSpecificUnits, avp.Mbit, 0, datatype.Unsigned64(credit.grantedUnits)))
+	default:
+		gsu = append(gsu, diam.NewAVP(avp.CCTotalOctets, avp.Mbit, 0, datatype.Unsigned64(credit.grantedUnits)))
+	}
+	if !credit.tariffTimeChange.IsZero() {
+		gsu = append(gsu, diam.NewAVP(avp.TariffTimeChange, avp.Mbit, 0, datatype.Time(credit.tariffTimeChange)))
+	}
+	creditGroup.AddAVP(diam.NewAVP(avp.GrantedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{AVP: gsu}))
+	creditGroup.AddAVP(diam.NewAVP(avp.ValidityTime, avp.Mbit, 0, datatype.Unsigned32(validityTime)))
+	if credit.poolID != 0 {
+		creditGroup.AddAVP(toGSUPoolReferenceAVP(credit))
+	}
+	if credit.final {
+		fui := credit.finalUnitIndication
+		if fui == nil {
+			fui = &protos.FinalUnitIndication{
+				FinalUnitAction: defaultFUI.FinalUnitAction,
+				RestrictRules:   defaultFUI.RestrictRules,
+				RedirectServer:  &protos.RedirectServer{RedirectServerAddress: defaultFUI.RedirectAddress},
+			}
+		}
+		creditGroup.AddAVP(
+			diam.NewAVP(avp.FinalUnitIndication, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: toFinalUnitActionAVP(
+					fui.GetFinalUnitAction(),
+					fui.GetRedirectServer().GetRedirectServerAddress(),
+					fui.GetRestrictRules()),
+			}),
+		)
+	}
+	return diam.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, creditGroup)
+}
+
+// toGSUPoolReferenceAVP links the grant to its credit pool, the unit value is
+// the price of a single unit so the client can share the pool across units
+func toGSUPoolReferenceAVP(credit *ratedCredit) *diam.AVP {
+	var unitType uint32
+	switch credit.unit {
+	case protos.Tariff_Time:
+		unitType = ccUnitTypeTime
+	case protos.Tariff_Event:
+		unitType = ccUnitTypeServiceSpecificUnits
+	default:
+		unitType = ccUnitTypeTotalOctets
+	}
+	return diam.NewAVP(avp.GSUPoolReference, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.GSUPoolIdentifier, avp.Mbit, 0, datatype.Unsigned32(credit.poolID)),
+			diam.NewAVP(avp.CCUnitType, avp.Mbit, 0, datatype.Enumerated(unitType)),
+			diam.NewAVP(avp.UnitValue, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.ValueDigits, avp.Mbit, 0,
+						datatype.Integer64(credit.price*unitValueScale/credit.unitSize)),
+					diam.NewAVP(avp.Exponent, avp.Mbit, 0, datatype.Integer32(unitValueExponent)),
+				},
+			}),
+		},
+	})
+}
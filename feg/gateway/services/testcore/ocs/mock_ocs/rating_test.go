@@ -0,0 +1,196 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mock_ocs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/services/session_proxy/credit_control"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIMSI = "001010000000001"
+
+func getTestScenario() *protos.TariffScenario {
+	return &protos.TariffScenario{
+		Name: "test",
+		Tariffs: []*protos.Tariff{
+			{
+				RatingGroup: 1,
+				Unit:        protos.Tariff_Volume,
+				UnitSize:    1000,
+				MaxGrant:    10000,
+				Periods: []*protos.TariffPeriod{
+					{StartTime: 8 * 3600, Price: 2},
+					{StartTime: 20 * 3600, Price: 1},
+				},
+			},
+			{
+				RatingGroup: 2,
+				Unit:        protos.Tariff_Time,
+				UnitSize:    60,
+				Price:       5,
+				PoolId:      1,
+				FinalUnitIndication: &protos.FinalUnitIndication{
+					FinalUnitAction: protos.FinalUnitAction_Redirect,
+					RedirectServer:  &protos.RedirectServer{RedirectServerAddress: "http://topup.magma.com"},
+				},
+			},
+			{
+				RatingGroup: 3,
+				Unit:        protos.Tariff_Event,
+				Price:       1,
+				PoolId:      1,
+			},
+		},
+		Balances: []*protos.SubscriberBalance{
+			{
+				Imsi: testIMSI,
+				Pools: []*protos.CreditPool{
+					{PoolId: 0, Balance: 100},
+					{PoolId: 1, Balance: 20},
+				},
+			},
+		},
+	}
+}
+
+func getTestRatingEngine(t *testing.T, now time.Time) *RatingEngine {
+	engine, err := NewRatingEngine(getTestScenario())
+	assert.NoError(t, err)
+	engine.clock = func() time.Time { return now }
+	return engine
+}
+
+func TestRatingEngine_TariffPeriods(t *testing.T) {
+	tariff := getTestScenario().Tariffs[0]
+	day := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, uint64(1), getPrice(tariff, day.Add(7*time.Hour)))
+	assert.Equal(t, uint64(2), getPrice(tariff, day.Add(8*time.Hour)))
+	assert.Equal(t, uint64(1), getPrice(tariff, day.Add(21*time.Hour)))
+
+	next, ok := getNextTariffChange(tariff, day.Add(9*time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, day.Add(20*time.Hour), next)
+	next, ok = getNextTariffChange(tariff, day.Add(21*time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, day.Add(32*time.Hour), next)
+
+	_, ok = getNextTariffChange(getTestScenario().Tariffs[1], day)
+	assert.False(t, ok)
+}
+
+func TestRatingEngine_VolumeGrantAndCharge(t *testing.T) {
+	now := time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+	engine := getTestRatingEngine(t, now)
+
+	answers := engine.Rate(testIMSI, credit_control.CRTInit, []*ratingCredit{{RatingGroup: 1}})
+	assert.Len(t, answers, 1)
+	assert.Equal(t, uint32(diam.Success), answers[0].resultCode)
+	assert.Equal(t, uint64(10000), answers[0].grantedUnits)
+	assert.False(t, answers[0].final)
+	assert.Equal(t, now.Truncate(24*time.Hour).Add(20*time.Hour), answers[0].tariffTimeChange)
+
+	// 2500 octets are 3 blocks at the day price
+	usu := &ratedServiceUnit{TotalOctets: 2500}
+	engine.Rate(testIMSI, credit_control.CRTUpdate, []*ratingCredit{{RatingGroup: 1, UsedServiceUnits: []*ratedServiceUnit{usu}}})
+	state, err := engine.State(testIMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(94), state.Pools[0].Balance)
+	assert.Equal(t, uint64(6), state.Usages[0].Charged)
+	assert.Equal(t, uint64(2500), state.Usages[0].UsedUnits)
+}
+
+func TestRatingEngine_TariffChangeUsage(t *testing.T) {
+	now := time.Date(2020, 6, 1, 19, 0, 0, 0, time.UTC)
+	engine := getTestRatingEngine(t, now)
+	engine.Rate(testIMSI, credit_control.CRTInit, []*ratingCredit{{RatingGroup: 1}})
+
+	engine.clock = func() time.Time { return now.Add(2 * time.Hour) }
+	before, after := uint32(unitBeforeTariffChange), uint32(unitAfterTariffChange)
+	engine.Rate(testIMSI, credit_control.CRTUpdate, []*ratingCredit{{
+		RatingGroup: 1,
+		UsedServiceUnits: []*ratedServiceUnit{
+			{TotalOctets: 1000, TariffChangeUsage: &before},
+			{TotalOctets: 3000, TariffChangeUsage: &after},
+		},
+	}})
+	state, err := engine.State(testIMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), state.Usages[0].Charged)
+}
+
+func TestRatingEngine_PoolDepletion(t *testing.T) {
+	engine := getTestRatingEngine(t, time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC))
+
+	// Both rating groups share pool 1, the first grant reserves the whole balance
+	answers := engine.Rate(testIMSI, credit_control.CRTInit, []*ratingCredit{{RatingGroup: 2}, {RatingGroup: 3}})
+	assert.Len(t, answers, 2)
+	assert.Equal(t, uint32(diam.Success), answers[0].resultCode)
+	assert.Equal(t, uint64(240), answers[0].grantedUnits)
+	assert.True(t, answers[0].final)
+	assert.Equal(t, protos.FinalUnitAction_Redirect, answers[0].finalUnitIndication.GetFinalUnitAction())
+	assert.Equal(t, uint32(DiameterCreditLimitReached), answers[1].resultCode)
+
+	// Using more than the balance floors it at zero
+	usu := &ratedServiceUnit{Time: 300}
+	answers = engine.Rate(testIMSI, credit_control.CRTUpdate, []*ratingCredit{{RatingGroup: 2, UsedServiceUnits: []*ratedServiceUnit{usu}}})
+	assert.Equal(t, uint32(DiameterCreditLimitReached), answers[0].resultCode)
+	state, err := engine.State(testIMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), state.Pools[1].Balance)
+	assert.Equal(t, uint64(20), state.Usages[0].Charged)
+	assert.True(t, state.Usages[0].FinalUnits)
+
+	// The main balance is untouched
+	assert.Equal(t, uint64(100), state.Pools[0].Balance)
+}
+
+func TestRatingEngine_UnknownRatingGroup(t *testing.T) {
+	engine := getTestRatingEngine(t, time.Now())
+	answers := engine.Rate(testIMSI, credit_control.CRTInit, []*ratingCredit{{RatingGroup: 42}})
+	assert.Equal(t, uint32(DiameterRatingFailed), answers[0].resultCode)
+
+	_, err := engine.State("001010000000002")
+	assert.Error(t, err)
+}
+
+func TestLoadTariffScenario(t *testing.T) {
+	f, err := ioutil.TempFile("", "tariff_scenario")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{
+		"name": "night",
+		"tariffs": [{"rating_group": 1, "unit": "Time", "price": 3}],
+		"balances": [{"imsi": "001010000000001", "pools": [{"balance": 30}]}]
+	}`)
+	assert.NoError(t, err)
+	f.Close()
+
+	scenario, err := LoadTariffScenario(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "night", scenario.GetName())
+	assert.Equal(t, protos.Tariff_Time, scenario.GetTariffs()[0].GetUnit())
+	assert.Equal(t, uint64(30), scenario.GetBalances()[0].GetPools()[0].GetBalance())
+
+	_, err = LoadTariffScenario(f.Name() + "_missing")
+	assert.Error(t, err)
+}
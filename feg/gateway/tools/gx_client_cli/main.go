@@ -18,8 +18,10 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/services/session_proxy/capture"
 	"magma/feg/gateway/services/session_proxy/credit_control"
 	"magma/feg/gateway/services/session_proxy/credit_control/gx"
 )
@@ -31,16 +33,21 @@ const (
 )
 
 var (
-	imsi     string
-	sid      string
-	ueIP     string
-	commands string
-	wait     bool
-	help     bool
-	msisdn   string
-	apn      string
-	plmn     string
-	serverid int
+	imsi         string
+	sid          string
+	ueIP         string
+	commands     string
+	wait         bool
+	help         bool
+	msisdn       string
+	apn          string
+	plmn         string
+	serverid     int
+	replay       string
+	replayIMSI   string
+	replaySID    string
+	replayNewSID bool
+	replayIgnore string
 )
 
 type cliConfig struct {
@@ -70,10 +77,16 @@ func init() {
 	flag.StringVar(&apn, "apn", "TestMagma", "apn")
 	flag.StringVar(&plmn, "plmn", "72207", "PLMN ID")
 	flag.IntVar(&serverid, "serverid", 0, "Index of one of the configured servers")
+	flag.StringVar(&replay, "replay", "", "session_proxy capture file to replay the Gx CCRs of instead of running commands")
+	flag.StringVar(&replayIMSI, "replay_imsi", "", "Only replay the captured sessions of this IMSI")
+	flag.StringVar(&replaySID, "replay_sid", "", "Only replay the captured session with this diameter session ID")
+	flag.BoolVar(&replayNewSID, "replay_new_sid", false, "Replay the captured sessions with new session IDs")
+	flag.StringVar(&replayIgnore, "replay_ignore", "", "AVPs to ignore when comparing the answers (comma separated)")
 
 	// Flag help
 	allFlags := []string{"help", "imsi", "sid", "ue_ip", "commands", "wait", "addr", "network", "host",
-		"realm", "product", "laddr", "dest_host", "dest_realm", "msisdn", "apn", "plmn", "serverid"}
+		"realm", "product", "laddr", "dest_host", "dest_realm", "msisdn", "apn", "plmn", "serverid",
+		"replay", "replay_imsi", "replay_sid", "replay_new_sid", "replay_ignore"}
 	flag.Usage = func() {
 		fmt.Println("Gx Client CLI for testing Gx Diameter CCR calls.")
 		fmt.Println("Usage:\n	gx_client_cli")
@@ -88,7 +101,8 @@ func init() {
 // either loaded from preexisting environment variables or specified through command line
 // flags.
 // Example usage:
-//   gx_client_cli --imsi=001010000000001 --sid="1234" --commands="I"
+//
+//	gx_client_cli --imsi=001010000000001 --sid="1234" --commands="I"
 func main() {
 	flag.Parse()
 
@@ -104,6 +118,10 @@ func main() {
 	clientCfg := gx.GetGxClientConfiguration()[serverid]
 	fmt.Printf("Client config: %+v\n", clientCfg)
 
+	if len(replay) > 0 {
+		os.Exit(replayCapture(capture.InterfaceGx, clientCfg, serverCfg))
+	}
+
 	globalCfg := gx.GetGxGlobalConfig()
 
 	config := &cliConfig{
@@ -193,3 +211,33 @@ func sendTerminateCall(config *cliConfig, requestNumber uint32) {
 func handleReAuth(request *gx.PolicyReAuthRequest) *gx.PolicyReAuthAnswer {
 	return nil
 }
+
+// replayCapture replays the captured Gx CCRs against the PCRF, prints how its answers
+// differ from the captured ones & returns the exit code
+func replayCapture(iface string, clientCfg *diameter.DiameterClientConfig, serverCfg *diameter.DiameterServerConfig) int {
+	opts := &capture.ReplayOptions{
+		IMSI:          strings.TrimPrefix(replayIMSI, "IMSI"),
+		SessionID:     replaySID,
+		NewSessionIDs: replayNewSID,
+	}
+	if len(replayIgnore) > 0 {
+		opts.IgnoredAVPs = strings.Split(replayIgnore, ",")
+	}
+	results, err := capture.ReplayFile(replay, iface, clientCfg, serverCfg, opts)
+	if err != nil {
+		fmt.Printf("Error replaying %s: %s\n", replay, err)
+		return 1
+	}
+	mismatches := 0
+	for _, result := range results {
+		fmt.Println(result)
+		if !result.Matches() {
+			mismatches++
+		}
+	}
+	fmt.Printf("Replayed %d requests, %d answers differ or failed\n", len(results), mismatches)
+	if mismatches > 0 {
+		return 1
+	}
+	return 0
+}
@@ -17,8 +17,11 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"magma/feg/cloud/go/protos"
 	"magma/feg/gateway/registry"
@@ -59,6 +62,11 @@ var (
 	apnMaxBandwidthDl          uint
 	pdn                        int
 	anid                       int
+	dsrFlags                   uint
+	contextIDs                 string
+	fileFormat                 = "csv"
+	overwrite                  bool
+	outputFile                 string
 )
 
 func main() {
@@ -86,7 +94,9 @@ func main() {
 	}
 	args := os.Args[2:]
 	cmd.Flags().Parse(args)
-	if len(subscriberID) == 0 {
+	// Bulk commands apply to all subscribers
+	isBulkCmd := cmdName == "IMPORT" || cmdName == "EXPORT"
+	if len(subscriberID) == 0 && !isBulkCmd {
 		println("Error: Subscriber ID missing")
 		cmd.Usage()
 		os.Exit(1)
@@ -182,6 +192,163 @@ func deregisterSubscriber(_ *commands.Command, _ []string) int {
 	return 0
 }
 
+// insertSubscriberData handles the IDR command (sends the subscriber's profile to the serving MME)
+func insertSubscriberData(_ *commands.Command, _ []string) int {
+	client, err := connectToHss()
+	if err != nil {
+		fmt.Printf("Failed to connect to hss: %v\n", err)
+		return 1
+	}
+	id := &lteprotos.SubscriberID{Id: subscriberID}
+	_, err = client.InsertSubscriberData(context.Background(), id)
+	if err != nil {
+		fmt.Printf("Failed to insert subscriber data: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// deleteSubscriberData handles the DSR command (deletes subscription data from the serving MME)
+func deleteSubscriberData(_ *commands.Command, _ []string) int {
+	client, err := connectToHss()
+	if err != nil {
+		fmt.Printf("Failed to connect to hss: %v\n", err)
+		return 1
+	}
+	req := &protos.DeleteSubscriberDataRequest{UserName: subscriberID, DsrFlags: uint32(dsrFlags)}
+	for _, contextID := range strings.Split(contextIDs, ",") {
+		if len(contextID) == 0 {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSpace(contextID), 10, 32)
+		if err != nil {
+			fmt.Printf("Invalid context identifier '%s': %v\n", contextID, err)
+			return 1
+		}
+		req.ContextIdentifiers = append(req.ContextIdentifiers, uint32(id))
+	}
+	_, err = client.DeleteSubscriberData(context.Background(), req)
+	if err != nil {
+		fmt.Printf("Failed to delete subscriber data: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// reAuthS6bSessions handles the S6B_RAR command (sends a RAR for each PGW session of the subscriber)
+func reAuthS6bSessions(_ *commands.Command, _ []string) int {
+	client, err := connectToHss()
+	if err != nil {
+		fmt.Printf("Failed to connect to hss: %v\n", err)
+		return 1
+	}
+	_, err = client.ReAuthS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: subscriberID})
+	if err != nil {
+		fmt.Printf("Failed to re-authorize S6b sessions: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// abortS6bSessions handles the S6B_ASR command (sends an ASR for each PGW session of the subscriber)
+func abortS6bSessions(_ *commands.Command, _ []string) int {
+	client, err := connectToHss()
+	if err != nil {
+		fmt.Printf("Failed to connect to hss: %v\n", err)
+		return 1
+	}
+	_, err = client.AbortS6BSessions(context.Background(), &lteprotos.SubscriberID{Id: subscriberID})
+	if err != nil {
+		fmt.Printf("Failed to abort S6b sessions: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// importSubscribers handles the IMPORT command (adds the subscribers of a CSV or JSON file)
+func importSubscribers(cmd *commands.Command, _ []string) int {
+	f := cmd.Flags()
+	if f.NArg() != 1 {
+		fmt.Printf("Please provide the subscriber file to import\n\n")
+		cmd.Usage()
+		return 1
+	}
+	format, err := getSubscriberFileFormat()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	content, err := ioutil.ReadFile(f.Arg(0))
+	if err != nil {
+		fmt.Printf("Failed to read subscriber file: %v\n", err)
+		return 1
+	}
+	client, err := connectToHss()
+	if err != nil {
+		fmt.Printf("Failed to connect to hss: %v\n", err)
+		return 1
+	}
+	req := &protos.SubscriberImportRequest{Format: format, Content: content, Overwrite: overwrite}
+	res, err := client.ImportSubscribers(context.Background(), req)
+	if err != nil {
+		fmt.Printf("Failed to import subscribers: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Added %d and updated %d subscribers\n", res.GetAdded(), res.GetUpdated())
+	for _, importErr := range res.GetErrors() {
+		fmt.Printf("\t%s\n", importErr)
+	}
+	if len(res.GetErrors()) > 0 {
+		return 2
+	}
+	return 0
+}
+
+// exportSubscribers handles the EXPORT command (writes all the subscribers to a CSV or JSON file)
+func exportSubscribers(_ *commands.Command, _ []string) int {
+	format, err := getSubscriberFileFormat()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	client, err := connectToHss()
+	if err != nil {
+		fmt.Printf("Failed to connect to hss: %v\n", err)
+		return 1
+	}
+	res, err := client.ExportSubscribers(context.Background(), &protos.SubscriberExportRequest{Format: format})
+	if err != nil {
+		fmt.Printf("Failed to export subscribers: %v\n", err)
+		return 1
+	}
+	if len(outputFile) == 0 {
+		fmt.Printf("%s\n", res.GetContent())
+		return 0
+	}
+	err = ioutil.WriteFile(outputFile, res.GetContent(), 0644)
+	if err != nil {
+		fmt.Printf("Failed to write subscriber file: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Exported %d subscribers to %s\n", res.GetCount(), outputFile)
+	return 0
+}
+
+func getSubscriberFileFormat() (protos.SubscriberFileFormat, error) {
+	switch strings.ToLower(fileFormat) {
+	case "csv":
+		return protos.SubscriberFileFormat_SUBSCRIBER_FILE_CSV, nil
+	case "json":
+		return protos.SubscriberFileFormat_SUBSCRIBER_FILE_JSON, nil
+	default:
+		return 0, fmt.Errorf("Unsupported subscriber file format '%s', must be csv or json", fileFormat)
+	}
+}
+
 func init() {
 	getCmd := cmdRegistry.Add(
 		"GET",
@@ -242,6 +409,82 @@ func init() {
 		deregFlags.PrintDefaults()
 	}
 	deregFlags.StringVar(&subscriberID, "subscriber_id", subscriberID, "IMSI of the subscriber to deregister")
+
+	idrCmd := cmdRegistry.Add(
+		"IDR",
+		"Send the subscriber's profile to the serving MME",
+		insertSubscriberData)
+	idrFlags := idrCmd.Flags()
+	idrFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS] <IMSI>\n", os.Args[0], idrCmd.Name(), idrCmd.Name())
+		idrFlags.PrintDefaults()
+	}
+	idrFlags.StringVar(&subscriberID, "subscriber_id", subscriberID, "IMSI of the subscriber")
+
+	dsrCmd := cmdRegistry.Add(
+		"DSR",
+		"Delete subscription data from the serving MME",
+		deleteSubscriberData)
+	dsrCmdFlags := dsrCmd.Flags()
+	dsrCmdFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS] <IMSI>\n", os.Args[0], dsrCmd.Name(), dsrCmd.Name())
+		dsrCmdFlags.PrintDefaults()
+	}
+	dsrCmdFlags.StringVar(&subscriberID, "subscriber_id", subscriberID, "IMSI of the subscriber")
+	dsrCmdFlags.UintVar(&dsrFlags, "dsr_flags", dsrFlags, "DSR-Flags (3GPP TS 29.272 7.3.25)")
+	dsrCmdFlags.StringVar(&contextIDs, "context_ids", contextIDs, "Comma separated context identifiers of the APNs to delete")
+
+	rarCmd := cmdRegistry.Add(
+		"S6B_RAR",
+		"Re-authorize the subscriber's PGW sessions over S6b",
+		reAuthS6bSessions)
+	rarFlags := rarCmd.Flags()
+	rarFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS] <IMSI>\n", os.Args[0], rarCmd.Name(), rarCmd.Name())
+		rarFlags.PrintDefaults()
+	}
+	rarFlags.StringVar(&subscriberID, "subscriber_id", subscriberID, "IMSI of the subscriber")
+
+	asrCmd := cmdRegistry.Add(
+		"S6B_ASR",
+		"Abort the subscriber's PGW sessions over S6b",
+		abortS6bSessions)
+	asrFlags := asrCmd.Flags()
+	asrFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS] <IMSI>\n", os.Args[0], asrCmd.Name(), asrCmd.Name())
+		asrFlags.PrintDefaults()
+	}
+	asrFlags.StringVar(&subscriberID, "subscriber_id", subscriberID, "IMSI of the subscriber")
+
+	importCmd := cmdRegistry.Add(
+		"IMPORT",
+		"Import subscribers from a CSV or JSON file (imsi, k, opc, amf, sqn, apns)",
+		importSubscribers)
+	importFlags := importCmd.Flags()
+	importFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS] <FILE>\n", os.Args[0], importCmd.Name(), importCmd.Name())
+		importFlags.PrintDefaults()
+	}
+	importFlags.StringVar(&fileFormat, "format", fileFormat, "Subscriber file format: csv/json")
+	importFlags.BoolVar(&overwrite, "overwrite", overwrite, "Update subscribers which already exist")
+
+	exportCmd := cmdRegistry.Add(
+		"EXPORT",
+		"Export all subscribers to a CSV or JSON file",
+		exportSubscribers)
+	exportFlags := exportCmd.Flags()
+	exportFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS]\n", os.Args[0], exportCmd.Name(), exportCmd.Name())
+		exportFlags.PrintDefaults()
+	}
+	exportFlags.StringVar(&fileFormat, "format", fileFormat, "Subscriber file format: csv/json")
+	exportFlags.StringVar(&outputFile, "out", outputFile, "File to write the subscribers to, stdout if not set")
 }
 
 // addSubscriberDataFlags adds all of the flags needed to fill a SubscriberData proto.
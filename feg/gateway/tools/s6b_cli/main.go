@@ -0,0 +1,325 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"magma/feg/cloud/go/protos"
+	"magma/feg/gateway/diameter"
+	"magma/feg/gateway/registry"
+	"magma/feg/gateway/services/s6b_proxy"
+	"magma/feg/gateway/services/s6b_proxy/servicers"
+	"magma/orc8r/cloud/go/tools/commands"
+	orcprotos "magma/orc8r/lib/go/protos"
+)
+
+var (
+	cmdRegistry  = new(commands.Map)
+	config       servicers.S6bProxyConfig
+	imsi         string
+	apn          = "magma.ipv4"
+	pgwHost      string
+	pgwRealm     string
+	pgwAddr      string
+	sessionID    string
+	terminate    bool
+	useRemote    bool
+	loopDelaySec int64
+	ignoreErrors bool
+)
+
+const (
+	DefaultProductName     = "magma"
+	DefaultNetworkProtocol = "sctp"
+)
+
+type s6bClient interface {
+	Authorize(
+		req *protos.S6BAuthorizationRequest) (*protos.S6BAuthorizationAnswer, error)
+	TerminateSession(
+		req *protos.S6BSessionTerminationRequest) (*protos.S6BSessionTerminationAnswer, error)
+}
+
+type s6bProxyCli struct{}
+
+func (s6bProxyCli) Authorize(
+	req *protos.S6BAuthorizationRequest,
+) (*protos.S6BAuthorizationAnswer, error) {
+	return s6b_proxy.Authorize(req)
+}
+
+func (s6bProxyCli) TerminateSession(
+	req *protos.S6BSessionTerminationRequest,
+) (*protos.S6BSessionTerminationAnswer, error) {
+	return s6b_proxy.TerminateSession(req)
+}
+
+type s6bBuiltIn struct {
+	impl protos.S6BProxyServer
+}
+
+func (s s6bBuiltIn) Authorize(
+	req *protos.S6BAuthorizationRequest,
+) (*protos.S6BAuthorizationAnswer, error) {
+	return s.impl.Authorize(context.Background(), req)
+}
+
+func (s s6bBuiltIn) TerminateSession(
+	req *protos.S6BSessionTerminationRequest,
+) (*protos.S6BSessionTerminationAnswer, error) {
+	return s.impl.TerminateSession(context.Background(), req)
+}
+
+func init() {
+	// Enable logging
+	flag.Set("v", "10")             // enable the most verbose logging, can be overwritten by 'v' flag
+	flag.Set("logtostderr", "true") // enable printing to console, can be overwritten by 'logtostderr' flag
+
+	flag.BoolVar(
+		&useRemote,
+		"remote_service",
+		false,
+		"Use remote S6b service (based on the Gateway control proxy configuration)")
+	flag.Int64Var(
+		&loopDelaySec,
+		"loop_delay",
+		0,
+		"Loop request indefinitely with specified delay between requests in seconds (<= 0 value disables looping)")
+	flag.BoolVar(
+		&ignoreErrors,
+		"ignore_errors",
+		false,
+		"Ignore errors & continue requests (only valid with non zero loop_delay)")
+
+	config = servicers.S6bProxyConfig{
+		ClientCfg: &diameter.DiameterClientConfig{
+			ProductName: DefaultProductName,
+		},
+		ServerCfg: &diameter.DiameterServerConfig{DiameterServerConnConfig: diameter.DiameterServerConnConfig{
+			Protocol: DefaultNetworkProtocol,
+		}},
+	}
+	aarCmd := cmdRegistry.Add("AAR", "Send AAR to the 3GPP AAA server/HSS", handleAARCmd)
+	strCmd := cmdRegistry.Add("STR", "Send STR to the 3GPP AAA server/HSS", handleSTRCmd)
+	aarFlags := aarCmd.Flags()
+	strFlags := strCmd.Flags()
+	aarFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS] <IMSI>\n", os.Args[0], aarCmd.Name(), aarCmd.Name())
+		aarFlags.PrintDefaults()
+	}
+	aarFlags.StringVar(
+		&config.ServerCfg.Addr,
+		"aaa_addr",
+		config.ServerCfg.Addr,
+		"3GPP AAA server/HSS address - use to send requests directly to the server")
+	aarFlags.StringVar(
+		&config.ServerCfg.Protocol,
+		"network",
+		config.ServerCfg.Protocol,
+		"3GPP AAA server network: tcp/sctp")
+	aarFlags.StringVar(
+		&config.ServerCfg.LocalAddr,
+		"local_addr",
+		config.ServerCfg.LocalAddr,
+		"s6b client local address to bind to")
+	aarFlags.StringVar(&config.ClientCfg.Host, "origin_host", config.ClientCfg.Host, "s6b origin host")
+	aarFlags.StringVar(&config.ClientCfg.Realm, "origin_realm", config.ClientCfg.Realm, "s6b origin realm")
+	aarFlags.StringVar(&config.ServerCfg.DestHost, "dest_host", config.ServerCfg.DestHost, "s6b destination host")
+	aarFlags.StringVar(
+		&config.ServerCfg.DestRealm,
+		"dest_realm",
+		config.ServerCfg.DestRealm,
+		"s6b destination realm")
+	aarFlags.StringVar(&sessionID, "session_id", sessionID, "S6b session ID, generated if not provided")
+
+	// Use the same flag set for both AAR and STR
+	*strFlags = *aarFlags
+	strFlags.Usage = func() {
+		fmt.Fprintf(os.Stderr, // std Usage() & PrintDefaults() use Stderr
+			"\tUsage: %s [OPTIONS] %s [%s OPTIONS] <IMSI>\n", os.Args[0], strCmd.Name(), strCmd.Name())
+		strFlags.PrintDefaults()
+	}
+
+	aarFlags.StringVar(&apn, "apn", apn, "APN (Service-Selection) to authorize")
+	aarFlags.StringVar(&pgwHost, "pgw_host", pgwHost, "PGW diameter host")
+	aarFlags.StringVar(&pgwRealm, "pgw_realm", pgwRealm, "PGW diameter realm")
+	aarFlags.StringVar(&pgwAddr, "pgw_addr", pgwAddr, "PGW IP address")
+	aarFlags.BoolVar(&terminate, "terminate", terminate, "Send STR after a successful AAR")
+}
+
+func handleAARCmd(cmd *commands.Command, args []string) int {
+	if err := parseImsi(cmd); err != nil {
+		fmt.Printf(err.Error())
+		cmd.Usage()
+		return 1
+	}
+	client, addr, res := getS6bClient()
+	if res != 0 {
+		return res
+	}
+	res = sendAar(addr, client)
+	if res != 0 || !terminate {
+		return res
+	}
+	return sendStr(addr, client)
+}
+
+func handleSTRCmd(cmd *commands.Command, args []string) int {
+	if err := parseImsi(cmd); err != nil {
+		fmt.Printf(err.Error())
+		cmd.Usage()
+		return 1
+	}
+	if len(sessionID) == 0 {
+		fmt.Printf("session_id must be provided for STR\n\n")
+		cmd.Usage()
+		return 1
+	}
+	client, addr, res := getS6bClient()
+	if res != 0 {
+		return res
+	}
+	return sendStr(addr, client)
+}
+
+func getS6bClient() (s6bClient, string, int) {
+	// Use built-in proxy
+	if len(config.ServerCfg.Addr) > 0 {
+		s6bProxyBuiltIn, err := servicers.NewS6bProxy(&config)
+		if err != nil {
+			fmt.Printf(err.Error())
+			return nil, "", 1
+		}
+		return s6bBuiltIn{s6bProxyBuiltIn}, config.ServerCfg.Addr, 0
+	}
+	if useRemote {
+		return s6bProxyCli{}, "<REMOTE Address>", 0
+	}
+	addr, _ := registry.GetServiceAddress(registry.S6B_PROXY)
+	return s6bProxyCli{}, addr, 0
+}
+
+func sendAar(addr string, client s6bClient) int {
+	req := &protos.S6BAuthorizationRequest{
+		SessionId: sessionID,
+		UserName:  imsi,
+		Apn:       apn,
+		Pgw: &protos.S6BPGWIdentity{
+			Host:      pgwHost,
+			Realm:     pgwRealm,
+			IpAddress: pgwAddr,
+		},
+		RatType: servicers.RadioAccessTechnologyType_WLAN,
+	}
+	json, err := orcprotos.MarshalIntern(req)
+	if err != nil {
+		fmt.Printf("Unable to convert request to JSON for printing; Still attempting to send request...")
+	} else {
+		fmt.Printf("Sending AAR to %s:\n%s\n%+#v\n\n", addr, json, *req)
+	}
+	res, err := client.Authorize(req)
+	if err != nil || res == nil {
+		fmt.Printf("Authorize Error: %s\n", err)
+		return 2
+	}
+	sessionID = res.GetSessionId()
+	json, err = orcprotos.MarshalIntern(res)
+	if err != nil {
+		fmt.Printf("Marshal Error %v for result: %+v", err, *res)
+		return 3
+	}
+	fmt.Printf("Received successful AAA:\n%s\n%+v\n", json, *res)
+	return 0
+}
+
+func sendStr(addr string, client s6bClient) int {
+	req := &protos.S6BSessionTerminationRequest{
+		SessionId: sessionID,
+		UserName:  imsi,
+	}
+	json, err := orcprotos.MarshalIntern(req)
+	if err != nil {
+		fmt.Printf("Unable to convert request to JSON for printing; Still attempting to send request...")
+	} else {
+		fmt.Printf("Sending STR to %s:\n%s\n%+#v\n\n", addr, json, *req)
+	}
+	res, err := client.TerminateSession(req)
+	if err != nil || res == nil {
+		fmt.Printf("TerminateSession Error: %s\n", err)
+		return 2
+	}
+	fmt.Printf("Successfully terminated session %s\n", res.GetSessionId())
+	return 0
+}
+
+func main() {
+	flag.Parse()
+	// Init help for all commands
+	flag.Usage = func() {
+		cmd := os.Args[0]
+		fmt.Printf(
+			"\nUsage: \033[1m%s command [OPTIONS]\033[0m\n\n",
+			filepath.Base(cmd))
+		flag.PrintDefaults()
+		fmt.Println("Commands:")
+		cmdRegistry.Usage()
+	}
+	flag.Parse()
+	if useRemote {
+		os.Setenv("USE_REMOTE_S6B_PROXY", "true")
+	} else {
+		os.Setenv("USE_REMOTE_S6B_PROXY", "false")
+	}
+	loopInterval := time.Second * time.Duration(loopDelaySec)
+	for {
+		exitCode, err := cmdRegistry.HandleCommand()
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			flag.Usage()
+		}
+		if loopInterval <= 0 || (exitCode != 0 && (!ignoreErrors)) {
+			os.Exit(exitCode)
+		}
+		time.Sleep(loopInterval)
+	}
+}
+
+func parseImsi(cmd *commands.Command) error {
+	f := cmd.Flags()
+	if f.NArg() != 1 {
+		return fmt.Errorf("Please provide only an IMSI argument - all other parameters should be provided with flags: %+v\n\n", f.Args())
+	}
+	imsi = strings.TrimSpace(f.Arg(0))
+	return validateImsi(imsi)
+}
+
+func validateImsi(imsi string) error {
+	if len(imsi) < 6 || len(imsi) > 15 {
+		return fmt.Errorf("The IMSI specified must be 6 - 15 digits long\n\n")
+	}
+	_, err := strconv.ParseUint(imsi, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid IMSI '%s': %v\n\n", imsi, err)
+	}
+	return nil
+}
@@ -15,20 +15,31 @@ package radius
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/tls"
 	"net"
+	"sync"
 	"time"
 )
 
 // Client is a RADIUS client that can exchange packets with a RADIUS server.
 type Client struct {
-	// Network on which to make the connection. Defaults to "udp".
+	// Network on which to make the connection. Defaults to "udp". Stream
+	// networks ("tcp", "tcp4", "tcp6") use RADIUS over TCP (RFC 6613) or,
+	// if TLSConfig is set, RADIUS over TLS (RadSec, RFC 6614).
 	Net string
 
+	// TLSConfig to use for RadSec connections, the config may include the
+	// client certificate for mutual TLS.
+	TLSConfig *tls.Config
+
 	// Dialer to use when making the outgoing connections.
 	Dialer net.Dialer
 
 	// Interval on which to resend packet (zero or negative value means no
-	// retry).
+	// retry). Packets are never resent over stream connections, the transport
+	// is reliable (RFC 6613 section 2.6.1).
 	Retry time.Duration
 
 	// MaxPacketErrors controls how many packet parsing and validation errors
@@ -40,8 +51,20 @@ type Client struct {
 	// InsecureSkipVerify controls whether the client should skip verifying
 	// response packets received.
 	InsecureSkipVerify bool
+
+	// MaxIdleConns is the maximum number of idle stream connections kept open
+	// per server for reuse, RFC 6614 expects long lived RadSec connections.
+	// If zero, DefaultMaxIdleConns is used. UDP sockets are never reused.
+	MaxIdleConns int
+
+	mu   sync.Mutex
+	idle map[string][]net.Conn // idle stream connections by network and address
 }
 
+// DefaultMaxIdleConns is the default maximum number of idle stream
+// connections kept per server.
+const DefaultMaxIdleConns = 2
+
 // DefaultClient is the RADIUS client used by the Exchange function.
 var DefaultClient = &Client{}
 
@@ -67,6 +90,16 @@ func (c *Client) Exchange(ctx context.Context, packet *Packet, addr string) (*Pa
 	if connNet == "" {
 		connNet = "udp"
 	}
+	// Attributes are not encoded in a stable order, so a Message-Authenticator
+	// must be computed over the final wire format. Over UDP this is only done
+	// for Status-Server, where it is mandatory (RFC 5997 section 3)
+	if _, hasMessageAuthenticator := packet.Lookup(Type(80)); hasMessageAuthenticator &&
+		(isStreamNetwork(connNet) || packet.Code == CodeStatusServer) {
+		refreshMessageAuthenticator(wire, packet.Code, packet.Secret)
+	}
+	if isStreamNetwork(connNet) {
+		return c.exchangeStream(ctx, connNet, packet, wire, addr)
+	}
 
 	conn, err := c.Dialer.DialContext(ctx, connNet, addr)
 	if err != nil {
@@ -128,3 +161,181 @@ func (c *Client) Exchange(ctx context.Context, packet *Packet, addr string) (*Pa
 		return received, nil
 	}
 }
+
+// exchangeStream sends the encoded packet over a stream connection, wrapped
+// with TLS if the client has TLSConfig set, and reads the response. Idle
+// connections to the server are reused, a connection is returned to the idle
+// pool only after a response was read from it, so a late response can't be
+// read as the response of another request.
+func (c *Client) exchangeStream(ctx context.Context, network string, packet *Packet, wire []byte, addr string) (*Packet, error) {
+	key := network + "/" + addr
+	conn, reused := c.getIdleConn(key)
+	if !reused {
+		var err error
+		conn, err = c.dialStream(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+	if _, err := conn.Write(wire); err != nil {
+		conn.Close()
+		if reused && ctx.Err() == nil {
+			// The server may have closed the idle connection, retry once on a
+			// new one
+			return c.exchangeStream(ctx, network, packet, wire, addr)
+		}
+		return nil, err
+	}
+
+	var packetErrorCount int
+	for {
+		incoming, err := ReadStreamPacket(conn)
+		if err != nil {
+			conn.Close()
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+
+		received, err := Parse(incoming, packet.Secret)
+		if err != nil || (!c.InsecureSkipVerify && !IsAuthenticResponse(incoming, wire, packet.Secret)) {
+			packetErrorCount++
+			if c.MaxPacketErrors > 0 && packetErrorCount >= c.MaxPacketErrors {
+				conn.Close()
+				if err == nil {
+					err = &NonAuthenticResponseError{}
+				}
+				return nil, err
+			}
+			continue
+		}
+		conn.SetDeadline(time.Time{})
+		c.putIdleConn(key, conn)
+		return received, nil
+	}
+}
+
+// dialStream opens a new stream connection to the server, completing the TLS
+// handshake for RadSec.
+func (c *Client) dialStream(ctx context.Context, network, addr string) (net.Conn, error) {
+	conn, err := c.Dialer.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	if c.TLSConfig == nil {
+		return conn, nil
+	}
+	tlsConfig := c.TLSConfig
+	if len(tlsConfig.ServerName) == 0 && !tlsConfig.InsecureSkipVerify {
+		tlsConfig = tlsConfig.Clone()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			tlsConfig.ServerName = host
+		}
+	}
+	tlsConn := tls.Client(conn, tlsConfig)
+	if deadline, deadlineSet := ctx.Deadline(); deadlineSet {
+		tlsConn.SetDeadline(deadline)
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+func (c *Client) getIdleConn(key string) (net.Conn, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	conns := c.idle[key]
+	if len(conns) == 0 {
+		return nil, false
+	}
+	conn := conns[len(conns)-1]
+	c.idle[key] = conns[:len(conns)-1]
+	return conn, true
+}
+
+func (c *Client) putIdleConn(key string, conn net.Conn) {
+	maxIdle := c.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdleConns
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.idle == nil {
+		c.idle = map[string][]net.Conn{}
+	}
+	if len(c.idle[key]) >= maxIdle {
+		conn.Close()
+		return
+	}
+	c.idle[key] = append(c.idle[key], conn)
+}
+
+// CloseIdleConnections closes the idle stream connections of the client.
+func (c *Client) CloseIdleConnections() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, conns := range c.idle {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	c.idle = nil
+}
+
+func isStreamNetwork(network string) bool {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		return true
+	}
+	return false
+}
+
+// refreshMessageAuthenticator re-computes the Message-Authenticator attribute
+// of an encoded request in place (RFC 3579 section 3.2, RFC 5176 section 3.3).
+// The Request Authenticator of non Access-Request packets covers the
+// Message-Authenticator, so it is re-computed as well.
+func refreshMessageAuthenticator(wire []byte, code Code, secret []byte) {
+	var requestAuthenticator [16]byte
+	switch code {
+	case CodeAccessRequest, CodeStatusServer:
+		copy(requestAuthenticator[:], wire[4:20])
+	case CodeAccountingRequest, CodeDisconnectRequest, CodeCoARequest:
+	default:
+		return
+	}
+
+	var value []byte
+	for b := wire[20:]; len(b) >= 2 && int(b[1]) >= 2 && int(b[1]) <= len(b); b = b[b[1]:] {
+		if b[0] == 80 && uint16(b[1]) == MessageAuthenticatorAttrLength {
+			value = b[2:MessageAuthenticatorAttrLength]
+			break
+		}
+	}
+	if value == nil {
+		return
+	}
+
+	for i := range value {
+		value[i] = 0
+	}
+	hash := hmac.New(md5.New, secret)
+	hash.Write(wire[:4])
+	hash.Write(requestAuthenticator[:])
+	hash.Write(wire[20:])
+	hash.Sum(value[:0])
+
+	if code != CodeAccessRequest && code != CodeStatusServer {
+		reqAuth := md5.New()
+		reqAuth.Write(wire[:4])
+		reqAuth.Write(requestAuthenticator[:])
+		reqAuth.Write(wire[20:])
+		reqAuth.Write(secret)
+		reqAuth.Sum(wire[4:4:20])
+	}
+}
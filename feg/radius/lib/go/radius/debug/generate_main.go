@@ -1,4 +1,5 @@
 // +build ignore
+
 /*
 Copyright 2020 The Magma Authors.
 
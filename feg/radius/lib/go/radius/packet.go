@@ -15,6 +15,7 @@ package radius
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"encoding/binary"
@@ -110,7 +111,7 @@ func (p *Packet) Encode() ([]byte, error) {
 	p.Attributes.encodeTo(b[20:])
 
 	switch p.Code {
-	case CodeAccessRequest:
+	case CodeAccessRequest, CodeStatusServer:
 		copy(b[4:20], p.Authenticator[:])
 	case CodeAccessAccept, CodeAccessReject, CodeAccountingRequest, CodeAccountingResponse, CodeAccessChallenge, CodeDisconnectRequest, CodeDisconnectACK, CodeDisconnectNAK, CodeCoARequest, CodeCoAACK, CodeCoANAK:
 		hash := md5.New()
@@ -158,6 +159,10 @@ func IsAuthenticRequest(request, secret []byte) bool {
 	switch Code(request[0]) {
 	case CodeAccessRequest:
 		return true
+	case CodeStatusServer:
+		// Status-Server requests are authenticated by their mandatory
+		// Message-Authenticator (RFC 5997 section 3)
+		return isAuthenticMessageAuthenticator(request, secret)
 	case CodeAccountingRequest, CodeDisconnectRequest, CodeCoARequest:
 		hash := md5.New()
 		hash.Write(request[:4])
@@ -171,3 +176,39 @@ func IsAuthenticRequest(request, secret []byte) bool {
 		return false
 	}
 }
+
+// isAuthenticMessageAuthenticator returns if the given request holds a
+// Message-Authenticator attribute which matches the HMAC-MD5 of the request
+// (RFC 3579 section 3.2).
+func isAuthenticMessageAuthenticator(request, secret []byte) bool {
+	length := int(binary.BigEndian.Uint16(request[2:4]))
+	if length < 20 || length > len(request) {
+		return false
+	}
+	request = request[:length]
+
+	offset := -1
+	for i := 20; i+2 <= len(request); {
+		attrLength := int(request[i+1])
+		if attrLength < 2 || i+attrLength > len(request) {
+			return false
+		}
+		if request[i] == 80 {
+			if offset != -1 || attrLength != int(MessageAuthenticatorAttrLength) {
+				return false
+			}
+			offset = i + 2
+		}
+		i += attrLength
+	}
+	if offset == -1 {
+		return false
+	}
+
+	var nul [16]byte
+	hash := hmac.New(md5.New, secret)
+	hash.Write(request[:offset])
+	hash.Write(nul[:])
+	hash.Write(request[offset+16:])
+	return hmac.Equal(hash.Sum(nil), request[offset:offset+16])
+}
@@ -187,6 +187,36 @@ func Test_RFC2865_7_2(t *testing.T) {
 	}
 }
 
+func TestIsAuthenticRequest_StatusServer(t *testing.T) {
+	// Source: https://tools.ietf.org/html/rfc5997#section-6.1
+
+	secret := []byte("xyzzy5461")
+
+	request := []byte{
+		0x0c, 0xda, 0x00, 0x26, 0x8a, 0x54, 0xf4, 0x68, 0x6f, 0xb3, 0x94, 0xc5, 0x28, 0x66, 0xe3, 0x02,
+		0x18, 0x5d, 0x06, 0x23, 0x50, 0x12, 0x5a, 0x66, 0x5e, 0x2e, 0x1e, 0x84, 0x11, 0xf3, 0xe2, 0x43,
+		0x82, 0x20, 0x97, 0xc8, 0x4f, 0xa3,
+	}
+	if !radius.IsAuthenticRequest(request, secret) {
+		t.Fatal("expecting Status-Server with valid Message-Authenticator to be authentic")
+	}
+	if radius.IsAuthenticRequest(request, []byte("wrong")) {
+		t.Fatal("expecting Status-Server with a different secret not to be authentic")
+	}
+
+	tampered := append([]byte(nil), request...)
+	tampered[len(tampered)-1] ^= 0xff
+	if radius.IsAuthenticRequest(tampered, secret) {
+		t.Fatal("expecting Status-Server with invalid Message-Authenticator not to be authentic")
+	}
+
+	missing := append([]byte(nil), request[:20]...)
+	missing[3] = 20
+	if radius.IsAuthenticRequest(missing, secret) {
+		t.Fatal("expecting Status-Server without Message-Authenticator not to be authentic")
+	}
+}
+
 func TestPasswords(t *testing.T) {
 	passwords := []string{
 		"",
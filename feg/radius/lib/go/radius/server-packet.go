@@ -47,7 +47,7 @@ func (r *packetResponseWriter) Write(packet *Packet) error {
 	// because this creates a circular dependecy.
 	_, hasEapMessage := packet.Lookup(Type(79))
 	if hasEapMessage && packet.Code.ImpliesMessageAuthenticatorNeeded() {
-		encoded = r.addMessageAuthenticator(encoded)
+		encoded = addMessageAuthenticator(encoded, r.requestAuthenticator, r.secret)
 	}
 
 	if _, err := r.conn.WriteTo(encoded, r.addr); err != nil {
@@ -63,7 +63,9 @@ func (c Code) ImpliesMessageAuthenticatorNeeded() bool {
 	return c == CodeAccessAccept || c == CodeAccessReject || c == CodeAccessChallenge
 }
 
-func (r *packetResponseWriter) addMessageAuthenticator(encoded []byte) []byte {
+// addMessageAuthenticator adds Message-Authenticator attribute to the encoded
+// response & re-calculates the response authenticator
+func addMessageAuthenticator(encoded []byte, requestAuthenticator [16]byte, secret []byte) []byte {
 	// Fix the size
 	size := binary.BigEndian.Uint16(encoded[2:4]) + MessageAuthenticatorAttrLength
 	binary.BigEndian.PutUint16(encoded[2:4], uint16(size))
@@ -72,7 +74,7 @@ func (r *packetResponseWriter) addMessageAuthenticator(encoded []byte) []byte {
 	zeroedOutMsgAuthenticator := [16]byte{}
 	allBytes := [][]byte{
 		encoded[:4],
-		r.requestAuthenticator[:],
+		requestAuthenticator[:],
 		encoded[20:],
 		[]byte{80, 18},
 		zeroedOutMsgAuthenticator[:],
@@ -83,16 +85,16 @@ func (r *packetResponseWriter) addMessageAuthenticator(encoded []byte) []byte {
 	}
 
 	// Calculate Message Authenticator & Overwrite
-	hash := hmac.New(md5.New, r.secret)
+	hash := hmac.New(md5.New, secret)
 	hash.Write(radiusMsg)
 	encoded = hash.Sum(radiusMsg[:len(radiusMsg)-16])
 
 	// Re-calc the Response Authenticator
 	resAuth := md5.New()
 	resAuth.Write(encoded[:4])
-	resAuth.Write(r.requestAuthenticator[:])
+	resAuth.Write(requestAuthenticator[:])
 	resAuth.Write(encoded[20:])
-	resAuth.Write(r.secret)
+	resAuth.Write(secret)
 	resAuth.Sum(encoded[4:4:20])
 
 	return encoded
@@ -173,14 +175,7 @@ func (s *PacketServer) Serve(conn net.PacketConn) error {
 		}
 		s.mu.Unlock()
 
-		if atomic.AddInt32(&s.activeCount, -1) == 0 {
-			s.mu.Lock()
-			s.shuttingDown = false
-			close(s.running)
-			s.running = nil
-			s.ctx = nil
-			s.mu.Unlock()
-		}
+		s.release()
 	}()
 
 	var buff [MaxPacketLength]byte
@@ -205,6 +200,10 @@ func (s *PacketServer) Serve(conn net.PacketConn) error {
 
 		atomic.AddInt32(&s.activeCount, 1)
 		go func(buff []byte, remoteAddr net.Addr) {
+			// Released on every return, so Shutdown doesn't wait for
+			// dropped packets
+			defer s.release()
+
 			secret, err := s.SecretSource.RADIUSSecret(ctx, remoteAddr)
 			if err != nil {
 				// TODO: log only if server is not shutting down?
@@ -248,15 +247,6 @@ func (s *PacketServer) Serve(conn net.PacketConn) error {
 				activeLock.Lock()
 				delete(active, key)
 				activeLock.Unlock()
-
-				if atomic.AddInt32(&s.activeCount, -1) == 0 {
-					s.mu.Lock()
-					s.shuttingDown = false
-					close(s.running)
-					s.running = nil
-					s.ctx = nil
-					s.mu.Unlock()
-				}
 			}()
 
 			request := Request{
@@ -271,6 +261,19 @@ func (s *PacketServer) Serve(conn net.PacketConn) error {
 	}
 }
 
+// release decrements the count of active listeners & handlers, and marks the
+// server as stopped once there are none left.
+func (s *PacketServer) release() {
+	if atomic.AddInt32(&s.activeCount, -1) == 0 {
+		s.mu.Lock()
+		s.shuttingDown = false
+		close(s.running)
+		s.running = nil
+		s.ctx = nil
+		s.mu.Unlock()
+	}
+}
+
 // ListenAndServe starts a RADIUS server on the address given in s.
 func (s *PacketServer) ListenAndServe() error {
 	if s.Handler == nil {
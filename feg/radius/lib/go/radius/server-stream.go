@@ -0,0 +1,281 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package radius
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+)
+
+// RadSecSecret is the shared secret used for RADIUS over TLS (RFC 6614 section 2.3)
+const RadSecSecret = "radsec"
+
+// ReadStreamPacket reads a single RADIUS packet from a stream connection. The
+// packet boundaries are determined by the packet's Length field (RFC 6613
+// section 2.3).
+func ReadStreamPacket(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	length := int(binary.BigEndian.Uint16(header[2:4]))
+	if length < 20 || length > MaxPacketLength {
+		return nil, errors.New("radius: invalid stream packet length")
+	}
+	buff := make([]byte, length)
+	copy(buff, header[:])
+	if _, err := io.ReadFull(r, buff[4:]); err != nil {
+		return nil, err
+	}
+	return buff, nil
+}
+
+type streamResponseWriter struct {
+	// connection the request was received on
+	conn                 net.Conn
+	requestAuthenticator [16]byte
+	secret               []byte
+	// serializes writes of concurrently handled requests on the same connection
+	mu *sync.Mutex
+}
+
+func (r *streamResponseWriter) Write(packet *Packet) error {
+	encoded, err := packet.Encode()
+	if err != nil {
+		return err
+	}
+
+	_, hasEapMessage := packet.Lookup(Type(79))
+	if hasEapMessage && packet.Code.ImpliesMessageAuthenticatorNeeded() {
+		encoded = addMessageAuthenticator(encoded, r.requestAuthenticator, r.secret)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.conn.Write(encoded)
+	return err
+}
+
+// StreamServer listens for RADIUS requests on stream based protocols: TCP
+// (RFC 6613) or, when TLSConfig is set, TLS (RadSec, RFC 6614).
+type StreamServer struct {
+	// The address on which the server listens. Defaults to :2083 for TLS &
+	// :1812 for TCP.
+	Addr string
+	// The network on which the server listens. Defaults to tcp.
+	Network      string
+	SecretSource SecretSource
+	Handler      Handler
+	// TLSConfig enables RADIUS over TLS when set, the config must include the
+	// server certificate. Client certificate verification (mutual TLS) is
+	// controlled by its ClientAuth & ClientCAs fields.
+	TLSConfig *tls.Config
+
+	// Skip incoming packet authenticity validation.
+	// This should only be set to true for debugging purposes.
+	InsecureSkipVerify bool
+
+	// Channel to indicate when server is listenning and ready to serve requests
+	Ready chan bool
+
+	mu           sync.Mutex
+	shuttingDown bool
+	ctx          context.Context
+	ctxDone      context.CancelFunc
+	listener     net.Listener
+	conns        map[net.Conn]struct{}
+	active       sync.WaitGroup
+}
+
+// Serve accepts incoming connections on the listener & serves RADIUS requests
+// received on them. The listener is wrapped with TLS if TLSConfig is set.
+func (s *StreamServer) Serve(l net.Listener) error {
+	if s.Handler == nil {
+		return errors.New("radius: nil Handler")
+	}
+	if s.SecretSource == nil {
+		return errors.New("radius: nil SecretSource")
+	}
+	if s.TLSConfig != nil {
+		l = tls.NewListener(l, s.TLSConfig)
+	}
+
+	s.mu.Lock()
+	if s.shuttingDown {
+		s.mu.Unlock()
+		return ErrServerShutdown
+	}
+	if s.ctx == nil {
+		s.ctx, s.ctxDone = context.WithCancel(context.Background())
+	}
+	if s.conns == nil {
+		s.conns = make(map[net.Conn]struct{})
+	}
+	s.listener = l
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			if s.shuttingDown {
+				s.mu.Unlock()
+				return nil
+			}
+			s.mu.Unlock()
+
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				continue
+			}
+			return err
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.active.Add(1)
+		go s.serveConn(ctx, conn)
+	}
+}
+
+func (s *StreamServer) serveConn(ctx context.Context, conn net.Conn) {
+	defer func() {
+		conn.Close()
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		s.active.Done()
+	}()
+
+	remoteAddr := conn.RemoteAddr()
+	secret, err := s.SecretSource.RADIUSSecret(ctx, remoteAddr)
+	if err != nil || len(secret) == 0 {
+		return
+	}
+
+	var (
+		writeLock sync.Mutex
+		handlers  sync.WaitGroup
+	)
+	defer handlers.Wait()
+
+	for {
+		buff, err := ReadStreamPacket(conn)
+		if err != nil {
+			// Connection is closed or the stream is out of sync, the
+			// connection must be closed in either case (RFC 6613 section 2.6.4)
+			return
+		}
+		if !s.InsecureSkipVerify && !IsAuthenticRequest(buff, secret) {
+			return
+		}
+		packet, err := Parse(buff, secret)
+		if err != nil {
+			return
+		}
+
+		handlers.Add(1)
+		go func(packet *Packet) {
+			defer handlers.Done()
+			response := streamResponseWriter{
+				conn:                 conn,
+				requestAuthenticator: packet.Authenticator,
+				secret:               secret,
+				mu:                   &writeLock,
+			}
+			request := Request{
+				LocalAddr:  conn.LocalAddr(),
+				RemoteAddr: remoteAddr,
+				Packet:     packet,
+				ctx:        ctx,
+			}
+			s.Handler.ServeRADIUS(&response, &request)
+		}(packet)
+	}
+}
+
+// ListenAndServe starts a RADIUS stream server on the address given in s.
+func (s *StreamServer) ListenAndServe() error {
+	if s.Handler == nil {
+		return errors.New("radius: nil Handler")
+	}
+	if s.SecretSource == nil {
+		return errors.New("radius: nil SecretSource")
+	}
+
+	addrStr := ":1812"
+	if s.TLSConfig != nil {
+		addrStr = ":2083"
+	}
+	if s.Addr != "" {
+		addrStr = s.Addr
+	}
+	network := "tcp"
+	if s.Network != "" {
+		network = s.Network
+	}
+	l, err := net.Listen(network, addrStr)
+	if err != nil {
+		if s.Ready != nil {
+			s.Ready <- false
+		}
+		return err
+	}
+	defer l.Close()
+
+	// Signal server is ready & serving requests
+	if s.Ready != nil {
+		s.Ready <- true
+	}
+	return s.Serve(l)
+}
+
+// Shutdown stops the server. It closes the listener & all open connections,
+// then waits for running handlers to complete.
+//
+// Shutdown returns after all handlers have completed, or when ctx is canceled.
+func (s *StreamServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.listener == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	if !s.shuttingDown {
+		s.shuttingDown = true
+		s.ctxDone()
+		s.listener.Close()
+		for conn := range s.conns {
+			conn.Close()
+		}
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.active.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package radius_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fbc/lib/go/radius"
+	. "fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc2869"
+)
+
+func TestStreamServer_tcp(t *testing.T) {
+	secret := []byte("123456790")
+	RunTestStreamServer(t, nil, &radius.Client{Net: "tcp"}, secret)
+}
+
+func TestStreamServer_tls(t *testing.T) {
+	cert, pool := generateTestCertificate(t)
+	serverTLS := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	client := &radius.Client{
+		Net: "tcp",
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      pool,
+		},
+	}
+	RunTestStreamServer(t, serverTLS, client, []byte(radius.RadSecSecret))
+}
+
+func TestStreamServer_tlsClientCertRequired(t *testing.T) {
+	cert, pool := generateTestCertificate(t)
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := radius.StreamServer{
+		SecretSource: radius.StaticSecretSource([]byte(radius.RadSecSecret)),
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			w.Write(r.Response(radius.CodeAccessAccept))
+		}),
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    pool,
+		},
+	}
+	go server.Serve(l)
+	defer server.Shutdown(context.Background())
+
+	client := radius.Client{Net: "tcp", TLSConfig: &tls.Config{RootCAs: pool}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	packet := radius.New(radius.CodeAccessRequest, []byte(radius.RadSecSecret))
+	if _, err := client.Exchange(ctx, packet, l.Addr().String()); err == nil {
+		t.Fatal("expected exchange without client certificate to fail")
+	}
+}
+
+// countingListener counts the accepted connections
+type countingListener struct {
+	net.Listener
+	accepted int32
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err == nil {
+		atomic.AddInt32(&l.accepted, 1)
+	}
+	return conn, err
+}
+
+func RunTestStreamServer(t *testing.T, serverTLS *tls.Config, client *radius.Client, secret []byte) {
+	tcpListener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &countingListener{Listener: tcpListener}
+	server := radius.StreamServer{
+		SecretSource: radius.StaticSecretSource(secret),
+		TLSConfig:    serverTLS,
+		Handler: radius.HandlerFunc(func(w radius.ResponseWriter, r *radius.Request) {
+			if UserName_GetString(r.Packet) != "tim" {
+				w.Write(r.Response(radius.CodeAccessReject))
+				return
+			}
+			res := r.Response(radius.CodeAccessAccept)
+			res.Attributes.Add(radius.Type(rfc2869.EAPMessage_Type), []byte{0x1, 0x2})
+			w.Write(res)
+		}),
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.Serve(l)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	// Multiple requests reuse the same connection
+	for i := 0; i < 3; i++ {
+		packet := radius.New(radius.CodeAccessRequest, secret)
+		UserName_SetString(packet, "tim")
+		response, err := client.Exchange(ctx, packet, l.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.Code != radius.CodeAccessAccept {
+			t.Fatalf("expected CodeAccessAccept, got %s", response.Code)
+		}
+		if response.Get(radius.Type(80)) == nil {
+			t.Fatal("Message Authenticator was not generated")
+		}
+	}
+	if accepted := atomic.LoadInt32(&l.accepted); accepted != 1 {
+		t.Fatalf("expected requests to reuse 1 connection, got %d connections", accepted)
+	}
+
+	client.CloseIdleConnections()
+	if err := server.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-serverErr; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func generateTestCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(parsed)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: parsed}, pool
+}
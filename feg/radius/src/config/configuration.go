@@ -35,6 +35,19 @@ type (
 		Type    string                 `json:"type"`
 		Modules []ModuleDescriptor     `json:"modules"`
 		Extra   map[string]interface{} `json:"extra"` // Extra config, per listener
+		TLS     *TLSConfig             `json:"tls"`   // TLS config, for 'tls' (RadSec) listeners
+	}
+
+	// TLSConfig certificates & mutual TLS settings of a RadSec endpoint
+	TLSConfig struct {
+		CertFile string `json:"certFile"` // PEM certificate (chain) of the endpoint
+		KeyFile  string `json:"keyFile"`  // PEM private key of the endpoint
+		CAFile   string `json:"caFile"`   // PEM CA bundle to verify the peer with (system CAs if empty)
+		// RequireClientCert enables mutual TLS on listeners, client certificates are verified using CAFile
+		RequireClientCert  bool   `json:"requireClientCert"`
+		ServerName         string `json:"serverName"`         // expected server name, clients only
+		InsecureSkipVerify bool   `json:"insecureSkipVerify"` // skip peer certificate verification, testing only
+		MinVersion         string `json:"minVersion"`         // minimal TLS version: "1.2" (default) or "1.3"
 	}
 
 	// ServiceTier represents a uniquely identifiable named set of upstream hosts
@@ -0,0 +1,88 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// ServerTLSConfig builds the TLS config of a RadSec listener
+func (c *TLSConfig) ServerTLSConfig() (*tls.Config, error) {
+	if c == nil || c.CertFile == "" || c.KeyFile == "" {
+		return nil, errors.New("TLS listener requires certFile and keyFile")
+	}
+	result, err := c.baseConfig()
+	if err != nil {
+		return nil, err
+	}
+	if c.RequireClientCert {
+		if result.RootCAs == nil {
+			return nil, errors.New("requireClientCert requires caFile")
+		}
+		result.ClientCAs = result.RootCAs
+		result.RootCAs = nil
+		result.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return result, nil
+}
+
+// ClientTLSConfig builds the TLS config of a RadSec client, the client
+// certificate is optional
+func (c *TLSConfig) ClientTLSConfig() (*tls.Config, error) {
+	if c == nil {
+		return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+	}
+	result, err := c.baseConfig()
+	if err != nil {
+		return nil, err
+	}
+	result.ServerName = c.ServerName
+	result.InsecureSkipVerify = c.InsecureSkipVerify
+	return result, nil
+}
+
+func (c *TLSConfig) baseConfig() (*tls.Config, error) {
+	result := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch c.MinVersion {
+	case "", "1.2":
+	case "1.3":
+		result.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("unsupported TLS minVersion '%s'", c.MinVersion)
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load TLS certificate")
+		}
+		result.Certificates = []tls.Certificate{cert}
+	}
+	if c.CAFile != "" {
+		caPEM, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read TLS CA file")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in TLS CA file '%s'", c.CAFile)
+		}
+		result.RootCAs = pool
+	}
+	return result, nil
+}
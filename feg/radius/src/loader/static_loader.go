@@ -31,6 +31,7 @@ import (
 	modmagmaacct "fbc/cwf/radius/modules/magmaacct"
 	ofpanalytics "fbc/cwf/radius/modules/ofpanalytics"
 	modproxy "fbc/cwf/radius/modules/proxy"
+	modrules "fbc/cwf/radius/modules/rules"
 	modloopback "fbc/cwf/radius/modules/testloopback"
 	testsessionstorage "fbc/cwf/radius/modules/testsessionstorage"
 	modxwfv3 "fbc/cwf/radius/modules/xwfv3"
@@ -64,6 +65,7 @@ var CWFModuleMap = ModuleNameMap{
 	"eap":                func() modules.Module { return NewModule(modeap.Init, modeap.Handle) },
 	"lbserve":            func() modules.Module { return NewModule(modlbserve.Init, modlbserve.Handle) },
 	"proxy":              func() modules.Module { return NewModule(modproxy.Init, modproxy.Handle) },
+	"rules":              func() modules.Module { return NewModule(modrules.Init, modrules.Handle) },
 	"ofpanalytics":       func() modules.Module { return NewModule(ofpanalytics.Init, ofpanalytics.Handle) },
 	"xwfv3":              func() modules.Module { return NewModule(modxwfv3.Init, modxwfv3.Handle) },
 	"testloopback":       func() modules.Module { return NewModule(modloopback.Init, modloopback.Handle) },
@@ -76,6 +78,16 @@ var CWFModuleMap = ModuleNameMap{
 	"testsessionstorage": func() modules.Module { return NewModule(testsessionstorage.Init, testsessionstorage.Handle) },
 }
 
+func init() {
+	// The rules module routes requests to chains of the modules above
+	modrules.ModuleLoader = func(name string) (modules.Module, error) {
+		if mod, ok := CWFModuleMap[name]; ok {
+			return mod(), nil
+		}
+		return nil, fmt.Errorf("failed to create module %s", name)
+	}
+}
+
 var CWFFilterMap = FilterNameMap{
 	"lballocate": func() filters.Filter { return NewFilter(filtlballocate.Init, filtlballocate.Process) },
 	"lbcanary":   func() filters.Filter { return NewFilter(filtlbcanary.Init, filtlbcanary.Process) },
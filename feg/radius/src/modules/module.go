@@ -60,6 +60,13 @@ type (
 		Handle(m Context, c *RequestContext, r *radius.Request, next Middleware) (*Response, error)
 	}
 
+	// Closer is optionally implemented by a module Context which holds
+	// resources, such as background goroutines, to release when the server
+	// stops
+	Closer interface {
+		Close() error
+	}
+
 	// ModuleInitFunc type for module's Init function
 	ModuleInitFunc func(loggert *zap.Logger, config ModuleConfig) (Context, error)
 
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+
+	"fbc/cwf/radius/monitoring"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	// UpstreamRequest forwarding a request to an upstream server
+	UpstreamRequest = monitoring.NewOperation("proxy_upstream_request")
+
+	// StatusServerProbe Status-Server (RFC 5997) health probe of an upstream server
+	StatusServerProbe = monitoring.NewOperation("proxy_status_server")
+
+	// UpstreamUp 1 when the upstream server is considered healthy, 0 otherwise
+	UpstreamUp = stats.Int64(
+		"proxy_upstream_up",
+		"Upstream server health state",
+		stats.UnitDimensionless,
+	)
+)
+
+func init() {
+	view.Register(&view.View{
+		Name:        "proxy_upstream_up",
+		Measure:     UpstreamUp,
+		Description: "Whether the upstream server is considered healthy",
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{monitoring.UpstreamTag},
+	})
+}
+
+func recordUpstreamState(address string, up bool) {
+	var value int64
+	if up {
+		value = 1
+	}
+	stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Upsert(monitoring.UpstreamTag, address)},
+		UpstreamUp.M(value),
+	)
+}
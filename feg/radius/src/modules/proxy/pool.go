@@ -0,0 +1,282 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"fbc/cwf/radius/monitoring"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+
+	"github.com/patrickmn/go-cache"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+)
+
+// Stickiness modes
+const (
+	// StickinessNone every request is balanced independently
+	StickinessNone = ""
+	// StickinessState requests carrying a State attribute are sent to the
+	// upstream which issued it (e.g. EAP conversations)
+	StickinessState = "state"
+	// StickinessCallingStationID requests of the same Calling-Station-Id are
+	// sent to the same upstream, State stickiness applies as well
+	StickinessCallingStationID = "calling_station_id"
+)
+
+var errNoUpstreamAvailable = errors.New("no upstream server available")
+
+// upstream a single RADIUS server of the pool & its health state
+type upstream struct {
+	address  string
+	secret   []byte // nil when the secret of the incoming request is used
+	client   *radius.Client
+	weight   uint
+	priority uint
+	probed   bool // health is determined by Status-Server probes
+
+	mu        sync.Mutex
+	up        bool
+	failures  int
+	downSince time.Time
+	probing   bool // a Status-Server probe is in flight
+}
+
+// pool a set of upstream servers, balanced by priority & weight
+type pool struct {
+	upstreams        []*upstream
+	timeout          time.Duration
+	maxAttempts      int
+	failureThreshold int
+	deadTime         time.Duration
+	stickiness       string
+	sticky           *cache.Cache
+	logger           *zap.Logger
+	done             chan struct{} // closed to stop the Status-Server probes
+	closeOnce        sync.Once
+}
+
+// isAvailable returns whether the upstream should be picked for new requests.
+// Upstreams which are not probed are given another chance after deadTime.
+func (u *upstream) isAvailable(deadTime time.Duration) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.up {
+		return true
+	}
+	return !u.probed && deadTime > 0 && time.Since(u.downSince) >= deadTime
+}
+
+// recordFailure counts a failure, returns true if the upstream is now down
+func (u *upstream) recordFailure(threshold int) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.failures++
+	if u.up && u.failures >= threshold {
+		u.up = false
+		u.downSince = time.Now()
+		return true
+	}
+	if !u.up {
+		// Restart the dead time of a revived upstream which failed again
+		u.downSince = time.Now()
+	}
+	return false
+}
+
+// recordSuccess resets the failure count, returns true if the upstream was down
+func (u *upstream) recordSuccess() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.failures = 0
+	if !u.up {
+		u.up = true
+		return true
+	}
+	return false
+}
+
+// startProbe returns true if no Status-Server probe of the upstream is in
+// flight, the caller then probes the upstream & calls endProbe when done
+func (u *upstream) startProbe() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.probing {
+		return false
+	}
+	u.probing = true
+	return true
+}
+
+func (u *upstream) endProbe() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.probing = false
+}
+
+// exchange forwards the packet to the pool, retransmitting to alternate
+// upstreams on timeouts & errors
+func (p *pool) exchange(packet *radius.Packet) (*radius.Packet, error) {
+	stickyKeys := p.stickyKeys(packet)
+	tried := make(map[*upstream]bool, len(p.upstreams))
+	lastErr := errNoUpstreamAvailable
+	for attempt := 0; attempt < p.maxAttempts; attempt++ {
+		var u *upstream
+		if attempt == 0 {
+			u = p.stickyUpstream(stickyKeys)
+		}
+		if u == nil {
+			u = p.pick(tried)
+		}
+		if u == nil {
+			break
+		}
+		tried[u] = true
+
+		forwarded := packet
+		if u.secret != nil {
+			var err error
+			forwarded, err = resecret(packet, u.secret)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		counter := UpstreamRequest.Start(tag.Upsert(monitoring.UpstreamTag, u.address))
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		res, err := u.client.Exchange(ctx, forwarded, u.address)
+		cancel()
+		if err != nil {
+			counter.Failure("exchange_failed")
+			p.logger.Warn(
+				"failed to forward request to upstream",
+				zap.String("upstream", u.address),
+				zap.Int("attempt", attempt+1),
+				zap.Error(err),
+			)
+			if u.recordFailure(p.failureThreshold) {
+				p.logger.Warn("upstream marked down", zap.String("upstream", u.address))
+				recordUpstreamState(u.address, false)
+			}
+			lastErr = err
+			continue
+		}
+		counter.Success(tag.Upsert(monitoring.ResponseCodeTag, res.Code.String()))
+		if u.recordSuccess() {
+			p.logger.Info("upstream marked up", zap.String("upstream", u.address))
+			recordUpstreamState(u.address, true)
+		}
+		p.stick(stickyKeys, res, u)
+		return res, nil
+	}
+	return nil, lastErr
+}
+
+// pick selects an upstream that was not tried yet: the available upstreams of
+// the best (lowest) priority are balanced by weight. If no upstream is
+// available, unavailable ones are tried as a last resort.
+func (p *pool) pick(tried map[*upstream]bool) *upstream {
+	var available, unavailable []*upstream
+	for _, u := range p.upstreams {
+		if tried[u] {
+			continue
+		}
+		if u.isAvailable(p.deadTime) {
+			available = append(available, u)
+		} else {
+			unavailable = append(unavailable, u)
+		}
+	}
+	if len(available) == 0 {
+		available = unavailable
+	}
+	if len(available) == 0 {
+		return nil
+	}
+
+	var candidates []*upstream
+	for _, u := range available {
+		switch {
+		case len(candidates) == 0 || u.priority < candidates[0].priority:
+			candidates = []*upstream{u}
+		case u.priority == candidates[0].priority:
+			candidates = append(candidates, u)
+		}
+	}
+
+	var totalWeight uint
+	for _, u := range candidates {
+		totalWeight += u.weight
+	}
+	if totalWeight == 0 {
+		return candidates[rand.Intn(len(candidates))]
+	}
+	n := uint(rand.Int63n(int64(totalWeight)))
+	for _, u := range candidates {
+		if n < u.weight {
+			return u
+		}
+		n -= u.weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+// stickyKeys returns the keys under which the request may be bound to an upstream
+func (p *pool) stickyKeys(packet *radius.Packet) []string {
+	var keys []string
+	if p.stickiness == StickinessNone {
+		return keys
+	}
+	if state := rfc2865.State_Get(packet); len(state) > 0 {
+		keys = append(keys, "state:"+string(state))
+	}
+	if p.stickiness == StickinessCallingStationID {
+		if csid := rfc2865.CallingStationID_GetString(packet); csid != "" {
+			keys = append(keys, "csid:"+csid)
+		}
+	}
+	return keys
+}
+
+// stickyUpstream returns the available upstream the request is bound to, if any
+func (p *pool) stickyUpstream(keys []string) *upstream {
+	for _, key := range keys {
+		if value, found := p.sticky.Get(key); found {
+			u := value.(*upstream)
+			if u.isAvailable(p.deadTime) {
+				return u
+			}
+		}
+	}
+	return nil
+}
+
+// stick binds the request & the State returned in its response to the upstream
+func (p *pool) stick(keys []string, res *radius.Packet, u *upstream) {
+	if p.stickiness == StickinessNone {
+		return
+	}
+	for _, key := range keys {
+		p.sticky.SetDefault(key, u)
+	}
+	if state := rfc2865.State_Get(res); len(state) > 0 {
+		p.sticky.SetDefault("state:"+string(state), u)
+	}
+}
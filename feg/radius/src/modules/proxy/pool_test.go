@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var poolTestSecret = []byte("pool_secret")
+
+// spawnUpstream starts a UDP RADIUS server which identifies itself in the
+// Reply-Message of its responses, unless silent
+func spawnUpstream(t *testing.T, name string, silent bool) (string, func()) {
+	conn, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+	server := radius.PacketServer{
+		Handler: radius.HandlerFunc(
+			func(w radius.ResponseWriter, r *radius.Request) {
+				if silent {
+					return
+				}
+				if r.Code == radius.CodeStatusServer {
+					w.Write(r.Response(radius.CodeAccessAccept))
+					return
+				}
+				resp := r.Response(radius.CodeAccessChallenge)
+				rfc2865.ReplyMessage_SetString(resp, name)
+				rfc2865.State_SetString(resp, "state_"+name)
+				w.Write(resp)
+			},
+		),
+		SecretSource: radius.StaticSecretSource(poolTestSecret),
+	}
+	go server.Serve(conn)
+	return conn.LocalAddr().String(), func() { server.Shutdown(context.Background()) }
+}
+
+func handleWithPool(t *testing.T, mCtx modules.Context, state string) *modules.Response {
+	req := createRadiusRequest("called", "calling")
+	req.Packet.Secret = poolTestSecret
+	if state != "" {
+		rfc2865.State_SetString(req.Packet, state)
+	}
+	res, err := Handle(mCtx, &modules.RequestContext{}, req, nil)
+	require.NoError(t, err)
+	return res
+}
+
+func TestPoolFailover(t *testing.T) {
+	silentAddr, stopSilent := spawnUpstream(t, "silent", true)
+	defer stopSilent()
+	backupAddr, stopBackup := spawnUpstream(t, "backup", false)
+	defer stopBackup()
+
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Upstreams": []map[string]interface{}{
+			{"Address": silentAddr, "Priority": 0},
+			{"Address": backupAddr, "Priority": 1},
+		},
+		"TimeoutMillis":    200,
+		"FailureThreshold": 1,
+	})
+	require.NoError(t, err)
+
+	// Retransmitted to the backup after the preferred upstream timed out
+	res := handleWithPool(t, mCtx, "")
+	require.Equal(t, "backup", string(res.Attributes[rfc2865.ReplyMessage_Type][0]))
+
+	// Preferred upstream is now down, requests go straight to the backup
+	start := time.Now()
+	res = handleWithPool(t, mCtx, "")
+	require.Equal(t, "backup", string(res.Attributes[rfc2865.ReplyMessage_Type][0]))
+	require.True(t, time.Since(start) < 200*time.Millisecond)
+}
+
+func TestPoolAllUpstreamsFail(t *testing.T) {
+	silentAddr, stopSilent := spawnUpstream(t, "silent", true)
+	defer stopSilent()
+
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Target":        silentAddr,
+		"TimeoutMillis": 100,
+	})
+	require.NoError(t, err)
+	req := createRadiusRequest("called", "calling")
+	_, err = Handle(mCtx, &modules.RequestContext{}, req, nil)
+	require.Error(t, err)
+}
+
+func TestPoolPickWeightsAndPriorities(t *testing.T) {
+	light := &upstream{address: "light", weight: 1, up: true}
+	heavy := &upstream{address: "heavy", weight: 3, up: true}
+	backup := &upstream{address: "backup", weight: 100, priority: 1, up: true}
+	p := &pool{upstreams: []*upstream{light, heavy, backup}}
+
+	picks := map[*upstream]int{}
+	for i := 0; i < 4000; i++ {
+		picks[p.pick(nil)]++
+	}
+	require.Equal(t, 0, picks[backup])
+	require.InDelta(t, 3000, picks[heavy], 200)
+	require.InDelta(t, 1000, picks[light], 200)
+
+	// Lower priority upstream used once preferred upstreams are tried or down
+	require.Equal(t, backup, p.pick(map[*upstream]bool{light: true, heavy: true}))
+	light.up, heavy.up = false, false
+	require.Equal(t, backup, p.pick(nil))
+
+	// Unavailable upstreams are tried as a last resort
+	backup.up = false
+	require.NotNil(t, p.pick(nil))
+	require.Nil(t, p.pick(map[*upstream]bool{light: true, heavy: true, backup: true}))
+}
+
+func TestPoolStateStickiness(t *testing.T) {
+	addr1, stop1 := spawnUpstream(t, "first", false)
+	defer stop1()
+	addr2, stop2 := spawnUpstream(t, "second", false)
+	defer stop2()
+
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Upstreams": []map[string]interface{}{
+			{"Address": addr1, "Weight": 1},
+			{"Address": addr2, "Weight": 1},
+		},
+		"Stickiness": StickinessState,
+	})
+	require.NoError(t, err)
+
+	res := handleWithPool(t, mCtx, "")
+	name := string(res.Attributes[rfc2865.ReplyMessage_Type][0])
+	state := string(res.Attributes[rfc2865.State_Type][0])
+	for i := 0; i < 20; i++ {
+		res = handleWithPool(t, mCtx, state)
+		require.Equal(t, name, string(res.Attributes[rfc2865.ReplyMessage_Type][0]))
+	}
+}
+
+func TestStatusServerProbe(t *testing.T) {
+	addr, stop := spawnUpstream(t, "probed", false)
+	defer stop()
+
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Target":                      addr,
+		"Secret":                      string(poolTestSecret),
+		"StatusServerIntervalSeconds": 3600,
+		"FailureThreshold":            1,
+	})
+	require.NoError(t, err)
+	p := mCtx.(ModuleCtx).pool
+	u := p.upstreams[0]
+	require.True(t, u.probed)
+
+	// Probed upstreams are revived by probes only
+	u.recordFailure(p.failureThreshold)
+	require.False(t, u.isAvailable(0))
+	p.probe(u)
+	require.True(t, u.isAvailable(0))
+
+	// No response to the probe marks the upstream down
+	stop()
+	p.timeout = 100 * time.Millisecond
+	p.probe(u)
+	require.False(t, u.isAvailable(p.deadTime))
+
+	// An upstream whose probe is still in flight isn't probed again
+	failures := u.failures
+	require.True(t, u.startProbe())
+	p.probe(u)
+	require.Equal(t, failures, u.failures)
+	u.endProbe()
+	p.probe(u)
+	require.Equal(t, failures+1, u.failures)
+}
+
+func TestProbeLoopStopsOnClose(t *testing.T) {
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Target": "localhost:1812",
+		"Secret": string(poolTestSecret),
+	})
+	require.NoError(t, err)
+	p := mCtx.(ModuleCtx).pool
+
+	stopped := make(chan struct{})
+	go func() {
+		p.probeLoop(time.Hour)
+		close(stopped)
+	}()
+	require.NoError(t, mCtx.(modules.Closer).Close())
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("probe loop still running after Close")
+	}
+	// Closing twice is harmless
+	require.NoError(t, mCtx.(modules.Closer).Close())
+}
+
+func TestInvalidStickiness(t *testing.T) {
+	_, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Target":     "localhost:1812",
+		"Stickiness": "nas_ip",
+	})
+	require.Error(t, err)
+}
@@ -14,26 +14,81 @@ limitations under the License.
 package proxy
 
 import (
-	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"fbc/cwf/radius/config"
 	"fbc/cwf/radius/modules"
 	"fbc/lib/go/radius"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/patrickmn/go-cache"
 	"go.uber.org/zap"
 )
 
 // Config configuration structure for proxy module
 type Config struct {
+	// Target a single upstream server, shorthand for a pool of one
 	Target string
+	// Network used to reach the target: "udp" (default), "tcp" or "tls" (RadSec)
+	Network string
+	// TLS client settings, for the "tls" network
+	TLS *config.TLSConfig
+	// Secret shared with the target, if it differs from the one of the
+	// listener. Defaults to "radsec" for the "tls" network.
+	Secret string
+
+	// Upstreams pool of upstream servers to balance & fail over between
+	Upstreams []UpstreamConfig
+	// TimeoutMillis time to wait for an upstream response, before
+	// retransmitting the request to an alternate upstream
+	TimeoutMillis uint
+	// MaxAttempts the number of upstreams tried per request, defaults to the
+	// number of upstreams
+	MaxAttempts int
+	// FailureThreshold consecutive failures after which an upstream is
+	// considered down
+	FailureThreshold int
+	// StatusServerIntervalSeconds interval of Status-Server (RFC 5997) probes,
+	// zero disables probing. Only upstreams with a Secret are probed.
+	StatusServerIntervalSeconds uint
+	// DeadTimeSeconds time after which a down upstream, that is not probed, is
+	// tried again
+	DeadTimeSeconds uint
+	// Stickiness binds requests to upstreams: "" (none), "state" or
+	// "calling_station_id"
+	Stickiness string
+	// StickinessTTLSeconds how long a binding is kept after its last use
+	StickinessTTLSeconds uint
+}
+
+// UpstreamConfig configuration of a single upstream server in the pool
+type UpstreamConfig struct {
+	Address string
+	// Network, TLS & Secret default to the module level values
+	Network string
+	TLS     *config.TLSConfig
+	Secret  string
+	// Weight relative share of requests among upstreams of the same priority
+	Weight uint
+	// Priority lower values are preferred, upstreams of higher values are
+	// used only when all preferred upstreams are down
+	Priority uint
 }
 
 // ModuleCtx ...
 type ModuleCtx struct {
-	target string
+	pool *pool
 }
 
+const (
+	defaultTimeoutMillis        = 3000
+	defaultFailureThreshold     = 3
+	defaultDeadTimeSeconds      = 30
+	defaultStickinessTTLSeconds = 60
+)
+
 // Init module interface implementation
 func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
 	var proxyConfig Config
@@ -42,17 +97,116 @@ func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, err
 		return nil, err
 	}
 
-	if proxyConfig.Target == "" {
+	upstreamConfigs := proxyConfig.Upstreams
+	if proxyConfig.Target != "" {
+		upstreamConfigs = append([]UpstreamConfig{{Address: proxyConfig.Target}}, upstreamConfigs...)
+	}
+	if len(upstreamConfigs) == 0 {
 		return nil, errors.New("proxy module cannot be initialize with empty Target value")
 	}
 
-	return ModuleCtx{target: proxyConfig.Target}, nil
+	switch proxyConfig.Stickiness {
+	case StickinessNone, StickinessState, StickinessCallingStationID:
+	default:
+		return nil, fmt.Errorf("proxy module does not support stickiness '%s'", proxyConfig.Stickiness)
+	}
+	if proxyConfig.TimeoutMillis == 0 {
+		proxyConfig.TimeoutMillis = defaultTimeoutMillis
+	}
+	if proxyConfig.FailureThreshold <= 0 {
+		proxyConfig.FailureThreshold = defaultFailureThreshold
+	}
+	if proxyConfig.DeadTimeSeconds == 0 {
+		proxyConfig.DeadTimeSeconds = defaultDeadTimeSeconds
+	}
+	if proxyConfig.StickinessTTLSeconds == 0 {
+		proxyConfig.StickinessTTLSeconds = defaultStickinessTTLSeconds
+	}
+	if proxyConfig.MaxAttempts <= 0 || proxyConfig.MaxAttempts > len(upstreamConfigs) {
+		proxyConfig.MaxAttempts = len(upstreamConfigs)
+	}
+
+	stickinessTTL := time.Duration(proxyConfig.StickinessTTLSeconds) * time.Second
+	p := &pool{
+		timeout:          time.Duration(proxyConfig.TimeoutMillis) * time.Millisecond,
+		maxAttempts:      proxyConfig.MaxAttempts,
+		failureThreshold: proxyConfig.FailureThreshold,
+		deadTime:         time.Duration(proxyConfig.DeadTimeSeconds) * time.Second,
+		stickiness:       proxyConfig.Stickiness,
+		sticky:           cache.New(stickinessTTL, 2*stickinessTTL),
+		logger:           logger,
+		done:             make(chan struct{}),
+	}
+	for _, upstreamConfig := range upstreamConfigs {
+		u, err := newUpstream(proxyConfig, upstreamConfig)
+		if err != nil {
+			return nil, err
+		}
+		u.probed = proxyConfig.StatusServerIntervalSeconds > 0 && u.secret != nil
+		p.upstreams = append(p.upstreams, u)
+		recordUpstreamState(u.address, true)
+	}
+	if proxyConfig.StatusServerIntervalSeconds > 0 {
+		go p.probeLoop(time.Duration(proxyConfig.StatusServerIntervalSeconds) * time.Second)
+	}
+	return ModuleCtx{pool: p}, nil
+}
+
+// Close stops the Status-Server probes of the module
+func (m ModuleCtx) Close() error {
+	m.pool.closeOnce.Do(func() { close(m.pool.done) })
+	return nil
+}
+
+func newUpstream(proxyConfig Config, upstreamConfig UpstreamConfig) (*upstream, error) {
+	if upstreamConfig.Address == "" {
+		return nil, errors.New("proxy module upstream cannot have an empty Address value")
+	}
+	network, tlsConfig, secret := upstreamConfig.Network, upstreamConfig.TLS, upstreamConfig.Secret
+	if network == "" {
+		network = proxyConfig.Network
+	}
+	if tlsConfig == nil {
+		tlsConfig = proxyConfig.TLS
+	}
+	if secret == "" {
+		secret = proxyConfig.Secret
+	}
+
+	u := &upstream{
+		address:  upstreamConfig.Address,
+		client:   &radius.Client{},
+		weight:   upstreamConfig.Weight,
+		priority: upstreamConfig.Priority,
+		up:       true,
+	}
+	switch network {
+	case "", "udp":
+	case "tcp":
+		u.client.Net = "tcp"
+	case "tls":
+		clientTLSConfig, err := tlsConfig.ClientTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		u.client.Net = "tcp"
+		u.client.TLSConfig = clientTLSConfig
+		if secret == "" {
+			secret = radius.RadSecSecret
+		}
+	default:
+		return nil, fmt.Errorf("proxy module does not support network '%s'", network)
+	}
+	if secret != "" {
+		u.secret = []byte(secret)
+	}
+	return u, nil
 }
 
 // Handle module interface implementation
 func Handle(m modules.Context, _ *modules.RequestContext, r *radius.Request, _ modules.Middleware) (*modules.Response, error) {
 	mCtx := m.(ModuleCtx)
-	res, err := radius.Exchange(context.Background(), r.Packet, mCtx.target)
+	res, err := mCtx.pool.exchange(r.Packet)
 	if err != nil {
 		return nil, err
 	}
@@ -62,3 +216,35 @@ func Handle(m modules.Context, _ *modules.RequestContext, r *radius.Request, _ m
 		Attributes: res.Attributes,
 	}, nil
 }
+
+// resecret copies the packet for forwarding with a different secret, the
+// secret dependant User-Password attribute is re-encrypted accordingly. The
+// Message-Authenticator, if any, is re-computed by the client upon sending.
+func resecret(p *radius.Packet, secret []byte) (*radius.Packet, error) {
+	result := &radius.Packet{
+		Code:          p.Code,
+		Identifier:    p.Identifier,
+		Authenticator: p.Authenticator,
+		Secret:        secret,
+		Attributes:    make(radius.Attributes, len(p.Attributes)),
+	}
+	for t, values := range p.Attributes {
+		result.Attributes[t] = append([]radius.Attribute(nil), values...)
+	}
+
+	if password, ok := p.Lookup(userPasswordType); ok && p.Code == radius.CodeAccessRequest {
+		plaintext, err := radius.UserPassword(password, p.Secret, p.Authenticator[:])
+		if err != nil {
+			return nil, err
+		}
+		password, err = radius.NewUserPassword(plaintext, secret, p.Authenticator[:])
+		if err != nil {
+			return nil, err
+		}
+		result.Set(userPasswordType, password)
+	}
+
+	return result, nil
+}
+
+const userPasswordType radius.Type = 2
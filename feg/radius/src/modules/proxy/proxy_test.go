@@ -21,6 +21,7 @@ import (
 	"fbc/lib/go/radius/rfc2865"
 	"fmt"
 	"math/rand"
+	"net"
 	"testing"
 
 	"go.uber.org/zap"
@@ -90,6 +91,67 @@ func TestProxy(t *testing.T) {
 	require.Equal(t, "server_returned_value", string(attr[0]))
 }
 
+func TestProxyTCPWithSecret(t *testing.T) {
+	// Arrange
+	upstreamSecret := []byte("upstream_secret")
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	l, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	mCtx, err := Init(logger, modules.ModuleConfig{
+		"target":  l.Addr().String(),
+		"network": "tcp",
+		"secret":  string(upstreamSecret),
+	})
+	require.NoError(t, err)
+
+	// Spawn a radius stream server, which only knows the upstream secret
+	radiusServer := radius.StreamServer{
+		Handler: radius.HandlerFunc(
+			func(w radius.ResponseWriter, r *radius.Request) {
+				if rfc2865.UserPassword_GetString(r.Packet) != "password12345678" {
+					w.Write(r.Response(radius.CodeAccessReject))
+					return
+				}
+				resp := r.Response(radius.CodeAccessAccept)
+				resp.Add(rfc2865.State_Type, []byte("server_returned_value"))
+				w.Write(resp)
+			},
+		),
+		SecretSource: radius.StaticSecretSource(upstreamSecret),
+	}
+	go radiusServer.Serve(l)
+	defer radiusServer.Shutdown(context.Background())
+
+	// Act
+	req := createRadiusRequest("called", "calling")
+	require.NoError(t, rfc2865.UserPassword_SetString(req.Packet, "password12345678"))
+	res, err := Handle(
+		mCtx,
+		&modules.RequestContext{Logger: logger},
+		req,
+		func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+			require.Fail(t, "Should never be called (proxy module should not call next()")
+			return nil, nil
+		},
+	)
+
+	// Assert
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessAccept, res.Code)
+	require.Equal(t, "server_returned_value", string(res.Attributes[rfc2865.State_Type][0]))
+}
+
+func TestInvalidNetwork(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err, "failed to get logger")
+	_, err = Init(logger, modules.ModuleConfig{
+		"target":  "localhost:1812",
+		"network": "sctp",
+	})
+	require.Error(t, err)
+}
+
 func TestInvalidConfig(t *testing.T) {
 	// Arrange
 	logger, err := zap.NewDevelopment()
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"time"
+
+	"fbc/cwf/radius/monitoring"
+	"fbc/lib/go/radius"
+
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+)
+
+// messageAuthenticatorType Message-Authenticator, mandatory in Status-Server
+// requests (RFC 5997 section 3)
+const messageAuthenticatorType radius.Type = 80
+
+// probe sends a Status-Server request to the upstream & updates its health
+// state according to the outcome. The upstream is skipped if its previous
+// probe is still in flight, so an unresponsive upstream isn't flooded with
+// probes when the timeout exceeds the probe interval.
+func (p *pool) probe(u *upstream) {
+	if !u.startProbe() {
+		return
+	}
+	defer u.endProbe()
+	counter := StatusServerProbe.Start(tag.Upsert(monitoring.UpstreamTag, u.address))
+	packet := radius.New(radius.CodeStatusServer, u.secret)
+	// The value is computed by the client upon sending
+	packet.Set(messageAuthenticatorType, make(radius.Attribute, 16))
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	_, err := u.client.Exchange(ctx, packet, u.address)
+	if err != nil {
+		counter.Failure("no_response")
+		if u.recordFailure(p.failureThreshold) {
+			p.logger.Warn("upstream marked down by Status-Server probe", zap.String("upstream", u.address), zap.Error(err))
+			recordUpstreamState(u.address, false)
+		}
+		return
+	}
+	counter.Success()
+	if u.recordSuccess() {
+		p.logger.Info("upstream marked up by Status-Server probe", zap.String("upstream", u.address))
+		recordUpstreamState(u.address, true)
+	}
+}
+
+// probeLoop probes all upstreams which have a known secret every interval,
+// until the pool is closed
+func (p *pool) probeLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			for _, u := range p.upstreams {
+				if u.probed {
+					go p.probe(u)
+				}
+			}
+		}
+	}
+}
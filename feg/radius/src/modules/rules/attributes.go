@@ -0,0 +1,257 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/debug"
+	"fbc/lib/go/radius/dictionary"
+	"fbc/lib/go/radius/rfc2865"
+)
+
+// attributeRef a RADIUS attribute, standard or vendor specific, resolved by
+// name from the dictionaries
+type attributeRef struct {
+	name       string
+	typ        radius.Type
+	vendorID   uint32 // non zero for vendor specific attributes
+	vendorType byte
+	dataType   dictionary.AttributeType
+	values     map[string]uint32 // named values of integer attributes
+}
+
+// loadDictionary merges the standard RFC 2865, 2866, 2867, 2869, 3162, 3576 &
+// 5176 dictionary, generated along with the RFC packages of the radius
+// library, with the given FreeRADIUS dictionary files
+func loadDictionary(files []string) (*dictionary.Dictionary, error) {
+	parser := dictionary.Parser{
+		Opener:                    &dictionary.FileSystemOpener{},
+		IgnoreIdenticalAttributes: true,
+	}
+	result := debug.IncludedDictionary
+	for _, file := range files {
+		dict, err := parser.ParseFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse dictionary '%s': %v", file, err)
+		}
+		result, err = dictionary.Merge(result, dict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge dictionary '%s': %v", file, err)
+		}
+	}
+	return result, nil
+}
+
+// resolveAttribute finds the attribute of the given name in the dictionary
+func resolveAttribute(dict *dictionary.Dictionary, name string) (*attributeRef, error) {
+	if attr := dictionary.AttributeByName(dict.Attributes, name); attr != nil {
+		oid, err := strconv.ParseUint(attr.OID, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported attribute '%s' (OID %s)", name, attr.OID)
+		}
+		return newAttributeRef(attr, radius.Type(oid), 0, dict.Values)
+	}
+	for _, vendor := range dict.Vendors {
+		attr := dictionary.AttributeByName(vendor.Attributes, name)
+		if attr == nil {
+			continue
+		}
+		if vendor.GetTypeOctets() != 1 || vendor.GetLengthOctets() != 1 {
+			return nil, fmt.Errorf("unsupported vendor format of attribute '%s'", name)
+		}
+		oid, err := strconv.ParseUint(attr.OID, 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported attribute '%s' (OID %s)", name, attr.OID)
+		}
+		ref, err := newAttributeRef(attr, rfc2865.VendorSpecific_Type, uint32(vendor.Number), vendor.Values)
+		if err != nil {
+			return nil, err
+		}
+		ref.vendorType = byte(oid)
+		return ref, nil
+	}
+	return nil, fmt.Errorf("unknown attribute '%s'", name)
+}
+
+func newAttributeRef(
+	attr *dictionary.Attribute,
+	typ radius.Type,
+	vendorID uint32,
+	values []*dictionary.Value,
+) (*attributeRef, error) {
+	if attr.FlagEncrypt != nil || attr.HasTag() {
+		return nil, fmt.Errorf("encrypted or tagged attribute '%s' is not supported", attr.Name)
+	}
+	switch attr.Type {
+	case dictionary.AttributeString, dictionary.AttributeOctets, dictionary.AttributeIPAddr,
+		dictionary.AttributeIPv6Addr, dictionary.AttributeInteger, dictionary.AttributeInteger64,
+		dictionary.AttributeDate:
+	default:
+		return nil, fmt.Errorf("attribute '%s' of type %s is not supported", attr.Name, attr.Type)
+	}
+	ref := &attributeRef{
+		name:     attr.Name,
+		typ:      typ,
+		vendorID: vendorID,
+		dataType: attr.Type,
+		values:   map[string]uint32{},
+	}
+	for _, value := range dictionary.ValuesByAttribute(values, attr.Name) {
+		ref.values[value.Name] = uint32(value.Number)
+	}
+	return ref, nil
+}
+
+// get returns the values of the attribute in the given attributes
+func (a *attributeRef) get(attrs radius.Attributes) []radius.Attribute {
+	if a.vendorID == 0 {
+		return attrs[a.typ]
+	}
+	var result []radius.Attribute
+	for _, vsa := range attrs[rfc2865.VendorSpecific_Type] {
+		vendorID, value, err := radius.VendorSpecific(vsa)
+		if err != nil || vendorID != a.vendorID {
+			continue
+		}
+		for len(value) >= 2 && int(value[1]) >= 2 && int(value[1]) <= len(value) {
+			if value[0] == a.vendorType {
+				result = append(result, value[2:value[1]])
+			}
+			value = value[value[1]:]
+		}
+	}
+	return result
+}
+
+// add adds the encoded value to the given attributes
+func (a *attributeRef) add(attrs radius.Attributes, value radius.Attribute) error {
+	if a.vendorID == 0 {
+		attrs.Add(a.typ, value)
+		return nil
+	}
+	if len(value) > 247 {
+		return fmt.Errorf("value of attribute '%s' is too long", a.name)
+	}
+	vsa, err := radius.NewVendorSpecific(a.vendorID, append([]byte{a.vendorType, byte(len(value) + 2)}, value...))
+	if err != nil {
+		return err
+	}
+	attrs.Add(rfc2865.VendorSpecific_Type, vsa)
+	return nil
+}
+
+// del removes all values of the attribute from the given attributes
+func (a *attributeRef) del(attrs radius.Attributes) {
+	if a.vendorID == 0 {
+		attrs.Del(a.typ)
+		return
+	}
+	var kept []radius.Attribute
+	for _, vsa := range attrs[rfc2865.VendorSpecific_Type] {
+		vendorID, value, err := radius.VendorSpecific(vsa)
+		if err != nil || vendorID != a.vendorID {
+			kept = append(kept, vsa)
+			continue
+		}
+		// A single VSA may hold several sub attributes, only the matching ones are removed
+		var remaining []byte
+		for len(value) >= 2 && int(value[1]) >= 2 && int(value[1]) <= len(value) {
+			if value[0] != a.vendorType {
+				remaining = append(remaining, value[:value[1]]...)
+			}
+			value = value[value[1]:]
+		}
+		if len(remaining) > 0 {
+			stripped, err := radius.NewVendorSpecific(vendorID, remaining)
+			if err == nil {
+				kept = append(kept, stripped)
+			}
+		}
+	}
+	if len(kept) == 0 {
+		attrs.Del(rfc2865.VendorSpecific_Type)
+		return
+	}
+	attrs[rfc2865.VendorSpecific_Type] = kept
+}
+
+// encode converts the textual value to the attribute's wire format
+func (a *attributeRef) encode(value string) (radius.Attribute, error) {
+	switch a.dataType {
+	case dictionary.AttributeString:
+		return radius.NewString(value)
+	case dictionary.AttributeOctets:
+		if strings.HasPrefix(value, "0x") {
+			return hex.DecodeString(value[2:])
+		}
+		return radius.NewBytes([]byte(value))
+	case dictionary.AttributeIPAddr:
+		return radius.NewIPAddr(net.ParseIP(value))
+	case dictionary.AttributeIPv6Addr:
+		return radius.NewIPv6Addr(net.ParseIP(value))
+	case dictionary.AttributeInteger:
+		if number, ok := a.values[value]; ok {
+			return radius.NewInteger(number), nil
+		}
+		number, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' of integer attribute '%s'", value, a.name)
+		}
+		return radius.NewInteger(uint32(number)), nil
+	case dictionary.AttributeInteger64:
+		number, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' of integer64 attribute '%s'", value, a.name)
+		}
+		return radius.NewInteger64(number), nil
+	case dictionary.AttributeDate:
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' of date attribute '%s'", value, a.name)
+		}
+		return radius.NewDate(t)
+	}
+	return nil, fmt.Errorf("attribute '%s' of type %s is not supported", a.name, a.dataType)
+}
+
+// format converts the wire value of the attribute to text, for regex matching
+func (a *attributeRef) format(value radius.Attribute) string {
+	switch a.dataType {
+	case dictionary.AttributeString:
+		return radius.String(value)
+	case dictionary.AttributeIPAddr, dictionary.AttributeIPv6Addr:
+		return net.IP(value).String()
+	case dictionary.AttributeInteger:
+		if len(value) == 4 {
+			return strconv.FormatUint(uint64(binary.BigEndian.Uint32(value)), 10)
+		}
+	case dictionary.AttributeInteger64:
+		if len(value) == 8 {
+			return strconv.FormatUint(binary.BigEndian.Uint64(value), 10)
+		}
+	case dictionary.AttributeDate:
+		if t, err := radius.Date(value); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return "0x" + hex.EncodeToString(value)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fileWatcher detects changes of the rules file by its modification time & size
+type fileWatcher struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+// changed returns whether the file changed since the last call
+func (w *fileWatcher) changed() (bool, error) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false, err
+	}
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false, nil
+	}
+	w.modTime, w.size = info.ModTime(), info.Size()
+	return true, nil
+}
+
+// reload loads & compiles the rules file if it changed. The current rules are
+// kept if the new ones are invalid.
+func (m ModuleCtx) reload(w *fileWatcher) (bool, error) {
+	changed, err := w.changed()
+	if err != nil || !changed {
+		return false, err
+	}
+	content, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		return false, err
+	}
+	var ruleSet RuleSet
+	if err := json.Unmarshal(content, &ruleSet); err != nil {
+		return false, fmt.Errorf("failed to parse rules file '%s': %v", w.path, err)
+	}
+	compiled, err := ruleSet.compile(m.dict, m.chainNames())
+	if err != nil {
+		return false, fmt.Errorf("invalid rules file '%s': %v", w.path, err)
+	}
+	m.rules.Store(compiled)
+	return true, nil
+}
+
+func (m ModuleCtx) reloadLoop(w *fileWatcher, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+		reloaded, err := m.reload(w)
+		if err != nil {
+			m.logger.Error("failed to reload rules, keeping current rules", zap.Error(err))
+			continue
+		}
+		if reloaded {
+			m.logger.Info("rules reloaded", zap.String("file", w.path))
+		}
+	}
+}
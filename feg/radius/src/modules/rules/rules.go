@@ -0,0 +1,239 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rules implements a declarative rule engine module: requests are
+// matched on their attributes & modified, rejected or routed to a named
+// module chain, according to a rule set which is reloaded when it changes.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/modules"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/dictionary"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc3576"
+
+	"github.com/mitchellh/mapstructure"
+	"go.uber.org/zap"
+)
+
+// ModuleLoader loads the modules of the named chains. It is set by the loader
+// package, which depends on this package.
+var ModuleLoader func(name string) (modules.Module, error)
+
+// Config configuration structure for rules module
+type Config struct {
+	// RulesFile JSON file holding the RuleSet, reloaded upon changes
+	RulesFile string
+	// Rules inline rule set, used if no RulesFile is set
+	Rules []Rule
+	// Dictionaries FreeRADIUS dictionary files of the vendor attributes used
+	// by the rules, standard attributes are always known
+	Dictionaries []string
+	// ReloadIntervalSeconds how often RulesFile is checked for changes
+	ReloadIntervalSeconds uint
+	// Chains named module chains requests can be routed to
+	Chains map[string][]config.ModuleDescriptor
+}
+
+// ModuleCtx ...
+type ModuleCtx struct {
+	logger *zap.Logger
+	dict   *dictionary.Dictionary
+	chains map[string]modules.Middleware
+	rules  *atomic.Value // compiledRuleSet
+	// closers the contexts of the chain modules which hold resources
+	closers *[]modules.Closer
+	done    chan struct{} // closed to stop reloading the rules file
+}
+
+const defaultReloadIntervalSeconds = 5
+
+// Init module interface implementation
+func Init(logger *zap.Logger, config modules.ModuleConfig) (modules.Context, error) {
+	var rulesConfig Config
+	err := mapstructure.Decode(config, &rulesConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	dict, err := loadDictionary(rulesConfig.Dictionaries)
+	if err != nil {
+		return nil, err
+	}
+
+	mCtx := ModuleCtx{
+		logger:  logger,
+		dict:    dict,
+		chains:  map[string]modules.Middleware{},
+		rules:   &atomic.Value{},
+		closers: &[]modules.Closer{},
+		done:    make(chan struct{}),
+	}
+	for name, descriptors := range rulesConfig.Chains {
+		chain, err := mCtx.loadChain(logger, descriptors)
+		if err != nil {
+			mCtx.Close()
+			return nil, fmt.Errorf("failed to load chain '%s': %v", name, err)
+		}
+		mCtx.chains[name] = chain
+	}
+
+	if rulesConfig.RulesFile == "" {
+		ruleSet := RuleSet{Rules: rulesConfig.Rules}
+		compiled, err := ruleSet.compile(dict, mCtx.chainNames())
+		if err != nil {
+			mCtx.Close()
+			return nil, err
+		}
+		mCtx.rules.Store(compiled)
+		return mCtx, nil
+	}
+
+	watcher := &fileWatcher{path: rulesConfig.RulesFile}
+	if _, err := mCtx.reload(watcher); err != nil {
+		mCtx.Close()
+		return nil, err
+	}
+	if rulesConfig.ReloadIntervalSeconds == 0 {
+		rulesConfig.ReloadIntervalSeconds = defaultReloadIntervalSeconds
+	}
+	go mCtx.reloadLoop(watcher, time.Duration(rulesConfig.ReloadIntervalSeconds)*time.Second)
+	return mCtx, nil
+}
+
+// Handle module interface implementation
+func Handle(m modules.Context, c *modules.RequestContext, r *radius.Request, next modules.Middleware) (*modules.Response, error) {
+	mCtx := m.(ModuleCtx)
+	rules := mCtx.rules.Load().(compiledRuleSet)
+	result, err := rules.evaluate(r.Packet)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.reject {
+		return rejectResponse(r.Code, result.replyMessage)
+	}
+
+	handler := next
+	if result.chain != "" {
+		handler = mCtx.chains[result.chain]
+	}
+	response, err := handler(c, r)
+	if err != nil || response == nil {
+		return response, err
+	}
+	if response.Attributes == nil && len(result.responseActions) > 0 {
+		response.Attributes = radius.Attributes{}
+	}
+	for _, action := range result.responseActions {
+		if err := action.apply(response.Attributes); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+// rejectResponse returns the negative response matching the request code:
+// Access-Reject, with the Reply-Message if any, or Disconnect-NAK & CoA-NAK
+// with an Administratively-Prohibited Error-Cause. Requests without a
+// negative response, such as Accounting-Request, are dropped.
+func rejectResponse(code radius.Code, replyMessage string) (*modules.Response, error) {
+	response := &modules.Response{Attributes: radius.Attributes{}}
+	switch code {
+	case radius.CodeAccessRequest:
+		response.Code = radius.CodeAccessReject
+		if replyMessage != "" {
+			value, err := radius.NewString(replyMessage)
+			if err != nil {
+				return nil, err
+			}
+			response.Attributes.Add(rfc2865.ReplyMessage_Type, value)
+		}
+	case radius.CodeDisconnectRequest, radius.CodeCoARequest:
+		response.Code = radius.CodeDisconnectNAK
+		if code == radius.CodeCoARequest {
+			response.Code = radius.CodeCoANAK
+		}
+		response.Attributes.Add(
+			rfc3576.ErrorCause_Type,
+			radius.NewInteger(uint32(rfc3576.ErrorCause_Value_AdministrativelyProhibited)),
+		)
+	default:
+		return nil, nil
+	}
+	return response, nil
+}
+
+func (m ModuleCtx) chainNames() map[string]bool {
+	result := map[string]bool{}
+	for name := range m.chains {
+		result[name] = true
+	}
+	return result
+}
+
+// Close stops reloading the rules file & closes the chain modules
+func (m ModuleCtx) Close() error {
+	select {
+	case <-m.done:
+		return nil
+	default:
+		close(m.done)
+	}
+	var result error
+	for _, closer := range *m.closers {
+		if err := closer.Close(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+// loadChain loads & initializes the modules of a chain, wrapping them in a
+// call chain like the modules of a listener
+func (m ModuleCtx) loadChain(logger *zap.Logger, descriptors []config.ModuleDescriptor) (modules.Middleware, error) {
+	if ModuleLoader == nil {
+		return nil, errors.New("module loader is not set")
+	}
+	handler := func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+		return nil, nil
+	}
+	for idx := len(descriptors) - 1; idx >= 0; idx-- {
+		module, err := ModuleLoader(descriptors[idx].Name)
+		if err != nil {
+			return nil, err
+		}
+		moduleCtx, err := module.Init(logger, descriptors[idx].Config)
+		if err != nil {
+			return nil, err
+		}
+		if closer, ok := moduleCtx.(modules.Closer); ok {
+			*m.closers = append(*m.closers, closer)
+		}
+		handler = wrapModule(module, moduleCtx, handler)
+	}
+	return handler, nil
+}
+
+func wrapModule(module modules.Module, moduleCtx modules.Context, next modules.Middleware) modules.Middleware {
+	return func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+		return module.Handle(moduleCtx, c, r, next)
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/modules/modulestest"
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/rfc2865"
+	"fbc/lib/go/radius/rfc3576"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const ruckusDictionary = "../../../lib/go/radius/dictionaries/ruckus/dictionary.ruckus"
+
+func createRequest(userName, nasIdentifier, calledStationID string) *radius.Request {
+	packet := radius.New(radius.CodeAccessRequest, []byte("secret"))
+	rfc2865.UserName_SetString(packet, userName)
+	rfc2865.NASIdentifier_SetString(packet, nasIdentifier)
+	rfc2865.CalledStationID_SetString(packet, calledStationID)
+	req := &radius.Request{}
+	req = req.WithContext(context.Background())
+	req.Packet = packet
+	return req
+}
+
+// acceptNext accepts the request, echoing its attributes in the response
+func acceptNext(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+	attrs := radius.Attributes{}
+	for t, values := range r.Packet.Attributes {
+		attrs[t] = append([]radius.Attribute(nil), values...)
+	}
+	return &modules.Response{Code: radius.CodeAccessAccept, Attributes: attrs}, nil
+}
+
+func TestRulesModifyRequestAndResponse(t *testing.T) {
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Dictionaries": []string{ruckusDictionary},
+		"Rules": []map[string]interface{}{
+			{
+				"name": "tag-magma-ssid",
+				"match": map[string]interface{}{
+					"codes": []string{"Access-Request"},
+					"attributes": []map[string]interface{}{
+						{"attribute": "NAS-Identifier", "equals": "ap-1"},
+						{"attribute": "Called-Station-Id", "regex": "(?i):magma$"},
+					},
+				},
+				"actions": []map[string]interface{}{
+					{"type": "add", "attribute": "Ruckus-User-Groups", "value": "magma"},
+					{"type": "replace", "attribute": "NAS-Identifier", "value": "gateway"},
+					{"type": "add", "attribute": "Session-Timeout", "value": "3600", "target": "response"},
+					{"type": "remove", "attribute": "Ruckus-User-Groups", "target": "response"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var forwarded *radius.Packet
+	res, err := Handle(mCtx, &modules.RequestContext{}, createRequest("user", "ap-1", "00-11:MAGMA"),
+		func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+			forwarded = r.Packet
+			return acceptNext(c, r)
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "gateway", rfc2865.NASIdentifier_GetString(forwarded))
+	vsa, vendorValue, err := radius.VendorSpecific(forwarded.Get(rfc2865.VendorSpecific_Type))
+	require.NoError(t, err)
+	require.Equal(t, uint32(25053), vsa)
+	require.Equal(t, append([]byte{1, 7}, "magma"...), []byte(vendorValue))
+
+	require.Equal(t, radius.CodeAccessAccept, res.Code)
+	require.Equal(t, []radius.Attribute{radius.NewInteger(3600)}, res.Attributes[rfc2865.SessionTimeout_Type])
+	require.Empty(t, res.Attributes[rfc2865.VendorSpecific_Type])
+
+	// Not matching, the request passes untouched
+	forwarded = nil
+	_, err = Handle(mCtx, &modules.RequestContext{}, createRequest("user", "ap-2", "00-11:magma"),
+		func(c *modules.RequestContext, r *radius.Request) (*modules.Response, error) {
+			forwarded = r.Packet
+			return nil, nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "ap-2", rfc2865.NASIdentifier_GetString(forwarded))
+	require.Nil(t, forwarded.Get(rfc2865.VendorSpecific_Type))
+}
+
+func TestRulesRejectByRealm(t *testing.T) {
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Rules": []map[string]interface{}{
+			{
+				"match": map[string]interface{}{"realm": "blocked.example.com"},
+				"actions": []map[string]interface{}{
+					{"type": "reject", "replyMessage": "realm not allowed"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := Handle(mCtx, &modules.RequestContext{}, createRequest("user@Blocked.Example.com", "ap-1", "x"), acceptNext)
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessReject, res.Code)
+	require.Equal(t, "realm not allowed", string(res.Attributes[rfc2865.ReplyMessage_Type][0]))
+
+	res, err = Handle(mCtx, &modules.RequestContext{}, createRequest("user@example.com", "ap-1", "x"), acceptNext)
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessAccept, res.Code)
+}
+
+func TestRulesRejectByRequestCode(t *testing.T) {
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Rules": []map[string]interface{}{
+			{"actions": []map[string]interface{}{{"type": "reject", "replyMessage": "not allowed"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	for code, expected := range map[radius.Code]radius.Code{
+		radius.CodeCoARequest:        radius.CodeCoANAK,
+		radius.CodeDisconnectRequest: radius.CodeDisconnectNAK,
+	} {
+		req := createRequest("user", "ap-1", "x")
+		req.Code = code
+		res, err := Handle(mCtx, &modules.RequestContext{}, req, acceptNext)
+		require.NoError(t, err)
+		require.Equal(t, expected, res.Code)
+		errorCause, err := radius.Integer(res.Attributes[rfc3576.ErrorCause_Type][0])
+		require.NoError(t, err)
+		require.Equal(t, rfc3576.ErrorCause_Value_AdministrativelyProhibited, rfc3576.ErrorCause(errorCause))
+		require.Nil(t, res.Attributes[rfc2865.ReplyMessage_Type])
+	}
+
+	// Accounting requests have no negative response & are dropped
+	req := createRequest("user", "ap-1", "x")
+	req.Code = radius.CodeAccountingRequest
+	res, err := Handle(mCtx, &modules.RequestContext{}, req, acceptNext)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+func TestRulesRouteToChain(t *testing.T) {
+	chainModule := &modulestest.MockModule{}
+	chainModule.On("Init", mock.Anything).Return(nil)
+	chainModule.On("Handle", mock.Anything, mock.Anything, mock.Anything).Return(
+		&modules.Response{Code: radius.CodeAccessChallenge, Attributes: radius.Attributes{}}, nil,
+	)
+	ModuleLoader = func(name string) (modules.Module, error) {
+		return chainModule, nil
+	}
+	defer func() { ModuleLoader = nil }()
+
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"Chains": map[string]interface{}{
+			"partner": []map[string]interface{}{{"name": "mock", "config": map[string]interface{}{}}},
+		},
+		"Rules": []map[string]interface{}{
+			{
+				"match": map[string]interface{}{
+					"attributes": []map[string]interface{}{{"attribute": "NAS-Identifier", "regex": "^partner-"}},
+				},
+				"actions": []map[string]interface{}{{"type": "route", "chain": "partner"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := Handle(mCtx, &modules.RequestContext{}, createRequest("user", "partner-ap", "x"), acceptNext)
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessChallenge, res.Code)
+	chainModule.AssertNumberOfCalls(t, "Handle", 1)
+
+	res, err = Handle(mCtx, &modules.RequestContext{}, createRequest("user", "ap", "x"), acceptNext)
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessAccept, res.Code)
+	chainModule.AssertNumberOfCalls(t, "Handle", 1)
+}
+
+func TestRulesInvalidConfig(t *testing.T) {
+	for _, rule := range []map[string]interface{}{
+		{"match": map[string]interface{}{"attributes": []map[string]interface{}{{"attribute": "No-Such-Attribute"}}}},
+		{"match": map[string]interface{}{"codes": []string{"Access-Nothing"}}},
+		{"actions": []map[string]interface{}{{"type": "route", "chain": "missing"}}},
+		{"actions": []map[string]interface{}{{"type": "add", "attribute": "User-Password", "value": "x"}}},
+		{"actions": []map[string]interface{}{{"type": "add", "attribute": "Session-Timeout", "value": "forever"}}},
+	} {
+		_, err := Init(zap.NewNop(), modules.ModuleConfig{"Rules": []map[string]interface{}{rule}})
+		require.Error(t, err, "rule %v", rule)
+	}
+}
+
+func TestRulesHotReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "radius_rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	rulesFile := filepath.Join(dir, "rules.json")
+	require.NoError(t, ioutil.WriteFile(rulesFile, []byte(`{"rules": []}`), 0644))
+
+	mCtx, err := Init(zap.NewNop(), modules.ModuleConfig{
+		"RulesFile":             rulesFile,
+		"ReloadIntervalSeconds": 1,
+	})
+	require.NoError(t, err)
+	res, err := Handle(mCtx, &modules.RequestContext{}, createRequest("user", "ap-1", "x"), acceptNext)
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessAccept, res.Code)
+
+	// Invalid rules are ignored, valid ones are picked up without a restart
+	require.NoError(t, ioutil.WriteFile(rulesFile, []byte(`{"rules": [{"actions": [{"type": "explode"}]}]}`), 0644))
+	time.Sleep(1500 * time.Millisecond)
+	res, err = Handle(mCtx, &modules.RequestContext{}, createRequest("user", "ap-1", "x"), acceptNext)
+	require.NoError(t, err)
+	require.Equal(t, radius.CodeAccessAccept, res.Code)
+
+	require.NoError(t, ioutil.WriteFile(rulesFile, []byte(`{"rules": [{"actions": [{"type": "reject"}]}]}`), 0644))
+	require.Eventually(t, func() bool {
+		res, err := Handle(mCtx, &modules.RequestContext{}, createRequest("user", "ap-1", "x"), acceptNext)
+		return err == nil && res.Code == radius.CodeAccessReject
+	}, 3*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"fbc/lib/go/radius"
+	"fbc/lib/go/radius/dictionary"
+	"fbc/lib/go/radius/rfc2865"
+)
+
+// Action types
+const (
+	// ActionAdd adds an attribute value
+	ActionAdd = "add"
+	// ActionReplace replaces all values of an attribute with the given value
+	ActionReplace = "replace"
+	// ActionRemove removes all values of an attribute
+	ActionRemove = "remove"
+	// ActionReject answers the request with the negative response of its
+	// code, with an optional Reply-Message for Access-Reject. Requests
+	// without a negative response, such as Accounting-Request, are dropped.
+	ActionReject = "reject"
+	// ActionRoute hands the request to a named module chain, instead of the
+	// next modules of the listener
+	ActionRoute = "route"
+)
+
+// Action targets
+const (
+	// TargetRequest the action modifies the request, before it is passed on
+	TargetRequest = "request"
+	// TargetResponse the action modifies the response returned downstream
+	TargetResponse = "response"
+)
+
+type (
+	// RuleSet the declarative rules, evaluated in order
+	RuleSet struct {
+		Rules []Rule `json:"rules"`
+	}
+
+	// Rule actions to take on requests that match
+	Rule struct {
+		Name    string   `json:"name"`
+		Match   Match    `json:"match"`
+		Actions []Action `json:"actions"`
+		// Continue evaluating the following rules once this rule matched
+		Continue bool `json:"continue"`
+	}
+
+	// Match conditions of a rule, all must hold for the rule to match
+	Match struct {
+		// Codes RADIUS codes of the request (e.g. "Access-Request"), any if empty
+		Codes []string `json:"codes"`
+		// Realm the part of the User-Name after the '@', case insensitive
+		Realm      string      `json:"realm"`
+		Attributes []Condition `json:"attributes"`
+	}
+
+	// Condition on the values of an attribute. If neither Equals, Regex nor
+	// Present are set, the condition holds if the attribute is present.
+	Condition struct {
+		Attribute string `json:"attribute"`
+		Equals    string `json:"equals"`
+		Regex     string `json:"regex"`
+		Present   *bool  `json:"present"`
+	}
+
+	// Action to take on a matching request
+	Action struct {
+		Type      string `json:"type"`
+		Attribute string `json:"attribute"`
+		Value     string `json:"value"`
+		// Target of attribute actions: "request" (default) or "response"
+		Target string `json:"target"`
+		// ReplyMessage of reject actions
+		ReplyMessage string `json:"replyMessage"`
+		// Chain name of route actions
+		Chain string `json:"chain"`
+	}
+)
+
+type (
+	compiledRuleSet []*compiledRule
+
+	compiledRule struct {
+		name     string
+		codes    map[radius.Code]bool
+		realm    string
+		matchers []matcher
+		actions  []compiledAction
+		cont     bool
+	}
+
+	matcher struct {
+		attr    *attributeRef
+		equals  radius.Attribute
+		regex   *regexp.Regexp
+		present bool
+	}
+
+	compiledAction struct {
+		typ          string
+		attr         *attributeRef
+		value        radius.Attribute
+		response     bool
+		replyMessage string
+		chain        string
+	}
+
+	// outcome the result of evaluating the rules on a request
+	outcome struct {
+		reject          bool
+		replyMessage    string
+		chain           string
+		responseActions []compiledAction
+	}
+)
+
+// compile validates the rule set & resolves its attributes, codes & chains
+func (rs *RuleSet) compile(dict *dictionary.Dictionary, chains map[string]bool) (compiledRuleSet, error) {
+	var result compiledRuleSet
+	for idx, rule := range rs.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", idx)
+		}
+		compiled, err := rule.compile(name, dict, chains)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %v", name, err)
+		}
+		result = append(result, compiled)
+	}
+	return result, nil
+}
+
+func (r *Rule) compile(name string, dict *dictionary.Dictionary, chains map[string]bool) (*compiledRule, error) {
+	result := &compiledRule{
+		name:  name,
+		realm: strings.ToLower(r.Match.Realm),
+		cont:  r.Continue,
+	}
+	if len(r.Match.Codes) > 0 {
+		result.codes = map[radius.Code]bool{}
+		for _, codeName := range r.Match.Codes {
+			code, ok := codesByName[codeName]
+			if !ok {
+				return nil, fmt.Errorf("unknown code '%s'", codeName)
+			}
+			result.codes[code] = true
+		}
+	}
+
+	for _, cond := range r.Match.Attributes {
+		attr, err := resolveAttribute(dict, cond.Attribute)
+		if err != nil {
+			return nil, err
+		}
+		m := matcher{attr: attr, present: true}
+		if cond.Present != nil {
+			m.present = *cond.Present
+		}
+		if cond.Equals != "" {
+			m.equals, err = attr.encode(cond.Equals)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if cond.Regex != "" {
+			m.regex, err = regexp.Compile(cond.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex of attribute '%s': %v", cond.Attribute, err)
+			}
+		}
+		result.matchers = append(result.matchers, m)
+	}
+
+	for _, action := range r.Actions {
+		compiled := compiledAction{typ: action.Type}
+		switch action.Target {
+		case "", TargetRequest:
+		case TargetResponse:
+			compiled.response = true
+		default:
+			return nil, fmt.Errorf("unknown action target '%s'", action.Target)
+		}
+
+		switch action.Type {
+		case ActionAdd, ActionReplace, ActionRemove:
+			attr, err := resolveAttribute(dict, action.Attribute)
+			if err != nil {
+				return nil, err
+			}
+			compiled.attr = attr
+			if action.Type != ActionRemove {
+				compiled.value, err = attr.encode(action.Value)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case ActionReject:
+			compiled.replyMessage = action.ReplyMessage
+		case ActionRoute:
+			if !chains[action.Chain] {
+				return nil, fmt.Errorf("unknown chain '%s'", action.Chain)
+			}
+			compiled.chain = action.Chain
+		default:
+			return nil, fmt.Errorf("unknown action type '%s'", action.Type)
+		}
+		result.actions = append(result.actions, compiled)
+	}
+	return result, nil
+}
+
+// matches returns whether all conditions of the rule hold for the packet
+func (r *compiledRule) matches(packet *radius.Packet) bool {
+	if r.codes != nil && !r.codes[packet.Code] {
+		return false
+	}
+	if r.realm != "" {
+		userName := rfc2865.UserName_GetString(packet)
+		at := strings.LastIndex(userName, "@")
+		if at < 0 || strings.ToLower(userName[at+1:]) != r.realm {
+			return false
+		}
+	}
+	for _, m := range r.matchers {
+		if !m.matches(packet.Attributes) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *matcher) matches(attrs radius.Attributes) bool {
+	values := m.attr.get(attrs)
+	if !m.present {
+		return len(values) == 0
+	}
+	if m.equals == nil && m.regex == nil {
+		return len(values) > 0
+	}
+	for _, value := range values {
+		if m.equals != nil && !bytes.Equal(m.equals, value) {
+			continue
+		}
+		if m.regex != nil && !m.regex.MatchString(m.attr.format(value)) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// evaluate applies the actions of the matching rules on the request packet,
+// response actions, rejection & routing are returned to the caller
+func (rs compiledRuleSet) evaluate(packet *radius.Packet) (outcome, error) {
+	var result outcome
+	for _, rule := range rs {
+		if !rule.matches(packet) {
+			continue
+		}
+		for _, action := range rule.actions {
+			switch {
+			case action.typ == ActionReject:
+				result.reject = true
+				result.replyMessage = action.replyMessage
+				return result, nil
+			case action.typ == ActionRoute:
+				result.chain = action.chain
+			case action.response:
+				result.responseActions = append(result.responseActions, action)
+			default:
+				if err := action.apply(packet.Attributes); err != nil {
+					return result, fmt.Errorf("rule %s: %v", rule.name, err)
+				}
+			}
+		}
+		if !rule.cont {
+			break
+		}
+	}
+	return result, nil
+}
+
+// apply executes an attribute action on the given attributes
+func (a *compiledAction) apply(attrs radius.Attributes) error {
+	switch a.typ {
+	case ActionAdd:
+		return a.attr.add(attrs, a.value)
+	case ActionReplace:
+		a.attr.del(attrs)
+		return a.attr.add(attrs, a.value)
+	case ActionRemove:
+		a.attr.del(attrs)
+	}
+	return nil
+}
+
+var codesByName = func() map[string]radius.Code {
+	result := map[string]radius.Code{}
+	for _, code := range []radius.Code{
+		radius.CodeAccessRequest,
+		radius.CodeAccountingRequest,
+		radius.CodeStatusServer,
+		radius.CodeDisconnectRequest,
+		radius.CodeCoARequest,
+	} {
+		result[code.String()] = code
+	}
+	return result
+}()
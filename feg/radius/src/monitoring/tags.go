@@ -42,9 +42,12 @@ var (
 
 	// ResponseCodeTag RADIUS response code
 	ResponseCodeTag, _ = tag.NewKey("response_code")
+
+	// UpstreamTag The upstream RADIUS server the operation was sent to
+	UpstreamTag, _ = tag.NewKey("upstream")
 )
 
 // AllTagKeys ...
 func AllTagKeys() []tag.Key {
-	return []tag.Key{ListenerTag, ModuleTag, FilterTag, RadiusTypeTag, ErrorCodeTag, SessionIDTag, StorageTag, RequestCodeTag, ResponseCodeTag}
+	return []tag.Key{ListenerTag, ModuleTag, FilterTag, RadiusTypeTag, ErrorCodeTag, SessionIDTag, StorageTag, RequestCodeTag, ResponseCodeTag, UpstreamTag}
 }
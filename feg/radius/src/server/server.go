@@ -144,6 +144,10 @@ func New(config config.ServerConfig, logger *zap.Logger, loader loader.Loader) (
 			listener = NewGRPCListener()
 		case "sse":
 			listener = NewSSEListener()
+		case "tcp":
+			listener = NewTCPListener()
+		case "tls":
+			listener = NewTLSListener()
 		default:
 			logger.Error(
 				fmt.Sprintf("failed to create listener, listener type '%s'", lconfig.Type),
@@ -340,6 +344,24 @@ func (s Server) Stop() {
 		}
 	}
 
+	// Release the resources held by the modules
+	for name, listener := range s.listeners {
+		for _, module := range listener.GetModules() {
+			closer, ok := module.Context.(modules.Closer)
+			if !ok {
+				continue
+			}
+			if err := closer.Close(); err != nil {
+				s.logger.Error(
+					"Error closing module",
+					zap.String("listener", name),
+					zap.String("module_name", module.Name),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
 	// Signal termination
 	s.logger.Debug("All listeners are now down, terminating server")
 	s.terminate <- true
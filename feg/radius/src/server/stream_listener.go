@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fbc/cwf/radius/config"
+	"fbc/cwf/radius/modules"
+	"fbc/cwf/radius/monitoring"
+	"fbc/lib/go/radius"
+	"fmt"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// StreamListener listens to Radius packets over TCP (RFC 6613) or TLS (RadSec, RFC 6614)
+type StreamListener struct {
+	Listener
+	Server *radius.StreamServer
+	useTLS bool
+	ready  chan bool
+}
+
+// StreamListenerExtraConfig extra config for TCP & TLS listeners
+type StreamListenerExtraConfig struct {
+	Port int `json:"port"`
+}
+
+// NewTCPListener ...
+func NewTCPListener() *StreamListener {
+	return &StreamListener{
+		ready: make(chan bool),
+	}
+}
+
+// NewTLSListener ...
+func NewTLSListener() *StreamListener {
+	return &StreamListener{
+		useTLS: true,
+		ready:  make(chan bool),
+	}
+}
+
+// Init override
+func (l *StreamListener) Init(
+	server *Server,
+	serverConfig config.ServerConfig,
+	listenerConfig config.ListenerConfig,
+	ctrs monitoring.ListenerCounters,
+) error {
+	// Parse configuration
+	var cfg StreamListenerExtraConfig
+	err := mapstructure.Decode(listenerConfig.Extra, &cfg)
+	if err != nil {
+		return err
+	}
+
+	secret := serverConfig.Secret
+	var tlsConfig *tls.Config
+	if l.useTLS {
+		tlsConfig, err = listenerConfig.TLS.ServerTLSConfig()
+		if err != nil {
+			return err
+		}
+		// RadSec peers use a well known secret, the TLS session provides the security
+		secret = radius.RadSecSecret
+		if cfg.Port == 0 {
+			cfg.Port = 2083
+		}
+	}
+
+	// Create stream server
+	l.Server = &radius.StreamServer{
+		Handler: radius.HandlerFunc(
+			generatePacketHandler(l, server, ctrs),
+		),
+		SecretSource: radius.StaticSecretSource([]byte(secret)),
+		Addr:         fmt.Sprintf(":%d", cfg.Port),
+		TLSConfig:    tlsConfig,
+		Ready:        make(chan bool),
+	}
+	return nil
+}
+
+// ListenAndServe override
+func (l *StreamListener) ListenAndServe() error {
+	serverError := make(chan error, 1)
+	go func() {
+		err := l.Server.ListenAndServe()
+		serverError <- err
+	}()
+
+	// Wait to see if initialization was successful
+	select {
+	case _ = <-l.Server.Ready:
+		l.ready <- true
+		return nil
+	case err := <-serverError:
+		l.ready <- false
+		return err // might be nil if no error
+	}
+}
+
+// GetHandleRequest override
+func (l *StreamListener) GetHandleRequest() modules.Middleware {
+	return l.HandleRequest
+}
+
+// Shutdown override
+func (l *StreamListener) Shutdown(ctx context.Context) error {
+	return l.Server.Shutdown(ctx)
+}
+
+// Ready override
+func (l *StreamListener) Ready() chan bool {
+	return l.ready
+}
+
+// SetConfig override
+func (l *StreamListener) SetConfig(c config.ListenerConfig) {
+	l.Config = c
+}
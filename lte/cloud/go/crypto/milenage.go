@@ -337,6 +337,33 @@ func (milenage *MilenageCipher) GenerateResync(auts, key, opc, rand []byte) (uin
 	return sqnMsInt, macS, nil
 }
 
+// GenerateAuts computes the AUTS sent by a client to re-synchronize its SQN.
+// It is the inverse of GenerateResync.
+//    AUTS = SQN_MS ^ AK || f1*(SQN_MS || RAND || AMF*)
+// Inputs:
+//    key: 128 bit subscriber key
+//    opc: 128 bit operator variant algorithm configuration field
+//    rand: 128 bit random challenge
+//    sqnMs: 48 bit sequence number of the client
+// Outputs: 112 bit authentication token or an error
+func (milenage *MilenageCipher) GenerateAuts(key, opc, rand []byte, sqnMs uint64) ([]byte, error) {
+	err := validateGenerateSIPAuthVectorWithRandInputs(rand, key, opc, sqnMs)
+	if err != nil {
+		return nil, err
+	}
+
+	ak, err := f5Star(key, rand, opc)
+	if err != nil {
+		return nil, err
+	}
+	sqnBytes := getSqnBytes(sqnMs)
+	_, macS, err := f1(key, sqnBytes, rand, opc, milenage.amf[:])
+	if err != nil {
+		return nil, err
+	}
+	return append(xor(sqnBytes, ak), macS...), nil
+}
+
 // validateGenerateResyncInputs ensures that each byte slice has the correct number of bytes.
 // Output: An error if any of the arguments is invalid or nil otherwise.
 func validateGenerateResyncInputs(auts, key, opc, rand []byte) error {
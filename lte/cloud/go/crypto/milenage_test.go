@@ -755,6 +755,30 @@ func TestGenerateResync(t *testing.T) {
 	assert.Equal(t, []byte("\xdb_c`Y\x1f4\xea"), macS[:])
 }
 
+func TestGenerateAuts(t *testing.T) {
+	rand := []byte("\xcd\x14\xa7S\x97\x7f\xbcq\x8eb\xbd\xdbS]\x88\xf8")
+	key := []byte("\x8b\xafG?/\x8f\xd0\x94\x87\xcc\xcb\xd7\t|hb")
+	opc := []byte("\x8e'\xb6\xaf\x0ei.u\x0f2fz;\x14`]")
+	amf := []byte{0, 0}
+
+	milenage, err := NewMilenageCipher(amf)
+	assert.NoError(t, err)
+
+	auts, err := milenage.GenerateAuts(key, opc, rand, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{236, 25, 14, 177, 16, 88, 219, 95, 99, 96, 89, 31, 52, 234}, auts)
+
+	auts, err = milenage.GenerateAuts(key, opc, rand, 7351)
+	assert.NoError(t, err)
+	sqn, macS, err := milenage.GenerateResync(auts, key, opc, rand)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7351), sqn)
+	assert.Equal(t, auts[6:], macS[:])
+
+	_, err = milenage.GenerateAuts(key, opc, rand[:4], 0)
+	assert.Error(t, err)
+}
+
 func TestGenerateResync_InvalidInput(t *testing.T) {
 	rand := make([]byte, RandChallengeBytes)
 	key := make([]byte, ExpectedKeyBytes)
@@ -30,6 +30,7 @@ limitations under the License.
 	policy                policy_rule            policy_qos_profile                         Stored as policy_rule_config
 	policy_qos_profile    policy_qos_profile
 	rating_group          *rating_group
+	static_ip_pool        static_ip_pool
 	subscriber            *subscriber           apn,policy,base_name,apn_policy_profile
 
 	Resulting DAG
@@ -61,19 +62,25 @@ const (
 	// in configurator.
 	CellularNetworkConfigType   = "cellular_network"
 	NetworkSubscriberConfigType = "network_subscriber_config"
+	RANPlanningConfigType       = "ran_planning_config"
+	UsageQuotaConfigType        = "usage_quota_config"
 
 	// APNEntityType etc. are configurator network entity types.
-	APNEntityType                 = "apn"
-	APNPolicyProfileEntityType    = "apn_policy_profile"
-	APNResourceEntityType         = "apn_resource"
-	BaseNameEntityType            = "base_name"
-	CellularEnodebEntityType      = "cellular_enodeb"
-	CellularGatewayEntityType     = "cellular_gateway"
-	CellularGatewayPoolEntityType = "cellular_gateway_pool"
-	PolicyQoSProfileEntityType    = "policy_qos_profile"
-	PolicyRuleEntityType          = "policy"
-	RatingGroupEntityType         = "rating_group"
-	SubscriberEntityType          = "subscriber"
+	APNEntityType                    = "apn"
+	APNPolicyProfileEntityType       = "apn_policy_profile"
+	APNResourceEntityType            = "apn_resource"
+	BaseNameEntityType               = "base_name"
+	CellularEnodebEntityType         = "cellular_enodeb"
+	CellularEnodebTemplateEntityType = "cellular_enodeb_template"
+	CellularGatewayEntityType        = "cellular_gateway"
+	CellularGatewayPoolEntityType    = "cellular_gateway_pool"
+	PolicyQoSProfileEntityType       = "policy_qos_profile"
+	PolicyRuleEntityType             = "policy"
+	RatingGroupEntityType            = "rating_group"
+	StaticIPAllocationEntityType     = "static_ip_allocation"
+	StaticIPPoolEntityType           = "static_ip_pool"
+	SubscriberEntityType             = "subscriber"
+	SubscriberGroupEntityType        = "subscriber_group"
 
 	// ApnRuleMappingsStreamName etc. are streamer stream names.
 	ApnRuleMappingsStreamName  = "apn_rule_mappings"
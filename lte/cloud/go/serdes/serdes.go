@@ -18,6 +18,7 @@ import (
 	lte_models "magma/lte/cloud/go/services/lte/obsidian/models"
 	policydb_models "magma/lte/cloud/go/services/policydb/obsidian/models"
 	subscriberdb_models "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	usaged_models "magma/lte/cloud/go/services/usaged/obsidian/models"
 	"magma/orc8r/cloud/go/serde"
 	"magma/orc8r/cloud/go/serdes"
 	"magma/orc8r/cloud/go/services/state"
@@ -28,7 +29,8 @@ var (
 	// used in the LTE module
 	Network = serdes.Network.
 		MustMerge(lte_models.NetworkSerdes).
-		MustMerge(policydb_models.NetworkSerdes)
+		MustMerge(policydb_models.NetworkSerdes).
+		MustMerge(usaged_models.NetworkSerdes)
 	// Entity contains the full set of configurator network entity serdes used
 	// in the LTE module
 	Entity = serdes.Entity.
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calculations
+
+import (
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	"magma/lte/cloud/go/services/subscriberdb/ippool"
+	subscriber_models "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	"magma/orc8r/cloud/go/services/analytics/calculations"
+	"magma/orc8r/cloud/go/services/analytics/protos"
+	"magma/orc8r/cloud/go/services/analytics/query_api"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/lib/go/metrics"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type StaticIPPoolMetricsCalculation struct {
+	calculations.BaseCalculation
+}
+
+// Calculate computes the size and number of allocated addresses of the
+// static IP pools of each network
+func (x *StaticIPPoolMetricsCalculation) Calculate(prometheusClient query_api.PrometheusAPI) ([]*protos.CalculationResult, error) {
+	glog.V(1).Info("Calculate Static IP Pool Metrics")
+	var results []*protos.CalculationResult
+	networks, err := configurator.ListNetworkIDs()
+	if err != nil || networks == nil {
+		return results, err
+	}
+
+	sizeCfg, sizeCfgOk := x.AnalyticsConfig.Metrics[metrics.StaticIPPoolSizeMetric]
+	allocatedCfg, allocatedCfgOk := x.AnalyticsConfig.Metrics[metrics.StaticIPPoolAllocatedMetric]
+	if !sizeCfgOk && !allocatedCfgOk {
+		return results, nil
+	}
+
+	for _, networkID := range networks {
+		poolEnts, _, err := configurator.LoadAllEntitiesOfType(
+			networkID,
+			lte.StaticIPPoolEntityType,
+			configurator.EntityLoadCriteria{LoadConfig: true},
+			serdes.Entity,
+		)
+		if err != nil || len(poolEnts) == 0 {
+			continue
+		}
+		subscriberEnts, _, err := configurator.LoadAllEntitiesOfType(
+			networkID,
+			lte.SubscriberEntityType,
+			configurator.EntityLoadCriteria{LoadConfig: true},
+			serdes.Entity,
+		)
+		if err != nil {
+			continue
+		}
+		allocations := subscriber_models.GetStaticIPAllocations(subscriberEnts)
+
+		for _, ent := range poolEnts {
+			model, err := (&subscriber_models.StaticIPPool{}).FromEntity(ent)
+			if err != nil {
+				glog.Errorf("static IP pool %s of network %s, err %v", ent.Key, networkID, err)
+				continue
+			}
+			pool, err := model.ToPool()
+			if err != nil {
+				glog.Errorf("static IP pool %s of network %s, err %v", ent.Key, networkID, err)
+				continue
+			}
+
+			labels := prometheus.Labels{
+				metrics.NetworkLabelName:  networkID,
+				metrics.APNLabel:          pool.APN,
+				metrics.StaticIPPoolLabel: pool.ID,
+			}
+			if sizeCfgOk {
+				results = append(results, calculations.NewResult(
+					float64(pool.Size()),
+					metrics.StaticIPPoolSizeMetric,
+					calculations.CombineLabels(labels, sizeCfg.Labels)))
+			}
+			if allocatedCfgOk {
+				results = append(results, calculations.NewResult(
+					float64(len(ippool.GetAllocated(pool, allocations))),
+					metrics.StaticIPPoolAllocatedMetric,
+					calculations.CombineLabels(labels, allocatedCfg.Labels)))
+			}
+		}
+	}
+	glog.V(1).Info("Static IP Pool Metrics Results ", results)
+	return results, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package calculations_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	lte_calculations "magma/lte/cloud/go/services/lte/analytics/calculations"
+	subscriber_models "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	"magma/orc8r/cloud/go/services/analytics/calculations"
+	"magma/orc8r/cloud/go/services/configurator"
+	configurator_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/lib/go/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticIPPoolCalculations(t *testing.T) {
+	configurator_test_init.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n0"}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n0",
+		[]configurator.NetworkEntity{
+			(&subscriber_models.StaticIPPool{ID: "p0", Apn: "internet", IPBlock: "10.0.0.0/24"}).ToEntity(),
+			(&subscriber_models.StaticIPPool{ID: "p1", Apn: "ims", IPBlock: "10.0.1.0/30"}).ToEntity(),
+			{
+				Type:   lte.SubscriberEntityType,
+				Key:    "IMSI001010000000001",
+				Config: &subscriber_models.SubscriberConfig{StaticIps: subscriber_models.SubscriberStaticIps{"internet": "10.0.0.1", "ims": "10.0.1.1"}},
+			},
+			{
+				Type:   lte.SubscriberEntityType,
+				Key:    "IMSI001010000000002",
+				Config: &subscriber_models.SubscriberConfig{StaticIps: subscriber_models.SubscriberStaticIps{"internet": "10.0.0.2"}},
+			},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+
+	analyticsConfig := &calculations.AnalyticsConfig{
+		Metrics: map[string]calculations.MetricConfig{
+			metrics.StaticIPPoolSizeMetric: {
+				Export:   true,
+				Register: true,
+			},
+			metrics.StaticIPPoolAllocatedMetric: {
+				Export:   true,
+				Register: true,
+			},
+		},
+	}
+	staticIPPoolMetricsCalculation := lte_calculations.StaticIPPoolMetricsCalculation{
+		BaseCalculation: calculations.BaseCalculation{
+			CalculationParams: calculations.CalculationParams{
+				AnalyticsConfig: analyticsConfig,
+			},
+		},
+	}
+	results, err := staticIPPoolMetricsCalculation.Calculate(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, len(results), 4)
+	resultMetricMap := make(map[string]float64)
+	for _, result := range results {
+		labels := result.GetLabels()
+		assert.Equal(t, "n0", labels[metrics.NetworkLabelName])
+		resultMetricMap[result.GetMetricName()+"/"+labels[metrics.StaticIPPoolLabel]+"/"+labels[metrics.APNLabel]] = result.GetValue()
+	}
+	assert.Equal(t, map[string]float64{
+		metrics.StaticIPPoolSizeMetric + "/p0/internet":      254,
+		metrics.StaticIPPoolAllocatedMetric + "/p0/internet": 2,
+		metrics.StaticIPPoolSizeMetric + "/p1/ims":           2,
+		metrics.StaticIPPoolAllocatedMetric + "/p1/ims":      1,
+	}, resultMetricMap)
+}
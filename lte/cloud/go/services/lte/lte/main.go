@@ -21,6 +21,7 @@ import (
 	lte_protos "magma/lte/cloud/go/services/lte/protos"
 	"magma/lte/cloud/go/services/lte/servicers"
 	lte_storage "magma/lte/cloud/go/services/lte/storage"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/swagger"
 	swagger_protos "magma/orc8r/cloud/go/obsidian/swagger/protos"
@@ -47,7 +48,6 @@ func main() {
 	obsidian.AttachHandlers(srv.EchoServer, handlers.GetHandlers())
 
 	builder_protos.RegisterMconfigBuilderServer(srv.GrpcServer, servicers.NewBuilderServicer())
-	provider_protos.RegisterStreamProviderServer(srv.GrpcServer, servicers.NewProviderServicer())
 	state_protos.RegisterIndexerServer(srv.GrpcServer, servicers.NewIndexerServicer())
 
 	swagger_protos.RegisterSwaggerSpecServer(srv.GrpcServer, swagger.NewSpecServicerFromFile(lte_service.ServiceName))
@@ -63,6 +63,13 @@ func main() {
 	}
 	lte_protos.RegisterEnodebStateLookupServer(srv.GrpcServer, servicers.NewLookupServicer(enbStateStore))
 
+	// The subscriber auth keys are decrypted only when streamed to the gateways
+	keyring, err := crypto.NewKeyringFromServiceConfig(db, sqorc.GetSqlBuilder())
+	if err != nil {
+		glog.Fatalf("Error initializing subscriber auth key keyring: %v", err)
+	}
+	provider_protos.RegisterStreamProviderServer(srv.GrpcServer, servicers.NewProviderServicer(keyring))
+
 	var serviceConfig lte_service.Config
 	_, _, err = config.GetStructuredServiceConfig(lte.ModuleName, lte_service.ServiceName, &serviceConfig)
 	if err != nil {
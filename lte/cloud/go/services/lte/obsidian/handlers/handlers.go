@@ -16,10 +16,12 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"sort"
 
 	"magma/lte/cloud/go/lte"
 	"magma/lte/cloud/go/serdes"
 	lte_models "magma/lte/cloud/go/services/lte/obsidian/models"
+	"magma/lte/cloud/go/services/lte/planning"
 	policydb_models "magma/lte/cloud/go/services/policydb/obsidian/models"
 	"magma/orc8r/cloud/go/models"
 	"magma/orc8r/cloud/go/obsidian"
@@ -28,9 +30,11 @@ import (
 	"magma/orc8r/cloud/go/services/orchestrator/obsidian/handlers"
 	orc8r_models "magma/orc8r/cloud/go/services/orchestrator/obsidian/models"
 	"magma/orc8r/cloud/go/services/state"
+	state_types "magma/orc8r/cloud/go/services/state/types"
 	"magma/orc8r/cloud/go/storage"
 	merrors "magma/orc8r/lib/go/errors"
 
+	"github.com/go-openapi/swag"
 	"github.com/labstack/echo"
 	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
@@ -85,6 +89,13 @@ const (
 	ListEnodebsPath    = ManageNetworkPath + obsidian.UrlSep + Enodebs
 	ManageEnodebPath   = ListEnodebsPath + obsidian.UrlSep + ":enodeb_serial"
 	GetEnodebStatePath = ManageEnodebPath + obsidian.UrlSep + "state"
+
+	ListEnodebTemplatesPath    = ManageNetworkPath + obsidian.UrlSep + "enodeb_templates"
+	ManageEnodebTemplatePath   = ListEnodebTemplatesPath + obsidian.UrlSep + ":enodeb_template_id"
+	GetEnodebTemplateDriftPath = ManageEnodebTemplatePath + obsidian.UrlSep + "drift"
+
+	ManageNetworkRANPlanningPath = ManageNetworkPath + obsidian.UrlSep + "ran_planning"
+	ManageNetworkRANPlanPath     = ManageNetworkRANPlanningPath + obsidian.UrlSep + "plan"
 )
 
 func GetHandlers() []obsidian.Handler {
@@ -111,6 +122,16 @@ func GetHandlers() []obsidian.Handler {
 		{Path: ManageGatewayConnectedEnodebsPath, Methods: obsidian.DELETE, HandlerFunc: deleteConnectedEnodeb},
 		{Path: GetEnodebStatePath, Methods: obsidian.GET, HandlerFunc: getEnodebState},
 
+		{Path: ListEnodebTemplatesPath, Methods: obsidian.GET, HandlerFunc: listEnodebTemplates},
+		{Path: ListEnodebTemplatesPath, Methods: obsidian.POST, HandlerFunc: createEnodebTemplate},
+		{Path: ManageEnodebTemplatePath, Methods: obsidian.GET, HandlerFunc: getEnodebTemplate},
+		{Path: ManageEnodebTemplatePath, Methods: obsidian.PUT, HandlerFunc: updateEnodebTemplate},
+		{Path: ManageEnodebTemplatePath, Methods: obsidian.DELETE, HandlerFunc: deleteEnodebTemplate},
+		{Path: GetEnodebTemplateDriftPath, Methods: obsidian.GET, HandlerFunc: getEnodebTemplateDrift},
+
+		{Path: ManageNetworkRANPlanPath, Methods: obsidian.GET, HandlerFunc: getRANPlan},
+		{Path: ManageNetworkRANPlanPath, Methods: obsidian.POST, HandlerFunc: applyRANPlan},
+
 		{Path: ListGatewayPoolsPath, Methods: obsidian.GET, HandlerFunc: listGatewayPoolsHandler},
 		{Path: ListGatewayPoolsPath, Methods: obsidian.POST, HandlerFunc: createGatewayPoolHandler},
 		{Path: ManageGatewayPoolsPath, Methods: obsidian.GET, HandlerFunc: getGatewayPoolHandler},
@@ -139,6 +160,7 @@ func GetHandlers() []obsidian.Handler {
 	ret = append(ret, handlers.GetPartialNetworkHandlers(ManageNetworkCellularEpcPath, &lte_models.NetworkEpcConfigs{}, "", serdes.Network)...)
 	ret = append(ret, handlers.GetPartialNetworkHandlers(ManageNetworkCellularRanPath, &lte_models.NetworkRanConfigs{}, "", serdes.Network)...)
 	ret = append(ret, handlers.GetPartialNetworkHandlers(ManageNetworkCellularFegNetworkID, new(lte_models.FegNetworkID), "", serdes.Network)...)
+	ret = append(ret, handlers.GetPartialNetworkHandlers(ManageNetworkRANPlanningPath, &lte_models.RanPlanningConfig{}, lte.RANPlanningConfigType, serdes.Network)...)
 	ret = append(ret, handlers.GetPartialNetworkHandlers(ManageNetworkSubscriberPath, &policydb_models.NetworkSubscriberConfig{}, "", serdes.Network)...)
 	ret = append(ret, handlers.GetPartialNetworkHandlers(ManageNetworkRuleNamesPath, new(policydb_models.RuleNames), "", serdes.Network)...)
 	ret = append(ret, handlers.GetPartialNetworkHandlers(ManageNetworkBaseNamesPath, new(policydb_models.BaseNames), "", serdes.Network)...)
@@ -332,19 +354,22 @@ func createEnodeb(c echo.Context) error {
 	if payload.AttachedGatewayID != "" {
 		return echo.NewHTTPError(http.StatusBadRequest, "attached_gateway_id is a read-only property")
 	}
+	if nerr := validateEnodebTemplate(nid, payload.EnodebConfig); nerr != nil {
+		return nerr
+	}
 
-	_, err := configurator.CreateEntity(
-		nid,
-		configurator.NetworkEntity{
-			Type:        lte.CellularEnodebEntityType,
-			Key:         payload.Serial,
-			Name:        payload.Name,
-			Description: payload.Description,
-			PhysicalID:  payload.Serial,
-			Config:      payload.EnodebConfig,
-		},
-		serdes.Entity,
-	)
+	ent := configurator.NetworkEntity{
+		Type:        lte.CellularEnodebEntityType,
+		Key:         payload.Serial,
+		Name:        payload.Name,
+		Description: payload.Description,
+		PhysicalID:  payload.Serial,
+		Config:      payload.EnodebConfig,
+	}
+	if payload.EnodebConfig != nil {
+		ent.Associations = payload.EnodebConfig.GetTemplateTKs()
+	}
+	_, err := configurator.CreateEntity(nid, ent, serdes.Entity)
 	if err != nil {
 		return obsidian.HttpError(err, http.StatusInternalServerError)
 	}
@@ -393,6 +418,9 @@ func updateEnodeb(c echo.Context) error {
 	if payload.Serial != eid {
 		return echo.NewHTTPError(http.StatusBadRequest, "serial in body must match serial in path")
 	}
+	if nerr := validateEnodebTemplate(nid, payload.EnodebConfig); nerr != nil {
+		return nerr
+	}
 
 	_, err := configurator.UpdateEntity(nid, payload.ToEntityUpdateCriteria(), serdes.Entity)
 	if err != nil {
@@ -434,6 +462,24 @@ func getEnodebState(c echo.Context) error {
 	return c.JSON(http.StatusOK, enodebState)
 }
 
+// validateEnodebTemplate returns an error if the enodeB config references a
+// template which doesn't exist
+func validateEnodebTemplate(networkID string, config *lte_models.EnodebConfig) *echo.HTTPError {
+	if config == nil {
+		return nil
+	}
+	for _, tk := range config.GetTemplateTKs() {
+		exists, err := configurator.DoesEntityExist(networkID, tk.Type, tk.Key)
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to check if enodeB template exists"), http.StatusInternalServerError)
+		}
+		if !exists {
+			return obsidian.HttpError(fmt.Errorf("enodeB template %s does not exist", tk.Key), http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
 func getNetworkAndEnbIDs(c echo.Context) (string, string, *echo.HTTPError) {
 	vals, err := obsidian.GetParamValues(c, "network_id", "enodeb_serial")
 	if err != nil {
@@ -876,6 +922,409 @@ func deleteGatewayPoolHandler(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+func listEnodebTemplates(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	ents, _, err := configurator.LoadAllEntitiesOfType(
+		networkID, lte.CellularEnodebTemplateEntityType,
+		configurator.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+
+	ret := make(map[string]*lte_models.EnodebTemplate, len(ents))
+	for _, ent := range ents {
+		ret[ent.Key] = (&lte_models.EnodebTemplate{}).FromBackendModels(ent)
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+func createEnodebTemplate(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	payload := &lte_models.EnodebTemplate{}
+	if err := c.Bind(payload); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := payload.ValidateModel(); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	_, err := configurator.CreateEntity(networkID, payload.ToEntity(), serdes.Entity)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+func getEnodebTemplate(c echo.Context) error {
+	networkID, templateID, nerr := getNetworkIDAndEnodebTemplateID(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	ent, err := configurator.LoadEntity(
+		networkID, lte.CellularEnodebTemplateEntityType, templateID,
+		configurator.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return makeErr(err)
+	}
+	return c.JSON(http.StatusOK, (&lte_models.EnodebTemplate{}).FromBackendModels(ent))
+}
+
+func updateEnodebTemplate(c echo.Context) error {
+	networkID, templateID, nerr := getNetworkIDAndEnodebTemplateID(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	payload := &lte_models.EnodebTemplate{}
+	if err := c.Bind(payload); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := payload.ValidateModel(); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if string(payload.ID) != templateID {
+		return echo.NewHTTPError(http.StatusBadRequest, "template ID in body must match template ID in path")
+	}
+
+	exists, err := configurator.DoesEntityExist(networkID, lte.CellularEnodebTemplateEntityType, templateID)
+	if err != nil {
+		return obsidian.HttpError(errors.Wrap(err, "failed to check if enodeB template exists"), http.StatusInternalServerError)
+	}
+	if !exists {
+		return echo.ErrNotFound
+	}
+	_, err = configurator.UpdateEntity(networkID, payload.ToEntityUpdateCriteria(), serdes.Entity)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func deleteEnodebTemplate(c echo.Context) error {
+	networkID, templateID, nerr := getNetworkIDAndEnodebTemplateID(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	ent, err := configurator.LoadEntity(
+		networkID, lte.CellularEnodebTemplateEntityType, templateID,
+		configurator.EntityLoadCriteria{LoadAssocsToThis: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return makeErr(err)
+	}
+	// Deleting the template would silently leave its enodeBs without a
+	// configuration, so they must first be moved off of it
+	enodebSerials := ent.ParentAssociations.Filter(lte.CellularEnodebEntityType).Keys()
+	if len(enodebSerials) > 0 {
+		err := fmt.Errorf("enodeBs %v still use template %s. All enodeBs must first be moved off of the template before it can be deleted", enodebSerials, templateID)
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	err = configurator.DeleteEntity(networkID, lte.CellularEnodebTemplateEntityType, templateID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getEnodebTemplateDrift returns the fields of the enodeBs using a template
+// which override the template with a different value.
+func getEnodebTemplateDrift(c echo.Context) error {
+	networkID, templateID, nerr := getNetworkIDAndEnodebTemplateID(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	templateEnt, err := configurator.LoadEntity(
+		networkID, lte.CellularEnodebTemplateEntityType, templateID,
+		configurator.EntityLoadCriteria{LoadConfig: true, LoadAssocsToThis: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return makeErr(err)
+	}
+	template := (&lte_models.EnodebTemplate{}).FromBackendModels(templateEnt)
+
+	enodebTKs := templateEnt.ParentAssociations.Filter(lte.CellularEnodebEntityType)
+	enodebEnts, _, err := configurator.LoadEntities(
+		networkID, nil, nil, nil, enodebTKs,
+		configurator.EntityLoadCriteria{LoadConfig: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return obsidian.HttpError(errors.Wrap(err, "failed to load enodeBs"), http.StatusInternalServerError)
+	}
+
+	ret := make([]*lte_models.EnodebTemplateDrift, 0, len(enodebEnts))
+	for _, ent := range enodebEnts {
+		config, ok := ent.Config.(*lte_models.EnodebConfig)
+		if !ok || config.TemplatedConfig == nil {
+			continue
+		}
+		ret = append(ret, &lte_models.EnodebTemplateDrift{
+			EnodebSerial:  ent.Key,
+			DriftedFields: config.TemplatedConfig.GetDriftedFields(template.Config),
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].EnodebSerial < ret[j].EnodebSerial })
+	return c.JSON(http.StatusOK, ret)
+}
+
+// getRANPlan returns the conflicts between the PCIs of the enodeBs of the
+// network, and the changes which would resolve them.
+func getRANPlan(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	plan, nerr := makeRANPlan(networkID)
+	if nerr != nil {
+		return nerr
+	}
+	return c.JSON(http.StatusOK, plan.toModel())
+}
+
+// applyRANPlan applies a previewed plan of the changes which resolve the
+// conflicts between the PCIs of the enodeBs of the network, and returns the
+// applied plan. Previews which no longer match the plan of the network, e.g.
+// because enodeBs changed since, are rejected as stale.
+func applyRANPlan(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	previewed := &lte_models.RanPlan{}
+	if err := c.Bind(previewed); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := previewed.ValidateModel(); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	plan, nerr := makeRANPlan(networkID)
+	if nerr != nil {
+		return nerr
+	}
+	if !plan.toModel().HasSameChanges(previewed) {
+		return echo.NewHTTPError(http.StatusConflict, "RAN plan is stale, preview it again")
+	}
+	updates := plan.getEnodebUpdates()
+	if len(updates) > 0 {
+		_, err := configurator.UpdateEntities(networkID, updates, serdes.Entity)
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to update enodeBs"), http.StatusInternalServerError)
+		}
+	}
+	return c.JSON(http.StatusOK, plan.toModel())
+}
+
+// ranPlan is the planning of the PCIs and EARFCNs of the enodeBs of a
+// network
+type ranPlan struct {
+	enodebConfigs map[string]*lte_models.EnodebConfig
+	conflicts     []planning.Conflict
+	changes       []planning.Change
+	unresolved    []string
+}
+
+func makeRANPlan(networkID string) (*ranPlan, *echo.HTTPError) {
+	network, err := configurator.LoadNetwork(networkID, false, true, serdes.Network)
+	if err == merrors.ErrNotFound {
+		return nil, echo.ErrNotFound
+	}
+	if err != nil {
+		return nil, obsidian.HttpError(errors.Wrap(err, "failed to load network"), http.StatusInternalServerError)
+	}
+	iCellularConfig, ok := network.Configs[lte.CellularNetworkConfigType]
+	if !ok || iCellularConfig == nil {
+		return nil, obsidian.HttpError(errors.New("network has no cellular config"), http.StatusBadRequest)
+	}
+	cellularConfig := iCellularConfig.(*lte_models.NetworkCellularConfigs)
+	planningConfig := &lte_models.RanPlanningConfig{}
+	if iPlanningConfig, ok := network.Configs[lte.RANPlanningConfigType]; ok && iPlanningConfig != nil {
+		planningConfig = iPlanningConfig.(*lte_models.RanPlanningConfig)
+	}
+
+	enodebs, _, err := configurator.LoadAllEntitiesOfType(
+		networkID, lte.CellularEnodebEntityType,
+		configurator.EntityLoadCriteria{LoadConfig: true, LoadAssocsToThis: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return nil, obsidian.HttpError(errors.Wrap(err, "failed to load enodeBs"), http.StatusInternalServerError)
+	}
+	templates, _, err := configurator.LoadAllEntitiesOfType(
+		networkID, lte.CellularEnodebTemplateEntityType,
+		configurator.EntityLoadCriteria{LoadConfig: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return nil, obsidian.HttpError(errors.Wrap(err, "failed to load enodeB templates"), http.StatusInternalServerError)
+	}
+	gatewayPCIs, nerr := getGatewayPCIs(networkID)
+	if nerr != nil {
+		return nil, nerr
+	}
+	states, err := state.SearchStates(networkID, []string{lte.EnodebStateType}, nil, nil, serdes.State)
+	if err != nil {
+		return nil, obsidian.HttpError(errors.Wrap(err, "failed to load enodeB states"), http.StatusInternalServerError)
+	}
+
+	templateConfigs := map[string]*lte_models.EnodebTemplateConfiguration{}
+	for _, ent := range templates {
+		if ent.Config != nil {
+			templateConfigs[ent.Key] = ent.Config.(*lte_models.EnodebTemplateConfiguration)
+		}
+	}
+
+	plan := &ranPlan{enodebConfigs: map[string]*lte_models.EnodebConfig{}}
+	var cells []planning.Cell
+	for _, ent := range enodebs {
+		enodebConfig, ok := ent.Config.(*lte_models.EnodebConfig)
+		if !ok {
+			continue
+		}
+		var config *lte_models.EnodebConfiguration
+		switch {
+		case enodebConfig.ConfigType == lte_models.ManagedConfigType && enodebConfig.ManagedConfig != nil:
+			config = enodebConfig.ManagedConfig
+		case enodebConfig.ConfigType == lte_models.TemplatedConfigType && enodebConfig.TemplatedConfig != nil:
+			template, ok := templateConfigs[string(enodebConfig.TemplatedConfig.TemplateID)]
+			if !ok {
+				continue
+			}
+			config = enodebConfig.TemplatedConfig.Merge(template)
+		default:
+			// The PCIs of unmanaged enodeBs aren't known
+			continue
+		}
+
+		cell := planning.Cell{Serial: ent.Key, PCI: config.Pci, Earfcndl: config.Earfcndl, Tac: config.Tac}
+		st, hasState := states[state_types.ID{Type: lte.EnodebStateType, DeviceID: ent.Key}]
+		if cell.PCI == 0 {
+			// enodeBs without a PCI use the PCI of their gateway, or else of
+			// the gateway which reports their state
+			gatewayIDs := ent.ParentAssociations.Filter(lte.CellularGatewayEntityType).Keys()
+			if len(gatewayIDs) > 0 {
+				cell.PCI = gatewayPCIs.byID[gatewayIDs[0]]
+			} else if hasState {
+				cell.PCI = gatewayPCIs.byHardwareID[st.ReporterID]
+			}
+		}
+		if cell.Earfcndl == 0 && cellularConfig.Ran != nil {
+			cell.Earfcndl = cellularConfig.GetEarfcndl()
+		}
+		if cell.Tac == 0 && cellularConfig.Epc != nil {
+			cell.Tac = cellularConfig.Epc.Tac
+		}
+		if hasState {
+			if enodebState, ok := st.ReportedState.(*lte_models.EnodebState); ok {
+				cell.Transmitting = swag.BoolValue(enodebState.RfTxOn)
+			}
+		}
+		cells = append(cells, cell)
+		plan.enodebConfigs[ent.Key] = enodebConfig
+	}
+
+	neighbors := planning.GetNeighbors(cells, planningConfig.GetDeclaredNeighbors())
+	plan.conflicts = planning.FindConflicts(cells, neighbors)
+	plan.changes, plan.unresolved = planning.Plan(cells, neighbors, planningConfig.Earfcndls)
+	return plan, nil
+}
+
+func (p *ranPlan) toModel() *lte_models.RanPlan {
+	return (&lte_models.RanPlan{}).FromPlanning(p.conflicts, p.changes, p.unresolved)
+}
+
+// getEnodebUpdates returns the updates which apply the changes of the plan
+// to the configs of the enodeBs. EARFCNs are only set when they change, so
+// that enodeBs keep inheriting them otherwise.
+func (p *ranPlan) getEnodebUpdates() []configurator.EntityUpdateCriteria {
+	var ret []configurator.EntityUpdateCriteria
+	for _, change := range p.changes {
+		enodebConfig := p.enodebConfigs[change.Serial]
+		switch enodebConfig.ConfigType {
+		case lte_models.ManagedConfigType:
+			enodebConfig.ManagedConfig.Pci = change.NewPCI
+			if change.NewEarfcndl != change.OldEarfcndl {
+				enodebConfig.ManagedConfig.Earfcndl = change.NewEarfcndl
+			}
+		case lte_models.TemplatedConfigType:
+			enodebConfig.TemplatedConfig.Pci = change.NewPCI
+			if change.NewEarfcndl != change.OldEarfcndl {
+				enodebConfig.TemplatedConfig.Earfcndl = change.NewEarfcndl
+			}
+		}
+		ret = append(ret, configurator.EntityUpdateCriteria{
+			Type:      lte.CellularEnodebEntityType,
+			Key:       change.Serial,
+			NewConfig: enodebConfig,
+		})
+	}
+	return ret
+}
+
+type gatewayPCIs struct {
+	byID         map[string]uint32
+	byHardwareID map[string]uint32
+}
+
+// getGatewayPCIs returns the PCIs of the RAN configs of the gateways of the
+// network, by gateway ID and by hardware ID
+func getGatewayPCIs(networkID string) (gatewayPCIs, *echo.HTTPError) {
+	ret := gatewayPCIs{byID: map[string]uint32{}, byHardwareID: map[string]uint32{}}
+	cellularGateways, _, err := configurator.LoadAllEntitiesOfType(
+		networkID, lte.CellularGatewayEntityType,
+		configurator.EntityLoadCriteria{LoadConfig: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return ret, obsidian.HttpError(errors.Wrap(err, "failed to load cellular gateways"), http.StatusInternalServerError)
+	}
+	for _, ent := range cellularGateways {
+		config, ok := ent.Config.(*lte_models.GatewayCellularConfigs)
+		if ok && config.Ran != nil {
+			ret.byID[ent.Key] = config.Ran.Pci
+		}
+	}
+
+	magmadGateways, _, err := configurator.LoadAllEntitiesOfType(networkID, orc8r.MagmadGatewayType, configurator.EntityLoadCriteria{}, serdes.Entity)
+	if err != nil {
+		return ret, obsidian.HttpError(errors.Wrap(err, "failed to load gateways"), http.StatusInternalServerError)
+	}
+	for _, ent := range magmadGateways {
+		if pci, ok := ret.byID[ent.Key]; ok {
+			ret.byHardwareID[ent.PhysicalID] = pci
+		}
+	}
+	return ret, nil
+}
+
+func getNetworkIDAndEnodebTemplateID(c echo.Context) (string, string, *echo.HTTPError) {
+	vals, err := obsidian.GetParamValues(c, "network_id", "enodeb_template_id")
+	if err != nil {
+		return "", "", err
+	}
+	return vals[0], vals[1], nil
+}
+
 func getNetworkIDAndGatewayPoolID(c echo.Context) (string, string, *echo.HTTPError) {
 	vals, err := obsidian.GetParamValues(c, "network_id", "gateway_pool_id")
 	if err != nil {
@@ -2725,6 +2725,450 @@ func TestGetEnodebState(t *testing.T) {
 	tests.RunUnitTest(t, e, tc)
 }
 
+func TestEnodebTemplates(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1"}, serdes.Network)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	templatesURLRoot := "/magma/v1/lte/:network_id/enodeb_templates"
+	templateURL := templatesURLRoot + "/:enodeb_template_id"
+	enodebsURLRoot := "/magma/v1/lte/:network_id/enodebs"
+
+	obsidianHandlers := handlers.GetHandlers()
+	listTemplates := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, templatesURLRoot, obsidian.GET).HandlerFunc
+	createTemplate := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, templatesURLRoot, obsidian.POST).HandlerFunc
+	getTemplate := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, templateURL, obsidian.GET).HandlerFunc
+	updateTemplate := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, templateURL, obsidian.PUT).HandlerFunc
+	deleteTemplate := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, templateURL, obsidian.DELETE).HandlerFunc
+	getDrift := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, templateURL+"/drift", obsidian.GET).HandlerFunc
+	createEnodeb := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, enodebsURLRoot, obsidian.POST).HandlerFunc
+	updateEnodeb := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, enodebsURLRoot+"/:enodeb_serial", obsidian.PUT).HandlerFunc
+
+	// List empty
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            templatesURLRoot,
+		Handler:        listTemplates,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(map[string]*lteModels.EnodebTemplate{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Create template
+	template := &lteModels.EnodebTemplate{
+		ID:          "baicells_b3",
+		Name:        "Baicells band 3",
+		Description: "outdoor FDD",
+		Config: &lteModels.EnodebTemplateConfiguration{
+			BandwidthMhz:    20,
+			DeviceClass:     "Baicells Nova-233 G2 OD FDD",
+			Earfcndl:        1300,
+			Tac:             1,
+			TransmitEnabled: swag.Bool(true),
+		},
+	}
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            templatesURLRoot,
+		Handler:        createTemplate,
+		Payload:        template,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Create invalid template
+	tc.Payload = &lteModels.EnodebTemplate{ID: "baicells_b7", Name: "Baicells band 7"}
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "validation failure list:\nconfig in body is required"
+	tests.RunUnitTest(t, e, tc)
+
+	// Get template
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            templateURL,
+		Handler:        getTemplate,
+		ParamNames:     []string{"network_id", "enodeb_template_id"},
+		ParamValues:    []string{"n1", "baicells_b3"},
+		ExpectedStatus: 200,
+		ExpectedResult: template,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.ParamValues = []string{"n1", "baicells_b7"}
+	tc.ExpectedStatus = 404
+	tc.ExpectedError = "Not Found"
+	tests.RunUnitTest(t, e, tc)
+
+	// Create enodeB referencing a missing template
+	enodeb := &lteModels.Enodeb{
+		Config: &lteModels.EnodebConfiguration{
+			CellID:          swag.Uint32(1234),
+			DeviceClass:     "Baicells Nova-233 G2 OD FDD",
+			TransmitEnabled: swag.Bool(true),
+		},
+		EnodebConfig: &lteModels.EnodebConfig{
+			ConfigType: lteModels.TemplatedConfigType,
+			TemplatedConfig: &lteModels.TemplatedEnodebConfiguration{
+				TemplateID: "baicells_b7",
+				CellID:     swag.Uint32(1234),
+				Earfcndl:   1301,
+				Pci:        260,
+			},
+		},
+		Name:   "foobar",
+		Serial: "enb1",
+	}
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            enodebsURLRoot,
+		Handler:        createEnodeb,
+		Payload:        enodeb,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 400,
+		ExpectedError:  "enodeB template baicells_b7 does not exist",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Create enodeBs referencing the template
+	enodeb.EnodebConfig.TemplatedConfig.TemplateID = "baicells_b3"
+	tc.ExpectedStatus = 201
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+
+	enodeb2 := &lteModels.Enodeb{
+		Config: &lteModels.EnodebConfiguration{
+			CellID:          swag.Uint32(1235),
+			DeviceClass:     "Baicells Nova-233 G2 OD FDD",
+			TransmitEnabled: swag.Bool(true),
+		},
+		EnodebConfig: &lteModels.EnodebConfig{
+			ConfigType: lteModels.TemplatedConfigType,
+			TemplatedConfig: &lteModels.TemplatedEnodebConfiguration{
+				TemplateID: "baicells_b3",
+				CellID:     swag.Uint32(1235),
+				Earfcndl:   1300,
+			},
+		},
+		Name:   "foobar 2",
+		Serial: "enb2",
+	}
+	tc.Payload = enodeb2
+	tests.RunUnitTest(t, e, tc)
+
+	actual, err := configurator.LoadEntity("n1", lte.CellularEnodebEntityType, "enb1", configurator.EntityLoadCriteria{LoadAssocsFromThis: true}, serdes.Entity)
+	assert.NoError(t, err)
+	assert.Equal(t, storage.TKs{{Type: lte.CellularEnodebTemplateEntityType, Key: "baicells_b3"}}, actual.Associations)
+
+	// Get drift
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            templateURL + "/drift",
+		Handler:        getDrift,
+		ParamNames:     []string{"network_id", "enodeb_template_id"},
+		ParamValues:    []string{"n1", "baicells_b3"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*lteModels.EnodebTemplateDrift{
+			{EnodebSerial: "enb1", DriftedFields: []string{"earfcndl"}},
+			{EnodebSerial: "enb2", DriftedFields: []string{}},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Update template
+	template.Config.Earfcndl = 1301
+	template.Config.Tac = 2
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            templateURL,
+		Handler:        updateTemplate,
+		Payload:        template,
+		ParamNames:     []string{"network_id", "enodeb_template_id"},
+		ParamValues:    []string{"n1", "baicells_b3"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.ParamValues = []string{"n1", "baicells_b7"}
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "template ID in body must match template ID in path"
+	tests.RunUnitTest(t, e, tc)
+
+	// List templates
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            templatesURLRoot,
+		Handler:        listTemplates,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(map[string]*lteModels.EnodebTemplate{"baicells_b3": template}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// enb1 now matches the template and enb2 has drifted
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            templateURL + "/drift",
+		Handler:        getDrift,
+		ParamNames:     []string{"network_id", "enodeb_template_id"},
+		ParamValues:    []string{"n1", "baicells_b3"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*lteModels.EnodebTemplateDrift{
+			{EnodebSerial: "enb1", DriftedFields: []string{}},
+			{EnodebSerial: "enb2", DriftedFields: []string{"earfcndl"}},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Delete fails while enodeBs use the template
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            templateURL,
+		Handler:        deleteTemplate,
+		ParamNames:     []string{"network_id", "enodeb_template_id"},
+		ParamValues:    []string{"n1", "baicells_b3"},
+		ExpectedStatus: 400,
+		ExpectedError:  "enodeBs [enb1 enb2] still use template baicells_b3. All enodeBs must first be moved off of the template before it can be deleted",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Move the enodeBs off of the template
+	for _, enb := range []*lteModels.Enodeb{enodeb, enodeb2} {
+		enb.EnodebConfig = &lteModels.EnodebConfig{
+			ConfigType: "MANAGED",
+			ManagedConfig: &lteModels.EnodebConfiguration{
+				CellID:          enb.EnodebConfig.TemplatedConfig.CellID,
+				DeviceClass:     "Baicells Nova-233 G2 OD FDD",
+				TransmitEnabled: swag.Bool(true),
+			},
+		}
+		tc = tests.Test{
+			Method:         "PUT",
+			URL:            enodebsURLRoot + "/:enodeb_serial",
+			Handler:        updateEnodeb,
+			Payload:        enb,
+			ParamNames:     []string{"network_id", "enodeb_serial"},
+			ParamValues:    []string{"n1", enb.Serial},
+			ExpectedStatus: 204,
+		}
+		tests.RunUnitTest(t, e, tc)
+	}
+
+	actual, err = configurator.LoadEntity("n1", lte.CellularEnodebEntityType, "enb1", configurator.EntityLoadCriteria{LoadAssocsFromThis: true}, serdes.Entity)
+	assert.NoError(t, err)
+	assert.Empty(t, actual.Associations)
+
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            templateURL,
+		Handler:        deleteTemplate,
+		ParamNames:     []string{"network_id", "enodeb_template_id"},
+		ParamValues:    []string{"n1", "baicells_b3"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	_, err = configurator.LoadEntity("n1", lte.CellularEnodebTemplateEntityType, "baicells_b3", configurator.EntityLoadCriteria{}, serdes.Entity)
+	assert.EqualError(t, err, "Not found")
+}
+
+func TestRANPlanning(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	stateTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	seedNetworks(t)
+
+	e := echo.New()
+	planningURL := "/magma/v1/lte/:network_id/ran_planning"
+	planURL := planningURL + "/plan"
+
+	obsidianHandlers := handlers.GetHandlers()
+	getPlanningConfig := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, planningURL, obsidian.GET).HandlerFunc
+	updatePlanningConfig := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, planningURL, obsidian.PUT).HandlerFunc
+	getPlan := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, planURL, obsidian.GET).HandlerFunc
+	applyPlan := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, planURL, obsidian.POST).HandlerFunc
+
+	ip := strfmt.IPv4("192.168.0.5")
+	// enb1 inherits the PCI 260 of gw1, which reports its state, and collides
+	// with enb2 in the default TAC. enb3 and enb4 are declared neighbours
+	// which collide on PCI 100. enb5 is unmanaged.
+	_, err := configurator.CreateEntities(
+		"n1",
+		[]configurator.NetworkEntity{
+			{
+				Type: lte.CellularEnodebEntityType, Key: "enb1", PhysicalID: "enb1",
+				Config: &lteModels.EnodebConfig{
+					ConfigType:    "MANAGED",
+					ManagedConfig: &lteModels.EnodebConfiguration{CellID: swag.Uint32(1), DeviceClass: "Baicells ID TDD/FDD", TransmitEnabled: swag.Bool(true)},
+				},
+			},
+			{
+				Type: lte.CellularEnodebEntityType, Key: "enb2", PhysicalID: "enb2",
+				Config: &lteModels.EnodebConfig{
+					ConfigType:    "MANAGED",
+					ManagedConfig: &lteModels.EnodebConfiguration{CellID: swag.Uint32(2), Pci: 260, DeviceClass: "Baicells ID TDD/FDD", TransmitEnabled: swag.Bool(true)},
+				},
+			},
+			{
+				Type: lte.CellularEnodebEntityType, Key: "enb3", PhysicalID: "enb3",
+				Config: &lteModels.EnodebConfig{
+					ConfigType:    "MANAGED",
+					ManagedConfig: &lteModels.EnodebConfiguration{CellID: swag.Uint32(3), Pci: 100, Tac: 3, DeviceClass: "Baicells ID TDD/FDD", TransmitEnabled: swag.Bool(true)},
+				},
+			},
+			{
+				Type: lte.CellularEnodebTemplateEntityType, Key: "t1",
+				Config: &lteModels.EnodebTemplateConfiguration{DeviceClass: "Baicells ID TDD/FDD", Tac: 2},
+			},
+			{
+				Type: lte.CellularEnodebEntityType, Key: "enb4", PhysicalID: "enb4",
+				Config: &lteModels.EnodebConfig{
+					ConfigType:      lteModels.TemplatedConfigType,
+					TemplatedConfig: &lteModels.TemplatedEnodebConfiguration{TemplateID: "t1", CellID: swag.Uint32(4), Pci: 100},
+				},
+				Associations: storage.TKs{{Type: lte.CellularEnodebTemplateEntityType, Key: "t1"}},
+			},
+			{
+				Type: lte.CellularEnodebEntityType, Key: "enb5", PhysicalID: "enb5",
+				Config: &lteModels.EnodebConfig{
+					ConfigType: "UNMANAGED",
+					UnmanagedConfig: &lteModels.UnmanagedEnodebConfiguration{
+						CellID:    swag.Uint32(5),
+						IPAddress: &ip,
+						Tac:       swag.Uint32(1),
+					},
+				},
+			},
+			{
+				Type: lte.CellularGatewayEntityType, Key: "gw1",
+				Config: newDefaultGatewayConfig(),
+			},
+			{
+				Type: orc8r.MagmadGatewayType, Key: "gw1", PhysicalID: "hwid1",
+				Associations: storage.TKs{{Type: lte.CellularGatewayEntityType, Key: "gw1"}},
+			},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+
+	ctx := test_utils.GetContextWithCertificate(t, "hwid1")
+	enb1State := lteModels.NewDefaultEnodebStatus()
+	enb1State.RfTxOn = swag.Bool(false)
+	reportEnodebState(t, ctx, "enb1", enb1State)
+	reportEnodebState(t, ctx, "enb2", lteModels.NewDefaultEnodebStatus())
+
+	planningConfig := &lteModels.RanPlanningConfig{
+		Neighbors: map[string]lteModels.EnodebSerials{"enb3": {"enb4"}},
+	}
+	tc := tests.Test{
+		Method:         "PUT",
+		URL:            planningURL,
+		Handler:        updatePlanningConfig,
+		Payload:        planningConfig,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            planningURL,
+		Handler:        getPlanningConfig,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 200,
+		ExpectedResult: planningConfig,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Preview the plan. enb2 keeps its PCI as it's transmitting.
+	expected := &lteModels.RanPlan{
+		Conflicts: []*lteModels.RanConflict{
+			{Type: "PCI_COLLISION", EnodebSerials: []string{"enb3", "enb4"}, Earfcndl: 44590, Pci: 100},
+			{Type: "PCI_COLLISION", EnodebSerials: []string{"enb1", "enb2"}, Earfcndl: 44590, Pci: 260},
+		},
+		Changes: []*lteModels.RanPlanChange{
+			{EnodebSerial: "enb1", OldEarfcndl: 44590, OldPci: 260, NewEarfcndl: 44590, NewPci: 1},
+			{EnodebSerial: "enb4", OldEarfcndl: 44590, OldPci: 100, NewEarfcndl: 44590, NewPci: 1},
+		},
+		Unresolved: []string{},
+	}
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            planURL,
+		Handler:        getPlan,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 200,
+		ExpectedResult: expected,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Previewing doesn't change the enodeBs
+	tests.RunUnitTest(t, e, tc)
+
+	// Plans which don't match the preview are stale
+	tc.Method = "POST"
+	tc.Handler = applyPlan
+	tc.Payload = &lteModels.RanPlan{
+		Conflicts:  []*lteModels.RanConflict{},
+		Changes:    expected.Changes[:1],
+		Unresolved: []string{},
+	}
+	tc.ExpectedStatus = 409
+	tc.ExpectedError = "RAN plan is stale, preview it again"
+	tests.RunUnitTest(t, e, tc)
+
+	tc.Payload = expected
+	tc.ExpectedStatus = 200
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+
+	// The applied plan is stale once applied
+	tc.ExpectedStatus = 409
+	tc.ExpectedError = "RAN plan is stale, preview it again"
+	tests.RunUnitTest(t, e, tc)
+
+	enb1, err := configurator.LoadEntity("n1", lte.CellularEnodebEntityType, "enb1", configurator.EntityLoadCriteria{LoadConfig: true}, serdes.Entity)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), enb1.Config.(*lteModels.EnodebConfig).ManagedConfig.Pci)
+	assert.Equal(t, uint32(0), enb1.Config.(*lteModels.EnodebConfig).ManagedConfig.Earfcndl)
+	enb4, err := configurator.LoadEntity("n1", lte.CellularEnodebEntityType, "enb4", configurator.EntityLoadCriteria{LoadConfig: true}, serdes.Entity)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), enb4.Config.(*lteModels.EnodebConfig).TemplatedConfig.Pci)
+
+	// The enodeBs are now conflict-free
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            planURL,
+		Handler:        getPlan,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 200,
+		ExpectedResult: &lteModels.RanPlan{
+			Conflicts:  []*lteModels.RanConflict{},
+			Changes:    []*lteModels.RanPlanChange{},
+			Unresolved: []string{},
+		},
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Networks without cellular config can't be planned
+	tc.ParamValues = []string{"n3"}
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "network has no cellular config"
+	tests.RunUnitTest(t, e, tc)
+}
+
 func TestCreateApn(t *testing.T) {
 	configuratorTestInit.StartTestService(t)
 	err := configurator.CreateNetwork(configurator.Network{ID: "n1"}, serdes.Network)
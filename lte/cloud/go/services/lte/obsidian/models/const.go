@@ -27,4 +27,7 @@ const (
 	ManagedConfigType = "MANAGED"
 	// UnmanagedConfigType Configuration type for externally managed radios
 	UnmanagedConfigType = "UNMANAGED"
+	// TemplatedConfigType Configuration type for managed radios configured
+	// from a template
+	TemplatedConfigType = "TEMPLATED"
 )
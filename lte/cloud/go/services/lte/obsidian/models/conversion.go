@@ -19,6 +19,7 @@ import (
 
 	"magma/lte/cloud/go/lte"
 	"magma/lte/cloud/go/protos"
+	"magma/lte/cloud/go/services/lte/planning"
 	policydbModels "magma/lte/cloud/go/services/policydb/obsidian/models"
 	"magma/orc8r/cloud/go/models"
 	commonModels "magma/orc8r/cloud/go/models"
@@ -143,6 +144,63 @@ func (m *NetworkRanConfigs) GetFromNetwork(network configurator.Network) interfa
 	return iCellularConfig.(*NetworkCellularConfigs).Ran
 }
 
+func (m *RanPlanningConfig) GetFromNetwork(network configurator.Network) interface{} {
+	return orc8rModels.GetNetworkConfig(network, lte.RANPlanningConfigType)
+}
+
+func (m *RanPlanningConfig) ToUpdateCriteria(network configurator.Network) (configurator.NetworkUpdateCriteria, error) {
+	return orc8rModels.GetNetworkConfigUpdateCriteria(network.ID, lte.RANPlanningConfigType, m), nil
+}
+
+// GetDeclaredNeighbors returns the declared neighbours of the enodeBs, by
+// serial.
+func (m *RanPlanningConfig) GetDeclaredNeighbors() map[string][]string {
+	ret := make(map[string][]string, len(m.Neighbors))
+	for serial, neighbors := range m.Neighbors {
+		ret[serial] = neighbors
+	}
+	return ret
+}
+
+func (m *RanPlan) FromPlanning(conflicts []planning.Conflict, changes []planning.Change, unresolved []string) *RanPlan {
+	m.Conflicts = make([]*RanConflict, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		m.Conflicts = append(m.Conflicts, &RanConflict{
+			Type:          conflict.Type,
+			EnodebSerials: conflict.Serials,
+			Earfcndl:      conflict.Earfcndl,
+			Pci:           conflict.PCI,
+			NeighborOf:    conflict.NeighborOf,
+		})
+	}
+	m.Changes = make([]*RanPlanChange, 0, len(changes))
+	for _, change := range changes {
+		m.Changes = append(m.Changes, &RanPlanChange{
+			EnodebSerial: change.Serial,
+			OldEarfcndl:  change.OldEarfcndl,
+			OldPci:       change.OldPCI,
+			NewEarfcndl:  change.NewEarfcndl,
+			NewPci:       change.NewPCI,
+		})
+	}
+	m.Unresolved = append([]string{}, unresolved...)
+	return m
+}
+
+// HasSameChanges returns true if the plans make the same changes to the
+// same enodeBs.
+func (m *RanPlan) HasSameChanges(other *RanPlan) bool {
+	if len(m.Changes) != len(other.Changes) {
+		return false
+	}
+	for i, change := range m.Changes {
+		if change == nil || other.Changes[i] == nil || *change != *other.Changes[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *LteGateway) FromBackendModels(
 	magmadGateway, cellularGateway configurator.NetworkEntity,
 	loadedEntsByTK configurator.NetworkEntitiesByTK,
@@ -494,13 +552,131 @@ func (m *Enodeb) FromBackendModels(ent configurator.NetworkEntity) *Enodeb {
 }
 
 func (m *Enodeb) ToEntityUpdateCriteria() configurator.EntityUpdateCriteria {
-	return configurator.EntityUpdateCriteria{
+	update := configurator.EntityUpdateCriteria{
 		Type:           lte.CellularEnodebEntityType,
 		Key:            m.Serial,
 		NewName:        swag.String(m.Name),
 		NewDescription: swag.String(m.Description),
 		NewConfig:      m.EnodebConfig,
 	}
+	if m.EnodebConfig != nil {
+		update.AssociationsToSet = m.EnodebConfig.GetTemplateTKs()
+	}
+	return update
+}
+
+// GetTemplateTKs returns the template referenced by the config, if any.
+// The template of an enodeB is associated from the enodeB, so that it is
+// part of the graph of the enodeB's gateway.
+func (m *EnodebConfig) GetTemplateTKs() storage.TKs {
+	tks := storage.TKs{}
+	if m.ConfigType == TemplatedConfigType && m.TemplatedConfig != nil {
+		tks = append(tks, storage.TypeAndKey{Type: lte.CellularEnodebTemplateEntityType, Key: string(m.TemplatedConfig.TemplateID)})
+	}
+	return tks
+}
+
+func (m *EnodebTemplate) FromBackendModels(ent configurator.NetworkEntity) *EnodebTemplate {
+	m.ID = EnodebTemplateID(ent.Key)
+	m.Name = ent.Name
+	m.Description = ent.Description
+	if ent.Config != nil {
+		m.Config = ent.Config.(*EnodebTemplateConfiguration)
+	}
+	return m
+}
+
+func (m *EnodebTemplate) ToEntity() configurator.NetworkEntity {
+	return configurator.NetworkEntity{
+		Type:        lte.CellularEnodebTemplateEntityType,
+		Key:         string(m.ID),
+		Name:        m.Name,
+		Description: m.Description,
+		Config:      m.Config,
+	}
+}
+
+func (m *EnodebTemplate) ToEntityUpdateCriteria() configurator.EntityUpdateCriteria {
+	return configurator.EntityUpdateCriteria{
+		Type:           lte.CellularEnodebTemplateEntityType,
+		Key:            string(m.ID),
+		NewName:        swag.String(m.Name),
+		NewDescription: swag.String(m.Description),
+		NewConfig:      m.Config,
+	}
+}
+
+// Merge returns the configuration of a templated enodeB, i.e. the template
+// overridden by the fields set on the enodeB. Fields unset by both are left
+// unset, to be inherited from the network and gateway configs.
+func (m *TemplatedEnodebConfiguration) Merge(template *EnodebTemplateConfiguration) *EnodebConfiguration {
+	ret := &EnodebConfiguration{
+		CellID: m.CellID,
+		Pci:    m.Pci,
+	}
+	if template != nil {
+		ret.BandwidthMhz = template.BandwidthMhz
+		ret.DeviceClass = template.DeviceClass
+		ret.Earfcndl = template.Earfcndl
+		ret.SpecialSubframePattern = template.SpecialSubframePattern
+		ret.SubframeAssignment = template.SubframeAssignment
+		ret.Tac = template.Tac
+		ret.TransmitEnabled = template.TransmitEnabled
+	}
+
+	if m.BandwidthMhz != 0 {
+		ret.BandwidthMhz = m.BandwidthMhz
+	}
+	if m.DeviceClass != "" {
+		ret.DeviceClass = m.DeviceClass
+	}
+	if m.Earfcndl != 0 {
+		ret.Earfcndl = m.Earfcndl
+	}
+	if m.SpecialSubframePattern != 0 {
+		ret.SpecialSubframePattern = m.SpecialSubframePattern
+	}
+	if m.SubframeAssignment != 0 {
+		ret.SubframeAssignment = m.SubframeAssignment
+	}
+	if m.Tac != 0 {
+		ret.Tac = m.Tac
+	}
+	if m.TransmitEnabled != nil {
+		ret.TransmitEnabled = m.TransmitEnabled
+	}
+	return ret
+}
+
+// GetDriftedFields returns the JSON names of the template fields which the
+// enodeB overrides with a different value.
+func (m *TemplatedEnodebConfiguration) GetDriftedFields(template *EnodebTemplateConfiguration) []string {
+	ret := []string{}
+	if template == nil {
+		template = &EnodebTemplateConfiguration{}
+	}
+	if m.BandwidthMhz != 0 && m.BandwidthMhz != template.BandwidthMhz {
+		ret = append(ret, "bandwidth_mhz")
+	}
+	if m.DeviceClass != "" && m.DeviceClass != template.DeviceClass {
+		ret = append(ret, "device_class")
+	}
+	if m.Earfcndl != 0 && m.Earfcndl != template.Earfcndl {
+		ret = append(ret, "earfcndl")
+	}
+	if m.SpecialSubframePattern != 0 && m.SpecialSubframePattern != template.SpecialSubframePattern {
+		ret = append(ret, "special_subframe_pattern")
+	}
+	if m.SubframeAssignment != 0 && m.SubframeAssignment != template.SubframeAssignment {
+		ret = append(ret, "subframe_assignment")
+	}
+	if m.Tac != 0 && m.Tac != template.Tac {
+		ret = append(ret, "tac")
+	}
+	if m.TransmitEnabled != nil && (template.TransmitEnabled == nil || *m.TransmitEnabled != *template.TransmitEnabled) {
+		ret = append(ret, "transmit_enabled")
+	}
+	return ret
 }
 
 func (m *Apn) FromBackendModels(ent configurator.NetworkEntity) *Apn {
@@ -21,12 +21,15 @@ type EnodebConfig struct {
 
 	// config type
 	// Required: true
-	// Enum: [MANAGED UNMANAGED]
+	// Enum: [MANAGED UNMANAGED TEMPLATED]
 	ConfigType string `json:"config_type"`
 
 	// managed config
 	ManagedConfig *EnodebConfiguration `json:"managed_config,omitempty"`
 
+	// templated config
+	TemplatedConfig *TemplatedEnodebConfiguration `json:"templated_config,omitempty"`
+
 	// unmanaged config
 	UnmanagedConfig *UnmanagedEnodebConfiguration `json:"unmanaged_config,omitempty"`
 }
@@ -43,6 +46,10 @@ func (m *EnodebConfig) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTemplatedConfig(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateUnmanagedConfig(formats); err != nil {
 		res = append(res, err)
 	}
@@ -57,7 +64,7 @@ var enodebConfigTypeConfigTypePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["MANAGED","UNMANAGED"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["MANAGED","UNMANAGED","TEMPLATED"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -72,6 +79,9 @@ const (
 
 	// EnodebConfigConfigTypeUNMANAGED captures enum value "UNMANAGED"
 	EnodebConfigConfigTypeUNMANAGED string = "UNMANAGED"
+
+	// EnodebConfigConfigTypeTEMPLATED captures enum value "TEMPLATED"
+	EnodebConfigConfigTypeTEMPLATED string = "TEMPLATED"
 )
 
 // prop value enum
@@ -114,6 +124,24 @@ func (m *EnodebConfig) validateManagedConfig(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *EnodebConfig) validateTemplatedConfig(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.TemplatedConfig) { // not required
+		return nil
+	}
+
+	if m.TemplatedConfig != nil {
+		if err := m.TemplatedConfig.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("templated_config")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *EnodebConfig) validateUnmanagedConfig(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.UnmanagedConfig) { // not required
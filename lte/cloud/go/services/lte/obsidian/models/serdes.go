@@ -23,12 +23,14 @@ var (
 	// NetworkSerdes contains the package's configurator network config serdes
 	NetworkSerdes = serde.NewRegistry(
 		configurator.NewNetworkConfigSerde(lte.CellularNetworkConfigType, &NetworkCellularConfigs{}),
+		configurator.NewNetworkConfigSerde(lte.RANPlanningConfigType, &RanPlanningConfig{}),
 	)
 	// EntitySerdes contains the package's configurator network entity serdes
 	EntitySerdes = serde.NewRegistry(
 		configurator.NewNetworkEntityConfigSerde(lte.APNEntityType, &ApnConfiguration{}),
 		configurator.NewNetworkEntityConfigSerde(lte.APNResourceEntityType, &ApnResource{}),
 		configurator.NewNetworkEntityConfigSerde(lte.CellularEnodebEntityType, &EnodebConfig{}),
+		configurator.NewNetworkEntityConfigSerde(lte.CellularEnodebTemplateEntityType, &EnodebTemplateConfiguration{}),
 		configurator.NewNetworkEntityConfigSerde(lte.CellularGatewayEntityType, &GatewayCellularConfigs{}),
 		configurator.NewNetworkEntityConfigSerde(lte.CellularGatewayPoolEntityType, &CellularGatewayPoolConfigs{}),
 	)
@@ -357,11 +357,46 @@ func (m *UnmanagedEnodebConfiguration) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
 
+func (m *TemplatedEnodebConfiguration) ValidateModel() error {
+	return m.Validate(strfmt.Default)
+}
+
+func (m *EnodebTemplate) ValidateModel() error {
+	return m.Validate(strfmt.Default)
+}
+
+func (m *EnodebTemplateConfiguration) ValidateModel() error {
+	return m.Validate(strfmt.Default)
+}
+
+func (m *RanPlan) ValidateModel() error {
+	return m.Validate(strfmt.Default)
+}
+
+func (m *RanPlanningConfig) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	for serial := range m.Neighbors {
+		if serial == "" {
+			return errors.New("neighbors must be keyed by enodeB serial")
+		}
+	}
+	return nil
+}
+
 func (m *EnodebConfig) validateEnodebConfig() error {
 	managedConfigSet := m.ManagedConfig != nil
 	unmanagedConfigSet := m.UnmanagedConfig != nil
+	templatedConfigSet := m.TemplatedConfig != nil
 
-	if managedConfigSet && unmanagedConfigSet {
+	numSet := 0
+	for _, set := range []bool{managedConfigSet, unmanagedConfigSet, templatedConfigSet} {
+		if set {
+			numSet++
+		}
+	}
+	if numSet > 1 {
 		return errors.New("only one of the eNodeb config types can be set")
 	}
 
@@ -376,6 +411,14 @@ func (m *EnodebConfig) validateEnodebConfig() error {
 			return errors.New("invalid type set for unmanaged config")
 		}
 	}
+	if templatedConfigSet {
+		if m.ConfigType != TemplatedConfigType {
+			return errors.New("invalid type set for templated config")
+		}
+	}
+	if m.ConfigType == TemplatedConfigType && !templatedConfigSet {
+		return errors.New("templated config must be set for templated eNodeBs")
+	}
 
 	return nil
 }
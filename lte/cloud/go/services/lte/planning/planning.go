@@ -0,0 +1,322 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package planning assigns physical cell IDs (PCIs) and downlink EARFCNs to
+// enodeBs so that neighbouring cells don't conflict.
+//
+// Two cells are neighbours if they share a TAC, or if the operator declared
+// them as neighbours. Cells on the same EARFCN with the same PCI conflict if
+// they are neighbours (collision), or if they are both neighbours of a third
+// cell (confusion), as UEs of that cell can't tell them apart.
+package planning
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// MinPCI and MaxPCI bound the PCIs which the planner assigns
+	MinPCI uint32 = 1
+	MaxPCI uint32 = 503
+
+	// CollisionConflictType is the type of conflicts between neighbours
+	CollisionConflictType = "PCI_COLLISION"
+	// ConfusionConflictType is the type of conflicts between neighbours of a
+	// cell
+	ConfusionConflictType = "PCI_CONFUSION"
+)
+
+// Cell is the radio configuration of an enodeB.
+type Cell struct {
+	Serial   string
+	PCI      uint32
+	Earfcndl uint32
+	Tac      uint32
+	// Transmitting cells keep their assignment in priority, as changing it
+	// drops their UEs
+	Transmitting bool
+}
+
+// Conflict is a set of cells on the same EARFCN with the same PCI.
+type Conflict struct {
+	Type     string
+	Serials  []string
+	PCI      uint32
+	Earfcndl uint32
+	// NeighborOf is the cell whose neighbours are confused, for confusion
+	// conflicts
+	NeighborOf string
+}
+
+// Change is the new assignment of a cell.
+type Change struct {
+	Serial      string
+	OldPCI      uint32
+	OldEarfcndl uint32
+	NewPCI      uint32
+	NewEarfcndl uint32
+}
+
+// Neighbors maps the serial of each cell to the sorted serials of its
+// neighbours.
+type Neighbors map[string][]string
+
+// GetNeighbors returns the neighbours of the cells, i.e. the cells which share
+// their TAC, and the declared neighbours. Declared neighbour relations are
+// symmetric, and declared serials which aren't cells are ignored.
+func GetNeighbors(cells []Cell, declared map[string][]string) Neighbors {
+	sets := map[string]map[string]bool{}
+	for _, cell := range cells {
+		sets[cell.Serial] = map[string]bool{}
+	}
+	link := func(a, b string) {
+		if a == b || sets[a] == nil || sets[b] == nil {
+			return
+		}
+		sets[a][b] = true
+		sets[b][a] = true
+	}
+
+	for i, a := range cells {
+		for _, b := range cells[i+1:] {
+			if a.Tac == b.Tac {
+				link(a.Serial, b.Serial)
+			}
+		}
+	}
+	for serial, neighbors := range declared {
+		for _, neighbor := range neighbors {
+			link(serial, neighbor)
+		}
+	}
+
+	ret := make(Neighbors, len(sets))
+	for serial, set := range sets {
+		ret[serial] = make([]string, 0, len(set))
+		for neighbor := range set {
+			ret[serial] = append(ret[serial], neighbor)
+		}
+		sort.Strings(ret[serial])
+	}
+	return ret
+}
+
+// FindConflicts returns the collisions and confusions between the cells,
+// sorted by type, EARFCN, PCI and serials. Confusions between cells which
+// all collide with each other aren't reported again.
+func FindConflicts(cells []Cell, neighbors Neighbors) []Conflict {
+	cellsBySerial := getCellsBySerial(cells)
+	var ret []Conflict
+
+	for _, cell := range sortCells(cells) {
+		for _, neighbor := range neighbors[cell.Serial] {
+			other, ok := cellsBySerial[neighbor]
+			if !ok || neighbor < cell.Serial || !sameCode(cell, other) {
+				continue
+			}
+			ret = append(ret, Conflict{
+				Type:     CollisionConflictType,
+				Serials:  []string{cell.Serial, neighbor},
+				PCI:      cell.PCI,
+				Earfcndl: cell.Earfcndl,
+			})
+		}
+
+		groups := map[code][]string{}
+		for _, neighbor := range neighbors[cell.Serial] {
+			if other, ok := cellsBySerial[neighbor]; ok && other.PCI != 0 {
+				groups[getCode(other)] = append(groups[getCode(other)], neighbor)
+			}
+		}
+		for c, serials := range groups {
+			if len(serials) < 2 || allNeighbors(serials, neighbors) {
+				continue
+			}
+			ret = append(ret, Conflict{
+				Type:       ConfusionConflictType,
+				Serials:    serials,
+				PCI:        c.pci,
+				Earfcndl:   c.earfcndl,
+				NeighborOf: cell.Serial,
+			})
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Earfcndl != b.Earfcndl {
+			return a.Earfcndl < b.Earfcndl
+		}
+		if a.PCI != b.PCI {
+			return a.PCI < b.PCI
+		}
+		if a.NeighborOf != b.NeighborOf {
+			return a.NeighborOf < b.NeighborOf
+		}
+		return fmt.Sprint(a.Serials) < fmt.Sprint(b.Serials)
+	})
+	return ret
+}
+
+// Plan returns the changes to the cells which make them conflict-free,
+// sorted by serial.
+//
+// Cells keep their assignment when it doesn't conflict with cells kept
+// before them, transmitting cells first and then in serial order. The other
+// cells get the lowest free PCI on their EARFCN, or else on the first
+// earfcndls with a free PCI. An empty earfcndls never changes the EARFCN of
+// cells.
+//
+// The changes are returned with the serials of the cells which couldn't be
+// assigned a conflict-free PCI, which keep their assignment.
+func Plan(cells []Cell, neighbors Neighbors, earfcndls []uint32) ([]Change, []string) {
+	planned := map[string]code{}
+	var changes []Change
+	var conflicting []Cell
+	var unresolved []string
+
+	ordered := sortCells(cells)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Transmitting && !ordered[j].Transmitting
+	})
+	for _, cell := range ordered {
+		current := getCode(cell)
+		if current.pci != 0 && !getUsedCodes(cell.Serial, neighbors, planned)[current] {
+			planned[cell.Serial] = current
+			continue
+		}
+		conflicting = append(conflicting, cell)
+	}
+
+	for _, cell := range conflicting {
+		current := getCode(cell)
+		next, ok := getFreeCode(current.earfcndl, earfcndls, getUsedCodes(cell.Serial, neighbors, planned))
+		if !ok {
+			planned[cell.Serial] = current
+			unresolved = append(unresolved, cell.Serial)
+			continue
+		}
+		planned[cell.Serial] = next
+		changes = append(changes, Change{
+			Serial:      cell.Serial,
+			OldPCI:      current.pci,
+			OldEarfcndl: current.earfcndl,
+			NewPCI:      next.pci,
+			NewEarfcndl: next.earfcndl,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Serial < changes[j].Serial })
+	sort.Strings(unresolved)
+	return changes, unresolved
+}
+
+// Apply returns the cells with the changes applied.
+func Apply(cells []Cell, changes []Change) []Cell {
+	changesBySerial := make(map[string]Change, len(changes))
+	for _, change := range changes {
+		changesBySerial[change.Serial] = change
+	}
+	ret := make([]Cell, 0, len(cells))
+	for _, cell := range cells {
+		if change, ok := changesBySerial[cell.Serial]; ok {
+			cell.PCI = change.NewPCI
+			cell.Earfcndl = change.NewEarfcndl
+		}
+		ret = append(ret, cell)
+	}
+	return ret
+}
+
+// code is the EARFCN and PCI pair which identifies a cell to UEs
+type code struct {
+	earfcndl uint32
+	pci      uint32
+}
+
+func getCode(cell Cell) code {
+	return code{earfcndl: cell.Earfcndl, pci: cell.PCI}
+}
+
+func sameCode(a, b Cell) bool {
+	return a.PCI != 0 && getCode(a) == getCode(b)
+}
+
+// getUsedCodes returns the codes of the planned cells within two hops of a
+// cell, which it must not reuse
+func getUsedCodes(serial string, neighbors Neighbors, planned map[string]code) map[code]bool {
+	ret := map[code]bool{}
+	for _, neighbor := range neighbors[serial] {
+		if c, ok := planned[neighbor]; ok {
+			ret[c] = true
+		}
+		for _, second := range neighbors[neighbor] {
+			if c, ok := planned[second]; ok && second != serial {
+				ret[c] = true
+			}
+		}
+	}
+	return ret
+}
+
+func getFreeCode(earfcndl uint32, earfcndls []uint32, used map[code]bool) (code, bool) {
+	candidates := []uint32{earfcndl}
+	for _, e := range earfcndls {
+		if e != earfcndl {
+			candidates = append(candidates, e)
+		}
+	}
+	for _, e := range candidates {
+		for pci := MinPCI; pci <= MaxPCI; pci++ {
+			c := code{earfcndl: e, pci: pci}
+			if !used[c] {
+				return c, true
+			}
+		}
+	}
+	return code{}, false
+}
+
+func allNeighbors(serials []string, neighbors Neighbors) bool {
+	for i, a := range serials {
+		for _, b := range serials[i+1:] {
+			if !contains(neighbors[a], b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func contains(sorted []string, s string) bool {
+	i := sort.SearchStrings(sorted, s)
+	return i < len(sorted) && sorted[i] == s
+}
+
+func getCellsBySerial(cells []Cell) map[string]Cell {
+	ret := make(map[string]Cell, len(cells))
+	for _, cell := range cells {
+		ret[cell.Serial] = cell
+	}
+	return ret
+}
+
+func sortCells(cells []Cell) []Cell {
+	ret := append([]Cell{}, cells...)
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Serial < ret[j].Serial })
+	return ret
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package planning_test
+
+import (
+	"fmt"
+	"testing"
+
+	"magma/lte/cloud/go/services/lte/planning"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNeighbors(t *testing.T) {
+	cells := []planning.Cell{
+		{Serial: "a", Tac: 1},
+		{Serial: "b", Tac: 1},
+		{Serial: "c", Tac: 2},
+		{Serial: "d", Tac: 3},
+	}
+	declared := map[string][]string{
+		"c": {"b", "c", "unknown"},
+	}
+	expected := planning.Neighbors{
+		"a": {"b"},
+		"b": {"a", "c"},
+		"c": {"b"},
+		"d": {},
+	}
+	assert.Equal(t, expected, planning.GetNeighbors(cells, declared))
+}
+
+func TestFindConflicts(t *testing.T) {
+	// a-b collide on PCI 10, and c and e are confused through d
+	cells := []planning.Cell{
+		{Serial: "a", PCI: 10, Earfcndl: 100, Tac: 1},
+		{Serial: "b", PCI: 10, Earfcndl: 100, Tac: 1},
+		{Serial: "c", PCI: 20, Earfcndl: 100, Tac: 2},
+		{Serial: "d", PCI: 30, Earfcndl: 100, Tac: 3},
+		{Serial: "e", PCI: 20, Earfcndl: 100, Tac: 4},
+		// Same PCI on another EARFCN doesn't conflict
+		{Serial: "f", PCI: 20, Earfcndl: 200, Tac: 3},
+		// Unset PCIs don't conflict
+		{Serial: "g", Earfcndl: 100, Tac: 3},
+		{Serial: "h", Earfcndl: 100, Tac: 3},
+	}
+	neighbors := planning.GetNeighbors(cells, map[string][]string{"d": {"c", "e"}})
+	expected := []planning.Conflict{
+		{Type: planning.CollisionConflictType, Serials: []string{"a", "b"}, PCI: 10, Earfcndl: 100},
+		{Type: planning.ConfusionConflictType, Serials: []string{"c", "e"}, PCI: 20, Earfcndl: 100, NeighborOf: "d"},
+	}
+	assert.Equal(t, expected, planning.FindConflicts(cells, neighbors))
+
+	// Cells which collide aren't also reported as confused
+	cells = []planning.Cell{
+		{Serial: "a", PCI: 10, Earfcndl: 100, Tac: 1},
+		{Serial: "b", PCI: 10, Earfcndl: 100, Tac: 1},
+		{Serial: "c", PCI: 20, Earfcndl: 100, Tac: 1},
+	}
+	expected = []planning.Conflict{
+		{Type: planning.CollisionConflictType, Serials: []string{"a", "b"}, PCI: 10, Earfcndl: 100},
+	}
+	assert.Equal(t, expected, planning.FindConflicts(cells, planning.GetNeighbors(cells, nil)))
+
+	assert.Empty(t, planning.FindConflicts(nil, nil))
+}
+
+func TestPlan(t *testing.T) {
+	cells := []planning.Cell{
+		{Serial: "a", PCI: 10, Earfcndl: 100, Tac: 1},
+		{Serial: "b", PCI: 10, Earfcndl: 100, Tac: 1, Transmitting: true},
+		{Serial: "c", PCI: 1, Earfcndl: 100, Tac: 1},
+		{Serial: "d", Earfcndl: 100, Tac: 1},
+		{Serial: "e", PCI: 10, Earfcndl: 100, Tac: 2},
+	}
+	neighbors := planning.GetNeighbors(cells, nil)
+
+	// b keeps its PCI as it's transmitting, and a and d get the lowest PCIs
+	// which aren't used in their TAC
+	changes, unresolved := planning.Plan(cells, neighbors, nil)
+	expected := []planning.Change{
+		{Serial: "a", OldPCI: 10, OldEarfcndl: 100, NewPCI: 2, NewEarfcndl: 100},
+		{Serial: "d", OldPCI: 0, OldEarfcndl: 100, NewPCI: 3, NewEarfcndl: 100},
+	}
+	assert.Equal(t, expected, changes)
+	assert.Empty(t, unresolved)
+	assert.Empty(t, planning.FindConflicts(planning.Apply(cells, changes), neighbors))
+
+	// Conflict-free cells are unchanged
+	changes, unresolved = planning.Plan(planning.Apply(cells, changes), neighbors, nil)
+	assert.Empty(t, changes)
+	assert.Empty(t, unresolved)
+
+	// Confusions are resolved, through the declared neighbours
+	cells = []planning.Cell{
+		{Serial: "a", PCI: 10, Earfcndl: 100, Tac: 1},
+		{Serial: "b", PCI: 20, Earfcndl: 100, Tac: 2},
+		{Serial: "c", PCI: 10, Earfcndl: 100, Tac: 3},
+	}
+	neighbors = planning.GetNeighbors(cells, map[string][]string{"b": {"a", "c"}})
+	changes, unresolved = planning.Plan(cells, neighbors, nil)
+	expected = []planning.Change{
+		{Serial: "c", OldPCI: 10, OldEarfcndl: 100, NewPCI: 1, NewEarfcndl: 100},
+	}
+	assert.Equal(t, expected, changes)
+	assert.Empty(t, unresolved)
+}
+
+func TestPlan_Earfcndls(t *testing.T) {
+	// Every PCI of EARFCN 100 is used by the neighbours of a
+	cells := []planning.Cell{{Serial: "a", PCI: 1, Earfcndl: 100, Tac: 1}}
+	declared := map[string][]string{}
+	for pci := planning.MinPCI; pci <= planning.MaxPCI; pci++ {
+		serial := fmt.Sprintf("b%03d", pci)
+		cells = append(cells, planning.Cell{Serial: serial, PCI: pci, Earfcndl: 100, Tac: pci + 1, Transmitting: true})
+		declared["a"] = append(declared["a"], serial)
+	}
+	neighbors := planning.GetNeighbors(cells, declared)
+
+	changes, unresolved := planning.Plan(cells, neighbors, nil)
+	assert.Empty(t, changes)
+	assert.Equal(t, []string{"a"}, unresolved)
+
+	changes, unresolved = planning.Plan(cells, neighbors, []uint32{100, 200})
+	expected := []planning.Change{
+		{Serial: "a", OldPCI: 1, OldEarfcndl: 100, NewPCI: 1, NewEarfcndl: 200},
+	}
+	assert.Equal(t, expected, changes)
+	assert.Empty(t, unresolved)
+}
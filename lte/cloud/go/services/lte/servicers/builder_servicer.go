@@ -104,7 +104,7 @@ func (s *builderServicer) Build(ctx context.Context, request *builder_protos.Bui
 		return nil, err
 	}
 
-	enbConfigsBySerial := getEnodebConfigsBySerial(cellularNwConfig, cellularGwConfig, enodebs)
+	enbConfigsBySerial := getEnodebConfigsBySerial(cellularNwConfig, cellularGwConfig, enodebs, graph)
 	heConfig := getHEConfig(cellularGwConfig.HeConfig)
 
 	mmePoolRecord, mmeGroupID, err := getMMEPoolConfigs(network.ID, cellularGwConfig.Pooling, cellGW, graph)
@@ -348,7 +348,7 @@ func getMMEPoolConfigs(networkID string, poolingConfig lte_models.CellularGatewa
 	return poolRecord, cfg.MmeGroupID, nil
 }
 
-func getEnodebConfigsBySerial(nwConfig *lte_models.NetworkCellularConfigs, gwConfig *lte_models.GatewayCellularConfigs, enodebs []configurator.NetworkEntity) map[string]*lte_mconfig.EnodebD_EnodebConfig {
+func getEnodebConfigsBySerial(nwConfig *lte_models.NetworkCellularConfigs, gwConfig *lte_models.GatewayCellularConfigs, enodebs []configurator.NetworkEntity, graph configurator.EntityGraph) map[string]*lte_mconfig.EnodebD_EnodebConfig {
 	ret := make(map[string]*lte_mconfig.EnodebD_EnodebConfig, len(enodebs))
 	for _, ent := range enodebs {
 		serial := ent.Key
@@ -361,41 +361,24 @@ func getEnodebConfigsBySerial(nwConfig *lte_models.NetworkCellularConfigs, gwCon
 		enbMconfig := &lte_mconfig.EnodebD_EnodebConfig{}
 
 		if enodebConfig.ConfigType == "MANAGED" {
-			cellularEnbConfig := enodebConfig.ManagedConfig
-			enbMconfig.Earfcndl = int32(cellularEnbConfig.Earfcndl)
-			enbMconfig.SubframeAssignment = int32(cellularEnbConfig.SubframeAssignment)
-			enbMconfig.SpecialSubframePattern = int32(cellularEnbConfig.SpecialSubframePattern)
-			enbMconfig.Pci = int32(cellularEnbConfig.Pci)
-			enbMconfig.TransmitEnabled = swag.BoolValue(cellularEnbConfig.TransmitEnabled)
-			enbMconfig.DeviceClass = cellularEnbConfig.DeviceClass
-			enbMconfig.BandwidthMhz = int32(cellularEnbConfig.BandwidthMhz)
-			enbMconfig.Tac = int32(cellularEnbConfig.Tac)
-			enbMconfig.CellId = int32(swag.Uint32Value(cellularEnbConfig.CellID))
-
-			// override zero values with network/gateway configs
-			if enbMconfig.Earfcndl == 0 {
-				enbMconfig.Earfcndl = int32(nwConfig.GetEarfcndl())
-			}
-			if enbMconfig.SubframeAssignment == 0 {
-				if nwConfig.Ran.TddConfig != nil {
-					enbMconfig.SubframeAssignment = int32(nwConfig.Ran.TddConfig.SubframeAssignment)
-				}
+			enbMconfig = getManagedEnodebConfig(nwConfig, gwConfig, enodebConfig.ManagedConfig)
+		} else if enodebConfig.ConfigType == lte_models.TemplatedConfigType {
+			// enodeBs whose template is missing keep their own fields,
+			// and inherit the rest from the network and gateway
+			var template *lte_models.EnodebTemplateConfiguration
+			templates, err := graph.GetAllChildrenOfType(ent, lte.CellularEnodebTemplateEntityType)
+			if err == nil && len(templates) != 0 {
+				template, _ = templates[0].Config.(*lte_models.EnodebTemplateConfiguration)
 			}
-			if enbMconfig.SpecialSubframePattern == 0 {
-				if nwConfig.Ran.TddConfig != nil {
-					enbMconfig.SpecialSubframePattern = int32(nwConfig.Ran.TddConfig.SpecialSubframePattern)
-				}
+			if template == nil {
+				glog.Errorf("enb with serial %s is missing its template %s, using its own config", serial, enodebConfig.TemplatedConfig.TemplateID)
 			}
-			if enbMconfig.Pci == 0 {
-				enbMconfig.Pci = int32(gwConfig.Ran.Pci)
+			cellularEnbConfig := enodebConfig.TemplatedConfig.Merge(template)
+			// enodeBs without an explicit transmit setting follow the gateway
+			if cellularEnbConfig.TransmitEnabled == nil {
+				cellularEnbConfig.TransmitEnabled = gwConfig.Ran.TransmitEnabled
 			}
-			if enbMconfig.BandwidthMhz == 0 {
-				enbMconfig.BandwidthMhz = int32(nwConfig.Ran.BandwidthMhz)
-			}
-			if enbMconfig.Tac == 0 {
-				enbMconfig.Tac = int32(nwConfig.Epc.Tac)
-			}
-
+			enbMconfig = getManagedEnodebConfig(nwConfig, gwConfig, cellularEnbConfig)
 		} else if enodebConfig.ConfigType == "UNMANAGED" {
 			cellularEnbConfig := enodebConfig.UnmanagedConfig
 			enbMconfig.CellId = int32(swag.Uint32Value(cellularEnbConfig.CellID))
@@ -412,6 +395,44 @@ func getEnodebConfigsBySerial(nwConfig *lte_models.NetworkCellularConfigs, gwCon
 	return ret
 }
 
+func getManagedEnodebConfig(nwConfig *lte_models.NetworkCellularConfigs, gwConfig *lte_models.GatewayCellularConfigs, cellularEnbConfig *lte_models.EnodebConfiguration) *lte_mconfig.EnodebD_EnodebConfig {
+	enbMconfig := &lte_mconfig.EnodebD_EnodebConfig{}
+	enbMconfig.Earfcndl = int32(cellularEnbConfig.Earfcndl)
+	enbMconfig.SubframeAssignment = int32(cellularEnbConfig.SubframeAssignment)
+	enbMconfig.SpecialSubframePattern = int32(cellularEnbConfig.SpecialSubframePattern)
+	enbMconfig.Pci = int32(cellularEnbConfig.Pci)
+	enbMconfig.TransmitEnabled = swag.BoolValue(cellularEnbConfig.TransmitEnabled)
+	enbMconfig.DeviceClass = cellularEnbConfig.DeviceClass
+	enbMconfig.BandwidthMhz = int32(cellularEnbConfig.BandwidthMhz)
+	enbMconfig.Tac = int32(cellularEnbConfig.Tac)
+	enbMconfig.CellId = int32(swag.Uint32Value(cellularEnbConfig.CellID))
+
+	// override zero values with network/gateway configs
+	if enbMconfig.Earfcndl == 0 {
+		enbMconfig.Earfcndl = int32(nwConfig.GetEarfcndl())
+	}
+	if enbMconfig.SubframeAssignment == 0 {
+		if nwConfig.Ran.TddConfig != nil {
+			enbMconfig.SubframeAssignment = int32(nwConfig.Ran.TddConfig.SubframeAssignment)
+		}
+	}
+	if enbMconfig.SpecialSubframePattern == 0 {
+		if nwConfig.Ran.TddConfig != nil {
+			enbMconfig.SpecialSubframePattern = int32(nwConfig.Ran.TddConfig.SpecialSubframePattern)
+		}
+	}
+	if enbMconfig.Pci == 0 {
+		enbMconfig.Pci = int32(gwConfig.Ran.Pci)
+	}
+	if enbMconfig.BandwidthMhz == 0 {
+		enbMconfig.BandwidthMhz = int32(nwConfig.Ran.BandwidthMhz)
+	}
+	if enbMconfig.Tac == 0 {
+		enbMconfig.Tac = int32(nwConfig.Epc.Tac)
+	}
+	return enbMconfig
+}
+
 func getEnodebTacs(enbConfigsBySerial map[string]*lte_mconfig.EnodebD_EnodebConfig) []int32 {
 	ret := make([]int32, 0, len(enbConfigsBySerial))
 	for _, enbConfig := range enbConfigsBySerial {
@@ -1017,6 +1017,183 @@ func TestBuilder_BuildUnmanagedEnbConfig(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestBuilder_BuildTemplatedEnbConfig(t *testing.T) {
+	lte_test_init.StartTestService(t)
+
+	nw := configurator.Network{
+		ID: "n1",
+		Configs: map[string]interface{}{
+			lte.CellularNetworkConfigType: lte_models.NewDefaultTDDNetworkConfig(),
+			orc8r.DnsdNetworkType: &models.NetworkDNSConfig{
+				EnableCaching: swag.Bool(true),
+			},
+		},
+	}
+	gw := configurator.NetworkEntity{
+		Type: orc8r.MagmadGatewayType, Key: "gw1",
+		Associations: []storage.TypeAndKey{
+			{Type: lte.CellularGatewayEntityType, Key: "gw1"},
+		},
+	}
+	lteGW := configurator.NetworkEntity{
+		Type: lte.CellularGatewayEntityType, Key: "gw1",
+		Config: newDefaultGatewayConfig(),
+		Associations: []storage.TypeAndKey{
+			{Type: lte.CellularEnodebEntityType, Key: "enb1"},
+		},
+		ParentAssociations: []storage.TypeAndKey{gw.GetTypeAndKey()},
+	}
+	enb := configurator.NetworkEntity{
+		Type: lte.CellularEnodebEntityType, Key: "enb1",
+		Config: &lte_models.EnodebConfig{
+			ConfigType: lte_models.TemplatedConfigType,
+			TemplatedConfig: &lte_models.TemplatedEnodebConfiguration{
+				TemplateID: "baicells_b40",
+				CellID:     swag.Uint32(42),
+				Pci:        100,
+				Tac:        6,
+			},
+		},
+		Associations: []storage.TypeAndKey{
+			{Type: lte.CellularEnodebTemplateEntityType, Key: "baicells_b40"},
+		},
+		ParentAssociations: []storage.TypeAndKey{lteGW.GetTypeAndKey()},
+	}
+	template := configurator.NetworkEntity{
+		Type: lte.CellularEnodebTemplateEntityType, Key: "baicells_b40",
+		Config: &lte_models.EnodebTemplateConfiguration{
+			DeviceClass:  "Baicells ID TDD/FDD",
+			BandwidthMhz: 10,
+			Earfcndl:     39150,
+			Tac:          5,
+		},
+		ParentAssociations: []storage.TypeAndKey{enb.GetTypeAndKey()},
+	}
+	graph := configurator.EntityGraph{
+		Entities: []configurator.NetworkEntity{enb, lteGW, gw, template},
+		Edges: []configurator.GraphEdge{
+			{From: gw.GetTypeAndKey(), To: lteGW.GetTypeAndKey()},
+			{From: lteGW.GetTypeAndKey(), To: enb.GetTypeAndKey()},
+			{From: enb.GetTypeAndKey(), To: template.GetTypeAndKey()},
+		},
+	}
+
+	expected := map[string]proto.Message{
+		"enodebd": &lte_mconfig.EnodebD{
+			LogLevel: protos.LogLevel_INFO,
+			Pci:      260,
+			TddConfig: &lte_mconfig.EnodebD_TDDConfig{
+				Earfcndl:               44590,
+				SubframeAssignment:     2,
+				SpecialSubframePattern: 7,
+			},
+			BandwidthMhz:        20,
+			AllowEnodebTransmit: true,
+			Tac:                 1,
+			PlmnidList:          "00101",
+			CsfbRat:             lte_mconfig.EnodebD_CSFBRAT_2G,
+			Arfcn_2G:            nil,
+			EnbConfigsBySerial: map[string]*lte_mconfig.EnodebD_EnodebConfig{
+				"enb1": {
+					Earfcndl:               39150,
+					SubframeAssignment:     2,
+					SpecialSubframePattern: 7,
+					Pci:                    100,
+					TransmitEnabled:        true,
+					DeviceClass:            "Baicells ID TDD/FDD",
+					BandwidthMhz:           10,
+					Tac:                    6,
+					CellId:                 42,
+				},
+			},
+		},
+		"mobilityd": &lte_mconfig.MobilityD{
+			LogLevel: protos.LogLevel_INFO,
+			IpBlock:  "192.168.128.0/24",
+		},
+		"mme": &lte_mconfig.MME{
+			LogLevel:                 protos.LogLevel_INFO,
+			Mcc:                      "001",
+			Mnc:                      "01",
+			Tac:                      1,
+			MmeCode:                  1,
+			MmeGid:                   1,
+			MmeRelativeCapacity:      10,
+			NonEpsServiceControl:     lte_mconfig.MME_NON_EPS_SERVICE_CONTROL_OFF,
+			CsfbMcc:                  "001",
+			CsfbMnc:                  "01",
+			Lac:                      1,
+			HssRelayEnabled:          false,
+			CloudSubscriberdbEnabled: false,
+			EnableDnsCaching:         false,
+			AttachedEnodebTacs:       []int32{6},
+			NatEnabled:               true,
+		},
+		"pipelined": &lte_mconfig.PipelineD{
+			LogLevel:      protos.LogLevel_INFO,
+			UeIpBlock:     "192.168.128.0/24",
+			NatEnabled:    true,
+			DefaultRuleId: "",
+			Services: []lte_mconfig.PipelineD_NetworkServices{
+				lte_mconfig.PipelineD_ENFORCEMENT,
+			},
+			SgiManagementIfaceVlan: "",
+			HeConfig:               &lte_mconfig.PipelineD_HEConfig{},
+		},
+		"subscriberdb": &lte_mconfig.SubscriberDB{
+			LogLevel:        protos.LogLevel_INFO,
+			LteAuthOp:       []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
+			LteAuthAmf:      []byte("\x80\x00"),
+			SubProfiles:     nil,
+			HssRelayEnabled: false,
+		},
+		"policydb": &lte_mconfig.PolicyDB{
+			LogLevel: protos.LogLevel_INFO,
+		},
+		"sessiond": &lte_mconfig.SessionD{
+			LogLevel:         protos.LogLevel_INFO,
+			GxGyRelayEnabled: false,
+			WalletExhaustDetection: &lte_mconfig.WalletExhaustDetection{
+				TerminateOnExhaust: false,
+			},
+		},
+		"dnsd": &lte_mconfig.DnsD{
+			LogLevel:          protos.LogLevel_INFO,
+			DhcpServerEnabled: true,
+		},
+	}
+
+	actual, err := build_non_federated(&nw, &graph, "gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// Without its template, the enodeB keeps its own fields and inherits the
+	// rest from the network and gateway
+	expected["enodebd"].(*lte_mconfig.EnodebD).EnbConfigsBySerial["enb1"] = &lte_mconfig.EnodebD_EnodebConfig{
+		Earfcndl:               44590,
+		SubframeAssignment:     2,
+		SpecialSubframePattern: 7,
+		Pci:                    100,
+		TransmitEnabled:        true,
+		BandwidthMhz:           20,
+		Tac:                    6,
+		CellId:                 42,
+	}
+	missingGraph := configurator.EntityGraph{
+		Entities: []configurator.NetworkEntity{enb, lteGW, gw},
+		Edges:    graph.Edges[:2],
+	}
+	actual, err = build_non_federated(&nw, &missingGraph, "gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	template.Config = nil
+	graph.Entities[3] = template
+	actual, err = build_non_federated(&nw, &graph, "gw1")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
 func TestBuilder_Build_MMEPool(t *testing.T) {
 	lte_test_init.StartTestService(t)
 
@@ -19,23 +19,28 @@ import (
 
 	"magma/lte/cloud/go/lte"
 	policydb_streamer "magma/lte/cloud/go/services/policydb/streamer"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	subscriber_streamer "magma/lte/cloud/go/services/subscriberdb/streamer"
 	streamer_protos "magma/orc8r/cloud/go/services/streamer/protos"
 	"magma/orc8r/cloud/go/services/streamer/providers"
 	"magma/orc8r/lib/go/protos"
 )
 
-type providerServicer struct{}
+type providerServicer struct {
+	keyring *crypto.Keyring
+}
 
-func NewProviderServicer() streamer_protos.StreamProviderServer {
-	return &providerServicer{}
+// NewProviderServicer returns the servicer of the lte streams, the auth keys
+// of the streamed subscribers are decrypted by the keyring.
+func NewProviderServicer(keyring *crypto.Keyring) streamer_protos.StreamProviderServer {
+	return &providerServicer{keyring: keyring}
 }
 
 func (s *providerServicer) GetUpdates(ctx context.Context, req *protos.StreamRequest) (*protos.DataUpdateBatch, error) {
 	var streamer providers.StreamProvider
 	switch req.GetStreamName() {
 	case lte.SubscriberStreamName:
-		streamer = &subscriber_streamer.SubscribersProvider{}
+		streamer = &subscriber_streamer.SubscribersProvider{Keyring: s.keyring}
 	case lte.PolicyStreamName:
 		streamer = &policydb_streamer.PoliciesProvider{}
 	case lte.ApnRuleMappingsStreamName:
@@ -22,6 +22,7 @@ import (
 	lte_protos "magma/lte/cloud/go/services/lte/protos"
 	"magma/lte/cloud/go/services/lte/servicers"
 	"magma/lte/cloud/go/services/lte/storage"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	"magma/orc8r/cloud/go/orc8r"
 	builder_protos "magma/orc8r/cloud/go/services/configurator/mconfig/protos"
 	state_protos "magma/orc8r/cloud/go/services/state/protos"
@@ -51,15 +52,17 @@ func StartTestService(t *testing.T) {
 
 	srv, lis := test_utils.NewTestOrchestratorService(t, lte.ModuleName, lte_service.ServiceName, labels, annotations)
 	builder_protos.RegisterMconfigBuilderServer(srv.GrpcServer, servicers.NewBuilderServicer())
-	provider_protos.RegisterStreamProviderServer(srv.GrpcServer, servicers.NewProviderServicer())
 
 	// Init storage
 	db, err := sqorc.Open("sqlite3", ":memory:")
 	assert.NoError(t, err)
 	enbStateStore := storage.NewEnodebStateLookup(db, sqorc.GetSqlBuilder())
 	assert.NoError(t, enbStateStore.Initialize())
+	keyring, err := crypto.NewKeyringFromServiceConfig(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, err)
 
 	// Add servicers
+	provider_protos.RegisterStreamProviderServer(srv.GrpcServer, servicers.NewProviderServicer(keyring))
 	lte_protos.RegisterEnodebStateLookupServer(srv.GrpcServer, servicers.NewLookupServicer(enbStateStore))
 	state_protos.RegisterIndexerServer(srv.GrpcServer, servicers.NewIndexerServicer())
 
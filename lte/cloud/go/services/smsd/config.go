@@ -0,0 +1,95 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smsd
+
+import (
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Config represents the configuration provided to smsd service
+type Config struct {
+	SMPP SMPPConfig `yaml:"smpp"`
+}
+
+// SMPPConfig configures the SMPP 3.4 interface of smsd, through which
+// external SMSCs and ESMEs submit messages and receive delivery receipts.
+type SMPPConfig struct {
+	// ListenAddress is the address the SMPP server listens on. SMPP is
+	// disabled if empty.
+	ListenAddress string `yaml:"listen_address"`
+	// Accounts are the SMPP peers allowed to bind
+	Accounts []SMPPAccount `yaml:"accounts"`
+	// WindowSize is the max number of outstanding requests in each
+	// direction of a session
+	WindowSize int `yaml:"window_size"`
+	// EnquireLinkIntervalSec is the idle time after which the liveness of a
+	// session is checked with an enquire_link
+	EnquireLinkIntervalSec int `yaml:"enquire_link_interval_sec"`
+	// ResponseTimeoutSec is how long to wait for the response to a request
+	// sent to a peer
+	ResponseTimeoutSec int `yaml:"response_timeout_sec"`
+	// Binds are the external SMSCs smsd binds to as an ESME, to receive the
+	// messages they deliver to subscribers
+	Binds []SMPPBind `yaml:"binds"`
+}
+
+// SMPPAccount is the credentials of an SMPP peer, and the network its
+// messages are submitted to.
+type SMPPAccount struct {
+	SystemID string `yaml:"system_id"`
+	// PasswordFile is the path of the file holding the password of the
+	// peer, e.g. a key of a mounted Kubernetes secret
+	PasswordFile string `yaml:"password_file"`
+	NetworkID    string `yaml:"network_id"`
+}
+
+// GetPassword reads the password of the peer from its password file.
+func (a SMPPAccount) GetPassword() (string, error) {
+	return readPasswordFile(a.PasswordFile)
+}
+
+// SMPPBind is an external SMSC smsd binds to as an ESME, and the network
+// the messages it delivers are sent to.
+type SMPPBind struct {
+	// Address is the host:port of the SMSC
+	Address  string `yaml:"address"`
+	SystemID string `yaml:"system_id"`
+	// PasswordFile is the path of the file holding the password of smsd at
+	// the SMSC, e.g. a key of a mounted Kubernetes secret
+	PasswordFile string `yaml:"password_file"`
+	SystemType   string `yaml:"system_type"`
+	NetworkID    string `yaml:"network_id"`
+}
+
+// GetPassword reads the password of smsd at the SMSC from its password file.
+func (b SMPPBind) GetPassword() (string, error) {
+	return readPasswordFile(b.PasswordFile)
+}
+
+// readPasswordFile returns the content of a password file, without
+// trailing newlines. The file is read on each use, so that rotated
+// passwords apply without a restart.
+func readPasswordFile(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("no password file configured")
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read password file")
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
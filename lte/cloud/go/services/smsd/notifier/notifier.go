@@ -0,0 +1,251 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package notifier expires SMS messages whose validity period has passed,
+// and notifies the callback URL of messages which reached a final status.
+//
+// The notification is sent by the Sender registered for the scheme of the
+// callback URL. For http and https URLs, it is a POST of the message, as
+// returned by the REST API. Notifications which fail are retried with an
+// exponential backoff, up to maxAttempts times.
+//
+// Every replica of smsd runs a notifier. A sender returns ErrNotRoutable
+// when the notification can only be sent from another replica, e.g. the one
+// an SMPP receiver is bound to. The notification is then left pending for
+// the other replicas without counting an attempt, until maxPendingAge after
+// its message reached its final status.
+package notifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"magma/lte/cloud/go/services/smsd/obsidian/models"
+	"magma/lte/cloud/go/services/smsd/storage"
+	"magma/orc8r/cloud/go/clock"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Max number of notifications sent per cycle
+	batchSize = 100
+
+	// How many times we'll try to send a notification before dropping it
+	maxAttempts = 5
+
+	baseBackoff = time.Minute
+	maxBackoff  = time.Hour
+
+	// Notifications which can't be sent from this replica are postponed by
+	// a random delay up to maxPostponeDelay, so that the replicas which can
+	// send them see them due regardless of the phase of their cycles
+	maxPostponeDelay = time.Minute
+	// How long notifications which no replica can send are kept, e.g. until
+	// an SMPP receiver binds again
+	maxPendingAge = 24 * time.Hour
+
+	// NetworkIDHeader is the header of the notification requests which
+	// holds the ID of the network of the message.
+	NetworkIDHeader = "X-Magma-Network-ID"
+)
+
+// ErrNotRoutable is returned by senders when the notification can't be
+// sent from this replica, but may be from another one.
+var ErrNotRoutable = errors.New("notification can't be sent from this replica")
+
+// Sender sends the notification of a message to its callback URL.
+type Sender interface {
+	Send(notification *storage.SMSNotification) error
+}
+
+// Notifier expires messages and sends the pending notifications.
+type Notifier struct {
+	store storage.SMSStorage
+
+	mu      sync.RWMutex
+	senders map[string]Sender
+}
+
+// NewNotifier returns a notifier which sends the notifications of http and
+// https callback URLs with client.
+func NewNotifier(store storage.SMSStorage, client *http.Client) *Notifier {
+	httpSender := &httpSender{client: client}
+	return &Notifier{
+		store:   store,
+		senders: map[string]Sender{"http": httpSender, "https": httpSender},
+	}
+}
+
+// RegisterSender registers the sender of the notifications to callback URLs
+// with a scheme, replacing any existing one.
+func (n *Notifier) RegisterSender(scheme string, sender Sender) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.senders[scheme] = sender
+}
+
+// Run periodically expires messages and sends the pending notifications.
+// It never returns.
+func (n *Notifier) Run(interval time.Duration) {
+	for {
+		clock.Sleep(interval)
+		if err := n.RunOnce(); err != nil {
+			glog.Errorf("Failed to send SMS notifications: %v", err)
+		}
+	}
+}
+
+// RunOnce expires the messages whose validity period has passed, then sends
+// the notifications which are due.
+func (n *Notifier) RunOnce() error {
+	err := n.store.ExpireSMSs()
+	if err != nil {
+		return errors.Wrap(err, "failed to expire SMSs")
+	}
+
+	notifications, err := n.store.GetSMSNotifications(batchSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to load SMS notifications")
+	}
+	for _, notification := range notifications {
+		n.notify(notification)
+	}
+	return nil
+}
+
+func (n *Notifier) notify(notification *storage.SMSNotification) {
+	pk := notification.SMS.Pk
+	err := n.send(notification)
+	if err == nil {
+		if err := n.store.DeleteSMSNotification(pk); err != nil {
+			glog.Errorf("Failed to delete notification of SMS %s: %v", pk, err)
+		}
+		return
+	}
+	if err == ErrNotRoutable {
+		n.postpone(notification)
+		return
+	}
+
+	attempts := notification.Attempts + 1
+	if attempts >= maxAttempts {
+		glog.Errorf("Dropping notification of SMS %s after %d attempts: %v", pk, attempts, err)
+		if err := n.store.DeleteSMSNotification(pk); err != nil {
+			glog.Errorf("Failed to delete notification of SMS %s: %v", pk, err)
+		}
+		return
+	}
+
+	glog.Warningf("Failed to send notification of SMS %s, will retry: %v", pk, err)
+	if err := n.store.DeferSMSNotification(pk, clock.Now().Add(getBackoff(attempts))); err != nil {
+		glog.Errorf("Failed to defer notification of SMS %s: %v", pk, err)
+	}
+}
+
+// postpone leaves a notification which can't be sent from this replica
+// pending for the other replicas, unless it's been pending for too long.
+func (n *Notifier) postpone(notification *storage.SMSNotification) {
+	pk := notification.SMS.Pk
+	if clock.Since(getFinalStatusTime(notification.SMS)) > maxPendingAge {
+		glog.Errorf("Dropping notification of SMS %s: not sent by any replica within %s", pk, maxPendingAge)
+		if err := n.store.DeleteSMSNotification(pk); err != nil {
+			glog.Errorf("Failed to delete notification of SMS %s: %v", pk, err)
+		}
+		return
+	}
+
+	delay := time.Duration(rand.Int63n(int64(maxPostponeDelay)))
+	if err := n.store.PostponeSMSNotification(pk, clock.Now().Add(delay)); err != nil {
+		glog.Errorf("Failed to postpone notification of SMS %s: %v", pk, err)
+	}
+}
+
+func (n *Notifier) send(notification *storage.SMSNotification) error {
+	u, err := url.Parse(notification.SMS.CallbackUrl)
+	if err != nil {
+		return errors.Wrap(err, "invalid callback URL")
+	}
+	n.mu.RLock()
+	sender, ok := n.senders[u.Scheme]
+	n.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no sender for callback URL scheme %q", u.Scheme)
+	}
+	return sender.Send(notification)
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+func (s *httpSender) Send(notification *storage.SMSNotification) error {
+	body, err := json.Marshal((&models.SmsMessage{}).FromProto(notification.SMS))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal message")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, notification.SMS.CallbackUrl, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(NetworkIDHeader, notification.NetworkID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// getFinalStatusTime returns when a message reached its final status.
+func getFinalStatusTime(sms *storage.SMS) time.Time {
+	final := sms.LastDeliveryAttemptTime
+	if sms.Status == storage.MessageStatus_EXPIRED {
+		final = sms.ExpiresTime
+	}
+	if final == nil {
+		final = sms.CreatedTime
+	}
+	ret, err := ptypes.Timestamp(final)
+	if err != nil {
+		return clock.Now()
+	}
+	return ret
+}
+
+// getBackoff returns how long to wait before the next attempt to send a
+// notification which failed attempts times.
+func getBackoff(attempts uint32) time.Duration {
+	backoff := baseBackoff
+	for i := uint32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
@@ -0,0 +1,184 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package notifier_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/services/smsd/notifier"
+	"magma/lte/cloud/go/services/smsd/obsidian/models"
+	"magma/lte/cloud/go/services/smsd/storage"
+	"magma/lte/cloud/go/services/smsd/storage/mocks"
+	"magma/orc8r/cloud/go/clock"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotifier_RunOnce(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+
+	var received []*models.SmsMessage
+	var networkIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		msg := &models.SmsMessage{}
+		assert.NoError(t, json.Unmarshal(body, msg))
+		received = append(received, msg)
+		networkIDs = append(networkIDs, r.Header.Get(notifier.NetworkIDHeader))
+	}))
+	defer srv.Close()
+
+	createdTs, err := ptypes.TimestampProto(time.Unix(500, 0))
+	assert.NoError(t, err)
+	newSMS := func(pk, callback string) *storage.SMS {
+		return &storage.SMS{
+			Pk:           pk,
+			Status:       storage.MessageStatus_DELIVERED,
+			Imsi:         "IMSI1234567890",
+			SourceMsisdn: "123",
+			Message:      "hello",
+			CreatedTime:  createdTs,
+			AttemptCount: 1,
+			CallbackUrl:  callback,
+		}
+	}
+
+	store := new(mocks.SMSStorage)
+	store.On("ExpireSMSs").Return(nil)
+	store.On("GetSMSNotifications", uint64(100)).Return(
+		[]*storage.SMSNotification{
+			{NetworkID: "n1", SMS: newSMS("1", srv.URL+"/ok")},
+			{NetworkID: "n1", SMS: newSMS("2", srv.URL+"/fail"), Attempts: 1},
+			{NetworkID: "n1", SMS: newSMS("3", srv.URL+"/fail"), Attempts: 4},
+		},
+		nil,
+	).Once()
+	// Delivered, and dropped after the last attempt
+	store.On("DeleteSMSNotification", "1").Return(nil).Once()
+	store.On("DeleteSMSNotification", "3").Return(nil).Once()
+	// Second failed attempt, retried in 2 minutes
+	store.On("DeferSMSNotification", "2", time.Unix(1120, 0)).Return(nil).Once()
+
+	n := notifier.NewNotifier(store, srv.Client())
+	err = n.RunOnce()
+	assert.NoError(t, err)
+	store.AssertExpectations(t)
+
+	assert.Equal(t, []string{"n1"}, networkIDs)
+	assert.Len(t, received, 1)
+	assert.Equal(t, "1", received[0].Pk)
+	assert.Equal(t, models.SmsMessageStatusDelivered, *received[0].Status)
+	assert.Equal(t, srv.URL+"/ok", received[0].CallbackURL)
+
+	// Store errors
+	store.On("GetSMSNotifications", uint64(100)).Return(nil, errors.New("oops")).Once()
+	err = n.RunOnce()
+	assert.EqualError(t, err, "failed to load SMS notifications: oops")
+
+	store = new(mocks.SMSStorage)
+	store.On("ExpireSMSs").Return(errors.New("oops"))
+	err = notifier.NewNotifier(store, srv.Client()).RunOnce()
+	assert.EqualError(t, err, "failed to expire SMSs: oops")
+}
+
+type fakeSender struct {
+	sent []*storage.SMSNotification
+	err  error
+}
+
+func (s *fakeSender) Send(notification *storage.SMSNotification) error {
+	s.sent = append(s.sent, notification)
+	return s.err
+}
+
+func TestNotifier_RegisterSender(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+
+	notifications := []*storage.SMSNotification{
+		{NetworkID: "n1", SMS: &storage.SMS{Pk: "1", CallbackUrl: "smpp://esme1"}},
+		{NetworkID: "n1", SMS: &storage.SMS{Pk: "2", CallbackUrl: "foo://bar"}},
+	}
+	store := new(mocks.SMSStorage)
+	store.On("ExpireSMSs").Return(nil)
+	store.On("GetSMSNotifications", uint64(100)).Return(notifications, nil).Once()
+	store.On("DeleteSMSNotification", "1").Return(nil).Once()
+	// No sender for the scheme, retried in a minute
+	store.On("DeferSMSNotification", "2", time.Unix(1060, 0)).Return(nil).Once()
+
+	sender := &fakeSender{}
+	n := notifier.NewNotifier(store, http.DefaultClient)
+	n.RegisterSender("smpp", sender)
+	err := n.RunOnce()
+	assert.NoError(t, err)
+	assert.Equal(t, notifications[:1], sender.sent)
+	store.AssertExpectations(t)
+
+	// Sender errors are retried
+	sender.err = errors.New("no session")
+	store.On("GetSMSNotifications", uint64(100)).Return(notifications[:1], nil).Once()
+	store.On("DeferSMSNotification", "1", time.Unix(1060, 0)).Return(nil).Once()
+	err = n.RunOnce()
+	assert.NoError(t, err)
+	store.AssertExpectations(t)
+}
+
+func TestNotifier_NotRoutable(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Unix(100000, 0))
+	defer clock.UnfreezeClock(t)
+
+	timestampProto := func(sec int64) *timestamp.Timestamp {
+		ts, err := ptypes.TimestampProto(time.Unix(sec, 0))
+		assert.NoError(t, err)
+		return ts
+	}
+	notifications := []*storage.SMSNotification{
+		// Delivered an hour ago
+		{NetworkID: "n1", SMS: &storage.SMS{Pk: "1", Status: storage.MessageStatus_DELIVERED, CallbackUrl: "smpp://esme1", LastDeliveryAttemptTime: timestampProto(96400)}, Attempts: 2},
+		// Expired more than a day ago
+		{NetworkID: "n1", SMS: &storage.SMS{Pk: "2", Status: storage.MessageStatus_EXPIRED, CallbackUrl: "smpp://esme1", ExpiresTime: timestampProto(10000)}},
+	}
+	store := new(mocks.SMSStorage)
+	store.On("ExpireSMSs").Return(nil)
+	store.On("GetSMSNotifications", uint64(100)).Return(notifications, nil).Once()
+	// Left pending for the other replicas within a minute, without counting
+	// an attempt
+	isPostponed := mock.MatchedBy(func(next time.Time) bool {
+		return !next.Before(time.Unix(100000, 0)) && next.Before(time.Unix(100060, 0))
+	})
+	store.On("PostponeSMSNotification", "1", isPostponed).Return(nil).Once()
+	// Dropped after a day
+	store.On("DeleteSMSNotification", "2").Return(nil).Once()
+
+	n := notifier.NewNotifier(store, http.DefaultClient)
+	n.RegisterSender("smpp", &fakeSender{err: notifier.ErrNotRoutable})
+	err := n.RunOnce()
+	assert.NoError(t, err)
+	store.AssertExpectations(t)
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 )
 
+// DefaultValidityPeriodSec is the validity period of messages created
+// through the API without one.
+const DefaultValidityPeriodSec = 24 * 60 * 60
+
 func (m *SmsMessage) FromProto(from *storage.SMS) *SmsMessage {
 	m.Pk = from.Pk
 	m.Imsi = models.SubscriberID(from.Imsi)
@@ -21,6 +25,11 @@ func (m *SmsMessage) FromProto(from *storage.SMS) *SmsMessage {
 	if lastAttempt != nil {
 		m.TimeLastAttempted = *lastAttempt
 	}
+	expires := tsToDT(from.ExpiresTime)
+	if expires != nil {
+		m.TimeExpires = *expires
+	}
+	m.CallbackURL = from.CallbackUrl
 
 	switch from.Status {
 	case storage.MessageStatus_WAITING:
@@ -29,6 +38,8 @@ func (m *SmsMessage) FromProto(from *storage.SMS) *SmsMessage {
 		m.Status = strPtr(SmsMessageStatusDelivered)
 	case storage.MessageStatus_FAILED:
 		m.Status = strPtr(SmsMessageStatusFailed)
+	case storage.MessageStatus_EXPIRED:
+		m.Status = strPtr(SmsMessageStatusExpired)
 	default:
 		m.Status = strPtr(SmsMessageStatusWaiting)
 	}
@@ -41,13 +52,28 @@ func (m *MutableSmsMessage) ValidateModel() error {
 }
 
 func (m *MutableSmsMessage) ToProto() storage.MutableSMS {
+	validityPeriod := m.ValidityPeriod
+	if validityPeriod == 0 {
+		validityPeriod = DefaultValidityPeriodSec
+	}
 	return storage.MutableSMS{
-		Imsi:         string(m.Imsi),
-		SourceMsisdn: m.SourceMsisdn,
-		Message:      m.Message,
+		Imsi:              string(m.Imsi),
+		SourceMsisdn:      m.SourceMsisdn,
+		Message:           m.Message,
+		ValidityPeriodSec: uint32(validityPeriod),
+		CallbackUrl:       m.CallbackURL,
 	}
 }
 
+func (m *MoSmsMessage) FromProto(from *storage.MOSMS) *MoSmsMessage {
+	m.Pk = from.Pk
+	m.Imsi = models.SubscriberID(from.Imsi)
+	m.Destination = from.Destination
+	m.Message = from.Message
+	m.TimeReceived = tsToDT(from.ReceivedTime)
+	return m
+}
+
 func tsToDT(ts *timestamp.Timestamp) *strfmt.DateTime {
 	if ts == nil {
 		return nil
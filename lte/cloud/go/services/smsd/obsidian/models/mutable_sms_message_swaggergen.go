@@ -18,6 +18,11 @@ import (
 // swagger:model mutable_sms_message
 type MutableSmsMessage struct {
 
+	// HTTP(S) URL which receives a POST of the message once it is delivered, expires or fails
+	//
+	// Pattern: ^https?://
+	CallbackURL string `json:"callback_url,omitempty"`
+
 	// imsi
 	// Required: true
 	Imsi models1.SubscriberID `json:"imsi"`
@@ -31,12 +36,22 @@ type MutableSmsMessage struct {
 	// Required: true
 	// Min Length: 1
 	SourceMsisdn string `json:"source_msisdn"`
+
+	// Number of seconds during which delivery of the message is attempted (TP-VP), 1 day if unset
+	//
+	// Maximum: 3.81024e+07
+	// Minimum: 300
+	ValidityPeriod int64 `json:"validity_period,omitempty"`
 }
 
 // Validate validates this mutable sms message
 func (m *MutableSmsMessage) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateCallbackURL(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateImsi(formats); err != nil {
 		res = append(res, err)
 	}
@@ -49,12 +64,29 @@ func (m *MutableSmsMessage) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateValidityPeriod(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *MutableSmsMessage) validateCallbackURL(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CallbackURL) { // not required
+		return nil
+	}
+
+	if err := validate.Pattern("callback_url", "body", string(m.CallbackURL), `^https?://`); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *MutableSmsMessage) validateImsi(formats strfmt.Registry) error {
 
 	if err := m.Imsi.Validate(formats); err != nil {
@@ -93,6 +125,23 @@ func (m *MutableSmsMessage) validateSourceMsisdn(formats strfmt.Registry) error
 	return nil
 }
 
+func (m *MutableSmsMessage) validateValidityPeriod(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.ValidityPeriod) { // not required
+		return nil
+	}
+
+	if err := validate.MinimumInt("validity_period", "body", int64(m.ValidityPeriod), 300, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("validity_period", "body", int64(m.ValidityPeriod), 3.81024e+07, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *MutableSmsMessage) MarshalBinary() ([]byte, error) {
 	if m == nil {
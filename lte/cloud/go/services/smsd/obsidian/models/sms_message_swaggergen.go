@@ -25,6 +25,9 @@ type SmsMessage struct {
 	// Minimum: 0
 	AttemptCount int64 `json:"attempt_count"`
 
+	// URL notified once the message is delivered, expires or fails
+	CallbackURL string `json:"callback_url,omitempty"`
+
 	// error status
 	ErrorStatus string `json:"error_status,omitempty"`
 
@@ -49,7 +52,7 @@ type SmsMessage struct {
 
 	// status
 	// Required: true
-	// Enum: [Waiting Delivered Failed]
+	// Enum: [Waiting Delivered Failed Expired]
 	Status *string `json:"status"`
 
 	// time created
@@ -57,6 +60,10 @@ type SmsMessage struct {
 	// Format: date-time
 	TimeCreated *strfmt.DateTime `json:"time_created"`
 
+	// Time after which delivery is no longer attempted
+	// Format: date-time
+	TimeExpires strfmt.DateTime `json:"time_expires,omitempty"`
+
 	// time last attempted
 	// Format: date-time
 	TimeLastAttempted strfmt.DateTime `json:"time_last_attempted,omitempty"`
@@ -94,6 +101,10 @@ func (m *SmsMessage) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateTimeExpires(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateTimeLastAttempted(formats); err != nil {
 		res = append(res, err)
 	}
@@ -172,7 +183,7 @@ var smsMessageTypeStatusPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["Waiting","Delivered","Failed"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["Waiting","Delivered","Failed","Expired"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -190,6 +201,9 @@ const (
 
 	// SmsMessageStatusFailed captures enum value "Failed"
 	SmsMessageStatusFailed string = "Failed"
+
+	// SmsMessageStatusExpired captures enum value "Expired"
+	SmsMessageStatusExpired string = "Expired"
 )
 
 // prop value enum
@@ -227,6 +241,19 @@ func (m *SmsMessage) validateTimeCreated(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *SmsMessage) validateTimeExpires(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.TimeExpires) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("time_expires", "body", "date-time", m.TimeExpires.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *SmsMessage) validateTimeLastAttempted(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.TimeLastAttempted) { // not required
@@ -86,6 +86,9 @@ func (s *smsdServicer) ReportDelivery(ctx context.Context, request *lteProtos.Re
 	}
 
 	decoded, err := s.serde.DecodeDelivery(request.Report.NasMessageContainer)
+	if err == sms_ll.ErrNotDeliveryReport {
+		return s.receiveMOSMS(networkID, request.Report)
+	}
 	if err != nil {
 		return ret, errors.Wrap(err, "failed to decode report")
 	}
@@ -106,3 +109,35 @@ func (s *smsdServicer) ReportDelivery(ctx context.Context, request *lteProtos.Re
 	}
 	return ret, nil
 }
+
+// receiveMOSMS stores a mobile originated SMS and returns the RP-ACK to send
+// back to the UE.
+func (s *smsdServicer) receiveMOSMS(networkID string, uplink *lteProtos.SMOUplinkUnitdata) (*lteProtos.ReportDeliveryResponse, error) {
+	ret := &lteProtos.ReportDeliveryResponse{}
+	submit, err := s.serde.DecodeSubmit(uplink.NasMessageContainer)
+	if err != nil {
+		return ret, errors.Wrap(err, "failed to decode MO SMS")
+	}
+
+	_, err = s.store.CreateMOSMS(networkID, storage.MOSMSSegment{
+		Imsi:        uplink.Imsi,
+		Destination: submit.Destination,
+		Message:     submit.Message,
+		Segments:    submit.Segments,
+		SeqNo:       submit.SeqNo,
+		ConcatRef:   submit.ConcatRef,
+	})
+	if err != nil {
+		return ret, errors.Wrap(err, "failed to store MO SMS")
+	}
+
+	ack, err := s.serde.EncodeSubmitAck(submit)
+	if err != nil {
+		return ret, errors.Wrap(err, "failed to encode MO SMS ack")
+	}
+	ret.Messages = append(ret.Messages, &lteProtos.SMODownlinkUnitdata{
+		Imsi:                uplink.Imsi,
+		NasMessageContainer: ack,
+	})
+	return ret, nil
+}
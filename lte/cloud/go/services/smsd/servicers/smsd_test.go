@@ -188,6 +188,51 @@ func TestSMSDServicer_ReportDelivery(t *testing.T) {
 	store.AssertExpectations(t)
 }
 
+func TestSMSDServicer_ReportDelivery_MOSMS(t *testing.T) {
+	store := new(mocks.SMSStorage)
+	serde := new(mocks2.SMSSerde)
+	srv := servicers.NewSMSDServicer(store, serde)
+	ctx := getTestContext(context.Background())
+
+	nasContainer := []byte{0x1, 0x2}
+	submit := sms_ll.SMSSubmit{TransactionID: 1, Reference: 2, Destination: "123", Message: "hello", Segments: 2, SeqNo: 1, ConcatRef: 3}
+	serde.On("DecodeDelivery", nasContainer).Return(sms_ll.SMSDeliveryReport{}, sms_ll.ErrNotDeliveryReport)
+	serde.On("DecodeSubmit", nasContainer).Return(submit, nil).Twice()
+	serde.On("EncodeSubmitAck", submit).Return([]byte{0x3, 0x4}, nil).Once()
+	expectedSegment := storage.MOSMSSegment{Imsi: "IMSI1", Destination: "123", Message: "hello", Segments: 2, SeqNo: 1, ConcatRef: 3}
+	store.On("CreateMOSMS", "n1", expectedSegment).Return("", nil).Once()
+
+	actual, err := srv.ReportDelivery(ctx, &protos.ReportDeliveryRequest{Report: &protos.SMOUplinkUnitdata{
+		Imsi:                "IMSI1",
+		NasMessageContainer: nasContainer,
+	}})
+	assert.NoError(t, err)
+	expected := &protos.ReportDeliveryResponse{
+		Messages: []*protos.SMODownlinkUnitdata{{Imsi: "IMSI1", NasMessageContainer: []byte{0x3, 0x4}}},
+	}
+	assert.Equal(t, expected, actual)
+
+	// storage error, no ack sent
+	store.On("CreateMOSMS", "n1", expectedSegment).Return("", errors.New("store")).Once()
+	actual, err = srv.ReportDelivery(ctx, &protos.ReportDeliveryRequest{Report: &protos.SMOUplinkUnitdata{
+		Imsi:                "IMSI1",
+		NasMessageContainer: nasContainer,
+	}})
+	assert.EqualError(t, err, "failed to store MO SMS: store")
+	assert.Empty(t, actual.Messages)
+
+	// serde error
+	serde.On("DecodeSubmit", nasContainer).Return(sms_ll.SMSSubmit{}, errors.New("serde")).Once()
+	_, err = srv.ReportDelivery(ctx, &protos.ReportDeliveryRequest{Report: &protos.SMOUplinkUnitdata{
+		Imsi:                "IMSI1",
+		NasMessageContainer: nasContainer,
+	}})
+	assert.EqualError(t, err, "failed to decode MO SMS: serde")
+
+	serde.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
+
 func tsProto(t *testing.T, ti time.Time) *timestamp.Timestamp {
 	ret, err := ptypes.TimestampProto(ti)
 	assert.NoError(t, err)
@@ -0,0 +1,158 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smpp
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"magma/lte/cloud/go/services/smsd/storage"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/warthog618/sms/encoding/gsm7"
+	"github.com/warthog618/sms/encoding/ucs2"
+)
+
+const (
+	// Format of the dates in delivery receipts (SMPP v3.4 appendix B)
+	receiptDateFormat = "0601021504"
+	// Max number of characters of the message included in receipts
+	receiptTextLength = 20
+)
+
+// decodeText returns the text of a submitted message, from its
+// message_payload if present.
+func decodeText(msg *ShortMessage) (string, error) {
+	text := msg.ShortMessage
+	if payload, ok := msg.GetTLV(TagMessagePayload); ok {
+		text = payload
+	}
+
+	switch msg.DataCoding {
+	case DataCodingDefault:
+		// The SMSC default alphabet is GSM 7-bit, sent unpacked
+		decoded, err := gsm7.Decode(text)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	case DataCodingIA5:
+		return string(text), nil
+	case DataCodingLatin1:
+		runes := make([]rune, 0, len(text))
+		for _, b := range text {
+			runes = append(runes, rune(b))
+		}
+		return string(runes), nil
+	case DataCodingUCS2:
+		runes, err := ucs2.Decode(text)
+		if err != nil {
+			return "", err
+		}
+		return string(runes), nil
+	default:
+		return "", fmt.Errorf("unsupported data coding 0x%x", msg.DataCoding)
+	}
+}
+
+// parseValidityPeriod returns the number of seconds from now until the
+// validity period of a message ends, or 0 if the period is unset.
+// Both the absolute and relative time formats are supported
+// (SMPP v3.4 7.1.1).
+func parseValidityPeriod(period string, now time.Time) (uint32, error) {
+	if period == "" {
+		return 0, nil
+	}
+	if len(period) != 16 {
+		return 0, fmt.Errorf("invalid time format %q", period)
+	}
+	fields := make([]int, 6)
+	for i := range fields {
+		v, err := strconv.Atoi(period[2*i : 2*i+2])
+		if err != nil {
+			return 0, fmt.Errorf("invalid time format %q", period)
+		}
+		fields[i] = v
+	}
+	years, months, days, hours, minutes, seconds := fields[0], fields[1], fields[2], fields[3], fields[4], fields[5]
+
+	var validity time.Duration
+	switch period[15] {
+	case 'R':
+		validity = time.Duration(years)*365*24*time.Hour +
+			time.Duration(months)*30*24*time.Hour +
+			time.Duration(days)*24*time.Hour +
+			time.Duration(hours)*time.Hour +
+			time.Duration(minutes)*time.Minute +
+			time.Duration(seconds)*time.Second
+	case '+', '-':
+		quarters, err := strconv.Atoi(period[13:15])
+		if err != nil {
+			return 0, fmt.Errorf("invalid time format %q", period)
+		}
+		offset := time.Duration(quarters) * 15 * time.Minute
+		if period[15] == '-' {
+			offset = -offset
+		}
+		end := time.Date(2000+years, time.Month(months), days, hours, minutes, seconds, 0, time.UTC).Add(-offset)
+		validity = end.Sub(now)
+	default:
+		return 0, fmt.Errorf("invalid time format %q", period)
+	}
+
+	if validity <= 0 {
+		return 0, fmt.Errorf("validity period %q has already passed", period)
+	}
+	return uint32(validity / time.Second), nil
+}
+
+// newDeliveryReceipt returns the deliver_sm of the delivery receipt of a
+// message which reached a final status (SMPP v3.4 appendix B).
+func newDeliveryReceipt(sms *storage.SMS, now time.Time) *ShortMessage {
+	stat, state, delivered := "UNDELIV", MessageStateUndeliverable, 0
+	switch sms.Status {
+	case storage.MessageStatus_DELIVERED:
+		stat, state, delivered = "DELIVRD", MessageStateDelivered, 1
+	case storage.MessageStatus_EXPIRED:
+		stat, state = "EXPIRED", MessageStateExpired
+	}
+
+	submitDate := now
+	if created, err := ptypes.Timestamp(sms.CreatedTime); err == nil {
+		submitDate = created
+	}
+	text := []rune(sms.Message)
+	if len(text) > receiptTextLength {
+		text = text[:receiptTextLength]
+	}
+	receipt := fmt.Sprintf(
+		"id:%s sub:001 dlvrd:%03d submit date:%s done date:%s stat:%s err:000 text:%s",
+		sms.Pk, delivered, submitDate.UTC().Format(receiptDateFormat), now.UTC().Format(receiptDateFormat), stat, string(text),
+	)
+
+	return &ShortMessage{
+		// The receipt is sent from the recipient of the message back to
+		// its originator
+		SourceAddr:      sms.Imsi,
+		DestinationAddr: sms.SourceMsisdn,
+		ESMClass:        ESMClassDeliveryReceipt,
+		DataCoding:      DataCodingIA5,
+		ShortMessage:    []byte(receipt),
+		TLVs: []TLV{
+			{Tag: TagReceiptedMessageID, Value: append([]byte(sms.Pk), 0)},
+			{Tag: TagMessageState, Value: []byte{state}},
+		},
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smpp
+
+import (
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/services/smsd/storage"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeText(t *testing.T) {
+	// GSM 7-bit, unpacked
+	text, err := decodeText(&ShortMessage{DataCoding: DataCodingDefault, ShortMessage: []byte{'h', 'i', 0x00, 0x1B, 0x65}})
+	assert.NoError(t, err)
+	assert.Equal(t, "hi@€", text)
+
+	text, err = decodeText(&ShortMessage{DataCoding: DataCodingIA5, ShortMessage: []byte("hello")})
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", text)
+
+	text, err = decodeText(&ShortMessage{DataCoding: DataCodingLatin1, ShortMessage: []byte{'c', 'a', 'f', 0xE9}})
+	assert.NoError(t, err)
+	assert.Equal(t, "café", text)
+
+	// The message_payload takes precedence
+	text, err = decodeText(&ShortMessage{
+		DataCoding:   DataCodingUCS2,
+		ShortMessage: []byte{0x00, 'x'},
+		TLVs:         []TLV{{Tag: TagMessagePayload, Value: []byte{0x00, 'h', 0x20, 0xAC}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "h€", text)
+
+	_, err = decodeText(&ShortMessage{DataCoding: DataCodingUCS2, ShortMessage: []byte{0x00}})
+	assert.Error(t, err)
+	_, err = decodeText(&ShortMessage{DataCoding: 0x04, ShortMessage: []byte{0x00}})
+	assert.EqualError(t, err, "unsupported data coding 0x4")
+}
+
+func TestParseValidityPeriod(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		period      string
+		expected    uint32
+		expectedErr string
+	}{
+		{period: "", expected: 0},
+		{period: "000001000000000R", expected: 86400},
+		{period: "000000013000000R", expected: 5400},
+		{period: "200101020000000+", expected: 7200},
+		// 03:00 at UTC+1
+		{period: "200101030000004+", expected: 7200},
+		// 23:00 at UTC-1 on the previous day
+		{period: "191231230000004-", expectedErr: `validity period "191231230000004-" has already passed`},
+		{period: "191231000000000+", expectedErr: `validity period "191231000000000+" has already passed`},
+		{period: "2001010200", expectedErr: `invalid time format "2001010200"`},
+		{period: "200101020000000X", expectedErr: `invalid time format "200101020000000X"`},
+		{period: "2001010200000a0+", expectedErr: `invalid time format "2001010200000a0+"`},
+	}
+	for _, tc := range tcs {
+		actual, err := parseValidityPeriod(tc.period, now)
+		if tc.expectedErr != "" {
+			assert.EqualError(t, err, tc.expectedErr)
+			continue
+		}
+		assert.NoError(t, err, tc.period)
+		assert.Equal(t, tc.expected, actual, tc.period)
+	}
+}
+
+func TestNewDeliveryReceipt(t *testing.T) {
+	created, err := ptypes.TimestampProto(time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	sms := &storage.SMS{
+		Pk:           "pk1",
+		Status:       storage.MessageStatus_DELIVERED,
+		Imsi:         "IMSI001010000000001",
+		SourceMsisdn: "123",
+		Message:      "this message is longer than twenty characters",
+		CreatedTime:  created,
+	}
+	now := time.Date(2020, 1, 1, 13, 0, 0, 0, time.UTC)
+
+	expected := &ShortMessage{
+		SourceAddr:      "IMSI001010000000001",
+		DestinationAddr: "123",
+		ESMClass:        ESMClassDeliveryReceipt,
+		DataCoding:      DataCodingIA5,
+		ShortMessage:    []byte("id:pk1 sub:001 dlvrd:001 submit date:2001011230 done date:2001011300 stat:DELIVRD err:000 text:this message is long"),
+		TLVs: []TLV{
+			{Tag: TagReceiptedMessageID, Value: []byte{'p', 'k', '1', 0x00}},
+			{Tag: TagMessageState, Value: []byte{MessageStateDelivered}},
+		},
+	}
+	assert.Equal(t, expected, newDeliveryReceipt(sms, now))
+
+	sms.Status = storage.MessageStatus_EXPIRED
+	sms.Message = "hi"
+	expected.ShortMessage = []byte("id:pk1 sub:001 dlvrd:000 submit date:2001011230 done date:2001011300 stat:EXPIRED err:000 text:hi")
+	expected.TLVs[1].Value = []byte{MessageStateExpired}
+	assert.Equal(t, expected, newDeliveryReceipt(sms, now))
+
+	sms.Status = storage.MessageStatus_FAILED
+	expected.ShortMessage = []byte("id:pk1 sub:001 dlvrd:000 submit date:2001011230 done date:2001011300 stat:UNDELIV err:000 text:hi")
+	expected.TLVs[1].Value = []byte{MessageStateUndeliverable}
+	assert.Equal(t, expected, newDeliveryReceipt(sms, now))
+}
@@ -0,0 +1,381 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smpp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Handles encoding and decoding of SMPP 3.4 PDUs (SMPP v3.4 section 3)
+
+const (
+	headerLength = 16
+	// Max length of the PDUs we accept, which is plenty for a
+	// message_payload of a long message
+	maxPDULength = 64 * 1024
+
+	// InterfaceVersion is the version of the protocol we support
+	InterfaceVersion = 0x34
+)
+
+// Command IDs (SMPP v3.4 5.1.2.1)
+const (
+	GenericNack         uint32 = 0x80000000
+	BindReceiver        uint32 = 0x00000001
+	BindReceiverResp    uint32 = 0x80000001
+	BindTransmitter     uint32 = 0x00000002
+	BindTransmitterResp uint32 = 0x80000002
+	SubmitSM            uint32 = 0x00000004
+	SubmitSMResp        uint32 = 0x80000004
+	DeliverSM           uint32 = 0x00000005
+	DeliverSMResp       uint32 = 0x80000005
+	Unbind              uint32 = 0x00000006
+	UnbindResp          uint32 = 0x80000006
+	BindTransceiver     uint32 = 0x00000009
+	BindTransceiverResp uint32 = 0x80000009
+	EnquireLink         uint32 = 0x00000015
+	EnquireLinkResp     uint32 = 0x80000015
+
+	responseMask uint32 = 0x80000000
+)
+
+// Command statuses (SMPP v3.4 5.1.3)
+const (
+	StatusOK              uint32 = 0x00000000
+	StatusInvalidLength   uint32 = 0x00000002
+	StatusInvalidCommand  uint32 = 0x00000003
+	StatusInvalidBindStat uint32 = 0x00000004
+	StatusAlreadyBound    uint32 = 0x00000005
+	StatusSystemError     uint32 = 0x00000008
+	StatusInvalidDestAddr uint32 = 0x0000000B
+	StatusBindFailed      uint32 = 0x0000000D
+	StatusInvalidPassword uint32 = 0x0000000E
+	StatusInvalidSystemID uint32 = 0x0000000F
+	StatusInvalidESMClass uint32 = 0x00000043
+	StatusSubmitFailed    uint32 = 0x00000045
+	StatusThrottled       uint32 = 0x00000058
+	StatusInvalidExpiry   uint32 = 0x00000062
+	StatusInvalidOptParam uint32 = 0x000000C4
+)
+
+// Optional parameter tags (SMPP v3.4 5.3.2)
+const (
+	TagReceiptedMessageID uint16 = 0x001E
+	TagMessagePayload     uint16 = 0x0424
+	TagMessageState       uint16 = 0x0427
+)
+
+// Message states of the message_state parameter (SMPP v3.4 5.2.28)
+const (
+	MessageStateDelivered     byte = 2
+	MessageStateExpired       byte = 3
+	MessageStateUndeliverable byte = 5
+)
+
+// ESM class bits (SMPP v3.4 5.2.12)
+const (
+	ESMClassDeliveryReceipt byte = 0x04
+	ESMClassUDHI            byte = 0x40
+
+	// Bits of the message type, which are unset for regular messages
+	esmClassTypeMask byte = 0x3C
+)
+
+// Data codings (SMPP v3.4 5.2.19)
+const (
+	DataCodingDefault byte = 0x00
+	DataCodingIA5     byte = 0x01
+	DataCodingLatin1  byte = 0x03
+	DataCodingUCS2    byte = 0x08
+)
+
+// PDU is a raw SMPP PDU. Body is decoded according to the command ID by
+// the body types below.
+type PDU struct {
+	CommandID      uint32
+	CommandStatus  uint32
+	SequenceNumber uint32
+	Body           []byte
+}
+
+// IsResponse returns true if the PDU is the response to a request
+func (p *PDU) IsResponse() bool {
+	return p.CommandID&responseMask != 0
+}
+
+// MarshalBinary encodes the PDU, header included
+func (p *PDU) MarshalBinary() []byte {
+	b := make([]byte, headerLength, headerLength+len(p.Body))
+	binary.BigEndian.PutUint32(b[0:], uint32(headerLength+len(p.Body)))
+	binary.BigEndian.PutUint32(b[4:], p.CommandID)
+	binary.BigEndian.PutUint32(b[8:], p.CommandStatus)
+	binary.BigEndian.PutUint32(b[12:], p.SequenceNumber)
+	return append(b, p.Body...)
+}
+
+// ReadPDU reads a single PDU from r
+func ReadPDU(r io.Reader) (*PDU, error) {
+	header := make([]byte, headerLength)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[0:])
+	if length < headerLength || length > maxPDULength {
+		return nil, fmt.Errorf("invalid PDU length %d", length)
+	}
+	pdu := &PDU{
+		CommandID:      binary.BigEndian.Uint32(header[4:]),
+		CommandStatus:  binary.BigEndian.Uint32(header[8:]),
+		SequenceNumber: binary.BigEndian.Uint32(header[12:]),
+		Body:           make([]byte, length-headerLength),
+	}
+	if _, err := io.ReadFull(r, pdu.Body); err != nil {
+		return nil, err
+	}
+	return pdu, nil
+}
+
+// Bind is the body of bind_transmitter, bind_receiver and bind_transceiver
+// (SMPP v3.4 4.1)
+type Bind struct {
+	SystemID         string
+	Password         string
+	SystemType       string
+	InterfaceVersion byte
+	AddrTON          byte
+	AddrNPI          byte
+	AddressRange     string
+}
+
+func (b *Bind) MarshalBinary() []byte {
+	w := &bodyWriter{}
+	w.cString(b.SystemID)
+	w.cString(b.Password)
+	w.cString(b.SystemType)
+	w.byte(b.InterfaceVersion)
+	w.byte(b.AddrTON)
+	w.byte(b.AddrNPI)
+	w.cString(b.AddressRange)
+	return w.Bytes()
+}
+
+func (b *Bind) UnmarshalBinary(input []byte) error {
+	r := &bodyReader{buf: input}
+	b.SystemID = r.cString()
+	b.Password = r.cString()
+	b.SystemType = r.cString()
+	b.InterfaceVersion = r.byte()
+	b.AddrTON = r.byte()
+	b.AddrNPI = r.byte()
+	b.AddressRange = r.cString()
+	return r.err
+}
+
+// MessageID is the body of bind responses (as system_id), submit_sm_resp
+// and deliver_sm_resp (SMPP v3.4 4.1.2, 4.4.2, 4.6.2)
+type MessageID struct {
+	ID string
+}
+
+func (m *MessageID) MarshalBinary() []byte {
+	w := &bodyWriter{}
+	w.cString(m.ID)
+	return w.Bytes()
+}
+
+func (m *MessageID) UnmarshalBinary(input []byte) error {
+	// Error responses may omit the body
+	if len(input) == 0 {
+		m.ID = ""
+		return nil
+	}
+	r := &bodyReader{buf: input}
+	m.ID = r.cString()
+	return r.err
+}
+
+// TLV is an optional parameter (SMPP v3.4 3.2.4.1)
+type TLV struct {
+	Tag   uint16
+	Value []byte
+}
+
+// ShortMessage is the body of submit_sm and deliver_sm
+// (SMPP v3.4 4.4.1, 4.6.1)
+type ShortMessage struct {
+	ServiceType          string
+	SourceAddrTON        byte
+	SourceAddrNPI        byte
+	SourceAddr           string
+	DestAddrTON          byte
+	DestAddrNPI          byte
+	DestinationAddr      string
+	ESMClass             byte
+	ProtocolID           byte
+	PriorityFlag         byte
+	ScheduleDeliveryTime string
+	ValidityPeriod       string
+	RegisteredDelivery   byte
+	ReplaceIfPresentFlag byte
+	DataCoding           byte
+	SMDefaultMsgID       byte
+	ShortMessage         []byte
+	TLVs                 []TLV
+}
+
+func (m *ShortMessage) MarshalBinary() []byte {
+	w := &bodyWriter{}
+	w.cString(m.ServiceType)
+	w.byte(m.SourceAddrTON)
+	w.byte(m.SourceAddrNPI)
+	w.cString(m.SourceAddr)
+	w.byte(m.DestAddrTON)
+	w.byte(m.DestAddrNPI)
+	w.cString(m.DestinationAddr)
+	w.byte(m.ESMClass)
+	w.byte(m.ProtocolID)
+	w.byte(m.PriorityFlag)
+	w.cString(m.ScheduleDeliveryTime)
+	w.cString(m.ValidityPeriod)
+	w.byte(m.RegisteredDelivery)
+	w.byte(m.ReplaceIfPresentFlag)
+	w.byte(m.DataCoding)
+	w.byte(m.SMDefaultMsgID)
+	w.byte(byte(len(m.ShortMessage)))
+	w.Write(m.ShortMessage)
+	for _, tlv := range m.TLVs {
+		w.tlv(tlv)
+	}
+	return w.Bytes()
+}
+
+func (m *ShortMessage) UnmarshalBinary(input []byte) error {
+	r := &bodyReader{buf: input}
+	m.ServiceType = r.cString()
+	m.SourceAddrTON = r.byte()
+	m.SourceAddrNPI = r.byte()
+	m.SourceAddr = r.cString()
+	m.DestAddrTON = r.byte()
+	m.DestAddrNPI = r.byte()
+	m.DestinationAddr = r.cString()
+	m.ESMClass = r.byte()
+	m.ProtocolID = r.byte()
+	m.PriorityFlag = r.byte()
+	m.ScheduleDeliveryTime = r.cString()
+	m.ValidityPeriod = r.cString()
+	m.RegisteredDelivery = r.byte()
+	m.ReplaceIfPresentFlag = r.byte()
+	m.DataCoding = r.byte()
+	m.SMDefaultMsgID = r.byte()
+	smLength := r.byte()
+	m.ShortMessage = r.bytes(int(smLength))
+	m.TLVs = nil
+	for r.err == nil && r.remaining() > 0 {
+		m.TLVs = append(m.TLVs, r.tlv())
+	}
+	return r.err
+}
+
+// GetTLV returns the value of the optional parameter with the tag, if
+// present.
+func (m *ShortMessage) GetTLV(tag uint16) ([]byte, bool) {
+	for _, tlv := range m.TLVs {
+		if tlv.Tag == tag {
+			return tlv.Value, true
+		}
+	}
+	return nil, false
+}
+
+type bodyWriter struct {
+	bytes.Buffer
+}
+
+func (w *bodyWriter) cString(s string) {
+	w.WriteString(s)
+	w.WriteByte(0)
+}
+
+func (w *bodyWriter) byte(b byte) {
+	w.WriteByte(b)
+}
+
+func (w *bodyWriter) tlv(tlv TLV) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint16(b[0:], tlv.Tag)
+	binary.BigEndian.PutUint16(b[2:], uint16(len(tlv.Value)))
+	w.Write(b)
+	w.Write(tlv.Value)
+}
+
+// bodyReader decodes the fields of a PDU body. The first decoding error is
+// kept in err, after which all reads return zero values.
+type bodyReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (r *bodyReader) remaining() int {
+	return len(r.buf) - r.pos
+}
+
+func (r *bodyReader) cString() string {
+	if r.err != nil {
+		return ""
+	}
+	end := bytes.IndexByte(r.buf[r.pos:], 0)
+	if end < 0 {
+		r.err = fmt.Errorf("unterminated C-Octet String at offset %d", r.pos)
+		return ""
+	}
+	s := string(r.buf[r.pos : r.pos+end])
+	r.pos += end + 1
+	return s
+}
+
+func (r *bodyReader) byte() byte {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *bodyReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.remaining() < n {
+		r.err = fmt.Errorf("PDU body too short: need %d octets at offset %d", n, r.pos)
+		return nil
+	}
+	b := make([]byte, n)
+	copy(b, r.buf[r.pos:r.pos+n])
+	r.pos += n
+	return b
+}
+
+func (r *bodyReader) tlv() TLV {
+	header := r.bytes(4)
+	if header == nil {
+		return TLV{}
+	}
+	tlv := TLV{Tag: binary.BigEndian.Uint16(header[0:])}
+	tlv.Value = r.bytes(int(binary.BigEndian.Uint16(header[2:])))
+	return tlv
+}
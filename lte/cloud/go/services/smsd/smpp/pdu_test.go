@@ -0,0 +1,92 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smpp_test
+
+import (
+	"bytes"
+	"testing"
+
+	"magma/lte/cloud/go/services/smsd/smpp"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDU(t *testing.T) {
+	bind := &smpp.Bind{SystemID: "esme1", Password: "pw", InterfaceVersion: smpp.InterfaceVersion}
+	pdu := &smpp.PDU{CommandID: smpp.BindTransmitter, SequenceNumber: 1, Body: bind.MarshalBinary()}
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x1E, // command_length
+		0x00, 0x00, 0x00, 0x02, // command_id
+		0x00, 0x00, 0x00, 0x00, // command_status
+		0x00, 0x00, 0x00, 0x01, // sequence_number
+		'e', 's', 'm', 'e', '1', 0x00, // system_id
+		'p', 'w', 0x00, // password
+		0x00,             // system_type
+		0x34,             // interface_version
+		0x00, 0x00, 0x00, // addr_ton, addr_npi, address_range
+	}
+	assert.Equal(t, expected, pdu.MarshalBinary())
+	assert.False(t, pdu.IsResponse())
+
+	actual, err := smpp.ReadPDU(bytes.NewReader(expected))
+	assert.NoError(t, err)
+	assert.Equal(t, pdu, actual)
+	actualBind := &smpp.Bind{}
+	assert.NoError(t, actualBind.UnmarshalBinary(actual.Body))
+	assert.Equal(t, bind, actualBind)
+
+	// Invalid length
+	_, err = smpp.ReadPDU(bytes.NewReader([]byte{0x00, 0x00, 0x00, 0x08, 0x00, 0x00, 0x00, 0x15, 0, 0, 0, 0, 0, 0, 0, 1}))
+	assert.EqualError(t, err, "invalid PDU length 8")
+	// Truncated body
+	_, err = smpp.ReadPDU(bytes.NewReader(expected[:20]))
+	assert.EqualError(t, err, "unexpected EOF")
+	// Unterminated string
+	assert.EqualError(t, actualBind.UnmarshalBinary([]byte("esme1")), "unterminated C-Octet String at offset 0")
+}
+
+func TestShortMessage(t *testing.T) {
+	msg := &smpp.ShortMessage{
+		SourceAddr:         "123",
+		DestinationAddr:    "456",
+		ValidityPeriod:     "000001000000000R",
+		RegisteredDelivery: 1,
+		DataCoding:         smpp.DataCodingUCS2,
+		ShortMessage:       []byte{},
+		TLVs: []smpp.TLV{
+			{Tag: smpp.TagMessagePayload, Value: []byte{0x00, 'h', 0x00, 'i'}},
+			{Tag: smpp.TagMessageState, Value: []byte{smpp.MessageStateDelivered}},
+		},
+	}
+	actual := &smpp.ShortMessage{}
+	assert.NoError(t, actual.UnmarshalBinary(msg.MarshalBinary()))
+	assert.Equal(t, msg, actual)
+
+	payload, ok := actual.GetTLV(smpp.TagMessagePayload)
+	assert.True(t, ok)
+	assert.Equal(t, []byte{0x00, 'h', 0x00, 'i'}, payload)
+	_, ok = actual.GetTLV(smpp.TagReceiptedMessageID)
+	assert.False(t, ok)
+
+	// Truncated TLV
+	body := msg.MarshalBinary()
+	assert.EqualError(t, actual.UnmarshalBinary(body[:len(body)-1]), "PDU body too short: need 1 octets at offset 51")
+
+	// Empty message IDs of error responses
+	id := &smpp.MessageID{ID: "foo"}
+	assert.NoError(t, id.UnmarshalBinary(nil))
+	assert.Equal(t, "", id.ID)
+	assert.NoError(t, id.UnmarshalBinary((&smpp.MessageID{ID: "bar"}).MarshalBinary()))
+	assert.Equal(t, "bar", id.ID)
+}
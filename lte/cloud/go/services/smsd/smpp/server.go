@@ -0,0 +1,439 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package smpp implements SMPP 3.4, through which external SMSCs and ESMEs
+// send messages to smsd and receive their delivery receipts.
+//
+// Peers either bind to the server of smsd and submit messages with
+// submit_sm, or are SMSCs which smsd binds to as an ESME and which deliver
+// messages with deliver_sm. Either way, the messages are created in the SMS
+// storage, as if created through the REST API. When a delivery receipt is requested, the
+// receipt is sent as a deliver_sm to a receiver or transceiver session of
+// the same system ID, once the message reaches a final status. When smsd
+// has several replicas, the receipt is sent by the replica the receiver is
+// bound to.
+package smpp
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"magma/lte/cloud/go/services/smsd"
+	"magma/lte/cloud/go/services/smsd/notifier"
+	"magma/lte/cloud/go/services/smsd/obsidian/models"
+	"magma/lte/cloud/go/services/smsd/storage"
+	"magma/orc8r/cloud/go/clock"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Scheme is the scheme of the callback URLs of messages submitted over
+	// SMPP which requested a delivery receipt
+	Scheme = "smpp"
+
+	// SystemID is the system_id of smsd in bind responses
+	SystemID = "magma"
+
+	defaultWindowSize          = 10
+	defaultEnquireLinkInterval = 30 * time.Second
+	defaultResponseTimeout     = 10 * time.Second
+
+	// Binds to SMSCs which fail or close are retried with an exponential
+	// backoff
+	defaultBindRetryDelay = 10 * time.Second
+	maxBindRetryDelay     = 5 * time.Minute
+
+	// Values of registered_delivery which request a delivery receipt
+	// (SMPP v3.4 5.2.17)
+	receiptMask      byte = 0x03
+	receiptOnFailure byte = 0x02
+	// Query parameter of callback URLs for failure-only receipts
+	receiptQuery = "receipt=failure"
+
+	imsiPrefix = "IMSI"
+)
+
+// IMSIResolver returns the IMSI of the subscriber with an MSISDN, or
+// ErrNotFound from magma/orc8r/lib/go/errors.
+type IMSIResolver func(networkID, msisdn string) (string, error)
+
+// Server is an SMPP 3.4 server.
+type Server struct {
+	config      smsd.SMPPConfig
+	store       storage.SMSStorage
+	resolveIMSI IMSIResolver
+
+	enquireLinkInterval time.Duration
+	responseTimeout     time.Duration
+	bindRetryDelay      time.Duration
+
+	mu       sync.Mutex
+	listener net.Listener
+	sessions map[*session]struct{}
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewServer(config smsd.SMPPConfig, store storage.SMSStorage, resolveIMSI IMSIResolver) *Server {
+	if config.WindowSize <= 0 {
+		config.WindowSize = defaultWindowSize
+	}
+	s := &Server{
+		config:              config,
+		store:               store,
+		resolveIMSI:         resolveIMSI,
+		enquireLinkInterval: defaultEnquireLinkInterval,
+		responseTimeout:     defaultResponseTimeout,
+		bindRetryDelay:      defaultBindRetryDelay,
+		sessions:            map[*session]struct{}{},
+		closed:              make(chan struct{}),
+	}
+	if config.EnquireLinkIntervalSec > 0 {
+		s.enquireLinkInterval = time.Duration(config.EnquireLinkIntervalSec) * time.Second
+	}
+	if config.ResponseTimeoutSec > 0 {
+		s.responseTimeout = time.Duration(config.ResponseTimeoutSec) * time.Second
+	}
+	return s
+}
+
+// CallbackURL returns the callback URL of messages submitted by a peer which
+// requested a delivery receipt.
+func CallbackURL(systemID string, failureOnly bool) string {
+	u := url.URL{Scheme: Scheme, Host: systemID}
+	if failureOnly {
+		u.RawQuery = receiptQuery
+	}
+	return u.String()
+}
+
+// Serve accepts connections on lis until the server is closed.
+func (s *Server) Serve(lis net.Listener) error {
+	s.mu.Lock()
+	s.listener = lis
+	s.mu.Unlock()
+
+	for {
+		conn, err := lis.Accept()
+		if err != nil {
+			return err
+		}
+		sess := newSession(s, conn)
+		if !s.addSession(sess) {
+			conn.Close()
+			return errors.New("SMPP server closed")
+		}
+		go sess.run()
+	}
+}
+
+// BindSMSCs binds to the external SMSCs of the config as an ESME, and keeps
+// the binds up until the server is closed.
+func (s *Server) BindSMSCs() {
+	for _, bind := range s.config.Binds {
+		go s.maintainBind(bind)
+	}
+}
+
+// Close stops accepting connections, closes all sessions and stops binding
+// to SMSCs.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() { close(s.closed) })
+	s.mu.Lock()
+	lis := s.listener
+	sessions := make([]*session, 0, len(s.sessions))
+	for sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	s.mu.Unlock()
+
+	if lis != nil {
+		lis.Close()
+	}
+	for _, sess := range sessions {
+		sess.close()
+	}
+}
+
+// Send sends the delivery receipt of a message submitted over SMPP. It
+// implements notifier.Sender, and returns notifier.ErrNotRoutable if no
+// receiver of the system ID is bound to this server.
+func (s *Server) Send(notification *storage.SMSNotification) error {
+	u, err := url.Parse(notification.SMS.CallbackUrl)
+	if err != nil || u.Scheme != Scheme {
+		return fmt.Errorf("invalid SMPP callback URL %q", notification.SMS.CallbackUrl)
+	}
+	if u.RawQuery == receiptQuery && notification.SMS.Status == storage.MessageStatus_DELIVERED {
+		return nil
+	}
+
+	body := newDeliveryReceipt(notification.SMS, clock.Now()).MarshalBinary()
+	var lastErr error
+	for _, sess := range s.getReceivers(u.Host, notification.NetworkID) {
+		resp, err := sess.request(DeliverSM, body)
+		if err == nil && resp.CommandStatus != StatusOK {
+			err = fmt.Errorf("deliver_sm rejected with status 0x%x", resp.CommandStatus)
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+	// The receiver may be bound to another replica
+	return notifier.ErrNotRoutable
+}
+
+// maintainBind binds to an SMSC, and binds again whenever the bind fails or
+// is closed, until the server is closed.
+func (s *Server) maintainBind(bind smsd.SMPPBind) {
+	delay := s.bindRetryDelay
+	for {
+		sess, err := s.bindSMSC(bind)
+		if err == nil {
+			glog.Infof("Bound to SMSC %s as %s", bind.Address, bind.SystemID)
+			delay = s.bindRetryDelay
+			select {
+			case <-sess.closed:
+				glog.Warningf("SMPP bind to SMSC %s closed, binding again", bind.Address)
+			case <-s.closed:
+				return
+			}
+		} else {
+			glog.Errorf("Failed to bind to SMSC %s as %s, retrying in %s: %v", bind.Address, bind.SystemID, delay, err)
+			select {
+			case <-time.After(delay):
+			case <-s.closed:
+				return
+			}
+			delay *= 2
+			if delay > maxBindRetryDelay {
+				delay = maxBindRetryDelay
+			}
+		}
+	}
+}
+
+// bindSMSC connects to an SMSC and binds to it as a transceiver.
+func (s *Server) bindSMSC(bind smsd.SMPPBind) (*session, error) {
+	password, err := bind.GetPassword()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout("tcp", bind.Address, s.responseTimeout)
+	if err != nil {
+		return nil, err
+	}
+	sess := newSession(s, conn)
+	sess.outbound = true
+	// The SMSC may deliver messages as soon as it responds to the bind, so
+	// the session is bound beforehand, and closed if the bind fails
+	sess.bindType = BindTransceiver
+	sess.account = smsd.SMPPAccount{SystemID: bind.SystemID, NetworkID: bind.NetworkID}
+	if !s.addSession(sess) {
+		conn.Close()
+		return nil, errors.New("SMPP server closed")
+	}
+	go sess.run()
+
+	body := (&Bind{
+		SystemID:         bind.SystemID,
+		Password:         password,
+		SystemType:       bind.SystemType,
+		InterfaceVersion: InterfaceVersion,
+	}).MarshalBinary()
+	resp, err := sess.request(BindTransceiver, body)
+	if err == nil && resp.CommandStatus != StatusOK {
+		err = fmt.Errorf("bind_transceiver rejected with status 0x%x", resp.CommandStatus)
+	}
+	if err != nil {
+		sess.close()
+		return nil, err
+	}
+	return sess, nil
+}
+
+// getReceivers returns the sessions of the peers of a system ID bound to
+// receive messages. Sessions with the SMSCs smsd binds to are excluded.
+func (s *Server) getReceivers(systemID, networkID string) []*session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ret []*session
+	for sess := range s.sessions {
+		_, account := sess.getBinding()
+		if !sess.outbound && sess.canReceive() && account.SystemID == systemID && account.NetworkID == networkID {
+			ret = append(ret, sess)
+		}
+	}
+	return ret
+}
+
+// addSession tracks a new session, unless the server is closed.
+func (s *Server) addSession(sess *session) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-s.closed:
+		return false
+	default:
+	}
+	s.sessions[sess] = struct{}{}
+	return true
+}
+
+func (s *Server) removeSession(sess *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sess)
+}
+
+func (s *Server) authenticate(systemID, password string) (smsd.SMPPAccount, uint32) {
+	for _, account := range s.config.Accounts {
+		if account.SystemID != systemID {
+			continue
+		}
+		expected, err := account.GetPassword()
+		if err != nil {
+			glog.Errorf("Failed to get the SMPP password of system ID %s: %v", systemID, err)
+			return smsd.SMPPAccount{}, StatusBindFailed
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(password)) != 1 {
+			return smsd.SMPPAccount{}, StatusInvalidPassword
+		}
+		return account, StatusOK
+	}
+	return smsd.SMPPAccount{}, StatusInvalidSystemID
+}
+
+// handleSubmit creates the message of a submit_sm, and returns the status
+// and body of the response.
+func (s *Server) handleSubmit(sess *session, pdu *PDU) (uint32, []byte) {
+	if !sess.canTransmit() {
+		return StatusInvalidBindStat, nil
+	}
+	_, account := sess.getBinding()
+
+	submit := &ShortMessage{}
+	if err := submit.UnmarshalBinary(pdu.Body); err != nil {
+		return StatusInvalidLength, nil
+	}
+	sms, status := s.getSMS(account, submit)
+	if status != StatusOK {
+		return status, nil
+	}
+	if receipt := submit.RegisteredDelivery & receiptMask; receipt != 0 {
+		sms.CallbackUrl = CallbackURL(account.SystemID, receipt == receiptOnFailure)
+	}
+	pk, err := s.store.CreateSMS(account.NetworkID, sms)
+	if err != nil {
+		glog.Errorf("Failed to create SMPP message from %s: %v", account.SystemID, err)
+		return StatusSystemError, nil
+	}
+	return StatusOK, (&MessageID{ID: pk}).MarshalBinary()
+}
+
+// handleDeliver creates the message of a deliver_sm of an SMSC smsd is
+// bound to, and returns the status and body of the response.
+func (s *Server) handleDeliver(sess *session, pdu *PDU) (uint32, []byte) {
+	if !sess.canReceive() {
+		return StatusInvalidBindStat, nil
+	}
+	_, account := sess.getBinding()
+
+	deliver := &ShortMessage{}
+	if err := deliver.UnmarshalBinary(pdu.Body); err != nil {
+		return StatusInvalidLength, nil
+	}
+	// The message_id of deliver_sm_resp is unused (SMPP v3.4 4.6.2)
+	resp := (&MessageID{}).MarshalBinary()
+	// smsd doesn't submit messages to SMSCs, so there are no receipts or
+	// other notifications to handle
+	if deliver.ESMClass&esmClassTypeMask != 0 {
+		glog.V(2).Infof("Ignoring SMPP notification with esm_class 0x%x from SMSC as %s", deliver.ESMClass, account.SystemID)
+		return StatusOK, resp
+	}
+	sms, status := s.getSMS(account, deliver)
+	if status != StatusOK {
+		return status, nil
+	}
+	if _, err := s.store.CreateSMS(account.NetworkID, sms); err != nil {
+		glog.Errorf("Failed to create SMPP message from SMSC as %s: %v", account.SystemID, err)
+		return StatusSystemError, nil
+	}
+	return StatusOK, resp
+}
+
+// getSMS returns the message to create for a submit_sm or deliver_sm, or
+// the status of the error response.
+func (s *Server) getSMS(account smsd.SMPPAccount, msg *ShortMessage) (storage.MutableSMS, uint32) {
+	// Long messages must be sent in a single message_payload
+	if msg.ESMClass&ESMClassUDHI != 0 {
+		return storage.MutableSMS{}, StatusInvalidESMClass
+	}
+	text, err := decodeText(msg)
+	if err != nil {
+		glog.Warningf("Failed to decode SMPP message from %s: %v", account.SystemID, err)
+		return storage.MutableSMS{}, StatusSubmitFailed
+	}
+	validityPeriod, err := parseValidityPeriod(msg.ValidityPeriod, clock.Now())
+	if err != nil {
+		return storage.MutableSMS{}, StatusInvalidExpiry
+	}
+	if validityPeriod == 0 {
+		validityPeriod = models.DefaultValidityPeriodSec
+	}
+	imsi, err := s.getIMSI(account.NetworkID, msg.DestinationAddr)
+	if err == merrors.ErrNotFound {
+		return storage.MutableSMS{}, StatusInvalidDestAddr
+	}
+	if err != nil {
+		glog.Errorf("Failed to resolve destination %s of SMPP message: %v", msg.DestinationAddr, err)
+		return storage.MutableSMS{}, StatusSystemError
+	}
+
+	sms := storage.MutableSMS{
+		Imsi:              imsi,
+		SourceMsisdn:      msg.SourceAddr,
+		Message:           text,
+		ValidityPeriodSec: validityPeriod,
+	}
+	return sms, StatusOK
+}
+
+// getIMSI returns the IMSI of the destination of a message, which is either
+// an IMSI or the MSISDN of a subscriber.
+func (s *Server) getIMSI(networkID, destination string) (string, error) {
+	if strings.HasPrefix(destination, imsiPrefix) {
+		return destination, nil
+	}
+	destination = strings.TrimPrefix(destination, "+")
+	if destination == "" {
+		return "", merrors.ErrNotFound
+	}
+	imsi, err := s.resolveIMSI(networkID, destination)
+	if err != nil && err != merrors.ErrNotFound {
+		return "", errors.Wrap(err, "failed to resolve MSISDN")
+	}
+	return imsi, err
+}
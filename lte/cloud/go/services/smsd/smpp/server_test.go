@@ -0,0 +1,544 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smpp
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/services/smsd"
+	"magma/lte/cloud/go/services/smsd/notifier"
+	"magma/lte/cloud/go/services/smsd/storage"
+	"magma/lte/cloud/go/services/smsd/storage/mocks"
+	"magma/orc8r/cloud/go/clock"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServer_Bind(t *testing.T) {
+	config, cleanup := newTestConfig(t)
+	defer cleanup()
+	store := new(mocks.SMSStorage)
+	srv := NewServer(config, store, resolveTestIMSI)
+	defer srv.Close()
+	addr := startTestServer(t, srv)
+
+	peer := dialTestPeer(t, addr)
+	defer peer.conn.Close()
+
+	// Requests before binding
+	resp := peer.request(SubmitSM, newSubmit("5551234", "hi").MarshalBinary())
+	assert.Equal(t, SubmitSMResp, resp.CommandID)
+	assert.Equal(t, StatusInvalidBindStat, resp.CommandStatus)
+	resp = peer.request(Unbind, nil)
+	assert.Equal(t, StatusInvalidBindStat, resp.CommandStatus)
+	resp = peer.request(EnquireLink, nil)
+	assert.Equal(t, EnquireLinkResp, resp.CommandID)
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	resp = peer.bind(BindTransmitter, "esme1", "wrong")
+	assert.Equal(t, BindTransmitterResp, resp.CommandID)
+	assert.Equal(t, StatusInvalidPassword, resp.CommandStatus)
+	resp = peer.bind(BindTransmitter, "esme4", "pw1")
+	assert.Equal(t, StatusInvalidSystemID, resp.CommandStatus)
+	// The password file of the account is missing
+	resp = peer.bind(BindTransmitter, "esme3", "pw3")
+	assert.Equal(t, StatusBindFailed, resp.CommandStatus)
+
+	resp = peer.bind(BindTransmitter, "esme1", "pw1")
+	assert.Equal(t, BindTransmitterResp, resp.CommandID)
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+	assert.Equal(t, SystemID, decodeMessageID(t, resp))
+	resp = peer.bind(BindReceiver, "esme1", "pw1")
+	assert.Equal(t, BindReceiverResp, resp.CommandID)
+	assert.Equal(t, StatusAlreadyBound, resp.CommandStatus)
+
+	// Unsupported commands (query_sm)
+	resp = peer.request(0x00000003, nil)
+	assert.Equal(t, GenericNack, resp.CommandID)
+	assert.Equal(t, StatusInvalidCommand, resp.CommandStatus)
+
+	resp = peer.request(Unbind, nil)
+	assert.Equal(t, UnbindResp, resp.CommandID)
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+	peer.assertClosed()
+}
+
+func TestServer_SubmitSM(t *testing.T) {
+	clock.SetAndFreezeClock(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer clock.UnfreezeClock(t)
+
+	config, cleanup := newTestConfig(t)
+	defer cleanup()
+	store := new(mocks.SMSStorage)
+	srv := NewServer(config, store, resolveTestIMSI)
+	defer srv.Close()
+	addr := startTestServer(t, srv)
+
+	peer := dialTestPeer(t, addr)
+	defer peer.conn.Close()
+	resp := peer.bind(BindTransmitter, "esme1", "pw1")
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	// Default validity, receipt requested
+	store.On("CreateSMS", "n1", storage.MutableSMS{
+		Imsi:              "IMSI001010000000001",
+		SourceMsisdn:      "123",
+		Message:           "hello",
+		ValidityPeriodSec: 86400,
+		CallbackUrl:       "smpp://esme1",
+	}).Return("pk1", nil).Once()
+	submit := newSubmit("+5551234", "hello")
+	submit.RegisteredDelivery = 0x01
+	resp = peer.request(SubmitSM, submit.MarshalBinary())
+	assert.Equal(t, SubmitSMResp, resp.CommandID)
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+	assert.Equal(t, "pk1", decodeMessageID(t, resp))
+
+	// IMSI destination, UCS2 message_payload, failure-only receipt
+	store.On("CreateSMS", "n1", storage.MutableSMS{
+		Imsi:              "IMSI001010000000002",
+		SourceMsisdn:      "123",
+		Message:           "h€llo",
+		ValidityPeriodSec: 3600,
+		CallbackUrl:       "smpp://esme1?receipt=failure",
+	}).Return("pk2", nil).Once()
+	submit = newSubmit("IMSI001010000000002", "")
+	submit.DataCoding = DataCodingUCS2
+	submit.TLVs = []TLV{{Tag: TagMessagePayload, Value: []byte{0x00, 'h', 0x20, 0xAC, 0x00, 'l', 0x00, 'l', 0x00, 'o'}}}
+	submit.ValidityPeriod = "000000010000000R"
+	submit.RegisteredDelivery = 0x02
+	resp = peer.request(SubmitSM, submit.MarshalBinary())
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+	assert.Equal(t, "pk2", decodeMessageID(t, resp))
+
+	// Unknown MSISDN
+	resp = peer.request(SubmitSM, newSubmit("5550000", "hello").MarshalBinary())
+	assert.Equal(t, StatusInvalidDestAddr, resp.CommandStatus)
+	assert.Empty(t, resp.Body)
+
+	// Segmented messages
+	submit = newSubmit("5551234", "hello")
+	submit.ESMClass = ESMClassUDHI
+	resp = peer.request(SubmitSM, submit.MarshalBinary())
+	assert.Equal(t, StatusInvalidESMClass, resp.CommandStatus)
+
+	// Expired validity period
+	submit = newSubmit("5551234", "hello")
+	submit.ValidityPeriod = "191231000000000+"
+	resp = peer.request(SubmitSM, submit.MarshalBinary())
+	assert.Equal(t, StatusInvalidExpiry, resp.CommandStatus)
+
+	// Malformed body
+	resp = peer.request(SubmitSM, []byte{0x00})
+	assert.Equal(t, StatusInvalidLength, resp.CommandStatus)
+
+	// Storage error
+	store.On("CreateSMS", "n1", mock.Anything).Return("", errors.New("oops")).Once()
+	resp = peer.request(SubmitSM, newSubmit("5551234", "hello").MarshalBinary())
+	assert.Equal(t, StatusSystemError, resp.CommandStatus)
+
+	// Receivers can't submit
+	receiver := dialTestPeer(t, addr)
+	defer receiver.conn.Close()
+	resp = receiver.bind(BindReceiver, "esme1", "pw1")
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+	resp = receiver.request(SubmitSM, newSubmit("5551234", "hello").MarshalBinary())
+	assert.Equal(t, StatusInvalidBindStat, resp.CommandStatus)
+
+	store.AssertExpectations(t)
+}
+
+func TestServer_Send(t *testing.T) {
+	config, cleanup := newTestConfig(t)
+	defer cleanup()
+	store := new(mocks.SMSStorage)
+	srv := NewServer(config, store, resolveTestIMSI)
+	defer srv.Close()
+	addr := startTestServer(t, srv)
+
+	// Transmitters don't receive receipts
+	transmitter := dialTestPeer(t, addr)
+	defer transmitter.conn.Close()
+	resp := transmitter.bind(BindTransmitter, "esme1", "pw1")
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	notification := &storage.SMSNotification{
+		NetworkID: "n1",
+		SMS: &storage.SMS{
+			Pk:           "pk1",
+			Status:       storage.MessageStatus_DELIVERED,
+			Imsi:         "IMSI001010000000001",
+			SourceMsisdn: "123",
+			Message:      "hello",
+			CallbackUrl:  "smpp://esme1",
+		},
+	}
+	err := srv.Send(notification)
+	assert.Equal(t, notifier.ErrNotRoutable, err)
+
+	peer := dialTestPeer(t, addr)
+	defer peer.conn.Close()
+	resp = peer.bind(BindTransceiver, "esme1", "pw1")
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	sent := make(chan error, 1)
+	go func() { sent <- srv.Send(notification) }()
+	req := peer.read()
+	assert.Equal(t, DeliverSM, req.CommandID)
+	receipt := &ShortMessage{}
+	assert.NoError(t, receipt.UnmarshalBinary(req.Body))
+	assert.Equal(t, ESMClassDeliveryReceipt, receipt.ESMClass)
+	assert.Equal(t, "123", receipt.DestinationAddr)
+	assert.Contains(t, string(receipt.ShortMessage), "id:pk1 ")
+	assert.Contains(t, string(receipt.ShortMessage), " stat:DELIVRD ")
+	peer.respond(req, StatusOK, (&MessageID{}).MarshalBinary())
+	assert.NoError(t, <-sent)
+
+	// Rejected by the peer
+	go func() { sent <- srv.Send(notification) }()
+	req = peer.read()
+	peer.respond(req, StatusSystemError, nil)
+	assert.EqualError(t, <-sent, "deliver_sm rejected with status 0x8")
+
+	// Failure-only receipts aren't sent for delivered messages
+	notification.SMS.CallbackUrl = "smpp://esme1?receipt=failure"
+	assert.NoError(t, srv.Send(notification))
+
+	// The receiver must be bound to the network of the message
+	notification.NetworkID = "n2"
+	notification.SMS.CallbackUrl = "smpp://esme1"
+	err = srv.Send(notification)
+	assert.Equal(t, notifier.ErrNotRoutable, err)
+
+	notification.SMS.CallbackUrl = "http://esme1"
+	err = srv.Send(notification)
+	assert.EqualError(t, err, `invalid SMPP callback URL "http://esme1"`)
+}
+
+func TestServer_Window(t *testing.T) {
+	store := new(mocks.SMSStorage)
+	config, cleanup := newTestConfig(t)
+	defer cleanup()
+	config.WindowSize = 1
+	srv := NewServer(config, store, resolveTestIMSI)
+	defer srv.Close()
+	srv.responseTimeout = 500 * time.Millisecond
+	addr := startTestServer(t, srv)
+
+	peer := dialTestPeer(t, addr)
+	defer peer.conn.Close()
+	resp := peer.bind(BindTransceiver, "esme1", "pw1")
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	// Submissions beyond the window are throttled
+	release := make(chan time.Time)
+	store.On("CreateSMS", "n1", mock.Anything).Return("pk1", nil).WaitUntil(release).Once()
+	first := peer.send(SubmitSM, newSubmit("5551234", "hello").MarshalBinary())
+	time.Sleep(50 * time.Millisecond)
+	second := peer.send(SubmitSM, newSubmit("5551234", "hello").MarshalBinary())
+	resp = peer.read()
+	assert.Equal(t, second, resp.SequenceNumber)
+	assert.Equal(t, StatusThrottled, resp.CommandStatus)
+	close(release)
+	resp = peer.read()
+	assert.Equal(t, first, resp.SequenceNumber)
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	// Receipts wait for a free slot, then for the response
+	notification := &storage.SMSNotification{
+		NetworkID: "n1",
+		SMS:       &storage.SMS{Pk: "pk1", Status: storage.MessageStatus_FAILED, CallbackUrl: "smpp://esme1"},
+	}
+	errs := make(chan error, 2)
+	go func() { errs <- srv.Send(notification) }()
+	req := peer.read()
+	assert.Equal(t, DeliverSM, req.CommandID)
+	go func() { errs <- srv.Send(notification) }()
+	peer.assertNothingReceived(100 * time.Millisecond)
+	peer.respond(req, StatusOK, nil)
+	assert.NoError(t, <-errs)
+	req = peer.read()
+	assert.Equal(t, DeliverSM, req.CommandID)
+	assert.EqualError(t, <-errs, "timed out waiting for the response to request 0x5")
+
+	store.AssertExpectations(t)
+}
+
+func TestServer_EnquireLink(t *testing.T) {
+	config, cleanup := newTestConfig(t)
+	defer cleanup()
+	store := new(mocks.SMSStorage)
+	srv := NewServer(config, store, resolveTestIMSI)
+	defer srv.Close()
+	srv.enquireLinkInterval = 100 * time.Millisecond
+	srv.responseTimeout = 100 * time.Millisecond
+	addr := startTestServer(t, srv)
+
+	// Peers which don't bind are disconnected
+	peer := dialTestPeer(t, addr)
+	defer peer.conn.Close()
+	peer.assertClosed()
+
+	// Idle sessions are checked, and closed if the peer doesn't respond
+	peer = dialTestPeer(t, addr)
+	defer peer.conn.Close()
+	resp := peer.bind(BindReceiver, "esme1", "pw1")
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+	req := peer.read()
+	assert.Equal(t, EnquireLink, req.CommandID)
+	peer.respond(req, StatusOK, nil)
+	req = peer.read()
+	assert.Equal(t, EnquireLink, req.CommandID)
+	peer.assertClosed()
+}
+
+func TestServer_Close(t *testing.T) {
+	config, cleanup := newTestConfig(t)
+	defer cleanup()
+	store := new(mocks.SMSStorage)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	srv := NewServer(config, store, resolveTestIMSI)
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(lis) }()
+
+	peer := dialTestPeer(t, lis.Addr().String())
+	defer peer.conn.Close()
+	resp := peer.bind(BindTransceiver, "esme1", "pw1")
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	srv.Close()
+	assert.Error(t, <-served)
+	peer.assertClosed()
+}
+
+func TestServer_BindSMSCs(t *testing.T) {
+	config, cleanup := newTestConfig(t)
+	defer cleanup()
+	smsc, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer smsc.Close()
+	config.Binds = []smsd.SMPPBind{
+		{Address: smsc.Addr().String(), SystemID: "magma", PasswordFile: config.Accounts[0].PasswordFile, NetworkID: "n1"},
+	}
+	store := new(mocks.SMSStorage)
+	srv := NewServer(config, store, resolveTestIMSI)
+	srv.bindRetryDelay = 10 * time.Millisecond
+	defer srv.Close()
+	srv.BindSMSCs()
+
+	// Rejected binds are retried
+	peer := acceptTestPeer(t, smsc)
+	req := peer.read()
+	assert.Equal(t, BindTransceiver, req.CommandID)
+	bind := &Bind{}
+	assert.NoError(t, bind.UnmarshalBinary(req.Body))
+	assert.Equal(t, Bind{SystemID: "magma", Password: "pw1", InterfaceVersion: InterfaceVersion}, *bind)
+	peer.respond(req, StatusInvalidPassword, (&MessageID{ID: "smsc"}).MarshalBinary())
+	peer.assertClosed()
+	peer.conn.Close()
+
+	peer = acceptTestPeer(t, smsc)
+	defer peer.conn.Close()
+	req = peer.read()
+	assert.Equal(t, BindTransceiver, req.CommandID)
+	peer.respond(req, StatusOK, (&MessageID{ID: "smsc"}).MarshalBinary())
+
+	// Delivered messages are created in the network of the bind, without
+	// receipts
+	store.On("CreateSMS", "n1", storage.MutableSMS{
+		Imsi:              "IMSI001010000000001",
+		SourceMsisdn:      "123",
+		Message:           "hello",
+		ValidityPeriodSec: 86400,
+	}).Return("pk1", nil).Once()
+	deliver := newSubmit("5551234", "hello")
+	deliver.RegisteredDelivery = 0x01
+	resp := peer.request(DeliverSM, deliver.MarshalBinary())
+	assert.Equal(t, DeliverSMResp, resp.CommandID)
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+	assert.Equal(t, "", decodeMessageID(t, resp))
+
+	resp = peer.request(DeliverSM, newSubmit("5550000", "hello").MarshalBinary())
+	assert.Equal(t, StatusInvalidDestAddr, resp.CommandStatus)
+
+	// Receipts are acknowledged and ignored
+	deliver = newSubmit("123", "id:x sub:001 dlvrd:001 submit date:2001010000 done date:2001010000 stat:DELIVRD err:000 text:")
+	deliver.ESMClass = ESMClassDeliveryReceipt
+	resp = peer.request(DeliverSM, deliver.MarshalBinary())
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	// The SMSC can't bind to or submit messages over the session
+	resp = peer.request(SubmitSM, newSubmit("5551234", "hello").MarshalBinary())
+	assert.Equal(t, GenericNack, resp.CommandID)
+	assert.Equal(t, StatusInvalidCommand, resp.CommandStatus)
+	resp = peer.bind(BindTransceiver, "esme1", "pw1")
+	assert.Equal(t, GenericNack, resp.CommandID)
+
+	// Receipts aren't sent to SMSCs
+	err = srv.Send(&storage.SMSNotification{
+		NetworkID: "n1",
+		SMS:       &storage.SMS{Pk: "pk1", Status: storage.MessageStatus_DELIVERED, CallbackUrl: "smpp://magma"},
+	})
+	assert.Equal(t, notifier.ErrNotRoutable, err)
+
+	// Closed binds are bound again
+	peer.conn.Close()
+	peer = acceptTestPeer(t, smsc)
+	defer peer.conn.Close()
+	req = peer.read()
+	assert.Equal(t, BindTransceiver, req.CommandID)
+	peer.respond(req, StatusOK, (&MessageID{ID: "smsc"}).MarshalBinary())
+	resp = peer.request(EnquireLink, nil)
+	assert.Equal(t, StatusOK, resp.CommandStatus)
+
+	// Closing the server closes and stops the binds
+	srv.Close()
+	peer.assertClosed()
+	smsc.(*net.TCPListener).SetDeadline(time.Now().Add(100 * time.Millisecond))
+	_, err = smsc.Accept()
+	assert.Error(t, err)
+	store.AssertExpectations(t)
+}
+
+// newTestConfig returns the SMPP config of the tests. The password files of
+// the accounts are written to a temporary directory, removed by cleanup.
+func newTestConfig(t *testing.T) (smsd.SMPPConfig, func()) {
+	dir, err := ioutil.TempDir("", "smpp_passwords")
+	assert.NoError(t, err)
+	// Trailing newlines aren't part of the password
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "esme1"), []byte("pw1\n"), 0600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "esme2"), []byte("pw2"), 0600))
+
+	config := smsd.SMPPConfig{
+		Accounts: []smsd.SMPPAccount{
+			{SystemID: "esme1", PasswordFile: filepath.Join(dir, "esme1"), NetworkID: "n1"},
+			{SystemID: "esme2", PasswordFile: filepath.Join(dir, "esme2"), NetworkID: "n2"},
+			{SystemID: "esme3", PasswordFile: filepath.Join(dir, "esme3"), NetworkID: "n1"},
+		},
+	}
+	return config, func() { os.RemoveAll(dir) }
+}
+
+// startTestServer serves srv on a local port, and returns its address
+func startTestServer(t *testing.T, srv *Server) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go srv.Serve(lis)
+	return lis.Addr().String()
+}
+
+func resolveTestIMSI(networkID, msisdn string) (string, error) {
+	if networkID == "n1" && msisdn == "5551234" {
+		return "IMSI001010000000001", nil
+	}
+	return "", merrors.ErrNotFound
+}
+
+func newSubmit(destination, text string) *ShortMessage {
+	return &ShortMessage{
+		SourceAddr:      "123",
+		DestinationAddr: destination,
+		DataCoding:      DataCodingIA5,
+		ShortMessage:    []byte(text),
+	}
+}
+
+func decodeMessageID(t *testing.T, pdu *PDU) string {
+	id := &MessageID{}
+	assert.NoError(t, id.UnmarshalBinary(pdu.Body))
+	return id.ID
+}
+
+// testPeer is an ESME connected to the server under test, or an SMSC the
+// server under test is connected to
+type testPeer struct {
+	t       *testing.T
+	conn    net.Conn
+	lastSeq uint32
+}
+
+func dialTestPeer(t *testing.T, addr string) *testPeer {
+	conn, err := net.Dial("tcp", addr)
+	assert.NoError(t, err)
+	return &testPeer{t: t, conn: conn}
+}
+
+// acceptTestPeer accepts a connection of the server under test to an SMSC
+func acceptTestPeer(t *testing.T, lis net.Listener) *testPeer {
+	lis.(*net.TCPListener).SetDeadline(time.Now().Add(5 * time.Second))
+	conn, err := lis.Accept()
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return &testPeer{t: t, conn: conn}
+}
+
+func (p *testPeer) send(commandID uint32, body []byte) uint32 {
+	p.lastSeq++
+	_, err := p.conn.Write((&PDU{CommandID: commandID, SequenceNumber: p.lastSeq, Body: body}).MarshalBinary())
+	assert.NoError(p.t, err)
+	return p.lastSeq
+}
+
+func (p *testPeer) read() *PDU {
+	p.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	pdu, err := ReadPDU(p.conn)
+	if !assert.NoError(p.t, err) {
+		p.t.FailNow()
+	}
+	return pdu
+}
+
+func (p *testPeer) request(commandID uint32, body []byte) *PDU {
+	seq := p.send(commandID, body)
+	resp := p.read()
+	assert.Equal(p.t, seq, resp.SequenceNumber)
+	return resp
+}
+
+func (p *testPeer) respond(req *PDU, status uint32, body []byte) {
+	resp := &PDU{CommandID: req.CommandID | responseMask, CommandStatus: status, SequenceNumber: req.SequenceNumber, Body: body}
+	_, err := p.conn.Write(resp.MarshalBinary())
+	assert.NoError(p.t, err)
+}
+
+func (p *testPeer) bind(commandID uint32, systemID, password string) *PDU {
+	bind := &Bind{SystemID: systemID, Password: password, InterfaceVersion: InterfaceVersion}
+	return p.request(commandID, bind.MarshalBinary())
+}
+
+// assertNothingReceived asserts that the server sends nothing for d
+func (p *testPeer) assertNothingReceived(d time.Duration) {
+	p.conn.SetReadDeadline(time.Now().Add(d))
+	_, err := ReadPDU(p.conn)
+	netErr, ok := err.(net.Error)
+	assert.True(p.t, ok && netErr.Timeout(), "unexpected PDU or error: %v", err)
+}
+
+// assertClosed asserts that the server closes the connection
+func (p *testPeer) assertClosed() {
+	p.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err := ReadPDU(p.conn)
+	assert.Equal(p.t, io.EOF, err)
+}
@@ -0,0 +1,328 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package smpp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"magma/lte/cloud/go/services/smsd"
+
+	"github.com/golang/glog"
+)
+
+var errSessionClosed = errors.New("SMPP session closed")
+
+// session is a connection of a peer to the server, or of the server to an
+// SMSC it binds to.
+//
+// Requests of the peer are handled concurrently, up to the window size.
+// Requests beyond the window are rejected as throttled. Requests sent to the
+// peer are also limited to the window size, and wait for a free slot.
+type session struct {
+	server *Server
+	conn   net.Conn
+	// outbound is set for the sessions with the SMSCs the server binds to,
+	// in which the server is the ESME
+	outbound bool
+
+	writeMu sync.Mutex
+
+	mu           sync.Mutex
+	bindType     uint32 // 0 if not bound
+	account      smsd.SMPPAccount
+	lastSeq      uint32
+	pending      map[uint32]chan *PDU
+	lastActivity time.Time
+
+	// Slots of the requests received from and sent to the peer
+	inWindow  chan struct{}
+	outWindow chan struct{}
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newSession(server *Server, conn net.Conn) *session {
+	return &session{
+		server:       server,
+		conn:         conn,
+		pending:      map[uint32]chan *PDU{},
+		lastActivity: time.Now(),
+		inWindow:     make(chan struct{}, server.config.WindowSize),
+		outWindow:    make(chan struct{}, server.config.WindowSize),
+		closed:       make(chan struct{}),
+	}
+}
+
+// run reads and handles the PDUs of the peer until the session is closed
+func (s *session) run() {
+	defer s.close()
+	go s.keepalive()
+
+	for {
+		pdu, err := ReadPDU(s.conn)
+		if err != nil {
+			select {
+			case <-s.closed:
+			default:
+				glog.V(2).Infof("Closing SMPP session with %s: %v", s.conn.RemoteAddr(), err)
+			}
+			return
+		}
+		s.mu.Lock()
+		s.lastActivity = time.Now()
+		s.mu.Unlock()
+
+		if pdu.IsResponse() {
+			s.handleResponse(pdu)
+			continue
+		}
+		s.handleRequest(pdu)
+	}
+}
+
+func (s *session) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		s.conn.Close()
+		s.server.removeSession(s)
+	})
+}
+
+func (s *session) getBinding() (uint32, smsd.SMPPAccount) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bindType, s.account
+}
+
+// canReceive returns true if the peer is bound to receive messages from the
+// server
+func (s *session) canReceive() bool {
+	bindType, _ := s.getBinding()
+	return bindType == BindReceiver || bindType == BindTransceiver
+}
+
+// canTransmit returns true if the peer is bound to submit messages to the
+// server
+func (s *session) canTransmit() bool {
+	bindType, _ := s.getBinding()
+	return bindType == BindTransmitter || bindType == BindTransceiver
+}
+
+func (s *session) handleRequest(pdu *PDU) {
+	switch {
+	case isBind(pdu.CommandID) && !s.outbound:
+		s.handleBind(pdu)
+	case pdu.CommandID == Unbind:
+		s.handleUnbind(pdu)
+	case pdu.CommandID == EnquireLink:
+		s.respond(pdu, StatusOK, nil)
+	case pdu.CommandID == SubmitSM && !s.outbound:
+		s.handleConcurrently(pdu, s.server.handleSubmit)
+	case pdu.CommandID == DeliverSM && s.outbound:
+		s.handleConcurrently(pdu, s.server.handleDeliver)
+	default:
+		s.write(&PDU{CommandID: GenericNack, CommandStatus: StatusInvalidCommand, SequenceNumber: pdu.SequenceNumber})
+	}
+}
+
+// handleConcurrently handles a request in the background, up to the window
+// size, and responds to it.
+func (s *session) handleConcurrently(pdu *PDU, handler func(*session, *PDU) (uint32, []byte)) {
+	select {
+	case s.inWindow <- struct{}{}:
+	default:
+		s.respond(pdu, StatusThrottled, nil)
+		return
+	}
+	go func() {
+		defer func() { <-s.inWindow }()
+		status, body := handler(s, pdu)
+		s.respond(pdu, status, body)
+	}()
+}
+
+func (s *session) handleBind(pdu *PDU) {
+	if bindType, _ := s.getBinding(); bindType != 0 {
+		s.respond(pdu, StatusAlreadyBound, nil)
+		return
+	}
+
+	bind := &Bind{}
+	if err := bind.UnmarshalBinary(pdu.Body); err != nil {
+		s.respond(pdu, StatusInvalidLength, nil)
+		return
+	}
+	account, status := s.server.authenticate(bind.SystemID, bind.Password)
+	if status != StatusOK {
+		glog.Warningf("Rejected SMPP bind of system ID %s from %s: status 0x%x", bind.SystemID, s.conn.RemoteAddr(), status)
+		s.respond(pdu, status, nil)
+		return
+	}
+
+	s.mu.Lock()
+	s.bindType = pdu.CommandID
+	s.account = account
+	s.mu.Unlock()
+	glog.Infof("SMPP peer %s bound from %s with command 0x%x", bind.SystemID, s.conn.RemoteAddr(), pdu.CommandID)
+	s.respond(pdu, StatusOK, (&MessageID{ID: SystemID}).MarshalBinary())
+}
+
+func (s *session) handleUnbind(pdu *PDU) {
+	if bindType, _ := s.getBinding(); bindType == 0 {
+		s.respond(pdu, StatusInvalidBindStat, nil)
+		return
+	}
+	s.respond(pdu, StatusOK, nil)
+	s.close()
+}
+
+func (s *session) handleResponse(pdu *PDU) {
+	s.mu.Lock()
+	ch, found := s.pending[pdu.SequenceNumber]
+	delete(s.pending, pdu.SequenceNumber)
+	s.mu.Unlock()
+	if !found {
+		glog.V(2).Infof("Ignoring unexpected SMPP response 0x%x with sequence number %d", pdu.CommandID, pdu.SequenceNumber)
+		return
+	}
+	ch <- pdu
+}
+
+// request sends a request to the peer and waits for its response
+func (s *session) request(commandID uint32, body []byte) (*PDU, error) {
+	timeout := time.NewTimer(s.server.responseTimeout)
+	defer timeout.Stop()
+
+	select {
+	case s.outWindow <- struct{}{}:
+	case <-s.closed:
+		return nil, errSessionClosed
+	case <-timeout.C:
+		return nil, errors.New("SMPP window is full")
+	}
+	defer func() { <-s.outWindow }()
+
+	ch := make(chan *PDU, 1)
+	s.mu.Lock()
+	s.lastSeq = s.lastSeq%0x7FFFFFFF + 1
+	seq := s.lastSeq
+	s.pending[seq] = ch
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.pending, seq)
+		s.mu.Unlock()
+	}()
+
+	err := s.write(&PDU{CommandID: commandID, SequenceNumber: seq, Body: body})
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.CommandID == GenericNack {
+			return nil, fmt.Errorf("request 0x%x rejected with generic_nack, status 0x%x", commandID, resp.CommandStatus)
+		}
+		return resp, nil
+	case <-s.closed:
+		return nil, errSessionClosed
+	case <-timeout.C:
+		return nil, fmt.Errorf("timed out waiting for the response to request 0x%x", commandID)
+	}
+}
+
+func (s *session) respond(req *PDU, status uint32, body []byte) {
+	resp := &PDU{
+		CommandID:      req.CommandID | responseMask,
+		CommandStatus:  status,
+		SequenceNumber: req.SequenceNumber,
+		Body:           body,
+	}
+	// Bind responses carry the system_id even on errors, other error
+	// responses have no body (SMPP v3.4 4.1.2)
+	if status != StatusOK && !isBind(req.CommandID) {
+		resp.Body = nil
+	}
+	s.write(resp)
+}
+
+func (s *session) write(pdu *PDU) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.conn.SetWriteDeadline(time.Now().Add(s.server.responseTimeout))
+	_, err := s.conn.Write(pdu.MarshalBinary())
+	if err != nil {
+		glog.V(2).Infof("Failed to write to SMPP peer %s: %v", s.conn.RemoteAddr(), err)
+		s.close()
+		return err
+	}
+	return nil
+}
+
+// keepalive checks the liveness of the peer with an enquire_link when the
+// session is idle, and closes the session if the peer doesn't respond.
+// Peers which don't bind in time are disconnected.
+func (s *session) keepalive() {
+	ticker := time.NewTicker(s.server.enquireLinkInterval / 2)
+	defer ticker.Stop()
+	started := time.Now()
+
+	for {
+		select {
+		case <-s.closed:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		idle := time.Since(s.lastActivity)
+		bound := s.bindType != 0
+		s.mu.Unlock()
+
+		if !bound {
+			if time.Since(started) >= s.server.enquireLinkInterval {
+				glog.Warningf("Closing SMPP session with %s: not bound in time", s.conn.RemoteAddr())
+				s.close()
+				return
+			}
+			continue
+		}
+		if idle < s.server.enquireLinkInterval {
+			continue
+		}
+		resp, err := s.request(EnquireLink, nil)
+		if err == nil && resp.CommandStatus != StatusOK {
+			err = fmt.Errorf("status 0x%x", resp.CommandStatus)
+		}
+		if err != nil {
+			glog.Warningf("Closing SMPP session with %s: enquire_link failed: %v", s.conn.RemoteAddr(), err)
+			s.close()
+			return
+		}
+	}
+}
+
+func isBind(commandID uint32) bool {
+	switch commandID &^ responseMask {
+	case BindReceiver, BindTransmitter, BindTransceiver:
+		return true
+	}
+	return false
+}
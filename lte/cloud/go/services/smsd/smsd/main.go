@@ -14,11 +14,18 @@
 package main
 
 import (
+	"net"
+	"net/http"
+	"time"
+
 	"magma/lte/cloud/go/lte"
 	"magma/lte/cloud/go/protos"
 	"magma/lte/cloud/go/services/smsd"
+	"magma/lte/cloud/go/services/smsd/notifier"
 	"magma/lte/cloud/go/services/smsd/servicers"
+	"magma/lte/cloud/go/services/smsd/smpp"
 	storage2 "magma/lte/cloud/go/services/smsd/storage"
+	"magma/lte/cloud/go/services/subscriberdb"
 	"magma/lte/cloud/go/sms_ll"
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/swagger"
@@ -26,10 +33,19 @@ import (
 	"magma/orc8r/cloud/go/service"
 	"magma/orc8r/cloud/go/sqorc"
 	"magma/orc8r/cloud/go/storage"
+	"magma/orc8r/lib/go/service/config"
 
 	"github.com/golang/glog"
 )
 
+const (
+	// notifyInterval is how often messages are expired and pending
+	// notifications are sent.
+	notifyInterval = 30 * time.Second
+
+	notifyTimeout = 10 * time.Second
+)
+
 func main() {
 	srv, err := service.NewOrchestratorService(lte.ModuleName, smsd.ServiceName)
 	if err != nil {
@@ -47,6 +63,32 @@ func main() {
 		glog.Fatalf("error initializing smsd storage: %s", err)
 	}
 
+	var serviceConfig smsd.Config
+	_, _, err = config.GetStructuredServiceConfig(lte.ModuleName, smsd.ServiceName, &serviceConfig)
+	if err != nil {
+		glog.Warningf("Failed to read smsd service config, using defaults: %v", err)
+	}
+
+	n := notifier.NewNotifier(store, &http.Client{Timeout: notifyTimeout})
+
+	// SMPP interface to external SMSCs and ESMEs, whose delivery receipts
+	// are sent by the notifier
+	if serviceConfig.SMPP.ListenAddress != "" || len(serviceConfig.SMPP.Binds) != 0 {
+		smppServer := smpp.NewServer(serviceConfig.SMPP, store, subscriberdb.GetIMSIForMSISDN)
+		n.RegisterSender(smpp.Scheme, smppServer)
+		if serviceConfig.SMPP.ListenAddress != "" {
+			lis, err := net.Listen("tcp", serviceConfig.SMPP.ListenAddress)
+			if err != nil {
+				glog.Fatalf("error listening for SMPP on %s: %v", serviceConfig.SMPP.ListenAddress, err)
+			}
+			go func() {
+				glog.Errorf("SMPP server stopped: %v", smppServer.Serve(lis))
+			}()
+		}
+		smppServer.BindSMSCs()
+	}
+	go n.Run(notifyInterval)
+
 	restServicer := servicers.NewRESTServicer(store)
 	obsidian.AttachHandlers(srv.EchoServer, restServicer.GetHandlers())
 	protos.RegisterSmsDServer(srv.GrpcServer, servicers.NewSMSDServicer(store, &sms_ll.DefaultSMSSerde{}))
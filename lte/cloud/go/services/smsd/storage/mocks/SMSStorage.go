@@ -15,6 +15,27 @@ type SMSStorage struct {
 	mock.Mock
 }
 
+// CreateMOSMS provides a mock function with given fields: networkID, segment
+func (_m *SMSStorage) CreateMOSMS(networkID string, segment storage.MOSMSSegment) (string, error) {
+	ret := _m.Called(networkID, segment)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, storage.MOSMSSegment) string); ok {
+		r0 = rf(networkID, segment)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, storage.MOSMSSegment) error); ok {
+		r1 = rf(networkID, segment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateSMS provides a mock function with given fields: networkID, sms
 func (_m *SMSStorage) CreateSMS(networkID string, sms storage.MutableSMS) (string, error) {
 	ret := _m.Called(networkID, sms)
@@ -36,6 +57,48 @@ func (_m *SMSStorage) CreateSMS(networkID string, sms storage.MutableSMS) (strin
 	return r0, r1
 }
 
+// DeferSMSNotification provides a mock function with given fields: pk, nextAttempt
+func (_m *SMSStorage) DeferSMSNotification(pk string, nextAttempt time.Time) error {
+	ret := _m.Called(pk, nextAttempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(pk, nextAttempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMOSMSs provides a mock function with given fields: networkID, pks
+func (_m *SMSStorage) DeleteMOSMSs(networkID string, pks []string) error {
+	ret := _m.Called(networkID, pks)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(networkID, pks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSMSNotification provides a mock function with given fields: pk
+func (_m *SMSStorage) DeleteSMSNotification(pk string) error {
+	ret := _m.Called(pk)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(pk)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteSMSs provides a mock function with given fields: networkID, pks
 func (_m *SMSStorage) DeleteSMSs(networkID string, pks []string) error {
 	ret := _m.Called(networkID, pks)
@@ -50,6 +113,66 @@ func (_m *SMSStorage) DeleteSMSs(networkID string, pks []string) error {
 	return r0
 }
 
+// ExpireSMSs provides a mock function with given fields:
+func (_m *SMSStorage) ExpireSMSs() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetMOSMSs provides a mock function with given fields: networkID, pks, imsis
+func (_m *SMSStorage) GetMOSMSs(networkID string, pks []string, imsis []string) ([]*storage.MOSMS, error) {
+	ret := _m.Called(networkID, pks, imsis)
+
+	var r0 []*storage.MOSMS
+	if rf, ok := ret.Get(0).(func(string, []string, []string) []*storage.MOSMS); ok {
+		r0 = rf(networkID, pks, imsis)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.MOSMS)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string, []string) error); ok {
+		r1 = rf(networkID, pks, imsis)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSMSNotifications provides a mock function with given fields: limit
+func (_m *SMSStorage) GetSMSNotifications(limit uint64) ([]*storage.SMSNotification, error) {
+	ret := _m.Called(limit)
+
+	var r0 []*storage.SMSNotification
+	if rf, ok := ret.Get(0).(func(uint64) []*storage.SMSNotification); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.SMSNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSMSs provides a mock function with given fields: networkID, pks, imsis, onlyWaiting, startTime, endTime
 func (_m *SMSStorage) GetSMSs(networkID string, pks []string, imsis []string, onlyWaiting bool, startTime *time.Time, endTime *time.Time) ([]*storage.SMS, error) {
 	ret := _m.Called(networkID, pks, imsis, onlyWaiting, startTime, endTime)
@@ -110,6 +233,20 @@ func (_m *SMSStorage) Init() error {
 	return r0
 }
 
+// PostponeSMSNotification provides a mock function with given fields: pk, nextAttempt
+func (_m *SMSStorage) PostponeSMSNotification(pk string, nextAttempt time.Time) error {
+	ret := _m.Called(pk, nextAttempt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
+		r0 = rf(pk, nextAttempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReportDelivery provides a mock function with given fields: networkID, deliveredMessages, failedMessages
 func (_m *SMSStorage) ReportDelivery(networkID string, deliveredMessages map[string][]byte, failedMessages map[string][]storage.SMSFailureReport) error {
 	ret := _m.Called(networkID, deliveredMessages, failedMessages)
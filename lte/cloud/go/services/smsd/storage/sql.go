@@ -44,11 +44,31 @@ const (
 	errorCol     = "error_message"
 	attemptsCol  = "num_attempts"
 	// TODO: save time last sent (delivery response received from AGW)?
+	expiresCol     = "expires_sec"
+	expiredCol     = "is_expired"
+	callbackCol    = "callback_url"
+	lastAttemptCol = "last_attempt_sec"
+	nextAttemptCol = "next_attempt_sec"
 
 	refsTable     = "smsd_refs"
 	refSmsCol     = "sms_id"
 	refCol        = "ref_num"
 	refCreatedCol = "ref_created_sec"
+
+	notificationsTable = "smsd_notifications"
+	notifSmsCol        = "sms_id"
+	notifAttemptsCol   = "num_attempts"
+	notifNextCol       = "next_attempt_sec"
+
+	moTable       = "smsd_mo_messages"
+	moImsiIdx     = "smsd_mo_imsi_idx"
+	moDestCol     = "destination"
+	moReceivedCol = "time_received_sec"
+
+	moSegmentsTable  = "smsd_mo_segments"
+	moConcatRefCol   = "concat_ref"
+	moSeqNoCol       = "seq_no"
+	moNumSegmentsCol = "num_segments"
 )
 
 const (
@@ -56,9 +76,34 @@ const (
 	maxRetries = 3
 
 	defaultTimeoutThreshold = 6 * time.Minute
+
+	// Backoff before retrying a failed delivery, doubled on each attempt
+	baseRetryBackoff = time.Minute
+	maxRetryBackoff  = 30 * time.Minute
+
+	// How long we keep the segments of an incomplete mobile originated
+	// message
+	moSegmentTimeout = time.Hour
+
+	// Max number of messages expired per transaction
+	expireBatchSize = 500
 )
 
-var allCols = []string{pkCol, deliveredCol, imsiCol, sourceCol, messageCol, createdCol, errorCol, attemptsCol, refCol, refCreatedCol}
+var allCols = []string{
+	pkCol, deliveredCol, imsiCol, sourceCol, messageCol, createdCol, errorCol, attemptsCol,
+	expiresCol, expiredCol, callbackCol, lastAttemptCol, refCol, refCreatedCol,
+}
+
+// Columns added to the messages table after its creation, with their type
+var addedCols = [][2]string{
+	{expiresCol, "INTEGER"},
+	{expiredCol, "BOOLEAN NOT NULL DEFAULT FALSE"},
+	{callbackCol, "TEXT"},
+	{lastAttemptCol, "INTEGER"},
+	{nextAttemptCol, "INTEGER"},
+}
+
+var allMOCols = []string{pkCol, imsiCol, moDestCol, messageCol, moReceivedCol}
 
 func NewSQLSMSStorage(db *sql.DB, sqlBuilder sqorc.StatementBuilder, counter SMSReferenceCounter, idGenerator storage.IDGenerator) SMSStorage {
 	return &sqlSMSStorage{
@@ -104,6 +149,11 @@ func (s *sqlSMSStorage) Init() (err error) {
 		Column(createdCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
 		Column(errorCol).Type(sqorc.ColumnTypeText).EndColumn().
 		Column(attemptsCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
+		Column(expiresCol).Type(sqorc.ColumnTypeInt).EndColumn().
+		Column(expiredCol).Type(sqorc.ColumnTypeBool).NotNull().Default(false).EndColumn().
+		Column(callbackCol).Type(sqorc.ColumnTypeText).EndColumn().
+		Column(lastAttemptCol).Type(sqorc.ColumnTypeInt).EndColumn().
+		Column(nextAttemptCol).Type(sqorc.ColumnTypeInt).EndColumn().
 		RunWith(tx).
 		Exec()
 	if err != nil {
@@ -111,6 +161,20 @@ func (s *sqlSMSStorage) Init() (err error) {
 		return
 	}
 
+	// Add the columns which were introduced after the messages table was
+	// first created, to upgrade existing tables.
+	// special case sqlite3 because ADD COLUMN IF NOT EXISTS is not supported
+	// and we only run sqlite3 for unit tests
+	if storage.SQLDriver != "sqlite3" {
+		for _, col := range addedCols {
+			_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", smsTable, col[0], col[1]))
+			if err != nil {
+				err = errors.Wrapf(err, "failed to add %s column to messages table", col[0])
+				return
+			}
+		}
+	}
+
 	// index on (nid, imsi)
 	_, err = s.builder.CreateIndex(imsiIdx).
 		IfNotExists().
@@ -137,6 +201,64 @@ func (s *sqlSMSStorage) Init() (err error) {
 		return
 	}
 
+	_, err = s.builder.CreateTable(notificationsTable).
+		IfNotExists().
+		Column(notifSmsCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(notifAttemptsCol).Type(sqorc.ColumnTypeInt).NotNull().Default(0).EndColumn().
+		Column(notifNextCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		ForeignKey(smsTable, map[string]string{notifSmsCol: pkCol}, sqorc.ColumnOnDeleteCascade).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create sms notification table")
+		return
+	}
+
+	_, err = s.builder.CreateTable(moTable).
+		IfNotExists().
+		Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(pkCol).Type(sqorc.ColumnTypeText).PrimaryKey().EndColumn().
+		Column(imsiCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(moDestCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(messageCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(moReceivedCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create mo sms table")
+		return
+	}
+
+	// index on (nid, imsi)
+	_, err = s.builder.CreateIndex(moImsiIdx).
+		IfNotExists().
+		On(moTable).
+		Columns(nidCol, imsiCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create mo sms imsi index")
+		return
+	}
+
+	_, err = s.builder.CreateTable(moSegmentsTable).
+		IfNotExists().
+		Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(imsiCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(moConcatRefCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(moSeqNoCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(moNumSegmentsCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		Column(moDestCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(messageCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+		Column(moReceivedCol).Type(sqorc.ColumnTypeInt).NotNull().EndColumn().
+		PrimaryKey(nidCol, imsiCol, moConcatRefCol, moSeqNoCol).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		err = errors.Wrap(err, "failed to create mo sms segment table")
+		return
+	}
+
 	return
 }
 
@@ -159,7 +281,10 @@ func (s *sqlSMSStorage) GetSMSs(networkID string, pks []string, imsis []string,
 			builder = builder.Where(sq.Eq{getFQColName(smsTable, imsiCol): imsis})
 		}
 		if onlyWaiting {
-			builder = builder.Where(sq.Eq{getFQColName(smsTable, deliveredCol): false})
+			builder = builder.Where(sq.Eq{
+				getFQColName(smsTable, deliveredCol): false,
+				getFQColName(smsTable, expiredCol):   false,
+			})
 		}
 		if startTime != nil {
 			builder = builder.Where(sq.Gt{getFQColName(smsTable, createdCol): startTime.Unix()})
@@ -223,12 +348,12 @@ func (s *sqlSMSStorage) GetSMSsToDeliver(networkID string, imsis []string, timeo
 			return nil, errors.Wrap(err, "failed to create timestamp")
 		}
 
-		err = garbageCollectExpiredRefs(tx, s.builder, networkID, imsis, timeoutSecs)
+		err = garbageCollectExpiredRefs(tx, s.builder, networkID, imsis, timeoutSecs, timeCreated)
 		if err != nil {
 			return nil, err
 		}
 
-		smsByImsi, err := loadMessagesToSend(tx, s.builder, networkID, imsis, timeoutSecs)
+		smsByImsi, err := loadMessagesToSend(tx, s.builder, networkID, imsis, timeoutSecs, timeCreated)
 		if err != nil {
 			return nil, err
 		}
@@ -280,10 +405,15 @@ func (s *sqlSMSStorage) CreateSMS(networkID string, sms MutableSMS) (string, err
 	txFn := func(tx *sql.Tx) (interface{}, error) {
 		pk := s.idGenerator.New()
 		timeCreated := clock.Now().Unix()
+		expires := sql.NullInt64{}
+		if sms.ValidityPeriodSec > 0 {
+			expires = sql.NullInt64{Valid: true, Int64: timeCreated + int64(sms.ValidityPeriodSec)}
+		}
+		callback := sql.NullString{Valid: sms.CallbackUrl != "", String: sms.CallbackUrl}
 
 		_, err := s.builder.Insert(smsTable).
-			Columns(pkCol, nidCol, imsiCol, sourceCol, messageCol, createdCol).
-			Values(pk, networkID, sms.Imsi, sms.SourceMsisdn, sms.Message, timeCreated).
+			Columns(pkCol, nidCol, imsiCol, sourceCol, messageCol, createdCol, expiresCol, callbackCol).
+			Values(pk, networkID, sms.Imsi, sms.SourceMsisdn, sms.Message, timeCreated, expires, callback).
 			RunWith(tx).
 			Exec()
 		if err != nil {
@@ -335,12 +465,13 @@ func (s *sqlSMSStorage) ReportDelivery(networkID string, deliveredMessages map[s
 			return nil, err
 		}
 
-		err = markMessagesAsDelivered(tx, s.builder, networkID, deliveredMessages, pksByRef)
+		now := clock.Now().Unix()
+		err = markMessagesAsDelivered(tx, s.builder, networkID, deliveredMessages, pksByRef, now)
 		if err != nil {
 			return nil, err
 		}
 
-		err = processFailedMessages(tx, s.builder, networkID, failedMessages, pksByRef)
+		err = processFailedMessages(tx, s.builder, networkID, failedMessages, pksByRef, now)
 		if err != nil {
 			return nil, err
 		}
@@ -351,3 +482,326 @@ func (s *sqlSMSStorage) ReportDelivery(networkID string, deliveredMessages map[s
 	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
 	return err
 }
+
+func (s *sqlSMSStorage) ExpireSMSs() error {
+	now := clock.Now().Unix()
+	// Expire in batches to keep the transactions (and the number of bound
+	// query arguments) small
+	for {
+		txFn := func(tx *sql.Tx) (interface{}, error) {
+			return expireMessages(tx, s.builder, now)
+		}
+		ret, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+		if err != nil {
+			return err
+		}
+		if ret.(int) < expireBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *sqlSMSStorage) GetSMSNotifications(limit uint64) ([]*SMSNotification, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		/*
+			SELECT smsd_notifications.sms_id, smsd_messages.network_id, smsd_notifications.num_attempts
+			FROM smsd_notifications
+			INNER JOIN smsd_messages ON smsd_notifications.sms_id = smsd_messages.pk
+			WHERE smsd_notifications.next_attempt_sec <= {now}
+			ORDER BY smsd_notifications.next_attempt_sec
+			LIMIT {limit}
+		*/
+		rows, err := s.builder.Select(getFQColName(notificationsTable, notifSmsCol), getFQColName(smsTable, nidCol), getFQColName(notificationsTable, notifAttemptsCol)).
+			From(notificationsTable).
+			JoinClause(fmt.Sprintf("INNER JOIN %s ON %s=%s", smsTable, getFQColName(notificationsTable, notifSmsCol), getFQColName(smsTable, pkCol))).
+			Where(sq.LtOrEq{getFQColName(notificationsTable, notifNextCol): clock.Now().Unix()}).
+			OrderBy(getFQColName(notificationsTable, notifNextCol)).
+			Limit(limit).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load SMS notifications")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "GetSMSNotifications")
+
+		var ret []*SMSNotification
+		var pks []string
+		for rows.Next() {
+			var pk, networkID string
+			var attempts int64
+			err = rows.Scan(&pk, &networkID, &attempts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to scan SMS notification")
+			}
+			ret = append(ret, &SMSNotification{NetworkID: networkID, SMS: &SMS{Pk: pk}, Attempts: uint32(attempts)})
+			pks = append(pks, pk)
+		}
+		err = rows.Err()
+		if err != nil {
+			return nil, errors.Wrap(err, "sql rows err")
+		}
+		if funk.IsEmpty(pks) {
+			return ret, nil
+		}
+
+		msgRows, err := s.builder.Select(allCols...).
+			From(smsTable).
+			LeftJoin(fmt.Sprintf("%s ON %s=%s", refsTable, getFQColName(refsTable, refSmsCol), getFQColName(smsTable, pkCol))).
+			Where(sq.Eq{getFQColName(smsTable, pkCol): pks}).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load notified messages")
+		}
+		defer sqorc.CloseRowsLogOnError(msgRows, "GetSMSNotifications")
+		smsByImsi, err := scanMessages(msgRows)
+		if err != nil {
+			return nil, err
+		}
+		smsByPk := tSmsByPk{}
+		for _, msgs := range smsByImsi {
+			for pk, msg := range msgs {
+				smsByPk[pk] = msg
+			}
+		}
+		for _, notif := range ret {
+			notif.SMS = smsByPk[notif.SMS.Pk]
+		}
+		return ret, nil
+	}
+
+	ret, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	if err != nil {
+		return nil, err
+	}
+	return ret.([]*SMSNotification), nil
+}
+
+func (s *sqlSMSStorage) DeleteSMSNotification(pk string) error {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		_, err := s.builder.Delete(notificationsTable).
+			Where(sq.Eq{notifSmsCol: pk}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete SMS notification")
+		}
+		return nil, nil
+	}
+
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
+
+func (s *sqlSMSStorage) DeferSMSNotification(pk string, nextAttempt time.Time) error {
+	return s.rescheduleSMSNotification(pk, nextAttempt, true)
+}
+
+func (s *sqlSMSStorage) PostponeSMSNotification(pk string, nextAttempt time.Time) error {
+	return s.rescheduleSMSNotification(pk, nextAttempt, false)
+}
+
+func (s *sqlSMSStorage) rescheduleSMSNotification(pk string, nextAttempt time.Time, failed bool) error {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		update := s.builder.Update(notificationsTable).
+			Set(notifNextCol, nextAttempt.Unix()).
+			Where(sq.Eq{notifSmsCol: pk})
+		if failed {
+			update = update.Set(notifAttemptsCol, sq.Expr(fmt.Sprintf("%s+1", notifAttemptsCol)))
+		}
+		_, err := update.RunWith(tx).Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to reschedule SMS notification")
+		}
+		return nil, nil
+	}
+
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
+
+func (s *sqlSMSStorage) CreateMOSMS(networkID string, segment MOSMSSegment) (string, error) {
+	if segment.Segments <= 1 {
+		return s.createMOSMS(networkID, segment.Imsi, segment.Destination, segment.Message)
+	}
+
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		now := clock.Now().Unix()
+
+		// Drop the segments of messages which were never completed
+		_, err := s.builder.Delete(moSegmentsTable).
+			Where(sq.And{
+				sq.Eq{nidCol: networkID, imsiCol: segment.Imsi},
+				sq.Lt{moReceivedCol: clock.Now().Add(-moSegmentTimeout).Unix()},
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to clear stale MO SMS segments")
+		}
+
+		_, err = s.builder.Insert(moSegmentsTable).
+			Columns(nidCol, imsiCol, moConcatRefCol, moSeqNoCol, moNumSegmentsCol, moDestCol, messageCol, moReceivedCol).
+			Values(networkID, segment.Imsi, segment.ConcatRef, segment.SeqNo, segment.Segments, segment.Destination, segment.Message, now).
+			OnConflict(
+				[]sqorc.UpsertValue{
+					{Column: moNumSegmentsCol, Value: segment.Segments},
+					{Column: moDestCol, Value: segment.Destination},
+					{Column: messageCol, Value: segment.Message},
+					{Column: moReceivedCol, Value: now},
+				},
+				nidCol, imsiCol, moConcatRefCol, moSeqNoCol,
+			).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create MO SMS segment")
+		}
+
+		segmentFilter := sq.Eq{nidCol: networkID, imsiCol: segment.Imsi, moConcatRefCol: segment.ConcatRef}
+		rows, err := s.builder.Select(moSeqNoCol, moNumSegmentsCol, messageCol).
+			From(moSegmentsTable).
+			Where(segmentFilter).
+			OrderBy(moSeqNoCol).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load MO SMS segments")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "CreateMOSMS")
+
+		message := ""
+		numReceived := 0
+		for rows.Next() {
+			var seqNo, numSegments int64
+			var part string
+			err = rows.Scan(&seqNo, &numSegments, &part)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to scan MO SMS segment")
+			}
+			// Segments of an earlier message with the same reference
+			if int(numSegments) != segment.Segments {
+				continue
+			}
+			message += part
+			numReceived++
+		}
+		err = rows.Err()
+		if err != nil {
+			return nil, errors.Wrap(err, "sql rows err")
+		}
+		if numReceived < segment.Segments {
+			return "", nil
+		}
+
+		_, err = s.builder.Delete(moSegmentsTable).
+			Where(segmentFilter).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to clear MO SMS segments")
+		}
+		return s.insertMOSMS(tx, networkID, segment.Imsi, segment.Destination, message, now)
+	}
+
+	iPK, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	if err != nil {
+		return "", err
+	}
+	return iPK.(string), nil
+}
+
+func (s *sqlSMSStorage) createMOSMS(networkID, imsi, destination, message string) (string, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		return s.insertMOSMS(tx, networkID, imsi, destination, message, clock.Now().Unix())
+	}
+
+	iPK, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	if err != nil {
+		return "", err
+	}
+	return iPK.(string), nil
+}
+
+func (s *sqlSMSStorage) insertMOSMS(tx *sql.Tx, networkID, imsi, destination, message string, timeReceived int64) (string, error) {
+	pk := s.idGenerator.New()
+	_, err := s.builder.Insert(moTable).
+		Columns(pkCol, nidCol, imsiCol, moDestCol, messageCol, moReceivedCol).
+		Values(pk, networkID, imsi, destination, message, timeReceived).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create MO SMS")
+	}
+	return pk, nil
+}
+
+func (s *sqlSMSStorage) GetMOSMSs(networkID string, pks []string, imsis []string) ([]*MOSMS, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		builder := s.builder.Select(allMOCols...).
+			From(moTable).
+			Where(sq.Eq{nidCol: networkID}).
+			OrderBy(pkCol).
+			RunWith(tx)
+		if !funk.IsEmpty(pks) {
+			builder = builder.Where(sq.Eq{pkCol: pks})
+		}
+		if !funk.IsEmpty(imsis) {
+			builder = builder.Where(sq.Eq{imsiCol: imsis})
+		}
+
+		rows, err := builder.Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load MO messages")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "GetMOSMSs")
+
+		ret := []*MOSMS{}
+		for rows.Next() {
+			var pk, imsi, destination, message string
+			var timeReceived int64
+			err = rows.Scan(&pk, &imsi, &destination, &message, &timeReceived)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to scan MO SMS row")
+			}
+			receivedTs, err := ptypes.TimestampProto(time.Unix(timeReceived, 0))
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not validate received time for MO SMS %s", pk)
+			}
+			ret = append(ret, &MOSMS{
+				Pk:           pk,
+				Imsi:         imsi,
+				Destination:  destination,
+				Message:      message,
+				ReceivedTime: receivedTs,
+			})
+		}
+		err = rows.Err()
+		if err != nil {
+			return nil, errors.Wrap(err, "sql rows err")
+		}
+		return ret, nil
+	}
+
+	ret, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	if err != nil {
+		return nil, err
+	}
+	return ret.([]*MOSMS), nil
+}
+
+func (s *sqlSMSStorage) DeleteMOSMSs(networkID string, pks []string) error {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		_, err := s.builder.Delete(moTable).
+			Where(sq.Eq{nidCol: networkID, pkCol: pks}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete MO SMSs")
+		}
+		return nil, nil
+	}
+
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
@@ -30,16 +30,14 @@ import (
 
 type tSmsByPk = map[string]*SMS
 
-func garbageCollectExpiredRefs(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, imsis []string, timeoutSecs int64) error {
+func garbageCollectExpiredRefs(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, imsis []string, timeoutSecs int64, now int64) error {
 	/*
-		DELETE FROM smsd_refs
-		WHERE sms_id IN (
-			SELECT sms_id FROM smsd_refs
-			INNER JOIN smsd_messages on smsd_refs.sms_id = smsd_messages.pk
-			WHERE network_id = {nid} AND imsi IN {imsis} AND ref_created_sec < {timeout} AND num_attempts >= {limit}
-		)
+		SELECT DISTINCT sms_id FROM smsd_refs
+		INNER JOIN smsd_messages on smsd_refs.sms_id = smsd_messages.pk
+		WHERE network_id = {nid} AND imsi IN {imsis} AND ref_created_sec < {timeout} AND num_attempts >= {limit}
 	*/
-	subSelect, selectArgs, _ := builder.Select(refSmsCol).
+	rows, err := builder.Select(getFQColName(refsTable, refSmsCol)).
+		Distinct().
 		From(refsTable).
 		JoinClause(fmt.Sprintf("INNER JOIN %s ON %s=%s", smsTable, getFQColName(refsTable, refSmsCol), getFQColName(smsTable, pkCol))).
 		Where(sq.And{
@@ -50,9 +48,29 @@ func garbageCollectExpiredRefs(tx *sql.Tx, builder sqorc.StatementBuilder, netwo
 			sq.Lt{getFQColName(refsTable, refCreatedCol): timeoutSecs},
 			sq.GtOrEq{getFQColName(smsTable, attemptsCol): maxRetries},
 		}).
-		ToSql()
-	_, err := builder.Delete(refsTable).
-		Where(fmt.Sprintf("%s IN (%s)", refSmsCol, subSelect), selectArgs...).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return errors.Wrap(err, "failed to load old SMS refs")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "garbageCollectExpiredRefs")
+	pks, err := scanPks(rows)
+	if err != nil {
+		return err
+	}
+	if funk.IsEmpty(pks) {
+		return nil
+	}
+
+	// These messages will never be sent again, so they've failed for good
+	err = enqueueNotifications(tx, builder, pks, now)
+	if err != nil {
+		return err
+	}
+
+	// DELETE FROM smsd_refs WHERE sms_id IN {pks}
+	_, err = builder.Delete(refsTable).
+		Where(sq.Eq{refSmsCol: pks}).
 		RunWith(tx).
 		Exec()
 	if err != nil {
@@ -61,7 +79,7 @@ func garbageCollectExpiredRefs(tx *sql.Tx, builder sqorc.StatementBuilder, netwo
 	return nil
 }
 
-func loadMessagesToSend(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, imsis []string, timeoutSecs int64) (map[string]tSmsByPk, error) {
+func loadMessagesToSend(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, imsis []string, timeoutSecs int64, now int64) (map[string]tSmsByPk, error) {
 	/*
 		SELECT * FROM smsd_messages
 		LEFT OUTER JOIN smsd_refs ON smsd_messages.pk = smsd_refs.sms_id
@@ -74,6 +92,12 @@ func loadMessagesToSend(tx *sql.Tx, builder sqorc.StatementBuilder, networkID st
 			AND
 			NOT smsd_messages.is_delivered
 			AND
+			NOT smsd_messages.is_expired
+			AND
+			(smsd_messages.expires_sec IS NULL OR smsd_messages.expires_sec > {now})
+			AND
+			(smsd_messages.next_attempt_sec IS NULL OR smsd_messages.next_attempt_sec <= {now})
+			AND
 			smsd_messages.num_attempts < 3
 	*/
 	rows, err := builder.Select(allCols...).
@@ -89,7 +113,18 @@ func loadMessagesToSend(tx *sql.Tx, builder sqorc.StatementBuilder, networkID st
 					sq.Eq{getFQColName(refsTable, refSmsCol): nil},
 					sq.Lt{getFQColName(refsTable, refCreatedCol): timeoutSecs},
 				},
-				sq.Eq{getFQColName(smsTable, deliveredCol): false},
+				sq.Eq{
+					getFQColName(smsTable, deliveredCol): false,
+					getFQColName(smsTable, expiredCol):   false,
+				},
+				sq.Or{
+					sq.Eq{getFQColName(smsTable, expiresCol): nil},
+					sq.Gt{getFQColName(smsTable, expiresCol): now},
+				},
+				sq.Or{
+					sq.Eq{getFQColName(smsTable, nextAttemptCol): nil},
+					sq.LtOrEq{getFQColName(smsTable, nextAttemptCol): now},
+				},
 				sq.Lt{getFQColName(smsTable, attemptsCol): maxRetries},
 			},
 		).
@@ -208,10 +243,11 @@ func persistNewRefNums(tx *sql.Tx, builder sqorc.StatementBuilder, refsByPk map[
 		}
 	}
 
-	// UPDATE smsd_messages SET num_attempts = num_attempts + 1 WHERE pk IN {pks}
+	// UPDATE smsd_messages SET num_attempts = num_attempts + 1, last_attempt_sec = {now} WHERE pk IN {pks}
 	allPks := funk.Keys(refsByPk).([]string)
 	_, err := builder.Update(smsTable).
 		Set(attemptsCol, sq.Expr(fmt.Sprintf("%s+1", attemptsCol))).
+		Set(lastAttemptCol, timeCreated).
 		Where(sq.Eq{pkCol: allPks}).
 		RunWith(sc).
 		Exec()
@@ -267,7 +303,7 @@ func loadPksByRefs(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string,
 	return ret, nil
 }
 
-func markMessagesAsDelivered(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, deliveredMessages map[string][]SMSRef, pksByRef map[imsiAndRef]string) error {
+func markMessagesAsDelivered(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, deliveredMessages map[string][]SMSRef, pksByRef map[imsiAndRef]string, now int64) error {
 	// For delivered messages, mark them as such in the table and delete
 	// all the refs that have been allocated for them.
 	var deliveredPks []string
@@ -292,6 +328,11 @@ func markMessagesAsDelivered(tx *sql.Tx, builder sqorc.StatementBuilder, network
 		return errors.Wrap(err, "failed to mark SMSs as delivered")
 	}
 
+	err = enqueueNotifications(tx, builder, deliveredPks, now)
+	if err != nil {
+		return err
+	}
+
 	// Subquery to limit operation to this network
 	/*
 		DELETE FROM smsd_refs
@@ -314,12 +355,13 @@ func markMessagesAsDelivered(tx *sql.Tx, builder sqorc.StatementBuilder, network
 	return nil
 }
 
-func processFailedMessages(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, failedMessages map[string][]SMSFailureReport, pksByRef map[imsiAndRef]string) error {
+func processFailedMessages(tx *sql.Tx, builder sqorc.StatementBuilder, networkID string, failedMessages map[string][]SMSFailureReport, pksByRef map[imsiAndRef]string, now int64) error {
 	sc := sq.NewStmtCache(tx)
 	defer sqorc.ClearStatementCacheLogOnError(sc, "processFailedMessages")
 
-	// For failed messages, persist the error message and delete the refs if
-	// the message's attempt count is over the retry limit
+	// For failed messages, persist the error message, schedule the next
+	// attempt and delete the refs so the message can be sent again once the
+	// backoff has passed
 	var failedPks []string
 	for imsi, failureReport := range failedMessages {
 		for _, report := range failureReport {
@@ -330,9 +372,15 @@ func processFailedMessages(tx *sql.Tx, builder sqorc.StatementBuilder, networkID
 			// track this to do the ref deletion later
 			failedPks = append(failedPks, pk)
 
-			// Set error message
+			// Set error message and next attempt time
+			/*
+				UPDATE smsd_messages
+				SET error_message = {msg}, next_attempt_sec = {now} + CASE num_attempts WHEN 1 THEN 60 ... END
+				WHERE network_id = {nid} AND pk = {pk}
+			*/
 			_, err := builder.Update(smsTable).
 				Set(errorCol, sql.NullString{Valid: true, String: report.ErrorMessage}).
+				Set(nextAttemptCol, sq.Expr(getNextAttemptExpr(now))).
 				Where(sq.Eq{nidCol: networkID, pkCol: pk}).
 				RunWith(sc).
 				Exec()
@@ -342,15 +390,13 @@ func processFailedMessages(tx *sql.Tx, builder sqorc.StatementBuilder, networkID
 		}
 	}
 	failedPks = funk.UniqString(failedPks)
+	if funk.IsEmpty(failedPks) {
+		return nil
+	}
 
-	/*
-		DELETE FROM smsd_refs
-		WHERE sms_id IN (
-			SELECT pk FROM smsd_messages
-			WHERE network_id = {nid} AND pk IN {pks} AND num_attempts >= 3
-		)
-	*/
-	subSelect, selectArgs, _ := builder.Select(pkCol).
+	// Messages over the retry limit have failed for good
+	// SELECT pk FROM smsd_messages WHERE network_id = {nid} AND pk IN {pks} AND num_attempts >= 3
+	rows, err := builder.Select(pkCol).
 		From(smsTable).
 		Where(
 			sq.And{
@@ -358,28 +404,184 @@ func processFailedMessages(tx *sql.Tx, builder sqorc.StatementBuilder, networkID
 				sq.GtOrEq{attemptsCol: maxRetries},
 			},
 		).
-		ToSql()
-	_, err := builder.Delete(refsTable).
-		Where(sq.Expr(fmt.Sprintf("%s IN (%s)", refSmsCol, subSelect), selectArgs...)).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return errors.Wrap(err, "failed to load failed messages over retry threshold")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "processFailedMessages")
+	finalPks, err := scanPks(rows)
+	if err != nil {
+		return err
+	}
+	err = enqueueNotifications(tx, builder, finalPks, now)
+	if err != nil {
+		return err
+	}
+
+	// DELETE FROM smsd_refs WHERE sms_id IN {pks}
+	_, err = builder.Delete(refsTable).
+		Where(sq.Eq{refSmsCol: failedPks}).
 		RunWith(tx).
 		Exec()
 	if err != nil {
-		return errors.Wrap(err, "failed to delete refs for failed messages over retry threshold")
+		return errors.Wrap(err, "failed to delete refs for failed messages")
+	}
+
+	return nil
+}
+
+// getRetryBackoff returns how long to wait before sending a message again
+// after its attempt-th delivery attempt failed.
+func getRetryBackoff(attempt int) time.Duration {
+	backoff := baseRetryBackoff
+	for i := 1; i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+// getNextAttemptExpr returns a SQL expression for the time of the next
+// delivery attempt of a message, based on its current attempt count.
+func getNextAttemptExpr(now int64) string {
+	expr := fmt.Sprintf("%d + CASE %s", now, attemptsCol)
+	for attempt := 1; attempt < maxRetries; attempt++ {
+		expr += fmt.Sprintf(" WHEN %d THEN %d", attempt, int64(getRetryBackoff(attempt).Seconds()))
+	}
+	return expr + fmt.Sprintf(" ELSE %d END", int64(maxRetryBackoff.Seconds()))
+}
+
+// enqueueNotifications schedules a notification for each of the messages
+// which has a callback URL.
+func enqueueNotifications(tx *sql.Tx, builder sqorc.StatementBuilder, pks []string, now int64) error {
+	if funk.IsEmpty(pks) {
+		return nil
+	}
+
+	// SELECT pk FROM smsd_messages WHERE pk IN {pks} AND callback_url IS NOT NULL AND callback_url <> ''
+	rows, err := builder.Select(pkCol).
+		From(smsTable).
+		Where(sq.And{
+			sq.Eq{pkCol: pks},
+			sq.NotEq{callbackCol: nil},
+			sq.NotEq{callbackCol: ""},
+		}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return errors.Wrap(err, "failed to load SMS callbacks")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "enqueueNotifications")
+	callbackPks, err := scanPks(rows)
+	if err != nil {
+		return err
 	}
 
+	// INSERT INTO smsd_notifications (sms_id, num_attempts, next_attempt_sec) VALUES ($1, 0, $2)
+	// ON CONFLICT (sms_id) DO UPDATE SET num_attempts = 0, next_attempt_sec = $3
+	sc := sq.NewStmtCache(tx)
+	defer sqorc.ClearStatementCacheLogOnError(sc, "enqueueNotifications")
+	for _, pk := range callbackPks {
+		_, err := builder.Insert(notificationsTable).
+			Columns(notifSmsCol, notifAttemptsCol, notifNextCol).
+			Values(pk, 0, now).
+			OnConflict(
+				[]sqorc.UpsertValue{
+					{Column: notifAttemptsCol, Value: 0},
+					{Column: notifNextCol, Value: now},
+				},
+				notifSmsCol,
+			).
+			RunWith(sc).
+			Exec()
+		if err != nil {
+			return errors.Wrap(err, "failed to create SMS notification")
+		}
+	}
 	return nil
 }
 
+// expireMessages marks up to expireBatchSize messages whose validity period
+// has passed as expired, and returns how many were expired.
+func expireMessages(tx *sql.Tx, builder sqorc.StatementBuilder, now int64) (int, error) {
+	// Messages which have used up all their attempts are only expired if
+	// they're still in-flight, otherwise they've already failed for good.
+	/*
+		SELECT DISTINCT smsd_messages.pk FROM smsd_messages
+		LEFT OUTER JOIN smsd_refs ON smsd_messages.pk = smsd_refs.sms_id
+		WHERE
+			NOT is_delivered AND NOT is_expired AND expires_sec <= {now}
+			AND (num_attempts < 3 OR smsd_refs.sms_id IS NOT NULL)
+		LIMIT {batch}
+	*/
+	rows, err := builder.Select(getFQColName(smsTable, pkCol)).
+		Distinct().
+		From(smsTable).
+		JoinClause(fmt.Sprintf("LEFT OUTER JOIN %s ON %s=%s", refsTable, getFQColName(smsTable, pkCol), getFQColName(refsTable, refSmsCol))).
+		Where(sq.And{
+			sq.Eq{
+				getFQColName(smsTable, deliveredCol): false,
+				getFQColName(smsTable, expiredCol):   false,
+			},
+			sq.LtOrEq{getFQColName(smsTable, expiresCol): now},
+			sq.Or{
+				sq.Lt{getFQColName(smsTable, attemptsCol): maxRetries},
+				sq.NotEq{getFQColName(refsTable, refSmsCol): nil},
+			},
+		}).
+		Limit(expireBatchSize).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to load expired SMSs")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "expireMessages")
+	pks, err := scanPks(rows)
+	if err != nil {
+		return 0, err
+	}
+	if funk.IsEmpty(pks) {
+		return 0, nil
+	}
+
+	_, err = builder.Update(smsTable).
+		Set(expiredCol, true).
+		Where(sq.Eq{pkCol: pks}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to mark SMSs as expired")
+	}
+	_, err = builder.Delete(refsTable).
+		Where(sq.Eq{refSmsCol: pks}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to clear refs for expired messages")
+	}
+	err = enqueueNotifications(tx, builder, pks, now)
+	if err != nil {
+		return 0, err
+	}
+	return len(pks), nil
+}
+
 func scanMessages(rows *sql.Rows) (map[string]tSmsByPk, error) {
 	smsByImsi := map[string]tSmsByPk{}
 	for rows.Next() {
 		var pk, imsi, srcMsisdn, message string
-		var errorMessage sql.NullString
-		var delivered bool
+		var errorMessage, callbackURL sql.NullString
+		var delivered, expired bool
 		var timeCreated, numAttempts int64
-		var refNum, refCreated sql.NullInt64
+		var expires, lastAttempt, refNum, refCreated sql.NullInt64
 
-		err := rows.Scan(&pk, &delivered, &imsi, &srcMsisdn, &message, &timeCreated, &errorMessage, &numAttempts, &refNum, &refCreated)
+		err := rows.Scan(
+			&pk, &delivered, &imsi, &srcMsisdn, &message, &timeCreated, &errorMessage, &numAttempts,
+			&expires, &expired, &callbackURL, &lastAttempt, &refNum, &refCreated,
+		)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to scan sms row")
 		}
@@ -389,18 +591,33 @@ func scanMessages(rows *sql.Rows) (map[string]tSmsByPk, error) {
 			return nil, errors.Wrapf(err, "could not validate created time for sms %s", pk)
 		}
 
+		// Messages created before the last attempt time was persisted only
+		// have the creation time of their refs
+		if !lastAttempt.Valid {
+			lastAttempt = refCreated
+		}
 		var attemptedTs *timestamp.Timestamp
-		if refCreated.Valid {
-			attemptedTs, err = ptypes.TimestampProto(time.Unix(refCreated.Int64, 0))
+		if lastAttempt.Valid {
+			attemptedTs, err = ptypes.TimestampProto(time.Unix(lastAttempt.Int64, 0))
 			if err != nil {
 				return nil, errors.Wrapf(err, "could not validate attempted time for sms %s", pk)
 			}
 		}
 
+		var expiresTs *timestamp.Timestamp
+		if expires.Valid {
+			expiresTs, err = ptypes.TimestampProto(time.Unix(expires.Int64, 0))
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not validate expiry time for sms %s", pk)
+			}
+		}
+
 		status := MessageStatus_WAITING
 		switch {
 		case delivered:
 			status = MessageStatus_DELIVERED
+		case expired:
+			status = MessageStatus_EXPIRED
 		case numAttempts >= maxRetries:
 			status = MessageStatus_FAILED
 		}
@@ -434,12 +651,31 @@ func scanMessages(rows *sql.Rows) (map[string]tSmsByPk, error) {
 				AttemptCount:  uint32(numAttempts),
 				DeliveryError: errorMessage.String,
 				RefNums:       refs,
+				ExpiresTime:   expiresTs,
+				CallbackUrl:   callbackURL.String,
 			}
 		}
 	}
 	return smsByImsi, nil
 }
 
+func scanPks(rows *sql.Rows) ([]string, error) {
+	var ret []string
+	for rows.Next() {
+		var pk string
+		err := rows.Scan(&pk)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan sms pk")
+		}
+		ret = append(ret, pk)
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, errors.Wrap(err, "sql rows err")
+	}
+	return ret, nil
+}
+
 // returns masks where true at index i means that ref#i has been assigned
 func scanRefs(rows *sql.Rows) (map[string]*[256]bool, error) {
 	ret := map[string]*[256]bool{}
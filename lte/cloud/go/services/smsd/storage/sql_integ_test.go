@@ -164,8 +164,8 @@ func TestSQLSMSStorage_Integration(t *testing.T) {
 	}
 	assert.Equal(t, actualMessages, expectedAllMessages)
 
-	// Report that delivery failed, should get it back again with the same ref
-	// number again but attempt time and count advanced
+	// Report that delivery failed, the ref should be released until the
+	// retry backoff has passed
 	// Also include an unknown message
 	err = store.ReportDelivery(
 		"n1",
@@ -178,19 +178,25 @@ func TestSQLSMSStorage_Integration(t *testing.T) {
 	actualMessages, err = store.GetSMSs("n1", nil, nil, false, nil, nil)
 	assert.NoError(t, err)
 	expectedAllMessages[0].DeliveryError = "foobar"
+	expectedAllMessages[0].RefNums = nil
 	assert.Equal(t, expectedAllMessages, actualMessages)
 
-	// We need to timeout the message to try and re-send it, because we don't
-	// delete the ref for a failed message that is not at the retry limit
-	// This is on purpose because otherwise the select query for this would
-	// have a crazy WHERE clause.
-	frozenClock += 1000
+	// The message shouldn't be re-sent before the backoff (2 minutes after
+	// the second attempt) has passed
+	frozenClock += 60
+	clock.SetAndFreezeClock(t, time.Unix(frozenClock, 0))
+	actualMessages, err = store.GetSMSsToDeliver("n1", []string{"IMSI1"}, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, actualMessages)
+
+	frozenClock += 940
 	clock.SetAndFreezeClock(t, time.Unix(frozenClock, 0))
 
 	actualMessages, err = store.GetSMSsToDeliver("n1", []string{"IMSI1"}, 0)
 	assert.NoError(t, err)
 	expectedMessages[0].LastDeliveryAttemptTime = timestampProto(t, frozenClock)
 	expectedMessages[0].AttemptCount = 3
+	expectedMessages[0].RefNums = []byte{0}
 	assert.Equal(t, actualMessages, expectedMessages)
 
 	// Mark this message as failed delivery again, time it out, and we should
@@ -211,16 +217,15 @@ func TestSQLSMSStorage_Integration(t *testing.T) {
 	actualMessages, err = store.GetSMSs("n1", nil, nil, false, nil, nil)
 	assert.NoError(t, err)
 	expectedAllMessages[0] = &storage.SMS{
-		Pk:            "1",
-		Status:        storage.MessageStatus_FAILED,
-		Imsi:          "IMSI1",
-		SourceMsisdn:  "123",
-		Message:       "hello world",
-		CreatedTime:   timestampProto(t, 1000),
-		AttemptCount:  3,
-		DeliveryError: "barbaz",
-		// Note that LastDeliveryAttemptTime is unfilled because the ref is
-		// gone and the ref row is where we save that timestamp.
+		Pk:                      "1",
+		Status:                  storage.MessageStatus_FAILED,
+		Imsi:                    "IMSI1",
+		SourceMsisdn:            "123",
+		Message:                 "hello world",
+		CreatedTime:             timestampProto(t, 1000),
+		LastDeliveryAttemptTime: timestampProto(t, 12100),
+		AttemptCount:            3,
+		DeliveryError:           "barbaz",
 	}
 	assert.Equal(t, expectedAllMessages, actualMessages)
 
@@ -373,41 +378,45 @@ func TestSQLSMSStorage_Integration(t *testing.T) {
 	assert.NoError(t, err)
 	expectedAllMessages = []*storage.SMS{
 		{
-			Pk:            "1",
-			Status:        storage.MessageStatus_FAILED,
-			Imsi:          "IMSI1",
-			SourceMsisdn:  "123",
-			Message:       "hello world",
-			CreatedTime:   timestampProto(t, 1000),
-			AttemptCount:  3,
-			DeliveryError: "barbaz",
+			Pk:                      "1",
+			Status:                  storage.MessageStatus_FAILED,
+			Imsi:                    "IMSI1",
+			SourceMsisdn:            "123",
+			Message:                 "hello world",
+			CreatedTime:             timestampProto(t, 1000),
+			LastDeliveryAttemptTime: timestampProto(t, 12100),
+			AttemptCount:            3,
+			DeliveryError:           "barbaz",
 		},
 		{
-			Pk:           "2",
-			Status:       storage.MessageStatus_DELIVERED,
-			Imsi:         "IMSI2",
-			SourceMsisdn: "456",
-			Message:      "goodbye world",
-			CreatedTime:  timestampProto(t, 1000),
-			AttemptCount: 1,
+			Pk:                      "2",
+			Status:                  storage.MessageStatus_DELIVERED,
+			Imsi:                    "IMSI2",
+			SourceMsisdn:            "456",
+			Message:                 "goodbye world",
+			CreatedTime:             timestampProto(t, 1000),
+			LastDeliveryAttemptTime: timestampProto(t, 13100),
+			AttemptCount:            1,
 		},
 		{
-			Pk:           "3",
-			Status:       storage.MessageStatus_DELIVERED,
-			Imsi:         "IMSI2",
-			SourceMsisdn: "789",
-			Message:      "message 3",
-			CreatedTime:  timestampProto(t, 13100),
-			AttemptCount: 1,
+			Pk:                      "3",
+			Status:                  storage.MessageStatus_DELIVERED,
+			Imsi:                    "IMSI2",
+			SourceMsisdn:            "789",
+			Message:                 "message 3",
+			CreatedTime:             timestampProto(t, 13100),
+			LastDeliveryAttemptTime: timestampProto(t, 13100),
+			AttemptCount:            1,
 		},
 		{
-			Pk:           "4",
-			Status:       storage.MessageStatus_DELIVERED,
-			Imsi:         "IMSI3",
-			SourceMsisdn: "123",
-			Message:      "message 4",
-			CreatedTime:  timestampProto(t, 13100),
-			AttemptCount: 2,
+			Pk:                      "4",
+			Status:                  storage.MessageStatus_DELIVERED,
+			Imsi:                    "IMSI3",
+			SourceMsisdn:            "123",
+			Message:                 "message 4",
+			CreatedTime:             timestampProto(t, 13100),
+			LastDeliveryAttemptTime: timestampProto(t, 14100),
+			AttemptCount:            2,
 		},
 		{
 			Pk:                      "5",
@@ -447,6 +456,191 @@ func TestSQLSMSStorage_Integration(t *testing.T) {
 	assert.Empty(t, actualMessages)
 }
 
+func TestSQLSMSStorage_ExpiryAndNotifications(t *testing.T) {
+	store := newTestStore(t)
+
+	var frozenClock int64 = 1000
+	clock.SetAndFreezeClock(t, time.Unix(frozenClock, 0))
+	defer clock.UnfreezeClock(t)
+
+	// 1: expires, with callback
+	// 2: expires, without callback
+	// 3: gets delivered, with callback
+	// 4: never expires
+	_, err := store.CreateSMS("n1", storage.MutableSMS{Imsi: "IMSI1", SourceMsisdn: "123", Message: "m1", ValidityPeriodSec: 600, CallbackUrl: "http://cb"})
+	assert.NoError(t, err)
+	_, err = store.CreateSMS("n1", storage.MutableSMS{Imsi: "IMSI1", SourceMsisdn: "123", Message: "m2", ValidityPeriodSec: 600})
+	assert.NoError(t, err)
+	_, err = store.CreateSMS("n2", storage.MutableSMS{Imsi: "IMSI2", SourceMsisdn: "123", Message: "m3", ValidityPeriodSec: 600, CallbackUrl: "http://cb"})
+	assert.NoError(t, err)
+	_, err = store.CreateSMS("n1", storage.MutableSMS{Imsi: "IMSI3", SourceMsisdn: "123", Message: "m4"})
+	assert.NoError(t, err)
+
+	actualMessages, err := store.GetSMSsToDeliver("n2", []string{"IMSI2"}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, actualMessages, 1)
+	err = store.ReportDelivery("n2", map[string][]storage.SMSRef{"IMSI2": {0x0}}, nil)
+	assert.NoError(t, err)
+
+	notifs, err := store.GetSMSNotifications(10)
+	assert.NoError(t, err)
+	expectedM3 := &storage.SMS{
+		Pk:                      "3",
+		Status:                  storage.MessageStatus_DELIVERED,
+		Imsi:                    "IMSI2",
+		SourceMsisdn:            "123",
+		Message:                 "m3",
+		CreatedTime:             timestampProto(t, 1000),
+		LastDeliveryAttemptTime: timestampProto(t, 1000),
+		AttemptCount:            1,
+		ExpiresTime:             timestampProto(t, 1600),
+		CallbackUrl:             "http://cb",
+	}
+	assert.Equal(t, []*storage.SMSNotification{{NetworkID: "n2", SMS: expectedM3}}, notifs)
+
+	// Failed notifications are retried once they're due
+	err = store.DeferSMSNotification("3", time.Unix(1100, 0))
+	assert.NoError(t, err)
+	notifs, err = store.GetSMSNotifications(10)
+	assert.NoError(t, err)
+	assert.Empty(t, notifs)
+	// Postponed notifications don't count an attempt
+	err = store.PostponeSMSNotification("3", time.Unix(1050, 0))
+	assert.NoError(t, err)
+	notifs, err = store.GetSMSNotifications(10)
+	assert.NoError(t, err)
+	assert.Empty(t, notifs)
+
+	// Nothing has expired yet
+	err = store.ExpireSMSs()
+	assert.NoError(t, err)
+	actualMessages, err = store.GetSMSs("n1", nil, nil, true, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, actualMessages, 3)
+
+	frozenClock += 600
+	clock.SetAndFreezeClock(t, time.Unix(frozenClock, 0))
+
+	// Expired messages aren't sent anymore, even before ExpireSMSs runs
+	actualMessages, err = store.GetSMSsToDeliver("n1", []string{"IMSI1"}, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, actualMessages)
+
+	err = store.ExpireSMSs()
+	assert.NoError(t, err)
+	actualMessages, err = store.GetSMSs("n1", []string{"1", "2"}, nil, false, nil, nil)
+	assert.NoError(t, err)
+	expectedM1 := &storage.SMS{
+		Pk:           "1",
+		Status:       storage.MessageStatus_EXPIRED,
+		Imsi:         "IMSI1",
+		SourceMsisdn: "123",
+		Message:      "m1",
+		CreatedTime:  timestampProto(t, 1000),
+		ExpiresTime:  timestampProto(t, 1600),
+		CallbackUrl:  "http://cb",
+	}
+	expectedM2 := &storage.SMS{
+		Pk:           "2",
+		Status:       storage.MessageStatus_EXPIRED,
+		Imsi:         "IMSI1",
+		SourceMsisdn: "123",
+		Message:      "m2",
+		CreatedTime:  timestampProto(t, 1000),
+		ExpiresTime:  timestampProto(t, 1600),
+	}
+	assert.Equal(t, []*storage.SMS{expectedM1, expectedM2}, actualMessages)
+	actualMessages, err = store.GetSMSs("n1", nil, nil, true, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, actualMessages, 1)
+	assert.Equal(t, "4", actualMessages[0].Pk)
+
+	// Only the message with a callback gets notified
+	notifs, err = store.GetSMSNotifications(10)
+	assert.NoError(t, err)
+	expectedNotifs := []*storage.SMSNotification{
+		{NetworkID: "n2", SMS: expectedM3, Attempts: 1},
+		{NetworkID: "n1", SMS: expectedM1},
+	}
+	assert.Equal(t, expectedNotifs, notifs)
+	notifs, err = store.GetSMSNotifications(1)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedNotifs[0:1], notifs)
+
+	err = store.DeleteSMSNotification("3")
+	assert.NoError(t, err)
+	// Deleting the message deletes its notification
+	err = store.DeleteSMSs("n1", []string{"1"})
+	assert.NoError(t, err)
+	notifs, err = store.GetSMSNotifications(10)
+	assert.NoError(t, err)
+	assert.Empty(t, notifs)
+}
+
+func TestSQLSMSStorage_MOSMS(t *testing.T) {
+	store := newTestStore(t)
+
+	clock.SetAndFreezeClock(t, time.Unix(1000, 0))
+	defer clock.UnfreezeClock(t)
+
+	actual, err := store.GetMOSMSs("n1", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	pk, err := store.CreateMOSMS("n1", storage.MOSMSSegment{Imsi: "IMSI1", Destination: "123", Message: "hello", Segments: 1, SeqNo: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", pk)
+
+	// Segments of a concatenated message, received out of order. A stale
+	// segment with the same reference shouldn't be used.
+	pk, err = store.CreateMOSMS("n1", storage.MOSMSSegment{Imsi: "IMSI2", Destination: "456", Message: "stale", Segments: 2, SeqNo: 1, ConcatRef: 7})
+	assert.NoError(t, err)
+	assert.Empty(t, pk)
+	clock.SetAndFreezeClock(t, time.Unix(5000, 0))
+	pk, err = store.CreateMOSMS("n1", storage.MOSMSSegment{Imsi: "IMSI2", Destination: "456", Message: "world", Segments: 2, SeqNo: 2, ConcatRef: 7})
+	assert.NoError(t, err)
+	assert.Empty(t, pk)
+	pk, err = store.CreateMOSMS("n1", storage.MOSMSSegment{Imsi: "IMSI2", Destination: "456", Message: "hello ", Segments: 2, SeqNo: 1, ConcatRef: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, "2", pk)
+
+	expected := []*storage.MOSMS{
+		{Pk: "1", Imsi: "IMSI1", Destination: "123", Message: "hello", ReceivedTime: timestampProto(t, 1000)},
+		{Pk: "2", Imsi: "IMSI2", Destination: "456", Message: "hello world", ReceivedTime: timestampProto(t, 5000)},
+	}
+	actual, err = store.GetMOSMSs("n1", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	actual, err = store.GetMOSMSs("n1", nil, []string{"IMSI2"})
+	assert.NoError(t, err)
+	assert.Equal(t, expected[1:], actual)
+	actual, err = store.GetMOSMSs("n1", []string{"1"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[0:1], actual)
+	actual, err = store.GetMOSMSs("n2", nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, actual)
+
+	err = store.DeleteMOSMSs("n1", []string{"1"})
+	assert.NoError(t, err)
+	actual, err = store.GetMOSMSs("n1", nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[1:], actual)
+}
+
+func newTestStore(t *testing.T) storage.SMSStorage {
+	db, err := sqorc.Open("sqlite3", ":memory:?_foreign.keys=1")
+	if err != nil {
+		t.Fatalf("Could not initialize sqlite DB: %s", err)
+	}
+	store := storage.NewSQLSMSStorage(db, sqorc.GetSqlBuilder(), &mockRefCounter{numRefs: 1}, &mockIDGenerator{})
+	err = store.Init()
+	if err != nil {
+		t.Fatalf("Could not initialize smsd tables: %s", err)
+	}
+	return store
+}
+
 type mockRefCounter struct {
 	numRefs uint16
 }
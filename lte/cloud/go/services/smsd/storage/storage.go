@@ -28,6 +28,31 @@ type SMSFailureReport struct {
 	ErrorMessage string
 }
 
+// MOSMSSegment is a segment of a mobile originated message, as decoded from
+// a single SMS-SUBMIT.
+type MOSMSSegment struct {
+	Imsi        string
+	Destination string
+	Message     string
+
+	// Segments is the number of segments of the message, 1 if the message
+	// wasn't split into several segments.
+	Segments int
+	// SeqNo is the 1-based position of the segment in the message
+	SeqNo int
+	// ConcatRef identifies the message across its segments
+	ConcatRef int
+}
+
+// SMSNotification is a pending notification to the callback URL of a
+// message which reached a final status.
+type SMSNotification struct {
+	NetworkID string
+	SMS       *SMS
+	// Attempts is the number of failed attempts to send the notification
+	Attempts uint32
+}
+
 // SMSStorage is the storage interface for managing SMS messages and their
 // delivery.
 // SMS's are intended to be immutable upon creation (delete or read only).
@@ -71,7 +96,50 @@ type SMSStorage interface {
 
 	// ReportDelivery reports delivery status of a set of SMSs
 	// Map keys for both arguments are IMSIs
+	//
+	// Failed messages are retried with an exponential backoff until they
+	// reach the retry limit.
 	ReportDelivery(networkID string, deliveredMessages map[string][]SMSRef, failedMessages map[string][]SMSFailureReport) error
+
+	// ExpireSMSs marks the undelivered messages of all networks whose
+	// validity period has passed as expired. Expired messages are no longer
+	// delivered.
+	ExpireSMSs() error
+
+	// GetSMSNotifications returns up to limit notifications which are due,
+	// across all networks.
+	// A notification is created when a message with a callback URL is
+	// delivered, expires, or fails its last delivery attempt.
+	GetSMSNotifications(limit uint64) ([]*SMSNotification, error)
+
+	// DeleteSMSNotification deletes the notification of a message, e.g. once
+	// it's been sent.
+	DeleteSMSNotification(pk string) error
+
+	// DeferSMSNotification records a failed attempt to send the
+	// notification of a message, and schedules the next attempt.
+	DeferSMSNotification(pk string, nextAttempt time.Time) error
+
+	// PostponeSMSNotification schedules the next attempt to send the
+	// notification of a message without recording a failed attempt, e.g.
+	// when it can't be sent from this replica.
+	PostponeSMSNotification(pk string, nextAttempt time.Time) error
+
+	// CreateMOSMS stores a segment of a mobile originated message.
+	// Once all the segments of a message are stored, the message is created
+	// and its auto-generated pk is returned. Otherwise, the returned pk is
+	// empty.
+	CreateMOSMS(networkID string, segment MOSMSSegment) (string, error)
+
+	// GetMOSMSs returns the mobile originated messages matching the provided
+	// filters.
+	// If pks is non-empty, this will fetch only the specified messages.
+	// If imsis is non-empty, this will fetch only the messages sent by the
+	// specified IMSIs.
+	GetMOSMSs(networkID string, pks []string, imsis []string) ([]*MOSMS, error)
+
+	// DeleteMOSMSs deletes mobile originated messages by pk.
+	DeleteMOSMSs(networkID string, pks []string) error
 }
 
 // SMSReferenceCounter is a functional interface that wraps the logic to
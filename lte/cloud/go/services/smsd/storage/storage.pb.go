@@ -27,18 +27,21 @@ const (
 	MessageStatus_WAITING   MessageStatus = 0
 	MessageStatus_DELIVERED MessageStatus = 1
 	MessageStatus_FAILED    MessageStatus = 2
+	MessageStatus_EXPIRED   MessageStatus = 3
 )
 
 var MessageStatus_name = map[int32]string{
 	0: "WAITING",
 	1: "DELIVERED",
 	2: "FAILED",
+	3: "EXPIRED",
 }
 
 var MessageStatus_value = map[string]int32{
 	"WAITING":   0,
 	"DELIVERED": 1,
 	"FAILED":    2,
+	"EXPIRED":   3,
 }
 
 func (x MessageStatus) String() string {
@@ -70,6 +73,11 @@ type SMS struct {
 	AttemptCount uint32 `protobuf:"varint,22,opt,name=attemptCount,proto3" json:"attemptCount,omitempty"`
 	// error message from the most recent failed delivery attempt
 	DeliveryError string `protobuf:"bytes,23,opt,name=deliveryError,proto3" json:"deliveryError,omitempty"`
+	// time after which the message will no longer be delivered. unset if the
+	// message never expires
+	ExpiresTime *timestamp.Timestamp `protobuf:"bytes,24,opt,name=expiresTime,proto3" json:"expiresTime,omitempty"`
+	// URL notified when the message reaches a final status
+	CallbackUrl string `protobuf:"bytes,25,opt,name=callbackUrl,proto3" json:"callbackUrl,omitempty"`
 	// Internal field which holds the reference numbers assigned to an SMS
 	// which is in flight.
 	// Value is a bytearray because one message could result in multiple SMSs
@@ -170,6 +178,20 @@ func (m *SMS) GetDeliveryError() string {
 	return ""
 }
 
+func (m *SMS) GetExpiresTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresTime
+	}
+	return nil
+}
+
+func (m *SMS) GetCallbackUrl() string {
+	if m != nil {
+		return m.CallbackUrl
+	}
+	return ""
+}
+
 func (m *SMS) GetRefNums() []byte {
 	if m != nil {
 		return m.RefNums
@@ -179,9 +201,13 @@ func (m *SMS) GetRefNums() []byte {
 
 // MutableSMS encapsulates the state that service clients are allowed to set.
 type MutableSMS struct {
-	Imsi                 string   `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
-	SourceMsisdn         string   `protobuf:"bytes,2,opt,name=sourceMsisdn,proto3" json:"sourceMsisdn,omitempty"`
-	Message              string   `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Imsi         string `protobuf:"bytes,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	SourceMsisdn string `protobuf:"bytes,2,opt,name=sourceMsisdn,proto3" json:"sourceMsisdn,omitempty"`
+	Message      string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// validity period of the message in seconds, 0 if the message never
+	// expires
+	ValidityPeriodSec    uint32   `protobuf:"varint,4,opt,name=validityPeriodSec,proto3" json:"validityPeriodSec,omitempty"`
+	CallbackUrl          string   `protobuf:"bytes,5,opt,name=callbackUrl,proto3" json:"callbackUrl,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -233,38 +259,139 @@ func (m *MutableSMS) GetMessage() string {
 	return ""
 }
 
+func (m *MutableSMS) GetValidityPeriodSec() uint32 {
+	if m != nil {
+		return m.ValidityPeriodSec
+	}
+	return 0
+}
+
+func (m *MutableSMS) GetCallbackUrl() string {
+	if m != nil {
+		return m.CallbackUrl
+	}
+	return ""
+}
+
+// MOSMS represents a mobile originated message sent by a UE
+type MOSMS struct {
+	// pk uniquely identifies an SMS message (generated unique key)
+	Pk string `protobuf:"bytes,1,opt,name=pk,proto3" json:"pk,omitempty"`
+	// source of the message
+	Imsi string `protobuf:"bytes,10,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	// destination number of the message, as entered on the UE
+	Destination string `protobuf:"bytes,11,opt,name=destination,proto3" json:"destination,omitempty"`
+	// the message content
+	Message string `protobuf:"bytes,12,opt,name=message,proto3" json:"message,omitempty"`
+	// time at which the message was received by the network
+	ReceivedTime         *timestamp.Timestamp `protobuf:"bytes,20,opt,name=receivedTime,proto3" json:"receivedTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *MOSMS) Reset()         { *m = MOSMS{} }
+func (m *MOSMS) String() string { return proto.CompactTextString(m) }
+func (*MOSMS) ProtoMessage()    {}
+func (*MOSMS) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0d2c4ccf1453ffdb, []int{2}
+}
+
+func (m *MOSMS) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MOSMS.Unmarshal(m, b)
+}
+func (m *MOSMS) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MOSMS.Marshal(b, m, deterministic)
+}
+func (m *MOSMS) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MOSMS.Merge(m, src)
+}
+func (m *MOSMS) XXX_Size() int {
+	return xxx_messageInfo_MOSMS.Size(m)
+}
+func (m *MOSMS) XXX_DiscardUnknown() {
+	xxx_messageInfo_MOSMS.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MOSMS proto.InternalMessageInfo
+
+func (m *MOSMS) GetPk() string {
+	if m != nil {
+		return m.Pk
+	}
+	return ""
+}
+
+func (m *MOSMS) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *MOSMS) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+func (m *MOSMS) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *MOSMS) GetReceivedTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ReceivedTime
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("magma.lte.smsd.storage.MessageStatus", MessageStatus_name, MessageStatus_value)
 	proto.RegisterType((*SMS)(nil), "magma.lte.smsd.storage.SMS")
 	proto.RegisterType((*MutableSMS)(nil), "magma.lte.smsd.storage.MutableSMS")
+	proto.RegisterType((*MOSMS)(nil), "magma.lte.smsd.storage.MOSMS")
 }
 
-func init() { proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb) }
+func init() {
+	proto.RegisterFile("storage.proto", fileDescriptor_0d2c4ccf1453ffdb)
+}
 
 var fileDescriptor_0d2c4ccf1453ffdb = []byte{
-	// 376 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4f, 0xab, 0xd3, 0x40,
-	0x14, 0xc5, 0x9d, 0x54, 0x5a, 0x7a, 0xd3, 0x3c, 0xca, 0xa0, 0xef, 0x0d, 0x5d, 0x68, 0x28, 0x0a,
-	0xc1, 0xc5, 0x14, 0xea, 0xc2, 0x8d, 0x2e, 0xaa, 0x89, 0x12, 0x68, 0xba, 0x48, 0x83, 0x82, 0x0b,
-	0x61, 0xda, 0x4c, 0x43, 0x68, 0xa6, 0x13, 0x66, 0x26, 0x82, 0x5f, 0xc6, 0xcf, 0x2a, 0x9d, 0x24,
-	0x60, 0x40, 0xfb, 0x76, 0xb9, 0x7f, 0xce, 0x09, 0xbf, 0x73, 0x07, 0x3c, 0x6d, 0xa4, 0x62, 0x05,
-	0xa7, 0xb5, 0x92, 0x46, 0xe2, 0x7b, 0xc1, 0x0a, 0xc1, 0x68, 0x65, 0x38, 0xd5, 0x42, 0xe7, 0xb4,
-	0x9b, 0x2e, 0x5e, 0x16, 0x52, 0x16, 0x15, 0x5f, 0xd9, 0xad, 0x43, 0x73, 0x5a, 0x99, 0x52, 0x70,
-	0x6d, 0x98, 0xa8, 0x5b, 0xe1, 0xf2, 0xf7, 0x08, 0x46, 0xfb, 0x64, 0x8f, 0xef, 0xc0, 0xa9, 0xcf,
-	0x04, 0xf9, 0x28, 0x98, 0xa6, 0x4e, 0x7d, 0xc6, 0x1f, 0x60, 0xac, 0x0d, 0x33, 0x8d, 0x26, 0x8e,
-	0x8f, 0x82, 0xbb, 0xf5, 0x6b, 0xfa, 0xef, 0x3f, 0xd0, 0x84, 0x6b, 0xcd, 0x0a, 0xbe, 0xb7, 0xcb,
-	0x69, 0x27, 0xc2, 0x18, 0x9e, 0x96, 0x42, 0x97, 0x04, 0xac, 0xa1, 0xfd, 0xc6, 0x4b, 0x98, 0x69,
-	0xd9, 0xa8, 0x23, 0x4f, 0x74, 0xa9, 0xf3, 0x0b, 0x71, 0xed, 0x6c, 0xd0, 0xc3, 0x04, 0x26, 0xa2,
-	0x35, 0x24, 0x33, 0x3b, 0xee, 0x4b, 0xfc, 0x1e, 0xdc, 0xa3, 0xe2, 0xcc, 0xf0, 0x3c, 0x2b, 0x05,
-	0x27, 0xcf, 0x7c, 0x14, 0xb8, 0xeb, 0x05, 0x6d, 0xf9, 0x68, 0xcf, 0x47, 0xb3, 0x9e, 0x2f, 0xfd,
-	0x7b, 0x1d, 0x67, 0xf0, 0x50, 0x31, 0x6d, 0x42, 0x5e, 0x95, 0x3f, 0xb9, 0xfa, 0xb5, 0x31, 0x86,
-	0x8b, 0xda, 0x58, 0xa7, 0xe7, 0x8f, 0x3a, 0xfd, 0x4f, 0x7a, 0x25, 0x62, 0x6d, 0xf9, 0x49, 0x36,
-	0x17, 0x43, 0xee, 0x7d, 0x14, 0x78, 0xe9, 0xa0, 0x87, 0x5f, 0x81, 0x97, 0x77, 0xd2, 0x48, 0x29,
-	0xa9, 0xc8, 0x83, 0xe5, 0x1a, 0x36, 0xaf, 0xdc, 0x8a, 0x9f, 0x76, 0x8d, 0xd0, 0xe4, 0x85, 0x8f,
-	0x82, 0x59, 0xda, 0x97, 0xcb, 0x1f, 0x00, 0x49, 0x63, 0xd8, 0xa1, 0xe2, 0xd7, 0x33, 0xf5, 0xb9,
-	0xa2, 0x1b, 0xb9, 0x3a, 0xb7, 0x73, 0x1d, 0x0d, 0x72, 0x7d, 0xf3, 0x0e, 0xbc, 0xc1, 0x09, 0xb1,
-	0x0b, 0x93, 0x6f, 0x9b, 0x38, 0x8b, 0x77, 0x5f, 0xe6, 0x4f, 0xb0, 0x07, 0xd3, 0x30, 0xda, 0xc6,
-	0x5f, 0xa3, 0x34, 0x0a, 0xe7, 0x08, 0x03, 0x8c, 0x3f, 0x6f, 0xe2, 0x6d, 0x14, 0xce, 0x9d, 0x8f,
-	0xd3, 0xef, 0x93, 0xee, 0x0d, 0x1c, 0xc6, 0x36, 0xb4, 0xb7, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff,
-	0xc3, 0x50, 0xc7, 0x85, 0x95, 0x02, 0x00, 0x00,
+	// 482 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0xc5, 0xed, 0xd6, 0xaa, 0x37, 0xed, 0x54, 0x2c, 0xd8, 0xcc, 0x1e, 0x20, 0xaa, 0x40, 0xaa,
+	0x10, 0xca, 0xa4, 0xf1, 0x0a, 0x48, 0x85, 0x06, 0x54, 0x69, 0x1d, 0x53, 0x5a, 0x3e, 0xc4, 0x9b,
+	0x9b, 0xdc, 0x55, 0x56, 0xe3, 0x3a, 0xb2, 0x9d, 0x8a, 0xfd, 0x22, 0xde, 0xf8, 0x89, 0x08, 0xc5,
+	0x6d, 0xa4, 0x86, 0x95, 0x6e, 0x6f, 0xf1, 0x39, 0xe7, 0xde, 0x9b, 0xe3, 0x7b, 0x0c, 0x1d, 0x63,
+	0x95, 0xe6, 0x73, 0x0c, 0x32, 0xad, 0xac, 0xa2, 0xc7, 0x92, 0xcf, 0x25, 0x0f, 0x52, 0x8b, 0x81,
+	0x91, 0x26, 0x09, 0x36, 0xec, 0xe9, 0xb3, 0xb9, 0x52, 0xf3, 0x14, 0xcf, 0x9c, 0x6a, 0x96, 0x5f,
+	0x9f, 0x59, 0x21, 0xd1, 0x58, 0x2e, 0xb3, 0x75, 0x61, 0xef, 0x4f, 0x1d, 0xea, 0x93, 0xf1, 0x84,
+	0x1e, 0x41, 0x2d, 0x5b, 0x30, 0xe2, 0x93, 0x7e, 0x2b, 0xaa, 0x65, 0x0b, 0xfa, 0x16, 0x1a, 0xc6,
+	0x72, 0x9b, 0x1b, 0x56, 0xf3, 0x49, 0xff, 0xe8, 0xfc, 0x45, 0xb0, 0x7b, 0x42, 0x30, 0x46, 0x63,
+	0xf8, 0x1c, 0x27, 0x4e, 0x1c, 0x6d, 0x8a, 0x28, 0x85, 0x03, 0x21, 0x8d, 0x60, 0xe0, 0x1a, 0xba,
+	0x6f, 0xda, 0x83, 0xb6, 0x51, 0xb9, 0x8e, 0x71, 0x6c, 0x84, 0x49, 0x96, 0xcc, 0x73, 0x5c, 0x05,
+	0xa3, 0x0c, 0x9a, 0x72, 0xdd, 0x90, 0xb5, 0x1d, 0x5d, 0x1e, 0xe9, 0x1b, 0xf0, 0x62, 0x8d, 0xdc,
+	0x62, 0x32, 0x15, 0x12, 0xd9, 0x23, 0x9f, 0xf4, 0xbd, 0xf3, 0xd3, 0x60, 0xed, 0x2f, 0x28, 0xfd,
+	0x05, 0xd3, 0xd2, 0x5f, 0xb4, 0x2d, 0xa7, 0x53, 0x38, 0x49, 0xb9, 0xb1, 0x43, 0x4c, 0xc5, 0x0a,
+	0xf5, 0xcd, 0xc0, 0x5a, 0x94, 0x99, 0x75, 0x9d, 0x1e, 0xdf, 0xd9, 0xe9, 0x7f, 0xa5, 0x85, 0x23,
+	0xbe, 0x3e, 0x7e, 0x50, 0xf9, 0xd2, 0xb2, 0x63, 0x9f, 0xf4, 0x3b, 0x51, 0x05, 0xa3, 0xcf, 0xa1,
+	0x93, 0x6c, 0x4a, 0x43, 0xad, 0x95, 0x66, 0x27, 0xce, 0x57, 0x15, 0x2c, 0xdc, 0xe1, 0xcf, 0x4c,
+	0x68, 0x34, 0xee, 0x9f, 0xd8, 0xdd, 0xee, 0xb6, 0xe4, 0xd4, 0x07, 0x2f, 0xe6, 0x69, 0x3a, 0xe3,
+	0xf1, 0xe2, 0x8b, 0x4e, 0xd9, 0x13, 0x37, 0x61, 0x1b, 0x2a, 0xee, 0x55, 0xe3, 0xf5, 0x65, 0x2e,
+	0x0d, 0x7b, 0xea, 0x93, 0x7e, 0x3b, 0x2a, 0x8f, 0xbd, 0xdf, 0x04, 0x60, 0x9c, 0x5b, 0x3e, 0x4b,
+	0xb1, 0xc8, 0x41, 0xb9, 0x38, 0xb2, 0x67, 0x71, 0xb5, 0xfd, 0x8b, 0xab, 0x57, 0x17, 0xf7, 0x0a,
+	0x1e, 0xae, 0x78, 0x2a, 0x12, 0x61, 0x6f, 0xae, 0x50, 0x0b, 0x95, 0x4c, 0x30, 0x66, 0x07, 0xee,
+	0xa6, 0x6e, 0x13, 0xff, 0x5a, 0x39, 0xbc, 0x65, 0xa5, 0xf7, 0x8b, 0xc0, 0xe1, 0xf8, 0xf3, 0xae,
+	0xcc, 0xee, 0x0a, 0x9d, 0x0f, 0x5e, 0x82, 0xc6, 0x8a, 0x25, 0xb7, 0x42, 0x95, 0x99, 0xdb, 0x86,
+	0xf6, 0x44, 0xee, 0x1d, 0xb4, 0x35, 0xc6, 0x28, 0x56, 0xf7, 0xce, 0x5c, 0x45, 0xff, 0x72, 0x08,
+	0x9d, 0xca, 0xeb, 0xa0, 0x1e, 0x34, 0xbf, 0x0d, 0x46, 0xd3, 0xd1, 0xe5, 0xa7, 0xee, 0x03, 0xda,
+	0x81, 0xd6, 0x30, 0xbc, 0x18, 0x7d, 0x0d, 0xa3, 0x70, 0xd8, 0x25, 0x14, 0xa0, 0xf1, 0x71, 0x30,
+	0xba, 0x08, 0x87, 0xdd, 0x5a, 0xa1, 0x0b, 0xbf, 0x5f, 0x8d, 0x0a, 0xa2, 0xfe, 0xbe, 0xf5, 0xa3,
+	0xb9, 0x79, 0x6b, 0xb3, 0x86, 0x1b, 0xf9, 0xfa, 0xef, 0x00, 0x08, 0xce, 0x08, 0xd1, 0xfd, 0x03,
+	0x00, 0x00,
 }
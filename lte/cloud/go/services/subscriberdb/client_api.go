@@ -48,6 +48,28 @@ func ListMSISDNs(networkID string) (map[string]string, error) {
 	return res.ImsisByMsisdn, nil
 }
 
+// ListMSISDNsWithPrefix returns the IMSIs of the tracked MSISDNs starting
+// with the prefix, keyed by MSISDN.
+func ListMSISDNsWithPrefix(networkID, prefix string) (map[string]string, error) {
+	client, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.GetMSISDNs(
+		context.Background(),
+		&protos.GetMSISDNsRequest{
+			NetworkId:    networkID,
+			MsisdnPrefix: prefix,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ImsisByMsisdn, nil
+}
+
 // GetIMSIForMSISDN returns the IMSI associated with the passed MSISDN.
 // If not found, returns ErrNotFound from magma/orc8r/lib/go/errors.
 func GetIMSIForMSISDN(networkID, msisdn string) (string, error) {
@@ -146,6 +168,27 @@ func GetIMSIsForIP(networkID, ip string) ([]string, error) {
 	return uniq, nil
 }
 
+// GetIPMappings returns the IP to IMSI mappings of the passed IPs.
+func GetIPMappings(networkID string, ips []string) ([]*protos.IPMapping, error) {
+	client, err := getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.GetIPs(
+		context.Background(),
+		&protos.GetIPsRequest{
+			NetworkId: networkID,
+			Ips:       ips,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.IpMappings, nil
+}
+
 // SetIMSIsForIPs creates a set of IP to IMSI mappings.
 func SetIMSIsForIPs(networkID string, mappings []*protos.IPMapping) error {
 	client, err := getClient()
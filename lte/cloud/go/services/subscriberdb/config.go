@@ -0,0 +1,28 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package subscriberdb
+
+// Config represents the configuration provided to subscriberdb service
+type Config struct {
+	AuthKeyEncryption AuthKeyEncryptionConfig `yaml:"auth_key_encryption"`
+}
+
+// AuthKeyEncryptionConfig configures the encryption at rest of the
+// subscriber auth keys. It's also read by the lte service, which decrypts the
+// auth keys streamed to the gateways.
+type AuthKeyEncryptionConfig struct {
+	// MasterKeyFile is the path of the file of master keys wrapping the data
+	// keys. Auth keys are stored in plaintext if empty.
+	MasterKeyFile string `yaml:"master_key_file"`
+}
@@ -19,4 +19,13 @@ const (
 	EntityType = "subscriber"
 
 	LookupTableBlobstore = "subscriber_lookup_blobstore"
+
+	// JobBlobstore is the table holding the status of the bulk subscriber
+	// import & subscriber group action jobs
+	JobBlobstore = "subscriber_job_blobstore"
+
+	// DataKeyBlobstore is the table holding the wrapped data keys encrypting
+	// the subscriber auth keys. It's shared by the subscriberdb & lte
+	// services, which encrypt & decrypt the auth keys respectively.
+	DataKeyBlobstore = "subscriber_data_key_blobstore"
 )
@@ -0,0 +1,55 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"database/sql"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/lib/go/service/config"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// NewKeyringFromServiceConfig returns the keyring configured by the
+// subscriberdb service config, with its data keys stored in the db.
+func NewKeyringFromServiceConfig(db *sql.DB, builder sqorc.StatementBuilder) (*Keyring, error) {
+	var serviceConfig subscriberdb.Config
+	_, _, err := config.GetStructuredServiceConfig(lte.ModuleName, subscriberdb.ServiceName, &serviceConfig)
+	if err != nil {
+		glog.Warningf("Failed to read subscriberdb service config, using defaults: %v", err)
+	}
+
+	fact := blobstore.NewEntStorage(subscriberdb.DataKeyBlobstore, db, builder)
+	if err := fact.InitializeFactory(); err != nil {
+		return nil, errors.Wrap(err, "failed to initialize data key storage")
+	}
+	store := storage.NewDataKeyBlobstore(fact)
+
+	masterKeyFile := serviceConfig.AuthKeyEncryption.MasterKeyFile
+	if masterKeyFile == "" {
+		glog.Warning("No master key file configured, subscriber auth keys are stored in plaintext")
+		return NewKeyring(nil, store), nil
+	}
+	provider, err := NewFileKeyProvider(masterKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyring(provider, store), nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// envelopeMagic prefixes the encrypted values. Plaintext auth keys are
+	// exactly 16 bytes, so they can't be confused with encrypted values.
+	envelopeMagic = "\x00MGE1"
+
+	// keySize is the size of the master & data keys, for AES-256
+	keySize = 32
+)
+
+// IsEncrypted returns true if the value was encrypted by a Keyring.
+func IsEncrypted(value []byte) bool {
+	return bytes.HasPrefix(value, []byte(envelopeMagic))
+}
+
+// seal encrypts the plaintext with AES-GCM, returning the envelope made of
+// the magic, len(keyID), keyID, nonce & ciphertext.
+func seal(keyID string, key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	if len(keyID) > 255 {
+		return nil, errors.Errorf("key ID %s is too long", keyID)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	ret := make([]byte, 0, len(envelopeMagic)+1+len(keyID)+len(nonce)+len(plaintext)+aead.Overhead())
+	ret = append(ret, envelopeMagic...)
+	ret = append(ret, byte(len(keyID)))
+	ret = append(ret, keyID...)
+	ret = append(ret, nonce...)
+	return aead.Seal(ret, nonce, plaintext, additionalData), nil
+}
+
+// additionalData returns the data authenticated along with an encrypted
+// value, which binds it to its network, subscriber & field. Each part is
+// prefixed with its length, so the parts can't be shifted into one another.
+func additionalData(networkID string, subscriberID string, field string) []byte {
+	var ret []byte
+	for _, part := range []string{networkID, subscriberID, field} {
+		ret = append(ret, make([]byte, 4)...)
+		binary.BigEndian.PutUint32(ret[len(ret)-4:], uint32(len(part)))
+		ret = append(ret, part...)
+	}
+	return ret
+}
+
+// envelopeKeyID returns the ID of the key which encrypted the envelope.
+func envelopeKeyID(envelope []byte) (string, error) {
+	if !IsEncrypted(envelope) || len(envelope) < len(envelopeMagic)+1 {
+		return "", errors.New("value is not encrypted")
+	}
+	idLen := int(envelope[len(envelopeMagic)])
+	start := len(envelopeMagic) + 1
+	if len(envelope) < start+idLen {
+		return "", errors.New("truncated encrypted value")
+	}
+	return string(envelope[start : start+idLen]), nil
+}
+
+// open decrypts an envelope returned by seal.
+func open(key []byte, envelope []byte, additionalData []byte) ([]byte, error) {
+	keyID, err := envelopeKeyID(envelope)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sealed := envelope[len(envelopeMagic)+1+len(keyID):]
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("truncated encrypted value")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decrypt value with key %s", keyID)
+	}
+	return plaintext, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, errors.Errorf("expected a %d byte key but got %d bytes", keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func newKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+	return key, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// KeyProvider wraps the per-network data keys with master keys which are
+// kept out of the database.
+type KeyProvider interface {
+	// CurrentKeyID returns the ID of the master key wrapping new data keys.
+	CurrentKeyID() string
+
+	// WrapKey encrypts a data key with the master key of the ID.
+	WrapKey(masterKeyID string, dataKey []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data key wrapped by the master key of the ID.
+	UnwrapKey(masterKeyID string, wrapped []byte) ([]byte, error)
+}
+
+type fileKeyProvider struct {
+	currentKeyID string
+	keys         map[string][]byte
+}
+
+// NewFileKeyProvider returns a KeyProvider with the master keys of a local
+// file. Each line of the file holds the ID of a key and the base64 encoded
+// 256-bit key, separated by whitespace. Empty lines and lines starting with #
+// are ignored.
+// The first key wraps new data keys, the following ones are only used to
+// unwrap existing data keys, until they are rewrapped by a key rotation.
+func NewFileKeyProvider(path string) (KeyProvider, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read master key file")
+	}
+	return parseMasterKeys(contents)
+}
+
+func parseMasterKeys(contents []byte) (*fileKeyProvider, error) {
+	provider := &fileKeyProvider{keys: map[string][]byte{}}
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("line %d of master key file: expected '<key ID> <base64 key>'", lineNum)
+		}
+		keyID := fields[0]
+		key, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(key) != keySize {
+			return nil, errors.Errorf("line %d of master key file: expected a base64 encoded %d byte key", lineNum, keySize)
+		}
+		if len(keyID) > 255 {
+			return nil, errors.Errorf("line %d of master key file: key ID is longer than 255 bytes", lineNum)
+		}
+		if _, exists := provider.keys[keyID]; exists {
+			return nil, errors.Errorf("line %d of master key file: duplicate key ID %s", lineNum, keyID)
+		}
+		provider.keys[keyID] = key
+		if provider.currentKeyID == "" {
+			provider.currentKeyID = keyID
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read master key file")
+	}
+	if provider.currentKeyID == "" {
+		return nil, errors.New("master key file has no keys")
+	}
+	return provider, nil
+}
+
+func (f *fileKeyProvider) CurrentKeyID() string {
+	return f.currentKeyID
+}
+
+func (f *fileKeyProvider) WrapKey(masterKeyID string, dataKey []byte) ([]byte, error) {
+	key, exists := f.keys[masterKeyID]
+	if !exists {
+		return nil, errors.Errorf("unknown master key %s", masterKeyID)
+	}
+	return seal(masterKeyID, key, dataKey, nil)
+}
+
+func (f *fileKeyProvider) UnwrapKey(masterKeyID string, wrapped []byte) ([]byte, error) {
+	key, exists := f.keys[masterKeyID]
+	if !exists {
+		return nil, errors.Errorf("unknown master key %s", masterKeyID)
+	}
+	return open(key, wrapped, nil)
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package crypto implements the envelope encryption of the subscriber auth
+// keys (K & OPc) stored in configurator.
+//
+// The auth keys of a network are encrypted with AES-256-GCM by a data key of
+// the network. The data keys are stored in a blobstore table, wrapped by a
+// master key of a KeyProvider. Rotating the data key of a network creates a
+// new data key, existing values stay readable until they are re-encrypted.
+package crypto
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/orc8r/cloud/go/clock"
+	orc8r_storage "magma/orc8r/cloud/go/storage"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FieldAuthKey & FieldAuthOpc name the encrypted fields of a subscriber.
+	FieldAuthKey = "auth_key"
+	FieldAuthOpc = "auth_opc"
+
+	// currentKeyTTL bounds how long a keyring keeps encrypting with a data
+	// key after the data key of the network was rotated.
+	currentKeyTTL = time.Minute
+)
+
+// wrappedDataKey is the stored form of a data key.
+type wrappedDataKey struct {
+	MasterKeyID string `json:"master_key_id"`
+	WrappedKey  []byte `json:"wrapped_key"`
+	// CreatedAt in unix nanoseconds, the newest data key of a network is its
+	// current data key
+	CreatedAt int64 `json:"created_at"`
+}
+
+type networkKeys struct {
+	// keys holds the unwrapped data keys by ID
+	keys         map[string][]byte
+	currentKeyID string
+	loadedAt     time.Time
+}
+
+// Keyring encrypts & decrypts the subscriber auth keys of each network with
+// the network's data keys.
+// A keyring without a KeyProvider has encryption disabled: values are
+// stored in plaintext and encrypted values can't be decrypted.
+type Keyring struct {
+	provider KeyProvider
+	store    storage.DataKeyStorage
+
+	mu       sync.Mutex
+	networks map[string]*networkKeys
+}
+
+func NewKeyring(provider KeyProvider, store storage.DataKeyStorage) *Keyring {
+	return &Keyring{provider: provider, store: store, networks: map[string]*networkKeys{}}
+}
+
+// Enabled returns true if the keyring encrypts the values. A nil keyring is
+// disabled.
+func (k *Keyring) Enabled() bool {
+	return k != nil && k.provider != nil
+}
+
+// Encrypt encrypts the value of the subscriber's field with the current data
+// key of the network, creating the network's first data key if needed. Empty
+// values & values which are already encrypted are returned as-is.
+func (k *Keyring) Encrypt(networkID string, subscriberID string, field string, value []byte) ([]byte, error) {
+	if !k.Enabled() || len(value) == 0 || IsEncrypted(value) {
+		return value, nil
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	keyID, key, err := k.getCurrentKey(networkID)
+	if err != nil {
+		return nil, err
+	}
+	return seal(keyID, key, value, additionalData(networkID, subscriberID, field))
+}
+
+// Decrypt decrypts a value encrypted by Encrypt for the same network,
+// subscriber & field. Plaintext values are returned as-is.
+func (k *Keyring) Decrypt(networkID string, subscriberID string, field string, value []byte) ([]byte, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if !k.Enabled() {
+		return nil, errors.New("value is encrypted but no master key is configured")
+	}
+	keyID, err := envelopeKeyID(value)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, err := k.getKey(networkID, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return open(key, value, additionalData(networkID, subscriberID, field))
+}
+
+// IsCurrent returns true if the value is encrypted with the current data key
+// of the network.
+func (k *Keyring) IsCurrent(networkID string, value []byte) (bool, error) {
+	if !k.Enabled() || !IsEncrypted(value) {
+		return false, nil
+	}
+	keyID, err := envelopeKeyID(value)
+	if err != nil {
+		return false, err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	currentKeyID, _, err := k.getCurrentKey(networkID)
+	if err != nil {
+		return false, err
+	}
+	return keyID == currentKeyID, nil
+}
+
+// RotateDataKey creates a new data key for the network, which encrypts all
+// the following values. It returns the ID of the new key.
+func (k *Keyring) RotateDataKey(networkID string) (string, error) {
+	if !k.Enabled() {
+		return "", errors.New("no master key is configured")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	keyID, _, err := k.createDataKey(networkID)
+	return keyID, err
+}
+
+// RewrapDataKeys wraps all the data keys of the network with the current
+// master key, so the previous master keys can be retired.
+func (k *Keyring) RewrapDataKeys(networkID string) error {
+	if !k.Enabled() {
+		return errors.New("no master key is configured")
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	stored, err := k.loadWrappedKeys(networkID)
+	if err != nil {
+		return err
+	}
+	currentMasterKeyID := k.provider.CurrentKeyID()
+	for keyID, wrapped := range stored {
+		if wrapped.MasterKeyID == currentMasterKeyID {
+			continue
+		}
+		key, err := k.provider.UnwrapKey(wrapped.MasterKeyID, wrapped.WrappedKey)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unwrap data key %s of network %s", keyID, networkID)
+		}
+		rewrapped, err := k.provider.WrapKey(currentMasterKeyID, key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to wrap data key %s of network %s", keyID, networkID)
+		}
+		wrapped.MasterKeyID, wrapped.WrappedKey = currentMasterKeyID, rewrapped
+		if err := k.storeWrappedKey(networkID, keyID, wrapped); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *Keyring) getCurrentKey(networkID string) (string, []byte, error) {
+	keys, exists := k.networks[networkID]
+	if !exists || clock.Since(keys.loadedAt) > currentKeyTTL {
+		var err error
+		keys, err = k.loadKeys(networkID)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	if keys.currentKeyID == "" {
+		return k.createDataKey(networkID)
+	}
+	return keys.currentKeyID, keys.keys[keys.currentKeyID], nil
+}
+
+func (k *Keyring) getKey(networkID string, keyID string) ([]byte, error) {
+	if keys, exists := k.networks[networkID]; exists {
+		if key, exists := keys.keys[keyID]; exists {
+			return key, nil
+		}
+	}
+	keys, err := k.loadKeys(networkID)
+	if err != nil {
+		return nil, err
+	}
+	key, exists := keys.keys[keyID]
+	if !exists {
+		return nil, errors.Errorf("unknown data key %s of network %s", keyID, networkID)
+	}
+	return key, nil
+}
+
+// loadKeys loads & unwraps the data keys of the network into the cache.
+func (k *Keyring) loadKeys(networkID string) (*networkKeys, error) {
+	stored, err := k.loadWrappedKeys(networkID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &networkKeys{keys: map[string][]byte{}, loadedAt: clock.Now()}
+	var currentCreatedAt int64
+	for keyID, wrapped := range stored {
+		key, err := k.provider.UnwrapKey(wrapped.MasterKeyID, wrapped.WrappedKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unwrap data key %s of network %s", keyID, networkID)
+		}
+		keys.keys[keyID] = key
+		// Break ties by key ID so all replicas agree on the current key
+		newer := wrapped.CreatedAt > currentCreatedAt || (wrapped.CreatedAt == currentCreatedAt && keyID > keys.currentKeyID)
+		if keys.currentKeyID == "" || newer {
+			keys.currentKeyID, currentCreatedAt = keyID, wrapped.CreatedAt
+		}
+	}
+	k.networks[networkID] = keys
+	return keys, nil
+}
+
+func (k *Keyring) loadWrappedKeys(networkID string) (map[string]*wrappedDataKey, error) {
+	marshaledKeys, err := k.store.GetDataKeys(networkID)
+	if err != nil {
+		return nil, err
+	}
+	ret := map[string]*wrappedDataKey{}
+	for keyID, marshaled := range marshaledKeys {
+		wrapped := &wrappedDataKey{}
+		if err := json.Unmarshal(marshaled, wrapped); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal data key %s of network %s", keyID, networkID)
+		}
+		ret[keyID] = wrapped
+	}
+	return ret, nil
+}
+
+// createDataKey creates & stores a new data key for the network. Data keys
+// are never overwritten, so replicas concurrently creating the first data
+// key of a network only result in an extra key.
+func (k *Keyring) createDataKey(networkID string) (string, []byte, error) {
+	key, err := newKey()
+	if err != nil {
+		return "", nil, err
+	}
+	masterKeyID := k.provider.CurrentKeyID()
+	wrappedKey, err := k.provider.WrapKey(masterKeyID, key)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to wrap data key of network %s", networkID)
+	}
+	now := clock.Now()
+	keyID := (&orc8r_storage.UUIDGenerator{}).New()
+	wrapped := &wrappedDataKey{MasterKeyID: masterKeyID, WrappedKey: wrappedKey, CreatedAt: now.UnixNano()}
+	if err := k.storeWrappedKey(networkID, keyID, wrapped); err != nil {
+		return "", nil, err
+	}
+
+	keys, exists := k.networks[networkID]
+	if !exists {
+		keys = &networkKeys{keys: map[string][]byte{}}
+		k.networks[networkID] = keys
+	}
+	keys.keys[keyID] = key
+	keys.currentKeyID, keys.loadedAt = keyID, now
+	return keyID, key, nil
+}
+
+func (k *Keyring) storeWrappedKey(networkID string, keyID string, wrapped *wrappedDataKey) error {
+	marshaled, err := json.Marshal(wrapped)
+	if err != nil {
+		return err
+	}
+	return k.store.StoreDataKey(networkID, keyID, marshaled)
+}
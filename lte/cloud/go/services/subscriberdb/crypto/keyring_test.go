@@ -0,0 +1,197 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto_test
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
+	"magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	authKey = []byte("\x00\x11\x22\x33\x44\x55\x66\x77\x88\x99\xaa\xbb\xcc\xdd\xee\xff")
+	authOpc = []byte("\xff\xee\xdd\xcc\xbb\xaa\x99\x88\x77\x66\x55\x44\x33\x22\x11\x00")
+)
+
+func TestKeyring(t *testing.T) {
+	store := newTestDataKeyStorage(t)
+	provider := newTestKeyProvider(t, "master0", "master1")
+	keyring := crypto.NewKeyring(provider, store)
+	assert.True(t, keyring.Enabled())
+
+	encrypted, err := keyring.Encrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, authKey)
+	assert.NoError(t, err)
+	assert.True(t, crypto.IsEncrypted(encrypted))
+	assert.NotContains(t, string(encrypted), string(authKey))
+	decrypted, err := keyring.Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, authKey, decrypted)
+
+	// Encrypted, empty & plaintext values
+	reencrypted, err := keyring.Encrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, encrypted, reencrypted)
+	empty, err := keyring.Encrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+	decrypted, err = keyring.Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, authOpc)
+	assert.NoError(t, err)
+	assert.Equal(t, authOpc, decrypted)
+
+	// Values are bound to their network, subscriber & field, each network has
+	// its own data key
+	_, err = keyring.Decrypt("n1", "IMSI001010000000001", crypto.FieldAuthKey, encrypted)
+	assert.Error(t, err)
+	_, err = keyring.Decrypt("n0", "IMSI001010000000002", crypto.FieldAuthKey, encrypted)
+	assert.Error(t, err)
+	_, err = keyring.Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthOpc, encrypted)
+	assert.Error(t, err)
+	encrypted1, err := keyring.Encrypt("n1", "IMSI001010000000001", crypto.FieldAuthKey, authKey)
+	assert.NoError(t, err)
+	keys, err := store.GetDataKeys("n0")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	keys, err = store.GetDataKeys("n1")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+
+	// Other keyrings sharing the storage decrypt the values
+	otherKeyring := crypto.NewKeyring(provider, store)
+	decrypted, err = otherKeyring.Decrypt("n1", "IMSI001010000000001", crypto.FieldAuthKey, encrypted1)
+	assert.NoError(t, err)
+	assert.Equal(t, authKey, decrypted)
+
+	// Tampered values are rejected
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = keyring.Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, tampered)
+	assert.Error(t, err)
+
+	// Without master key encrypted values can't be decrypted
+	disabled := crypto.NewKeyring(nil, store)
+	assert.False(t, disabled.Enabled())
+	plaintext, err := disabled.Encrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, authKey)
+	assert.NoError(t, err)
+	assert.Equal(t, authKey, plaintext)
+	_, err = disabled.Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, encrypted)
+	assert.EqualError(t, err, "value is encrypted but no master key is configured")
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	store := newTestDataKeyStorage(t)
+	keyring := crypto.NewKeyring(newTestKeyProvider(t, "master0", "master1"), store)
+
+	encrypted, err := keyring.Encrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, authKey)
+	assert.NoError(t, err)
+	current, err := keyring.IsCurrent("n0", encrypted)
+	assert.NoError(t, err)
+	assert.True(t, current)
+	current, err = keyring.IsCurrent("n0", authKey)
+	assert.NoError(t, err)
+	assert.False(t, current)
+
+	_, err = keyring.RotateDataKey("n0")
+	assert.NoError(t, err)
+	current, err = keyring.IsCurrent("n0", encrypted)
+	assert.NoError(t, err)
+	assert.False(t, current)
+	rotated, err := keyring.Encrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, authKey)
+	assert.NoError(t, err)
+	current, err = keyring.IsCurrent("n0", rotated)
+	assert.NoError(t, err)
+	assert.True(t, current)
+
+	// Values encrypted by previous data keys stay readable
+	decrypted, err := keyring.Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, authKey, decrypted)
+
+	// Rewrapping the data keys with a new master key retires the previous one
+	rotatedProvider := newTestKeyProvider(t, "master1", "master0")
+	assert.NoError(t, crypto.NewKeyring(rotatedProvider, store).RewrapDataKeys("n0"))
+	retiredProvider := newTestKeyProvider(t, "master1")
+	decrypted, err = crypto.NewKeyring(retiredProvider, store).Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, authKey, decrypted)
+	_, err = crypto.NewKeyring(newTestKeyProvider(t, "master0"), store).Decrypt("n0", "IMSI001010000000001", crypto.FieldAuthKey, encrypted)
+	assert.Error(t, err)
+}
+
+func TestNewFileKeyProvider(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	provider, err := newFileKeyProvider(t, "# master keys\n\nkey1 "+key+"\nkey0\t"+key+"\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "key1", provider.CurrentKeyID())
+	wrapped, err := provider.WrapKey("key0", authKey)
+	assert.NoError(t, err)
+	unwrapped, err := provider.UnwrapKey("key0", wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, authKey, unwrapped)
+	_, err = provider.WrapKey("key2", authKey)
+	assert.EqualError(t, err, "unknown master key key2")
+
+	_, err = newFileKeyProvider(t, "key0 "+key+"\nkey0 "+key+"\n")
+	assert.EqualError(t, err, "line 2 of master key file: duplicate key ID key0")
+	_, err = newFileKeyProvider(t, "key0 AAAA\n")
+	assert.EqualError(t, err, "line 1 of master key file: expected a base64 encoded 32 byte key")
+	_, err = newFileKeyProvider(t, "key0\n")
+	assert.EqualError(t, err, "line 1 of master key file: expected '<key ID> <base64 key>'")
+	_, err = newFileKeyProvider(t, "# no keys\n")
+	assert.EqualError(t, err, "master key file has no keys")
+}
+
+func newTestDataKeyStorage(t *testing.T) storage.DataKeyStorage {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	fact := blobstore.NewSQLBlobStorageFactory(subscriberdb.DataKeyBlobstore, db, sqorc.GetSqlBuilder())
+	assert.NoError(t, fact.InitializeFactory())
+	return storage.NewDataKeyBlobstore(fact)
+}
+
+// newTestKeyProvider returns a file key provider with the master keys of the
+// IDs, the first one being the current key. The keys are derived from their
+// IDs so providers of the same IDs share their keys.
+func newTestKeyProvider(t *testing.T, keyIDs ...string) crypto.KeyProvider {
+	var lines []string
+	for _, keyID := range keyIDs {
+		key := make([]byte, 32)
+		copy(key, keyID)
+		lines = append(lines, keyID+" "+base64.StdEncoding.EncodeToString(key))
+	}
+	provider, err := newFileKeyProvider(t, strings.Join(lines, "\n"))
+	assert.NoError(t, err)
+	return provider
+}
+
+// newFileKeyProvider returns the file key provider of a master key file with
+// the contents.
+func newFileKeyProvider(t *testing.T, contents string) (crypto.KeyProvider, error) {
+	f, err := ioutil.TempFile("", "magma_master_keys")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(contents)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	return crypto.NewFileKeyProvider(f.Name())
+}
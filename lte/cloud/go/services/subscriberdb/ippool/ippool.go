@@ -0,0 +1,376 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ippool allocates the static IPs of subscribers from pools of IPv4
+// addresses managed in the cloud.
+//
+// Each pool is a block of addresses of an APN. The allocations are the
+// static IPs of the subscribers. The package doesn't store them: callers
+// reserve the allocated addresses, and release them when the static IP of
+// their subscriber is removed, e.g. when the subscriber is deleted.
+package ippool
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinPrefixLength bounds the size of the blocks of pools
+	MinPrefixLength = 8
+
+	// DuplicateConflictType is the type of conflicts between allocations of
+	// the same address
+	DuplicateConflictType = "DUPLICATE_ALLOCATION"
+	// OverlapConflictType is the type of conflicts between pools whose
+	// blocks overlap
+	OverlapConflictType = "OVERLAPPING_POOLS"
+	// ReportedConflictType is the type of conflicts between an allocation and
+	// the subscriber to which gateways reported assigning the address
+	ReportedConflictType = "REPORTED_MISMATCH"
+)
+
+// ErrExhausted is returned when the pools of an APN have no free address.
+var ErrExhausted = errors.New("no free address in the IP pools of the APN")
+
+// Pool is a block of addresses allocated to the subscribers of an APN.
+type Pool struct {
+	ID    string
+	APN   string
+	Block *net.IPNet
+}
+
+// Allocation is the static IP of a subscriber for an APN.
+type Allocation struct {
+	IMSI string
+	APN  string
+	IP   string
+}
+
+// Conflict is a set of pools or allocations which conflict.
+type Conflict struct {
+	Type    string
+	IP      string
+	PoolIDs []string
+	// Allocations are the allocations of the address, for duplicate and
+	// reported conflicts
+	Allocations []Allocation
+	// Reported are the assignments of the address reported by gateways, for
+	// reported conflicts
+	Reported []Allocation
+}
+
+// ParseBlock parses the CIDR notation of the block of a pool, which must be
+// an IPv4 network address.
+func ParseBlock(cidr string) (*net.IPNet, error) {
+	ip, block, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid IP block %s", cidr)
+	}
+	if ip.To4() == nil {
+		return nil, errors.Errorf("IP block %s is not IPv4", cidr)
+	}
+	if !ip.Equal(block.IP) {
+		return nil, errors.Errorf("IP block %s is not a network address, did you mean %s?", cidr, block)
+	}
+	if ones, _ := block.Mask.Size(); ones < MinPrefixLength {
+		return nil, errors.Errorf("IP block %s is larger than /%d", cidr, MinPrefixLength)
+	}
+	return block, nil
+}
+
+// Size returns the number of addresses of the pool which can be allocated.
+// The network and broadcast addresses of blocks larger than /31 can't.
+func (p Pool) Size() uint64 {
+	first, last := p.bounds()
+	return uint64(last-first) + 1
+}
+
+// Contains returns true if the address can be allocated from the pool.
+func (p Pool) Contains(ip string) bool {
+	addr, ok := toUint32(net.ParseIP(ip))
+	if !ok {
+		return false
+	}
+	first, last := p.bounds()
+	return first <= addr && addr <= last
+}
+
+// Overlaps returns true if the blocks of the pools share addresses.
+func (p Pool) Overlaps(other Pool) bool {
+	return p.Block.Contains(other.Block.IP) || other.Block.Contains(p.Block.IP)
+}
+
+// bounds returns the first and last addresses of the pool which can be
+// allocated
+func (p Pool) bounds() (uint32, uint32) {
+	ones, bits := p.Block.Mask.Size()
+	network, _ := toUint32(p.Block.IP)
+	last := network | (1<<uint(bits-ones) - 1)
+	if bits-ones < 2 {
+		return network, last
+	}
+	return network + 1, last - 1
+}
+
+// GetAllocated returns the distinct addresses of the pool which are
+// allocated, sorted.
+func GetAllocated(pool Pool, allocations []Allocation) []string {
+	set := map[string]bool{}
+	for _, a := range allocations {
+		if pool.Contains(a.IP) {
+			set[Normalize(a.IP)] = true
+		}
+	}
+	return sortIPs(set)
+}
+
+// GetPoolAllocations returns the allocations of addresses of the pool,
+// sorted by address and IMSI.
+func GetPoolAllocations(pool Pool, allocations []Allocation) []Allocation {
+	var ret []Allocation
+	for _, a := range allocations {
+		if pool.Contains(a.IP) {
+			ret = append(ret, a)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if x, y := Normalize(ret[i].IP), Normalize(ret[j].IP); x != y {
+			return lessIP(x, y)
+		}
+		if ret[i].IMSI != ret[j].IMSI {
+			return ret[i].IMSI < ret[j].IMSI
+		}
+		return ret[i].APN < ret[j].APN
+	})
+	return ret
+}
+
+// Allocator allocates the free addresses of pools.
+type Allocator struct {
+	poolsByAPN map[string][]Pool
+	// owners maps the allocated addresses to the IMSI of their subscriber
+	owners map[string]string
+	// next is the address of each pool from which to look for free
+	// addresses, as the addresses before it are all allocated
+	next map[string]uint64
+}
+
+// NewAllocator returns an allocator of the pools, with the addresses of the
+// allocations allocated.
+func NewAllocator(pools []Pool, allocations []Allocation) *Allocator {
+	a := &Allocator{poolsByAPN: map[string][]Pool{}, owners: map[string]string{}, next: map[string]uint64{}}
+	for _, pool := range pools {
+		a.poolsByAPN[pool.APN] = append(a.poolsByAPN[pool.APN], pool)
+		first, _ := pool.bounds()
+		a.next[pool.ID] = uint64(first)
+	}
+	for _, pools := range a.poolsByAPN {
+		sort.Slice(pools, func(i, j int) bool { return pools[i].ID < pools[j].ID })
+	}
+	for _, allocation := range allocations {
+		a.Reserve(allocation.IP, allocation.IMSI)
+	}
+	return a
+}
+
+// HasPools returns true if addresses of the APN are allocated from pools.
+func (a *Allocator) HasPools(apn string) bool {
+	return len(a.poolsByAPN[apn]) != 0
+}
+
+// Manages returns true if the address belongs to a pool.
+func (a *Allocator) Manages(ip string) bool {
+	for _, pools := range a.poolsByAPN {
+		for _, pool := range pools {
+			if pool.Contains(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetOwner returns the IMSI of the subscriber the address is allocated to.
+func (a *Allocator) GetOwner(ip string) (string, bool) {
+	imsi, ok := a.owners[Normalize(ip)]
+	return imsi, ok
+}
+
+// Reserve allocates the address to the subscriber, unless it's already
+// allocated.
+func (a *Allocator) Reserve(ip string, imsi string) {
+	if _, ok := a.owners[Normalize(ip)]; !ok {
+		a.owners[Normalize(ip)] = imsi
+	}
+}
+
+// Allocate allocates to the subscriber the lowest free address of the first
+// pool of the APN, by ID, which has one.
+func (a *Allocator) Allocate(apn string, imsi string) (string, error) {
+	for _, pool := range a.poolsByAPN[apn] {
+		_, last := pool.bounds()
+		for ; a.next[pool.ID] <= uint64(last); a.next[pool.ID]++ {
+			ip := toIP(uint32(a.next[pool.ID])).String()
+			if _, used := a.owners[ip]; !used {
+				a.owners[ip] = imsi
+				return ip, nil
+			}
+		}
+	}
+	return "", ErrExhausted
+}
+
+// FindConflicts returns the conflicts of the pools and allocations, sorted
+// by type, address and pool IDs:
+//	- pools whose blocks overlap
+//	- addresses of pools allocated more than once
+//	- addresses of pools which gateways reported assigning to another
+//	  subscriber than the one it's allocated to
+func FindConflicts(pools []Pool, allocations []Allocation, reported []Allocation) []Conflict {
+	var ret []Conflict
+
+	sorted := append([]Pool{}, pools...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for i, a := range sorted {
+		for _, b := range sorted[i+1:] {
+			if a.Overlaps(b) {
+				ret = append(ret, Conflict{Type: OverlapConflictType, PoolIDs: []string{a.ID, b.ID}})
+			}
+		}
+	}
+
+	allocationsByIP := map[string][]Allocation{}
+	for _, allocation := range allocations {
+		if ids := getPoolIDs(sorted, allocation.IP); len(ids) != 0 {
+			ip := Normalize(allocation.IP)
+			allocationsByIP[ip] = append(allocationsByIP[ip], allocation)
+		}
+	}
+	reportedByIP := map[string][]Allocation{}
+	for _, r := range reported {
+		ip := Normalize(r.IP)
+		reportedByIP[ip] = append(reportedByIP[ip], r)
+	}
+
+	for ip, ipAllocations := range allocationsByIP {
+		sortAllocations(ipAllocations)
+		if len(ipAllocations) > 1 {
+			ret = append(ret, Conflict{
+				Type:        DuplicateConflictType,
+				IP:          ip,
+				PoolIDs:     getPoolIDs(sorted, ip),
+				Allocations: ipAllocations,
+			})
+		}
+
+		var mismatched []Allocation
+		for _, r := range reportedByIP[ip] {
+			if !containsIMSI(ipAllocations, r.IMSI) {
+				mismatched = append(mismatched, r)
+			}
+		}
+		if len(mismatched) != 0 {
+			sortAllocations(mismatched)
+			ret = append(ret, Conflict{
+				Type:        ReportedConflictType,
+				IP:          ip,
+				PoolIDs:     getPoolIDs(sorted, ip),
+				Allocations: ipAllocations,
+				Reported:    mismatched,
+			})
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i], ret[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.IP != b.IP {
+			return lessIP(a.IP, b.IP)
+		}
+		return fmt.Sprint(a.PoolIDs) < fmt.Sprint(b.PoolIDs)
+	})
+	return ret
+}
+
+func getPoolIDs(sortedPools []Pool, ip string) []string {
+	var ret []string
+	for _, pool := range sortedPools {
+		if pool.Contains(ip) {
+			ret = append(ret, pool.ID)
+		}
+	}
+	return ret
+}
+
+func containsIMSI(allocations []Allocation, imsi string) bool {
+	for _, a := range allocations {
+		if a.IMSI == imsi {
+			return true
+		}
+	}
+	return false
+}
+
+func sortAllocations(allocations []Allocation) {
+	sort.Slice(allocations, func(i, j int) bool {
+		if allocations[i].IMSI != allocations[j].IMSI {
+			return allocations[i].IMSI < allocations[j].IMSI
+		}
+		return allocations[i].APN < allocations[j].APN
+	})
+}
+
+func sortIPs(set map[string]bool) []string {
+	ret := make([]string, 0, len(set))
+	for ip := range set {
+		ret = append(ret, ip)
+	}
+	sort.Slice(ret, func(i, j int) bool { return lessIP(ret[i], ret[j]) })
+	return ret
+}
+
+func lessIP(a, b string) bool {
+	x, _ := toUint32(net.ParseIP(a))
+	y, _ := toUint32(net.ParseIP(b))
+	return x < y
+}
+
+// Normalize returns the canonical form of an address, so differently
+// formatted addresses are compared equal
+func Normalize(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
+
+func toUint32(ip net.IP) (uint32, bool) {
+	v4 := ip.To4()
+	if v4 == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(v4), true
+}
+
+func toIP(addr uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, addr)
+	return ip
+}
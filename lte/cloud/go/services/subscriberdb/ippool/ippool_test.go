@@ -0,0 +1,164 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ippool_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/services/subscriberdb/ippool"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBlock(t *testing.T) {
+	block, err := ippool.ParseBlock("192.168.128.0/24")
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.128.0/24", block.String())
+
+	_, err = ippool.ParseBlock("192.168.128.1/24")
+	assert.EqualError(t, err, "IP block 192.168.128.1/24 is not a network address, did you mean 192.168.128.0/24?")
+	_, err = ippool.ParseBlock("10.0.0.0/7")
+	assert.EqualError(t, err, "IP block 10.0.0.0/7 is larger than /8")
+	_, err = ippool.ParseBlock("fd00::/64")
+	assert.EqualError(t, err, "IP block fd00::/64 is not IPv4")
+	_, err = ippool.ParseBlock("192.168.128.0")
+	assert.EqualError(t, err, "invalid IP block 192.168.128.0: invalid CIDR address: 192.168.128.0")
+}
+
+func TestPool(t *testing.T) {
+	pool := newPool(t, "p1", "internet", "192.168.128.0/30")
+	assert.Equal(t, uint64(2), pool.Size())
+	assert.False(t, pool.Contains("192.168.128.0"))
+	assert.True(t, pool.Contains("192.168.128.1"))
+	assert.True(t, pool.Contains("192.168.128.2"))
+	assert.False(t, pool.Contains("192.168.128.3"))
+	assert.False(t, pool.Contains("not an IP"))
+
+	// The network and broadcast addresses of /31 and /32 are allocated
+	assert.Equal(t, uint64(2), newPool(t, "p2", "internet", "10.0.0.0/31").Size())
+	assert.Equal(t, uint64(1), newPool(t, "p3", "internet", "10.0.0.1/32").Size())
+	assert.True(t, newPool(t, "p3", "internet", "10.0.0.1/32").Contains("10.0.0.1"))
+	assert.Equal(t, uint64(1<<24-2), newPool(t, "p4", "internet", "10.0.0.0/8").Size())
+
+	assert.True(t, pool.Overlaps(newPool(t, "p5", "ims", "192.168.0.0/16")))
+	assert.True(t, newPool(t, "p5", "ims", "192.168.0.0/16").Overlaps(pool))
+	assert.False(t, pool.Overlaps(newPool(t, "p6", "ims", "192.168.128.4/30")))
+
+	allocations := []ippool.Allocation{
+		{IMSI: "IMSI1", APN: "internet", IP: "192.168.128.2"},
+		{IMSI: "IMSI2", APN: "ims", IP: "192.168.128.2"},
+		{IMSI: "IMSI3", APN: "internet", IP: "192.168.128.1"},
+		{IMSI: "IMSI4", APN: "internet", IP: "192.168.129.1"},
+	}
+	assert.Equal(t, []string{"192.168.128.1", "192.168.128.2"}, ippool.GetAllocated(pool, allocations))
+	expected := []ippool.Allocation{
+		{IMSI: "IMSI3", APN: "internet", IP: "192.168.128.1"},
+		{IMSI: "IMSI1", APN: "internet", IP: "192.168.128.2"},
+		{IMSI: "IMSI2", APN: "ims", IP: "192.168.128.2"},
+	}
+	assert.Equal(t, expected, ippool.GetPoolAllocations(pool, allocations))
+}
+
+func TestAllocator(t *testing.T) {
+	pools := []ippool.Pool{
+		newPool(t, "p2", "internet", "192.168.128.4/30"),
+		newPool(t, "p1", "internet", "192.168.128.0/30"),
+		newPool(t, "p3", "ims", "10.0.0.0/24"),
+	}
+	allocations := []ippool.Allocation{{IMSI: "IMSI1", APN: "internet", IP: "192.168.128.1"}}
+	allocator := ippool.NewAllocator(pools, allocations)
+
+	assert.True(t, allocator.HasPools("internet"))
+	assert.False(t, allocator.HasPools("other"))
+	assert.True(t, allocator.Manages("10.0.0.1"))
+	assert.False(t, allocator.Manages("10.0.1.1"))
+
+	// Pools are used in ID order, skipping allocated addresses
+	allocator.Reserve("192.168.128.5", "IMSI2")
+	allocator.Reserve("192.168.128.5", "IMSI3")
+	owner, ok := allocator.GetOwner("192.168.128.5")
+	assert.True(t, ok)
+	assert.Equal(t, "IMSI2", owner)
+	for _, expected := range []string{"192.168.128.2", "192.168.128.6"} {
+		ip, err := allocator.Allocate("internet", "IMSI4")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, ip)
+	}
+	owner, ok = allocator.GetOwner("192.168.128.6")
+	assert.True(t, ok)
+	assert.Equal(t, "IMSI4", owner)
+	_, ok = allocator.GetOwner("192.168.128.7")
+	assert.False(t, ok)
+
+	_, err := allocator.Allocate("internet", "IMSI5")
+	assert.Equal(t, ippool.ErrExhausted, err)
+	_, err = allocator.Allocate("other", "IMSI5")
+	assert.Equal(t, ippool.ErrExhausted, err)
+
+	ip, err := allocator.Allocate("ims", "IMSI5")
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", ip)
+}
+
+func TestFindConflicts(t *testing.T) {
+	pools := []ippool.Pool{
+		newPool(t, "p1", "internet", "192.168.128.0/24"),
+		newPool(t, "p2", "ims", "192.168.128.128/25"),
+		newPool(t, "p3", "ims", "10.0.0.0/24"),
+	}
+	allocations := []ippool.Allocation{
+		{IMSI: "IMSI2", APN: "ims", IP: "192.168.128.200"},
+		{IMSI: "IMSI1", APN: "internet", IP: "192.168.128.200"},
+		{IMSI: "IMSI3", APN: "ims", IP: "10.0.0.1"},
+		{IMSI: "IMSI4", APN: "ims", IP: "10.0.0.2"},
+		// Addresses out of pools aren't managed
+		{IMSI: "IMSI5", APN: "other", IP: "172.16.0.1"},
+		{IMSI: "IMSI6", APN: "other", IP: "172.16.0.1"},
+	}
+	reported := []ippool.Allocation{
+		{IMSI: "IMSI3", APN: "ims", IP: "10.0.0.1"},
+		{IMSI: "IMSI7", APN: "ims", IP: "10.0.0.2"},
+	}
+	expected := []ippool.Conflict{
+		{
+			Type:    ippool.DuplicateConflictType,
+			IP:      "192.168.128.200",
+			PoolIDs: []string{"p1", "p2"},
+			Allocations: []ippool.Allocation{
+				{IMSI: "IMSI1", APN: "internet", IP: "192.168.128.200"},
+				{IMSI: "IMSI2", APN: "ims", IP: "192.168.128.200"},
+			},
+		},
+		{
+			Type:    ippool.OverlapConflictType,
+			PoolIDs: []string{"p1", "p2"},
+		},
+		{
+			Type:        ippool.ReportedConflictType,
+			IP:          "10.0.0.2",
+			PoolIDs:     []string{"p3"},
+			Allocations: []ippool.Allocation{{IMSI: "IMSI4", APN: "ims", IP: "10.0.0.2"}},
+			Reported:    []ippool.Allocation{{IMSI: "IMSI7", APN: "ims", IP: "10.0.0.2"}},
+		},
+	}
+	assert.Equal(t, expected, ippool.FindConflicts(pools, allocations, reported))
+
+	assert.Empty(t, ippool.FindConflicts(nil, nil, nil))
+}
+
+func newPool(t *testing.T, id, apn, cidr string) ippool.Pool {
+	block, err := ippool.ParseBlock(cidr)
+	assert.NoError(t, err)
+	return ippool.Pool{ID: id, APN: apn, Block: block}
+}
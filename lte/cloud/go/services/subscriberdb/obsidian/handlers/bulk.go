@@ -0,0 +1,560 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	ltemodels "magma/lte/cloud/go/services/lte/obsidian/models"
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
+	subscribermodels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	subscriberdb_storage "magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/glog"
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+const (
+	ImportSubscribersPath = ListSubscribersPath + obsidian.UrlSep + "import"
+	ManageImportJobPath   = ImportSubscribersPath + obsidian.UrlSep + ":job_id"
+	ExportSubscribersPath = ListSubscribersPath + obsidian.UrlSep + "export"
+
+	ParamFile   = "file"
+	ParamFormat = "format"
+
+	// importBatchSize is the number of subscribers created per configurator
+	// call, and the granularity of the job progress.
+	importBatchSize = 100
+	// maxImportFileSize bounds the size of the uploaded files, which are
+	// held in memory for the duration of the job.
+	maxImportFileSize = 64 << 20
+	// maxImportErrors bounds the number of row errors stored with a job.
+	maxImportErrors = 1000
+)
+
+// GetBulkHandlers returns the handlers of the bulk subscriber import & export
+// endpoints. Import jobs run in the background on the replica which received
+// the file, their status is kept in jobStorage so it can be polled from any
+// replica until it expires. Imported auth keys are encrypted by the keyring,
+// exported subscribers have their auth keys redacted.
+func GetBulkHandlers(jobStorage subscriberdb_storage.JobStorage, keyring *crypto.Keyring) []obsidian.Handler {
+	return []obsidian.Handler{
+		{Path: ImportSubscribersPath, Methods: obsidian.POST, HandlerFunc: makeImportSubscribersHandler(jobStorage, keyring)},
+		{Path: ManageImportJobPath, Methods: obsidian.GET, HandlerFunc: makeGetImportJobHandler(jobStorage)},
+		{Path: ExportSubscribersPath, Methods: obsidian.GET, HandlerFunc: exportSubscribersHandler},
+	}
+}
+
+// importRow is a subscriber of an import file, or the reason it couldn't be
+// parsed.
+type importRow struct {
+	row uint32
+	sub *subscribermodels.BulkSubscriber
+	err error
+}
+
+func makeImportSubscribersHandler(jobStorage subscriberdb_storage.JobStorage, keyring *crypto.Keyring) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, nerr := obsidian.GetNetworkId(c)
+		if nerr != nil {
+			return nerr
+		}
+
+		fileHeader, err := c.FormFile(ParamFile)
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "missing subscribers file"), http.StatusBadRequest)
+		}
+		format := strings.ToLower(c.FormValue(ParamFormat))
+		if format == "" {
+			format = strings.ToLower(strings.TrimPrefix(filepath.Ext(fileHeader.Filename), "."))
+		}
+		if format != subscribermodels.SubscriberImportJobFormatCsv && format != subscribermodels.SubscriberImportJobFormatJSON {
+			return obsidian.HttpError(errors.Errorf("unsupported subscribers file format '%s', expected csv or json", format), http.StatusBadRequest)
+		}
+		if fileHeader.Size > maxImportFileSize {
+			return obsidian.HttpError(errors.Errorf("subscribers file is larger than %d bytes", maxImportFileSize), http.StatusBadRequest)
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		defer file.Close()
+
+		rows, err := parseImportFile(file, format)
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "invalid subscribers file"), http.StatusBadRequest)
+		}
+
+		job := &subscribermodels.SubscriberImportJob{
+			ID:        (&storage.UUIDGenerator{}).New(),
+			Format:    format,
+			State:     subscribermodels.SubscriberImportJobStateRUNNING,
+			StartedAt: strfmt.DateTime(clock.Now()),
+			TotalRows: uint32(len(rows)),
+		}
+		if err := jobStorage.DeleteExpiredJobs(networkID, subscriberdb_storage.ImportJobType); err != nil {
+			glog.Errorf("Error deleting expired subscriber import jobs of network %s: %v", networkID, err)
+		}
+		if err := storeImportJob(jobStorage, networkID, job); err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		ret := *job
+
+		go runImportJob(jobStorage, keyring, networkID, job, rows)
+		return c.JSON(http.StatusAccepted, &ret)
+	}
+}
+
+func makeGetImportJobHandler(jobStorage subscriberdb_storage.JobStorage) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		vals, nerr := obsidian.GetParamValues(c, "network_id", "job_id")
+		if nerr != nil {
+			return nerr
+		}
+
+		marshaled, err := jobStorage.GetJob(vals[0], subscriberdb_storage.ImportJobType, vals[1])
+		if err != nil {
+			return makeErr(err)
+		}
+		job := &subscribermodels.SubscriberImportJob{}
+		if err := job.UnmarshalBinary(marshaled); err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusOK, job)
+	}
+}
+
+// exportSubscribersHandler streams all the subscribers of the network, one
+// page at a time. Errors after the first page can't be reported in the
+// response status, so they abort the stream.
+func exportSubscribersHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+	format := c.QueryParam(ParamFormat)
+	if format == "" {
+		format = subscribermodels.SubscriberImportJobFormatJSON
+	}
+	if format != subscribermodels.SubscriberImportJobFormatCsv && format != subscribermodels.SubscriberImportJobFormatJSON {
+		return obsidian.HttpError(errors.Errorf("unsupported export format '%s', expected csv or json", format), http.StatusBadRequest)
+	}
+
+	msisdnsByIMSI := map[string]string{}
+	msisdns, err := subscriberdb.ListMSISDNs(networkID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	for msisdn, imsi := range msisdns {
+		msisdnsByIMSI[imsi] = msisdn
+	}
+
+	subs, nextPageToken, err := loadMutableSubscriberPage(networkID, 0, "")
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	redactSubscriberPageKeys(subs)
+
+	res := c.Response()
+	if format == subscribermodels.SubscriberImportJobFormatCsv {
+		res.Header().Set(echo.HeaderContentType, "text/csv")
+	} else {
+		res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	}
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"subscribers_%s.%s\"", networkID, format))
+	res.WriteHeader(http.StatusOK)
+
+	exporter := newSubscriberExporter(res, format)
+	for {
+		if err := exporter.writePage(subs, msisdnsByIMSI); err != nil {
+			glog.Errorf("Error exporting subscribers of network %s: %v", networkID, err)
+			return nil
+		}
+		res.Flush()
+		if nextPageToken == "" {
+			break
+		}
+		subs, nextPageToken, err = loadMutableSubscriberPage(networkID, 0, nextPageToken)
+		if err != nil {
+			glog.Errorf("Error loading subscribers of network %s for export: %v", networkID, err)
+			return nil
+		}
+		redactSubscriberPageKeys(subs)
+	}
+	if err := exporter.close(); err != nil {
+		glog.Errorf("Error exporting subscribers of network %s: %v", networkID, err)
+	}
+	return nil
+}
+
+// redactSubscriberPageKeys removes the auth keys of the exported subscribers.
+func redactSubscriberPageKeys(subs map[string]*subscribermodels.MutableSubscriber) {
+	for _, sub := range subs {
+		if sub.Lte != nil {
+			sub.Lte.RedactKeys()
+		}
+	}
+}
+
+type subscriberExporter struct {
+	w       io.Writer
+	csv     *csv.Writer
+	written int
+	started bool
+}
+
+func newSubscriberExporter(w io.Writer, format string) *subscriberExporter {
+	exporter := &subscriberExporter{w: w}
+	if format == subscribermodels.SubscriberImportJobFormatCsv {
+		exporter.csv = csv.NewWriter(w)
+	}
+	return exporter
+}
+
+func (e *subscriberExporter) writePage(subs map[string]*subscribermodels.MutableSubscriber, msisdnsByIMSI map[string]string) error {
+	imsis := make([]string, 0, len(subs))
+	for imsi := range subs {
+		imsis = append(imsis, imsi)
+	}
+	sort.Strings(imsis)
+
+	if e.csv != nil {
+		if err := e.writeCSVHeader(); err != nil {
+			return err
+		}
+		for _, imsi := range imsis {
+			sub := (&subscribermodels.BulkSubscriber{}).FromMutableSubscriber(subs[imsi], msisdnsByIMSI[imsi])
+			if err := e.csv.Write(sub.ToCSVRecord()); err != nil {
+				return err
+			}
+			e.written++
+		}
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+
+	for _, imsi := range imsis {
+		sub := (&subscribermodels.BulkSubscriber{}).FromMutableSubscriber(subs[imsi], msisdnsByIMSI[imsi])
+		marshaled, err := json.Marshal(sub)
+		if err != nil {
+			return err
+		}
+		sep := ","
+		if e.written == 0 {
+			sep = "["
+		}
+		if _, err := io.WriteString(e.w, sep); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(marshaled); err != nil {
+			return err
+		}
+		e.written++
+	}
+	return nil
+}
+
+func (e *subscriberExporter) close() error {
+	if e.csv != nil {
+		if err := e.writeCSVHeader(); err != nil {
+			return err
+		}
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+	end := "]"
+	if e.written == 0 {
+		end = "[]"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+func (e *subscriberExporter) writeCSVHeader() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	return e.csv.Write(subscribermodels.BulkSubscriberCSVHeader)
+}
+
+// parseImportFile returns the subscribers of the file. Errors of individual
+// rows are returned with the rows, errors of the file as a whole are
+// returned directly.
+func parseImportFile(file io.Reader, format string) ([]importRow, error) {
+	if format == subscribermodels.SubscriberImportJobFormatCsv {
+		return parseImportCSV(file)
+	}
+	return parseImportJSON(file)
+}
+
+func parseImportCSV(file io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns, err := subscribermodels.NewCSVColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := importRow{row: uint32(len(rows) + 1)}
+		row.sub, row.err = (&subscribermodels.BulkSubscriber{}).FromCSVRecord(columns, record)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseImportJSON(file io.Reader) ([]importRow, error) {
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, errors.Wrap(err, "expected a JSON array of subscribers")
+	}
+
+	rows := make([]importRow, 0, len(items))
+	for i, item := range items {
+		row := importRow{row: uint32(i + 1), sub: &subscribermodels.BulkSubscriber{}}
+		if err := json.Unmarshal(item, row.sub); err != nil {
+			row.err = err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// runImportJob creates the subscribers of the rows in batches, storing the
+// progress of the job after each batch.
+func runImportJob(jobStorage subscriberdb_storage.JobStorage, keyring *crypto.Keyring, networkID string, job *subscribermodels.SubscriberImportJob, rows []importRow) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Errorf("Subscriber import job %s of network %s panicked: %v", job.ID, networkID, r)
+			failImportJob(jobStorage, networkID, job, fmt.Sprintf("internal error: %v", r))
+		}
+	}()
+
+	subProfiles, err := getNetworkSubProfiles(networkID)
+	if err != nil {
+		failImportJob(jobStorage, networkID, job, err.Error())
+		return
+	}
+	allocator, err := newStaticIPAllocator(networkID)
+	if err != nil {
+		failImportJob(jobStorage, networkID, job, errors.Wrap(err, "failed to load static IP pools").Error())
+		return
+	}
+
+	seenIDs := map[string]uint32{}
+	seenMSISDNs := map[string]uint32{}
+	for start := 0; start < len(rows); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		var batch []importRow
+		for _, row := range rows[start:end] {
+			if err := validateImportRow(row, subProfiles, seenIDs, seenMSISDNs); err != nil {
+				addImportError(job, row, err)
+				continue
+			}
+			batch = append(batch, row)
+		}
+		importBatch(keyring, allocator, networkID, job, batch)
+		sort.SliceStable(job.Errors, func(i, j int) bool { return job.Errors[i].Row < job.Errors[j].Row })
+
+		job.ProcessedRows = uint32(end)
+		if err := storeImportJob(jobStorage, networkID, job); err != nil {
+			glog.Errorf("Error storing progress of subscriber import job %s of network %s: %v", job.ID, networkID, err)
+		}
+	}
+
+	job.State = subscribermodels.SubscriberImportJobStateCOMPLETED
+	job.FinishedAt = strfmt.DateTime(clock.Now())
+	if err := storeImportJob(jobStorage, networkID, job); err != nil {
+		glog.Errorf("Error storing result of subscriber import job %s of network %s: %v", job.ID, networkID, err)
+	}
+}
+
+func validateImportRow(row importRow, subProfiles map[string]bool, seenIDs, seenMSISDNs map[string]uint32) error {
+	if row.err != nil {
+		return row.err
+	}
+	sub := row.sub
+	if err := sub.ValidateModel(); err != nil {
+		return err
+	}
+	if profile := string(sub.Lte.SubProfile); !subProfiles[profile] {
+		return errors.Errorf("subscriber profile %s does not exist for the network", profile)
+	}
+
+	id := string(sub.ID)
+	if first, exists := seenIDs[id]; exists {
+		return errors.Errorf("duplicate of the subscriber of row %d", first)
+	}
+	seenIDs[id] = row.row
+	if msisdn := string(sub.Msisdn); msisdn != "" {
+		if first, exists := seenMSISDNs[msisdn]; exists {
+			return errors.Errorf("duplicate of the MSISDN of row %d", first)
+		}
+		seenMSISDNs[msisdn] = row.row
+	}
+	return nil
+}
+
+// importBatch creates the subscribers of the batch with a single
+// configurator call. If the call fails, the subscribers are created one at a
+// time to find which rows are at fault.
+func importBatch(keyring *crypto.Keyring, allocator *staticIPAllocator, networkID string, job *subscribermodels.SubscriberImportJob, batch []importRow) {
+	var writes []configurator.EntityWriteOperation
+	subs := map[uint32]*subscribermodels.MutableSubscriber{}
+	reservations := map[uint32][]configurator.EntityWriteOperation{}
+	var encrypted []importRow
+	for _, row := range batch {
+		mutableSub := row.sub.ToMutableSubscriber()
+		subReservations, err := allocator.allocate(mutableSub)
+		if err != nil {
+			addImportError(job, row, err)
+			continue
+		}
+		sub, err := encryptSubscriberKeys(keyring, networkID, mutableSub)
+		if err != nil {
+			addImportError(job, row, err)
+			continue
+		}
+		subs[row.row] = sub
+		reservations[row.row] = subReservations
+		for _, ent := range getSubscriberEntities(sub) {
+			writes = append(writes, ent)
+		}
+		writes = append(writes, subReservations...)
+		encrypted = append(encrypted, row)
+	}
+	if len(encrypted) == 0 {
+		return
+	}
+
+	var created []importRow
+	if err := configurator.WriteEntities(networkID, writes, serdes.Entity); err == nil {
+		created = encrypted
+	} else {
+		for _, row := range encrypted {
+			if err := createSubscriber(networkID, subs[row.row], reservations[row.row]); err != nil {
+				addImportError(job, row, err)
+				continue
+			}
+			created = append(created, row)
+		}
+	}
+
+	for _, row := range created {
+		if msisdn := string(row.sub.Msisdn); msisdn != "" {
+			err := subscriberdb.SetIMSIForMSISDN(networkID, msisdn, string(row.sub.ID))
+			if err != nil {
+				// Don't leave behind a subscriber without the requested MSISDN
+				if delErr := deleteSubscriber(networkID, string(row.sub.ID)); delErr != nil {
+					glog.Errorf("Error deleting subscriber %s after failing to assign MSISDN %s: %v", row.sub.ID, msisdn, delErr)
+				}
+				addImportError(job, row, errors.Wrapf(err, "failed to assign MSISDN %s", msisdn))
+				continue
+			}
+		}
+		job.CreatedRows++
+	}
+}
+
+func addImportError(job *subscribermodels.SubscriberImportJob, row importRow, err error) {
+	job.FailedRows++
+	if len(job.Errors) >= maxImportErrors {
+		job.ErrorsTruncated = true
+		return
+	}
+	importErr := &subscribermodels.SubscriberImportError{Row: row.row, Message: err.Error()}
+	if row.sub != nil {
+		importErr.SubscriberID = string(row.sub.ID)
+	}
+	job.Errors = append(job.Errors, importErr)
+}
+
+func failImportJob(jobStorage subscriberdb_storage.JobStorage, networkID string, job *subscribermodels.SubscriberImportJob, message string) {
+	job.State = subscribermodels.SubscriberImportJobStateFAILED
+	job.Message = message
+	job.FinishedAt = strfmt.DateTime(clock.Now())
+	if err := storeImportJob(jobStorage, networkID, job); err != nil {
+		glog.Errorf("Error storing failure of subscriber import job %s of network %s: %v", job.ID, networkID, err)
+	}
+}
+
+func storeImportJob(jobStorage subscriberdb_storage.JobStorage, networkID string, job *subscribermodels.SubscriberImportJob) error {
+	marshaled, err := job.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return jobStorage.StoreJob(networkID, subscriberdb_storage.ImportJobType, job.ID, marshaled)
+}
+
+// getNetworkSubProfiles returns the sub profiles available on the network,
+// including the default one which is always available.
+func getNetworkSubProfiles(networkID string) (map[string]bool, error) {
+	profiles := map[string]bool{"default": true}
+	netConf, err := configurator.LoadNetworkConfig(networkID, lte.CellularNetworkConfigType, serdes.Network)
+	switch {
+	case err == merrors.ErrNotFound:
+		return profiles, nil
+	case err != nil:
+		return nil, errors.Wrap(err, "failed to load cellular config of the network")
+	}
+	cellNetConf := netConf.(*ltemodels.NetworkCellularConfigs)
+	if cellNetConf.Epc != nil {
+		for name := range cellNetConf.Epc.SubProfiles {
+			profiles[name] = true
+		}
+	}
+	return profiles, nil
+}
@@ -0,0 +1,275 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/obsidian/handlers"
+	subscriberModels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	subscriberdbStorage "magma/lte/cloud/go/services/subscriberdb/storage"
+	subscriberdbTestInit "magma/lte/cloud/go/services/subscriberdb/test_init"
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testImportPath = "/magma/v1/lte/:network_id/subscribers/import"
+	testJobPath    = "/magma/v1/lte/:network_id/subscribers/import/:job_id"
+	testExportPath = "/magma/v1/lte/:network_id/subscribers/export"
+)
+
+func TestImportSubscribers(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	subscriberdbTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n0"}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n0", configurator.NetworkEntity{Type: lte.APNEntityType, Key: "internet"}, serdes.Entity)
+	assert.NoError(t, err)
+
+	bulkHandlers := handlers.GetBulkHandlers(newTestJobStorage(t), nil)
+	importSubscribers := tests.GetHandlerByPathAndMethod(t, bulkHandlers, testImportPath, obsidian.POST).HandlerFunc
+	getImportJob := tests.GetHandlerByPathAndMethod(t, bulkHandlers, testJobPath, obsidian.GET).HandlerFunc
+
+	file := strings.Join([]string{
+		"id,auth_key,auth_opc,active_apns,static_ips,msisdn",
+		"IMSI001010000000001,00112233445566778899aabbccddeeff,00112233445566778899aabbccddeeff,internet,internet=10.0.0.1,13105550001",
+		"IMSI001010000000002,00112233445566778899aabbccddeeff,,,,",
+		// Invalid key
+		"IMSI001010000000003,0011,,,,",
+		// Duplicate subscriber
+		"IMSI001010000000002,00112233445566778899aabbccddeeff,,,,",
+		// Unknown APN
+		"IMSI001010000000004,00112233445566778899aabbccddeeff,,ims,,",
+	}, "\n")
+	job := startImportJob(t, importSubscribers, "subscribers.csv", "", file, http.StatusAccepted)
+	assert.Equal(t, subscriberModels.SubscriberImportJobFormatCsv, job.Format)
+	assert.Equal(t, uint32(5), job.TotalRows)
+
+	job = waitForImportJob(t, getImportJob, job.ID)
+	assert.Equal(t, subscriberModels.SubscriberImportJobStateCOMPLETED, job.State)
+	assert.Equal(t, uint32(5), job.ProcessedRows)
+	assert.Equal(t, uint32(2), job.CreatedRows)
+	assert.Equal(t, uint32(3), job.FailedRows)
+	if assert.Len(t, job.Errors, 3) {
+		assert.Equal(t, uint32(3), job.Errors[0].Row)
+		assert.Equal(t, "IMSI001010000000003", job.Errors[0].SubscriberID)
+		assert.Equal(t, "expected lte auth key to be 16 bytes but got 2 bytes", job.Errors[0].Message)
+		assert.Equal(t, uint32(4), job.Errors[1].Row)
+		assert.Equal(t, "duplicate of the subscriber of row 2", job.Errors[1].Message)
+		assert.Equal(t, uint32(5), job.Errors[2].Row)
+		assert.Equal(t, "IMSI001010000000004", job.Errors[2].SubscriberID)
+	}
+
+	exists, err := configurator.DoesEntityExist("n0", lte.SubscriberEntityType, "IMSI001010000000001")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = configurator.DoesEntityExist("n0", lte.SubscriberEntityType, "IMSI001010000000004")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	imsi, err := subscriberdb.GetIMSIForMSISDN("n0", "13105550001")
+	assert.NoError(t, err)
+	assert.Equal(t, "IMSI001010000000001", imsi)
+
+	// Existing subscribers & MSISDNs are reported, format from the form value
+	file = `[
+		{"id": "IMSI001010000000001", "lte": {"auth_algo": "MILENAGE", "auth_key": "ABEiM0RVZneImaq7zN3u/w==", "state": "ACTIVE", "sub_profile": "default"}},
+		{"id": "IMSI001010000000005", "msisdn": "13105550001", "lte": {"auth_algo": "MILENAGE", "auth_key": "ABEiM0RVZneImaq7zN3u/w==", "state": "ACTIVE", "sub_profile": "default"}},
+		{"id": "IMSI001010000000006", "lte": {"auth_algo": "MILENAGE", "auth_key": "ABEiM0RVZneImaq7zN3u/w==", "state": "ACTIVE", "sub_profile": "gold"}},
+		{"id": 6}
+	]`
+	job = startImportJob(t, importSubscribers, "subscribers.txt", "json", file, http.StatusAccepted)
+	job = waitForImportJob(t, getImportJob, job.ID)
+	assert.Equal(t, subscriberModels.SubscriberImportJobStateCOMPLETED, job.State)
+	assert.Equal(t, uint32(0), job.CreatedRows)
+	assert.Equal(t, uint32(4), job.FailedRows)
+	if assert.Len(t, job.Errors, 4) {
+		assert.Equal(t, "IMSI001010000000001", job.Errors[0].SubscriberID)
+		assert.Contains(t, job.Errors[1].Message, "failed to assign MSISDN 13105550001")
+		assert.Equal(t, "subscriber profile gold does not exist for the network", job.Errors[2].Message)
+		assert.Equal(t, uint32(4), job.Errors[3].Row)
+	}
+	// Subscriber isn't left behind without its MSISDN
+	exists, err = configurator.DoesEntityExist("n0", lte.SubscriberEntityType, "IMSI001010000000005")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// Invalid files are rejected up front
+	startImportJob(t, importSubscribers, "subscribers.xml", "", "<subscribers/>", http.StatusBadRequest)
+	startImportJob(t, importSubscribers, "subscribers.csv", "", "id,imei\n", http.StatusBadRequest)
+	startImportJob(t, importSubscribers, "subscribers.json", "", `{"id": "IMSI001010000000001"}`, http.StatusBadRequest)
+
+	// Unknown job
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/subscribers/import/foo",
+		Handler:        getImportJob,
+		ParamNames:     []string{"network_id", "job_id"},
+		ParamValues:    []string{"n0", "foo"},
+		ExpectedStatus: 404,
+		ExpectedError:  "Not Found",
+	}
+	tests.RunUnitTest(t, echo.New(), tc)
+}
+
+func TestExportSubscribers(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	subscriberdbTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n0"}, serdes.Network)
+	assert.NoError(t, err)
+
+	bulkHandlers := handlers.GetBulkHandlers(newTestJobStorage(t), nil)
+	exportSubscribers := tests.GetHandlerByPathAndMethod(t, bulkHandlers, testExportPath, obsidian.GET).HandlerFunc
+
+	// Empty network
+	assert.Equal(t, "[]", exportSubscribersBody(t, exportSubscribers, "json"))
+	assert.Equal(t, strings.Join(subscriberModels.BulkSubscriberCSVHeader, ",")+"\n", exportSubscribersBody(t, exportSubscribers, "csv"))
+
+	for _, id := range []string{"IMSI001010000000002", "IMSI001010000000001"} {
+		_, err = configurator.CreateEntity("n0", configurator.NetworkEntity{
+			Type: lte.SubscriberEntityType,
+			Key:  id,
+			Config: &subscriberModels.SubscriberConfig{
+				Lte: &subscriberModels.LteSubscription{
+					AuthAlgo:   "MILENAGE",
+					AuthKey:    []byte("\x00\x11\x22\x33\x44\x55\x66\x77\x88\x99\xaa\xbb\xcc\xdd\xee\xff"),
+					State:      "ACTIVE",
+					SubProfile: "default",
+				},
+			},
+		}, serdes.Entity)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, subscriberdb.SetIMSIForMSISDN("n0", "13105550001", "IMSI001010000000001"))
+
+	// Auth keys are never exported
+	assert.Equal(t, strings.Join([]string{
+		strings.Join(subscriberModels.BulkSubscriberCSVHeader, ","),
+		"IMSI001010000000001,,,,ACTIVE,default,,,,,,13105550001",
+		"IMSI001010000000002,,,,ACTIVE,default,,,,,,",
+		"",
+	}, "\n"), exportSubscribersBody(t, exportSubscribers, "csv"))
+
+	var subs []*subscriberModels.BulkSubscriber
+	assert.NoError(t, json.Unmarshal([]byte(exportSubscribersBody(t, exportSubscribers, "")), &subs))
+	if assert.Len(t, subs, 2) {
+		assert.Equal(t, "IMSI001010000000001", string(subs[0].ID))
+		assert.Equal(t, "13105550001", string(subs[0].Msisdn))
+		assert.Empty(t, subs[0].Lte.AuthKey)
+		assert.Equal(t, "IMSI001010000000002", string(subs[1].ID))
+		assert.Empty(t, subs[1].Msisdn)
+	}
+
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/subscribers/export?format=xml",
+		Handler:        exportSubscribers,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 400,
+		ExpectedError:  "unsupported export format 'xml', expected csv or json",
+	}
+	tests.RunUnitTest(t, echo.New(), tc)
+}
+
+func newTestJobStorage(t *testing.T) subscriberdbStorage.JobStorage {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	fact := blobstore.NewSQLBlobStorageFactory(subscriberdb.JobBlobstore, db, sqorc.GetSqlBuilder())
+	assert.NoError(t, fact.InitializeFactory())
+	return subscriberdbStorage.NewJobBlobstore(fact)
+}
+
+func startImportJob(t *testing.T, handler echo.HandlerFunc, fileName, format, file string, expectedStatus int) *subscriberModels.SubscriberImportJob {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(handlers.ParamFile, fileName)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(file))
+	assert.NoError(t, err)
+	if format != "" {
+		assert.NoError(t, writer.WriteField(handlers.ParamFormat, format))
+	}
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/magma/v1/lte/n0/subscribers/import", body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(req, recorder)
+	c.SetParamNames("network_id")
+	c.SetParamValues("n0")
+	if err := handler(c); err != nil {
+		c.Error(err)
+	}
+	assert.Equal(t, expectedStatus, recorder.Code)
+	if expectedStatus != http.StatusAccepted {
+		return nil
+	}
+
+	job := &subscriberModels.SubscriberImportJob{}
+	assert.NoError(t, job.UnmarshalBinary(recorder.Body.Bytes()))
+	assert.Equal(t, subscriberModels.SubscriberImportJobStateRUNNING, job.State)
+	return job
+}
+
+func waitForImportJob(t *testing.T, handler echo.HandlerFunc, jobID string) *subscriberModels.SubscriberImportJob {
+	job := &subscriberModels.SubscriberImportJob{}
+	assert.Eventually(t, func() bool {
+		req := httptest.NewRequest(http.MethodGet, "/magma/v1/lte/n0/subscribers/import/"+jobID, nil)
+		recorder := httptest.NewRecorder()
+		c := echo.New().NewContext(req, recorder)
+		c.SetParamNames("network_id", "job_id")
+		c.SetParamValues("n0", jobID)
+		if err := handler(c); err != nil {
+			return false
+		}
+		return job.UnmarshalBinary(recorder.Body.Bytes()) == nil && job.State != subscriberModels.SubscriberImportJobStateRUNNING
+	}, 10*time.Second, 50*time.Millisecond)
+	return job
+}
+
+func exportSubscribersBody(t *testing.T, handler echo.HandlerFunc, format string) string {
+	url := "/magma/v1/lte/n0/subscribers/export"
+	if format != "" {
+		url += "?format=" + format
+	}
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(req, recorder)
+	c.SetParamNames("network_id")
+	c.SetParamValues("n0")
+	assert.NoError(t, handler(c))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
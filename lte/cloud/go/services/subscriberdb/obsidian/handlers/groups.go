@@ -0,0 +1,596 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	ltehandlers "magma/lte/cloud/go/services/lte/obsidian/handlers"
+	policymodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	subscribermodels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	subscriberdb_storage "magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/glog"
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+const (
+	SubscriberGroups                = "subscriber_groups"
+	ListSubscriberGroupsPath        = ltehandlers.ManageNetworkPath + obsidian.UrlSep + SubscriberGroups
+	ManageSubscriberGroupPath       = ListSubscriberGroupsPath + obsidian.UrlSep + ":group_id"
+	SubscriberGroupMembersPath      = ManageSubscriberGroupPath + obsidian.UrlSep + "subscribers"
+	ManageSubscriberGroupMemberPath = SubscriberGroupMembersPath + obsidian.UrlSep + ":subscriber_id"
+	SubscriberGroupActionsPath      = ManageSubscriberGroupPath + obsidian.UrlSep + "actions"
+	ManageSubscriberGroupJobPath    = ManageSubscriberGroupPath + obsidian.UrlSep + "jobs" + obsidian.UrlSep + ":job_id"
+
+	// defaultMaxSyncGroupActionSize is the size of the largest group whose
+	// actions are applied in a single transaction, while the request waits.
+	defaultMaxSyncGroupActionSize = 500
+	// groupActionBatchSize is the number of subscribers updated per
+	// transaction by action jobs, and the granularity of the job progress.
+	groupActionBatchSize = 100
+	// maxGroupJobErrors bounds the number of subscriber errors stored with
+	// a job.
+	maxGroupJobErrors = 1000
+)
+
+var (
+	subscriberGroupLoadCriteria = configurator.EntityLoadCriteria{LoadMetadata: true, LoadAssocsFromThis: true}
+	maxSyncGroupActionSize      = defaultMaxSyncGroupActionSize
+)
+
+// GetGroupHandlers returns the handlers of the subscriber group endpoints.
+// Actions on large groups run in the background on the replica which
+// received the request, their status is kept in jobStorage so it can be
+// polled from any replica until it expires.
+func GetGroupHandlers(jobStorage subscriberdb_storage.JobStorage) []obsidian.Handler {
+	return []obsidian.Handler{
+		{Path: ListSubscriberGroupsPath, Methods: obsidian.GET, HandlerFunc: listSubscriberGroupsHandler},
+		{Path: ListSubscriberGroupsPath, Methods: obsidian.POST, HandlerFunc: createSubscriberGroupHandler},
+		{Path: ManageSubscriberGroupPath, Methods: obsidian.GET, HandlerFunc: getSubscriberGroupHandler},
+		{Path: ManageSubscriberGroupPath, Methods: obsidian.PUT, HandlerFunc: updateSubscriberGroupHandler},
+		{Path: ManageSubscriberGroupPath, Methods: obsidian.DELETE, HandlerFunc: deleteSubscriberGroupHandler},
+		{Path: SubscriberGroupMembersPath, Methods: obsidian.POST, HandlerFunc: addSubscriberGroupMembersHandler},
+		{Path: ManageSubscriberGroupMemberPath, Methods: obsidian.DELETE, HandlerFunc: removeSubscriberGroupMemberHandler},
+		{Path: SubscriberGroupActionsPath, Methods: obsidian.POST, HandlerFunc: makeSubscriberGroupActionHandler(jobStorage)},
+		{Path: ManageSubscriberGroupJobPath, Methods: obsidian.GET, HandlerFunc: makeGetSubscriberGroupJobHandler(jobStorage)},
+	}
+}
+
+// SetMaxSyncGroupActionSizeForTest sets the size of the largest group whose
+// actions are applied synchronously. A size of 0 restores the default.
+// This should only be called by test code.
+func SetMaxSyncGroupActionSizeForTest(t *testing.T, size int) {
+	if t == nil {
+		panic("for tests only")
+	}
+	if size == 0 {
+		size = defaultMaxSyncGroupActionSize
+	}
+	maxSyncGroupActionSize = size
+}
+
+func listSubscriberGroupsHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	ents, _, err := configurator.LoadAllEntitiesOfType(networkID, lte.SubscriberGroupEntityType, subscriberGroupLoadCriteria, serdes.Entity)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	ret := map[string]*subscribermodels.SubscriberGroup{}
+	for _, ent := range ents {
+		ret[ent.Key] = (&subscribermodels.SubscriberGroup{}).FromEntity(ent)
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+func createSubscriberGroupHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	group := &subscribermodels.SubscriberGroup{}
+	if err := c.Bind(group); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := group.ValidateModel(); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	exists, err := configurator.DoesEntityExist(networkID, lte.SubscriberGroupEntityType, string(group.ID))
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if exists {
+		return obsidian.HttpError(errors.Errorf("subscriber group %s already exists", group.ID), http.StatusBadRequest)
+	}
+	if nerr := validateGroupSubscribers(networkID, group.Subscribers); nerr != nil {
+		return nerr
+	}
+
+	_, err = configurator.CreateEntity(networkID, group.ToEntity(), serdes.Entity)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+func getSubscriberGroupHandler(c echo.Context) error {
+	networkID, groupID, nerr := getNetworkAndGroupIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	group, err := loadSubscriberGroup(networkID, groupID)
+	if err != nil {
+		return makeErr(err)
+	}
+	return c.JSON(http.StatusOK, group)
+}
+
+func updateSubscriberGroupHandler(c echo.Context) error {
+	networkID, groupID, nerr := getNetworkAndGroupIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	group := &subscribermodels.SubscriberGroup{}
+	if err := c.Bind(group); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := group.ValidateModel(); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if string(group.ID) != groupID {
+		err := fmt.Errorf("subscriber group ID from parameters (%s) and payload (%s) must match", groupID, group.ID)
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	exists, err := configurator.DoesEntityExist(networkID, lte.SubscriberGroupEntityType, groupID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if !exists {
+		return echo.ErrNotFound
+	}
+	if nerr := validateGroupSubscribers(networkID, group.Subscribers); nerr != nil {
+		return nerr
+	}
+
+	_, err = configurator.UpdateEntity(networkID, group.ToUpdateCriteria(), serdes.Entity)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func deleteSubscriberGroupHandler(c echo.Context) error {
+	networkID, groupID, nerr := getNetworkAndGroupIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	err := configurator.DeleteEntity(networkID, lte.SubscriberGroupEntityType, groupID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func addSubscriberGroupMembersHandler(c echo.Context) error {
+	networkID, groupID, nerr := getNetworkAndGroupIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	var subscriberIDs []policymodels.SubscriberID
+	if err := c.Bind(&subscriberIDs); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	for _, sid := range subscriberIDs {
+		if err := sid.Validate(strfmt.Default); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+	}
+
+	exists, err := configurator.DoesEntityExist(networkID, lte.SubscriberGroupEntityType, groupID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if !exists {
+		return echo.ErrNotFound
+	}
+	if nerr := validateGroupSubscribers(networkID, subscriberIDs); nerr != nil {
+		return nerr
+	}
+
+	group := &subscribermodels.SubscriberGroup{Subscribers: subscriberIDs}
+	_, err = configurator.UpdateEntity(
+		networkID,
+		configurator.EntityUpdateCriteria{Type: lte.SubscriberGroupEntityType, Key: groupID, AssociationsToAdd: group.GetAssocs()},
+		serdes.Entity,
+	)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func removeSubscriberGroupMemberHandler(c echo.Context) error {
+	vals, nerr := obsidian.GetParamValues(c, "network_id", "group_id", "subscriber_id")
+	if nerr != nil {
+		return nerr
+	}
+	networkID, groupID, subscriberID := vals[0], vals[1], vals[2]
+
+	group, err := loadSubscriberGroup(networkID, groupID)
+	if err != nil {
+		return makeErr(err)
+	}
+	isMember := false
+	for _, sid := range group.Subscribers {
+		isMember = isMember || string(sid) == subscriberID
+	}
+	if !isMember {
+		return echo.ErrNotFound
+	}
+
+	_, err = configurator.UpdateEntity(
+		networkID,
+		configurator.EntityUpdateCriteria{
+			Type:                 lte.SubscriberGroupEntityType,
+			Key:                  groupID,
+			AssociationsToDelete: []storage.TypeAndKey{{Type: lte.SubscriberEntityType, Key: subscriberID}},
+		},
+		serdes.Entity,
+	)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// makeSubscriberGroupActionHandler applies an action to the subscribers of a
+// group. Actions on groups of up to maxSyncGroupActionSize subscribers are
+// applied in a single transaction, so either all or none of the subscribers
+// are updated. Actions on larger groups are applied by a job, one
+// transaction per batch of subscribers.
+func makeSubscriberGroupActionHandler(jobStorage subscriberdb_storage.JobStorage) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, groupID, nerr := getNetworkAndGroupIDs(c)
+		if nerr != nil {
+			return nerr
+		}
+
+		action := &subscribermodels.SubscriberGroupAction{}
+		if err := c.Bind(action); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if err := action.ValidateModel(); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if nerr := validateGroupAction(networkID, action); nerr != nil {
+			return nerr
+		}
+
+		group, err := loadSubscriberGroup(networkID, groupID)
+		if err != nil {
+			return makeErr(err)
+		}
+		imsis := make([]string, 0, len(group.Subscribers))
+		for _, sid := range group.Subscribers {
+			imsis = append(imsis, string(sid))
+		}
+
+		job := &subscribermodels.SubscriberGroupJob{
+			ID:               (&storage.UUIDGenerator{}).New(),
+			GroupID:          group.ID,
+			Action:           action.Action,
+			State:            subscribermodels.SubscriberGroupJobStateRUNNING,
+			StartedAt:        strfmt.DateTime(clock.Now()),
+			TotalSubscribers: uint32(len(imsis)),
+		}
+		if err := jobStorage.DeleteExpiredJobs(networkID, subscriberdb_storage.GroupJobType); err != nil {
+			glog.Errorf("Error deleting expired subscriber group jobs of network %s: %v", networkID, err)
+		}
+
+		if len(imsis) > maxSyncGroupActionSize {
+			if err := storeGroupJob(jobStorage, networkID, job); err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			ret := *job
+
+			go runGroupActionJob(jobStorage, networkID, job, action, imsis)
+			return c.JSON(http.StatusAccepted, &ret)
+		}
+
+		writesBySub, subErrs, err := getGroupActionWrites(networkID, action, imsis)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		for _, imsi := range imsis {
+			if subErr, failed := subErrs[imsi]; failed {
+				return obsidian.HttpError(errors.Wrapf(subErr, "failed to apply action to subscriber %s", imsi), http.StatusBadRequest)
+			}
+		}
+		var writes []configurator.EntityWriteOperation
+		for _, imsi := range imsis {
+			writes = append(writes, writesBySub[imsi]...)
+		}
+		if len(writes) != 0 {
+			if err := configurator.WriteEntities(networkID, writes, serdes.Entity); err != nil {
+				return obsidian.HttpError(errors.Wrap(err, "failed to update subscribers"), http.StatusInternalServerError)
+			}
+		}
+
+		job.State = subscribermodels.SubscriberGroupJobStateCOMPLETED
+		job.ProcessedSubscribers = uint32(len(imsis))
+		job.UpdatedSubscribers = uint32(len(imsis))
+		job.FinishedAt = strfmt.DateTime(clock.Now())
+		if err := storeGroupJob(jobStorage, networkID, job); err != nil {
+			glog.Errorf("Error storing result of subscriber group job %s of network %s: %v", job.ID, networkID, err)
+		}
+		return c.JSON(http.StatusOK, job)
+	}
+}
+
+func makeGetSubscriberGroupJobHandler(jobStorage subscriberdb_storage.JobStorage) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		vals, nerr := obsidian.GetParamValues(c, "network_id", "group_id", "job_id")
+		if nerr != nil {
+			return nerr
+		}
+
+		marshaled, err := jobStorage.GetJob(vals[0], subscriberdb_storage.GroupJobType, vals[2])
+		if err != nil {
+			return makeErr(err)
+		}
+		job := &subscribermodels.SubscriberGroupJob{}
+		if err := job.UnmarshalBinary(marshaled); err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		if string(job.GroupID) != vals[1] {
+			return echo.ErrNotFound
+		}
+		return c.JSON(http.StatusOK, job)
+	}
+}
+
+func getNetworkAndGroupIDs(c echo.Context) (string, string, *echo.HTTPError) {
+	vals, err := obsidian.GetParamValues(c, "network_id", "group_id")
+	if err != nil {
+		return "", "", err
+	}
+	return vals[0], vals[1], nil
+}
+
+func loadSubscriberGroup(networkID, groupID string) (*subscribermodels.SubscriberGroup, error) {
+	ent, err := configurator.LoadEntity(networkID, lte.SubscriberGroupEntityType, groupID, subscriberGroupLoadCriteria, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	return (&subscribermodels.SubscriberGroup{}).FromEntity(ent), nil
+}
+
+// validateGroupSubscribers returns a bad request error if some of the
+// subscribers don't exist.
+func validateGroupSubscribers(networkID string, subscriberIDs []policymodels.SubscriberID) *echo.HTTPError {
+	var tks storage.TKs
+	for _, sid := range subscriberIDs {
+		tks = append(tks, storage.TypeAndKey{Type: lte.SubscriberEntityType, Key: string(sid)})
+	}
+	return validateEntitiesExist(networkID, tks, "subscribers")
+}
+
+// validateGroupAction returns a bad request error if the policies, APNs or
+// sub profile the action adds don't exist.
+func validateGroupAction(networkID string, action *subscribermodels.SubscriberGroupAction) *echo.HTTPError {
+	switch action.Action {
+	case subscribermodels.SubscriberGroupActionActionADDPOLICIES:
+		return validateEntitiesExist(networkID, action.Policies.ToTKs(), "policies")
+	case subscribermodels.SubscriberGroupActionActionADDAPNS:
+		return validateEntitiesExist(networkID, action.Apns.ToTKs(), "APNs")
+	case subscribermodels.SubscriberGroupActionActionSETSUBPROFILE:
+		return validateSubscriberProfile(networkID, &subscribermodels.LteSubscription{SubProfile: action.SubProfile})
+	}
+	return nil
+}
+
+func validateEntitiesExist(networkID string, tks storage.TKs, what string) *echo.HTTPError {
+	var missing []string
+	for start := 0; start < len(tks); start += groupActionBatchSize {
+		end := start + groupActionBatchSize
+		if end > len(tks) {
+			end = len(tks)
+		}
+		_, notFound, err := configurator.LoadEntities(networkID, nil, nil, nil, tks[start:end], configurator.EntityLoadCriteria{}, serdes.Entity)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		missing = append(missing, notFound.Keys()...)
+	}
+	if len(missing) != 0 {
+		return obsidian.HttpError(errors.Errorf("%s not found: %s", what, strings.Join(missing, ", ")), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// getGroupActionWrites returns the writes applying the action to each of the
+// subscribers, keyed by IMSI. Subscribers the action can't be applied to are
+// returned with their error instead. Subscribers added to APNs with static
+// IP pools are allocated static IPs from them, and subscribers removed from
+// APNs release their static IPs.
+func getGroupActionWrites(networkID string, action *subscribermodels.SubscriberGroupAction, imsis []string) (map[string][]configurator.EntityWriteOperation, map[string]error, error) {
+	writesBySub := map[string][]configurator.EntityWriteOperation{}
+	subErrs := map[string]error{}
+	var allocator *staticIPAllocator
+	switch action.Action {
+	case subscribermodels.SubscriberGroupActionActionADDAPNS, subscribermodels.SubscriberGroupActionActionREMOVEAPNS:
+		var err error
+		allocator, err = newStaticIPAllocator(networkID)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to load static IP pools")
+		}
+	}
+	for start := 0; start < len(imsis); start += groupActionBatchSize {
+		end := start + groupActionBatchSize
+		if end > len(imsis) {
+			end = len(imsis)
+		}
+
+		ents, profileEntsBySub, err := loadSubscriberEnts(networkID, imsis[start:end])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, ent := range ents {
+			sub, err := (&subscribermodels.MutableSubscriber{}).FromEnt(ent, profileEntsBySub[ent.GetTypeAndKey()])
+			if err != nil {
+				subErrs[ent.Key] = err
+				continue
+			}
+			if err := action.ApplyTo(sub); err != nil {
+				subErrs[ent.Key] = err
+				continue
+			}
+			var reservations []configurator.EntityWriteOperation
+			switch action.Action {
+			case subscribermodels.SubscriberGroupActionActionADDAPNS:
+				reservations, err = allocator.allocate(sub)
+				if err != nil {
+					subErrs[ent.Key] = err
+					continue
+				}
+			case subscribermodels.SubscriberGroupActionActionREMOVEAPNS:
+				reservations = allocator.reserve(sub)
+			}
+			writesBySub[ent.Key] = append(getSubscriberUpdates(ent, sub), reservations...)
+		}
+	}
+	for _, imsi := range imsis {
+		_, found := writesBySub[imsi]
+		if _, failed := subErrs[imsi]; !found && !failed {
+			subErrs[imsi] = errors.New("subscriber not found")
+		}
+	}
+	return writesBySub, subErrs, nil
+}
+
+// runGroupActionJob applies the action to the subscribers in batches, one
+// transaction per batch, storing the progress of the job after each batch.
+func runGroupActionJob(jobStorage subscriberdb_storage.JobStorage, networkID string, job *subscribermodels.SubscriberGroupJob, action *subscribermodels.SubscriberGroupAction, imsis []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Errorf("Subscriber group job %s of network %s panicked: %v", job.ID, networkID, r)
+			failGroupJob(jobStorage, networkID, job, fmt.Sprintf("internal error: %v", r))
+		}
+	}()
+
+	for start := 0; start < len(imsis); start += groupActionBatchSize {
+		end := start + groupActionBatchSize
+		if end > len(imsis) {
+			end = len(imsis)
+		}
+		batch := imsis[start:end]
+
+		writesBySub, subErrs, err := getGroupActionWrites(networkID, action, batch)
+		if err != nil {
+			failGroupJob(jobStorage, networkID, job, err.Error())
+			return
+		}
+		applyGroupActionBatch(networkID, job, batch, writesBySub, subErrs)
+
+		job.ProcessedSubscribers = uint32(end)
+		if err := storeGroupJob(jobStorage, networkID, job); err != nil {
+			glog.Errorf("Error storing progress of subscriber group job %s of network %s: %v", job.ID, networkID, err)
+		}
+	}
+
+	job.State = subscribermodels.SubscriberGroupJobStateCOMPLETED
+	job.FinishedAt = strfmt.DateTime(clock.Now())
+	if err := storeGroupJob(jobStorage, networkID, job); err != nil {
+		glog.Errorf("Error storing result of subscriber group job %s of network %s: %v", job.ID, networkID, err)
+	}
+}
+
+// applyGroupActionBatch writes the updates of the batch in a single
+// transaction. If the transaction fails, the subscribers are updated one at
+// a time to find which subscribers are at fault.
+func applyGroupActionBatch(networkID string, job *subscribermodels.SubscriberGroupJob, batch []string, writesBySub map[string][]configurator.EntityWriteOperation, subErrs map[string]error) {
+	var updated []string
+	var writes []configurator.EntityWriteOperation
+	for _, imsi := range batch {
+		if subErr, failed := subErrs[imsi]; failed {
+			addGroupJobError(job, imsi, subErr)
+			continue
+		}
+		updated = append(updated, imsi)
+		writes = append(writes, writesBySub[imsi]...)
+	}
+	if len(writes) == 0 {
+		return
+	}
+
+	if err := configurator.WriteEntities(networkID, writes, serdes.Entity); err == nil {
+		job.UpdatedSubscribers += uint32(len(updated))
+		return
+	}
+	for _, imsi := range updated {
+		if err := configurator.WriteEntities(networkID, writesBySub[imsi], serdes.Entity); err != nil {
+			addGroupJobError(job, imsi, err)
+			continue
+		}
+		job.UpdatedSubscribers++
+	}
+}
+
+func addGroupJobError(job *subscribermodels.SubscriberGroupJob, imsi string, err error) {
+	job.FailedSubscribers++
+	if len(job.Errors) >= maxGroupJobErrors {
+		job.ErrorsTruncated = true
+		return
+	}
+	job.Errors = append(job.Errors, &subscribermodels.SubscriberGroupJobError{SubscriberID: imsi, Message: err.Error()})
+}
+
+func failGroupJob(jobStorage subscriberdb_storage.JobStorage, networkID string, job *subscribermodels.SubscriberGroupJob, message string) {
+	job.State = subscribermodels.SubscriberGroupJobStateFAILED
+	job.Message = message
+	job.FinishedAt = strfmt.DateTime(clock.Now())
+	if err := storeGroupJob(jobStorage, networkID, job); err != nil {
+		glog.Errorf("Error storing failure of subscriber group job %s of network %s: %v", job.ID, networkID, err)
+	}
+}
+
+func storeGroupJob(jobStorage subscriberdb_storage.JobStorage, networkID string, job *subscribermodels.SubscriberGroupJob) error {
+	marshaled, err := job.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return jobStorage.StoreJob(networkID, subscriberdb_storage.GroupJobType, job.ID, marshaled)
+}
@@ -0,0 +1,425 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	policydbModels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	"magma/lte/cloud/go/services/subscriberdb/obsidian/handlers"
+	subscriberModels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testGroupsPath       = "/magma/v1/lte/:network_id/subscriber_groups"
+	testGroupPath        = testGroupsPath + "/:group_id"
+	testGroupMembersPath = testGroupPath + "/subscribers"
+	testGroupMemberPath  = testGroupMembersPath + "/:subscriber_id"
+	testGroupActionsPath = testGroupPath + "/actions"
+	testGroupJobPath     = testGroupPath + "/jobs/:job_id"
+)
+
+func TestSubscriberGroups(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n0"}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n0",
+		[]configurator.NetworkEntity{
+			{Type: lte.APNEntityType, Key: "apn0"},
+			{Type: lte.APNEntityType, Key: "apn1"},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+	createTestGroupSubscribers(t, "IMSI001010000000001", "IMSI001010000000002", "IMSI001010000000003")
+
+	e := echo.New()
+	groupHandlers := handlers.GetGroupHandlers(newTestJobStorage(t))
+	listGroups := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupsPath, obsidian.GET).HandlerFunc
+	createGroup := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupsPath, obsidian.POST).HandlerFunc
+	getGroup := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupPath, obsidian.GET).HandlerFunc
+	updateGroup := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupPath, obsidian.PUT).HandlerFunc
+	deleteGroup := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupPath, obsidian.DELETE).HandlerFunc
+	addMembers := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupMembersPath, obsidian.POST).HandlerFunc
+	removeMember := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupMemberPath, obsidian.DELETE).HandlerFunc
+	putSubscriber := tests.GetHandlerByPathAndMethod(t, handlers.GetHandlers(nil), "/magma/v1/lte/:network_id/subscribers/:subscriber_id", obsidian.PUT).HandlerFunc
+
+	// Empty list
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/subscriber_groups",
+		Handler:        listGroups,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.SubscriberGroup{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Unknown subscriber
+	group := &subscriberModels.SubscriberGroup{
+		ID:          "acme",
+		Name:        "ACME Corp.",
+		Description: "Corporate SIMs",
+		Subscribers: []policydbModels.SubscriberID{"IMSI001010000000001", "IMSI001010000000009"},
+	}
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n0/subscriber_groups",
+		Payload:        group,
+		Handler:        createGroup,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 400,
+		ExpectedError:  "subscribers not found: IMSI001010000000009",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Invalid ID
+	group.ID = "ac me"
+	group.Subscribers = []policydbModels.SubscriberID{"IMSI001010000000002", "IMSI001010000000001"}
+	tc.ExpectedErrorSubstring, tc.ExpectedError = "id in body should match", ""
+	tests.RunUnitTest(t, e, tc)
+
+	// Successful create
+	group.ID = "acme"
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n0/subscriber_groups",
+		Payload:        group,
+		Handler:        createGroup,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Duplicate create
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "subscriber group acme already exists"
+	tests.RunUnitTest(t, e, tc)
+
+	// Subscribers are sorted
+	group.Subscribers = []policydbModels.SubscriberID{"IMSI001010000000001", "IMSI001010000000002"}
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/subscriber_groups",
+		Handler:        listGroups,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.SubscriberGroup{"acme": group}),
+	}
+	tests.RunUnitTest(t, e, tc)
+	expectGroup(t, getGroup, group)
+
+	// Updating a subscriber keeps its group membership
+	mutableSub := newMutableSubscriber("IMSI001010000000001")
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            "/magma/v1/lte/n0/subscribers/IMSI001010000000001",
+		Payload:        mutableSub,
+		Handler:        putSubscriber,
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n0", "IMSI001010000000001"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	expectGroup(t, getGroup, group)
+
+	// ID mismatch
+	group.Subscribers = []policydbModels.SubscriberID{"IMSI001010000000003"}
+	group.Name = "ACME Corporation"
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            "/magma/v1/lte/n0/subscriber_groups/other",
+		Payload:        group,
+		Handler:        updateGroup,
+		ParamNames:     []string{"network_id", "group_id"},
+		ParamValues:    []string{"n0", "other"},
+		ExpectedStatus: 400,
+		ExpectedError:  "subscriber group ID from parameters (other) and payload (acme) must match",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Successful update replaces the subscribers
+	tc.URL = "/magma/v1/lte/n0/subscriber_groups/acme"
+	tc.ParamValues = []string{"n0", "acme"}
+	tc.ExpectedStatus, tc.ExpectedError = 204, ""
+	tests.RunUnitTest(t, e, tc)
+	expectGroup(t, getGroup, group)
+
+	// Add subscribers
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n0/subscriber_groups/acme/subscribers",
+		Payload:        tests.JSONMarshaler([]string{"IMSI001010000000001", "IMSI001010000000003"}),
+		Handler:        addMembers,
+		ParamNames:     []string{"network_id", "group_id"},
+		ParamValues:    []string{"n0", "acme"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	group.Subscribers = []policydbModels.SubscriberID{"IMSI001010000000001", "IMSI001010000000003"}
+	expectGroup(t, getGroup, group)
+
+	tc.Payload = tests.JSONMarshaler([]string{"IMSI001010000000009"})
+	tc.ExpectedStatus, tc.ExpectedError = 400, "subscribers not found: IMSI001010000000009"
+	tests.RunUnitTest(t, e, tc)
+
+	// Remove subscribers
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            "/magma/v1/lte/n0/subscriber_groups/acme/subscribers/IMSI001010000000003",
+		Handler:        removeMember,
+		ParamNames:     []string{"network_id", "group_id", "subscriber_id"},
+		ParamValues:    []string{"n0", "acme", "IMSI001010000000003"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	group.Subscribers = []policydbModels.SubscriberID{"IMSI001010000000001"}
+	expectGroup(t, getGroup, group)
+
+	tc.ExpectedStatus, tc.ExpectedError = 404, "Not Found"
+	tests.RunUnitTest(t, e, tc)
+
+	// Delete the group, its subscribers are kept
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            "/magma/v1/lte/n0/subscriber_groups/acme",
+		Handler:        deleteGroup,
+		ParamNames:     []string{"network_id", "group_id"},
+		ParamValues:    []string{"n0", "acme"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/subscriber_groups/acme",
+		Handler:        getGroup,
+		ParamNames:     []string{"network_id", "group_id"},
+		ParamValues:    []string{"n0", "acme"},
+		ExpectedStatus: 404,
+		ExpectedError:  "Not Found",
+	}
+	tests.RunUnitTest(t, e, tc)
+	exists, err := configurator.DoesEntityExist("n0", lte.SubscriberEntityType, "IMSI001010000000001")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestSubscriberGroupActions(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n0"}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n0",
+		[]configurator.NetworkEntity{
+			{Type: lte.APNEntityType, Key: "apn0"},
+			{Type: lte.APNEntityType, Key: "apn1"},
+			{Type: lte.PolicyRuleEntityType, Key: "rule0"},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+	createTestGroupSubscribers(t, "IMSI001010000000001", "IMSI001010000000002")
+	_, err = configurator.CreateEntity("n0", (&subscriberModels.SubscriberGroup{
+		ID:          "acme",
+		Subscribers: []policydbModels.SubscriberID{"IMSI001010000000001", "IMSI001010000000002"},
+	}).ToEntity(), serdes.Entity)
+	assert.NoError(t, err)
+
+	groupHandlers := handlers.GetGroupHandlers(newTestJobStorage(t))
+	applyAction := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupActionsPath, obsidian.POST).HandlerFunc
+	getJob := tests.GetHandlerByPathAndMethod(t, groupHandlers, testGroupJobPath, obsidian.GET).HandlerFunc
+
+	// Small groups are updated synchronously
+	job := postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "DEACTIVATE"}, http.StatusOK)
+	assert.Equal(t, subscriberModels.SubscriberGroupJobStateCOMPLETED, job.State)
+	assert.Equal(t, uint32(2), job.TotalSubscribers)
+	assert.Equal(t, uint32(2), job.UpdatedSubscribers)
+	for _, imsi := range []string{"IMSI001010000000001", "IMSI001010000000002"} {
+		sub := loadTestGroupSubscriber(t, imsi)
+		assert.Equal(t, "INACTIVE", sub.Lte.State)
+		// Auth keys are kept
+		assert.Equal(t, []byte("\x00\x11\x22\x33\x44\x55\x66\x77\x88\x99\xaa\xbb\xcc\xdd\xee\xff"), []byte(sub.Lte.AuthKey))
+	}
+	assert.Equal(t, job, getGroupJob(t, getJob, "acme", job.ID))
+
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "ACTIVATE"}, http.StatusOK)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "ADD_POLICIES", Policies: policydbModels.PolicyIds{"rule0"}}, http.StatusOK)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "ADD_APNS", Apns: subscriberModels.ApnList{"apn1"}}, http.StatusOK)
+	sub := loadTestGroupSubscriber(t, "IMSI001010000000001")
+	assert.Equal(t, "ACTIVE", sub.Lte.State)
+	assert.Equal(t, policydbModels.PolicyIds{"rule0"}, sub.ActivePolicies)
+	assert.ElementsMatch(t, subscriberModels.ApnList{"apn0", "apn1"}, sub.ActiveApns)
+
+	// Removing an APN removes its static IP & policies
+	_, err = configurator.WriteEntities("n0", []configurator.EntityWriteOperation{
+		configurator.EntityUpdateCriteria{
+			Type: lte.SubscriberEntityType, Key: "IMSI001010000000001",
+			NewConfig: &subscriberModels.SubscriberConfig{Lte: sub.Lte, StaticIps: subscriberModels.SubscriberStaticIps{"apn1": "10.0.0.1"}},
+		},
+	}, serdes.Entity), nil
+	assert.NoError(t, err)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "REMOVE_APNS", Apns: subscriberModels.ApnList{"apn1"}}, http.StatusOK)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "REMOVE_POLICIES", Policies: policydbModels.PolicyIds{"rule0"}}, http.StatusOK)
+	sub = loadTestGroupSubscriber(t, "IMSI001010000000001")
+	assert.Equal(t, subscriberModels.ApnList{"apn0"}, sub.ActiveApns)
+	assert.Empty(t, sub.StaticIps)
+	assert.Empty(t, sub.ActivePolicies)
+
+	// Invalid actions
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "DELETE"}, http.StatusBadRequest)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "ADD_POLICIES"}, http.StatusBadRequest)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "ADD_POLICIES", Policies: policydbModels.PolicyIds{"ruleXXX"}}, http.StatusBadRequest)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "ADD_APNS", Apns: subscriberModels.ApnList{"apnXXX"}}, http.StatusBadRequest)
+	postGroupAction(t, applyAction, "acme", &subscriberModels.SubscriberGroupAction{Action: "SET_SUB_PROFILE", SubProfile: "gold"}, http.StatusInternalServerError)
+	postGroupAction(t, applyAction, "other", &subscriberModels.SubscriberGroupAction{Action: "ACTIVATE"}, http.StatusNotFound)
+
+	// Large groups are updated by a job
+	handlers.SetMaxSyncGroupActionSizeForTest(t, 100)
+	defer handlers.SetMaxSyncGroupActionSizeForTest(t, 0)
+	var imsis []string
+	var members []policydbModels.SubscriberID
+	for i := 0; i < 150; i++ {
+		imsi := fmt.Sprintf("IMSI00102%010d", i)
+		imsis = append(imsis, imsi)
+		members = append(members, policydbModels.SubscriberID(imsi))
+	}
+	createTestGroupSubscribers(t, imsis...)
+	_, err = configurator.CreateEntity("n0", (&subscriberModels.SubscriberGroup{ID: "large", Subscribers: members}).ToEntity(), serdes.Entity)
+	assert.NoError(t, err)
+
+	job = postGroupAction(t, applyAction, "large", &subscriberModels.SubscriberGroupAction{Action: "SET_SUB_PROFILE", SubProfile: "default"}, http.StatusAccepted)
+	assert.Equal(t, subscriberModels.SubscriberGroupJobStateRUNNING, job.State)
+	assert.Equal(t, uint32(150), job.TotalSubscribers)
+	assert.Eventually(t, func() bool {
+		job = getGroupJob(t, getJob, "large", job.ID)
+		return job.State != subscriberModels.SubscriberGroupJobStateRUNNING
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.Equal(t, subscriberModels.SubscriberGroupJobStateCOMPLETED, job.State)
+	assert.Equal(t, uint32(150), job.ProcessedSubscribers)
+	assert.Equal(t, uint32(150), job.UpdatedSubscribers)
+	assert.Equal(t, uint32(0), job.FailedSubscribers)
+
+	// Jobs are scoped to their group
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/subscriber_groups/acme/jobs/" + job.ID,
+		Handler:        getJob,
+		ParamNames:     []string{"network_id", "group_id", "job_id"},
+		ParamValues:    []string{"n0", "acme", job.ID},
+		ExpectedStatus: 404,
+		ExpectedError:  "Not Found",
+	}
+	tests.RunUnitTest(t, echo.New(), tc)
+}
+
+func createTestGroupSubscribers(t *testing.T, imsis ...string) {
+	var ents []configurator.NetworkEntity
+	for _, imsi := range imsis {
+		ents = append(ents, configurator.NetworkEntity{
+			Type: lte.SubscriberEntityType,
+			Key:  imsi,
+			Config: &subscriberModels.SubscriberConfig{
+				Lte: &subscriberModels.LteSubscription{
+					AuthAlgo:   "MILENAGE",
+					AuthKey:    []byte("\x00\x11\x22\x33\x44\x55\x66\x77\x88\x99\xaa\xbb\xcc\xdd\xee\xff"),
+					State:      "ACTIVE",
+					SubProfile: "default",
+				},
+			},
+			Associations: []storage.TypeAndKey{{Type: lte.APNEntityType, Key: "apn0"}},
+		})
+	}
+	_, err := configurator.CreateEntities("n0", ents, serdes.Entity)
+	assert.NoError(t, err)
+}
+
+func loadTestGroupSubscriber(t *testing.T, imsi string) *subscriberModels.MutableSubscriber {
+	ent, err := configurator.LoadEntity(
+		"n0", lte.SubscriberEntityType, imsi,
+		configurator.EntityLoadCriteria{LoadConfig: true, LoadAssocsFromThis: true},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+	sub, err := (&subscriberModels.MutableSubscriber{}).FromEnt(ent, nil)
+	assert.NoError(t, err)
+	return sub
+}
+
+func expectGroup(t *testing.T, getGroup echo.HandlerFunc, group *subscriberModels.SubscriberGroup) {
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/subscriber_groups/" + string(group.ID),
+		Handler:        getGroup,
+		ParamNames:     []string{"network_id", "group_id"},
+		ParamValues:    []string{"n0", string(group.ID)},
+		ExpectedStatus: 200,
+		ExpectedResult: group,
+	}
+	tests.RunUnitTest(t, echo.New(), tc)
+}
+
+func postGroupAction(t *testing.T, handler echo.HandlerFunc, groupID string, action *subscriberModels.SubscriberGroupAction, expectedStatus int) *subscriberModels.SubscriberGroupJob {
+	body, err := json.Marshal(action)
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/magma/v1/lte/n0/subscriber_groups/"+groupID+"/actions", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(req, recorder)
+	c.SetParamNames("network_id", "group_id")
+	c.SetParamValues("n0", groupID)
+	if err := handler(c); err != nil {
+		c.Error(err)
+	}
+	assert.Equal(t, expectedStatus, recorder.Code)
+	if expectedStatus != http.StatusOK && expectedStatus != http.StatusAccepted {
+		return nil
+	}
+
+	job := &subscriberModels.SubscriberGroupJob{}
+	assert.NoError(t, job.UnmarshalBinary(recorder.Body.Bytes()))
+	return job
+}
+
+func getGroupJob(t *testing.T, handler echo.HandlerFunc, groupID, jobID string) *subscriberModels.SubscriberGroupJob {
+	req := httptest.NewRequest(http.MethodGet, "/magma/v1/lte/n0/subscriber_groups/"+groupID+"/jobs/"+jobID, nil)
+	recorder := httptest.NewRecorder()
+	c := echo.New().NewContext(req, recorder)
+	c.SetParamNames("network_id", "group_id", "job_id")
+	c.SetParamValues("n0", groupID, jobID)
+	assert.NoError(t, handler(c))
+	job := &subscriberModels.SubscriberGroupJob{}
+	assert.NoError(t, job.UnmarshalBinary(recorder.Body.Bytes()))
+	return job
+}
@@ -25,6 +25,7 @@ import (
 	ltemodels "magma/lte/cloud/go/services/lte/obsidian/models"
 	policydbmodels "magma/lte/cloud/go/services/policydb/obsidian/models"
 	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	subscribermodels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/orc8r"
@@ -56,19 +57,28 @@ const (
 	listMSISDNsPath   = ltehandlers.ManageNetworkPath + obsidian.UrlSep + "msisdns"
 	manageMSISDNsPath = listMSISDNsPath + obsidian.UrlSep + ":msisdn"
 
-	ParamMSISDN    = "msisdn"
-	ParamIP        = "ip"
-	ParamPageSize  = "page_size"
-	ParamPageToken = "page_token"
+	ParamMSISDN       = "msisdn"
+	ParamMSISDNPrefix = "msisdn_prefix"
+	ParamIP           = "ip"
+	ParamAPN          = "apn"
+	ParamPolicy       = "policy"
+	ParamState        = "state"
+	ParamName         = "name"
+	ParamSortBy       = "sort_by"
+	ParamSortOrder    = "sort_order"
+	ParamPageSize     = "page_size"
+	ParamPageToken    = "page_token"
 )
 
-func GetHandlers() []obsidian.Handler {
+// GetHandlers returns the subscriber handlers. The auth keys of created &
+// updated subscribers are encrypted by the keyring, and are never returned.
+func GetHandlers(keyring *crypto.Keyring) []obsidian.Handler {
 	ret := []obsidian.Handler{
 		{Path: ListSubscribersPath, Methods: obsidian.GET, HandlerFunc: listSubscribersHandler},
 		{Path: ListSubscribersV2Path, Methods: obsidian.GET, HandlerFunc: listSubscribersV2Handler},
-		{Path: ListSubscribersPath, Methods: obsidian.POST, HandlerFunc: createSubscriberHandler},
+		{Path: ListSubscribersPath, Methods: obsidian.POST, HandlerFunc: makeCreateSubscriberHandler(keyring)},
 		{Path: ManageSubscriberPath, Methods: obsidian.GET, HandlerFunc: getSubscriberHandler},
-		{Path: ManageSubscriberPath, Methods: obsidian.PUT, HandlerFunc: updateSubscriberHandler},
+		{Path: ManageSubscriberPath, Methods: obsidian.PUT, HandlerFunc: makeUpdateSubscriberHandler(keyring)},
 		{Path: ManageSubscriberPath, Methods: obsidian.DELETE, HandlerFunc: deleteSubscriberHandler},
 
 		{Path: ListSubscriberStatePath, Methods: obsidian.GET, HandlerFunc: listSubscriberStateHandler},
@@ -182,15 +192,34 @@ func listSubscribersHandler(c echo.Context) error {
 // The returned subscribers can be filtered using the following query
 // parameters
 //	- msisdn
+//	- msisdn_prefix
 //	- ip
+//	- apn
+//	- policy
+//	- state
+//	- name
 //
-// The MSISDN parameter is config-based, and is enforced to be a unique
-// identifier.
+// The MSISDN, MSISDN prefix and IP parameters are served by the subscriberdb
+// lookup tables. The IP->IMSI mapping is cached as the output of a mobilityd
+// state indexer, then each reported subscriber is checked to ensure it
+// actually is assigned the requested IP.
 //
-// The IP parameter is state-based, and not guaranteed to be unique. The
-// IP->IMSI mapping is cached as the output of a mobilityd state indexer, then
-// each reported subscriber is checked to ensure it actually is assigned the
-// requested IP.
+// The APN and policy parameters are served by the associations of the
+// configurator entities.
+//
+// The state and name parameters are not indexed, so they require one of the
+// other parameters. The subscribers matched by the other parameters are
+// scanned until the page is full. A page scans at most 10000 subscribers, so
+// it can hold fewer subscribers than the page size while still having a next
+// page token.
+//
+// The returned subscribers can be sorted using the following parameters
+//  - sort_by (id, name or state)
+//  - sort_order (asc or desc)
+//
+// Sorting by anything other than ascending ID requires an indexed parameter,
+// and loads all the subscribers it matches, so it is limited to indexed
+// parameters matching at most 10000 subscribers.
 //
 // The returned subscribers can be paginated using the following parameters
 //  - page_size
@@ -219,67 +248,52 @@ func listSubscribersV2Handler(c echo.Context) error {
 	}
 	pageToken := c.QueryParam(ParamPageToken)
 
-	// First check for query params to filter by
-	if msisdn := c.QueryParam(ParamMSISDN); msisdn != "" {
-		queryIMSI, err := subscriberdb.GetIMSIForMSISDN(networkID, msisdn)
-		if err != nil {
-			return makeErr(err)
-		}
-		subs, err := loadSubscribers(networkID, acceptAll, queryIMSI)
-		if err != nil {
-			return makeErr(err)
-		}
-		return c.JSON(http.StatusOK, subs)
-	}
-	if ip := c.QueryParam(ParamIP); ip != "" {
-		queryIMSIs, err := subscriberdb.GetIMSIsForIP(networkID, ip)
-		if err != nil {
-			return makeErr(err)
-		}
-		filter := func(sub *subscribermodels.Subscriber) bool { return sub.IsAssignedIP(ip) }
-		subs, err := loadSubscribers(networkID, filter, queryIMSIs...)
-		if err != nil {
-			return makeErr(err)
-		}
-		return c.JSON(http.StatusOK, subs)
+	query, err := getSubscriberQuery(c)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
 	}
 
-	// List subscribers for a given page. If no page is specified, the max
-	// size will be returned.
-	subs, nextPageToken, err := loadSubscriberPage(networkID, uint32(pageSize), pageToken)
+	paginatedSubs, err := listSubscriberPage(networkID, query, uint32(pageSize), pageToken)
 	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
-	}
-	paginatedSubs := subscribermodels.PaginatedSubscribers{
-		NextPageToken: subscribermodels.NextPageToken(nextPageToken),
-		Subscribers:   subs,
+		return err
 	}
 	return c.JSON(http.StatusOK, paginatedSubs)
 }
 
-func createSubscriberHandler(c echo.Context) error {
-	networkID, nerr := obsidian.GetNetworkId(c)
-	if nerr != nil {
-		return nerr
-	}
+func makeCreateSubscriberHandler(keyring *crypto.Keyring) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, nerr := obsidian.GetNetworkId(c)
+		if nerr != nil {
+			return nerr
+		}
 
-	payload := &subscribermodels.MutableSubscriber{}
-	if err := c.Bind(payload); err != nil {
-		return obsidian.HttpError(err, http.StatusBadRequest)
-	}
-	if err := payload.ValidateModel(); err != nil {
-		return obsidian.HttpError(err, http.StatusBadRequest)
-	}
-	if nerr := validateSubscriberProfile(networkID, payload.Lte); nerr != nil {
-		return nerr
-	}
+		payload := &subscribermodels.MutableSubscriber{}
+		if err := c.Bind(payload); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if err := payload.ValidateModel(); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if nerr := validateSubscriberProfile(networkID, payload.Lte); nerr != nil {
+			return nerr
+		}
+		nerr = writeWithStaticIPs(networkID, payload, func(reservations []configurator.EntityWriteOperation) *echo.HTTPError {
+			sub, err := encryptSubscriberKeys(keyring, networkID, payload)
+			if err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			err = createSubscriber(networkID, sub, reservations)
+			if err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			return nil
+		})
+		if nerr != nil {
+			return nerr
+		}
 
-	err := createSubscriber(networkID, payload)
-	if err != nil {
-		return obsidian.HttpError(err, http.StatusInternalServerError)
+		return c.NoContent(http.StatusCreated)
 	}
-
-	return c.NoContent(http.StatusCreated)
 }
 
 func getSubscriberHandler(c echo.Context) error {
@@ -287,41 +301,52 @@ func getSubscriberHandler(c echo.Context) error {
 	if nerr != nil {
 		return nerr
 	}
-	subs, err := loadSubscriber(networkID, subscriberID)
+	sub, err := loadSubscriber(networkID, subscriberID)
 	if err != nil {
 		return makeErr(err)
 	}
-	return c.JSON(http.StatusOK, subs)
+	return c.JSON(http.StatusOK, sub)
 }
 
-func updateSubscriberHandler(c echo.Context) error {
-	networkID, subscriberID, nerr := getNetworkAndSubIDs(c)
-	if nerr != nil {
-		return nerr
-	}
+func makeUpdateSubscriberHandler(keyring *crypto.Keyring) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, subscriberID, nerr := getNetworkAndSubIDs(c)
+		if nerr != nil {
+			return nerr
+		}
 
-	payload := &subscribermodels.MutableSubscriber{}
-	if err := c.Bind(payload); err != nil {
-		return obsidian.HttpError(err, http.StatusBadRequest)
-	}
-	if err := payload.ValidateModel(); err != nil {
-		return obsidian.HttpError(err, http.StatusBadRequest)
-	}
-	if string(payload.ID) != subscriberID {
-		err := fmt.Errorf("subscriber ID from parameters (%s) and payload (%s) must match", subscriberID, payload.ID)
-		return obsidian.HttpError(err, http.StatusBadRequest)
-	}
+		payload := &subscribermodels.MutableSubscriber{}
+		if err := c.Bind(payload); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if err := payload.ValidateModel(); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if string(payload.ID) != subscriberID {
+			err := fmt.Errorf("subscriber ID from parameters (%s) and payload (%s) must match", subscriberID, payload.ID)
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
 
-	if nerr := validateSubscriberProfile(networkID, payload.Lte); nerr != nil {
-		return nerr
-	}
+		if nerr := validateSubscriberProfile(networkID, payload.Lte); nerr != nil {
+			return nerr
+		}
+		nerr = writeWithStaticIPs(networkID, payload, func(reservations []configurator.EntityWriteOperation) *echo.HTTPError {
+			sub, err := encryptSubscriberKeys(keyring, networkID, payload)
+			if err != nil {
+				return obsidian.HttpError(err, http.StatusInternalServerError)
+			}
+			err = updateSubscriber(networkID, sub, reservations)
+			if err != nil {
+				return makeErr(err)
+			}
+			return nil
+		})
+		if nerr != nil {
+			return nerr
+		}
 
-	err := updateSubscriber(networkID, payload)
-	if err != nil {
-		return makeErr(err)
+		return c.NoContent(http.StatusNoContent)
 	}
-
-	return c.NoContent(http.StatusNoContent)
 }
 
 func deleteSubscriberHandler(c echo.Context) error {
@@ -561,6 +586,9 @@ func loadSubscriber(networkID, key string) (*subscribermodels.Subscriber, error)
 	if err != nil {
 		return nil, err
 	}
+	if mutableSub.Lte != nil {
+		mutableSub.Lte.RedactKeys()
+	}
 
 	states, err := state.SearchStates(networkID, allSubscriberStateTypes, nil, &key, serdes.State)
 	if err != nil {
@@ -602,6 +630,9 @@ func loadSubscriberPage(networkID string, pageSize uint32, pageToken string) (ma
 
 	subs := map[string]*subscribermodels.Subscriber{}
 	for _, mutableSub := range mutableSubs {
+		if mutableSub.Lte != nil {
+			mutableSub.Lte.RedactKeys()
+		}
 		sub := mutableSub.ToSubscriber()
 		sub.FillAugmentedFields(states[string(sub.ID)])
 		subs[string(sub.ID)] = sub
@@ -637,7 +668,72 @@ func loadMutableSubscriberPage(networkID string, pageSize uint32, pageToken stri
 	return subs, nextPageToken, nil
 }
 
-func createSubscriber(networkID string, sub *subscribermodels.MutableSubscriber) error {
+// loadSubscriberEnts loads the subscriber entities of the IMSIs, and their
+// apn_policy_profile entities keyed by subscriber. IMSIs of subscribers which
+// don't exist are ignored.
+func loadSubscriberEnts(networkID string, imsis []string) (configurator.NetworkEntities, map[storage.TypeAndKey]configurator.NetworkEntities, error) {
+	ents, _, err := configurator.LoadEntities(
+		networkID, nil, nil, nil,
+		storage.MakeTKs(lte.SubscriberEntityType, imsis),
+		getSubscriberLoadCriteria(0, ""),
+		serdes.Entity,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	var profileTKs storage.TKs
+	for _, ent := range ents {
+		profileTKs = append(profileTKs, ent.Associations.Filter(lte.APNPolicyProfileEntityType)...)
+	}
+	var profileEnts configurator.NetworkEntities
+	if len(profileTKs) != 0 {
+		profileEnts, _, err = configurator.LoadEntities(networkID, nil, nil, nil, profileTKs, apnPolicyProfileLoadCriteria, serdes.Entity)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return ents, profileEnts.MakeByParentTK(), nil
+}
+
+// createSubscriber creates the subscriber, along with the reservations of
+// its static IPs.
+func createSubscriber(networkID string, sub *subscribermodels.MutableSubscriber, reservations []configurator.EntityWriteOperation) error {
+	var writes []configurator.EntityWriteOperation
+	for _, ent := range getSubscriberEntities(sub) {
+		writes = append(writes, ent)
+	}
+	writes = append(writes, reservations...)
+	err := configurator.WriteEntities(networkID, writes, serdes.Entity)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// encryptSubscriberKeys returns a copy of the subscriber with its auth keys
+// encrypted by the keyring.
+func encryptSubscriberKeys(keyring *crypto.Keyring, networkID string, sub *subscribermodels.MutableSubscriber) (*subscribermodels.MutableSubscriber, error) {
+	if sub.Lte == nil {
+		return sub, nil
+	}
+	lteSub := *sub.Lte
+	authKey, err := keyring.Encrypt(networkID, string(sub.ID), crypto.FieldAuthKey, lteSub.AuthKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt auth key")
+	}
+	authOpc, err := keyring.Encrypt(networkID, string(sub.ID), crypto.FieldAuthOpc, lteSub.AuthOpc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt auth OPc")
+	}
+	lteSub.AuthKey, lteSub.AuthOpc = authKey, authOpc
+
+	ret := *sub
+	ret.Lte = &lteSub
+	return &ret, nil
+}
+
+func getSubscriberEntities(sub *subscribermodels.MutableSubscriber) []configurator.NetworkEntity {
 	// New ents
 	//	- active_policies_by_apn
 	//		- Assocs: policy_rule..., apn
@@ -658,18 +754,12 @@ func createSubscriber(networkID string, sub *subscribermodels.MutableSubscriber)
 	var ents []configurator.NetworkEntity
 	ents = append(ents, sub.ActivePoliciesByApn.ToEntities(subEnt.Key)...)
 	ents = append(ents, subEnt)
-
-	_, err := configurator.CreateEntities(networkID, ents, serdes.Entity)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return ents
 }
 
-func updateSubscriber(networkID string, sub *subscribermodels.MutableSubscriber) error {
-	var writes []configurator.EntityWriteOperation
-
+// updateSubscriber updates the subscriber, along with the reservations of
+// its static IPs.
+func updateSubscriber(networkID string, sub *subscribermodels.MutableSubscriber, reservations []configurator.EntityWriteOperation) error {
 	existingSub, err := configurator.LoadEntity(
 		networkID, lte.SubscriberEntityType, string(sub.ID),
 		configurator.EntityLoadCriteria{LoadMetadata: true, LoadConfig: true, LoadAssocsFromThis: true},
@@ -679,6 +769,20 @@ func updateSubscriber(networkID string, sub *subscribermodels.MutableSubscriber)
 		return err
 	}
 
+	writes := append(getSubscriberUpdates(existingSub, sub), reservations...)
+	err = configurator.WriteEntities(networkID, writes, serdes.Entity)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getSubscriberUpdates returns the writes replacing the existing subscriber
+// entity by the subscriber.
+func getSubscriberUpdates(existingSub configurator.NetworkEntity, sub *subscribermodels.MutableSubscriber) []configurator.EntityWriteOperation {
+	var writes []configurator.EntityWriteOperation
+
 	// For simplicity, delete all of subscriber's existing
 	// apn_policy_profile, then add new
 	policyMapTKs := existingSub.Associations.Filter(lte.APNPolicyProfileEntityType)
@@ -700,24 +804,26 @@ func updateSubscriber(networkID string, sub *subscribermodels.MutableSubscriber)
 		AssociationsToSet: sub.GetAssocs(),
 	}
 	writes = append(writes, subUpdate)
-
-	err = configurator.WriteEntities(networkID, writes, serdes.Entity)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return writes
 }
 
+// deleteSubscriber deletes the subscriber, releasing the reservations of its
+// static IPs.
 func deleteSubscriber(networkID, key string) error {
 	ent, err := configurator.LoadEntity(
 		networkID, lte.SubscriberEntityType, key,
-		configurator.EntityLoadCriteria{LoadAssocsFromThis: true},
+		configurator.EntityLoadCriteria{LoadConfig: true, LoadAssocsFromThis: true},
 		serdes.Entity,
 	)
 	if err != nil {
 		return err
 	}
+	var staticIPs subscribermodels.SubscriberStaticIps
+	if config, ok := ent.Config.(*subscribermodels.SubscriberConfig); ok {
+		staticIPs = config.StaticIps
+	}
+	// Only the associations of the subscriber are needed to delete it
+	ent.Config = nil
 	// Configurator doesn't currently support loading a specified subgraph,
 	// so we have to load the subscriber and its apn_policy_profile ents in
 	// separate calls.
@@ -742,6 +848,11 @@ func deleteSubscriber(networkID, key string) error {
 	var deletes []storage.TypeAndKey
 	deletes = append(deletes, sub.ToTK())
 	deletes = append(deletes, sub.ActivePoliciesByApn.ToTKs(string(sub.ID))...)
+	reservationTKs, err := loadSubscriberReservationTKs(networkID, key, staticIPs)
+	if err != nil {
+		return err
+	}
+	deletes = append(deletes, reservationTKs...)
 
 	err = configurator.DeleteEntities(networkID, deletes)
 	if err != nil {
@@ -14,6 +14,9 @@
 package handlers_test
 
 import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
@@ -23,9 +26,13 @@ import (
 	lteModels "magma/lte/cloud/go/services/lte/obsidian/models"
 	policydbHandlers "magma/lte/cloud/go/services/policydb/obsidian/handlers"
 	policydbModels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	"magma/lte/cloud/go/services/subscriberdb/obsidian/handlers"
 	subscriberModels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	subscriberdbStorage "magma/lte/cloud/go/services/subscriberdb/storage"
 	subscriberdbTestInit "magma/lte/cloud/go/services/subscriberdb/test_init"
+	"magma/orc8r/cloud/go/blobstore"
 	"magma/orc8r/cloud/go/clock"
 	"magma/orc8r/cloud/go/obsidian"
 	"magma/orc8r/cloud/go/obsidian/tests"
@@ -39,6 +46,7 @@ import (
 	stateTestInit "magma/orc8r/cloud/go/services/state/test_init"
 	"magma/orc8r/cloud/go/services/state/test_utils"
 	stateTypes "magma/orc8r/cloud/go/services/state/types"
+	"magma/orc8r/cloud/go/sqorc"
 	"magma/orc8r/cloud/go/storage"
 
 	"github.com/go-openapi/swag"
@@ -54,7 +62,7 @@ func TestCreateSubscriber(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	createSubscriber := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot, obsidian.POST).HandlerFunc
 
 	//preseed 2 apns
@@ -245,7 +253,7 @@ func TestListSubscribers(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	listSubscribers := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot, obsidian.GET).HandlerFunc
 
 	//preseed 2 apns
@@ -317,16 +325,12 @@ func TestListSubscribers(t *testing.T) {
 				ID: "IMSI1234567890",
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-					AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 					State:      "ACTIVE",
 					SubProfile: "default",
 				},
 				Config: &subscriberModels.SubscriberConfig{
 					Lte: &subscriberModels.LteSubscription{
 						AuthAlgo:   "MILENAGE",
-						AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-						AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 						State:      "ACTIVE",
 						SubProfile: "default",
 					},
@@ -338,16 +342,12 @@ func TestListSubscribers(t *testing.T) {
 				ID: "IMSI0987654321",
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-					AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 					State:      "ACTIVE",
 					SubProfile: "foo",
 				},
 				Config: &subscriberModels.SubscriberConfig{
 					Lte: &subscriberModels.LteSubscription{
 						AuthAlgo:   "MILENAGE",
-						AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-						AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 						State:      "ACTIVE",
 						SubProfile: "foo",
 					},
@@ -407,16 +407,12 @@ func TestListSubscribers(t *testing.T) {
 				ID: "IMSI1234567890",
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-					AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 					State:      "ACTIVE",
 					SubProfile: "default",
 				},
 				Config: &subscriberModels.SubscriberConfig{
 					Lte: &subscriberModels.LteSubscription{
 						AuthAlgo:   "MILENAGE",
-						AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-						AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 						State:      "ACTIVE",
 						SubProfile: "default",
 					},
@@ -453,16 +449,12 @@ func TestListSubscribers(t *testing.T) {
 				ID: "IMSI0987654321",
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-					AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 					State:      "ACTIVE",
 					SubProfile: "foo",
 				},
 				Config: &subscriberModels.SubscriberConfig{
 					Lte: &subscriberModels.LteSubscription{
 						AuthAlgo:   "MILENAGE",
-						AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-						AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 						State:      "ACTIVE",
 						SubProfile: "foo",
 					},
@@ -483,7 +475,7 @@ func TestListSubscribersV2(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers_v2"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	listSubscribers := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot, obsidian.GET).HandlerFunc
 
 	// preseed 2 apns
@@ -596,16 +588,12 @@ func TestListSubscribersV2(t *testing.T) {
 			ID: "IMSI0987654321",
 			Lte: &subscriberModels.LteSubscription{
 				AuthAlgo:   "MILENAGE",
-				AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-				AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 				State:      "ACTIVE",
 				SubProfile: "foo",
 			},
 			Config: &subscriberModels.SubscriberConfig{
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-					AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 					State:      "ACTIVE",
 					SubProfile: "foo",
 				},
@@ -616,16 +604,12 @@ func TestListSubscribersV2(t *testing.T) {
 			ID: "IMSI0987654322",
 			Lte: &subscriberModels.LteSubscription{
 				AuthAlgo:   "MILENAGE",
-				AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-				AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 				State:      "ACTIVE",
 				SubProfile: "foo",
 			},
 			Config: &subscriberModels.SubscriberConfig{
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
-					AuthOpc:    []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22"),
 					State:      "ACTIVE",
 					SubProfile: "foo",
 				},
@@ -633,6 +617,7 @@ func TestListSubscribersV2(t *testing.T) {
 			ActiveApns: subscriberModels.ApnList{apn2},
 		},
 	}
+	expectedResult.SubscriberIds = []policydbModels.SubscriberID{"IMSI0987654321", "IMSI0987654322"}
 	expectedResult.NextPageToken = "Cg5JTVNJMDk4NzY1NDMyMg=="
 
 	// Test paginated requests
@@ -676,16 +661,12 @@ func TestListSubscribersV2(t *testing.T) {
 			ID: "IMSI1234567890",
 			Lte: &subscriberModels.LteSubscription{
 				AuthAlgo:   "MILENAGE",
-				AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-				AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 				State:      "ACTIVE",
 				SubProfile: "default",
 			},
 			Config: &subscriberModels.SubscriberConfig{
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-					AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 					State:      "ACTIVE",
 					SubProfile: "default",
 				},
@@ -719,6 +700,7 @@ func TestListSubscribersV2(t *testing.T) {
 			},
 		},
 	}
+	expectedResult.SubscriberIds = []policydbModels.SubscriberID{"IMSI1234567890"}
 	expectedResult.NextPageToken = ""
 	// Get last page of subscribers
 	tc = tests.Test{
@@ -733,6 +715,137 @@ func TestListSubscribersV2(t *testing.T) {
 	tests.RunUnitTest(t, e, tc)
 }
 
+func TestSearchSubscribersV2(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	stateTestInit.StartTestService(t)
+	subscriberdbTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n0"}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n0",
+		[]configurator.NetworkEntity{
+			{Type: lte.APNEntityType, Key: "apn0"},
+			{Type: lte.APNEntityType, Key: "apn1"},
+			{Type: lte.PolicyRuleEntityType, Key: "rule0"},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	urlBase := "/magma/v1/lte/:network_id/subscribers"
+	urlV2 := "/magma/v1/lte/:network_id/subscribers_v2"
+	subscriberdbHandlers := handlers.GetHandlers(nil)
+	postSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlBase, obsidian.POST).HandlerFunc
+	listSubscribers := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlV2, obsidian.GET).HandlerFunc
+
+	// sub0 has rule0 active for all APNs, sub2 has rule0 active for apn1
+	sub0 := newMutableSubscriber("IMSI0000000000")
+	sub0.Name = "Alice"
+	sub0.ActivePolicies = policydbModels.PolicyIds{"rule0"}
+	sub1 := newMutableSubscriber("IMSI1111111111")
+	sub1.Name = "Bob"
+	sub1.Lte.State = "INACTIVE"
+	sub1.StaticIps = nil
+	sub1.ActiveApns = subscriberModels.ApnList{"apn0"}
+	sub2 := newMutableSubscriber("IMSI2222222222")
+	sub2.Name = "alina"
+	sub2.ActiveApns = subscriberModels.ApnList{"apn1"}
+	sub2.ActivePoliciesByApn = policydbModels.PolicyIdsByApn{"apn1": policydbModels.PolicyIds{"rule0"}}
+
+	for _, sub := range []*subscriberModels.MutableSubscriber{sub0, sub1, sub2} {
+		tc := tests.Test{
+			Method:         "POST",
+			URL:            "/magma/v1/lte/n0/subscribers",
+			Payload:        sub,
+			Handler:        postSubscriber,
+			ParamNames:     []string{"network_id"},
+			ParamValues:    []string{"n0"},
+			ExpectedStatus: 201,
+		}
+		tests.RunUnitTest(t, e, tc)
+	}
+	assert.NoError(t, subscriberdb.SetIMSIForMSISDN("n0", "1310000", string(sub0.ID)))
+	assert.NoError(t, subscriberdb.SetIMSIForMSISDN("n0", "1310111", string(sub1.ID)))
+	assert.NoError(t, subscriberdb.SetIMSIForMSISDN("n0", "1420222", string(sub2.ID)))
+
+	expectPage := func(nextPageToken string, subs ...*subscriberModels.MutableSubscriber) *subscriberModels.PaginatedSubscribers {
+		page := &subscriberModels.PaginatedSubscribers{
+			NextPageToken: subscriberModels.NextPageToken(nextPageToken),
+			Subscribers:   map[string]*subscriberModels.Subscriber{},
+		}
+		for _, sub := range subs {
+			page.Subscribers[string(sub.ID)] = toRedactedSubscriber(sub)
+			page.SubscriberIds = append(page.SubscriberIds, sub.ID)
+		}
+		return page
+	}
+	searchTests := []struct {
+		query    string
+		expected *subscriberModels.PaginatedSubscribers
+	}{
+		{query: "apn=apn0", expected: expectPage("", sub0, sub1)},
+		{query: "apn=apn0&state=ACTIVE", expected: expectPage("", sub0)},
+		{query: "apn=apnXXX", expected: expectPage("")},
+		{query: "policy=rule0", expected: expectPage("", sub0, sub2)},
+		{query: "policy=rule0&apn=apn1", expected: expectPage("", sub0, sub2)},
+		{query: "policy=rule0&apn=apn0", expected: expectPage("", sub0)},
+		{query: "policy=rule0&sort_by=name&sort_order=desc", expected: expectPage("", sub2, sub0)},
+		{query: "msisdn=1420222", expected: expectPage("", sub2)},
+		{query: "msisdn=1999999", expected: expectPage("")},
+		{query: "msisdn_prefix=1310", expected: expectPage("", sub0, sub1)},
+		{query: "msisdn_prefix=1310&sort_order=desc", expected: expectPage("", sub1, sub0)},
+		{query: "msisdn_prefix=1&sort_by=state", expected: expectPage("", sub0, sub2, sub1)},
+		{query: "msisdn_prefix=1&name=AL", expected: expectPage("", sub0, sub2)},
+		// Pages of an indexed search
+		{query: "msisdn_prefix=1310&page_size=1", expected: expectPage("MQ==", sub0)},
+		{query: "msisdn_prefix=1310&page_size=1&page_token=MQ==", expected: expectPage("", sub1)},
+		{query: "msisdn_prefix=1310&page_size=1&page_token=Mg==", expected: expectPage("")},
+		// Pages of an indexed search are filled past the non-matching subscribers
+		{query: "msisdn_prefix=1&state=ACTIVE&page_size=1", expected: expectPage("MQ==", sub0)},
+		{query: "msisdn_prefix=1&state=ACTIVE&page_size=1&page_token=MQ==", expected: expectPage("", sub2)},
+		{query: "msisdn_prefix=1&sort_by=name&page_size=1&page_token=MQ==", expected: expectPage("Mg==", sub1)},
+	}
+	for _, st := range searchTests {
+		tc := tests.Test{
+			Method:         "GET",
+			URL:            "/magma/v1/lte/n0/subscribers_v2?" + st.query,
+			Handler:        listSubscribers,
+			ParamNames:     []string{"network_id"},
+			ParamValues:    []string{"n0"},
+			ExpectedStatus: 200,
+			ExpectedResult: tests.JSONMarshaler(st.expected),
+		}
+		tests.RunUnitTest(t, e, tc)
+	}
+
+	invalidTests := []struct {
+		query         string
+		expectedError string
+	}{
+		{query: "state=UNKNOWN", expectedError: "invalid state 'UNKNOWN', expected ACTIVE or INACTIVE"},
+		{query: "apn=apn0&sort_by=msisdn", expectedError: "invalid sort field 'msisdn', expected id, name or state"},
+		{query: "apn=apn0&sort_order=up", expectedError: "invalid sort order 'up', expected asc or desc"},
+		{query: "sort_by=name", expectedError: "sorting other than by ascending id requires a msisdn, msisdn_prefix, ip, apn or policy filter"},
+		{query: "name=al", expectedError: "filtering by state or name requires a msisdn, msisdn_prefix, ip, apn or policy filter"},
+		{query: "state=INACTIVE", expectedError: "filtering by state or name requires a msisdn, msisdn_prefix, ip, apn or policy filter"},
+		{query: "apn=apn0&page_token=notanoffset", expectedError: "invalid page token"},
+	}
+	for _, it := range invalidTests {
+		tc := tests.Test{
+			Method:         "GET",
+			URL:            "/magma/v1/lte/n0/subscribers_v2?" + it.query,
+			Handler:        listSubscribers,
+			ParamNames:     []string{"network_id"},
+			ParamValues:    []string{"n0"},
+			ExpectedStatus: 400,
+			ExpectedError:  it.expectedError,
+		}
+		tests.RunUnitTest(t, e, tc)
+	}
+}
+
 func TestGetSubscriber(t *testing.T) {
 	configuratorTestInit.StartTestService(t)
 	deviceTestInit.StartTestService(t)
@@ -742,7 +855,7 @@ func TestGetSubscriber(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers/:subscriber_id"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	getSubscriber := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot, obsidian.GET).HandlerFunc
 
 	//preseed 2 apns
@@ -801,16 +914,12 @@ func TestGetSubscriber(t *testing.T) {
 			Name: "Jane Doe",
 			Lte: &subscriberModels.LteSubscription{
 				AuthAlgo:   "MILENAGE",
-				AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-				AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 				State:      "ACTIVE",
 				SubProfile: "default",
 			},
 			Config: &subscriberModels.SubscriberConfig{
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-					AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 					State:      "ACTIVE",
 					SubProfile: "default",
 				},
@@ -869,16 +978,12 @@ func TestGetSubscriber(t *testing.T) {
 			Name: "Jane Doe",
 			Lte: &subscriberModels.LteSubscription{
 				AuthAlgo:   "MILENAGE",
-				AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-				AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 				State:      "ACTIVE",
 				SubProfile: "default",
 			},
 			Config: &subscriberModels.SubscriberConfig{
 				Lte: &subscriberModels.LteSubscription{
 					AuthAlgo:   "MILENAGE",
-					AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
-					AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
 					State:      "ACTIVE",
 					SubProfile: "default",
 				},
@@ -924,7 +1029,7 @@ func TestListSubscriberStates(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscriber_state"
-	listSubscribers := tests.GetHandlerByPathAndMethod(t, handlers.GetHandlers(), testURLRoot, obsidian.GET).HandlerFunc
+	listSubscribers := tests.GetHandlerByPathAndMethod(t, handlers.GetHandlers(nil), testURLRoot, obsidian.GET).HandlerFunc
 
 	// Initially no state
 	tc := tests.Test{
@@ -1041,7 +1146,7 @@ func TestGetSubscriberState(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscriber_state/:subscriber_id"
-	getSubscriber := tests.GetHandlerByPathAndMethod(t, handlers.GetHandlers(), testURLRoot, obsidian.GET).HandlerFunc
+	getSubscriber := tests.GetHandlerByPathAndMethod(t, handlers.GetHandlers(nil), testURLRoot, obsidian.GET).HandlerFunc
 
 	// Initially no state
 	tc := tests.Test{
@@ -1140,7 +1245,7 @@ func TestGetSubscriberByMSISDN(t *testing.T) {
 	assert.NoError(t, err)
 
 	e := echo.New()
-	subscriberdbHandlers := handlers.GetHandlers()
+	subscriberdbHandlers := handlers.GetHandlers(nil)
 
 	subURLBase := "/magma/v1/lte/:network_id/subscribers"
 	getAllSubscribers := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, subURLBase, obsidian.GET).HandlerFunc
@@ -1298,7 +1403,7 @@ func TestGetSubscriberByIP(t *testing.T) {
 	assert.NoError(t, err)
 
 	e := echo.New()
-	subscriberdbHandlers := handlers.GetHandlers()
+	subscriberdbHandlers := handlers.GetHandlers(nil)
 
 	subURLBase := "/magma/v1/lte/:network_id/subscribers"
 	getAllSubscribers := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, subURLBase, obsidian.GET).HandlerFunc
@@ -1438,7 +1543,7 @@ func TestUpdateSubscriber(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers/:subscriber_id"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	updateSubscriber := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot, obsidian.PUT).HandlerFunc
 
 	//preseed 2 apns
@@ -1576,7 +1681,7 @@ func TestDeleteSubscriber(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers/:subscriber_id"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	deleteSubscriber := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot, obsidian.DELETE).HandlerFunc
 
 	//preseed 2 apns
@@ -1635,7 +1740,7 @@ func TestActivateDeactivateSubscriber(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers/:subscriber_id"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	activateSubscriber := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot+"/activate", obsidian.POST).HandlerFunc
 	deactivateSubscriber := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot+"/deactivate", obsidian.POST).HandlerFunc
 
@@ -1784,7 +1889,7 @@ func TestUpdateSubscriberProfile(t *testing.T) {
 
 	e := echo.New()
 	testURLRoot := "/magma/v1/lte/:network_id/subscribers/:subscriber_id/lte/sub_profile"
-	handlers := handlers.GetHandlers()
+	handlers := handlers.GetHandlers(nil)
 	updateProfile := tests.GetHandlerByPathAndMethod(t, handlers, testURLRoot, obsidian.PUT).HandlerFunc
 
 	// 404
@@ -1906,7 +2011,7 @@ func TestSubscriberBasename(t *testing.T) {
 	e := echo.New()
 	urlBase := "/magma/v1/lte/:network_id/subscribers"
 	urlManage := urlBase + "/:subscriber_id"
-	subscriberdbHandlers := handlers.GetHandlers()
+	subscriberdbHandlers := handlers.GetHandlers(nil)
 	getAllSubscribers := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlBase, obsidian.GET).HandlerFunc
 	postSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlBase, obsidian.POST).HandlerFunc
 	putSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlManage, obsidian.PUT).HandlerFunc
@@ -1949,7 +2054,7 @@ func TestSubscriberBasename(t *testing.T) {
 		ParamNames:     []string{"network_id"},
 		ParamValues:    []string{"n0"},
 		ExpectedStatus: 200,
-		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: mutableSub.ToSubscriber()}),
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: toRedactedSubscriber(mutableSub)}),
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -1988,7 +2093,7 @@ func TestSubscriberBasename(t *testing.T) {
 		ParamNames:     []string{"network_id"},
 		ParamValues:    []string{"n0"},
 		ExpectedStatus: 200,
-		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: mutableSub.ToSubscriber()}),
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: toRedactedSubscriber(mutableSub)}),
 	}
 	tests.RunUnitTest(t, e, tc)
 }
@@ -2014,7 +2119,7 @@ func TestSubscriberPolicy(t *testing.T) {
 	e := echo.New()
 	urlBase := "/magma/v1/lte/:network_id/subscribers"
 	urlManage := urlBase + "/:subscriber_id"
-	subscriberdbHandlers := handlers.GetHandlers()
+	subscriberdbHandlers := handlers.GetHandlers(nil)
 	getAllSubscribers := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlBase, obsidian.GET).HandlerFunc
 	postSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlBase, obsidian.POST).HandlerFunc
 	putSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlManage, obsidian.PUT).HandlerFunc
@@ -2057,7 +2162,7 @@ func TestSubscriberPolicy(t *testing.T) {
 		ParamNames:     []string{"network_id"},
 		ParamValues:    []string{"n0"},
 		ExpectedStatus: 200,
-		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: mutableSub.ToSubscriber()}),
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: toRedactedSubscriber(mutableSub)}),
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -2096,7 +2201,7 @@ func TestSubscriberPolicy(t *testing.T) {
 		ParamNames:     []string{"network_id"},
 		ParamValues:    []string{"n0"},
 		ExpectedStatus: 200,
-		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: mutableSub.ToSubscriber()}),
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: toRedactedSubscriber(mutableSub)}),
 	}
 	tests.RunUnitTest(t, e, tc)
 }
@@ -2122,7 +2227,7 @@ func TestAPNPolicyProfile(t *testing.T) {
 	e := echo.New()
 	urlBase := "/magma/v1/lte/:network_id/subscribers"
 	urlManage := urlBase + "/:subscriber_id"
-	subscriberdbHandlers := handlers.GetHandlers()
+	subscriberdbHandlers := handlers.GetHandlers(nil)
 	getAllSubscribers := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlBase, obsidian.GET).HandlerFunc
 	postSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlBase, obsidian.POST).HandlerFunc
 	putSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, urlManage, obsidian.PUT).HandlerFunc
@@ -2136,7 +2241,7 @@ func TestAPNPolicyProfile(t *testing.T) {
 	imsi := "IMSI1234567890"
 	imsi1 := "IMSI1234567800"
 	mutableSub := newMutableSubscriber(imsi)
-	sub := mutableSub.ToSubscriber()
+	sub := toRedactedSubscriber(mutableSub)
 
 	t.Run("dangling apn_policy_profile regression", func(t *testing.T) {
 		// Post policy
@@ -2296,7 +2401,7 @@ func TestAPNPolicyProfile(t *testing.T) {
 		ParamNames:     []string{"network_id"},
 		ParamValues:    []string{"n0"},
 		ExpectedStatus: 200,
-		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: mutableSub.ToSubscriber()}),
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.Subscriber{imsi: toRedactedSubscriber(mutableSub)}),
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -2357,7 +2462,7 @@ func TestAPNPolicyProfile(t *testing.T) {
 		ParamValues:    []string{"n0", imsi},
 		Handler:        getSubscriber,
 		ExpectedStatus: 200,
-		ExpectedResult: mutableSub.ToSubscriber(),
+		ExpectedResult: toRedactedSubscriber(mutableSub),
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -2424,7 +2529,7 @@ func TestAPNPolicyProfile(t *testing.T) {
 		ParamValues:    []string{"n0", imsi},
 		Handler:        getSubscriber,
 		ExpectedStatus: 200,
-		ExpectedResult: mutableSub.ToSubscriber(),
+		ExpectedResult: toRedactedSubscriber(mutableSub),
 	}
 	tests.RunUnitTest(t, e, tc)
 
@@ -2513,6 +2618,103 @@ func TestAPNPolicyProfile(t *testing.T) {
 	assert.Len(t, profiles, 2)
 }
 
+func TestSubscriberAuthKeyEncryption(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	subscriberdbTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1"}, serdes.Network)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	keyring := newTestKeyring(t)
+	subscriberdbHandlers := handlers.GetHandlers(keyring)
+	createSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, "/magma/v1/lte/:network_id/subscribers", obsidian.POST).HandlerFunc
+	getSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, "/magma/v1/lte/:network_id/subscribers/:subscriber_id", obsidian.GET).HandlerFunc
+	updateSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberdbHandlers, "/magma/v1/lte/:network_id/subscribers/:subscriber_id", obsidian.PUT).HandlerFunc
+
+	authKey := []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11")
+	authOpc := []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22")
+	getStoredKeys := func() ([]byte, []byte) {
+		ent, err := configurator.LoadEntity("n1", lte.SubscriberEntityType, "IMSI1234567890", configurator.EntityLoadCriteria{LoadConfig: true}, serdes.Entity)
+		assert.NoError(t, err)
+		cfg := ent.Config.(*subscriberModels.SubscriberConfig)
+		assert.True(t, crypto.IsEncrypted(cfg.Lte.AuthKey))
+		assert.True(t, crypto.IsEncrypted(cfg.Lte.AuthOpc))
+		key, err := keyring.Decrypt("n1", "IMSI1234567890", crypto.FieldAuthKey, cfg.Lte.AuthKey)
+		assert.NoError(t, err)
+		opc, err := keyring.Decrypt("n1", "IMSI1234567890", crypto.FieldAuthOpc, cfg.Lte.AuthOpc)
+		assert.NoError(t, err)
+		return key, opc
+	}
+
+	// Auth keys are stored encrypted
+	payload := &subscriberModels.MutableSubscriber{
+		ID: "IMSI1234567890",
+		Lte: &subscriberModels.LteSubscription{
+			AuthAlgo:   "MILENAGE",
+			AuthKey:    authKey,
+			AuthOpc:    authOpc,
+			State:      "ACTIVE",
+			SubProfile: "default",
+		},
+	}
+	tc := tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n1/subscribers",
+		Handler:        createSubscriber,
+		Payload:        payload,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+	key, opc := getStoredKeys()
+	assert.Equal(t, authKey, key)
+	assert.Equal(t, authOpc, opc)
+
+	// Auth keys are redacted
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n1/subscribers/IMSI1234567890",
+		Handler:        getSubscriber,
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n1", "IMSI1234567890"},
+		ExpectedStatus: 200,
+		ExpectedResult: toRedactedSubscriber(payload),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Auth keys can't be requested
+	tc.URL = "/magma/v1/lte/n1/subscribers/IMSI1234567890?include_keys=true"
+	tests.RunUnitTest(t, e, tc)
+
+	// Updates without auth keys are rejected
+	payload.Name = "Jane Doe"
+	payload.Lte.RedactKeys()
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            "/magma/v1/lte/n1/subscribers/IMSI1234567890",
+		Handler:        updateSubscriber,
+		Payload:        payload,
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n1", "IMSI1234567890"},
+		ExpectedStatus: 400,
+		ExpectedError:  "expected lte auth key to be 16 bytes but got 0 bytes",
+	}
+	tests.RunUnitTest(t, e, tc)
+	key, opc = getStoredKeys()
+	assert.Equal(t, authKey, key)
+	assert.Equal(t, authOpc, opc)
+
+	// Updates with auth keys replace them
+	payload.Lte.AuthKey, payload.Lte.AuthOpc = authOpc, authKey
+	tc.ExpectedStatus, tc.ExpectedError = 204, ""
+	tests.RunUnitTest(t, e, tc)
+	key, opc = getStoredKeys()
+	assert.Equal(t, authOpc, key)
+	assert.Equal(t, authKey, opc)
+}
+
 func f32Ptr(f float32) *float32 {
 	return &f
 }
@@ -2536,6 +2738,16 @@ func newMutableSubscriber(id string) *subscriberModels.MutableSubscriber {
 	return sub
 }
 
+// toRedactedSubscriber returns the subscriber as returned by the API, without
+// its auth keys.
+func toRedactedSubscriber(mutableSub *subscriberModels.MutableSubscriber) *subscriberModels.Subscriber {
+	sub := mutableSub.ToSubscriber()
+	redacted := *sub.Lte
+	redacted.RedactKeys()
+	sub.Lte, sub.Config.Lte = &redacted, &redacted
+	return sub
+}
+
 func newPolicy(id string) *policydbModels.PolicyRule {
 	policy := &policydbModels.PolicyRule{
 		ID: policydbModels.PolicyID(id),
@@ -2552,3 +2764,23 @@ func newPolicy(id string) *policydbModels.PolicyRule {
 	}
 	return policy
 }
+
+// newTestKeyring returns a keyring encrypting the auth keys with a test
+// master key.
+func newTestKeyring(t *testing.T) *crypto.Keyring {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	fact := blobstore.NewSQLBlobStorageFactory(subscriberdb.DataKeyBlobstore, db, sqorc.GetSqlBuilder())
+	assert.NoError(t, fact.InitializeFactory())
+
+	keyFile, err := ioutil.TempFile("", "magma_master_keys")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	_, err = keyFile.WriteString("key0 " + base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	assert.NoError(t, err)
+	assert.NoError(t, keyFile.Close())
+	provider, err := crypto.NewFileKeyProvider(keyFile.Name())
+	assert.NoError(t, err)
+
+	return crypto.NewKeyring(provider, subscriberdbStorage.NewDataKeyBlobstore(fact))
+}
@@ -0,0 +1,494 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	"magma/lte/cloud/go/services/subscriberdb"
+	subscribermodels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/services/configurator"
+	state_types "magma/orc8r/cloud/go/services/state/types"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+const (
+	SortByID    = "id"
+	SortByName  = "name"
+	SortByState = "state"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+
+	// searchLoadBatchSize bounds the number of subscribers loaded from
+	// configurator in a single call while searching.
+	searchLoadBatchSize = 100
+	// searchMaxScannedSubscribers bounds the number of subscribers scanned
+	// to fill a page of a search with a state, name or IP filter.
+	searchMaxScannedSubscribers = 10000
+	// searchMaxSortedSubscribers bounds the number of subscribers matching
+	// the indexed filters which can be sorted by name or state.
+	searchMaxSortedSubscribers = 10000
+)
+
+// subscriberQuery holds the filters & sort order of a subscriber listing.
+//
+// The MSISDN, IP, APN and policy filters are indexed: the matching IMSIs are
+// read from the subscriberdb lookup tables and from the associations of the
+// configurator entities. The state and name filters are applied to the loaded
+// subscribers, so they require an indexed filter to bound the subscribers to
+// load.
+type subscriberQuery struct {
+	msisdn       string
+	msisdnPrefix string
+	ip           string
+	apn          string
+	policy       string
+
+	state string
+	name  string
+
+	sortBy   string
+	sortDesc bool
+}
+
+func getSubscriberQuery(c echo.Context) (*subscriberQuery, error) {
+	q := &subscriberQuery{
+		msisdn:       c.QueryParam(ParamMSISDN),
+		msisdnPrefix: c.QueryParam(ParamMSISDNPrefix),
+		ip:           c.QueryParam(ParamIP),
+		apn:          c.QueryParam(ParamAPN),
+		policy:       c.QueryParam(ParamPolicy),
+		state:        c.QueryParam(ParamState),
+		name:         strings.ToLower(c.QueryParam(ParamName)),
+		sortBy:       c.QueryParam(ParamSortBy),
+	}
+
+	switch q.state {
+	case "", subscribermodels.LteSubscriptionStateACTIVE, subscribermodels.LteSubscriptionStateINACTIVE:
+	default:
+		return nil, fmt.Errorf("invalid state '%s', expected ACTIVE or INACTIVE", q.state)
+	}
+	switch q.sortBy {
+	case "":
+		q.sortBy = SortByID
+	case SortByID, SortByName, SortByState:
+	default:
+		return nil, fmt.Errorf("invalid sort field '%s', expected id, name or state", q.sortBy)
+	}
+	switch sortOrder := c.QueryParam(ParamSortOrder); sortOrder {
+	case "", SortOrderAsc:
+	case SortOrderDesc:
+		q.sortDesc = true
+	default:
+		return nil, fmt.Errorf("invalid sort order '%s', expected asc or desc", sortOrder)
+	}
+
+	if !q.isIndexed() && (q.state != "" || q.name != "") {
+		return nil, errors.New("filtering by state or name requires a msisdn, msisdn_prefix, ip, apn or policy filter")
+	}
+	if !q.isIndexed() && (q.sortBy != SortByID || q.sortDesc) {
+		return nil, errors.New("sorting other than by ascending id requires a msisdn, msisdn_prefix, ip, apn or policy filter")
+	}
+	return q, nil
+}
+
+// isIndexed returns true if the query has an indexed filter.
+func (q *subscriberQuery) isIndexed() bool {
+	return q.msisdn != "" || q.msisdnPrefix != "" || q.ip != "" || q.apn != "" || q.policy != ""
+}
+
+// matches returns true if the subscriber matches the non-indexed filters of
+// the query. The IP filter is also checked, as the IP lookup table may be
+// stale.
+func (q *subscriberQuery) matches(sub *subscribermodels.Subscriber) bool {
+	if q.ip != "" && !sub.IsAssignedIP(q.ip) {
+		return false
+	}
+	if q.state != "" && (sub.Lte == nil || sub.Lte.State != q.state) {
+		return false
+	}
+	if q.name != "" && !strings.Contains(strings.ToLower(sub.Name), q.name) {
+		return false
+	}
+	return true
+}
+
+// less orders the subscribers by the sort field of the query, then by ID.
+func (q *subscriberQuery) less(a, b *subscribermodels.Subscriber) bool {
+	var aKey, bKey string
+	switch q.sortBy {
+	case SortByName:
+		aKey, bKey = a.Name, b.Name
+	case SortByState:
+		aKey, bKey = getLteState(a), getLteState(b)
+	}
+	if aKey == bKey {
+		aKey, bKey = string(a.ID), string(b.ID)
+	}
+	if q.sortDesc {
+		return aKey > bKey
+	}
+	return aKey < bKey
+}
+
+func getLteState(sub *subscribermodels.Subscriber) string {
+	if sub.Lte == nil {
+		return ""
+	}
+	return sub.Lte.State
+}
+
+// listSubscriberPage returns the page of the subscribers matching the query,
+// in the query's order.
+func listSubscriberPage(networkID string, q *subscriberQuery, pageSize uint32, pageToken string) (*subscribermodels.PaginatedSubscribers, error) {
+	if q.isIndexed() {
+		return searchSubscribers(networkID, q, pageSize, pageToken)
+	}
+	subs, nextPageToken, err := loadSubscriberPage(networkID, pageSize, pageToken)
+	if err != nil {
+		return nil, obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	page := make([]*subscribermodels.Subscriber, 0, len(subs))
+	for _, sub := range subs {
+		page = append(page, sub)
+	}
+	return makePaginatedSubscribers(q, page, nextPageToken), nil
+}
+
+// searchSubscribers returns the page of the subscribers matching a query with
+// an indexed filter. The page token is the offset of the page in the IMSIs
+// matching the indexed filters when sorting by ID, and the offset of the page
+// in the sorted matching subscribers otherwise.
+func searchSubscribers(networkID string, q *subscriberQuery, pageSize uint32, pageToken string) (*subscribermodels.PaginatedSubscribers, error) {
+	offset, err := parseSearchPageToken(pageToken)
+	if err != nil {
+		return nil, obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	imsis, err := getIndexedIMSIs(networkID, q)
+	if err != nil {
+		return nil, makeErr(err)
+	}
+
+	var page []*subscribermodels.Subscriber
+	var nextOffset int
+	var nerr *echo.HTTPError
+	if q.sortBy == SortByID {
+		if q.sortDesc {
+			sort.Sort(sort.Reverse(sort.StringSlice(imsis)))
+		}
+		page, nextOffset, nerr = scanSearchedSubscribers(networkID, q, imsis, pageSize, offset)
+	} else {
+		page, nextOffset, nerr = sortSearchedSubscribers(networkID, q, imsis, pageSize, offset)
+	}
+	if nerr != nil {
+		return nil, nerr
+	}
+	if err := fillSubscriberStates(networkID, page); err != nil {
+		return nil, makeErr(err)
+	}
+
+	nextPageToken := ""
+	if nextOffset != 0 {
+		nextPageToken = makeSearchPageToken(nextOffset)
+	}
+	return makePaginatedSubscribers(q, page, nextPageToken), nil
+}
+
+// scanSearchedSubscribers returns the page of the subscribers of the ordered
+// IMSIs matching the non-indexed filters of the query, starting at the passed
+// offset, along with the offset of the next page, or 0 if there is none.
+//
+// At most searchMaxScannedSubscribers subscribers are scanned per page, so the
+// page of a sparse filter can hold fewer subscribers than the page size while
+// still having a next page token.
+func scanSearchedSubscribers(networkID string, q *subscriberQuery, imsis []string, pageSize uint32, offset int) ([]*subscribermodels.Subscriber, int, *echo.HTTPError) {
+	var page []*subscribermodels.Subscriber
+	for start := offset; start < len(imsis); start += searchLoadBatchSize {
+		if start-offset >= searchMaxScannedSubscribers {
+			return page, start, nil
+		}
+		end := start + searchLoadBatchSize
+		if end > len(imsis) {
+			end = len(imsis)
+		}
+		subs, err := loadSearchedSubscribers(networkID, imsis[start:end], q.ip != "")
+		if err != nil {
+			return nil, 0, makeErr(err)
+		}
+		for i, imsi := range imsis[start:end] {
+			sub, ok := subs[imsi]
+			if !ok || !q.matches(sub) {
+				continue
+			}
+			page = append(page, sub)
+			if pageSize != 0 && len(page) == int(pageSize) {
+				if start+i+1 == len(imsis) {
+					return page, 0, nil
+				}
+				return page, start + i + 1, nil
+			}
+		}
+	}
+	return page, 0, nil
+}
+
+// sortSearchedSubscribers returns the page of the subscribers of the IMSIs
+// matching the non-indexed filters of the query, sorted by name or state,
+// starting at the passed offset, along with the offset of the next page, or 0
+// if there is none.
+//
+// Sorting requires loading all the subscribers of the IMSIs, so it is only
+// supported when the indexed filters match at most
+// searchMaxSortedSubscribers subscribers.
+func sortSearchedSubscribers(networkID string, q *subscriberQuery, imsis []string, pageSize uint32, offset int) ([]*subscribermodels.Subscriber, int, *echo.HTTPError) {
+	if len(imsis) > searchMaxSortedSubscribers {
+		err := fmt.Errorf("sorting by %s is limited to %d subscribers matching the msisdn, msisdn_prefix, ip, apn and policy filters, got %d", q.sortBy, searchMaxSortedSubscribers, len(imsis))
+		return nil, 0, obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	subs, err := loadSearchedSubscribers(networkID, imsis, q.ip != "")
+	if err != nil {
+		return nil, 0, makeErr(err)
+	}
+	var matches []*subscribermodels.Subscriber
+	for _, sub := range subs {
+		if q.matches(sub) {
+			matches = append(matches, sub)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return q.less(matches[i], matches[j]) })
+
+	start, end := getPageBounds(len(matches), offset, pageSize)
+	if end == len(matches) {
+		return matches[start:end], 0, nil
+	}
+	return matches[start:end], end, nil
+}
+
+// fillSubscriberStates fills the subscriber states of the page.
+func fillSubscriberStates(networkID string, page []*subscribermodels.Subscriber) error {
+	imsis := make([]string, 0, len(page))
+	for _, sub := range page {
+		imsis = append(imsis, string(sub.ID))
+	}
+	states, err := loadAllStatesForIMSIs(networkID, imsis)
+	if err != nil {
+		return err
+	}
+	for _, sub := range page {
+		sub.FillAugmentedFields(states[string(sub.ID)])
+	}
+	return nil
+}
+
+func makePaginatedSubscribers(q *subscriberQuery, subs []*subscribermodels.Subscriber, nextPageToken string) *subscribermodels.PaginatedSubscribers {
+	sort.Slice(subs, func(i, j int) bool { return q.less(subs[i], subs[j]) })
+	ret := &subscribermodels.PaginatedSubscribers{
+		NextPageToken: subscribermodels.NextPageToken(nextPageToken),
+		Subscribers:   map[string]*subscribermodels.Subscriber{},
+	}
+	for _, sub := range subs {
+		ret.Subscribers[string(sub.ID)] = sub
+		ret.SubscriberIds = append(ret.SubscriberIds, sub.ID)
+	}
+	return ret
+}
+
+// getIndexedIMSIs returns the sorted IMSIs of the subscribers matching all
+// the indexed filters of the query.
+func getIndexedIMSIs(networkID string, q *subscriberQuery) ([]string, error) {
+	var matching map[string]bool
+	restrict := func(imsis []string) {
+		restricted := map[string]bool{}
+		for _, imsi := range imsis {
+			if matching == nil || matching[imsi] {
+				restricted[imsi] = true
+			}
+		}
+		matching = restricted
+	}
+
+	if q.msisdn != "" {
+		imsi, err := subscriberdb.GetIMSIForMSISDN(networkID, q.msisdn)
+		if err != nil && err != merrors.ErrNotFound {
+			return nil, err
+		}
+		restrict([]string{imsi})
+	}
+	if q.msisdnPrefix != "" {
+		imsisByMSISDN, err := subscriberdb.ListMSISDNsWithPrefix(networkID, q.msisdnPrefix)
+		if err != nil {
+			return nil, err
+		}
+		var imsis []string
+		for _, imsi := range imsisByMSISDN {
+			imsis = append(imsis, imsi)
+		}
+		restrict(imsis)
+	}
+	if q.ip != "" {
+		imsis, err := subscriberdb.GetIMSIsForIP(networkID, q.ip)
+		if err != nil {
+			return nil, err
+		}
+		restrict(imsis)
+	}
+	if q.apn != "" {
+		imsis, err := getAPNSubscriberIMSIs(networkID, q.apn)
+		if err != nil {
+			return nil, err
+		}
+		restrict(imsis)
+	}
+	if q.policy != "" {
+		imsis, err := getPolicySubscriberIMSIs(networkID, q.policy)
+		if err != nil {
+			return nil, err
+		}
+		restrict(imsis)
+	}
+
+	ret := make([]string, 0, len(matching))
+	for imsi := range matching {
+		if imsi != "" {
+			ret = append(ret, imsi)
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// getAPNSubscriberIMSIs returns the IMSIs of the subscribers with the APN
+// active.
+func getAPNSubscriberIMSIs(networkID, apn string) ([]string, error) {
+	ent, err := configurator.LoadEntity(networkID, lte.APNEntityType, apn, configurator.EntityLoadCriteria{LoadAssocsToThis: true}, serdes.Entity)
+	if err == merrors.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ent.ParentAssociations.Filter(lte.SubscriberEntityType).Keys(), nil
+}
+
+// getPolicySubscriberIMSIs returns the IMSIs of the subscribers with the
+// policy rule active, for all APNs or through an apn_policy_profile.
+func getPolicySubscriberIMSIs(networkID, policyID string) ([]string, error) {
+	ent, err := configurator.LoadEntity(networkID, lte.PolicyRuleEntityType, policyID, configurator.EntityLoadCriteria{LoadAssocsToThis: true}, serdes.Entity)
+	if err == merrors.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	imsis := ent.ParentAssociations.Filter(lte.SubscriberEntityType).Keys()
+
+	profileTKs := ent.ParentAssociations.Filter(lte.APNPolicyProfileEntityType)
+	if len(profileTKs) == 0 {
+		return imsis, nil
+	}
+	profileEnts, _, err := configurator.LoadEntities(networkID, nil, nil, nil, profileTKs, configurator.EntityLoadCriteria{LoadAssocsToThis: true}, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	for _, profileEnt := range profileEnts {
+		imsis = append(imsis, profileEnt.ParentAssociations.Filter(lte.SubscriberEntityType).Keys()...)
+	}
+	return imsis, nil
+}
+
+// loadSearchedSubscribers loads the subscribers of the IMSIs in batches,
+// keyed by IMSI. Subscribers which don't exist anymore are skipped. The
+// subscriber states are only loaded if withStates is set.
+func loadSearchedSubscribers(networkID string, imsis []string, withStates bool) (map[string]*subscribermodels.Subscriber, error) {
+	subs := map[string]*subscribermodels.Subscriber{}
+	for start := 0; start < len(imsis); start += searchLoadBatchSize {
+		end := start + searchLoadBatchSize
+		if end > len(imsis) {
+			end = len(imsis)
+		}
+		batch := imsis[start:end]
+
+		ents, profileEntsBySub, err := loadSubscriberEnts(networkID, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		var states map[string]state_types.StatesByID
+		if withStates {
+			states, err = loadAllStatesForIMSIs(networkID, batch)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, ent := range ents {
+			mutableSub, err := (&subscribermodels.MutableSubscriber{}).FromEnt(ent, profileEntsBySub[ent.GetTypeAndKey()])
+			if err != nil {
+				return nil, err
+			}
+			if mutableSub.Lte != nil {
+				mutableSub.Lte.RedactKeys()
+			}
+			sub := mutableSub.ToSubscriber()
+			if withStates {
+				sub.FillAugmentedFields(states[ent.Key])
+			}
+			subs[ent.Key] = sub
+		}
+	}
+	return subs, nil
+}
+
+// getPageBounds returns the bounds of the page in a list of n items.
+func getPageBounds(n int, offset int, pageSize uint32) (int, int) {
+	if offset > n {
+		offset = n
+	}
+	end := n
+	if pageSize != 0 && offset+int(pageSize) < n {
+		end = offset + int(pageSize)
+	}
+	return offset, end
+}
+
+func makeSearchPageToken(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func parseSearchPageToken(pageToken string) (int, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(pageToken)
+	if err != nil {
+		return 0, errors.New("invalid page token")
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid page token")
+	}
+	return offset, nil
+}
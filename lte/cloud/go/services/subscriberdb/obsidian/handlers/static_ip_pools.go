@@ -0,0 +1,686 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	ltehandlers "magma/lte/cloud/go/services/lte/obsidian/handlers"
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/ippool"
+	subscribermodels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/golang/glog"
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+const (
+	StaticIPPools          = "static_ip_pools"
+	ListStaticIPPoolsPath  = ltehandlers.ManageNetworkPath + obsidian.UrlSep + StaticIPPools
+	ManageStaticIPPoolPath = ListStaticIPPoolsPath + obsidian.UrlSep + ":static_ip_pool_id"
+	StaticIPPoolUsagePath  = ManageStaticIPPoolPath + obsidian.UrlSep + "usage"
+	StaticIPConflictsPath  = ltehandlers.ManageNetworkPath + obsidian.UrlSep + "static_ip_conflicts"
+)
+
+var staticIPPoolLoadCriteria = configurator.EntityLoadCriteria{LoadConfig: true}
+
+// GetStaticIPPoolHandlers returns the handlers of the static IP pool
+// endpoints.
+func GetStaticIPPoolHandlers() []obsidian.Handler {
+	return []obsidian.Handler{
+		{Path: ListStaticIPPoolsPath, Methods: obsidian.GET, HandlerFunc: listStaticIPPoolsHandler},
+		{Path: ListStaticIPPoolsPath, Methods: obsidian.POST, HandlerFunc: createStaticIPPoolHandler},
+		{Path: ManageStaticIPPoolPath, Methods: obsidian.GET, HandlerFunc: getStaticIPPoolHandler},
+		{Path: ManageStaticIPPoolPath, Methods: obsidian.PUT, HandlerFunc: updateStaticIPPoolHandler},
+		{Path: ManageStaticIPPoolPath, Methods: obsidian.DELETE, HandlerFunc: deleteStaticIPPoolHandler},
+		{Path: StaticIPPoolUsagePath, Methods: obsidian.GET, HandlerFunc: getStaticIPPoolUsageHandler},
+		{Path: StaticIPConflictsPath, Methods: obsidian.GET, HandlerFunc: listStaticIPConflictsHandler},
+	}
+}
+
+func listStaticIPPoolsHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	pools, err := loadStaticIPPools(networkID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	ret := map[string]*subscribermodels.StaticIPPool{}
+	for _, pool := range pools {
+		ret[string(pool.ID)] = pool
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+func createStaticIPPoolHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	pool := &subscribermodels.StaticIPPool{}
+	if err := c.Bind(pool); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := pool.ValidateModel(); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	exists, err := configurator.DoesEntityExist(networkID, lte.StaticIPPoolEntityType, string(pool.ID))
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if exists {
+		return obsidian.HttpError(errors.Errorf("static IP pool %s already exists", pool.ID), http.StatusBadRequest)
+	}
+	if nerr := validateStaticIPPool(networkID, pool); nerr != nil {
+		return nerr
+	}
+
+	// The static IPs subscribers already have from the block are reserved
+	// along with the pool
+	reservations, nerr := getNewPoolReservationWrites(networkID, pool, nil)
+	if nerr != nil {
+		return nerr
+	}
+	writes := append([]configurator.EntityWriteOperation{pool.ToEntity()}, reservations...)
+	err = configurator.WriteEntities(networkID, writes, serdes.Entity)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+func getStaticIPPoolHandler(c echo.Context) error {
+	networkID, poolID, nerr := getNetworkAndStaticIPPoolIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	pool, err := loadStaticIPPool(networkID, poolID)
+	if err != nil {
+		return makeErr(err)
+	}
+	return c.JSON(http.StatusOK, pool)
+}
+
+func updateStaticIPPoolHandler(c echo.Context) error {
+	networkID, poolID, nerr := getNetworkAndStaticIPPoolIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	pool := &subscribermodels.StaticIPPool{}
+	if err := c.Bind(pool); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if err := pool.ValidateModel(); err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	if string(pool.ID) != poolID {
+		err := fmt.Errorf("static IP pool ID from parameters (%s) and payload (%s) must match", poolID, pool.ID)
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+
+	exists, err := configurator.DoesEntityExist(networkID, lte.StaticIPPoolEntityType, poolID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if !exists {
+		return echo.ErrNotFound
+	}
+	if nerr := validateStaticIPPool(networkID, pool); nerr != nil {
+		return nerr
+	}
+
+	existing, err := loadPoolReservations(networkID, poolID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	reservations, nerr := getNewPoolReservationWrites(networkID, pool, existing)
+	if nerr != nil {
+		return nerr
+	}
+	writes := append([]configurator.EntityWriteOperation{pool.ToUpdateCriteria()}, reservations...)
+	err = configurator.WriteEntities(networkID, writes, serdes.Entity)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func deleteStaticIPPoolHandler(c echo.Context) error {
+	networkID, poolID, nerr := getNetworkAndStaticIPPoolIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	// The addresses of the pool are released, the static IPs of the
+	// subscribers are kept
+	deletes := storage.TKs{{Type: lte.StaticIPPoolEntityType, Key: poolID}}
+	reservations, err := loadPoolReservations(networkID, poolID)
+	if err == merrors.ErrNotFound {
+		return c.NoContent(http.StatusNoContent)
+	}
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	for _, reservation := range reservations {
+		deletes = append(deletes, storage.TypeAndKey{Type: lte.StaticIPAllocationEntityType, Key: string(reservation.IP)})
+	}
+	err = configurator.DeleteEntities(networkID, deletes)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func getStaticIPPoolUsageHandler(c echo.Context) error {
+	networkID, poolID, nerr := getNetworkAndStaticIPPoolIDs(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	model, err := loadStaticIPPool(networkID, poolID)
+	if err != nil {
+		return makeErr(err)
+	}
+	pool, err := model.ToPool()
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	reservations, err := loadPoolReservations(networkID, poolID)
+	if err != nil {
+		return makeErr(err)
+	}
+	var allocations []ippool.Allocation
+	for _, reservation := range reservations {
+		allocations = append(allocations, reservation.ToAllocation())
+	}
+	return c.JSON(http.StatusOK, (&subscribermodels.StaticIPPoolUsage{}).FromAllocations(pool, allocations))
+}
+
+// listStaticIPConflictsHandler returns the conflicts of the static IP pools,
+// including the allocated addresses which gateways reported assigning to
+// other subscribers.
+func listStaticIPConflictsHandler(c echo.Context) error {
+	networkID, nerr := obsidian.GetNetworkId(c)
+	if nerr != nil {
+		return nerr
+	}
+
+	pools, err := loadPools(networkID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	allocations, err := loadStaticIPAllocations(networkID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+
+	var managedIPs []string
+	for _, allocation := range allocations {
+		for _, pool := range pools {
+			if pool.Contains(allocation.IP) {
+				managedIPs = append(managedIPs, allocation.IP)
+				break
+			}
+		}
+	}
+	var reported []ippool.Allocation
+	if len(managedIPs) != 0 {
+		mappings, err := subscriberdb.GetIPMappings(networkID, managedIPs)
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to get the IPs reported by gateways"), http.StatusInternalServerError)
+		}
+		for _, m := range mappings {
+			reported = append(reported, ippool.Allocation{IMSI: m.Imsi, APN: m.Apn, IP: m.Ip})
+		}
+	}
+
+	ret := []*subscribermodels.StaticIPConflict{}
+	for _, conflict := range ippool.FindConflicts(pools, allocations, reported) {
+		ret = append(ret, (&subscribermodels.StaticIPConflict{}).FromConflict(conflict))
+	}
+	return c.JSON(http.StatusOK, ret)
+}
+
+func getNetworkAndStaticIPPoolIDs(c echo.Context) (string, string, *echo.HTTPError) {
+	vals, err := obsidian.GetParamValues(c, "network_id", "static_ip_pool_id")
+	if err != nil {
+		return "", "", err
+	}
+	return vals[0], vals[1], nil
+}
+
+// validateStaticIPPool returns a bad request error if the APN of the pool
+// doesn't exist, or if its block overlaps the block of another pool.
+func validateStaticIPPool(networkID string, pool *subscribermodels.StaticIPPool) *echo.HTTPError {
+	exists, err := configurator.DoesEntityExist(networkID, lte.APNEntityType, pool.Apn)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	if !exists {
+		return obsidian.HttpError(errors.Errorf("APN %s does not exist", pool.Apn), http.StatusBadRequest)
+	}
+
+	newPool, err := pool.ToPool()
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	others, err := loadPools(networkID)
+	if err != nil {
+		return obsidian.HttpError(err, http.StatusInternalServerError)
+	}
+	for _, other := range others {
+		if other.ID != newPool.ID && other.Overlaps(newPool) {
+			err := errors.Errorf("IP block %s overlaps the IP block %s of static IP pool %s", newPool.Block, other.Block, other.ID)
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
+// getNewPoolReservationWrites returns the writes reserving the static IPs of
+// the subscribers from the new block of the pool.
+func getNewPoolReservationWrites(networkID string, pool *subscribermodels.StaticIPPool, existing []*subscribermodels.StaticIPAllocation) ([]configurator.EntityWriteOperation, *echo.HTTPError) {
+	newPool, err := pool.ToPool()
+	if err != nil {
+		return nil, obsidian.HttpError(err, http.StatusBadRequest)
+	}
+	writes, err := getPoolReservationWrites(networkID, newPool, existing)
+	if err != nil {
+		return nil, obsidian.HttpError(errors.Wrap(err, "failed to load static IPs"), http.StatusInternalServerError)
+	}
+	return writes, nil
+}
+
+func loadStaticIPPool(networkID, poolID string) (*subscribermodels.StaticIPPool, error) {
+	ent, err := configurator.LoadEntity(networkID, lte.StaticIPPoolEntityType, poolID, staticIPPoolLoadCriteria, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	return (&subscribermodels.StaticIPPool{}).FromEntity(ent)
+}
+
+// loadStaticIPPools returns the static IP pools of the network, sorted by ID.
+func loadStaticIPPools(networkID string) ([]*subscribermodels.StaticIPPool, error) {
+	ents, _, err := configurator.LoadAllEntitiesOfType(networkID, lte.StaticIPPoolEntityType, staticIPPoolLoadCriteria, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*subscribermodels.StaticIPPool
+	for _, ent := range ents {
+		pool, err := (&subscribermodels.StaticIPPool{}).FromEntity(ent)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, pool)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].ID < ret[j].ID })
+	return ret, nil
+}
+
+// loadPools returns the pools allocating the addresses of the static IP
+// pools of the network.
+func loadPools(networkID string) ([]ippool.Pool, error) {
+	models, err := loadStaticIPPools(networkID)
+	if err != nil {
+		return nil, err
+	}
+	var ret []ippool.Pool
+	for _, model := range models {
+		pool, err := model.ToPool()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid static IP pool %s", model.ID)
+		}
+		ret = append(ret, pool)
+	}
+	return ret, nil
+}
+
+// loadStaticIPAllocations returns the static IPs of the subscribers of the
+// network.
+func loadStaticIPAllocations(networkID string) ([]ippool.Allocation, error) {
+	ents, _, err := configurator.LoadAllEntitiesOfType(networkID, lte.SubscriberEntityType, configurator.EntityLoadCriteria{LoadConfig: true}, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	return subscribermodels.GetStaticIPAllocations(ents), nil
+}
+
+// loadStaticIPReservations returns the reservations of the addresses
+// allocated from the static IP pools of the network.
+func loadStaticIPReservations(networkID string) ([]*subscribermodels.StaticIPAllocation, error) {
+	ents, _, err := configurator.LoadAllEntitiesOfType(networkID, lte.StaticIPAllocationEntityType, staticIPPoolLoadCriteria, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	return getStaticIPReservations(ents)
+}
+
+// loadPoolReservations returns the reservations of the addresses allocated
+// from the static IP pool.
+func loadPoolReservations(networkID, poolID string) ([]*subscribermodels.StaticIPAllocation, error) {
+	pool, err := configurator.LoadEntity(
+		networkID, lte.StaticIPPoolEntityType, poolID,
+		configurator.EntityLoadCriteria{LoadAssocsToThis: true},
+		serdes.Entity,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tks := pool.ParentAssociations.Filter(lte.StaticIPAllocationEntityType)
+	if len(tks) == 0 {
+		return nil, nil
+	}
+	ents, _, err := configurator.LoadEntities(networkID, nil, nil, nil, tks, staticIPPoolLoadCriteria, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	return getStaticIPReservations(ents)
+}
+
+func getStaticIPReservations(ents configurator.NetworkEntities) ([]*subscribermodels.StaticIPAllocation, error) {
+	var ret []*subscribermodels.StaticIPAllocation
+	for _, ent := range ents {
+		reservation, err := (&subscribermodels.StaticIPAllocation{}).FromEntity(ent)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, reservation)
+	}
+	return ret, nil
+}
+
+// getPoolReservationWrites returns the writes indexing the static IPs of the
+// subscribers which belong to the pool, replacing its existing reservations.
+// It scans the subscribers of the network, so it's only used when pools are
+// written. Addresses allocated to several subscribers are reserved for the
+// first of them by IMSI, the others are reported as conflicts.
+func getPoolReservationWrites(networkID string, pool ippool.Pool, existing []*subscribermodels.StaticIPAllocation) ([]configurator.EntityWriteOperation, error) {
+	allocations, err := loadStaticIPAllocations(networkID)
+	if err != nil {
+		return nil, err
+	}
+	wanted := map[string]ippool.Allocation{}
+	for _, allocation := range ippool.GetPoolAllocations(pool, allocations) {
+		allocation.IP = ippool.Normalize(allocation.IP)
+		if _, ok := wanted[allocation.IP]; !ok {
+			wanted[allocation.IP] = allocation
+		}
+	}
+	reserved := map[string]ippool.Allocation{}
+	for _, reservation := range existing {
+		reserved[string(reservation.IP)] = reservation.ToAllocation()
+	}
+	poolIDs := map[string]string{}
+	for ip := range wanted {
+		poolIDs[ip] = pool.ID
+	}
+	return getReservationWrites(reserved, wanted, poolIDs), nil
+}
+
+// getReservationWrites returns the writes replacing the reserved allocations
+// by the wanted ones, both keyed by address. The reservations are created in
+// the pools of poolIDs, keyed by address.
+func getReservationWrites(reserved, wanted map[string]ippool.Allocation, poolIDs map[string]string) []configurator.EntityWriteOperation {
+	var writes []configurator.EntityWriteOperation
+	for _, ip := range sortedIPs(reserved) {
+		if _, ok := wanted[ip]; !ok {
+			writes = append(writes, configurator.EntityUpdateCriteria{Type: lte.StaticIPAllocationEntityType, Key: ip, DeleteEntity: true})
+		}
+	}
+	for _, ip := range sortedIPs(wanted) {
+		allocation := (&subscribermodels.StaticIPAllocation{}).FromAllocation(wanted[ip])
+		existing, ok := reserved[ip]
+		switch {
+		case !ok:
+			writes = append(writes, allocation.ToEntity(poolIDs[ip]))
+		case existing != wanted[ip]:
+			writes = append(writes, configurator.EntityUpdateCriteria{Type: lte.StaticIPAllocationEntityType, Key: ip, NewConfig: allocation})
+		}
+	}
+	return writes
+}
+
+func sortedIPs(allocations map[string]ippool.Allocation) []string {
+	ips := make([]string, 0, len(allocations))
+	for ip := range allocations {
+		ips = append(ips, ip)
+	}
+	sort.Strings(ips)
+	return ips
+}
+
+// loadSubscriberReservationTKs returns the reservations of the static IPs of
+// the subscriber, which are released when it's deleted.
+func loadSubscriberReservationTKs(networkID, imsi string, staticIPs subscribermodels.SubscriberStaticIps) (storage.TKs, error) {
+	var tks storage.TKs
+	for _, ip := range staticIPs {
+		tks = append(tks, storage.TypeAndKey{Type: lte.StaticIPAllocationEntityType, Key: ippool.Normalize(ip.String())})
+	}
+	if len(tks) == 0 {
+		return nil, nil
+	}
+	ents, _, err := configurator.LoadEntities(networkID, nil, nil, nil, tks, staticIPPoolLoadCriteria, serdes.Entity)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := getStaticIPReservations(ents)
+	if err != nil {
+		return nil, err
+	}
+	var ret storage.TKs
+	for _, reservation := range reservations {
+		if string(reservation.SubscriberID) == imsi {
+			ret = append(ret, storage.TypeAndKey{Type: lte.StaticIPAllocationEntityType, Key: string(reservation.IP)})
+		}
+	}
+	return ret, nil
+}
+
+// staticIPAllocator allocates the static IPs of subscribers from the static
+// IP pools of their APNs, and returns the writes reserving the allocated
+// addresses.
+type staticIPAllocator struct {
+	pools     []ippool.Pool
+	allocator *ippool.Allocator
+	// reserved are the reserved addresses of the subscribers, by IMSI and
+	// address
+	reserved map[string]map[string]ippool.Allocation
+}
+
+// newStaticIPAllocator returns an allocator of the static IP pools of the
+// network, with their reserved addresses allocated.
+func newStaticIPAllocator(networkID string) (*staticIPAllocator, error) {
+	a := &staticIPAllocator{reserved: map[string]map[string]ippool.Allocation{}}
+	pools, err := loadPools(networkID)
+	if err != nil {
+		return nil, err
+	}
+	a.pools = pools
+	if len(pools) == 0 {
+		a.allocator = ippool.NewAllocator(nil, nil)
+		return a, nil
+	}
+
+	reservations, err := loadStaticIPReservations(networkID)
+	if err != nil {
+		return nil, err
+	}
+	var allocations []ippool.Allocation
+	for _, reservation := range reservations {
+		allocation := reservation.ToAllocation()
+		if a.reserved[allocation.IMSI] == nil {
+			a.reserved[allocation.IMSI] = map[string]ippool.Allocation{}
+		}
+		a.reserved[allocation.IMSI][allocation.IP] = allocation
+		allocations = append(allocations, allocation)
+	}
+	a.allocator = ippool.NewAllocator(pools, allocations)
+	return a, nil
+}
+
+// allocate sets the static IPs of the subscriber for its active APNs which
+// have static IP pools, and returns the writes reserving them. The reserved
+// static IPs of the subscriber for these APNs are kept, otherwise a free
+// address is allocated. It returns an error if one of the static IPs of the
+// subscriber from the pools is allocated to another subscriber.
+func (a *staticIPAllocator) allocate(sub *subscribermodels.MutableSubscriber) ([]configurator.EntityWriteOperation, error) {
+	imsi := string(sub.ID)
+	for _, apn := range sortedAPNs(sub.StaticIps) {
+		ip := sub.StaticIps[apn].String()
+		if owner, allocated := a.allocator.GetOwner(ip); allocated && owner != imsi && a.allocator.Manages(ip) {
+			return nil, errors.Errorf("static IP %s of APN %s is already allocated to subscriber %s", ip, apn, owner)
+		}
+		a.allocator.Reserve(ip, imsi)
+	}
+
+	for _, apn := range sub.ActiveApns {
+		if _, ok := sub.StaticIps[apn]; ok || !a.allocator.HasPools(apn) {
+			continue
+		}
+		ip, ok := a.getReserved(imsi, apn)
+		if !ok {
+			var err error
+			ip, err = a.allocator.Allocate(apn, imsi)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to allocate a static IP of APN %s", apn)
+			}
+		}
+		if sub.StaticIps == nil {
+			sub.StaticIps = subscribermodels.SubscriberStaticIps{}
+		}
+		sub.StaticIps[apn] = strfmt.IPv4(ip)
+	}
+	return a.reserve(sub), nil
+}
+
+// reserve returns the writes replacing the reservations of the subscriber by
+// reservations of its static IPs from the pools. Addresses reserved by other
+// subscribers are left to them.
+func (a *staticIPAllocator) reserve(sub *subscribermodels.MutableSubscriber) []configurator.EntityWriteOperation {
+	imsi := string(sub.ID)
+	wanted := map[string]ippool.Allocation{}
+	poolIDs := map[string]string{}
+	for _, apn := range sortedAPNs(sub.StaticIps) {
+		ip := ippool.Normalize(sub.StaticIps[apn].String())
+		poolID, managed := a.getPoolID(ip)
+		if _, dup := wanted[ip]; !managed || dup {
+			continue
+		}
+		if owner, allocated := a.allocator.GetOwner(ip); allocated && owner != imsi {
+			continue
+		}
+		wanted[ip] = ippool.Allocation{IMSI: imsi, APN: apn, IP: ip}
+		poolIDs[ip] = poolID
+	}
+	writes := getReservationWrites(a.reserved[imsi], wanted, poolIDs)
+	a.reserved[imsi] = wanted
+	return writes
+}
+
+// getReserved returns the address reserved by the subscriber for the APN
+func (a *staticIPAllocator) getReserved(imsi, apn string) (string, bool) {
+	for _, ip := range sortedIPs(a.reserved[imsi]) {
+		if a.reserved[imsi][ip].APN == apn {
+			return ip, true
+		}
+	}
+	return "", false
+}
+
+// getPoolID returns the ID of the first pool, by ID, the address belongs to
+func (a *staticIPAllocator) getPoolID(ip string) (string, bool) {
+	for _, pool := range a.pools {
+		if pool.Contains(ip) {
+			return pool.ID, true
+		}
+	}
+	return "", false
+}
+
+func sortedAPNs(staticIPs subscribermodels.SubscriberStaticIps) []string {
+	apns := make([]string, 0, len(staticIPs))
+	for apn := range staticIPs {
+		apns = append(apns, apn)
+	}
+	sort.Strings(apns)
+	return apns
+}
+
+// maxStaticIPAttempts is how many times a subscriber is written when the
+// addresses allocated to it are concurrently reserved by other writes
+const maxStaticIPAttempts = 3
+
+// writeWithStaticIPs allocates the static IPs of the subscriber from the
+// static IP pools of the network, and calls write with the writes reserving
+// them, to be applied in the same transaction as the subscriber. Concurrent
+// writes can allocate the same free address, in which case all but one of
+// them fail, and the others allocate again.
+func writeWithStaticIPs(networkID string, sub *subscribermodels.MutableSubscriber, write func(reservations []configurator.EntityWriteOperation) *echo.HTTPError) *echo.HTTPError {
+	requested := sub.StaticIps
+	for attempt := 1; ; attempt++ {
+		sub.StaticIps = nil
+		for apn, ip := range requested {
+			if sub.StaticIps == nil {
+				sub.StaticIps = subscribermodels.SubscriberStaticIps{}
+			}
+			sub.StaticIps[apn] = ip
+		}
+		allocator, err := newStaticIPAllocator(networkID)
+		if err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to load static IP pools"), http.StatusInternalServerError)
+		}
+		reservations, err := allocator.allocate(sub)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		nerr := write(reservations)
+		if nerr == nil || attempt == maxStaticIPAttempts || !isReservationTaken(networkID, reservations) {
+			return nerr
+		}
+		glog.V(2).Infof("Static IPs of subscriber %s were concurrently reserved, allocating again", sub.ID)
+	}
+}
+
+// isReservationTaken returns true if one of the reservations the writes
+// create exists, i.e. the address was reserved by another write.
+func isReservationTaken(networkID string, writes []configurator.EntityWriteOperation) bool {
+	var tks storage.TKs
+	for _, write := range writes {
+		if ent, ok := write.(configurator.NetworkEntity); ok {
+			tks = append(tks, ent.GetTypeAndKey())
+		}
+	}
+	if len(tks) == 0 {
+		return false
+	}
+	ents, _, err := configurator.LoadEntities(networkID, nil, nil, nil, tks, configurator.EntityLoadCriteria{}, serdes.Entity)
+	return err == nil && len(ents) != 0
+}
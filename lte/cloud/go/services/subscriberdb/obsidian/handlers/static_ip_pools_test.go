@@ -0,0 +1,396 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handlers_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	policydbModels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/obsidian/handlers"
+	subscriberModels "magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	"magma/lte/cloud/go/services/subscriberdb/protos"
+	subscriberdbTestInit "magma/lte/cloud/go/services/subscriberdb/test_init"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	deviceTestInit "magma/orc8r/cloud/go/services/device/test_init"
+
+	"github.com/go-openapi/swag"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testStaticIPPoolsPath     = "/magma/v1/lte/:network_id/static_ip_pools"
+	testStaticIPPoolPath      = testStaticIPPoolsPath + "/:static_ip_pool_id"
+	testStaticIPPoolUsagePath = testStaticIPPoolPath + "/usage"
+	testStaticIPConflictsPath = "/magma/v1/lte/:network_id/static_ip_conflicts"
+)
+
+func TestStaticIPPools(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	deviceTestInit.StartTestService(t)
+	subscriberdbTestInit.StartTestService(t)
+	err := configurator.CreateNetwork(configurator.Network{ID: "n0"}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n0",
+		[]configurator.NetworkEntity{
+			{Type: lte.APNEntityType, Key: "internet"},
+			{Type: lte.APNEntityType, Key: "ims"},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	poolHandlers := handlers.GetStaticIPPoolHandlers()
+	listPools := tests.GetHandlerByPathAndMethod(t, poolHandlers, testStaticIPPoolsPath, obsidian.GET).HandlerFunc
+	createPool := tests.GetHandlerByPathAndMethod(t, poolHandlers, testStaticIPPoolsPath, obsidian.POST).HandlerFunc
+	getPool := tests.GetHandlerByPathAndMethod(t, poolHandlers, testStaticIPPoolPath, obsidian.GET).HandlerFunc
+	updatePool := tests.GetHandlerByPathAndMethod(t, poolHandlers, testStaticIPPoolPath, obsidian.PUT).HandlerFunc
+	deletePool := tests.GetHandlerByPathAndMethod(t, poolHandlers, testStaticIPPoolPath, obsidian.DELETE).HandlerFunc
+	getUsage := tests.GetHandlerByPathAndMethod(t, poolHandlers, testStaticIPPoolUsagePath, obsidian.GET).HandlerFunc
+	listConflicts := tests.GetHandlerByPathAndMethod(t, poolHandlers, testStaticIPConflictsPath, obsidian.GET).HandlerFunc
+	subscriberHandlers := handlers.GetHandlers(nil)
+	createSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberHandlers, "/magma/v1/lte/:network_id/subscribers", obsidian.POST).HandlerFunc
+	updateSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberHandlers, "/magma/v1/lte/:network_id/subscribers/:subscriber_id", obsidian.PUT).HandlerFunc
+	deleteSubscriber := tests.GetHandlerByPathAndMethod(t, subscriberHandlers, "/magma/v1/lte/:network_id/subscribers/:subscriber_id", obsidian.DELETE).HandlerFunc
+
+	// Empty list
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/static_ip_pools",
+		Handler:        listPools,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.StaticIPPool{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Unknown APN
+	pool := &subscriberModels.StaticIPPool{ID: "p1", Apn: "other", IPBlock: "192.168.128.0/30"}
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n0/static_ip_pools",
+		Payload:        pool,
+		Handler:        createPool,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 400,
+		ExpectedError:  "APN other does not exist",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Invalid block
+	pool.Apn, pool.IPBlock = "internet", "192.168.128.1/30"
+	tc.ExpectedError = "IP block 192.168.128.1/30 is not a network address, did you mean 192.168.128.0/30?"
+	tests.RunUnitTest(t, e, tc)
+
+	// Successful create
+	pool.IPBlock = "192.168.128.0/30"
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n0/static_ip_pools",
+		Payload:        pool,
+		Handler:        createPool,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Duplicate create
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "static IP pool p1 already exists"
+	tests.RunUnitTest(t, e, tc)
+
+	// Overlapping block
+	tc.Payload = &subscriberModels.StaticIPPool{ID: "p2", Apn: "ims", IPBlock: "192.168.128.0/29"}
+	tc.ExpectedError = "IP block 192.168.128.0/29 overlaps the IP block 192.168.128.0/30 of static IP pool p1"
+	tests.RunUnitTest(t, e, tc)
+
+	// Mismatched IDs
+	pool.Description = "Internet static IPs"
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            "/magma/v1/lte/n0/static_ip_pools/p2",
+		Payload:        pool,
+		Handler:        updatePool,
+		ParamNames:     []string{"network_id", "static_ip_pool_id"},
+		ParamValues:    []string{"n0", "p2"},
+		ExpectedStatus: 400,
+		ExpectedError:  "static IP pool ID from parameters (p2) and payload (p1) must match",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Successful update
+	tc.URL = "/magma/v1/lte/n0/static_ip_pools/p1"
+	tc.ParamValues = []string{"n0", "p1"}
+	tc.ExpectedStatus, tc.ExpectedError = 204, ""
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/static_ip_pools/p1",
+		Handler:        getPool,
+		ParamNames:     []string{"network_id", "static_ip_pool_id"},
+		ParamValues:    []string{"n0", "p1"},
+		ExpectedStatus: 200,
+		ExpectedResult: pool,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/static_ip_pools",
+		Handler:        listPools,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(map[string]*subscriberModels.StaticIPPool{"p1": pool}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Subscribers of the APN are allocated the free addresses of the pool
+	for _, imsi := range []string{"IMSI001010000000001", "IMSI001010000000002"} {
+		tc = tests.Test{
+			Method:         "POST",
+			URL:            "/magma/v1/lte/n0/subscribers",
+			Payload:        newTestStaticIPSubscriber(imsi, nil, "internet", "ims"),
+			Handler:        createSubscriber,
+			ParamNames:     []string{"network_id"},
+			ParamValues:    []string{"n0"},
+			ExpectedStatus: 201,
+		}
+		tests.RunUnitTest(t, e, tc)
+	}
+	assert.Equal(t, subscriberModels.SubscriberStaticIps{"internet": "192.168.128.1"}, loadTestGroupSubscriber(t, "IMSI001010000000001").StaticIps)
+	assert.Equal(t, subscriberModels.SubscriberStaticIps{"internet": "192.168.128.2"}, loadTestGroupSubscriber(t, "IMSI001010000000002").StaticIps)
+	assert.Equal(t, map[string]string{"192.168.128.1": "IMSI001010000000001", "192.168.128.2": "IMSI001010000000002"}, loadTestStaticIPReservations(t))
+
+	// Pool exhausted
+	tc.Payload = newTestStaticIPSubscriber("IMSI001010000000003", nil, "internet")
+	tc.ExpectedStatus = 400
+	tc.ExpectedError = "failed to allocate a static IP of APN internet: no free address in the IP pools of the APN"
+	tests.RunUnitTest(t, e, tc)
+
+	// Address allocated to another subscriber
+	tc.Payload = newTestStaticIPSubscriber("IMSI001010000000003", subscriberModels.SubscriberStaticIps{"internet": "192.168.128.2"}, "internet")
+	tc.ExpectedError = "static IP 192.168.128.2 of APN internet is already allocated to subscriber IMSI001010000000002"
+	tests.RunUnitTest(t, e, tc)
+
+	// Updates keep the allocated address
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            "/magma/v1/lte/n0/subscribers/IMSI001010000000001",
+		Payload:        newTestStaticIPSubscriber("IMSI001010000000001", nil, "internet"),
+		Handler:        updateSubscriber,
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n0", "IMSI001010000000001"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	assert.Equal(t, subscriberModels.SubscriberStaticIps{"internet": "192.168.128.1"}, loadTestGroupSubscriber(t, "IMSI001010000000001").StaticIps)
+
+	// Removing the APN releases the address
+	tc.Payload = newTestStaticIPSubscriber("IMSI001010000000001", nil)
+	tests.RunUnitTest(t, e, tc)
+	assert.Equal(t, map[string]string{"192.168.128.2": "IMSI001010000000002"}, loadTestStaticIPReservations(t))
+	tc.Payload = newTestStaticIPSubscriber("IMSI001010000000001", subscriberModels.SubscriberStaticIps{"internet": "192.168.128.1"}, "internet")
+	tests.RunUnitTest(t, e, tc)
+	assert.Equal(t, map[string]string{"192.168.128.1": "IMSI001010000000001", "192.168.128.2": "IMSI001010000000002"}, loadTestStaticIPReservations(t))
+
+	usageTc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/static_ip_pools/p1/usage",
+		Handler:        getUsage,
+		ParamNames:     []string{"network_id", "static_ip_pool_id"},
+		ParamValues:    []string{"n0", "p1"},
+		ExpectedStatus: 200,
+		ExpectedResult: &subscriberModels.StaticIPPoolUsage{
+			Size:      swag.Uint64(2),
+			Allocated: swag.Uint64(2),
+			Allocations: []*subscriberModels.StaticIPAllocation{
+				{SubscriberID: "IMSI001010000000001", Apn: "internet", IP: "192.168.128.1"},
+				{SubscriberID: "IMSI001010000000002", Apn: "internet", IP: "192.168.128.2"},
+			},
+		},
+	}
+	tests.RunUnitTest(t, e, usageTc)
+
+	// Deleting a subscriber releases its address
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            "/magma/v1/lte/n0/subscribers/IMSI001010000000002",
+		Handler:        deleteSubscriber,
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n0", "IMSI001010000000002"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	usageTc.ExpectedResult = &subscriberModels.StaticIPPoolUsage{
+		Size:      swag.Uint64(2),
+		Allocated: swag.Uint64(1),
+		Allocations: []*subscriberModels.StaticIPAllocation{
+			{SubscriberID: "IMSI001010000000001", Apn: "internet", IP: "192.168.128.1"},
+		},
+	}
+	tests.RunUnitTest(t, e, usageTc)
+	assert.Equal(t, map[string]string{"192.168.128.1": "IMSI001010000000001"}, loadTestStaticIPReservations(t))
+
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n0/subscribers",
+		Payload:        newTestStaticIPSubscriber("IMSI001010000000003", nil, "internet"),
+		Handler:        createSubscriber,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+	assert.Equal(t, subscriberModels.SubscriberStaticIps{"internet": "192.168.128.2"}, loadTestGroupSubscriber(t, "IMSI001010000000003").StaticIps)
+
+	// No conflicts
+	conflictsTc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/static_ip_conflicts",
+		Handler:        listConflicts,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*subscriberModels.StaticIPConflict{}),
+	}
+	tests.RunUnitTest(t, e, conflictsTc)
+
+	// Gateway reports assigning an allocated address to another subscriber,
+	// and a pool overlapping p1 was written around the validation
+	err = subscriberdb.SetIMSIsForIPs("n0", []*protos.IPMapping{
+		{Ip: "192.168.128.1", Imsi: "IMSI001010000000001", Apn: "internet"},
+		{Ip: "192.168.128.2", Imsi: "IMSI001010000000009", Apn: "internet"},
+	})
+	assert.NoError(t, err)
+	overlapping := &subscriberModels.StaticIPPool{ID: "p0", Apn: "ims", IPBlock: "192.168.0.0/16"}
+	_, err = configurator.CreateEntity("n0", overlapping.ToEntity(), serdes.Entity)
+	assert.NoError(t, err)
+	conflictsTc.ExpectedResult = tests.JSONMarshaler([]*subscriberModels.StaticIPConflict{
+		{
+			Type:    subscriberModels.StaticIPConflictTypeOVERLAPPINGPOOLS,
+			PoolIds: []subscriberModels.StaticIPPoolID{"p0", "p1"},
+		},
+		{
+			Type:        subscriberModels.StaticIPConflictTypeREPORTEDMISMATCH,
+			IP:          "192.168.128.2",
+			PoolIds:     []subscriberModels.StaticIPPoolID{"p0", "p1"},
+			Allocations: []*subscriberModels.StaticIPAllocation{{SubscriberID: "IMSI001010000000003", Apn: "internet", IP: "192.168.128.2"}},
+			Reported:    []*subscriberModels.StaticIPAllocation{{SubscriberID: "IMSI001010000000009", Apn: "internet", IP: "192.168.128.2"}},
+		},
+	})
+	tests.RunUnitTest(t, e, conflictsTc)
+
+	// Deleting a pool releases its addresses, but keeps the static IPs of
+	// the subscribers
+	for _, id := range []string{"p0", "p1"} {
+		tc = tests.Test{
+			Method:         "DELETE",
+			URL:            "/magma/v1/lte/n0/static_ip_pools/" + id,
+			Handler:        deletePool,
+			ParamNames:     []string{"network_id", "static_ip_pool_id"},
+			ParamValues:    []string{"n0", id},
+			ExpectedStatus: 204,
+		}
+		tests.RunUnitTest(t, e, tc)
+	}
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n0/static_ip_pools/p1",
+		Handler:        getPool,
+		ParamNames:     []string{"network_id", "static_ip_pool_id"},
+		ParamValues:    []string{"n0", "p1"},
+		ExpectedStatus: 404,
+		ExpectedError:  "Not Found",
+	}
+	tests.RunUnitTest(t, e, tc)
+	assert.Equal(t, subscriberModels.SubscriberStaticIps{"internet": "192.168.128.1"}, loadTestGroupSubscriber(t, "IMSI001010000000001").StaticIps)
+	assert.Empty(t, loadTestStaticIPReservations(t))
+
+	// Creating a pool reserves the static IPs subscribers already have from
+	// its block
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n0/static_ip_pools",
+		Payload:        pool,
+		Handler:        createPool,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n0"},
+		ExpectedStatus: 201,
+	}
+	tests.RunUnitTest(t, e, tc)
+	assert.Equal(t, map[string]string{"192.168.128.1": "IMSI001010000000001", "192.168.128.2": "IMSI001010000000003"}, loadTestStaticIPReservations(t))
+	usageTc.ExpectedResult = &subscriberModels.StaticIPPoolUsage{
+		Size:      swag.Uint64(2),
+		Allocated: swag.Uint64(2),
+		Allocations: []*subscriberModels.StaticIPAllocation{
+			{SubscriberID: "IMSI001010000000001", Apn: "internet", IP: "192.168.128.1"},
+			{SubscriberID: "IMSI001010000000003", Apn: "internet", IP: "192.168.128.2"},
+		},
+	}
+	tests.RunUnitTest(t, e, usageTc)
+
+	// Shrinking the block of a pool releases the addresses outside of it
+	pool.IPBlock = "192.168.128.0/31"
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            "/magma/v1/lte/n0/static_ip_pools/p1",
+		Payload:        pool,
+		Handler:        updatePool,
+		ParamNames:     []string{"network_id", "static_ip_pool_id"},
+		ParamValues:    []string{"n0", "p1"},
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	assert.Equal(t, map[string]string{"192.168.128.1": "IMSI001010000000001"}, loadTestStaticIPReservations(t))
+}
+
+// loadTestStaticIPReservations returns the IMSIs of the subscribers the
+// addresses of network n0 are reserved for, by address.
+func loadTestStaticIPReservations(t *testing.T) map[string]string {
+	ents, _, err := configurator.LoadAllEntitiesOfType("n0", lte.StaticIPAllocationEntityType, configurator.EntityLoadCriteria{LoadConfig: true}, serdes.Entity)
+	assert.NoError(t, err)
+	ret := map[string]string{}
+	for _, ent := range ents {
+		ret[ent.Key] = string(ent.Config.(*subscriberModels.StaticIPAllocation).SubscriberID)
+	}
+	return ret
+}
+
+func newTestStaticIPSubscriber(imsi string, staticIPs subscriberModels.SubscriberStaticIps, apns ...string) *subscriberModels.MutableSubscriber {
+	return &subscriberModels.MutableSubscriber{
+		ID: policydbModels.SubscriberID(imsi),
+		Lte: &subscriberModels.LteSubscription{
+			AuthAlgo:   "MILENAGE",
+			AuthKey:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
+			AuthOpc:    []byte("\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11\x11"),
+			State:      "ACTIVE",
+			SubProfile: "default",
+		},
+		StaticIps:  staticIPs,
+		ActiveApns: apns,
+	}
+}
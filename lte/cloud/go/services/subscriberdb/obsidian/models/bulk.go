@@ -0,0 +1,284 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package models
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	policymodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+)
+
+// Columns of the subscriber CSV files. List columns hold ';' separated
+// values, static IPs are 'apn=ip' pairs and policies by APN are
+// 'apn=policy1|policy2' pairs. Auth keys are hex encoded.
+const (
+	CSVColumnID                  = "id"
+	CSVColumnName                = "name"
+	CSVColumnAuthKey             = "auth_key"
+	CSVColumnAuthOpc             = "auth_opc"
+	CSVColumnState               = "state"
+	CSVColumnSubProfile          = "sub_profile"
+	CSVColumnActiveApns          = "active_apns"
+	CSVColumnStaticIps           = "static_ips"
+	CSVColumnActivePolicies      = "active_policies"
+	CSVColumnActivePoliciesByApn = "active_policies_by_apn"
+	CSVColumnActiveBaseNames     = "active_base_names"
+	CSVColumnMsisdn              = "msisdn"
+
+	csvListSep      = ";"
+	csvPairSep      = "="
+	csvPolicyIDsSep = "|"
+
+	defaultAuthAlgo   = "MILENAGE"
+	defaultSubState   = LteSubscriptionStateACTIVE
+	defaultSubProfile = "default"
+)
+
+// BulkSubscriberCSVHeader is the header row of the exported subscriber CSV files
+var BulkSubscriberCSVHeader = []string{
+	CSVColumnID,
+	CSVColumnName,
+	CSVColumnAuthKey,
+	CSVColumnAuthOpc,
+	CSVColumnState,
+	CSVColumnSubProfile,
+	CSVColumnActiveApns,
+	CSVColumnStaticIps,
+	CSVColumnActivePolicies,
+	CSVColumnActivePoliciesByApn,
+	CSVColumnActiveBaseNames,
+	CSVColumnMsisdn,
+}
+
+// CSVColumns maps the columns of the header row of a subscriber CSV file to
+// their index.
+type CSVColumns map[string]int
+
+// NewCSVColumns validates the header row of a subscriber CSV file. Columns
+// can be in any order, only the id & auth_key columns are required.
+func NewCSVColumns(header []string) (CSVColumns, error) {
+	known := map[string]bool{}
+	for _, column := range BulkSubscriberCSVHeader {
+		known[column] = true
+	}
+	columns := CSVColumns{}
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !known[column] {
+			return nil, errors.Errorf("unknown column %s", column)
+		}
+		if _, exists := columns[column]; exists {
+			return nil, errors.Errorf("duplicate column %s", column)
+		}
+		columns[column] = i
+	}
+	for _, column := range []string{CSVColumnID, CSVColumnAuthKey} {
+		if _, exists := columns[column]; !exists {
+			return nil, errors.Errorf("missing column %s", column)
+		}
+	}
+	return columns, nil
+}
+
+func (c CSVColumns) get(record []string, column string) string {
+	i, exists := c[column]
+	if !exists || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// FromCSVRecord fills the subscriber from a row of a CSV file. The auth algo,
+// state & sub profile default to MILENAGE, ACTIVE & default.
+func (m *BulkSubscriber) FromCSVRecord(columns CSVColumns, record []string) (*BulkSubscriber, error) {
+	authKey, err := decodeHexKey(columns.get(record, CSVColumnAuthKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid auth_key")
+	}
+	authOpc, err := decodeHexKey(columns.get(record, CSVColumnAuthOpc))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid auth_opc")
+	}
+	staticIPs, err := parseCSVPairs(columns.get(record, CSVColumnStaticIps))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid static_ips")
+	}
+	policiesByApn, err := parseCSVPairs(columns.get(record, CSVColumnActivePoliciesByApn))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid active_policies_by_apn")
+	}
+
+	m.ID = policymodels.SubscriberID(columns.get(record, CSVColumnID))
+	m.Name = columns.get(record, CSVColumnName)
+	m.Msisdn = Msisdn(columns.get(record, CSVColumnMsisdn))
+	m.Lte = &LteSubscription{
+		AuthAlgo:   defaultAuthAlgo,
+		AuthKey:    authKey,
+		AuthOpc:    authOpc,
+		State:      columns.get(record, CSVColumnState),
+		SubProfile: SubProfile(columns.get(record, CSVColumnSubProfile)),
+	}
+	if m.Lte.State == "" {
+		m.Lte.State = defaultSubState
+	}
+	if m.Lte.SubProfile == "" {
+		m.Lte.SubProfile = defaultSubProfile
+	}
+
+	m.ActiveApns = splitCSVList(columns.get(record, CSVColumnActiveApns))
+	for _, policyID := range splitCSVList(columns.get(record, CSVColumnActivePolicies)) {
+		m.ActivePolicies = append(m.ActivePolicies, policymodels.PolicyID(policyID))
+	}
+	for _, baseName := range splitCSVList(columns.get(record, CSVColumnActiveBaseNames)) {
+		m.ActiveBaseNames = append(m.ActiveBaseNames, policymodels.BaseName(baseName))
+	}
+	if len(staticIPs) > 0 {
+		m.StaticIps = SubscriberStaticIps{}
+		for apn, ip := range staticIPs {
+			m.StaticIps[apn] = strfmt.IPv4(ip)
+		}
+	}
+	if len(policiesByApn) > 0 {
+		m.ActivePoliciesByApn = policymodels.PolicyIdsByApn{}
+		for apn, policies := range policiesByApn {
+			policyIDs := policymodels.PolicyIds{}
+			for _, policyID := range strings.Split(policies, csvPolicyIDsSep) {
+				if policyID = strings.TrimSpace(policyID); policyID != "" {
+					policyIDs = append(policyIDs, policymodels.PolicyID(policyID))
+				}
+			}
+			m.ActivePoliciesByApn[apn] = policyIDs
+		}
+	}
+	return m, nil
+}
+
+// ToCSVRecord returns the row of the subscriber in the columns of
+// BulkSubscriberCSVHeader.
+func (m *BulkSubscriber) ToCSVRecord() []string {
+	var lte LteSubscription
+	if m.Lte != nil {
+		lte = *m.Lte
+	}
+
+	var staticIPs []string
+	for apn, ip := range m.StaticIps {
+		staticIPs = append(staticIPs, apn+csvPairSep+string(ip))
+	}
+	var policiesByApn []string
+	for apn, policyIDs := range m.ActivePoliciesByApn {
+		var policies []string
+		for _, policyID := range policyIDs {
+			policies = append(policies, string(policyID))
+		}
+		policiesByApn = append(policiesByApn, apn+csvPairSep+strings.Join(policies, csvPolicyIDsSep))
+	}
+	var policies []string
+	for _, policyID := range m.ActivePolicies {
+		policies = append(policies, string(policyID))
+	}
+	var baseNames []string
+	for _, baseName := range m.ActiveBaseNames {
+		baseNames = append(baseNames, string(baseName))
+	}
+	sort.Strings(staticIPs)
+	sort.Strings(policiesByApn)
+
+	return []string{
+		string(m.ID),
+		m.Name,
+		hex.EncodeToString(lte.AuthKey),
+		hex.EncodeToString(lte.AuthOpc),
+		lte.State,
+		string(lte.SubProfile),
+		strings.Join(m.ActiveApns, csvListSep),
+		strings.Join(staticIPs, csvListSep),
+		strings.Join(policies, csvListSep),
+		strings.Join(policiesByApn, csvListSep),
+		strings.Join(baseNames, csvListSep),
+		string(m.Msisdn),
+	}
+}
+
+// FromMutableSubscriber fills the bulk record from a subscriber & its MSISDN,
+// which may be empty.
+func (m *BulkSubscriber) FromMutableSubscriber(sub *MutableSubscriber, msisdn string) *BulkSubscriber {
+	m.ActiveApns = sub.ActiveApns
+	m.ActiveBaseNames = sub.ActiveBaseNames
+	m.ActivePolicies = sub.ActivePolicies
+	m.ActivePoliciesByApn = sub.ActivePoliciesByApn
+	m.ID = sub.ID
+	m.Lte = sub.Lte
+	m.Msisdn = Msisdn(msisdn)
+	m.Name = sub.Name
+	m.StaticIps = sub.StaticIps
+	return m
+}
+
+// ToMutableSubscriber returns the subscriber of the bulk record, without its MSISDN.
+func (m *BulkSubscriber) ToMutableSubscriber() *MutableSubscriber {
+	return &MutableSubscriber{
+		ActiveApns:          m.ActiveApns,
+		ActiveBaseNames:     m.ActiveBaseNames,
+		ActivePolicies:      m.ActivePolicies,
+		ActivePoliciesByApn: m.ActivePoliciesByApn,
+		ID:                  m.ID,
+		Lte:                 m.Lte,
+		Name:                m.Name,
+		StaticIps:           m.StaticIps,
+	}
+}
+
+func decodeHexKey(key string) (strfmt.Base64, error) {
+	if key == "" {
+		return nil, nil
+	}
+	decoded, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(key), "0x"))
+	if err != nil {
+		return nil, errors.New("expected a hex encoded key")
+	}
+	return decoded, nil
+}
+
+func splitCSVList(value string) []string {
+	var ret []string
+	for _, item := range strings.Split(value, csvListSep) {
+		if item = strings.TrimSpace(item); item != "" {
+			ret = append(ret, item)
+		}
+	}
+	return ret
+}
+
+func parseCSVPairs(value string) (map[string]string, error) {
+	ret := map[string]string{}
+	for _, pair := range splitCSVList(value) {
+		kv := strings.SplitN(pair, csvPairSep, 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			return nil, fmt.Errorf("expected apn%svalue pair but got '%s'", csvPairSep, pair)
+		}
+		key := strings.TrimSpace(kv[0])
+		if _, exists := ret[key]; exists {
+			return nil, fmt.Errorf("duplicate apn %s", key)
+		}
+		ret[key] = strings.TrimSpace(kv[1])
+	}
+	return ret, nil
+}
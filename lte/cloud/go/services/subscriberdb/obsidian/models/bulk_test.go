@@ -0,0 +1,114 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package models
+
+import (
+	"testing"
+
+	policymodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCSVColumns(t *testing.T) {
+	columns, err := NewCSVColumns([]string{"auth_key", " ID ", "msisdn"})
+	assert.NoError(t, err)
+	assert.Equal(t, CSVColumns{"auth_key": 0, "id": 1, "msisdn": 2}, columns)
+
+	_, err = NewCSVColumns([]string{"id", "auth_key", "imei"})
+	assert.EqualError(t, err, "unknown column imei")
+	_, err = NewCSVColumns([]string{"id", "auth_key", "id"})
+	assert.EqualError(t, err, "duplicate column id")
+	_, err = NewCSVColumns([]string{"id", "name"})
+	assert.EqualError(t, err, "missing column auth_key")
+}
+
+func TestBulkSubscriber_CSVRecord(t *testing.T) {
+	sub := &BulkSubscriber{
+		ID:   "IMSI001010000000001",
+		Name: "Jane Doe",
+		Lte: &LteSubscription{
+			AuthAlgo:   "MILENAGE",
+			AuthKey:    []byte("\x00\x11\x22\x33\x44\x55\x66\x77\x88\x99\xaa\xbb\xcc\xdd\xee\xff"),
+			AuthOpc:    []byte("\xff\xee\xdd\xcc\xbb\xaa\x99\x88\x77\x66\x55\x44\x33\x22\x11\x00"),
+			State:      "INACTIVE",
+			SubProfile: "gold",
+		},
+		ActiveApns:      []string{"internet", "ims"},
+		StaticIps:       SubscriberStaticIps{"ims": "10.0.0.2", "internet": "192.168.0.2"},
+		ActivePolicies:  policymodels.PolicyIds{"p1", "p2"},
+		ActiveBaseNames: policymodels.BaseNames{"b1"},
+		ActivePoliciesByApn: policymodels.PolicyIdsByApn{
+			"internet": {"p3", "p4"},
+			"ims":      {"p5"},
+		},
+		Msisdn: "13105551234",
+	}
+
+	record := sub.ToCSVRecord()
+	assert.Equal(t, []string{
+		"IMSI001010000000001",
+		"Jane Doe",
+		"00112233445566778899aabbccddeeff",
+		"ffeeddccbbaa99887766554433221100",
+		"INACTIVE",
+		"gold",
+		"internet;ims",
+		"ims=10.0.0.2;internet=192.168.0.2",
+		"p1;p2",
+		"ims=p5;internet=p3|p4",
+		"b1",
+		"13105551234",
+	}, record)
+
+	columns, err := NewCSVColumns(BulkSubscriberCSVHeader)
+	assert.NoError(t, err)
+	parsed, err := (&BulkSubscriber{}).FromCSVRecord(columns, record)
+	assert.NoError(t, err)
+	assert.Equal(t, sub, parsed)
+	assert.NoError(t, parsed.ValidateModel())
+
+	// Defaults & reordered columns
+	columns, err = NewCSVColumns([]string{"auth_key", "id"})
+	assert.NoError(t, err)
+	parsed, err = (&BulkSubscriber{}).FromCSVRecord(columns, []string{"0x00112233445566778899AABBCCDDEEFF", "IMSI001010000000002"})
+	assert.NoError(t, err)
+	assert.Equal(t, &BulkSubscriber{
+		ID: "IMSI001010000000002",
+		Lte: &LteSubscription{
+			AuthAlgo:   "MILENAGE",
+			AuthKey:    []byte("\x00\x11\x22\x33\x44\x55\x66\x77\x88\x99\xaa\xbb\xcc\xdd\xee\xff"),
+			State:      "ACTIVE",
+			SubProfile: "default",
+		},
+	}, parsed)
+	assert.NoError(t, parsed.ValidateModel())
+
+	// Invalid values
+	columns, err = NewCSVColumns([]string{"id", "auth_key", "static_ips", "active_policies_by_apn"})
+	assert.NoError(t, err)
+	_, err = (&BulkSubscriber{}).FromCSVRecord(columns, []string{"IMSI001010000000003", "xyz", "", ""})
+	assert.EqualError(t, err, "invalid auth_key: expected a hex encoded key")
+	_, err = (&BulkSubscriber{}).FromCSVRecord(columns, []string{"IMSI001010000000003", "", "internet", ""})
+	assert.EqualError(t, err, "invalid static_ips: expected apn=value pair but got 'internet'")
+	_, err = (&BulkSubscriber{}).FromCSVRecord(columns, []string{"IMSI001010000000003", "", "", "ims=p1;ims=p2"})
+	assert.EqualError(t, err, "invalid active_policies_by_apn: duplicate apn ims")
+
+	// Models are validated as a whole
+	parsed, err = (&BulkSubscriber{}).FromCSVRecord(columns, []string{"IMSI001010000000003", "0011", "internet=10.0.0.1", ""})
+	assert.NoError(t, err)
+	assert.EqualError(t, parsed.ValidateModel(), "expected lte auth key to be 16 bytes but got 2 bytes")
+	parsed.Lte.AuthKey = sub.Lte.AuthKey
+	assert.EqualError(t, parsed.ValidateModel(), "static IP assigned to APN internet which is not active for the subscriber")
+}
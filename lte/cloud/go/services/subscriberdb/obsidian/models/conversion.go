@@ -183,6 +183,11 @@ func (m *MutableSubscriber) GetAssocs() []storage.TypeAndKey {
 	return assocs
 }
 
+// RedactKeys removes the auth key & OPc, which are never returned by the API.
+func (m *LteSubscription) RedactKeys() {
+	m.AuthKey, m.AuthOpc = nil, nil
+}
+
 func (m *SubProfile) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
@@ -0,0 +1,143 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package models
+
+import (
+	"sort"
+
+	"magma/lte/cloud/go/lte"
+	policymodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+)
+
+// Subscriber groups are stored as subscriber_group entities, with an
+// association to each subscriber of the group. Subscribers are children of
+// their groups, so updates of the subscribers keep their group memberships.
+
+func (m *SubscriberGroup) ToEntity() configurator.NetworkEntity {
+	return configurator.NetworkEntity{
+		Type:         lte.SubscriberGroupEntityType,
+		Key:          string(m.ID),
+		Name:         m.Name,
+		Description:  m.Description,
+		Associations: m.GetAssocs(),
+	}
+}
+
+func (m *SubscriberGroup) FromEntity(ent configurator.NetworkEntity) *SubscriberGroup {
+	m.ID = SubscriberGroupID(ent.Key)
+	m.Name = ent.Name
+	m.Description = ent.Description
+	m.Subscribers = nil
+	for _, tk := range ent.Associations.Filter(lte.SubscriberEntityType) {
+		m.Subscribers = append(m.Subscribers, policymodels.SubscriberID(tk.Key))
+	}
+	sort.Slice(m.Subscribers, func(i, j int) bool { return m.Subscribers[i] < m.Subscribers[j] })
+	return m
+}
+
+func (m *SubscriberGroup) ToUpdateCriteria() configurator.EntityUpdateCriteria {
+	return configurator.EntityUpdateCriteria{
+		Type:              lte.SubscriberGroupEntityType,
+		Key:               string(m.ID),
+		NewName:           swag.String(m.Name),
+		NewDescription:    swag.String(m.Description),
+		AssociationsToSet: m.GetAssocs(),
+	}
+}
+
+// GetAssocs returns the associations to the subscribers of the group. The
+// returned slice is never nil, so updates clear the subscribers of emptied
+// groups.
+func (m *SubscriberGroup) GetAssocs() []storage.TypeAndKey {
+	assocs := []storage.TypeAndKey{}
+	for _, sid := range m.Subscribers {
+		assocs = append(assocs, storage.TypeAndKey{Type: lte.SubscriberEntityType, Key: string(sid)})
+	}
+	return assocs
+}
+
+// ApplyTo applies the action to the subscriber.
+func (m *SubscriberGroupAction) ApplyTo(sub *MutableSubscriber) error {
+	if sub.Lte == nil {
+		return errors.Errorf("subscriber %s has no LTE subscription", sub.ID)
+	}
+
+	switch m.Action {
+	case SubscriberGroupActionActionACTIVATE:
+		sub.Lte.State = LteSubscriptionStateACTIVE
+	case SubscriberGroupActionActionDEACTIVATE:
+		sub.Lte.State = LteSubscriptionStateINACTIVE
+	case SubscriberGroupActionActionSETSUBPROFILE:
+		sub.Lte.SubProfile = m.SubProfile
+	case SubscriberGroupActionActionADDPOLICIES:
+		for _, policyID := range m.Policies {
+			if !containsPolicy(sub.ActivePolicies, policyID) {
+				sub.ActivePolicies = append(sub.ActivePolicies, policyID)
+			}
+		}
+	case SubscriberGroupActionActionREMOVEPOLICIES:
+		var kept policymodels.PolicyIds
+		for _, policyID := range sub.ActivePolicies {
+			if !containsPolicy(m.Policies, policyID) {
+				kept = append(kept, policyID)
+			}
+		}
+		sub.ActivePolicies = kept
+	case SubscriberGroupActionActionADDAPNS:
+		for _, apn := range m.Apns {
+			if !containsAPN(sub.ActiveApns, apn) {
+				sub.ActiveApns = append(sub.ActiveApns, apn)
+			}
+		}
+	case SubscriberGroupActionActionREMOVEAPNS:
+		var kept ApnList
+		for _, apn := range sub.ActiveApns {
+			if !containsAPN(m.Apns, apn) {
+				kept = append(kept, apn)
+			}
+		}
+		sub.ActiveApns = kept
+		// The static IPs & policies of removed APNs can't be kept
+		for _, apn := range m.Apns {
+			delete(sub.StaticIps, apn)
+			delete(sub.ActivePoliciesByApn, apn)
+		}
+	default:
+		return errors.Errorf("unknown subscriber group action %s", m.Action)
+	}
+	return nil
+}
+
+func containsPolicy(policyIDs policymodels.PolicyIds, policyID policymodels.PolicyID) bool {
+	for _, id := range policyIDs {
+		if id == policyID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAPN(apns ApnList, apn string) bool {
+	for _, a := range apns {
+		if a == apn {
+			return true
+		}
+	}
+	return false
+}
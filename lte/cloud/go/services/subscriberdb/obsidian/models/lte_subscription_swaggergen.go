@@ -24,12 +24,12 @@ type LteSubscription struct {
 	// Enum: [MILENAGE]
 	AuthAlgo string `json:"auth_algo"`
 
-	// auth key
+	// Auth key (K). Never returned by the API.
 	// Required: true
 	// Format: byte
 	AuthKey strfmt.Base64 `json:"auth_key"`
 
-	// auth opc
+	// Auth OPc. Never returned by the API.
 	// Format: byte
 	AuthOpc strfmt.Base64 `json:"auth_opc,omitempty"`
 
@@ -6,7 +6,10 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	strfmt "github.com/go-openapi/strfmt"
+	models1 "magma/lte/cloud/go/services/policydb/obsidian/models"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/swag"
@@ -21,6 +24,9 @@ type PaginatedSubscribers struct {
 	// Required: true
 	NextPageToken NextPageToken `json:"next_page_token"`
 
+	// IDs of the subscribers of the page, in the requested sort order
+	SubscriberIds []models1.SubscriberID `json:"subscriber_ids,omitempty"`
+
 	// subscribers
 	// Required: true
 	Subscribers map[string]*Subscriber `json:"subscribers"`
@@ -34,6 +40,10 @@ func (m *PaginatedSubscribers) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateSubscriberIds(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateSubscribers(formats); err != nil {
 		res = append(res, err)
 	}
@@ -56,6 +66,26 @@ func (m *PaginatedSubscribers) validateNextPageToken(formats strfmt.Registry) er
 	return nil
 }
 
+func (m *PaginatedSubscribers) validateSubscriberIds(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.SubscriberIds) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.SubscriberIds); i++ {
+
+		if err := m.SubscriberIds[i].Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("subscriber_ids" + "." + strconv.Itoa(i))
+			}
+			return err
+		}
+
+	}
+
+	return nil
+}
+
 func (m *PaginatedSubscribers) validateSubscribers(formats strfmt.Registry) error {
 
 	for k := range m.Subscribers {
@@ -23,5 +23,7 @@ var (
 	// EntitySerdes contains the package's configurator network entity serdes
 	EntitySerdes = serde.NewRegistry(
 		configurator.NewNetworkEntityConfigSerde(lte.SubscriberEntityType, &SubscriberConfig{}),
+		configurator.NewNetworkEntityConfigSerde(lte.StaticIPPoolEntityType, &StaticIPPool{}),
+		configurator.NewNetworkEntityConfigSerde(lte.StaticIPAllocationEntityType, &StaticIPAllocation{}),
 	)
 )
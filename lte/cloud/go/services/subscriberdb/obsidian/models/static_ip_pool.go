@@ -0,0 +1,145 @@
+/*
+ * Copyright 2020 The Magma Authors.
+ *
+ * This source code is licensed under the BSD-style license found in the
+ * LICENSE file in the root directory of this source tree.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package models
+
+import (
+	"magma/lte/cloud/go/lte"
+	policymodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	"magma/lte/cloud/go/services/subscriberdb/ippool"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/pkg/errors"
+)
+
+// Static IP pools are stored as static_ip_pool entities, with the pool as
+// config. The addresses allocated from the pools are indexed by
+// static_ip_allocation entities, keyed by address, with the allocation as
+// config and an association to their pool. Entity keys are unique in a
+// network, so an address is reserved by writing its allocation in the same
+// transaction as the static IP of the subscriber, which fails if the address
+// is already reserved.
+
+func (m *StaticIPPool) ToEntity() configurator.NetworkEntity {
+	return configurator.NetworkEntity{
+		Type:   lte.StaticIPPoolEntityType,
+		Key:    string(m.ID),
+		Config: m,
+	}
+}
+
+func (m *StaticIPPool) FromEntity(ent configurator.NetworkEntity) (*StaticIPPool, error) {
+	config, ok := ent.Config.(*StaticIPPool)
+	if !ok {
+		return nil, errors.Errorf("static IP pool %s has no config", ent.Key)
+	}
+	*m = *config
+	m.ID = StaticIPPoolID(ent.Key)
+	return m, nil
+}
+
+func (m *StaticIPPool) ToUpdateCriteria() configurator.EntityUpdateCriteria {
+	return configurator.EntityUpdateCriteria{
+		Type:      lte.StaticIPPoolEntityType,
+		Key:       string(m.ID),
+		NewConfig: m,
+	}
+}
+
+// ToPool returns the pool allocating the addresses of the model.
+func (m *StaticIPPool) ToPool() (ippool.Pool, error) {
+	block, err := ippool.ParseBlock(m.IPBlock)
+	if err != nil {
+		return ippool.Pool{}, err
+	}
+	return ippool.Pool{ID: string(m.ID), APN: m.Apn, Block: block}, nil
+}
+
+// GetStaticIPAllocations returns the static IPs of the subscriber entities.
+func GetStaticIPAllocations(subscriberEnts configurator.NetworkEntities) []ippool.Allocation {
+	var ret []ippool.Allocation
+	for _, ent := range subscriberEnts {
+		config, ok := ent.Config.(*SubscriberConfig)
+		if !ok {
+			continue
+		}
+		for apn, ip := range config.StaticIps {
+			ret = append(ret, ippool.Allocation{IMSI: ent.Key, APN: apn, IP: string(ip)})
+		}
+	}
+	return ret
+}
+
+func (m *StaticIPAllocation) FromAllocation(allocation ippool.Allocation) *StaticIPAllocation {
+	m.SubscriberID = policymodels.SubscriberID(allocation.IMSI)
+	m.Apn = allocation.APN
+	m.IP = strfmt.IPv4(allocation.IP)
+	return m
+}
+
+// ToEntity returns the entity reserving the address of the allocation in the
+// pool.
+func (m *StaticIPAllocation) ToEntity(poolID string) configurator.NetworkEntity {
+	return configurator.NetworkEntity{
+		Type:         lte.StaticIPAllocationEntityType,
+		Key:          ippool.Normalize(string(m.IP)),
+		Config:       m,
+		Associations: []storage.TypeAndKey{{Type: lte.StaticIPPoolEntityType, Key: poolID}},
+	}
+}
+
+func (m *StaticIPAllocation) FromEntity(ent configurator.NetworkEntity) (*StaticIPAllocation, error) {
+	config, ok := ent.Config.(*StaticIPAllocation)
+	if !ok {
+		return nil, errors.Errorf("static IP allocation %s has no config", ent.Key)
+	}
+	*m = *config
+	m.IP = strfmt.IPv4(ent.Key)
+	return m, nil
+}
+
+func (m *StaticIPAllocation) ToAllocation() ippool.Allocation {
+	return ippool.Allocation{IMSI: string(m.SubscriberID), APN: m.Apn, IP: string(m.IP)}
+}
+
+// FromAllocations returns the usage of the pool by the allocations, which are
+// sorted by address.
+func (m *StaticIPPoolUsage) FromAllocations(pool ippool.Pool, allocations []ippool.Allocation) *StaticIPPoolUsage {
+	m.Size = swag.Uint64(pool.Size())
+	m.Allocated = swag.Uint64(uint64(len(ippool.GetAllocated(pool, allocations))))
+	m.Allocations = []*StaticIPAllocation{}
+	for _, allocation := range ippool.GetPoolAllocations(pool, allocations) {
+		m.Allocations = append(m.Allocations, (&StaticIPAllocation{}).FromAllocation(allocation))
+	}
+	return m
+}
+
+func (m *StaticIPConflict) FromConflict(conflict ippool.Conflict) *StaticIPConflict {
+	m.Type = conflict.Type
+	m.IP = strfmt.IPv4(conflict.IP)
+	m.PoolIds = []StaticIPPoolID{}
+	for _, id := range conflict.PoolIDs {
+		m.PoolIds = append(m.PoolIds, StaticIPPoolID(id))
+	}
+	m.Allocations, m.Reported = nil, nil
+	for _, allocation := range conflict.Allocations {
+		m.Allocations = append(m.Allocations, (&StaticIPAllocation{}).FromAllocation(allocation))
+	}
+	for _, allocation := range conflict.Reported {
+		m.Reported = append(m.Reported, (&StaticIPAllocation{}).FromAllocation(allocation))
+	}
+	return m
+}
@@ -74,3 +74,43 @@ func (m *IcmpStatus) ValidateModel() error {
 func (m *MsisdnAssignment) ValidateModel() error {
 	return m.Validate(strfmt.Default)
 }
+
+func (m *BulkSubscriber) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	return m.ToMutableSubscriber().ValidateModel()
+}
+
+func (m *SubscriberGroup) ValidateModel() error {
+	return m.Validate(strfmt.Default)
+}
+
+func (m *SubscriberGroupAction) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	switch m.Action {
+	case SubscriberGroupActionActionADDPOLICIES, SubscriberGroupActionActionREMOVEPOLICIES:
+		if len(m.Policies) == 0 {
+			return errors.Errorf("action %s requires policies", m.Action)
+		}
+	case SubscriberGroupActionActionSETSUBPROFILE:
+		if m.SubProfile == "" {
+			return errors.Errorf("action %s requires a sub profile", m.Action)
+		}
+	case SubscriberGroupActionActionADDAPNS, SubscriberGroupActionActionREMOVEAPNS:
+		if len(m.Apns) == 0 {
+			return errors.Errorf("action %s requires apns", m.Action)
+		}
+	}
+	return nil
+}
+
+func (m *StaticIPPool) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	_, err := m.ToPool()
+	return err
+}
@@ -29,7 +29,10 @@ type GetMSISDNsRequest struct {
 	NetworkId string `protobuf:"bytes,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
 	// msisdns whose IMSIs should be retrieved
 	// An empty list returns all tracked MSISDNs
-	Msisdns              []string `protobuf:"bytes,2,rep,name=msisdns,proto3" json:"msisdns,omitempty"`
+	Msisdns []string `protobuf:"bytes,2,rep,name=msisdns,proto3" json:"msisdns,omitempty"`
+	// msisdn_prefix restricts the returned MSISDNs to the ones starting with
+	// the prefix. Can't be used with msisdns.
+	MsisdnPrefix         string   `protobuf:"bytes,3,opt,name=msisdn_prefix,json=msisdnPrefix,proto3" json:"msisdn_prefix,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -74,6 +77,13 @@ func (m *GetMSISDNsRequest) GetMsisdns() []string {
 	return nil
 }
 
+func (m *GetMSISDNsRequest) GetMsisdnPrefix() string {
+	if m != nil {
+		return m.MsisdnPrefix
+	}
+	return ""
+}
+
 type GetMSISDNsResponse struct {
 	// imsis_by_msisdn lists the requested imsis, keyed by their msisdn
 	ImsisByMsisdn        map[string]string `protobuf:"bytes,1,rep,name=imsis_by_msisdn,json=imsisByMsisdn,proto3" json:"imsis_by_msisdn,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -529,38 +539,40 @@ func init() {
 func init() { proto.RegisterFile("subscriberdb.proto", fileDescriptor_7926c2bb91580e5a) }
 
 var fileDescriptor_7926c2bb91580e5a = []byte{
-	// 483 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xd1, 0x8a, 0xd3, 0x40,
-	0x14, 0xdd, 0x24, 0x6b, 0x35, 0x77, 0x6d, 0xcd, 0xde, 0x5d, 0x4a, 0x08, 0x08, 0x75, 0x40, 0xe9,
-	0xaa, 0xe4, 0x61, 0x7d, 0x11, 0x41, 0x58, 0xcb, 0xca, 0x12, 0x68, 0x65, 0xc9, 0xf8, 0xa2, 0x20,
-	0x25, 0xb1, 0xc3, 0x32, 0xb4, 0x4d, 0xc6, 0xcc, 0x54, 0xe9, 0x9b, 0x3f, 0xe6, 0xbf, 0x49, 0x92,
-	0x49, 0x9a, 0xed, 0x6e, 0xdd, 0x28, 0x3e, 0xf5, 0xce, 0xf4, 0xdc, 0x73, 0xe6, 0xde, 0x73, 0x5a,
-	0x40, 0xb9, 0x8a, 0xe5, 0xd7, 0x8c, 0xc7, 0x2c, 0x9b, 0xc5, 0xbe, 0xc8, 0x52, 0x95, 0x62, 0x7f,
-	0x19, 0x5d, 0x2d, 0x23, 0x7f, 0xa1, 0x98, 0xdf, 0xfc, 0x96, 0x8c, 0xe1, 0xf0, 0x82, 0xa9, 0x09,
-	0x0d, 0xe8, 0xf9, 0x07, 0x19, 0xb2, 0x6f, 0x2b, 0x26, 0x15, 0x3e, 0x06, 0x48, 0x98, 0xfa, 0x91,
-	0x66, 0xf3, 0x29, 0x9f, 0xb9, 0xc6, 0xc0, 0x18, 0xda, 0xa1, 0xad, 0x6f, 0x82, 0x19, 0xba, 0x70,
-	0x7f, 0x29, 0xb9, 0x9c, 0x25, 0xd2, 0x35, 0x07, 0xd6, 0xd0, 0x0e, 0xab, 0x23, 0xf9, 0x65, 0x00,
-	0x36, 0xe9, 0xa4, 0x48, 0x13, 0xc9, 0x90, 0xc1, 0x23, 0x9e, 0x43, 0xa6, 0xf1, 0x7a, 0x5a, 0x42,
-	0x5d, 0x63, 0x60, 0x0d, 0x0f, 0x4e, 0xdf, 0xfa, 0xb7, 0x3f, 0xcb, 0xbf, 0x49, 0xe2, 0x07, 0x79,
-	0xe7, 0x68, 0x3d, 0x29, 0xfa, 0xdf, 0x27, 0x2a, 0x5b, 0x87, 0x5d, 0xde, 0xbc, 0xf3, 0xce, 0x00,
-	0x6f, 0x82, 0xd0, 0x01, 0x6b, 0xce, 0xd6, 0x7a, 0x8a, 0xbc, 0xc4, 0x63, 0xb8, 0xf7, 0x3d, 0x5a,
-	0xac, 0x98, 0x6b, 0x16, 0x77, 0xe5, 0xe1, 0x8d, 0xf9, 0xda, 0x20, 0x5f, 0xc0, 0xa1, 0x95, 0x72,
-	0xcb, 0x65, 0xf4, 0xa1, 0xa3, 0x47, 0x2a, 0xd9, 0xf4, 0x09, 0x11, 0xf6, 0xf3, 0xd7, 0xb9, 0x56,
-	0x71, 0x5b, 0xd4, 0xe4, 0x08, 0x0e, 0x1b, 0xf4, 0xe5, 0x5c, 0x64, 0x0c, 0x47, 0xe7, 0x6c, 0xc1,
-	0x14, 0xfb, 0x1f, 0xb2, 0xa4, 0x0f, 0xc7, 0xd7, 0xd9, 0xb4, 0xca, 0x19, 0x74, 0x2f, 0x98, 0x0a,
-	0x2e, 0xdb, 0x7a, 0xec, 0x80, 0xc5, 0x45, 0xe5, 0x6f, 0x5e, 0x92, 0x8f, 0xd0, 0xab, 0x18, 0xb4,
-	0xad, 0x23, 0x38, 0xe0, 0x62, 0xba, 0x8c, 0x84, 0xe0, 0xc9, 0x95, 0xd4, 0x96, 0x3e, 0xd9, 0x65,
-	0x69, 0x70, 0x39, 0x29, 0x91, 0x21, 0x70, 0xa1, 0x4b, 0x49, 0x32, 0xe8, 0xd2, 0xbf, 0x79, 0xd7,
-	0x96, 0xa6, 0xf9, 0x2f, 0x9a, 0x0e, 0xf4, 0xe8, 0xb5, 0x49, 0xc8, 0x3b, 0xb0, 0x6b, 0x28, 0xf6,
-	0xc0, 0xe4, 0x42, 0x2b, 0x9b, 0x5c, 0xd4, 0x4e, 0x9a, 0x1b, 0x27, 0xf3, 0xf5, 0x44, 0x22, 0xd1,
-	0xe6, 0xe6, 0xe5, 0xe9, 0xcf, 0x7d, 0x70, 0x68, 0xad, 0x3d, 0x4e, 0xd3, 0xf9, 0x4a, 0x20, 0x03,
-	0xd8, 0x24, 0x19, 0x4f, 0xda, 0xa4, 0xbd, 0xd8, 0x82, 0xf7, 0xbc, 0xfd, 0x0f, 0x83, 0xec, 0x61,
-	0x0c, 0x76, 0x9d, 0x2b, 0x1c, 0xee, 0x6a, 0xdd, 0x4e, 0xb6, 0x77, 0xd2, 0x02, 0x59, 0x6b, 0xcc,
-	0xe1, 0x61, 0x33, 0x58, 0xf8, 0x62, 0x57, 0xf3, 0x2d, 0x61, 0xf6, 0x5e, 0xb6, 0x03, 0xd7, 0x62,
-	0x9f, 0xa0, 0x53, 0x66, 0x0d, 0x9f, 0xfe, 0x61, 0x11, 0x9b, 0xd4, 0x78, 0xcf, 0xee, 0x82, 0x35,
-	0xa9, 0xe9, 0x1d, 0xd4, 0xb4, 0x1d, 0xf5, 0x56, 0x86, 0xf6, 0x46, 0x0f, 0x3e, 0x77, 0x8a, 0x3f,
-	0x5b, 0x19, 0x97, 0x9f, 0xaf, 0x7e, 0x07, 0x00, 0x00, 0xff, 0xff, 0x86, 0x0c, 0x92, 0x79, 0x8a,
-	0x05, 0x00, 0x00,
+	// 520 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xe1, 0x6b, 0xd3, 0x40,
+	0x14, 0x5f, 0xd3, 0x39, 0xcd, 0xdb, 0x5a, 0xb3, 0xdb, 0x28, 0x21, 0x20, 0xd4, 0x13, 0xa5, 0x53,
+	0x49, 0x60, 0x7e, 0x11, 0x51, 0x98, 0x65, 0x32, 0x02, 0xab, 0x94, 0xc4, 0x2f, 0x0a, 0x12, 0x92,
+	0xe6, 0x2c, 0x47, 0xd3, 0xe4, 0x96, 0x4b, 0xa6, 0xfd, 0xe6, 0x3f, 0xe6, 0xff, 0x26, 0xc9, 0x5d,
+	0xd3, 0xb4, 0x5b, 0x5d, 0x14, 0x3f, 0xe5, 0xdd, 0xcb, 0xef, 0xfd, 0x7e, 0xf7, 0xde, 0xfb, 0x25,
+	0xf0, 0x36, 0xca, 0x88, 0x35, 0x89, 0x92, 0x3c, 0xb4, 0xa6, 0x89, 0xc5, 0x49, 0x7a, 0x4d, 0x27,
+	0x84, 0x5b, 0x3c, 0x0f, 0xf8, 0x24, 0xa5, 0x01, 0x49, 0xc3, 0xc0, 0x62, 0x69, 0x92, 0x25, 0xeb,
+	0x39, 0xb3, 0xcc, 0xa1, 0xde, 0xdc, 0x9f, 0xce, 0x7d, 0x33, 0xca, 0x88, 0x59, 0x7f, 0x8b, 0xaf,
+	0xe0, 0xf0, 0x82, 0x64, 0x23, 0xd7, 0x76, 0xcf, 0x3f, 0x72, 0x87, 0x5c, 0xe5, 0x84, 0x67, 0xe8,
+	0x11, 0x40, 0x4c, 0xb2, 0xef, 0x49, 0x3a, 0xf3, 0x68, 0xa8, 0xb7, 0xfa, 0xad, 0x81, 0xea, 0xa8,
+	0x32, 0x63, 0x87, 0x48, 0x87, 0xfb, 0x73, 0x4e, 0x79, 0x18, 0x73, 0x5d, 0xe9, 0xb7, 0x07, 0xaa,
+	0xb3, 0x3c, 0xa2, 0x27, 0xd0, 0x11, 0xa1, 0xc7, 0x52, 0xf2, 0x8d, 0xfe, 0xd0, 0xdb, 0x65, 0xed,
+	0x81, 0x48, 0x8e, 0xcb, 0x1c, 0xfe, 0xd5, 0x02, 0x54, 0xd7, 0xe4, 0x2c, 0x89, 0x39, 0x41, 0x04,
+	0x1e, 0xd2, 0x02, 0xe7, 0x05, 0x0b, 0x4f, 0xe0, 0xf5, 0x56, 0xbf, 0x3d, 0xd8, 0x3f, 0x7d, 0x67,
+	0xde, 0x7e, 0x77, 0xf3, 0x26, 0x89, 0x69, 0x17, 0x95, 0xc3, 0xc5, 0xa8, 0xac, 0xff, 0x10, 0x67,
+	0xe9, 0xc2, 0xe9, 0xd0, 0x7a, 0xce, 0x38, 0x03, 0x74, 0x13, 0x84, 0x34, 0x68, 0xcf, 0xc8, 0x42,
+	0xb6, 0x5a, 0x84, 0xe8, 0x18, 0xee, 0x5d, 0xfb, 0x51, 0x4e, 0x74, 0xa5, 0xcc, 0x89, 0xc3, 0x1b,
+	0xe5, 0x75, 0x0b, 0x7f, 0x05, 0xcd, 0x5d, 0x2a, 0x37, 0x9c, 0x58, 0x0f, 0xf6, 0x64, 0x4b, 0x82,
+	0x4d, 0x9e, 0x10, 0x82, 0xdd, 0xe2, 0x76, 0x72, 0x4c, 0x65, 0x8c, 0x8f, 0xe0, 0xb0, 0x46, 0x2f,
+	0xfa, 0xc2, 0x97, 0x70, 0x74, 0x4e, 0x22, 0x92, 0x91, 0xff, 0x21, 0x8b, 0x7b, 0x70, 0xbc, 0xce,
+	0x26, 0x55, 0xce, 0xa0, 0x73, 0x41, 0x32, 0x7b, 0xdc, 0xd4, 0x08, 0x1a, 0xb4, 0x29, 0x5b, 0x9a,
+	0xa0, 0x08, 0xf1, 0x27, 0xe8, 0x2e, 0x19, 0xe4, 0x5a, 0x87, 0xb0, 0x4f, 0x99, 0x37, 0xf7, 0x19,
+	0xa3, 0xf1, 0x94, 0xcb, 0x95, 0x3e, 0xde, 0xb6, 0x52, 0x7b, 0x3c, 0x12, 0x48, 0x07, 0x28, 0x93,
+	0x21, 0xc7, 0x29, 0x74, 0xdc, 0xbf, 0xb9, 0xd7, 0x86, 0xa6, 0xf2, 0x2f, 0x9a, 0x1a, 0x74, 0xdd,
+	0xb5, 0x4e, 0xf0, 0x7b, 0x50, 0x2b, 0x28, 0xea, 0x82, 0x42, 0x99, 0x54, 0x56, 0x28, 0xab, 0x36,
+	0xa9, 0xac, 0x36, 0x59, 0x8c, 0xc7, 0x67, 0xb1, 0x5c, 0x6e, 0x11, 0x9e, 0xfe, 0xdc, 0x05, 0xcd,
+	0xad, 0xb4, 0x2f, 0x93, 0x64, 0x96, 0x33, 0x44, 0x00, 0x56, 0x4e, 0x46, 0x27, 0x4d, 0xdc, 0x5e,
+	0x4e, 0xc1, 0x78, 0xde, 0xfc, 0xc3, 0xc0, 0x3b, 0x28, 0x00, 0xb5, 0xf2, 0x15, 0x1a, 0x6c, 0x2b,
+	0xdd, 0x74, 0xb6, 0x71, 0xd2, 0x00, 0x59, 0x69, 0xcc, 0xe0, 0xa0, 0x6e, 0x2c, 0xf4, 0x62, 0x5b,
+	0xf1, 0x2d, 0x66, 0x36, 0x5e, 0x36, 0x03, 0x57, 0x62, 0x9f, 0x61, 0x4f, 0x78, 0x0d, 0x3d, 0xfd,
+	0xc3, 0x20, 0x56, 0xae, 0x31, 0x9e, 0xdd, 0x05, 0xab, 0x53, 0xbb, 0x77, 0x50, 0xbb, 0xcd, 0xa8,
+	0x37, 0x3c, 0xb4, 0x33, 0x7c, 0xf0, 0x65, 0x4f, 0xfc, 0xa5, 0x03, 0xf1, 0x7c, 0xf5, 0x7b, 0x00,
+	0x58, 0xbf, 0xf6, 0x96, 0xd9, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -575,7 +587,8 @@ const _ = grpc.SupportPackageIsVersion6
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type SubscriberLookupClient interface {
-	// GetMSISDNs returns MSISDN -> IMSI mappings.
+	// GetMSISDNs returns MSISDN -> IMSI mappings, optionally filtered by
+	// MSISDN prefix.
 	GetMSISDNs(ctx context.Context, in *GetMSISDNsRequest, opts ...grpc.CallOption) (*GetMSISDNsResponse, error)
 	// SetMSISDN creates a MSISDN -> IMSI mapping.
 	// Error if MSISDN has already been assigned.
@@ -644,7 +657,8 @@ func (c *subscriberLookupClient) SetIPs(ctx context.Context, in *SetIPsRequest,
 
 // SubscriberLookupServer is the server API for SubscriberLookup service.
 type SubscriberLookupServer interface {
-	// GetMSISDNs returns MSISDN -> IMSI mappings.
+	// GetMSISDNs returns MSISDN -> IMSI mappings, optionally filtered by
+	// MSISDN prefix.
 	GetMSISDNs(context.Context, *GetMSISDNsRequest) (*GetMSISDNsResponse, error)
 	// SetMSISDN creates a MSISDN -> IMSI mapping.
 	// Error if MSISDN has already been assigned.
@@ -21,6 +21,9 @@ func (m *GetMSISDNsRequest) Validate() error {
 	if m.NetworkId == "" {
 		return errors.New("network ID cannot be empty")
 	}
+	if len(m.Msisdns) != 0 && m.MsisdnPrefix != "" {
+		return errors.New("msisdns and msisdn prefix cannot both be set")
+	}
 	return nil
 }
 
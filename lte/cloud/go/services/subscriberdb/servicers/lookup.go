@@ -61,7 +61,18 @@ func (l *lookupServicer) GetMSISDNs(ctx context.Context, req *protos.GetMSISDNsR
 
 	tks := storage.MakeTKs(lte.MSISDNBlobstoreType, req.Msisdns)
 	var blobs blobstore.Blobs
-	if len(tks) == 0 {
+	if req.MsisdnPrefix != "" {
+		filter := blobstore.SearchFilter{
+			NetworkID: &req.NetworkId,
+			Types:     map[string]bool{lte.MSISDNBlobstoreType: true},
+			KeyPrefix: &req.MsisdnPrefix,
+		}
+		blobsByNetwork, err := store.Search(filter, blobstore.LoadCriteria{LoadValue: true})
+		if err != nil {
+			return nil, makeErr(err, "search msisdns in blobstore")
+		}
+		blobs = blobsByNetwork[req.NetworkId]
+	} else if len(tks) == 0 {
 		blobs, err = blobstore.GetAllOfType(store, req.NetworkId, lte.MSISDNBlobstoreType)
 		if err != nil {
 			return nil, makeErr(err, "get msisdns from blobstore")
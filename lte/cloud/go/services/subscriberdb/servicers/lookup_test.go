@@ -103,6 +103,37 @@ func TestLookupServicer_MSISDNs(t *testing.T) {
 		assert.Equal(t, map[string]string{"msisdn0": "imsi0", "msisdn1": "imsi1"}, gotAll.ImsisByMsisdn)
 	})
 
+	t.Run("search by prefix", func(t *testing.T) {
+		got, err := l.GetMSISDNs(ctx, &protos.GetMSISDNsRequest{
+			NetworkId:    "nid0",
+			MsisdnPrefix: "msisdn1",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"msisdn1": "imsi1"}, got.ImsisByMsisdn)
+
+		got, err = l.GetMSISDNs(ctx, &protos.GetMSISDNsRequest{
+			NetworkId:    "nid0",
+			MsisdnPrefix: "msisdn",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"msisdn0": "imsi0", "msisdn1": "imsi1"}, got.ImsisByMsisdn)
+
+		got, err = l.GetMSISDNs(ctx, &protos.GetMSISDNsRequest{
+			NetworkId:    "nid0",
+			MsisdnPrefix: "msisdn2",
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, got.ImsisByMsisdn)
+
+		// Can't search by both MSISDNs and prefix
+		_, err = l.GetMSISDNs(ctx, &protos.GetMSISDNsRequest{
+			NetworkId:    "nid0",
+			Msisdns:      []string{"msisdn0"},
+			MsisdnPrefix: "msisdn",
+		})
+		assert.Error(t, err)
+	})
+
 	t.Run("validate requests", func(t *testing.T) {
 		// Can't overwrite existing mapping
 		_, err := l.SetMSISDN(ctx, &protos.SetMSISDNRequest{
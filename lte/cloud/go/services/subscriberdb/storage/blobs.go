@@ -0,0 +1,113 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/storage"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/pkg/errors"
+)
+
+// typedBlobstore stores the blobs of a single type, each call running in its
+// own transaction.
+type typedBlobstore struct {
+	factory  blobstore.BlobStorageFactory
+	blobType string
+}
+
+func newTypedBlobstore(factory blobstore.BlobStorageFactory, blobType string) *typedBlobstore {
+	return &typedBlobstore{factory: factory, blobType: blobType}
+}
+
+// put creates or overwrites the value of a blob.
+func (b *typedBlobstore) put(networkID string, key string, value []byte) error {
+	store, err := b.factory.StartTransaction(&storage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return errors.Wrap(err, "failed to start transaction")
+	}
+	defer store.Rollback()
+
+	err = store.CreateOrUpdate(networkID, blobstore.Blobs{{Type: b.blobType, Key: key, Value: value}})
+	if err != nil {
+		return errors.Wrapf(err, "failed to store %s %s", b.blobType, key)
+	}
+	return store.Commit()
+}
+
+// get returns the value of a blob, or ErrNotFound.
+func (b *typedBlobstore) get(networkID string, key string) ([]byte, error) {
+	store, err := b.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start transaction")
+	}
+	defer store.Rollback()
+
+	blob, err := store.Get(networkID, storage.TypeAndKey{Type: b.blobType, Key: key})
+	if err == merrors.ErrNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s %s", b.blobType, key)
+	}
+	return blob.Value, store.Commit()
+}
+
+// getAll returns the values of all the blobs of the network, keyed by blob key.
+func (b *typedBlobstore) getAll(networkID string) (map[string][]byte, error) {
+	store, err := b.factory.StartTransaction(&storage.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start transaction")
+	}
+	defer store.Rollback()
+
+	blobs, err := blobstore.GetAllOfType(store, networkID, b.blobType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s blobs of network %s", b.blobType, networkID)
+	}
+	values := map[string][]byte{}
+	for _, blob := range blobs {
+		values[blob.Key] = blob.Value
+	}
+	return values, store.Commit()
+}
+
+// deleteMatching deletes the blobs of the network whose value matches the
+// predicate.
+func (b *typedBlobstore) deleteMatching(networkID string, match func(value []byte) bool) error {
+	store, err := b.factory.StartTransaction(&storage.TxOptions{ReadOnly: false})
+	if err != nil {
+		return errors.Wrap(err, "failed to start transaction")
+	}
+	defer store.Rollback()
+
+	blobs, err := blobstore.GetAllOfType(store, networkID, b.blobType)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %s blobs of network %s", b.blobType, networkID)
+	}
+	var tks []storage.TypeAndKey
+	for _, blob := range blobs {
+		if match(blob.Value) {
+			tks = append(tks, storage.TypeAndKey{Type: b.blobType, Key: blob.Key})
+		}
+	}
+	if len(tks) == 0 {
+		return store.Commit()
+	}
+	if err := store.Delete(networkID, tks); err != nil {
+		return errors.Wrapf(err, "failed to delete %s blobs of network %s", b.blobType, networkID)
+	}
+	return store.Commit()
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"magma/orc8r/cloud/go/blobstore"
+)
+
+const (
+	DataKeyBlobType = "subscriber_data_key"
+)
+
+// DataKeyStorage holds the wrapped data keys encrypting the subscriber auth
+// keys of each network.
+type DataKeyStorage interface {
+	// GetDataKeys returns the wrapped data keys of the network, keyed by ID.
+	GetDataKeys(networkID string) (map[string][]byte, error)
+
+	// StoreDataKey creates or overwrites a wrapped data key.
+	StoreDataKey(networkID string, keyID string, key []byte) error
+}
+
+type dataKeyBlobstore struct {
+	blobs *typedBlobstore
+}
+
+func NewDataKeyBlobstore(factory blobstore.BlobStorageFactory) DataKeyStorage {
+	return &dataKeyBlobstore{blobs: newTypedBlobstore(factory, DataKeyBlobType)}
+}
+
+func (d *dataKeyBlobstore) GetDataKeys(networkID string) (map[string][]byte, error) {
+	return d.blobs.getAll(networkID)
+}
+
+func (d *dataKeyBlobstore) StoreDataKey(networkID string, keyID string, key []byte) error {
+	return d.blobs.put(networkID, keyID, key)
+}
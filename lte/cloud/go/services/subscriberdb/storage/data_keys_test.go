@@ -0,0 +1,49 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"testing"
+
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataKeyBlobstore(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	fact := blobstore.NewSQLBlobStorageFactory(subscriberdb.DataKeyBlobstore, db, sqorc.GetSqlBuilder())
+	assert.NoError(t, fact.InitializeFactory())
+	s := storage.NewDataKeyBlobstore(fact)
+
+	keys, err := s.GetDataKeys("n0")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	assert.NoError(t, s.StoreDataKey("n0", "key0", []byte("wrapped0")))
+	assert.NoError(t, s.StoreDataKey("n0", "key1", []byte("wrapped1")))
+	assert.NoError(t, s.StoreDataKey("n1", "key2", []byte("wrapped2")))
+	keys, err = s.GetDataKeys("n0")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key0": []byte("wrapped0"), "key1": []byte("wrapped1")}, keys)
+
+	assert.NoError(t, s.StoreDataKey("n1", "key2", []byte("rewrapped2")))
+	keys, err = s.GetDataKeys("n1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key2": []byte("rewrapped2")}, keys)
+}
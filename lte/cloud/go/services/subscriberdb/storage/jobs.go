@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"time"
+
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ImportJobType = "subscriber_import_job"
+	GroupJobType  = "subscriber_group_job"
+
+	// JobTTL is how long the status of a job is kept after its last update.
+	JobTTL = 7 * 24 * time.Hour
+)
+
+// JobStorage holds the serialized status of the subscriberdb jobs, e.g. the
+// bulk subscriber imports, so the status of a job can be polled from any
+// subscriberdb replica. Jobs expire JobTTL after their last update.
+type JobStorage interface {
+	// StoreJob creates or overwrites the status of a job.
+	StoreJob(networkID string, jobType string, jobID string, job []byte) error
+
+	// GetJob returns the status of a job, or ErrNotFound if the job doesn't
+	// exist or expired.
+	GetJob(networkID string, jobType string, jobID string) ([]byte, error)
+
+	// DeleteExpiredJobs deletes the expired jobs of the type.
+	DeleteExpiredJobs(networkID string, jobType string) error
+}
+
+// storedJob is the blob value of a job.
+type storedJob struct {
+	UpdatedAt int64  `json:"updated_at"`
+	Job       []byte `json:"job"`
+}
+
+func (s *storedJob) isExpired() bool {
+	return clock.Since(time.Unix(s.UpdatedAt, 0)) > JobTTL
+}
+
+type jobBlobstore struct {
+	factory blobstore.BlobStorageFactory
+}
+
+func NewJobBlobstore(factory blobstore.BlobStorageFactory) JobStorage {
+	return &jobBlobstore{factory: factory}
+}
+
+func (j *jobBlobstore) StoreJob(networkID string, jobType string, jobID string, job []byte) error {
+	value, err := json.Marshal(&storedJob{UpdatedAt: clock.Now().Unix(), Job: job})
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s %s", jobType, jobID)
+	}
+	return newTypedBlobstore(j.factory, jobType).put(networkID, jobID, value)
+}
+
+func (j *jobBlobstore) GetJob(networkID string, jobType string, jobID string) ([]byte, error) {
+	value, err := newTypedBlobstore(j.factory, jobType).get(networkID, jobID)
+	if err != nil {
+		return nil, err
+	}
+	stored := &storedJob{}
+	if err := json.Unmarshal(value, stored); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s %s", jobType, jobID)
+	}
+	if stored.isExpired() {
+		return nil, merrors.ErrNotFound
+	}
+	return stored.Job, nil
+}
+
+func (j *jobBlobstore) DeleteExpiredJobs(networkID string, jobType string) error {
+	return newTypedBlobstore(j.factory, jobType).deleteMatching(networkID, func(value []byte) bool {
+		stored := &storedJob{}
+		// Unreadable jobs can't be polled either
+		return json.Unmarshal(value, stored) != nil || stored.isExpired()
+	})
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/orc8r/cloud/go/blobstore"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/sqorc"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobBlobstore(t *testing.T) {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	fact := blobstore.NewSQLBlobStorageFactory(subscriberdb.JobBlobstore, db, sqorc.GetSqlBuilder())
+	assert.NoError(t, fact.InitializeFactory())
+	s := storage.NewJobBlobstore(fact)
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0))
+	defer clock.UnfreezeClock(t)
+
+	_, err = s.GetJob("n0", storage.ImportJobType, "job0")
+	assert.Exactly(t, merrors.ErrNotFound, err)
+
+	assert.NoError(t, s.StoreJob("n0", storage.ImportJobType, "job0", []byte("running")))
+	got, err := s.GetJob("n0", storage.ImportJobType, "job0")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("running"), got)
+
+	assert.NoError(t, s.StoreJob("n0", storage.ImportJobType, "job0", []byte("completed")))
+	got, err = s.GetJob("n0", storage.ImportJobType, "job0")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("completed"), got)
+
+	// Jobs are scoped to their network & type
+	_, err = s.GetJob("n1", storage.ImportJobType, "job0")
+	assert.Exactly(t, merrors.ErrNotFound, err)
+	_, err = s.GetJob("n0", storage.GroupJobType, "job0")
+	assert.Exactly(t, merrors.ErrNotFound, err)
+
+	// Jobs expire JobTTL after their last update
+	clock.SetAndFreezeClock(t, time.Unix(1000000, 0).Add(storage.JobTTL))
+	assert.NoError(t, s.StoreJob("n0", storage.ImportJobType, "job1", []byte("running")))
+	assert.NoError(t, s.StoreJob("n0", storage.GroupJobType, "job2", []byte("running")))
+	_, err = s.GetJob("n0", storage.ImportJobType, "job0")
+	assert.NoError(t, err)
+
+	clock.SetAndFreezeClock(t, time.Unix(1000001, 0).Add(storage.JobTTL))
+	_, err = s.GetJob("n0", storage.ImportJobType, "job0")
+	assert.Exactly(t, merrors.ErrNotFound, err)
+	got, err = s.GetJob("n0", storage.ImportJobType, "job1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("running"), got)
+
+	// Expired jobs are deleted
+	assert.NoError(t, s.DeleteExpiredJobs("n0", storage.ImportJobType))
+	store, err := fact.StartTransaction(nil)
+	assert.NoError(t, err)
+	keys, err := blobstore.ListKeys(store, "n0", storage.ImportJobType)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"job1"}, keys)
+	keys, err = blobstore.ListKeys(store, "n0", storage.GroupJobType)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"job2"}, keys)
+	assert.NoError(t, store.Commit())
+}
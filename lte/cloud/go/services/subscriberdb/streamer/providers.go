@@ -20,6 +20,7 @@ import (
 	lte_protos "magma/lte/cloud/go/protos"
 	"magma/lte/cloud/go/serdes"
 	lte_models "magma/lte/cloud/go/services/lte/obsidian/models"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	"magma/lte/cloud/go/services/subscriberdb/obsidian/models"
 	"magma/orc8r/cloud/go/services/configurator"
 	"magma/orc8r/lib/go/protos"
@@ -30,7 +31,11 @@ import (
 )
 
 // SubscribersProvider provides the implementation for subscriber streaming.
-type SubscribersProvider struct{}
+// The auth keys of the subscribers are decrypted by the keyring, which is the
+// only place they are decrypted.
+type SubscribersProvider struct {
+	Keyring *crypto.Keyring
+}
 
 func (p *SubscribersProvider) GetStreamName() string {
 	return lte.SubscriberStreamName
@@ -64,7 +69,7 @@ func (p *SubscribersProvider) GetUpdates(gatewayId string, extraArgs *any.Any) (
 
 	subProtos := make([]*lte_protos.SubscriberData, 0, len(subEnts))
 	for _, sub := range subEnts {
-		subProto, err := subscriberToMconfig(sub, apnsByName, apnResourcesByAPN)
+		subProto, err := subscriberToMconfig(p.Keyring, sub, apnsByName, apnResourcesByAPN)
 		if err != nil {
 			return nil, err
 		}
@@ -111,7 +116,7 @@ func subscribersToUpdates(subs []*lte_protos.SubscriberData) ([]*protos.DataUpda
 	return ret, nil
 }
 
-func subscriberToMconfig(ent configurator.NetworkEntity, apnConfigs map[string]*lte_models.ApnConfiguration, apnResources lte_models.ApnResources) (*lte_protos.SubscriberData, error) {
+func subscriberToMconfig(keyring *crypto.Keyring, ent configurator.NetworkEntity, apnConfigs map[string]*lte_models.ApnConfiguration, apnResources lte_models.ApnResources) (*lte_protos.SubscriberData, error) {
 	sub := &lte_protos.SubscriberData{}
 	t, err := lte_protos.SidProto(ent.Key)
 	if err != nil {
@@ -124,11 +129,19 @@ func subscriberToMconfig(ent configurator.NetworkEntity, apnConfigs map[string]*
 	}
 
 	cfg := ent.Config.(*models.SubscriberConfig)
+	authKey, err := keyring.Decrypt(ent.NetworkID, ent.Key, crypto.FieldAuthKey, cfg.Lte.AuthKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypt auth key of subscriber %s", ent.Key)
+	}
+	authOpc, err := keyring.Decrypt(ent.NetworkID, ent.Key, crypto.FieldAuthOpc, cfg.Lte.AuthOpc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypt auth OPc of subscriber %s", ent.Key)
+	}
 	sub.Lte = &lte_protos.LTESubscription{
 		State:    lte_protos.LTESubscription_LTESubscriptionState(lte_protos.LTESubscription_LTESubscriptionState_value[cfg.Lte.State]),
 		AuthAlgo: lte_protos.LTESubscription_LTEAuthAlgo(lte_protos.LTESubscription_LTEAuthAlgo_value[cfg.Lte.AuthAlgo]),
-		AuthKey:  cfg.Lte.AuthKey,
-		AuthOpc:  cfg.Lte.AuthOpc,
+		AuthKey:  authKey,
+		AuthOpc:  authOpc,
 	}
 
 	if cfg.Lte.SubProfile != "" {
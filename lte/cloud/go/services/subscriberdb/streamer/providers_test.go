@@ -14,6 +14,9 @@ limitations under the License.
 package streamer_test
 
 import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"magma/lte/cloud/go/lte"
@@ -21,11 +24,17 @@ import (
 	"magma/lte/cloud/go/serdes"
 	lte_models "magma/lte/cloud/go/services/lte/obsidian/models"
 	lte_test_init "magma/lte/cloud/go/services/lte/test_init"
+	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	"magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	subscriberdb_storage "magma/lte/cloud/go/services/subscriberdb/storage"
+	"magma/lte/cloud/go/services/subscriberdb/streamer"
+	"magma/orc8r/cloud/go/blobstore"
 	"magma/orc8r/cloud/go/orc8r"
 	"magma/orc8r/cloud/go/services/configurator"
 	configurator_test_init "magma/orc8r/cloud/go/services/configurator/test_init"
 	"magma/orc8r/cloud/go/services/streamer/providers"
+	"magma/orc8r/cloud/go/sqorc"
 	"magma/orc8r/cloud/go/storage"
 	"magma/orc8r/lib/go/protos"
 
@@ -242,3 +251,62 @@ func TestSubscriberdbStreamer(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expected, actual)
 }
+
+func TestSubscriberdbStreamer_EncryptedAuthKeys(t *testing.T) {
+	configurator_test_init.StartTestService(t)
+
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	fact := blobstore.NewSQLBlobStorageFactory(subscriberdb.DataKeyBlobstore, db, sqorc.GetSqlBuilder())
+	assert.NoError(t, fact.InitializeFactory())
+	keyFile, err := ioutil.TempFile("", "magma_master_keys")
+	assert.NoError(t, err)
+	defer os.Remove(keyFile.Name())
+	_, err = keyFile.WriteString("key0 " + base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	assert.NoError(t, err)
+	assert.NoError(t, keyFile.Close())
+	keyProvider, err := crypto.NewFileKeyProvider(keyFile.Name())
+	assert.NoError(t, err)
+	keyring := crypto.NewKeyring(keyProvider, subscriberdb_storage.NewDataKeyBlobstore(fact))
+
+	err = configurator.CreateNetwork(configurator.Network{ID: "n1"}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: orc8r.MagmadGatewayType, Key: "g1", PhysicalID: "hw1"}, serdes.Entity)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: lte.CellularGatewayEntityType, Key: "g1"}, serdes.Entity)
+	assert.NoError(t, err)
+
+	authKey := []byte("\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22\x22")
+	authOpc := []byte("\x33\x33\x33\x33\x33\x33\x33\x33\x33\x33\x33\x33\x33\x33\x33\x33")
+	encryptedKey, err := keyring.Encrypt("n1", "IMSI12345", crypto.FieldAuthKey, authKey)
+	assert.NoError(t, err)
+	encryptedOpc, err := keyring.Encrypt("n1", "IMSI12345", crypto.FieldAuthOpc, authOpc)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity(
+		"n1",
+		configurator.NetworkEntity{
+			Type: lte.SubscriberEntityType, Key: "IMSI12345",
+			Config: &models.SubscriberConfig{
+				Lte: &models.LteSubscription{State: "ACTIVE", AuthKey: encryptedKey, AuthOpc: encryptedOpc},
+			},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+
+	// Auth keys are streamed decrypted
+	provider := &streamer.SubscribersProvider{Keyring: keyring}
+	actual, err := provider.GetUpdates("hw1", nil)
+	assert.NoError(t, err)
+	if assert.Len(t, actual, 1) {
+		sub := &lte_protos.SubscriberData{}
+		assert.NoError(t, proto.Unmarshal(actual[0].Value, sub))
+		assert.Equal(t, authKey, sub.Lte.AuthKey)
+		assert.Equal(t, authOpc, sub.Lte.AuthOpc)
+	}
+
+	// Subscribers aren't streamed without their auth keys
+	provider = &streamer.SubscribersProvider{}
+	_, err = provider.GetUpdates("hw1", nil)
+	assert.EqualError(t, err, "decrypt auth key of subscriber IMSI12345: value is encrypted but no master key is configured")
+}
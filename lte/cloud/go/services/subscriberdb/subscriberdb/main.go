@@ -16,6 +16,7 @@ package main
 import (
 	"magma/lte/cloud/go/lte"
 	"magma/lte/cloud/go/services/subscriberdb"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
 	"magma/lte/cloud/go/services/subscriberdb/obsidian/handlers"
 	"magma/lte/cloud/go/services/subscriberdb/protos"
 	"magma/lte/cloud/go/services/subscriberdb/servicers"
@@ -48,13 +49,25 @@ func main() {
 	if err := fact.InitializeFactory(); err != nil {
 		glog.Fatalf("Error initializing MSISDN lookup storage: %v", err)
 	}
+	jobFact := blobstore.NewEntStorage(subscriberdb.JobBlobstore, db, sqorc.GetSqlBuilder())
+	if err := jobFact.InitializeFactory(); err != nil {
+		glog.Fatalf("Error initializing subscriber job storage: %v", err)
+	}
+	jobStore := subscriberdb_storage.NewJobBlobstore(jobFact)
+	keyring, err := crypto.NewKeyringFromServiceConfig(db, sqorc.GetSqlBuilder())
+	if err != nil {
+		glog.Fatalf("Error initializing subscriber auth key encryption: %v", err)
+	}
 	ipStore := subscriberdb_storage.NewIPLookup(db, sqorc.GetSqlBuilder())
 	if err := ipStore.Initialize(); err != nil {
 		glog.Fatalf("Error initializing IP lookup storage: %v", err)
 	}
 
 	// Attach handlers
-	obsidian.AttachHandlers(srv.EchoServer, handlers.GetHandlers())
+	obsidian.AttachHandlers(srv.EchoServer, handlers.GetHandlers(keyring))
+	obsidian.AttachHandlers(srv.EchoServer, handlers.GetBulkHandlers(jobStore, keyring))
+	obsidian.AttachHandlers(srv.EchoServer, handlers.GetGroupHandlers(jobStore))
+	obsidian.AttachHandlers(srv.EchoServer, handlers.GetStaticIPPoolHandlers())
 	protos.RegisterSubscriberLookupServer(srv.GrpcServer, servicers.NewLookupServicer(fact, ipStore))
 	state_protos.RegisterIndexerServer(srv.GrpcServer, servicers.NewIndexerServicer())
 
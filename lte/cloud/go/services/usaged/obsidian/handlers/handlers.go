@@ -0,0 +1,234 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	ltehandlers "magma/lte/cloud/go/services/lte/obsidian/handlers"
+	policydbmodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	"magma/lte/cloud/go/services/usaged/obsidian/models"
+	"magma/lte/cloud/go/services/usaged/quota"
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	orc8rhandlers "magma/orc8r/cloud/go/services/orchestrator/obsidian/handlers"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/labstack/echo"
+	"github.com/pkg/errors"
+)
+
+const (
+	UsageQuotaPath            = ltehandlers.ManageNetworkPath + obsidian.UrlSep + "usage_quota"
+	UsageReportsPath          = ltehandlers.ManageNetworkPath + obsidian.UrlSep + "usage_reports"
+	ListSubscriberUsagePath   = ltehandlers.ManageNetworkPath + obsidian.UrlSep + "subscriber_usage"
+	ManageSubscriberUsagePath = ListSubscriberUsagePath + obsidian.UrlSep + ":subscriber_id"
+	SubscriberDailyUsagePath  = ManageSubscriberUsagePath + obsidian.UrlSep + "daily"
+
+	ParamStart = "start"
+	ParamEnd   = "end"
+
+	dateFormat = "2006-01-02"
+	// defaultHistoryDays is the number of days of daily usage returned when
+	// the start of the history isn't specified.
+	defaultHistoryDays = 30
+	// maxHistoryDays bounds the number of days of daily usage returned.
+	maxHistoryDays = 366
+)
+
+// GetHandlers returns the usage handlers. Besides the reports of the gateways
+// to the UsageReporter servicer, usage can be posted to the usage reports
+// endpoint, e.g. by a charging or CDR pipeline.
+func GetHandlers(store storage.UsageStorage, enforcer *quota.Enforcer) []obsidian.Handler {
+	ret := []obsidian.Handler{
+		{Path: UsageReportsPath, Methods: obsidian.POST, HandlerFunc: makeReportUsageHandler(store, enforcer)},
+		{Path: ListSubscriberUsagePath, Methods: obsidian.GET, HandlerFunc: makeListSubscriberUsageHandler(store)},
+		{Path: ManageSubscriberUsagePath, Methods: obsidian.GET, HandlerFunc: makeGetSubscriberUsageHandler(store)},
+		{Path: SubscriberDailyUsagePath, Methods: obsidian.GET, HandlerFunc: makeGetSubscriberDailyUsageHandler(store)},
+	}
+	ret = append(ret, orc8rhandlers.GetPartialNetworkHandlers(UsageQuotaPath, &models.UsageQuotaConfig{}, lte.UsageQuotaConfigType, serdes.Network)...)
+	return ret
+}
+
+// makeReportUsageHandler returns the handler recording the usage of the
+// reported sessions, then enforcing the quotas of their subscribers.
+func makeReportUsageHandler(store storage.UsageStorage, enforcer *quota.Enforcer) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, nerr := obsidian.GetNetworkId(c)
+		if nerr != nil {
+			return nerr
+		}
+
+		report := &models.UsageReport{}
+		if err := c.Bind(report); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+		if err := report.ValidateModel(); err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+
+		now := clock.Now()
+		sessions := report.ToStorage()
+		if err := store.RecordUsage(networkID, now, sessions); err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to record usage"), http.StatusInternalServerError)
+		}
+		// Retrying a failed report is safe since the usage of the sessions
+		// was already recorded
+		if err := enforcer.EnforceQuotas(networkID, storage.GetIMSIs(sessions), now); err != nil {
+			return obsidian.HttpError(errors.Wrap(err, "failed to enforce quotas"), http.StatusInternalServerError)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func makeListSubscriberUsageHandler(store storage.UsageStorage) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, nerr := obsidian.GetNetworkId(c)
+		if nerr != nil {
+			return nerr
+		}
+
+		usages, err := getSubscriberUsages(store, networkID, nil)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusOK, usages)
+	}
+}
+
+func makeGetSubscriberUsageHandler(store storage.UsageStorage) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, subscriberID, nerr := getNetworkAndSubscriberIDs(c)
+		if nerr != nil {
+			return nerr
+		}
+
+		usages, err := getSubscriberUsages(store, networkID, []string{subscriberID})
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		return c.JSON(http.StatusOK, usages[subscriberID])
+	}
+}
+
+func makeGetSubscriberDailyUsageHandler(store storage.UsageStorage) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		networkID, subscriberID, nerr := getNetworkAndSubscriberIDs(c)
+		if nerr != nil {
+			return nerr
+		}
+		start, end, err := getHistoryBounds(c)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusBadRequest)
+		}
+
+		daily, err := store.GetDailyUsage(networkID, subscriberID, start, end)
+		if err != nil {
+			return obsidian.HttpError(err, http.StatusInternalServerError)
+		}
+		ret := make([]*models.DailyUsage, 0, len(daily))
+		for _, usage := range daily {
+			ret = append(ret, (&models.DailyUsage{}).FromStorage(usage))
+		}
+		return c.JSON(http.StatusOK, ret)
+	}
+}
+
+// getSubscriberUsages returns the usage of the subscribers in the current
+// quota period, keyed by IMSI.
+// If imsis is empty, this returns the usage of the subscribers which used
+// data or have an active enforcement in the period. Otherwise, this returns
+// the usage of all the passed subscribers.
+func getSubscriberUsages(store storage.UsageStorage, networkID string, imsis []string) (map[string]*models.SubscriberUsage, error) {
+	config, err := quota.LoadQuotaConfig(networkID)
+	if err != nil {
+		return nil, err
+	}
+	periodStart := models.GetPeriodStart(clock.Now())
+	totals, err := store.GetUsageTotals(networkID, imsis, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	enforcements, err := store.GetEnforcements(networkID, imsis)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(imsis) == 0 {
+		for imsi := range totals {
+			imsis = append(imsis, imsi)
+		}
+		for imsi := range enforcements {
+			if _, ok := totals[imsi]; !ok {
+				imsis = append(imsis, imsi)
+			}
+		}
+	}
+	ret := map[string]*models.SubscriberUsage{}
+	for _, imsi := range imsis {
+		usage := &models.SubscriberUsage{
+			SubscriberID: policydbmodels.SubscriberID(imsi),
+			PeriodStart:  strfmt.Date(periodStart),
+		}
+		if total, ok := totals[imsi]; ok {
+			usage.BytesTx, usage.BytesRx = total.BytesTx, total.BytesRx
+		}
+		if config != nil {
+			usage.QuotaBytes = config.GetQuota(imsi)
+		}
+		_, usage.QuotaEnforced = enforcements[imsi]
+		ret[imsi] = usage
+	}
+	return ret, nil
+}
+
+// getHistoryBounds returns the first and last days of the requested daily
+// usage history.
+func getHistoryBounds(c echo.Context) (time.Time, time.Time, error) {
+	end := clock.Now().UTC()
+	if endParam := c.QueryParam(ParamEnd); endParam != "" {
+		parsed, err := time.Parse(dateFormat, endParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Errorf("invalid end date '%s', expected YYYY-MM-DD", endParam)
+		}
+		end = parsed
+	}
+	start := end.AddDate(0, 0, -(defaultHistoryDays - 1))
+	if startParam := c.QueryParam(ParamStart); startParam != "" {
+		parsed, err := time.Parse(dateFormat, startParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.Errorf("invalid start date '%s', expected YYYY-MM-DD", startParam)
+		}
+		start = parsed
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, errors.New("start date must not be after end date")
+	}
+	if end.Sub(start) >= maxHistoryDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.Errorf("usage history is limited to %d days", maxHistoryDays)
+	}
+	return start, end, nil
+}
+
+func getNetworkAndSubscriberIDs(c echo.Context) (string, string, *echo.HTTPError) {
+	vals, err := obsidian.GetParamValues(c, "network_id", "subscriber_id")
+	if err != nil {
+		return "", "", err
+	}
+	return vals[0], vals[1], nil
+}
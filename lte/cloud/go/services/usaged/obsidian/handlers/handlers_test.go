@@ -0,0 +1,322 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package handlers_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	policydbmodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+	"magma/lte/cloud/go/services/usaged/obsidian/handlers"
+	"magma/lte/cloud/go/services/usaged/obsidian/models"
+	"magma/lte/cloud/go/services/usaged/quota"
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/tests"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageQuotaHandlers(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	e := echo.New()
+	obsidianHandlers := newTestHandlers(newTestStore(t))
+	getQuota := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, handlers.UsageQuotaPath, obsidian.GET).HandlerFunc
+	putQuota := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, handlers.UsageQuotaPath, obsidian.PUT).HandlerFunc
+	deleteQuota := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, handlers.UsageQuotaPath, obsidian.DELETE).HandlerFunc
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1", Type: lte.NetworkType}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntity("n1", configurator.NetworkEntity{Type: lte.PolicyRuleEntityType, Key: "throttle"}, serdes.Entity)
+	assert.NoError(t, err)
+
+	// No config
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n1/usage_quota",
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        getQuota,
+		ExpectedStatus: 404,
+		ExpectedError:  "Not found",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Unknown enforcement policy
+	config := &models.UsageQuotaConfig{
+		EnforcementPolicy: "unknown",
+		MonthlyQuotaBytes: 1000,
+		SubscriberQuotas:  map[string]uint64{"IMSI001010000000001": 0},
+	}
+	tc = tests.Test{
+		Method:         "PUT",
+		URL:            "/magma/v1/lte/n1/usage_quota",
+		Payload:        config,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        putQuota,
+		ExpectedStatus: 400,
+		ExpectedError:  "enforcement policy unknown does not exist",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Invalid subscriber ID
+	config.EnforcementPolicy = "throttle"
+	config.SubscriberQuotas = map[string]uint64{"foo": 0}
+	tc.Payload = config
+	tc.ExpectedError = "invalid subscriber ID foo in subscriber_quotas"
+	tests.RunUnitTest(t, e, tc)
+
+	// Happy path
+	config.SubscriberQuotas = map[string]uint64{"IMSI001010000000001": 0}
+	tc.Payload = config
+	tc.ExpectedStatus = 204
+	tc.ExpectedError = ""
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n1/usage_quota",
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        getQuota,
+		ExpectedStatus: 200,
+		ExpectedResult: config,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "DELETE",
+		URL:            "/magma/v1/lte/n1/usage_quota",
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        deleteQuota,
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	_, err = configurator.LoadNetworkConfig("n1", lte.UsageQuotaConfigType, serdes.Network)
+	assert.Error(t, err)
+}
+
+func TestReportUsageHandler(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	now := time.Date(2020, time.March, 10, 12, 0, 0, 0, time.UTC)
+	clock.SetAndFreezeClock(t, now)
+	defer clock.UnfreezeClock(t)
+
+	e := echo.New()
+	store := newTestStore(t)
+	obsidianHandlers := newTestHandlers(store)
+	reportUsage := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, handlers.UsageReportsPath, obsidian.POST).HandlerFunc
+
+	imsi := "IMSI001010000000001"
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1", Type: lte.NetworkType}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n1",
+		[]configurator.NetworkEntity{
+			{Type: lte.PolicyRuleEntityType, Key: "throttle"},
+			{Type: lte.SubscriberEntityType, Key: imsi},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+	config := &models.UsageQuotaConfig{EnforcementPolicy: "throttle", MonthlyQuotaBytes: 1000}
+	err = configurator.UpdateNetworkConfig("n1", lte.UsageQuotaConfigType, config, serdes.Network)
+	assert.NoError(t, err)
+
+	// Invalid report
+	tc := tests.Test{
+		Method:                 "POST",
+		URL:                    "/magma/v1/lte/n1/usage_reports",
+		Payload:                &models.UsageReport{Sessions: []*models.SessionUsage{{SessionID: "s1", Apn: "internet"}}},
+		ParamNames:             []string{"network_id"},
+		ParamValues:            []string{"n1"},
+		Handler:                reportUsage,
+		ExpectedStatus:         400,
+		ExpectedErrorSubstring: "subscriber_id",
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Within quota
+	report := &models.UsageReport{
+		Sessions: []*models.SessionUsage{
+			{SessionID: "s1", SubscriberID: policydbmodels.SubscriberID(imsi), Apn: "internet", BytesTx: 300, BytesRx: 400},
+		},
+	}
+	tc = tests.Test{
+		Method:         "POST",
+		URL:            "/magma/v1/lte/n1/usage_reports",
+		Payload:        report,
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        reportUsage,
+		ExpectedStatus: 204,
+	}
+	tests.RunUnitTest(t, e, tc)
+	totals, err := store.GetUsageTotals("n1", []string{imsi}, models.GetPeriodStart(now))
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.UsageTotal{BytesTx: 300, BytesRx: 400}, totals[imsi])
+	enforcements, err := store.GetEnforcements("n1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, enforcements)
+
+	// Over quota, with counters past 32 bits
+	report.Sessions[0].BytesRx = 1 << 33
+	tests.RunUnitTest(t, e, tc)
+	totals, err = store.GetUsageTotals("n1", []string{imsi}, models.GetPeriodStart(now))
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.UsageTotal{BytesTx: 300, BytesRx: 1 << 33}, totals[imsi])
+	enforcements, err = store.GetEnforcements("n1", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, enforcements, imsi)
+	subscriber, err := configurator.LoadEntity("n1", lte.SubscriberEntityType, imsi, configurator.EntityLoadCriteria{LoadAssocsFromThis: true}, serdes.Entity)
+	assert.NoError(t, err)
+	assert.Len(t, subscriber.Associations, 1)
+	assert.Equal(t, "throttle", subscriber.Associations[0].Key)
+}
+
+func TestSubscriberUsageHandlers(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	now := time.Date(2020, time.March, 10, 12, 0, 0, 0, time.UTC)
+	clock.SetAndFreezeClock(t, now)
+	defer clock.UnfreezeClock(t)
+
+	e := echo.New()
+	store := newTestStore(t)
+	obsidianHandlers := newTestHandlers(store)
+	listUsage := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, handlers.ListSubscriberUsagePath, obsidian.GET).HandlerFunc
+	getUsage := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, handlers.ManageSubscriberUsagePath, obsidian.GET).HandlerFunc
+	getDailyUsage := tests.GetHandlerByPathAndMethod(t, obsidianHandlers, handlers.SubscriberDailyUsagePath, obsidian.GET).HandlerFunc
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1", Type: lte.NetworkType}, serdes.Network)
+	assert.NoError(t, err)
+
+	// Empty
+	tc := tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n1/subscriber_usage",
+		ParamNames:     []string{"network_id"},
+		ParamValues:    []string{"n1"},
+		Handler:        listUsage,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler(map[string]*models.SubscriberUsage{}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Usage of last month isn't included in the totals
+	lastMonth := time.Date(2020, time.February, 28, 12, 0, 0, 0, time.UTC)
+	err = store.RecordUsage("n1", lastMonth, []*storage.SessionUsage{{SessionID: "s1", IMSI: "IMSI1", APN: "internet", BytesTx: 100, BytesRx: 200}})
+	assert.NoError(t, err)
+	err = store.RecordUsage("n1", now.AddDate(0, 0, -1), []*storage.SessionUsage{{SessionID: "s1", IMSI: "IMSI1", APN: "internet", BytesTx: 150, BytesRx: 300}})
+	assert.NoError(t, err)
+	err = store.RecordUsage("n1", now, []*storage.SessionUsage{
+		{SessionID: "s1", IMSI: "IMSI1", APN: "internet", BytesTx: 200, BytesRx: 400},
+		{SessionID: "s2", IMSI: "IMSI1", APN: "ims", BytesTx: 10, BytesRx: 20},
+		{SessionID: "s3", IMSI: "IMSI2", APN: "internet", BytesTx: 1000, BytesRx: 2000},
+	})
+	assert.NoError(t, err)
+	err = store.CreateEnforcements("n1", []*storage.Enforcement{{IMSI: "IMSI2", PolicyID: "throttle", EnforcedAt: now}})
+	assert.NoError(t, err)
+	err = configurator.UpdateNetworkConfig(
+		"n1", lte.UsageQuotaConfigType,
+		&models.UsageQuotaConfig{EnforcementPolicy: "throttle", MonthlyQuotaBytes: 1000, SubscriberQuotas: map[string]uint64{"IMSI1": 0}},
+		serdes.Network,
+	)
+	assert.NoError(t, err)
+
+	periodStart := strfmt.Date(time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC))
+	usage1 := &models.SubscriberUsage{SubscriberID: "IMSI1", BytesTx: 110, BytesRx: 220, PeriodStart: periodStart}
+	usage2 := &models.SubscriberUsage{SubscriberID: "IMSI2", BytesTx: 1000, BytesRx: 2000, PeriodStart: periodStart, QuotaBytes: 1000, QuotaEnforced: true}
+	tc.ExpectedResult = tests.JSONMarshaler(map[string]*models.SubscriberUsage{"IMSI1": usage1, "IMSI2": usage2})
+	tests.RunUnitTest(t, e, tc)
+
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n1/subscriber_usage/IMSI1",
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n1", "IMSI1"},
+		Handler:        getUsage,
+		ExpectedStatus: 200,
+		ExpectedResult: usage1,
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	// Subscribers without usage have none
+	tc.URL = "/magma/v1/lte/n1/subscriber_usage/IMSI3"
+	tc.ParamValues = []string{"n1", "IMSI3"}
+	tc.ExpectedResult = &models.SubscriberUsage{SubscriberID: "IMSI3", PeriodStart: periodStart, QuotaBytes: 1000}
+	tests.RunUnitTest(t, e, tc)
+
+	// Daily usage defaults to the last 30 days
+	day := func(month time.Month, day int) strfmt.Date {
+		return strfmt.Date(time.Date(2020, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	tc = tests.Test{
+		Method:         "GET",
+		URL:            "/magma/v1/lte/n1/subscriber_usage/IMSI1/daily",
+		ParamNames:     []string{"network_id", "subscriber_id"},
+		ParamValues:    []string{"n1", "IMSI1"},
+		Handler:        getDailyUsage,
+		ExpectedStatus: 200,
+		ExpectedResult: tests.JSONMarshaler([]*models.DailyUsage{
+			{Apn: "internet", Date: day(time.February, 28), BytesTx: 100, BytesRx: 200},
+			{Apn: "internet", Date: day(time.March, 9), BytesTx: 50, BytesRx: 100},
+			{Apn: "ims", Date: day(time.March, 10), BytesTx: 10, BytesRx: 20},
+			{Apn: "internet", Date: day(time.March, 10), BytesTx: 50, BytesRx: 100},
+		}),
+	}
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/lte/n1/subscriber_usage/IMSI1/daily?start=2020-03-01&end=2020-03-09"
+	tc.ExpectedResult = tests.JSONMarshaler([]*models.DailyUsage{
+		{Apn: "internet", Date: day(time.March, 9), BytesTx: 50, BytesRx: 100},
+	})
+	tests.RunUnitTest(t, e, tc)
+
+	// Invalid bounds
+	tc.URL = "/magma/v1/lte/n1/subscriber_usage/IMSI1/daily?start=2020-03-10&end=2020-03-09"
+	tc.ExpectedStatus = 400
+	tc.ExpectedResult = nil
+	tc.ExpectedError = "start date must not be after end date"
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/lte/n1/subscriber_usage/IMSI1/daily?start=2019-01-01"
+	tc.ExpectedError = "usage history is limited to 366 days"
+	tests.RunUnitTest(t, e, tc)
+
+	tc.URL = "/magma/v1/lte/n1/subscriber_usage/IMSI1/daily?end=March"
+	tc.ExpectedError = "invalid end date 'March', expected YYYY-MM-DD"
+	tests.RunUnitTest(t, e, tc)
+}
+
+func newTestHandlers(store storage.UsageStorage) []obsidian.Handler {
+	return handlers.GetHandlers(store, quota.NewEnforcer(store))
+}
+
+func newTestStore(t *testing.T) storage.UsageStorage {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	store := storage.NewSQLUsageStorage(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Init())
+	return store
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package models
+
+import (
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/services/configurator"
+	orc8rmodels "magma/orc8r/cloud/go/services/orchestrator/obsidian/models"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+)
+
+func (m *UsageQuotaConfig) GetFromNetwork(network configurator.Network) interface{} {
+	return orc8rmodels.GetNetworkConfig(network, lte.UsageQuotaConfigType)
+}
+
+func (m *UsageQuotaConfig) ToUpdateCriteria(network configurator.Network) (configurator.NetworkUpdateCriteria, error) {
+	exists, err := configurator.DoesEntityExist(network.ID, lte.PolicyRuleEntityType, string(m.EnforcementPolicy))
+	if err != nil {
+		return configurator.NetworkUpdateCriteria{}, errors.Wrap(err, "failed to check for enforcement policy")
+	}
+	if !exists {
+		return configurator.NetworkUpdateCriteria{}, errors.Errorf("enforcement policy %s does not exist", m.EnforcementPolicy)
+	}
+	return orc8rmodels.GetNetworkConfigUpdateCriteria(network.ID, lte.UsageQuotaConfigType, m), nil
+}
+
+// GetQuota returns the monthly quota of the subscriber in bytes, 0 if the
+// subscriber's usage is unlimited.
+func (m *UsageQuotaConfig) GetQuota(imsi string) uint64 {
+	if quota, ok := m.SubscriberQuotas[imsi]; ok {
+		return quota
+	}
+	return m.MonthlyQuotaBytes
+}
+
+// GetPeriodStart returns the start of the quota period containing t, i.e.
+// the first day of its month in UTC.
+func GetPeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (m *DailyUsage) FromStorage(usage *storage.DailyUsage) *DailyUsage {
+	return &DailyUsage{
+		Apn:     usage.APN,
+		BytesRx: usage.BytesRx,
+		BytesTx: usage.BytesTx,
+		Date:    strfmt.Date(usage.Day),
+	}
+}
+
+func (m *UsageReport) ToStorage() []*storage.SessionUsage {
+	sessions := make([]*storage.SessionUsage, 0, len(m.Sessions))
+	for _, session := range m.Sessions {
+		sessions = append(sessions, &storage.SessionUsage{
+			SessionID:  session.SessionID,
+			IMSI:       string(session.SubscriberID),
+			APN:        session.Apn,
+			BytesTx:    session.BytesTx,
+			BytesRx:    session.BytesRx,
+			Terminated: session.Terminated,
+		})
+	}
+	return sessions
+}
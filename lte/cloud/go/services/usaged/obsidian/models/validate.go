@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package models
+
+import (
+	policydbmodels "magma/lte/cloud/go/services/policydb/obsidian/models"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/pkg/errors"
+)
+
+func (m *UsageQuotaConfig) ValidateModel() error {
+	if err := m.Validate(strfmt.Default); err != nil {
+		return err
+	}
+	for sid := range m.SubscriberQuotas {
+		if err := policydbmodels.SubscriberID(sid).Validate(strfmt.Default); err != nil {
+			return errors.Errorf("invalid subscriber ID %s in subscriber_quotas", sid)
+		}
+	}
+	return nil
+}
+
+func (m *UsageReport) ValidateModel() error {
+	return m.Validate(strfmt.Default)
+}
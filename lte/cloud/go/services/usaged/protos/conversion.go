@@ -0,0 +1,33 @@
+/*
+ Copyright 2020 The Magma Authors.
+
+ This source code is licensed under the BSD-style license found in the
+ LICENSE file in the root directory of this source tree.
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package protos
+
+import (
+	"magma/lte/cloud/go/services/usaged/storage"
+)
+
+func (m *ReportUsageRequest) ToStorage() []*storage.SessionUsage {
+	sessions := make([]*storage.SessionUsage, 0, len(m.Sessions))
+	for _, session := range m.Sessions {
+		sessions = append(sessions, &storage.SessionUsage{
+			SessionID:  session.SessionId,
+			IMSI:       session.Imsi,
+			APN:        session.Apn,
+			BytesTx:    session.BytesTx,
+			BytesRx:    session.BytesRx,
+			Terminated: session.Terminated,
+		})
+	}
+	return sessions
+}
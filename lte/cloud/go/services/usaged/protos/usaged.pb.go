@@ -0,0 +1,298 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: usaged.proto
+
+package protos
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type SessionUsage struct {
+	// session_id uniquely identifies the session within the network
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// imsi of the subscriber, e.g. IMSI001010000000001
+	Imsi string `protobuf:"bytes,2,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	// apn of the session
+	Apn string `protobuf:"bytes,3,opt,name=apn,proto3" json:"apn,omitempty"`
+	// bytes_tx is the total number of bytes sent by the session
+	BytesTx uint64 `protobuf:"varint,4,opt,name=bytes_tx,json=bytesTx,proto3" json:"bytes_tx,omitempty"`
+	// bytes_rx is the total number of bytes received by the session
+	BytesRx uint64 `protobuf:"varint,5,opt,name=bytes_rx,json=bytesRx,proto3" json:"bytes_rx,omitempty"`
+	// terminated is set on the last report of the session
+	Terminated           bool     `protobuf:"varint,6,opt,name=terminated,proto3" json:"terminated,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionUsage) Reset()         { *m = SessionUsage{} }
+func (m *SessionUsage) String() string { return proto.CompactTextString(m) }
+func (*SessionUsage) ProtoMessage()    {}
+func (*SessionUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e440f9a3826b100, []int{0}
+}
+
+func (m *SessionUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionUsage.Unmarshal(m, b)
+}
+func (m *SessionUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionUsage.Marshal(b, m, deterministic)
+}
+func (m *SessionUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionUsage.Merge(m, src)
+}
+func (m *SessionUsage) XXX_Size() int {
+	return xxx_messageInfo_SessionUsage.Size(m)
+}
+func (m *SessionUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionUsage proto.InternalMessageInfo
+
+func (m *SessionUsage) GetSessionId() string {
+	if m != nil {
+		return m.SessionId
+	}
+	return ""
+}
+
+func (m *SessionUsage) GetImsi() string {
+	if m != nil {
+		return m.Imsi
+	}
+	return ""
+}
+
+func (m *SessionUsage) GetApn() string {
+	if m != nil {
+		return m.Apn
+	}
+	return ""
+}
+
+func (m *SessionUsage) GetBytesTx() uint64 {
+	if m != nil {
+		return m.BytesTx
+	}
+	return 0
+}
+
+func (m *SessionUsage) GetBytesRx() uint64 {
+	if m != nil {
+		return m.BytesRx
+	}
+	return 0
+}
+
+func (m *SessionUsage) GetTerminated() bool {
+	if m != nil {
+		return m.Terminated
+	}
+	return false
+}
+
+type ReportUsageRequest struct {
+	// sessions whose usage is reported
+	// The byte counts are cumulative over the lifetime of the session, so
+	// reports can safely be retried.
+	Sessions             []*SessionUsage `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ReportUsageRequest) Reset()         { *m = ReportUsageRequest{} }
+func (m *ReportUsageRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportUsageRequest) ProtoMessage()    {}
+func (*ReportUsageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e440f9a3826b100, []int{1}
+}
+
+func (m *ReportUsageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportUsageRequest.Unmarshal(m, b)
+}
+func (m *ReportUsageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportUsageRequest.Marshal(b, m, deterministic)
+}
+func (m *ReportUsageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportUsageRequest.Merge(m, src)
+}
+func (m *ReportUsageRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportUsageRequest.Size(m)
+}
+func (m *ReportUsageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportUsageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportUsageRequest proto.InternalMessageInfo
+
+func (m *ReportUsageRequest) GetSessions() []*SessionUsage {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
+type ReportUsageResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReportUsageResponse) Reset()         { *m = ReportUsageResponse{} }
+func (m *ReportUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportUsageResponse) ProtoMessage()    {}
+func (*ReportUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3e440f9a3826b100, []int{2}
+}
+
+func (m *ReportUsageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportUsageResponse.Unmarshal(m, b)
+}
+func (m *ReportUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportUsageResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportUsageResponse.Merge(m, src)
+}
+func (m *ReportUsageResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportUsageResponse.Size(m)
+}
+func (m *ReportUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportUsageResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*SessionUsage)(nil), "magma.lte.usaged.SessionUsage")
+	proto.RegisterType((*ReportUsageRequest)(nil), "magma.lte.usaged.ReportUsageRequest")
+	proto.RegisterType((*ReportUsageResponse)(nil), "magma.lte.usaged.ReportUsageResponse")
+}
+
+func init() {
+	proto.RegisterFile("usaged.proto", fileDescriptor_3e440f9a3826b100)
+}
+
+var fileDescriptor_3e440f9a3826b100 = []byte{
+	// 259 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0x41, 0x4b, 0xc3, 0x40,
+	0x10, 0x85, 0x5d, 0x13, 0x6b, 0x3a, 0xad, 0x50, 0x46, 0x84, 0x55, 0xb0, 0x84, 0xa0, 0x90, 0x53,
+	0x0e, 0xf5, 0xe6, 0xd1, 0x9b, 0x37, 0x59, 0xf5, 0x22, 0x42, 0x49, 0xc9, 0x50, 0x16, 0xdc, 0x6c,
+	0xdc, 0xd9, 0x42, 0xfc, 0x49, 0xfe, 0x4b, 0xc9, 0x26, 0x68, 0xb4, 0x07, 0x4f, 0x3b, 0xf3, 0xbd,
+	0xc7, 0xce, 0x1b, 0x06, 0xe6, 0x3b, 0x2e, 0xb7, 0x54, 0x15, 0x8d, 0xb3, 0xde, 0xe2, 0xc2, 0x94,
+	0x5b, 0x53, 0x16, 0x6f, 0x9e, 0x8a, 0x9e, 0x67, 0x9f, 0x02, 0xe6, 0x8f, 0xc4, 0xac, 0x6d, 0xfd,
+	0xdc, 0x11, 0xbc, 0x04, 0xe0, 0xbe, 0x5f, 0xeb, 0x4a, 0x8a, 0x54, 0xe4, 0x53, 0x35, 0x1d, 0xc8,
+	0x7d, 0x85, 0x08, 0xb1, 0x36, 0xac, 0xe5, 0x61, 0x10, 0x42, 0x8d, 0x0b, 0x88, 0xca, 0xa6, 0x96,
+	0x51, 0x40, 0x5d, 0x89, 0xe7, 0x90, 0x6c, 0x3e, 0x3c, 0xf1, 0xda, 0xb7, 0x32, 0x4e, 0x45, 0x1e,
+	0xab, 0xe3, 0xd0, 0x3f, 0xb5, 0x3f, 0x92, 0x6b, 0xe5, 0xd1, 0x48, 0x52, 0x2d, 0x2e, 0x01, 0x3c,
+	0x39, 0xa3, 0xeb, 0xd2, 0x53, 0x25, 0x27, 0xa9, 0xc8, 0x13, 0x35, 0x22, 0xd9, 0x03, 0xa0, 0xa2,
+	0xc6, 0x3a, 0x1f, 0x92, 0x2a, 0x7a, 0xdf, 0x11, 0x7b, 0xbc, 0x85, 0x64, 0x88, 0xc7, 0x52, 0xa4,
+	0x51, 0x3e, 0x5b, 0x2d, 0x8b, 0xbf, 0x6b, 0x16, 0xe3, 0x15, 0xd5, 0xb7, 0x3f, 0x3b, 0x83, 0xd3,
+	0x5f, 0x3f, 0x72, 0x63, 0x6b, 0xa6, 0x95, 0x81, 0x93, 0x01, 0x74, 0x1a, 0x39, 0x7c, 0x85, 0xd9,
+	0xc8, 0x87, 0x57, 0xfb, 0x03, 0xf6, 0x83, 0x5d, 0x5c, 0xff, 0xe3, 0xea, 0x87, 0x65, 0x07, 0x77,
+	0xc9, 0xcb, 0x24, 0x9c, 0x87, 0x37, 0xfd, 0x7b, 0xf3, 0x35, 0x00, 0xba, 0x4e, 0xb2, 0x02, 0xb6,
+	0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// UsageReporterClient is the client API for UsageReporter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UsageReporterClient interface {
+	// ReportUsage records the usage of the sessions since their last report,
+	// and enforces the quotas of their subscribers.
+	ReportUsage(ctx context.Context, in *ReportUsageRequest, opts ...grpc.CallOption) (*ReportUsageResponse, error)
+}
+
+type usageReporterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUsageReporterClient(cc grpc.ClientConnInterface) UsageReporterClient {
+	return &usageReporterClient{cc}
+}
+
+func (c *usageReporterClient) ReportUsage(ctx context.Context, in *ReportUsageRequest, opts ...grpc.CallOption) (*ReportUsageResponse, error) {
+	out := new(ReportUsageResponse)
+	err := c.cc.Invoke(ctx, "/magma.lte.usaged.UsageReporter/ReportUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UsageReporterServer is the server API for UsageReporter service.
+type UsageReporterServer interface {
+	// ReportUsage records the usage of the sessions since their last report,
+	// and enforces the quotas of their subscribers.
+	ReportUsage(context.Context, *ReportUsageRequest) (*ReportUsageResponse, error)
+}
+
+// UnimplementedUsageReporterServer can be embedded to have forward compatible implementations.
+type UnimplementedUsageReporterServer struct {
+}
+
+func (*UnimplementedUsageReporterServer) ReportUsage(ctx context.Context, req *ReportUsageRequest) (*ReportUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportUsage not implemented")
+}
+
+func RegisterUsageReporterServer(s *grpc.Server, srv UsageReporterServer) {
+	s.RegisterService(&_UsageReporter_serviceDesc, srv)
+}
+
+func _UsageReporter_ReportUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UsageReporterServer).ReportUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/magma.lte.usaged.UsageReporter/ReportUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UsageReporterServer).ReportUsage(ctx, req.(*ReportUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _UsageReporter_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "magma.lte.usaged.UsageReporter",
+	HandlerType: (*UsageReporterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportUsage",
+			Handler:    _UsageReporter_ReportUsage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "usaged.proto",
+}
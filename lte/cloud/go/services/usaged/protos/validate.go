@@ -0,0 +1,33 @@
+/*
+ Copyright 2020 The Magma Authors.
+
+ This source code is licensed under the BSD-style license found in the
+ LICENSE file in the root directory of this source tree.
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package protos
+
+import (
+	"github.com/pkg/errors"
+)
+
+func (m *ReportUsageRequest) Validate() error {
+	for _, session := range m.Sessions {
+		if session.SessionId == "" {
+			return errors.Errorf("session ID cannot be empty in session %v", session)
+		}
+		if session.Imsi == "" {
+			return errors.Errorf("imsi cannot be empty in session %v", session)
+		}
+		if session.Apn == "" {
+			return errors.Errorf("apn cannot be empty in session %v", session)
+		}
+	}
+	return nil
+}
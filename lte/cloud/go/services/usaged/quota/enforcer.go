@@ -0,0 +1,250 @@
+/*
+ Copyright 2020 The Magma Authors.
+
+ This source code is licensed under the BSD-style license found in the
+ LICENSE file in the root directory of this source tree.
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+// Package quota enforces the monthly usage quotas of the subscribers.
+//
+// When a subscriber exceeds their quota, the enforcement policy of the
+// network's usage quota config is activated for the subscriber, which
+// policydb then streams to the gateways. The policy is deactivated once the
+// quota period ends, or when the subscriber's quota is raised above their
+// usage.
+package quota
+
+import (
+	"sort"
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	"magma/lte/cloud/go/services/usaged/obsidian/models"
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/configurator"
+	orc8rstorage "magma/orc8r/cloud/go/storage"
+	merrors "magma/orc8r/lib/go/errors"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// staleSessionAge is the time after which sessions which stopped being
+	// reported are forgotten.
+	staleSessionAge = 7 * 24 * time.Hour
+)
+
+// Enforcer activates and deactivates the enforcement policy of the
+// subscribers according to their usage.
+type Enforcer struct {
+	store storage.UsageStorage
+}
+
+func NewEnforcer(store storage.UsageStorage) *Enforcer {
+	return &Enforcer{store: store}
+}
+
+// Run periodically lifts the expired enforcements of all LTE networks and
+// forgets the stale sessions. It never returns.
+func (e *Enforcer) Run(interval time.Duration) {
+	for {
+		clock.Sleep(interval)
+		now := clock.Now()
+
+		networkIDs, err := configurator.ListNetworksOfType(lte.NetworkType)
+		if err != nil {
+			glog.Errorf("Failed to list LTE networks: %v", err)
+			continue
+		}
+		for _, networkID := range networkIDs {
+			if err := e.LiftEnforcements(networkID, now); err != nil {
+				glog.Errorf("Failed to lift quota enforcements of network %s: %v", networkID, err)
+			}
+		}
+
+		if err := e.store.DeleteStaleSessions(now.Add(-staleSessionAge)); err != nil {
+			glog.Errorf("Failed to delete stale sessions: %v", err)
+		}
+	}
+}
+
+// EnforceQuotas activates the enforcement policy for the subscribers who
+// exceeded their quota in the period of now.
+// Subscribers for whom the policy is already active, e.g. because it was
+// assigned to them by an operator, are left as is.
+func (e *Enforcer) EnforceQuotas(networkID string, imsis []string, now time.Time) error {
+	if len(imsis) == 0 {
+		return nil
+	}
+	config, err := LoadQuotaConfig(networkID)
+	if err != nil || config == nil {
+		return err
+	}
+
+	totals, err := e.store.GetUsageTotals(networkID, imsis, models.GetPeriodStart(now))
+	if err != nil {
+		return err
+	}
+	enforcements, err := e.store.GetEnforcements(networkID, imsis)
+	if err != nil {
+		return err
+	}
+	var tks orc8rstorage.TKs
+	for imsi, total := range totals {
+		if _, enforced := enforcements[imsi]; enforced || !isOverQuota(config, imsi, total) {
+			continue
+		}
+		tks = append(tks, orc8rstorage.TypeAndKey{Type: lte.SubscriberEntityType, Key: imsi})
+	}
+	if len(tks) == 0 {
+		return nil
+	}
+
+	// Deleted subscribers are skipped
+	ents, _, err := configurator.LoadEntities(networkID, nil, nil, nil, tks, configurator.EntityLoadCriteria{LoadAssocsFromThis: true}, serdes.Entity)
+	if err != nil {
+		return errors.Wrap(err, "failed to load subscribers")
+	}
+	policyTK := orc8rstorage.TypeAndKey{Type: lte.PolicyRuleEntityType, Key: string(config.EnforcementPolicy)}
+	var writes []configurator.EntityWriteOperation
+	var newEnforcements []*storage.Enforcement
+	var enforcedIMSIs []string
+	for _, ent := range ents {
+		if hasAssoc(ent, policyTK) {
+			continue
+		}
+		writes = append(writes, configurator.EntityUpdateCriteria{
+			Type:              lte.SubscriberEntityType,
+			Key:               ent.Key,
+			AssociationsToAdd: orc8rstorage.TKs{policyTK},
+		})
+		newEnforcements = append(newEnforcements, &storage.Enforcement{IMSI: ent.Key, PolicyID: policyTK.Key, EnforcedAt: now})
+		enforcedIMSIs = append(enforcedIMSIs, ent.Key)
+	}
+	if len(writes) == 0 {
+		return nil
+	}
+
+	// Record the enforcements first, so the policy is never activated
+	// without being eventually deactivated
+	if err := e.store.CreateEnforcements(networkID, newEnforcements); err != nil {
+		return err
+	}
+	if err := configurator.WriteEntities(networkID, writes, serdes.Entity); err != nil {
+		if delErr := e.store.DeleteEnforcements(networkID, enforcedIMSIs); delErr != nil {
+			glog.Errorf("Failed to delete quota enforcements of network %s after failed activation: %v", networkID, delErr)
+		}
+		return errors.Wrap(err, "failed to activate enforcement policy")
+	}
+	sort.Strings(enforcedIMSIs)
+	glog.Infof("Activated enforcement policy %s for subscribers %v of network %s", policyTK.Key, enforcedIMSIs, networkID)
+	return nil
+}
+
+// LiftEnforcements deactivates the enforcement policy for the subscribers
+// whose quota period ended, or who are now within their quota.
+func (e *Enforcer) LiftEnforcements(networkID string, now time.Time) error {
+	enforcements, err := e.store.GetEnforcements(networkID, nil)
+	if err != nil || len(enforcements) == 0 {
+		return err
+	}
+	config, err := LoadQuotaConfig(networkID)
+	if err != nil {
+		return err
+	}
+
+	periodStart := models.GetPeriodStart(now)
+	var imsis []string
+	for imsi := range enforcements {
+		imsis = append(imsis, imsi)
+	}
+	totals, err := e.store.GetUsageTotals(networkID, imsis, periodStart)
+	if err != nil {
+		return err
+	}
+
+	var tks orc8rstorage.TKs
+	for imsi, enforcement := range enforcements {
+		if !enforcement.EnforcedAt.Before(periodStart) && isEnforced(config, enforcement, totals[imsi]) {
+			continue
+		}
+		tks = append(tks, orc8rstorage.TypeAndKey{Type: lte.SubscriberEntityType, Key: imsi})
+	}
+	if len(tks) == 0 {
+		return nil
+	}
+	sort.Slice(tks, func(i, j int) bool { return tks[i].Key < tks[j].Key })
+
+	// Deleted subscribers only have their enforcement removed
+	ents, _, err := configurator.LoadEntities(networkID, nil, nil, nil, tks, configurator.EntityLoadCriteria{}, serdes.Entity)
+	if err != nil {
+		return errors.Wrap(err, "failed to load subscribers")
+	}
+	var writes []configurator.EntityWriteOperation
+	for _, ent := range ents {
+		writes = append(writes, configurator.EntityUpdateCriteria{
+			Type:                 lte.SubscriberEntityType,
+			Key:                  ent.Key,
+			AssociationsToDelete: orc8rstorage.TKs{{Type: lte.PolicyRuleEntityType, Key: enforcements[ent.Key].PolicyID}},
+		})
+	}
+	if len(writes) != 0 {
+		if err := configurator.WriteEntities(networkID, writes, serdes.Entity); err != nil {
+			return errors.Wrap(err, "failed to deactivate enforcement policy")
+		}
+	}
+
+	var liftedIMSIs []string
+	for _, tk := range tks {
+		liftedIMSIs = append(liftedIMSIs, tk.Key)
+	}
+	if err := e.store.DeleteEnforcements(networkID, liftedIMSIs); err != nil {
+		return err
+	}
+	glog.Infof("Deactivated enforcement policy for subscribers %v of network %s", liftedIMSIs, networkID)
+	return nil
+}
+
+// LoadQuotaConfig returns the usage quota config of the network, nil if the
+// network doesn't have quotas.
+func LoadQuotaConfig(networkID string) (*models.UsageQuotaConfig, error) {
+	iConfig, err := configurator.LoadNetworkConfig(networkID, lte.UsageQuotaConfigType, serdes.Network)
+	if err == merrors.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load usage quota config")
+	}
+	return iConfig.(*models.UsageQuotaConfig), nil
+}
+
+func isOverQuota(config *models.UsageQuotaConfig, imsi string, total *storage.UsageTotal) bool {
+	quota := config.GetQuota(imsi)
+	return quota != 0 && total != nil && total.Bytes() >= quota
+}
+
+// isEnforced returns true if the enforcement still applies under the
+// current config.
+func isEnforced(config *models.UsageQuotaConfig, enforcement *storage.Enforcement, total *storage.UsageTotal) bool {
+	if config == nil || string(config.EnforcementPolicy) != enforcement.PolicyID {
+		return false
+	}
+	return isOverQuota(config, enforcement.IMSI, total)
+}
+
+func hasAssoc(ent configurator.NetworkEntity, tk orc8rstorage.TypeAndKey) bool {
+	for _, assoc := range ent.Associations {
+		if assoc == tk {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package quota_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	"magma/lte/cloud/go/services/usaged/obsidian/models"
+	"magma/lte/cloud/go/services/usaged/quota"
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/sqorc"
+	orc8rstorage "magma/orc8r/cloud/go/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnforcer(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	store := newTestStore(t)
+	enforcer := quota.NewEnforcer(store)
+	now := time.Date(2020, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	err := configurator.CreateNetwork(configurator.Network{ID: "n1", Type: lte.NetworkType}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n1",
+		[]configurator.NetworkEntity{
+			{Type: lte.PolicyRuleEntityType, Key: "throttle"},
+			{Type: lte.SubscriberEntityType, Key: "IMSI1"},
+			{Type: lte.SubscriberEntityType, Key: "IMSI2"},
+			{
+				Type:         lte.SubscriberEntityType,
+				Key:          "IMSI3",
+				Associations: orc8rstorage.TKs{{Type: lte.PolicyRuleEntityType, Key: "throttle"}},
+			},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+
+	// No quota config, nothing is enforced
+	recordUsage(t, store, now, "s1", "IMSI1", 5000)
+	err = enforcer.EnforceQuotas("n1", []string{"IMSI1"}, now)
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", false)
+	assertHasPolicy(t, "IMSI1", false)
+
+	// IMSI1 is over the default quota, IMSI2 has unlimited quota and IMSI3
+	// already has the policy
+	config := &models.UsageQuotaConfig{
+		EnforcementPolicy: "throttle",
+		MonthlyQuotaBytes: 1000,
+		SubscriberQuotas:  map[string]uint64{"IMSI2": 0},
+	}
+	err = configurator.UpdateNetworkConfig("n1", lte.UsageQuotaConfigType, config, serdes.Network)
+	assert.NoError(t, err)
+	recordUsage(t, store, now, "s2", "IMSI2", 5000)
+	recordUsage(t, store, now, "s3", "IMSI3", 5000)
+	err = enforcer.EnforceQuotas("n1", []string{"IMSI1", "IMSI2", "IMSI3"}, now)
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", true)
+	assertHasPolicy(t, "IMSI1", true)
+	assertEnforced(t, store, "IMSI2", false)
+	assertHasPolicy(t, "IMSI2", false)
+	assertEnforced(t, store, "IMSI3", false)
+	assertHasPolicy(t, "IMSI3", true)
+
+	// Enforcing again is a no-op
+	err = enforcer.EnforceQuotas("n1", []string{"IMSI1"}, now)
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", true)
+	assertHasPolicy(t, "IMSI1", true)
+
+	// Still over quota in the same period
+	err = enforcer.LiftEnforcements("n1", now.Add(time.Hour))
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", true)
+	assertHasPolicy(t, "IMSI1", true)
+
+	// Raising the quota lifts the enforcement
+	config.SubscriberQuotas["IMSI1"] = 10000
+	err = configurator.UpdateNetworkConfig("n1", lte.UsageQuotaConfigType, config, serdes.Network)
+	assert.NoError(t, err)
+	err = enforcer.LiftEnforcements("n1", now.Add(time.Hour))
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", false)
+	assertHasPolicy(t, "IMSI1", false)
+
+	// The start of the next month lifts the enforcement, IMSI3 keeps the
+	// policy assigned by the operator
+	delete(config.SubscriberQuotas, "IMSI1")
+	err = configurator.UpdateNetworkConfig("n1", lte.UsageQuotaConfigType, config, serdes.Network)
+	assert.NoError(t, err)
+	err = enforcer.EnforceQuotas("n1", []string{"IMSI1"}, now)
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", true)
+	err = enforcer.LiftEnforcements("n1", time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", false)
+	assertHasPolicy(t, "IMSI1", false)
+	assertHasPolicy(t, "IMSI3", true)
+
+	// Deleted subscribers only have their enforcement removed
+	err = enforcer.EnforceQuotas("n1", []string{"IMSI1"}, now)
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", true)
+	err = configurator.DeleteEntity("n1", lte.SubscriberEntityType, "IMSI1")
+	assert.NoError(t, err)
+	err = enforcer.LiftEnforcements("n1", time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assertEnforced(t, store, "IMSI1", false)
+}
+
+func newTestStore(t *testing.T) storage.UsageStorage {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	store := storage.NewSQLUsageStorage(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Init())
+	return store
+}
+
+func recordUsage(t *testing.T, store storage.UsageStorage, now time.Time, sessionID, imsi string, bytesTx uint64) {
+	err := store.RecordUsage("n1", now, []*storage.SessionUsage{{SessionID: sessionID, IMSI: imsi, APN: "internet", BytesTx: bytesTx}})
+	assert.NoError(t, err)
+}
+
+func assertEnforced(t *testing.T, store storage.UsageStorage, imsi string, expected bool) {
+	enforcements, err := store.GetEnforcements("n1", []string{imsi})
+	assert.NoError(t, err)
+	_, enforced := enforcements[imsi]
+	assert.Equal(t, expected, enforced, imsi)
+}
+
+func assertHasPolicy(t *testing.T, imsi string, expected bool) {
+	policy, err := configurator.LoadEntity("n1", lte.PolicyRuleEntityType, "throttle", configurator.EntityLoadCriteria{LoadAssocsToThis: true}, serdes.Entity)
+	assert.NoError(t, err)
+	hasPolicy := false
+	for _, tk := range policy.ParentAssociations {
+		if tk == (orc8rstorage.TypeAndKey{Type: lte.SubscriberEntityType, Key: imsi}) {
+			hasPolicy = true
+		}
+	}
+	assert.Equal(t, expected, hasPolicy, imsi)
+}
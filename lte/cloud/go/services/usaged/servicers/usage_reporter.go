@@ -0,0 +1,61 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package servicers
+
+import (
+	"context"
+
+	"magma/lte/cloud/go/services/usaged/protos"
+	"magma/lte/cloud/go/services/usaged/quota"
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/identity"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type usageReporterServicer struct {
+	store    storage.UsageStorage
+	enforcer *quota.Enforcer
+}
+
+func NewUsageReporterServicer(store storage.UsageStorage, enforcer *quota.Enforcer) protos.UsageReporterServer {
+	return &usageReporterServicer{store: store, enforcer: enforcer}
+}
+
+func (u *usageReporterServicer) ReportUsage(ctx context.Context, req *protos.ReportUsageRequest) (*protos.ReportUsageResponse, error) {
+	ret := &protos.ReportUsageResponse{}
+	networkID, err := identity.GetClientNetworkID(ctx)
+	if err != nil {
+		return ret, err
+	}
+	if err := req.Validate(); err != nil {
+		return ret, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	now := clock.Now()
+	sessions := req.ToStorage()
+	err = u.store.RecordUsage(networkID, now, sessions)
+	if err != nil {
+		return ret, status.Errorf(codes.Internal, "failed to record usage: %s", err)
+	}
+
+	// Retrying a failed report is safe since the usage of the sessions
+	// was already recorded
+	err = u.enforcer.EnforceQuotas(networkID, storage.GetIMSIs(sessions), now)
+	if err != nil {
+		return ret, status.Errorf(codes.Internal, "failed to enforce quotas: %s", err)
+	}
+	return ret, nil
+}
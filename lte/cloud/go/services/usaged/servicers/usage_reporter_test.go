@@ -0,0 +1,100 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package servicers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	"magma/lte/cloud/go/services/usaged/obsidian/models"
+	"magma/lte/cloud/go/services/usaged/protos"
+	"magma/lte/cloud/go/services/usaged/quota"
+	"magma/lte/cloud/go/services/usaged/servicers"
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/clock"
+	"magma/orc8r/cloud/go/services/configurator"
+	configuratorTestInit "magma/orc8r/cloud/go/services/configurator/test_init"
+	"magma/orc8r/cloud/go/sqorc"
+	orc8rprotos "magma/orc8r/lib/go/protos"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUsageReporterServicer_ReportUsage(t *testing.T) {
+	configuratorTestInit.StartTestService(t)
+	now := time.Date(2020, time.March, 10, 12, 0, 0, 0, time.UTC)
+	clock.SetAndFreezeClock(t, now)
+	defer clock.UnfreezeClock(t)
+
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	store := storage.NewSQLUsageStorage(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Init())
+	srv := servicers.NewUsageReporterServicer(store, quota.NewEnforcer(store))
+	ctx := orc8rprotos.NewGatewayIdentity("hw1", "n1", "gw1").NewContextWithIdentity(context.Background())
+
+	err = configurator.CreateNetwork(configurator.Network{ID: "n1", Type: lte.NetworkType}, serdes.Network)
+	assert.NoError(t, err)
+	_, err = configurator.CreateEntities(
+		"n1",
+		[]configurator.NetworkEntity{
+			{Type: lte.PolicyRuleEntityType, Key: "throttle"},
+			{Type: lte.SubscriberEntityType, Key: "IMSI1"},
+		},
+		serdes.Entity,
+	)
+	assert.NoError(t, err)
+	config := &models.UsageQuotaConfig{EnforcementPolicy: "throttle", MonthlyQuotaBytes: 1000}
+	err = configurator.UpdateNetworkConfig("n1", lte.UsageQuotaConfigType, config, serdes.Network)
+	assert.NoError(t, err)
+
+	// Invalid report
+	_, err = srv.ReportUsage(ctx, &protos.ReportUsageRequest{Sessions: []*protos.SessionUsage{{SessionId: "s1", Apn: "internet"}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Within quota
+	req := &protos.ReportUsageRequest{
+		Sessions: []*protos.SessionUsage{
+			{SessionId: "s1", Imsi: "IMSI1", Apn: "internet", BytesTx: 300, BytesRx: 400},
+		},
+	}
+	_, err = srv.ReportUsage(ctx, req)
+	assert.NoError(t, err)
+	totals, err := store.GetUsageTotals("n1", []string{"IMSI1"}, models.GetPeriodStart(now))
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.UsageTotal{BytesTx: 300, BytesRx: 400}, totals["IMSI1"])
+	enforcements, err := store.GetEnforcements("n1", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, enforcements)
+
+	// Over quota
+	req.Sessions[0].BytesRx = 800
+	_, err = srv.ReportUsage(ctx, req)
+	assert.NoError(t, err)
+	totals, err = store.GetUsageTotals("n1", []string{"IMSI1"}, models.GetPeriodStart(now))
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.UsageTotal{BytesTx: 300, BytesRx: 800}, totals["IMSI1"])
+	enforcements, err = store.GetEnforcements("n1", nil)
+	assert.NoError(t, err)
+	assert.Contains(t, enforcements, "IMSI1")
+	subscriber, err := configurator.LoadEntity("n1", lte.SubscriberEntityType, "IMSI1", configurator.EntityLoadCriteria{LoadAssocsFromThis: true}, serdes.Entity)
+	assert.NoError(t, err)
+	assert.Len(t, subscriber.Associations, 1)
+	assert.Equal(t, "throttle", subscriber.Associations[0].Key)
+}
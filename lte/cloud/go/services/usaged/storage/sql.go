@@ -0,0 +1,444 @@
+/*
+ Copyright 2020 The Magma Authors.
+
+ This source code is licensed under the BSD-style license found in the
+ LICENSE file in the root directory of this source tree.
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"magma/orc8r/cloud/go/sqorc"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+const (
+	dailyTable = "usaged_daily_usage"
+	dayIdx     = "usaged_daily_usage_day_idx"
+
+	sessionTable = "usaged_sessions"
+
+	enforcementTable = "usaged_enforcements"
+
+	nidCol        = "network_id"
+	imsiCol       = "imsi"
+	apnCol        = "apn"
+	dayCol        = "day_sec"
+	txCol         = "bytes_tx"
+	rxCol         = "bytes_rx"
+	sessionCol    = "session_id"
+	updatedCol    = "updated_sec"
+	policyCol     = "policy_id"
+	enforcedAtCol = "enforced_sec"
+)
+
+func NewSQLUsageStorage(db *sql.DB, builder sqorc.StatementBuilder) UsageStorage {
+	return &sqlUsageStorage{db: db, builder: builder}
+}
+
+type sqlUsageStorage struct {
+	db      *sql.DB
+	builder sqorc.StatementBuilder
+}
+
+// usageKey identifies the usage of a subscriber on an APN.
+type usageKey struct {
+	imsi string
+	apn  string
+}
+
+func (s *sqlUsageStorage) Init() error {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		_, err := s.builder.CreateTable(dailyTable).
+			IfNotExists().
+			Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(imsiCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(apnCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(dayCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(txCol).Type(sqorc.ColumnTypeBigInt).NotNull().Default(0).EndColumn().
+			Column(rxCol).Type(sqorc.ColumnTypeBigInt).NotNull().Default(0).EndColumn().
+			PrimaryKey(nidCol, imsiCol, apnCol, dayCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create daily usage table")
+		}
+
+		// index on (nid, day) for the network-wide usage totals
+		_, err = s.builder.CreateIndex(dayIdx).
+			IfNotExists().
+			On(dailyTable).
+			Columns(nidCol, dayCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create daily usage day index")
+		}
+
+		_, err = s.builder.CreateTable(sessionTable).
+			IfNotExists().
+			Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(sessionCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(txCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(rxCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			Column(updatedCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			PrimaryKey(nidCol, sessionCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create sessions table")
+		}
+
+		_, err = s.builder.CreateTable(enforcementTable).
+			IfNotExists().
+			Column(nidCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(imsiCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(policyCol).Type(sqorc.ColumnTypeText).NotNull().EndColumn().
+			Column(enforcedAtCol).Type(sqorc.ColumnTypeBigInt).NotNull().EndColumn().
+			PrimaryKey(nidCol, imsiCol).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create enforcements table")
+		}
+		return nil, nil
+	}
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
+
+func (s *sqlUsageStorage) RecordUsage(networkID string, now time.Time, sessions []*SessionUsage) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		prevCounts, err := s.getSessionCounts(tx, networkID, sessions)
+		if err != nil {
+			return nil, err
+		}
+
+		deltas := map[usageKey]*UsageTotal{}
+		for _, session := range sessions {
+			prev, ok := prevCounts[session.SessionID]
+			if !ok {
+				prev = &UsageTotal{}
+			}
+			key := usageKey{imsi: session.IMSI, apn: session.APN}
+			if _, ok := deltas[key]; !ok {
+				deltas[key] = &UsageTotal{}
+			}
+			deltas[key].BytesTx += getDelta(prev.BytesTx, session.BytesTx)
+			deltas[key].BytesRx += getDelta(prev.BytesRx, session.BytesRx)
+			// Later reports of a session in the same request count from
+			// this one
+			prevCounts[session.SessionID] = &UsageTotal{BytesTx: session.BytesTx, BytesRx: session.BytesRx}
+		}
+
+		day := getDay(now).Unix()
+		for key, delta := range deltas {
+			if delta.Bytes() == 0 {
+				continue
+			}
+			_, err := s.builder.Insert(dailyTable).
+				Columns(nidCol, imsiCol, apnCol, dayCol, txCol, rxCol).
+				Values(networkID, key.imsi, key.apn, day, delta.BytesTx, delta.BytesRx).
+				OnConflict(
+					[]sqorc.UpsertValue{
+						{Column: txCol, Value: sq.Expr(fmt.Sprintf("%s.%s+%d", dailyTable, txCol, delta.BytesTx))},
+						{Column: rxCol, Value: sq.Expr(fmt.Sprintf("%s.%s+%d", dailyTable, rxCol, delta.BytesRx))},
+					},
+					nidCol, imsiCol, apnCol, dayCol,
+				).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to record usage of subscriber %s on APN %s", key.imsi, key.apn)
+			}
+		}
+
+		return nil, s.updateSessionCounts(tx, networkID, now, sessions)
+	}
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
+
+func (s *sqlUsageStorage) GetDailyUsage(networkID string, imsi string, from, to time.Time) ([]*DailyUsage, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		rows, err := s.builder.Select(apnCol, dayCol, txCol, rxCol).
+			From(dailyTable).
+			Where(sq.And{
+				sq.Eq{nidCol: networkID, imsiCol: imsi},
+				sq.GtOrEq{dayCol: getDay(from).Unix()},
+				sq.LtOrEq{dayCol: getDay(to).Unix()},
+			}).
+			OrderBy(dayCol, apnCol).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query daily usage")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "GetDailyUsage")
+
+		ret := []*DailyUsage{}
+		for rows.Next() {
+			usage := &DailyUsage{IMSI: imsi}
+			var daySec int64
+			if err := rows.Scan(&usage.APN, &daySec, &usage.BytesTx, &usage.BytesRx); err != nil {
+				return nil, errors.Wrap(err, "failed to scan daily usage row")
+			}
+			usage.Day = time.Unix(daySec, 0).UTC()
+			ret = append(ret, usage)
+		}
+		return ret, rows.Err()
+	}
+	ret, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	if err != nil {
+		return nil, err
+	}
+	return ret.([]*DailyUsage), nil
+}
+
+func (s *sqlUsageStorage) GetUsageTotals(networkID string, imsis []string, since time.Time) (map[string]*UsageTotal, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		where := sq.And{
+			sq.Eq{nidCol: networkID},
+			sq.GtOrEq{dayCol: getDay(since).Unix()},
+		}
+		if len(imsis) != 0 {
+			where = append(where, sq.Eq{imsiCol: imsis})
+		}
+		rows, err := s.builder.Select(imsiCol, fmt.Sprintf("SUM(%s)", txCol), fmt.Sprintf("SUM(%s)", rxCol)).
+			From(dailyTable).
+			Where(where).
+			GroupBy(imsiCol).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query usage totals")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "GetUsageTotals")
+
+		ret := map[string]*UsageTotal{}
+		for rows.Next() {
+			var imsi string
+			total := &UsageTotal{}
+			if err := rows.Scan(&imsi, &total.BytesTx, &total.BytesRx); err != nil {
+				return nil, errors.Wrap(err, "failed to scan usage total row")
+			}
+			ret[imsi] = total
+		}
+		return ret, rows.Err()
+	}
+	ret, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	if err != nil {
+		return nil, err
+	}
+	return ret.(map[string]*UsageTotal), nil
+}
+
+func (s *sqlUsageStorage) GetEnforcements(networkID string, imsis []string) (map[string]*Enforcement, error) {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		where := sq.Eq{nidCol: networkID}
+		if len(imsis) != 0 {
+			where[imsiCol] = imsis
+		}
+		rows, err := s.builder.Select(imsiCol, policyCol, enforcedAtCol).
+			From(enforcementTable).
+			Where(where).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query enforcements")
+		}
+		defer sqorc.CloseRowsLogOnError(rows, "GetEnforcements")
+
+		ret := map[string]*Enforcement{}
+		for rows.Next() {
+			enforcement := &Enforcement{}
+			var enforcedSec int64
+			if err := rows.Scan(&enforcement.IMSI, &enforcement.PolicyID, &enforcedSec); err != nil {
+				return nil, errors.Wrap(err, "failed to scan enforcement row")
+			}
+			enforcement.EnforcedAt = time.Unix(enforcedSec, 0).UTC()
+			ret[enforcement.IMSI] = enforcement
+		}
+		return ret, rows.Err()
+	}
+	ret, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	if err != nil {
+		return nil, err
+	}
+	return ret.(map[string]*Enforcement), nil
+}
+
+func (s *sqlUsageStorage) CreateEnforcements(networkID string, enforcements []*Enforcement) error {
+	if len(enforcements) == 0 {
+		return nil
+	}
+
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		for _, enforcement := range enforcements {
+			_, err := s.builder.Insert(enforcementTable).
+				Columns(nidCol, imsiCol, policyCol, enforcedAtCol).
+				Values(networkID, enforcement.IMSI, enforcement.PolicyID, enforcement.EnforcedAt.Unix()).
+				OnConflict(
+					[]sqorc.UpsertValue{
+						{Column: policyCol, Value: enforcement.PolicyID},
+						{Column: enforcedAtCol, Value: enforcement.EnforcedAt.Unix()},
+					},
+					nidCol, imsiCol,
+				).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to create enforcement for subscriber %s", enforcement.IMSI)
+			}
+		}
+		return nil, nil
+	}
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
+
+func (s *sqlUsageStorage) DeleteEnforcements(networkID string, imsis []string) error {
+	if len(imsis) == 0 {
+		return nil
+	}
+
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		_, err := s.builder.Delete(enforcementTable).
+			Where(sq.Eq{nidCol: networkID, imsiCol: imsis}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete enforcements")
+		}
+		return nil, nil
+	}
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
+
+func (s *sqlUsageStorage) DeleteStaleSessions(updatedBefore time.Time) error {
+	txFn := func(tx *sql.Tx) (interface{}, error) {
+		_, err := s.builder.Delete(sessionTable).
+			Where(sq.Lt{updatedCol: updatedBefore.Unix()}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to delete stale sessions")
+		}
+		return nil, nil
+	}
+	_, err := sqorc.ExecInTx(s.db, nil, nil, txFn)
+	return err
+}
+
+// getSessionCounts returns the byte counts of the last report of the
+// sessions, keyed by session ID.
+func (s *sqlUsageStorage) getSessionCounts(tx *sql.Tx, networkID string, sessions []*SessionUsage) (map[string]*UsageTotal, error) {
+	var sessionIDs []string
+	for _, session := range sessions {
+		sessionIDs = append(sessionIDs, session.SessionID)
+	}
+	rows, err := s.builder.Select(sessionCol, txCol, rxCol).
+		From(sessionTable).
+		Where(sq.Eq{nidCol: networkID, sessionCol: sessionIDs}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query sessions")
+	}
+	defer sqorc.CloseRowsLogOnError(rows, "getSessionCounts")
+
+	ret := map[string]*UsageTotal{}
+	for rows.Next() {
+		var sessionID string
+		counts := &UsageTotal{}
+		if err := rows.Scan(&sessionID, &counts.BytesTx, &counts.BytesRx); err != nil {
+			return nil, errors.Wrap(err, "failed to scan session row")
+		}
+		ret[sessionID] = counts
+	}
+	return ret, rows.Err()
+}
+
+// updateSessionCounts stores the byte counts of the reported sessions, and
+// forgets the terminated sessions.
+func (s *sqlUsageStorage) updateSessionCounts(tx *sql.Tx, networkID string, now time.Time, sessions []*SessionUsage) error {
+	// Apply the last report of each session
+	lastReports := map[string]*SessionUsage{}
+	for _, session := range sessions {
+		lastReports[session.SessionID] = session
+	}
+	sessionIDs := make([]string, 0, len(lastReports))
+	for sessionID := range lastReports {
+		sessionIDs = append(sessionIDs, sessionID)
+	}
+	sort.Strings(sessionIDs)
+
+	var terminated []string
+	for _, sessionID := range sessionIDs {
+		session := lastReports[sessionID]
+		if session.Terminated {
+			terminated = append(terminated, sessionID)
+			continue
+		}
+		_, err := s.builder.Insert(sessionTable).
+			Columns(nidCol, sessionCol, txCol, rxCol, updatedCol).
+			Values(networkID, sessionID, session.BytesTx, session.BytesRx, now.Unix()).
+			OnConflict(
+				[]sqorc.UpsertValue{
+					{Column: txCol, Value: session.BytesTx},
+					{Column: rxCol, Value: session.BytesRx},
+					{Column: updatedCol, Value: now.Unix()},
+				},
+				nidCol, sessionCol,
+			).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return errors.Wrapf(err, "failed to update session %s", sessionID)
+		}
+	}
+
+	if len(terminated) == 0 {
+		return nil
+	}
+	_, err := s.builder.Delete(sessionTable).
+		Where(sq.Eq{nidCol: networkID, sessionCol: terminated}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete terminated sessions")
+	}
+	return nil
+}
+
+// getDelta returns the bytes counted since the previous report of a session.
+func getDelta(prev, cur uint64) uint64 {
+	if cur < prev {
+		// The session was restarted
+		return cur
+	}
+	return cur - prev
+}
+
+// getDay returns midnight UTC of the day of t.
+func getDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,68 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package storage_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dbName = "usaged___storage_integ_test"
+
+// Byte counters are BIGINT columns, which must hold counts past 32 bits on
+// both dialects.
+func TestSQLUsageStorage_Integration_Postgres(t *testing.T) {
+	testLargeCounters(t, sqorc.PostgresDriver, sqorc.NewPostgresStatementBuilder())
+}
+
+func TestSQLUsageStorage_Integration_Maria(t *testing.T) {
+	testLargeCounters(t, sqorc.MariaDriver, sqorc.NewMariaDBStatementBuilder())
+}
+
+func testLargeCounters(t *testing.T, driver string, builder sqorc.StatementBuilder) {
+	// Opening the test DB sets the SQL dialect of the process, which the
+	// sqlite tests of the package rely on
+	dialect, ok := os.LookupEnv("SQL_DIALECT")
+	defer func() {
+		if ok {
+			os.Setenv("SQL_DIALECT", dialect)
+		} else {
+			os.Unsetenv("SQL_DIALECT")
+		}
+	}()
+
+	db := sqorc.OpenCleanForTest(t, dbName, driver)
+	store := storage.NewSQLUsageStorage(db, builder)
+	assert.NoError(t, store.Init())
+	day := time.Date(2020, time.October, 31, 10, 0, 0, 0, time.UTC)
+
+	err := store.RecordUsage("n0", day, []*storage.SessionUsage{
+		{SessionID: "s0", IMSI: "IMSI1", APN: "apn0", BytesTx: 1 << 33, BytesRx: 1 << 40},
+	})
+	assert.NoError(t, err)
+	err = store.RecordUsage("n0", day, []*storage.SessionUsage{
+		{SessionID: "s0", IMSI: "IMSI1", APN: "apn0", BytesTx: 1<<33 + 1, BytesRx: 1<<40 + 1},
+	})
+	assert.NoError(t, err)
+
+	totals, err := store.GetUsageTotals("n0", []string{"IMSI1"}, day)
+	assert.NoError(t, err)
+	assert.Equal(t, &storage.UsageTotal{BytesTx: 1<<33 + 1, BytesRx: 1<<40 + 1}, totals["IMSI1"])
+}
@@ -0,0 +1,184 @@
+/*
+ Copyright 2020 The Magma Authors.
+
+ This source code is licensed under the BSD-style license found in the
+ LICENSE file in the root directory of this source tree.
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+package storage_test
+
+import (
+	"testing"
+	"time"
+
+	"magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/sqorc"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLUsageStorage_RecordUsage(t *testing.T) {
+	store := newTestStore(t)
+	day0 := time.Date(2020, time.October, 31, 10, 0, 0, 0, time.UTC)
+	day1 := day0.Add(24 * time.Hour)
+
+	// Initially empty
+	totals, err := store.GetUsageTotals("n0", nil, day0)
+	assert.NoError(t, err)
+	assert.Empty(t, totals)
+
+	err = store.RecordUsage("n0", day0, []*storage.SessionUsage{
+		{SessionID: "s0", IMSI: "IMSI1", APN: "apn0", BytesTx: 100, BytesRx: 1000},
+		{SessionID: "s1", IMSI: "IMSI1", APN: "apn1", BytesTx: 10, BytesRx: 20},
+		{SessionID: "s2", IMSI: "IMSI2", APN: "apn0", BytesTx: 1, BytesRx: 2},
+	})
+	assert.NoError(t, err)
+	err = store.RecordUsage("n1", day0, []*storage.SessionUsage{
+		{SessionID: "s0", IMSI: "IMSI1", APN: "apn0", BytesTx: 5, BytesRx: 5},
+	})
+	assert.NoError(t, err)
+
+	// Counts are cumulative: retries and reports without new usage don't
+	// count twice
+	err = store.RecordUsage("n0", day0, []*storage.SessionUsage{
+		{SessionID: "s0", IMSI: "IMSI1", APN: "apn0", BytesTx: 100, BytesRx: 1000},
+		{SessionID: "s2", IMSI: "IMSI2", APN: "apn0", BytesTx: 1, BytesRx: 2},
+	})
+	assert.NoError(t, err)
+
+	// Usage since the last report is counted on the day of the report
+	err = store.RecordUsage("n0", day1, []*storage.SessionUsage{
+		{SessionID: "s0", IMSI: "IMSI1", APN: "apn0", BytesTx: 150, BytesRx: 1500},
+		// Restarted session
+		{SessionID: "s1", IMSI: "IMSI1", APN: "apn1", BytesTx: 5, BytesRx: 5, Terminated: true},
+	})
+	assert.NoError(t, err)
+
+	daily, err := store.GetDailyUsage("n0", "IMSI1", day0, day1)
+	assert.NoError(t, err)
+	expected := []*storage.DailyUsage{
+		{IMSI: "IMSI1", APN: "apn0", Day: time.Date(2020, time.October, 31, 0, 0, 0, 0, time.UTC), BytesTx: 100, BytesRx: 1000},
+		{IMSI: "IMSI1", APN: "apn1", Day: time.Date(2020, time.October, 31, 0, 0, 0, 0, time.UTC), BytesTx: 10, BytesRx: 20},
+		{IMSI: "IMSI1", APN: "apn0", Day: time.Date(2020, time.November, 1, 0, 0, 0, 0, time.UTC), BytesTx: 50, BytesRx: 500},
+		{IMSI: "IMSI1", APN: "apn1", Day: time.Date(2020, time.November, 1, 0, 0, 0, 0, time.UTC), BytesTx: 5, BytesRx: 5},
+	}
+	assert.Equal(t, expected, daily)
+
+	daily, err = store.GetDailyUsage("n0", "IMSI1", day1, day1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[2:], daily)
+
+	daily, err = store.GetDailyUsage("n0", "IMSI3", day0, day1)
+	assert.NoError(t, err)
+	assert.Empty(t, daily)
+
+	totals, err = store.GetUsageTotals("n0", nil, day0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*storage.UsageTotal{
+		"IMSI1": {BytesTx: 165, BytesRx: 1525},
+		"IMSI2": {BytesTx: 1, BytesRx: 2},
+	}, totals)
+	totals, err = store.GetUsageTotals("n0", []string{"IMSI2", "IMSI3"}, day0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*storage.UsageTotal{"IMSI2": {BytesTx: 1, BytesRx: 2}}, totals)
+	totals, err = store.GetUsageTotals("n0", nil, day1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*storage.UsageTotal{"IMSI1": {BytesTx: 55, BytesRx: 505}}, totals)
+
+	// Terminated sessions are forgotten, so a new session with the same ID
+	// counts from 0
+	err = store.RecordUsage("n0", day1, []*storage.SessionUsage{
+		{SessionID: "s1", IMSI: "IMSI1", APN: "apn1", BytesTx: 1, BytesRx: 1},
+	})
+	assert.NoError(t, err)
+	daily, err = store.GetDailyUsage("n0", "IMSI1", day1, day1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), daily[1].BytesTx)
+
+	// Stale sessions are forgotten
+	err = store.DeleteStaleSessions(day1)
+	assert.NoError(t, err)
+	err = store.RecordUsage("n0", day1, []*storage.SessionUsage{
+		{SessionID: "s0", IMSI: "IMSI1", APN: "apn0", BytesTx: 150, BytesRx: 1500},
+		{SessionID: "s2", IMSI: "IMSI2", APN: "apn0", BytesTx: 1, BytesRx: 2},
+	})
+	assert.NoError(t, err)
+	totals, err = store.GetUsageTotals("n0", nil, day1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*storage.UsageTotal{
+		"IMSI1": {BytesTx: 56, BytesRx: 506},
+		"IMSI2": {BytesTx: 1, BytesRx: 2},
+	}, totals)
+
+	// Other networks are unaffected
+	totals, err = store.GetUsageTotals("n1", nil, day0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*storage.UsageTotal{"IMSI1": {BytesTx: 5, BytesRx: 5}}, totals)
+}
+
+func TestSQLUsageStorage_Enforcements(t *testing.T) {
+	store := newTestStore(t)
+	t0 := time.Unix(1600000000, 0).UTC()
+
+	enforcements, err := store.GetEnforcements("n0", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, enforcements)
+
+	err = store.CreateEnforcements("n0", []*storage.Enforcement{
+		{IMSI: "IMSI1", PolicyID: "throttle", EnforcedAt: t0},
+		{IMSI: "IMSI2", PolicyID: "throttle", EnforcedAt: t0},
+	})
+	assert.NoError(t, err)
+	err = store.CreateEnforcements("n1", []*storage.Enforcement{
+		{IMSI: "IMSI1", PolicyID: "redirect", EnforcedAt: t0},
+	})
+	assert.NoError(t, err)
+
+	// Replace an enforcement
+	err = store.CreateEnforcements("n0", []*storage.Enforcement{
+		{IMSI: "IMSI2", PolicyID: "redirect", EnforcedAt: t0.Add(time.Hour)},
+	})
+	assert.NoError(t, err)
+
+	enforcements, err = store.GetEnforcements("n0", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*storage.Enforcement{
+		"IMSI1": {IMSI: "IMSI1", PolicyID: "throttle", EnforcedAt: t0},
+		"IMSI2": {IMSI: "IMSI2", PolicyID: "redirect", EnforcedAt: t0.Add(time.Hour)},
+	}, enforcements)
+	enforcements, err = store.GetEnforcements("n0", []string{"IMSI2", "IMSI3"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*storage.Enforcement{
+		"IMSI2": {IMSI: "IMSI2", PolicyID: "redirect", EnforcedAt: t0.Add(time.Hour)},
+	}, enforcements)
+
+	err = store.DeleteEnforcements("n0", []string{"IMSI1", "IMSI3"})
+	assert.NoError(t, err)
+	enforcements, err = store.GetEnforcements("n0", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"IMSI2"}, getIMSIs(enforcements))
+	enforcements, err = store.GetEnforcements("n1", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"IMSI1"}, getIMSIs(enforcements))
+}
+
+func newTestStore(t *testing.T) storage.UsageStorage {
+	db, err := sqorc.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	store := storage.NewSQLUsageStorage(db, sqorc.GetSqlBuilder())
+	assert.NoError(t, store.Init())
+	return store
+}
+
+func getIMSIs(enforcements map[string]*storage.Enforcement) []string {
+	var ret []string
+	for imsi := range enforcements {
+		ret = append(ret, imsi)
+	}
+	return ret
+}
@@ -0,0 +1,117 @@
+/*
+ Copyright 2020 The Magma Authors.
+
+ This source code is licensed under the BSD-style license found in the
+ LICENSE file in the root directory of this source tree.
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+package storage
+
+import (
+	"sort"
+	"time"
+)
+
+// SessionUsage is the cumulative data usage of a session.
+type SessionUsage struct {
+	// SessionID uniquely identifies the session within the network
+	SessionID string
+	IMSI      string
+	APN       string
+	// BytesTx and BytesRx are the totals over the lifetime of the session
+	BytesTx uint64
+	BytesRx uint64
+	// Terminated is set on the last report of the session
+	Terminated bool
+}
+
+// GetIMSIs returns the sorted IMSIs of the subscribers of the sessions.
+func GetIMSIs(sessions []*SessionUsage) []string {
+	imsiSet := map[string]struct{}{}
+	for _, session := range sessions {
+		imsiSet[session.IMSI] = struct{}{}
+	}
+	imsis := make([]string, 0, len(imsiSet))
+	for imsi := range imsiSet {
+		imsis = append(imsis, imsi)
+	}
+	sort.Strings(imsis)
+	return imsis
+}
+
+// DailyUsage is the data usage of a subscriber on an APN during a day (UTC).
+type DailyUsage struct {
+	IMSI string
+	APN  string
+	// Day is midnight UTC of the day
+	Day     time.Time
+	BytesTx uint64
+	BytesRx uint64
+}
+
+// UsageTotal is the data usage of a subscriber across APNs over a period.
+type UsageTotal struct {
+	BytesTx uint64
+	BytesRx uint64
+}
+
+// Bytes returns the number of bytes sent and received.
+func (u *UsageTotal) Bytes() uint64 {
+	return u.BytesTx + u.BytesRx
+}
+
+// Enforcement records the activation of the quota enforcement policy for a
+// subscriber.
+type Enforcement struct {
+	IMSI     string
+	PolicyID string
+	// EnforcedAt is the time the policy was activated
+	EnforcedAt time.Time
+}
+
+// UsageStorage is the storage interface for the data usage of the subscribers
+// and the quota enforcements applied to them.
+type UsageStorage interface {
+	// Init performs on-start initialization work such as table creation.
+	Init() error
+
+	// RecordUsage adds the usage of the sessions since their last report to
+	// the usage of their subscribers on the day of now.
+	// The byte counts of the sessions are cumulative, so recording the same
+	// report twice doesn't count its usage twice. A session whose counts
+	// went down is assumed to have been restarted.
+	RecordUsage(networkID string, now time.Time, sessions []*SessionUsage) error
+
+	// GetDailyUsage returns the daily usage of the subscriber per APN between
+	// the days of from and to, inclusive, sorted by day and APN.
+	GetDailyUsage(networkID string, imsi string, from, to time.Time) ([]*DailyUsage, error)
+
+	// GetUsageTotals returns the usage of the subscribers since the day of
+	// since, keyed by IMSI.
+	// If imsis is empty, this returns the usage of all the subscribers of the
+	// network which used data during the period.
+	GetUsageTotals(networkID string, imsis []string, since time.Time) (map[string]*UsageTotal, error)
+
+	// GetEnforcements returns the quota enforcements of the subscribers,
+	// keyed by IMSI.
+	// If imsis is empty, this returns all the enforcements of the network.
+	GetEnforcements(networkID string, imsis []string) (map[string]*Enforcement, error)
+
+	// CreateEnforcements records quota enforcements, replacing the existing
+	// enforcements of their subscribers.
+	CreateEnforcements(networkID string, enforcements []*Enforcement) error
+
+	// DeleteEnforcements removes the quota enforcements of the subscribers.
+	DeleteEnforcements(networkID string, imsis []string) error
+
+	// DeleteStaleSessions forgets the byte counts of the sessions of all
+	// networks last reported before updatedBefore.
+	// This bounds the number of tracked sessions when the termination of
+	// sessions isn't reported, e.g. after a gateway restart.
+	DeleteStaleSessions(updatedBefore time.Time) error
+}
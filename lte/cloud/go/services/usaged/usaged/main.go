@@ -0,0 +1,69 @@
+/*
+ *  Copyright 2020 The Magma Authors.
+ *
+ *  This source code is licensed under the BSD-style license found in the
+ *  LICENSE file in the root directory of this source tree.
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package main
+
+import (
+	"time"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/services/usaged"
+	"magma/lte/cloud/go/services/usaged/obsidian/handlers"
+	"magma/lte/cloud/go/services/usaged/protos"
+	"magma/lte/cloud/go/services/usaged/quota"
+	"magma/lte/cloud/go/services/usaged/servicers"
+	usaged_storage "magma/lte/cloud/go/services/usaged/storage"
+	"magma/orc8r/cloud/go/obsidian"
+	"magma/orc8r/cloud/go/obsidian/swagger"
+	swagger_protos "magma/orc8r/cloud/go/obsidian/swagger/protos"
+	"magma/orc8r/cloud/go/service"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+
+	"github.com/golang/glog"
+)
+
+// liftInterval is how often enforcements of subscribers which are no
+// longer over quota, e.g. after the start of a new month, are lifted.
+const liftInterval = 10 * time.Minute
+
+func main() {
+	srv, err := service.NewOrchestratorService(lte.ModuleName, usaged.ServiceName)
+	if err != nil {
+		glog.Fatalf("error creating usaged service: %v", err)
+	}
+
+	// Storage
+	db, err := sqorc.Open(storage.SQLDriver, storage.DatabaseSource)
+	if err != nil {
+		glog.Fatalf("error opening db conn: %v", err)
+	}
+	store := usaged_storage.NewSQLUsageStorage(db, sqorc.GetSqlBuilder())
+	err = store.Init()
+	if err != nil {
+		glog.Fatalf("error initializing usaged storage: %s", err)
+	}
+
+	enforcer := quota.NewEnforcer(store)
+	go enforcer.Run(liftInterval)
+
+	obsidian.AttachHandlers(srv.EchoServer, handlers.GetHandlers(store, enforcer))
+	protos.RegisterUsageReporterServer(srv.GrpcServer, servicers.NewUsageReporterServicer(store, enforcer))
+
+	swagger_protos.RegisterSwaggerSpecServer(srv.GrpcServer, swagger.NewSpecServicerFromFile(usaged.ServiceName))
+
+	err = srv.Run()
+	if err != nil {
+		glog.Fatalf("error while running usaged service: %v", err)
+	}
+}
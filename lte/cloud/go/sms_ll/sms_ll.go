@@ -28,6 +28,8 @@ import (
 type SMSSerde interface {
 	EncodeMessage(message string, fromNum string, timestamp time.Time, references []uint8) ([][]byte, error)
 	DecodeDelivery(input []byte) (SMSDeliveryReport, error)
+	DecodeSubmit(input []byte) (SMSSubmit, error)
+	EncodeSubmitAck(submit SMSSubmit) ([]byte, error)
 }
 
 // DefaultSMSSerde is the SMSSerde impl that's backed by the exported functions
@@ -42,6 +44,14 @@ func (d *DefaultSMSSerde) DecodeDelivery(input []byte) (SMSDeliveryReport, error
 	return Decode(input)
 }
 
+func (d *DefaultSMSSerde) DecodeSubmit(input []byte) (SMSSubmit, error) {
+	return DecodeSubmit(input)
+}
+
+func (d *DefaultSMSSerde) EncodeSubmitAck(submit SMSSubmit) ([]byte, error) {
+	return GenerateSubmitAck(submit)
+}
+
 // Generate fully encoded SMS PDUs for delivery to a UE (MS). Will handle
 // encoding and chunking of messages as appropriate. We first generate TPDUs,
 // then RP-DATA headers, and finally CP-DATA headers, resulting in a set of
@@ -100,6 +110,10 @@ func GetMessageCount(message string) int {
 	return len(createTpdus(message, "123456", time.Now()))
 }
 
+// ErrNotDeliveryReport is returned when decoding a message which isn't a
+// delivery report, e.g. a mobile originated RP-DATA.
+var ErrNotDeliveryReport = errors.New("RP-DATA message, ignoring")
+
 // SMSDeliveryReport is a struct that wraps the decoded result of a
 // SMS-DELIVERY-REPORT message.
 // ErrorMessage field will be non-empty if IsSuccessful is false and the input
@@ -154,8 +168,95 @@ func Decode(input []byte) (SMSDeliveryReport, error) {
 			ErrorMessage: rpm.cause.causeStr,
 		}, nil
 	default:
-		return ret, errors.New("RP-DATA message, ignoring")
+		return ret, ErrNotDeliveryReport
+	}
+}
+
+// SMSSubmit is a struct that wraps the decoded result of a mobile originated
+// SMS-SUBMIT message.
+// Messages which are too long for a single SMS are split into several
+// segments by the UE. Segments is 1 for single-segment messages, otherwise
+// SeqNo is the 1-based position of this segment and ConcatRef identifies
+// the message the segment is part of.
+type SMSSubmit struct {
+	// TransactionID is the CP transaction ID of the message
+	TransactionID uint8
+	// Reference is the RP message reference of the message
+	Reference   uint8
+	Destination string
+	Message     string
+
+	Segments  int
+	SeqNo     int
+	ConcatRef int
+}
+
+// Decodes a mobile originated SMS-SUBMIT message.
+// Inputs:
+//	input: A byte array representing a fully encoded SMS sent by a UE
+// Outputs:
+//	- SMSSubmit: the decoded message
+//	- error: if the message received is not a CP-DATA(RP-DATA(SMS-SUBMIT)).
+func DecodeSubmit(input []byte) (SMSSubmit, error) {
+	ret := SMSSubmit{}
+	cpm := new(cpMessage)
+	err := cpm.unmarshalBinary(input)
+	if err != nil {
+		return ret, err
+	}
+	if cpm.messageType != CpData {
+		return ret, fmt.Errorf("not a CP-DATA message: %x", cpm.messageType)
+	}
+
+	rpm := new(rpMessage)
+	err = rpm.unmarshalBinary(cpm.rpdu)
+	if err != nil {
+		return ret, err
+	}
+	if rpm.mti != RpMtiMoData {
+		return ret, fmt.Errorf("not a MO RP-DATA message: %x", rpm.mti)
+	}
+
+	tp, err := tpdu.NewSubmit(tpdu.MO)
+	if err != nil {
+		return ret, err
+	}
+	err = tp.UnmarshalBinary(rpm.userData.tpdu)
+	if err != nil {
+		return ret, err
+	}
+	if tp.SmsType() != tpdu.SmsSubmit {
+		return ret, fmt.Errorf("not a SMS-SUBMIT message: %s", tp.SmsType())
+	}
+	message, err := sms.Decode([]*tpdu.TPDU{tp})
+	if err != nil {
+		return ret, err
+	}
+
+	ret = SMSSubmit{
+		TransactionID: cpm.GetTransactionId(),
+		Reference:     rpm.reference,
+		Destination:   tp.DA.Number(),
+		Message:       string(message),
+		Segments:      1,
+	}
+	if segments, seqNo, concatRef, ok := tp.ConcatInfo(); ok && segments > 1 {
+		ret.Segments, ret.SeqNo, ret.ConcatRef = segments, seqNo, concatRef
+	}
+	return ret, nil
+}
+
+// Generate the fully encoded RP-ACK acknowledging a mobile originated SMS to
+// the UE, i.e. a CP-DATA(RP-ACK) in the transaction of the SMS.
+func GenerateSubmitAck(submit SMSSubmit) ([]byte, error) {
+	rpm := rpMessage{mti: RpMtiMtAck, reference: submit.Reference}
+	// The transaction was originated by the UE, so the TI flag of the
+	// network's response is set (TS 24.007 11.2.3.1.3)
+	cpm, err := createCpDataMessage(rpm.marshalBinary(), (submit.TransactionID&0x7)|0x8)
+	if err != nil {
+		return nil, err
 	}
+	return cpm.marshalBinary(), nil
 }
 
 func createTpdus(message string, from_num string, timestamp time.Time) []tpdu.TPDU {
@@ -84,6 +84,46 @@ func TestDecode(t *testing.T) {
 	}
 }
 
+func TestDecodeSubmit(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    SMSSubmit
+		wantErr bool
+	}{
+		{
+			name:  "single-segment",
+			input: "390116002a0002b9110f01010691214365000005e8329bfd06",
+			want:  SMSSubmit{TransactionID: 3, Reference: 42, Destination: "+123456", Message: "hello", Segments: 1},
+		},
+		{
+			name:  "second-segment",
+			input: "29012c00110007914477581006502041020791551532f4000018050003010202c865f3199d5687c56372d97c46a7d5",
+			want:  SMSSubmit{TransactionID: 2, Reference: 17, Destination: "+5551234", Message: "defghijabcdefghij", Segments: 2, SeqNo: 2, ConcatRef: 1},
+		},
+		{name: "delivery-report", input: "d90106020141020000", wantErr: true},
+		{name: "mt-data", input: "790127010702b9110020240b918156685703f90000029041610305000ec8b2bc7c9a83c2207a794e7701", wantErr: true},
+		{name: "truncated", input: "29012c00110007914477581006", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		msg, _ := hex.DecodeString(tc.input)
+		actual, err := DecodeSubmit(msg)
+		if tc.wantErr {
+			assert.Error(t, err, tc.name)
+			continue
+		}
+		assert.NoError(t, err, tc.name)
+		assert.Equal(t, tc.want, actual, tc.name)
+	}
+}
+
+func TestGenerateSubmitAck(t *testing.T) {
+	ack, err := GenerateSubmitAck(SMSSubmit{TransactionID: 2, Reference: 17})
+	assert.NoError(t, err)
+	assert.Equal(t, "a901020311", hex.EncodeToString(ack))
+}
+
 func TestPiecewiseDecodeDeliveryFailure(t *testing.T) {
 	input := "d9010404010160"
 	cp_hex, _ := hex.DecodeString(input)
@@ -158,7 +198,8 @@ func TestPiecewiseDecodeDeliveryReport(t *testing.T) {
 }
 
 func TestUnmarshalAddressElement(t *testing.T) {
-	input := "0b911605935713f2"
+	// 11 digits, the last half-octet padded with 0xf: 7 octets of contents
+	input := "07911605935713f2"
 	rpadde_hex, _ := hex.DecodeString(input)
 	rpadde := new(rpAddressElement)
 	l, err := rpadde.unmarshalBinary(rpadde_hex)
@@ -166,7 +207,7 @@ func TestUnmarshalAddressElement(t *testing.T) {
 		t.Errorf("Failed to decode RP Address Element")
 	}
 
-	if l != 8 || rpadde.length != 0x0b {
+	if l != 8 || rpadde.length != 0x07 {
 		t.Errorf("RPAddressElement incorrect length")
 	}
 	if rpadde.numberInfo != 0x91 {
@@ -177,3 +218,16 @@ func TestUnmarshalAddressElement(t *testing.T) {
 		t.Errorf("RPAddressElement incorrect number. Have:\n%s\nwant\n%s", hex.Dump(rpadde.number), hex.Dump(num))
 	}
 }
+
+func TestAddressElementRoundTrip(t *testing.T) {
+	rpadde := newFakeRpAddressElement()
+	decoded := new(rpAddressElement)
+	l, err := decoded.unmarshalBinary(rpadde.marshalBinary())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, l)
+	assert.Equal(t, rpadde, *decoded)
+
+	// The length counts octets, so a half-octet count overruns the input
+	_, err = decoded.unmarshalBinary([]byte{0x0b, 0x91, 0x16, 0x05, 0x93, 0x57, 0x13, 0xf2})
+	assert.EqualError(t, err, "smsrp: RP Address too short")
+}
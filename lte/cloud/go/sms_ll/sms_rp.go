@@ -26,20 +26,19 @@ func smsRpError(field string) error {
 // suggest that the first octet is an IEI, 7.3.1 notes that this is a Type
 // 4 LV IE, which means there's no IEI present -- just a length and values.
 type rpAddressElement struct {
-	length     byte // of the number in half-octets!
+	length     byte // of the address contents in octets
 	numberInfo byte // octet 3
 	number     []byte
 }
 
-// The length field of an RP Address Element is the number of half-octets in
-// the number. This converts to a byte length of the number itself.
+// The length field of an RP Address Element is the number of octets of the
+// address contents, i.e. the number info octet followed by the BCD number
+// (TS 24.011 8.2.5.1). This converts to a byte length of the number itself.
 func (rpadde rpAddressElement) getNumberOctets() int {
-	l := int(rpadde.length)
-	if l%2 == 0 {
-		return l / 2
-	} else {
-		return (l + 1) / 2 // last half-octet is padded with 0xf
+	if rpadde.length == 0 {
+		return 0
 	}
+	return int(rpadde.length) - 1
 }
 
 func (rpadde rpAddressElement) marshalBinary() []byte {
@@ -54,14 +53,13 @@ func (rpadde rpAddressElement) marshalBinary() []byte {
 
 // Decode an address element. Returns the length of the address element if present.
 func (rpadde *rpAddressElement) unmarshalBinary(input []byte) (int, error) {
+	if len(input) == 0 {
+		return -1, smsRpError("Missing RP Address")
+	}
 	// Empty addresses will be one byte long with a zero value length
-	if len(input) == 1 {
-		if input[0] != 0x0 {
-			return -1, smsRpError("Invalid RP Address of length 1")
-		} else {
-			rpadde.length = input[0]
-			return 1, nil
-		}
+	if input[0] == 0x0 {
+		rpadde.length = input[0]
+		return 1, nil
 	} else if len(input) < 3 { // if it's not zero length, we must have at least 3 octets
 		return -1, smsRpError("Invalid RP Address")
 	}
@@ -70,6 +68,9 @@ func (rpadde *rpAddressElement) unmarshalBinary(input []byte) (int, error) {
 	rpadde.numberInfo = input[1]
 
 	num_bytes := rpadde.getNumberOctets()
+	if len(input) < num_bytes+2 {
+		return -1, smsRpError("RP Address too short")
+	}
 	rpadde.number = make([]byte, num_bytes)
 	copy(rpadde.number, input[2:num_bytes+2])
 
@@ -117,19 +118,28 @@ func (rpue rpUserElement) marshalBinary(msgType byte) []byte {
 	return b
 }
 
-func (rpue *rpUserElement) unmarshalBinary(msgType byte, input []byte) int {
+func (rpue *rpUserElement) unmarshalBinary(msgType byte, input []byte) (int, error) {
 	idx := 0
 	if msgType == RpAck || msgType == RpError { // these start with IEI
+		if len(input) < 1 {
+			return -1, smsRpError("Missing RP-User-Data IEI")
+		}
 		rpue.iei = input[idx]
 		idx++
 	}
+	if len(input) < idx+1 {
+		return -1, smsRpError("Missing RP-User-Data length")
+	}
 	rpue.length = input[idx]
 	idx++
 
 	end := idx + int(rpue.length)
+	if len(input) < end {
+		return -1, smsRpError("RP-User-Data too short")
+	}
 	rpue.tpdu = make([]byte, rpue.length)
 	copy(rpue.tpdu, input[idx:end])
-	return end
+	return end, nil
 }
 
 // RP-Cause element (TS 24.011 8.2.5.4)
@@ -150,6 +160,9 @@ func (rpce rpCauseElement) marshalBinary() []byte {
 }
 
 func (rpce *rpCauseElement) unmarshalBinary(input []byte) (int, error) {
+	if len(input) < 2 || (input[0] == 2 && len(input) < 3) {
+		return 0, smsRpError("RP-Cause too short")
+	}
 	if cs, ok := RpCauseStr[input[1]]; ok {
 		rpce.cause = input[1]
 		rpce.causeStr = cs
@@ -221,25 +234,35 @@ func (rpm *rpMessage) unmarshalBinary(input []byte) error {
 	switch rpmt {
 	case RpData:
 		// The next two IEs should be adddresses in this case. So, get the lengths and pass to unmarshal
-		n, _ := rpm.originatorAddress.unmarshalBinary(input[idx:])
+		n, err := rpm.originatorAddress.unmarshalBinary(input[idx:])
+		if err != nil {
+			return err
+		}
 		if rpm.direction() == RpMo && n != 1 {
 			return smsRpError("SMS-RP-DATA is MO, but OA length != 1")
 		}
 		idx += n
-		n, _ = rpm.destinationAddress.unmarshalBinary(input[idx:])
+		n, err = rpm.destinationAddress.unmarshalBinary(input[idx:])
+		if err != nil {
+			return err
+		}
 		if rpm.direction() == RpMt && n != 1 {
 			return smsRpError("SMS-RP-DATA is MT, but DA length != 1")
 		}
 		idx += n
 
-		rpm.userData.unmarshalBinary(RpData, input[idx:])
+		if _, err := rpm.userData.unmarshalBinary(RpData, input[idx:]); err != nil {
+			return err
+		}
 	case RpAck:
 		// RP-ACK and RP-ERROR may optionally contain an RP-User-Data
 		// element (TS24.001 7.3.3). If this is the case, it will be a
 		// TLV IE, with the first octet starting with the RP-User-Data
 		// IE ID (0x41).
 		if len(input) > 2 && input[idx] == RpUdeIei {
-			rpm.userData.unmarshalBinary(RpAck, input[idx:])
+			if _, err := rpm.userData.unmarshalBinary(RpAck, input[idx:]); err != nil {
+				return err
+			}
 		}
 	case RpError:
 		// Do nothing
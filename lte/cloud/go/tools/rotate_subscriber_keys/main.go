@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Magma Authors.
+
+This source code is licensed under the BSD-style license found in the
+LICENSE file in the root directory of this source tree.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// rotate_subscriber_keys rotates the data keys encrypting the subscriber auth
+// keys (K & OPc) and re-encrypts the stored auth keys with the new data keys.
+// Plaintext auth keys, stored before the encryption was enabled, are
+// encrypted as well.
+//
+// The previous data keys are kept, so running gateway streams & values
+// written concurrently stay readable.
+//
+// Usage: rotate_subscriber_keys [-networks=network1,network2] [-rewrap]
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"magma/lte/cloud/go/lte"
+	"magma/lte/cloud/go/serdes"
+	"magma/lte/cloud/go/services/subscriberdb/crypto"
+	"magma/lte/cloud/go/services/subscriberdb/obsidian/models"
+	"magma/orc8r/cloud/go/services/configurator"
+	"magma/orc8r/cloud/go/sqorc"
+	"magma/orc8r/cloud/go/storage"
+	"magma/orc8r/lib/go/registry"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+const pageSize = 100
+
+var (
+	networks = flag.String("networks", "", "Comma-separated networks to rotate the keys of, defaults to all networks")
+	rewrap   = flag.Bool("rewrap", false, "Also wrap the data keys with the current master key")
+	noRotate = flag.Bool("no-rotate", false, "Re-encrypt the auth keys without rotating the data keys")
+)
+
+func main() {
+	flag.Parse()
+	registry.MustPopulateServices()
+
+	db, err := sqorc.Open(storage.SQLDriver, storage.DatabaseSource)
+	if err != nil {
+		glog.Fatalf("Error opening db connection: %v", err)
+	}
+	keyring, err := crypto.NewKeyringFromServiceConfig(db, sqorc.GetSqlBuilder())
+	if err != nil {
+		glog.Fatalf("Error initializing keyring: %v", err)
+	}
+	if !keyring.Enabled() {
+		glog.Fatal("No master key file configured in the subscriberdb service config")
+	}
+
+	networkIDs, err := getNetworkIDs()
+	if err != nil {
+		glog.Fatalf("Error listing networks: %v", err)
+	}
+	for _, networkID := range networkIDs {
+		if err := rotateNetwork(keyring, networkID); err != nil {
+			glog.Fatalf("Error rotating the keys of network %s: %v", networkID, err)
+		}
+	}
+}
+
+func getNetworkIDs() ([]string, error) {
+	if *networks != "" {
+		return strings.Split(*networks, ","), nil
+	}
+	return configurator.ListNetworkIDs()
+}
+
+func rotateNetwork(keyring *crypto.Keyring, networkID string) error {
+	if *rewrap {
+		if err := keyring.RewrapDataKeys(networkID); err != nil {
+			return err
+		}
+	}
+	if !*noRotate {
+		keyID, err := keyring.RotateDataKey(networkID)
+		if err != nil {
+			return err
+		}
+		glog.Infof("Rotated the data key of network %s to %s", networkID, keyID)
+	}
+
+	reencrypted, pageToken := 0, ""
+	for {
+		criteria := configurator.EntityLoadCriteria{LoadConfig: true, PageSize: pageSize, PageToken: pageToken}
+		ents, nextPageToken, err := configurator.LoadAllEntitiesOfType(networkID, lte.SubscriberEntityType, criteria, serdes.Entity)
+		if err != nil {
+			return errors.Wrap(err, "failed to load subscribers")
+		}
+		for _, ent := range ents {
+			cfg, ok := ent.Config.(*models.SubscriberConfig)
+			if !ok || cfg.Lte == nil {
+				continue
+			}
+			changed, err := reencryptSubscription(keyring, networkID, ent.Key, cfg.Lte)
+			if err != nil {
+				return errors.Wrapf(err, "failed to re-encrypt the auth keys of subscriber %s", ent.Key)
+			}
+			if !changed {
+				continue
+			}
+			if err := configurator.CreateOrUpdateEntityConfig(networkID, lte.SubscriberEntityType, ent.Key, cfg, serdes.Entity); err != nil {
+				return errors.Wrapf(err, "failed to update subscriber %s", ent.Key)
+			}
+			reencrypted++
+		}
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+	glog.Infof("Re-encrypted the auth keys of %d subscribers of network %s", reencrypted, networkID)
+	return nil
+}
+
+// reencryptSubscription re-encrypts the auth keys of the subscription which
+// aren't encrypted with the current data key of the network. It returns true
+// if any auth key changed.
+func reencryptSubscription(keyring *crypto.Keyring, networkID string, subscriberID string, sub *models.LteSubscription) (bool, error) {
+	changed := false
+	values := map[string]*[]byte{
+		crypto.FieldAuthKey: (*[]byte)(&sub.AuthKey),
+		crypto.FieldAuthOpc: (*[]byte)(&sub.AuthOpc),
+	}
+	for field, value := range values {
+		if len(*value) == 0 {
+			continue
+		}
+		current, err := keyring.IsCurrent(networkID, *value)
+		if err != nil {
+			return false, err
+		}
+		if current {
+			continue
+		}
+		plaintext, err := keyring.Decrypt(networkID, subscriberID, field, *value)
+		if err != nil {
+			return false, err
+		}
+		encrypted, err := keyring.Encrypt(networkID, subscriberID, field, plaintext)
+		if err != nil {
+			return false, err
+		}
+		*value, changed = encrypted, true
+	}
+	return changed, nil
+}
@@ -106,7 +106,7 @@ class SmsRelay(Job):
             return 
 
         try:
-            self._smsd.ReportDelivery(
+            smsd_resp = self._smsd.ReportDelivery(
                 sms_orc8r_pb2.ReportDeliveryRequest(
                     report=sms_orc8r_pb2.SMOUplinkUnitdata(
                         imsi="IMSI"+request.imsi,
@@ -119,6 +119,14 @@ class SmsRelay(Job):
             context.set_code(grpc.StatusCode.INTERNAL)
             return
 
+        # Relay responses to the UE, e.g. the acknowledgement of a mobile
+        # originated SMS
+        for msg in smsd_resp.messages:
+            try:
+                self._mme_sms.SMODownlink(msg, SMS_TIMEOUT_SECS)
+            except grpc.RpcError as err:
+                logging.error("RPC call to MME failed: %s", err)
+
     def _is_enabled(self):
         """ 
         Returns True if MME's NON_EPS_SERVICE_CONFIG is set to SMS_ORC8R, False
@@ -21,6 +21,7 @@ export type aaa_server = {
     event_logging_enabled ? : boolean,
     idle_session_timeout_ms ? : number,
     radius_config ? : radius_config,
+    session_store ? : "memory" | "redis",
 };
 export type aggregated_maximum_bitrate = {
     max_bandwidth_dl: number,
@@ -418,8 +418,13 @@ func calculateEntitiesNotFound(entsByPk map[string]*NetworkEntity, requestedIDs
 
 func getNextPageToken(entities []*NetworkEntity) (string, error) {
 	lastEntity := entities[len(entities)-1]
-	nextPageToken := &EntityPageToken{LastIncludedEntity: lastEntity.Key}
-	return serializePageToken(nextPageToken)
+	return MakeEntityPageToken(lastEntity.Key)
+}
+
+// MakeEntityPageToken returns the page token of a paginated load resuming
+// after the entity with the passed key.
+func MakeEntityPageToken(lastIncludedEntity string) (string, error) {
+	return serializePageToken(&EntityPageToken{LastIncludedEntity: lastIncludedEntity})
 }
 
 func serializePageToken(token *EntityPageToken) (string, error) {
@@ -44,8 +44,9 @@ var postgresColumnTypeMap = map[ColumnType]string{
 	ColumnTypeText: "TEXT",
 	ColumnTypeInt:  "INTEGER",
 	// BYTEA is effectively limited to 1GB
-	ColumnTypeBytes: "BYTEA",
-	ColumnTypeBool:  "BOOLEAN",
+	ColumnTypeBytes:  "BYTEA",
+	ColumnTypeBool:   "BOOLEAN",
+	ColumnTypeBigInt: "BIGINT",
 }
 
 var mariaColumnTypeMap = map[ColumnType]string{
@@ -54,8 +55,9 @@ var mariaColumnTypeMap = map[ColumnType]string{
 	ColumnTypeInt:  "INT",
 	// LONGBLOB stores up to 4GB and the cost is a flat extra 2 bytes of
 	// storage over BLOB, which is limited to 64KB
-	ColumnTypeBytes: "LONGBLOB",
-	ColumnTypeBool:  "BOOLEAN",
+	ColumnTypeBytes:  "LONGBLOB",
+	ColumnTypeBool:   "BOOLEAN",
+	ColumnTypeBigInt: "BIGINT",
 }
 
 // ColumnOnDeleteOption is an enum type to specify ON DELETE behavior for
@@ -76,6 +78,10 @@ const (
 	ColumnTypeInt
 	ColumnTypeBytes
 	ColumnTypeBool
+	// ColumnTypeBigInt is a signed 64-bit integer, for values which don't fit
+	// the 32 bits of ColumnTypeInt on both dialects, e.g. byte counters
+	// passing 2GB or unix timestamps past 2038.
+	ColumnTypeBigInt
 	// Fill in other types as needed
 )
 
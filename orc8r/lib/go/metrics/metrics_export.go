@@ -47,6 +47,14 @@ const (
 
 	SubscriberThroughputMetric = "subscriber_throughput"
 
+	// StaticIPPoolSizeMetric tracks the number of addresses of static IP
+	// pools which can be allocated
+	StaticIPPoolSizeMetric = "static_ip_pool_size"
+
+	// StaticIPPoolAllocatedMetric tracks the number of addresses of static
+	// IP pools allocated to subscribers
+	StaticIPPoolAllocatedMetric = "static_ip_pool_allocated"
+
 	/* Labels */
 	NetworkLabelName         = "networkID"
 	GatewayLabelName         = "gatewayID"
@@ -58,6 +66,7 @@ const (
 	GatewayMagmaVersionLabel = "version"
 	QuantileLabel            = "quantile"
 	ImsiLabelName            = "IMSI"
+	StaticIPPoolLabel        = "ipPoolID"
 )
 
 // GetMetrics gathers metrics from Prometheus' default registry,